@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log"
 	"sync"
+	"time"
 
 	"fyne.io/fyne/v2"
 
@@ -13,7 +15,9 @@ import (
 	"nmf/internal/jobs"
 	"nmf/internal/keymanager"
 	"nmf/internal/secret"
+	"nmf/internal/tagindex"
 	"nmf/internal/ui"
+	"nmf/internal/vault"
 	"nmf/internal/watcher"
 )
 
@@ -26,6 +30,12 @@ type ApplicationRuntime struct {
 	jobManager           *jobs.Manager
 	jobsWindowController *JobsWindowController
 	promptBroker         *applicationPromptBroker
+	tagIndex             *tagindex.Index
+	secretStore          secret.Store
+	vaults               *vault.Manager // nil while ui.vault.enabled is off
+	auditLog             *jobs.AuditLog // nil while ui.auditLog.enabled is off
+	pinnedWatcher        *watcher.PinnedWatcher
+	watchRules           []config.WatchRule
 	closeOnce            sync.Once
 }
 
@@ -37,7 +47,9 @@ func newApplicationRuntime(app fyne.App) *ApplicationRuntime {
 		jobManager:           jobs.GetManager(),
 		jobsWindowController: NewJobsWindowController(app, debugPrint),
 		promptBroker:         broker,
+		tagIndex:             tagindex.New(),
 	}
+	runtime.jobsWindowController.SetConflictResolver(broker.ResolveConflict)
 
 	// These package-level hooks bridge VFS code to the one application-scoped
 	// cache and broker. New windows register prompt targets with the broker;
@@ -47,11 +59,28 @@ func newApplicationRuntime(app fyne.App) *ApplicationRuntime {
 	fileinfo.SetSecretStore(nil)
 	if store, err := secret.NewKeyringStore(); err == nil {
 		fileinfo.SetSecretStore(store)
+		runtime.secretStore = store
 	}
 
 	return runtime
 }
 
+// configureVaults starts vault support with cfg, or leaves it off.
+func (r *ApplicationRuntime) configureVaults(cfg config.VaultConfig) {
+	if !cfg.Enabled {
+		return
+	}
+	r.vaults = vault.NewManager()
+	r.vaults.Configure(vault.Options{
+		IdleTimeout:   time.Duration(cfg.IdleTimeoutMinutes) * time.Minute,
+		SavePasswords: cfg.SavePasswords,
+		Store:         r.secretStore,
+		Prompt:        r.promptBroker.GetVaultPassword,
+		InUse:         openWindowPaths,
+		Debugf:        debugPrint,
+	})
+}
+
 func (r *ApplicationRuntime) Close() {
 	if r == nil {
 		return
@@ -60,6 +89,14 @@ func (r *ApplicationRuntime) Close() {
 		if r.jobsWindowController != nil {
 			r.jobsWindowController.Close()
 		}
+		if r.pinnedWatcher != nil {
+			r.pinnedWatcher.Close()
+		}
+		if r.vaults != nil {
+			if err := r.vaults.Close(); err != nil {
+				log.Printf("Error locking vaults: %v", err)
+			}
+		}
 	})
 }
 
@@ -69,9 +106,11 @@ func (r *ApplicationRuntime) registerWindowPrompts(fm *FileManager) {
 	}
 	target := applicationPromptTarget{
 		smb:      ui.NewSMBCredentialsProvider(fm.window, fm.keyManager, fm.config.UI.KeyBindings),
-		archive:  ui.NewArchivePasswordProvider(fm.window, fm.keyManager, fm.config.UI.KeyBindings),
 		conflict: newWindowConflictResolver(fm.window, fm.keyManager, fm.config.UI.KeyBindings),
 	}
+	archive := ui.NewArchivePasswordProvider(fm.window, fm.keyManager, fm.config.UI.KeyBindings)
+	target.archive = archive
+	target.vault = archive.GetVaultPassword
 	fm.promptTargetID, fm.promptUnregister = r.promptBroker.Register(target)
 	r.promptBroker.SetActive(fm.promptTargetID, true)
 }
@@ -105,6 +144,7 @@ func newWindowConflictResolver(window fyne.Window, km *keymanager.KeyManager, bi
 type applicationPromptTarget struct {
 	smb      fileinfo.CredentialsProvider
 	archive  fileinfo.ArchivePasswordProvider
+	vault    func(context.Context, vault.PasswordRequest) (string, error)
 	conflict jobs.ConflictResolver
 }
 
@@ -243,6 +283,23 @@ func (b *applicationPromptBroker) GetArchivePassword(ctx context.Context, req fi
 	return target.archive.GetArchivePassword(promptCtx, req)
 }
 
+func (b *applicationPromptBroker) GetVaultPassword(ctx context.Context, req vault.PasswordRequest) (string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !b.acquire(ctx) {
+		return "", ctx.Err()
+	}
+	defer b.release()
+	target, ok := b.target()
+	if !ok || target.vault == nil {
+		return "", errNoInteractiveWindow
+	}
+	promptCtx, cancel := promptTargetContext(ctx, target.ctx)
+	defer cancel()
+	return target.vault(promptCtx, req)
+}
+
 func (b *applicationPromptBroker) ResolveConflict(ctx context.Context, req jobs.ConflictRequest) jobs.ConflictResolution {
 	if ctx == nil {
 		ctx = context.Background()
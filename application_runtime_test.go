@@ -10,6 +10,7 @@ import (
 
 	"nmf/internal/fileinfo"
 	"nmf/internal/jobs"
+	"nmf/internal/vault"
 )
 
 type promptCredentialsProvider struct {
@@ -47,6 +48,9 @@ func TestApplicationPromptBrokerUsesActiveWindowAndFallback(t *testing.T) {
 	secondID, unregisterSecond := broker.Register(applicationPromptTarget{
 		smb:     promptCredentialsProvider{username: "second"},
 		archive: promptArchiveProvider{password: "second-pass"},
+		vault: func(context.Context, vault.PasswordRequest) (string, error) {
+			return "second-vault", nil
+		},
 	})
 	t.Cleanup(unregisterSecond)
 
@@ -67,11 +71,18 @@ func TestApplicationPromptBrokerUsesActiveWindowAndFallback(t *testing.T) {
 	if password != "second-pass" {
 		t.Fatalf("fallback target password = %q, want second-pass", password)
 	}
+	password, err = broker.GetVaultPassword(context.Background(), vault.PasswordRequest{Path: "/v", Kind: vault.KindGocryptfs})
+	if err != nil || password != "second-vault" {
+		t.Fatalf("fallback target vault password = %q, %v; want second-vault", password, err)
+	}
 
 	unregisterSecond()
 	if _, err := broker.Get(context.Background(), "host", "share", "path"); !errors.Is(err, errNoInteractiveWindow) {
 		t.Fatalf("credentials error = %v, want errNoInteractiveWindow", err)
 	}
+	if _, err := broker.GetVaultPassword(context.Background(), vault.PasswordRequest{Path: "/v"}); !errors.Is(err, errNoInteractiveWindow) {
+		t.Fatalf("vault password error = %v, want errNoInteractiveWindow", err)
+	}
 	if secondID == firstID {
 		t.Fatal("prompt target IDs should be unique")
 	}
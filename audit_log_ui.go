@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"time"
+
+	"nmf/internal/config"
+	"nmf/internal/fileinfo"
+	"nmf/internal/jobs"
+	"nmf/internal/ui"
+)
+
+// configureAuditLog records mutating operations in path when cfg enables the
+// audit log. Jobs are recorded by the job manager; renames and creates by the
+// window that performs them (recordAudit).
+func (r *ApplicationRuntime) configureAuditLog(cfg config.AuditLogConfig, path string) {
+	if !cfg.Enabled {
+		return
+	}
+	r.auditLog = jobs.NewAuditLog(path)
+	r.jobManager.SetAuditLog(r.auditLog)
+}
+
+// recordAudit appends an operation that does not run as a job to the audit
+// log. err is the operation's outcome; nothing is recorded while the log is
+// off.
+func (fm *FileManager) recordAudit(operation string, sources []string, destination string, err error) {
+	if fm.runtime == nil || fm.runtime.auditLog == nil {
+		return
+	}
+	entry := jobs.AuditEntry{
+		Operation:   operation,
+		Sources:     sources,
+		Destination: destination,
+		Outcome:     jobs.StatusCompleted,
+	}
+	if err != nil {
+		entry.Outcome = jobs.StatusFailed
+		entry.Error = err.Error()
+	}
+	if appendErr := fm.runtime.auditLog.Append(entry); appendErr != nil {
+		debugPrint("FileManager: Audit log append failed op=%s err=%v", operation, appendErr)
+	}
+}
+
+// auditLogEntries reads the audit log, or reports why it cannot be shown.
+func (fm *FileManager) auditLogEntries() ([]jobs.AuditEntry, bool) {
+	if fm.runtime == nil || fm.runtime.auditLog == nil {
+		fm.ShowMessageDialog("Audit log disabled", "Set ui.auditLog.enabled to record copies, moves, deletes, renames, and creates.")
+		return nil, false
+	}
+	entries, skipped, err := jobs.ReadAuditLog(fm.runtime.auditLog.Path())
+	if err != nil {
+		fm.ShowMessageDialog("Audit log unavailable", err.Error())
+		return nil, false
+	}
+	if skipped > 0 {
+		debugPrint("FileManager: Audit log skipped %d unreadable lines", skipped)
+	}
+	return entries, true
+}
+
+// ShowAuditLog opens the audit log in the built-in viewer, newest entry
+// first.
+func (fm *FileManager) ShowAuditLog() {
+	entries, ok := fm.auditLogEntries()
+	if !ok {
+		return
+	}
+	text := jobs.FormatAuditEntries(entries)
+	if text == "" {
+		text = "No operations recorded yet.\n"
+	}
+	preview := &fileinfo.PreviewFile{
+		Path:      fm.runtime.auditLog.Path(),
+		Name:      "Audit log",
+		Data:      []byte(text),
+		Text:      text,
+		Encoding:  "UTF-8",
+		Size:      int64(len(text)),
+		SizeKnown: true,
+	}
+	dialog := ui.NewFileViewerDialog(preview, fm.keyManager)
+	dialog.SetMaxSize(fm.config.UI.Viewer.MaxWidth, fm.config.UI.Viewer.MaxHeight)
+	dialog.SetDefaultWrap(fm.config.UI.Viewer.DefaultWrap)
+	dialog.SetKeyBindings(fm.config.UI.KeyBindings)
+	dialog.SetDebugPrint(debugPrint)
+	dialog.ShowDialog(fm.window)
+}
+
+// ShowExportAuditLogDialog asks for a file name and exports the audit log
+// there as CSV.
+func (fm *FileManager) ShowExportAuditLogDialog() {
+	if fm.runtime == nil || fm.runtime.auditLog == nil {
+		fm.auditLogEntries()
+		return
+	}
+	dlg := ui.NewLineEditDialog(ui.LineEditDialogOptions{
+		Title:       "Export Audit Log",
+		Prompt:      "CSV file name:",
+		InitialText: "nmf-audit-" + time.Now().Format("20060102") + ".csv",
+		ConfirmText: "Export",
+	}, fm.keyManager, fm.config.UI.KeyBindings)
+	dlg.ShowDialog(fm.window, func(name string) bool {
+		return fm.ExportAuditLog(name)
+	})
+}
+
+// ExportAuditLog writes the audit log as CSV to a new file name in the
+// current directory, which may be on an SMB share.
+func (fm *FileManager) ExportAuditLog(name string) bool {
+	entries, ok := fm.auditLogEntries()
+	if !ok {
+		return false
+	}
+	var buf bytes.Buffer
+	if err := jobs.WriteAuditCSV(&buf, entries); err != nil {
+		fm.ShowMessageDialog("Export failed", err.Error())
+		return false
+	}
+	newPath, err := fileinfo.CreateTextFilePortable(fm.currentPath, name, buf.String())
+	if err != nil {
+		debugPrint("FileManager: Audit log export failed parent=%s name=%s err=%v", fm.currentPath, name, err)
+		fm.ShowMessageDialog("Export failed", err.Error())
+		return false
+	}
+	fm.applyCreatedPathToList(newPath, false)
+	debugPrint("FileManager: Exported %d audit entries to %s", len(entries), newPath)
+	fm.FocusFileList()
+	return true
+}
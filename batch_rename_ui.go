@@ -0,0 +1,63 @@
+package main
+
+import (
+	"nmf/internal/fileinfo"
+	"nmf/internal/jobs"
+	"nmf/internal/ui"
+)
+
+// ShowBatchRenameDialog renames the marked items, or the cursor item, by
+// rules previewed live against the names in the current listing. The
+// accepted plan runs as one rename job that undoes itself on failure.
+func (fm *FileManager) ShowBatchRenameDialog() {
+	targets := fm.targetFileInfos()
+	if len(targets) == 0 {
+		debugPrint("FileManager: No valid targets for batch rename")
+		return
+	}
+	parent := fileinfo.ParentPath(targets[0].Path)
+	for _, fi := range targets[1:] {
+		if fileinfo.ParentPath(fi.Path) != parent {
+			fm.ShowMessageDialog("Batch Rename", "Batch rename works on items of one directory.")
+			return
+		}
+	}
+	existing := fm.listedNames()
+
+	dlg := ui.NewBatchRenameDialog(func(rules jobs.RenameRules) ([]jobs.RenamePlanEntry, error) {
+		return jobs.PlanRenames(targets, existing, rules)
+	}, fm.keyManager, fm.config.UI.KeyBindings)
+	dlg.ShowDialog(fm.window, func(plan []jobs.RenamePlanEntry) {
+		sources, names := batchRenameChanges(plan)
+		j := fm.jobManager().EnqueueRename(sources, names)
+		debugPrint("FileManager: Batch rename job id=%d items=%d", j.ID, len(sources))
+		fm.FocusFileList()
+	})
+}
+
+// listedNames returns every name in the current listing, filtered out or
+// not, for collision checks.
+func (fm *FileManager) listedNames() []string {
+	fm.mu.RLock()
+	defer fm.mu.RUnlock()
+	names := make([]string, 0, len(fm.originalFiles))
+	for _, fi := range fm.originalFiles {
+		if isTargetFileInfo(fi) {
+			names = append(names, fi.Name)
+		}
+	}
+	return names
+}
+
+// batchRenameChanges returns the sources and new names of the entries that
+// change.
+func batchRenameChanges(plan []jobs.RenamePlanEntry) ([]string, []string) {
+	var sources, names []string
+	for _, e := range plan {
+		if e.Changed() {
+			sources = append(sources, e.Source)
+			names = append(names, e.NewName)
+		}
+	}
+	return sources, names
+}
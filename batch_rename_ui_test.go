@@ -0,0 +1,22 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"nmf/internal/jobs"
+)
+
+func TestBatchRenameChangesSkipsUnchangedAndBlockedEntries(t *testing.T) {
+	plan := []jobs.RenamePlanEntry{
+		{Source: "/p/a.txt", OldName: "a.txt", NewName: "b.txt"},
+		{Source: "/p/c.txt", OldName: "c.txt", NewName: "c.txt"},
+		{Source: "/p/d.txt", OldName: "d.txt", NewName: "x.txt", Err: errors.New("x.txt already exists")},
+		{Source: "/p/e.txt", OldName: "e.txt", NewName: "E.txt"},
+	}
+	sources, names := batchRenameChanges(plan)
+	if !reflect.DeepEqual(sources, []string{"/p/a.txt", "/p/e.txt"}) || !reflect.DeepEqual(names, []string{"b.txt", "E.txt"}) {
+		t.Fatalf("changes = %q -> %q", sources, names)
+	}
+}
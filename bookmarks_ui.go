@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+
+	"nmf/internal/config"
+	"nmf/internal/fileinfo"
+	"nmf/internal/ui"
+)
+
+// ShowBookmarksDialog shows the saved bookmarks. Edits made in the dialog are
+// written to state.json as they happen.
+func (fm *FileManager) ShowBookmarksDialog() {
+	dialog := ui.NewBookmarksDialog(fm.state.GetBookmarks(), fm.currentPath, fm.keyManager, debugPrint, fm.config.UI.KeyBindings)
+	dialog.ShowDialog(fm.window, func(selectedPath string) {
+		debugPrint("FileManager: bookmarks dialog selected path=%s", selectedPath)
+		fm.jumpToConfiguredDirectory(selectedPath)
+		fm.focusFileList("bookmarks-dialog-selected")
+	}, fm.saveBookmarks)
+}
+
+// JumpToBookmarkSlot opens the bookmark holding hotkey n.
+func (fm *FileManager) JumpToBookmarkSlot(n int) {
+	bookmark, ok := fm.state.BookmarkForHotkey(n)
+	if !ok {
+		debugPrint("FileManager: Bookmark slot %d is empty", n)
+		fm.ShowMessageDialog("Bookmarks", fmt.Sprintf("Slot %d is empty. Press Ctrl+Alt+%d to save the current directory there.", n, n))
+		return
+	}
+	debugPrint("FileManager: Bookmark slot %d path=%s", n, bookmark.Path)
+	fm.jumpToConfiguredDirectory(bookmark.Path)
+}
+
+// SetBookmarkSlot saves the current directory as the bookmark holding hotkey
+// n, taking the slot from the bookmark that had it.
+func (fm *FileManager) SetBookmarkSlot(n int) {
+	path := fm.currentPath
+	if !fm.state.SetBookmarkHotkey(n, path, fileinfo.BaseName(path)) {
+		return
+	}
+	fm.saveBookmarks(fm.state.GetBookmarks())
+	debugPrint("FileManager: Bookmark slot %d set path=%s", n, path)
+	fm.ShowMessageDialog("Bookmarks", fmt.Sprintf("Slot %d:\n%s", n, path))
+}
+
+func (fm *FileManager) saveBookmarks(bookmarks []config.Bookmark) {
+	fm.state.SetBookmarks(bookmarks)
+	fm.runtime.updatePinnedWatch(fm.state)
+	if fm.stateManager != nil {
+		if err := fm.stateManager.SaveAsync(fm.state); err != nil {
+			debugPrint("FileManager: Error saving bookmarks: %v", err)
+		}
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"nmf/internal/fileinfo"
 	"nmf/internal/keymanager"
 	"nmf/internal/search"
+	"nmf/internal/selection"
 	customtheme "nmf/internal/theme"
 	"nmf/internal/ui"
 	"nmf/internal/watcher"
@@ -24,9 +25,10 @@ func NewFileManager(runtime *ApplicationRuntime, path string, config *config.Con
 	fm := &FileManager{
 		window:            runtime.app.NewWindow("File Manager"),
 		currentPath:       path,
+		tabs:              []tabState{{path: path}},
 		cursorPath:        "",
 		cursorIndex:       -1,
-		selectedFiles:     make(map[string]bool),
+		selection:         selection.New(),
 		config:            config,
 		configManager:     configManager,
 		state:             state,
@@ -35,38 +37,116 @@ func NewFileManager(runtime *ApplicationRuntime, path string, config *config.Con
 		initialWindowSize: fyne.NewSize(float32(config.Window.Width), float32(config.Window.Height)),
 		windowActive:      true,
 		activeSort:        state.EffectiveSort(config.UI.Sort),
+		manualRefresh:     !config.UI.AutoRefresh,
+		showHidden:        state.EffectiveShowHiddenFiles(config.UI.ShowHiddenFiles),
+		hideGitIgnored:    state.EffectiveHideGitIgnored(config.UI.HideGitIgnored),
 		customTheme:       customTheme,
 		keyManager:        keymanager.NewKeyManager(debugPrint),
 		searchMatchers:    search.NewProvider(debugPrint),
 		runtime:           runtime,
 	}
 
+	// Mark changes redraw the status bars once per UI event, however many
+	// entries a command marks. The model keeps the selection bar's totals.
+	fm.selection.SetWeigher(fm.markWeight)
+	fm.selection.Observe(fm.onSelectionChanged)
+	fm.navigator.Observe(fm.onNavigationEvent)
+
 	// Busy overlay (hidden by default)
 	fm.busyOverlay = ui.NewBusyOverlay(customTheme)
 	fm.busyDelay = 150 * time.Millisecond
 
-	// Initialize async icon service and subscribe for updates
-	fm.iconSvc = fileinfo.NewIconService(debugPrint)
-	// Refresh the list when icons arrive. Icon notifications are emitted from
-	// background workers, so widget refreshes must run on the Fyne call thread.
-	fm.iconSvc.OnUpdated(func() {
+	// Initialize async icon service and subscribe for updates. The mono icon
+	// set never asks the OS for icons, so it runs without the service.
+	if !fm.usesMonoIcons() {
+		fm.iconSvc = fileinfo.NewIconService(debugPrint)
+		// Refresh the list when icons arrive. Icon notifications are emitted from
+		// background workers, so widget refreshes must run on the Fyne call thread.
+		fm.iconSvc.OnUpdated(func() {
+			if fm.isWindowClosed() {
+				return
+			}
+			fyne.Do(func() {
+				if !fm.isWindowClosed() && fm.fileList != nil {
+					canvas.Refresh(fm.fileList)
+				}
+				if !fm.isWindowClosed() && fm.thumbnailMode && fm.fileGrid != nil {
+					fm.fileGrid.Refresh()
+				}
+			})
+		})
+	}
+
+	// Media metadata is parsed lazily; rows refresh when the optional list
+	// column has new summaries to show, and "dateTaken" sorting and grouping
+	// re-sort once capture dates arrive for entries that were sorted by mtime
+	// meanwhile. That re-sort only reads the cache, so it never queues parses
+	// that would bring another update. The preview pane picks up the cursor file's details the same way.
+	fm.metadataSvc = fileinfo.NewMetadataService(debugPrint)
+	fm.metadataSvc.OnUpdated(func() {
+		if fm.isWindowClosed() {
+			return
+		}
+		fyne.Do(func() {
+			if fm.isWindowClosed() || fm.fileList == nil {
+				return
+			}
+			if usesDateTaken(fm.CurrentSort()) && fm.captureDates {
+				fm.applySortWithDates(fm.CurrentSort(), fm.dateTakenResolver(true, false))
+				fm.refreshFileList()
+			} else if fm.showMetadataInList() {
+				fm.refreshFileList()
+			}
+			fm.updatePreviewPane()
+		})
+	})
+
+	// The preview pane loads the cursor file in the background and redraws
+	// when it lands; later requests cancel earlier ones.
+	fm.previewSvc = fileinfo.NewPreviewService(debugPrint)
+	fm.previewSvc.OnUpdated(func() {
+		if fm.isWindowClosed() {
+			return
+		}
+		fyne.Do(func() {
+			if !fm.isWindowClosed() {
+				fm.updatePreviewPane()
+			}
+		})
+	})
+
+	// The thumbnail grid asks for thumbnails of visible image files and
+	// redraws in batches as they are generated or read from the disk cache.
+	fm.thumbnailMode = config.UI.Thumbnails.Enabled
+	thumbnailCacheDir := ""
+	if config.UI.Thumbnails.DiskCache {
+		if dir, err := fileinfo.ThumbnailCacheDir(); err == nil {
+			thumbnailCacheDir = dir
+		} else {
+			debugPrint("FileManager: thumbnail cache unavailable: %v", err)
+		}
+	}
+	fm.thumbnailSvc = fileinfo.NewThumbnailService(config.UI.Thumbnails.Size, thumbnailCacheDir, debugPrint)
+	fm.thumbnailSvc.OnUpdated(func() {
 		if fm.isWindowClosed() {
 			return
 		}
 		fyne.Do(func() {
-			if !fm.isWindowClosed() && fm.fileList != nil {
-				canvas.Refresh(fm.fileList)
+			if !fm.isWindowClosed() && fm.thumbnailMode && fm.fileGrid != nil {
+				fm.fileGrid.Refresh()
 			}
 		})
 	})
 
 	// Create directory watcher
 	fm.dirWatcher = watcher.NewDirectoryWatcher(fm, runtime.watchHub, debugPrint)
+	fm.dirWatcher.SetEntryFilter(hiddenEntryFilter(fm.showHidden))
 
 	// Create incremental search overlay
 	fm.searchOverlay = ui.NewIncrementalSearchOverlay([]fileinfo.FileInfo{}, fm.keyManager, customTheme, debugPrint, fm.searchMatchers)
 	fm.searchHandler = keymanager.NewIncrementalSearchKeyHandler(fm, debugPrint)
 	fm.searchHandler.SetTransitionGate(fm.keyManager.BeginOwnerTransition)
+	fm.commandPalette = ui.NewCommandPaletteOverlay(customTheme, debugPrint)
 
 	// Setup KeyManager with main screen handler
 	keymanager.WarnUnknownKeyBindingTargets(config.UI.KeyBindings, debugPrint)
@@ -80,24 +160,45 @@ func NewFileManager(runtime *ApplicationRuntime, path string, config *config.Con
 		ShowDirectoryTreeDialog:     fm.ShowDirectoryTreeDialog,
 		ShowNavigationHistoryDialog: fm.ShowNavigationHistoryDialog,
 		ShowDirectoryJumpDialog:     fm.ShowDirectoryJumpDialog,
+		ShowBookmarksDialog:         fm.ShowBookmarksDialog,
+		ShowPlacesDialog:            fm.ShowPlacesDialog,
 		ShowFilterDialog:            fm.ShowFilterDialog,
 		ShowIncrementalSearchDialog: fm.ShowIncrementalSearchDialog,
 		ShowSortDialog:              fm.ShowSortDialog,
 		ShowJobsDialog:              fm.ShowJobsDialog,
 		ShowPathEditDialog:          fm.ShowPathEditDialog,
 		ShowCreateDirectoryDialog:   fm.ShowCreateDirectoryDialog,
+		ShowCreateFileDialog:        fm.ShowCreateFileDialog,
+		ShowSelectByPatternDialog:   fm.ShowSelectByPatternDialog,
+		ShowDirectoryNoteDialog:     fm.ShowDirectoryNoteDialog,
 		ShowClipboardTextFileDialog: fm.ShowClipboardTextFileDialog,
 		ShowMessageDialog:           fm.ShowMessageDialog,
 		ShowCopyDialog:              fm.ShowCopyDialog,
 		ShowMoveDialog:              fm.ShowMoveDialog,
 		ShowExtractArchiveDialog:    fm.ShowExtractArchiveDialog,
+		ShowCompressDialog:          fm.ShowCompressDialog,
 		ShowCompareDialog:           fm.ShowCompareDialog,
+		ShowChecksumMenu:            fm.ShowChecksumMenu,
 		ShowRenameDialog:            fm.ShowRenameDialog,
+		ShowBatchRenameDialog:       fm.ShowBatchRenameDialog,
 		ShowDeleteDialog:            fm.ShowDeleteDialog,
+		ShowSecureDeleteDialog:      fm.ShowSecureDeleteDialog,
 		ShowExplorerContextMenu:     fm.ShowExplorerContextMenu,
+		ShowSendToMenu:              fm.ShowSendToMenu,
+		ShowOpenWithMenu:            fm.ShowOpenWithMenu,
+		ShowVolumesMenu:             fm.ShowVolumesMenu,
+		ShowConnectionMenu:          fm.ShowConnectionMenu,
+		ShowCredentialsDialog:       fm.ShowCredentialsDialog,
+		ShowRunCommandDialog:        fm.ShowRunCommandDialog,
+		ShowQuickLook:               fm.ShowQuickLook,
 		ShowExternalCommandMenu:     fm.ShowExternalCommandMenu,
 		ShowFileViewer:              fm.ShowFileViewer,
 		ShowMaintenanceDialog:       fm.ShowMaintenanceDialog,
+		ShowPropertiesDialog:        fm.ShowPropertiesDialog,
+		ShowCommandPalette:          fm.ShowCommandPalette,
+		ShowAuditLog:                fm.ShowAuditLog,
+		ShowExportAuditLogDialog:    fm.ShowExportAuditLogDialog,
+		ShowExportListingDialog:     fm.ShowExportListingDialog,
 		ShowCommandMenu:             fm.ShowCommandMenu,
 	})
 	fm.mainKeyHandler = mainHandler
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+
+	"fyne.io/fyne/v2"
+
+	"nmf/internal/config"
+	"nmf/internal/fileinfo"
+	"nmf/internal/ui"
+)
+
+// confirmLargeOperation runs proceed at once for targets within the
+// ui.jobs.confirmFiles and confirmGB thresholds, and otherwise only after the
+// dialog built by newDialog is confirmed. Directories are counted through in
+// the background; the walk stops as soon as a threshold is passed, and every
+// count still running is canceled when the window closes.
+func (fm *FileManager) confirmLargeOperation(targets []fileinfo.FileInfo, newDialog func(fileinfo.DirSize) *ui.BulkConfirmDialog, proceed func()) {
+	limits := fm.config.UI.Jobs
+	if limits.ConfirmFiles == 0 && limits.ConfirmGB == 0 {
+		proceed()
+		return
+	}
+	if fm.bulkMeasureCtx == nil {
+		fm.bulkMeasureCtx, fm.bulkMeasureCancel = context.WithCancel(context.Background())
+	}
+	ctx := fm.bulkMeasureCtx
+	exceeds := func(size fileinfo.DirSize) bool { return exceedsConfirmThreshold(size, limits) }
+	go func() {
+		size := measureTargets(ctx, targets, exceeds)
+		fyne.Do(func() {
+			if ctx.Err() != nil || fm.isWindowClosed() {
+				return
+			}
+			if !exceeds(size) {
+				proceed()
+				return
+			}
+			debugPrint("FileManager: Large operation files=%d dirs=%d bytes=%d", size.Files, size.Dirs, size.Bytes)
+			newDialog(size).ShowDialog(fm.window, proceed)
+		})
+	}()
+}
+
+// exceedsConfirmThreshold reports whether size passes a non-zero threshold.
+func exceedsConfirmThreshold(size fileinfo.DirSize, limits config.JobsConfig) bool {
+	if limits.ConfirmFiles > 0 && size.Files > limits.ConfirmFiles {
+		return true
+	}
+	return limits.ConfirmGB > 0 && size.Bytes > int64(limits.ConfirmGB)<<30
+}
+
+// cancelBulkMeasures stops every confirmation count still walking. It runs
+// on the UI thread when the window closes.
+func (fm *FileManager) cancelBulkMeasures() {
+	if fm.bulkMeasureCancel != nil {
+		fm.bulkMeasureCancel()
+	}
+	fm.bulkMeasureCtx = nil
+	fm.bulkMeasureCancel = nil
+}
+
+// measureTargets adds up the files below targets until exceeds is true,
+// stopping inside a directory walk as soon as the running total passes.
+// Links count as files, as moving or deleting one leaves what it points to
+// alone. Directories that cannot be listed are left out; the job reports
+// them. Canceling ctx returns the partial total.
+func measureTargets(ctx context.Context, targets []fileinfo.FileInfo, exceeds func(fileinfo.DirSize) bool) fileinfo.DirSize {
+	var total fileinfo.DirSize
+	for _, fi := range targets {
+		if ctx.Err() != nil || exceeds(total) {
+			break
+		}
+		if !fi.IsDir || fi.FileType == fileinfo.FileTypeSymlink {
+			total.Files++
+			total.Bytes += fi.Size
+			continue
+		}
+		total.Dirs++
+		base := total
+		size, err := fileinfo.DirectorySizeUntil(ctx, fi.Path, func(s fileinfo.DirSize) bool {
+			return exceeds(fileinfo.DirSize{Files: base.Files + s.Files, Dirs: base.Dirs + s.Dirs, Bytes: base.Bytes + s.Bytes})
+		})
+		if err != nil {
+			continue
+		}
+		total.Files += size.Files
+		total.Dirs += size.Dirs
+		total.Bytes += size.Bytes
+	}
+	return total
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"nmf/internal/config"
+	"nmf/internal/fileinfo"
+)
+
+func TestExceedsConfirmThreshold(t *testing.T) {
+	limits := config.JobsConfig{ConfirmFiles: 10, ConfirmGB: 1}
+	cases := []struct {
+		size fileinfo.DirSize
+		want bool
+	}{
+		{fileinfo.DirSize{Files: 10, Bytes: 1 << 30}, false},
+		{fileinfo.DirSize{Files: 11}, true},
+		{fileinfo.DirSize{Files: 1, Bytes: 1<<30 + 1}, true},
+	}
+	for _, c := range cases {
+		if got := exceedsConfirmThreshold(c.size, limits); got != c.want {
+			t.Errorf("exceedsConfirmThreshold(%+v) = %t, want %t", c.size, got, c.want)
+		}
+	}
+	if exceedsConfirmThreshold(fileinfo.DirSize{Files: 1 << 20, Bytes: 1 << 50}, config.JobsConfig{}) {
+		t.Error("zero thresholds should never ask")
+	}
+}
+
+func TestMeasureTargetsCountsThroughDirectoriesAndStopsEarly(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "dir")
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a", "sub/b"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("12345"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	targets := []fileinfo.FileInfo{
+		{Name: "dir", Path: dir, IsDir: true, FileType: fileinfo.FileTypeDirectory},
+		{Name: "c", Path: filepath.Join(root, "c"), Size: 7},
+	}
+
+	size := measureTargets(context.Background(), targets, func(fileinfo.DirSize) bool { return false })
+	if size.Files != 3 || size.Dirs != 2 || size.Bytes != 17 {
+		t.Fatalf("size = %+v, want 3 files in 2 dirs, 17 bytes", size)
+	}
+	size = measureTargets(context.Background(), targets, func(s fileinfo.DirSize) bool { return s.Files >= 1 })
+	if size.Files != 1 {
+		t.Fatalf("size = %+v, want the count to stop inside the directory", size)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	size = measureTargets(ctx, targets, func(fileinfo.DirSize) bool { return false })
+	if size.Files != 0 {
+		t.Fatalf("size = %+v, want a canceled count to stop before walking", size)
+	}
+}
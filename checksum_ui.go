@@ -0,0 +1,117 @@
+package main
+
+import (
+	"sync"
+
+	"fyne.io/fyne/v2"
+
+	"nmf/internal/fileinfo"
+	"nmf/internal/jobs"
+	"nmf/internal/keymanager"
+	"nmf/internal/ui"
+)
+
+// ShowChecksumMenu offers the digests to compute for the marked entries, or
+// the cursor entry, and verification of checksum files. Each choice runs as
+// a background job whose report opens in the viewer when it finishes.
+func (fm *FileManager) ShowChecksumMenu() {
+	paths := fm.collectTargetPaths()
+	if len(paths) == 0 {
+		debugPrint("FileManager: No target for checksum")
+		return
+	}
+	fm.showCommandMenu(checksumMenuItems(paths, fm.startChecksumJob, fm.startVerifyJob))
+}
+
+// checksumMenuItems lists one entry per digest, SHA-256 with sidecar files,
+// and verification of paths as checksum files.
+func checksumMenuItems(paths []string, compute func([]string, jobs.ChecksumOptions), verify func([]string)) []keymanager.CommandMenuItem {
+	keys := map[jobs.ChecksumAlgorithm]string{jobs.ChecksumSHA256: "S", jobs.ChecksumSHA1: "H", jobs.ChecksumMD5: "M"}
+	var items []keymanager.CommandMenuItem
+	for _, algorithm := range jobs.ChecksumAlgorithms {
+		opts := jobs.ChecksumOptions{Algorithm: algorithm}
+		items = append(items, keymanager.CommandMenuItem{Label: algorithm.Label(), Key: keys[algorithm], Action: func() { compute(paths, opts) }})
+	}
+	sidecars := jobs.ChecksumOptions{Algorithm: jobs.ChecksumSHA256, WriteSidecars: true}
+	return append(items,
+		keymanager.CommandMenuItem{Label: "SHA-256 and write " + sidecars.Algorithm.Extension() + " files", Key: "W", Action: func() { compute(paths, sidecars) }},
+		keymanager.CommandMenuItem{Separator: true},
+		keymanager.CommandMenuItem{Label: "Verify checksum file", Key: "V", Action: func() { verify(paths) }},
+	)
+}
+
+func (fm *FileManager) startChecksumJob(paths []string, opts jobs.ChecksumOptions) {
+	j := fm.jobManager().EnqueueChecksum(paths, opts)
+	debugPrint("FileManager: Checksum job id=%d algorithm=%s sidecars=%t sources=%d", j.ID, opts.Algorithm, opts.WriteSidecars, len(paths))
+	fm.showChecksumReportWhenDone(j, opts.Algorithm.Label())
+	fm.FocusFileList()
+}
+
+func (fm *FileManager) startVerifyJob(paths []string) {
+	j := fm.jobManager().EnqueueVerify(paths, jobs.ChecksumOptions{})
+	debugPrint("FileManager: Verify job id=%d sources=%d", j.ID, len(paths))
+	fm.showChecksumReportWhenDone(j, "Verify")
+	fm.FocusFileList()
+}
+
+// showChecksumReportWhenDone opens j's report once it finishes running.
+// Canceled jobs show nothing; the jobs list already says so.
+func (fm *FileManager) showChecksumReportWhenDone(j *jobs.Job, title string) {
+	fm.whenJobFinished(j, func(snap jobs.JobSnapshot) {
+		if snap.Status == jobs.StatusCanceled {
+			return
+		}
+		if len(j.ChecksumResults()) == 0 && snap.Error != "" {
+			fyne.Do(func() { fm.ShowMessageDialog(title+" failed", snap.Error) })
+			return
+		}
+		report := jobs.FormatChecksumResults(snap.Checksum.Algorithm, j.ChecksumResults())
+		fyne.Do(func() { fm.showChecksumReport(title, report) })
+	})
+}
+
+// whenJobFinished calls fn once, off the UI goroutine, when j completes,
+// fails, or is canceled.
+func (fm *FileManager) whenJobFinished(j *jobs.Job, fn func(jobs.JobSnapshot)) {
+	var once sync.Once
+	var unsubscribe func()
+	var mu sync.Mutex
+	check := func() {
+		snap := j.Snapshot()
+		if snap.Status != jobs.StatusCompleted && snap.Status != jobs.StatusFailed && snap.Status != jobs.StatusCanceled {
+			return
+		}
+		once.Do(func() {
+			mu.Lock()
+			if unsubscribe != nil {
+				unsubscribe()
+			}
+			mu.Unlock()
+			fn(snap)
+		})
+	}
+	mu.Lock()
+	unsubscribe = fm.jobManager().Subscribe(check)
+	mu.Unlock()
+	check()
+}
+
+// showChecksumReport opens report in the built-in viewer, where it can be
+// searched and copied.
+func (fm *FileManager) showChecksumReport(title, report string) {
+	preview := &fileinfo.PreviewFile{
+		Path:      title,
+		Name:      title + " checksums",
+		Data:      []byte(report),
+		Text:      report,
+		Encoding:  "UTF-8",
+		Size:      int64(len(report)),
+		SizeKnown: true,
+	}
+	dialog := ui.NewFileViewerDialog(preview, fm.keyManager)
+	dialog.SetMaxSize(fm.config.UI.Viewer.MaxWidth, fm.config.UI.Viewer.MaxHeight)
+	dialog.SetDefaultWrap(fm.config.UI.Viewer.DefaultWrap)
+	dialog.SetKeyBindings(fm.config.UI.KeyBindings)
+	dialog.SetDebugPrint(debugPrint)
+	dialog.ShowDialog(fm.window)
+}
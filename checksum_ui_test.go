@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	"nmf/internal/jobs"
+)
+
+func TestChecksumMenuItemsRunTheChosenJob(t *testing.T) {
+	paths := []string{"/data/a.iso"}
+	var computed []jobs.ChecksumOptions
+	var verified [][]string
+	items := checksumMenuItems(paths, func(p []string, opts jobs.ChecksumOptions) {
+		if len(p) != 1 || p[0] != paths[0] {
+			t.Fatalf("compute paths = %v", p)
+		}
+		computed = append(computed, opts)
+	}, func(p []string) { verified = append(verified, p) })
+
+	keys := map[string]func(){}
+	for _, item := range items {
+		if !item.Separator {
+			keys[item.Key] = item.Action
+		}
+	}
+	for _, key := range []string{"S", "H", "M", "W", "V"} {
+		if keys[key] == nil {
+			t.Fatalf("no menu item for key %s in %+v", key, items)
+		}
+		keys[key]()
+	}
+	want := []jobs.ChecksumOptions{
+		{Algorithm: jobs.ChecksumSHA256},
+		{Algorithm: jobs.ChecksumSHA1},
+		{Algorithm: jobs.ChecksumMD5},
+		{Algorithm: jobs.ChecksumSHA256, WriteSidecars: true},
+	}
+	if len(computed) != len(want) {
+		t.Fatalf("computed = %+v, want %+v", computed, want)
+	}
+	for i := range want {
+		if computed[i] != want[i] {
+			t.Fatalf("computed = %+v, want %+v", computed, want)
+		}
+	}
+	if len(verified) != 1 || verified[0][0] != paths[0] {
+		t.Fatalf("verified = %v", verified)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fyne.io/fyne/v2"
 
 	"nmf/internal/fileinfo"
+	"nmf/internal/jobs"
 	"nmf/internal/ui"
 )
 
@@ -49,6 +50,7 @@ func (fm *FileManager) CreateClipboardTextFile(name string) bool {
 	}
 
 	newPath, err := fileinfo.CreateTextFilePortable(fm.currentPath, name, text)
+	fm.recordAudit(jobs.AuditCreateFile, nil, fileinfo.JoinPath(fm.currentPath, name), err)
 	if err != nil {
 		debugPrint("FileManager: Create text file failed parent=%s name=%s err=%v", fm.currentPath, name, err)
 		fm.ShowMessageDialog("Create text file failed", err.Error())
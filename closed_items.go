@@ -0,0 +1,80 @@
+package main
+
+import "slices"
+
+// maxClosedItems bounds the recently closed windows and tabs kept for
+// reopening.
+const maxClosedItems = 20
+
+// closedItem is a recently closed window, with all its tabs, or a single
+// closed tab. Tabs keep their cursor, filter, sort, and marks.
+type closedItem struct {
+	window bool
+	tabs   []tabState
+	active int
+}
+
+// path is the directory the item was showing when it closed.
+func (item closedItem) path() string {
+	if item.active < 0 || item.active >= len(item.tabs) {
+		return ""
+	}
+	return item.tabs[item.active].path
+}
+
+// recordClosedItem pushes item onto the reopen stack, dropping the oldest
+// entry beyond maxClosedItems. Items without a path are ignored.
+func recordClosedItem(item closedItem) {
+	if item.path() == "" {
+		return
+	}
+
+	windowOrderMu.Lock()
+	defer windowOrderMu.Unlock()
+	closedItems = append(closedItems, item)
+	if len(closedItems) > maxClosedItems {
+		closedItems = slices.Delete(closedItems, 0, len(closedItems)-maxClosedItems)
+	}
+}
+
+// popClosedItem removes and returns the most recently closed item, or the
+// most recently closed window when windowsOnly is set.
+func popClosedItem(windowsOnly bool) (closedItem, bool) {
+	windowOrderMu.Lock()
+	defer windowOrderMu.Unlock()
+	for i := len(closedItems) - 1; i >= 0; i-- {
+		item := closedItems[i]
+		if windowsOnly && !item.window {
+			continue
+		}
+		closedItems = slices.Delete(closedItems, i, i+1)
+		return item, true
+	}
+	return closedItem{}, false
+}
+
+// ReopenClosed reopens the most recently closed window or tab. A tab comes
+// back in this window, after the active tab.
+func (fm *FileManager) ReopenClosed() {
+	item, ok := popClosedItem(false)
+	if !ok {
+		debugPrint("FileManager: Nothing closed to reopen")
+		return
+	}
+	fm.reopenClosedItem(item)
+}
+
+func (fm *FileManager) reopenClosedItem(item closedItem) {
+	if item.window {
+		debugPrint("FileManager: Reopening closed window path=%s tabs=%d", item.path(), len(item.tabs))
+		newFM := fm.openWindowAtPath(item.path())
+		newFM.tabs = slices.Clone(item.tabs)
+		newFM.showTab(item.active)
+		return
+	}
+	fm.captureActiveTab()
+	index := fm.activeTab + 1
+	fm.tabs = slices.Insert(fm.tabs, index, item.tabs[item.active])
+	debugPrint("FileManager: Reopened closed tab index=%d path=%s tabs=%d", index, item.path(), len(fm.tabs))
+	fm.showTab(index)
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fyne.io/fyne/v2"
+
+	"nmf/internal/fileinfo"
+)
+
+// SetColorTag assigns tag to the marked files in this window, or to the
+// cursor entry when nothing is marked. Assigning a tag every target already
+// carries clears it instead, so the same key toggles the label.
+func (fm *FileManager) SetColorTag(tag fileinfo.ColorTag) {
+	targets := fm.colorTagTargets()
+	if len(targets) == 0 {
+		return
+	}
+	if tag != fileinfo.ColorTagNone && allColorTagged(targets, tag) {
+		tag = fileinfo.ColorTagNone
+	}
+
+	dir := fm.currentPath
+	index := fm.tagIndex()
+	paths := make([]string, len(targets))
+	for i, fi := range targets {
+		paths[i] = fi.Path
+	}
+	go func() {
+		tagged := make(map[string]bool, len(paths))
+		var firstErr error
+		for _, p := range paths {
+			if err := fileinfo.SetColorTag(p, tag); err != nil {
+				debugPrint("FileManager: Set color tag failed path=%s err=%v", p, err)
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			tagged[p] = true
+			if index != nil {
+				index.Set(p, tag)
+			}
+		}
+		fyne.Do(func() {
+			if fm.isWindowClosed() {
+				return
+			}
+			if fm.currentPath == dir && len(tagged) > 0 {
+				fm.applyColorTag(tagged, tag)
+			}
+			if firstErr != nil {
+				fm.ShowMessageDialog("Color tag failed", firstErr.Error())
+			}
+		})
+	}()
+}
+
+func (fm *FileManager) colorTagTargets() []fileinfo.FileInfo {
+	targets := fm.selectedFileInfos()
+	if len(targets) == 0 {
+		file, ok := fm.FileAt(fm.GetCurrentCursorIndex())
+		if !ok || !isTargetFileInfo(file) {
+			return nil
+		}
+		targets = []fileinfo.FileInfo{file}
+	}
+	// The tag:// root lists the views themselves, which cannot be tagged.
+	real := targets[:0:0]
+	for _, fi := range targets {
+		if !fileinfo.IsTagViewPath(fi.Path) {
+			real = append(real, fi)
+		}
+	}
+	return real
+}
+
+func allColorTagged(files []fileinfo.FileInfo, tag fileinfo.ColorTag) bool {
+	for _, fi := range files {
+		if fi.ColorTag != tag {
+			return false
+		}
+	}
+	return true
+}
+
+// applyColorTag updates the listing after tags were stored. The filter and
+// sort are re-applied because both may depend on tags. A tag view drops the
+// entries that no longer carry its tag.
+func (fm *FileManager) applyColorTag(paths map[string]bool, tag fileinfo.ColorTag) {
+	base := fm.originalFiles
+	if len(base) == 0 {
+		base = fm.files
+	}
+	viewTag, tagView := fileinfo.ParseTagViewPath(fm.currentPath)
+	files := make([]fileinfo.FileInfo, 0, len(base))
+	for _, fi := range base {
+		if paths[fi.Path] {
+			fi.ColorTag = tag
+			if tagView && viewTag != fileinfo.ColorTagNone && tag != viewTag {
+				fm.RemoveFromSelections(fi.Path)
+				continue
+			}
+		}
+		files = append(files, fi)
+	}
+	fm.updateFiles(files, true)
+}
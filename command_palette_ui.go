@@ -0,0 +1,64 @@
+package main
+
+import (
+	"nmf/internal/keymanager"
+	"nmf/internal/ui"
+)
+
+// ShowCommandPalette opens the command palette over the file list with every
+// main-screen command and its current keys.
+func (fm *FileManager) ShowCommandPalette() {
+	if fm.commandPalette == nil || fm.mainKeyHandler == nil || fm.commandPalette.IsVisible() {
+		return
+	}
+	debugPrint("FileManager: Showing command palette")
+	fm.paletteToken = fm.keyManager.PushHandler(keymanager.NewCommandPaletteKeyHandler(fm, debugPrint))
+	fm.commandPalette.Show(fm.window, commandPaletteItems(fm.mainKeyHandler.Commands()))
+}
+
+func commandPaletteItems(entries []keymanager.CommandEntry) []ui.CommandPaletteItem {
+	items := make([]ui.CommandPaletteItem, len(entries))
+	for i, entry := range entries {
+		items[i] = ui.CommandPaletteItem{ID: entry.ID, Keys: entry.Keys}
+	}
+	return items
+}
+
+// HideCommandPalette closes the palette without running anything.
+func (fm *FileManager) HideCommandPalette() {
+	fm.closeCommandPalette("commandPalette.cancel", "")
+}
+
+// AcceptCommandPalette closes the palette and runs the highlighted command
+// once the main screen owns input again.
+func (fm *FileManager) AcceptCommandPalette() {
+	commandID, _ := fm.commandPalette.Selected()
+	fm.closeCommandPalette("commandPalette.accept", commandID)
+}
+
+func (fm *FileManager) closeCommandPalette(label, commandID string) {
+	fm.commandPalette.Hide()
+	fm.keyManager.BeginOwnerTransition(label, func() {
+		fm.keyManager.RemoveHandler(fm.paletteToken)
+		fm.FocusFileList()
+		if commandID != "" {
+			debugPrint("FileManager: Command palette runs %s", commandID)
+			fm.mainKeyHandler.RunCommand(commandID)
+		}
+	})
+}
+
+// AddCommandPaletteCharacter extends the palette query.
+func (fm *FileManager) AddCommandPaletteCharacter(char rune) {
+	fm.commandPalette.AddCharacter(char)
+}
+
+// RemoveLastCommandPaletteCharacter shortens the palette query.
+func (fm *FileManager) RemoveLastCommandPaletteCharacter() {
+	fm.commandPalette.RemoveLastCharacter()
+}
+
+// MoveCommandPaletteSelection moves the palette highlight.
+func (fm *FileManager) MoveCommandPaletteSelection(delta int) {
+	fm.commandPalette.MoveSelection(delta)
+}
@@ -10,15 +10,10 @@ import (
 	"nmf/internal/ui"
 )
 
-// ShowCompareDialog opens the direct-directory comparison dialog.
+// ShowCompareDialog opens the direct-directory comparison dialog, which also
+// starts synchronizing the current directory tree with the destination.
 func (fm *FileManager) ShowCompareDialog() {
 	sourceFiles := fm.compareSourceFiles()
-	if len(sourceFiles) == 0 {
-		debugPrint("FileManager: No source files available for compare path=%s", fm.currentPath)
-		fm.ShowMessageDialog("Compare Directories", "There are no files to compare in the current directory.")
-		return
-	}
-
 	dest := fm.buildDestinationCandidates()
 	dlg := ui.NewCompareDialog(fm.currentPath, len(sourceFiles), dest, fm.keyManager, debugPrint, fm.searchMatchers)
 	openDest := destinationCandidateOpenMap(dest)
@@ -62,11 +57,26 @@ func (fm *FileManager) runCompare(sourcePath string, sourceFiles []fileinfo.File
 		fm.FocusFileList()
 		return
 	}
-	if sameDirectoryPath(result.Destination, sourcePath) {
+	if fileinfo.SamePath(result.Destination, sourcePath) {
 		fm.ShowMessageDialog("Compare Directories", "Choose a different destination directory.")
 		fm.FocusFileList()
 		return
 	}
+	if result.Method.IsSync() {
+		if isAncestorPath(sourcePath, result.Destination) || isAncestorPath(result.Destination, sourcePath) {
+			fm.ShowMessageDialog("Synchronize Directories", "Choose a destination outside the current directory tree.")
+			fm.FocusFileList()
+			return
+		}
+		fm.previewSync(sourcePath, result.Destination, result.Method)
+		return
+	}
+	if len(sourceFiles) == 0 {
+		debugPrint("FileManager: No source files available for compare path=%s", sourcePath)
+		fm.ShowMessageDialog("Compare Directories", "There are no files to compare in the current directory.")
+		fm.FocusFileList()
+		return
+	}
 
 	fm.beginBusy(fmt.Sprintf("Comparing %s...", result.Destination))
 	go func() {
@@ -99,13 +109,12 @@ func (fm *FileManager) applyCompareMarks(matched []fileinfo.FileInfo) int {
 	fm.mu.Lock()
 	defer fm.mu.Unlock()
 
-	fm.selectedFiles = make(map[string]bool, len(matched))
-	for _, fi := range matched {
-		fm.selectedFiles[fi.Path] = true
-	}
-	if fm.fileList != nil {
-		fm.fileList.Refresh()
+	paths := make([]string, len(matched))
+	for i, fi := range matched {
+		paths[i] = fi.Path
 	}
+	fm.selection.Replace(paths)
+	fm.refreshFileList()
 	fm.updateStatusBar()
 	return len(matched)
 }
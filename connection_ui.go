@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fyne.io/fyne/v2/widget"
+
+	"nmf/internal/fileinfo"
+	"nmf/internal/keymanager"
+)
+
+// updateConnectionIndicator shows the connection state of the share holding
+// path next to the path bar, and hides it for local paths and shares not
+// dialed yet.
+func (fm *FileManager) updateConnectionIndicator(path string) {
+	if fm.connectionButton == nil {
+		return
+	}
+	share, ok := fileinfo.RemoteShareOf(path)
+	if !ok {
+		fm.connectionButton.Hide()
+		return
+	}
+	text, importance, visible := connectionIndicator(fileinfo.ConnectionStateOf(share))
+	if !visible {
+		fm.connectionButton.Hide()
+		return
+	}
+	fm.connectionButton.SetText(text)
+	fm.connectionButton.Importance = importance
+	fm.connectionButton.Show()
+	fm.connectionButton.Refresh()
+}
+
+func connectionIndicator(state fileinfo.ConnectionState) (string, widget.Importance, bool) {
+	switch state {
+	case fileinfo.ConnectionConnected:
+		return "Connected", widget.SuccessImportance, true
+	case fileinfo.ConnectionReconnecting:
+		return "Reconnecting", widget.WarningImportance, true
+	case fileinfo.ConnectionAuthRequired:
+		return "Auth required", widget.DangerImportance, true
+	default:
+		return "", widget.MediumImportance, false
+	}
+}
+
+// ShowConnectionMenu offers reconnecting to the share holding the current
+// directory, or re-entering its credentials, then reloads the directory. A
+// load still pending is not restarted: it may be waiting for exactly this,
+// and Reconnect resumes it. The saved credentials manager is offered on any
+// directory.
+func (fm *FileManager) ShowConnectionMenu() {
+	share, ok := fileinfo.RemoteShareOf(fm.currentPath)
+	if !ok {
+		fm.showCommandMenu([]keymanager.CommandMenuItem{
+			{Label: "Not on a remote share.", Action: func() {}},
+			{Separator: true},
+			savedCredentialsMenuItem(fm.ShowCredentialsDialog),
+		})
+		return
+	}
+	fm.showCommandMenu(connectionMenuItems(fileinfo.ConnectionStateOf(share), func(reenter bool) {
+		debugPrint("FileManager: Reconnect share=%s/%s reenter=%t", share.Host, share.Share, reenter)
+		fileinfo.Reconnect(share, reenter)
+		if !fm.navigator.Pending() {
+			fm.LoadDirectory(fm.currentPath)
+		}
+		fm.FocusFileList()
+	}, fm.ShowCredentialsDialog))
+}
+
+// connectionMenuItems puts re-entering credentials first when the server
+// rejected the current ones.
+func connectionMenuItems(state fileinfo.ConnectionState, reconnect func(reenter bool), manage func()) []keymanager.CommandMenuItem {
+	items := []keymanager.CommandMenuItem{
+		{Label: "Reconnect", Key: "R", Action: func() { reconnect(false) }},
+		{Label: "Re-enter credentials", Key: "C", Action: func() { reconnect(true) }},
+	}
+	if state == fileinfo.ConnectionAuthRequired {
+		items[0], items[1] = items[1], items[0]
+	}
+	return append(items, keymanager.CommandMenuItem{Separator: true}, savedCredentialsMenuItem(manage))
+}
+
+func savedCredentialsMenuItem(manage func()) keymanager.CommandMenuItem {
+	return keymanager.CommandMenuItem{Label: "Saved credentials...", Key: "K", Action: manage}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"testing"
+
+	"nmf/internal/fileinfo"
+)
+
+func TestConnectionMenuPutsCredentialsFirstWhenRejected(t *testing.T) {
+	var reentered []bool
+	reconnect := func(reenter bool) { reentered = append(reentered, reenter) }
+
+	managed := 0
+	manage := func() { managed++ }
+
+	items := connectionMenuItems(fileinfo.ConnectionReconnecting, reconnect, manage)
+	if items[0].Key != "R" || items[1].Key != "C" {
+		t.Fatalf("items = %+v, want Reconnect first", items)
+	}
+	if last := items[len(items)-1]; last.Key != "K" {
+		t.Fatalf("items = %+v, want the credentials manager last", items)
+	} else if last.Action(); managed != 1 {
+		t.Fatal("the credentials manager item did not open the manager")
+	}
+	items = connectionMenuItems(fileinfo.ConnectionAuthRequired, reconnect, manage)
+	if items[0].Key != "C" {
+		t.Fatalf("items = %+v, want re-entering credentials first", items)
+	}
+	items[0].Action()
+	items[1].Action()
+	if len(reentered) != 2 || !reentered[0] || reentered[1] {
+		t.Fatalf("reconnect calls = %v, want [true false]", reentered)
+	}
+
+	if _, _, visible := connectionIndicator(fileinfo.ConnectionUnknown); visible {
+		t.Fatal("indicator shown for a share not dialed yet")
+	}
+}
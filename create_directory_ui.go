@@ -3,6 +3,7 @@ package main
 import (
 	"nmf/internal/config"
 	"nmf/internal/fileinfo"
+	"nmf/internal/jobs"
 	"nmf/internal/ui"
 )
 
@@ -21,6 +22,7 @@ func (fm *FileManager) ShowCreateDirectoryDialog() {
 // CreateDirectory creates a directory under the current path and selects it.
 func (fm *FileManager) CreateDirectory(name string) bool {
 	newPath, err := fileinfo.CreateDirectoryPortable(fm.currentPath, name)
+	fm.recordAudit(jobs.AuditCreateDirectory, nil, fileinfo.JoinPath(fm.currentPath, name), err)
 	if err != nil {
 		debugPrint("FileManager: Create directory failed parent=%s name=%s err=%v", fm.currentPath, name, err)
 		fm.ShowMessageDialog("Create directory failed", err.Error())
@@ -34,6 +36,35 @@ func (fm *FileManager) CreateDirectory(name string) bool {
 	return true
 }
 
+// ShowCreateFileDialog shows a single-name empty file creation dialog.
+func (fm *FileManager) ShowCreateFileDialog() {
+	dlg := ui.NewLineEditDialog(ui.LineEditDialogOptions{
+		Title:       "Create File",
+		Prompt:      "File name:",
+		ConfirmText: "Create",
+	}, fm.keyManager, fm.config.UI.KeyBindings)
+	dlg.ShowDialog(fm.window, func(name string) bool {
+		return fm.CreateFile(name)
+	})
+}
+
+// CreateFile creates an empty file under the current path and places the
+// cursor on it.
+func (fm *FileManager) CreateFile(name string) bool {
+	newPath, err := fileinfo.CreateFilePortable(fm.currentPath, name)
+	fm.recordAudit(jobs.AuditCreateFile, nil, fileinfo.JoinPath(fm.currentPath, name), err)
+	if err != nil {
+		debugPrint("FileManager: Create file failed parent=%s name=%s err=%v", fm.currentPath, name, err)
+		fm.ShowMessageDialog("Create file failed", err.Error())
+		return false
+	}
+
+	fm.applyCreatedPathToList(newPath, false)
+	debugPrint("FileManager: Created file %s", newPath)
+	fm.FocusFileList()
+	return true
+}
+
 func (fm *FileManager) applyCreatedPathToList(path string, isDir bool) {
 	name := fileinfo.BaseName(path)
 	info, err := fileinfo.StatPortable(path)
@@ -60,7 +91,7 @@ func (fm *FileManager) applyCreatedPathToList(path string, isDir bool) {
 	fm.mu.Lock()
 	fm.originalFiles = upsertFileInfo(fm.originalFiles, created)
 	if fm.currentFilter != nil && config.EffectiveFilterPattern(fm.currentFilter.Pattern) != "" {
-		filtered, err := fileinfo.FilterFiles(fm.originalFiles, config.EffectiveFilterPattern(fm.currentFilter.Pattern))
+		filtered, err := fm.filterFiles(fm.originalFiles, config.EffectiveFilterPattern(fm.currentFilter.Pattern))
 		if err != nil {
 			debugPrint("FileManager: Filter error after create: %v", err)
 			fm.files = fm.originalFiles
@@ -72,6 +103,7 @@ func (fm *FileManager) applyCreatedPathToList(path string, isDir bool) {
 		copy(fm.files, fm.originalFiles)
 	}
 	fm.sortFilesWithConfig(fm.CurrentSort())
+	fm.reweighMarks()
 	fm.cursorPath = path
 	if fm.GetCurrentCursorIndex() < 0 && len(fm.files) > 0 {
 		fm.SetCursorByIndex(0)
@@ -1,9 +1,11 @@
 package main
 
 import (
+	"path/filepath"
 	"testing"
 
 	"nmf/internal/config"
+	"nmf/internal/selection"
 )
 
 func TestCreateDirectoryAddsNewPathToNavigationHistory(t *testing.T) {
@@ -31,3 +33,28 @@ func TestCreateDirectoryAddsNewPathToNavigationHistory(t *testing.T) {
 		t.Fatalf("history[0] = %q, want %q", got, want)
 	}
 }
+
+func TestCreateFilePlacesCursorOnNewFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	fm := &FileManager{
+		currentPath: tmpDir,
+		config:      &config.Config{},
+		state:       &config.State{},
+		selection:   selection.New(),
+	}
+
+	if !fm.CreateFile("notes.txt") {
+		t.Fatal("CreateFile returned false")
+	}
+
+	want := filepath.Join(tmpDir, "notes.txt")
+	if fm.cursorPath != want {
+		t.Fatalf("cursorPath = %q, want %q", fm.cursorPath, want)
+	}
+	if len(fm.files) != 1 || fm.files[0].IsDir {
+		t.Fatalf("files = %+v, want one regular file", fm.files)
+	}
+	if history := fm.state.GetNavigationHistory(); len(history) != 0 {
+		t.Fatalf("creating a file should not record navigation history, got %q", history)
+	}
+}
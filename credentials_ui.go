@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+
+	"fyne.io/fyne/v2"
+
+	"nmf/internal/fileinfo"
+	"nmf/internal/secret"
+	"nmf/internal/ui"
+	"nmf/internal/vault"
+)
+
+// ShowCredentialsDialog lists the SMB credentials saved in the keyring for
+// editing and removal. The keyring is read off the UI thread, since reading
+// it may wait for the keyring to unlock.
+func (fm *FileManager) ShowCredentialsDialog() {
+	go func() {
+		entries, err := fileinfo.StoredCredentials()
+		fyne.Do(func() {
+			if fm.isWindowClosed() {
+				return
+			}
+			if err != nil {
+				debugPrint("FileManager: Listing saved credentials failed: %v", err)
+				message := err.Error()
+				if errors.Is(err, fileinfo.ErrNoSecretStore) {
+					message = "No keyring is available, so no credentials are saved."
+				}
+				fm.ShowMessageDialog("Credentials", message)
+				return
+			}
+			dialog := ui.NewCredentialsDialog(smbCredentialEntries(entries), ui.CredentialsDialogActions{
+				Load:   fileinfo.StoredCredentialsFor,
+				Save:   fileinfo.SaveStoredCredentials,
+				Forget: fileinfo.ForgetStoredCredentials,
+			}, fm.keyManager, debugPrint, fm.config.UI.KeyBindings)
+			dialog.ShowDialog(fm.window)
+		})
+	}()
+}
+
+// smbCredentialEntries drops the vault passwords kept in the same keyring.
+func smbCredentialEntries(entries []secret.Entry) []secret.Entry {
+	smb := make([]secret.Entry, 0, len(entries))
+	for _, e := range entries {
+		if e.Host != vault.SecretHost {
+			smb = append(smb, e)
+		}
+	}
+	return smb
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 
+	"nmf/internal/fileinfo"
 	"nmf/internal/jobs"
 	"nmf/internal/ui"
 )
@@ -20,18 +21,52 @@ func (fm *FileManager) ShowDeleteDialog(permanent bool) {
 		return
 	}
 
+	targetInfos := fm.targetFileInfos()
+
 	dlg := ui.NewDeleteConfirmDialog(targets, permanent, fm.keyManager)
 	dlg.ShowDialog(fm.window, func() {
-		mode := jobs.DeleteModeTrash
-		if permanent {
-			mode = jobs.DeleteModePermanent
-		}
-		fm.jobManager().EnqueueDelete(srcPaths, mode)
+		action := "Trash"
 		if permanent {
-			fm.ShowMessageDialog("Delete", fmt.Sprintf("Queued permanent delete for %d item(s).", len(srcPaths)))
-		} else {
-			fm.ShowMessageDialog("Trash", fmt.Sprintf("Queued %d item(s) to Trash.", len(srcPaths)))
+			action = "Delete"
 		}
-		fm.FocusFileList()
+		fm.confirmLargeOperation(targetInfos, func(size fileinfo.DirSize) *ui.BulkConfirmDialog {
+			return ui.NewBulkDeleteConfirmDialog(size, action, fm.keyManager)
+		}, func() {
+			mode := jobs.DeleteModeTrash
+			if permanent {
+				mode = jobs.DeleteModePermanent
+			}
+			fm.jobManager().EnqueueDelete(srcPaths, mode)
+			if permanent {
+				fm.ShowMessageDialog("Delete", fmt.Sprintf("Queued permanent delete for %d item(s).", len(srcPaths)))
+			} else {
+				fm.ShowMessageDialog("Trash", fmt.Sprintf("Queued %d item(s) to Trash.", len(srcPaths)))
+			}
+			fm.FocusFileList()
+		})
+	})
+}
+
+// ShowSecureDeleteDialog confirms and queues a secure delete, which overwrites
+// local files before removing them.
+func (fm *FileManager) ShowSecureDeleteDialog() {
+	targets := fm.collectTargets()
+	srcPaths := fm.collectTargetPaths()
+	if len(targets) == 0 || len(srcPaths) == 0 {
+		debugPrint("FileManager: No valid target for secure delete")
+		return
+	}
+
+	targetInfos := fm.targetFileInfos()
+
+	dlg := ui.NewSecureDeleteConfirmDialog(targets, fm.keyManager)
+	dlg.ShowDialog(fm.window, func() {
+		fm.confirmLargeOperation(targetInfos, func(size fileinfo.DirSize) *ui.BulkConfirmDialog {
+			return ui.NewBulkDeleteConfirmDialog(size, "Shred", fm.keyManager)
+		}, func() {
+			fm.jobManager().EnqueueDelete(srcPaths, jobs.DeleteModeSecure)
+			fm.ShowMessageDialog("Secure Delete", fmt.Sprintf("Queued secure delete for %d item(s).", len(srcPaths)))
+			fm.FocusFileList()
+		})
 	})
 }
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fyne.io/fyne/v2"
+
+	"nmf/internal/fileinfo"
+	"nmf/internal/ui"
+)
+
+// ShowDirectoryNoteDialog edits the note attached to the current directory.
+// Committing an empty note removes it.
+func (fm *FileManager) ShowDirectoryNoteDialog() {
+	dlg := ui.NewLineEditDialog(ui.LineEditDialogOptions{
+		Title:       "Directory Note",
+		Prompt:      "Note for " + fileinfo.BaseName(fm.currentPath) + ":",
+		InitialText: fm.dirNote,
+		ConfirmText: "Save",
+	}, fm.keyManager, fm.config.UI.KeyBindings)
+	dlg.ShowDialog(fm.window, func(note string) bool {
+		fm.SaveDirectoryNote(note)
+		return true
+	})
+}
+
+// SaveDirectoryNote writes note for the current directory in the background
+// and updates the status bar once it is stored.
+func (fm *FileManager) SaveDirectoryNote(note string) {
+	dir := fm.currentPath
+	note = fileinfo.NormalizeDirNote(note)
+	go func() {
+		err := fileinfo.WriteDirNote(dir, note)
+		fyne.Do(func() {
+			if fm.isWindowClosed() {
+				return
+			}
+			if err != nil {
+				debugPrint("FileManager: Save directory note failed dir=%s err=%v", dir, err)
+				fm.ShowMessageDialog("Save note failed", err.Error())
+				return
+			}
+			debugPrint("FileManager: Saved directory note dir=%s empty=%t", dir, note == "")
+			if fm.currentPath == dir {
+				fm.dirNote = note
+				fm.updateStatusBar()
+			}
+		})
+	}()
+}
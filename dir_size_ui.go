@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+
+	"nmf/internal/fileinfo"
+)
+
+// dirSizeState is the size-mode result for one directory row.
+type dirSizeState struct {
+	size fileinfo.DirSize
+	done bool
+	err  error
+}
+
+// CalculateDirectorySizes counts the recursive size of the marked
+// directories, or the cursor directory when none are marked, in the
+// background. Results replace "<dir>" in the info column until the window
+// leaves the directory, which also cancels counts still running.
+func (fm *FileManager) CalculateDirectorySizes() {
+	var dirs []fileinfo.FileInfo
+	for _, fi := range fm.selectedFileInfos() {
+		if fi.IsDir {
+			dirs = append(dirs, fi)
+		}
+	}
+	if len(dirs) == 0 {
+		idx := fm.GetCurrentCursorIndex()
+		files := fm.GetFiles()
+		if idx >= 0 && idx < len(files) && files[idx].IsDir && files[idx].Name != ".." && files[idx].Status != fileinfo.StatusDeleted {
+			dirs = append(dirs, files[idx])
+		}
+	}
+	if len(dirs) == 0 {
+		debugPrint("FileManager: No directory target for size calculation")
+		return
+	}
+	if fileinfo.IsTagViewPath(fm.currentPath) {
+		debugPrint("FileManager: Directory size unsupported in tag view path=%s", fm.currentPath)
+		return
+	}
+
+	if fm.dirSizes == nil {
+		fm.dirSizes = make(map[string]dirSizeState)
+		fm.dirSizeCtx, fm.dirSizeCancel = context.WithCancel(context.Background())
+	}
+	ctx := fm.dirSizeCtx
+	pending := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if state, ok := fm.dirSizes[dir.Path]; ok && !state.done {
+			continue
+		}
+		fm.dirSizes[dir.Path] = dirSizeState{}
+		pending = append(pending, dir.Path)
+	}
+	if len(pending) == 0 {
+		return
+	}
+	debugPrint("FileManager: Directory size start count=%d", len(pending))
+	fm.refreshFileList()
+
+	// One goroutine per request walks its directories in turn so marking
+	// many siblings does not start that many concurrent tree walks.
+	go func() {
+		for _, p := range pending {
+			size, err := fileinfo.DirectorySize(ctx, p)
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			fyne.Do(func() {
+				if ctx.Err() != nil || fm.isWindowClosed() {
+					return
+				}
+				fm.dirSizes[p] = dirSizeState{size: size, done: true, err: err}
+				debugPrint("FileManager: Directory size done path=%s bytes=%d err=%v", p, size.Bytes, err)
+				fm.refreshFileList()
+			})
+		}
+	}()
+}
+
+// clearDirectorySizes cancels running counts and drops every result. It runs
+// on the UI thread when the window leaves the directory or closes.
+func (fm *FileManager) clearDirectorySizes() {
+	if fm.dirSizeCancel != nil {
+		fm.dirSizeCancel()
+	}
+	fm.dirSizes = nil
+	fm.dirSizeCtx = nil
+	fm.dirSizeCancel = nil
+}
+
+// directoryInfoSize is the size column text for a directory row: "<dir>"
+// until a size has been requested, "<...>" while it is counted.
+func directoryInfoSize(state dirSizeState, ok bool) string {
+	switch {
+	case !ok || (state.done && state.err != nil):
+		return "<dir>"
+	case !state.done:
+		return "<...>"
+	case state.size.Unreadable > 0:
+		return fileinfo.FormatFileSize(state.size.Bytes) + "+"
+	default:
+		return fileinfo.FormatFileSize(state.size.Bytes)
+	}
+}
+
+func (fm *FileManager) directoryInfoText(file fileinfo.FileInfo) string {
+	state, ok := fm.dirSizes[file.Path]
+	return fmt.Sprintf("%s %s %s",
+		directoryInfoSize(state, ok),
+		file.Modified.Format("2006-01-02"),
+		file.Modified.Format("15:04:05"))
+}
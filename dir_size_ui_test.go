@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"nmf/internal/fileinfo"
+)
+
+func TestDirectoryInfoSizeFollowsCalculationState(t *testing.T) {
+	cases := []struct {
+		state dirSizeState
+		ok    bool
+		want  string
+	}{
+		{dirSizeState{}, false, "<dir>"},
+		{dirSizeState{}, true, "<...>"},
+		{dirSizeState{done: true, size: fileinfo.DirSize{Bytes: 2048}}, true, "2.0 KB"},
+		{dirSizeState{done: true, size: fileinfo.DirSize{Bytes: 2048, Unreadable: 1}}, true, "2.0 KB+"},
+		{dirSizeState{done: true, err: errors.New("denied")}, true, "<dir>"},
+	}
+	for _, tc := range cases {
+		if got := directoryInfoSize(tc.state, tc.ok); got != tc.want {
+			t.Fatalf("directoryInfoSize(%+v, %t) = %q, want %q", tc.state, tc.ok, got, tc.want)
+		}
+	}
+}
+
+func TestClearDirectorySizesCancelsRunningCounts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	fm := &FileManager{
+		dirSizes:      map[string]dirSizeState{"/proj": {}},
+		dirSizeCtx:    ctx,
+		dirSizeCancel: cancel,
+	}
+	dir := fileinfo.FileInfo{Name: "proj", Path: "/proj", IsDir: true, Modified: time.Date(2024, 1, 2, 3, 4, 5, 0, time.Local)}
+	if got := fm.directoryInfoText(dir); !strings.HasPrefix(got, "<...> 2024-01-02") {
+		t.Fatalf("info while counting = %q", got)
+	}
+
+	fm.clearDirectorySizes()
+
+	if ctx.Err() == nil {
+		t.Fatal("leaving the directory should cancel running counts")
+	}
+	if got := fm.directoryInfoText(dir); got != "<dir> 2024-01-02 03:04:05" {
+		t.Fatalf("info after clear = %q", got)
+	}
+}
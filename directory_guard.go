@@ -0,0 +1,104 @@
+package main
+
+import (
+	"nmf/internal/config"
+	"nmf/internal/keymanager"
+)
+
+// openDirectoryLocation is a window, and one of its inactive tabs or -1 for
+// the active one, that already shows a directory.
+type openDirectoryLocation struct {
+	manager *FileManager
+	tab     int
+}
+
+// redirectToOpenDirectory handles entering path while another window or tab
+// shows it, as ui.openDuplicates says: ask, switch there, or load it again.
+// It reports whether the load was taken over. Refreshes, tab switches, and a
+// window's first load always load, so every window and tab gets a listing.
+// Each extra listing of a network share costs its own watcher polling the
+// server, which is what switching saves.
+func (fm *FileManager) redirectToOpenDirectory(path string) bool {
+	if fm.config == nil || fm.config.UI.OpenDuplicates == config.OpenDuplicatesOpen {
+		return false
+	}
+	if fm.currentPath == "" || fm.currentPath == path {
+		return false
+	}
+	if tab := fm.pendingTabRestore; tab != nil && canonicalNavigationHistoryPath(tab.path) == path {
+		return false
+	}
+	loc, ok := fm.findOpenDirectory(path)
+	if !ok {
+		return false
+	}
+	debugPrint("FileManager: Directory already open path=%s window=%s tab=%d mode=%s", path, loc.manager.currentPath, loc.tab, fm.config.UI.OpenDuplicates)
+	fm.setPathDisplay(fm.currentPath)
+	if fm.config.UI.OpenDuplicates == config.OpenDuplicatesSwitch {
+		fm.switchToOpenDirectory(loc)
+		return true
+	}
+	fm.showCommandMenu(openDirectoryMenuItems(loc.manager == fm,
+		func() { fm.switchToOpenDirectory(loc) },
+		func() { fm.loadDirectory(path) },
+	))
+	return true
+}
+
+// findOpenDirectory looks for path in the other tabs of this window, then in
+// the other windows. Windows the platform cannot focus, such as on Wayland,
+// are skipped since switching to them would do nothing.
+func (fm *FileManager) findOpenDirectory(path string) (openDirectoryLocation, bool) {
+	if tab := openDirectoryTab(fm.tabs, fm.activeTab, path); tab >= 0 {
+		return openDirectoryLocation{manager: fm, tab: tab}, true
+	}
+	for _, other := range snapshotFileManagerWindows() {
+		if other == fm || windowFocusUnsupported(other.window) {
+			continue
+		}
+		if other.currentPath == path {
+			return openDirectoryLocation{manager: other, tab: -1}, true
+		}
+		if tab := openDirectoryTab(other.tabs, other.activeTab, path); tab >= 0 {
+			return openDirectoryLocation{manager: other, tab: tab}, true
+		}
+	}
+	return openDirectoryLocation{}, false
+}
+
+// openDirectoryTab returns the first inactive tab showing path, or -1.
+func openDirectoryTab(tabs []tabState, active int, path string) int {
+	for i, tab := range tabs {
+		if i != active && tab.path != "" && canonicalNavigationHistoryPath(tab.path) == path {
+			return i
+		}
+	}
+	return -1
+}
+
+func (fm *FileManager) switchToOpenDirectory(loc openDirectoryLocation) {
+	target := loc.manager
+	if loc.tab >= 0 && loc.tab < len(target.tabs) {
+		target.captureActiveTab()
+		target.showTab(loc.tab)
+	}
+	if target != fm {
+		restoreWindowBeforeFocus(target.window)
+		target.window.Show()
+		target.window.RequestFocus()
+	}
+	target.FocusFileList()
+}
+
+// openDirectoryMenuItems offers switching to where the directory is already
+// open or loading it here as well.
+func openDirectoryMenuItems(sameWindow bool, switchTo, open func()) []keymanager.CommandMenuItem {
+	label := "Switch to existing window"
+	if sameWindow {
+		label = "Switch to existing tab"
+	}
+	return []keymanager.CommandMenuItem{
+		{Label: label, Key: "S", Action: switchTo},
+		{Label: "Open here anyway", Key: "O", Action: open},
+	}
+}
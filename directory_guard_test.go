@@ -0,0 +1,33 @@
+package main
+
+import "testing"
+
+func TestOpenDirectoryTabSkipsActiveTab(t *testing.T) {
+	tabs := []tabState{{path: "/work"}, {path: "/srv/share"}, {path: "/work"}}
+
+	if got := openDirectoryTab(tabs, 0, "/work"); got != 2 {
+		t.Fatalf("openDirectoryTab from tab 0 = %d, want 2", got)
+	}
+	if got := openDirectoryTab(tabs, 1, "/srv/share"); got != -1 {
+		t.Fatalf("openDirectoryTab for the active tab's path = %d, want -1", got)
+	}
+	if got := openDirectoryTab(nil, 0, "/work"); got != -1 {
+		t.Fatalf("openDirectoryTab without tabs = %d, want -1", got)
+	}
+}
+
+func TestOpenDirectoryMenuItemsRunTheirChoice(t *testing.T) {
+	var switched, opened int
+	items := openDirectoryMenuItems(false, func() { switched++ }, func() { opened++ })
+	if len(items) != 2 || items[0].Label != "Switch to existing window" || items[0].Key != "S" || items[1].Key != "O" {
+		t.Fatalf("items = %+v", items)
+	}
+	items[0].Action()
+	items[1].Action()
+	if switched != 1 || opened != 1 {
+		t.Fatalf("switched %d opened %d, want 1 and 1", switched, opened)
+	}
+	if got := openDirectoryMenuItems(true, nil, nil)[0].Label; got != "Switch to existing tab" {
+		t.Fatalf("same-window label = %q", got)
+	}
+}
@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -14,9 +13,12 @@ import (
 	"nmf/internal/config"
 	"nmf/internal/fileinfo"
 	"nmf/internal/keymanager"
+	"nmf/internal/navigation"
+	"nmf/internal/watcher"
 )
 
-// SaveCursorPosition saves the current cursor position for the given directory.
+// SaveCursorPosition saves the current cursor position, and in list mode the
+// scroll offset, for the given directory.
 func (fm *FileManager) SaveCursorPosition(dirPath string) {
 	currentIdx := fm.GetCurrentCursorIndex()
 	if currentIdx < 0 || currentIdx >= len(fm.files) {
@@ -35,6 +37,14 @@ func (fm *FileManager) SaveCursorPosition(dirPath string) {
 	// Save the cursor position and update last used time
 	cursorMemory.Entries[dirPath] = fileName
 	cursorMemory.LastUsed[dirPath] = time.Now()
+	if cursorMemory.ScrollOffsets == nil {
+		cursorMemory.ScrollOffsets = make(map[string]float32)
+	}
+	if !fm.thumbnailMode && fm.fileList != nil {
+		cursorMemory.ScrollOffsets[dirPath] = fm.fileList.GetScrollOffset()
+	} else {
+		delete(cursorMemory.ScrollOffsets, dirPath)
+	}
 
 	// Save state to disk
 	if fm.stateManager != nil {
@@ -83,6 +93,7 @@ func (fm *FileManager) cleanupOldCursorEntries() {
 	if oldestPath != "" {
 		delete(cursorMemory.Entries, oldestPath)
 		delete(cursorMemory.LastUsed, oldestPath)
+		delete(cursorMemory.ScrollOffsets, oldestPath)
 	}
 }
 
@@ -149,9 +160,19 @@ func (fm *FileManager) focusFileList(reason string) {
 	debugPrint("FileManager: FocusFileList skipped reason=%s fileListView=nil path=%s", reason, fm.currentPath)
 }
 
+// LoadDirectory shows path in this window unless ui.openDuplicates sends the
+// user to another window or tab already showing it (directory_guard.go).
 func (fm *FileManager) LoadDirectory(path string) {
 	path = canonicalNavigationHistoryPath(path)
+	if fm.redirectToOpenDirectory(path) {
+		return
+	}
+	fm.loadDirectory(path)
+}
 
+// loadDirectory shows path in this window without looking for other windows
+// or tabs showing it.
+func (fm *FileManager) loadDirectory(path string) {
 	// Save current cursor position before changing directory
 	// Skip saving if already saved manually (e.g., during refresh)
 	if fm.currentPath != "" && fm.currentPath != path {
@@ -163,66 +184,150 @@ func (fm *FileManager) LoadDirectory(path string) {
 		fm.dirWatcher.Stop()
 	}
 
-	// Store the previous directory for parent navigation logic
-	previousPath := fm.currentPath
-	debugPrint("FileManager: LoadDirectory start path=%s previous=%s focused=%s active=%t", path, previousPath, focusedObjectLabel(fm.window), fm.windowActive)
-	ctx, loadID := fm.beginDirectoryLoad()
-
-	// Indicate busy and block input while loading
-	fm.beginBusy(fmt.Sprintf("Loading %s...", path), fm.cancelActiveDirectoryLoad)
+	debugPrint("FileManager: LoadDirectory start path=%s previous=%s focused=%s active=%t", path, fm.currentPath, focusedObjectLabel(fm.window), fm.windowActive)
 
 	// Capture the sort config on the UI thread: fm.state is mutated by the
 	// sort dialog on the UI thread, so the background goroutine below must
 	// never read it directly (that would be a data race).
 	sortCfg := fm.state.EffectiveSort(fm.config.UI.Sort)
+	if profile, ok := fm.viewProfileFor(path); ok && profile.Sort != nil {
+		sortCfg = *profile.Sort
+	}
+	if view, ok := fm.state.LookupDirectoryView(fm.vaultCipherPath(path)); ok {
+		sortCfg = view.Sort
+	}
+	fm.gitIgnoreActive = fm.gitIgnoreApplies(path)
+	keep := fm.listingEntryFilter(fm.gitIgnoreActive)
+	if fm.dirWatcher != nil {
+		fm.dirWatcher.SetEntryFilter(keep)
+	}
+
+	// Walking up the chain of directories entered this session shows the
+	// kept listing at once and checks it in the background. Network
+	// directories left a moment ago are shown at once as well, and the
+	// watcher reconciles them.
+	fm.rememberParentListing(path)
+	fm.rememberRemoteListing(path)
+	cached, fromCache := fm.cachedParentListing(path, sortCfg)
+	fm.parentListings.prune(path)
+	remote, fromRemote := fm.cachedRemoteListing(path, sortCfg)
+
+	// Begin cancels any load still in flight and reports EventStarted, which
+	// shows the busy state (see onNavigationEvent).
+	load := fm.navigator.Begin(path, fm.currentPath)
+	if fromCache {
+		fm.showCachedListing(load, cached, keep)
+		return
+	}
+	if fromRemote {
+		fm.showRemoteListing(load, remote)
+		return
+	}
 
 	// Load directory asynchronously to avoid blocking UI (applies to both local and remote paths)
-	go fm.loadDirectoryAsync(ctx, loadID, path, previousPath, sortCfg)
+	fm.loads.Go(func() { fm.loadDirectoryAsync(load, sortCfg, keep) })
+}
+
+// directoryListing is what a load collects off the UI thread before it is
+// applied to the window.
+type directoryListing struct {
+	path         string
+	files        []fileinfo.FileInfo // sorted, ".." first when present
+	storage      fileinfo.StorageInfo
+	storageErr   error
+	note         string
+	sortCfg      config.SortConfig
+	captureDates bool // Capture dates may be read from the files (see readsCaptureDates)
 }
 
 // loadDirectoryAsync lists a path in a background goroutine and applies UI updates on the main thread.
-func (fm *FileManager) loadDirectoryAsync(ctx context.Context, loadID uint64, path string, previousPath string, sortCfg config.SortConfig) {
-	entries, err := fileinfo.ReadDirPortableContext(ctx, path)
+func (fm *FileManager) loadDirectoryAsync(load *navigation.Load, sortCfg config.SortConfig, keep func(fileinfo.FileInfo) bool) {
+	ctx := load.Context()
+	path := load.Path
+	tagView := fileinfo.IsTagViewPath(path)
+	var entries []os.DirEntry
+	var tagged []fileinfo.FileInfo
+	var err error
+	if tagView {
+		tagged, err = fm.listTagView(ctx, path)
+	} else if path, err = fm.unlockVaultPath(ctx, path); err == nil {
+		entries, err = fileinfo.ReadDirPortableContext(ctx, path)
+	}
 	if err != nil {
-		if fm.ignoreCanceledDirectoryLoad(ctx, loadID, err) {
+		if fm.staleDirectoryLoad(load, err) {
 			return
 		}
 		log.Printf("Error reading directory: %v", err)
 		fyne.Do(func() {
-			if !fm.finishDirectoryLoad(loadID) {
-				return
-			}
-			// Clear busy state on error
-			fm.endBusy()
-			fm.ShowMessageDialog("フォルダを開けませんでした", err.Error())
-			// Revert to previous path on error and restart watcher
-			if previousPath != "" {
-				fm.currentPath = previousPath
-				fm.setPathDisplay(previousPath)
-				if fm.dirWatcher != nil && fm.shouldWatchPath(previousPath) {
-					fm.dirWatcher.SetPollInterval(fm.pollIntervalForPath(previousPath))
-					fm.dirWatcher.Start()
-				}
+			if fm.navigator.Claim(load) {
+				fm.navigator.Fail(load, err)
 			}
 		})
 		return
 	}
-	if fm.ignoreCanceledDirectoryLoad(ctx, loadID, nil) {
+	if fm.staleDirectoryLoad(load, nil) {
 		return
 	}
 
 	// Build file list off the UI thread
-	files := make([]fileinfo.FileInfo, 0, len(entries)+1)
-	storage, storageErr := fileinfo.StatStoragePortable(path)
-	if fm.ignoreCanceledDirectoryLoad(ctx, loadID, nil) {
+	listing, ok := fm.newDirectoryListing(load, path, sortCfg, tagView, len(entries)+len(tagged)+1)
+	if !ok {
+		return
+	}
+
+	// Sort off the UI thread using the sort config captured before this
+	// goroutine started (see LoadDirectory).
+	dateTaken := fm.listingDateTaken(listing)
+
+	if len(entries) > streamedLoadThreshold {
+		fm.streamDirectoryLoad(load, listing, entries, keep, dateTaken)
 		return
 	}
-	if storageErr != nil {
-		debugPrint("FileManager: Storage info unavailable for %s: %v", path, storageErr)
+
+	if tagView {
+		listing.files = sortFileInfoSliceWithDates(append(listing.files, tagged...), sortCfg, dateTaken)
+	} else if !fm.fillDirectoryListing(load, &listing, entries, keep, dateTaken) {
+		return
+	}
+	if fm.staleDirectoryLoad(load, nil) {
+		return
+	}
+
+	// Apply UI updates on main thread
+	fyne.Do(func() {
+		if !fm.navigator.Claim(load) {
+			return
+		}
+		fm.applyDirectoryListing(load, listing, true)
+		// History, busy state, and the watcher follow in onNavigationEvent.
+		fm.navigator.Complete(load)
+	})
+}
+
+// newDirectoryListing starts the listing of path with its storage details,
+// directory note, and ".." entry when path has a parent. It reports false
+// when load went stale on the way.
+func (fm *FileManager) newDirectoryListing(load *navigation.Load, path string, sortCfg config.SortConfig, tagView bool, capacity int) (directoryListing, bool) {
+	listing := directoryListing{path: path, sortCfg: sortCfg, storageErr: fileinfo.ErrTagView}
+	if !tagView {
+		listing.storage, listing.storageErr = fileinfo.StatStoragePortable(path)
+		if fm.staleDirectoryLoad(load, nil) {
+			return listing, false
+		}
+		if listing.storageErr != nil {
+			debugPrint("FileManager: Storage info unavailable for %s: %v", path, listing.storageErr)
+		}
+		var noteErr error
+		listing.note, noteErr = fileinfo.ReadDirNote(path)
+		if noteErr != nil {
+			debugPrint("FileManager: Directory note unavailable for %s: %v", path, noteErr)
+		}
+		listing.captureDates = readsCaptureDates(path)
 	}
 
 	// Add parent directory entry if not at root
-	parent := fileinfo.ParentPath(path)
+	listing.files = make([]fileinfo.FileInfo, 0, capacity)
+	parent := fileinfo.ParentPath(fm.vaultCipherPath(path))
 	if parent != path {
 		parentInfo := fileinfo.FileInfo{
 			Name:     "..",
@@ -233,209 +338,224 @@ func (fm *FileManager) loadDirectoryAsync(ctx context.Context, loadID uint64, pa
 			FileType: fileinfo.FileTypeDirectory,
 			Status:   fileinfo.StatusNormal,
 		}
-		files = append(files, parentInfo)
+		listing.files = append(listing.files, parentInfo)
 	}
+	return listing, true
+}
 
-	for _, entry := range entries {
-		if fm.ignoreCanceledDirectoryLoad(ctx, loadID, nil) {
-			return
-		}
-		fi, err := fileinfo.FileInfoFromDirEntry(path, entry)
-		if err != nil {
-			continue
-		}
-		files = append(files, fi)
+// listingDateTaken returns the capture date lookup sorting listing by its
+// sort config needs, or nil.
+func (fm *FileManager) listingDateTaken(listing directoryListing) func(fileinfo.FileInfo) time.Time {
+	if usesDateTaken(listing.sortCfg) {
+		return fm.dateTakenResolver(listing.captureDates, true)
 	}
+	return nil
+}
 
-	// Sort off the UI thread using the sort config captured before this
-	// goroutine started (see LoadDirectory).
-	files = sortFileInfoSlice(files, sortCfg)
-	if fm.ignoreCanceledDirectoryLoad(ctx, loadID, nil) {
-		return
+// fillDirectoryListing stats entries into listing after its ".." entry,
+// loads their color tags, and sorts the result. It reports false when the
+// load went stale on the way.
+func (fm *FileManager) fillDirectoryListing(load *navigation.Load, listing *directoryListing, entries []os.DirEntry, keep func(fileinfo.FileInfo) bool, dateTaken func(fileinfo.FileInfo) time.Time) bool {
+	chunk, ok := fm.directoryChunk(load, listing.path, entries, keep)
+	if !ok {
+		return false
 	}
+	files := append(listing.files, chunk...)
+	if err := fileinfo.LoadColorTags(listing.path, files); err != nil {
+		debugPrint("FileManager: Color tags unavailable for %s: %v", listing.path, err)
+	} else if index := fm.tagIndex(); index != nil {
+		index.UpdateDirectory(listing.path, files)
+	}
+	listing.files = sortFileInfoSliceWithDates(files, listing.sortCfg, dateTaken)
+	return true
+}
 
-	originalFiles := make([]fileinfo.FileInfo, len(files))
-	copy(originalFiles, files)
-
-	// Apply UI updates on main thread
-	fyne.Do(func() {
-		if !fm.finishDirectoryLoad(loadID) {
-			return
-		}
-		// Stop existing watcher (if any) before applying
-		if fm.dirWatcher != nil {
-			fm.dirWatcher.Stop()
-		}
-
-		// Add previous path to navigation history before changing directory
-		if previousPath != "" && previousPath != path {
-			fm.recordNavigationHistory(previousPath)
-		}
-
-		fm.currentPath = path
-		fm.setPathDisplay(path)
-		fm.files = files
-		fm.originalFiles = originalFiles
-		fm.storageInfo = storage
-		fm.storageKnown = storageErr == nil
-		fm.activeSort = sortCfg
-
-		// files/originalFiles arrive pre-sorted from the background goroutine
-		// above; no sort call needed here.
-
-		// Clear selections and restore cursor
-		fm.selectedFiles = make(map[string]bool)
-		if len(fm.files) > 0 {
-			parentPrev := fileinfo.ParentPath(previousPath)
-			if parentPrev == path && previousPath != "" {
-				dirName := fileinfo.BaseName(previousPath)
-				cursorSet := false
-				for i, f := range fm.files {
-					if f.Name == dirName {
-						fm.SetCursorByIndex(i)
-						cursorSet = true
-						break
-					}
-				}
-				if !cursorSet {
-					fm.SetCursorByIndex(0)
-				}
-			} else {
-				saved := fm.restoreCursorPosition(path)
-				cursorSet := false
-				if saved != "" {
-					for i, f := range fm.files {
-						if f.Name == saved {
-							fm.SetCursorByIndex(i)
-							cursorSet = true
-							break
-						}
-					}
-				}
-				if !cursorSet {
-					fm.SetCursorByIndex(0)
-				}
-			}
-		} else {
-			fm.cursorPath = ""
-		}
-		// Content was replaced: refresh before the cursor scroll (see
-		// refreshListAndCursor) and re-query the list length even when empty.
-		fm.refreshListAndCursor()
-		fm.updateStatusBar()
-
-		// Hide busy only now that list state and cursor are rendered-ready,
-		// so input stays blocked until the new listing is actually usable.
-		fm.endBusy()
-
-		// Restart watcher with appropriate interval when the provider can be watched.
-		if fm.dirWatcher != nil && fm.shouldWatchPath(path) {
-			fm.dirWatcher.SetPollInterval(fm.pollIntervalForPath(path))
-			fm.dirWatcher.Start()
+// directoryChunk stats entries, up to statWorkers at a time, and builds
+// their FileInfos, dropping the ones keep rejects. It reports false when the
+// load went stale on the way.
+func (fm *FileManager) directoryChunk(load *navigation.Load, path string, entries []os.DirEntry, keep func(fileinfo.FileInfo) bool) ([]fileinfo.FileInfo, bool) {
+	statted, ok, err := fileinfo.StatDirEntries(load.Context(), path, entries, fm.statWorkers())
+	if fm.staleDirectoryLoad(load, err) {
+		return nil, false
+	}
+	files := make([]fileinfo.FileInfo, 0, len(entries))
+	for i, fi := range statted {
+		if ok[i] && (keep == nil || keep(fi)) {
+			files = append(files, fi)
 		}
-		fm.focusFileList("directory-load-success")
-		debugPrint("FileManager: LoadDirectory done path=%s previous=%s files=%d cursor=%s index=%d focused=%s active=%t", path, previousPath, len(fm.files), fm.cursorPath, fm.GetCurrentCursorIndex(), focusedObjectLabel(fm.window), fm.windowActive)
-	})
+	}
+	return files, true
 }
 
-func (fm *FileManager) beginDirectoryLoad() (context.Context, uint64) {
-	ctx, cancel := context.WithCancel(context.Background())
-	fm.loadMu.Lock()
-	if fm.loadCancel != nil {
-		fm.loadCancel()
-	}
-	fm.nextLoadID++
-	loadID := fm.nextLoadID
-	fm.activeLoadID = loadID
-	fm.loadCancel = cancel
-	fm.loadMu.Unlock()
-	return ctx, loadID
+// statWorkers returns how many entries a load stats at once.
+func (fm *FileManager) statWorkers() int {
+	if fm.config == nil || fm.config.UI.StatWorkers < 1 {
+		return fileinfo.DefaultStatWorkers
+	}
+	return fm.config.UI.StatWorkers
 }
 
-func (fm *FileManager) finishDirectoryLoad(loadID uint64) bool {
-	fm.loadMu.Lock()
-	defer fm.loadMu.Unlock()
-	if fm.activeLoadID != loadID {
-		return false
+// applyDirectoryListing switches the window to a loaded listing and places
+// the cursor. complete is false for the first page of a streamed load, whose
+// remembered scroll offset would point past the entries shown so far.
+func (fm *FileManager) applyDirectoryListing(load *navigation.Load, listing directoryListing, complete bool) {
+	path := listing.path
+	previousPath := load.Previous
+	// Stop existing watcher (if any) before applying
+	if fm.dirWatcher != nil {
+		fm.dirWatcher.Stop()
 	}
-	fm.activeLoadID = 0
-	fm.loadCancel = nil
-	return true
-}
 
-func (fm *FileManager) directoryLoadActive(loadID uint64) bool {
-	fm.loadMu.Lock()
-	defer fm.loadMu.Unlock()
-	return fm.activeLoadID == loadID
+	if path != previousPath {
+		fm.clearDirectorySizes()
+	}
+	fm.currentPath = path
+	fm.setPathDisplay(path)
+	fm.files = listing.files
+	fm.originalFiles = make([]fileinfo.FileInfo, len(listing.files))
+	copy(fm.originalFiles, listing.files)
+	fm.storageInfo = listing.storage
+	fm.storageKnown = listing.storageErr == nil
+	fm.dirNote = listing.note
+	fm.loadedAt = time.Now()
+	fm.captureDates = listing.captureDates
+	fm.setActiveSort(listing.sortCfg)
+	fm.applyViewProfile(fm.viewProfileFor(fm.vaultCipherPath(path)))
+
+	// Clear selections and restore cursor
+	fm.selection.Clear()
+	fm.reweighMarks()
+	restoreOffset := float32(-1)
+	if len(fm.files) > 0 {
+		want, remembered := fm.initialCursorName(path, previousPath)
+		if !fm.setCursorByName(want) {
+			fm.SetCursorByIndex(0)
+		} else if offset, ok := fm.state.CursorMemory.ScrollOffsets[path]; ok && remembered && complete {
+			restoreOffset = offset
+		}
+	} else {
+		fm.cursorPath = ""
+	}
+	fm.applyPendingTabRestore(path)
+	if fm.activeTab < len(fm.tabs) {
+		fm.tabs[fm.activeTab].path = path
+	}
+	fm.updateTabBar()
+	// Content was replaced: refresh before the cursor scroll (see
+	// refreshListAndCursor) and re-query the list length even when empty.
+	fm.refreshListAndCursor()
+	if restoreOffset >= 0 {
+		fm.restoreListScrollOffset(restoreOffset)
+	}
+	fm.updateStatusBar()
 }
 
-func (fm *FileManager) ignoreCanceledDirectoryLoad(ctx context.Context, loadID uint64, err error) bool {
-	if ctx != nil && ctx.Err() != nil {
-		debugPrint("FileManager: LoadDirectory canceled id=%d err=%v", loadID, ctx.Err())
-		return true
+// initialCursorName returns the entry the cursor should land on after
+// loading path: the directory just left when going up, otherwise the entry
+// remembered for path. remembered is true in the second case.
+func (fm *FileManager) initialCursorName(path, previousPath string) (name string, remembered bool) {
+	previousDir := fm.vaultCipherPath(previousPath)
+	if previousPath != "" && fileinfo.ParentPath(previousDir) == path {
+		return fileinfo.BaseName(previousDir), false
 	}
-	if err != nil && errors.Is(err, context.Canceled) {
-		debugPrint("FileManager: LoadDirectory canceled id=%d err=%v", loadID, err)
-		return true
+	return fm.restoreCursorPosition(path), true
+}
+
+// setCursorByName moves the cursor to the visible entry called name.
+func (fm *FileManager) setCursorByName(name string) bool {
+	if name == "" {
+		return false
 	}
-	if !fm.directoryLoadActive(loadID) {
-		debugPrint("FileManager: LoadDirectory stale id=%d path=%s", loadID, fm.currentPath)
-		return true
+	for i, f := range fm.files {
+		if f.Name == name {
+			fm.SetCursorByIndex(i)
+			return true
+		}
 	}
 	return false
 }
 
-func (fm *FileManager) cancelDirectoryLoad(loadID uint64) {
-	var cancel context.CancelFunc
-	fm.loadMu.Lock()
-	if fm.activeLoadID == 0 || fm.activeLoadID != loadID {
-		fm.loadMu.Unlock()
-		return
+// onNavigationEvent keeps the window in step with its directory loads. It
+// runs on the UI goroutine for every navigator transition.
+func (fm *FileManager) onNavigationEvent(ev navigation.Event) {
+	load := ev.Load
+	switch ev.Kind {
+	case navigation.EventStarted:
+		// Indicate busy and block input while loading
+		fm.beginBusy(fmt.Sprintf("Loading %s...", load.Path), fm.cancelActiveDirectoryLoad)
+	case navigation.EventCompleted:
+		// Add previous path to navigation history now that the change stuck
+		if load.Previous != "" && load.Previous != load.Path {
+			fm.recordNavigationHistory(load.Previous)
+		}
+		// Hide busy only now that list state and cursor are rendered-ready,
+		// so input stays blocked until the new listing is actually usable.
+		fm.endBusyAndReplay()
+		if !load.Streaming {
+			fm.restartWatcher()
+		}
+		fm.focusFileList("directory-load-success")
+		debugPrint("FileManager: LoadDirectory done path=%s previous=%s files=%d cursor=%s index=%d focused=%s active=%t", load.Path, load.Previous, len(fm.files), fm.cursorPath, fm.GetCurrentCursorIndex(), focusedObjectLabel(fm.window), fm.windowActive)
+	case navigation.EventFailed:
+		// Clear busy state on error
+		fm.endBusy()
+		fm.pendingTabRestore = nil
+		fm.ShowMessageDialog("フォルダを開けませんでした", ev.Err.Error())
+		// Revert to previous path on error and restart watcher
+		if load.Previous != "" {
+			fm.currentPath = load.Previous
+			fm.setPathDisplay(load.Previous)
+			fm.restartWatcher()
+		}
+	case navigation.EventCanceled:
+		fm.endBusy()
+		fm.restartWatcher()
+		fm.focusFileList("directory-load-cancel")
+		debugPrint("FileManager: LoadDirectory cancel id=%d path=%s", load.ID, fm.currentPath)
+	case navigation.EventRestLoaded:
+		// The watcher's baseline is the listing, so it starts only once the
+		// listing is whole.
+		fm.restartWatcher()
+		debugPrint("FileManager: LoadDirectory streamed rest path=%s files=%d", load.Path, len(fm.originalFiles))
 	}
-	cancel = fm.loadCancel
-	fm.activeLoadID = 0
-	fm.loadCancel = nil
-	fm.loadMu.Unlock()
+}
 
-	if cancel != nil {
-		cancel()
+// restartWatcher starts the directory watcher for the current path with its
+// poll interval, when the path can be watched. Directories that cannot be,
+// such as those on an MTP phone, are flagged in the status line instead.
+func (fm *FileManager) restartWatcher() {
+	watch := fm.shouldWatchPath(fm.currentPath)
+	unwatched := !watch && !fm.manualRefresh && !fileinfo.IsArchivePath(fm.currentPath) && !fileinfo.IsTagViewPath(fm.currentPath)
+	if unwatched != fm.unwatched {
+		fm.unwatched = unwatched
+		fm.updateStatusBar()
 	}
-	fm.endBusy()
-	if fm.dirWatcher != nil && fm.shouldWatchPath(fm.currentPath) {
-		fm.dirWatcher.SetPollInterval(fm.pollIntervalForPath(fm.currentPath))
+	if fm.dirWatcher != nil && watch {
+		fm.dirWatcher.SetPollInterval(watcher.PollInterval(fm.currentPath))
 		fm.dirWatcher.Start()
 	}
-	fm.focusFileList("directory-load-cancel")
-	debugPrint("FileManager: LoadDirectory cancel id=%d path=%s", loadID, fm.currentPath)
 }
 
-func (fm *FileManager) cancelActiveDirectoryLoad() {
-	fm.loadMu.Lock()
-	loadID := fm.activeLoadID
-	fm.loadMu.Unlock()
-	if loadID != 0 {
-		fm.cancelDirectoryLoad(loadID)
+// staleDirectoryLoad reports whether the background part of load should stop
+// because it was canceled or superseded.
+func (fm *FileManager) staleDirectoryLoad(load *navigation.Load, err error) bool {
+	if !fm.navigator.Stale(load, err) {
+		return false
 	}
+	debugPrint("FileManager: LoadDirectory stale or canceled id=%d path=%s err=%v", load.ID, load.Path, err)
+	return true
 }
 
-// invalidateActiveDirectoryLoad cancels the current load without restarting
-// window-owned UI or watcher state. It is used while the window is closing.
-func (fm *FileManager) invalidateActiveDirectoryLoad() {
-	var cancel context.CancelFunc
-	fm.loadMu.Lock()
-	cancel = fm.loadCancel
-	fm.activeLoadID = 0
-	fm.loadCancel = nil
-	fm.loadMu.Unlock()
-
-	if cancel != nil {
-		cancel()
-	}
+func (fm *FileManager) cancelActiveDirectoryLoad() {
+	fm.navigator.CancelActive()
 }
 
-// beginBusy shows the busy overlay and pushes a swallowing key handler.
+// beginBusy shows the busy overlay and pushes a swallowing key handler. The
+// status bar says so at once; the overlay follows after busyDelay, or as soon
+// as a key is swallowed, so ignored input is never left unexplained.
 func (fm *FileManager) beginBusy(text string, onCancel ...func()) {
 	fm.busyMu.Lock()
+	defer fm.updateStatusBar()
 	defer fm.busyMu.Unlock()
 
 	if fm.busyActive {
@@ -455,7 +575,9 @@ func (fm *FileManager) beginBusy(text string, onCancel ...func()) {
 	if len(onCancel) > 0 {
 		cancel = onCancel[0]
 	}
-	fm.busyToken = fm.keyManager.PushHandler(keymanager.NewBusyKeyHandler(cancel))
+	fm.busyHandler = keymanager.NewBusyKeyHandler(cancel)
+	fm.busyHandler.SetOnSwallowed(fm.showBusyOverlayNow)
+	fm.busyToken = fm.keyManager.PushHandler(fm.busyHandler)
 
 	// Delay overlay to prevent flicker on very fast operations
 	if fm.busyTimer != nil {
@@ -475,7 +597,26 @@ func (fm *FileManager) beginBusy(text string, onCancel ...func()) {
 	})
 }
 
-// endBusy hides the busy overlay and pops the swallowing key handler.
+// showBusyOverlayNow shows the overlay without waiting for busyDelay. It runs
+// on the UI thread from the busy key handler.
+func (fm *FileManager) showBusyOverlayNow() {
+	fm.busyMu.Lock()
+	active := fm.busyActive
+	text := fm.busyText
+	if fm.busyTimer != nil {
+		fm.busyTimer.Stop()
+		fm.busyTimer = nil
+	}
+	fm.busyMu.Unlock()
+	if active && fm.busyOverlay != nil {
+		debugPrint("FileManager: busy input swallowed; overlay shown early text=%q", text)
+		fm.busyOverlay.Show(fm.window, text)
+	}
+}
+
+// endBusy hides the busy overlay and pops the swallowing key handler. A key
+// queued while busy is dropped; callers that finished successfully use
+// endBusyAndReplay instead.
 func (fm *FileManager) endBusy() {
 	fm.busyMu.Lock()
 	if !fm.busyActive {
@@ -489,6 +630,7 @@ func (fm *FileManager) endBusy() {
 	}
 	busyToken := fm.busyToken
 	fm.busyToken = 0
+	fm.busyHandler = nil
 	fm.busyMu.Unlock()
 
 	// Hide overlay (if visible) and remove guard
@@ -498,29 +640,96 @@ func (fm *FileManager) endBusy() {
 	if fm.keyManager != nil && busyToken != 0 {
 		fm.keyManager.RemoveHandler(busyToken)
 	}
+	fm.updateStatusBar()
 	debugPrint("FileManager: busy end focused=%s active=%t path=%s", focusedObjectLabel(fm.window), fm.windowActive, fm.currentPath)
 }
 
-// pollIntervalForPath returns the recommended watcher polling interval for a path.
-// Remote (SMB) paths get a longer interval to reduce load/latency impact.
-func (fm *FileManager) pollIntervalForPath(p string) time.Duration {
-	if fileinfo.IsArchivePath(p) {
-		return 0
+// endBusyAndReplay ends the busy state and replays the first navigation key
+// pressed during it, as if it had arrived after the operation finished.
+func (fm *FileManager) endBusyAndReplay() {
+	fm.busyMu.Lock()
+	handler := fm.busyHandler
+	fm.busyMu.Unlock()
+	fm.endBusy()
+	if handler == nil || fm.keyManager == nil {
+		return
 	}
-	if strings.HasPrefix(strings.ToLower(p), "smb://") {
-		return 4 * time.Second
+	if ev, modifiers, ok := handler.QueuedKey(); ok {
+		debugPrint("FileManager: busy replay key=%s", ev.Name)
+		fm.keyManager.HandleShortcutKey(ev, modifiers)
 	}
-	return 2 * time.Second
 }
 
+// listTagView lists a tag:// view from the shared tag index.
+func (fm *FileManager) listTagView(ctx context.Context, path string) ([]fileinfo.FileInfo, error) {
+	index := fm.tagIndex()
+	if index == nil {
+		return nil, fileinfo.ErrTagView
+	}
+	return index.List(ctx, path)
+}
+
+// ToggleAutoRefresh turns the directory watcher off or on for this window.
+// Turning it back on reloads the directory, since the listing may have gone
+// stale while nothing was watching it.
+func (fm *FileManager) ToggleAutoRefresh() {
+	fm.manualRefresh = !fm.manualRefresh
+	debugPrint("FileManager: Auto-refresh enabled=%t path=%s", !fm.manualRefresh, fm.currentPath)
+	if !fm.manualRefresh {
+		fm.LoadDirectory(fm.currentPath)
+		return
+	}
+	if fm.dirWatcher != nil {
+		fm.dirWatcher.Stop()
+	}
+	fm.updateStatusBar()
+}
+
+// ToggleHiddenFiles shows or hides dotfiles and Windows hidden entries, saves
+// the choice to state.json for new windows, and reloads the directory.
+func (fm *FileManager) ToggleHiddenFiles() {
+	fm.showHidden = !fm.showHidden
+	debugPrint("FileManager: Show hidden files=%t path=%s", fm.showHidden, fm.currentPath)
+	show := fm.showHidden
+	fm.state.ShowHiddenFiles = &show
+	if fm.stateManager != nil {
+		if err := fm.stateManager.SaveAsync(fm.state); err != nil {
+			debugPrint("FileManager: Error saving hidden file toggle: %v", err)
+		}
+	}
+	if fm.dirWatcher != nil {
+		fm.dirWatcher.SetEntryFilter(hiddenEntryFilter(fm.showHidden))
+	}
+	fm.SaveCursorPosition(fm.currentPath)
+	fm.LoadDirectory(fm.currentPath)
+}
+
+// hiddenEntryFilter returns the listing filter for the hidden-file setting:
+// nil when hidden entries are shown, otherwise one that drops them.
+func hiddenEntryFilter(showHidden bool) func(fileinfo.FileInfo) bool {
+	if showHidden {
+		return nil
+	}
+	return func(file fileinfo.FileInfo) bool { return !fileinfo.IsHidden(file) }
+}
+
+// shouldWatchPath reports whether the directory watcher runs for p. It never
+// does while auto-refresh is off for the window. SMB directories without a
+// kernel mount have no native events, but the hub polls them through the
+// same portable listing the loader uses. Devices such as MTP phones are left
+// to manual refresh, even through a gvfsd-fuse path: their changes raise no
+// events and every poll would list the device again.
 func (fm *FileManager) shouldWatchPath(p string) bool {
-	if fileinfo.IsArchivePath(p) {
+	if fm.manualRefresh || fileinfo.IsArchivePath(p) {
+		return false
+	}
+	if class, err := fileinfo.ClassifyPath(p); err == nil && class.Device {
 		return false
 	}
-	vfs, _, err := fileinfo.ResolveRead(p)
+	vfs, parsed, err := fileinfo.ResolveRead(p)
 	if err != nil {
 		return false
 	}
 	defer fileinfo.CloseVFS(vfs)
-	return vfs.Capabilities().Watch
+	return vfs.Capabilities().Watch || parsed.Scheme == fileinfo.SchemeSMB
 }
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"os"
+	"slices"
+	"time"
+
+	"fyne.io/fyne/v2"
+
+	"nmf/internal/config"
+	"nmf/internal/fileinfo"
+	"nmf/internal/navigation"
+)
+
+const (
+	// streamedLoadThreshold is the entry count above which a directory is
+	// shown page by page instead of after the whole listing is built.
+	streamedLoadThreshold = 5000
+	// streamedLoadChunk is how many entries each streamed step stats and
+	// sorts. The first chunk is the first page.
+	streamedLoadChunk = 2000
+	// streamedLoadRefresh throttles list refreshes while chunks merge in.
+	streamedLoadRefresh = 250 * time.Millisecond
+)
+
+// streamDirectoryLoad shows a large directory before all its entries are
+// statted. The first chunk is sorted and applied like a normal load, which
+// ends the busy state; later chunks are sorted on their own and merged into
+// the listing, with the list refreshed at most every streamedLoadRefresh.
+// listing.files holds the ".." entry, if any, on entry.
+//
+// The load stays cancelable until the last chunk is applied, so navigating
+// away stops the remaining stat calls. The watcher starts only then, since
+// its baseline must be the whole listing. Sorts by name or extension take
+// fillPendingDirectoryLoad instead, which shows every entry at once.
+func (fm *FileManager) streamDirectoryLoad(load *navigation.Load, listing directoryListing, entries []os.DirEntry, keep func(fileinfo.FileInfo) bool, dateTaken func(fileinfo.FileInfo) time.Time) {
+	if !sortNeedsMetadata(listing.sortCfg) {
+		fm.fillPendingDirectoryLoad(load, listing, entries, keep)
+		return
+	}
+	path := listing.path
+	sortCfg := listing.sortCfg
+	sortedChunk := func(entries []os.DirEntry, extra []fileinfo.FileInfo) ([]fileinfo.FileInfo, bool) {
+		chunk, ok := fm.directoryChunk(load, path, entries, keep)
+		if !ok {
+			return nil, false
+		}
+		chunk = append(extra, chunk...)
+		if err := fileinfo.LoadColorTags(path, chunk); err != nil {
+			debugPrint("FileManager: Color tags unavailable for %s: %v", path, err)
+		}
+		return sortFileInfoSliceWithDates(chunk, sortCfg, dateTaken), true
+	}
+
+	first, ok := sortedChunk(entries[:streamedLoadChunk], listing.files)
+	if !ok {
+		return
+	}
+	listing.files = first
+	if fm.staleDirectoryLoad(load, nil) {
+		return
+	}
+	debugPrint("FileManager: LoadDirectory streaming path=%s entries=%d first=%d", path, len(entries), len(first))
+
+	// autoCursor is where the first page left the cursor when the entry it
+	// wanted was not listed yet; once that entry arrives, the cursor moves
+	// to it unless the user moved it meanwhile.
+	var want, autoCursor string
+	fyne.Do(func() {
+		if !fm.navigator.ClaimFirstPage(load) {
+			return
+		}
+		fm.applyDirectoryListing(load, listing, false)
+		want, _ = fm.initialCursorName(path, load.Previous)
+		if want != "" && (fm.GetCurrentCursorIndex() < 0 || fm.files[fm.GetCurrentCursorIndex()].Name != want) {
+			autoCursor = fm.cursorPath
+		} else {
+			want = ""
+		}
+		fm.navigator.Complete(load)
+	})
+
+	all := first
+	lastRefresh := time.Now()
+	for start := streamedLoadChunk; start < len(entries); start += streamedLoadChunk {
+		end := min(start+streamedLoadChunk, len(entries))
+		chunk, ok := sortedChunk(entries[start:end], nil)
+		if !ok {
+			return
+		}
+		all = mergeSortedFiles(all, chunk, sortCfg, dateTaken)
+		if end == len(entries) || time.Since(lastRefresh) < streamedLoadRefresh {
+			continue
+		}
+		lastRefresh = time.Now()
+		merged := all
+		fyne.Do(func() {
+			if fm.navigator.Stale(load, nil) {
+				return
+			}
+			fm.applyStreamedFiles(merged, &want, autoCursor)
+		})
+	}
+	if index := fm.tagIndex(); index != nil {
+		index.UpdateDirectory(path, all)
+	}
+	if fm.staleDirectoryLoad(load, nil) {
+		return
+	}
+
+	fyne.Do(func() {
+		if !fm.navigator.ClaimRest(load) {
+			return
+		}
+		fm.applyStreamedFiles(all, &want, autoCursor)
+		fm.navigator.CompleteRest(load)
+	})
+}
+
+// fillPendingDirectoryLoad is streamDirectoryLoad for sorts that need only
+// names. Every entry is shown at once from the directory listing alone, as a
+// pending entry without size or date, and the stats fill them in chunk by
+// chunk. Entries that vanish or that keep rejects once statted are dropped,
+// and the listing is sorted again at the end, since a symlink turns out to
+// be a directory only when statted.
+func (fm *FileManager) fillPendingDirectoryLoad(load *navigation.Load, listing directoryListing, entries []os.DirEntry, keep func(fileinfo.FileInfo) bool) {
+	path := listing.path
+	all := listing.files
+	for _, entry := range entries {
+		fi := fileinfo.PendingFileInfo(path, entry)
+		if keep == nil || keep(fi) {
+			all = append(all, fi)
+		}
+	}
+	all = sortFileInfoSliceWithDates(all, listing.sortCfg, nil)
+	index := make(map[string]int, len(all))
+	for i, fi := range all {
+		index[fi.Path] = i
+	}
+	listing.files = slices.Clone(all)
+	if fm.staleDirectoryLoad(load, nil) {
+		return
+	}
+	debugPrint("FileManager: LoadDirectory pending path=%s entries=%d", path, len(entries))
+
+	fyne.Do(func() {
+		if !fm.navigator.ClaimFirstPage(load) {
+			return
+		}
+		fm.applyDirectoryListing(load, listing, true)
+		fm.navigator.Complete(load)
+	})
+
+	var dropped map[string]bool
+	lastRefresh := time.Now()
+	for start := 0; start < len(entries); start += streamedLoadChunk {
+		end := min(start+streamedLoadChunk, len(entries))
+		statted, ok, err := fileinfo.StatDirEntries(load.Context(), path, entries[start:end], fm.statWorkers())
+		if fm.staleDirectoryLoad(load, err) {
+			return
+		}
+		if err := fileinfo.LoadColorTags(path, statted); err != nil {
+			debugPrint("FileManager: Color tags unavailable for %s: %v", path, err)
+		}
+		for i, fi := range statted {
+			pendingPath := fileinfo.JoinPath(path, entries[start+i].Name())
+			at, listed := index[pendingPath]
+			if !listed {
+				continue
+			}
+			if !ok[i] || (keep != nil && !keep(fi)) {
+				if dropped == nil {
+					dropped = make(map[string]bool)
+				}
+				dropped[pendingPath] = true
+				continue
+			}
+			all[at] = fi
+		}
+		if end == len(entries) || time.Since(lastRefresh) < streamedLoadRefresh {
+			continue
+		}
+		lastRefresh = time.Now()
+		snapshot := slices.Clone(all)
+		fyne.Do(func() {
+			if !fm.navigator.Stale(load, nil) {
+				fm.updateFiles(snapshot, false)
+			}
+		})
+	}
+	if len(dropped) > 0 {
+		all = slices.DeleteFunc(all, func(fi fileinfo.FileInfo) bool { return dropped[fi.Path] })
+	}
+	all = sortFileInfoSliceWithDates(all, listing.sortCfg, nil)
+	if index := fm.tagIndex(); index != nil {
+		index.UpdateDirectory(path, all)
+	}
+	if fm.staleDirectoryLoad(load, nil) {
+		return
+	}
+
+	fyne.Do(func() {
+		if !fm.navigator.ClaimRest(load) {
+			return
+		}
+		fm.updateFiles(all, false)
+		fm.navigator.CompleteRest(load)
+	})
+}
+
+// sortNeedsMetadata reports whether sorting by cfg needs stat results, so
+// entries cannot be placed from the directory listing alone.
+func sortNeedsMetadata(cfg config.SortConfig) bool {
+	if cfg.GroupBy != "" {
+		return true
+	}
+	switch cfg.SortBy {
+	case "name", "extension", "":
+		return false
+	}
+	return true
+}
+
+// applyStreamedFiles shows the entries a streamed load has merged so far.
+// The cursor keeps its entry; when it is still where the first page put it
+// and the wanted entry has arrived, it moves there.
+func (fm *FileManager) applyStreamedFiles(files []fileinfo.FileInfo, want *string, autoCursor string) {
+	fm.updateFiles(files, false)
+	if *want == "" || fm.cursorPath != autoCursor {
+		return
+	}
+	if fm.setCursorByName(*want) {
+		*want = ""
+		fm.refreshListAndCursor()
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+
+	"nmf/internal/fileinfo"
+)
+
+// diskUsageText formats the bottom bar's disk indicator for the file system
+// holding the current directory and reports whether its free space is below
+// warnPercent of the total.
+func diskUsageText(info fileinfo.StorageInfo, known bool, warnPercent int) (string, bool) {
+	if !known || info.Total == 0 {
+		return "Disk: -", false
+	}
+	percent := float64(info.Free) * 100 / float64(info.Total)
+	text := fmt.Sprintf("Disk: %s free of %s (%.0f%%)",
+		fileinfo.FormatFileSize(int64(info.Free)), fileinfo.FormatFileSize(int64(info.Total)), percent)
+	return text, percent < float64(warnPercent)
+}
+
+func (fm *FileManager) updateDiskUsageBar() {
+	if fm.diskUsageBar == nil {
+		return
+	}
+	text, low := diskUsageText(fm.storageInfo, fm.storageKnown, fm.config.UI.DiskUsage.WarnPercent)
+	if low {
+		fm.diskUsageBar.Importance = widget.DangerImportance
+	} else {
+		fm.diskUsageBar.Importance = widget.MediumImportance
+	}
+	fm.diskUsageBar.SetText(text)
+}
+
+// startDiskUsageRefresh re-reads the current file system's usage every
+// diskUsage.refreshSeconds, so copies running elsewhere show up without
+// navigating. Directory loads refresh it as well.
+func (fm *FileManager) startDiskUsageRefresh() {
+	seconds := fm.config.UI.DiskUsage.RefreshSeconds
+	if seconds <= 0 {
+		return
+	}
+	fm.diskUsageStop = make(chan struct{})
+	go func(stop <-chan struct{}) {
+		ticker := time.NewTicker(time.Duration(seconds) * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				fyne.Do(fm.refreshDiskUsage)
+			case <-stop:
+				return
+			}
+		}
+	}(fm.diskUsageStop)
+}
+
+func (fm *FileManager) stopDiskUsageRefresh() {
+	if fm.diskUsageStop != nil {
+		close(fm.diskUsageStop)
+		fm.diskUsageStop = nil
+	}
+}
+
+// refreshDiskUsage stats the current directory's file system off the UI
+// goroutine. Views whose storage is unknown, such as tag views and archives,
+// are skipped, and a slow SMB stat is never queued twice.
+func (fm *FileManager) refreshDiskUsage() {
+	if fm.isWindowClosed() || !fm.storageKnown || !fm.diskUsagePending.CompareAndSwap(false, true) {
+		return
+	}
+	path := fm.currentPath
+	go func() {
+		info, err := fileinfo.StatStoragePortable(path)
+		fyne.Do(func() {
+			fm.diskUsagePending.Store(false)
+			if err != nil {
+				debugPrint("FileManager: Disk usage refresh failed path=%s err=%v", path, err)
+				return
+			}
+			if fm.isWindowClosed() || fm.currentPath != path {
+				return
+			}
+			fm.storageInfo = info
+			fm.updateStatusBar()
+		})
+	}()
+}
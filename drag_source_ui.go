@@ -82,10 +82,7 @@ func (fm *FileManager) collectDragSourcePaths(dragged fileinfo.FileInfo) ([]stri
 
 func (fm *FileManager) selectedDragCandidates() []fileinfo.FileInfo {
 	selected := make([]fileinfo.FileInfo, 0)
-	for p, ok := range fm.selectedFiles {
-		if !ok {
-			continue
-		}
+	for _, p := range fm.selection.Paths() {
 		for _, fi := range fm.files {
 			if fi.Path == p {
 				selected = append(selected, fi)
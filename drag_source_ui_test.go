@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"nmf/internal/fileinfo"
+	"nmf/internal/selection"
 )
 
 func TestCollectDragSourcePathsUsesSelectionBeforeDraggedItem(t *testing.T) {
@@ -24,7 +25,7 @@ func TestCollectDragSourcePathsUsesSelectionBeforeDraggedItem(t *testing.T) {
 			{Name: "selected.txt", Path: selectedPath},
 			{Name: "dragged.txt", Path: draggedPath},
 		},
-		selectedFiles: map[string]bool{selectedPath: true},
+		selection: selection.New(selectedPath),
 	}
 
 	paths, err := fm.collectDragSourcePaths(fileinfo.FileInfo{Name: "dragged.txt", Path: draggedPath})
@@ -43,7 +44,7 @@ func TestCollectDragSourcePathsFallsBackToDraggedItem(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	fm := &FileManager{selectedFiles: map[string]bool{}}
+	fm := &FileManager{selection: selection.New()}
 	paths, err := fm.collectDragSourcePaths(fileinfo.FileInfo{Name: "dragged.txt", Path: draggedPath})
 	if err != nil {
 		t.Fatalf("collectDragSourcePaths returned error: %v", err)
@@ -141,7 +141,10 @@ func droppedURIPaths(uris []fyne.URI) ([]string, error) {
 			return nil, fmt.Errorf("Cannot access dropped file %s: %w", p, err)
 		}
 
-		key := normalizeComparablePath(p)
+		key := p
+		if parsed, err := fileinfo.ParsePath(p); err == nil {
+			key = parsed.Key()
+		}
 		if seen[key] {
 			continue
 		}
@@ -175,7 +178,12 @@ func (fm *FileManager) showDropActionDialog(paths []string, dest string) {
 				return
 			}
 		}
-		enqueueDroppedTransfer(fm.jobManager(), op, paths, dest, fm.conflictResolver(), jobs.TransferOptions{PreserveTimestamps: fm.config.UI.Copy.PreserveTimestamps})
+		j := enqueueDroppedTransfer(fm.jobManager(), op, paths, dest, fm.conflictResolver(), jobs.TransferOptions{
+			PreserveTimestamps: fm.config.UI.Copy.PreserveTimestamps,
+			PreserveOwnership:  fm.config.UI.Copy.PreserveOwnership,
+			PreserveXattrs:     fm.config.UI.Copy.PreserveXattrs,
+		})
+		fm.reportSpaceShortfall(j)
 		debugPrint("FileManager: Drop queued action=%s sources=%d dest=%s", string(op), len(paths), dest)
 	}
 
@@ -213,7 +221,7 @@ func dropSummary(paths []string, dest string) string {
 func droppedMoveSources(paths []string, dest string) []string {
 	filtered := make([]string, 0, len(paths))
 	for _, p := range paths {
-		if !sameDirectoryPath(filepath.Dir(p), dest) {
+		if !fileinfo.SamePath(fileinfo.ParentPath(p), dest) {
 			filtered = append(filtered, p)
 		}
 	}
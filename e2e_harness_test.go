@@ -0,0 +1,263 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+
+	"nmf/internal/config"
+	"nmf/internal/jobs"
+	customtheme "nmf/internal/theme"
+	"nmf/internal/uitest"
+)
+
+// e2eTimeout bounds every wait of the end-to-end harness.
+const e2eTimeout = 10 * time.Second
+
+// e2eHarness runs a real FileManager window on the Fyne test app against a
+// temporary directory. Config and state live under the test's temp dirs, so
+// a run never reads or writes the user's files. Tests drive the window
+// through its KeyManager, the way key events from the window arrive, and
+// assert on the listing, marks, and job results. The test goroutine plays
+// the UI thread: fyne.Do callbacks from background work wait in a uitest
+// queue until do or a wait pumps them.
+type e2eHarness struct {
+	t    *testing.T
+	app  *uitest.App
+	fm   *FileManager
+	root string
+}
+
+// newE2EHarness creates the fixture under a temp root and opens a window on
+// it. Fixture entries are slash-separated paths relative to the root; a
+// trailing slash makes a directory, anything else a file whose content is
+// its own name. configure, if not nil, adjusts the default config before the
+// window is built.
+func newE2EHarness(t *testing.T, configure func(*config.Config), fixture ...string) *e2eHarness {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "config"))
+	t.Setenv("XDG_STATE_HOME", filepath.Join(home, "state"))
+	t.Setenv("APPDATA", filepath.Join(home, "appdata"))
+	t.Setenv("LOCALAPPDATA", filepath.Join(home, "localappdata"))
+
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("resolve fixture root: %v", err)
+	}
+	for _, entry := range fixture {
+		writeE2EFixture(t, root, entry)
+	}
+
+	resetFileManagerWindowTestRegistry(t)
+	atomic.StoreInt32(&windowCount, 0)
+	t.Cleanup(func() {
+		atomic.StoreInt32(&windowCount, 0)
+	})
+
+	app := uitest.NewApp(t)
+	configManager := config.NewManager(debugPrint)
+	cfg, err := configManager.Load()
+	if err != nil {
+		t.Fatalf("load default config: %v", err)
+	}
+	if configure != nil {
+		configure(cfg)
+	}
+	stateManager := config.NewStateManager(debugPrint)
+	state, err := stateManager.Load(configManager.ConfigPath())
+	if err != nil {
+		t.Fatalf("load state: %v", err)
+	}
+	customTheme := customtheme.NewCustomTheme(cfg, debugPrint)
+	app.Settings().SetTheme(customTheme)
+
+	runtime := newApplicationRuntime(app)
+	h := &e2eHarness{t: t, app: app, root: root}
+	h.do(func() {
+		h.fm = NewFileManager(runtime, root, cfg, configManager, state, stateManager, customTheme, nil)
+	})
+	t.Cleanup(func() {
+		h.waitIdle()
+		// Closing the last window also closes the runtime and quits the app.
+		// Its loads and watchers are cancelled; join them before the next
+		// test, pumping what they report on the way out, then drop the rest.
+		h.do(h.fm.closeWindow)
+		app.PumpWhile(func() {
+			h.fm.loads.Wait()
+			runtime.watchHub.Wait()
+		})
+		app.Stop()
+		if err := stateManager.Close(); err != nil {
+			t.Errorf("close state manager: %v", err)
+		}
+	})
+	h.waitLoaded(root)
+	return h
+}
+
+func writeE2EFixture(t *testing.T, root, entry string) {
+	t.Helper()
+	full := filepath.Join(root, filepath.FromSlash(entry))
+	if strings.HasSuffix(entry, "/") {
+		if err := os.MkdirAll(full, 0o755); err != nil {
+			t.Fatalf("create fixture dir %s: %v", entry, err)
+		}
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		t.Fatalf("create fixture parent %s: %v", entry, err)
+	}
+	if err := os.WriteFile(full, []byte(entry), 0o644); err != nil {
+		t.Fatalf("create fixture file %s: %v", entry, err)
+	}
+}
+
+// path returns the absolute path of a fixture entry.
+func (h *e2eHarness) path(rel string) string {
+	return filepath.Join(h.root, filepath.FromSlash(rel))
+}
+
+// do runs the callbacks queued so far and then fn, both on the test
+// goroutine. Tests read and change window state only through it.
+func (h *e2eHarness) do(fn func()) {
+	h.app.Pump()
+	fn()
+}
+
+// press delivers one key press with the given modifiers, as the window's
+// key callbacks would.
+func (h *e2eHarness) press(key fyne.KeyName, modifiers ...fyne.KeyName) {
+	h.do(func() {
+		km := h.fm.keyManager
+		for _, mod := range modifiers {
+			km.HandleKeyDown(&fyne.KeyEvent{Name: mod})
+		}
+		ev := &fyne.KeyEvent{Name: key}
+		km.HandleKeyDown(ev)
+		km.HandleTypedKey(ev)
+		km.HandleKeyUp(ev)
+		for i := len(modifiers) - 1; i >= 0; i-- {
+			km.HandleKeyUp(&fyne.KeyEvent{Name: modifiers[i]})
+		}
+	})
+}
+
+// typeText delivers runes to the current input owner, such as an open
+// search or dialog field.
+func (h *e2eHarness) typeText(text string) {
+	h.do(func() {
+		for _, r := range text {
+			h.fm.keyManager.HandleTypedRune(r)
+		}
+	})
+}
+
+// waitFor pumps queued callbacks and polls cond until it holds or the
+// harness timeout expires.
+func (h *e2eHarness) waitFor(what string, cond func() bool) {
+	h.t.Helper()
+	if !h.app.WaitFor(cond, e2eTimeout) {
+		h.t.Fatalf("timed out waiting for %s", what)
+	}
+}
+
+// waitIdle waits until no directory load is in flight and busy mode ended.
+func (h *e2eHarness) waitIdle() {
+	h.t.Helper()
+	h.waitFor("directory load to finish", func() bool {
+		h.fm.busyMu.Lock()
+		busy := h.fm.busyActive
+		h.fm.busyMu.Unlock()
+		return !busy && !h.fm.navigator.Pending()
+	})
+}
+
+// waitLoaded waits until the window shows path and is idle.
+func (h *e2eHarness) waitLoaded(path string) {
+	h.t.Helper()
+	h.waitIdle()
+	var current string
+	h.do(func() { current = h.fm.currentPath })
+	if current != path {
+		h.t.Fatalf("current path = %q, want %q", current, path)
+	}
+}
+
+// names returns the names shown in the list, in order.
+func (h *e2eHarness) names() []string {
+	var names []string
+	h.do(func() { names = namesOf(h.fm.files) })
+	return names
+}
+
+// cursorName returns the name under the cursor, or "" with no cursor.
+func (h *e2eHarness) cursorName() string {
+	var name string
+	h.do(func() {
+		if file, ok := h.fm.FileAt(h.fm.GetCurrentCursorIndex()); ok {
+			name = file.Name
+		}
+	})
+	return name
+}
+
+// marked returns the names of the marked entries, sorted.
+func (h *e2eHarness) marked() []string {
+	var names []string
+	h.do(func() {
+		for path, on := range h.fm.GetSelectedFiles() {
+			if on {
+				names = append(names, filepath.Base(path))
+			}
+		}
+	})
+	sort.Strings(names)
+	return names
+}
+
+// waitJobs waits until the job manager has no pending or running job and
+// returns the snapshots it lists.
+func (h *e2eHarness) waitJobs() []jobs.JobSnapshot {
+	h.t.Helper()
+	var snaps []jobs.JobSnapshot
+	h.waitFor("jobs to finish", func() bool {
+		snaps = h.fm.jobManager().List()
+		return activeJobCount(snaps) == 0
+	})
+	return snaps
+}
+
+func (h *e2eHarness) assertNames(want ...string) {
+	h.t.Helper()
+	if got := h.names(); !reflect.DeepEqual(got, want) {
+		h.t.Fatalf("listing = %v, want %v", got, want)
+	}
+}
+
+func (h *e2eHarness) assertCursor(want string) {
+	h.t.Helper()
+	if got := h.cursorName(); got != want {
+		h.t.Fatalf("cursor = %q, want %q", got, want)
+	}
+}
+
+func (h *e2eHarness) assertMarked(want ...string) {
+	h.t.Helper()
+	got := h.marked()
+	if len(got) == 0 && len(want) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(got, want) {
+		h.t.Fatalf("marked = %v, want %v", got, want)
+	}
+}
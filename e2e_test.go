@@ -0,0 +1,209 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+
+	"nmf/internal/config"
+	"nmf/internal/jobs"
+	"nmf/internal/ui"
+)
+
+func TestE2ENavigatesIntoAndOutOfDirectories(t *testing.T) {
+	h := newE2EHarness(t, nil, "alpha/", "alpha/inner.txt", "beta.txt")
+	h.assertNames("..", "alpha", "beta.txt")
+	h.assertCursor("..")
+
+	h.press(fyne.KeyDown)
+	h.assertCursor("alpha")
+	h.press(fyne.KeyReturn)
+	h.waitLoaded(h.path("alpha"))
+	h.assertNames("..", "inner.txt")
+
+	h.press(fyne.KeyBackspace)
+	h.waitLoaded(h.root)
+	h.assertNames("..", "alpha", "beta.txt")
+	h.assertCursor("alpha")
+}
+
+func TestE2EMarksFollowTheCursorAndClearOnNavigation(t *testing.T) {
+	h := newE2EHarness(t, nil, "a.txt", "b.txt", "c.txt", "sub/")
+
+	// Directories sort first by default: .., sub, a.txt, b.txt, c.txt.
+	h.press(fyne.KeyDown)
+	h.press(fyne.KeyDown)
+	h.press(fyne.KeySpace)
+	h.assertCursor("b.txt")
+	h.press(fyne.KeySpace)
+	h.assertMarked("a.txt", "b.txt")
+	h.press(fyne.KeyUp)
+	h.press(fyne.KeySpace)
+	h.assertMarked("a.txt")
+
+	h.do(func() { h.fm.LoadDirectory(h.path("sub")) })
+	h.waitLoaded(h.path("sub"))
+	h.assertMarked()
+}
+
+func TestE2ECopiesMarkedFilesThroughTheJobManager(t *testing.T) {
+	h := newE2EHarness(t, nil, "dest/", "one.txt", "two.txt")
+
+	h.press(fyne.KeyDown)
+	h.press(fyne.KeyDown)
+	h.press(fyne.KeySpace)
+	h.press(fyne.KeySpace)
+	h.assertMarked("one.txt", "two.txt")
+
+	h.do(func() {
+		h.fm.enqueueTransfer(ui.OpCopy, h.fm.collectTargetPaths(), h.path("dest"), jobs.TransferOptions{})
+	})
+	snaps := h.waitJobs()
+	if len(snaps) == 0 || snaps[0].Status != jobs.StatusCompleted {
+		t.Fatalf("latest job = %+v, want completed", snaps)
+	}
+	for _, name := range []string{"one.txt", "two.txt"} {
+		data, err := os.ReadFile(h.path("dest/" + name))
+		if err != nil || string(data) != name {
+			t.Fatalf("copied %s = %q, %v", name, data, err)
+		}
+	}
+
+	h.do(func() { h.fm.LoadDirectory(h.path("dest")) })
+	h.waitLoaded(h.path("dest"))
+	h.assertNames("..", "one.txt", "two.txt")
+}
+
+func TestE2EKeepsASortSavedForOneDirectory(t *testing.T) {
+	h := newE2EHarness(t, nil, "downloads/a.txt", "downloads/bbbb.txt", "downloads/cc.txt", "x.txt", "yyyy.txt")
+
+	h.do(func() { h.fm.LoadDirectory(h.path("downloads")) })
+	h.waitLoaded(h.path("downloads"))
+	h.do(func() {
+		h.fm.saveSortChoice(config.SortConfig{SortBy: "size", SortOrder: "desc", DirectoriesFirst: true}, true)
+	})
+	// Fixture files hold their own path, so the longest name is the largest.
+	h.assertNames("..", "bbbb.txt", "cc.txt", "a.txt")
+
+	h.press(fyne.KeyBackspace)
+	h.waitLoaded(h.root)
+	h.assertNames("..", "downloads", "x.txt", "yyyy.txt")
+
+	h.do(func() { h.fm.LoadDirectory(h.path("downloads")) })
+	h.waitLoaded(h.path("downloads"))
+	h.assertNames("..", "bbbb.txt", "cc.txt", "a.txt")
+
+	// Saving for the whole window drops the directory's own sort.
+	var kept bool
+	h.do(func() {
+		h.fm.saveSortChoice(config.SortConfig{SortBy: "name", SortOrder: "desc", DirectoriesFirst: true}, false)
+		_, kept = h.fm.state.LookupDirectoryView(h.path("downloads"))
+	})
+	if kept {
+		t.Fatal("directory sort kept after saving a window sort")
+	}
+	h.assertNames("..", "cc.txt", "bbbb.txt", "a.txt")
+}
+
+func TestE2EContextMenuRunsTheKeyboardCommands(t *testing.T) {
+	h := newE2EHarness(t, nil, "sub/inner.txt", "a.txt", "b.txt")
+
+	// Directories sort first: .., sub, a.txt, b.txt.
+	h.press(fyne.KeyDown)
+	h.press(fyne.KeyDown)
+	h.press(fyne.KeySpace)
+	h.assertMarked("a.txt")
+
+	contextMenuKey := func(index int, key rune) {
+		t.Helper()
+		var focused fyne.Focusable
+		h.do(func() {
+			h.fm.showFileContextMenu(index, fyne.NewPos(10, 10))
+			focused = h.fm.window.Canvas().Focused()
+			if menu, ok := focused.(*ui.CommandMenu); ok {
+				menu.TypedRune(key)
+			}
+		})
+		if _, ok := focused.(*ui.CommandMenu); !ok {
+			t.Fatalf("focused = %T, want the context menu", focused)
+		}
+	}
+
+	// A right click moves the cursor without touching the marks, and the
+	// commands act on the marks as their keys do.
+	contextMenuKey(3, 'a')
+	h.assertCursor("b.txt")
+	h.assertMarked("a.txt")
+	var clipboard string
+	h.do(func() { clipboard = h.app.Clipboard().Content() })
+	if clipboard != h.path("a.txt") {
+		t.Fatalf("clipboard = %q, want the marked path", clipboard)
+	}
+
+	contextMenuKey(1, 'o')
+	h.waitLoaded(h.path("sub"))
+	h.assertNames("..", "inner.txt")
+}
+
+func TestE2EReopensTheLastClosedTab(t *testing.T) {
+	h := newE2EHarness(t, nil, "alpha/inner.txt", "beta.txt")
+
+	h.press(fyne.KeyT, desktop.KeyControlLeft)
+	h.waitLoaded(h.root)
+	h.press(fyne.KeyDown)
+	h.press(fyne.KeyReturn)
+	h.waitLoaded(h.path("alpha"))
+	h.press(fyne.KeyDown)
+	h.assertCursor("inner.txt")
+
+	h.press(fyne.KeyW, desktop.KeyControlLeft)
+	h.waitLoaded(h.root)
+	var tabs, active int
+	h.do(func() { tabs = len(h.fm.tabs) })
+	if tabs != 1 {
+		t.Fatalf("tabs after close = %d, want 1", tabs)
+	}
+
+	// closed.reopen runs through the owner-transition gate.
+	h.press(fyne.KeyN, desktop.KeyControlLeft, desktop.KeyShiftLeft)
+	h.waitFor("reopened tab", func() bool { return len(h.fm.tabs) == 2 })
+	h.waitLoaded(h.path("alpha"))
+	h.assertCursor("inner.txt")
+	h.do(func() { tabs, active = len(h.fm.tabs), h.fm.activeTab })
+	if tabs != 2 || active != 1 {
+		t.Fatalf("tabs = %d active = %d, want the closed tab back after the first", tabs, active)
+	}
+}
+
+func TestE2EWalksUpThroughCachedParentListings(t *testing.T) {
+	h := newE2EHarness(t, nil, "alpha/", "alpha/inner/", "beta.txt")
+	h.do(func() { h.fm.LoadDirectory(h.path("alpha")) })
+	h.waitLoaded(h.path("alpha"))
+	h.do(func() { h.fm.LoadDirectory(h.path("alpha/inner")) })
+	h.waitLoaded(h.path("alpha/inner"))
+	for _, p := range []string{h.root, h.path("alpha")} {
+		var ok bool
+		h.do(func() { _, ok = h.fm.parentListings[p] })
+		if !ok {
+			t.Fatalf("no cached listing for %s", p)
+		}
+	}
+
+	// The cached root listing is refreshed in the background after use.
+	writeE2EFixture(t, h.root, "gamma.txt")
+	h.press(fyne.KeyBackspace)
+	h.waitLoaded(h.path("alpha"))
+	h.assertNames("..", "inner")
+	h.assertCursor("inner")
+	h.press(fyne.KeyBackspace)
+	h.waitLoaded(h.root)
+	h.assertNames("..", "alpha", "beta.txt", "gamma.txt")
+	h.assertCursor("alpha")
+	var left int
+	h.do(func() { left = len(h.fm.parentListings) })
+	if left != 0 {
+		t.Fatalf("parent listings left at the root: %v", left)
+	}
+}
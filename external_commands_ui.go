@@ -78,13 +78,18 @@ func (fm *FileManager) showCommandPopup(title string, items ...*fyne.MenuItem) {
 }
 
 func (fm *FileManager) showCommandMenu(items []keymanager.CommandMenuItem) {
+	fm.showCommandMenuAt(items, fm.externalCommandMenuPosition())
+}
+
+// showCommandMenuAt displays a command menu at pos on the window canvas.
+func (fm *FileManager) showCommandMenuAt(items []keymanager.CommandMenuItem, pos fyne.Position) {
 	if fm.window == nil || fm.window.Canvas() == nil {
 		return
 	}
 
 	menu := ui.NewCommandMenu(items, fm.FocusFileList)
 	menu.SetTransientStateReset(fm.keyManager.ResetTransientState)
-	menu.ShowAtPosition(fm.window.Canvas(), fm.externalCommandMenuPosition())
+	menu.ShowAtPosition(fm.window.Canvas(), pos)
 }
 
 func (fm *FileManager) externalCommandMenuPosition() fyne.Position {
@@ -307,6 +312,9 @@ func externalCommandWorkingDirectory(cwd string) (string, bool) {
 	if parsed.Scheme == fileinfo.SchemeSMB && parsed.Provider != "local" {
 		return "", true
 	}
+	if fileinfo.IsGioProviderPath(parsed) {
+		return "", true
+	}
 	if parsed.Provider == "local" && parsed.Native != "" {
 		return parsed.Native, false
 	}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+
+	"nmf/internal/config"
+	"nmf/internal/fileinfo"
+	"nmf/internal/ui"
+)
+
+// usesColumns reports whether rows use the detailed column layout.
+func (fm *FileManager) usesColumns() bool {
+	return fm.config != nil && len(fm.config.UI.Columns) > 0
+}
+
+// newColumnHeader creates the header above the detailed list, or nil when
+// ui.columns is empty.
+func (fm *FileManager) newColumnHeader() *ui.ColumnHeader {
+	if !fm.usesColumns() {
+		return nil
+	}
+	header := ui.NewColumnHeader(fm.config.UI.Columns, fm.sortByColumn)
+	current := fm.CurrentSort()
+	header.SetSort(current.SortBy, current.SortOrder != "desc")
+	return header
+}
+
+// setRowColumns fills the column cells of a detailed row.
+func (fm *FileManager) setRowColumns(row *ui.FileListRow, file fileinfo.FileInfo) {
+	for _, column := range fm.config.UI.Columns {
+		row.SetColumnText(column, fm.columnText(column, file))
+	}
+}
+
+func (fm *FileManager) columnText(column string, file fileinfo.FileInfo) string {
+	if file.Pending && column != config.ColumnExtension {
+		return ""
+	}
+	switch column {
+	case config.ColumnSize:
+		if file.IsDir {
+			state, ok := fm.dirSizes[file.Path]
+			return directoryInfoSize(state, ok)
+		}
+		return fileinfo.FormatFileSize(file.Size)
+	case config.ColumnExtension:
+		if file.IsDir {
+			return ""
+		}
+		return strings.TrimPrefix(filepath.Ext(file.Name), ".")
+	case config.ColumnModified:
+		return fm.rowDisplayTime(file).Format("2006-01-02 15:04:05")
+	case config.ColumnPermissions:
+		if file.Mode == 0 {
+			return ""
+		}
+		return file.Mode.String()
+	case config.ColumnOwner:
+		return file.Owner
+	case config.ColumnLinkTarget:
+		return file.LinkTarget
+	}
+	return ""
+}
+
+// sortByColumn applies a header click: the sorted column flips its order,
+// another column sorts ascending. Like the sort dialog, the result is saved
+// as this window's sort.
+func (fm *FileManager) sortByColumn(sortBy string) {
+	next := fm.CurrentSort()
+	if next.SortBy == sortBy && next.SortOrder != "desc" {
+		next.SortOrder = "desc"
+	} else {
+		next.SortOrder = "asc"
+	}
+	next.SortBy = sortBy
+	debugPrint("FileManager: Column header sort %+v", next)
+	fm.applyPersistentSort(next)
+	fm.FocusFileList()
+}
+
+// setActiveSort records the sort of the visible list and moves the header's
+// sort arrow to match.
+func (fm *FileManager) setActiveSort(sortConfig config.SortConfig) {
+	fm.activeSort = sortConfig
+	if fm.columnHeader != nil {
+		fm.columnHeader.SetSort(sortConfig.SortBy, sortConfig.SortOrder != "desc")
+	}
+}
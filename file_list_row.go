@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/theme"
@@ -15,10 +16,63 @@ import (
 )
 
 func (fm *FileManager) newFileListRow() fyne.CanvasObject {
-	return ui.NewFileListRow(
-		fm.config.UI.CursorStyle,
-		fm.customTheme.GetCustomColor(customtheme.ColorFileRegular),
-	)
+	var row *ui.FileListRow
+	if fm.usesColumns() {
+		row = ui.NewColumnFileListRow(
+			fm.config.UI.CursorStyle,
+			fm.customTheme.GetCustomColor(customtheme.ColorFileRegular),
+			fm.config.UI.Columns,
+		)
+	} else {
+		row = ui.NewFileListRow(
+			fm.config.UI.CursorStyle,
+			fm.customTheme.GetCustomColor(customtheme.ColorFileRegular),
+		)
+	}
+	fm.installFileListRowHandlers(row)
+	return row
+}
+
+// installFileListRowHandlers sets the row's tap, right-click, and drag
+// handlers once. They act on the entry updateFileListRow last bound to the
+// recycled row.
+func (fm *FileManager) installFileListRowHandlers(row *ui.FileListRow) {
+	row.Icon.SetOnTapped(func() {
+		_, fileInfo, ok := row.Bound()
+		if !ok {
+			return
+		}
+		debugPrint("FileManager: Icon tapped path=%s dir=%t", fileInfo.Path, fileInfo.IsDir)
+		if fileInfo.IsDir {
+			fm.LoadDirectory(fileInfo.Path)
+		}
+	})
+	row.Icon.SetOnDragged(func() {
+		if _, fileInfo, ok := row.Bound(); ok {
+			debugPrint("FileManager: Icon dragged path=%s", fileInfo.Path)
+			fm.StartFileDrag(fileInfo)
+		}
+	})
+	row.NameLabel.SetOnTapped(func(modifier fyne.KeyModifier) {
+		index, fileInfo, ok := row.Bound()
+		if !ok {
+			return
+		}
+		debugPrint("FileManager: File name tapped file=%q modifier=%d active=%t focused=%s path=%q",
+			fileInfo.Path, modifier, fm.windowActive, focusedObjectLabel(fm.window), fm.currentPath)
+		fm.handleFileNameClick(index, fileInfo, modifier)
+	})
+	row.NameLabel.SetOnDragged(func() {
+		if _, fileInfo, ok := row.Bound(); ok {
+			debugPrint("FileManager: File name dragged path=%s", fileInfo.Path)
+			fm.StartFileDrag(fileInfo)
+		}
+	})
+	row.SetOnTappedSecondary(func(ev *fyne.PointEvent) {
+		if index, _, ok := row.Bound(); ok {
+			fm.showFileContextMenu(index, ev.AbsolutePosition)
+		}
+	})
 }
 
 func (fm *FileManager) updateFileListRow(id widget.ListItemID, obj fyne.CanvasObject) {
@@ -32,75 +86,116 @@ func (fm *FileManager) updateFileListRow(id widget.ListItemID, obj fyne.CanvasOb
 	if !ok {
 		return
 	}
+	row.Bind(index, fileInfo)
 
-	// Set icon resource with async service (Windows uses real icons if available).
+	textColor := fileinfo.GetTextColor(fileInfo.FileType, fm.customTheme)
+
+	// Set icon resource from the async service's cache (Windows uses real
+	// icons if available). Fetches are requested for the visible range only;
+	// see icon_prefetch.go. Until a fetch finishes the row shows a loading
+	// placeholder rather than a theme icon that would be swapped out. The
+	// mono icon set draws built-in SVG glyphs in the entry's text color
+	// instead.
 	folderRes := theme.FolderIcon()
 	fileRes := theme.FileIcon()
-	if fileInfo.IsDir {
+	loading := false
+	if fm.usesMonoIcons() {
+		row.Icon.SetResource(ui.MonoIcon(ui.MonoIconKindFor(fileInfo), textColor))
+	} else if fileInfo.IsDir {
 		row.Icon.SetResource(folderRes)
 	} else {
-		textSize := int(fyne.CurrentApp().Settings().Theme().Size(theme.SizeNameText))
 		ext := strings.ToLower(filepath.Ext(fileInfo.Name))
 		if fm.iconSvc != nil {
-			if res, ok := fm.iconSvc.GetCachedOrRequest(fileInfo.Path, fileInfo.IsDir, ext, textSize); ok && res != nil {
+			if res, ok := fm.iconSvc.GetCached(fileInfo.Path, fileInfo.IsDir, ext); ok && res != nil {
 				row.Icon.SetResource(res)
 			} else {
 				row.Icon.SetResource(fileRes)
+				loading = fm.iconSvc.Loading(fileInfo.Path, fileInfo.IsDir, ext)
 			}
+			fm.scheduleIconPrefetch()
 		} else {
 			row.Icon.SetResource(fileRes)
 		}
 	}
+	row.Icon.SetLoading(loading)
 
-	// Set callbacks for the file currently assigned to this recycled row.
-	row.Icon.SetOnTapped(func() {
-		debugPrint("FileManager: Icon tapped path=%s dir=%t", fileInfo.Path, fileInfo.IsDir)
-		if fileInfo.IsDir {
-			fm.LoadDirectory(fileInfo.Path)
-		}
-	})
-	row.Icon.SetOnDragged(func() {
-		debugPrint("FileManager: Icon dragged path=%s", fileInfo.Path)
-		fm.StartFileDrag(fileInfo)
-	})
-
-	textColor := fileinfo.GetTextColor(fileInfo.FileType, fm.customTheme)
 	row.NameLabel.SetFile(fileInfo.Name, textColor, fileInfo.Status == fileinfo.StatusDeleted)
-	row.NameLabel.SetOnTapped(func(modifier fyne.KeyModifier) {
-		debugPrint("FileManager: File name tapped file=%q modifier=%d active=%t focused=%s path=%q",
-			fileInfo.Path, modifier, fm.windowActive, focusedObjectLabel(fm.window), fm.currentPath)
-		fm.handleFileNameClick(index, fileInfo, modifier)
-	})
-	row.NameLabel.SetOnDragged(func() {
-		debugPrint("FileManager: File name dragged path=%s", fileInfo.Path)
-		fm.StartFileDrag(fileInfo)
-	})
 
-	if fileInfo.IsDir {
-		row.InfoLabel.SetText(fmt.Sprintf("<dir> %s %s",
-			fileInfo.Modified.Format("2006-01-02"),
-			fileInfo.Modified.Format("15:04:05")))
+	if row.InfoLabel == nil {
+		fm.setRowColumns(row, fileInfo)
+	} else if fileInfo.Pending {
+		// Not statted yet; size and date fill in when the load gets to it.
+		row.InfoLabel.SetText("")
+	} else if fileInfo.IsDir {
+		row.InfoLabel.SetText(withLinkTarget(fileInfo, fm.directoryInfoText(fileInfo)))
 	} else {
-		row.InfoLabel.SetText(fmt.Sprintf("%s %s %s",
+		shown := fm.rowDisplayTime(fileInfo)
+		info := fmt.Sprintf("%s %s %s",
 			fileinfo.FormatFileSize(fileInfo.Size),
-			fileInfo.Modified.Format("2006-01-02"),
-			fileInfo.Modified.Format("15:04:05")))
+			shown.Format("2006-01-02"),
+			shown.Format("15:04:05"))
+		if summary := fm.mediaMetadataSummary(fileInfo); summary != "" {
+			info = summary + "  " + info
+		}
+		row.InfoLabel.SetText(withLinkTarget(fileInfo, info))
 	}
 
 	currentCursorIdx := fm.GetCurrentCursorIndex()
 	isCursor := index == currentCursorIdx
-	isSelected := fm.selectedFiles[fileInfo.Path]
+	isSelected := fm.selection.IsMarked(fileInfo.Path)
 	if isCursor {
 		fm.cursorAnchor = cursorRowAnchor{path: fileInfo.Path, object: row}
 	} else if fm.cursorAnchor.object == row {
 		fm.cursorAnchor = cursorRowAnchor{}
 	}
 
-	statusColor := fileinfo.GetStatusBackgroundColor(fileInfo.Status, fm.customTheme)
-	selectionColor := fm.customTheme.GetCustomColor(customtheme.ColorSelectionBackground)
-	cursorColor := fm.cursorThemeProvider().GetCustomColor(customtheme.ColorCursor)
-	row.SetDecorations(statusColor, isSelected, selectionColor, isCursor, cursorColor)
+	row.SetState(ui.FileListRowState{
+		StatusColor:    fileinfo.GetStatusBackgroundColor(fileInfo.Status, fm.customTheme),
+		Selected:       isSelected,
+		SelectionColor: fm.customTheme.GetCustomColor(customtheme.ColorSelectionBackground),
+		Cursor:         isCursor,
+		CursorColor:    fm.cursorThemeProvider().GetCustomColor(customtheme.ColorCursor),
+		TagColor:       fileInfo.ColorTag.RGBA(),
+		Emblems:        ui.EmblemsFor(fileInfo, isSelected),
+		GroupStart:     fm.startsDateGroup(index),
+	})
 	if isCursor {
 		fm.noteCursorItemUpdated(index)
 	}
 }
+
+// withLinkTarget puts where a symlink points in front of its row info, so a
+// link reads as "→ /target  size date".
+func withLinkTarget(file fileinfo.FileInfo, info string) string {
+	if file.LinkTarget == "" {
+		return info
+	}
+	return "→ " + file.LinkTarget + "  " + info
+}
+
+// rowDisplayTime returns the timestamp shown in a file row. While the list is
+// sorted or grouped by "dateTaken" the capture date replaces the modification
+// time, so photos taken on the same day read as one group.
+func (fm *FileManager) rowDisplayTime(file fileinfo.FileInfo) time.Time {
+	if !usesDateTaken(fm.CurrentSort()) || file.IsDir {
+		return file.Modified
+	}
+	if resolve := fm.dateTakenResolver(fm.captureDates, true); resolve != nil {
+		return resolve(file)
+	}
+	return file.Modified
+}
+
+// startsDateGroup reports whether the entry at index begins another capture
+// day while the list is grouped by "dateTaken", so its row draws a rule
+// above it. The first entry and the one after ".." draw none.
+func (fm *FileManager) startsDateGroup(index int) bool {
+	if index <= 0 || index >= len(fm.files) || fm.CurrentSort().GroupBy != "dateTaken" {
+		return false
+	}
+	prev := fm.files[index-1]
+	if prev.Name == ".." {
+		return false
+	}
+	return captureDay(fm.rowDisplayTime(prev)) != captureDay(fm.rowDisplayTime(fm.files[index]))
+}
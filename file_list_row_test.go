@@ -3,11 +3,13 @@ package main
 import (
 	"testing"
 
+	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/test"
 	"fyne.io/fyne/v2/widget"
 
 	"nmf/internal/config"
 	"nmf/internal/fileinfo"
+	"nmf/internal/selection"
 	customtheme "nmf/internal/theme"
 	"nmf/internal/ui"
 )
@@ -26,7 +28,7 @@ func TestUpdateFileListRowPreservesCursorAnchorAndDiagnostics(t *testing.T) {
 		cursorPath:       "/tmp/alpha.txt",
 		cursorIndex:      0,
 		cursorRefreshSeq: 1,
-		selectedFiles:    map[string]bool{},
+		selection:        selection.New(),
 		config:           cfg,
 		customTheme:      theme,
 		windowActive:     true,
@@ -52,3 +54,82 @@ func TestUpdateFileListRowPreservesCursorAnchorAndDiagnostics(t *testing.T) {
 		t.Fatalf("cursor anchor after row reuse = %+v, want cleared", fm.cursorAnchor)
 	}
 }
+
+func TestUpdateFileListRowFillsConfiguredColumns(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	cfg := config.Default()
+	cfg.UI.Columns = []string{config.ColumnName, config.ColumnSize, config.ColumnExtension, config.ColumnPermissions, config.ColumnOwner, config.ColumnLinkTarget}
+	theme := customtheme.NewCustomTheme(cfg, nil)
+	fm := &FileManager{
+		files: []fileinfo.FileInfo{
+			{Name: "photo.JPG", Path: "/tmp/photo.JPG", Size: 2048, Mode: 0o640, Owner: "alice", LinkTarget: "/photos/photo.JPG"},
+		},
+		selection:   selection.New(),
+		config:      cfg,
+		customTheme: theme,
+	}
+
+	row := fm.newFileListRow().(*ui.FileListRow)
+	fm.updateFileListRow(widget.ListItemID(0), row)
+
+	for column, want := range map[string]string{
+		config.ColumnSize:        "2.0 KB",
+		config.ColumnExtension:   "JPG",
+		config.ColumnPermissions: "-rw-r-----",
+		config.ColumnOwner:       "alice",
+		config.ColumnLinkTarget:  "/photos/photo.JPG",
+	} {
+		if got := row.ColumnText(column); got != want {
+			t.Errorf("%s column = %q, want %q", column, got, want)
+		}
+	}
+}
+
+func TestSortByColumnFlipsOrderOfTheSortedColumn(t *testing.T) {
+	fm := &FileManager{
+		config:     config.Default(),
+		state:      &config.State{},
+		activeSort: config.SortConfig{SortBy: "name", SortOrder: "asc", DirectoriesFirst: true},
+	}
+	fm.sortByColumn("name")
+	if fm.activeSort.SortBy != "name" || fm.activeSort.SortOrder != "desc" {
+		t.Fatalf("sort after clicking the sorted column = %+v, want name desc", fm.activeSort)
+	}
+	fm.sortByColumn("size")
+	if fm.activeSort.SortBy != "size" || fm.activeSort.SortOrder != "asc" || !fm.activeSort.DirectoriesFirst {
+		t.Fatalf("sort after clicking another column = %+v, want size asc keeping directories first", fm.activeSort)
+	}
+	if fm.state.Sort == nil || *fm.state.Sort != fm.activeSort {
+		t.Fatalf("state sort = %v, want the applied sort saved", fm.state.Sort)
+	}
+}
+
+func TestFileListRowHandlersFollowRecycledBinding(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	cfg := config.Default()
+	fm := &FileManager{
+		files: []fileinfo.FileInfo{
+			{Name: "alpha.txt", Path: "/tmp/alpha.txt"},
+			{Name: "beta.txt", Path: "/tmp/beta.txt"},
+		},
+		cursorIndex: -1,
+		selection:   selection.New(),
+		config:      cfg,
+		customTheme: customtheme.NewCustomTheme(cfg, nil),
+	}
+
+	row := fm.newFileListRow().(*ui.FileListRow)
+	fm.updateFileListRow(widget.ListItemID(0), row)
+	fm.updateFileListRow(widget.ListItemID(1), row)
+
+	// The tap handler was installed once at creation; it must act on the
+	// entry the row shows now, not on the first one it was bound to.
+	row.NameLabel.Tapped(&fyne.PointEvent{})
+	if !fm.selection.IsMarked("/tmp/beta.txt") || fm.selection.IsMarked("/tmp/alpha.txt") {
+		t.Fatalf("selected files = %+v, want only beta marked", fm.selection.Paths())
+	}
+}
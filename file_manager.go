@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -14,7 +15,10 @@ import (
 	"nmf/internal/fileinfo"
 	"nmf/internal/jobs"
 	"nmf/internal/keymanager"
+	"nmf/internal/navigation"
 	"nmf/internal/search"
+	"nmf/internal/selection"
+	"nmf/internal/tagindex"
 	customtheme "nmf/internal/theme"
 	"nmf/internal/ui"
 	"nmf/internal/watcher"
@@ -22,7 +26,7 @@ import (
 
 // FileManager is the main file manager struct.
 type FileManager struct {
-	mu                   sync.RWMutex // Protects files and selectedFiles from concurrent access
+	mu                   sync.RWMutex // Protects files from concurrent access
 	window               fyne.Window
 	currentPath          string
 	files                []fileinfo.FileInfo
@@ -30,19 +34,30 @@ type FileManager struct {
 	fileList             *widget.List
 	fileListView         *ui.KeySink
 	fileListItemHeight   float32
+	fileGrid             *widget.GridWrap // Thumbnail view of files, shown instead of fileList in thumbnail mode
+	columnHeader         *ui.ColumnHeader // Sort header of the detailed column view; nil without ui.columns
+	thumbnailMode        bool
 	windowHighlight      *canvas.Rectangle
 	windowActive         bool
 	pathDisplay          *widget.Label
 	statusLabel          *widget.Label
-	cursorPath           string          // Current cursor file path
-	cursorIndex          int             // Cache of cursorPath's index in files; validated against cursorPath on every read in GetCurrentCursorIndex, so direct cursorPath assignments elsewhere self-heal
-	cursorRefreshSeq     uint64          // Diagnostic sequence for requested cursor refreshes
-	cursorItemUpdateSeq  uint64          // Latest cursor refresh sequence observed by the list UpdateItem callback
-	cursorMoveDirection  int             // Pending vertical cursor movement: -1 up, 0 none, +1 down
-	cursorAnchor         cursorRowAnchor // Last visible row object for shell menu positioning
-	selectedFiles        map[string]bool // Set of selected file paths
+	selectionBar         *widget.Label    // Bottom bar with item and mark totals
+	diskUsageBar         *widget.Label    // Right end of the bottom bar with the current file system's free space
+	cursorPath           string           // Current cursor file path
+	cursorIndex          int              // Cache of cursorPath's index in files; validated against cursorPath on every read in GetCurrentCursorIndex, so direct cursorPath assignments elsewhere self-heal
+	cursorRefreshSeq     uint64           // Diagnostic sequence for requested cursor refreshes
+	cursorItemUpdateSeq  uint64           // Latest cursor refresh sequence observed by the list UpdateItem callback
+	cursorMoveDirection  int              // Pending vertical cursor movement: -1 up, 0 none, +1 down
+	cursorAnchor         cursorRowAnchor  // Last visible row object for shell menu positioning
+	selection            *selection.Model // Marked paths; observed to redraw the status bars
+	markIndex            map[string]int   // Path -> index in markListing for markWeight; nil until needed
+	markListing          []fileinfo.FileInfo
+	selectionPending     atomic.Bool // A selection redraw is queued on the UI goroutine
 	storageInfo          fileinfo.StorageInfo
 	storageKnown         bool
+	diskUsagePending     atomic.Bool   // A periodic disk usage stat is in flight
+	diskUsageStop        chan struct{} // Stops the periodic disk usage refresh
+	dirNote              string        // note from the current directory's sidecar file
 	config               *config.Config
 	configManager        *config.Manager
 	state                *config.State
@@ -50,16 +65,34 @@ type FileManager struct {
 	configScript         *configscript.Runtime
 	initialWindowSize    fyne.Size
 	activeSort           config.SortConfig
+	manualRefresh        bool                                    // Auto-refresh is off: no directory watcher for this window
+	unwatched            bool                                    // Auto-refresh is on but the current directory cannot be watched
+	showHidden           bool                                    // Dotfiles and Windows hidden entries are listed
+	hideGitIgnored       bool                                    // Entries a git repository ignores are left out of local listings
+	gitIgnoreActive      bool                                    // The current load applies git ignore rules
+	loadedAt             time.Time                               // When the current listing was read
+	captureDates         bool                                    // Capture dates are read for the current listing (see readsCaptureDates)
 	customTheme          *customtheme.CustomTheme                // Custom theme for colors
 	keyManager           *keymanager.KeyManager                  // Keyboard input manager
 	mainKeyHandler       *keymanager.MainScreenKeyHandler        // Main screen key handler (for canvas shortcut registration)
 	dirWatcher           *watcher.DirectoryWatcher               // Directory change watcher
 	currentFilter        *config.FilterEntry                     // Currently applied filter
+	profileFilter        *config.FilterEntry                     // Filter set by the active view profile
+	viewProfile          string                                  // Name of the view profile matching currentPath
+	profileShowMetadata  *bool                                   // View profile override of ui.metadata.showInList
 	searchOverlay        *ui.IncrementalSearchOverlay            // Incremental search overlay
 	searchHandler        *keymanager.IncrementalSearchKeyHandler // Search key handler
 	searchToken          keymanager.HandlerToken                 // Token of the pushed search handler
 	searchMatchers       *search.Provider                        // Shared search matcher provider
+	commandPalette       *ui.CommandPaletteOverlay               // Command palette overlay
+	paletteToken         keymanager.HandlerToken                 // Token of the pushed palette handler
 	iconSvc              *fileinfo.IconService                   // Async icon service
+	iconPrefetchPending  bool                                    // A visible-range icon request is scheduled (UI thread only)
+	metadataSvc          *fileinfo.MetadataService               // Lazy media metadata parser
+	previewSvc           *fileinfo.PreviewService                // Async preview pane loader
+	previewPane          *ui.PreviewPane                         // Optional right-hand preview pane
+	treePane             *ui.DirectoryTreePane                   // Optional left-hand directory tree pane
+	thumbnailSvc         *fileinfo.ThumbnailService              // Async thumbnail generator for the grid view
 	runtime              *ApplicationRuntime                     // Application-scoped services
 	promptTargetID       uint64
 	promptUnregister     func()
@@ -75,21 +108,48 @@ type FileManager struct {
 	busyDelay    time.Duration
 	busyText     string
 	busyToken    keymanager.HandlerToken
+	busyHandler  *keymanager.BusyKeyHandler
 	busyMu       sync.Mutex
-	loadMu       sync.Mutex
-	nextLoadID   uint64
-	activeLoadID uint64
-	loadCancel   context.CancelFunc
+	navigator    navigation.Navigator // Current directory load and its cancellation
+	loads        sync.WaitGroup       // Background directory reads, joined after close
 	viewerMu     sync.Mutex
 	nextViewerID uint64
 	activeViewer uint64
 	viewerCancel context.CancelFunc
 
+	// Directory tabs (UI thread only)
+	tabs              []tabState
+	activeTab         int
+	pendingTabRestore *tabState
+	tabBar            *ui.DirectoryTabBar
+
+	// Listings of the directories above the current one (UI thread only)
+	parentListings parentListingCache
+	// Listings of network directories left recently (UI thread only)
+	remoteListings remoteListingCache
+
+	// Directory size mode results for the current directory (UI thread only)
+	dirSizes      map[string]dirSizeState
+	dirSizeCtx    context.Context
+	dirSizeCancel context.CancelFunc
+
+	// Confirmation counts for large operations (UI thread only)
+	bulkMeasureCtx    context.Context
+	bulkMeasureCancel context.CancelFunc
+
 	// Jobs indicator
 	jobsButton    *widget.Button
 	jobsBlinking  bool
 	jobsBlinkStop chan struct{}
 	jobsUnsub     func()
+
+	// Connection state of the current share, next to the path bar
+	connectionButton *widget.Button
+	connectionUnsub  func()
+
+	// Last Run Command prompt (UI thread only)
+	shellCommandLine      string
+	shellCommandNoRefresh bool
 }
 
 func (fm *FileManager) beginViewerLoad() (uint64, context.Context) {
@@ -140,6 +200,15 @@ func (fm *FileManager) jobManager() *jobs.Manager {
 	return jobs.GetManager()
 }
 
+// tagIndex returns the shared color tag index, or nil for windows built
+// without a runtime (tests).
+func (fm *FileManager) tagIndex() *tagindex.Index {
+	if fm != nil && fm.runtime != nil {
+		return fm.runtime.tagIndex
+	}
+	return nil
+}
+
 type cursorRowAnchor struct {
 	path   string
 	object fyne.CanvasObject
@@ -180,7 +249,7 @@ func (fm *FileManager) updateFiles(files []fileinfo.FileInfo, resort bool) {
 
 	// Apply filter if one is active
 	if fm.currentFilter != nil && config.EffectiveFilterPattern(fm.currentFilter.Pattern) != "" {
-		filtered, err := fileinfo.FilterFiles(files, config.EffectiveFilterPattern(fm.currentFilter.Pattern))
+		filtered, err := fm.filterFiles(files, config.EffectiveFilterPattern(fm.currentFilter.Pattern))
 		if err != nil {
 			debugPrint("FileManager: Filter error: %v", err)
 			fm.files = files // Fall back to showing all files
@@ -194,24 +263,69 @@ func (fm *FileManager) updateFiles(files []fileinfo.FileInfo, resort bool) {
 	if resort {
 		fm.sortFilesWithConfig(fm.CurrentSort())
 	}
+	fm.reweighMarks()
 
 	// widget.List is not data-bound, so it never redraws on its own; refresh
 	// explicitly to reflect additions, deletions, and modifications.
-	fm.fileList.Refresh()
+	fm.refreshFileList()
 	fm.updateStatusBar()
+	fm.updatePreviewPane()
 }
 
 func (fm *FileManager) RemoveFromSelections(path string) {
-	fm.mu.Lock()
-	defer fm.mu.Unlock()
-	delete(fm.selectedFiles, path)
+	fm.selection.Set(path, false)
+}
+
+// ReplaceListing installs a complete listing from the watcher after a mass
+// change, instead of merging thousands of changes one by one. Entries come
+// back without added/modified highlights; color tags, which snapshots do not
+// read, are kept by path. Marks on vanished paths are dropped and the cursor
+// stays on its path, or near its old row when the path is gone. Runs on the
+// Fyne main goroutine like ApplyChanges.
+func (fm *FileManager) ReplaceListing(files []fileinfo.FileInfo) {
+	cursorIdx := fm.GetCurrentCursorIndex()
+	previous := fm.originalFiles
+	if len(previous) == 0 {
+		previous = fm.files
+	}
+	tags := make(map[string]fileinfo.ColorTag, len(previous))
+	listing := make([]fileinfo.FileInfo, 0, len(files)+1)
+	for _, file := range previous {
+		if file.Name == ".." {
+			listing = append(listing, file)
+		} else if file.ColorTag != fileinfo.ColorTagNone {
+			tags[file.Path] = file.ColorTag
+		}
+	}
+	present := make(map[string]bool, len(files))
+	for _, file := range files {
+		file.Status = fileinfo.StatusNormal
+		file.ColorTag = tags[file.Path]
+		listing = append(listing, file)
+		present[file.Path] = true
+	}
+	var gone []string
+	for _, path := range fm.selection.Paths() {
+		if !present[path] {
+			gone = append(gone, path)
+		}
+	}
+	fm.selection.SetMany(gone, false)
+
+	fm.updateFiles(listing, true)
+	fm.refreshTreePane()
+	if fm.GetCurrentCursorIndex() < 0 && len(fm.files) > 0 {
+		fm.SetCursorByIndex(min(max(cursorIdx, 0), len(fm.files)-1))
+	}
+	fm.refreshListAndCursor()
+	debugPrint("FileManager: Listing replaced after mass change entries=%d cursor=%q", len(fm.files), fm.cursorPath)
 }
 
 // ApplyChanges merges watcher-detected added/deleted/modified files into the
 // current listing. Must only run on the Fyne main goroutine: the watcher
 // marshals into this call via fyne.DoAndWait (internal/watcher/watcher.go
-// applyDataChanges), since fm.files/fm.selectedFiles are otherwise mutated
-// without synchronization from UI-thread code such as SetFileSelected.
+// applyDataChanges), since fm.files is otherwise mutated without
+// synchronization from UI-thread code such as sorting and filtering.
 func (fm *FileManager) ApplyChanges(added, deleted, modified []fileinfo.FileInfo) {
 	files := fm.GetFiles()
 
@@ -229,13 +343,23 @@ func (fm *FileManager) ApplyChanges(added, deleted, modified []fileinfo.FileInfo
 
 	// Handle modified files - update status
 	typeFlipped := false
+	dirsChanged := false
+	for _, deletedFile := range deleted {
+		dirsChanged = dirsChanged || deletedFile.IsDir
+	}
+	for _, addedFile := range added {
+		dirsChanged = dirsChanged || addedFile.IsDir
+	}
 	for _, modifiedFile := range modified {
 		for i, file := range files {
 			if file.Path == modifiedFile.Path {
 				if file.IsDir != modifiedFile.IsDir {
 					typeFlipped = true
 				}
+				// Snapshots do not read tags; keep the loaded one.
+				tag := file.ColorTag
 				files[i] = modifiedFile
+				files[i].ColorTag = tag
 				break
 			}
 		}
@@ -264,10 +388,13 @@ func (fm *FileManager) ApplyChanges(added, deleted, modified []fileinfo.FileInfo
 	sortAffected := len(added) > 0 || len(deleted) > 0 || typeFlipped
 	if !sortAffected {
 		switch fm.CurrentSort().SortBy {
-		case "size", "modified":
+		case "size", "modified", "dateTaken":
 			sortAffected = true
 		}
 	}
 
 	fm.updateFiles(files, sortAffected)
+	if dirsChanged || typeFlipped {
+		fm.refreshTreePane()
+	}
 }
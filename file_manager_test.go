@@ -10,6 +10,7 @@ import (
 
 	"nmf/internal/config"
 	"nmf/internal/fileinfo"
+	"nmf/internal/selection"
 )
 
 func newApplyChangesTestFileManager(files []fileinfo.FileInfo, sortCfg config.SortConfig) *FileManager {
@@ -20,8 +21,8 @@ func newApplyChangesTestFileManager(files []fileinfo.FileInfo, sortCfg config.So
 			func() fyne.CanvasObject { return widget.NewLabel("") },
 			func(widget.ListItemID, fyne.CanvasObject) {},
 		),
-		activeSort:    sortCfg,
-		selectedFiles: map[string]bool{},
+		activeSort: sortCfg,
+		selection:  selection.New(),
 	}
 }
 
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"nmf/internal/fileinfo"
+	"nmf/internal/gitignore"
+)
+
+// ToggleGitIgnored hides or shows the entries a git repository's ignore
+// rules match, saves the choice to state.json for new windows, and reloads
+// the directory.
+func (fm *FileManager) ToggleGitIgnored() {
+	fm.hideGitIgnored = !fm.hideGitIgnored
+	debugPrint("FileManager: Hide git-ignored files=%t path=%s", fm.hideGitIgnored, fm.currentPath)
+	hide := fm.hideGitIgnored
+	fm.state.HideGitIgnored = &hide
+	if fm.stateManager != nil {
+		if err := fm.stateManager.SaveAsync(fm.state); err != nil {
+			debugPrint("FileManager: Error saving git-ignored toggle: %v", err)
+		}
+	}
+	fm.SaveCursorPosition(fm.currentPath)
+	fm.LoadDirectory(fm.currentPath)
+}
+
+// listingEntryFilter returns the listing filter for a load: the hidden-file
+// filter, plus the repository's ignore rules when gitIgnore is set. The
+// rules are read once per load, off the UI thread, on the first entry
+// checked.
+func (fm *FileManager) listingEntryFilter(gitIgnore bool) func(fileinfo.FileInfo) bool {
+	hidden := hiddenEntryFilter(fm.showHidden)
+	if !gitIgnore {
+		return hidden
+	}
+	ignore := gitignore.NewFilter()
+	return func(file fileinfo.FileInfo) bool {
+		if hidden != nil && !hidden(file) {
+			return false
+		}
+		return file.Name == ".." || !ignore.Ignored(file.Path, file.IsDir)
+	}
+}
+
+// gitIgnoreApplies reports whether git-ignored entries are hidden in path.
+// Network shares, devices, archives, and tag views are listed in full:
+// looking for a repository there would cost a round trip per parent.
+func (fm *FileManager) gitIgnoreApplies(path string) bool {
+	if !fm.hideGitIgnored || path == "" || fileinfo.IsArchivePath(path) || fileinfo.IsTagViewPath(path) {
+		return false
+	}
+	if _, ignored := externalCommandWorkingDirectory(path); ignored {
+		return false
+	}
+	class, err := fileinfo.ClassifyPath(path)
+	return err == nil && !class.Network && !class.Device
+}
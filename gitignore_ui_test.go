@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"nmf/internal/fileinfo"
+)
+
+func TestListingEntryFilterDropsGitIgnoredEntries(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".gitignore"), []byte("build/\n*.log\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fm := &FileManager{showHidden: true, hideGitIgnored: true}
+	if !fm.gitIgnoreApplies(root) {
+		t.Fatal("git ignore rules should apply to a local directory")
+	}
+
+	keep := fm.listingEntryFilter(true)
+	entries := []fileinfo.FileInfo{
+		{Name: "build", Path: filepath.Join(root, "build"), IsDir: true},
+		{Name: "run.log", Path: filepath.Join(root, "run.log")},
+		{Name: "main.go", Path: filepath.Join(root, "main.go")},
+		{Name: ".gitignore", Path: filepath.Join(root, ".gitignore")},
+	}
+	var kept []string
+	for _, entry := range entries {
+		if keep(entry) {
+			kept = append(kept, entry.Name)
+		}
+	}
+	if got := strings.Join(kept, ","); got != "main.go,.gitignore" {
+		t.Fatalf("kept %s, want main.go,.gitignore", got)
+	}
+}
+
+func TestGitIgnoreAppliesOnlyWhileHidingLocalEntries(t *testing.T) {
+	dir := t.TempDir()
+	if (&FileManager{}).gitIgnoreApplies(dir) {
+		t.Fatal("git ignore rules should not apply while the toggle is off")
+	}
+	fm := &FileManager{hideGitIgnored: true}
+	if fm.gitIgnoreApplies(filepath.Join(dir, "a.zip") + "!/inner") {
+		t.Fatal("git ignore rules should not apply inside archives")
+	}
+}
@@ -8,14 +8,7 @@ import (
 )
 
 func canonicalNavigationHistoryPath(p string) string {
-	resolved, parsed, err := fileinfo.CanonicalDisplayPath(p)
-	if err != nil {
-		return p
-	}
-	if parsed.Scheme == fileinfo.SchemeArchive && parsed.Display != "" {
-		return parsed.Display
-	}
-	return resolved
+	return fileinfo.CanonicalPath(p)
 }
 
 func normalizeNavigationHistory(state *config.State) bool {
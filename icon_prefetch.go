@@ -0,0 +1,121 @@
+package main
+
+import (
+	"math"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+
+	"nmf/internal/config"
+	"nmf/internal/fileinfo"
+)
+
+const (
+	// iconPrefetchDelay coalesces the row updates of one scroll frame into a
+	// single visible-range request.
+	iconPrefetchDelay = 30 * time.Millisecond
+	// iconReadAheadRows and iconReadBehindRows extend the request past the
+	// viewport so icons are usually ready before a row scrolls into view.
+	iconReadAheadRows  = 16
+	iconReadBehindRows = 4
+)
+
+// usesMonoIcons reports whether rows draw the built-in monochrome SVG icon
+// set (ui.iconSet "mono") instead of OS icons.
+func (fm *FileManager) usesMonoIcons() bool {
+	return fm.config != nil && fm.config.UI.IconSet == config.IconSetMono
+}
+
+// scheduleIconPrefetch queues one visible-range icon request after the
+// current burst of row updates. Rows themselves only read the icon cache, so
+// recycled off-screen rows never enqueue work.
+func (fm *FileManager) scheduleIconPrefetch() {
+	if fm.iconSvc == nil || fm.iconPrefetchPending {
+		return
+	}
+	fm.iconPrefetchPending = true
+	time.AfterFunc(iconPrefetchDelay, func() {
+		fyne.Do(func() {
+			fm.iconPrefetchPending = false
+			if fm.isWindowClosed() {
+				return
+			}
+			fm.requestVisibleIcons()
+		})
+	})
+}
+
+// requestVisibleIcons replaces the icon service queue with the rows in the
+// viewport followed by the read-ahead and read-behind windows.
+func (fm *FileManager) requestVisibleIcons() {
+	if fm.iconSvc == nil || fm.fileList == nil {
+		return
+	}
+	first, last := fm.visibleRowRange()
+	rows := iconPrefetchOrder(first, last, len(fm.files))
+	if len(rows) == 0 {
+		return
+	}
+	textSize := fyne.CurrentApp().Settings().Theme().Size(theme.SizeNameText)
+	var scale float32 = 1
+	if fm.window != nil && fm.window.Canvas() != nil {
+		scale = fm.window.Canvas().Scale()
+	}
+	reqs := make([]fileinfo.IconRequest, 0, len(rows))
+	for _, i := range rows {
+		fi := fm.files[i]
+		reqs = append(reqs, fileinfo.IconRequest{
+			Path:  fi.Path,
+			IsDir: fi.IsDir,
+			Ext:   strings.ToLower(filepath.Ext(fi.Name)),
+		})
+	}
+	fm.iconSvc.RequestVisible(reqs, iconPixelSize(textSize, scale))
+}
+
+// iconPixelSize converts the icon's size in Fyne units to device pixels so
+// platform icons are fetched at the resolution they are drawn at. A changed
+// canvas scale yields a new size, which makes the icon service regenerate
+// its cache.
+func iconPixelSize(size, scale float32) int {
+	if scale <= 0 {
+		scale = 1
+	}
+	return int(math.Ceil(float64(size * scale)))
+}
+
+// visibleRowRange returns the first and last list rows inside the viewport.
+func (fm *FileManager) visibleRowRange() (first, last int) {
+	if fm.fileList == nil || fm.fileListItemHeight <= 0 {
+		return 0, -1
+	}
+	rowStride := fm.fileListItemHeight + fm.fileList.Theme().Size(theme.SizeNamePadding)
+	offset := fm.fileList.GetScrollOffset()
+	first = int(offset / rowStride)
+	last = int((offset + fm.fileList.Size().Height) / rowStride)
+	return first, last
+}
+
+// iconPrefetchOrder lists row indexes by fetch priority: visible rows top to
+// bottom, then rows below the viewport, then rows above it.
+func iconPrefetchOrder(first, last, count int) []int {
+	if count <= 0 || last < first {
+		return nil
+	}
+	first = max(0, min(first, count-1))
+	last = max(first, min(last, count-1))
+	below := min(count-1, last+iconReadAheadRows)
+	above := max(0, first-iconReadBehindRows)
+
+	rows := make([]int, 0, below-above+1)
+	for i := first; i <= below; i++ {
+		rows = append(rows, i)
+	}
+	for i := first - 1; i >= above; i-- {
+		rows = append(rows, i)
+	}
+	return rows
+}
@@ -0,0 +1,118 @@
+package config
+
+import "strings"
+
+// MaxBookmarkHotkey is the highest quick-jump digit a bookmark can hold.
+const MaxBookmarkHotkey = 9
+
+// MaxWatchedBookmarks caps bookmarks watched in the background; each costs a
+// watch source even while no window shows it.
+const MaxWatchedBookmarks = 8
+
+// Bookmark is a named directory saved from the Bookmarks dialog (state.json).
+type Bookmark struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Hotkey int    `json:"hotkey,omitempty"` // 1-9 quick-jump digit; 0 means none
+	Watch  bool   `json:"watch,omitempty"`  // Watched for ui.watchRules even when not displayed
+}
+
+// GetBookmarks returns a copy of the saved bookmarks in display order.
+func (s *State) GetBookmarks() []Bookmark {
+	bookmarks := make([]Bookmark, len(s.Bookmarks))
+	copy(bookmarks, s.Bookmarks)
+	return bookmarks
+}
+
+// SetBookmarks replaces the saved bookmarks with a normalized copy of
+// bookmarks.
+func (s *State) SetBookmarks(bookmarks []Bookmark) {
+	s.Bookmarks = NormalizeBookmarks(bookmarks)
+}
+
+// BookmarkForHotkey returns the bookmark bound to digit n.
+func (s *State) BookmarkForHotkey(n int) (Bookmark, bool) {
+	if n < 1 || n > MaxBookmarkHotkey {
+		return Bookmark{}, false
+	}
+	for _, b := range s.Bookmarks {
+		if b.Hotkey == n {
+			return b, true
+		}
+	}
+	return Bookmark{}, false
+}
+
+// SetBookmarkHotkey puts path into slot n (1-9), taking the slot from any
+// other bookmark. An existing bookmark for path keeps its name; otherwise a
+// bookmark named name is added at the end. It reports false for an invalid
+// slot or empty path.
+func (s *State) SetBookmarkHotkey(n int, path, name string) bool {
+	path = strings.TrimSpace(path)
+	if n < 1 || n > MaxBookmarkHotkey || path == "" {
+		return false
+	}
+	bookmarks := s.GetBookmarks()
+	found := false
+	for i := range bookmarks {
+		switch {
+		case bookmarks[i].Path == path:
+			bookmarks[i].Hotkey = n
+			found = true
+		case bookmarks[i].Hotkey == n:
+			bookmarks[i].Hotkey = 0
+		}
+	}
+	if !found {
+		bookmarks = append(bookmarks, Bookmark{Name: name, Path: path, Hotkey: n})
+	}
+	s.SetBookmarks(bookmarks)
+	return true
+}
+
+// WatchedBookmarks returns the bookmarks marked watch, in display order.
+func (s *State) WatchedBookmarks() []Bookmark {
+	var watched []Bookmark
+	for _, b := range s.Bookmarks {
+		if b.Watch {
+			watched = append(watched, b)
+		}
+	}
+	return watched
+}
+
+// NormalizeBookmarks trims names and paths, drops entries without a path or
+// repeating an earlier path, and clears hotkeys that are out of range or
+// already taken by an earlier entry. Watch marks past MaxWatchedBookmarks are
+// cleared. A missing name defaults to the path.
+func NormalizeBookmarks(bookmarks []Bookmark) []Bookmark {
+	out := make([]Bookmark, 0, len(bookmarks))
+	seenPaths := make(map[string]bool, len(bookmarks))
+	seenHotkeys := make(map[int]bool, MaxBookmarkHotkey)
+	watched := 0
+	for _, b := range bookmarks {
+		b.Path = strings.TrimSpace(b.Path)
+		if b.Path == "" || seenPaths[b.Path] {
+			continue
+		}
+		seenPaths[b.Path] = true
+		b.Name = strings.TrimSpace(b.Name)
+		if b.Name == "" {
+			b.Name = b.Path
+		}
+		if b.Hotkey < 1 || b.Hotkey > MaxBookmarkHotkey || seenHotkeys[b.Hotkey] {
+			b.Hotkey = 0
+		} else {
+			seenHotkeys[b.Hotkey] = true
+		}
+		if b.Watch {
+			if watched < MaxWatchedBookmarks {
+				watched++
+			} else {
+				b.Watch = false
+			}
+		}
+		out = append(out, b)
+	}
+	return out
+}
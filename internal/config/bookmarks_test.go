@@ -0,0 +1,81 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeBookmarksDropsDuplicatesAndClearsTakenHotkeys(t *testing.T) {
+	got := NormalizeBookmarks([]Bookmark{
+		{Name: " src ", Path: " /src ", Hotkey: 1},
+		{Name: "dup", Path: "/src", Hotkey: 2},
+		{Name: "", Path: "/docs", Hotkey: 1},
+		{Name: "tmp", Path: "/tmp", Hotkey: 12},
+		{Name: "empty", Path: " "},
+	})
+	want := []Bookmark{
+		{Name: "src", Path: "/src", Hotkey: 1},
+		{Name: "/docs", Path: "/docs"},
+		{Name: "tmp", Path: "/tmp"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("NormalizeBookmarks = %+v, want %+v", got, want)
+	}
+}
+
+func TestNormalizeBookmarksLimitsWatchedBookmarks(t *testing.T) {
+	bookmarks := make([]Bookmark, MaxWatchedBookmarks+2)
+	for i := range bookmarks {
+		bookmarks[i] = Bookmark{Path: "/dir" + string(rune('a'+i)), Watch: true}
+	}
+	s := newDefaultState()
+	s.SetBookmarks(bookmarks)
+	watched := s.WatchedBookmarks()
+	if len(watched) != MaxWatchedBookmarks || watched[0].Path != "/dira" {
+		t.Fatalf("WatchedBookmarks = %+v, want the first %d", watched, MaxWatchedBookmarks)
+	}
+	if s.Bookmarks[MaxWatchedBookmarks].Watch {
+		t.Fatal("bookmarks past the limit should lose their watch mark")
+	}
+}
+
+func TestStateSetBookmarkHotkeyMovesSlot(t *testing.T) {
+	s := newDefaultState()
+	s.SetBookmarks([]Bookmark{{Name: "src", Path: "/src", Hotkey: 2}, {Name: "docs", Path: "/docs"}})
+
+	if !s.SetBookmarkHotkey(2, "/docs", "ignored") {
+		t.Fatal("SetBookmarkHotkey(2, /docs) = false")
+	}
+	if !s.SetBookmarkHotkey(5, "/tmp", "tmp") {
+		t.Fatal("SetBookmarkHotkey(5, /tmp) = false")
+	}
+	want := []Bookmark{
+		{Name: "src", Path: "/src"},
+		{Name: "docs", Path: "/docs", Hotkey: 2},
+		{Name: "tmp", Path: "/tmp", Hotkey: 5},
+	}
+	if !reflect.DeepEqual(s.Bookmarks, want) {
+		t.Fatalf("bookmarks = %+v, want %+v", s.Bookmarks, want)
+	}
+	if s.SetBookmarkHotkey(0, "/src", "src") || s.SetBookmarkHotkey(MaxBookmarkHotkey+1, "/src", "src") {
+		t.Fatal("out-of-range slots should be rejected")
+	}
+}
+
+func TestStateBookmarkForHotkeyAndCloneIsolation(t *testing.T) {
+	s := newDefaultState()
+	s.SetBookmarks([]Bookmark{{Name: "src", Path: "/src", Hotkey: 3}})
+
+	if b, ok := s.BookmarkForHotkey(3); !ok || b.Path != "/src" {
+		t.Fatalf("BookmarkForHotkey(3) = %+v, %t", b, ok)
+	}
+	if _, ok := s.BookmarkForHotkey(4); ok {
+		t.Fatal("BookmarkForHotkey(4) should not match")
+	}
+
+	clone := cloneState(s)
+	clone.Bookmarks[0].Name = "changed"
+	if s.Bookmarks[0].Name != "src" {
+		t.Fatal("cloneState should copy bookmarks")
+	}
+}
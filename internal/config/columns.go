@@ -0,0 +1,61 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Columns of the detailed list view (ui.columns).
+const (
+	ColumnName        = "name"
+	ColumnSize        = "size"
+	ColumnExtension   = "extension"
+	ColumnModified    = "modified"
+	ColumnPermissions = "permissions"
+	ColumnOwner       = "owner"
+	ColumnLinkTarget  = "linkTarget"
+)
+
+// AllColumns lists the supported columns in their documented order.
+var AllColumns = []string{ColumnName, ColumnSize, ColumnExtension, ColumnModified, ColumnPermissions, ColumnOwner, ColumnLinkTarget}
+
+// ValidateColumns checks ui.columns. An empty list keeps the compact
+// layout; otherwise every column is known, listed once, and name is present.
+func ValidateColumns(columns []string) error {
+	if len(columns) == 0 {
+		return nil
+	}
+	seen := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		if !isKnownColumn(column) {
+			return fmt.Errorf("ui.columns: unknown column %q (use %s)", column, strings.Join(AllColumns, ", "))
+		}
+		if seen[column] {
+			return fmt.Errorf("ui.columns: duplicate column %q", column)
+		}
+		seen[column] = true
+	}
+	if !seen[ColumnName] {
+		return fmt.Errorf("ui.columns must include %q", ColumnName)
+	}
+	return nil
+}
+
+func isKnownColumn(column string) bool {
+	for _, known := range AllColumns {
+		if column == known {
+			return true
+		}
+	}
+	return false
+}
+
+// ColumnSortBy returns the ui.sort.sortBy value a column header sorts by;
+// false for columns the list cannot be sorted by.
+func ColumnSortBy(column string) (string, bool) {
+	switch column {
+	case ColumnName, ColumnSize, ColumnExtension, ColumnModified:
+		return column, true
+	}
+	return "", false
+}
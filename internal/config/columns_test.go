@@ -0,0 +1,31 @@
+package config
+
+import "testing"
+
+func TestValidateColumns(t *testing.T) {
+	cases := []struct {
+		columns []string
+		ok      bool
+	}{
+		{nil, true},
+		{[]string{"size", "name", "owner"}, true},
+		{[]string{"name", "linkTarget"}, true},
+		{[]string{"size", "modified"}, false},
+		{[]string{"name", "name"}, false},
+		{[]string{"name", "group"}, false},
+	}
+	for _, tc := range cases {
+		if err := ValidateColumns(tc.columns); (err == nil) != tc.ok {
+			t.Errorf("ValidateColumns(%q) = %v, want ok=%t", tc.columns, err, tc.ok)
+		}
+	}
+}
+
+func TestColumnSortBy(t *testing.T) {
+	if got, ok := ColumnSortBy(ColumnExtension); !ok || got != "extension" {
+		t.Fatalf("extension header sorts by %q, %t", got, ok)
+	}
+	if _, ok := ColumnSortBy(ColumnOwner); ok {
+		t.Fatal("the owner column should not be sortable")
+	}
+}
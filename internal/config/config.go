@@ -10,24 +10,28 @@ import (
 	"runtime"
 	"strings"
 	"time"
+
+	"golang.org/x/text/language"
 )
 
 // Config represents the application configuration
 type Config struct {
-	Window  WindowConfig  `json:"window"`
-	Startup StartupConfig `json:"startup"`
-	Theme   ThemeConfig   `json:"theme"`
-	Debug   DebugConfig   `json:"debug"`
-	UI      UIConfig      `json:"ui"`
+	Window       WindowConfig       `json:"window"`
+	Startup      StartupConfig      `json:"startup"`
+	Theme        ThemeConfig        `json:"theme"`
+	Debug        DebugConfig        `json:"debug"`
+	UI           UIConfig           `json:"ui"`
+	Integrations IntegrationsConfig `json:"integrations"`
 }
 
 // rawConfig mirrors Config but uses pointer fields to detect presence in JSON.
 type rawConfig struct {
-	Window  rawWindowConfig  `json:"window"`
-	Startup rawStartupConfig `json:"startup"`
-	Theme   rawThemeConfig   `json:"theme"`
-	Debug   rawDebugConfig   `json:"debug"`
-	UI      rawUIConfig      `json:"ui"`
+	Window       rawWindowConfig       `json:"window"`
+	Startup      rawStartupConfig      `json:"startup"`
+	Theme        rawThemeConfig        `json:"theme"`
+	Debug        rawDebugConfig        `json:"debug"`
+	UI           rawUIConfig           `json:"ui"`
+	Integrations rawIntegrationsConfig `json:"integrations"`
 }
 
 type rawWindowConfig struct {
@@ -38,7 +42,8 @@ type rawWindowConfig struct {
 }
 
 type rawStartupConfig struct {
-	Directory *string `json:"directory"`
+	Directory   *string `json:"directory"`
+	RestoreTabs *bool   `json:"restoreTabs"`
 }
 
 type rawThemeConfig struct {
@@ -52,37 +57,84 @@ type rawThemeConfig struct {
 }
 
 type rawDebugConfig struct {
-	Enabled      *bool   `json:"enabled"`
-	LogDirectory *string `json:"logDirectory"`
-	MaxLogFiles  *int    `json:"maxLogFiles"`
+	Enabled        *bool   `json:"enabled"`
+	LogDirectory   *string `json:"logDirectory"`
+	MaxLogFiles    *int    `json:"maxLogFiles"`
+	FaultInjection *string `json:"faultInjection"`
+}
+
+type rawIntegrationsConfig struct {
+	Terminal rawTerminalConfig `json:"terminal"`
+}
+
+type rawTerminalConfig struct {
+	Command *string  `json:"command"`
+	Args    []string `json:"args"`
 }
 
 type rawUIConfig struct {
 	ShowHiddenFiles   *bool                      `json:"showHiddenFiles"`
+	HideGitIgnored    *bool                      `json:"hideGitIgnored"`
 	Sort              rawSortConfig              `json:"sort"`
 	ItemSpacing       *int                       `json:"itemSpacing"`
 	ScrollMargin      *int                       `json:"scrollMargin"`
+	IconSet           *string                    `json:"iconSet"`
+	OpenLinks         *string                    `json:"openLinks"`
+	OpenDuplicates    *string                    `json:"openDuplicates"`
+	AutoRefresh       *bool                      `json:"autoRefresh"`
+	WatchDebounceMs   *int                       `json:"watchDebounceMs"`
+	StatWorkers       *int                       `json:"statWorkers"`
+	RemoteListingTTL  *int                       `json:"remoteListingTTLSeconds"`
+	LargeFileWarnMB   *int                       `json:"largeFileWarnMB"`
 	Copy              rawCopyConfig              `json:"copy"`
+	Jobs              rawJobsConfig              `json:"jobs"`
 	Viewer            rawViewerConfig            `json:"viewer"`
 	Archive           rawArchiveConfig           `json:"archive"`
+	Gio               rawGioConfig               `json:"gio"`
+	Vault             rawVaultConfig             `json:"vault"`
+	AuditLog          rawAuditLogConfig          `json:"auditLog"`
 	IME               rawIMEConfig               `json:"ime"`
+	Metadata          rawMetadataConfig          `json:"metadata"`
+	PreviewPane       rawPreviewPaneConfig       `json:"previewPane"`
+	TreePane          rawTreePaneConfig          `json:"treePane"`
+	Thumbnails        rawThumbnailsConfig        `json:"thumbnails"`
+	DiskUsage         rawDiskUsageConfig         `json:"diskUsage"`
 	CursorStyle       rawCursorStyleConfig       `json:"cursorStyle"`
 	CursorMemory      rawCursorMemoryConfig      `json:"cursorMemory"`
+	DirectoryViews    rawDirectoryViewsConfig    `json:"directoryViews"`
 	NavigationHistory rawNavigationHistoryConfig `json:"navigationHistory"`
 	FileFilter        rawFileFilterConfig        `json:"fileFilter"`
 	DirectoryJumps    rawDirectoryJumpsConfig    `json:"directoryJumps"`
 	KeyBindings       []KeyBindingEntry          `json:"keyBindings"`
 	ExternalCommands  []ExternalCommandEntry     `json:"externalCommands"`
+	OpenWith          []OpenWithEntry            `json:"openWith"`
+	ViewProfiles      []ViewProfile              `json:"viewProfiles"`
+	WatchRules        []WatchRule                `json:"watchRules"`
+	Columns           []string                   `json:"columns"`
 }
 
 type rawSortConfig struct {
 	SortBy           *string `json:"sortBy"`
 	SortOrder        *string `json:"sortOrder"`
 	DirectoriesFirst *bool   `json:"directoriesFirst"`
+	Natural          *bool   `json:"natural"`
+	Locale           *string `json:"locale"`
+	GroupBy          *string `json:"groupBy"`
 }
 
 type rawCopyConfig struct {
-	PreserveTimestamps *bool `json:"preserveTimestamps"`
+	PreserveTimestamps *bool   `json:"preserveTimestamps"`
+	PreserveOwnership  *bool   `json:"preserveOwnership"`
+	PreserveXattrs     *bool   `json:"preserveXattrs"`
+	Elevate            *bool   `json:"elevate"`
+	DuplicateName      *string `json:"duplicateName"`
+}
+
+type rawJobsConfig struct {
+	Workers        *int `json:"workers"`
+	PerVolumeLimit *int `json:"perVolumeLimit"`
+	ConfirmFiles   *int `json:"confirmFiles"`
+	ConfirmGB      *int `json:"confirmGB"`
 }
 
 type rawViewerConfig struct {
@@ -101,16 +153,60 @@ type rawIMEConfig struct {
 	Enabled *bool `json:"enabled"`
 }
 
+type rawMetadataConfig struct {
+	ShowInList *bool `json:"showInList"`
+}
+
+type rawPreviewPaneConfig struct {
+	Visible *bool `json:"visible"`
+	Width   *int  `json:"width"`
+}
+
+type rawTreePaneConfig struct {
+	Visible *bool `json:"visible"`
+	Width   *int  `json:"width"`
+}
+
+type rawThumbnailsConfig struct {
+	Enabled   *bool `json:"enabled"`
+	Size      *int  `json:"size"`
+	DiskCache *bool `json:"diskCache"`
+}
+
+type rawDiskUsageConfig struct {
+	WarnPercent    *int `json:"warnPercent"`
+	RefreshSeconds *int `json:"refreshSeconds"`
+}
+
 type rawArchiveConfig struct {
 	ZipNameEncoding *string `json:"zipNameEncoding"`
 }
 
+type rawGioConfig struct {
+	Enabled     *bool `json:"enabled"`
+	SMBKerberos *bool `json:"smbKerberos"`
+}
+
+type rawAuditLogConfig struct {
+	Enabled *bool `json:"enabled"`
+}
+
+type rawVaultConfig struct {
+	Enabled            *bool `json:"enabled"`
+	IdleTimeoutMinutes *int  `json:"idleTimeoutMinutes"`
+	SavePasswords      *bool `json:"savePasswords"`
+}
+
 type rawCursorMemoryConfig struct {
 	MaxEntries *int                 `json:"maxEntries"`
 	Entries    map[string]string    `json:"entries"`
 	LastUsed   map[string]time.Time `json:"lastUsed"`
 }
 
+type rawDirectoryViewsConfig struct {
+	MaxEntries *int `json:"maxEntries"`
+}
+
 type rawNavigationHistoryConfig struct {
 	MaxEntries *int                 `json:"maxEntries"`
 	Entries    []string             `json:"entries"`
@@ -120,10 +216,11 @@ type rawNavigationHistoryConfig struct {
 }
 
 type rawFileFilterConfig struct {
-	MaxEntries *int          `json:"maxEntries"`
-	Entries    []FilterEntry `json:"entries"`
-	Current    *FilterEntry  `json:"current"`
-	Enabled    *bool         `json:"enabled"`
+	MaxEntries *int           `json:"maxEntries"`
+	Presets    []FilterPreset `json:"presets"`
+	Entries    []FilterEntry  `json:"entries"`
+	Current    *FilterEntry   `json:"current"`
+	Enabled    *bool          `json:"enabled"`
 }
 
 type rawDirectoryJumpsConfig struct {
@@ -140,7 +237,8 @@ type WindowConfig struct {
 
 // StartupConfig represents startup-related settings.
 type StartupConfig struct {
-	Directory string `json:"directory,omitempty"` // Starting directory used when no command-line path is supplied
+	Directory   string `json:"directory,omitempty"`   // Starting directory used when no command-line path is supplied
+	RestoreTabs bool   `json:"restoreTabs,omitempty"` // Reopen the last saved tab session when no command-line path is supplied
 }
 
 // ThemeConfig represents theme-related settings
@@ -156,9 +254,22 @@ type ThemeConfig struct {
 
 // DebugConfig controls persistent debug logging.
 type DebugConfig struct {
-	Enabled      bool   `json:"enabled"`      // Whether debug logging is enabled for normal startup
-	LogDirectory string `json:"logDirectory"` // Empty means a logs directory next to config.json/init.star
-	MaxLogFiles  int    `json:"maxLogFiles"`  // Maximum rotating session log files to retain
+	Enabled        bool   `json:"enabled"`        // Whether debug logging is enabled for normal startup
+	LogDirectory   string `json:"logDirectory"`   // Empty means a logs directory next to config.json/init.star
+	MaxLogFiles    int    `json:"maxLogFiles"`    // Maximum rotating session log files to retain
+	FaultInjection string `json:"faultInjection"` // Development-only filesystem faults; see fileinfo.ParseFaultSpec
+}
+
+// IntegrationsConfig configures the desktop programs NMF hands off to.
+type IntegrationsConfig struct {
+	Terminal TerminalConfig `json:"terminal"`
+}
+
+// TerminalConfig selects the terminal emulator terminal.open starts in the
+// current directory.
+type TerminalConfig struct {
+	Command string   `json:"command"`        // Executable path or command name; empty picks a per-OS default
+	Args    []string `json:"args,omitempty"` // Supports {dir}; the terminal also starts with {dir} as its working directory
 }
 
 // ThemeColorValue is a color override expressed as either an RGBA tuple or a
@@ -293,20 +404,43 @@ func (v ThemeColorValue) MarshalJSON() ([]byte, error) {
 // UIConfig represents UI-related settings
 type UIConfig struct {
 	ShowHiddenFiles   bool                    `json:"showHiddenFiles"`
+	HideGitIgnored    bool                    `json:"hideGitIgnored"` // Hide entries a git repository's ignore rules match
 	Sort              SortConfig              `json:"sort"`
 	ItemSpacing       int                     `json:"itemSpacing"`
 	ScrollMargin      int                     `json:"scrollMargin"`
+	IconSet           string                  `json:"iconSet"`                 // "native" (OS icons) or "mono" (built-in SVG set)
+	OpenLinks         string                  `json:"openLinks"`               // What opening a symlinked directory does: "ask", "follow", or "physical"
+	OpenDuplicates    string                  `json:"openDuplicates"`          // What entering a directory open in another window or tab does: "ask", "switch", or "open"
+	AutoRefresh       bool                    `json:"autoRefresh"`             // Whether new windows watch their directory for changes
+	WatchDebounceMs   int                     `json:"watchDebounceMs"`         // Quiet time after a burst of change events before the directory is re-read
+	StatWorkers       int                     `json:"statWorkers"`             // Concurrent stat calls while a directory loads
+	RemoteListingTTL  int                     `json:"remoteListingTTLSeconds"` // Seconds a left network directory's listing is shown again without waiting for a read; 0 disables
+	LargeFileWarnMB   int                     `json:"largeFileWarnMB"`         // Files larger than this many MiB ask before opening with the default app; 0 never asks
 	Copy              CopyConfig              `json:"copy"`
+	Jobs              JobsConfig              `json:"jobs"`
 	Viewer            ViewerConfig            `json:"viewer"`
 	Archive           ArchiveConfig           `json:"archive"`
+	Gio               GioConfig               `json:"gio"`
+	Vault             VaultConfig             `json:"vault"`
+	AuditLog          AuditLogConfig          `json:"auditLog"`
 	IME               IMEConfig               `json:"ime"`
+	Metadata          MetadataConfig          `json:"metadata"`
+	PreviewPane       PreviewPaneConfig       `json:"previewPane"`
+	TreePane          TreePaneConfig          `json:"treePane"`
+	Thumbnails        ThumbnailsConfig        `json:"thumbnails"`
+	DiskUsage         DiskUsageConfig         `json:"diskUsage"`
 	CursorStyle       CursorStyleConfig       `json:"cursorStyle"`
 	CursorMemory      CursorMemoryConfig      `json:"cursorMemory"`
+	DirectoryViews    DirectoryViewsConfig    `json:"directoryViews"`
 	NavigationHistory NavigationHistoryConfig `json:"navigationHistory"`
 	FileFilter        FileFilterConfig        `json:"fileFilter"`
 	DirectoryJumps    DirectoryJumpsConfig    `json:"directoryJumps"`
 	KeyBindings       []KeyBindingEntry       `json:"keyBindings,omitempty"`
 	ExternalCommands  []ExternalCommandEntry  `json:"externalCommands,omitempty"`
+	OpenWith          []OpenWithEntry         `json:"openWith,omitempty"`
+	ViewProfiles      []ViewProfile           `json:"viewProfiles,omitempty"`
+	WatchRules        []WatchRule             `json:"watchRules,omitempty"` // Notification rules for bookmarks marked watch
+	Columns           []string                `json:"columns,omitempty"`    // Detailed view columns in display order; empty keeps the compact rows
 }
 
 // IMEConfig controls platform IME integration behavior.
@@ -314,21 +448,85 @@ type IMEConfig struct {
 	Enabled bool `json:"enabled"` // Whether to update native IME candidate/composition anchor positions
 }
 
+// MetadataConfig controls media metadata (EXIF, audio/video tags) display.
+type MetadataConfig struct {
+	ShowInList bool `json:"showInList"` // Whether file rows append a media metadata summary
+}
+
+// PreviewPaneConfig controls the right-hand preview pane.
+type PreviewPaneConfig struct {
+	Visible bool `json:"visible"` // Whether new windows open with the pane shown
+	Width   int  `json:"width"`   // Pane width in pixels
+}
+
+// TreePaneConfig controls the left-hand directory tree pane.
+type TreePaneConfig struct {
+	Visible bool `json:"visible"` // Whether new windows open with the pane shown
+	Width   int  `json:"width"`   // Pane width in pixels
+}
+
+// ThumbnailsConfig controls the thumbnail grid view mode.
+type ThumbnailsConfig struct {
+	Enabled   bool `json:"enabled"`   // Whether new windows open in the thumbnail grid
+	Size      int  `json:"size"`      // Longer thumbnail edge in pixels
+	DiskCache bool `json:"diskCache"` // Whether thumbnails of local files are kept in the shared thumbnail cache
+}
+
+// DiskUsageConfig controls the disk usage indicator in the bottom bar.
+type DiskUsageConfig struct {
+	WarnPercent    int `json:"warnPercent"`    // Free space, in percent of the total, below which the indicator turns red; 0 never warns
+	RefreshSeconds int `json:"refreshSeconds"` // Seconds between re-reads of the current file system's usage; 0 refreshes only on navigation
+}
+
 // ArchiveConfig controls archive virtual directory behavior.
 type ArchiveConfig struct {
 	ZipNameEncoding string `json:"zipNameEncoding"` // Fallback charset for non-UTF-8 ZIP entry names
 }
 
+// GioConfig controls delegation to gio (GVFS) on Linux.
+type GioConfig struct {
+	Enabled     bool `json:"enabled"`     // Whether trash and GVFS locations (mtp://, google-drive://, ...) go through gio when it is installed
+	SMBKerberos bool `json:"smbKerberos"` // Whether SMB shares are mounted through GVFS with the user's Kerberos ticket before asking for a password
+}
+
+// AuditLogConfig controls the operation audit log. The log itself is
+// audit-log.jsonl next to state.json.
+type AuditLogConfig struct {
+	Enabled bool `json:"enabled"` // Whether copies, moves, deletes, renames, and creates are appended to the log
+}
+
+// VaultConfig controls unlocking of gocryptfs and age encrypted directories.
+type VaultConfig struct {
+	Enabled            bool `json:"enabled"`            // Whether entering a vault directory unlocks it
+	IdleTimeoutMinutes int  `json:"idleTimeoutMinutes"` // Lock vaults no window has shown for this long; 0 disables
+	SavePasswords      bool `json:"savePasswords"`      // Save accepted vault passwords in the OS keyring
+}
+
 // SortConfig represents file sorting settings
 type SortConfig struct {
-	SortBy           string `json:"sortBy"`           // "name", "size", "modified", "extension"
+	SortBy           string `json:"sortBy"`           // "name", "size", "modified", "extension", "dateTaken", "tag"
 	SortOrder        string `json:"sortOrder"`        // "asc", "desc"
 	DirectoriesFirst bool   `json:"directoriesFirst"` // Whether to show directories before files
+	Natural          bool   `json:"natural"`          // Compare digit runs by value, so file2 sorts before file10
+	Locale           string `json:"locale"`           // "" for code point order, "auto" for the system locale, or a BCP 47 tag
+	GroupBy          string `json:"groupBy"`          // "" for no groups, or "dateTaken" to group by capture day
 }
 
 // CopyConfig controls copy operation defaults.
 type CopyConfig struct {
-	PreserveTimestamps bool `json:"preserveTimestamps"` // Default for preserving file and directory modified times
+	PreserveTimestamps bool   `json:"preserveTimestamps"` // Default for preserving access and modified times
+	PreserveOwnership  bool   `json:"preserveOwnership"`  // Give local copies their source's owner and group (Unix)
+	PreserveXattrs     bool   `json:"preserveXattrs"`     // Copy extended attributes of local files (Unix)
+	Elevate            bool   `json:"elevate"`            // Place copies the user cannot write through pkexec (Linux)
+	DuplicateName      string `json:"duplicateName"`      // Name pattern for auto-suffixed copies: {name}, {n}, {ext}
+}
+
+// JobsConfig controls background job scheduling.
+type JobsConfig struct {
+	Workers        int `json:"workers"`        // Maximum jobs running at once
+	PerVolumeLimit int `json:"perVolumeLimit"` // Maximum running jobs per source/destination volume; 0 means unlimited
+	ConfirmFiles   int `json:"confirmFiles"`   // Moves and deletes of more files than this ask twice; 0 never asks
+	ConfirmGB      int `json:"confirmGB"`      // Moves and deletes of more GiB than this ask twice; 0 never asks
 }
 
 // ViewerConfig controls the built-in file viewer dialog.
@@ -352,6 +550,12 @@ type CursorMemoryConfig struct {
 	MaxEntries int `json:"maxEntries"` // Maximum number of directories to remember
 }
 
+// DirectoryViewsConfig limits the per-directory view overrides. The saved
+// overrides live in state.json (see State.DirectoryViews).
+type DirectoryViewsConfig struct {
+	MaxEntries int `json:"maxEntries"` // Maximum number of directories with a saved view
+}
+
 // NavigationHistoryConfig represents navigation history settings. The actual
 // history data lives in state.json (see State.NavigationHistory); this is
 // just the user-configured entry limit.
@@ -368,9 +572,10 @@ type FilterEntry struct {
 
 // FileFilterConfig represents file filter settings. The actual filter history
 // and currently applied filter live in state.json (see State.FileFilter);
-// this is just the user-configured entry limit.
+// this is just the user-configured entry limit and the configured presets.
 type FileFilterConfig struct {
-	MaxEntries int `json:"maxEntries"` // Maximum number of filter patterns to remember
+	MaxEntries int            `json:"maxEntries"`        // Maximum number of filter patterns to remember
+	Presets    []FilterPreset `json:"presets,omitempty"` // Named presets applied as "preset:<name>"
 }
 
 // DirectoryJumpEntry represents a configured directory jump target.
@@ -403,6 +608,15 @@ type ExternalCommandEntry struct {
 	Edit       bool     `json:"edit,omitempty"`       // Confirm and edit the final command line before running
 }
 
+// OpenWithEntry represents one application in the Open With menu.
+type OpenWithEntry struct {
+	Name       string   `json:"name"`                 // Menu label
+	Key        string   `json:"key,omitempty"`        // Optional single-key menu accelerator
+	Extensions []string `json:"extensions,omitempty"` // Case-insensitive, with or without dot; "*" matches all files
+	Command    string   `json:"command"`              // Executable path or command name
+	Args       []string `json:"args,omitempty"`       // Supports %f (once per file), %F (all files), %d, %%; default %f
+}
+
 // Manager loads configuration from config.json. config.json is treated as
 // read-only application state: runtime state that used to be saved back into
 // it (cursor memory, navigation history, file filter history, last-applied
@@ -526,10 +740,28 @@ func getDefaultConfig() *Config {
 				SortOrder:        "asc",
 				DirectoriesFirst: true,
 			},
-			ItemSpacing:  4,
-			ScrollMargin: 3,
+			ItemSpacing:      4,
+			ScrollMargin:     3,
+			IconSet:          IconSetNative,
+			OpenLinks:        OpenLinksAsk,
+			OpenDuplicates:   OpenDuplicatesAsk,
+			AutoRefresh:      true,
+			WatchDebounceMs:  200,
+			StatWorkers:      8,
+			RemoteListingTTL: 30,
+			LargeFileWarnMB:  1024,
 			Copy: CopyConfig{
-				PreserveTimestamps: false,
+				PreserveTimestamps: true,
+				PreserveOwnership:  false,
+				PreserveXattrs:     false,
+				Elevate:            false,
+				DuplicateName:      DefaultDuplicateNamePattern,
+			},
+			Jobs: JobsConfig{
+				Workers:        2,
+				PerVolumeLimit: 1,
+				ConfirmFiles:   1000,
+				ConfirmGB:      10,
 			},
 			Viewer: ViewerConfig{
 				MaxWidth:    0,
@@ -540,9 +772,41 @@ func getDefaultConfig() *Config {
 			Archive: ArchiveConfig{
 				ZipNameEncoding: "shift_jis",
 			},
+			Gio: GioConfig{
+				Enabled:     true,
+				SMBKerberos: true,
+			},
+			Vault: VaultConfig{
+				Enabled:            true,
+				IdleTimeoutMinutes: 15,
+				SavePasswords:      false,
+			},
+			AuditLog: AuditLogConfig{
+				Enabled: false,
+			},
 			IME: IMEConfig{
 				Enabled: true,
 			},
+			Metadata: MetadataConfig{
+				ShowInList: false,
+			},
+			PreviewPane: PreviewPaneConfig{
+				Visible: false,
+				Width:   320,
+			},
+			TreePane: TreePaneConfig{
+				Visible: false,
+				Width:   240,
+			},
+			Thumbnails: ThumbnailsConfig{
+				Enabled:   false,
+				Size:      128,
+				DiskCache: true,
+			},
+			DiskUsage: DiskUsageConfig{
+				WarnPercent:    10,
+				RefreshSeconds: 30,
+			},
 			CursorStyle: CursorStyleConfig{
 				Type:      "underline",
 				Thickness: 2,
@@ -550,6 +814,9 @@ func getDefaultConfig() *Config {
 			CursorMemory: CursorMemoryConfig{
 				MaxEntries: 100,
 			},
+			DirectoryViews: DirectoryViewsConfig{
+				MaxEntries: 200,
+			},
 			NavigationHistory: NavigationHistoryConfig{
 				MaxEntries: 10000,
 			},
@@ -561,6 +828,10 @@ func getDefaultConfig() *Config {
 			},
 			KeyBindings:      make([]KeyBindingEntry, 0),
 			ExternalCommands: make([]ExternalCommandEntry, 0),
+			OpenWith:         make([]OpenWithEntry, 0),
+			ViewProfiles:     make([]ViewProfile, 0),
+			WatchRules:       make([]WatchRule, 0),
+			Columns:          make([]string, 0),
 		},
 	}
 }
@@ -631,6 +902,9 @@ func mergeConfigs(defaultConfig *Config, fileConfig *rawConfig) error {
 	if fileConfig.Startup.Directory != nil {
 		defaultConfig.Startup.Directory = strings.TrimSpace(*fileConfig.Startup.Directory)
 	}
+	if fileConfig.Startup.RestoreTabs != nil {
+		defaultConfig.Startup.RestoreTabs = *fileConfig.Startup.RestoreTabs
+	}
 
 	// Merge Theme config
 	if fileConfig.Theme.Dark != nil {
@@ -668,11 +942,25 @@ func mergeConfigs(defaultConfig *Config, fileConfig *rawConfig) error {
 	if fileConfig.Debug.MaxLogFiles != nil && *fileConfig.Debug.MaxLogFiles > 0 {
 		defaultConfig.Debug.MaxLogFiles = *fileConfig.Debug.MaxLogFiles
 	}
+	if fileConfig.Debug.FaultInjection != nil {
+		defaultConfig.Debug.FaultInjection = strings.TrimSpace(*fileConfig.Debug.FaultInjection)
+	}
+
+	// Merge Integrations config
+	if fileConfig.Integrations.Terminal.Command != nil {
+		defaultConfig.Integrations.Terminal.Command = strings.TrimSpace(*fileConfig.Integrations.Terminal.Command)
+	}
+	if fileConfig.Integrations.Terminal.Args != nil {
+		defaultConfig.Integrations.Terminal.Args = append([]string(nil), fileConfig.Integrations.Terminal.Args...)
+	}
 
 	// Merge UI config
 	if fileConfig.UI.ShowHiddenFiles != nil {
 		defaultConfig.UI.ShowHiddenFiles = *fileConfig.UI.ShowHiddenFiles
 	}
+	if fileConfig.UI.HideGitIgnored != nil {
+		defaultConfig.UI.HideGitIgnored = *fileConfig.UI.HideGitIgnored
+	}
 	if fileConfig.UI.Sort.SortBy != nil && *fileConfig.UI.Sort.SortBy != "" {
 		defaultConfig.UI.Sort.SortBy = *fileConfig.UI.Sort.SortBy
 	}
@@ -682,15 +970,72 @@ func mergeConfigs(defaultConfig *Config, fileConfig *rawConfig) error {
 	if fileConfig.UI.Sort.DirectoriesFirst != nil {
 		defaultConfig.UI.Sort.DirectoriesFirst = *fileConfig.UI.Sort.DirectoriesFirst
 	}
+	if fileConfig.UI.Sort.Natural != nil {
+		defaultConfig.UI.Sort.Natural = *fileConfig.UI.Sort.Natural
+	}
+	if fileConfig.UI.Sort.Locale != nil {
+		defaultConfig.UI.Sort.Locale = *fileConfig.UI.Sort.Locale
+	}
+	if fileConfig.UI.Sort.GroupBy != nil {
+		defaultConfig.UI.Sort.GroupBy = *fileConfig.UI.Sort.GroupBy
+	}
 	if fileConfig.UI.ItemSpacing != nil && *fileConfig.UI.ItemSpacing != 0 {
 		defaultConfig.UI.ItemSpacing = *fileConfig.UI.ItemSpacing
 	}
 	if fileConfig.UI.ScrollMargin != nil {
 		defaultConfig.UI.ScrollMargin = *fileConfig.UI.ScrollMargin
 	}
+	if fileConfig.UI.IconSet != nil {
+		defaultConfig.UI.IconSet = *fileConfig.UI.IconSet
+	}
+	if fileConfig.UI.OpenLinks != nil {
+		defaultConfig.UI.OpenLinks = *fileConfig.UI.OpenLinks
+	}
+	if fileConfig.UI.OpenDuplicates != nil {
+		defaultConfig.UI.OpenDuplicates = *fileConfig.UI.OpenDuplicates
+	}
+	if fileConfig.UI.AutoRefresh != nil {
+		defaultConfig.UI.AutoRefresh = *fileConfig.UI.AutoRefresh
+	}
+	if fileConfig.UI.WatchDebounceMs != nil && *fileConfig.UI.WatchDebounceMs > 0 {
+		defaultConfig.UI.WatchDebounceMs = *fileConfig.UI.WatchDebounceMs
+	}
+	if fileConfig.UI.StatWorkers != nil {
+		defaultConfig.UI.StatWorkers = *fileConfig.UI.StatWorkers
+	}
+	if fileConfig.UI.RemoteListingTTL != nil {
+		defaultConfig.UI.RemoteListingTTL = *fileConfig.UI.RemoteListingTTL
+	}
+	if fileConfig.UI.LargeFileWarnMB != nil {
+		defaultConfig.UI.LargeFileWarnMB = *fileConfig.UI.LargeFileWarnMB
+	}
 	if fileConfig.UI.Copy.PreserveTimestamps != nil {
 		defaultConfig.UI.Copy.PreserveTimestamps = *fileConfig.UI.Copy.PreserveTimestamps
 	}
+	if fileConfig.UI.Copy.PreserveOwnership != nil {
+		defaultConfig.UI.Copy.PreserveOwnership = *fileConfig.UI.Copy.PreserveOwnership
+	}
+	if fileConfig.UI.Copy.PreserveXattrs != nil {
+		defaultConfig.UI.Copy.PreserveXattrs = *fileConfig.UI.Copy.PreserveXattrs
+	}
+	if fileConfig.UI.Copy.Elevate != nil {
+		defaultConfig.UI.Copy.Elevate = *fileConfig.UI.Copy.Elevate
+	}
+	if fileConfig.UI.Copy.DuplicateName != nil {
+		defaultConfig.UI.Copy.DuplicateName = *fileConfig.UI.Copy.DuplicateName
+	}
+	if fileConfig.UI.Jobs.Workers != nil && *fileConfig.UI.Jobs.Workers > 0 {
+		defaultConfig.UI.Jobs.Workers = *fileConfig.UI.Jobs.Workers
+	}
+	if fileConfig.UI.Jobs.PerVolumeLimit != nil && *fileConfig.UI.Jobs.PerVolumeLimit >= 0 {
+		defaultConfig.UI.Jobs.PerVolumeLimit = *fileConfig.UI.Jobs.PerVolumeLimit
+	}
+	if fileConfig.UI.Jobs.ConfirmFiles != nil && *fileConfig.UI.Jobs.ConfirmFiles >= 0 {
+		defaultConfig.UI.Jobs.ConfirmFiles = *fileConfig.UI.Jobs.ConfirmFiles
+	}
+	if fileConfig.UI.Jobs.ConfirmGB != nil && *fileConfig.UI.Jobs.ConfirmGB >= 0 {
+		defaultConfig.UI.Jobs.ConfirmGB = *fileConfig.UI.Jobs.ConfirmGB
+	}
 	if fileConfig.UI.Viewer.MaxWidth != nil && *fileConfig.UI.Viewer.MaxWidth >= 0 {
 		defaultConfig.UI.Viewer.MaxWidth = *fileConfig.UI.Viewer.MaxWidth
 	}
@@ -708,9 +1053,57 @@ func mergeConfigs(defaultConfig *Config, fileConfig *rawConfig) error {
 	if fileConfig.UI.Archive.ZipNameEncoding != nil && strings.TrimSpace(*fileConfig.UI.Archive.ZipNameEncoding) != "" {
 		defaultConfig.UI.Archive.ZipNameEncoding = strings.TrimSpace(*fileConfig.UI.Archive.ZipNameEncoding)
 	}
+	if fileConfig.UI.Gio.Enabled != nil {
+		defaultConfig.UI.Gio.Enabled = *fileConfig.UI.Gio.Enabled
+	}
+	if fileConfig.UI.Gio.SMBKerberos != nil {
+		defaultConfig.UI.Gio.SMBKerberos = *fileConfig.UI.Gio.SMBKerberos
+	}
+	if fileConfig.UI.Vault.Enabled != nil {
+		defaultConfig.UI.Vault.Enabled = *fileConfig.UI.Vault.Enabled
+	}
+	if fileConfig.UI.AuditLog.Enabled != nil {
+		defaultConfig.UI.AuditLog.Enabled = *fileConfig.UI.AuditLog.Enabled
+	}
+	if fileConfig.UI.Vault.IdleTimeoutMinutes != nil && *fileConfig.UI.Vault.IdleTimeoutMinutes >= 0 {
+		defaultConfig.UI.Vault.IdleTimeoutMinutes = *fileConfig.UI.Vault.IdleTimeoutMinutes
+	}
+	if fileConfig.UI.Vault.SavePasswords != nil {
+		defaultConfig.UI.Vault.SavePasswords = *fileConfig.UI.Vault.SavePasswords
+	}
 	if fileConfig.UI.IME.Enabled != nil {
 		defaultConfig.UI.IME.Enabled = *fileConfig.UI.IME.Enabled
 	}
+	if fileConfig.UI.Metadata.ShowInList != nil {
+		defaultConfig.UI.Metadata.ShowInList = *fileConfig.UI.Metadata.ShowInList
+	}
+	if fileConfig.UI.PreviewPane.Visible != nil {
+		defaultConfig.UI.PreviewPane.Visible = *fileConfig.UI.PreviewPane.Visible
+	}
+	if fileConfig.UI.PreviewPane.Width != nil {
+		defaultConfig.UI.PreviewPane.Width = *fileConfig.UI.PreviewPane.Width
+	}
+	if fileConfig.UI.TreePane.Visible != nil {
+		defaultConfig.UI.TreePane.Visible = *fileConfig.UI.TreePane.Visible
+	}
+	if fileConfig.UI.TreePane.Width != nil {
+		defaultConfig.UI.TreePane.Width = *fileConfig.UI.TreePane.Width
+	}
+	if fileConfig.UI.Thumbnails.Enabled != nil {
+		defaultConfig.UI.Thumbnails.Enabled = *fileConfig.UI.Thumbnails.Enabled
+	}
+	if fileConfig.UI.Thumbnails.Size != nil {
+		defaultConfig.UI.Thumbnails.Size = *fileConfig.UI.Thumbnails.Size
+	}
+	if fileConfig.UI.Thumbnails.DiskCache != nil {
+		defaultConfig.UI.Thumbnails.DiskCache = *fileConfig.UI.Thumbnails.DiskCache
+	}
+	if fileConfig.UI.DiskUsage.WarnPercent != nil {
+		defaultConfig.UI.DiskUsage.WarnPercent = *fileConfig.UI.DiskUsage.WarnPercent
+	}
+	if fileConfig.UI.DiskUsage.RefreshSeconds != nil {
+		defaultConfig.UI.DiskUsage.RefreshSeconds = *fileConfig.UI.DiskUsage.RefreshSeconds
+	}
 
 	// Merge CursorStyle config
 	if fileConfig.UI.CursorStyle.Type != nil && *fileConfig.UI.CursorStyle.Type != "" {
@@ -724,6 +1117,9 @@ func mergeConfigs(defaultConfig *Config, fileConfig *rawConfig) error {
 	if fileConfig.UI.CursorMemory.MaxEntries != nil && *fileConfig.UI.CursorMemory.MaxEntries != 0 {
 		defaultConfig.UI.CursorMemory.MaxEntries = *fileConfig.UI.CursorMemory.MaxEntries
 	}
+	if fileConfig.UI.DirectoryViews.MaxEntries != nil && *fileConfig.UI.DirectoryViews.MaxEntries != 0 {
+		defaultConfig.UI.DirectoryViews.MaxEntries = *fileConfig.UI.DirectoryViews.MaxEntries
+	}
 
 	// Merge NavigationHistory config
 	if fileConfig.UI.NavigationHistory.MaxEntries != nil && *fileConfig.UI.NavigationHistory.MaxEntries != 0 {
@@ -734,6 +1130,9 @@ func mergeConfigs(defaultConfig *Config, fileConfig *rawConfig) error {
 	if fileConfig.UI.FileFilter.MaxEntries != nil && *fileConfig.UI.FileFilter.MaxEntries != 0 {
 		defaultConfig.UI.FileFilter.MaxEntries = *fileConfig.UI.FileFilter.MaxEntries
 	}
+	if fileConfig.UI.FileFilter.Presets != nil {
+		defaultConfig.UI.FileFilter.Presets = fileConfig.UI.FileFilter.Presets
+	}
 
 	// Merge DirectoryJumps config
 	if fileConfig.UI.DirectoryJumps.Entries != nil {
@@ -745,6 +1144,18 @@ func mergeConfigs(defaultConfig *Config, fileConfig *rawConfig) error {
 	if fileConfig.UI.ExternalCommands != nil {
 		defaultConfig.UI.ExternalCommands = fileConfig.UI.ExternalCommands
 	}
+	if fileConfig.UI.OpenWith != nil {
+		defaultConfig.UI.OpenWith = fileConfig.UI.OpenWith
+	}
+	if fileConfig.UI.ViewProfiles != nil {
+		defaultConfig.UI.ViewProfiles = fileConfig.UI.ViewProfiles
+	}
+	if fileConfig.UI.WatchRules != nil {
+		defaultConfig.UI.WatchRules = fileConfig.UI.WatchRules
+	}
+	if fileConfig.UI.Columns != nil {
+		defaultConfig.UI.Columns = fileConfig.UI.Columns
+	}
 	return nil
 }
 
@@ -764,18 +1175,68 @@ func validateRawConfig(cfg *rawConfig) error {
 	if cfg.Debug.MaxLogFiles != nil && *cfg.Debug.MaxLogFiles <= 0 {
 		return fmt.Errorf("debug.maxLogFiles must be positive")
 	}
+	if len(cfg.Integrations.Terminal.Args) > 0 && (cfg.Integrations.Terminal.Command == nil || strings.TrimSpace(*cfg.Integrations.Terminal.Command) == "") {
+		return fmt.Errorf("integrations.terminal.args needs integrations.terminal.command")
+	}
 	if cfg.UI.Sort.SortBy != nil && !IsValidSortBy(*cfg.UI.Sort.SortBy) {
-		return fmt.Errorf("ui.sort.sortBy must be name, size, modified, or extension")
+		return fmt.Errorf("ui.sort.sortBy must be name, size, modified, extension, dateTaken, or tag")
 	}
 	if cfg.UI.Sort.SortOrder != nil && !IsValidSortOrder(*cfg.UI.Sort.SortOrder) {
 		return fmt.Errorf("ui.sort.sortOrder must be asc or desc")
 	}
+	if cfg.UI.Sort.Locale != nil && !IsValidSortLocale(*cfg.UI.Sort.Locale) {
+		return fmt.Errorf("ui.sort.locale must be empty, auto, or a BCP 47 language tag")
+	}
+	if cfg.UI.Sort.GroupBy != nil && !IsValidGroupBy(*cfg.UI.Sort.GroupBy) {
+		return fmt.Errorf("ui.sort.groupBy must be empty or dateTaken")
+	}
 	if cfg.UI.ItemSpacing != nil && *cfg.UI.ItemSpacing < 0 {
 		return fmt.Errorf("ui.itemSpacing must be zero or positive")
 	}
 	if cfg.UI.ScrollMargin != nil && *cfg.UI.ScrollMargin < 0 {
 		return fmt.Errorf("ui.scrollMargin must be zero or positive")
 	}
+	if cfg.UI.IconSet != nil && !IsValidIconSet(*cfg.UI.IconSet) {
+		return fmt.Errorf("ui.iconSet must be native or mono")
+	}
+	if cfg.UI.OpenLinks != nil && !IsValidOpenLinks(*cfg.UI.OpenLinks) {
+		return fmt.Errorf("ui.openLinks must be ask, follow, or physical")
+	}
+	if cfg.UI.OpenDuplicates != nil && !IsValidOpenDuplicates(*cfg.UI.OpenDuplicates) {
+		return fmt.Errorf("ui.openDuplicates must be ask, switch, or open")
+	}
+	if cfg.UI.Copy.DuplicateName != nil {
+		if err := ValidateDuplicateNamePattern(*cfg.UI.Copy.DuplicateName); err != nil {
+			return fmt.Errorf("ui.copy.duplicateName: %w", err)
+		}
+	}
+	if cfg.UI.Jobs.Workers != nil && *cfg.UI.Jobs.Workers <= 0 {
+		return fmt.Errorf("ui.jobs.workers must be positive")
+	}
+	if cfg.UI.Jobs.PerVolumeLimit != nil && *cfg.UI.Jobs.PerVolumeLimit < 0 {
+		return fmt.Errorf("ui.jobs.perVolumeLimit must be zero or positive")
+	}
+	if cfg.UI.Jobs.ConfirmFiles != nil && *cfg.UI.Jobs.ConfirmFiles < 0 {
+		return fmt.Errorf("ui.jobs.confirmFiles must be zero or positive")
+	}
+	if cfg.UI.Jobs.ConfirmGB != nil && *cfg.UI.Jobs.ConfirmGB < 0 {
+		return fmt.Errorf("ui.jobs.confirmGB must be zero or positive")
+	}
+	if cfg.UI.PreviewPane.Width != nil && *cfg.UI.PreviewPane.Width <= 0 {
+		return fmt.Errorf("ui.previewPane.width must be positive")
+	}
+	if cfg.UI.TreePane.Width != nil && *cfg.UI.TreePane.Width <= 0 {
+		return fmt.Errorf("ui.treePane.width must be positive")
+	}
+	if cfg.UI.Thumbnails.Size != nil && !IsValidThumbnailSize(*cfg.UI.Thumbnails.Size) {
+		return fmt.Errorf("ui.thumbnails.size must be between %d and %d", MinThumbnailSize, MaxThumbnailSize)
+	}
+	if cfg.UI.DiskUsage.WarnPercent != nil && (*cfg.UI.DiskUsage.WarnPercent < 0 || *cfg.UI.DiskUsage.WarnPercent > 100) {
+		return fmt.Errorf("ui.diskUsage.warnPercent must be between 0 and 100")
+	}
+	if cfg.UI.DiskUsage.RefreshSeconds != nil && *cfg.UI.DiskUsage.RefreshSeconds < 0 {
+		return fmt.Errorf("ui.diskUsage.refreshSeconds must be zero or positive")
+	}
 	if cfg.UI.Viewer.MaxWidth != nil && *cfg.UI.Viewer.MaxWidth < 0 {
 		return fmt.Errorf("ui.viewer.maxWidth must be zero or positive")
 	}
@@ -788,6 +1249,21 @@ func validateRawConfig(cfg *rawConfig) error {
 	if cfg.UI.Archive.ZipNameEncoding != nil && strings.TrimSpace(*cfg.UI.Archive.ZipNameEncoding) == "" {
 		return fmt.Errorf("ui.archive.zipNameEncoding must not be empty")
 	}
+	if cfg.UI.WatchDebounceMs != nil && *cfg.UI.WatchDebounceMs < 0 {
+		return fmt.Errorf("ui.watchDebounceMs must be zero or positive")
+	}
+	if cfg.UI.StatWorkers != nil && (*cfg.UI.StatWorkers < 1 || *cfg.UI.StatWorkers > MaxStatWorkers) {
+		return fmt.Errorf("ui.statWorkers must be between 1 and %d", MaxStatWorkers)
+	}
+	if cfg.UI.RemoteListingTTL != nil && *cfg.UI.RemoteListingTTL < 0 {
+		return fmt.Errorf("ui.remoteListingTTLSeconds must be zero or positive")
+	}
+	if cfg.UI.LargeFileWarnMB != nil && *cfg.UI.LargeFileWarnMB < 0 {
+		return fmt.Errorf("ui.largeFileWarnMB must be zero or positive")
+	}
+	if cfg.UI.Vault.IdleTimeoutMinutes != nil && *cfg.UI.Vault.IdleTimeoutMinutes < 0 {
+		return fmt.Errorf("ui.vault.idleTimeoutMinutes must be zero or positive")
+	}
 	if cfg.UI.CursorStyle.Type != nil && !IsValidCursorStyleType(*cfg.UI.CursorStyle.Type) {
 		return fmt.Errorf("ui.cursorStyle.type must be underline, border, background, icon, or font")
 	}
@@ -797,25 +1273,135 @@ func validateRawConfig(cfg *rawConfig) error {
 	if cfg.UI.CursorMemory.MaxEntries != nil && *cfg.UI.CursorMemory.MaxEntries <= 0 {
 		return fmt.Errorf("ui.cursorMemory.maxEntries must be positive")
 	}
+	if cfg.UI.DirectoryViews.MaxEntries != nil && *cfg.UI.DirectoryViews.MaxEntries <= 0 {
+		return fmt.Errorf("ui.directoryViews.maxEntries must be positive")
+	}
 	if cfg.UI.NavigationHistory.MaxEntries != nil && *cfg.UI.NavigationHistory.MaxEntries <= 0 {
 		return fmt.Errorf("ui.navigationHistory.maxEntries must be positive")
 	}
 	if cfg.UI.FileFilter.MaxEntries != nil && *cfg.UI.FileFilter.MaxEntries <= 0 {
 		return fmt.Errorf("ui.fileFilter.maxEntries must be positive")
 	}
+	if err := ValidateViewProfiles(cfg.UI.ViewProfiles); err != nil {
+		return err
+	}
+	if err := ValidateFilterPresets(cfg.UI.FileFilter.Presets); err != nil {
+		return err
+	}
+	if err := ValidateWatchRules(cfg.UI.WatchRules); err != nil {
+		return err
+	}
+	if err := ValidateColumns(cfg.UI.Columns); err != nil {
+		return err
+	}
 	return nil
 }
 
 // IsValidSortBy reports whether value is a supported sort field.
 func IsValidSortBy(value string) bool {
 	switch value {
-	case "name", "size", "modified", "extension":
+	case "name", "size", "modified", "extension", "dateTaken", "tag":
 		return true
 	default:
 		return false
 	}
 }
 
+// IsValidGroupBy reports whether value is a supported grouping: empty for
+// none, or "dateTaken" for one group per capture day.
+func IsValidGroupBy(value string) bool {
+	return value == "" || value == "dateTaken"
+}
+
+// SortLocaleAuto selects the system locale for ui.sort.locale.
+const SortLocaleAuto = "auto"
+
+// IsValidSortLocale reports whether value is a supported sort locale: empty
+// for code point order, SortLocaleAuto, or a BCP 47 language tag.
+func IsValidSortLocale(value string) bool {
+	if value == "" || value == SortLocaleAuto {
+		return true
+	}
+	_, err := language.Parse(value)
+	return err == nil
+}
+
+// Icon sets for ui.iconSet.
+const (
+	IconSetNative = "native"
+	IconSetMono   = "mono"
+)
+
+// IsValidIconSet reports whether value is a supported icon set.
+func IsValidIconSet(value string) bool {
+	return value == IconSetNative || value == IconSetMono
+}
+
+// Ways to open a symlinked directory for ui.openLinks: ask each time, open
+// the link's own path, or open the directory it resolves to.
+const (
+	OpenLinksAsk      = "ask"
+	OpenLinksFollow   = "follow"
+	OpenLinksPhysical = "physical"
+)
+
+// IsValidOpenLinks reports whether value is a supported ui.openLinks mode.
+func IsValidOpenLinks(value string) bool {
+	return value == OpenLinksAsk || value == OpenLinksFollow || value == OpenLinksPhysical
+}
+
+// What entering a directory that another window or tab already shows does
+// for ui.openDuplicates: ask each time, switch to that window or tab, or load
+// it again here.
+const (
+	OpenDuplicatesAsk    = "ask"
+	OpenDuplicatesSwitch = "switch"
+	OpenDuplicatesOpen   = "open"
+)
+
+// IsValidOpenDuplicates reports whether value is a supported
+// ui.openDuplicates mode.
+func IsValidOpenDuplicates(value string) bool {
+	return value == OpenDuplicatesAsk || value == OpenDuplicatesSwitch || value == OpenDuplicatesOpen
+}
+
+// DefaultDuplicateNamePattern names the copies of "file.txt" "file (1).txt",
+// "file (2).txt", and so on.
+const DefaultDuplicateNamePattern = "{name} ({n}){ext}"
+
+// ValidateDuplicateNamePattern checks a ui.copy.duplicateName pattern: {name}
+// and {n} exactly once, {ext} at most once, and no path separators.
+func ValidateDuplicateNamePattern(pattern string) error {
+	if strings.Count(pattern, "{name}") != 1 {
+		return fmt.Errorf("pattern %q must contain {name} once", pattern)
+	}
+	if strings.Count(pattern, "{n}") != 1 {
+		return fmt.Errorf("pattern %q must contain {n} once", pattern)
+	}
+	if strings.Count(pattern, "{ext}") > 1 {
+		return fmt.Errorf("pattern %q must contain {ext} at most once", pattern)
+	}
+	if strings.ContainsAny(pattern, `/\`) {
+		return fmt.Errorf("pattern %q must not contain path separators", pattern)
+	}
+	return nil
+}
+
+// Bounds for ui.thumbnails.size.
+const (
+	MinThumbnailSize = 32
+	MaxThumbnailSize = 1024
+)
+
+// IsValidThumbnailSize reports whether size is a supported thumbnail edge.
+func IsValidThumbnailSize(size int) bool {
+	return size >= MinThumbnailSize && size <= MaxThumbnailSize
+}
+
+// MaxStatWorkers caps ui.statWorkers; more parallel stats than this only
+// crowd a file server.
+const MaxStatWorkers = 64
+
 // IsValidSortOrder reports whether value is a supported sort direction.
 func IsValidSortOrder(value string) bool {
 	return value == "asc" || value == "desc"
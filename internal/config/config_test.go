@@ -58,6 +58,24 @@ func TestGetDefaultConfig(t *testing.T) {
 	if config.UI.ShowHiddenFiles {
 		t.Error("Expected ShowHiddenFiles to be false by default")
 	}
+	if !config.UI.AutoRefresh {
+		t.Error("Expected AutoRefresh to be true by default")
+	}
+	if config.UI.WatchDebounceMs != 200 {
+		t.Errorf("Expected default WatchDebounceMs 200, got %d", config.UI.WatchDebounceMs)
+	}
+	if config.UI.StatWorkers != 8 {
+		t.Errorf("Expected default StatWorkers 8, got %d", config.UI.StatWorkers)
+	}
+	if config.UI.RemoteListingTTL != 30 {
+		t.Errorf("Expected default RemoteListingTTL 30, got %d", config.UI.RemoteListingTTL)
+	}
+	if config.UI.LargeFileWarnMB != 1024 {
+		t.Errorf("Expected default LargeFileWarnMB 1024, got %d", config.UI.LargeFileWarnMB)
+	}
+	if config.UI.DirectoryViews.MaxEntries != 200 {
+		t.Errorf("Expected default DirectoryViews.MaxEntries 200, got %d", config.UI.DirectoryViews.MaxEntries)
+	}
 	if config.UI.Sort.SortBy != "name" {
 		t.Errorf("Expected default sort by 'name', got '%s'", config.UI.Sort.SortBy)
 	}
@@ -73,8 +91,23 @@ func TestGetDefaultConfig(t *testing.T) {
 	if config.UI.ScrollMargin != 3 {
 		t.Errorf("Expected default scroll margin 3, got %d", config.UI.ScrollMargin)
 	}
-	if config.UI.Copy.PreserveTimestamps {
-		t.Error("Expected copy preserve timestamps to be disabled by default")
+	if !config.UI.Copy.PreserveTimestamps {
+		t.Error("Expected copy preserve timestamps to be enabled by default")
+	}
+	if config.UI.Copy.PreserveOwnership || config.UI.Copy.PreserveXattrs {
+		t.Error("Expected copy ownership and xattr preservation to be disabled by default")
+	}
+	if config.UI.Copy.Elevate {
+		t.Error("Expected copy elevation to be disabled by default")
+	}
+	if config.UI.Copy.DuplicateName != "{name} ({n}){ext}" {
+		t.Errorf("Expected default duplicate name pattern, got %q", config.UI.Copy.DuplicateName)
+	}
+	if config.UI.Jobs.Workers != 2 || config.UI.Jobs.PerVolumeLimit != 1 {
+		t.Errorf("Expected default jobs scheduler 2 workers/1 per volume, got %d/%d", config.UI.Jobs.Workers, config.UI.Jobs.PerVolumeLimit)
+	}
+	if config.UI.Jobs.ConfirmFiles != 1000 || config.UI.Jobs.ConfirmGB != 10 {
+		t.Errorf("Expected default double confirmation above 1000 files/10 GiB, got %d/%d", config.UI.Jobs.ConfirmFiles, config.UI.Jobs.ConfirmGB)
 	}
 	if config.UI.Viewer.MaxWidth != 0 || config.UI.Viewer.MaxHeight != 0 {
 		t.Errorf("Expected default viewer max size 0x0, got %dx%d", config.UI.Viewer.MaxWidth, config.UI.Viewer.MaxHeight)
@@ -91,6 +124,36 @@ func TestGetDefaultConfig(t *testing.T) {
 	if !config.UI.IME.Enabled {
 		t.Error("Expected IME integration to be enabled by default")
 	}
+	if !config.UI.Gio.Enabled {
+		t.Error("Expected gio integration to be enabled by default")
+	}
+	if !config.UI.Gio.SMBKerberos {
+		t.Error("Expected Kerberos SMB mounts to be enabled by default")
+	}
+	if !config.UI.Vault.Enabled || config.UI.Vault.IdleTimeoutMinutes != 15 || config.UI.Vault.SavePasswords {
+		t.Errorf("Expected vault defaults enabled, 15 minutes, no saved passwords, got %+v", config.UI.Vault)
+	}
+	if config.UI.AuditLog.Enabled {
+		t.Error("Expected the audit log to be disabled by default")
+	}
+	if config.UI.Metadata.ShowInList {
+		t.Error("Expected metadata list column to be disabled by default")
+	}
+	if config.UI.PreviewPane.Visible || config.UI.PreviewPane.Width != 320 {
+		t.Errorf("Expected hidden 320px preview pane by default, got %+v", config.UI.PreviewPane)
+	}
+	if config.UI.TreePane.Visible || config.UI.TreePane.Width != 240 {
+		t.Errorf("Expected hidden 240px tree pane by default, got %+v", config.UI.TreePane)
+	}
+	if config.UI.Thumbnails.Enabled || config.UI.Thumbnails.Size != 128 || !config.UI.Thumbnails.DiskCache {
+		t.Errorf("Expected list mode with disk-cached 128px thumbnails by default, got %+v", config.UI.Thumbnails)
+	}
+	if config.UI.DiskUsage.WarnPercent != 10 || config.UI.DiskUsage.RefreshSeconds != 30 {
+		t.Errorf("Expected disk usage warning below 10%% refreshed every 30s by default, got %+v", config.UI.DiskUsage)
+	}
+	if len(config.UI.Columns) != 0 {
+		t.Errorf("Expected compact rows without columns by default, got %q", config.UI.Columns)
+	}
 
 	// Test CursorStyle defaults
 	if config.UI.CursorStyle.Type != "underline" {
@@ -128,6 +191,15 @@ func TestGetDefaultConfig(t *testing.T) {
 	if config.UI.ExternalCommands == nil {
 		t.Error("Expected external commands to be initialized")
 	}
+	if config.UI.OpenWith == nil {
+		t.Error("Expected open with entries to be initialized")
+	}
+	if config.UI.ViewProfiles == nil {
+		t.Error("Expected view profiles to be initialized")
+	}
+	if config.UI.WatchRules == nil || len(config.UI.WatchRules) != 0 {
+		t.Errorf("Expected no watch rules by default, got %+v", config.UI.WatchRules)
+	}
 }
 
 func TestMergeConfigsWindowPositionAndStartupDirectory(t *testing.T) {
@@ -135,6 +207,7 @@ func TestMergeConfigsWindowPositionAndStartupDirectory(t *testing.T) {
 	x := 1920
 	y := -40
 	directory := "  ~/work  "
+	restoreTabs := true
 
 	mergeConfigs(cfg, &rawConfig{
 		Window: rawWindowConfig{
@@ -142,7 +215,8 @@ func TestMergeConfigsWindowPositionAndStartupDirectory(t *testing.T) {
 			Y: &y,
 		},
 		Startup: rawStartupConfig{
-			Directory: &directory,
+			Directory:   &directory,
+			RestoreTabs: &restoreTabs,
 		},
 	})
 
@@ -152,6 +226,9 @@ func TestMergeConfigsWindowPositionAndStartupDirectory(t *testing.T) {
 	if cfg.Startup.Directory != "~/work" {
 		t.Fatalf("startup directory = %q, want ~/work", cfg.Startup.Directory)
 	}
+	if !cfg.Startup.RestoreTabs {
+		t.Fatal("startup restoreTabs = false, want true")
+	}
 }
 
 func TestMergeConfigsMergesMaxEntriesForTrimmedSections(t *testing.T) {
@@ -163,7 +240,7 @@ func TestMergeConfigsMergesMaxEntriesForTrimmedSections(t *testing.T) {
 		UI: rawUIConfig{
 			CursorMemory:      rawCursorMemoryConfig{MaxEntries: &cursorMax},
 			NavigationHistory: rawNavigationHistoryConfig{MaxEntries: &historyMax},
-			FileFilter:        rawFileFilterConfig{MaxEntries: &filterMax},
+			FileFilter:        rawFileFilterConfig{MaxEntries: &filterMax, Presets: []FilterPreset{{Name: "big", MinSize: "1G"}}},
 		},
 	}
 
@@ -178,6 +255,9 @@ func TestMergeConfigsMergesMaxEntriesForTrimmedSections(t *testing.T) {
 	if cfg.UI.FileFilter.MaxEntries != 7 {
 		t.Errorf("file filter max entries = %d, want 7", cfg.UI.FileFilter.MaxEntries)
 	}
+	if len(cfg.UI.FileFilter.Presets) != 1 || cfg.UI.FileFilter.Presets[0].Name != "big" {
+		t.Errorf("file filter presets = %+v, want big", cfg.UI.FileFilter.Presets)
+	}
 }
 
 func TestMergeConfigsAllowsZeroScrollMargin(t *testing.T) {
@@ -194,6 +274,158 @@ func TestMergeConfigsAllowsZeroScrollMargin(t *testing.T) {
 	}
 }
 
+func TestIconSetDefaultsToNativeAndValidates(t *testing.T) {
+	cfg := getDefaultConfig()
+	if cfg.UI.IconSet != IconSetNative {
+		t.Fatalf("default icon set = %q, want %q", cfg.UI.IconSet, IconSetNative)
+	}
+	mono := IconSetMono
+	if err := mergeConfigs(cfg, &rawConfig{UI: rawUIConfig{IconSet: &mono}}); err != nil {
+		t.Fatalf("mergeConfigs returned error: %v", err)
+	}
+	if cfg.UI.IconSet != IconSetMono {
+		t.Fatalf("icon set = %q, want %q", cfg.UI.IconSet, IconSetMono)
+	}
+	unknown := "color"
+	if err := validateRawConfig(&rawConfig{UI: rawUIConfig{IconSet: &unknown}}); err == nil {
+		t.Fatal("unknown icon set should be rejected")
+	}
+}
+
+func TestOpenLinksDefaultsToAskAndValidates(t *testing.T) {
+	cfg := getDefaultConfig()
+	if cfg.UI.OpenLinks != OpenLinksAsk {
+		t.Fatalf("default openLinks = %q, want %q", cfg.UI.OpenLinks, OpenLinksAsk)
+	}
+	physical := OpenLinksPhysical
+	if err := mergeConfigs(cfg, &rawConfig{UI: rawUIConfig{OpenLinks: &physical}}); err != nil {
+		t.Fatalf("mergeConfigs returned error: %v", err)
+	}
+	if cfg.UI.OpenLinks != OpenLinksPhysical {
+		t.Fatalf("openLinks = %q, want %q", cfg.UI.OpenLinks, OpenLinksPhysical)
+	}
+	unknown := "logical"
+	if err := validateRawConfig(&rawConfig{UI: rawUIConfig{OpenLinks: &unknown}}); err == nil {
+		t.Fatal("unknown openLinks mode should be rejected")
+	}
+}
+
+func TestTerminalIntegrationMergesAndValidates(t *testing.T) {
+	cfg := getDefaultConfig()
+	if cfg.Integrations.Terminal.Command != "" || cfg.Integrations.Terminal.Args != nil {
+		t.Fatalf("default terminal = %+v, want the per-OS default", cfg.Integrations.Terminal)
+	}
+	command := " wezterm "
+	raw := &rawConfig{Integrations: rawIntegrationsConfig{Terminal: rawTerminalConfig{Command: &command, Args: []string{"start", "--cwd", "{dir}"}}}}
+	if err := validateRawConfig(raw); err != nil {
+		t.Fatalf("validateRawConfig returned error: %v", err)
+	}
+	if err := mergeConfigs(cfg, raw); err != nil {
+		t.Fatalf("mergeConfigs returned error: %v", err)
+	}
+	if cfg.Integrations.Terminal.Command != "wezterm" || len(cfg.Integrations.Terminal.Args) != 3 {
+		t.Fatalf("terminal = %+v", cfg.Integrations.Terminal)
+	}
+	if err := validateRawConfig(&rawConfig{Integrations: rawIntegrationsConfig{Terminal: rawTerminalConfig{Args: []string{"{dir}"}}}}); err == nil {
+		t.Fatal("terminal args without a command should be rejected")
+	}
+}
+
+func TestOpenDuplicatesDefaultsToAskAndValidates(t *testing.T) {
+	cfg := getDefaultConfig()
+	if cfg.UI.OpenDuplicates != OpenDuplicatesAsk {
+		t.Fatalf("default openDuplicates = %q, want %q", cfg.UI.OpenDuplicates, OpenDuplicatesAsk)
+	}
+	open := OpenDuplicatesOpen
+	if err := mergeConfigs(cfg, &rawConfig{UI: rawUIConfig{OpenDuplicates: &open}}); err != nil {
+		t.Fatalf("mergeConfigs returned error: %v", err)
+	}
+	if cfg.UI.OpenDuplicates != OpenDuplicatesOpen {
+		t.Fatalf("openDuplicates = %q, want %q", cfg.UI.OpenDuplicates, OpenDuplicatesOpen)
+	}
+	unknown := "focus"
+	if err := validateRawConfig(&rawConfig{UI: rawUIConfig{OpenDuplicates: &unknown}}); err == nil {
+		t.Fatal("unknown openDuplicates mode should be rejected")
+	}
+}
+
+func TestValidateRawConfigBoundsStatWorkers(t *testing.T) {
+	for _, workers := range []int{0, MaxStatWorkers + 1} {
+		if err := validateRawConfig(&rawConfig{UI: rawUIConfig{StatWorkers: &workers}}); err == nil {
+			t.Fatalf("statWorkers %d should be rejected", workers)
+		}
+	}
+	workers := 1
+	if err := validateRawConfig(&rawConfig{UI: rawUIConfig{StatWorkers: &workers}}); err != nil {
+		t.Fatalf("statWorkers 1 rejected: %v", err)
+	}
+}
+
+func TestValidateRawConfigRejectsNegativeRemoteListingTTL(t *testing.T) {
+	ttl := -1
+	if err := validateRawConfig(&rawConfig{UI: rawUIConfig{RemoteListingTTL: &ttl}}); err == nil {
+		t.Fatal("remoteListingTTLSeconds -1 should be rejected")
+	}
+	ttl = 0
+	if err := validateRawConfig(&rawConfig{UI: rawUIConfig{RemoteListingTTL: &ttl}}); err != nil {
+		t.Fatalf("remoteListingTTLSeconds 0 rejected: %v", err)
+	}
+}
+
+func TestValidateRawConfigBoundsDiskUsage(t *testing.T) {
+	for _, percent := range []int{-1, 101} {
+		if err := validateRawConfig(&rawConfig{UI: rawUIConfig{DiskUsage: rawDiskUsageConfig{WarnPercent: &percent}}}); err == nil {
+			t.Fatalf("warnPercent %d should be rejected", percent)
+		}
+	}
+	seconds := -1
+	if err := validateRawConfig(&rawConfig{UI: rawUIConfig{DiskUsage: rawDiskUsageConfig{RefreshSeconds: &seconds}}}); err == nil {
+		t.Fatal("negative refreshSeconds should be rejected")
+	}
+}
+
+func TestValidateRawConfigChecksDuplicateNamePattern(t *testing.T) {
+	for _, pattern := range []string{"{name}_copy{n}{ext}", "Copy {n} of {name}"} {
+		if err := validateRawConfig(&rawConfig{UI: rawUIConfig{Copy: rawCopyConfig{DuplicateName: &pattern}}}); err != nil {
+			t.Fatalf("duplicateName %q rejected: %v", pattern, err)
+		}
+	}
+	for _, pattern := range []string{"", "{name}", "{name} ({n}) ({n})", "{name}{n}{ext}{ext}", "{name}/{n}"} {
+		if err := validateRawConfig(&rawConfig{UI: rawUIConfig{Copy: rawCopyConfig{DuplicateName: &pattern}}}); err == nil {
+			t.Fatalf("duplicateName %q should be rejected", pattern)
+		}
+	}
+}
+
+func TestMergeConfigsAllowsUnlimitedJobsPerVolume(t *testing.T) {
+	cfg := getDefaultConfig()
+	workers := 4
+	perVolume := 0
+
+	if err := mergeConfigs(cfg, &rawConfig{
+		UI: rawUIConfig{Jobs: rawJobsConfig{Workers: &workers, PerVolumeLimit: &perVolume}},
+	}); err != nil {
+		t.Fatalf("mergeConfigs returned error: %v", err)
+	}
+	if cfg.UI.Jobs.Workers != 4 || cfg.UI.Jobs.PerVolumeLimit != 0 {
+		t.Fatalf("jobs config = %+v, want 4 workers and unlimited per volume", cfg.UI.Jobs)
+	}
+}
+
+func TestMergeConfigsRejectsNegativeConfirmThreshold(t *testing.T) {
+	cfg := getDefaultConfig()
+	files := -1
+
+	if err := mergeConfigs(cfg, &rawConfig{
+		UI: rawUIConfig{Jobs: rawJobsConfig{ConfirmFiles: &files}},
+	}); err == nil {
+		t.Fatal("mergeConfigs should reject a negative confirmFiles")
+	}
+	if cfg.UI.Jobs.ConfirmFiles != 1000 {
+		t.Fatalf("confirmFiles = %d, want unchanged 1000", cfg.UI.Jobs.ConfirmFiles)
+	}
+}
+
 func TestMergeConfigsRejectsNegativeViewerMaxSize(t *testing.T) {
 	cfg := getDefaultConfig()
 	cfg.UI.Viewer.MaxWidth = 1000
@@ -286,20 +518,31 @@ func TestMergeConfigs(t *testing.T) {
 	falseVal := false
 	sortBy := "size"
 	sortOrder := "desc"
+	sortLocale := "auto"
+	groupBy := "dateTaken"
 	border := "border"
 	path := "/path/to/font.ttf"
 	fontName := "Noto Sans CJK JP"
 	monospacePath := "/path/to/mono.ttf"
 	monospaceFontName := "UDEV Gothic"
 	itemSpacing := 8
+	watchDebounceMs := 500
+	statWorkers := 16
+	largeFileWarnMB := 0
 	scrollMargin := 6
-	preserveTimestamps := true
+	preserveTimestamps := false
 	viewerMaxWidth := 1200
 	viewerMaxHeight := 900
 	viewerDefaultPane := "text"
 	viewerDefaultWrap := true
 	zipNameEncoding := "cp437"
 	imeEnabled := false
+	metadataShowInList := true
+	previewPaneWidth := 480
+	treePaneWidth := 300
+	thumbnailSize := 256
+	diskWarnPercent := 5
+	diskRefreshSeconds := 0
 	fontSize := 16
 	width := 1024
 	height := 768
@@ -326,15 +569,25 @@ func TestMergeConfigs(t *testing.T) {
 		},
 		UI: rawUIConfig{
 			ShowHiddenFiles: &trueVal,
+			HideGitIgnored:  &trueVal,
+			AutoRefresh:     &falseVal,
+			WatchDebounceMs: &watchDebounceMs,
+			StatWorkers:     &statWorkers,
+			LargeFileWarnMB: &largeFileWarnMB,
 			Sort: rawSortConfig{
 				SortBy:           &sortBy,
 				SortOrder:        &sortOrder,
 				DirectoriesFirst: &falseVal,
+				Natural:          &trueVal,
+				Locale:           &sortLocale,
+				GroupBy:          &groupBy,
 			},
 			ItemSpacing:  &itemSpacing,
 			ScrollMargin: &scrollMargin,
 			Copy: rawCopyConfig{
 				PreserveTimestamps: &preserveTimestamps,
+				PreserveXattrs:     &trueVal,
+				Elevate:            &trueVal,
 			},
 			Viewer: rawViewerConfig{
 				MaxWidth:    &viewerMaxWidth,
@@ -348,6 +601,26 @@ func TestMergeConfigs(t *testing.T) {
 			IME: rawIMEConfig{
 				Enabled: &imeEnabled,
 			},
+			Metadata: rawMetadataConfig{
+				ShowInList: &metadataShowInList,
+			},
+			PreviewPane: rawPreviewPaneConfig{
+				Visible: &trueVal,
+				Width:   &previewPaneWidth,
+			},
+			TreePane: rawTreePaneConfig{
+				Visible: &trueVal,
+				Width:   &treePaneWidth,
+			},
+			Thumbnails: rawThumbnailsConfig{
+				Enabled:   &trueVal,
+				Size:      &thumbnailSize,
+				DiskCache: &falseVal,
+			},
+			DiskUsage: rawDiskUsageConfig{
+				WarnPercent:    &diskWarnPercent,
+				RefreshSeconds: &diskRefreshSeconds,
+			},
 			CursorStyle: rawCursorStyleConfig{
 				Type:      &border,
 				Thickness: &thickness,
@@ -365,6 +638,15 @@ func TestMergeConfigs(t *testing.T) {
 			ExternalCommands: []ExternalCommandEntry{
 				{Name: "Open in editor", Extensions: []string{".go"}, Command: "vim", Args: []string{"{file}"}, Cwd: "{dir}"},
 			},
+			OpenWith: []OpenWithEntry{
+				{Name: "GIMP", Extensions: []string{"png"}, Command: "gimp", Args: []string{"%F"}},
+			},
+			ViewProfiles: []ViewProfile{
+				{Name: "photos", Directories: []string{"~/Pictures/**"}, Filter: "*.jpg"},
+			},
+			WatchRules: []WatchRule{
+				{Name: "pdf", Directories: []string{"~/Downloads"}, Patterns: []string{"*.pdf"}},
+			},
 		},
 	}
 
@@ -398,6 +680,21 @@ func TestMergeConfigs(t *testing.T) {
 	if defaultConfig.UI.ShowHiddenFiles != true {
 		t.Error("Expected merged ShowHiddenFiles to be true")
 	}
+	if !defaultConfig.UI.HideGitIgnored {
+		t.Error("Expected merged HideGitIgnored to be true")
+	}
+	if defaultConfig.UI.AutoRefresh {
+		t.Error("Expected merged AutoRefresh to be false")
+	}
+	if defaultConfig.UI.WatchDebounceMs != 500 {
+		t.Errorf("Expected merged WatchDebounceMs 500, got %d", defaultConfig.UI.WatchDebounceMs)
+	}
+	if defaultConfig.UI.StatWorkers != 16 {
+		t.Errorf("Expected merged StatWorkers 16, got %d", defaultConfig.UI.StatWorkers)
+	}
+	if defaultConfig.UI.LargeFileWarnMB != 0 {
+		t.Errorf("Expected merged LargeFileWarnMB 0, got %d", defaultConfig.UI.LargeFileWarnMB)
+	}
 	if defaultConfig.UI.Sort.SortBy != "size" {
 		t.Errorf("Expected merged sort by 'size', got '%s'", defaultConfig.UI.Sort.SortBy)
 	}
@@ -407,11 +704,20 @@ func TestMergeConfigs(t *testing.T) {
 	if defaultConfig.UI.Sort.DirectoriesFirst != false {
 		t.Error("Expected merged DirectoriesFirst to be false")
 	}
+	if !defaultConfig.UI.Sort.Natural || defaultConfig.UI.Sort.Locale != "auto" || defaultConfig.UI.Sort.GroupBy != "dateTaken" {
+		t.Errorf("Expected merged natural auto-locale sort grouped by date taken, got %+v", defaultConfig.UI.Sort)
+	}
 	if defaultConfig.UI.ScrollMargin != 6 {
 		t.Errorf("Expected merged scroll margin 6, got %d", defaultConfig.UI.ScrollMargin)
 	}
-	if !defaultConfig.UI.Copy.PreserveTimestamps {
-		t.Error("Expected merged copy preserve timestamps to be true")
+	if defaultConfig.UI.Copy.PreserveTimestamps {
+		t.Error("Expected merged copy preserve timestamps to be false")
+	}
+	if !defaultConfig.UI.Copy.PreserveXattrs || defaultConfig.UI.Copy.PreserveOwnership {
+		t.Errorf("Expected merged xattr preservation only, got %+v", defaultConfig.UI.Copy)
+	}
+	if !defaultConfig.UI.Copy.Elevate {
+		t.Error("Expected merged copy elevation to be true")
 	}
 	if defaultConfig.UI.Viewer.MaxWidth != 1200 || defaultConfig.UI.Viewer.MaxHeight != 900 {
 		t.Errorf("Expected merged viewer max size 1200x900, got %dx%d", defaultConfig.UI.Viewer.MaxWidth, defaultConfig.UI.Viewer.MaxHeight)
@@ -428,6 +734,21 @@ func TestMergeConfigs(t *testing.T) {
 	if defaultConfig.UI.IME.Enabled {
 		t.Error("Expected merged IME integration to be disabled")
 	}
+	if !defaultConfig.UI.Metadata.ShowInList {
+		t.Error("Expected merged metadata list column to be enabled")
+	}
+	if !defaultConfig.UI.PreviewPane.Visible || defaultConfig.UI.PreviewPane.Width != 480 {
+		t.Errorf("Expected merged visible 480px preview pane, got %+v", defaultConfig.UI.PreviewPane)
+	}
+	if !defaultConfig.UI.TreePane.Visible || defaultConfig.UI.TreePane.Width != 300 {
+		t.Errorf("Expected merged visible 300px tree pane, got %+v", defaultConfig.UI.TreePane)
+	}
+	if !defaultConfig.UI.Thumbnails.Enabled || defaultConfig.UI.Thumbnails.Size != 256 || defaultConfig.UI.Thumbnails.DiskCache {
+		t.Errorf("Expected merged 256px thumbnails without disk cache, got %+v", defaultConfig.UI.Thumbnails)
+	}
+	if defaultConfig.UI.DiskUsage.WarnPercent != 5 || defaultConfig.UI.DiskUsage.RefreshSeconds != 0 {
+		t.Errorf("Expected merged disk usage warning below 5%% without periodic refresh, got %+v", defaultConfig.UI.DiskUsage)
+	}
 	if defaultConfig.UI.CursorStyle.Type != "border" {
 		t.Errorf("Expected merged cursor type 'border', got '%s'", defaultConfig.UI.CursorStyle.Type)
 	}
@@ -445,6 +766,15 @@ func TestMergeConfigs(t *testing.T) {
 	if len(defaultConfig.UI.ExternalCommands) != 1 || defaultConfig.UI.ExternalCommands[0].Command != "vim" || defaultConfig.UI.ExternalCommands[0].Cwd != "{dir}" {
 		t.Errorf("Expected external commands to be merged, got %+v", defaultConfig.UI.ExternalCommands)
 	}
+	if len(defaultConfig.UI.OpenWith) != 1 || defaultConfig.UI.OpenWith[0].Command != "gimp" || defaultConfig.UI.OpenWith[0].Args[0] != "%F" {
+		t.Errorf("Expected open with entries to be merged, got %+v", defaultConfig.UI.OpenWith)
+	}
+	if len(defaultConfig.UI.ViewProfiles) != 1 || defaultConfig.UI.ViewProfiles[0].Filter != "*.jpg" {
+		t.Errorf("Expected view profiles to be merged, got %+v", defaultConfig.UI.ViewProfiles)
+	}
+	if len(defaultConfig.UI.WatchRules) != 1 || defaultConfig.UI.WatchRules[0].Patterns[0] != "*.pdf" {
+		t.Errorf("Expected watch rules to be merged, got %+v", defaultConfig.UI.WatchRules)
+	}
 }
 
 func TestThemeColorConfigUnmarshal(t *testing.T) {
@@ -591,6 +921,7 @@ func TestManagerLoadRejectsInvalidValues(t *testing.T) {
 		{name: "scroll margin", json: `{"ui":{"scrollMargin":-1}}`, want: "ui.scrollMargin"},
 		{name: "cursor entries", json: `{"ui":{"cursorMemory":{"maxEntries":-1}}}`, want: "ui.cursorMemory.maxEntries"},
 		{name: "viewer size", json: `{"ui":{"viewer":{"maxWidth":-1}}}`, want: "ui.viewer.maxWidth"},
+		{name: "job workers", json: `{"ui":{"jobs":{"workers":0}}}`, want: "ui.jobs.workers"},
 	}
 
 	for _, tt := range tests {
@@ -609,12 +940,18 @@ func TestManagerLoadRejectsInvalidValues(t *testing.T) {
 }
 
 func TestSharedConfigValueValidators(t *testing.T) {
-	if !IsValidSortBy("modified") || IsValidSortBy("random") {
+	if !IsValidSortBy("modified") || !IsValidSortBy("dateTaken") || !IsValidSortBy("tag") || IsValidSortBy("random") {
 		t.Fatal("sort field validator returned an unexpected result")
 	}
 	if !IsValidSortOrder("desc") || IsValidSortOrder("sideways") {
 		t.Fatal("sort order validator returned an unexpected result")
 	}
+	if !IsValidSortLocale("") || !IsValidSortLocale("auto") || !IsValidSortLocale("de-DE") || IsValidSortLocale("not a locale") {
+		t.Fatal("sort locale validator returned an unexpected result")
+	}
+	if !IsValidGroupBy("") || !IsValidGroupBy("dateTaken") || IsValidGroupBy("modified") {
+		t.Fatal("group validator returned an unexpected result")
+	}
 	if !IsValidCursorStyleType("border") || IsValidCursorStyleType("blink") {
 		t.Fatal("cursor style validator returned an unexpected result")
 	}
@@ -0,0 +1,97 @@
+package config
+
+import "time"
+
+// DirectoryView holds the view settings saved for one directory. Only the
+// sort is saved so far.
+type DirectoryView struct {
+	Sort SortConfig `json:"sort"`
+}
+
+// DirectoryViewState holds the per-directory view overrides (state.json).
+// The least recently used entries are evicted once there are more than
+// DirectoryViewsConfig.MaxEntries, as with CursorMemoryState.
+type DirectoryViewState struct {
+	Entries  map[string]DirectoryView `json:"entries"`  // key: dirPath
+	LastUsed map[string]time.Time     `json:"lastUsed"` // LRU management
+}
+
+// LookupDirectoryView returns the view saved for dir and marks it used.
+func (s *State) LookupDirectoryView(dir string) (DirectoryView, bool) {
+	if s == nil {
+		return DirectoryView{}, false
+	}
+	views := &s.DirectoryViews
+	view, ok := views.Entries[dir]
+	if !ok {
+		return DirectoryView{}, false
+	}
+	ensureDirectoryViewState(views)
+	views.LastUsed[dir] = time.Now()
+	return view, true
+}
+
+// SetDirectoryView saves view for dir and evicts the least recently used
+// entries beyond maxEntries.
+func (s *State) SetDirectoryView(dir string, view DirectoryView, maxEntries int) {
+	views := &s.DirectoryViews
+	ensureDirectoryViewState(views)
+	views.Entries[dir] = view
+	views.LastUsed[dir] = time.Now()
+	for maxEntries > 0 && len(views.Entries) > maxEntries {
+		oldest := ""
+		var oldestTime time.Time
+		for path := range views.Entries {
+			if path == dir {
+				continue
+			}
+			used := views.LastUsed[path]
+			if oldest == "" || used.Before(oldestTime) || (used.Equal(oldestTime) && path < oldest) {
+				oldest, oldestTime = path, used
+			}
+		}
+		if oldest == "" {
+			break
+		}
+		delete(views.Entries, oldest)
+		delete(views.LastUsed, oldest)
+	}
+}
+
+// ClearDirectoryView removes the view saved for dir and reports whether
+// there was one.
+func (s *State) ClearDirectoryView(dir string) bool {
+	views := &s.DirectoryViews
+	if _, ok := views.Entries[dir]; !ok {
+		return false
+	}
+	delete(views.Entries, dir)
+	delete(views.LastUsed, dir)
+	return true
+}
+
+func ensureDirectoryViewState(views *DirectoryViewState) {
+	if views.Entries == nil {
+		views.Entries = make(map[string]DirectoryView)
+	}
+	if views.LastUsed == nil {
+		views.LastUsed = make(map[string]time.Time)
+	}
+}
+
+func cloneDirectoryViewState(src DirectoryViewState) DirectoryViewState {
+	clone := src
+	if src.Entries != nil {
+		clone.Entries = make(map[string]DirectoryView, len(src.Entries))
+		for k, v := range src.Entries {
+			clone.Entries[k] = v
+		}
+	}
+	if src.LastUsed != nil {
+		clone.LastUsed = make(map[string]time.Time, len(src.LastUsed))
+		for k, v := range src.LastUsed {
+			clone.LastUsed[k] = v
+		}
+	}
+	return clone
+}
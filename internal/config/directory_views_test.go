@@ -0,0 +1,82 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestSetDirectoryViewEvictsLeastRecentlyUsed(t *testing.T) {
+	state := newDefaultState()
+	byModified := SortConfig{SortBy: "modified", SortOrder: "desc", DirectoriesFirst: true}
+	state.SetDirectoryView("/a", DirectoryView{Sort: byModified}, 2)
+	state.SetDirectoryView("/b", DirectoryView{Sort: byModified}, 2)
+	state.DirectoryViews.LastUsed["/a"] = time.Now().Add(-time.Hour)
+	state.DirectoryViews.LastUsed["/b"] = time.Now().Add(-2 * time.Hour)
+
+	// Looking /b up makes it the most recently used, so /a goes first.
+	if view, ok := state.LookupDirectoryView("/b"); !ok || view.Sort != byModified {
+		t.Fatalf("LookupDirectoryView(/b) = %+v, %t", view, ok)
+	}
+	state.SetDirectoryView("/c", DirectoryView{Sort: byModified}, 2)
+
+	if _, ok := state.DirectoryViews.Entries["/a"]; ok {
+		t.Fatal("least recently used entry /a was kept")
+	}
+	for _, dir := range []string{"/b", "/c"} {
+		if _, ok := state.LookupDirectoryView(dir); !ok {
+			t.Fatalf("entry %s was evicted", dir)
+		}
+	}
+	if len(state.DirectoryViews.LastUsed) != 2 {
+		t.Fatalf("lastUsed = %v, want two entries", state.DirectoryViews.LastUsed)
+	}
+}
+
+func TestClearDirectoryView(t *testing.T) {
+	state := newDefaultState()
+	state.SetDirectoryView("/downloads", DirectoryView{Sort: SortConfig{SortBy: "size", SortOrder: "asc"}}, 10)
+
+	if !state.ClearDirectoryView("/downloads") {
+		t.Fatal("ClearDirectoryView reported no saved view")
+	}
+	if _, ok := state.LookupDirectoryView("/downloads"); ok {
+		t.Fatal("view still saved after ClearDirectoryView")
+	}
+	if state.ClearDirectoryView("/downloads") {
+		t.Fatal("second ClearDirectoryView reported a saved view")
+	}
+}
+
+func TestDirectoryViewsSurviveCloneAndLoad(t *testing.T) {
+	state := newDefaultState()
+	sortCfg := SortConfig{SortBy: "modified", SortOrder: "desc", DirectoriesFirst: true}
+	state.SetDirectoryView("/downloads", DirectoryView{Sort: sortCfg}, 10)
+
+	clone := cloneState(state)
+	clone.SetDirectoryView("/other", DirectoryView{}, 10)
+	if _, ok := state.DirectoryViews.Entries["/other"]; ok {
+		t.Fatal("clone shares its directory views with the original")
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loaded State
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	normalizeState(&loaded)
+	if view, ok := loaded.LookupDirectoryView("/downloads"); !ok || view.Sort != sortCfg {
+		t.Fatalf("loaded view = %+v, %t, want %+v", view, ok, sortCfg)
+	}
+
+	// A state.json from before directory views loads with usable maps.
+	var old State
+	if err := json.Unmarshal([]byte(`{"cursorMemory":{}}`), &old); err != nil {
+		t.Fatal(err)
+	}
+	normalizeState(&old)
+	old.SetDirectoryView("/x", DirectoryView{Sort: sortCfg}, 10)
+}
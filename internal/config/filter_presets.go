@@ -0,0 +1,247 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// FilterPresetPrefix selects a named preset in a filter pattern
+// ("preset:large") instead of a name glob.
+const FilterPresetPrefix = "preset:"
+
+// Filter preset entry types.
+const (
+	FilterTypeFile   = "file"
+	FilterTypeDir    = "dir"
+	FilterTypeHidden = "hidden"
+)
+
+// FilterPreset is a named filter combining name globs with attribute
+// predicates. Presets come from config.json (ui.fileFilter.presets) or are
+// saved from the Filter dialog to state.json. Empty fields do not restrict.
+type FilterPreset struct {
+	Name          string   `json:"name"`
+	Include       []string `json:"include,omitempty"`       // Name globs or tag:<color>; a file must match one
+	Exclude       []string `json:"exclude,omitempty"`       // Name globs or tag:<color>; a file matching any is hidden
+	MinSize       string   `json:"minSize,omitempty"`       // Smallest file size kept, such as "10MB"
+	MaxSize       string   `json:"maxSize,omitempty"`       // Largest file size kept
+	ModifiedSince string   `json:"modifiedSince,omitempty"` // Age such as "7d" or "12h", or a date "2006-01-02"
+	Types         []string `json:"types,omitempty"`         // Any of file, dir, hidden
+}
+
+// FilterCriteria is a FilterPreset with its sizes and date resolved.
+// MinSize and MaxSize are -1 and ModifiedSince is zero when unset.
+type FilterCriteria struct {
+	Include       []string
+	Exclude       []string
+	MinSize       int64
+	MaxSize       int64
+	ModifiedSince time.Time
+	Types         []string
+}
+
+// FilterPresetName returns the preset named by a "preset:<name>" pattern.
+func FilterPresetName(pattern string) (string, bool) {
+	name, ok := strings.CutPrefix(strings.TrimSpace(pattern), FilterPresetPrefix)
+	if !ok {
+		return "", false
+	}
+	return strings.TrimSpace(name), true
+}
+
+// FindFilterPreset returns the preset called name.
+func FindFilterPreset(presets []FilterPreset, name string) (FilterPreset, bool) {
+	for _, preset := range presets {
+		if preset.Name == name {
+			return preset, true
+		}
+	}
+	return FilterPreset{}, false
+}
+
+// MergeFilterPresets lists configured presets first, then saved presets
+// whose names the configuration does not already use.
+func MergeFilterPresets(configured, saved []FilterPreset) []FilterPreset {
+	merged := make([]FilterPreset, 0, len(configured)+len(saved))
+	merged = append(merged, configured...)
+	for _, preset := range saved {
+		if _, exists := FindFilterPreset(configured, preset.Name); !exists {
+			merged = append(merged, preset)
+		}
+	}
+	return merged
+}
+
+// Criteria resolves the preset's sizes and relative date against now.
+func (p FilterPreset) Criteria(now time.Time) (FilterCriteria, error) {
+	c := FilterCriteria{Include: p.Include, Exclude: p.Exclude, MinSize: -1, MaxSize: -1, Types: p.Types}
+	var err error
+	if p.MinSize != "" {
+		if c.MinSize, err = ParseFilterSize(p.MinSize); err != nil {
+			return FilterCriteria{}, err
+		}
+	}
+	if p.MaxSize != "" {
+		if c.MaxSize, err = ParseFilterSize(p.MaxSize); err != nil {
+			return FilterCriteria{}, err
+		}
+	}
+	if p.ModifiedSince != "" {
+		if c.ModifiedSince, err = ParseModifiedSince(p.ModifiedSince, now); err != nil {
+			return FilterCriteria{}, err
+		}
+	}
+	return c, nil
+}
+
+// Spec renders the preset in the term syntax read by ParseFilterPresetSpec.
+func (p FilterPreset) Spec() string {
+	var terms []string
+	terms = append(terms, p.Include...)
+	for _, pattern := range p.Exclude {
+		terms = append(terms, "!"+pattern)
+	}
+	if p.MinSize != "" {
+		terms = append(terms, "size>"+p.MinSize)
+	}
+	if p.MaxSize != "" {
+		terms = append(terms, "size<"+p.MaxSize)
+	}
+	if p.ModifiedSince != "" {
+		terms = append(terms, "since:"+p.ModifiedSince)
+	}
+	if len(p.Types) > 0 {
+		terms = append(terms, "type:"+strings.Join(p.Types, ","))
+	}
+	return strings.Join(terms, " ")
+}
+
+// ParseFilterPresetSpec builds a preset called name from whitespace separated
+// terms: a glob includes, "!glob" excludes, "size>N" and "size<N" bound the
+// size, "since:7d" or "since:2006-01-02" bounds the modification time, and
+// "type:file,dir,hidden" limits the entry types.
+func ParseFilterPresetSpec(name, spec string) (FilterPreset, error) {
+	preset := FilterPreset{Name: strings.TrimSpace(name)}
+	for _, term := range strings.Fields(spec) {
+		lower := strings.ToLower(term)
+		switch {
+		case strings.HasPrefix(term, "!"):
+			preset.Exclude = append(preset.Exclude, term[1:])
+		case strings.HasPrefix(lower, "size>"):
+			preset.MinSize = term[len("size>"):]
+		case strings.HasPrefix(lower, "size<"):
+			preset.MaxSize = term[len("size<"):]
+		case strings.HasPrefix(lower, "since:"):
+			preset.ModifiedSince = term[len("since:"):]
+		case strings.HasPrefix(lower, "type:"):
+			for _, t := range strings.Split(lower[len("type:"):], ",") {
+				if t != "" {
+					preset.Types = append(preset.Types, t)
+				}
+			}
+		default:
+			preset.Include = append(preset.Include, term)
+		}
+	}
+	if err := validateFilterPreset(preset); err != nil {
+		return FilterPreset{}, err
+	}
+	return preset, nil
+}
+
+var filterSizeUnits = []struct {
+	suffix string
+	factor float64
+}{
+	{"TB", 1 << 40}, {"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10},
+	{"T", 1 << 40}, {"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}, {"B", 1},
+}
+
+// ParseFilterSize parses a size such as "512", "10KB", or "1.5G" into bytes.
+// Units are binary, matching the sizes the list shows.
+func ParseFilterSize(s string) (int64, error) {
+	text := strings.ToUpper(strings.TrimSpace(s))
+	factor := 1.0
+	for _, unit := range filterSizeUnits {
+		if strings.HasSuffix(text, unit.suffix) {
+			text = strings.TrimSpace(strings.TrimSuffix(text, unit.suffix))
+			factor = unit.factor
+			break
+		}
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || value < 0 || math.IsInf(value, 0) || math.IsNaN(value) {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return int64(value * factor), nil
+}
+
+// ParseModifiedSince resolves an age ("30m", "12h", "7d", "2w") relative to
+// now, or a local date ("2006-01-02").
+func ParseModifiedSince(s string, now time.Time) (time.Time, error) {
+	text := strings.TrimSpace(s)
+	if t, err := time.ParseInLocation("2006-01-02", text, now.Location()); err == nil {
+		return t, nil
+	}
+	if len(text) >= 2 {
+		unit := map[byte]time.Duration{'m': time.Minute, 'h': time.Hour, 'd': 24 * time.Hour, 'w': 7 * 24 * time.Hour}[text[len(text)-1]]
+		if n, err := strconv.Atoi(text[:len(text)-1]); err == nil && n >= 0 && unit != 0 {
+			return now.Add(-time.Duration(n) * unit), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid modified-since %q: use an age like 7d or a date like 2006-01-02", s)
+}
+
+// ValidateFilterPresets reports the first unusable preset: a missing or
+// duplicate name, a bad glob, size, date, or type.
+func ValidateFilterPresets(presets []FilterPreset) error {
+	seen := make(map[string]bool)
+	for i, preset := range presets {
+		if strings.TrimSpace(preset.Name) == "" {
+			return fmt.Errorf("ui.fileFilter.presets[%d].name must not be empty", i)
+		}
+		if seen[preset.Name] {
+			return fmt.Errorf("ui.fileFilter.presets: duplicate preset %q", preset.Name)
+		}
+		seen[preset.Name] = true
+		if err := validateFilterPreset(preset); err != nil {
+			return fmt.Errorf("ui.fileFilter.presets %q: %w", preset.Name, err)
+		}
+	}
+	return nil
+}
+
+func validateFilterPreset(preset FilterPreset) error {
+	if strings.TrimSpace(preset.Name) == "" {
+		return fmt.Errorf("preset name must not be empty")
+	}
+	for _, pattern := range append(append([]string(nil), preset.Include...), preset.Exclude...) {
+		if pattern == "" {
+			return fmt.Errorf("empty name pattern")
+		}
+		if strings.HasPrefix(strings.ToLower(pattern), "tag:") {
+			continue
+		}
+		if !doublestar.ValidatePattern(pattern) {
+			return fmt.Errorf("invalid name pattern %q", pattern)
+		}
+	}
+	for _, t := range preset.Types {
+		if t != FilterTypeFile && t != FilterTypeDir && t != FilterTypeHidden {
+			return fmt.Errorf("type must be file, dir, or hidden, got %q", t)
+		}
+	}
+	criteria, err := preset.Criteria(time.Now())
+	if err != nil {
+		return err
+	}
+	if criteria.MinSize >= 0 && criteria.MaxSize >= 0 && criteria.MinSize > criteria.MaxSize {
+		return fmt.Errorf("minSize %s is larger than maxSize %s", preset.MinSize, preset.MaxSize)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseFilterPresetSpecRoundTrips(t *testing.T) {
+	preset, err := ParseFilterPresetSpec(" large ", "*.iso *.img !tmp* size>1.5GB size<4G since:7d type:file,hidden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := FilterPreset{
+		Name:          "large",
+		Include:       []string{"*.iso", "*.img"},
+		Exclude:       []string{"tmp*"},
+		MinSize:       "1.5GB",
+		MaxSize:       "4G",
+		ModifiedSince: "7d",
+		Types:         []string{"file", "hidden"},
+	}
+	if !reflect.DeepEqual(preset, want) {
+		t.Fatalf("preset = %+v, want %+v", preset, want)
+	}
+	again, err := ParseFilterPresetSpec(preset.Name, preset.Spec())
+	if err != nil || !reflect.DeepEqual(again, preset) {
+		t.Fatalf("Spec round trip = %+v, %v", again, err)
+	}
+}
+
+func TestFilterPresetCriteriaResolvesSizesAndDates(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	c, err := FilterPreset{Name: "p", MinSize: "10KB", ModifiedSince: "2d"}.Criteria(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.MinSize != 10<<10 || c.MaxSize != -1 || !c.ModifiedSince.Equal(now.Add(-48*time.Hour)) {
+		t.Fatalf("criteria = %+v", c)
+	}
+	if got, err := ParseModifiedSince("2026-01-02", now); err != nil || got.Day() != 2 || got.Month() != time.January {
+		t.Fatalf("date = %v, %v", got, err)
+	}
+	if got, err := ParseFilterSize("512"); err != nil || got != 512 {
+		t.Fatalf("ParseFilterSize(512) = %d, %v", got, err)
+	}
+}
+
+func TestValidateFilterPresets(t *testing.T) {
+	invalid := [][]FilterPreset{
+		{{Name: ""}},
+		{{Name: "a"}, {Name: "a"}},
+		{{Name: "a", Include: []string{"[a"}}},
+		{{Name: "a", MinSize: "lots"}},
+		{{Name: "a", MinSize: "2MB", MaxSize: "1MB"}},
+		{{Name: "a", ModifiedSince: "yesterday"}},
+		{{Name: "a", Types: []string{"socket"}}},
+	}
+	for _, presets := range invalid {
+		if err := ValidateFilterPresets(presets); err == nil {
+			t.Errorf("ValidateFilterPresets(%+v) accepted invalid presets", presets)
+		}
+	}
+	if err := ValidateFilterPresets([]FilterPreset{{Name: "red", Include: []string{"tag:red"}}}); err != nil {
+		t.Fatalf("tag include rejected: %v", err)
+	}
+}
+
+func TestStateFilterPresetsMergeBehindConfiguredOnes(t *testing.T) {
+	s := newDefaultState()
+	s.SaveFilterPreset(FilterPreset{Name: "docs", Include: []string{"*.md"}})
+	s.SaveFilterPreset(FilterPreset{Name: "big", MinSize: "1G"})
+	s.SaveFilterPreset(FilterPreset{Name: "docs", Include: []string{"*.txt"}})
+
+	merged := MergeFilterPresets([]FilterPreset{{Name: "big", MinSize: "2G"}}, s.FileFilter.Presets)
+	if len(merged) != 2 || merged[0].MinSize != "2G" || merged[1].Include[0] != "*.txt" {
+		t.Fatalf("merged = %+v", merged)
+	}
+	if !s.RemoveFilterPreset("docs") || s.RemoveFilterPreset("docs") {
+		t.Fatal("RemoveFilterPreset should remove once")
+	}
+	if name, ok := FilterPresetName(" preset:big "); !ok || name != "big" {
+		t.Fatalf("FilterPresetName = %q, %t", name, ok)
+	}
+}
@@ -12,11 +12,13 @@ import (
 	"time"
 )
 
-// CursorMemoryState holds the per-directory remembered cursor file (state.json).
-// The maximum entry count is a config.json setting (CursorMemoryConfig.MaxEntries).
+// CursorMemoryState holds the per-directory remembered cursor file and list
+// scroll offset (state.json). Both share the Entries/LastUsed LRU; the maximum
+// entry count is a config.json setting (CursorMemoryConfig.MaxEntries).
 type CursorMemoryState struct {
-	Entries  map[string]string    `json:"entries"`  // key: dirPath, value: fileName
-	LastUsed map[string]time.Time `json:"lastUsed"` // LRU management
+	Entries       map[string]string    `json:"entries"`                 // key: dirPath, value: fileName
+	LastUsed      map[string]time.Time `json:"lastUsed"`                // LRU management
+	ScrollOffsets map[string]float32   `json:"scrollOffsets,omitempty"` // key: dirPath, value: list scroll offset
 }
 
 // NavigationHistoryState holds visited-path history (state.json). The maximum
@@ -32,9 +34,10 @@ type NavigationHistoryState struct {
 // (state.json). The maximum entry count is a config.json setting
 // (FileFilterConfig.MaxEntries).
 type FileFilterState struct {
-	Entries []FilterEntry `json:"entries"` // Filter history (frecency order)
-	Current *FilterEntry  `json:"current"` // Currently applied filter pattern
-	Enabled bool          `json:"enabled"` // Current filter enabled state
+	Entries []FilterEntry  `json:"entries"`           // Filter history (frecency order)
+	Current *FilterEntry   `json:"current"`           // Currently applied filter pattern
+	Enabled bool           `json:"enabled"`           // Current filter enabled state
+	Presets []FilterPreset `json:"presets,omitempty"` // Presets saved from the Filter dialog
 }
 
 // State represents runtime state persisted to state.json. It holds the parts
@@ -47,6 +50,11 @@ type State struct {
 	NavigationHistory NavigationHistoryState `json:"navigationHistory"`
 	FileFilter        FileFilterState        `json:"fileFilter"`
 	Sort              *SortConfig            `json:"sort,omitempty"` // Last-applied sort; nil means config.json's ui.sort is the effective default
+	DirectoryViews    DirectoryViewState     `json:"directoryViews"`
+	Bookmarks         []Bookmark             `json:"bookmarks"`
+	Tabs              *TabSessionState       `json:"tabs,omitempty"`            // Tab set of the window that changed its tabs last
+	ShowHiddenFiles   *bool                  `json:"showHiddenFiles,omitempty"` // Last hidden-file toggle; nil means config.json's ui.showHiddenFiles applies
+	HideGitIgnored    *bool                  `json:"hideGitIgnored,omitempty"`  // Last git-ignored toggle; nil means config.json's ui.hideGitIgnored applies
 }
 
 // newDefaultState returns a State with empty, non-nil maps/slices and no
@@ -54,8 +62,9 @@ type State struct {
 func newDefaultState() *State {
 	return &State{
 		CursorMemory: CursorMemoryState{
-			Entries:  make(map[string]string),
-			LastUsed: make(map[string]time.Time),
+			Entries:       make(map[string]string),
+			LastUsed:      make(map[string]time.Time),
+			ScrollOffsets: make(map[string]float32),
 		},
 		NavigationHistory: NavigationHistoryState{
 			Entries:  make([]string, 0),
@@ -69,6 +78,11 @@ func newDefaultState() *State {
 			Enabled: false,
 		},
 		Sort: nil,
+		DirectoryViews: DirectoryViewState{
+			Entries:  make(map[string]DirectoryView),
+			LastUsed: make(map[string]time.Time),
+		},
+		Bookmarks: make([]Bookmark, 0),
 	}
 }
 
@@ -83,10 +97,27 @@ func cloneState(s *State) *State {
 	clone.CursorMemory = cloneCursorMemoryState(s.CursorMemory)
 	clone.NavigationHistory = cloneNavigationHistoryState(s.NavigationHistory)
 	clone.FileFilter = cloneFileFilterState(s.FileFilter)
+	clone.DirectoryViews = cloneDirectoryViewState(s.DirectoryViews)
 	if s.Sort != nil {
 		sortCopy := *s.Sort
 		clone.Sort = &sortCopy
 	}
+	if s.ShowHiddenFiles != nil {
+		show := *s.ShowHiddenFiles
+		clone.ShowHiddenFiles = &show
+	}
+	if s.HideGitIgnored != nil {
+		hide := *s.HideGitIgnored
+		clone.HideGitIgnored = &hide
+	}
+	if s.Bookmarks != nil {
+		clone.Bookmarks = make([]Bookmark, len(s.Bookmarks))
+		copy(clone.Bookmarks, s.Bookmarks)
+	}
+	if s.Tabs != nil {
+		session := s.GetTabSession()
+		clone.Tabs = &session
+	}
 	return &clone
 }
 
@@ -104,6 +135,12 @@ func cloneCursorMemoryState(src CursorMemoryState) CursorMemoryState {
 			clone.LastUsed[k] = v
 		}
 	}
+	if src.ScrollOffsets != nil {
+		clone.ScrollOffsets = make(map[string]float32, len(src.ScrollOffsets))
+		for k, v := range src.ScrollOffsets {
+			clone.ScrollOffsets[k] = v
+		}
+	}
 	return clone
 }
 
@@ -142,6 +179,10 @@ func cloneFileFilterState(src FileFilterState) FileFilterState {
 		currentCopy := *src.Current
 		clone.Current = &currentCopy
 	}
+	if src.Presets != nil {
+		clone.Presets = make([]FilterPreset, len(src.Presets))
+		copy(clone.Presets, src.Presets)
+	}
 	return clone
 }
 
@@ -321,6 +362,28 @@ func (s *State) AddToFileFilterHistory(entry *FilterEntry, maxEntries int) {
 	pruneFileFilterEntriesState(filter, maxEntries, now)
 }
 
+// SaveFilterPreset stores preset, replacing a saved preset of the same name.
+func (s *State) SaveFilterPreset(preset FilterPreset) {
+	for i := range s.FileFilter.Presets {
+		if s.FileFilter.Presets[i].Name == preset.Name {
+			s.FileFilter.Presets[i] = preset
+			return
+		}
+	}
+	s.FileFilter.Presets = append(s.FileFilter.Presets, preset)
+}
+
+// RemoveFilterPreset deletes the saved preset called name.
+func (s *State) RemoveFilterPreset(name string) bool {
+	for i := range s.FileFilter.Presets {
+		if s.FileFilter.Presets[i].Name == name {
+			s.FileFilter.Presets = append(s.FileFilter.Presets[:i], s.FileFilter.Presets[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 // RemoveFileFilterEntry removes an exact saved filter pattern from history.
 func (s *State) RemoveFileFilterEntry(pattern string) bool {
 	entries := s.FileFilter.Entries
@@ -414,6 +477,24 @@ func (s *State) EffectiveSort(configDefault SortConfig) SortConfig {
 	return configDefault
 }
 
+// EffectiveShowHiddenFiles returns the last hidden-file toggle, or
+// configDefault (cfg.UI.ShowHiddenFiles) when it was never toggled.
+func (s *State) EffectiveShowHiddenFiles(configDefault bool) bool {
+	if s != nil && s.ShowHiddenFiles != nil {
+		return *s.ShowHiddenFiles
+	}
+	return configDefault
+}
+
+// EffectiveHideGitIgnored returns the last git-ignored toggle, or
+// configDefault (cfg.UI.HideGitIgnored) when it was never toggled.
+func (s *State) EffectiveHideGitIgnored(configDefault bool) bool {
+	if s != nil && s.HideGitIgnored != nil {
+		return *s.HideGitIgnored
+	}
+	return configDefault
+}
+
 // StateManager manages persistence of runtime state to state.json. It
 // mirrors Manager's debounced background-save worker (SaveAsync/Flush/Close)
 // but is kept as a separate implementation rather than shared/generic code,
@@ -455,6 +536,27 @@ func (m *StateManager) StatePath() string {
 	return m.statePath
 }
 
+// JobHistoryPath returns the job history file kept next to state.json.
+func (m *StateManager) JobHistoryPath() string {
+	return filepath.Join(filepath.Dir(m.statePath), "job-history.json")
+}
+
+// TransferResumePath returns the interrupted transfer journal kept next to
+// state.json.
+func (m *StateManager) TransferResumePath() string {
+	return filepath.Join(filepath.Dir(m.statePath), "transfer-resume.json")
+}
+
+// AuditLogPath returns the operation audit log kept next to state.json.
+func (m *StateManager) AuditLogPath() string {
+	return filepath.Join(filepath.Dir(m.statePath), "audit-log.jsonl")
+}
+
+// TagIndexPath returns the color tag index kept next to state.json.
+func (m *StateManager) TagIndexPath() string {
+	return filepath.Join(filepath.Dir(m.statePath), "tag-index.json")
+}
+
 // Load returns runtime state from state.json. If state.json does not exist,
 // it performs a one-time, best-effort migration of legacy runtime keys out of
 // configPath (config.json's ui.cursorMemory/navigationHistory/fileFilter data
@@ -496,6 +598,9 @@ func normalizeState(state *State) {
 	if state.CursorMemory.LastUsed == nil {
 		state.CursorMemory.LastUsed = make(map[string]time.Time)
 	}
+	if state.CursorMemory.ScrollOffsets == nil {
+		state.CursorMemory.ScrollOffsets = make(map[string]float32)
+	}
 	if state.NavigationHistory.Entries == nil {
 		state.NavigationHistory.Entries = make([]string, 0)
 	}
@@ -504,6 +609,8 @@ func normalizeState(state *State) {
 	if state.FileFilter.Entries == nil {
 		state.FileFilter.Entries = make([]FilterEntry, 0)
 	}
+	ensureDirectoryViewState(&state.DirectoryViews)
+	state.Bookmarks = NormalizeBookmarks(state.Bookmarks)
 }
 
 // legacyRuntimeStateDoc mirrors only the runtime-state keys that used to live
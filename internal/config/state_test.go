@@ -530,10 +530,12 @@ func TestCloneStateDeepCopiesCursorMemory(t *testing.T) {
 	state := newDefaultState()
 	state.CursorMemory.Entries["/dir"] = "file.txt"
 	state.CursorMemory.LastUsed["/dir"] = time.Now()
+	state.CursorMemory.ScrollOffsets["/dir"] = 120
 
 	clone := cloneState(state)
 	clone.CursorMemory.Entries["/dir"] = "changed.txt"
 	clone.CursorMemory.LastUsed["/dir"] = time.Time{}
+	clone.CursorMemory.ScrollOffsets["/dir"] = 0
 
 	if state.CursorMemory.Entries["/dir"] != "file.txt" {
 		t.Errorf("expected original cursor memory entry to remain unchanged, got %q", state.CursorMemory.Entries["/dir"])
@@ -541,6 +543,9 @@ func TestCloneStateDeepCopiesCursorMemory(t *testing.T) {
 	if state.CursorMemory.LastUsed["/dir"].IsZero() {
 		t.Error("expected original cursor memory lastUsed to remain unchanged")
 	}
+	if state.CursorMemory.ScrollOffsets["/dir"] != 120 {
+		t.Errorf("expected original scroll offset to remain unchanged, got %v", state.CursorMemory.ScrollOffsets["/dir"])
+	}
 }
 
 func TestCloneStateDeepCopiesFileFilterEntries(t *testing.T) {
@@ -571,6 +576,36 @@ func TestEffectiveSortReturnsOverrideWhenPresent(t *testing.T) {
 	}
 }
 
+func TestEffectiveShowHiddenFilesPrefersToggle(t *testing.T) {
+	state := newDefaultState()
+	if !state.EffectiveShowHiddenFiles(true) {
+		t.Fatal("EffectiveShowHiddenFiles = false, want config default true")
+	}
+	hidden := false
+	state.ShowHiddenFiles = &hidden
+	if state.EffectiveShowHiddenFiles(true) {
+		t.Fatal("EffectiveShowHiddenFiles = true, want toggled false")
+	}
+	if clone := cloneState(state); clone.ShowHiddenFiles == state.ShowHiddenFiles || *clone.ShowHiddenFiles {
+		t.Fatalf("clone ShowHiddenFiles = %v, want independent false copy", clone.ShowHiddenFiles)
+	}
+}
+
+func TestEffectiveHideGitIgnoredPrefersToggle(t *testing.T) {
+	state := newDefaultState()
+	if state.EffectiveHideGitIgnored(false) {
+		t.Fatal("EffectiveHideGitIgnored = true, want config default false")
+	}
+	hide := true
+	state.HideGitIgnored = &hide
+	if !state.EffectiveHideGitIgnored(false) {
+		t.Fatal("EffectiveHideGitIgnored = false, want toggled true")
+	}
+	if clone := cloneState(state); clone.HideGitIgnored == state.HideGitIgnored || !*clone.HideGitIgnored {
+		t.Fatalf("clone HideGitIgnored = %v, want independent true copy", clone.HideGitIgnored)
+	}
+}
+
 func TestEffectiveSortFallsBackToConfigDefault(t *testing.T) {
 	state := newDefaultState()
 	configDefault := SortConfig{SortBy: "name", SortOrder: "asc", DirectoriesFirst: true}
@@ -0,0 +1,68 @@
+package config
+
+import "strings"
+
+// TabState is one saved directory tab (state.json). Cursor is the file name
+// under the cursor; Filter and Sort are the tab's own view settings and nil
+// when the tab used none.
+type TabState struct {
+	Path   string       `json:"path"`
+	Cursor string       `json:"cursor,omitempty"`
+	Filter *FilterEntry `json:"filter,omitempty"`
+	Sort   *SortConfig  `json:"sort,omitempty"`
+}
+
+// TabSessionState is a window's tab set and the index of its active tab.
+type TabSessionState struct {
+	Tabs   []TabState `json:"tabs"`
+	Active int        `json:"active"`
+}
+
+// SetTabSession replaces the saved tab session with a normalized copy of
+// tabs. An empty set clears it.
+func (s *State) SetTabSession(tabs []TabState, active int) {
+	session := NormalizeTabSession(TabSessionState{Tabs: tabs, Active: active})
+	if len(session.Tabs) == 0 {
+		s.Tabs = nil
+		return
+	}
+	s.Tabs = &session
+}
+
+// GetTabSession returns a deep copy of the saved tab session; Tabs is empty
+// when none is saved.
+func (s *State) GetTabSession() TabSessionState {
+	if s.Tabs == nil {
+		return TabSessionState{}
+	}
+	return NormalizeTabSession(*s.Tabs)
+}
+
+// NormalizeTabSession trims paths, drops tabs without one, deep-copies the
+// per-tab filter and sort, and clamps Active into range.
+func NormalizeTabSession(session TabSessionState) TabSessionState {
+	out := TabSessionState{Tabs: make([]TabState, 0, len(session.Tabs))}
+	for i, tab := range session.Tabs {
+		tab.Path = strings.TrimSpace(tab.Path)
+		if tab.Path == "" {
+			if i < session.Active {
+				session.Active--
+			}
+			continue
+		}
+		if tab.Filter != nil {
+			filter := *tab.Filter
+			tab.Filter = &filter
+		}
+		if tab.Sort != nil {
+			sortCopy := *tab.Sort
+			tab.Sort = &sortCopy
+		}
+		out.Tabs = append(out.Tabs, tab)
+	}
+	out.Active = session.Active
+	if out.Active < 0 || out.Active >= len(out.Tabs) {
+		out.Active = 0
+	}
+	return out
+}
@@ -0,0 +1,48 @@
+package config
+
+import "testing"
+
+func TestSetTabSessionNormalizesTabsAndActive(t *testing.T) {
+	s := newDefaultState()
+	filter := &FilterEntry{Pattern: "*.go"}
+	s.SetTabSession([]TabState{
+		{Path: "  /tmp  "},
+		{Path: " "},
+		{Path: "/src", Cursor: "main.go", Filter: filter},
+	}, 2)
+
+	got := s.GetTabSession()
+	if len(got.Tabs) != 2 || got.Tabs[0].Path != "/tmp" || got.Tabs[1].Path != "/src" {
+		t.Fatalf("tabs = %+v, want /tmp and /src", got.Tabs)
+	}
+	if got.Active != 1 {
+		t.Fatalf("active = %d, want 1 after dropping the empty tab", got.Active)
+	}
+	filter.Pattern = "changed"
+	if s.Tabs.Tabs[1].Filter.Pattern != "*.go" {
+		t.Fatal("saved filter aliases the caller's entry")
+	}
+
+	s.SetTabSession(nil, 0)
+	if s.Tabs != nil {
+		t.Fatalf("empty session = %+v, want nil", s.Tabs)
+	}
+}
+
+func TestNormalizeTabSessionClampsActive(t *testing.T) {
+	got := NormalizeTabSession(TabSessionState{Tabs: []TabState{{Path: "/a"}}, Active: 5})
+	if got.Active != 0 {
+		t.Fatalf("active = %d, want 0", got.Active)
+	}
+}
+
+func TestCloneStateCopiesTabSession(t *testing.T) {
+	s := newDefaultState()
+	s.SetTabSession([]TabState{{Path: "/a"}, {Path: "/b"}}, 1)
+
+	clone := cloneState(s)
+	clone.Tabs.Tabs[0].Path = "/changed"
+	if s.Tabs.Tabs[0].Path != "/a" {
+		t.Fatal("cloneState shares the tab slice")
+	}
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// ViewProfile bundles list view settings applied automatically to the
+// directories its globs match. Unset fields keep the global setting.
+type ViewProfile struct {
+	Name         string      `json:"name"`                   // Profile name shown in the status bar
+	Directories  []string    `json:"directories"`            // Doublestar globs; a leading ~ is the home directory
+	Sort         *SortConfig `json:"sort,omitempty"`         // Sort for matching directories
+	Filter       string      `json:"filter,omitempty"`       // Filter pattern applied on entry
+	ShowMetadata *bool       `json:"showMetadata,omitempty"` // Overrides ui.metadata.showInList
+}
+
+// MatchViewProfile returns the first profile with a directory glob matching
+// dir. "~/Pictures/**" matches ~/Pictures itself and everything below it.
+func MatchViewProfile(profiles []ViewProfile, dir string) (ViewProfile, bool) {
+	if dir == "" {
+		return ViewProfile{}, false
+	}
+	target := filepath.ToSlash(dir)
+	for _, profile := range profiles {
+		for _, pattern := range profile.Directories {
+			if ok, err := doublestar.Match(expandViewProfilePattern(pattern), target); err == nil && ok {
+				return profile, true
+			}
+		}
+	}
+	return ViewProfile{}, false
+}
+
+func expandViewProfilePattern(pattern string) string {
+	if pattern == "~" || strings.HasPrefix(pattern, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			pattern = filepath.ToSlash(home) + pattern[1:]
+		}
+	}
+	return filepath.ToSlash(pattern)
+}
+
+// ValidateViewProfiles reports the first unusable profile: a missing name, a
+// duplicate name, a bad glob, or an invalid sort.
+func ValidateViewProfiles(profiles []ViewProfile) error {
+	seen := make(map[string]bool)
+	for i, profile := range profiles {
+		if strings.TrimSpace(profile.Name) == "" {
+			return fmt.Errorf("ui.viewProfiles[%d].name must not be empty", i)
+		}
+		if seen[profile.Name] {
+			return fmt.Errorf("ui.viewProfiles: duplicate profile %q", profile.Name)
+		}
+		seen[profile.Name] = true
+		for _, pattern := range profile.Directories {
+			if !doublestar.ValidatePattern(expandViewProfilePattern(pattern)) {
+				return fmt.Errorf("ui.viewProfiles %q: invalid directory pattern %q", profile.Name, pattern)
+			}
+		}
+		if profile.Sort != nil {
+			if !IsValidSortBy(profile.Sort.SortBy) {
+				return fmt.Errorf("ui.viewProfiles %q: sort.sortBy must be name, size, modified, extension, dateTaken, or tag", profile.Name)
+			}
+			if !IsValidSortOrder(profile.Sort.SortOrder) {
+				return fmt.Errorf("ui.viewProfiles %q: sort.sortOrder must be asc or desc", profile.Name)
+			}
+			if !IsValidSortLocale(profile.Sort.Locale) {
+				return fmt.Errorf("ui.viewProfiles %q: sort.locale must be empty, auto, or a BCP 47 language tag", profile.Name)
+			}
+			if !IsValidGroupBy(profile.Sort.GroupBy) {
+				return fmt.Errorf("ui.viewProfiles %q: sort.groupBy must be empty or dateTaken", profile.Name)
+			}
+		}
+	}
+	return nil
+}
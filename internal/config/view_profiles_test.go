@@ -0,0 +1,53 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMatchViewProfileUsesFirstMatchingGlob(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip(err)
+	}
+	profiles := []ViewProfile{
+		{Name: "code", Directories: []string{"/src/**/*.git", "~/src/**"}},
+		{Name: "photos", Directories: []string{"~/Pictures/**"}},
+		{Name: "nas", Directories: []string{"smb://nas/photos/**"}},
+	}
+	cases := []struct {
+		dir  string
+		want string
+	}{
+		{filepath.Join(home, "Pictures"), "photos"},
+		{filepath.Join(home, "Pictures", "2026", "trip"), "photos"},
+		{filepath.Join(home, "src", "nmf"), "code"},
+		{"smb://nas/photos/raw", "nas"},
+		{filepath.Join(home, "PicturesOld"), ""},
+	}
+	for _, tc := range cases {
+		got, ok := MatchViewProfile(profiles, tc.dir)
+		if got.Name != tc.want || ok != (tc.want != "") {
+			t.Errorf("MatchViewProfile(%q) = %q, %t; want %q", tc.dir, got.Name, ok, tc.want)
+		}
+	}
+}
+
+func TestValidateViewProfiles(t *testing.T) {
+	valid := []ViewProfile{{Name: "photos", Directories: []string{"~/Pictures/**"}, Sort: &SortConfig{SortBy: "dateTaken", SortOrder: "desc"}}}
+	if err := ValidateViewProfiles(valid); err != nil {
+		t.Fatalf("valid profile rejected: %v", err)
+	}
+	invalid := [][]ViewProfile{
+		{{Name: ""}},
+		{{Name: "a"}, {Name: "a"}},
+		{{Name: "a", Directories: []string{"/x/[a"}}},
+		{{Name: "a", Sort: &SortConfig{SortBy: "colour", SortOrder: "asc"}}},
+	}
+	for _, profiles := range invalid {
+		if err := ValidateViewProfiles(profiles); err == nil {
+			t.Errorf("ValidateViewProfiles(%+v) accepted invalid profiles", profiles)
+		}
+	}
+}
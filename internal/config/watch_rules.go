@@ -0,0 +1,92 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// WatchRule raises a desktop notification when a new entry whose name matches
+// one of Patterns appears in a watched bookmark directory matched by
+// Directories.
+type WatchRule struct {
+	Name        string   `json:"name"`                  // Rule name shown in the notification title
+	Directories []string `json:"directories,omitempty"` // Doublestar globs; empty matches every watched bookmark
+	Patterns    []string `json:"patterns,omitempty"`    // Name globs such as "*.pdf"; empty matches every new entry
+}
+
+// MatchWatchRule returns the first rule matching a new entry called name in
+// dir. Directory globs follow ViewProfile; name globs are matched against the
+// base name only.
+func MatchWatchRule(rules []WatchRule, dir, name string) (WatchRule, bool) {
+	if dir == "" || name == "" {
+		return WatchRule{}, false
+	}
+	target := filepath.ToSlash(dir)
+	for _, rule := range rules {
+		if !matchWatchRuleDirectory(rule, target) {
+			continue
+		}
+		if len(rule.Patterns) == 0 {
+			return rule, true
+		}
+		for _, pattern := range rule.Patterns {
+			if ok, err := doublestar.Match(pattern, name); err == nil && ok {
+				return rule, true
+			}
+		}
+	}
+	return WatchRule{}, false
+}
+
+// WatchRulesCover reports whether some rule applies to entries in dir, so
+// that watching dir can raise notifications.
+func WatchRulesCover(rules []WatchRule, dir string) bool {
+	target := filepath.ToSlash(dir)
+	for _, rule := range rules {
+		if dir != "" && matchWatchRuleDirectory(rule, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchWatchRuleDirectory(rule WatchRule, target string) bool {
+	if len(rule.Directories) == 0 {
+		return true
+	}
+	for _, pattern := range rule.Directories {
+		if ok, err := doublestar.Match(expandViewProfilePattern(pattern), target); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateWatchRules reports the first unusable rule: a missing or duplicate
+// name, or a bad directory or name glob.
+func ValidateWatchRules(rules []WatchRule) error {
+	seen := make(map[string]bool)
+	for i, rule := range rules {
+		if strings.TrimSpace(rule.Name) == "" {
+			return fmt.Errorf("ui.watchRules[%d].name must not be empty", i)
+		}
+		if seen[rule.Name] {
+			return fmt.Errorf("ui.watchRules: duplicate rule %q", rule.Name)
+		}
+		seen[rule.Name] = true
+		for _, pattern := range rule.Directories {
+			if !doublestar.ValidatePattern(expandViewProfilePattern(pattern)) {
+				return fmt.Errorf("ui.watchRules %q: invalid directory pattern %q", rule.Name, pattern)
+			}
+		}
+		for _, pattern := range rule.Patterns {
+			if pattern == "" || !doublestar.ValidatePattern(pattern) {
+				return fmt.Errorf("ui.watchRules %q: invalid name pattern %q", rule.Name, pattern)
+			}
+		}
+	}
+	return nil
+}
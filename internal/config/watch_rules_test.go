@@ -0,0 +1,59 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMatchWatchRuleChecksDirectoryAndName(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip(err)
+	}
+	rules := []WatchRule{
+		{Name: "invoices", Directories: []string{"~/Downloads"}, Patterns: []string{"*.pdf"}},
+		{Name: "anything", Directories: []string{"smb://nas/inbox"}},
+	}
+	downloads := filepath.Join(home, "Downloads")
+	cases := []struct {
+		dir, name string
+		want      string
+	}{
+		{downloads, "bill.pdf", "invoices"},
+		{downloads, "photo.jpg", ""},
+		{filepath.Join(home, "Documents"), "bill.pdf", ""},
+		{"smb://nas/inbox", "notes.txt", "anything"},
+	}
+	for _, tc := range cases {
+		got, ok := MatchWatchRule(rules, tc.dir, tc.name)
+		if got.Name != tc.want || ok != (tc.want != "") {
+			t.Errorf("MatchWatchRule(%q, %q) = %q, %t; want %q", tc.dir, tc.name, got.Name, ok, tc.want)
+		}
+	}
+	if !WatchRulesCover(rules, downloads) || WatchRulesCover(rules, filepath.Join(home, "Documents")) {
+		t.Error("WatchRulesCover should follow the directory globs")
+	}
+	if got, ok := MatchWatchRule([]WatchRule{{Name: "all"}}, "/any", "x"); !ok || got.Name != "all" {
+		t.Errorf("a rule without globs should match every entry, got %q, %t", got.Name, ok)
+	}
+}
+
+func TestValidateWatchRules(t *testing.T) {
+	valid := []WatchRule{{Name: "pdf", Directories: []string{"~/Downloads"}, Patterns: []string{"*.pdf"}}}
+	if err := ValidateWatchRules(valid); err != nil {
+		t.Fatalf("valid rule rejected: %v", err)
+	}
+	invalid := [][]WatchRule{
+		{{Name: " "}},
+		{{Name: "a"}, {Name: "a"}},
+		{{Name: "a", Directories: []string{"/x/[a"}}},
+		{{Name: "a", Patterns: []string{""}}},
+		{{Name: "a", Patterns: []string{"[a"}}},
+	}
+	for _, rules := range invalid {
+		if err := ValidateWatchRules(rules); err == nil {
+			t.Errorf("ValidateWatchRules(%+v) accepted invalid rules", rules)
+		}
+	}
+}
@@ -241,11 +241,23 @@ func (rt *Runtime) predeclared() starlark.StringDict {
 			"debug_logging":      starlark.NewBuiltin("nmf.debug_logging", rt.builtinDebugLogging),
 			"ui":                 starlark.NewBuiltin("nmf.ui", rt.builtinUI),
 			"copy":               starlark.NewBuiltin("nmf.copy", rt.builtinCopy),
+			"jobs":               starlark.NewBuiltin("nmf.jobs", rt.builtinJobs),
 			"viewer":             starlark.NewBuiltin("nmf.viewer", rt.builtinViewer),
 			"archive":            starlark.NewBuiltin("nmf.archive", rt.builtinArchive),
+			"gio":                starlark.NewBuiltin("nmf.gio", rt.builtinGio),
+			"terminal":           starlark.NewBuiltin("nmf.terminal", rt.builtinTerminal),
+			"vault":              starlark.NewBuiltin("nmf.vault", rt.builtinVault),
+			"audit_log":          starlark.NewBuiltin("nmf.audit_log", rt.builtinAuditLog),
+			"metadata":           starlark.NewBuiltin("nmf.metadata", rt.builtinMetadata),
+			"preview_pane":       starlark.NewBuiltin("nmf.preview_pane", rt.builtinPreviewPane),
+			"tree_pane":          starlark.NewBuiltin("nmf.tree_pane", rt.builtinTreePane),
+			"thumbnails":         starlark.NewBuiltin("nmf.thumbnails", rt.builtinThumbnails),
+			"disk_usage":         starlark.NewBuiltin("nmf.disk_usage", rt.builtinDiskUsage),
+			"columns":            starlark.NewBuiltin("nmf.columns", rt.builtinColumns),
 			"sort":               starlark.NewBuiltin("nmf.sort", rt.builtinSort),
 			"cursor_style":       starlark.NewBuiltin("nmf.cursor_style", rt.builtinCursorStyle),
 			"cursor_memory":      starlark.NewBuiltin("nmf.cursor_memory", rt.builtinCursorMemory),
+			"directory_views":    starlark.NewBuiltin("nmf.directory_views", rt.builtinDirectoryViews),
 			"navigation_history": starlark.NewBuiltin("nmf.navigation_history", rt.builtinNavigationHistory),
 			"file_filter":        starlark.NewBuiltin("nmf.file_filter", rt.builtinFileFilter),
 			"directory_jump":     starlark.NewBuiltin("nmf.directory_jump", rt.builtinDirectoryJump),
@@ -264,6 +276,38 @@ func (rt *Runtime) predeclared() starlark.StringDict {
 				"nmf.clear_external_commands",
 				rt.builtinClearExternalCommands,
 			),
+			"open_with": starlark.NewBuiltin(
+				"nmf.open_with",
+				rt.builtinOpenWith,
+			),
+			"clear_open_with": starlark.NewBuiltin(
+				"nmf.clear_open_with",
+				rt.builtinClearOpenWith,
+			),
+			"view_profile": starlark.NewBuiltin(
+				"nmf.view_profile",
+				rt.builtinViewProfile,
+			),
+			"clear_view_profiles": starlark.NewBuiltin(
+				"nmf.clear_view_profiles",
+				rt.builtinClearViewProfiles,
+			),
+			"watch_rule": starlark.NewBuiltin(
+				"nmf.watch_rule",
+				rt.builtinWatchRule,
+			),
+			"clear_watch_rules": starlark.NewBuiltin(
+				"nmf.clear_watch_rules",
+				rt.builtinClearWatchRules,
+			),
+			"filter_preset": starlark.NewBuiltin(
+				"nmf.filter_preset",
+				rt.builtinFilterPreset,
+			),
+			"clear_filter_presets": starlark.NewBuiltin(
+				"nmf.clear_filter_presets",
+				rt.builtinClearFilterPresets,
+			),
 			"command":        starlark.NewBuiltin("nmf.command", rt.builtinCommand),
 			"menu":           starlark.NewBuiltin("nmf.menu", rt.builtinMenu),
 			"menu_item":      starlark.NewBuiltin("nmf.menu_item", rt.builtinMenuItem),
@@ -344,10 +388,12 @@ func (rt *Runtime) builtinStartup(thread *starlark.Thread, fn *starlark.Builtin,
 		return nil, err
 	}
 	directory := rt.cfg.Startup.Directory
-	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "directory?", &directory); err != nil {
+	restoreTabs := rt.cfg.Startup.RestoreTabs
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "directory?", &directory, "restore_tabs?", &restoreTabs); err != nil {
 		return nil, err
 	}
 	rt.cfg.Startup.Directory = strings.TrimSpace(directory)
+	rt.cfg.Startup.RestoreTabs = restoreTabs
 	return starlark.None, nil
 }
 
@@ -503,16 +549,26 @@ func (rt *Runtime) builtinUI(thread *starlark.Thread, fn *starlark.Builtin, args
 		return nil, err
 	}
 	showHiddenFiles := rt.cfg.UI.ShowHiddenFiles
+	hideGitIgnored := rt.cfg.UI.HideGitIgnored
 	itemSpacing := rt.cfg.UI.ItemSpacing
 	scrollMargin := rt.cfg.UI.ScrollMargin
-	if err := starlark.UnpackArgs(
-		fn.Name(),
-		args,
-		kwargs,
+	iconSet := rt.cfg.UI.IconSet
+	openLinks := rt.cfg.UI.OpenLinks
+	autoRefresh := rt.cfg.UI.AutoRefresh
+	watchDebounceMs := rt.cfg.UI.WatchDebounceMs
+	statWorkers := rt.cfg.UI.StatWorkers
+	params := []any{
 		"show_hidden_files?", &showHiddenFiles,
 		"item_spacing?", &itemSpacing,
 		"scroll_margin?", &scrollMargin,
-	); err != nil {
+		"icon_set?", &iconSet,
+		"open_links?", &openLinks,
+		"auto_refresh?", &autoRefresh,
+		"watch_debounce_ms?", &watchDebounceMs,
+		"stat_workers?", &statWorkers,
+		"hide_git_ignored?", &hideGitIgnored,
+	}
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, params...); err != nil {
 		return nil, err
 	}
 	if itemSpacing < 0 {
@@ -521,21 +577,99 @@ func (rt *Runtime) builtinUI(thread *starlark.Thread, fn *starlark.Builtin, args
 	if scrollMargin < 0 {
 		return nil, fmt.Errorf("scroll_margin must be zero or positive")
 	}
+	if !config.IsValidIconSet(iconSet) {
+		return nil, fmt.Errorf("icon_set must be native or mono")
+	}
+	if argGiven(args, kwargs, params, "open_links") && !config.IsValidOpenLinks(openLinks) {
+		return nil, fmt.Errorf("open_links must be ask, follow, or physical")
+	}
+	if watchDebounceMs < 0 {
+		return nil, fmt.Errorf("watch_debounce_ms must be zero or positive")
+	}
+	if argGiven(args, kwargs, params, "stat_workers") && (statWorkers < 1 || statWorkers > config.MaxStatWorkers) {
+		return nil, fmt.Errorf("stat_workers must be between 1 and %d", config.MaxStatWorkers)
+	}
 	rt.cfg.UI.ShowHiddenFiles = showHiddenFiles
+	rt.cfg.UI.HideGitIgnored = hideGitIgnored
 	rt.cfg.UI.ItemSpacing = itemSpacing
 	rt.cfg.UI.ScrollMargin = scrollMargin
+	rt.cfg.UI.IconSet = iconSet
+	rt.cfg.UI.OpenLinks = openLinks
+	rt.cfg.UI.AutoRefresh = autoRefresh
+	if watchDebounceMs > 0 {
+		rt.cfg.UI.WatchDebounceMs = watchDebounceMs
+	}
+	rt.cfg.UI.StatWorkers = statWorkers
 	return starlark.None, nil
 }
 
+// argGiven reports whether a call passed the parameter name of its UnpackArgs
+// pairs, either positionally or as name=. Validation of a setting the script
+// did not mention would otherwise reject values the config already holds,
+// such as the zero value of an unset field.
+func argGiven(args starlark.Tuple, kwargs []starlark.Tuple, pairs []any, name string) bool {
+	for i := 0; i < len(pairs); i += 2 {
+		if pairs[i] == name || pairs[i] == name+"?" {
+			if i/2 < len(args) {
+				return true
+			}
+			break
+		}
+	}
+	for _, kw := range kwargs {
+		if key, ok := kw.Index(0).(starlark.String); ok && string(key) == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (rt *Runtime) builtinCopy(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
 	if err := rejectCommandContext(thread, fn.Name()); err != nil {
 		return nil, err
 	}
 	preserveTimestamps := rt.cfg.UI.Copy.PreserveTimestamps
-	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "preserve_timestamps?", &preserveTimestamps); err != nil {
+	preserveOwnership := rt.cfg.UI.Copy.PreserveOwnership
+	preserveXattrs := rt.cfg.UI.Copy.PreserveXattrs
+	elevate := rt.cfg.UI.Copy.Elevate
+	duplicateName := rt.cfg.UI.Copy.DuplicateName
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "preserve_timestamps?", &preserveTimestamps, "preserve_ownership?", &preserveOwnership, "preserve_xattrs?", &preserveXattrs, "elevate?", &elevate, "duplicate_name?", &duplicateName); err != nil {
 		return nil, err
 	}
+	if err := config.ValidateDuplicateNamePattern(duplicateName); err != nil {
+		return nil, fmt.Errorf("duplicate_name %w", err)
+	}
 	rt.cfg.UI.Copy.PreserveTimestamps = preserveTimestamps
+	rt.cfg.UI.Copy.PreserveOwnership = preserveOwnership
+	rt.cfg.UI.Copy.PreserveXattrs = preserveXattrs
+	rt.cfg.UI.Copy.Elevate = elevate
+	rt.cfg.UI.Copy.DuplicateName = duplicateName
+	return starlark.None, nil
+}
+
+func (rt *Runtime) builtinJobs(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := rejectCommandContext(thread, fn.Name()); err != nil {
+		return nil, err
+	}
+	workers := rt.cfg.UI.Jobs.Workers
+	perVolumeLimit := rt.cfg.UI.Jobs.PerVolumeLimit
+	if err := starlark.UnpackArgs(
+		fn.Name(),
+		args,
+		kwargs,
+		"workers?", &workers,
+		"per_volume_limit?", &perVolumeLimit,
+	); err != nil {
+		return nil, err
+	}
+	if workers <= 0 {
+		return nil, fmt.Errorf("workers must be positive")
+	}
+	if perVolumeLimit < 0 {
+		return nil, fmt.Errorf("per_volume_limit must be zero or positive")
+	}
+	rt.cfg.UI.Jobs.Workers = workers
+	rt.cfg.UI.Jobs.PerVolumeLimit = perVolumeLimit
 	return starlark.None, nil
 }
 
@@ -571,6 +705,113 @@ func (rt *Runtime) builtinViewer(thread *starlark.Thread, fn *starlark.Builtin,
 	return starlark.None, nil
 }
 
+func (rt *Runtime) builtinMetadata(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := rejectCommandContext(thread, fn.Name()); err != nil {
+		return nil, err
+	}
+	showInList := rt.cfg.UI.Metadata.ShowInList
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "show_in_list?", &showInList); err != nil {
+		return nil, err
+	}
+	rt.cfg.UI.Metadata.ShowInList = showInList
+	return starlark.None, nil
+}
+
+func (rt *Runtime) builtinPreviewPane(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := rejectCommandContext(thread, fn.Name()); err != nil {
+		return nil, err
+	}
+	visible := rt.cfg.UI.PreviewPane.Visible
+	width := rt.cfg.UI.PreviewPane.Width
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "visible?", &visible, "width?", &width); err != nil {
+		return nil, err
+	}
+	if width <= 0 {
+		return nil, fmt.Errorf("width must be positive")
+	}
+	rt.cfg.UI.PreviewPane.Visible = visible
+	rt.cfg.UI.PreviewPane.Width = width
+	return starlark.None, nil
+}
+
+func (rt *Runtime) builtinTreePane(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := rejectCommandContext(thread, fn.Name()); err != nil {
+		return nil, err
+	}
+	visible := rt.cfg.UI.TreePane.Visible
+	width := rt.cfg.UI.TreePane.Width
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "visible?", &visible, "width?", &width); err != nil {
+		return nil, err
+	}
+	if width <= 0 {
+		return nil, fmt.Errorf("width must be positive")
+	}
+	rt.cfg.UI.TreePane.Visible = visible
+	rt.cfg.UI.TreePane.Width = width
+	return starlark.None, nil
+}
+
+func (rt *Runtime) builtinThumbnails(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := rejectCommandContext(thread, fn.Name()); err != nil {
+		return nil, err
+	}
+	enabled := rt.cfg.UI.Thumbnails.Enabled
+	size := rt.cfg.UI.Thumbnails.Size
+	diskCache := rt.cfg.UI.Thumbnails.DiskCache
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "enabled?", &enabled, "size?", &size, "disk_cache?", &diskCache); err != nil {
+		return nil, err
+	}
+	if !config.IsValidThumbnailSize(size) {
+		return nil, fmt.Errorf("size must be between %d and %d", config.MinThumbnailSize, config.MaxThumbnailSize)
+	}
+	rt.cfg.UI.Thumbnails.Enabled = enabled
+	rt.cfg.UI.Thumbnails.Size = size
+	rt.cfg.UI.Thumbnails.DiskCache = diskCache
+	return starlark.None, nil
+}
+
+func (rt *Runtime) builtinDiskUsage(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := rejectCommandContext(thread, fn.Name()); err != nil {
+		return nil, err
+	}
+	warnPercent := rt.cfg.UI.DiskUsage.WarnPercent
+	refreshSeconds := rt.cfg.UI.DiskUsage.RefreshSeconds
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "warn_percent?", &warnPercent, "refresh_seconds?", &refreshSeconds); err != nil {
+		return nil, err
+	}
+	if warnPercent < 0 || warnPercent > 100 {
+		return nil, fmt.Errorf("warn_percent must be between 0 and 100")
+	}
+	if refreshSeconds < 0 {
+		return nil, fmt.Errorf("refresh_seconds must be zero or positive")
+	}
+	rt.cfg.UI.DiskUsage.WarnPercent = warnPercent
+	rt.cfg.UI.DiskUsage.RefreshSeconds = refreshSeconds
+	return starlark.None, nil
+}
+
+func (rt *Runtime) builtinColumns(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := rejectCommandContext(thread, fn.Name()); err != nil {
+		return nil, err
+	}
+	var columnsValue starlark.Value
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "columns", &columnsValue); err != nil {
+		return nil, err
+	}
+	columns, err := stringList(columnsValue, "columns")
+	if err != nil {
+		return nil, err
+	}
+	if columns == nil {
+		columns = []string{}
+	}
+	if err := config.ValidateColumns(columns); err != nil {
+		return nil, err
+	}
+	rt.cfg.UI.Columns = columns
+	return starlark.None, nil
+}
+
 func (rt *Runtime) builtinArchive(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
 	if err := rejectCommandContext(thread, fn.Name()); err != nil {
 		return nil, err
@@ -586,10 +827,87 @@ func (rt *Runtime) builtinArchive(thread *starlark.Thread, fn *starlark.Builtin,
 	return starlark.None, nil
 }
 
+func (rt *Runtime) builtinGio(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := rejectCommandContext(thread, fn.Name()); err != nil {
+		return nil, err
+	}
+	enabled := rt.cfg.UI.Gio.Enabled
+	smbKerberos := rt.cfg.UI.Gio.SMBKerberos
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "enabled?", &enabled, "smb_kerberos?", &smbKerberos); err != nil {
+		return nil, err
+	}
+	rt.cfg.UI.Gio.Enabled = enabled
+	rt.cfg.UI.Gio.SMBKerberos = smbKerberos
+	return starlark.None, nil
+}
+
+func (rt *Runtime) builtinTerminal(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := rejectCommandContext(thread, fn.Name()); err != nil {
+		return nil, err
+	}
+	command := rt.cfg.Integrations.Terminal.Command
+	argsValue := starlark.Value(starlark.None)
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "cmd?", &command, "args?", &argsValue); err != nil {
+		return nil, err
+	}
+	commandArgs, err := stringList(argsValue, "args")
+	if err != nil {
+		return nil, err
+	}
+	command = strings.TrimSpace(command)
+	if command == "" && len(commandArgs) > 0 {
+		return nil, fmt.Errorf("terminal args need cmd")
+	}
+	rt.cfg.Integrations.Terminal.Command = command
+	rt.cfg.Integrations.Terminal.Args = commandArgs
+	return starlark.None, nil
+}
+
+func (rt *Runtime) builtinAuditLog(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := rejectCommandContext(thread, fn.Name()); err != nil {
+		return nil, err
+	}
+	enabled := rt.cfg.UI.AuditLog.Enabled
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "enabled?", &enabled); err != nil {
+		return nil, err
+	}
+	rt.cfg.UI.AuditLog.Enabled = enabled
+	return starlark.None, nil
+}
+
+func (rt *Runtime) builtinVault(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := rejectCommandContext(thread, fn.Name()); err != nil {
+		return nil, err
+	}
+	enabled := rt.cfg.UI.Vault.Enabled
+	idleTimeoutMinutes := rt.cfg.UI.Vault.IdleTimeoutMinutes
+	savePasswords := rt.cfg.UI.Vault.SavePasswords
+	if err := starlark.UnpackArgs(
+		fn.Name(),
+		args,
+		kwargs,
+		"enabled?", &enabled,
+		"idle_timeout_minutes?", &idleTimeoutMinutes,
+		"save_passwords?", &savePasswords,
+	); err != nil {
+		return nil, err
+	}
+	if idleTimeoutMinutes < 0 {
+		return nil, fmt.Errorf("idle_timeout_minutes must be zero or positive")
+	}
+	rt.cfg.UI.Vault.Enabled = enabled
+	rt.cfg.UI.Vault.IdleTimeoutMinutes = idleTimeoutMinutes
+	rt.cfg.UI.Vault.SavePasswords = savePasswords
+	return starlark.None, nil
+}
+
 func (rt *Runtime) builtinSort(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
 	sortBy := rt.cfg.UI.Sort.SortBy
 	sortOrder := rt.cfg.UI.Sort.SortOrder
 	directoriesFirst := rt.cfg.UI.Sort.DirectoriesFirst
+	natural := rt.cfg.UI.Sort.Natural
+	locale := rt.cfg.UI.Sort.Locale
+	groupBy := rt.cfg.UI.Sort.GroupBy
 	temporary := false
 	if err := starlark.UnpackArgs(
 		fn.Name(),
@@ -599,10 +917,13 @@ func (rt *Runtime) builtinSort(thread *starlark.Thread, fn *starlark.Builtin, ar
 		"order?", &sortOrder,
 		"directories_first?", &directoriesFirst,
 		"temporary?", &temporary,
+		"natural?", &natural,
+		"locale?", &locale,
+		"group?", &groupBy,
 	); err != nil {
 		return nil, err
 	}
-	sortConfig, err := validateSortConfig(sortBy, sortOrder, directoriesFirst)
+	sortConfig, err := validateSortConfig(sortBy, sortOrder, directoriesFirst, natural, locale, groupBy)
 	if err != nil {
 		return nil, err
 	}
@@ -659,6 +980,21 @@ func (rt *Runtime) builtinCursorMemory(thread *starlark.Thread, fn *starlark.Bui
 	return starlark.None, nil
 }
 
+func (rt *Runtime) builtinDirectoryViews(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := rejectCommandContext(thread, fn.Name()); err != nil {
+		return nil, err
+	}
+	maxEntries := rt.cfg.UI.DirectoryViews.MaxEntries
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "max_entries?", &maxEntries); err != nil {
+		return nil, err
+	}
+	if maxEntries <= 0 {
+		return nil, fmt.Errorf("max_entries must be positive")
+	}
+	rt.cfg.UI.DirectoryViews.MaxEntries = maxEntries
+	return starlark.None, nil
+}
+
 func (rt *Runtime) builtinNavigationHistory(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
 	if err := rejectCommandContext(thread, fn.Name()); err != nil {
 		return nil, err
@@ -913,6 +1249,276 @@ func (rt *Runtime) builtinClearExternalCommands(thread *starlark.Thread, _ *star
 	return starlark.None, nil
 }
 
+func (rt *Runtime) builtinOpenWith(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := rejectCommandContext(thread, fn.Name()); err != nil {
+		return nil, err
+	}
+	var name string
+	var key string
+	var command string
+	extensionsValue := starlark.Value(starlark.None)
+	argsValue := starlark.Value(starlark.None)
+	if err := starlark.UnpackArgs(
+		fn.Name(),
+		args,
+		kwargs,
+		"name", &name,
+		"cmd", &command,
+		"exts?", &extensionsValue,
+		"args?", &argsValue,
+		"key?", &key,
+	); err != nil {
+		return nil, err
+	}
+	extensions, err := stringList(extensionsValue, "exts")
+	if err != nil {
+		return nil, err
+	}
+	commandArgs, err := stringList(argsValue, "args")
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("open with name must not be empty")
+	}
+	if strings.TrimSpace(command) == "" {
+		return nil, fmt.Errorf("open with cmd must not be empty")
+	}
+	key, err = normalizeCommandMenuKey(key)
+	if err != nil {
+		return nil, err
+	}
+	rt.cfg.UI.OpenWith = append(rt.cfg.UI.OpenWith, config.OpenWithEntry{
+		Name:       name,
+		Key:        key,
+		Extensions: extensions,
+		Command:    command,
+		Args:       commandArgs,
+	})
+	return starlark.None, nil
+}
+
+func (rt *Runtime) builtinClearOpenWith(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := rejectCommandContext(thread, fn.Name()); err != nil {
+		return nil, err
+	}
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs); err != nil {
+		return nil, err
+	}
+	rt.cfg.UI.OpenWith = nil
+	return starlark.None, nil
+}
+
+func (rt *Runtime) builtinViewProfile(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := rejectCommandContext(thread, fn.Name()); err != nil {
+		return nil, err
+	}
+	var name string
+	var filter string
+	var sortBy string
+	sortOrder := "asc"
+	directoriesFirst := true
+	natural := false
+	var locale string
+	var groupBy string
+	directoriesValue := starlark.Value(starlark.None)
+	showMetadataValue := starlark.Value(starlark.None)
+	if err := starlark.UnpackArgs(
+		fn.Name(),
+		args,
+		kwargs,
+		"name", &name,
+		"directories?", &directoriesValue,
+		"sort_by?", &sortBy,
+		"sort_order?", &sortOrder,
+		"directories_first?", &directoriesFirst,
+		"sort_natural?", &natural,
+		"sort_locale?", &locale,
+		"sort_group?", &groupBy,
+		"filter?", &filter,
+		"show_metadata?", &showMetadataValue,
+	); err != nil {
+		return nil, err
+	}
+	directories, err := stringList(directoriesValue, "directories")
+	if err != nil {
+		return nil, err
+	}
+	profile := config.ViewProfile{Name: name, Directories: directories, Filter: filter}
+	if sortBy != "" {
+		sortConfig, err := validateSortConfig(sortBy, sortOrder, directoriesFirst, natural, locale, groupBy)
+		if err != nil {
+			return nil, err
+		}
+		profile.Sort = &sortConfig
+	}
+	if showMetadataValue != starlark.None {
+		showMetadata, ok := showMetadataValue.(starlark.Bool)
+		if !ok {
+			return nil, fmt.Errorf("show_metadata must be a bool or None")
+		}
+		value := bool(showMetadata)
+		profile.ShowMetadata = &value
+	}
+	// A profile with the same name replaces the earlier one in place.
+	profiles := make([]config.ViewProfile, 0, len(rt.cfg.UI.ViewProfiles)+1)
+	replaced := false
+	for _, existing := range rt.cfg.UI.ViewProfiles {
+		if existing.Name == name {
+			existing, replaced = profile, true
+		}
+		profiles = append(profiles, existing)
+	}
+	if !replaced {
+		profiles = append(profiles, profile)
+	}
+	if err := config.ValidateViewProfiles(profiles); err != nil {
+		return nil, err
+	}
+	rt.cfg.UI.ViewProfiles = profiles
+	return starlark.None, nil
+}
+
+func (rt *Runtime) builtinClearViewProfiles(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := rejectCommandContext(thread, fn.Name()); err != nil {
+		return nil, err
+	}
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs); err != nil {
+		return nil, err
+	}
+	rt.cfg.UI.ViewProfiles = nil
+	return starlark.None, nil
+}
+
+func (rt *Runtime) builtinWatchRule(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := rejectCommandContext(thread, fn.Name()); err != nil {
+		return nil, err
+	}
+	var name string
+	directoriesValue := starlark.Value(starlark.None)
+	patternsValue := starlark.Value(starlark.None)
+	if err := starlark.UnpackArgs(
+		fn.Name(),
+		args,
+		kwargs,
+		"name", &name,
+		"directories?", &directoriesValue,
+		"patterns?", &patternsValue,
+	); err != nil {
+		return nil, err
+	}
+	directories, err := stringList(directoriesValue, "directories")
+	if err != nil {
+		return nil, err
+	}
+	patterns, err := stringList(patternsValue, "patterns")
+	if err != nil {
+		return nil, err
+	}
+	rule := config.WatchRule{Name: name, Directories: directories, Patterns: patterns}
+	// A rule with the same name replaces the earlier one in place.
+	rules := make([]config.WatchRule, 0, len(rt.cfg.UI.WatchRules)+1)
+	replaced := false
+	for _, existing := range rt.cfg.UI.WatchRules {
+		if existing.Name == name {
+			existing, replaced = rule, true
+		}
+		rules = append(rules, existing)
+	}
+	if !replaced {
+		rules = append(rules, rule)
+	}
+	if err := config.ValidateWatchRules(rules); err != nil {
+		return nil, err
+	}
+	rt.cfg.UI.WatchRules = rules
+	return starlark.None, nil
+}
+
+func (rt *Runtime) builtinClearWatchRules(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := rejectCommandContext(thread, fn.Name()); err != nil {
+		return nil, err
+	}
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs); err != nil {
+		return nil, err
+	}
+	rt.cfg.UI.WatchRules = nil
+	return starlark.None, nil
+}
+
+func (rt *Runtime) builtinFilterPreset(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := rejectCommandContext(thread, fn.Name()); err != nil {
+		return nil, err
+	}
+	var name, minSize, maxSize, modifiedSince string
+	includeValue := starlark.Value(starlark.None)
+	excludeValue := starlark.Value(starlark.None)
+	typesValue := starlark.Value(starlark.None)
+	if err := starlark.UnpackArgs(
+		fn.Name(),
+		args,
+		kwargs,
+		"name", &name,
+		"include?", &includeValue,
+		"exclude?", &excludeValue,
+		"min_size?", &minSize,
+		"max_size?", &maxSize,
+		"modified_since?", &modifiedSince,
+		"types?", &typesValue,
+	); err != nil {
+		return nil, err
+	}
+	include, err := stringList(includeValue, "include")
+	if err != nil {
+		return nil, err
+	}
+	exclude, err := stringList(excludeValue, "exclude")
+	if err != nil {
+		return nil, err
+	}
+	types, err := stringList(typesValue, "types")
+	if err != nil {
+		return nil, err
+	}
+	preset := config.FilterPreset{
+		Name:          name,
+		Include:       include,
+		Exclude:       exclude,
+		MinSize:       minSize,
+		MaxSize:       maxSize,
+		ModifiedSince: modifiedSince,
+		Types:         types,
+	}
+	// A preset with the same name replaces the earlier one in place.
+	presets := make([]config.FilterPreset, 0, len(rt.cfg.UI.FileFilter.Presets)+1)
+	replaced := false
+	for _, existing := range rt.cfg.UI.FileFilter.Presets {
+		if existing.Name == name {
+			existing, replaced = preset, true
+		}
+		presets = append(presets, existing)
+	}
+	if !replaced {
+		presets = append(presets, preset)
+	}
+	if err := config.ValidateFilterPresets(presets); err != nil {
+		return nil, err
+	}
+	rt.cfg.UI.FileFilter.Presets = presets
+	return starlark.None, nil
+}
+
+func (rt *Runtime) builtinClearFilterPresets(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := rejectCommandContext(thread, fn.Name()); err != nil {
+		return nil, err
+	}
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs); err != nil {
+		return nil, err
+	}
+	rt.cfg.UI.FileFilter.Presets = nil
+	return starlark.None, nil
+}
+
 func (rt *Runtime) builtinCommand(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
 	if err := rejectCommandContext(thread, fn.Name()); err != nil {
 		return nil, err
@@ -1487,6 +2093,9 @@ func sortConfigValue(sortConfig config.SortConfig) starlark.Value {
 		"by":                starlark.String(sortConfig.SortBy),
 		"order":             starlark.String(sortConfig.SortOrder),
 		"directories_first": starlark.Bool(sortConfig.DirectoriesFirst),
+		"natural":           starlark.Bool(sortConfig.Natural),
+		"locale":            starlark.String(sortConfig.Locale),
+		"group":             starlark.String(sortConfig.GroupBy),
 	})
 }
 
@@ -1670,16 +2279,25 @@ func formatStarlarkError(err error) string {
 	return err.Error()
 }
 
-func validateSortConfig(sortBy string, sortOrder string, directoriesFirst bool) (config.SortConfig, error) {
+func validateSortConfig(sortBy string, sortOrder string, directoriesFirst bool, natural bool, locale string, groupBy string) (config.SortConfig, error) {
 	if !config.IsValidSortBy(sortBy) {
-		return config.SortConfig{}, fmt.Errorf("sort by must be one of name, size, modified, or extension")
+		return config.SortConfig{}, fmt.Errorf("sort by must be one of name, size, modified, extension, dateTaken, or tag")
 	}
 	if !config.IsValidSortOrder(sortOrder) {
 		return config.SortConfig{}, fmt.Errorf("sort order must be asc or desc")
 	}
+	if !config.IsValidSortLocale(locale) {
+		return config.SortConfig{}, fmt.Errorf("sort locale must be empty, auto, or a BCP 47 language tag")
+	}
+	if !config.IsValidGroupBy(groupBy) {
+		return config.SortConfig{}, fmt.Errorf("sort group must be empty or dateTaken")
+	}
 	return config.SortConfig{
 		SortBy:           sortBy,
 		SortOrder:        sortOrder,
 		DirectoriesFirst: directoriesFirst,
+		Natural:          natural,
+		Locale:           locale,
+		GroupBy:          groupBy,
 	}, nil
 }
@@ -20,7 +20,7 @@ func TestLoadAppliesStarlarkConfig(t *testing.T) {
 	path := filepath.Join(dir, FileName)
 	src := `
 nmf.window(width = 1000, height = 720, x = 200, y = 120)
-nmf.startup(directory = "~/work")
+nmf.startup(directory = "~/work", restore_tabs = True)
 nmf.theme(dark = False, font_size = 16, font_name = "Noto Sans")
 if nmf.dark_theme():
     nmf.theme(font_name = "wrong")
@@ -30,13 +30,25 @@ nmf.color("lineEditCursor", value = "primary")
 nmf.color("lineEditSelection", value = [5, 6, 7, 8])
 nmf.color("dialogListCursor", value = "selection")
 nmf.debug_logging(enabled = True, log_directory = "logs/debug", max_files = 4)
-nmf.ui(show_hidden_files = True, item_spacing = 2, scroll_margin = 5)
-nmf.copy(preserve_timestamps = True)
+nmf.ui(show_hidden_files = True, hide_git_ignored = True, item_spacing = 2, scroll_margin = 5, icon_set = "mono", open_links = "follow", auto_refresh = False)
+nmf.copy(preserve_timestamps = True, preserve_xattrs = True, elevate = True, duplicate_name = "{name}_copy{n}{ext}")
+nmf.jobs(workers = 3, per_volume_limit = 0)
 nmf.viewer(max_width = 1200, max_height = 900, default_pane = "text", default_wrap = True)
 nmf.archive(zip_name_encoding = "cp437")
-nmf.sort(by = "extension", order = "desc", directories_first = False)
+nmf.gio(enabled = False, smb_kerberos = False)
+nmf.terminal(cmd = "kitty", args = ["--directory", "{dir}"])
+nmf.audit_log(enabled = True)
+nmf.vault(enabled = False, idle_timeout_minutes = 5, save_passwords = True)
+nmf.metadata(show_in_list = True)
+nmf.preview_pane(visible = True, width = 400)
+nmf.tree_pane(visible = True, width = 280)
+nmf.thumbnails(enabled = True, size = 256, disk_cache = False)
+nmf.disk_usage(warn_percent = 15, refresh_seconds = 60)
+nmf.columns(["name", "size", "modified", "owner"])
+nmf.sort(by = "extension", order = "desc", directories_first = False, natural = True, locale = "ja", group = "dateTaken")
 nmf.cursor_style(type = "border", thickness = 3)
 nmf.cursor_memory(max_entries = 12)
+nmf.directory_views(max_entries = 33)
 nmf.navigation_history(max_entries = 9)
 nmf.file_filter(max_entries = 7)
 nmf.clear_directory_jumps()
@@ -53,6 +65,12 @@ nmf.external_command(
     cwd = "{dir}",
     edit = True,
 )
+nmf.clear_open_with()
+nmf.open_with(name = "GIMP", key = "g", exts = ["png"], cmd = "gimp", args = ["%F"])
+nmf.view_profile(name = "photos", directories = ["~/Pictures/**"], sort_by = "dateTaken", sort_order = "desc", filter = "*.jpg", show_metadata = True)
+nmf.watch_rule(name = "pdf", directories = ["~/Downloads"], patterns = ["*.pdf"])
+nmf.watch_rule(name = "pdf", directories = ["~/Downloads"], patterns = ["*.pdf", "*.epub"])
+nmf.filter_preset(name = "big", include = ["*.iso"], min_size = "1G", types = ["file"])
 def parent(ctx):
     return None
 nmf.command("user.parent", parent)
@@ -76,6 +94,9 @@ nmf.command("user.parent", parent)
 	if cfg.Startup.Directory != "~/work" {
 		t.Fatalf("startup directory = %q, want ~/work", cfg.Startup.Directory)
 	}
+	if !cfg.Startup.RestoreTabs {
+		t.Fatal("startup restore_tabs = false, want true")
+	}
 	if cfg.Theme.Dark || cfg.Theme.FontSize != 16 || cfg.Theme.FontName != "Noto Sans" {
 		t.Fatalf("theme = %+v, want light 16 Noto Sans", cfg.Theme)
 	}
@@ -103,11 +124,14 @@ nmf.command("user.parent", parent)
 	if !cfg.Debug.Enabled || cfg.Debug.LogDirectory != "logs/debug" || cfg.Debug.MaxLogFiles != 4 {
 		t.Fatalf("debug = %+v, want enabled logs/debug max 4", cfg.Debug)
 	}
-	if !cfg.UI.ShowHiddenFiles || cfg.UI.ItemSpacing != 2 || cfg.UI.ScrollMargin != 5 {
-		t.Fatalf("ui = %+v, want hidden=true spacing=2 scroll margin=5", cfg.UI)
+	if !cfg.UI.ShowHiddenFiles || !cfg.UI.HideGitIgnored || cfg.UI.ItemSpacing != 2 || cfg.UI.ScrollMargin != 5 || cfg.UI.IconSet != "mono" || cfg.UI.OpenLinks != "follow" || cfg.UI.AutoRefresh {
+		t.Fatalf("ui = %+v, want hidden=true spacing=2 scroll margin=5 icon set=mono open links=follow", cfg.UI)
+	}
+	if !cfg.UI.Copy.PreserveTimestamps || !cfg.UI.Copy.PreserveXattrs || cfg.UI.Copy.PreserveOwnership || !cfg.UI.Copy.Elevate || cfg.UI.Copy.DuplicateName != "{name}_copy{n}{ext}" {
+		t.Fatalf("copy = %+v, want preserve_timestamps=true preserve_xattrs=true elevate=true duplicate_name={name}_copy{n}{ext}", cfg.UI.Copy)
 	}
-	if !cfg.UI.Copy.PreserveTimestamps {
-		t.Fatalf("copy = %+v, want preserve_timestamps=true", cfg.UI.Copy)
+	if cfg.UI.Jobs.Workers != 3 || cfg.UI.Jobs.PerVolumeLimit != 0 {
+		t.Fatalf("jobs = %+v, want workers=3 per_volume_limit=0", cfg.UI.Jobs)
 	}
 	if cfg.UI.Viewer.MaxWidth != 1200 || cfg.UI.Viewer.MaxHeight != 900 ||
 		cfg.UI.Viewer.DefaultPane != "text" || !cfg.UI.Viewer.DefaultWrap {
@@ -116,12 +140,45 @@ nmf.command("user.parent", parent)
 	if cfg.UI.Archive.ZipNameEncoding != "cp437" {
 		t.Fatalf("archive = %+v, want cp437", cfg.UI.Archive)
 	}
-	if cfg.UI.Sort.SortBy != "extension" || cfg.UI.Sort.SortOrder != "desc" || cfg.UI.Sort.DirectoriesFirst {
-		t.Fatalf("sort = %+v, want extension desc dirs=false", cfg.UI.Sort)
+	if term := cfg.Integrations.Terminal; term.Command != "kitty" || len(term.Args) != 2 || term.Args[1] != "{dir}" {
+		t.Fatalf("terminal = %+v, want kitty --directory {dir}", term)
+	}
+	if cfg.UI.Gio.Enabled || cfg.UI.Gio.SMBKerberos {
+		t.Fatalf("gio = %+v, want enabled=false smbKerberos=false", cfg.UI.Gio)
+	}
+	if !cfg.UI.AuditLog.Enabled {
+		t.Fatalf("audit log = %+v, want enabled=true", cfg.UI.AuditLog)
+	}
+	if cfg.UI.Vault.Enabled || cfg.UI.Vault.IdleTimeoutMinutes != 5 || !cfg.UI.Vault.SavePasswords {
+		t.Fatalf("vault = %+v, want enabled=false idle=5 save=true", cfg.UI.Vault)
+	}
+	if !cfg.UI.Metadata.ShowInList {
+		t.Fatalf("metadata = %+v, want show_in_list=true", cfg.UI.Metadata)
+	}
+	if !cfg.UI.PreviewPane.Visible || cfg.UI.PreviewPane.Width != 400 {
+		t.Fatalf("preview pane = %+v, want visible width=400", cfg.UI.PreviewPane)
+	}
+	if !cfg.UI.TreePane.Visible || cfg.UI.TreePane.Width != 280 {
+		t.Fatalf("tree pane = %+v, want visible width=280", cfg.UI.TreePane)
+	}
+	if !cfg.UI.Thumbnails.Enabled || cfg.UI.Thumbnails.Size != 256 || cfg.UI.Thumbnails.DiskCache {
+		t.Fatalf("thumbnails = %+v, want enabled size=256 no disk cache", cfg.UI.Thumbnails)
+	}
+	if cfg.UI.DiskUsage.WarnPercent != 15 || cfg.UI.DiskUsage.RefreshSeconds != 60 {
+		t.Fatalf("disk usage = %+v, want warn_percent=15 refresh_seconds=60", cfg.UI.DiskUsage)
+	}
+	if got := strings.Join(cfg.UI.Columns, ","); got != "name,size,modified,owner" {
+		t.Fatalf("columns = %q, want name,size,modified,owner", got)
+	}
+	if cfg.UI.Sort.SortBy != "extension" || cfg.UI.Sort.SortOrder != "desc" || cfg.UI.Sort.DirectoriesFirst || !cfg.UI.Sort.Natural || cfg.UI.Sort.Locale != "ja" || cfg.UI.Sort.GroupBy != "dateTaken" {
+		t.Fatalf("sort = %+v, want extension desc dirs=false natural ja grouped by date taken", cfg.UI.Sort)
 	}
 	if cfg.UI.CursorStyle.Type != "border" || cfg.UI.CursorStyle.Thickness != 3 {
 		t.Fatalf("cursor style = %+v, want border thickness 3", cfg.UI.CursorStyle)
 	}
+	if cfg.UI.DirectoryViews.MaxEntries != 33 {
+		t.Fatalf("directory views max entries = %d, want 33", cfg.UI.DirectoryViews.MaxEntries)
+	}
 	if cfg.UI.CursorMemory.MaxEntries != 12 || cfg.UI.NavigationHistory.MaxEntries != 9 || cfg.UI.FileFilter.MaxEntries != 7 {
 		t.Fatalf("max entries not applied: cursor=%d history=%d filter=%d",
 			cfg.UI.CursorMemory.MaxEntries,
@@ -140,6 +197,21 @@ nmf.command("user.parent", parent)
 	if len(cfg.UI.ExternalCommands) != 1 || cfg.UI.ExternalCommands[0].Key != "V" || cfg.UI.ExternalCommands[0].Command != "vim" || cfg.UI.ExternalCommands[0].Cwd != "{dir}" || !cfg.UI.ExternalCommands[0].Edit {
 		t.Fatalf("external commands = %+v, want vim", cfg.UI.ExternalCommands)
 	}
+	if len(cfg.UI.OpenWith) != 1 || cfg.UI.OpenWith[0].Key != "g" || cfg.UI.OpenWith[0].Command != "gimp" || cfg.UI.OpenWith[0].Args[0] != "%F" {
+		t.Fatalf("open with = %+v, want gimp", cfg.UI.OpenWith)
+	}
+	if len(cfg.UI.ViewProfiles) != 1 {
+		t.Fatalf("view profiles = %+v, want photos", cfg.UI.ViewProfiles)
+	}
+	if profile := cfg.UI.ViewProfiles[0]; profile.Sort == nil || profile.Sort.SortBy != "dateTaken" || profile.Filter != "*.jpg" || profile.ShowMetadata == nil || !*profile.ShowMetadata {
+		t.Fatalf("view profile = %+v, want dateTaken sort, jpg filter, metadata", profile)
+	}
+	if len(cfg.UI.WatchRules) != 1 || len(cfg.UI.WatchRules[0].Patterns) != 2 {
+		t.Fatalf("watch rules = %+v, want one replaced pdf rule", cfg.UI.WatchRules)
+	}
+	if presets := cfg.UI.FileFilter.Presets; len(presets) != 1 || presets[0].Name != "big" || presets[0].MinSize != "1G" {
+		t.Fatalf("filter presets = %+v, want big preset", presets)
+	}
 	if _, ok := rt.Commands["user.parent"]; !ok {
 		t.Fatal("user.parent command was not registered")
 	}
@@ -247,6 +319,97 @@ func TestUIRejectsNegativeScrollMargin(t *testing.T) {
 	}
 }
 
+func TestUIRejectsUnknownIconSet(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, FileName)
+	if err := os.WriteFile(path, []byte(`nmf.ui(icon_set = "color")`), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	_, err := Load(path, testConfig(), Options{})
+	if err == nil || !strings.Contains(err.Error(), "icon_set must be native or mono") {
+		t.Fatalf("Load error = %v, want unknown icon set error", err)
+	}
+}
+
+func TestUIRejectsUnknownOpenLinks(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, FileName)
+	if err := os.WriteFile(path, []byte(`nmf.ui(open_links = "maybe")`), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	_, err := Load(path, testConfig(), Options{})
+	if err == nil || !strings.Contains(err.Error(), "open_links must be ask, follow, or physical") {
+		t.Fatalf("Load error = %v, want unknown open links error", err)
+	}
+}
+
+func TestUIKeepsOpenLinksWhenOmitted(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, FileName)
+	if err := os.WriteFile(path, []byte(`nmf.ui(show_hidden_files = True)`), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	cfg := testConfig()
+	cfg.UI.OpenLinks = ""
+
+	if _, err := Load(path, cfg, Options{}); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.UI.OpenLinks != "" || !cfg.UI.ShowHiddenFiles {
+		t.Fatalf("ui = open_links %q show_hidden %t, want untouched open_links and hidden files shown", cfg.UI.OpenLinks, cfg.UI.ShowHiddenFiles)
+	}
+}
+
+func TestUIKeepsPositionalArgumentOrder(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, FileName)
+	if err := os.WriteFile(path, []byte(`nmf.ui(True, 2, 3)`), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	cfg := testConfig()
+	cfg.UI.OpenLinks = ""
+
+	if _, err := Load(path, cfg, Options{}); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !cfg.UI.ShowHiddenFiles || cfg.UI.ItemSpacing != 2 || cfg.UI.ScrollMargin != 3 || cfg.UI.HideGitIgnored {
+		t.Fatalf("ui = hidden %t spacing %d margin %d git ignored %t, want True, 2, 3 and git-ignored files shown",
+			cfg.UI.ShowHiddenFiles, cfg.UI.ItemSpacing, cfg.UI.ScrollMargin, cfg.UI.HideGitIgnored)
+	}
+}
+
+func TestUIRejectsOutOfRangeStatWorkers(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, FileName)
+	if err := os.WriteFile(path, []byte(`nmf.ui(stat_workers = 0)`), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	_, err := Load(path, testConfig(), Options{})
+	if err == nil || !strings.Contains(err.Error(), "stat_workers must be between") {
+		t.Fatalf("Load error = %v, want stat workers range error", err)
+	}
+}
+
+func TestUIKeepsStatWorkersWhenOmitted(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, FileName)
+	if err := os.WriteFile(path, []byte(`nmf.ui(item_spacing = 2)`), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	cfg := testConfig()
+	cfg.UI.StatWorkers = 0
+
+	if _, err := Load(path, cfg, Options{}); err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if cfg.UI.StatWorkers != 0 {
+		t.Fatalf("StatWorkers = %d, want the untouched 0", cfg.UI.StatWorkers)
+	}
+}
+
 func TestViewerRejectsInvalidDefaultPane(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, FileName)
@@ -683,6 +846,44 @@ nmf.command("user.sort_size", sort_size)
 	}
 }
 
+func TestSortKeepsTemporaryAsFourthPositionalArgument(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, FileName)
+	src := `
+def sort_name(ctx):
+    nmf.sort("name", "asc", True, True)
+nmf.command("user.sort_name", sort_name)
+`
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	rt, err := Load(path, testConfig(), Options{})
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+
+	fm := &configScriptFakeFileManager{}
+	rt.Commands["user.sort_name"](keymanager.CommandContext{FileManager: fm})
+
+	want := config.SortConfig{SortBy: "name", SortOrder: "asc", DirectoriesFirst: true}
+	if !fm.temporarySortApplied || !reflect.DeepEqual(fm.temporarySort, want) {
+		t.Fatalf("temporary sort = applied %t config %+v, want %+v", fm.temporarySortApplied, fm.temporarySort, want)
+	}
+}
+
+func TestSortRejectsUnknownSortBy(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, FileName)
+	if err := os.WriteFile(path, []byte(`nmf.sort(by = "owner")`), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	_, err := Load(path, testConfig(), Options{})
+	if err == nil || !strings.Contains(err.Error(), "sort by must be one of name, size, modified, extension, dateTaken, or tag") {
+		t.Fatalf("Load error = %v, want unknown sort by error", err)
+	}
+}
+
 func TestSortWithoutTemporaryFailsInsideCustomCommand(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, FileName)
@@ -2318,6 +2519,7 @@ func testConfig() *config.Config {
 			},
 			ItemSpacing:  4,
 			ScrollMargin: 3,
+			IconSet:      config.IconSetNative,
 			Archive: config.ArchiveConfig{
 				ZipNameEncoding: "shift_jis",
 			},
@@ -2414,13 +2616,28 @@ func (f *configScriptFakeFileManager) RefreshFileList()                  {}
 func (f *configScriptFakeFileManager) SaveCursorPosition(dirPath string) {}
 func (f *configScriptFakeFileManager) OpenNewWindow()                    {}
 func (f *configScriptFakeFileManager) ReopenClosedWindow()               {}
+func (f *configScriptFakeFileManager) ReopenClosed()                     {}
+func (f *configScriptFakeFileManager) NewTab()                           {}
+func (f *configScriptFakeFileManager) CloseTab()                         {}
+func (f *configScriptFakeFileManager) SwitchTab(delta int)               {}
 func (f *configScriptFakeFileManager) FocusWindowLeft()                  {}
 func (f *configScriptFakeFileManager) FocusWindowRight()                 {}
 func (f *configScriptFakeFileManager) ResetWindowSize()                  {}
 func (f *configScriptFakeFileManager) ResetAllWindowSizes()              {}
 func (f *configScriptFakeFileManager) PinCurrentHistoryPath()            {}
+func (f *configScriptFakeFileManager) JumpToBookmarkSlot(int)            {}
+func (f *configScriptFakeFileManager) SetBookmarkSlot(int)               {}
 func (f *configScriptFakeFileManager) ClearFilter()                      {}
 func (f *configScriptFakeFileManager) ToggleFilter()                     {}
+func (f *configScriptFakeFileManager) TogglePreviewPane()                {}
+func (f *configScriptFakeFileManager) ToggleTreePane()                   {}
+func (f *configScriptFakeFileManager) ToggleAutoRefresh()                {}
+func (f *configScriptFakeFileManager) ToggleThumbnails()                 {}
+func (f *configScriptFakeFileManager) ToggleHiddenFiles()                {}
+func (f *configScriptFakeFileManager) ToggleGitIgnored()                 {}
+func (f *configScriptFakeFileManager) SetColorTag(fileinfo.ColorTag)     {}
+func (f *configScriptFakeFileManager) CalculateDirectorySizes()          {}
+func (f *configScriptFakeFileManager) OpenTerminalHere()                 {}
 func (f *configScriptFakeFileManager) CreateDirectory(name string) bool {
 	f.createDirName = name
 	return f.createDirResult
@@ -63,5 +63,6 @@ const (
 // Tree dialog constants
 const (
 	RootModeOptionText   = "Root /"
+	ShareModeOptionText  = "Share Root"
 	ParentModeOptionText = "Parent Dir"
 )
@@ -0,0 +1,245 @@
+package filecompare
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"nmf/internal/fileinfo"
+)
+
+const (
+	// SyncMirror makes the destination tree an exact copy of the source:
+	// missing and changed entries are copied over and entries found only in
+	// the destination are deleted.
+	SyncMirror Method = "sync_mirror"
+	// SyncTwoWay copies entries missing on either side across and, for files
+	// on both sides, the newer copy over the older one. Nothing is deleted.
+	SyncTwoWay Method = "sync_two_way"
+)
+
+// IsSync reports whether m synchronizes directory trees instead of marking
+// files.
+func (m Method) IsSync() bool {
+	return m == SyncMirror || m == SyncTwoWay
+}
+
+// SyncActionKind identifies one step of a synchronization plan.
+type SyncActionKind string
+
+const (
+	SyncCopy   SyncActionKind = "copy"
+	SyncDelete SyncActionKind = "delete"
+	// SyncConflict marks an entry a two-way sync cannot settle: a file on
+	// one side and a directory on the other, or files with the same time and
+	// different sizes. It is listed in the preview and left alone.
+	SyncConflict SyncActionKind = "conflict"
+)
+
+// SyncAction is one planned step. A copy writes From to To, replacing what
+// is there; a delete removes To. Dir marks a whole directory tree.
+type SyncAction struct {
+	Kind SyncActionKind
+	From string
+	To   string
+	Dir  bool
+	// Size is the bytes a copy writes, the tree total for directories.
+	Size int64
+}
+
+// syncTimeTolerance absorbs the coarse timestamps of FAT volumes and some
+// SMB servers, so files copied with preserved times compare as unchanged.
+const syncTimeTolerance = 2 * time.Second
+
+// PlanSync compares the trees under source and target and returns the
+// actions that synchronize them with method, parents before children.
+// Directories on both sides are compared recursively; symlinks are compared
+// as entries and never followed. Any directory that cannot be listed stops
+// the plan, since a partial listing would turn into wrong copies or deletes.
+func PlanSync(ctx context.Context, source, target string, method Method) ([]SyncAction, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	p := syncPlanner{ctx: ctx, method: method}
+	if err := p.dir(source, target); err != nil {
+		return nil, err
+	}
+	return p.actions, nil
+}
+
+type syncPlanner struct {
+	ctx     context.Context
+	method  Method
+	actions []SyncAction
+}
+
+func (p *syncPlanner) dir(source, target string) error {
+	if err := p.ctx.Err(); err != nil {
+		return err
+	}
+	sourceEntries, err := syncEntries(p.ctx, source)
+	if err != nil {
+		return err
+	}
+	targetEntries, err := syncEntries(p.ctx, target)
+	if err != nil {
+		return err
+	}
+	for _, pair := range pairSyncEntries(sourceEntries, targetEntries) {
+		if err := p.pair(source, target, pair); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *syncPlanner) pair(source, target string, pair syncPair) error {
+	s, d := pair.source, pair.target
+	switch {
+	case d == nil:
+		p.copy(*s, fileinfo.JoinPath(target, s.Name))
+	case s == nil:
+		if p.method == SyncMirror {
+			p.delete(*d)
+		} else {
+			p.copy(*d, fileinfo.JoinPath(source, d.Name))
+		}
+	case isSyncDir(*s) && isSyncDir(*d):
+		return p.dir(s.Path, d.Path)
+	case isSyncDir(*s) != isSyncDir(*d):
+		if p.method == SyncMirror {
+			p.delete(*d)
+			p.copy(*s, d.Path)
+		} else {
+			p.conflict(*s, *d)
+		}
+	case sameSyncFile(*s, *d):
+	case p.method == SyncMirror:
+		p.copy(*s, d.Path)
+	case s.Modified.Sub(d.Modified) > syncTimeTolerance:
+		p.copy(*s, d.Path)
+	case d.Modified.Sub(s.Modified) > syncTimeTolerance:
+		p.copy(*d, s.Path)
+	default:
+		p.conflict(*s, *d)
+	}
+	return nil
+}
+
+func (p *syncPlanner) copy(from fileinfo.FileInfo, to string) {
+	size := from.Size
+	if isSyncDir(from) {
+		size = syncTreeBytes(p.ctx, from.Path)
+	}
+	p.actions = append(p.actions, SyncAction{Kind: SyncCopy, From: from.Path, To: to, Dir: isSyncDir(from), Size: size})
+}
+
+func (p *syncPlanner) delete(fi fileinfo.FileInfo) {
+	p.actions = append(p.actions, SyncAction{Kind: SyncDelete, To: fi.Path, Dir: isSyncDir(fi)})
+}
+
+func (p *syncPlanner) conflict(source, target fileinfo.FileInfo) {
+	p.actions = append(p.actions, SyncAction{Kind: SyncConflict, From: source.Path, To: target.Path, Dir: isSyncDir(source)})
+}
+
+// syncPair is one name as it exists in the source and target directories;
+// either side is nil when the entry is missing there.
+type syncPair struct {
+	source *fileinfo.FileInfo
+	target *fileinfo.FileInfo
+}
+
+// pairSyncEntries matches entries by exact name, then leftovers whose names
+// differ only in case, so a case-insensitive destination such as an SMB share
+// never sees a copy of A.txt followed by a delete of a.txt.
+func pairSyncEntries(source, target []fileinfo.FileInfo) []syncPair {
+	targetByName := make(map[string]int, len(target))
+	for i, fi := range target {
+		targetByName[fi.Name] = i
+	}
+	used := make([]bool, len(target))
+	pairs := make([]syncPair, 0, len(source)+len(target))
+	var unmatched []int
+	for i := range source {
+		if j, ok := targetByName[source[i].Name]; ok {
+			used[j] = true
+			pairs = append(pairs, syncPair{source: &source[i], target: &target[j]})
+			continue
+		}
+		unmatched = append(unmatched, i)
+	}
+	for _, i := range unmatched {
+		pair := syncPair{source: &source[i]}
+		for j := range target {
+			if !used[j] && strings.EqualFold(source[i].Name, target[j].Name) {
+				used[j] = true
+				pair.target = &target[j]
+				break
+			}
+		}
+		pairs = append(pairs, pair)
+	}
+	for j := range target {
+		if !used[j] {
+			pairs = append(pairs, syncPair{target: &target[j]})
+		}
+	}
+	sort.SliceStable(pairs, func(a, b int) bool {
+		return pairs[a].name() < pairs[b].name()
+	})
+	return pairs
+}
+
+func (p syncPair) name() string {
+	if p.source != nil {
+		return p.source.Name
+	}
+	return p.target.Name
+}
+
+func syncEntries(ctx context.Context, dir string) ([]fileinfo.FileInfo, error) {
+	entries, err := fileinfo.ReadDirPortableContext(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]fileinfo.FileInfo, 0, len(entries))
+	for _, entry := range entries {
+		fi, err := fileinfo.FileInfoFromDirEntry(dir, entry)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, fi)
+	}
+	return files, nil
+}
+
+// syncTreeBytes sums the file sizes under dir for progress and the preview.
+// Unreadable subdirectories count as empty.
+func syncTreeBytes(ctx context.Context, dir string) int64 {
+	entries, err := syncEntries(ctx, dir)
+	if err != nil {
+		return 0
+	}
+	var total int64
+	for _, fi := range entries {
+		if isSyncDir(fi) {
+			total += syncTreeBytes(ctx, fi.Path)
+		} else {
+			total += fi.Size
+		}
+	}
+	return total
+}
+
+func isSyncDir(fi fileinfo.FileInfo) bool {
+	return fi.IsDir && fi.FileType != fileinfo.FileTypeSymlink
+}
+
+func sameSyncFile(a, b fileinfo.FileInfo) bool {
+	diff := a.Modified.Sub(b.Modified)
+	if diff < 0 {
+		diff = -diff
+	}
+	return a.Size == b.Size && diff <= syncTimeTolerance
+}
@@ -0,0 +1,132 @@
+package filecompare
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlanSyncMirrorAndTwoWay(t *testing.T) {
+	base := time.Unix(1_700_000_000, 0)
+	srcDir := t.TempDir()
+	dstDir := t.TempDir()
+	for _, dir := range []string{"src/sub", "src/newdir", "dst/sub", "dst/olddir", "src/kind", "dst/oldsub"} {
+		root, rel, _ := strings.Cut(dir, "/")
+		parent := srcDir
+		if root == "dst" {
+			parent = dstDir
+		}
+		if err := os.MkdirAll(filepath.Join(parent, rel), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(t, srcDir, "same.txt", "same", base)
+	writeFile(t, dstDir, "same.txt", "same", base.Add(time.Second))
+	writeFile(t, srcDir, "src-newer.txt", "new", base.Add(time.Hour))
+	writeFile(t, dstDir, "src-newer.txt", "old", base)
+	writeFile(t, srcDir, "dst-newer.txt", "old", base)
+	writeFile(t, dstDir, "dst-newer.txt", "new", base.Add(time.Hour))
+	writeFile(t, srcDir, "only-src.txt", "s", base)
+	writeFile(t, dstDir, "only-dst.txt", "d", base)
+	writeFile(t, srcDir, "newdir/a.txt", "12345", base)
+	writeFile(t, dstDir, "sub/gone.txt", "g", base)
+	writeFile(t, dstDir, "kind", "file", base)
+
+	tests := []struct {
+		method Method
+		want   []string
+	}{
+		{method: SyncMirror, want: []string{
+			"copy src/dst-newer.txt -> dst/dst-newer.txt",
+			"delete dst/kind",
+			"copy src/kind/ -> dst/kind (0)",
+			"copy src/newdir/ -> dst/newdir (5)",
+			"delete dst/oldsub/",
+			"delete dst/only-dst.txt",
+			"copy src/only-src.txt -> dst/only-src.txt",
+			"copy src/src-newer.txt -> dst/src-newer.txt",
+			"delete dst/sub/gone.txt",
+			"delete dst/olddir/",
+		}},
+		{method: SyncTwoWay, want: []string{
+			"copy dst/dst-newer.txt -> src/dst-newer.txt",
+			"conflict src/kind/ -> dst/kind",
+			"copy src/newdir/ -> dst/newdir (5)",
+			"copy dst/oldsub/ -> src/oldsub (0)",
+			"copy dst/only-dst.txt -> src/only-dst.txt",
+			"copy src/only-src.txt -> dst/only-src.txt",
+			"copy src/src-newer.txt -> dst/src-newer.txt",
+			"copy dst/sub/gone.txt -> src/sub/gone.txt",
+			"copy dst/olddir/ -> src/olddir (0)",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.method), func(t *testing.T) {
+			plan, err := PlanSync(context.Background(), srcDir, dstDir, tt.method)
+			if err != nil {
+				t.Fatalf("PlanSync: %v", err)
+			}
+			got := describeSyncPlan(plan, srcDir, dstDir)
+			if !sameNames(got, tt.want) {
+				t.Fatalf("plan =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestPlanSyncPairsNamesDifferingOnlyInCase(t *testing.T) {
+	base := time.Unix(1_700_000_000, 0)
+	srcDir := t.TempDir()
+	dstDir := t.TempDir()
+	writeFile(t, srcDir, "Report.TXT", "new", base.Add(time.Hour))
+	writeFile(t, dstDir, "report.txt", "old", base)
+
+	plan, err := PlanSync(context.Background(), srcDir, dstDir, SyncMirror)
+	if err != nil {
+		t.Fatalf("PlanSync: %v", err)
+	}
+	want := []string{"copy src/Report.TXT -> dst/report.txt"}
+	if got := describeSyncPlan(plan, srcDir, dstDir); !reflect.DeepEqual(got, want) {
+		t.Fatalf("plan = %v, want %v", got, want)
+	}
+}
+
+func TestPlanSyncFailsWhenADirectoryCannotBeListed(t *testing.T) {
+	srcDir := t.TempDir()
+	if _, err := PlanSync(context.Background(), srcDir, filepath.Join(srcDir, "missing"), SyncMirror); err == nil {
+		t.Fatal("PlanSync with a missing destination succeeded, want error")
+	}
+}
+
+// describeSyncPlan renders actions with src/ and dst/ prefixes, a trailing
+// slash on directories, and copied directory sizes in parentheses.
+func describeSyncPlan(plan []SyncAction, srcDir, dstDir string) []string {
+	rel := func(p string) string {
+		p = filepath.ToSlash(p)
+		p = strings.Replace(p, filepath.ToSlash(srcDir), "src", 1)
+		return strings.Replace(p, filepath.ToSlash(dstDir), "dst", 1)
+	}
+	lines := make([]string, 0, len(plan))
+	for _, a := range plan {
+		slash := ""
+		if a.Dir {
+			slash = "/"
+		}
+		switch a.Kind {
+		case SyncDelete:
+			lines = append(lines, "delete "+rel(a.To)+slash)
+		default:
+			line := string(a.Kind) + " " + rel(a.From) + slash + " -> " + rel(a.To)
+			if a.Kind == SyncCopy && a.Dir {
+				line += fmt.Sprintf(" (%d)", a.Size)
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,225 @@
+package fileinfo
+
+import (
+	"errors"
+	"image/color"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ColorTag is a Finder-style color label attached to a file or directory.
+type ColorTag uint8
+
+const (
+	ColorTagNone ColorTag = iota
+	ColorTagRed
+	ColorTagOrange
+	ColorTagYellow
+	ColorTagGreen
+	ColorTagBlue
+	ColorTagPurple
+	ColorTagGray
+)
+
+// MaxColorTag is the highest assignable tag; keyboard slots 1..7 map onto
+// ColorTagRed..MaxColorTag.
+const MaxColorTag = ColorTagGray
+
+// ColorTagSidecarName is the per-directory file holding color tags for
+// entries whose storage has no extended attributes (SMB, Windows, or
+// filesystems that reject user xattrs).
+const ColorTagSidecarName = ".nmf-tags"
+
+// colorTagXattr stores the tag name on filesystems with user xattrs.
+const colorTagXattr = "user.nmf.color-tag"
+
+// maxColorTagSidecarBytes bounds how much of a sidecar is read.
+const maxColorTagSidecarBytes = 1 << 20
+
+// errXattrUnsupported reports that the platform or filesystem cannot store
+// the tag as an extended attribute, so the sidecar must be used instead.
+var errXattrUnsupported = errors.New("extended attributes are unsupported")
+
+var colorTagNames = [...]string{"", "red", "orange", "yellow", "green", "blue", "purple", "gray"}
+
+// Finder's label palette.
+var colorTagColors = [...]color.RGBA{
+	{},
+	{R: 0xff, G: 0x45, B: 0x3a, A: 0xff},
+	{R: 0xff, G: 0x9f, B: 0x0a, A: 0xff},
+	{R: 0xff, G: 0xd6, B: 0x0a, A: 0xff},
+	{R: 0x32, G: 0xd7, B: 0x4b, A: 0xff},
+	{R: 0x0a, G: 0x84, B: 0xff, A: 0xff},
+	{R: 0xbf, G: 0x5a, B: 0xf2, A: 0xff},
+	{R: 0x98, G: 0x98, B: 0x9d, A: 0xff},
+}
+
+// String returns the tag's color name, or "" for ColorTagNone.
+func (t ColorTag) String() string {
+	if t > MaxColorTag {
+		return ""
+	}
+	return colorTagNames[t]
+}
+
+// RGBA returns the swatch color for t; ColorTagNone is fully transparent.
+func (t ColorTag) RGBA() color.RGBA {
+	if t > MaxColorTag {
+		return color.RGBA{}
+	}
+	return colorTagColors[t]
+}
+
+// ParseColorTag accepts a color name ("red") or slot number ("1") and
+// reports whether it names an assignable tag.
+func ParseColorTag(s string) (ColorTag, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if n, err := strconv.Atoi(s); err == nil {
+		if n >= int(ColorTagRed) && n <= int(MaxColorTag) {
+			return ColorTag(n), true
+		}
+		return ColorTagNone, false
+	}
+	if s == "grey" {
+		s = "gray"
+	}
+	for i := ColorTagRed; i <= MaxColorTag; i++ {
+		if colorTagNames[i] == s {
+			return i, true
+		}
+	}
+	return ColorTagNone, false
+}
+
+// LoadColorTags fills ColorTag for the entries of dir in place. Extended
+// attributes win over the directory sidecar when both exist.
+func LoadColorTags(dir string, files []FileInfo) error {
+	sidecar, err := readColorTagSidecar(dir)
+	local, isLocal := localNativeDir(dir)
+	for i := range files {
+		if files[i].Name == ".." {
+			continue
+		}
+		files[i].ColorTag = sidecar[files[i].Name]
+		if isLocal {
+			if tag, ok := getColorTagXattr(JoinPath(local, files[i].Name)); ok {
+				files[i].ColorTag = tag
+			}
+		}
+	}
+	return err
+}
+
+// SetColorTag attaches tag to p, or clears it for ColorTagNone. Local
+// paths use an extended attribute when the filesystem accepts one; other
+// paths fall back to the parent directory's sidecar.
+func SetColorTag(p string, tag ColorTag) error {
+	if tag > MaxColorTag {
+		return errors.New("invalid color tag")
+	}
+	dir := ParentPath(p)
+	name := BaseName(p)
+	if dir, isLocal := localNativeDir(dir); isLocal {
+		err := setColorTagXattr(JoinPath(dir, name), tag)
+		if err == nil {
+			// Drop any sidecar entry so an old tag cannot resurface when
+			// the file is later read without xattr support.
+			return updateColorTagSidecar(ParentPath(p), name, ColorTagNone)
+		}
+		if !errors.Is(err, errXattrUnsupported) {
+			return err
+		}
+	}
+	return updateColorTagSidecar(dir, name, tag)
+}
+
+// localNativeDir returns the OS path for dir when it is served by the local
+// filesystem (including mounted SMB shares).
+func localNativeDir(dir string) (string, bool) {
+	vfs, parsed, err := ResolveRead(dir)
+	if err != nil {
+		return "", false
+	}
+	defer CloseVFS(vfs)
+	if parsed.Scheme == SchemeArchive {
+		return "", false
+	}
+	if parsed.Provider != "local" && parsed.Scheme != SchemeFile {
+		return "", false
+	}
+	native := parsed.Native
+	if native == "" {
+		native = dir
+	}
+	return native, true
+}
+
+func readColorTagSidecar(dir string) (map[string]ColorTag, error) {
+	data, err := readSidecar(dir, ColorTagSidecarName, maxColorTagSidecarBytes)
+	if err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return parseColorTagSidecar(data), nil
+}
+
+// parseColorTagSidecar reads "color<TAB>name" lines. Unknown colors and
+// malformed lines are ignored so a hand-edited sidecar never breaks loading.
+func parseColorTagSidecar(data string) map[string]ColorTag {
+	tags := make(map[string]ColorTag)
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		colorName, name, ok := strings.Cut(line, "\t")
+		if !ok || name == "" {
+			continue
+		}
+		if tag, ok := ParseColorTag(colorName); ok {
+			tags[name] = tag
+		}
+	}
+	return tags
+}
+
+func formatColorTagSidecar(tags map[string]ColorTag) string {
+	names := make([]string, 0, len(tags))
+	for name, tag := range tags {
+		if tag != ColorTagNone {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(tags[name].String())
+		b.WriteByte('\t')
+		b.WriteString(name)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func updateColorTagSidecar(dir, name string, tag ColorTag) error {
+	if strings.ContainsAny(name, "\n\r") {
+		return errors.New("color tags cannot be stored for names containing line breaks")
+	}
+	tags, err := readColorTagSidecar(dir)
+	if err != nil {
+		return err
+	}
+	if tags == nil {
+		tags = make(map[string]ColorTag)
+	}
+	if tags[name] == tag {
+		return nil
+	}
+	if tag == ColorTagNone {
+		delete(tags, name)
+	} else {
+		tags[name] = tag
+	}
+	return writeSidecar(dir, ColorTagSidecarName, formatColorTagSidecar(tags))
+}
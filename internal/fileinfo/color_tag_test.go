@@ -0,0 +1,98 @@
+package fileinfo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseColorTag(t *testing.T) {
+	for input, want := range map[string]ColorTag{"red": ColorTagRed, " Blue ": ColorTagBlue, "grey": ColorTagGray, "7": ColorTagGray} {
+		if got, ok := ParseColorTag(input); !ok || got != want {
+			t.Fatalf("ParseColorTag(%q) = %v,%t want %v", input, got, ok, want)
+		}
+	}
+	for _, input := range []string{"", "0", "8", "teal"} {
+		if _, ok := ParseColorTag(input); ok {
+			t.Fatalf("ParseColorTag(%q) should be rejected", input)
+		}
+	}
+}
+
+func TestColorTagSidecarRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := updateColorTagSidecar(dir, "b.txt", ColorTagGreen); err != nil {
+		t.Fatalf("tag b: %v", err)
+	}
+	if err := updateColorTagSidecar(dir, "a b.txt", ColorTagRed); err != nil {
+		t.Fatalf("tag a: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, ColorTagSidecarName))
+	if err != nil {
+		t.Fatalf("read sidecar: %v", err)
+	}
+	if got, want := string(data), "red\ta b.txt\ngreen\tb.txt\n"; got != want {
+		t.Fatalf("sidecar = %q, want %q", got, want)
+	}
+
+	files := []FileInfo{{Name: ".."}, {Name: "a b.txt"}, {Name: "b.txt"}, {Name: "c.txt"}}
+	if err := LoadColorTags(dir, files); err != nil {
+		t.Fatalf("LoadColorTags: %v", err)
+	}
+	if files[1].ColorTag != ColorTagRed || files[2].ColorTag != ColorTagGreen || files[3].ColorTag != ColorTagNone {
+		t.Fatalf("loaded tags = %v/%v/%v", files[1].ColorTag, files[2].ColorTag, files[3].ColorTag)
+	}
+
+	for _, name := range []string{"a b.txt", "b.txt"} {
+		if err := updateColorTagSidecar(dir, name, ColorTagNone); err != nil {
+			t.Fatalf("clear %s: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, ColorTagSidecarName)); !os.IsNotExist(err) {
+		t.Fatalf("empty sidecar should be removed, stat err=%v", err)
+	}
+}
+
+func TestSetColorTagIsReadBackByLoadColorTags(t *testing.T) {
+	dir := t.TempDir()
+	p := filepath.Join(dir, "report.pdf")
+	if err := os.WriteFile(p, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetColorTag(p, ColorTagPurple); err != nil {
+		t.Fatalf("SetColorTag: %v", err)
+	}
+	files := []FileInfo{{Name: "report.pdf", Path: p}}
+	if err := LoadColorTags(dir, files); err != nil || files[0].ColorTag != ColorTagPurple {
+		t.Fatalf("tag = %v err=%v, want purple", files[0].ColorTag, err)
+	}
+
+	if err := SetColorTag(p, ColorTagNone); err != nil {
+		t.Fatalf("clear tag: %v", err)
+	}
+	if err := LoadColorTags(dir, files); err != nil || files[0].ColorTag != ColorTagNone {
+		t.Fatalf("tag after clear = %v err=%v, want none", files[0].ColorTag, err)
+	}
+}
+
+func TestFilterFilesByColorTag(t *testing.T) {
+	files := []FileInfo{
+		{Name: "dir", IsDir: true},
+		{Name: "red.txt", ColorTag: ColorTagRed},
+		{Name: "plain.txt"},
+	}
+
+	filtered, err := FilterFiles(files, "tag:red")
+	if err != nil {
+		t.Fatalf("FilterFiles: %v", err)
+	}
+	if len(filtered) != 2 || filtered[1].Name != "red.txt" {
+		t.Fatalf("filtered = %+v, want dir and red.txt", filtered)
+	}
+	if err := ValidatePattern("tag:teal"); err == nil || !strings.Contains(err.Error(), "teal") {
+		t.Fatalf("ValidatePattern(tag:teal) = %v, want unknown tag error", err)
+	}
+}
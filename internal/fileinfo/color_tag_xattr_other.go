@@ -0,0 +1,8 @@
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package fileinfo
+
+func getColorTagXattr(string) (ColorTag, bool) { return ColorTagNone, false }
+
+func setColorTagXattr(string, ColorTag) error { return errXattrUnsupported }
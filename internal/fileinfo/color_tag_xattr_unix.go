@@ -0,0 +1,35 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package fileinfo
+
+import (
+	"errors"
+
+	"golang.org/x/sys/unix"
+)
+
+func getColorTagXattr(p string) (ColorTag, bool) {
+	buf := make([]byte, 16)
+	n, err := unix.Lgetxattr(p, colorTagXattr, buf)
+	if err != nil || n <= 0 {
+		return ColorTagNone, false
+	}
+	return ParseColorTag(string(buf[:n]))
+}
+
+func setColorTagXattr(p string, tag ColorTag) error {
+	var err error
+	if tag == ColorTagNone {
+		if _, ok := getColorTagXattr(p); !ok {
+			return nil
+		}
+		err = unix.Lremovexattr(p, colorTagXattr)
+	} else {
+		err = unix.Lsetxattr(p, colorTagXattr, []byte(tag.String()), 0)
+	}
+	if errors.Is(err, unix.ENOTSUP) || errors.Is(err, unix.EOPNOTSUPP) || errors.Is(err, unix.EPERM) {
+		return errXattrUnsupported
+	}
+	return err
+}
@@ -0,0 +1,222 @@
+package fileinfo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// ConnectionState is the last known state of the connection to a remote
+// share.
+type ConnectionState int
+
+const (
+	// ConnectionUnknown means the share has not been dialed yet, or a
+	// reconnect was forced.
+	ConnectionUnknown ConnectionState = iota
+	ConnectionConnected
+	// ConnectionReconnecting means the last dial failed on the network; the
+	// next access, such as a watcher poll, dials again.
+	ConnectionReconnecting
+	// ConnectionAuthRequired means the server rejected the credentials.
+	ConnectionAuthRequired
+)
+
+func (s ConnectionState) String() string {
+	switch s {
+	case ConnectionConnected:
+		return "connected"
+	case ConnectionReconnecting:
+		return "reconnecting"
+	case ConnectionAuthRequired:
+		return "auth required"
+	default:
+		return "unknown"
+	}
+}
+
+// RemoteShare names a share whose connection state is tracked.
+type RemoteShare struct {
+	Host  string
+	Share string
+}
+
+func (r RemoteShare) key() string {
+	return strings.ToLower(r.Host) + "/" + strings.ToLower(r.Share)
+}
+
+// RemoteShareOf returns the SMB share holding path, without touching the
+// network.
+func RemoteShareOf(path string) (RemoteShare, bool) {
+	_, parsed, err := CanonicalDisplayPath(path)
+	if err != nil || parsed.Scheme != SchemeSMB || parsed.Host == "" || parsed.Share == "" {
+		return RemoteShare{}, false
+	}
+	return RemoteShare{Host: parsed.Host, Share: parsed.Share}, true
+}
+
+var connectionStates = struct {
+	mu         sync.Mutex
+	states     map[string]ConnectionState
+	skipStored map[string]bool            // shares whose next dial must prompt
+	waiters    map[string][]chan struct{} // operations awaiting re-authentication
+	subs       map[int64]func(RemoteShare)
+	nextSubID  int64
+}{
+	states:     make(map[string]ConnectionState),
+	skipStored: make(map[string]bool),
+	waiters:    make(map[string][]chan struct{}),
+	subs:       make(map[int64]func(RemoteShare)),
+}
+
+// ConnectionStateOf returns the last known connection state of share.
+func ConnectionStateOf(share RemoteShare) ConnectionState {
+	connectionStates.mu.Lock()
+	defer connectionStates.mu.Unlock()
+	return connectionStates.states[share.key()]
+}
+
+// SubscribeConnectionState calls cb, on the goroutine that dialed, whenever
+// a share's connection state changes. It returns an unsubscribe function.
+func SubscribeConnectionState(cb func(RemoteShare)) func() {
+	if cb == nil {
+		return func() {}
+	}
+	connectionStates.mu.Lock()
+	connectionStates.nextSubID++
+	id := connectionStates.nextSubID
+	connectionStates.subs[id] = cb
+	connectionStates.mu.Unlock()
+	return func() {
+		connectionStates.mu.Lock()
+		delete(connectionStates.subs, id)
+		connectionStates.mu.Unlock()
+	}
+}
+
+func setConnectionState(share RemoteShare, state ConnectionState) {
+	connectionStates.mu.Lock()
+	key := share.key()
+	if connectionStates.states[key] == state {
+		connectionStates.mu.Unlock()
+		return
+	}
+	if state == ConnectionUnknown {
+		delete(connectionStates.states, key)
+	} else {
+		connectionStates.states[key] = state
+	}
+	if state == ConnectionConnected || state == ConnectionUnknown {
+		for _, ch := range connectionStates.waiters[key] {
+			close(ch)
+		}
+		delete(connectionStates.waiters, key)
+	}
+	subs := make([]func(RemoteShare), 0, len(connectionStates.subs))
+	for _, cb := range connectionStates.subs {
+		subs = append(subs, cb)
+	}
+	connectionStates.mu.Unlock()
+	for _, cb := range subs {
+		cb(share)
+	}
+}
+
+// recordConnection updates share's state from the result of a dial or of an
+// operation on a dialed share. Cancellation says nothing about the server
+// and leaves the state alone.
+func recordConnection(share RemoteShare, err error) {
+	switch {
+	case err == nil:
+		setConnectionState(share, ConnectionConnected)
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+	case isAuthError(err):
+		setConnectionState(share, ConnectionAuthRequired)
+	default:
+		setConnectionState(share, ConnectionReconnecting)
+	}
+}
+
+// awaitReauth parks an operation whose established connection to share was
+// rejected, as when the session expired or the password changed, instead of
+// prompting in its way: the state turns to auth required, so the path bar
+// shows it, and the operation waits until the share connects again or
+// Reconnect runs, typically once the user chose to re-enter credentials. It
+// reports whether to retry; it returns false when ctx ends, and at once for
+// contexts that cannot end, since such a wait could outlive its caller.
+func awaitReauth(ctx context.Context, share RemoteShare) bool {
+	if ctx == nil || ctx.Done() == nil {
+		setConnectionState(share, ConnectionAuthRequired)
+		return false
+	}
+	ch := make(chan struct{})
+	key := share.key()
+	connectionStates.mu.Lock()
+	connectionStates.waiters[key] = append(connectionStates.waiters[key], ch)
+	connectionStates.mu.Unlock()
+	setConnectionState(share, ConnectionAuthRequired)
+	select {
+	case <-ch:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Reconnect forgets share's connection state so the next access dials
+// afresh, and wakes operations awaiting re-authentication. With
+// reenterCredentials it also drops the cached credentials and skips the
+// keyring once, so the credentials provider prompts again; the keyring entry
+// is only replaced if the new credentials ask to persist.
+func Reconnect(share RemoteShare, reenterCredentials bool) {
+	if reenterCredentials {
+		ClearCachedCredentials(share.Host, share.Share)
+		connectionStates.mu.Lock()
+		connectionStates.skipStored[share.key()] = true
+		connectionStates.mu.Unlock()
+	}
+	closePooledSMBShare(share)
+	forgetIntegratedSMBFailure(share)
+	setConnectionState(share, ConnectionUnknown)
+}
+
+// takeSkipStoredCredentials reports, once, whether a reconnect asked for
+// fresh credentials for the share.
+func takeSkipStoredCredentials(host, share string) bool {
+	key := RemoteShare{Host: host, Share: share}.key()
+	connectionStates.mu.Lock()
+	defer connectionStates.mu.Unlock()
+	skip := connectionStates.skipStored[key]
+	delete(connectionStates.skipStored, key)
+	return skip
+}
+
+// isSessionExpiredError reports whether the server dropped an established
+// session, which a new mount, with new credentials if need be, recovers.
+func isSessionExpiredError(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := strings.ToLower(err.Error())
+	return strings.Contains(e, "session has expired") ||
+		strings.Contains(e, "session has been deleted") ||
+		strings.Contains(e, "network_session_expired") ||
+		strings.Contains(e, "user_session_deleted")
+}
+
+func isAuthError(err error) bool {
+	if err == nil {
+		return false
+	}
+	e := strings.ToLower(err.Error())
+	// Common indicators from Windows/SMB servers
+	if strings.Contains(e, "logon is invalid") ||
+		strings.Contains(e, "bad username") ||
+		strings.Contains(e, "authentication") ||
+		strings.Contains(e, "status_logon_failure") ||
+		strings.Contains(e, "access is denied") {
+		return true
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package fileinfo
 
 import (
 	"context"
+	"errors"
 	"sync"
 
 	"nmf/internal/secret"
@@ -13,8 +14,15 @@ type Credentials struct {
 	Username string
 	Password string
 	Persist  bool
+	// Forget removes the share's keyring entry once these credentials
+	// work, as when the prompt's save box was cleared.
+	Forget bool
 }
 
+// ErrNoSecretStore is returned when no keyring is available to hold
+// credentials.
+var ErrNoSecretStore = errors.New("no keyring available")
+
 // CredentialsProvider can interactively or programmatically provide credentials.
 type CredentialsProvider interface {
 	Get(context.Context, string, string, string) (Credentials, error)
@@ -52,16 +60,90 @@ func currentSecretStore() secret.Store {
 	return secretStore
 }
 
+// SecretStoreAvailable reports whether credentials can be saved to a keyring.
+func SecretStoreAvailable() bool {
+	return currentSecretStore() != nil
+}
+
+// StoredCredentials lists the entries saved in the keyring, without their
+// passwords.
+func StoredCredentials() ([]secret.Entry, error) {
+	store := currentSecretStore()
+	if store == nil {
+		return nil, ErrNoSecretStore
+	}
+	return store.List()
+}
+
+// StoredCredentialsFor returns the keyring entry for host/share, if any.
+func StoredCredentialsFor(host, share string) (Credentials, bool) {
+	store := currentSecretStore()
+	if store == nil {
+		return Credentials{}, false
+	}
+	d, u, p, found, err := store.Get(host, share)
+	if err != nil || !found {
+		return Credentials{}, false
+	}
+	return Credentials{Domain: d, Username: u, Password: p}, true
+}
+
+// SaveStoredCredentials replaces the keyring entry for host/share. The
+// session cache is cleared so the next mount uses the new entry; shares
+// already mounted keep their session.
+func SaveStoredCredentials(host, share string, c Credentials) error {
+	store := currentSecretStore()
+	if store == nil {
+		return ErrNoSecretStore
+	}
+	if err := store.Set(host, share, c.Domain, c.Username, c.Password); err != nil {
+		return err
+	}
+	ClearCachedCredentials(host, share)
+	return nil
+}
+
+// ForgetStoredCredentials removes the keyring entry for host/share and the
+// credentials cached for this session, so the next mount prompts.
+func ForgetStoredCredentials(host, share string) error {
+	store := currentSecretStore()
+	if store == nil {
+		return ErrNoSecretStore
+	}
+	if err := store.Delete(host, share); err != nil {
+		return err
+	}
+	ClearCachedCredentials(host, share)
+	return nil
+}
+
+// persistCredentials saves or removes the keyring entry for host/share as c
+// asks, after c was accepted by the server.
+func persistCredentials(host, share string, c Credentials) {
+	store := currentSecretStore()
+	if store == nil {
+		return
+	}
+	switch {
+	case c.Persist:
+		_ = store.Set(host, share, c.Domain, c.Username, c.Password)
+	case c.Forget:
+		_ = store.Delete(host, share)
+	}
+}
+
 func getCredentials(ctx context.Context, host, share, rel string) (Credentials, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	// A reconnect asking to re-enter credentials goes straight to the provider.
+	fresh := takeSkipStoredCredentials(host, share)
 	// 1) Prefer in-memory cached credentials (e.g., seeded from URL)
-	if c, ok := GetCachedCredentials(host, share); ok {
+	if c, ok := GetCachedCredentials(host, share); ok && !fresh {
 		return c, nil
 	}
 	// 2) Then try keyring (if available)
-	if store := currentSecretStore(); store != nil {
+	if store := currentSecretStore(); store != nil && !fresh {
 		if d, u, p, found, _ := store.Get(host, share); found {
 			c := Credentials{Domain: d, Username: u, Password: p}
 			// Seed memory cache for this session
@@ -3,6 +3,8 @@ package fileinfo
 import (
 	"context"
 	"testing"
+
+	"nmf/internal/secret"
 )
 
 // stub secret store for tests
@@ -16,6 +18,7 @@ func (s stubSecret) Get(host, share string) (string, string, string, bool, error
 }
 func (s stubSecret) Set(host, share, d, u, p string) error { return nil }
 func (s stubSecret) Delete(host, share string) error       { return nil }
+func (s stubSecret) List() ([]secret.Entry, error)         { return nil, nil }
 
 // stub provider counting calls
 type countingProv struct {
@@ -0,0 +1,36 @@
+package fileinfo
+
+import "strings"
+
+// DirNoteFileName is the sidecar file that stores a directory's note. A
+// sidecar (rather than an xattr) travels with the directory over SMB shares
+// and through archive tools.
+const DirNoteFileName = ".nmf-notes"
+
+// maxDirNoteBytes caps how much of a sidecar is read; notes are meant to be
+// one short line.
+const maxDirNoteBytes = 4096
+
+// ReadDirNote returns the note attached to dir, or "" when it has none.
+func ReadDirNote(dir string) (string, error) {
+	data, err := readSidecar(dir, DirNoteFileName, maxDirNoteBytes)
+	if err != nil {
+		return "", err
+	}
+	return NormalizeDirNote(data), nil
+}
+
+// NormalizeDirNote folds a note onto one trimmed line.
+func NormalizeDirNote(note string) string {
+	return strings.Join(strings.Fields(note), " ")
+}
+
+// WriteDirNote stores note in dir's sidecar file. An empty note removes the
+// sidecar so unannotated directories stay clean.
+func WriteDirNote(dir, note string) error {
+	note = NormalizeDirNote(note)
+	if note == "" {
+		return writeSidecar(dir, DirNoteFileName, "")
+	}
+	return writeSidecar(dir, DirNoteFileName, note+"\n")
+}
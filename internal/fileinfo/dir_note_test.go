@@ -0,0 +1,31 @@
+package fileinfo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirNoteRoundTripAndClear(t *testing.T) {
+	dir := t.TempDir()
+
+	if note, err := ReadDirNote(dir); err != nil || note != "" {
+		t.Fatalf("unannotated note = %q err=%v, want empty", note, err)
+	}
+	if err := WriteDirNote(dir, "  release\nbuilds  "); err != nil {
+		t.Fatalf("WriteDirNote: %v", err)
+	}
+	if note, err := ReadDirNote(dir); err != nil || note != "release builds" {
+		t.Fatalf("note = %q err=%v, want folded single line", note, err)
+	}
+
+	if err := WriteDirNote(dir, " "); err != nil {
+		t.Fatalf("clear note: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, DirNoteFileName)); !os.IsNotExist(err) {
+		t.Fatalf("empty note should remove the sidecar, stat err=%v", err)
+	}
+	if err := WriteDirNote(dir, ""); err != nil {
+		t.Fatalf("clearing an absent note should succeed: %v", err)
+	}
+}
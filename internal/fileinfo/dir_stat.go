@@ -0,0 +1,92 @@
+package fileinfo
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+)
+
+// DefaultStatWorkers is the stat concurrency used when none is configured.
+const DefaultStatWorkers = 8
+
+// PendingFileInfo returns what the directory listing alone says about entry,
+// without a stat: name, path, and whether it is a directory or symlink. The
+// result has Pending set and zero Size, Modified, and Mode until it is
+// replaced by FileInfoFromDirEntry. Symlinks to directories count as files
+// until then.
+func PendingFileInfo(parent string, entry os.DirEntry) FileInfo {
+	name := entry.Name()
+	isLink := entry.Type()&os.ModeSymlink != 0
+	isDir := entry.IsDir() && !isLink
+	fileType := FileTypeRegular
+	switch {
+	case isLink:
+		fileType = FileTypeSymlink
+	case isDir:
+		fileType = FileTypeDirectory
+	case strings.HasPrefix(name, "."):
+		fileType = FileTypeHidden
+	}
+	return FileInfo{
+		Name:     name,
+		Path:     JoinPath(parent, name),
+		IsDir:    isDir,
+		FileType: fileType,
+		Status:   StatusNormal,
+		Pending:  true,
+	}
+}
+
+// StatDirEntries builds FileInfos for entries with up to workers stat calls
+// in flight, which hides most of the per-entry round trip on network
+// filesystems. Results keep the order of entries; ok[i] is false when entry i
+// could not be statted, typically because it vanished after the listing.
+// It returns ctx.Err() when ctx is canceled before all entries are done.
+func StatDirEntries(ctx context.Context, parent string, entries []os.DirEntry, workers int) ([]FileInfo, []bool, error) {
+	files := make([]FileInfo, len(entries))
+	ok := make([]bool, len(entries))
+	if workers < 1 {
+		workers = 1
+	}
+	workers = min(workers, len(entries))
+	if workers <= 1 {
+		for i, entry := range entries {
+			if err := ctx.Err(); err != nil {
+				return nil, nil, err
+			}
+			fi, err := FileInfoFromDirEntry(parent, entry)
+			files[i], ok[i] = fi, err == nil
+		}
+		return files, ok, nil
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				fi, err := FileInfoFromDirEntry(parent, entries[i])
+				files[i], ok[i] = fi, err == nil
+			}
+		}()
+	}
+	var err error
+feed:
+	for i := range entries {
+		select {
+		case next <- i:
+		case <-ctx.Done():
+			err = ctx.Err()
+			break feed
+		}
+	}
+	close(next)
+	wg.Wait()
+	if err != nil {
+		return nil, nil, err
+	}
+	return files, ok, nil
+}
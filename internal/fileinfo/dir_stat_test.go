@@ -0,0 +1,90 @@
+package fileinfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStatDirEntriesKeepsOrderAndSkipsVanishedEntries(t *testing.T) {
+	dir := t.TempDir()
+	for i := range 20 {
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("f%02d", i)), make([]byte, i), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dir, "f05")); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, workers := range []int{1, 4} {
+		files, ok, err := StatDirEntries(context.Background(), dir, entries, workers)
+		if err != nil {
+			t.Fatalf("workers=%d: %v", workers, err)
+		}
+		for i, entry := range entries {
+			if entry.Name() == "f05" {
+				if ok[i] {
+					t.Fatalf("workers=%d: vanished entry reported ok", workers)
+				}
+				continue
+			}
+			if !ok[i] || files[i].Name != entry.Name() || files[i].Size != int64(i) || files[i].Pending {
+				t.Fatalf("workers=%d: entry %d = %+v ok=%t", workers, i, files[i], ok[i])
+			}
+		}
+	}
+}
+
+func TestStatDirEntriesStopsOnCanceledContext(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := StatDirEntries(ctx, dir, entries, 4); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestPendingFileInfoUsesListingTypeOnly(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".hidden"), []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		fi := PendingFileInfo(dir, entry)
+		if !fi.Pending || fi.Size != 0 || !fi.Modified.IsZero() {
+			t.Fatalf("%s: pending info carries metadata: %+v", entry.Name(), fi)
+		}
+		switch entry.Name() {
+		case "sub":
+			if !fi.IsDir || fi.FileType != FileTypeDirectory {
+				t.Fatalf("sub = %+v, want directory", fi)
+			}
+		case ".hidden":
+			if fi.IsDir || fi.FileType != FileTypeHidden {
+				t.Fatalf(".hidden = %+v, want hidden file", fi)
+			}
+		}
+	}
+}
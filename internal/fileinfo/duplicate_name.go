@@ -0,0 +1,127 @@
+package fileinfo
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"nmf/internal/config"
+)
+
+// DuplicateNamePattern generates names for copies that would otherwise
+// overwrite an existing entry. {name} is the name without its extension,
+// {n} the copy number, and {ext} the extension including its dot, so
+// "{name}_copy{n}{ext}" names copies "file_copy1.txt". The zero value uses
+// config.DefaultDuplicateNamePattern.
+type DuplicateNamePattern struct {
+	pattern string
+}
+
+// ParseDuplicateNamePattern checks pattern as config.ValidateDuplicateNamePattern
+// does and that it yields valid file names. Without {ext} the extension is
+// appended after the pattern.
+func ParseDuplicateNamePattern(pattern string) (DuplicateNamePattern, error) {
+	if err := config.ValidateDuplicateNamePattern(pattern); err != nil {
+		return DuplicateNamePattern{}, fmt.Errorf("duplicate name %w", err)
+	}
+	if !strings.Contains(pattern, "{ext}") {
+		pattern += "{ext}"
+	}
+	if _, err := ValidateRenameName(strings.NewReplacer("{name}", "a", "{n}", "1", "{ext}", ".b").Replace(pattern)); err != nil {
+		return DuplicateNamePattern{}, fmt.Errorf("duplicate name pattern %q: %w", pattern, err)
+	}
+	return DuplicateNamePattern{pattern: pattern}, nil
+}
+
+// String returns the pattern, with {ext} appended if it was left out.
+func (p DuplicateNamePattern) String() string {
+	if p.pattern == "" {
+		return config.DefaultDuplicateNamePattern
+	}
+	return p.pattern
+}
+
+// Format returns copy n of the entry whose name splits into stem and ext.
+func (p DuplicateNamePattern) Format(stem string, n int, ext string) string {
+	return strings.NewReplacer("{name}", stem, "{n}", strconv.Itoa(n), "{ext}", ext).Replace(p.String())
+}
+
+// Next returns the first copy name for name that exists reports as free.
+// A name that is already a numbered copy continues its numbering, so a
+// second copy of "file (2).txt" becomes "file (3).txt" rather than
+// "file (2) (1).txt". Directories keep dots in their names as part of the
+// stem.
+func (p DuplicateNamePattern) Next(name string, isDir bool, exists func(string) (bool, error)) (string, error) {
+	stem, ext := name, ""
+	if !isDir {
+		stem, ext = SplitDuplicateName(name)
+	}
+	stem, n := p.stripNumber(stem, ext)
+	for ; ; n++ {
+		candidate := p.Format(stem, n, ext)
+		taken, err := exists(candidate)
+		if err != nil {
+			return candidate, err
+		}
+		if !taken {
+			return candidate, nil
+		}
+	}
+}
+
+// stripNumber undoes Format for names that are already copies and returns
+// the original stem with the next number to try.
+func (p DuplicateNamePattern) stripNumber(stem, ext string) (string, int) {
+	pattern := p.String()
+	var expr strings.Builder
+	expr.WriteString("^")
+	for pattern != "" {
+		start := strings.Index(pattern, "{")
+		end := -1
+		if start >= 0 {
+			end = strings.Index(pattern[start:], "}")
+		}
+		if end < 0 {
+			expr.WriteString(regexp.QuoteMeta(pattern))
+			break
+		}
+		end += start
+		expr.WriteString(regexp.QuoteMeta(pattern[:start]))
+		switch placeholder := pattern[start : end+1]; placeholder {
+		case "{name}":
+			expr.WriteString("(?P<name>.+)")
+		case "{n}":
+			expr.WriteString("(?P<n>[1-9][0-9]{0,8})")
+		case "{ext}":
+			expr.WriteString(regexp.QuoteMeta(ext))
+		default:
+			expr.WriteString(regexp.QuoteMeta(placeholder))
+		}
+		pattern = pattern[end+1:]
+	}
+	expr.WriteString("$")
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return stem, 1
+	}
+	match := re.FindStringSubmatch(stem + ext)
+	if match == nil {
+		return stem, 1
+	}
+	n, err := strconv.Atoi(match[re.SubexpIndex("n")])
+	if err != nil {
+		return stem, 1
+	}
+	return match[re.SubexpIndex("name")], n + 1
+}
+
+// SplitDuplicateName splits a file name before its last dot. Dotfiles such
+// as ".bashrc" have no extension.
+func SplitDuplicateName(name string) (string, string) {
+	dot := strings.LastIndex(name, ".")
+	if dot <= 0 {
+		return name, ""
+	}
+	return name[:dot], name[dot:]
+}
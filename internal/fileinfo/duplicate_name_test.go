@@ -0,0 +1,58 @@
+package fileinfo
+
+import "testing"
+
+func TestDuplicateNamePatternNext(t *testing.T) {
+	custom, err := ParseDuplicateNamePattern("{name}_copy{n}{ext}")
+	if err != nil {
+		t.Fatalf("ParseDuplicateNamePattern: %v", err)
+	}
+	noExt, err := ParseDuplicateNamePattern("Copy {n} of {name}")
+	if err != nil {
+		t.Fatalf("ParseDuplicateNamePattern without {ext}: %v", err)
+	}
+	tests := []struct {
+		name    string
+		pattern DuplicateNamePattern
+		file    string
+		isDir   bool
+		taken   []string
+		want    string
+	}{
+		{name: "default", file: "file.txt", want: "file (1).txt"},
+		{name: "skips taken copies", file: "file.txt", taken: []string{"file (1).txt", "file (2).txt"}, want: "file (3).txt"},
+		{name: "continues numbered copy", file: "file (2).txt", want: "file (3).txt"},
+		{name: "double extension keeps last", file: "a.tar.gz", want: "a.tar (1).gz"},
+		{name: "dotfile", file: ".bashrc", want: ".bashrc (1)"},
+		{name: "directory keeps dots", file: "v1.2", isDir: true, want: "v1.2 (1)"},
+		{name: "custom", pattern: custom, file: "file.txt", want: "file_copy1.txt"},
+		{name: "custom continues", pattern: custom, file: "file_copy4.txt", taken: []string{"file_copy5.txt"}, want: "file_copy6.txt"},
+		{name: "custom ignores other scheme", pattern: custom, file: "file (2).txt", want: "file (2)_copy1.txt"},
+		{name: "extension appended", pattern: noExt, file: "Copy 1 of notes.md", want: "Copy 2 of notes.md"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taken := map[string]bool{}
+			for _, name := range tt.taken {
+				taken[name] = true
+			}
+			got, err := tt.pattern.Next(tt.file, tt.isDir, func(name string) (bool, error) {
+				return taken[name], nil
+			})
+			if err != nil {
+				t.Fatalf("Next: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("Next(%q) = %q, want %q", tt.file, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDuplicateNamePatternRejectsBadPatterns(t *testing.T) {
+	for _, pattern := range []string{"{name}", "{n}{ext}", "{name}/{n}", "{name}{n}{ext}{ext}"} {
+		if _, err := ParseDuplicateNamePattern(pattern); err == nil {
+			t.Fatalf("ParseDuplicateNamePattern(%q) should fail", pattern)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package fileinfo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Fault operations. Providers and jobs name the operation they are about to
+// run when they ask InjectFault for a fault.
+const (
+	FaultOpReadDir = "readdir"
+	FaultOpStat    = "stat"
+	FaultOpOpen    = "open"
+	FaultOpWrite   = "write"
+	FaultOpMkdir   = "mkdir"
+	FaultOpRemove  = "remove"
+	FaultOpRename  = "rename"
+)
+
+var faultOps = []string{FaultOpReadDir, FaultOpStat, FaultOpOpen, FaultOpWrite, FaultOpMkdir, FaultOpRemove, FaultOpRename}
+
+// FaultSpec describes the faults injected into filesystem operations for
+// development. Each matching operation first sleeps for Latency, then fails
+// with EIO with probability EIORate, else with a permission error with
+// probability DeniedRate. Rates of 1 fail every matching operation, which
+// makes a run reproducible; lower rates draw from a generator seeded with
+// Seed, so the same sequence of operations fails the same way.
+type FaultSpec struct {
+	Latency    time.Duration
+	EIORate    float64
+	DeniedRate float64
+	Path       string          // only paths containing this substring; "" matches all
+	Ops        map[string]bool // only these operations; empty matches all
+	Seed       uint64
+}
+
+// ParseFaultSpec parses a comma-separated fault spec such as
+// "latency=300ms,eio=0.2,path=/tmp/slow,ops=readdir+stat,seed=7".
+// Keys are latency, eio, eacces, path, ops, and seed. The empty spec
+// injects nothing.
+func ParseFaultSpec(spec string) (FaultSpec, error) {
+	var out FaultSpec
+	for _, field := range strings.Split(spec, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return FaultSpec{}, fmt.Errorf("fault spec field %q must be key=value", field)
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		var err error
+		switch key {
+		case "latency":
+			out.Latency, err = time.ParseDuration(value)
+			if err == nil && out.Latency < 0 {
+				err = fmt.Errorf("must not be negative")
+			}
+		case "eio":
+			out.EIORate, err = parseFaultRate(value)
+		case "eacces":
+			out.DeniedRate, err = parseFaultRate(value)
+		case "path":
+			out.Path = value
+		case "ops":
+			out.Ops = make(map[string]bool)
+			for _, op := range strings.Split(value, "+") {
+				op = strings.ToLower(strings.TrimSpace(op))
+				if !isFaultOp(op) {
+					return FaultSpec{}, fmt.Errorf("fault spec: unknown op %q (want %s)", op, strings.Join(faultOps, ", "))
+				}
+				out.Ops[op] = true
+			}
+		case "seed":
+			out.Seed, err = strconv.ParseUint(value, 10, 64)
+		default:
+			return FaultSpec{}, fmt.Errorf("fault spec: unknown key %q", key)
+		}
+		if err != nil {
+			return FaultSpec{}, fmt.Errorf("fault spec %s: %w", key, err)
+		}
+	}
+	return out, nil
+}
+
+func parseFaultRate(value string) (float64, error) {
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	if rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("rate %v must be between 0 and 1", rate)
+	}
+	return rate, nil
+}
+
+func isFaultOp(op string) bool {
+	for _, known := range faultOps {
+		if op == known {
+			return true
+		}
+	}
+	return false
+}
+
+// IsZero reports whether s injects nothing.
+func (s FaultSpec) IsZero() bool {
+	return s.Latency == 0 && s.EIORate == 0 && s.DeniedRate == 0
+}
+
+type faultInjector struct {
+	spec FaultSpec
+	mu   sync.Mutex
+	rng  *rand.Rand
+}
+
+var faults struct {
+	mu       sync.RWMutex
+	injector *faultInjector
+}
+
+// SetFaultInjection installs spec for every later filesystem operation; the
+// zero spec turns injection off. It is meant for development runs only
+// (the -faults flag or debug.faultInjection).
+func SetFaultInjection(spec FaultSpec) {
+	faults.mu.Lock()
+	defer faults.mu.Unlock()
+	if spec.IsZero() {
+		faults.injector = nil
+		return
+	}
+	faults.injector = &faultInjector{spec: spec, rng: rand.New(rand.NewPCG(spec.Seed, spec.Seed))}
+}
+
+func currentFaultInjector() *faultInjector {
+	faults.mu.RLock()
+	defer faults.mu.RUnlock()
+	return faults.injector
+}
+
+// InjectFault applies the installed fault spec to op on path: it sleeps for
+// the configured latency and returns the injected error, if any. It returns
+// nil at once while injection is off.
+func InjectFault(op, path string) error {
+	inj := currentFaultInjector()
+	if inj == nil {
+		return nil
+	}
+	spec := inj.spec
+	if len(spec.Ops) > 0 && !spec.Ops[op] {
+		return nil
+	}
+	if spec.Path != "" && !strings.Contains(path, spec.Path) {
+		return nil
+	}
+	if spec.Latency > 0 {
+		time.Sleep(spec.Latency)
+	}
+	inj.mu.Lock()
+	roll := inj.rng.Float64()
+	inj.mu.Unlock()
+	switch {
+	case roll < spec.EIORate:
+		return &fs.PathError{Op: op, Path: path, Err: syscall.EIO}
+	case roll < spec.EIORate+spec.DeniedRate:
+		return &fs.PathError{Op: op, Path: path, Err: fs.ErrPermission}
+	}
+	return nil
+}
+
+// faultVFS wraps a provider while fault injection is on. Entries it lists
+// inject stat faults from Info, which is where directory loads stat them.
+type faultVFS struct {
+	VFS
+}
+
+func wrapFaultVFS(vfs VFS) VFS {
+	if vfs == nil || currentFaultInjector() == nil {
+		return vfs
+	}
+	return faultVFS{VFS: vfs}
+}
+
+func (f faultVFS) ReadDir(path string) ([]os.DirEntry, error) {
+	if err := InjectFault(FaultOpReadDir, path); err != nil {
+		return nil, err
+	}
+	entries, err := f.VFS.ReadDir(path)
+	return faultEntries(path, entries), err
+}
+
+func (f faultVFS) ReadDirContext(ctx context.Context, path string) ([]os.DirEntry, error) {
+	if err := InjectFault(FaultOpReadDir, path); err != nil {
+		return nil, err
+	}
+	entries, err := readDirWithContext(ctx, f.VFS, path)
+	return faultEntries(path, entries), err
+}
+
+func (f faultVFS) Stat(path string) (os.FileInfo, error) {
+	if err := InjectFault(FaultOpStat, path); err != nil {
+		return nil, err
+	}
+	return f.VFS.Stat(path)
+}
+
+func (f faultVFS) Open(path string) (io.ReadCloser, error) {
+	if err := InjectFault(FaultOpOpen, path); err != nil {
+		return nil, err
+	}
+	return f.VFS.Open(path)
+}
+
+// Close releases the wrapped provider, as CloseVFS would.
+func (f faultVFS) Close() error {
+	return CloseVFS(f.VFS)
+}
+
+type faultDirEntry struct {
+	os.DirEntry
+	path string
+}
+
+func faultEntries(dir string, entries []os.DirEntry) []os.DirEntry {
+	for i, entry := range entries {
+		entries[i] = faultDirEntry{DirEntry: entry, path: JoinPath(dir, entry.Name())}
+	}
+	return entries
+}
+
+func (e faultDirEntry) Info() (os.FileInfo, error) {
+	if err := InjectFault(FaultOpStat, e.path); err != nil {
+		return nil, err
+	}
+	return e.DirEntry.Info()
+}
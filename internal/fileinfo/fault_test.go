@@ -0,0 +1,97 @@
+package fileinfo
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestParseFaultSpec(t *testing.T) {
+	spec, err := ParseFaultSpec("latency=20ms, eio=0.25,eacces=0.5,path=/slow,ops=readdir+stat,seed=7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Latency != 20*time.Millisecond || spec.EIORate != 0.25 || spec.DeniedRate != 0.5 || spec.Path != "/slow" || spec.Seed != 7 {
+		t.Fatalf("spec = %+v", spec)
+	}
+	if !spec.Ops[FaultOpReadDir] || !spec.Ops[FaultOpStat] || spec.Ops[FaultOpOpen] {
+		t.Fatalf("ops = %v", spec.Ops)
+	}
+	if empty, err := ParseFaultSpec(""); err != nil || !empty.IsZero() {
+		t.Fatalf("empty spec = %+v, %v", empty, err)
+	}
+
+	for _, bad := range []string{"eio", "eio=2", "latency=-1s", "ops=chmod", "flaky=1"} {
+		if _, err := ParseFaultSpec(bad); err == nil {
+			t.Fatalf("%q should be rejected", bad)
+		}
+	}
+}
+
+func TestInjectFaultFailsMatchingOperations(t *testing.T) {
+	t.Cleanup(func() { SetFaultInjection(FaultSpec{}) })
+	SetFaultInjection(FaultSpec{EIORate: 1, Path: "broken", Ops: map[string]bool{FaultOpReadDir: true}})
+
+	if err := InjectFault(FaultOpReadDir, "/data/broken/dir"); !errors.Is(err, syscall.EIO) {
+		t.Fatalf("readdir fault = %v, want EIO", err)
+	}
+	if err := InjectFault(FaultOpStat, "/data/broken/dir"); err != nil {
+		t.Fatalf("unlisted op got fault %v", err)
+	}
+	if err := InjectFault(FaultOpReadDir, "/data/fine"); err != nil {
+		t.Fatalf("unmatched path got fault %v", err)
+	}
+
+	SetFaultInjection(FaultSpec{DeniedRate: 1})
+	if err := InjectFault(FaultOpWrite, "/x"); !errors.Is(err, fs.ErrPermission) {
+		t.Fatalf("write fault = %v, want permission error", err)
+	}
+	SetFaultInjection(FaultSpec{})
+	if err := InjectFault(FaultOpWrite, "/x"); err != nil {
+		t.Fatalf("injection off still failed: %v", err)
+	}
+}
+
+func TestInjectFaultIsReproducibleForASeed(t *testing.T) {
+	t.Cleanup(func() { SetFaultInjection(FaultSpec{}) })
+	run := func() []bool {
+		SetFaultInjection(FaultSpec{EIORate: 0.5, Seed: 42})
+		out := make([]bool, 32)
+		for i := range out {
+			out[i] = InjectFault(FaultOpStat, "/p") != nil
+		}
+		return out
+	}
+	first, second := run(), run()
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("run differs at %d: %v vs %v", i, first, second)
+		}
+	}
+}
+
+func TestPortableReadsInjectFaults(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { SetFaultInjection(FaultSpec{}) })
+
+	SetFaultInjection(FaultSpec{EIORate: 1, Ops: map[string]bool{FaultOpReadDir: true}})
+	if _, err := ReadDirPortable(dir); !errors.Is(err, syscall.EIO) {
+		t.Fatalf("ReadDirPortable err = %v, want EIO", err)
+	}
+
+	SetFaultInjection(FaultSpec{DeniedRate: 1, Ops: map[string]bool{FaultOpStat: true}})
+	entries, err := ReadDirPortable(dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("ReadDirPortable = %v, %v", entries, err)
+	}
+	if _, err := FileInfoFromDirEntry(dir, entries[0]); !errors.Is(err, fs.ErrPermission) {
+		t.Fatalf("stat of listed entry err = %v, want permission error", err)
+	}
+}
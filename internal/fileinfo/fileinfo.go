@@ -3,6 +3,9 @@ package fileinfo
 import (
 	"fmt"
 	"image/color"
+	"os"
+	"runtime"
+	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -10,6 +13,7 @@ import (
 	fynetheme "fyne.io/fyne/v2/theme"
 	"github.com/bmatcuk/doublestar/v4"
 
+	"nmf/internal/config"
 	customtheme "nmf/internal/theme"
 )
 
@@ -42,6 +46,13 @@ type FileInfo struct {
 	Modified time.Time
 	FileType FileType
 	Status   FileStatus // ファイルの現在のステータス
+	ColorTag ColorTag   // Finder-style label; see LoadColorTags
+	ReadOnly bool       // owner write permission is missing
+	Mode     os.FileMode
+	Owner    string // owning user name of local files on Unix; "" elsewhere
+	Pending  bool   // not statted yet; see PendingFileInfo
+	// LinkTarget is where a symbolic link points, as stored in the link.
+	LinkTarget string
 }
 
 // DetermineFileType determines the file type based on file attributes
@@ -163,7 +174,6 @@ func FilterFiles(files []FileInfo, pattern string) ([]FileInfo, error) {
 	if pattern == "" {
 		return files, nil
 	}
-
 	var filtered []FileInfo
 	for _, file := range files {
 		// Always include directories (including ".." parent directory)
@@ -173,7 +183,7 @@ func FilterFiles(files []FileInfo, pattern string) ([]FileInfo, error) {
 		}
 
 		// Apply pattern matching only to regular files
-		matched, err := doublestar.Match(pattern, file.Name)
+		matched, err := MatchesFile(file, pattern)
 		if err != nil {
 			// Invalid pattern - return error
 			return nil, fmt.Errorf("invalid filter pattern '%s': %w", pattern, err)
@@ -186,6 +196,146 @@ func FilterFiles(files []FileInfo, pattern string) ([]FileInfo, error) {
 	return filtered, nil
 }
 
+// FilterFilesWithPresets filters like FilterFiles, but resolves a
+// "preset:<name>" pattern against presets and applies its criteria.
+func FilterFilesWithPresets(files []FileInfo, pattern string, presets []config.FilterPreset) ([]FileInfo, error) {
+	name, ok := config.FilterPresetName(pattern)
+	if !ok {
+		return FilterFiles(files, pattern)
+	}
+	preset, found := config.FindFilterPreset(presets, name)
+	if !found {
+		return nil, fmt.Errorf("unknown filter preset %q", name)
+	}
+	criteria, err := preset.Criteria(time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("filter preset %q: %w", name, err)
+	}
+	var filtered []FileInfo
+	for _, file := range files {
+		if file.Name == ".." {
+			filtered = append(filtered, file)
+			continue
+		}
+		matched, err := MatchesCriteria(file, criteria)
+		if err != nil {
+			return nil, fmt.Errorf("filter preset %q: %w", name, err)
+		}
+		if matched {
+			filtered = append(filtered, file)
+		}
+	}
+	return filtered, nil
+}
+
+// MatchesCriteria checks an entry against preset criteria. The type list
+// applies to every entry; name, size, and date apply to files only, so
+// directories stay navigable as with glob filters.
+func MatchesCriteria(file FileInfo, c config.FilterCriteria) (bool, error) {
+	if len(c.Types) > 0 && !matchesFilterType(file, c.Types) {
+		return false, nil
+	}
+	if file.IsDir {
+		return true, nil
+	}
+	if len(c.Include) > 0 {
+		included := false
+		for _, pattern := range c.Include {
+			matched, err := MatchesFile(file, pattern)
+			if err != nil {
+				return false, err
+			}
+			if matched {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return false, nil
+		}
+	}
+	for _, pattern := range c.Exclude {
+		matched, err := MatchesFile(file, pattern)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			return false, nil
+		}
+	}
+	if c.MinSize >= 0 && file.Size < c.MinSize {
+		return false, nil
+	}
+	if c.MaxSize >= 0 && file.Size > c.MaxSize {
+		return false, nil
+	}
+	if !c.ModifiedSince.IsZero() && file.Modified.Before(c.ModifiedSince) {
+		return false, nil
+	}
+	return true, nil
+}
+
+// IsHidden reports whether an entry is a dotfile or carries the Windows
+// hidden attribute. Directories are typed FileTypeDirectory, so their
+// attribute is checked here; ".." is never hidden.
+func IsHidden(file FileInfo) bool {
+	if file.Name == ".." {
+		return false
+	}
+	if file.FileType == FileTypeHidden || strings.HasPrefix(file.Name, ".") {
+		return true
+	}
+	return file.IsDir && runtime.GOOS == "windows" && IsWindowsHidden(file.Path)
+}
+
+func matchesFilterType(file FileInfo, types []string) bool {
+	for _, t := range types {
+		switch t {
+		case config.FilterTypeFile:
+			if !file.IsDir {
+				return true
+			}
+		case config.FilterTypeDir:
+			if file.IsDir {
+				return true
+			}
+		case config.FilterTypeHidden:
+			if IsHidden(file) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// colorTagFilterPrefix selects files by color tag ("tag:red") instead of by
+// name glob.
+const colorTagFilterPrefix = "tag:"
+
+// MatchesFile checks a file against a filter pattern: either a doublestar
+// glob on the name or "tag:<color>" on its color tag.
+func MatchesFile(file FileInfo, pattern string) (bool, error) {
+	if tag, ok, err := parseColorTagFilter(pattern); ok {
+		if err != nil {
+			return false, err
+		}
+		return file.ColorTag == tag, nil
+	}
+	return MatchesPattern(file.Name, pattern)
+}
+
+func parseColorTagFilter(pattern string) (ColorTag, bool, error) {
+	rest, ok := strings.CutPrefix(strings.ToLower(strings.TrimSpace(pattern)), colorTagFilterPrefix)
+	if !ok {
+		return ColorTagNone, false, nil
+	}
+	tag, valid := ParseColorTag(rest)
+	if !valid {
+		return ColorTagNone, true, fmt.Errorf("unknown color tag %q", rest)
+	}
+	return tag, true, nil
+}
+
 // MatchesPattern checks if a single filename matches a doublestar glob pattern
 func MatchesPattern(filename, pattern string) (bool, error) {
 	if pattern == "" {
@@ -199,6 +349,9 @@ func ValidatePattern(pattern string) error {
 	if pattern == "" {
 		return nil
 	}
+	if _, ok, err := parseColorTagFilter(pattern); ok {
+		return err
+	}
 	// Test the pattern with a dummy filename to check for syntax errors
 	_, err := doublestar.Match(pattern, "test")
 	return err
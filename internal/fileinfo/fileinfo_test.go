@@ -4,8 +4,11 @@ import (
 	"image/color"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
+
+	"nmf/internal/config"
 )
 
 func TestDetermineFileType(t *testing.T) {
@@ -78,6 +81,29 @@ func TestFileInfoFromDirEntryDirectorySymlink(t *testing.T) {
 	if !got.IsDir {
 		t.Fatal("directory symlink should be navigable")
 	}
+	if got.LinkTarget != target {
+		t.Fatalf("LinkTarget = %q, want %q", got.LinkTarget, target)
+	}
+}
+
+func TestPhysicalPathResolvesDirectorySymlinks(t *testing.T) {
+	tmp, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := filepath.Join(tmp, "target")
+	if err := os.MkdirAll(filepath.Join(target, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(tmp, "link")
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlink unavailable: %v", err)
+	}
+
+	got, err := PhysicalPath(filepath.Join(link, "sub"))
+	if err != nil || got != filepath.Join(target, "sub") {
+		t.Fatalf("PhysicalPath = %q, %v; want %q", got, err, filepath.Join(target, "sub"))
+	}
 }
 
 func TestFileInfoFromDirEntryBrokenSymlinkIsNotNavigable(t *testing.T) {
@@ -98,6 +124,9 @@ func TestFileInfoFromDirEntryBrokenSymlinkIsNotNavigable(t *testing.T) {
 	if got.IsDir {
 		t.Fatal("broken symlink should not be navigable")
 	}
+	if got.LinkTarget != "missing-target" {
+		t.Fatalf("LinkTarget = %q, want the stored relative target", got.LinkTarget)
+	}
 }
 
 func readDirEntry(t *testing.T, dir string, name string) os.DirEntry {
@@ -139,6 +168,52 @@ func (m *mockThemeColorProvider) GetCustomColor(colorType string) color.RGBA {
 	}
 }
 
+func TestFilterFilesWithPresetsAppliesCriteria(t *testing.T) {
+	now := time.Now()
+	files := []FileInfo{
+		{Name: "..", IsDir: true},
+		{Name: "src", IsDir: true},
+		{Name: ".cache", IsDir: true, FileType: FileTypeDirectory},
+		{Name: "big.iso", Size: 2 << 30, Modified: now},
+		{Name: "old.iso", Size: 2 << 30, Modified: now.Add(-30 * 24 * time.Hour)},
+		{Name: "tmp.iso", Size: 2 << 30, Modified: now},
+		{Name: "small.iso", Size: 10, Modified: now},
+		{Name: "notes.txt", Size: 2 << 30, Modified: now},
+	}
+	presets := []config.FilterPreset{
+		{Name: "images", Include: []string{"*.iso"}, Exclude: []string{"tmp*"}, MinSize: "1G", ModifiedSince: "7d"},
+		{Name: "hidden", Types: []string{"hidden"}},
+	}
+	names := func(files []FileInfo) []string {
+		out := make([]string, len(files))
+		for i, f := range files {
+			out[i] = f.Name
+		}
+		return out
+	}
+
+	got, err := FilterFilesWithPresets(files, "preset:images", presets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"..", "src", ".cache", "big.iso"}; !reflect.DeepEqual(names(got), want) {
+		t.Fatalf("preset:images = %v, want %v", names(got), want)
+	}
+	got, err = FilterFilesWithPresets(files, "preset:hidden", presets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"..", ".cache"}; !reflect.DeepEqual(names(got), want) {
+		t.Fatalf("preset:hidden = %v, want %v", names(got), want)
+	}
+	if _, err := FilterFilesWithPresets(files, "preset:missing", presets); err == nil {
+		t.Fatal("an unknown preset should be an error")
+	}
+	if got, err := FilterFilesWithPresets(files, "*.txt", presets); err != nil || len(got) != 4 {
+		t.Fatalf("plain globs should keep FilterFiles behavior, got %v, %v", names(got), err)
+	}
+}
+
 func TestGetTextColor(t *testing.T) {
 	themeProvider := &mockThemeColorProvider{}
 
@@ -290,3 +365,21 @@ func TestFileInfo(t *testing.T) {
 		t.Errorf("Expected Status Normal, got %v", fileInfo.Status)
 	}
 }
+
+func TestIsHiddenCoversDotDirectoriesButNotParent(t *testing.T) {
+	cases := []struct {
+		file FileInfo
+		want bool
+	}{
+		{FileInfo{Name: ".."}, false},
+		{FileInfo{Name: ".git", IsDir: true, FileType: FileTypeDirectory}, true},
+		{FileInfo{Name: ".bashrc", FileType: FileTypeHidden}, true},
+		{FileInfo{Name: "desktop.ini", FileType: FileTypeHidden}, true},
+		{FileInfo{Name: "main.go", FileType: FileTypeRegular}, false},
+	}
+	for _, tc := range cases {
+		if got := IsHidden(tc.file); got != tc.want {
+			t.Fatalf("IsHidden(%q) = %v, want %v", tc.file.Name, got, tc.want)
+		}
+	}
+}
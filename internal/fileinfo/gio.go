@@ -0,0 +1,362 @@
+package fileinfo
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// SchemeGio marks GVFS locations (mtp://, google-drive://, sftp://, network:///
+// and similar) that are reached through the gio command line tool on Linux.
+const SchemeGio Scheme = "gio"
+
+var (
+	// ErrGioDisabled is returned for GVFS locations while ui.gio.enabled is off.
+	ErrGioDisabled = errors.New("gio integration is disabled")
+	// ErrGioUnavailable is returned for GVFS locations when gio cannot be used
+	// on this system.
+	ErrGioUnavailable = errors.New("gio is not available")
+)
+
+// gioSchemes lists URI schemes served by GVFS backends. smb:// is deliberately
+// absent: it keeps its own CIFS-mount/direct-SMB resolution.
+var gioSchemes = map[string]bool{
+	"admin":        true,
+	"afc":          true,
+	"afp":          true,
+	"dav":          true,
+	"davs":         true,
+	"ftp":          true,
+	"ftps":         true,
+	"google-drive": true,
+	"gphoto2":      true,
+	"mtp":          true,
+	"network":      true,
+	"nfs":          true,
+	"onedrive":     true,
+	"recent":       true,
+	"sftp":         true,
+	"trash":        true,
+}
+
+var gioEnabled atomic.Bool
+
+var smbKerberosEnabled atomic.Bool
+
+func init() {
+	gioEnabled.Store(true)
+	smbKerberosEnabled.Store(true)
+}
+
+// SetGioEnabled turns delegation of GVFS locations and trash to gio on or off.
+func SetGioEnabled(enabled bool) {
+	gioEnabled.Store(enabled)
+}
+
+// GioEnabled reports whether GVFS locations and trash may be delegated to gio.
+func GioEnabled() bool {
+	return gioEnabled.Load()
+}
+
+// SetSMBKerberosEnabled turns mounting SMB shares through GVFS with the
+// user's Kerberos ticket on or off.
+func SetSMBKerberosEnabled(enabled bool) {
+	smbKerberosEnabled.Store(enabled)
+}
+
+// SMBKerberosEnabled reports whether SMB shares may be mounted through GVFS
+// with the user's Kerberos ticket before the direct provider asks for a
+// password.
+func SMBKerberosEnabled() bool {
+	return smbKerberosEnabled.Load()
+}
+
+// gioLocation is a parsed GVFS URI. Segments are unescaped path components.
+type gioLocation struct {
+	scheme    string
+	authority string
+	segments  []string
+}
+
+// IsGioURI reports whether p is a URI for one of the GVFS-backed schemes.
+func IsGioURI(p string) bool {
+	_, ok := parseGioURI(p)
+	return ok
+}
+
+func parseGioURI(p string) (gioLocation, bool) {
+	s := strings.TrimSpace(p)
+	sep := strings.Index(s, "://")
+	if sep <= 0 {
+		return gioLocation{}, false
+	}
+	scheme := strings.ToLower(s[:sep])
+	if !gioSchemes[scheme] {
+		return gioLocation{}, false
+	}
+	rest := s[sep+len("://"):]
+	authority := rest
+	var pathPart string
+	if slash := strings.Index(rest, "/"); slash >= 0 {
+		authority = rest[:slash]
+		pathPart = rest[slash+1:]
+	}
+	loc := gioLocation{scheme: scheme, authority: authority}
+	for _, segment := range strings.Split(pathPart, "/") {
+		if segment == "" || segment == "." {
+			continue
+		}
+		if unescaped, err := url.PathUnescape(segment); err == nil && !strings.ContainsAny(unescaped, "/\x00") {
+			segment = unescaped
+		}
+		if segment == ".." {
+			if len(loc.segments) > 0 {
+				loc.segments = loc.segments[:len(loc.segments)-1]
+			}
+			continue
+		}
+		loc.segments = append(loc.segments, segment)
+	}
+	return loc, true
+}
+
+func (l gioLocation) root() gioLocation {
+	return gioLocation{scheme: l.scheme, authority: l.authority}
+}
+
+// display returns the canonical display form: unescaped segments, and a
+// trailing slash only at the location root.
+func (l gioLocation) display() string {
+	return l.scheme + "://" + l.authority + "/" + strings.Join(l.segments, "/")
+}
+
+// uri returns the escaped URI passed to gio.
+func (l gioLocation) uri() string {
+	escaped := make([]string, len(l.segments))
+	for i, segment := range l.segments {
+		escaped[i] = url.PathEscape(segment)
+	}
+	return l.scheme + "://" + l.authority + "/" + strings.Join(escaped, "/")
+}
+
+func (l gioLocation) parsed(input string) Parsed {
+	return Parsed{
+		Scheme:   SchemeGio,
+		Host:     l.authority,
+		Segments: l.segments,
+		Raw:      input,
+		Display:  l.display(),
+		Native:   l.uri(),
+		Provider: "gio",
+	}
+}
+
+func canonicalGioDisplayPath(input, trimmed string) (string, Parsed, bool) {
+	loc, ok := parseGioURI(trimmed)
+	if !ok {
+		return "", Parsed{}, false
+	}
+	parsed := loc.parsed(input)
+	return parsed.Display, parsed, true
+}
+
+func gioJoinPath(base, name string) string {
+	loc, _ := parseGioURI(base)
+	loc.segments = append(append([]string(nil), loc.segments...), name)
+	return loc.display()
+}
+
+func gioParentPath(p string) string {
+	loc, _ := parseGioURI(p)
+	if len(loc.segments) > 0 {
+		loc.segments = loc.segments[:len(loc.segments)-1]
+	}
+	return loc.display()
+}
+
+func gioBaseName(p string) string {
+	loc, _ := parseGioURI(p)
+	if len(loc.segments) > 0 {
+		return loc.segments[len(loc.segments)-1]
+	}
+	if loc.authority != "" {
+		return loc.authority
+	}
+	return loc.scheme + "://"
+}
+
+// gioPathClass reports device-style backends as removable devices and the
+// rest as network-backed.
+func gioPathClass(scheme string) PathClass {
+	switch scheme {
+	case "mtp", "gphoto2", "afc":
+		return PathClass{Removable: true, Device: true}
+	case "trash", "recent", "admin":
+		return PathClass{}
+	default:
+		return PathClass{Network: true}
+	}
+}
+
+// gioFileInfo implements os.FileInfo for entries reported by gio.
+type gioFileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi gioFileInfo) Name() string       { return fi.name }
+func (fi gioFileInfo) Size() int64        { return fi.size }
+func (fi gioFileInfo) Mode() os.FileMode  { return fi.mode }
+func (fi gioFileInfo) ModTime() time.Time { return fi.modTime }
+func (fi gioFileInfo) IsDir() bool        { return fi.mode.IsDir() }
+func (fi gioFileInfo) Sys() interface{}   { return nil }
+
+type gioDirEntry struct{ fi gioFileInfo }
+
+func (e gioDirEntry) Name() string               { return e.fi.name }
+func (e gioDirEntry) IsDir() bool                { return e.fi.IsDir() }
+func (e gioDirEntry) Type() os.FileMode          { return e.fi.mode.Type() }
+func (e gioDirEntry) Info() (os.FileInfo, error) { return e.fi, nil }
+
+// gioFileMode maps a gio file type name (as printed by gio list/info) and an
+// optional unix::mode value to an os.FileMode. Mountables and shortcuts, such
+// as network:/// entries, are browsed like directories.
+func gioFileMode(fileType string, unixMode int64, hasUnixMode bool) os.FileMode {
+	var mode os.FileMode
+	perm := os.FileMode(0o644)
+	switch fileType {
+	case "directory", "mountable", "shortcut":
+		mode = os.ModeDir
+		perm = 0o755
+	case "symbolic-link":
+		mode = os.ModeSymlink
+		perm = 0o777
+	case "special":
+		mode = os.ModeIrregular
+	}
+	if hasUnixMode {
+		perm = os.FileMode(unixMode) & os.ModePerm
+	}
+	return mode | perm
+}
+
+// gioTypeName maps the numeric standard::type attribute to its name.
+func gioTypeName(n string) string {
+	switch n {
+	case "1":
+		return "regular"
+	case "2":
+		return "directory"
+	case "3":
+		return "symbolic-link"
+	case "4":
+		return "special"
+	case "5":
+		return "shortcut"
+	case "6":
+		return "mountable"
+	default:
+		return "unknown"
+	}
+}
+
+// parseGioListLine parses one line of `gio list -l -u -a ...` output:
+// URI, size, (type), then space-separated name=value attributes.
+func parseGioListLine(line string) (gioFileInfo, bool) {
+	fields := strings.Split(line, "\t")
+	if len(fields) < 3 {
+		return gioFileInfo{}, false
+	}
+	loc, ok := parseGioURI(fields[0])
+	if !ok || len(loc.segments) == 0 {
+		return gioFileInfo{}, false
+	}
+	fi := gioFileInfo{name: loc.segments[len(loc.segments)-1]}
+	fi.size, _ = strconv.ParseInt(strings.TrimSpace(fields[1]), 10, 64)
+	fileType := strings.Trim(strings.TrimSpace(fields[2]), "()")
+	attrs := map[string]string{}
+	if len(fields) > 3 {
+		for _, attr := range strings.Fields(strings.Join(fields[3:], " ")) {
+			if key, value, ok := strings.Cut(attr, "="); ok {
+				attrs[key] = value
+			}
+		}
+	}
+	fi.mode, fi.modTime = gioModeAndTime(fileType, attrs)
+	return fi, true
+}
+
+// parseGioInfo parses `gio info -a ...` output for the location named name.
+// It also returns the "local path:" line, which is present when gvfsd-fuse
+// exposes the location on the local filesystem.
+func parseGioInfo(name, output string) (gioFileInfo, string) {
+	fi := gioFileInfo{name: name}
+	attrs := map[string]string{}
+	fileType := ""
+	localPath := ""
+	inAttributes := false
+	for _, line := range strings.Split(output, "\n") {
+		if inAttributes && strings.HasPrefix(line, " ") {
+			if key, value, ok := strings.Cut(strings.TrimSpace(line), ": "); ok {
+				attrs[key] = strings.TrimSpace(value)
+			}
+			continue
+		}
+		inAttributes = false
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "type":
+			fileType = value
+		case "local path":
+			localPath = value
+		case "attributes":
+			inAttributes = true
+		}
+	}
+	if fileType == "" {
+		fileType = gioTypeName(attrs["standard::type"])
+	}
+	fi.size, _ = strconv.ParseInt(attrs["standard::size"], 10, 64)
+	fi.mode, fi.modTime = gioModeAndTime(fileType, attrs)
+	return fi, localPath
+}
+
+func gioModeAndTime(fileType string, attrs map[string]string) (os.FileMode, time.Time) {
+	unixMode, err := strconv.ParseInt(attrs["unix::mode"], 10, 64)
+	mode := gioFileMode(fileType, unixMode, err == nil)
+	var modTime time.Time
+	if secs, err := strconv.ParseInt(attrs["time::modified"], 10, 64); err == nil {
+		modTime = time.Unix(secs, 0)
+	}
+	return mode, modTime
+}
+
+// gioNativeLocalPath maps loc below a gvfsd-fuse mount root.
+func gioNativeLocalPath(fuseRoot string, loc gioLocation) string {
+	if len(loc.segments) == 0 {
+		return fuseRoot
+	}
+	return fuseRoot + "/" + path.Join(loc.segments...)
+}
+
+// IsGioProviderPath reports whether parsed is served by GioFS rather than a
+// gvfsd-fuse local path. Such paths are read-only.
+func IsGioProviderPath(parsed Parsed) bool {
+	return parsed.Scheme == SchemeGio && parsed.Provider != "local"
+}
+
+func errGioReadOnly(display string) error {
+	return fmt.Errorf("gio locations without a gvfs FUSE mount are read-only: %s", display)
+}
@@ -0,0 +1,239 @@
+//go:build linux
+// +build linux
+
+package fileinfo
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	gioInfoTimeout       = 15 * time.Second
+	gioFuseNegativeCache = 10 * time.Second
+	gioListAttributes    = "standard::is-hidden,time::modified,unix::mode"
+	gioInfoAttributes    = "standard::type,standard::size,time::modified,unix::mode"
+)
+
+// runGio runs the gio command and returns its stdout. Tests replace it.
+var runGio = func(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "gio", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, gioCommandError(args, err, stderr.String())
+	}
+	return out, nil
+}
+
+// gioAvailable reports whether the gio command is installed. Tests replace it.
+var gioAvailable = sync.OnceValue(func() error {
+	if _, err := exec.LookPath("gio"); err != nil {
+		return fmt.Errorf("%w: %v", ErrGioUnavailable, err)
+	}
+	return nil
+})
+
+func gioCommandError(args []string, err error, stderr string) error {
+	msg := strings.TrimSpace(stderr)
+	op := "gio"
+	if len(args) > 0 {
+		op += " " + args[0]
+	}
+	if strings.Contains(msg, "No such file or directory") {
+		return fmt.Errorf("%s: %s: %w", op, msg, os.ErrNotExist)
+	}
+	if strings.Contains(msg, "Permission denied") {
+		return fmt.Errorf("%s: %s: %w", op, msg, os.ErrPermission)
+	}
+	if msg == "" {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return fmt.Errorf("%s: %w: %s", op, err, msg)
+}
+
+func isGioNotMounted(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "not mounted")
+}
+
+// gioFuseRoots caches the gvfsd-fuse directory of each mounted location
+// root (scheme://authority/). Misses expire so a later mount is noticed.
+var gioFuseRoots = struct {
+	sync.Mutex
+	found  map[string]string
+	missed map[string]time.Time
+}{found: map[string]string{}, missed: map[string]time.Time{}}
+
+// gioFuseRoot returns the local gvfsd-fuse directory exposing loc's root, if
+// any, so mounted GVFS locations can be used like any other local path.
+func gioFuseRoot(ctx context.Context, loc gioLocation) (string, bool) {
+	return gioFuseRootURI(ctx, loc.root().uri())
+}
+
+// gioFuseRootURI is gioFuseRoot for the mount root URI key.
+func gioFuseRootURI(ctx context.Context, key string) (string, bool) {
+	gioFuseRoots.Lock()
+	if root, ok := gioFuseRoots.found[key]; ok {
+		gioFuseRoots.Unlock()
+		if _, err := os.Stat(root); err == nil {
+			return root, true
+		}
+		gioFuseRoots.Lock()
+		delete(gioFuseRoots.found, key)
+	} else if missed, ok := gioFuseRoots.missed[key]; ok && time.Since(missed) < gioFuseNegativeCache {
+		gioFuseRoots.Unlock()
+		return "", false
+	}
+	gioFuseRoots.Unlock()
+
+	infoCtx, cancel := context.WithTimeout(ctx, gioInfoTimeout)
+	defer cancel()
+	out, err := runGio(infoCtx, "info", "-a", "standard::type", "--", key)
+	localPath := ""
+	if err == nil {
+		_, localPath = parseGioInfo("", string(out))
+	}
+
+	gioFuseRoots.Lock()
+	defer gioFuseRoots.Unlock()
+	if localPath == "" {
+		gioFuseRoots.missed[key] = time.Now()
+		return "", false
+	}
+	delete(gioFuseRoots.missed, key)
+	gioFuseRoots.found[key] = localPath
+	return localPath, true
+}
+
+// gioVolumes lists GVFS mounts and mountable devices known to the volume
+// monitor, so an MTP phone shows up as soon as it is plugged in.
+func gioVolumes(ctx context.Context) []Volume {
+	if !GioEnabled() || gioAvailable() != nil {
+		return nil
+	}
+	listCtx, cancel := context.WithTimeout(ctx, gioInfoTimeout)
+	defer cancel()
+	out, err := runGio(listCtx, "mount", "-l", "-i")
+	if err != nil {
+		return nil
+	}
+	return parseGioMountList(string(out))
+}
+
+func resolveGio(ctx context.Context, input string, loc gioLocation) (VFS, Parsed, error) {
+	parsed := loc.parsed(input)
+	if !GioEnabled() {
+		return nil, parsed, fmt.Errorf("%w: %s (ui.gio.enabled)", ErrGioDisabled, parsed.Display)
+	}
+	if err := gioAvailable(); err != nil {
+		return nil, parsed, err
+	}
+	if root, ok := gioFuseRoot(ctx, loc); ok {
+		parsed.Native = gioNativeLocalPath(root, loc)
+		parsed.Provider = "local"
+		return LocalFS{}, parsed, nil
+	}
+	return GioFS{root: loc.root().uri()}, parsed, nil
+}
+
+// GioFS implements VFS for GVFS locations that have no gvfsd-fuse path by
+// running the gio command. Native paths are escaped URIs. It is read-only and
+// cannot be watched; listings refresh on demand.
+type GioFS struct {
+	root string
+}
+
+func (GioFS) Capabilities() Capabilities { return Capabilities{FastList: false, Watch: false} }
+
+func (GioFS) Join(elem ...string) string {
+	if len(elem) == 0 {
+		return ""
+	}
+	loc, ok := parseGioURI(elem[0])
+	if !ok {
+		return strings.Join(elem, "/")
+	}
+	for _, name := range elem[1:] {
+		if name != "" {
+			loc.segments = append(loc.segments, name)
+		}
+	}
+	return loc.uri()
+}
+
+func (GioFS) Base(p string) string { return gioBaseName(p) }
+
+func (g GioFS) ReadDir(uri string) ([]os.DirEntry, error) {
+	return g.ReadDirContext(context.Background(), uri)
+}
+
+// ReadDirContext lists uri, mounting the location once if gio reports it is
+// not mounted yet.
+func (g GioFS) ReadDirContext(ctx context.Context, uri string) ([]os.DirEntry, error) {
+	args := []string{"list", "-l", "-u", "-h", "-a", gioListAttributes, "--", uri}
+	out, err := runGio(ctx, args...)
+	if isGioNotMounted(err) {
+		if _, mountErr := runGio(ctx, "mount", "--", g.root); mountErr != nil {
+			return nil, err
+		}
+		out, err = runGio(ctx, args...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []os.DirEntry
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if fi, ok := parseGioListLine(scanner.Text()); ok {
+			entries = append(entries, gioDirEntry{fi: fi})
+		}
+	}
+	return entries, scanner.Err()
+}
+
+func (GioFS) Stat(uri string) (os.FileInfo, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gioInfoTimeout)
+	defer cancel()
+	out, err := runGio(ctx, "info", "-a", gioInfoAttributes, "--", uri)
+	if err != nil {
+		return nil, err
+	}
+	fi, _ := parseGioInfo(gioBaseName(uri), string(out))
+	return fi, nil
+}
+
+type gioReadCloser struct {
+	io.ReadCloser
+	cmd *exec.Cmd
+}
+
+func (r *gioReadCloser) Close() error {
+	closeErr := r.ReadCloser.Close()
+	_ = r.cmd.Process.Kill()
+	_ = r.cmd.Wait()
+	return closeErr
+}
+
+func (GioFS) Open(uri string) (io.ReadCloser, error) {
+	cmd := exec.Command("gio", "cat", "--", uri)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &gioReadCloser{ReadCloser: stdout, cmd: cmd}, nil
+}
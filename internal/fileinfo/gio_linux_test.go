@@ -0,0 +1,150 @@
+//go:build linux
+// +build linux
+
+package fileinfo
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func stubGio(t *testing.T, run func(args []string) ([]byte, error)) {
+	t.Helper()
+	oldRun, oldAvailable := runGio, gioAvailable
+	runGio = func(_ context.Context, args ...string) ([]byte, error) { return run(args) }
+	gioAvailable = func() error { return nil }
+	gioFuseRoots.Lock()
+	gioFuseRoots.found = map[string]string{}
+	gioFuseRoots.missed = map[string]time.Time{}
+	gioFuseRoots.Unlock()
+	t.Cleanup(func() {
+		runGio, gioAvailable = oldRun, oldAvailable
+		SetGioEnabled(true)
+	})
+}
+
+func TestResolveGioUsesFuseMountWhenPresent(t *testing.T) {
+	fuseRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(fuseRoot, "Internal storage", "DCIM"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	stubGio(t, func(args []string) ([]byte, error) {
+		if args[0] == "info" && args[len(args)-1] == "mtp://Pixel_7/" {
+			return []byte("type: directory\nlocal path: " + fuseRoot + "\n"), nil
+		}
+		return nil, errors.New("unexpected gio " + strings.Join(args, " "))
+	})
+
+	vfs, parsed, err := ResolveRead("mtp://Pixel_7/Internal storage/DCIM")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := vfs.(LocalFS); !ok || parsed.Provider != "local" {
+		t.Fatalf("provider = %T %q, want LocalFS local", vfs, parsed.Provider)
+	}
+	if want := filepath.Join(fuseRoot, "Internal storage", "DCIM"); parsed.Native != want {
+		t.Fatalf("native = %q, want %q", parsed.Native, want)
+	}
+	if parsed.Display != "mtp://Pixel_7/Internal storage/DCIM" || IsGioProviderPath(parsed) {
+		t.Fatalf("parsed = %+v", parsed)
+	}
+}
+
+func TestResolveGioListsThroughGioWithoutFuse(t *testing.T) {
+	var listed string
+	stubGio(t, func(args []string) ([]byte, error) {
+		switch args[0] {
+		case "info":
+			if strings.HasSuffix(args[len(args)-1], "/new") {
+				return nil, os.ErrNotExist
+			}
+			return []byte("type: directory\n"), nil
+		case "list":
+			listed = args[len(args)-1]
+			return []byte("sftp://host/home/u/a%20b.txt\t3\t(regular)\ttime::modified=1700000000\n" +
+				"sftp://host/home/u/sub\t0\t(directory)\n"), nil
+		}
+		return nil, errors.New("unexpected gio " + strings.Join(args, " "))
+	})
+
+	entries, err := ReadDirPortable("sftp://host/home/u")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if listed != "sftp://host/home/u" {
+		t.Fatalf("listed uri = %q", listed)
+	}
+	if len(entries) != 2 || entries[0].Name() != "a b.txt" || entries[0].IsDir() || !entries[1].IsDir() {
+		t.Fatalf("entries = %v", entries)
+	}
+
+	_, parsed, err := ResolveRead("sftp://host/home/u")
+	if err != nil || !IsGioProviderPath(parsed) {
+		t.Fatalf("parsed = %+v err=%v, want gio provider", parsed, err)
+	}
+	if _, err := CreateDirectoryPortable("sftp://host/home/u", "new"); err == nil || !strings.Contains(err.Error(), "read-only") {
+		t.Fatalf("CreateDirectoryPortable error = %v, want read-only", err)
+	}
+}
+
+func TestResolveGioMountsOnceWhenNotMounted(t *testing.T) {
+	mounted := false
+	stubGio(t, func(args []string) ([]byte, error) {
+		switch args[0] {
+		case "info":
+			return nil, errors.New("gio info: location is not mounted")
+		case "mount":
+			mounted = true
+			return nil, nil
+		case "list":
+			if !mounted {
+				return nil, errors.New("gio list: The specified location is not mounted")
+			}
+			return []byte("mtp://Pixel_7/DCIM\t0\t(directory)\n"), nil
+		}
+		return nil, errors.New("unexpected gio " + strings.Join(args, " "))
+	})
+
+	entries, err := ReadDirPortable("mtp://Pixel_7/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !mounted || len(entries) != 1 || entries[0].Name() != "DCIM" {
+		t.Fatalf("mounted=%v entries=%v", mounted, entries)
+	}
+}
+
+func TestResolveGioDisabled(t *testing.T) {
+	stubGio(t, func(args []string) ([]byte, error) {
+		return nil, errors.New("gio should not run while disabled")
+	})
+	SetGioEnabled(false)
+
+	if _, _, err := ResolveRead("mtp://Pixel_7/"); !errors.Is(err, ErrGioDisabled) {
+		t.Fatalf("ResolveRead error = %v, want ErrGioDisabled", err)
+	}
+}
+
+func TestGioVolumesSkippedWhenDisabled(t *testing.T) {
+	calls := 0
+	stubGio(t, func(args []string) ([]byte, error) {
+		calls++
+		return []byte("Volume(0): Pixel 7\n  activation_root=mtp://Pixel_7/\n"), nil
+	})
+
+	if got := gioVolumes(context.Background()); len(got) != 1 || got[0].Kind != VolumeDevice {
+		t.Fatalf("gioVolumes = %+v, want one device", got)
+	}
+	SetGioEnabled(false)
+	if got := gioVolumes(context.Background()); got != nil {
+		t.Fatalf("gioVolumes disabled = %+v, want nil", got)
+	}
+	if calls != 1 {
+		t.Fatalf("gio calls = %d, want 1", calls)
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !linux
+// +build !linux
+
+package fileinfo
+
+import (
+	"context"
+	"fmt"
+)
+
+// GVFS only exists on Linux desktops; elsewhere gio URIs fail explicitly
+// instead of being treated as relative local paths.
+func resolveGio(_ context.Context, input string, loc gioLocation) (VFS, Parsed, error) {
+	parsed := loc.parsed(input)
+	return nil, parsed, fmt.Errorf("%w on this platform: %s", ErrGioUnavailable, parsed.Display)
+}
+
+func gioVolumes(context.Context) []Volume { return nil }
@@ -0,0 +1,117 @@
+package fileinfo
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestParseGioURIDisplayAndURI(t *testing.T) {
+	tests := []struct {
+		in      string
+		display string
+		uri     string
+	}{
+		{in: "mtp://Pixel_7/", display: "mtp://Pixel_7/", uri: "mtp://Pixel_7/"},
+		{in: "MTP://Pixel_7", display: "mtp://Pixel_7/", uri: "mtp://Pixel_7/"},
+		{in: "mtp://Pixel_7/Internal%20storage/DCIM/", display: "mtp://Pixel_7/Internal storage/DCIM", uri: "mtp://Pixel_7/Internal%20storage/DCIM"},
+		{in: "mtp://Pixel_7/Internal storage/./a/../DCIM", display: "mtp://Pixel_7/Internal storage/DCIM", uri: "mtp://Pixel_7/Internal%20storage/DCIM"},
+		{in: "network:///", display: "network:///", uri: "network:///"},
+		{in: "google-drive://me@example.com/id1", display: "google-drive://me@example.com/id1", uri: "google-drive://me@example.com/id1"},
+	}
+	for _, tt := range tests {
+		loc, ok := parseGioURI(tt.in)
+		if !ok {
+			t.Fatalf("parseGioURI(%q) not recognized", tt.in)
+		}
+		if got := loc.display(); got != tt.display {
+			t.Fatalf("display(%q) = %q, want %q", tt.in, got, tt.display)
+		}
+		if got := loc.uri(); got != tt.uri {
+			t.Fatalf("uri(%q) = %q, want %q", tt.in, got, tt.uri)
+		}
+	}
+}
+
+func TestIsGioURIIgnoresOtherSchemes(t *testing.T) {
+	for _, p := range []string{"smb://host/share", "tag://red", "/home/u", "C:\\Users", "file:///tmp", "mtp:"} {
+		if IsGioURI(p) {
+			t.Fatalf("IsGioURI(%q) = true, want false", p)
+		}
+	}
+}
+
+func TestGioPathHelpers(t *testing.T) {
+	if got := JoinPath("mtp://Pixel_7/", "Internal storage"); got != "mtp://Pixel_7/Internal storage" {
+		t.Fatalf("JoinPath root = %q", got)
+	}
+	if got := JoinPath("network:///", "smb-root"); got != "network:///smb-root" {
+		t.Fatalf("JoinPath empty authority = %q", got)
+	}
+	if got := ParentPath("mtp://Pixel_7/Internal storage/DCIM"); got != "mtp://Pixel_7/Internal storage" {
+		t.Fatalf("ParentPath = %q", got)
+	}
+	if got := ParentPath("mtp://Pixel_7/"); got != "mtp://Pixel_7/" {
+		t.Fatalf("ParentPath root = %q, want itself", got)
+	}
+	if got := BaseName("mtp://Pixel_7/Internal storage"); got != "Internal storage" {
+		t.Fatalf("BaseName = %q", got)
+	}
+	if got := BaseName("mtp://Pixel_7/"); got != "Pixel_7" {
+		t.Fatalf("BaseName root = %q", got)
+	}
+}
+
+func TestCanonicalDisplayPathGio(t *testing.T) {
+	display, parsed, err := CanonicalDisplayPath(" sftp://host/home/u/ ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if display != "sftp://host/home/u" || parsed.Scheme != SchemeGio || parsed.Host != "host" {
+		t.Fatalf("CanonicalDisplayPath = %q %+v", display, parsed)
+	}
+	class, err := ClassifyPath("mtp://Pixel_7/DCIM")
+	if err != nil || !class.Removable || !class.Device || class.Network {
+		t.Fatalf("ClassifyPath(mtp) = %+v, %v", class, err)
+	}
+}
+
+func TestParseGioListLine(t *testing.T) {
+	fi, ok := parseGioListLine("mtp://Pixel_7/DCIM/IMG%200001.jpg\t2048\t(regular)\tstandard::is-hidden=FALSE time::modified=1700000000 unix::mode=33188")
+	if !ok {
+		t.Fatal("line not parsed")
+	}
+	if fi.Name() != "IMG 0001.jpg" || fi.Size() != 2048 || fi.IsDir() || fi.Mode().Perm() != 0o644 {
+		t.Fatalf("file info = %+v", fi)
+	}
+	if !fi.ModTime().Equal(time.Unix(1700000000, 0)) {
+		t.Fatalf("mod time = %v", fi.ModTime())
+	}
+
+	fi, ok = parseGioListLine("network:///smb-root\t0\t(mountable)")
+	if !ok || !fi.IsDir() || fi.Name() != "smb-root" {
+		t.Fatalf("mountable = %+v ok=%v, want directory", fi, ok)
+	}
+	if _, ok := parseGioListLine("garbage"); ok {
+		t.Fatal("garbage line should not parse")
+	}
+}
+
+func TestParseGioInfo(t *testing.T) {
+	out := `display name: DCIM
+type: directory
+uri: mtp://Pixel_7/DCIM
+local path: /run/user/1000/gvfs/mtp:host=Pixel_7/DCIM
+attributes:
+  standard::type: 2
+  standard::size: 0
+  time::modified: 1700000000
+`
+	fi, localPath := parseGioInfo("DCIM", out)
+	if !fi.IsDir() || fi.Name() != "DCIM" || fi.Mode()&os.ModePerm != 0o755 {
+		t.Fatalf("file info = %+v", fi)
+	}
+	if localPath != "/run/user/1000/gvfs/mtp:host=Pixel_7/DCIM" {
+		t.Fatalf("local path = %q", localPath)
+	}
+}
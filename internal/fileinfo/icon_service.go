@@ -1,6 +1,7 @@
 package fileinfo
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
@@ -10,14 +11,21 @@ import (
 // IconService provides asynchronous icon fetching with simple in-memory caches.
 // - On Windows, platform-specific functions provide actual icons.
 // - On other platforms, it falls back to nil (UI should use theme defaults).
+//
+// Caches hold icons for one pixel size at a time. Requesting a different size
+// (the window moved to a display with another scale factor) drops the caches
+// and waiting jobs so icons are regenerated at the new size.
 type IconService struct {
-	mu        sync.RWMutex
-	extCache  map[string]fyne.Resource // key: lower-case file extension (e.g., ".txt")
-	fileCache map[string]fyne.Resource // key: full path (or strategy-defined key)
-	pending   map[string]struct{}      // de-duplicate queued jobs (use scope+key)
-	jobs      chan iconJob
-	done      chan struct{}
-	closeOnce sync.Once
+	mu            sync.RWMutex
+	platformIcons bool                     // the platform draws icons; otherwise rows keep theme icons
+	size          int                      // pixel size of cached icons; 0 until the first request
+	extCache      map[string]fyne.Resource // key: lower-case file extension (e.g., ".txt"); nil when the platform has none
+	fileCache     map[string]fyne.Resource // key: full path (or strategy-defined key); nil when the platform has none
+	pending       map[string]struct{}      // de-duplicate queued and in-flight jobs (use scope+key+size)
+	queue         []iconJob                // waiting jobs, highest priority first
+	wake          chan struct{}
+	done          chan struct{}
+	closeOnce     sync.Once
 
 	// Update batching
 	updMu       sync.Mutex
@@ -30,18 +38,32 @@ type IconService struct {
 type iconJob struct {
 	scope string // "ext" or "file"
 	key   string // ext (".txt") or full path
-	size  int    // desired size in pixels (16/24/32 etc.)
+	size  int    // desired size in device pixels (16/24/32 etc.)
 }
 
+func (j iconJob) pendingKey() string { return fmt.Sprintf("%s|%s@%d", j.scope, j.key, j.size) }
+
+// IconRequest describes one list row whose icon should be fetched.
+type IconRequest struct {
+	Path  string
+	IsDir bool
+	Ext   string
+}
+
+// maxQueuedIcons bounds the waiting jobs so a huge read-ahead cannot grow
+// without limit; excess requests are dropped like a full queue.
+const maxQueuedIcons = 256
+
 // NewIconService creates a new icon service with background workers.
 func NewIconService(debug func(format string, args ...interface{})) *IconService {
 	s := &IconService{
-		extCache:   make(map[string]fyne.Resource, 256),
-		fileCache:  make(map[string]fyne.Resource, 512),
-		pending:    make(map[string]struct{}, 512),
-		jobs:       make(chan iconJob, 256),
-		done:       make(chan struct{}),
-		debugPrint: debug,
+		platformIcons: platformProvidesIcons,
+		extCache:      make(map[string]fyne.Resource, 256),
+		fileCache:     make(map[string]fyne.Resource, 512),
+		pending:       make(map[string]struct{}, 512),
+		wake:          make(chan struct{}, 1),
+		done:          make(chan struct{}),
+		debugPrint:    debug,
 	}
 
 	// Start workers
@@ -72,34 +94,148 @@ func (s *IconService) OnUpdated(f func()) {
 // - Directories: always return (nil, false) and let UI use folder icon.
 // - For .exe files on Windows, prefer file-specific icon. For others, prefer extension icon.
 func (s *IconService) GetCachedOrRequest(path string, isDir bool, ext string, size int) (fyne.Resource, bool) {
+	s.useSize(size)
+	res, ok := s.GetCached(path, isDir, ext)
+	if !ok && !isDir {
+		for _, job := range s.missingJobs(path, ext, size) {
+			s.enqueue(job.scope, job.key, job.size)
+		}
+	}
+	return res, ok
+}
+
+// GetCached returns a cached icon without requesting one. List rows use it so
+// recycled off-screen rows never queue work; RequestVisible does the fetching.
+func (s *IconService) GetCached(path string, isDir bool, ext string) (fyne.Resource, bool) {
 	if isDir {
 		return nil, false
 	}
-
-	// 1) File-specific cache (platform policy decides if it’s worth fetching)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	if preferFileIcon(path, ext) {
-		s.mu.RLock()
-		if r, ok := s.fileCache[path]; ok {
-			s.mu.RUnlock()
+		if r, ok := s.fileCache[path]; ok && r != nil {
 			return r, true
 		}
-		s.mu.RUnlock()
-		s.enqueue("file", path, size)
-		// No immediate result; fall back to extension cache/default below
 	}
-
-	// 2) Extension cache
-	s.mu.RLock()
 	if r, ok := s.extCache[ext]; ok {
-		s.mu.RUnlock()
 		return r, true
 	}
-	s.mu.RUnlock()
-
-	s.enqueue("ext", ext, size)
 	return nil, false
 }
 
+// Loading reports whether a file row's platform icon has not been fetched
+// yet, so the row can show a placeholder instead of a theme icon that would
+// be swapped out moments later. It is always false on platforms without
+// icons of their own, and once a fetch found no icon.
+func (s *IconService) Loading(path string, isDir bool, ext string) bool {
+	if isDir || !s.platformIcons || s.closed() {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if preferFileIcon(path, ext) {
+		if _, ok := s.fileCache[path]; ok {
+			return false
+		}
+	}
+	_, ok := s.extCache[ext]
+	return !ok
+}
+
+// missingJobs lists the fetches needed for a file row: the file-specific icon
+// when the platform prefers one, then the extension icon as fallback.
+func (s *IconService) missingJobs(path, ext string, size int) []iconJob {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var jobs []iconJob
+	if preferFileIcon(path, ext) {
+		if _, ok := s.fileCache[path]; !ok {
+			jobs = append(jobs, iconJob{scope: "file", key: path, size: size})
+		}
+	}
+	if _, ok := s.extCache[ext]; !ok {
+		jobs = append(jobs, iconJob{scope: "ext", key: ext, size: size})
+	}
+	return jobs
+}
+
+// RequestVisible replaces the waiting queue with fetches for reqs at size
+// device pixels, in order. Callers pass the visible rows first and a small
+// read-ahead window after them. Waiting jobs for rows that scrolled away are
+// dropped; fetches already running finish and are cached.
+func (s *IconService) RequestVisible(reqs []IconRequest, size int) {
+	if s.closed() {
+		return
+	}
+	s.useSize(size)
+	var wanted []iconJob
+	for _, r := range reqs {
+		if r.IsDir {
+			continue
+		}
+		wanted = append(wanted, s.missingJobs(r.Path, r.Ext, size)...)
+	}
+
+	s.mu.Lock()
+	for _, job := range s.queue {
+		delete(s.pending, job.pendingKey())
+	}
+	stale := len(s.queue)
+	s.queue = s.queue[:0]
+	for _, job := range wanted {
+		if len(s.queue) >= maxQueuedIcons {
+			break
+		}
+		k := job.pendingKey()
+		if _, exists := s.pending[k]; exists {
+			continue
+		}
+		s.pending[k] = struct{}{}
+		s.queue = append(s.queue, job)
+	}
+	queued := len(s.queue)
+	s.mu.Unlock()
+
+	if s.debugPrint != nil && (stale > 0 || queued > 0) {
+		s.debugPrint("IconService: visible request queued=%d replaced=%d", queued, stale)
+	}
+	s.signal()
+}
+
+// useSize switches the cached pixel size. Icons and waiting jobs for the old
+// size are dropped; in-flight fetches for it are discarded when they finish.
+func (s *IconService) useSize(size int) {
+	if size <= 0 {
+		return
+	}
+	s.mu.Lock()
+	if s.size == size {
+		s.mu.Unlock()
+		return
+	}
+	old := s.size
+	s.size = size
+	if old != 0 {
+		for _, job := range s.queue {
+			delete(s.pending, job.pendingKey())
+		}
+		s.queue = s.queue[:0]
+		s.extCache = make(map[string]fyne.Resource, len(s.extCache))
+		s.fileCache = make(map[string]fyne.Resource, len(s.fileCache))
+	}
+	s.mu.Unlock()
+	if old != 0 && s.debugPrint != nil {
+		s.debugPrint("IconService: icon size %d -> %d, cache cleared", old, size)
+	}
+}
+
+func (s *IconService) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
 // Close stops workers/notifier and releases update callbacks. An in-flight
 // platform icon fetch is allowed to return, but its result is discarded.
 func (s *IconService) Close() {
@@ -131,39 +267,53 @@ func (s *IconService) enqueue(scope, key string, size int) {
 	if s.closed() {
 		return
 	}
-	k := scope + "|" + key
+	job := iconJob{scope: scope, key: key, size: size}
+	k := job.pendingKey()
 	s.mu.Lock()
 	if _, exists := s.pending[k]; exists {
 		s.mu.Unlock()
 		return
 	}
-	s.pending[k] = struct{}{}
-	s.mu.Unlock()
-
-	select {
-	case <-s.done:
-		s.mu.Lock()
-		delete(s.pending, k)
+	if len(s.queue) >= maxQueuedIcons {
 		s.mu.Unlock()
-	case s.jobs <- iconJob{scope: scope, key: key, size: size}:
-	default:
 		// queue full; drop silently to protect UI responsiveness
 		if s.debugPrint != nil {
 			s.debugPrint("IconService: job queue full, dropping %s:%s", scope, key)
 		}
-		s.mu.Lock()
-		delete(s.pending, k)
-		s.mu.Unlock()
+		return
+	}
+	s.pending[k] = struct{}{}
+	s.queue = append(s.queue, job)
+	s.mu.Unlock()
+	s.signal()
+}
+
+// next pops the highest-priority waiting job. Its pending marker stays set
+// while the fetch runs so the same icon is not queued twice.
+func (s *IconService) next() (iconJob, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.queue) == 0 {
+		return iconJob{}, false
+	}
+	job := s.queue[0]
+	s.queue = s.queue[1:]
+	if len(s.queue) > 0 {
+		s.signal()
 	}
+	return job, true
 }
 
 func (s *IconService) worker() {
 	for {
-		var job iconJob
-		select {
-		case <-s.done:
-			return
-		case job = <-s.jobs:
+		job, ok := s.next()
+		if !ok {
+			select {
+			case <-s.done:
+				return
+			case <-s.wake:
+			}
+			continue
 		}
 		if s.closed() {
 			return
@@ -173,28 +323,39 @@ func (s *IconService) worker() {
 		switch job.scope {
 		case "ext":
 			res, err = platformFetchExtIcon(job.key, job.size)
-			if err == nil && res != nil && !s.closed() {
-				s.mu.Lock()
-				s.extCache[job.key] = res
-				s.mu.Unlock()
-				s.flagUpdated()
-			}
 		case "file":
 			res, err = platformFetchFileIcon(job.key, job.size)
-			if err == nil && res != nil && !s.closed() {
-				s.mu.Lock()
-				s.fileCache[job.key] = res
-				s.mu.Unlock()
-				s.flagUpdated()
-			}
+		}
+		if err != nil {
+			res = nil
+		}
+		// A missing icon is cached too, so its row stops showing the loading
+		// placeholder and is not fetched again.
+		if (res != nil || s.platformIcons) && !s.closed() && s.store(job, res) {
+			s.flagUpdated()
 		}
 		// clear pending marker
 		s.mu.Lock()
-		delete(s.pending, job.scope+"|"+job.key)
+		delete(s.pending, job.pendingKey())
 		s.mu.Unlock()
 	}
 }
 
+// store caches a fetched icon unless the service switched sizes meanwhile.
+func (s *IconService) store(job iconJob, res fyne.Resource) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if job.size != s.size {
+		return false
+	}
+	if job.scope == "file" {
+		s.fileCache[job.key] = res
+	} else {
+		s.extCache[job.key] = res
+	}
+	return true
+}
+
 func (s *IconService) flagUpdated() {
 	if s.closed() {
 		return
@@ -1,8 +1,11 @@
 package fileinfo
 
 import (
+	"fmt"
 	"testing"
 	"time"
+
+	"fyne.io/fyne/v2"
 )
 
 func TestIconServiceCloseIsIdempotentAndRejectsNewWork(t *testing.T) {
@@ -12,7 +15,7 @@ func TestIconServiceCloseIsIdempotentAndRejectsNewWork(t *testing.T) {
 
 	service.enqueue("ext", ".txt", 16)
 	service.mu.RLock()
-	_, pending := service.pending["ext|.txt"]
+	_, pending := service.pending["ext|.txt@16"]
 	service.mu.RUnlock()
 	if pending {
 		t.Fatal("closed icon service should not retain new work")
@@ -38,3 +41,124 @@ func TestIconServiceCloseReleasesSubscribers(t *testing.T) {
 		t.Fatalf("subscriber count = %d, want 0 after Close", len(service.subscribers))
 	}
 }
+
+// newIdleIconService builds a service without workers so tests can inspect
+// the waiting queue deterministically.
+func newIdleIconService() *IconService {
+	return &IconService{
+		extCache:  make(map[string]fyne.Resource),
+		fileCache: make(map[string]fyne.Resource),
+		pending:   make(map[string]struct{}),
+		wake:      make(chan struct{}, 1),
+		done:      make(chan struct{}),
+	}
+}
+
+func queuedKeys(s *IconService) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	keys := make([]string, len(s.queue))
+	for i, job := range s.queue {
+		keys[i] = job.pendingKey()
+	}
+	return keys
+}
+
+func TestIconServiceRequestVisibleReplacesStaleJobs(t *testing.T) {
+	service := newIdleIconService()
+	service.size = 16
+	service.extCache[".txt"] = fyne.NewStaticResource("txt", nil)
+
+	service.RequestVisible([]IconRequest{
+		{Path: "/a/x.pdf", Ext: ".pdf"},
+		{Path: "/a/y.txt", Ext: ".txt"},
+		{Path: "/a/sub", IsDir: true},
+		{Path: "/a/z.pdf", Ext: ".pdf"},
+		{Path: "/a/w.png", Ext: ".png"},
+	}, 16)
+	if got := fmt.Sprint(queuedKeys(service)); got != "[ext|.pdf@16 ext|.png@16]" {
+		t.Fatalf("queue = %s", got)
+	}
+
+	// Scrolling away drops waiting work that is no longer visible.
+	service.RequestVisible([]IconRequest{{Path: "/b/m.mp3", Ext: ".mp3"}}, 16)
+	if got := fmt.Sprint(queuedKeys(service)); got != "[ext|.mp3@16]" {
+		t.Fatalf("queue after scroll = %s", got)
+	}
+	service.mu.RLock()
+	_, stale := service.pending["ext|.pdf@16"]
+	service.mu.RUnlock()
+	if stale {
+		t.Fatal("dropped job kept its pending marker")
+	}
+}
+
+func TestIconServiceGetCachedDoesNotQueue(t *testing.T) {
+	service := newIdleIconService()
+	if _, ok := service.GetCached("/a/x.pdf", false, ".pdf"); ok {
+		t.Fatal("empty cache reported a hit")
+	}
+	if keys := queuedKeys(service); len(keys) != 0 {
+		t.Fatalf("GetCached queued %v", keys)
+	}
+	service.GetCachedOrRequest("/a/x.pdf", false, ".pdf", 16)
+	if got := fmt.Sprint(queuedKeys(service)); got != "[ext|.pdf@16]" {
+		t.Fatalf("GetCachedOrRequest queue = %s", got)
+	}
+}
+
+func TestIconServiceSizeChangeRegeneratesIcons(t *testing.T) {
+	service := newIdleIconService()
+	service.RequestVisible([]IconRequest{{Path: "/a/x.pdf", Ext: ".pdf"}}, 16)
+	service.mu.Lock()
+	service.extCache[".txt"] = fyne.NewStaticResource("txt16", nil)
+	service.mu.Unlock()
+
+	// A finished 16px fetch still lands while the size is unchanged.
+	if !service.store(iconJob{scope: "ext", key: ".doc", size: 16}, fyne.NewStaticResource("doc16", nil)) {
+		t.Fatal("fetch for the current size should be cached")
+	}
+
+	service.RequestVisible([]IconRequest{{Path: "/a/y.txt", Ext: ".txt"}}, 24)
+	if _, ok := service.GetCached("/a/y.txt", false, ".txt"); ok {
+		t.Fatal("icons cached at the old size should be dropped")
+	}
+	if got := fmt.Sprint(queuedKeys(service)); got != "[ext|.txt@24]" {
+		t.Fatalf("queue after size change = %s", got)
+	}
+	if service.store(iconJob{scope: "ext", key: ".pdf", size: 16}, fyne.NewStaticResource("pdf16", nil)) {
+		t.Fatal("late fetch for the old size must be discarded")
+	}
+}
+
+func TestIconServiceLoadingEndsWhenFetchFinishes(t *testing.T) {
+	service := newIdleIconService()
+	if service.Loading("/a/x.txt", false, ".txt") {
+		t.Fatal("rows keep theme icons when the platform draws none")
+	}
+
+	service.platformIcons = true
+	service.RequestVisible([]IconRequest{{Path: "/a/x.txt", Ext: ".txt"}, {Path: "/a/y.pdf", Ext: ".pdf"}}, 16)
+	if !service.Loading("/a/x.txt", false, ".txt") {
+		t.Fatal("uncached platform icon should be loading")
+	}
+	if service.Loading("/a", true, "") {
+		t.Fatal("directories use the theme folder icon and never load")
+	}
+
+	service.store(iconJob{scope: "ext", key: ".txt", size: 16}, fyne.NewStaticResource("txt16", nil))
+	// A fetch that found nothing is cached as nil: the row stops loading and
+	// falls back to the theme icon.
+	service.store(iconJob{scope: "ext", key: ".pdf", size: 16}, nil)
+	if service.Loading("/a/x.txt", false, ".txt") || service.Loading("/a/y.pdf", false, ".pdf") {
+		t.Fatal("finished fetches should end loading")
+	}
+	if res, ok := service.GetCached("/a/y.pdf", false, ".pdf"); !ok || res != nil {
+		t.Fatalf("missing icon GetCached = %v, %t; want nil, true", res, ok)
+	}
+	service.Close()
+	service.platformIcons = true
+	if service.Loading("/a/z.doc", false, ".doc") {
+		t.Fatal("a closed service should not report loading")
+	}
+}
@@ -6,6 +6,9 @@ import (
 	"fyne.io/fyne/v2"
 )
 
+// platformProvidesIcons is false: rows use theme icons from the start.
+const platformProvidesIcons = false
+
 // Non-Windows platforms: return nil to indicate using theme defaults.
 func platformFetchExtIcon(ext string, size int) (fyne.Resource, error) {
 	return nil, nil
@@ -16,6 +16,9 @@ import (
 
 // Minimal Windows icon extraction using SHGetFileInfo and GDI to render HICON into a 32-bit DIB.
 
+// platformProvidesIcons is true: rows wait for the shell's icons.
+const platformProvidesIcons = true
+
 var (
 	modShell32         = syscall.NewLazyDLL("shell32.dll")
 	procSHGetFileInfoW = modShell32.NewProc("SHGetFileInfoW")
@@ -86,7 +89,9 @@ func platformFetchExtIcon(ext string, size int) (fyne.Resource, error) {
 	if err := png.Encode(buf, img); err != nil {
 		return nil, err
 	}
-	return fyne.NewStaticResource("ext:"+ext, buf.Bytes()), nil
+	// The size is part of the name because Fyne caches decoded images by
+	// resource name; a rescaled icon must not reuse the old bitmap.
+	return fyne.NewStaticResource(fmt.Sprintf("ext:%s@%d", ext, iconSizeFor(size)), buf.Bytes()), nil
 }
 
 func platformFetchFileIcon(path string, size int) (fyne.Resource, error) {
@@ -103,7 +108,7 @@ func platformFetchFileIcon(path string, size int) (fyne.Resource, error) {
 	if err := png.Encode(buf, img); err != nil {
 		return nil, err
 	}
-	return fyne.NewStaticResource("file:"+path, buf.Bytes()), nil
+	return fyne.NewStaticResource(fmt.Sprintf("file:%s@%d", path, iconSizeFor(size)), buf.Bytes()), nil
 }
 
 // preferFileIcon returns true for types where a file-specific icon is beneficial.
@@ -118,17 +123,16 @@ func preferFileIcon(path, ext string) bool {
 	}
 }
 
+// iconSizeFor rounds a device-pixel size up to a common icon size. Sizes
+// above 32 come from the large system icon drawn at that size, which keeps
+// 150% and 200% displays from upscaling a 32px bitmap in Fyne.
 func iconSizeFor(size int) int {
-	if size <= 16 {
-		return 16
-	}
-	if size <= 24 {
-		return 24
-	}
-	if size <= 32 {
-		return 32
+	for _, n := range []int{16, 24, 32, 48, 64} {
+		if size <= n {
+			return n
+		}
 	}
-	return 32
+	return 64
 }
 
 func getHICONForExt(ext string, size int) (syscall.Handle, error) {
@@ -2,6 +2,7 @@ package fileinfo
 
 import (
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
@@ -17,16 +18,20 @@ type readlinkProvider interface {
 
 // PathMetadata is the normalized file metadata nmf uses for list rendering and navigation.
 type PathMetadata struct {
-	Info     os.FileInfo
-	IsDir    bool
-	IsLink   bool
-	FileType FileType
-	Size     int64
-	Modified time.Time
+	Info       os.FileInfo
+	IsDir      bool
+	IsLink     bool
+	LinkTarget string // the link's target as stored; "" for non-links or when unreadable
+	FileType   FileType
+	Size       int64
+	Modified   time.Time
 }
 
 // LstatPortable resolves the path to its backend and stats the path without following links.
 func LstatPortable(p string) (os.FileInfo, error) {
+	if err := InjectFault(FaultOpStat, p); err != nil {
+		return nil, err
+	}
 	vfs, parsed, err := ResolveRead(p)
 	if err != nil {
 		return nil, err
@@ -65,6 +70,25 @@ func ReadlinkPortable(p string) (string, error) {
 	return "", os.ErrInvalid
 }
 
+// PhysicalPath resolves every symbolic link in a local path, so a directory
+// reached through a link opens at the path it really lives at. Paths on other
+// backends are returned unchanged.
+func PhysicalPath(p string) (string, error) {
+	vfs, parsed, err := ResolveRead(p)
+	if err != nil {
+		return "", err
+	}
+	CloseVFS(vfs)
+	if parsed.Provider != "local" && parsed.Scheme != SchemeFile {
+		return p, nil
+	}
+	native := parsed.Native
+	if native == "" {
+		native = p
+	}
+	return filepath.EvalSymlinks(native)
+}
+
 // IsLinkModeCandidate reports whether a mode may describe a link-like object.
 // Windows directory junctions are reparse points that Go exposes as irregular
 // files on current releases, so callers must confirm them with Readlink.
@@ -85,9 +109,11 @@ func InspectPath(path string, name string, entry os.DirEntry) (PathMetadata, err
 	}
 
 	isLink := info.Mode()&os.ModeSymlink != 0
-	if !isLink && runtime.GOOS == "windows" && info.Mode()&os.ModeIrregular != 0 {
-		if _, err := ReadlinkPortable(path); err == nil {
+	linkTarget := ""
+	if isLink || runtime.GOOS == "windows" && info.Mode()&os.ModeIrregular != 0 {
+		if target, err := ReadlinkPortable(path); err == nil {
 			isLink = true
+			linkTarget = target
 		}
 	}
 
@@ -100,12 +126,13 @@ func InspectPath(path string, name string, entry os.DirEntry) (PathMetadata, err
 	}
 
 	return PathMetadata{
-		Info:     info,
-		IsDir:    isDir,
-		IsLink:   isLink,
-		FileType: determineFileType(path, name, isDir, isLink),
-		Size:     info.Size(),
-		Modified: info.ModTime(),
+		Info:       info,
+		IsDir:      isDir,
+		IsLink:     isLink,
+		LinkTarget: linkTarget,
+		FileType:   determineFileType(path, name, isDir, isLink),
+		Size:       info.Size(),
+		Modified:   info.ModTime(),
 	}, nil
 }
 
@@ -117,13 +144,17 @@ func FileInfoFromDirEntry(parent string, entry os.DirEntry) (FileInfo, error) {
 		return FileInfo{}, err
 	}
 	return FileInfo{
-		Name:     entry.Name(),
-		Path:     fullPath,
-		IsDir:    metadata.IsDir,
-		Size:     metadata.Size,
-		Modified: metadata.Modified,
-		FileType: metadata.FileType,
-		Status:   StatusNormal,
+		Name:       entry.Name(),
+		Path:       fullPath,
+		IsDir:      metadata.IsDir,
+		Size:       metadata.Size,
+		Modified:   metadata.Modified,
+		FileType:   metadata.FileType,
+		Status:     StatusNormal,
+		ReadOnly:   metadata.Info.Mode().Perm()&0200 == 0,
+		Mode:       metadata.Info.Mode(),
+		Owner:      listingOwner(metadata.Info),
+		LinkTarget: metadata.LinkTarget,
 	}, nil
 }
 
@@ -0,0 +1,154 @@
+package fileinfo
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ListingFormat is a file format for an exported directory listing.
+type ListingFormat string
+
+const (
+	ListingCSV      ListingFormat = "csv"
+	ListingJSON     ListingFormat = "json"
+	ListingMarkdown ListingFormat = "markdown"
+)
+
+// ListingFormatForName picks the export format from a file name's
+// extension: .csv, .json, or .md.
+func ListingFormatForName(name string) (ListingFormat, bool) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return ListingCSV, true
+	case ".json":
+		return ListingJSON, true
+	case ".md", ".markdown":
+		return ListingMarkdown, true
+	default:
+		return "", false
+	}
+}
+
+// listingRecord is one exported entry; Size is nil for directories.
+type listingRecord struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Type     string `json:"type"`
+	Size     *int64 `json:"size,omitempty"`
+	Modified string `json:"modified,omitempty"`
+	SHA256   string `json:"sha256,omitempty"`
+}
+
+func newListingRecord(fi FileInfo, sums map[string]string) listingRecord {
+	r := listingRecord{Name: fi.Name, Path: fi.Path, Type: listingType(fi), SHA256: sums[fi.Path]}
+	if !fi.IsDir {
+		size := fi.Size
+		r.Size = &size
+	}
+	if !fi.Modified.IsZero() {
+		r.Modified = fi.Modified.Format(time.RFC3339)
+	}
+	return r
+}
+
+func listingType(fi FileInfo) string {
+	switch {
+	case fi.FileType == FileTypeSymlink:
+		return "symlink"
+	case fi.IsDir:
+		return "directory"
+	default:
+		return "file"
+	}
+}
+
+// WriteListing writes files, in their order, as format. A non-nil sums adds
+// a SHA-256 column keyed by path, left empty for entries without a digest.
+func WriteListing(w io.Writer, format ListingFormat, files []FileInfo, sums map[string]string) error {
+	records := make([]listingRecord, len(files))
+	for i, fi := range files {
+		records[i] = newListingRecord(fi, sums)
+	}
+	switch format {
+	case ListingCSV:
+		return writeListingCSV(w, records, sums != nil)
+	case ListingJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	case ListingMarkdown:
+		return writeListingMarkdown(w, records, sums != nil)
+	default:
+		return fmt.Errorf("unknown listing format: %s", format)
+	}
+}
+
+func writeListingCSV(w io.Writer, records []listingRecord, withSums bool) error {
+	cw := csv.NewWriter(w)
+	header := []string{"name", "path", "type", "size", "modified"}
+	if withSums {
+		header = append(header, "sha256")
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, r := range records {
+		size := ""
+		if r.Size != nil {
+			size = strconv.FormatInt(*r.Size, 10)
+		}
+		row := []string{r.Name, r.Path, r.Type, size, r.Modified}
+		if withSums {
+			row = append(row, r.SHA256)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeListingMarkdown writes a table meant for reading, so sizes are
+// rounded and paths left out.
+func writeListingMarkdown(w io.Writer, records []listingRecord, withSums bool) error {
+	var b strings.Builder
+	b.WriteString("| Name | Type | Size | Modified |")
+	if withSums {
+		b.WriteString(" SHA-256 |")
+	}
+	b.WriteString("\n| --- | --- | ---: | --- |")
+	if withSums {
+		b.WriteString(" --- |")
+	}
+	b.WriteString("\n")
+	for _, r := range records {
+		size := ""
+		if r.Size != nil {
+			size = FormatFileSize(*r.Size)
+		}
+		modified := ""
+		if t, err := time.Parse(time.RFC3339, r.Modified); err == nil {
+			modified = t.Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |", markdownCell(r.Name), r.Type, size, modified)
+		if withSums {
+			fmt.Fprintf(&b, " %s |", r.SHA256)
+		}
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
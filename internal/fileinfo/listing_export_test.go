@@ -0,0 +1,73 @@
+package fileinfo
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testListing() []FileInfo {
+	modified := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	return []FileInfo{
+		{Name: "docs", Path: "/data/docs", IsDir: true, FileType: FileTypeDirectory, Modified: modified},
+		{Name: "a|b.txt", Path: "/data/a|b.txt", Size: 2048, Modified: modified},
+	}
+}
+
+func TestListingFormatForName(t *testing.T) {
+	for name, want := range map[string]ListingFormat{"x.csv": ListingCSV, "X.JSON": ListingJSON, "report.md": ListingMarkdown} {
+		if got, ok := ListingFormatForName(name); !ok || got != want {
+			t.Errorf("ListingFormatForName(%q) = %q, %t", name, got, ok)
+		}
+	}
+	if _, ok := ListingFormatForName("list.txt"); ok {
+		t.Error(".txt should not pick a format")
+	}
+}
+
+func TestWriteListingCSVWithChecksums(t *testing.T) {
+	var buf bytes.Buffer
+	sums := map[string]string{"/data/a|b.txt": "abc123"}
+	if err := WriteListing(&buf, ListingCSV, testListing(), sums); err != nil {
+		t.Fatalf("WriteListing: %v", err)
+	}
+	want := "name,path,type,size,modified,sha256\n" +
+		"docs,/data/docs,directory,,2026-03-04T05:06:07Z,\n" +
+		"a|b.txt,/data/a|b.txt,file,2048,2026-03-04T05:06:07Z,abc123\n"
+	if buf.String() != want {
+		t.Fatalf("CSV =\n%s\nwant\n%s", buf.String(), want)
+	}
+}
+
+func TestWriteListingJSONOmitsDirectorySize(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteListing(&buf, ListingJSON, testListing(), nil); err != nil {
+		t.Fatalf("WriteListing: %v", err)
+	}
+	var records []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &records); err != nil {
+		t.Fatalf("output is not JSON: %v", err)
+	}
+	if _, ok := records[0]["size"]; ok || records[0]["type"] != "directory" {
+		t.Fatalf("directory record = %v, want no size", records[0])
+	}
+	if records[1]["size"] != float64(2048) || records[1]["sha256"] != nil {
+		t.Fatalf("file record = %v, want size and no checksum", records[1])
+	}
+}
+
+func TestWriteListingMarkdownEscapesCells(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteListing(&buf, ListingMarkdown, testListing(), nil); err != nil {
+		t.Fatalf("WriteListing: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("lines = %q, want header, rule, and two rows", lines)
+	}
+	if lines[3] != `| a\|b.txt | file | 2.0 KB | 2026-03-04 05:06 |` {
+		t.Fatalf("file row = %q", lines[3])
+	}
+}
@@ -0,0 +1,526 @@
+package fileinfo
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// MetadataReadLimit bounds how much of a file ReadMediaMetadata inspects.
+// Container headers (EXIF, ID3v2, FLAC STREAMINFO, MP4 moov) normally live in
+// the first megabyte; files that store them later report what was found.
+// Images stop at imageMetadataReadLimit because a JPEG APP1 (EXIF) segment is
+// capped at 64KiB, which keeps date-taken sorting of photo folders cheap.
+const (
+	MetadataReadLimit      = 1 << 20
+	imageMetadataReadLimit = 256 << 10
+)
+
+// MediaKind classifies files that carry media metadata.
+type MediaKind int
+
+const (
+	MediaKindNone MediaKind = iota
+	MediaKindImage
+	MediaKindAudio
+	MediaKindVideo
+)
+
+func (k MediaKind) String() string {
+	switch k {
+	case MediaKindImage:
+		return "image"
+	case MediaKindAudio:
+		return "audio"
+	case MediaKindVideo:
+		return "video"
+	default:
+		return "none"
+	}
+}
+
+// MediaMetadata holds the subset of image EXIF and audio/video tags NMF shows.
+// Zero values mean the field was not present or could not be parsed.
+type MediaMetadata struct {
+	Kind        MediaKind
+	Format      string
+	CameraMake  string
+	CameraModel string
+	DateTaken   time.Time
+	Width       int
+	Height      int
+	Duration    time.Duration
+	Title       string
+	Artist      string
+	Album       string
+}
+
+var mediaKindsByExt = map[string]MediaKind{
+	".jpg":  MediaKindImage,
+	".jpeg": MediaKindImage,
+	".png":  MediaKindImage,
+	".gif":  MediaKindImage,
+	".bmp":  MediaKindImage,
+	".tif":  MediaKindImage,
+	".tiff": MediaKindImage,
+	".webp": MediaKindImage,
+	".mp3":  MediaKindAudio,
+	".flac": MediaKindAudio,
+	".wav":  MediaKindAudio,
+	".m4a":  MediaKindAudio,
+	".mp4":  MediaKindVideo,
+	".m4v":  MediaKindVideo,
+	".mov":  MediaKindVideo,
+}
+
+// MediaKindForName returns the media kind implied by a file name extension.
+func MediaKindForName(name string) MediaKind {
+	return mediaKindsByExt[strings.ToLower(filepath.Ext(name))]
+}
+
+// IsMediaFile reports whether name has an extension ReadMediaMetadata parses.
+func IsMediaFile(name string) bool {
+	return MediaKindForName(name) != MediaKindNone
+}
+
+// ReadMediaMetadata reads up to MetadataReadLimit bytes of p and extracts
+// media metadata. Unsupported files return an error.
+func ReadMediaMetadata(ctx context.Context, p string) (MediaMetadata, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	kind := MediaKindForName(p)
+	if kind == MediaKindNone {
+		return MediaMetadata{}, fmt.Errorf("unsupported media file: %s", p)
+	}
+	if err := ctx.Err(); err != nil {
+		return MediaMetadata{}, err
+	}
+	rc, err := OpenPortable(p)
+	if err != nil {
+		return MediaMetadata{}, err
+	}
+	defer rc.Close()
+	limit := int64(MetadataReadLimit)
+	if kind == MediaKindImage {
+		limit = imageMetadataReadLimit
+	}
+	data, err := io.ReadAll(io.LimitReader(rc, limit))
+	if err != nil {
+		return MediaMetadata{}, err
+	}
+	if err := ctx.Err(); err != nil {
+		return MediaMetadata{}, err
+	}
+	return ParseMediaMetadata(kind, data), nil
+}
+
+// ParseMediaMetadata extracts metadata from the leading bytes of a media file.
+func ParseMediaMetadata(kind MediaKind, data []byte) MediaMetadata {
+	meta := MediaMetadata{Kind: kind}
+	switch {
+	case len(data) >= 2 && data[0] == 0xFF && data[1] == 0xD8:
+		meta.Format = "JPEG"
+		if exif := jpegEXIF(data); exif != nil {
+			parseTIFFEXIF(exif, &meta)
+		}
+	case bytes.HasPrefix(data, []byte("II*\x00")) || bytes.HasPrefix(data, []byte("MM\x00*")):
+		meta.Format = "TIFF"
+		parseTIFFEXIF(data, &meta)
+	case bytes.HasPrefix(data, []byte("ID3")):
+		meta.Format = "MP3"
+		parseID3v2(data, &meta)
+	case bytes.HasPrefix(data, []byte("fLaC")):
+		meta.Format = "FLAC"
+		parseFLAC(data, &meta)
+	case len(data) >= 12 && bytes.Equal(data[0:4], []byte("RIFF")) && bytes.Equal(data[8:12], []byte("WAVE")):
+		meta.Format = "WAV"
+		parseWAV(data, &meta)
+	case len(data) >= 8 && bytes.Equal(data[4:8], []byte("ftyp")):
+		meta.Format = "MP4"
+		parseMP4(data, &meta)
+	}
+	if meta.Kind == MediaKindImage && (meta.Width == 0 || meta.Height == 0) {
+		if cfg, format, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+			meta.Width = cfg.Width
+			meta.Height = cfg.Height
+			if meta.Format == "" {
+				meta.Format = strings.ToUpper(format)
+			}
+		}
+	}
+	return meta
+}
+
+// Lines returns human-readable "Label: value" rows for present fields.
+func (m MediaMetadata) Lines() []string {
+	var lines []string
+	add := func(label, value string) {
+		if value != "" {
+			lines = append(lines, label+": "+value)
+		}
+	}
+	add("Format", m.Format)
+	if m.Width > 0 && m.Height > 0 {
+		add("Dimensions", fmt.Sprintf("%dx%d", m.Width, m.Height))
+	}
+	add("Camera", strings.TrimSpace(m.CameraMake+" "+m.CameraModel))
+	if !m.DateTaken.IsZero() {
+		add("Date taken", m.DateTaken.Format("2006-01-02 15:04:05"))
+	}
+	if m.Duration > 0 {
+		add("Duration", formatMediaDuration(m.Duration))
+	}
+	add("Title", m.Title)
+	add("Artist", m.Artist)
+	add("Album", m.Album)
+	return lines
+}
+
+// Summary returns a short single-line description suitable for a list column.
+func (m MediaMetadata) Summary() string {
+	var parts []string
+	if m.Width > 0 && m.Height > 0 {
+		parts = append(parts, fmt.Sprintf("%dx%d", m.Width, m.Height))
+	}
+	if !m.DateTaken.IsZero() {
+		parts = append(parts, m.DateTaken.Format("2006-01-02"))
+	}
+	if m.Duration > 0 {
+		parts = append(parts, formatMediaDuration(m.Duration))
+	}
+	if m.Artist != "" {
+		parts = append(parts, m.Artist)
+	}
+	return strings.Join(parts, " ")
+}
+
+func formatMediaDuration(d time.Duration) string {
+	total := int64(d.Round(time.Second) / time.Second)
+	h, m, s := total/3600, (total/60)%60, total%60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
+func jpegEXIF(data []byte) []byte {
+	pos := 2
+	for pos+4 <= len(data) {
+		if data[pos] != 0xFF {
+			return nil
+		}
+		marker := data[pos+1]
+		if marker == 0xD9 || marker == 0xDA {
+			return nil
+		}
+		size := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		if size < 2 || pos+2+size > len(data) {
+			return nil
+		}
+		segment := data[pos+4 : pos+2+size]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return segment[6:]
+		}
+		pos += 2 + size
+	}
+	return nil
+}
+
+const (
+	exifTagMake             = 0x010F
+	exifTagModel            = 0x0110
+	exifTagDateTime         = 0x0132
+	exifTagExifIFD          = 0x8769
+	exifTagDateTimeOriginal = 0x9003
+	exifTagPixelXDimension  = 0xA002
+	exifTagPixelYDimension  = 0xA003
+	exifTagImageWidth       = 0x0100
+	exifTagImageLength      = 0x0101
+)
+
+func parseTIFFEXIF(tiff []byte, meta *MediaMetadata) {
+	if len(tiff) < 8 {
+		return
+	}
+	var order binary.ByteOrder
+	switch string(tiff[0:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return
+	}
+	var modified time.Time
+	var exifOffset uint32
+	readIFD(tiff, order, order.Uint32(tiff[4:8]), func(tag uint16, value func() (string, uint32)) {
+		switch tag {
+		case exifTagMake:
+			meta.CameraMake, _ = value()
+		case exifTagModel:
+			meta.CameraModel, _ = value()
+		case exifTagDateTime:
+			s, _ := value()
+			modified = parseEXIFTime(s)
+		case exifTagExifIFD:
+			_, exifOffset = value()
+		case exifTagImageWidth:
+			_, n := value()
+			meta.Width = int(n)
+		case exifTagImageLength:
+			_, n := value()
+			meta.Height = int(n)
+		}
+	})
+	if exifOffset != 0 {
+		readIFD(tiff, order, exifOffset, func(tag uint16, value func() (string, uint32)) {
+			switch tag {
+			case exifTagDateTimeOriginal:
+				s, _ := value()
+				meta.DateTaken = parseEXIFTime(s)
+			case exifTagPixelXDimension:
+				_, n := value()
+				meta.Width = int(n)
+			case exifTagPixelYDimension:
+				_, n := value()
+				meta.Height = int(n)
+			}
+		})
+	}
+	if meta.DateTaken.IsZero() {
+		meta.DateTaken = modified
+	}
+}
+
+// readIFD walks one TIFF IFD and reports each entry. The value callback
+// decodes ASCII entries as strings and SHORT/LONG entries as integers.
+func readIFD(tiff []byte, order binary.ByteOrder, offset uint32, visit func(tag uint16, value func() (string, uint32))) {
+	if int(offset)+2 > len(tiff) {
+		return
+	}
+	count := int(order.Uint16(tiff[offset : offset+2]))
+	for i := 0; i < count; i++ {
+		entry := int(offset) + 2 + i*12
+		if entry+12 > len(tiff) {
+			return
+		}
+		tag := order.Uint16(tiff[entry : entry+2])
+		typ := order.Uint16(tiff[entry+2 : entry+4])
+		n := order.Uint32(tiff[entry+4 : entry+8])
+		raw := tiff[entry+8 : entry+12]
+		visit(tag, func() (string, uint32) {
+			switch typ {
+			case 2: // ASCII
+				data := raw
+				if n > 4 {
+					start := order.Uint32(raw)
+					if uint64(start)+uint64(n) > uint64(len(tiff)) {
+						return "", 0
+					}
+					data = tiff[start : start+n]
+				} else {
+					data = raw[:n]
+				}
+				return strings.TrimSpace(strings.TrimRight(string(data), "\x00")), 0
+			case 3: // SHORT
+				return "", uint32(order.Uint16(raw))
+			case 4: // LONG
+				return "", order.Uint32(raw)
+			}
+			return "", 0
+		})
+	}
+}
+
+func parseEXIFTime(s string) time.Time {
+	t, err := time.ParseInLocation("2006:01:02 15:04:05", s, time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+func parseID3v2(data []byte, meta *MediaMetadata) {
+	if len(data) < 10 {
+		return
+	}
+	version := data[3]
+	size := syncsafe(data[6:10])
+	end := 10 + size
+	if end > len(data) {
+		end = len(data)
+	}
+	pos := 10
+	for pos+10 <= end {
+		id := string(data[pos : pos+4])
+		if id[0] == 0 {
+			return
+		}
+		var frameSize int
+		if version >= 4 {
+			frameSize = syncsafe(data[pos+4 : pos+8])
+		} else {
+			frameSize = int(binary.BigEndian.Uint32(data[pos+4 : pos+8]))
+		}
+		body := pos + 10
+		if frameSize <= 0 || body+frameSize > end {
+			return
+		}
+		frame := data[body : body+frameSize]
+		switch id {
+		case "TIT2":
+			meta.Title = decodeID3Text(frame)
+		case "TPE1":
+			meta.Artist = decodeID3Text(frame)
+		case "TALB":
+			meta.Album = decodeID3Text(frame)
+		case "TLEN":
+			var ms int64
+			if _, err := fmt.Sscan(decodeID3Text(frame), &ms); err == nil && ms > 0 {
+				meta.Duration = time.Duration(ms) * time.Millisecond
+			}
+		}
+		pos = body + frameSize
+	}
+}
+
+func syncsafe(b []byte) int {
+	return int(b[0]&0x7F)<<21 | int(b[1]&0x7F)<<14 | int(b[2]&0x7F)<<7 | int(b[3]&0x7F)
+}
+
+func decodeID3Text(frame []byte) string {
+	if len(frame) == 0 {
+		return ""
+	}
+	encoding, text := frame[0], frame[1:]
+	switch encoding {
+	case 1, 2: // UTF-16 with BOM, UTF-16BE
+		var order binary.ByteOrder = binary.BigEndian
+		if encoding == 1 && len(text) >= 2 {
+			if text[0] == 0xFF && text[1] == 0xFE {
+				order = binary.LittleEndian
+			}
+			text = text[2:]
+		}
+		units := make([]uint16, 0, len(text)/2)
+		for i := 0; i+1 < len(text); i += 2 {
+			units = append(units, order.Uint16(text[i:i+2]))
+		}
+		return strings.TrimRight(string(utf16.Decode(units)), "\x00")
+	case 0: // ISO-8859-1
+		runes := make([]rune, 0, len(text))
+		for _, b := range text {
+			runes = append(runes, rune(b))
+		}
+		return strings.TrimRight(string(runes), "\x00")
+	default:
+		return strings.TrimRight(string(text), "\x00")
+	}
+}
+
+func parseFLAC(data []byte, meta *MediaMetadata) {
+	// STREAMINFO is mandatory and always the first metadata block.
+	if len(data) < 4+4+18 || data[4]&0x7F != 0 {
+		return
+	}
+	info := data[8:]
+	sampleRate := uint32(info[10])<<12 | uint32(info[11])<<4 | uint32(info[12])>>4
+	totalSamples := uint64(info[13]&0x0F)<<32 | uint64(binary.BigEndian.Uint32(info[14:18]))
+	if sampleRate > 0 && totalSamples > 0 {
+		meta.Duration = time.Duration(totalSamples * uint64(time.Second) / uint64(sampleRate))
+	}
+}
+
+func parseWAV(data []byte, meta *MediaMetadata) {
+	var byteRate uint32
+	pos := 12
+	for pos+8 <= len(data) {
+		id := string(data[pos : pos+4])
+		size := binary.LittleEndian.Uint32(data[pos+4 : pos+8])
+		body := pos + 8
+		switch id {
+		case "fmt ":
+			if body+12 <= len(data) {
+				byteRate = binary.LittleEndian.Uint32(data[body+8 : body+12])
+			}
+		case "data":
+			if byteRate > 0 {
+				meta.Duration = time.Duration(uint64(size) * uint64(time.Second) / uint64(byteRate))
+			}
+			return
+		}
+		pos = body + int(size) + int(size&1)
+	}
+}
+
+func parseMP4(data []byte, meta *MediaMetadata) {
+	walkMP4Boxes(data, func(box string, body []byte) bool {
+		switch box {
+		case "moov", "trak":
+			return true
+		case "mvhd":
+			if len(body) < 4 {
+				return false
+			}
+			var timescale uint32
+			var duration uint64
+			if body[0] == 1 && len(body) >= 32 {
+				timescale = binary.BigEndian.Uint32(body[20:24])
+				duration = binary.BigEndian.Uint64(body[24:32])
+			} else if len(body) >= 20 {
+				timescale = binary.BigEndian.Uint32(body[12:16])
+				duration = uint64(binary.BigEndian.Uint32(body[16:20]))
+			}
+			if timescale > 0 {
+				meta.Duration = time.Duration(duration * uint64(time.Second) / uint64(timescale))
+			}
+		case "tkhd":
+			// Width and height are 16.16 fixed-point values closing the box.
+			if len(body) >= 8 && meta.Width == 0 {
+				w := int(binary.BigEndian.Uint32(body[len(body)-8:]) >> 16)
+				h := int(binary.BigEndian.Uint32(body[len(body)-4:]) >> 16)
+				if w > 0 && h > 0 {
+					meta.Width, meta.Height = w, h
+				}
+			}
+		}
+		return false
+	})
+}
+
+// walkMP4Boxes visits boxes in data; descend reports whether to recurse into
+// the box body as a container.
+func walkMP4Boxes(data []byte, descend func(box string, body []byte) bool) {
+	pos := 0
+	for pos+8 <= len(data) {
+		size := uint64(binary.BigEndian.Uint32(data[pos : pos+4]))
+		box := string(data[pos+4 : pos+8])
+		header := uint64(8)
+		if size == 1 && pos+16 <= len(data) {
+			size = binary.BigEndian.Uint64(data[pos+8 : pos+16])
+			header = 16
+		} else if size == 0 {
+			size = uint64(len(data) - pos)
+		}
+		if size < header {
+			return
+		}
+		end := uint64(pos) + size
+		if end > uint64(len(data)) {
+			end = uint64(len(data))
+		}
+		body := data[uint64(pos)+header : end]
+		if descend(box, body) {
+			walkMP4Boxes(body, descend)
+		}
+		pos = int(end)
+	}
+}
@@ -0,0 +1,168 @@
+package fileinfo
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func buildTestEXIFJPEG() []byte {
+	// Little-endian TIFF: IFD0 with Make, Model, ExifIFD pointer; Exif IFD with
+	// DateTimeOriginal and pixel dimensions.
+	var tiff bytes.Buffer
+	le := binary.LittleEndian
+	tiff.WriteString("II*\x00")
+	binary.Write(&tiff, le, uint32(8))
+
+	const ifd0Entries = 3
+	ifd0Size := 2 + ifd0Entries*12 + 4
+	exifOffset := 8 + ifd0Size
+	const exifEntries = 3
+	exifSize := 2 + exifEntries*12 + 4
+	dataOffset := exifOffset + exifSize
+
+	makeStr := "NMFCam\x00"
+	modelStr := "Model-7\x00"
+	dateStr := "2024:05:06 07:08:09\x00"
+	makeOff := dataOffset
+	modelOff := makeOff + len(makeStr)
+	dateOff := modelOff + len(modelStr)
+
+	entry := func(tag, typ uint16, count, value uint32) {
+		binary.Write(&tiff, le, tag)
+		binary.Write(&tiff, le, typ)
+		binary.Write(&tiff, le, count)
+		binary.Write(&tiff, le, value)
+	}
+	binary.Write(&tiff, le, uint16(ifd0Entries))
+	entry(exifTagMake, 2, uint32(len(makeStr)), uint32(makeOff))
+	entry(exifTagModel, 2, uint32(len(modelStr)), uint32(modelOff))
+	entry(exifTagExifIFD, 4, 1, uint32(exifOffset))
+	binary.Write(&tiff, le, uint32(0))
+
+	binary.Write(&tiff, le, uint16(exifEntries))
+	entry(exifTagDateTimeOriginal, 2, uint32(len(dateStr)), uint32(dateOff))
+	entry(exifTagPixelXDimension, 4, 1, 4000)
+	entry(exifTagPixelYDimension, 4, 1, 3000)
+	binary.Write(&tiff, le, uint32(0))
+	tiff.WriteString(makeStr + modelStr + dateStr)
+
+	var jpeg bytes.Buffer
+	jpeg.Write([]byte{0xFF, 0xD8, 0xFF, 0xE1})
+	binary.Write(&jpeg, binary.BigEndian, uint16(2+6+tiff.Len()))
+	jpeg.WriteString("Exif\x00\x00")
+	jpeg.Write(tiff.Bytes())
+	jpeg.Write([]byte{0xFF, 0xD9})
+	return jpeg.Bytes()
+}
+
+func TestParseMediaMetadataReadsJPEGEXIF(t *testing.T) {
+	meta := ParseMediaMetadata(MediaKindImage, buildTestEXIFJPEG())
+
+	if meta.CameraMake != "NMFCam" || meta.CameraModel != "Model-7" {
+		t.Fatalf("camera = %q %q, want NMFCam Model-7", meta.CameraMake, meta.CameraModel)
+	}
+	want := time.Date(2024, 5, 6, 7, 8, 9, 0, time.Local)
+	if !meta.DateTaken.Equal(want) {
+		t.Fatalf("DateTaken = %v, want %v", meta.DateTaken, want)
+	}
+	if meta.Width != 4000 || meta.Height != 3000 {
+		t.Fatalf("dimensions = %dx%d, want 4000x3000", meta.Width, meta.Height)
+	}
+	if got := meta.Summary(); got != "4000x3000 2024-05-06" {
+		t.Fatalf("Summary() = %q", got)
+	}
+}
+
+func TestParseMediaMetadataReadsID3v2Tags(t *testing.T) {
+	var buf bytes.Buffer
+	frame := func(id, text string) {
+		buf.WriteString(id)
+		binary.Write(&buf, binary.BigEndian, uint32(len(text)+1))
+		buf.Write([]byte{0, 0, 3})
+		buf.WriteString(text)
+	}
+	frame("TIT2", "Song")
+	frame("TPE1", "Artist")
+	frame("TALB", "Album")
+	frame("TLEN", "65000")
+	body := buf.Bytes()
+	size := len(body)
+	data := append([]byte{'I', 'D', '3', 3, 0, 0,
+		byte(size >> 21 & 0x7F), byte(size >> 14 & 0x7F), byte(size >> 7 & 0x7F), byte(size & 0x7F)}, body...)
+
+	meta := ParseMediaMetadata(MediaKindAudio, data)
+
+	if meta.Title != "Song" || meta.Artist != "Artist" || meta.Album != "Album" {
+		t.Fatalf("tags = %+v", meta)
+	}
+	if meta.Duration != 65*time.Second {
+		t.Fatalf("Duration = %v, want 65s", meta.Duration)
+	}
+}
+
+func TestParseMediaMetadataReadsWAVDuration(t *testing.T) {
+	var buf bytes.Buffer
+	le := binary.LittleEndian
+	buf.WriteString("RIFF")
+	binary.Write(&buf, le, uint32(0))
+	buf.WriteString("WAVEfmt ")
+	binary.Write(&buf, le, uint32(16))
+	binary.Write(&buf, le, uint16(1))     // PCM
+	binary.Write(&buf, le, uint16(2))     // channels
+	binary.Write(&buf, le, uint32(44100)) // sample rate
+	binary.Write(&buf, le, uint32(176400))
+	binary.Write(&buf, le, uint16(4))
+	binary.Write(&buf, le, uint16(16))
+	buf.WriteString("data")
+	binary.Write(&buf, le, uint32(176400*3))
+
+	meta := ParseMediaMetadata(MediaKindAudio, buf.Bytes())
+
+	if meta.Format != "WAV" || meta.Duration != 3*time.Second {
+		t.Fatalf("meta = %+v, want WAV 3s", meta)
+	}
+}
+
+func TestParseMediaMetadataReadsMP4DurationAndSize(t *testing.T) {
+	box := func(name string, body []byte) []byte {
+		out := make([]byte, 8, 8+len(body))
+		binary.BigEndian.PutUint32(out, uint32(8+len(body)))
+		copy(out[4:], name)
+		return append(out, body...)
+	}
+	mvhd := make([]byte, 100)
+	binary.BigEndian.PutUint32(mvhd[12:16], 1000)
+	binary.BigEndian.PutUint32(mvhd[16:20], 90500)
+	tkhd := make([]byte, 84)
+	binary.BigEndian.PutUint32(tkhd[76:80], 1920<<16)
+	binary.BigEndian.PutUint32(tkhd[80:84], 1080<<16)
+	data := append(box("ftyp", []byte("isom")), box("moov", append(box("mvhd", mvhd), box("trak", box("tkhd", tkhd))...))...)
+
+	meta := ParseMediaMetadata(MediaKindVideo, data)
+
+	if meta.Duration != 90500*time.Millisecond {
+		t.Fatalf("Duration = %v", meta.Duration)
+	}
+	if meta.Width != 1920 || meta.Height != 1080 {
+		t.Fatalf("dimensions = %dx%d", meta.Width, meta.Height)
+	}
+	if got := strings.Join(meta.Lines(), "\n"); !strings.Contains(got, "Duration: 1:31") {
+		t.Fatalf("Lines() = %q", got)
+	}
+}
+
+func TestReadMediaMetadataRejectsNonMediaFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(path, []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadMediaMetadata(context.Background(), path); err == nil {
+		t.Fatal("ReadMediaMetadata should reject non-media files")
+	}
+}
@@ -0,0 +1,290 @@
+package fileinfo
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// MetadataService parses media metadata lazily in the background and caches
+// results per path and modification time. It mirrors IconService: callers get
+// a cached value or (zero, false) immediately, and OnUpdated subscribers are
+// notified in 50ms batches once new results land.
+type MetadataService struct {
+	mu        sync.Mutex
+	cache     map[string]*list.Element // Path -> element of recent holding a *metadataEntry
+	recent    *list.List               // Cached entries, most recently used first
+	pending   map[string]struct{}
+	queue     []metadataJob // Requested parses, oldest first
+	wake      chan struct{} // Signalled when queue gains a job
+	done      chan struct{}
+	closeOnce sync.Once
+	read      func(context.Context, string) (MediaMetadata, error)
+
+	updMu       sync.Mutex
+	updatedAny  bool
+	subscribers []func()
+
+	debugPrint func(format string, args ...interface{})
+}
+
+type metadataEntry struct {
+	path     string
+	modified time.Time
+	meta     MediaMetadata
+	ok       bool
+}
+
+type metadataJob struct {
+	path     string
+	modified time.Time
+}
+
+// metadataCacheLimit caps cached entries; storing past it evicts the least
+// recently used one, so a folder larger than the cache keeps most of its
+// results instead of losing all of them at once.
+const metadataCacheLimit = 4096
+
+// NewMetadataService creates a metadata service with background workers.
+func NewMetadataService(debug func(format string, args ...interface{})) *MetadataService {
+	return newMetadataService(debug, ReadMediaMetadata)
+}
+
+func newMetadataService(debug func(format string, args ...interface{}), read func(context.Context, string) (MediaMetadata, error)) *MetadataService {
+	s := &MetadataService{
+		cache:      make(map[string]*list.Element, 256),
+		recent:     list.New(),
+		pending:    make(map[string]struct{}, 64),
+		wake:       make(chan struct{}, 1),
+		done:       make(chan struct{}),
+		read:       read,
+		debugPrint: debug,
+	}
+	for i := 0; i < 2; i++ {
+		go s.worker()
+	}
+	go s.batchNotifier()
+	return s
+}
+
+// OnUpdated registers a callback invoked after batches of parsed metadata.
+func (s *MetadataService) OnUpdated(f func()) {
+	if f == nil || s.closed() {
+		return
+	}
+	s.updMu.Lock()
+	defer s.updMu.Unlock()
+	if s.closed() {
+		return
+	}
+	s.subscribers = append(s.subscribers, f)
+}
+
+// GetCachedOrRequest returns cached metadata for a media file. On a miss it
+// queues a background parse and returns (zero, false). Non-media files and
+// files whose metadata could not be parsed also return false.
+func (s *MetadataService) GetCachedOrRequest(file FileInfo) (MediaMetadata, bool) {
+	meta, ok, found := s.cached(file)
+	if !found && s != nil && !file.IsDir && IsMediaFile(file.Name) {
+		s.enqueue(metadataJob{path: file.Path, modified: file.Modified})
+	}
+	return meta, ok
+}
+
+// GetCached returns cached metadata for a media file like GetCachedOrRequest
+// but never queues a parse, so callers that run because results arrived,
+// such as a re-sort, cannot create more work.
+func (s *MetadataService) GetCached(file FileInfo) (MediaMetadata, bool) {
+	meta, ok, _ := s.cached(file)
+	return meta, ok
+}
+
+// cached looks file up and marks a hit as recently used. found reports
+// whether the cache held a result for file's modification time.
+func (s *MetadataService) cached(file FileInfo) (meta MediaMetadata, ok, found bool) {
+	if s == nil || file.IsDir || !IsMediaFile(file.Name) {
+		return MediaMetadata{}, false, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	elem, hit := s.cache[file.Path]
+	if !hit {
+		return MediaMetadata{}, false, false
+	}
+	entry := elem.Value.(*metadataEntry)
+	if !entry.modified.Equal(file.Modified) {
+		return MediaMetadata{}, false, false
+	}
+	s.recent.MoveToFront(elem)
+	return entry.meta, entry.ok, true
+}
+
+// Lookup parses metadata synchronously, consulting and filling the cache.
+// Dialogs use it when the user explicitly asks for a file's properties.
+func (s *MetadataService) Lookup(ctx context.Context, file FileInfo) (MediaMetadata, bool) {
+	if s == nil || file.IsDir || !IsMediaFile(file.Name) {
+		return MediaMetadata{}, false
+	}
+	if meta, ok, found := s.cached(file); found {
+		return meta, ok
+	}
+	meta, err := s.read(ctx, file.Path)
+	if ctx != nil && ctx.Err() != nil {
+		return MediaMetadata{}, false
+	}
+	s.store(file.Path, file.Modified, meta, err)
+	return meta, err == nil
+}
+
+// Close stops workers and releases update callbacks.
+func (s *MetadataService) Close() {
+	if s == nil {
+		return
+	}
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.updMu.Lock()
+		s.updatedAny = false
+		s.subscribers = nil
+		s.updMu.Unlock()
+	})
+}
+
+func (s *MetadataService) closed() bool {
+	if s == nil {
+		return true
+	}
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *MetadataService) enqueue(job metadataJob) {
+	if s.closed() {
+		return
+	}
+	s.mu.Lock()
+	if _, exists := s.pending[job.path]; exists {
+		s.mu.Unlock()
+		return
+	}
+	s.pending[job.path] = struct{}{}
+	s.queue = append(s.queue, job)
+	s.mu.Unlock()
+	s.signal()
+}
+
+// signal wakes a worker without blocking; one pending wake-up is enough,
+// since a worker drains the queue before it waits again.
+func (s *MetadataService) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// nextJob takes the oldest queued job, passing the wake-up on while more
+// remain so the other worker helps.
+func (s *MetadataService) nextJob() (metadataJob, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.queue) == 0 {
+		return metadataJob{}, false
+	}
+	job := s.queue[0]
+	s.queue[0] = metadataJob{}
+	s.queue = s.queue[1:]
+	if len(s.queue) > 0 {
+		s.signal()
+	} else {
+		s.queue = nil
+	}
+	return job, true
+}
+
+func (s *MetadataService) clearPending(path string) {
+	s.mu.Lock()
+	delete(s.pending, path)
+	s.mu.Unlock()
+}
+
+func (s *MetadataService) worker() {
+	for {
+		job, ok := s.nextJob()
+		if !ok {
+			select {
+			case <-s.done:
+				return
+			case <-s.wake:
+			}
+			continue
+		}
+		if s.closed() {
+			return
+		}
+		meta, err := s.read(context.Background(), job.path)
+		if err != nil && s.debugPrint != nil {
+			s.debugPrint("MetadataService: parse failed path=%s err=%v", job.path, err)
+		}
+		if !s.closed() {
+			s.store(job.path, job.modified, meta, err)
+			s.flagUpdated()
+		}
+		s.clearPending(job.path)
+	}
+}
+
+func (s *MetadataService) store(path string, modified time.Time, meta MediaMetadata, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry := &metadataEntry{path: path, modified: modified, meta: meta, ok: err == nil}
+	if elem, found := s.cache[path]; found {
+		elem.Value = entry
+		s.recent.MoveToFront(elem)
+		return
+	}
+	s.cache[path] = s.recent.PushFront(entry)
+	for s.recent.Len() > metadataCacheLimit {
+		oldest := s.recent.Back()
+		s.recent.Remove(oldest)
+		delete(s.cache, oldest.Value.(*metadataEntry).path)
+	}
+}
+
+func (s *MetadataService) flagUpdated() {
+	if s.closed() {
+		return
+	}
+	s.updMu.Lock()
+	if !s.closed() {
+		s.updatedAny = true
+	}
+	s.updMu.Unlock()
+}
+
+func (s *MetadataService) batchNotifier() {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+		s.updMu.Lock()
+		if !s.updatedAny {
+			s.updMu.Unlock()
+			continue
+		}
+		s.updatedAny = false
+		subs := append([]func(){}, s.subscribers...)
+		s.updMu.Unlock()
+		for _, f := range subs {
+			f()
+		}
+	}
+}
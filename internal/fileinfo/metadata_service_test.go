@@ -0,0 +1,146 @@
+package fileinfo
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMetadataServiceCachesByModifiedTime(t *testing.T) {
+	var reads int32
+	service := newMetadataService(nil, func(context.Context, string) (MediaMetadata, error) {
+		atomic.AddInt32(&reads, 1)
+		return MediaMetadata{Kind: MediaKindImage, Width: 10, Height: 20}, nil
+	})
+	defer service.Close()
+	file := FileInfo{Name: "a.jpg", Path: "/p/a.jpg", Modified: time.Unix(100, 0)}
+
+	if meta, ok := service.Lookup(context.Background(), file); !ok || meta.Width != 10 {
+		t.Fatalf("Lookup = %+v, %t", meta, ok)
+	}
+	if _, ok := service.GetCachedOrRequest(file); !ok {
+		t.Fatal("GetCachedOrRequest should hit the cache after Lookup")
+	}
+	if got := atomic.LoadInt32(&reads); got != 1 {
+		t.Fatalf("reads = %d, want 1", got)
+	}
+
+	file.Modified = time.Unix(200, 0)
+	service.Lookup(context.Background(), file)
+	if got := atomic.LoadInt32(&reads); got != 2 {
+		t.Fatalf("reads after modification = %d, want 2", got)
+	}
+}
+
+func TestMetadataServiceRequestNotifiesSubscribers(t *testing.T) {
+	service := newMetadataService(nil, func(context.Context, string) (MediaMetadata, error) {
+		return MediaMetadata{Kind: MediaKindAudio, Duration: time.Second}, nil
+	})
+	defer service.Close()
+	updated := make(chan struct{}, 1)
+	service.OnUpdated(func() {
+		select {
+		case updated <- struct{}{}:
+		default:
+		}
+	})
+	file := FileInfo{Name: "a.mp3", Path: "/p/a.mp3"}
+
+	if _, ok := service.GetCachedOrRequest(file); ok {
+		t.Fatal("first request should miss the cache")
+	}
+	select {
+	case <-updated:
+	case <-time.After(2 * time.Second):
+		t.Fatal("metadata service did not notify subscribers")
+	}
+	if meta, ok := service.GetCachedOrRequest(file); !ok || meta.Duration != time.Second {
+		t.Fatalf("cached metadata = %+v, %t", meta, ok)
+	}
+}
+
+func TestMetadataServiceIgnoresNonMediaFiles(t *testing.T) {
+	service := NewMetadataService(nil)
+	defer service.Close()
+
+	if _, ok := service.GetCachedOrRequest(FileInfo{Name: "a.txt", Path: "/p/a.txt"}); ok {
+		t.Fatal("non-media file should not return metadata")
+	}
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	if len(service.pending) != 0 {
+		t.Fatalf("pending = %d, want 0", len(service.pending))
+	}
+}
+
+func TestMetadataServiceEvictsLeastRecentlyUsed(t *testing.T) {
+	service := newMetadataService(nil, func(context.Context, string) (MediaMetadata, error) {
+		return MediaMetadata{Kind: MediaKindImage}, nil
+	})
+	defer service.Close()
+	file := func(i int) FileInfo {
+		return FileInfo{Name: fmt.Sprintf("%d.jpg", i), Path: fmt.Sprintf("/p/%d.jpg", i)}
+	}
+	for i := range metadataCacheLimit {
+		service.Lookup(context.Background(), file(i))
+	}
+	// Using the oldest entry keeps it when the next one overflows the cache.
+	if _, ok := service.GetCached(file(0)); !ok {
+		t.Fatal("entry 0 missing before the cache was full")
+	}
+	service.Lookup(context.Background(), file(metadataCacheLimit))
+
+	if _, ok := service.GetCached(file(0)); !ok {
+		t.Fatal("recently used entry was evicted")
+	}
+	if _, ok := service.GetCached(file(1)); ok {
+		t.Fatal("least recently used entry survived")
+	}
+	if _, ok := service.GetCached(file(metadataCacheLimit - 1)); !ok {
+		t.Fatal("the rest of the cache was dropped along with the oldest entry")
+	}
+}
+
+func TestMetadataServiceGetCachedNeverQueues(t *testing.T) {
+	var reads int32
+	service := newMetadataService(nil, func(context.Context, string) (MediaMetadata, error) {
+		atomic.AddInt32(&reads, 1)
+		return MediaMetadata{Kind: MediaKindImage}, nil
+	})
+	defer service.Close()
+
+	if _, ok := service.GetCached(FileInfo{Name: "a.jpg", Path: "/p/a.jpg"}); ok {
+		t.Fatal("GetCached hit an empty cache")
+	}
+	time.Sleep(100 * time.Millisecond)
+	if got := atomic.LoadInt32(&reads); got != 0 {
+		t.Fatalf("reads = %d after GetCached, want 0", got)
+	}
+}
+
+func TestMetadataServiceQueuesEveryRequest(t *testing.T) {
+	release := make(chan struct{})
+	var reads int32
+	service := newMetadataService(nil, func(context.Context, string) (MediaMetadata, error) {
+		<-release
+		atomic.AddInt32(&reads, 1)
+		return MediaMetadata{Kind: MediaKindImage}, nil
+	})
+	defer service.Close()
+
+	// Far more requests than the workers take at once; none is dropped.
+	const n = 1000
+	for i := range n {
+		service.GetCachedOrRequest(FileInfo{Name: fmt.Sprintf("%d.jpg", i), Path: fmt.Sprintf("/p/%d.jpg", i)})
+	}
+	close(release)
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&reads) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("reads = %d, want %d", atomic.LoadInt32(&reads), n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
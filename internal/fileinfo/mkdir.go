@@ -9,7 +9,40 @@ import (
 // CreateDirectoryPortable creates a single directory inside parentPath.
 // It returns the resulting display path. Existing targets are rejected.
 func CreateDirectoryPortable(parentPath, name string) (string, error) {
-	dirName, err := ValidateRenameName(name)
+	return createEntryPortable(parentPath, name, func(ops SMBPathOps, native string) error {
+		if ops != nil {
+			return ops.Mkdir(native, 0755)
+		}
+		return os.Mkdir(native, 0755)
+	})
+}
+
+// CreateFilePortable creates a single empty file inside parentPath.
+// It returns the resulting display path. Existing targets are rejected, and
+// creation is exclusive so a racing writer is never truncated.
+func CreateFilePortable(parentPath, name string) (string, error) {
+	return createEntryPortable(parentPath, name, func(ops SMBPathOps, native string) error {
+		flag := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+		if ops != nil {
+			f, err := ops.OpenFile(native, flag, 0644)
+			if err != nil {
+				return err
+			}
+			return f.Close()
+		}
+		f, err := os.OpenFile(native, flag, 0644)
+		if err != nil {
+			return err
+		}
+		return f.Close()
+	})
+}
+
+// createEntryPortable validates name, rejects existing targets, and calls
+// create with the direct SMB operations (nil for local paths) and the native
+// path of the new entry.
+func createEntryPortable(parentPath, name string, create func(ops SMBPathOps, native string) error) (string, error) {
+	entryName, err := ValidateRenameName(name)
 	if err != nil {
 		return "", err
 	}
@@ -22,7 +55,7 @@ func CreateDirectoryPortable(parentPath, name string) (string, error) {
 		return "", fmt.Errorf("archive paths are read-only: %s", parentDisplay)
 	}
 
-	newDisplay := JoinPath(parentDisplay, dirName)
+	newDisplay := JoinPath(parentDisplay, entryName)
 	if _, err := StatPortable(newDisplay); err == nil {
 		return "", fmt.Errorf("target already exists: %s", newDisplay)
 	} else if !IsNotExist(err) {
@@ -39,18 +72,21 @@ func CreateDirectoryPortable(parentPath, name string) (string, error) {
 		newNative = newDisplay
 	}
 
+	if IsGioProviderPath(newParsed) {
+		return "", errGioReadOnly(newDisplay)
+	}
 	if newParsed.Scheme == SchemeSMB && newParsed.Provider != "local" {
 		ops, ok := vfs.(SMBPathOps)
 		if !ok {
 			return "", fmt.Errorf("direct SMB provider is unavailable: %s", newDisplay)
 		}
-		if err := ops.Mkdir(newNative, 0755); err != nil {
+		if err := create(ops, newNative); err != nil {
 			return "", err
 		}
 		return newDisplay, nil
 	}
 
-	if err := os.Mkdir(newNative, 0755); err != nil {
+	if err := create(nil, newNative); err != nil {
 		return "", err
 	}
 	if newParsed.Scheme == SchemeFile && !filepath.IsAbs(newDisplay) {
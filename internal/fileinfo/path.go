@@ -0,0 +1,138 @@
+package fileinfo
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Path is a canonical nmf display path together with its parsed parts. It
+// is the value to use for path arithmetic and comparison: Parent, Join, and
+// Base follow the rules of the path's scheme (local, smb://, archive, gio,
+// tag view), so callers never mix filepath.Dir with smb:// display paths.
+// Strings stay at the storage and widget boundaries; convert with ParsePath
+// and String.
+type Path struct {
+	Scheme   Scheme
+	Host     string   // SMB server
+	Share    string   // SMB share
+	Segments []string // SMB path below the share
+	Native   string   // path handed to the provider
+	display  string
+}
+
+// ParsePath canonicalizes input into a Path. It does not touch the network:
+// SMB and gio inputs are parsed lexically and local inputs made absolute.
+func ParsePath(input string) (Path, error) {
+	display, parsed, err := CanonicalDisplayPath(input)
+	if err != nil {
+		return Path{}, err
+	}
+	if parsed.Scheme == SchemeArchive && parsed.Display != "" {
+		display = parsed.Display
+	}
+	native := parsed.Native
+	if native == "" && parsed.Scheme != SchemeSMB && parsed.Scheme != SchemeArchive {
+		native = display
+	}
+	return Path{
+		Scheme:   parsed.Scheme,
+		Host:     parsed.Host,
+		Share:    parsed.Share,
+		Segments: parsed.Segments,
+		Native:   native,
+		display:  display,
+	}, nil
+}
+
+// CanonicalPath returns the canonical display form of p, or p unchanged when
+// it cannot be parsed.
+func CanonicalPath(p string) string {
+	parsed, err := ParsePath(p)
+	if err != nil {
+		return p
+	}
+	return parsed.String()
+}
+
+// String returns the display path, as shown in the path bar and stored in
+// history and bookmarks.
+func (p Path) String() string {
+	return p.display
+}
+
+// IsZero reports whether p is the zero Path.
+func (p Path) IsZero() bool {
+	return p.display == ""
+}
+
+// Base returns the last segment of p.
+func (p Path) Base() string {
+	return BaseName(p.display)
+}
+
+// Parent returns the directory containing p. A root is its own parent.
+func (p Path) Parent() Path {
+	parent := ParentPath(p.display)
+	if parent == p.display {
+		return p
+	}
+	out, err := ParsePath(parent)
+	if err != nil {
+		return p
+	}
+	return out
+}
+
+// IsRoot reports whether p has no parent: a filesystem root, an SMB share
+// root, or the root of a virtual view.
+func (p Path) IsRoot() bool {
+	return !p.IsZero() && ParentPath(p.display) == p.display
+}
+
+// Join returns the child name of p.
+func (p Path) Join(name string) Path {
+	out, err := ParsePath(JoinPath(p.display, name))
+	if err != nil {
+		return p
+	}
+	return out
+}
+
+// Equal reports whether p and q name the same location. SMB host, share, and
+// path compare case-insensitively, as do local paths on Windows.
+func (p Path) Equal(q Path) bool {
+	return p.Key() == q.Key()
+}
+
+// Key returns a string that is the same for paths that are Equal, for use as
+// a map key when deduplicating paths.
+func (p Path) Key() string {
+	s := p.display
+	if p.Scheme == SchemeSMB || IsSMBDisplay(s) {
+		return strings.ToLower(strings.TrimRight(strings.ReplaceAll(s, "\\", "/"), "/"))
+	}
+	if p.Scheme == SchemeFile || p.Scheme == "" {
+		s = filepath.Clean(s)
+		if runtime.GOOS == "windows" {
+			s = strings.ToLower(s)
+		}
+	}
+	return s
+}
+
+// SamePath reports whether the display paths a and b name the same location.
+// Empty or unparsable inputs never match anything but themselves.
+func SamePath(a, b string) bool {
+	a = strings.TrimSpace(a)
+	b = strings.TrimSpace(b)
+	if a == "" || b == "" {
+		return false
+	}
+	pa, errA := ParsePath(a)
+	pb, errB := ParsePath(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return pa.Equal(pb)
+}
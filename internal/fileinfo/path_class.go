@@ -5,6 +5,10 @@ package fileinfo
 type PathClass struct {
 	Network   bool
 	Removable bool
+	// Device marks phones and cameras reached through a device protocol
+	// such as MTP: listing is slow and changes made on the device raise no
+	// file system events.
+	Device bool
 }
 
 // ClassifyPath reports whether a path is known to be network-backed or
@@ -17,6 +21,9 @@ func ClassifyPath(p string) (PathClass, error) {
 	if parsed.Scheme == SchemeSMB {
 		return PathClass{Network: true}, nil
 	}
+	if loc, ok := parseGioURI(parsed.Display); ok {
+		return gioPathClass(loc.scheme), nil
+	}
 
 	native := parsed.Native
 	if native == "" {
@@ -30,7 +37,7 @@ func ClassifyPath(p string) (PathClass, error) {
 
 func isNetworkFilesystemType(fsType string) bool {
 	switch fsType {
-	case "9p", "afs", "cifs", "davfs", "fuse.sshfs", "ncpfs", "nfs", "nfs4", "smb3", "smbfs", "sshfs":
+	case "9p", "afpfs", "afs", "cifs", "davfs", "fuse.sshfs", "ncpfs", "nfs", "nfs4", "smb3", "smbfs", "sshfs", "webdav":
 		return true
 	default:
 		return false
@@ -0,0 +1,16 @@
+//go:build darwin
+// +build darwin
+
+package fileinfo
+
+import "golang.org/x/sys/unix"
+
+// statfsFilesystemType returns the filesystem type name of the mount
+// holding p, such as "smbfs" or "nfs".
+func statfsFilesystemType(p string) (string, bool) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(p, &st); err != nil {
+		return "", false
+	}
+	return unix.ByteSliceToString(st.Fstypename[:]), true
+}
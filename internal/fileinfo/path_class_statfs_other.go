@@ -0,0 +1,10 @@
+//go:build !windows && !darwin
+// +build !windows,!darwin
+
+package fileinfo
+
+// statfsFilesystemType has no portable source outside macOS; Linux reads
+// the type from /proc/self/mountinfo instead.
+func statfsFilesystemType(string) (string, bool) {
+	return "", false
+}
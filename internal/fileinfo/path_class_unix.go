@@ -27,14 +27,32 @@ func classifyLocalPath(p string) (PathClass, error) {
 
 	entry, ok := bestMountInfoEntry(abs, readProcSelfMountInfo)
 	if !ok {
+		if fsType, ok := statfsFilesystemType(abs); ok {
+			return PathClass{Network: isNetworkFilesystemType(fsType)}, nil
+		}
 		return PathClass{}, nil
 	}
+	if strings.HasPrefix(entry.fsType, "fuse.gvfsd") {
+		return gvfsFusePathClass(abs, filepath.Clean(entry.mountPoint)), nil
+	}
 	return PathClass{
 		Network:   isNetworkFilesystemType(entry.fsType),
 		Removable: isRemovableBlockDevice(entry.majorMinor),
 	}, nil
 }
 
+// gvfsFusePathClass classifies a path below the gvfsd-fuse root by the
+// backend named in its first component, such as "mtp:host=Pixel_7".
+func gvfsFusePathClass(p, mountPoint string) PathClass {
+	rel := strings.TrimPrefix(strings.TrimPrefix(p, mountPoint), "/")
+	first, _, _ := strings.Cut(rel, "/")
+	scheme, _, ok := strings.Cut(first, ":")
+	if !ok {
+		return PathClass{}
+	}
+	return gioPathClass(scheme)
+}
+
 func bestMountInfoEntry(p string, read func() ([]mountInfoEntry, error)) (mountInfoEntry, bool) {
 	entries, err := read()
 	if err != nil {
@@ -3,7 +3,12 @@
 
 package fileinfo
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
 
 func TestParseMountInfoLine(t *testing.T) {
 	line := "35 24 0:32 / /mnt/share rw,relatime - nfs4 server:/share rw"
@@ -49,3 +54,67 @@ func TestNetworkFilesystemTypes(t *testing.T) {
 		t.Fatal("ext4 should not be classified as network")
 	}
 }
+
+func TestGvfsFusePathClass(t *testing.T) {
+	root := "/run/user/1000/gvfs"
+	for _, tc := range []struct {
+		path string
+		want PathClass
+	}{
+		{root + "/mtp:host=Google_Pixel_7/Internal shared storage/DCIM", PathClass{Removable: true, Device: true}},
+		{root + "/gphoto2:host=Canon_EOS", PathClass{Removable: true, Device: true}},
+		{root + "/smb-share:server=nas,share=media/films", PathClass{Network: true}},
+		{root, PathClass{}},
+	} {
+		if got := gvfsFusePathClass(tc.path, root); got != tc.want {
+			t.Fatalf("gvfsFusePathClass(%q) = %+v, want %+v", tc.path, got, tc.want)
+		}
+	}
+}
+
+func TestGvfsFuseVolumesListMountedLocations(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"mtp:host=Google_Pixel_7_1A2B", "smb-share:server=nas,share=media", "sftp:host=%5B::1%5D"} {
+		if err := os.Mkdir(filepath.Join(root, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries := []mountInfoEntry{
+		{mountPoint: "/", fsType: "ext4"},
+		{mountPoint: root, fsType: "fuse.gvfsd-fuse"},
+	}
+	got := gvfsFuseVolumesFrom(entries)
+	want := []Volume{
+		{Name: "Google_Pixel_7_1A2B", Path: filepath.Join(root, "mtp:host=Google_Pixel_7_1A2B"), Kind: VolumeDevice},
+		{Name: "[::1]", Path: filepath.Join(root, "sftp:host=%5B::1%5D"), Kind: VolumeNetwork},
+		{Name: "nas/media", Path: filepath.Join(root, "smb-share:server=nas,share=media"), Kind: VolumeNetwork},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("gvfsFuseVolumesFrom = %+v, want %+v", got, want)
+	}
+}
+
+func TestVolumesFromMountInfo(t *testing.T) {
+	entries := []mountInfoEntry{
+		{mountPoint: "/", fsType: "ext4", majorMinor: "259:2"},
+		{mountPoint: "/proc", fsType: "proc", majorMinor: "0:22"},
+		{mountPoint: "/run/media/me/USB STICK", fsType: "vfat", majorMinor: "8:17"},
+		{mountPoint: "/mnt/data", fsType: "ext4", majorMinor: "259:5"},
+		{mountPoint: "/srv/nfs", fsType: "nfs4", majorMinor: "0:50"},
+		{mountPoint: "/run/user/1000/gvfs", fsType: "fuse.gvfsd-fuse", majorMinor: "0:60"},
+	}
+	got := volumesFromMountInfo(entries, func(majorMinor string) bool { return majorMinor == "8:17" })
+	want := []Volume{
+		{Name: "USB STICK", Path: "/run/media/me/USB STICK", Kind: VolumeRemovable},
+		{Name: "data", Path: "/mnt/data", Kind: VolumeLocal},
+		{Name: "nfs", Path: "/srv/nfs", Kind: VolumeNetwork},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("volumesFromMountInfo = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("volume %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -5,6 +5,7 @@ package fileinfo
 
 import (
 	"path/filepath"
+	"strings"
 
 	"golang.org/x/sys/windows"
 )
@@ -19,6 +20,11 @@ func classifyLocalPath(p string) (PathClass, error) {
 	if root == "" {
 		return PathClass{}, nil
 	}
+	if isUNCVolume(root) {
+		// \\server\share is always remote; asking the drive type would
+		// be one more round trip to the server.
+		return PathClass{Network: true}, nil
+	}
 	root += `\`
 	ptr, err := windows.UTF16PtrFromString(root)
 	if err != nil {
@@ -33,3 +39,12 @@ func classifyLocalPath(p string) (PathClass, error) {
 		return PathClass{}, nil
 	}
 }
+
+// isUNCVolume reports whether a volume name is a UNC share, either
+// \\server\share or the long form \\?\UNC\server\share.
+func isUNCVolume(volume string) bool {
+	if strings.HasPrefix(strings.ToUpper(volume), `\\?\UNC\`) {
+		return true
+	}
+	return strings.HasPrefix(volume, `\\`) && !strings.HasPrefix(volume, `\\?\`) && !strings.HasPrefix(volume, `\\.\`)
+}
@@ -15,9 +15,15 @@ func CanonicalDisplayPath(input string) (string, Parsed, error) {
 		return "", Parsed{}, fmt.Errorf("path is empty")
 	}
 
+	if IsTagViewPath(trimmed) {
+		return canonicalTagViewPath(input, trimmed)
+	}
 	if display, parsed, ok, err := canonicalArchiveDisplayPath(input, trimmed); ok {
 		return display, parsed, err
 	}
+	if display, parsed, ok := canonicalGioDisplayPath(input, trimmed); ok {
+		return display, parsed, nil
+	}
 
 	if isUNC(trimmed) {
 		parsed := parseUNC(trimmed)
@@ -68,6 +74,9 @@ func ResolvePathDisplay(input string) (string, Parsed, error) {
 		return "", Parsed{}, fmt.Errorf("path is empty")
 	}
 
+	if IsTagViewPath(trimmed) {
+		return canonicalTagViewPath(input, trimmed)
+	}
 	if display, parsed, ok, err := canonicalArchiveDisplayPath(input, trimmed); ok {
 		return display, parsed, err
 	}
@@ -79,7 +88,7 @@ func ResolvePathDisplay(input string) (string, Parsed, error) {
 	}
 	defer CloseVFS(vfs)
 
-	if parsed.Scheme == SchemeSMB {
+	if parsed.Scheme == SchemeSMB || parsed.Scheme == SchemeGio {
 		if parsed.Display != "" {
 			return parsed.Display, parsed, nil
 		}
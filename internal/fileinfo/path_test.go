@@ -0,0 +1,56 @@
+package fileinfo
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestParsePathSMBArithmetic(t *testing.T) {
+	p, err := ParsePath("smb://Server/share/docs/report.txt")
+	if err != nil {
+		t.Fatalf("ParsePath: %v", err)
+	}
+	if p.Scheme != SchemeSMB || p.Share != "share" || len(p.Segments) != 2 {
+		t.Fatalf("parsed = %+v, want SMB share with two segments", p)
+	}
+	if got := p.Base(); got != "report.txt" {
+		t.Fatalf("Base = %q, want report.txt", got)
+	}
+	parent := p.Parent()
+	if got := parent.String(); got != "smb://server/share/docs" {
+		t.Fatalf("Parent = %q, want smb://server/share/docs", got)
+	}
+	if got := parent.Join("other.txt").String(); got != "smb://server/share/docs/other.txt" {
+		t.Fatalf("Join = %q", got)
+	}
+	root := parent.Parent()
+	if !root.IsRoot() || !root.Parent().Equal(root) {
+		t.Fatalf("share root %q should be its own parent", root)
+	}
+	if parent.IsRoot() {
+		t.Fatal("directory below the share should not be a root")
+	}
+}
+
+func TestSamePathLocalCleanedPath(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	if !SamePath(filepath.Join(tmpDir, "."), tmpDir) {
+		t.Fatalf("expected cleaned local paths to match")
+	}
+	if SamePath(tmpDir, filepath.Join(tmpDir, "child")) {
+		t.Fatalf("parent and child should not match")
+	}
+}
+
+func TestSamePathSMBNormalizedPath(t *testing.T) {
+	if !SamePath("smb://Example.Local/share/path/", "smb://example.local/share/path") {
+		t.Fatalf("expected normalized SMB paths to match")
+	}
+}
+
+func TestSamePathEmptyDoesNotMatch(t *testing.T) {
+	if SamePath("", "") {
+		t.Fatalf("expected empty paths not to match")
+	}
+}
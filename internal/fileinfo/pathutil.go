@@ -15,9 +15,15 @@ func IsSMBDisplay(p string) bool {
 // - For smb:// display paths, it joins using forward slashes.
 // - Otherwise it uses filepath.Join.
 func JoinPath(base, name string) string {
+	if IsTagViewPath(base) {
+		return tagViewJoinPath(base, name)
+	}
 	if IsArchivePath(base) {
 		return archiveJoinPath(base, name)
 	}
+	if IsGioURI(base) {
+		return gioJoinPath(base, name)
+	}
 	if IsSMBDisplay(base) {
 		b := strings.TrimRight(base, "/")
 		return b + "/" + name
@@ -30,9 +36,15 @@ func JoinPath(base, name string) string {
 //     Root (smb://host/share) returns itself.
 //   - Otherwise it uses filepath.Dir.
 func ParentPath(p string) string {
+	if IsTagViewPath(p) {
+		return tagViewParentPath(p)
+	}
 	if IsArchivePath(p) {
 		return archiveParentPath(p)
 	}
+	if IsGioURI(p) {
+		return gioParentPath(p)
+	}
 	if !IsSMBDisplay(p) {
 		return filepath.Dir(p)
 	}
@@ -48,9 +60,15 @@ func ParentPath(p string) string {
 // BaseName returns the last path segment analogous to filepath.Base.
 // For smb:// paths, it uses URL-style segments.
 func BaseName(p string) string {
+	if IsTagViewPath(p) {
+		return tagViewBaseName(p)
+	}
 	if IsArchivePath(p) {
 		return archiveBaseName(p)
 	}
+	if IsGioURI(p) {
+		return gioBaseName(p)
+	}
 	if !IsSMBDisplay(p) {
 		return filepath.Base(p)
 	}
@@ -28,6 +28,7 @@ func ReadDirPortableContext(ctx context.Context, p string) ([]os.DirEntry, error
 	if err != nil {
 		return nil, err
 	}
+	vfs = wrapFaultVFS(vfs)
 	defer CloseVFS(vfs)
 	native := parsed.Native
 	if native == "" {
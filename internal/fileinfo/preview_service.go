@@ -0,0 +1,286 @@
+package fileinfo
+
+import (
+	"context"
+	"image"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	xdraw "golang.org/x/image/draw"
+)
+
+const (
+	// PreviewThumbnailEdge bounds the longer edge of pane thumbnails in pixels.
+	PreviewThumbnailEdge = 512
+	// PreviewExcerptLimit bounds the text excerpt kept for the preview pane.
+	PreviewExcerptLimit = 8 << 10
+	// previewHexExcerpt is how many bytes of a binary file are hex dumped.
+	previewHexExcerpt = 256
+	// previewCacheLimit caps cached pane previews; the oldest is evicted.
+	previewCacheLimit = 32
+)
+
+// PanePreview is the reduced form of a PreviewFile kept for the preview pane:
+// a thumbnail instead of the decoded image and a short text excerpt, so the
+// cache stays small however large the previewed files are.
+type PanePreview struct {
+	Thumbnail   image.Image
+	ImageFormat string
+	ImageWidth  int
+	ImageHeight int
+	Text        string
+	Encoding    string
+	Binary      bool
+	Truncated   bool
+	Err         string
+}
+
+// PreviewService loads pane previews in the background. It mirrors
+// MetadataService, except that only the most recent request matters: a new
+// request cancels a load still running for another file, so fast cursor
+// movement never queues work. OnUpdated subscribers are called from the
+// worker as soon as a preview lands.
+type PreviewService struct {
+	mu        sync.Mutex
+	cache     map[string]previewEntry
+	order     []string
+	want      *previewJob
+	running   *previewJob
+	cancel    context.CancelFunc
+	wake      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+	read      func(context.Context, string) (*PreviewFile, error)
+
+	updMu       sync.Mutex
+	subscribers []func()
+
+	debugPrint func(format string, args ...interface{})
+}
+
+type previewEntry struct {
+	modified time.Time
+	preview  PanePreview
+}
+
+type previewJob struct {
+	path     string
+	modified time.Time
+}
+
+// NewPreviewService creates a preview service with one background worker.
+func NewPreviewService(debug func(format string, args ...interface{})) *PreviewService {
+	return newPreviewService(debug, ReadPreviewFileContext)
+}
+
+func newPreviewService(debug func(format string, args ...interface{}), read func(context.Context, string) (*PreviewFile, error)) *PreviewService {
+	s := &PreviewService{
+		cache:      make(map[string]previewEntry, previewCacheLimit),
+		wake:       make(chan struct{}, 1),
+		done:       make(chan struct{}),
+		read:       read,
+		debugPrint: debug,
+	}
+	go s.worker()
+	return s
+}
+
+// OnUpdated registers a callback invoked after each loaded preview.
+func (s *PreviewService) OnUpdated(f func()) {
+	if f == nil || s.closed() {
+		return
+	}
+	s.updMu.Lock()
+	defer s.updMu.Unlock()
+	if s.closed() {
+		return
+	}
+	s.subscribers = append(s.subscribers, f)
+}
+
+// GetCachedOrRequest returns the cached preview for a file. On a miss it
+// makes the file the one to load next, canceling any other load, and
+// returns (zero, false). Directories never have previews.
+func (s *PreviewService) GetCachedOrRequest(file FileInfo) (PanePreview, bool) {
+	if s == nil || file.IsDir || s.closed() {
+		return PanePreview{}, false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if entry, ok := s.cache[file.Path]; ok && entry.modified.Equal(file.Modified) {
+		return entry.preview, true
+	}
+	job := &previewJob{path: file.Path, modified: file.Modified}
+	if s.running != nil && *s.running == *job {
+		s.want = nil
+		return PanePreview{}, false
+	}
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.want = job
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return PanePreview{}, false
+}
+
+// Close stops the worker and releases update callbacks.
+func (s *PreviewService) Close() {
+	if s == nil {
+		return
+	}
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.mu.Lock()
+		if s.cancel != nil {
+			s.cancel()
+		}
+		s.mu.Unlock()
+		s.updMu.Lock()
+		s.subscribers = nil
+		s.updMu.Unlock()
+	})
+}
+
+func (s *PreviewService) closed() bool {
+	if s == nil {
+		return true
+	}
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *PreviewService) worker() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.wake:
+		}
+		s.mu.Lock()
+		job := s.want
+		s.want = nil
+		if job == nil {
+			s.mu.Unlock()
+			continue
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		s.running = job
+		s.cancel = cancel
+		s.mu.Unlock()
+
+		preview, err := s.read(ctx, job.path)
+		canceled := ctx.Err() != nil
+		cancel()
+
+		s.mu.Lock()
+		s.running = nil
+		s.cancel = nil
+		if !canceled && !s.closed() {
+			s.storeLocked(job, reducePreview(preview, err))
+		}
+		s.mu.Unlock()
+		if canceled {
+			if s.debugPrint != nil {
+				s.debugPrint("PreviewService: load canceled path=%s", job.path)
+			}
+			continue
+		}
+		if err != nil && s.debugPrint != nil {
+			s.debugPrint("PreviewService: load failed path=%s err=%v", job.path, err)
+		}
+		s.notify()
+	}
+}
+
+func (s *PreviewService) storeLocked(job *previewJob, preview PanePreview) {
+	if _, exists := s.cache[job.path]; !exists {
+		if len(s.order) >= previewCacheLimit {
+			delete(s.cache, s.order[0])
+			s.order = s.order[1:]
+		}
+		s.order = append(s.order, job.path)
+	}
+	s.cache[job.path] = previewEntry{modified: job.modified, preview: preview}
+}
+
+func (s *PreviewService) notify() {
+	s.updMu.Lock()
+	subs := append([]func(){}, s.subscribers...)
+	s.updMu.Unlock()
+	for _, f := range subs {
+		f()
+	}
+}
+
+// reducePreview keeps what the pane shows of a loaded preview.
+func reducePreview(preview *PreviewFile, err error) PanePreview {
+	if err != nil {
+		return PanePreview{Err: err.Error()}
+	}
+	pane := PanePreview{
+		ImageFormat: preview.ImageFormat,
+		ImageWidth:  preview.ImageWidth,
+		ImageHeight: preview.ImageHeight,
+		Encoding:    preview.Encoding,
+		Binary:      preview.Binary,
+		Truncated:   preview.Truncated,
+		Err:         preview.ImageError,
+	}
+	switch {
+	case preview.Image != nil:
+		pane.Thumbnail = thumbnail(preview.Image, PreviewThumbnailEdge)
+	case preview.ImageFormat != "":
+	case preview.Binary:
+		data := preview.Data
+		if len(data) > previewHexExcerpt {
+			data = data[:previewHexExcerpt]
+		}
+		pane.Text = FormatHexDump(data)
+	default:
+		pane.Text = textExcerpt(preview.Text, PreviewExcerptLimit)
+		if len(pane.Text) < len(preview.Text) {
+			pane.Truncated = true
+		}
+	}
+	return pane
+}
+
+// thumbnail scales img down so its longer edge is at most edge pixels.
+// Smaller images are returned unchanged.
+func thumbnail(img image.Image, edge int) image.Image {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= edge && h <= edge {
+		return img
+	}
+	if w >= h {
+		h = max(1, h*edge/w)
+		w = edge
+	} else {
+		w = max(1, w*edge/h)
+		h = edge
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	xdraw.ApproxBiLinear.Scale(dst, dst.Bounds(), img, bounds, xdraw.Src, nil)
+	return dst
+}
+
+// textExcerpt cuts text to at most limit bytes without splitting a rune.
+func textExcerpt(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut]
+}
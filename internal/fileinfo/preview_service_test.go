@@ -0,0 +1,58 @@
+package fileinfo
+
+import (
+	"context"
+	"image"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPreviewServiceCancelsSupersededLoad(t *testing.T) {
+	started := make(chan string, 4)
+	service := newPreviewService(nil, func(ctx context.Context, path string) (*PreviewFile, error) {
+		started <- path
+		if path == "/p/slow.txt" {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return &PreviewFile{Text: "hello " + path}, nil
+	})
+	defer service.Close()
+	updated := make(chan struct{}, 4)
+	service.OnUpdated(func() { updated <- struct{}{} })
+
+	slow := FileInfo{Name: "slow.txt", Path: "/p/slow.txt"}
+	fast := FileInfo{Name: "fast.txt", Path: "/p/fast.txt"}
+	service.GetCachedOrRequest(slow)
+	if got := <-started; got != slow.Path {
+		t.Fatalf("first load = %s, want %s", got, slow.Path)
+	}
+	service.GetCachedOrRequest(fast)
+	select {
+	case <-updated:
+	case <-time.After(2 * time.Second):
+		t.Fatal("preview service did not notify subscribers")
+	}
+	if preview, ok := service.GetCachedOrRequest(fast); !ok || preview.Text != "hello /p/fast.txt" {
+		t.Fatalf("fast preview = %+v, %t", preview, ok)
+	}
+	service.mu.Lock()
+	_, slowCached := service.cache[slow.Path]
+	service.mu.Unlock()
+	if slowCached {
+		t.Fatal("canceled load should not be cached")
+	}
+}
+
+func TestReducePreviewKeepsThumbnailAndExcerpt(t *testing.T) {
+	img := reducePreview(&PreviewFile{Image: image.NewRGBA(image.Rect(0, 0, 2048, 1024)), ImageFormat: "png"}, nil)
+	if got := img.Thumbnail.Bounds(); got.Dx() != PreviewThumbnailEdge || got.Dy() != PreviewThumbnailEdge/2 {
+		t.Fatalf("thumbnail bounds = %v", got)
+	}
+
+	text := reducePreview(&PreviewFile{Text: strings.Repeat("é", PreviewExcerptLimit)}, nil)
+	if len(text.Text) > PreviewExcerptLimit || !text.Truncated || !strings.HasSuffix(text.Text, "é") {
+		t.Fatalf("excerpt len=%d truncated=%t", len(text.Text), text.Truncated)
+	}
+}
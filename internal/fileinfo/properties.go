@@ -0,0 +1,173 @@
+package fileinfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrPermissionsUnsupported is returned when permission bits cannot be
+// changed on a path's backend (SMB shares, archives, and tag views).
+var ErrPermissionsUnsupported = errors.New("permissions can only be changed on local files")
+
+// PathProperties holds what the Properties dialog shows beyond FileInfo.
+// Fields a platform or backend cannot provide are left zero.
+type PathProperties struct {
+	Mode       os.FileMode
+	Owner      string
+	Group      string
+	Accessed   time.Time
+	Changed    time.Time // inode/metadata change time
+	Created    time.Time
+	LinkTarget string
+	Xattrs     []string // extended attribute names, sorted
+	Local      bool     // permission bits can be changed with ChmodPortable
+}
+
+// AccessTime returns info's last access time, or its modified time where
+// the platform or backend does not report one.
+func AccessTime(info os.FileInfo) time.Time {
+	if t, ok := accessTime(info); ok {
+		return t
+	}
+	return info.ModTime()
+}
+
+// ReadPathProperties stats p without following links. Owner, extra
+// timestamps, and extended attributes are only read for local paths.
+func ReadPathProperties(p string) (PathProperties, error) {
+	native, local, err := localNativePath(p)
+	if err != nil {
+		return PathProperties{}, err
+	}
+	info, err := LstatPortable(p)
+	if err != nil {
+		return PathProperties{}, err
+	}
+	props := PathProperties{Mode: info.Mode(), Local: local}
+	if IsLinkModeCandidate(info.Mode()) {
+		if target, err := ReadlinkPortable(p); err == nil {
+			props.LinkTarget = target
+		}
+	}
+	if local {
+		readPlatformProperties(native, info, &props)
+	}
+	return props, nil
+}
+
+// ChmodPortable sets the permission bits of a local path. Links are changed
+// through to their targets, as chmod(1) does.
+func ChmodPortable(p string, perm os.FileMode) error {
+	native, local, err := localNativePath(p)
+	if err != nil {
+		return err
+	}
+	if !local {
+		return ErrPermissionsUnsupported
+	}
+	return os.Chmod(native, perm.Perm())
+}
+
+// ParsePermissionBits parses an octal permission string such as "755" or
+// "0644". Only the nine rwx bits are accepted.
+func ParsePermissionBits(s string) (os.FileMode, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New("permission bits are empty")
+	}
+	n, err := strconv.ParseUint(s, 8, 32)
+	if err != nil || n > 0o777 {
+		return 0, fmt.Errorf("invalid permission bits %q: use three octal digits such as 755", s)
+	}
+	return os.FileMode(n), nil
+}
+
+func localNativePath(p string) (string, bool, error) {
+	if IsTagViewPath(p) {
+		return "", false, ErrTagView
+	}
+	if IsArchivePath(p) {
+		return "", false, nil
+	}
+	vfs, parsed, err := ResolveRead(p)
+	if err != nil {
+		return "", false, err
+	}
+	CloseVFS(vfs)
+	native := parsed.Native
+	if native == "" {
+		native = p
+	}
+	return native, parsed.Provider == "local" || parsed.Scheme == SchemeFile, nil
+}
+
+// DirSize is the recursive content size of a directory.
+type DirSize struct {
+	Files      int
+	Dirs       int
+	Bytes      int64
+	Unreadable int // subdirectories that could not be listed
+}
+
+// DirectorySize adds up the regular files below p. Links are counted but not
+// followed, and unreadable subdirectories are skipped and counted; only a
+// failure to list p itself or cancellation returns an error.
+func DirectorySize(ctx context.Context, p string) (DirSize, error) {
+	return DirectorySizeUntil(ctx, p, nil)
+}
+
+// errSizeStopped ends a DirectorySizeUntil walk once stop reports true.
+var errSizeStopped = errors.New("directory size stopped")
+
+// DirectorySizeUntil is DirectorySize that checks stop after every entry it
+// counts and returns the partial size as soon as stop reports true, so a
+// caller that only needs to know whether a limit is passed does not walk the
+// rest of a large tree. A nil stop walks everything.
+func DirectorySizeUntil(ctx context.Context, p string, stop func(DirSize) bool) (DirSize, error) {
+	var size DirSize
+	if err := addDirectorySize(ctx, p, &size, stop, true); err != nil {
+		if errors.Is(err, errSizeStopped) {
+			return size, nil
+		}
+		return DirSize{}, err
+	}
+	return size, nil
+}
+
+func addDirectorySize(ctx context.Context, p string, size *DirSize, stop func(DirSize) bool, top bool) error {
+	entries, err := ReadDirPortableContext(ctx, p)
+	if err != nil {
+		if top || ctx.Err() != nil {
+			return err
+		}
+		size.Unreadable++
+		return nil
+	}
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if entry.IsDir() && entry.Type()&os.ModeSymlink == 0 {
+			size.Dirs++
+			if err := addDirectorySize(ctx, JoinPath(p, entry.Name()), size, stop, false); err != nil {
+				return err
+			}
+			continue
+		}
+		size.Files++
+		if entry.Type().IsRegular() {
+			if info, err := entry.Info(); err == nil {
+				size.Bytes += info.Size()
+			}
+		}
+		if stop != nil && stop(*size) {
+			return errSizeStopped
+		}
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+//go:build !linux && !darwin && !windows
+// +build !linux,!darwin,!windows
+
+package fileinfo
+
+import (
+	"os"
+	"time"
+)
+
+func readPlatformProperties(string, os.FileInfo, *PathProperties) {}
+
+func listingOwner(os.FileInfo) string { return "" }
+
+func accessTime(os.FileInfo) (time.Time, bool) { return time.Time{}, false }
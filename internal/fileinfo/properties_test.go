@@ -0,0 +1,105 @@
+package fileinfo
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+)
+
+func TestDirectorySizeCountsNestedFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "a", "b"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, size := range map[string]int{"top.txt": 10, "a/mid.txt": 20, "a/b/leaf.txt": 30} {
+		if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(name)), make([]byte, size), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	size, err := DirectorySize(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("DirectorySize: %v", err)
+	}
+	if size.Files != 3 || size.Dirs != 2 || size.Bytes != 60 {
+		t.Fatalf("size = %+v, want 3 files, 2 dirs, 60 bytes", size)
+	}
+}
+
+func TestDirectorySizeStopsWhenCancelled(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := DirectorySize(ctx, dir); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestDirectorySizeUntilStopsAtLimit(t *testing.T) {
+	dir := t.TempDir()
+	for i := range 10 {
+		if err := os.WriteFile(filepath.Join(dir, strconv.Itoa(i)), []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	size, err := DirectorySizeUntil(context.Background(), dir, func(s DirSize) bool { return s.Files >= 3 })
+	if err != nil {
+		t.Fatalf("DirectorySizeUntil: %v", err)
+	}
+	if size.Files != 3 || size.Bytes != 3 {
+		t.Fatalf("size = %+v, want the walk to stop at 3 files", size)
+	}
+}
+
+func TestParsePermissionBits(t *testing.T) {
+	for in, want := range map[string]os.FileMode{"755": 0o755, "0644": 0o644, " 7 ": 0o7} {
+		got, err := ParsePermissionBits(in)
+		if err != nil || got != want {
+			t.Fatalf("ParsePermissionBits(%q) = %o, %v; want %o", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "8", "1777", "rwx"} {
+		if _, err := ParsePermissionBits(in); err == nil {
+			t.Fatalf("ParsePermissionBits(%q) should fail", in)
+		}
+	}
+}
+
+func TestChmodPortableAndReadPathProperties(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("windows only keeps the read-only bit")
+	}
+	p := filepath.Join(t.TempDir(), "f.txt")
+	if err := os.WriteFile(p, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ChmodPortable(p, 0o600); err != nil {
+		t.Fatalf("ChmodPortable: %v", err)
+	}
+
+	props, err := ReadPathProperties(p)
+	if err != nil {
+		t.Fatalf("ReadPathProperties: %v", err)
+	}
+	if props.Mode.Perm() != 0o600 || !props.Local {
+		t.Fatalf("props = %+v, want local 0600", props)
+	}
+	if props.Owner == "" || props.Changed.IsZero() {
+		t.Fatalf("props = %+v, want owner and change time", props)
+	}
+}
+
+func TestChmodPortableRefusesSMB(t *testing.T) {
+	if err := ChmodPortable("smb://server/share/file.txt", 0o644); err == nil {
+		t.Fatal("ChmodPortable on an smb:// path should fail")
+	}
+}
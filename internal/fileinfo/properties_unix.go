@@ -0,0 +1,96 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package fileinfo
+
+import (
+	"bytes"
+	"os"
+	"os/user"
+	"sort"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+func readPlatformProperties(native string, info os.FileInfo, props *PathProperties) {
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		props.Owner = ownerName(st.Uid)
+		props.Group = groupName(st.Gid)
+		props.Accessed, props.Changed = statTimes(st)
+	}
+	props.Xattrs = listXattrs(native)
+}
+
+func accessTime(info os.FileInfo) (time.Time, bool) {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return time.Time{}, false
+	}
+	accessed, _ := statTimes(st)
+	return accessed, true
+}
+
+// listingOwner returns the user name shown in the owner column. Names are
+// looked up once per uid; listings of thousands of files share a handful.
+func listingOwner(info os.FileInfo) string {
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return ""
+	}
+	ownerNamesMu.Lock()
+	defer ownerNamesMu.Unlock()
+	if name, ok := ownerNames[st.Uid]; ok {
+		return name
+	}
+	name := strconv.FormatUint(uint64(st.Uid), 10)
+	if u, err := user.LookupId(name); err == nil {
+		name = u.Username
+	}
+	ownerNames[st.Uid] = name
+	return name
+}
+
+var (
+	ownerNamesMu sync.Mutex
+	ownerNames   = make(map[uint32]string)
+)
+
+func ownerName(uid uint32) string {
+	id := strconv.FormatUint(uint64(uid), 10)
+	if u, err := user.LookupId(id); err == nil {
+		return u.Username + " (" + id + ")"
+	}
+	return id
+}
+
+func groupName(gid uint32) string {
+	id := strconv.FormatUint(uint64(gid), 10)
+	if g, err := user.LookupGroupId(id); err == nil {
+		return g.Name + " (" + id + ")"
+	}
+	return id
+}
+
+func listXattrs(p string) []string {
+	n, err := unix.Llistxattr(p, nil)
+	if err != nil || n <= 0 {
+		return nil
+	}
+	buf := make([]byte, n)
+	n, err = unix.Llistxattr(p, buf)
+	if err != nil || n <= 0 {
+		return nil
+	}
+	var names []string
+	for _, name := range bytes.Split(buf[:n], []byte{0}) {
+		if len(name) > 0 {
+			names = append(names, string(name))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,24 @@
+package fileinfo
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+func listingOwner(os.FileInfo) string { return "" }
+
+func accessTime(info os.FileInfo) (time.Time, bool) {
+	data, ok := info.Sys().(*syscall.Win32FileAttributeData)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(0, data.LastAccessTime.Nanoseconds()), true
+}
+
+func readPlatformProperties(_ string, info os.FileInfo, props *PathProperties) {
+	if data, ok := info.Sys().(*syscall.Win32FileAttributeData); ok {
+		props.Accessed = time.Unix(0, data.LastAccessTime.Nanoseconds())
+		props.Created = time.Unix(0, data.CreationTime.Nanoseconds())
+	}
+}
@@ -0,0 +1,33 @@
+package fileinfo
+
+import "errors"
+
+// ErrQuickLookUnsupported is returned by QuickLook off macOS.
+var ErrQuickLookUnsupported = errors.New("Quick Look is only available on macOS")
+
+// QuickLook toggles the macOS Quick Look preview for p: it opens a preview,
+// or closes the one it opened earlier when it is still showing. Archive
+// entries are extracted to a temp file first, and smb:// paths use their
+// local mount.
+func QuickLook(p string) error {
+	if !QuickLookAvailable() {
+		return ErrQuickLookUnsupported
+	}
+	if quickLookClose() {
+		return nil
+	}
+	target := p
+	if IsArchivePath(p) {
+		tmpPath, err := ExtractArchiveEntryToTemp(p)
+		if err != nil {
+			return err
+		}
+		target = tmpPath
+	} else if vfs, parsed, err := ResolveRead(p); err == nil {
+		CloseVFS(vfs)
+		if parsed.Provider == "local" && parsed.Native != "" {
+			target = parsed.Native
+		}
+	}
+	return quickLookOpen(target)
+}
@@ -0,0 +1,50 @@
+package fileinfo
+
+import (
+	"os/exec"
+	"sync"
+)
+
+var quickLookState struct {
+	sync.Mutex
+	cmd *exec.Cmd
+}
+
+// QuickLookAvailable reports whether QuickLook can show a preview.
+func QuickLookAvailable() bool {
+	return true
+}
+
+// quickLookOpen shows p in a Quick Look panel through `qlmanage -p`, which
+// runs until the panel is closed.
+func quickLookOpen(p string) error {
+	cmd := exec.Command("/usr/bin/qlmanage", "-p", p)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	quickLookState.Lock()
+	quickLookState.cmd = cmd
+	quickLookState.Unlock()
+	go func() {
+		_ = cmd.Wait()
+		quickLookState.Lock()
+		if quickLookState.cmd == cmd {
+			quickLookState.cmd = nil
+		}
+		quickLookState.Unlock()
+	}()
+	return nil
+}
+
+// quickLookClose closes a panel opened by quickLookOpen and reports whether
+// one was still showing.
+func quickLookClose() bool {
+	quickLookState.Lock()
+	cmd := quickLookState.cmd
+	quickLookState.cmd = nil
+	quickLookState.Unlock()
+	if cmd == nil || cmd.Process == nil {
+		return false
+	}
+	return cmd.Process.Kill() == nil
+}
@@ -0,0 +1,16 @@
+//go:build !darwin
+
+package fileinfo
+
+// QuickLookAvailable reports whether QuickLook can show a preview.
+func QuickLookAvailable() bool {
+	return false
+}
+
+func quickLookOpen(string) error {
+	return ErrQuickLookUnsupported
+}
+
+func quickLookClose() bool {
+	return false
+}
@@ -0,0 +1,17 @@
+//go:build !darwin
+
+package fileinfo
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestQuickLookReturnsUnsupportedOffDarwin(t *testing.T) {
+	if QuickLookAvailable() {
+		t.Fatal("QuickLookAvailable() = true off macOS")
+	}
+	if err := QuickLook("/tmp/example.txt"); !errors.Is(err, ErrQuickLookUnsupported) {
+		t.Fatalf("QuickLook error = %v, want ErrQuickLookUnsupported", err)
+	}
+}
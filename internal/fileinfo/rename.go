@@ -74,6 +74,9 @@ func RenamePortable(oldPath, newName string) (string, error) {
 		return "", err
 	}
 
+	if IsGioProviderPath(oldParsed) {
+		return "", errGioReadOnly(oldDisplay)
+	}
 	if oldParsed.Scheme == SchemeSMB && oldParsed.Provider != "local" {
 		ops, ok := oldVFS.(SMBPathOps)
 		if !ok {
@@ -195,3 +195,33 @@ func TestCreateDirectoryPortableRejectsExistingTarget(t *testing.T) {
 		t.Fatal("CreateDirectoryPortable returned nil error for existing target")
 	}
 }
+
+func TestCreateFilePortable(t *testing.T) {
+	dir := t.TempDir()
+
+	newPath, err := CreateFilePortable(dir, "notes.txt")
+	if err != nil {
+		t.Fatalf("CreateFilePortable returned error: %v", err)
+	}
+	if newPath != filepath.Join(dir, "notes.txt") {
+		t.Fatalf("newPath = %q, want %q", newPath, filepath.Join(dir, "notes.txt"))
+	}
+	if info, err := os.Stat(newPath); err != nil || info.IsDir() || info.Size() != 0 {
+		t.Fatalf("created file missing or not empty: info=%v err=%v", info, err)
+	}
+}
+
+func TestCreateFilePortableRejectsExistingTarget(t *testing.T) {
+	dir := t.TempDir()
+	existingPath := filepath.Join(dir, "existing.txt")
+	if err := os.WriteFile(existingPath, []byte("keep"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := CreateFilePortable(dir, "existing.txt"); err == nil {
+		t.Fatal("CreateFilePortable returned nil error for existing target")
+	}
+	if got, err := os.ReadFile(existingPath); err != nil || string(got) != "keep" {
+		t.Fatalf("existing file changed: got %q err=%v", got, err)
+	}
+}
@@ -59,6 +59,9 @@ func ResolveReadContext(ctx context.Context, input string) (VFS, Parsed, error)
 	if err := ctx.Err(); err != nil {
 		return nil, Parsed{Raw: input, Display: raw}, err
 	}
+	if IsTagViewPath(raw) {
+		return nil, Parsed{Raw: input, Scheme: SchemeTag, Display: raw, Provider: "tag"}, ErrTagView
+	}
 
 	if archiveFile, inner, ok := SplitArchivePath(raw); ok {
 		vfs, err := NewArchiveVFSContext(ctx, archiveFile)
@@ -77,6 +80,10 @@ func ResolveReadContext(ctx context.Context, input string) (VFS, Parsed, error)
 		}, nil
 	}
 
+	if loc, ok := parseGioURI(raw); ok {
+		return resolveGio(ctx, input, loc)
+	}
+
 	// Windows: support UNC and smb://
 	if runtime.GOOS == "windows" {
 		if isUNC(raw) {
@@ -112,8 +119,9 @@ func ResolveReadContext(ctx context.Context, input string) (VFS, Parsed, error)
 		return LocalFS{}, Parsed{Raw: input, Scheme: SchemeFile, Display: input, Native: input, Provider: "local"}, nil
 	}
 
-	// Non-Windows: support smb:// (and //) via existing CIFS mounts if present.
-	// If no mount found, fall back to SMBFS provider (direct SMB access).
+	// Non-Windows: support smb:// (and //) via existing CIFS mounts if present,
+	// then via a GVFS mount made with the user's Kerberos ticket. If no mount
+	// is found, fall back to SMBFS provider (direct SMB access).
 	if isSMBURL(raw) || strings.HasPrefix(raw, "//") {
 		host, share, segs, user, pass, domain := parseSMBURL(raw)
 		if host != "" && share != "" {
@@ -122,7 +130,11 @@ func ResolveReadContext(ctx context.Context, input string) (VFS, Parsed, error)
 			if err != nil {
 				return nil, Parsed{Raw: input, Scheme: SchemeSMB, Host: host, Share: share, Provider: "smb"}, err
 			}
-			if mp, ok := findSMBMount(host, share); ok {
+			mp, ok := findSMBMount(host, share)
+			if !ok && user == "" && pass == "" && domain == "" {
+				mp, ok = integratedSMBMount(ctx, host, share)
+			}
+			if ok {
 				native, err := mountedSMBNativePath(mp, segs)
 				if err != nil {
 					return nil, Parsed{Raw: input, Scheme: SchemeSMB, Host: host, Share: share, Segments: segs, Provider: "local"}, err
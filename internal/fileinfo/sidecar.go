@@ -0,0 +1,81 @@
+package fileinfo
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// readSidecar returns the contents of the nmf sidecar file name inside dir,
+// reading at most limit bytes. A missing sidecar reads as "".
+func readSidecar(dir, name string, limit int64) (string, error) {
+	r, err := OpenPortable(JoinPath(dir, name))
+	if err != nil {
+		if IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	defer r.Close()
+	data, err := io.ReadAll(io.LimitReader(r, limit))
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// writeSidecar replaces the nmf sidecar file name inside dir with content,
+// over local paths and direct SMB alike. Empty content removes the sidecar.
+func writeSidecar(dir, name, content string) error {
+	dirDisplay, dirParsed, err := ResolvePathDisplay(dir)
+	if err != nil {
+		return err
+	}
+	if dirParsed.Scheme == SchemeArchive {
+		return fmt.Errorf("archive paths are read-only: %s", dirDisplay)
+	}
+
+	target := JoinPath(dirDisplay, name)
+	vfs, parsed, err := ResolveRead(target)
+	if err != nil {
+		return err
+	}
+	defer CloseVFS(vfs)
+	native := parsed.Native
+	if native == "" {
+		native = target
+	}
+
+	if IsGioProviderPath(parsed) {
+		return errGioReadOnly(target)
+	}
+	if parsed.Scheme == SchemeSMB && parsed.Provider != "local" {
+		ops, ok := vfs.(SMBPathOps)
+		if !ok {
+			return fmt.Errorf("direct SMB provider is unavailable: %s", target)
+		}
+		if content == "" {
+			if err := ops.Remove(native); err != nil && !IsNotExist(err) {
+				return err
+			}
+			return nil
+		}
+		f, err := ops.OpenFile(native, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, content); err != nil {
+			_ = f.Close()
+			return err
+		}
+		return f.Close()
+	}
+
+	if content == "" {
+		if err := os.Remove(native); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return os.WriteFile(native, []byte(content), 0644)
+}
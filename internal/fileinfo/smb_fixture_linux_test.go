@@ -0,0 +1,610 @@
+//go:build linux
+
+package fileinfo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hirochachacha/go-smb2"
+
+	"nmf/internal/secret"
+)
+
+// NTSTATUS codes the test server answers with, as a Windows or Samba server
+// would.
+const (
+	smbTestStatusLogonFailure      = 0xC000006D
+	smbTestStatusBadNetworkName    = 0xC00000CC
+	smbTestStatusNameCollision     = 0xC0000035
+	smbTestStatusDirectoryNotEmpty = 0xC0000101
+	smbTestStatusNotADirectory     = 0xC0000103
+	smbTestStatusFileIsADirectory  = 0xC00000BA
+	smbTestStatusNotAReparsePoint  = 0xC0000275
+	smbTestStatusSessionExpired    = 0xC000035C
+)
+
+// smbTestServer is an in-process stand-in for an SMB server. It holds share
+// trees in memory and checks logins; useSMBTestServer routes mountSMBShare
+// to it, so SMBFS, the resolver, and the credential flow run end to end
+// without a network. Failures come back the way go-smb2 reports them: an
+// *os.PathError around an *smb2.ResponseError carrying the NTSTATUS.
+type smbTestServer struct {
+	host string
+
+	mu       sync.Mutex
+	shares   map[string]map[string]*smbTestNode // share → share-relative path → node
+	accounts map[string]string                  // user → password; nil accepts anyone
+	logins   []Credentials                      // every mount attempt, in order
+	mounts   int                                // successful mounts
+	closes   int                                // mounts closed again
+
+	// onCall, when set, runs before each share operation and fails it by
+	// returning an error.
+	onCall func(share *smbTestShare, op, path string) error
+}
+
+type smbTestNode struct {
+	dir      bool
+	data     []byte
+	target   string // symlink target, share-relative
+	modified time.Time
+}
+
+// newSMBTestServer creates a server for host with one share per name. Each
+// share starts with the given entries: share-relative slash paths, where a
+// trailing slash makes a directory and anything else a file whose content
+// is its own path.
+func newSMBTestServer(host string, shares map[string][]string) *smbTestServer {
+	srv := &smbTestServer{host: host, shares: make(map[string]map[string]*smbTestNode)}
+	for name, entries := range shares {
+		tree := map[string]*smbTestNode{"": {dir: true, modified: smbTestTime}}
+		srv.shares[strings.ToLower(name)] = tree
+		for _, entry := range entries {
+			p := strings.Trim(entry, "/")
+			for dir := path.Dir(p); dir != "."; dir = path.Dir(dir) {
+				tree[dir] = &smbTestNode{dir: true, modified: smbTestTime}
+			}
+			if strings.HasSuffix(entry, "/") {
+				tree[p] = &smbTestNode{dir: true, modified: smbTestTime}
+			} else {
+				tree[p] = &smbTestNode{data: []byte(p), modified: smbTestTime}
+			}
+		}
+	}
+	return srv
+}
+
+var smbTestTime = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+// useSMBTestServer mounts every SMB share through srv until the test ends.
+// Hosts other than srv.host fail to dial. Mounts pooled by earlier tests are
+// dropped first, and the test's own when it ends.
+func useSMBTestServer(t *testing.T, srv *smbTestServer) {
+	t.Helper()
+	previous := mountSMBShare
+	smbPool.closeAll()
+	mountSMBShare = srv.mount
+	resetConnectionStates()
+	t.Cleanup(func() {
+		smbPool.closeAll()
+		mountSMBShare = previous
+		resetConnectionStates()
+	})
+}
+
+// resetConnectionStates forgets what earlier tests left in the global
+// connection state, which decides whether a rejected mount prompts or waits.
+func resetConnectionStates() {
+	connectionStates.mu.Lock()
+	defer connectionStates.mu.Unlock()
+	connectionStates.states = make(map[string]ConnectionState)
+	connectionStates.skipStored = make(map[string]bool)
+	connectionStates.waiters = make(map[string][]chan struct{})
+}
+
+// useSMBTestCredentials installs provider and store for the test and puts
+// the previous ones back afterwards.
+func useSMBTestCredentials(t *testing.T, provider CredentialsProvider, store *recordingSecretStore) {
+	t.Helper()
+	previousProvider, previousStore := currentCredentialsProvider(), currentSecretStore()
+	SetCredentialsProvider(provider)
+	if store != nil {
+		SetSecretStore(store)
+	} else {
+		SetSecretStore(nil)
+	}
+	t.Cleanup(func() {
+		SetCredentialsProvider(previousProvider)
+		SetSecretStore(previousStore)
+	})
+}
+
+func (srv *smbTestServer) mount(ctx context.Context, host, shareName string, creds Credentials) (smbShare, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(host, srv.host) {
+		return nil, &os.PathError{Op: "dial", Path: host, Err: errors.New("no such host")}
+	}
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.logins = append(srv.logins, creds)
+	if srv.accounts != nil {
+		if password, ok := srv.accounts[creds.Username]; !ok || password != creds.Password {
+			return nil, &smb2.ResponseError{Code: smbTestStatusLogonFailure}
+		}
+	}
+	tree, ok := srv.shares[strings.ToLower(shareName)]
+	if !ok {
+		return nil, &smb2.ResponseError{Code: smbTestStatusBadNetworkName}
+	}
+	srv.mounts++
+	return &smbTestShare{srv: srv, name: shareName, tree: tree}, nil
+}
+
+// counts returns the mount and close counters.
+func (srv *smbTestServer) counts() (mounts, closes int) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	return srv.mounts, srv.closes
+}
+
+// smbTestShare is one mount of a test server share.
+type smbTestShare struct {
+	srv    *smbTestServer
+	name   string
+	tree   map[string]*smbTestNode
+	closed bool
+}
+
+func smbTestError(op, p string, code uint32) error {
+	return &os.PathError{Op: op, Path: p, Err: &smb2.ResponseError{Code: code}}
+}
+
+// begin runs the onCall hook and then locks the server for the operation.
+func (s *smbTestShare) begin(op, p string) error {
+	if hook := s.srv.onCall; hook != nil {
+		if err := hook(s, op, p); err != nil {
+			return err
+		}
+	}
+	s.srv.mu.Lock()
+	if s.closed {
+		s.srv.mu.Unlock()
+		return &os.PathError{Op: op, Path: p, Err: os.ErrClosed}
+	}
+	return nil
+}
+
+func (s *smbTestShare) end() { s.srv.mu.Unlock() }
+
+func smbTestKey(p string) string {
+	p = strings.Trim(strings.ReplaceAll(p, "\\", "/"), "/")
+	if p == "." {
+		return ""
+	}
+	return path.Clean("/" + p)[1:]
+}
+
+// lookup returns the node at p, following symlinks when follow is set.
+func (s *smbTestShare) lookup(op, p string, follow bool) (string, *smbTestNode, error) {
+	key := smbTestKey(p)
+	for range 8 {
+		node, ok := s.tree[key]
+		if !ok {
+			return key, nil, smbTestError(op, p, smbStatusObjectNameMissing)
+		}
+		if node.target == "" || !follow {
+			return key, node, nil
+		}
+		key = smbTestKey(node.target)
+	}
+	return key, nil, smbTestError(op, p, smbStatusObjectNameMissing)
+}
+
+func (s *smbTestShare) parentDir(op, p, key string) error {
+	parent := path.Dir(key)
+	if parent == "." {
+		parent = ""
+	}
+	node, ok := s.tree[parent]
+	if !ok {
+		return smbTestError(op, p, smbStatusObjectPathMissing)
+	}
+	if !node.dir {
+		return smbTestError(op, p, smbTestStatusNotADirectory)
+	}
+	return nil
+}
+
+func (s *smbTestShare) ReadDir(p string) ([]os.FileInfo, error) {
+	if err := s.begin("readdir", p); err != nil {
+		return nil, err
+	}
+	defer s.end()
+	key, node, err := s.lookup("readdir", p, true)
+	if err != nil {
+		return nil, err
+	}
+	if !node.dir {
+		return nil, smbTestError("readdir", p, smbTestStatusNotADirectory)
+	}
+	var out []os.FileInfo
+	for child, node := range s.tree {
+		if child != "" && smbTestParent(child) == key {
+			out = append(out, smbTestInfo(child, node))
+		}
+	}
+	slices.SortFunc(out, func(a, b os.FileInfo) int { return strings.Compare(a.Name(), b.Name()) })
+	return out, nil
+}
+
+func smbTestParent(key string) string {
+	parent := path.Dir(key)
+	if parent == "." {
+		return ""
+	}
+	return parent
+}
+
+func (s *smbTestShare) Stat(p string) (os.FileInfo, error) {
+	return s.stat("stat", p, true)
+}
+
+func (s *smbTestShare) Lstat(p string) (os.FileInfo, error) {
+	return s.stat("lstat", p, false)
+}
+
+func (s *smbTestShare) stat(op, p string, follow bool) (os.FileInfo, error) {
+	if err := s.begin(op, p); err != nil {
+		return nil, err
+	}
+	defer s.end()
+	key, node, err := s.lookup(op, p, follow)
+	if err != nil {
+		return nil, err
+	}
+	if !follow {
+		key = smbTestKey(p)
+	}
+	return smbTestInfo(key, node), nil
+}
+
+func (s *smbTestShare) OpenFile(p string, flag int, perm os.FileMode) (smbFile, error) {
+	if err := s.begin("open", p); err != nil {
+		return nil, err
+	}
+	defer s.end()
+	key, node, err := s.lookup("open", p, true)
+	switch {
+	case err != nil && flag&os.O_CREATE == 0:
+		return nil, err
+	case err != nil:
+		if err := s.parentDir("open", p, key); err != nil {
+			return nil, err
+		}
+		node = &smbTestNode{modified: time.Now()}
+		s.tree[key] = node
+	case flag&os.O_CREATE != 0 && flag&os.O_EXCL != 0:
+		return nil, smbTestError("open", p, smbTestStatusNameCollision)
+	case node.dir:
+		return nil, smbTestError("open", p, smbTestStatusFileIsADirectory)
+	}
+	if flag&os.O_TRUNC != 0 {
+		node.data = nil
+	}
+	f := &smbTestFile{share: s, node: node}
+	if flag&os.O_APPEND != 0 {
+		f.pos = int64(len(node.data))
+	}
+	return f, nil
+}
+
+func (s *smbTestShare) Mkdir(p string, perm os.FileMode) error {
+	if err := s.begin("mkdir", p); err != nil {
+		return err
+	}
+	defer s.end()
+	key := smbTestKey(p)
+	if _, ok := s.tree[key]; ok {
+		return smbTestError("mkdir", p, smbTestStatusNameCollision)
+	}
+	if err := s.parentDir("mkdir", p, key); err != nil {
+		return err
+	}
+	s.tree[key] = &smbTestNode{dir: true, modified: time.Now()}
+	return nil
+}
+
+func (s *smbTestShare) MkdirAll(p string, perm os.FileMode) error {
+	key := smbTestKey(p)
+	if key == "" {
+		return nil
+	}
+	var dirs []string
+	for dir := key; dir != "."; dir = path.Dir(dir) {
+		dirs = append(dirs, dir)
+	}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		err := s.Mkdir(dirs[i], perm)
+		if err == nil {
+			continue
+		}
+		var responseErr *smb2.ResponseError
+		if !errors.As(err, &responseErr) || responseErr.Code != smbTestStatusNameCollision {
+			return err
+		}
+		if info, statErr := s.Stat(dirs[i]); statErr != nil || !info.IsDir() {
+			return smbTestError("mkdir", p, smbTestStatusNotADirectory)
+		}
+	}
+	return nil
+}
+
+func (s *smbTestShare) Chtimes(p string, atime, mtime time.Time) error {
+	if err := s.begin("chtimes", p); err != nil {
+		return err
+	}
+	defer s.end()
+	_, node, err := s.lookup("chtimes", p, true)
+	if err != nil {
+		return err
+	}
+	node.modified = mtime
+	return nil
+}
+
+func (s *smbTestShare) Remove(p string) error {
+	if err := s.begin("remove", p); err != nil {
+		return err
+	}
+	defer s.end()
+	key, node, err := s.lookup("remove", p, false)
+	if err != nil {
+		return err
+	}
+	if node.dir {
+		for child := range s.tree {
+			if child != "" && smbTestParent(child) == key {
+				return smbTestError("remove", p, smbTestStatusDirectoryNotEmpty)
+			}
+		}
+	}
+	delete(s.tree, key)
+	return nil
+}
+
+func (s *smbTestShare) Rename(oldpath, newpath string) error {
+	if err := s.begin("rename", oldpath); err != nil {
+		return err
+	}
+	defer s.end()
+	oldKey, _, err := s.lookup("rename", oldpath, false)
+	if err != nil {
+		return err
+	}
+	newKey := smbTestKey(newpath)
+	if _, ok := s.tree[newKey]; ok {
+		return smbTestError("rename", newpath, smbTestStatusNameCollision)
+	}
+	if err := s.parentDir("rename", newpath, newKey); err != nil {
+		return err
+	}
+	for key, node := range s.tree {
+		if key == oldKey || strings.HasPrefix(key, oldKey+"/") {
+			delete(s.tree, key)
+			s.tree[newKey+strings.TrimPrefix(key, oldKey)] = node
+		}
+	}
+	return nil
+}
+
+func (s *smbTestShare) Readlink(p string) (string, error) {
+	if err := s.begin("readlink", p); err != nil {
+		return "", err
+	}
+	defer s.end()
+	_, node, err := s.lookup("readlink", p, false)
+	if err != nil {
+		return "", err
+	}
+	if node.target == "" {
+		return "", smbTestError("readlink", p, smbTestStatusNotAReparsePoint)
+	}
+	return node.target, nil
+}
+
+func (s *smbTestShare) Symlink(target, linkpath string) error {
+	if err := s.begin("symlink", linkpath); err != nil {
+		return err
+	}
+	defer s.end()
+	key := smbTestKey(linkpath)
+	if _, ok := s.tree[key]; ok {
+		return smbTestError("symlink", linkpath, smbTestStatusNameCollision)
+	}
+	if err := s.parentDir("symlink", linkpath, key); err != nil {
+		return err
+	}
+	s.tree[key] = &smbTestNode{target: target, modified: time.Now()}
+	return nil
+}
+
+func (s *smbTestShare) Statfs(p string) (smb2.FileFsInfo, error) {
+	if err := s.begin("statfs", p); err != nil {
+		return nil, err
+	}
+	defer s.end()
+	if _, _, err := s.lookup("statfs", p, true); err != nil {
+		return nil, err
+	}
+	return smbTestFsInfo{}, nil
+}
+
+func (s *smbTestShare) Close() error {
+	s.srv.mu.Lock()
+	defer s.srv.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	s.srv.closes++
+	return nil
+}
+
+// smbTestFsInfo reports a 1 MiB share with 256 KiB available.
+type smbTestFsInfo struct{}
+
+func (smbTestFsInfo) BlockSize() uint64           { return 512 }
+func (smbTestFsInfo) FragmentSize() uint64        { return 8 }
+func (smbTestFsInfo) TotalBlockCount() uint64     { return 256 }
+func (smbTestFsInfo) FreeBlockCount() uint64      { return 64 }
+func (smbTestFsInfo) AvailableBlockCount() uint64 { return 64 }
+
+type smbTestFile struct {
+	share *smbTestShare
+	node  *smbTestNode
+	pos   int64
+}
+
+func (f *smbTestFile) Read(p []byte) (int, error) {
+	f.share.srv.mu.Lock()
+	defer f.share.srv.mu.Unlock()
+	if f.pos >= int64(len(f.node.data)) {
+		return 0, io.EOF
+	}
+	n := copy(p, f.node.data[f.pos:])
+	f.pos += int64(n)
+	return n, nil
+}
+
+func (f *smbTestFile) Write(p []byte) (int, error) {
+	f.share.srv.mu.Lock()
+	defer f.share.srv.mu.Unlock()
+	if end := f.pos + int64(len(p)); end > int64(len(f.node.data)) {
+		f.node.data = append(f.node.data, make([]byte, end-int64(len(f.node.data)))...)
+	}
+	copy(f.node.data[f.pos:], p)
+	f.pos += int64(len(p))
+	f.node.modified = time.Now()
+	return len(p), nil
+}
+
+func (f *smbTestFile) Seek(offset int64, whence int) (int64, error) {
+	f.share.srv.mu.Lock()
+	defer f.share.srv.mu.Unlock()
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.pos
+	case io.SeekEnd:
+		offset += int64(len(f.node.data))
+	}
+	if offset < 0 {
+		return 0, errors.New("negative seek offset")
+	}
+	f.pos = offset
+	return offset, nil
+}
+
+func (f *smbTestFile) Close() error { return nil }
+
+// smbTestFileInfo is what the test server reports for an entry.
+type smbTestFileInfo struct {
+	name string
+	node smbTestNode
+}
+
+func smbTestInfo(key string, node *smbTestNode) os.FileInfo {
+	name := path.Base(key)
+	if key == "" {
+		name = "."
+	}
+	return smbTestFileInfo{name: name, node: *node}
+}
+
+func (fi smbTestFileInfo) Name() string       { return fi.name }
+func (fi smbTestFileInfo) Size() int64        { return int64(len(fi.node.data)) }
+func (fi smbTestFileInfo) ModTime() time.Time { return fi.node.modified }
+func (fi smbTestFileInfo) IsDir() bool        { return fi.node.dir }
+func (fi smbTestFileInfo) Sys() any           { return nil }
+
+func (fi smbTestFileInfo) Mode() os.FileMode {
+	switch {
+	case fi.node.target != "":
+		return os.ModeSymlink | 0o777
+	case fi.node.dir:
+		return os.ModeDir | 0o755
+	}
+	return 0o644
+}
+
+// recordingSecretStore is an in-memory keyring that records what SMBFS
+// saves to it.
+type recordingSecretStore struct {
+	mu      sync.Mutex
+	entries map[string]Credentials
+}
+
+func (s *recordingSecretStore) Get(host, share string) (string, string, string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.entries[host+"\x00"+share]
+	return c.Domain, c.Username, c.Password, ok, nil
+}
+
+func (s *recordingSecretStore) Set(host, share, domain, user, password string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.entries == nil {
+		s.entries = make(map[string]Credentials)
+	}
+	s.entries[host+"\x00"+share] = Credentials{Domain: domain, Username: user, Password: password}
+	return nil
+}
+
+func (s *recordingSecretStore) Delete(host, share string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, host+"\x00"+share)
+	return nil
+}
+
+func (s *recordingSecretStore) List() ([]secret.Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := make([]secret.Entry, 0, len(s.entries))
+	for key, c := range s.entries {
+		host, share, _ := strings.Cut(key, "\x00")
+		entries = append(entries, secret.Entry{Host: host, Share: share, Domain: c.Domain, User: c.Username})
+	}
+	return entries, nil
+}
+
+// scriptedCredentialsProvider answers prompts from a fixed list, as a user
+// typing into the credentials dialog would, and counts the prompts.
+type scriptedCredentialsProvider struct {
+	mu      sync.Mutex
+	answers []Credentials
+	prompts int
+}
+
+func (p *scriptedCredentialsProvider) Get(context.Context, string, string, string) (Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prompts++
+	if len(p.answers) == 0 {
+		return Credentials{}, errors.New("credentials prompt canceled")
+	}
+	c := p.answers[0]
+	p.answers = p.answers[1:]
+	return c, nil
+}
@@ -0,0 +1,107 @@
+//go:build linux
+// +build linux
+
+package fileinfo
+
+import (
+	"context"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+const (
+	gioMountTimeout        = 30 * time.Second
+	kerberosTicketCacheTTL = 30 * time.Second
+	kerberosMountRetry     = 5 * time.Minute
+)
+
+// kerberosTicketValid reports whether the user holds an unexpired Kerberos
+// ticket, as after logging in to a domain-joined desktop or running kinit.
+// Tests replace it.
+var kerberosTicketValid = func(ctx context.Context) bool {
+	if _, err := exec.LookPath("klist"); err != nil {
+		return false
+	}
+	return exec.CommandContext(ctx, "klist", "-s").Run() == nil
+}
+
+// kerberosSMB remembers the last ticket check and the shares whose mount
+// with the ticket failed, so browsing does not run klist or gio mount for
+// every path resolved.
+var kerberosSMB = struct {
+	sync.Mutex
+	checked time.Time
+	valid   bool
+	failed  map[string]time.Time
+}{failed: map[string]time.Time{}}
+
+func hasKerberosTicket(ctx context.Context) bool {
+	kerberosSMB.Lock()
+	if time.Since(kerberosSMB.checked) < kerberosTicketCacheTTL {
+		valid := kerberosSMB.valid
+		kerberosSMB.Unlock()
+		return valid
+	}
+	kerberosSMB.Unlock()
+	valid := kerberosTicketValid(ctx)
+	kerberosSMB.Lock()
+	kerberosSMB.checked = time.Now()
+	kerberosSMB.valid = valid
+	kerberosSMB.Unlock()
+	return valid
+}
+
+// integratedSMBMount returns a local directory exposing host/share mounted
+// with the user's Kerberos ticket. GVFS mounts the share through
+// libsmbclient, which authenticates with the ticket and needs no password;
+// the direct SMB provider only speaks NTLM. The share is mounted with
+// `gio mount` when GVFS has not mounted it yet, and exposed through
+// gvfsd-fuse. Without a ticket, or when the server refuses it, the caller
+// falls back to the direct SMB provider and its credentials prompt; a
+// refused share is not tried again for a few minutes or until Reconnect.
+func integratedSMBMount(ctx context.Context, host, share string) (string, bool) {
+	if !SMBKerberosEnabled() || !GioEnabled() || gioAvailable() != nil {
+		return "", false
+	}
+	if !hasKerberosTicket(ctx) {
+		return "", false
+	}
+	key := gioLocation{scheme: "smb", authority: host, segments: []string{share}}.uri() + "/"
+	if root, ok := gioFuseRootURI(ctx, key); ok {
+		return root, true
+	}
+	remote := RemoteShare{Host: host, Share: share}.key()
+	kerberosSMB.Lock()
+	failed, ok := kerberosSMB.failed[remote]
+	kerberosSMB.Unlock()
+	if ok && time.Since(failed) < kerberosMountRetry {
+		return "", false
+	}
+
+	// gio mount reads a password from stdin when the ticket is refused;
+	// stdin is empty, so the mount fails instead of waiting.
+	mountCtx, cancel := context.WithTimeout(ctx, gioMountTimeout)
+	defer cancel()
+	if _, err := runGio(mountCtx, "mount", "--", key); err != nil {
+		if ctx.Err() == nil {
+			kerberosSMB.Lock()
+			kerberosSMB.failed[remote] = time.Now()
+			kerberosSMB.Unlock()
+		}
+		return "", false
+	}
+	gioFuseRoots.Lock()
+	delete(gioFuseRoots.missed, key)
+	gioFuseRoots.Unlock()
+	return gioFuseRootURI(ctx, key)
+}
+
+// forgetIntegratedSMBFailure lets the next access to share try its Kerberos
+// mount again, as after the user renewed the ticket.
+func forgetIntegratedSMBFailure(share RemoteShare) {
+	kerberosSMB.Lock()
+	defer kerberosSMB.Unlock()
+	delete(kerberosSMB.failed, share.key())
+	kerberosSMB.checked = time.Time{}
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+// +build !linux
+
+package fileinfo
+
+import "context"
+
+// integratedSMBMount has no GVFS to mount through outside Linux. Windows
+// reaches shares through UNC paths, where the OS signs in with the user's
+// domain credentials.
+func integratedSMBMount(context.Context, string, string) (string, bool) { return "", false }
+
+func forgetIntegratedSMBFailure(RemoteShare) {}
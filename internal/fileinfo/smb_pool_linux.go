@@ -0,0 +1,220 @@
+//go:build linux
+// +build linux
+
+package fileinfo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// smbPoolIdleTimeout is how long a pooled share stays mounted without use.
+// It is well below the idle disconnect of common servers (15 minutes on
+// Windows), so a pooled session rarely finds itself dropped.
+const smbPoolIdleTimeout = time.Minute
+
+// smbPool keeps one mounted share per host, share, and explicit credentials
+// between SMBFS calls, so browsing does not dial, authenticate, and mount
+// for every ReadDir or Stat. Calls share the mount concurrently; it is
+// unmounted once it has been idle for smbPoolIdleTimeout.
+var smbPool = &smbSharePool{entries: make(map[string]*smbPoolEntry)}
+
+type smbSharePool struct {
+	mu      sync.Mutex
+	entries map[string]*smbPoolEntry
+}
+
+// smbPoolEntry is one pooled mount. users counts calls and open files using
+// it; a discarded entry is out of the pool and closes with its last user.
+type smbPoolEntry struct {
+	key       string
+	remote    RemoteShare
+	share     smbShare
+	users     int
+	idle      *time.Timer
+	discarded bool
+}
+
+// poolKey separates mounts made with URL credentials from those made with
+// the credentials provider's, which may differ per user.
+func (s SMBFS) poolKey() string {
+	key := RemoteShare{Host: s.host, Share: s.share}.key()
+	if s.cred != nil {
+		key += "\x00" + s.cred.Domain + "\x00" + s.cred.Username + "\x00" + s.cred.Password
+	}
+	return key
+}
+
+// acquire returns the pooled mount for s, mounting the share when there is
+// none. The caller releases it when done.
+func (p *smbSharePool) acquire(ctx context.Context, s SMBFS, relPath string) (*smbPoolEntry, error) {
+	key := s.poolKey()
+	if e := p.use(key); e != nil {
+		return e, nil
+	}
+	share, _, err := s.dialAndMountContext(ctx, relPath)
+	if err != nil {
+		return nil, err
+	}
+	if e := p.use(key); e != nil {
+		// Another call mounted the share meanwhile.
+		_ = share.Close()
+		return e, nil
+	}
+	e := &smbPoolEntry{key: key, remote: RemoteShare{Host: s.host, Share: s.share}, share: share, users: 1}
+	p.mu.Lock()
+	p.entries[key] = e
+	p.mu.Unlock()
+	return e, nil
+}
+
+func (p *smbSharePool) use(key string) *smbPoolEntry {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	e := p.entries[key]
+	if e == nil {
+		return nil
+	}
+	e.users++
+	if e.idle != nil {
+		e.idle.Stop()
+		e.idle = nil
+	}
+	return e
+}
+
+// release ends one use of e and starts its idle timer when it was the last.
+func (p *smbSharePool) release(e *smbPoolEntry) {
+	p.mu.Lock()
+	e.users--
+	if e.users > 0 {
+		p.mu.Unlock()
+		return
+	}
+	if e.discarded {
+		p.mu.Unlock()
+		_ = e.share.Close()
+		return
+	}
+	e.idle = time.AfterFunc(smbPoolIdleTimeout, func() { p.expire(e) })
+	p.mu.Unlock()
+}
+
+func (p *smbSharePool) expire(e *smbPoolEntry) {
+	p.mu.Lock()
+	if e.users > 0 || p.entries[e.key] != e {
+		p.mu.Unlock()
+		return
+	}
+	delete(p.entries, e.key)
+	e.discarded = true
+	p.mu.Unlock()
+	_ = e.share.Close()
+}
+
+// discard takes e out of the pool so the next call mounts afresh.
+func (p *smbSharePool) discard(e *smbPoolEntry) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.entries[e.key] == e {
+		delete(p.entries, e.key)
+	}
+	e.discarded = true
+}
+
+// closeShare discards every pooled mount of remote, whatever credentials
+// opened it; idle ones are unmounted now, busy ones when their calls end.
+func (p *smbSharePool) closeShare(remote RemoteShare) {
+	key := remote.key()
+	var idle []*smbPoolEntry
+	p.mu.Lock()
+	for k, e := range p.entries {
+		if e.remote.key() != key {
+			continue
+		}
+		delete(p.entries, k)
+		e.discarded = true
+		if e.users == 0 {
+			if e.idle != nil {
+				e.idle.Stop()
+			}
+			idle = append(idle, e)
+		}
+	}
+	p.mu.Unlock()
+	for _, e := range idle {
+		_ = e.share.Close()
+	}
+}
+
+// closeAll unmounts every idle pooled share and discards the busy ones.
+func (p *smbSharePool) closeAll() {
+	p.mu.Lock()
+	remotes := make([]RemoteShare, 0, len(p.entries))
+	for _, e := range p.entries {
+		remotes = append(remotes, e.remote)
+	}
+	p.mu.Unlock()
+	for _, remote := range remotes {
+		p.closeShare(remote)
+	}
+}
+
+func closePooledSMBShare(remote RemoteShare) { smbPool.closeShare(remote) }
+
+// withShare runs op on the pooled mount of s. When the server dropped the
+// session, or a read finds the pooled connection broken, as after a server
+// restart, the mount is discarded and op runs once more on a fresh one.
+// Writes are not retried after a broken connection, since they may have
+// reached the server.
+func (s SMBFS) withShare(ctx context.Context, relPath string, readOnly bool, op func(smbShare) error) error {
+	return s.withEntry(ctx, relPath, readOnly, func(e *smbPoolEntry) (bool, error) {
+		return false, op(e.share)
+	})
+}
+
+// withEntry is withShare for operations that keep the mount in use after
+// they return, as an open file does; op reports whether it did so, and
+// releases the entry itself later.
+func (s SMBFS) withEntry(ctx context.Context, relPath string, readOnly bool, op func(*smbPoolEntry) (bool, error)) error {
+	for attempt := 0; ; attempt++ {
+		e, err := smbPool.acquire(ctx, s, relPath)
+		if err != nil {
+			return err
+		}
+		held, err := op(e)
+		if held {
+			return nil
+		}
+		broken := isBrokenConnectionError(err)
+		retry := attempt == 0 && ctx.Err() == nil && (isSessionExpiredError(err) || readOnly && broken)
+		if retry || broken || isAuthError(err) {
+			smbPool.discard(e)
+		}
+		smbPool.release(e)
+		if isAuthError(err) {
+			ClearCachedCredentials(s.host, s.share)
+		}
+		if !retry {
+			return err
+		}
+	}
+}
+
+// isBrokenConnectionError reports whether err means the connection under a
+// mount is gone rather than that the operation failed on the server.
+func isBrokenConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if isBenignNetworkCloseError(err) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	return strings.Contains(strings.ToLower(err.Error()), "connection reset")
+}
@@ -9,3 +9,6 @@ package fileinfo
 func newSMBProvider(host, share string, c *Credentials) (VFS, error) {
 	return nil, errUnsupportedSMB()
 }
+
+// closePooledSMBShare has no pooled mounts to drop without direct SMB.
+func closePooledSMBShare(RemoteShare) {}
@@ -0,0 +1,92 @@
+//go:build linux
+
+package fileinfo
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	"github.com/hirochachacha/go-smb2"
+)
+
+// smbShare is one mounted SMB share: the go-smb2 share calls SMBFS makes,
+// plus Close, which unmounts, logs off, and closes the connection.
+type smbShare interface {
+	ReadDir(path string) ([]os.FileInfo, error)
+	Stat(path string) (os.FileInfo, error)
+	Lstat(path string) (os.FileInfo, error)
+	OpenFile(path string, flag int, perm os.FileMode) (smbFile, error)
+	Mkdir(path string, perm os.FileMode) error
+	MkdirAll(path string, perm os.FileMode) error
+	Chtimes(path string, atime, mtime time.Time) error
+	Remove(path string) error
+	Rename(oldpath, newpath string) error
+	Readlink(path string) (string, error)
+	Symlink(target, linkpath string) error
+	Statfs(path string) (smb2.FileFsInfo, error)
+	Close() error
+}
+
+// smbFile is an open file on an smbShare.
+type smbFile interface {
+	io.ReadWriteSeeker
+	io.Closer
+}
+
+// mountSMBShare connects to host, authenticates with creds, and mounts
+// shareName. It dials the server with go-smb2; provider tests replace it
+// with an in-memory server (smb_fixture_linux_test.go).
+var mountSMBShare = dialSMBShare
+
+func dialSMBShare(ctx context.Context, host, shareName string, creds Credentials) (smbShare, error) {
+	d := &smb2.Dialer{
+		Initiator: &smb2.NTLMInitiator{
+			User:     creds.Username,
+			Password: creds.Password,
+			Domain:   creds.Domain,
+		},
+	}
+
+	// TCP keep-alives let a pooled mount notice a vanished server while idle.
+	dialer := net.Dialer{Timeout: 5 * time.Second, KeepAlive: 30 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, "445"))
+	if err != nil {
+		return nil, err
+	}
+
+	sess, err := d.DialContext(ctx, conn)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	share, err := sess.Mount(shareName)
+	if err != nil {
+		_ = sess.Logoff()
+		_ = conn.Close()
+		return nil, err
+	}
+	return &smb2Share{Share: share, sess: sess, conn: conn}, nil
+}
+
+// smb2Share is an smbShare mounted by go-smb2.
+type smb2Share struct {
+	*smb2.Share
+	sess *smb2.Session
+	conn net.Conn
+}
+
+func (s *smb2Share) OpenFile(path string, flag int, perm os.FileMode) (smbFile, error) {
+	f, err := s.Share.OpenFile(path, flag, perm)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (s *smb2Share) Close() error {
+	return closeSMBSession(nil, s.Share, s.sess, s.conn)
+}
@@ -11,6 +11,7 @@ import (
 	"net"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hirochachacha/go-smb2"
@@ -30,33 +31,36 @@ func NewSMBFSWithCred(host, share string, c Credentials) SMBFS {
 
 func (SMBFS) Capabilities() Capabilities { return Capabilities{FastList: false, Watch: false} }
 
+// smbReadWriteFile keeps its pooled mount in use until it is closed.
 type smbReadWriteFile struct {
-	file  *smb2.File
-	share *smb2.Share
-	sess  *smb2.Session
-	conn  net.Conn
+	file  smbFile
+	entry *smbPoolEntry
 }
 
 func (f *smbReadWriteFile) Read(p []byte) (int, error)  { return f.file.Read(p) }
 func (f *smbReadWriteFile) Write(p []byte) (int, error) { return f.file.Write(p) }
 
 func (f *smbReadWriteFile) Close() error {
-	if f == nil {
+	if f == nil || f.entry == nil {
 		return nil
 	}
 	var fileErr error
 	if f.file != nil {
 		fileErr = f.file.Close()
+		if isBrokenConnectionError(fileErr) {
+			smbPool.discard(f.entry)
+		}
 		if isBenignNetworkCloseError(fileErr) {
 			fileErr = nil
 		}
 	}
-	_ = closeSMBSession(nil, f.share, f.sess, f.conn)
+	smbPool.release(f.entry)
+	f.entry = nil
 	return fileErr
 }
 
 type smbFileCloser struct {
-	file *smb2.File
+	file smbFile
 }
 
 func (f *smbFileCloser) Read(p []byte) (int, error) {
@@ -73,6 +77,14 @@ func (f *smbFileCloser) Write(p []byte) (int, error) {
 	return f.file.Write(p)
 }
 
+// Seek lets resumed transfers continue a partial file at its recorded offset.
+func (f *smbFileCloser) Seek(offset int64, whence int) (int64, error) {
+	if f == nil || f.file == nil {
+		return 0, os.ErrClosed
+	}
+	return f.file.Seek(offset, whence)
+}
+
 func (f *smbFileCloser) Close() error {
 	if f == nil || f.file == nil {
 		return nil
@@ -84,22 +96,60 @@ func (f *smbFileCloser) Close() error {
 	return err
 }
 
+// smbMountedShare is a share mounted once for a job. ctx is the job's, so a
+// remount waiting for re-entered credentials ends with the job.
 type smbMountedShare struct {
-	share *smb2.Share
-	sess  *smb2.Session
-	conn  net.Conn
+	fs  SMBFS
+	ctx context.Context
+
+	mu    sync.Mutex
+	share smbShare
 }
 
 func (m *smbMountedShare) Close() error {
 	if m == nil {
 		return nil
 	}
-	return closeSMBSession(nil, m.share, m.sess, m.conn)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.share.Close()
+}
+
+// do runs op on the mounted share. When the server dropped the session, as
+// after an idle expiry or a password rotation, it mounts the share again,
+// waiting for re-entered credentials if need be, and retries op once. Files
+// opened on the old mount are not reopened; a transfer failing in the middle
+// of one is left to resume.
+func (m *smbMountedShare) do(op func(smbShare) error) error {
+	m.mu.Lock()
+	share := m.share
+	m.mu.Unlock()
+	err := op(share)
+	if !isSessionExpiredError(err) {
+		return err
+	}
+	m.mu.Lock()
+	if m.share == share {
+		fresh, _, mountErr := m.fs.dialAndMountContext(m.ctx, "")
+		if mountErr != nil {
+			m.mu.Unlock()
+			return mountErr
+		}
+		_ = m.share.Close()
+		m.share = fresh
+	}
+	share = m.share
+	m.mu.Unlock()
+	return op(share)
 }
 
 func (m *smbMountedShare) ReadDir(relPath string) ([]os.DirEntry, error) {
 	p := normalizeSMBPath(relPath)
-	fis, err := m.share.ReadDir(p)
+	var fis []os.FileInfo
+	err := m.do(func(share smbShare) (err error) {
+		fis, err = share.ReadDir(p)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -114,12 +164,20 @@ func (m *smbMountedShare) ReadDir(relPath string) ([]os.DirEntry, error) {
 	return out, nil
 }
 
-func (m *smbMountedShare) Stat(relPath string) (os.FileInfo, error) {
-	return m.share.Stat(normalizeSMBPathForStat(relPath))
+func (m *smbMountedShare) Stat(relPath string) (info os.FileInfo, err error) {
+	err = m.do(func(share smbShare) (err error) {
+		info, err = share.Stat(normalizeSMBPathForStat(relPath))
+		return err
+	})
+	return info, err
 }
 
-func (m *smbMountedShare) Lstat(relPath string) (os.FileInfo, error) {
-	return m.share.Lstat(normalizeSMBPathForStat(relPath))
+func (m *smbMountedShare) Lstat(relPath string) (info os.FileInfo, err error) {
+	err = m.do(func(share smbShare) (err error) {
+		info, err = share.Lstat(normalizeSMBPathForStat(relPath))
+		return err
+	})
+	return info, err
 }
 
 func (m *smbMountedShare) Open(relPath string) (io.ReadCloser, error) {
@@ -127,11 +185,7 @@ func (m *smbMountedShare) Open(relPath string) (io.ReadCloser, error) {
 	if p == "" {
 		return nil, fmt.Errorf("cannot open SMB share root as file")
 	}
-	f, err := m.share.Open(p)
-	if err != nil {
-		return nil, err
-	}
-	return &smbFileCloser{file: f}, nil
+	return m.openFile(p, os.O_RDONLY, 0)
 }
 
 func (m *smbMountedShare) OpenFile(relPath string, flag int, perm os.FileMode) (io.ReadWriteCloser, error) {
@@ -139,7 +193,15 @@ func (m *smbMountedShare) OpenFile(relPath string, flag int, perm os.FileMode) (
 	if p == "" {
 		return nil, fmt.Errorf("invalid SMB file path")
 	}
-	f, err := m.share.OpenFile(p, flag, perm)
+	return m.openFile(p, flag, perm)
+}
+
+func (m *smbMountedShare) openFile(p string, flag int, perm os.FileMode) (*smbFileCloser, error) {
+	var f smbFile
+	err := m.do(func(share smbShare) (err error) {
+		f, err = share.OpenFile(p, flag, perm)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -151,7 +213,7 @@ func (m *smbMountedShare) Mkdir(relPath string, perm os.FileMode) error {
 	if p == "" {
 		return fmt.Errorf("invalid SMB directory path")
 	}
-	return m.share.Mkdir(p, perm)
+	return m.do(func(share smbShare) error { return share.Mkdir(p, perm) })
 }
 
 func (m *smbMountedShare) MkdirAll(relPath string, perm os.FileMode) error {
@@ -159,7 +221,7 @@ func (m *smbMountedShare) MkdirAll(relPath string, perm os.FileMode) error {
 	if p == "" {
 		return nil
 	}
-	return m.share.MkdirAll(p, perm)
+	return m.do(func(share smbShare) error { return share.MkdirAll(p, perm) })
 }
 
 func (m *smbMountedShare) Chtimes(relPath string, atime, mtime time.Time) error {
@@ -167,7 +229,7 @@ func (m *smbMountedShare) Chtimes(relPath string, atime, mtime time.Time) error
 	if p == "" {
 		return fmt.Errorf("invalid SMB chtimes path")
 	}
-	return m.share.Chtimes(p, atime, mtime)
+	return m.do(func(share smbShare) error { return share.Chtimes(p, atime, mtime) })
 }
 
 func (m *smbMountedShare) Remove(relPath string) error {
@@ -175,7 +237,7 @@ func (m *smbMountedShare) Remove(relPath string) error {
 	if p == "" {
 		return fmt.Errorf("cannot remove SMB share root")
 	}
-	return m.share.Remove(p)
+	return m.do(func(share smbShare) error { return share.Remove(p) })
 }
 
 func (m *smbMountedShare) Rename(oldRelPath, newRelPath string) error {
@@ -184,15 +246,19 @@ func (m *smbMountedShare) Rename(oldRelPath, newRelPath string) error {
 	if oldp == "" || newp == "" {
 		return fmt.Errorf("invalid SMB rename path")
 	}
-	return m.share.Rename(oldp, newp)
+	return m.do(func(share smbShare) error { return share.Rename(oldp, newp) })
 }
 
-func (m *smbMountedShare) Readlink(relPath string) (string, error) {
+func (m *smbMountedShare) Readlink(relPath string) (target string, err error) {
 	p := normalizeSMBPath(relPath)
 	if p == "" {
 		return "", fmt.Errorf("invalid SMB symlink path")
 	}
-	return m.share.Readlink(p)
+	err = m.do(func(share smbShare) (err error) {
+		target, err = share.Readlink(p)
+		return err
+	})
+	return target, err
 }
 
 func (m *smbMountedShare) Symlink(target, linkRelPath string) error {
@@ -200,7 +266,7 @@ func (m *smbMountedShare) Symlink(target, linkRelPath string) error {
 	if linkp == "" {
 		return fmt.Errorf("invalid SMB symlink path")
 	}
-	return m.share.Symlink(target, linkp)
+	return m.do(func(share smbShare) error { return share.Symlink(target, linkp) })
 }
 
 func (m *smbMountedShare) Join(elem ...string) string {
@@ -223,66 +289,73 @@ func (s SMBFS) credentialsFor(ctx context.Context, relPath string) (Credentials,
 	return getCredentials(ctx, s.host, s.share, relPath)
 }
 
-// OpenSession opens a reusable mounted SMB share session.
-func (s SMBFS) OpenSession() (SMBSession, error) {
-	share, sess, conn, _, err := s.dialAndMount("")
+// OpenSession opens a reusable mounted SMB share session. ctx bounds waits
+// for re-entered credentials, both now and when the session expires later.
+func (s SMBFS) OpenSession(ctx context.Context) (SMBSession, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	share, _, err := s.dialAndMountContext(ctx, "")
 	if err != nil {
 		return nil, err
 	}
-	return &smbMountedShare{share: share, sess: sess, conn: conn}, nil
+	return &smbMountedShare{fs: s, ctx: ctx, share: share}, nil
 }
 
-func (s SMBFS) dialAndMount(relPath string) (*smb2.Share, *smb2.Session, net.Conn, Credentials, error) {
+func (s SMBFS) dialAndMount(relPath string) (smbShare, Credentials, error) {
 	return s.dialAndMountContext(context.Background(), relPath)
 }
 
-func (s SMBFS) dialAndMountContext(ctx context.Context, relPath string) (*smb2.Share, *smb2.Session, net.Conn, Credentials, error) {
+// dialAndMountContext mounts the share, retrying once when the server rejects
+// the credentials. On first contact the retry prompts at once, as for a typo
+// or a stale keyring entry. A share that was connected, whose session
+// expired or whose password was rotated meanwhile, waits instead for the
+// user to re-enter credentials from the connection indicator, so background
+// loads and jobs do not pop up prompts of their own.
+func (s SMBFS) dialAndMountContext(ctx context.Context, relPath string) (smbShare, Credentials, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	creds, err := s.credentialsFor(ctx, relPath)
-	if err != nil {
-		return nil, nil, nil, Credentials{}, err
-	}
-	d := &smb2.Dialer{
-		Initiator: &smb2.NTLMInitiator{
-			User:     creds.Username,
-			Password: creds.Password,
-			Domain:   creds.Domain,
-		},
+	remote := RemoteShare{Host: s.host, Share: s.share}
+	prev := ConnectionStateOf(remote)
+	share, creds, err := s.mountOnce(ctx, relPath, remote)
+	if err == nil || s.cred != nil || !isAuthError(err) {
+		return share, creds, err
 	}
-
-	dialer := net.Dialer{Timeout: 5 * time.Second}
-	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.host, "445"))
-	if err != nil {
-		return nil, nil, nil, creds, err
+	if prev == ConnectionConnected || prev == ConnectionAuthRequired {
+		if !awaitReauth(ctx, remote) {
+			return nil, creds, err
+		}
+	} else {
+		connectionStates.mu.Lock()
+		connectionStates.skipStored[remote.key()] = true
+		connectionStates.mu.Unlock()
 	}
+	return s.mountOnce(ctx, relPath, remote)
+}
 
-	sess, err := d.DialContext(ctx, conn)
+func (s SMBFS) mountOnce(ctx context.Context, relPath string, remote RemoteShare) (smbShare, Credentials, error) {
+	creds, err := s.credentialsFor(ctx, relPath)
 	if err != nil {
-		if isAuthError(err) {
-			ClearCachedCredentials(s.host, s.share)
+		if ctx.Err() == nil {
+			// The prompt was dismissed or no window could ask.
+			setConnectionState(remote, ConnectionAuthRequired)
 		}
-		_ = conn.Close()
-		return nil, nil, nil, creds, err
+		return nil, Credentials{}, err
 	}
 
-	share, err := sess.Mount(s.share)
+	share, err := mountSMBShare(ctx, s.host, s.share, creds)
+	recordConnection(remote, err)
 	if err != nil {
 		if isAuthError(err) {
 			ClearCachedCredentials(s.host, s.share)
 		}
-		_ = sess.Logoff()
-		_ = conn.Close()
-		return nil, nil, nil, creds, err
+		return nil, creds, err
 	}
 
-	// Persist credentials after a successful mount if requested.
-	if store := currentSecretStore(); creds.Persist && store != nil {
-		_ = store.Set(s.host, s.share, creds.Domain, creds.Username, creds.Password)
-	}
+	persistCredentials(s.host, s.share, creds)
 
-	return share, sess, conn, creds, nil
+	return share, creds, nil
 }
 
 func closeSMBSession(file io.Closer, share *smb2.Share, sess *smb2.Session, conn net.Conn) error {
@@ -347,35 +420,45 @@ func (s SMBFS) ReadDir(relPath string) ([]os.DirEntry, error) {
 	return s.ReadDirContext(context.Background(), relPath)
 }
 
+// ReadDirContext lists relPath on the pooled mount. Cancelling ctx returns
+// at once and takes the mount out of the pool, so later calls do not queue
+// behind a stalled listing; the mount stays up for the calls and files still
+// using it and is closed when the listing and the last of them end.
 func (s SMBFS) ReadDirContext(ctx context.Context, relPath string) ([]os.DirEntry, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	share, sess, conn, _, err := s.dialAndMountContext(ctx, relPath)
-	if err != nil {
-		return nil, err
-	}
-	done := make(chan struct{})
-	go func() {
+	var fis []os.FileInfo
+	err := s.withEntry(ctx, relPath, true, func(e *smbPoolEntry) (bool, error) {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		type listing struct {
+			fis []os.FileInfo
+			err error
+		}
+		done := make(chan listing, 1)
+		go func() {
+			fis, err := e.share.ReadDir(normalizeSMBPath(relPath))
+			done <- listing{fis, err}
+		}()
 		select {
+		case l := <-done:
+			fis = l.fis
+			return false, l.err
 		case <-ctx.Done():
-			_ = conn.Close()
-		case <-done:
+			// The abandoned listing keeps its use of the mount until it ends.
+			smbPool.discard(e)
+			go func() {
+				<-done
+				smbPool.release(e)
+			}()
+			return true, ctx.Err()
 		}
-	}()
-	defer func() {
-		close(done)
-		_ = closeSMBSession(nil, share, sess, conn)
-	}()
-
-	if err := ctx.Err(); err != nil {
-		return nil, err
-	}
-	p := normalizeSMBPath(relPath)
-	fis, err := share.ReadDir(p)
+	})
 	if err != nil {
 		if isAuthError(err) {
-			ClearCachedCredentials(s.host, s.share)
+			setConnectionState(RemoteShare{Host: s.host, Share: s.share}, ConnectionAuthRequired)
 		}
 		return nil, err
 	}
@@ -394,20 +477,12 @@ func (s SMBFS) ReadDirContext(ctx context.Context, relPath string) ([]os.DirEntr
 }
 
 // Stat returns file info for a path relative to the share (leading separators allowed).
-func (s SMBFS) Stat(relPath string) (os.FileInfo, error) {
-	share, sess, conn, _, err := s.dialAndMount(relPath)
-	if err != nil {
-		return nil, err
-	}
-	defer func() {
-		_ = closeSMBSession(nil, share, sess, conn)
-	}()
-
-	fi, err := share.Stat(normalizeSMBPathForStat(relPath))
+func (s SMBFS) Stat(relPath string) (fi os.FileInfo, err error) {
+	err = s.withShare(context.Background(), relPath, true, func(share smbShare) error {
+		fi, err = share.Stat(normalizeSMBPathForStat(relPath))
+		return err
+	})
 	if err != nil {
-		if isAuthError(err) {
-			ClearCachedCredentials(s.host, s.share)
-		}
 		return nil, err
 	}
 	return fi, nil
@@ -415,19 +490,12 @@ func (s SMBFS) Stat(relPath string) (os.FileInfo, error) {
 
 // StorageInfo returns capacity information for the SMB share.
 func (s SMBFS) StorageInfo(relPath string) (StorageInfo, error) {
-	share, sess, conn, _, err := s.dialAndMount(relPath)
-	if err != nil {
-		return StorageInfo{}, err
-	}
-	defer func() {
-		_ = closeSMBSession(nil, share, sess, conn)
-	}()
-
-	info, err := share.Statfs(normalizeSMBPathForStat(relPath))
+	var info smb2.FileFsInfo
+	err := s.withShare(context.Background(), relPath, true, func(share smbShare) (err error) {
+		info, err = share.Statfs(normalizeSMBPathForStat(relPath))
+		return err
+	})
 	if err != nil {
-		if isAuthError(err) {
-			ClearCachedCredentials(s.host, s.share)
-		}
 		return StorageInfo{}, err
 	}
 	blockSize := info.BlockSize() * info.FragmentSize()
@@ -435,22 +503,14 @@ func (s SMBFS) StorageInfo(relPath string) (StorageInfo, error) {
 }
 
 // Lstat returns file info without following symlinks.
-func (s SMBFS) Lstat(relPath string) (os.FileInfo, error) {
-	share, sess, conn, _, err := s.dialAndMount(relPath)
+func (s SMBFS) Lstat(relPath string) (fi os.FileInfo, err error) {
+	err = s.withShare(context.Background(), relPath, true, func(share smbShare) error {
+		fi, err = share.Lstat(normalizeSMBPathForStat(relPath))
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		_ = closeSMBSession(nil, share, sess, conn)
-	}()
-
-	fi, err := share.Lstat(normalizeSMBPathForStat(relPath))
-	if err != nil {
-		if isAuthError(err) {
-			ClearCachedCredentials(s.host, s.share)
-		}
-		return nil, err
-	}
 	return fi, nil
 }
 
@@ -469,52 +529,36 @@ func (SMBFS) Base(p string) string {
 
 // Open opens a file for reading.
 func (s SMBFS) Open(relPath string) (io.ReadCloser, error) {
-	share, sess, conn, _, err := s.dialAndMount(relPath)
-	if err != nil {
-		return nil, err
-	}
-
-	p := normalizeSMBPath(relPath)
-	if p == "" {
-		_ = closeSMBSession(nil, share, sess, conn)
+	if normalizeSMBPath(relPath) == "" {
 		return nil, fmt.Errorf("cannot open SMB share root as file")
 	}
-
-	f, err := share.Open(p)
-	if err != nil {
-		if isAuthError(err) {
-			ClearCachedCredentials(s.host, s.share)
-		}
-		_ = closeSMBSession(nil, share, sess, conn)
-		return nil, err
-	}
-
-	return &smbReadWriteFile{file: f, share: share, sess: sess, conn: conn}, nil
+	return s.openPooled(relPath, os.O_RDONLY, 0)
 }
 
 // OpenFile opens a file with flags for read/write operations.
 func (s SMBFS) OpenFile(relPath string, flag int, perm os.FileMode) (io.ReadWriteCloser, error) {
-	share, sess, conn, _, err := s.dialAndMount(relPath)
-	if err != nil {
-		return nil, err
-	}
-
-	p := normalizeSMBPath(relPath)
-	if p == "" {
-		_ = closeSMBSession(nil, share, sess, conn)
+	if normalizeSMBPath(relPath) == "" {
 		return nil, fmt.Errorf("invalid SMB file path")
 	}
+	return s.openPooled(relPath, flag, perm)
+}
 
-	f, err := share.OpenFile(p, flag, perm)
-	if err != nil {
-		if isAuthError(err) {
-			ClearCachedCredentials(s.host, s.share)
+// openPooled opens relPath on the pooled mount, which stays in use until
+// the file is closed.
+func (s SMBFS) openPooled(relPath string, flag int, perm os.FileMode) (*smbReadWriteFile, error) {
+	var file *smbReadWriteFile
+	err := s.withEntry(context.Background(), relPath, flag == os.O_RDONLY, func(e *smbPoolEntry) (bool, error) {
+		f, err := e.share.OpenFile(normalizeSMBPath(relPath), flag, perm)
+		if err != nil {
+			return false, err
 		}
-		_ = closeSMBSession(nil, share, sess, conn)
+		file = &smbReadWriteFile{file: f, entry: e}
+		return true, nil
+	})
+	if err != nil {
 		return nil, err
 	}
-
-	return &smbReadWriteFile{file: f, share: share, sess: sess, conn: conn}, nil
+	return file, nil
 }
 
 // MkdirAll creates a directory path (including parents).
@@ -523,20 +567,9 @@ func (s SMBFS) MkdirAll(relPath string, perm os.FileMode) error {
 	if p == "" {
 		return nil
 	}
-
-	share, sess, conn, _, err := s.dialAndMount(relPath)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		_ = closeSMBSession(nil, share, sess, conn)
-	}()
-
-	err = share.MkdirAll(p, perm)
-	if err != nil && isAuthError(err) {
-		ClearCachedCredentials(s.host, s.share)
-	}
-	return err
+	return s.withShare(context.Background(), relPath, false, func(share smbShare) error {
+		return share.MkdirAll(p, perm)
+	})
 }
 
 // Mkdir creates exactly one directory and fails when the target exists.
@@ -545,20 +578,9 @@ func (s SMBFS) Mkdir(relPath string, perm os.FileMode) error {
 	if p == "" {
 		return fmt.Errorf("invalid SMB directory path")
 	}
-
-	share, sess, conn, _, err := s.dialAndMount(relPath)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		_ = closeSMBSession(nil, share, sess, conn)
-	}()
-
-	err = share.Mkdir(p, perm)
-	if err != nil && isAuthError(err) {
-		ClearCachedCredentials(s.host, s.share)
-	}
-	return err
+	return s.withShare(context.Background(), relPath, false, func(share smbShare) error {
+		return share.Mkdir(p, perm)
+	})
 }
 
 // Chtimes changes access and modification times.
@@ -567,20 +589,9 @@ func (s SMBFS) Chtimes(relPath string, atime, mtime time.Time) error {
 	if p == "" {
 		return fmt.Errorf("invalid SMB chtimes path")
 	}
-
-	share, sess, conn, _, err := s.dialAndMount(relPath)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		_ = closeSMBSession(nil, share, sess, conn)
-	}()
-
-	err = share.Chtimes(p, atime, mtime)
-	if err != nil && isAuthError(err) {
-		ClearCachedCredentials(s.host, s.share)
-	}
-	return err
+	return s.withShare(context.Background(), relPath, false, func(share smbShare) error {
+		return share.Chtimes(p, atime, mtime)
+	})
 }
 
 // Remove removes a file or an empty directory.
@@ -589,20 +600,9 @@ func (s SMBFS) Remove(relPath string) error {
 	if p == "" {
 		return fmt.Errorf("cannot remove SMB share root")
 	}
-
-	share, sess, conn, _, err := s.dialAndMount(relPath)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		_ = closeSMBSession(nil, share, sess, conn)
-	}()
-
-	err = share.Remove(p)
-	if err != nil && isAuthError(err) {
-		ClearCachedCredentials(s.host, s.share)
-	}
-	return err
+	return s.withShare(context.Background(), relPath, false, func(share smbShare) error {
+		return share.Remove(p)
+	})
 }
 
 // Rename renames a file or directory path within the same share.
@@ -612,42 +612,22 @@ func (s SMBFS) Rename(oldRelPath, newRelPath string) error {
 	if oldp == "" || newp == "" {
 		return fmt.Errorf("invalid SMB rename path")
 	}
-
-	share, sess, conn, _, err := s.dialAndMount(oldRelPath)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		_ = closeSMBSession(nil, share, sess, conn)
-	}()
-
-	err = share.Rename(oldp, newp)
-	if err != nil && isAuthError(err) {
-		ClearCachedCredentials(s.host, s.share)
-	}
-	return err
+	return s.withShare(context.Background(), oldRelPath, false, func(share smbShare) error {
+		return share.Rename(oldp, newp)
+	})
 }
 
 // Readlink reads symlink target path.
-func (s SMBFS) Readlink(relPath string) (string, error) {
+func (s SMBFS) Readlink(relPath string) (target string, err error) {
 	p := normalizeSMBPath(relPath)
 	if p == "" {
 		return "", fmt.Errorf("invalid SMB symlink path")
 	}
-
-	share, sess, conn, _, err := s.dialAndMount(relPath)
-	if err != nil {
-		return "", err
-	}
-	defer func() {
-		_ = closeSMBSession(nil, share, sess, conn)
-	}()
-
-	target, err := share.Readlink(p)
+	err = s.withShare(context.Background(), relPath, true, func(share smbShare) error {
+		target, err = share.Readlink(p)
+		return err
+	})
 	if err != nil {
-		if isAuthError(err) {
-			ClearCachedCredentials(s.host, s.share)
-		}
 		return "", err
 	}
 	return target, nil
@@ -659,34 +639,7 @@ func (s SMBFS) Symlink(target, linkRelPath string) error {
 	if linkp == "" {
 		return fmt.Errorf("invalid SMB symlink path")
 	}
-
-	share, sess, conn, _, err := s.dialAndMount(linkRelPath)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		_ = closeSMBSession(nil, share, sess, conn)
-	}()
-
-	err = share.Symlink(target, linkp)
-	if err != nil && isAuthError(err) {
-		ClearCachedCredentials(s.host, s.share)
-	}
-	return err
-}
-
-func isAuthError(err error) bool {
-	if err == nil {
-		return false
-	}
-	e := strings.ToLower(err.Error())
-	// Common indicators from Windows/SMB servers
-	if strings.Contains(e, "logon is invalid") ||
-		strings.Contains(e, "bad username") ||
-		strings.Contains(e, "authentication") ||
-		strings.Contains(e, "status_logon_failure") ||
-		strings.Contains(e, "access is denied") {
-		return true
-	}
-	return false
+	return s.withShare(context.Background(), linkRelPath, false, func(share smbShare) error {
+		return share.Symlink(target, linkp)
+	})
 }
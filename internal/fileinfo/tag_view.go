@@ -0,0 +1,95 @@
+package fileinfo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SchemeTag marks tag:// views, which list files by color tag instead of by
+// directory. They have no provider: listings come from the tag index.
+const SchemeTag Scheme = "tag"
+
+const tagViewPrefix = "tag://"
+
+// ErrTagView is returned when a tag:// view is used as a filesystem path.
+var ErrTagView = errors.New("tag views are virtual and have no files of their own")
+
+// IsTagViewPath reports whether p is a tag:// virtual view path.
+func IsTagViewPath(p string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(p)), tagViewPrefix)
+}
+
+// TagViewPath returns the view listing tag, or the tag:// root listing every
+// tag for ColorTagNone.
+func TagViewPath(tag ColorTag) string {
+	return tagViewPrefix + tag.String()
+}
+
+// ParseTagViewPath returns the tag shown by a tag:// view. The root view
+// yields ColorTagNone. ok is false for non-tag paths and unknown colors.
+func ParseTagViewPath(p string) (ColorTag, bool) {
+	if !IsTagViewPath(p) {
+		return ColorTagNone, false
+	}
+	rest := strings.Trim(strings.TrimSpace(p)[len(tagViewPrefix):], "/")
+	if rest == "" {
+		return ColorTagNone, true
+	}
+	if strings.Contains(rest, "/") {
+		return ColorTagNone, false
+	}
+	return ParseColorTag(rest)
+}
+
+func canonicalTagViewPath(input, trimmed string) (string, Parsed, error) {
+	parsed := Parsed{Scheme: SchemeTag, Raw: input, Provider: "tag"}
+	tag, ok := ParseTagViewPath(trimmed)
+	if !ok {
+		return "", parsed, fmt.Errorf("unknown tag view %q", trimmed)
+	}
+	parsed.Display = TagViewPath(tag)
+	return parsed.Display, parsed, nil
+}
+
+func tagViewParentPath(string) string {
+	return tagViewPrefix
+}
+
+func tagViewJoinPath(base, name string) string {
+	if tag, ok := ParseTagViewPath(base); ok && tag == ColorTagNone {
+		return tagViewPrefix + name
+	}
+	return strings.TrimRight(base, "/") + "/" + name
+}
+
+func tagViewBaseName(p string) string {
+	tag, ok := ParseTagViewPath(p)
+	if !ok || tag == ColorTagNone {
+		return tagViewPrefix
+	}
+	return tag.String()
+}
+
+// FileInfoFromPath builds a FileInfo for a single path using the same link
+// semantics as directory listings.
+func FileInfoFromPath(p string) (FileInfo, error) {
+	name := BaseName(p)
+	metadata, err := InspectPath(p, name, nil)
+	if err != nil {
+		return FileInfo{}, err
+	}
+	return FileInfo{
+		Name:       name,
+		Path:       p,
+		IsDir:      metadata.IsDir,
+		Size:       metadata.Size,
+		Modified:   metadata.Modified,
+		FileType:   metadata.FileType,
+		Status:     StatusNormal,
+		ReadOnly:   metadata.Info.Mode().Perm()&0200 == 0,
+		Mode:       metadata.Info.Mode(),
+		Owner:      listingOwner(metadata.Info),
+		LinkTarget: metadata.LinkTarget,
+	}, nil
+}
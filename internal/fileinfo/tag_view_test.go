@@ -0,0 +1,44 @@
+package fileinfo
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTagViewPaths(t *testing.T) {
+	if tag, ok := ParseTagViewPath("TAG://Red/"); !ok || tag != ColorTagRed {
+		t.Fatalf("ParseTagViewPath(red) = %v,%t", tag, ok)
+	}
+	if tag, ok := ParseTagViewPath("tag://"); !ok || tag != ColorTagNone {
+		t.Fatalf("ParseTagViewPath(root) = %v,%t", tag, ok)
+	}
+	for _, p := range []string{"tag://teal", "tag://red/x", "/tmp/tag://red"} {
+		if _, ok := ParseTagViewPath(p); ok {
+			t.Fatalf("ParseTagViewPath(%q) should be rejected", p)
+		}
+	}
+
+	display, parsed, err := CanonicalDisplayPath("tag://Blue")
+	if err != nil || display != "tag://blue" || parsed.Scheme != SchemeTag {
+		t.Fatalf("CanonicalDisplayPath = %q,%+v,%v", display, parsed, err)
+	}
+	if _, _, err := CanonicalDisplayPath("tag://teal"); err == nil {
+		t.Fatal("unknown tag view should not canonicalize")
+	}
+
+	if got := ParentPath("tag://red"); got != "tag://" {
+		t.Fatalf("ParentPath = %q", got)
+	}
+	if got := ParentPath("tag://"); got != "tag://" {
+		t.Fatalf("ParentPath(root) = %q", got)
+	}
+	if got := BaseName("tag://red"); got != "red" {
+		t.Fatalf("BaseName = %q", got)
+	}
+	if got := JoinPath("tag://", "green"); got != "tag://green" {
+		t.Fatalf("JoinPath = %q", got)
+	}
+	if _, _, err := ResolveRead("tag://red"); !errors.Is(err, ErrTagView) {
+		t.Fatalf("ResolveRead err = %v, want ErrTagView", err)
+	}
+}
@@ -24,6 +24,9 @@ func CreateTextFilePortable(parentPath, name, content string) (string, error) {
 	if parentParsed.Scheme == SchemeArchive {
 		return "", fmt.Errorf("archive paths are read-only: %s", parentDisplay)
 	}
+	if IsGioProviderPath(parentParsed) {
+		return "", errGioReadOnly(parentDisplay)
+	}
 	if parentParsed.Scheme == SchemeSMB && parentParsed.Provider != "local" {
 		return "", fmt.Errorf("direct SMB paths do not support text file creation: %s", parentDisplay)
 	}
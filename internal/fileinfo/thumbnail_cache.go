@@ -0,0 +1,189 @@
+package fileinfo
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// ThumbnailCacheDir returns the shared thumbnail directory of the freedesktop
+// thumbnail specification ($XDG_CACHE_HOME/thumbnails) on Linux and the BSDs.
+// Other platforms have no shared cache, so nmf keeps its own under the user
+// cache directory in the same format.
+func ThumbnailCacheDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	switch runtime.GOOS {
+	case "windows", "darwin":
+		return filepath.Join(base, "nmf", "thumbnails"), nil
+	default:
+		return filepath.Join(base, "thumbnails"), nil
+	}
+}
+
+// thumbnailSizeDir maps a thumbnail edge to the specification's size
+// directory, or "" when the edge is not one of its sizes.
+func thumbnailSizeDir(edge int) string {
+	switch edge {
+	case 128:
+		return "normal"
+	case 256:
+		return "large"
+	case 512:
+		return "x-large"
+	case 1024:
+		return "xx-large"
+	}
+	return ""
+}
+
+// thumbnailDiskCache stores thumbnails of local files as PNGs named after
+// the MD5 of the file URI, tagged with Thumb::URI and Thumb::MTime so stale
+// entries are detected, as the specification requires.
+type thumbnailDiskCache struct {
+	dir string
+}
+
+func newThumbnailDiskCache(root string, edge int) *thumbnailDiskCache {
+	size := thumbnailSizeDir(edge)
+	if root == "" || size == "" {
+		return nil
+	}
+	return &thumbnailDiskCache{dir: filepath.Join(root, size)}
+}
+
+// thumbnailURI returns the file URI the specification hashes; Windows drive
+// paths become file:///C:/...
+func thumbnailURI(native string) string {
+	p := filepath.ToSlash(native)
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	return (&url.URL{Scheme: "file", Path: p}).String()
+}
+
+func (c *thumbnailDiskCache) file(uri string) string {
+	sum := md5.Sum([]byte(uri))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".png")
+}
+
+// load returns the cached thumbnail of native when its recorded URI and
+// modification time still match.
+func (c *thumbnailDiskCache) load(native string, mtime int64) (image.Image, bool) {
+	uri := thumbnailURI(native)
+	data, err := os.ReadFile(c.file(uri))
+	if err != nil {
+		return nil, false
+	}
+	text, err := pngTextChunks(data)
+	if err != nil || text["Thumb::URI"] != uri || text["Thumb::MTime"] != strconv.FormatInt(mtime, 10) {
+		return nil, false
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, false
+	}
+	return img, true
+}
+
+// store writes img atomically with owner-only permissions.
+func (c *thumbnailDiskCache) store(native string, mtime int64, img image.Image) error {
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return err
+	}
+	uri := thumbnailURI(native)
+	data, err := insertPNGText(buf.Bytes(), [][2]string{
+		{"Thumb::URI", uri},
+		{"Thumb::MTime", strconv.FormatInt(mtime, 10)},
+	})
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(c.dir, "nmf-*.png")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o600); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), c.file(uri)); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+var errInvalidPNG = errors.New("invalid png")
+
+const pngSignatureLen = 8
+
+// pngTextChunks returns the tEXt chunks that precede the image data.
+func pngTextChunks(data []byte) (map[string]string, error) {
+	if len(data) < pngSignatureLen || string(data[1:4]) != "PNG" {
+		return nil, errInvalidPNG
+	}
+	text := make(map[string]string)
+	for pos := pngSignatureLen; pos+8 <= len(data); {
+		length := int(binary.BigEndian.Uint32(data[pos:]))
+		kind := string(data[pos+4 : pos+8])
+		end := pos + 8 + length + 4
+		if length < 0 || end > len(data) {
+			return nil, errInvalidPNG
+		}
+		if kind == "IDAT" || kind == "IEND" {
+			break
+		}
+		if kind == "tEXt" {
+			if key, value, ok := bytes.Cut(data[pos+8:pos+8+length], []byte{0}); ok {
+				text[string(key)] = string(value)
+			}
+		}
+		pos = end
+	}
+	return text, nil
+}
+
+// insertPNGText adds tEXt chunks right after the IHDR chunk, which
+// image/png cannot write itself.
+func insertPNGText(data []byte, entries [][2]string) ([]byte, error) {
+	const ihdrEnd = pngSignatureLen + 8 + 13 + 4
+	if len(data) < ihdrEnd || string(data[pngSignatureLen+4:pngSignatureLen+8]) != "IHDR" {
+		return nil, errInvalidPNG
+	}
+	out := make([]byte, 0, len(data)+256)
+	out = append(out, data[:ihdrEnd]...)
+	for _, entry := range entries {
+		body := append(append([]byte("tEXt"+entry[0]), 0), entry[1]...)
+		out = binary.BigEndian.AppendUint32(out, uint32(len(body)-4))
+		out = append(out, body...)
+		out = binary.BigEndian.AppendUint32(out, crc32.ChecksumIEEE(body))
+	}
+	return append(out, data[ihdrEnd:]...), nil
+}
@@ -0,0 +1,290 @@
+package fileinfo
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"sync"
+	"time"
+)
+
+// ThumbnailService generates image thumbnails for the grid view in the
+// background. It mirrors MetadataService: callers get a cached thumbnail or
+// (nil, false) immediately, and OnUpdated subscribers are notified in 50ms
+// batches once new thumbnails land. Thumbnails of local files are also kept
+// in the on-disk thumbnail cache, so they survive restarts and are shared
+// with other applications that follow the freedesktop specification.
+type ThumbnailService struct {
+	mu        sync.RWMutex
+	edge      int
+	cache     map[string]thumbnailEntry
+	pending   map[string]struct{}
+	jobs      chan thumbnailJob
+	done      chan struct{}
+	closeOnce sync.Once
+	disk      *thumbnailDiskCache
+	read      func(context.Context, string) (image.Image, error)
+
+	updMu       sync.Mutex
+	updatedAny  bool
+	subscribers []func()
+
+	debugPrint func(format string, args ...interface{})
+}
+
+type thumbnailEntry struct {
+	modified time.Time
+	img      image.Image
+}
+
+type thumbnailJob struct {
+	path     string
+	modified time.Time
+}
+
+// thumbnailCacheLimit caps thumbnails held in memory; the cache is cleared
+// wholesale when it grows past the limit, like the metadata cache.
+const thumbnailCacheLimit = 1024
+
+// NewThumbnailService creates a thumbnail service producing thumbnails whose
+// longer edge is edge pixels. cacheDir is the root of the on-disk cache (see
+// ThumbnailCacheDir); "" keeps thumbnails in memory only, as does an edge
+// that is not one of the specification's sizes.
+func NewThumbnailService(edge int, cacheDir string, debug func(format string, args ...interface{})) *ThumbnailService {
+	return newThumbnailService(edge, cacheDir, debug, readThumbnailSource)
+}
+
+func newThumbnailService(edge int, cacheDir string, debug func(format string, args ...interface{}), read func(context.Context, string) (image.Image, error)) *ThumbnailService {
+	s := &ThumbnailService{
+		edge:       edge,
+		cache:      make(map[string]thumbnailEntry, 256),
+		pending:    make(map[string]struct{}, 64),
+		jobs:       make(chan thumbnailJob, 256),
+		done:       make(chan struct{}),
+		disk:       newThumbnailDiskCache(cacheDir, edge),
+		read:       read,
+		debugPrint: debug,
+	}
+	for i := 0; i < 2; i++ {
+		go s.worker()
+	}
+	go s.batchNotifier()
+	return s
+}
+
+// readThumbnailSource decodes the image at p through the viewer's reader.
+func readThumbnailSource(ctx context.Context, p string) (image.Image, error) {
+	preview, err := ReadPreviewFileContext(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	if preview.Image == nil {
+		if preview.ImageError != "" {
+			return nil, fmt.Errorf("%s", preview.ImageError)
+		}
+		return nil, fmt.Errorf("not a supported image: %s", p)
+	}
+	return preview.Image, nil
+}
+
+// OnUpdated registers a callback invoked after batches of new thumbnails.
+func (s *ThumbnailService) OnUpdated(f func()) {
+	if f == nil || s.closed() {
+		return
+	}
+	s.updMu.Lock()
+	defer s.updMu.Unlock()
+	if s.closed() {
+		return
+	}
+	s.subscribers = append(s.subscribers, f)
+}
+
+// GetCachedOrRequest returns the cached thumbnail of an image file. On a miss
+// it queues generation and returns (nil, false). Files that are not images,
+// or whose thumbnail could not be made, also return false.
+func (s *ThumbnailService) GetCachedOrRequest(file FileInfo) (image.Image, bool) {
+	if s == nil || file.IsDir || MediaKindForName(file.Name) != MediaKindImage {
+		return nil, false
+	}
+	s.mu.RLock()
+	entry, found := s.cache[file.Path]
+	s.mu.RUnlock()
+	if found && entry.modified.Equal(file.Modified) {
+		return entry.img, entry.img != nil
+	}
+	s.enqueue(thumbnailJob{path: file.Path, modified: file.Modified})
+	return nil, false
+}
+
+// Loading reports whether file's thumbnail is queued or being generated.
+// GetCachedOrRequest queues it, so call Loading after that.
+func (s *ThumbnailService) Loading(file FileInfo) bool {
+	if s == nil || file.IsDir || s.closed() {
+		return false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, pending := s.pending[file.Path]
+	return pending
+}
+
+// Close stops workers and releases update callbacks.
+func (s *ThumbnailService) Close() {
+	if s == nil {
+		return
+	}
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.updMu.Lock()
+		s.updatedAny = false
+		s.subscribers = nil
+		s.updMu.Unlock()
+	})
+}
+
+func (s *ThumbnailService) closed() bool {
+	if s == nil {
+		return true
+	}
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *ThumbnailService) enqueue(job thumbnailJob) {
+	if s.closed() {
+		return
+	}
+	s.mu.Lock()
+	if _, exists := s.pending[job.path]; exists {
+		s.mu.Unlock()
+		return
+	}
+	s.pending[job.path] = struct{}{}
+	s.mu.Unlock()
+
+	select {
+	case <-s.done:
+		s.clearPending(job.path)
+	case s.jobs <- job:
+	default:
+		if s.debugPrint != nil {
+			s.debugPrint("ThumbnailService: job queue full, dropping %s", job.path)
+		}
+		s.clearPending(job.path)
+	}
+}
+
+func (s *ThumbnailService) clearPending(path string) {
+	s.mu.Lock()
+	delete(s.pending, path)
+	s.mu.Unlock()
+}
+
+func (s *ThumbnailService) worker() {
+	for {
+		var job thumbnailJob
+		select {
+		case <-s.done:
+			return
+		case job = <-s.jobs:
+		}
+		if s.closed() {
+			return
+		}
+		img := s.generate(job)
+		if !s.closed() {
+			s.store(job, img)
+			s.flagUpdated()
+		}
+		s.clearPending(job.path)
+	}
+}
+
+// generate returns the thumbnail for job from the disk cache, or decodes and
+// scales the image and saves the result there. It returns nil on failure.
+func (s *ThumbnailService) generate(job thumbnailJob) image.Image {
+	native, local := s.localPath(job.path)
+	mtime := job.modified.Unix()
+	if local {
+		if img, ok := s.disk.load(native, mtime); ok {
+			return img
+		}
+	}
+	source, err := s.read(context.Background(), job.path)
+	if err != nil {
+		if s.debugPrint != nil {
+			s.debugPrint("ThumbnailService: decode failed path=%s err=%v", job.path, err)
+		}
+		return nil
+	}
+	img := thumbnail(source, s.edge)
+	if local {
+		if err := s.disk.store(native, mtime, img); err != nil && s.debugPrint != nil {
+			s.debugPrint("ThumbnailService: disk cache write failed path=%s err=%v", job.path, err)
+		}
+	}
+	return img
+}
+
+// localPath returns the native path of p when the disk cache applies to it.
+func (s *ThumbnailService) localPath(p string) (string, bool) {
+	if s.disk == nil {
+		return "", false
+	}
+	_, parsed, err := CanonicalDisplayPath(p)
+	if err != nil || parsed.Scheme == SchemeArchive || (parsed.Provider != "local" && parsed.Scheme != SchemeFile) {
+		return "", false
+	}
+	if parsed.Native != "" {
+		return parsed.Native, true
+	}
+	return p, true
+}
+
+func (s *ThumbnailService) store(job thumbnailJob, img image.Image) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.cache) >= thumbnailCacheLimit {
+		s.cache = make(map[string]thumbnailEntry, 256)
+	}
+	s.cache[job.path] = thumbnailEntry{modified: job.modified, img: img}
+}
+
+func (s *ThumbnailService) flagUpdated() {
+	if s.closed() {
+		return
+	}
+	s.updMu.Lock()
+	if !s.closed() {
+		s.updatedAny = true
+	}
+	s.updMu.Unlock()
+}
+
+func (s *ThumbnailService) batchNotifier() {
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+		s.updMu.Lock()
+		if !s.updatedAny {
+			s.updMu.Unlock()
+			continue
+		}
+		s.updatedAny = false
+		subs := append([]func(){}, s.subscribers...)
+		s.updMu.Unlock()
+		for _, f := range subs {
+			f()
+		}
+	}
+}
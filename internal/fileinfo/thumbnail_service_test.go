@@ -0,0 +1,82 @@
+package fileinfo
+
+import (
+	"context"
+	"image"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestThumbnailDiskCacheRoundTripsAndDetectsStaleEntries(t *testing.T) {
+	cache := newThumbnailDiskCache(t.TempDir(), 128)
+	native := filepath.Join(t.TempDir(), "photo one.png")
+	img := image.NewRGBA(image.Rect(0, 0, 128, 64))
+
+	if err := cache.store(native, 100, img); err != nil {
+		t.Fatalf("store: %v", err)
+	}
+	got, ok := cache.load(native, 100)
+	if !ok || got.Bounds() != img.Bounds() {
+		t.Fatalf("load = %v, %t", got, ok)
+	}
+	if _, ok := cache.load(native, 200); ok {
+		t.Fatal("a thumbnail with another mtime should be stale")
+	}
+
+	data, err := os.ReadFile(cache.file(thumbnailURI(native)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	text, err := pngTextChunks(data)
+	if err != nil || text["Thumb::URI"] != thumbnailURI(native) || text["Thumb::MTime"] != "100" {
+		t.Fatalf("png text = %v, %v", text, err)
+	}
+	if filepath.Base(filepath.Dir(cache.file(""))) != "normal" {
+		t.Fatalf("128px thumbnails belong in normal/, got %s", cache.file(""))
+	}
+}
+
+func TestThumbnailServiceUsesDiskCacheAcrossInstances(t *testing.T) {
+	root := t.TempDir()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.png")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	file := FileInfo{Name: "a.png", Path: path, Modified: time.Unix(100, 0)}
+	var reads int32
+	read := func(context.Context, string) (image.Image, error) {
+		atomic.AddInt32(&reads, 1)
+		return image.NewRGBA(image.Rect(0, 0, 1000, 500)), nil
+	}
+
+	for i := 0; i < 2; i++ {
+		service := newThumbnailService(128, root, nil, read)
+		updated := make(chan struct{}, 1)
+		service.OnUpdated(func() {
+			select {
+			case updated <- struct{}{}:
+			default:
+			}
+		})
+		if _, ok := service.GetCachedOrRequest(file); ok {
+			t.Fatal("a new service should start with an empty memory cache")
+		}
+		select {
+		case <-updated:
+		case <-time.After(2 * time.Second):
+			t.Fatal("thumbnail service did not notify subscribers")
+		}
+		img, ok := service.GetCachedOrRequest(file)
+		service.Close()
+		if !ok || img.Bounds().Dx() != 128 || img.Bounds().Dy() != 64 {
+			t.Fatalf("thumbnail = %v, %t", img, ok)
+		}
+	}
+	if got := atomic.LoadInt32(&reads); got != 1 {
+		t.Fatalf("source decoded %d times, want 1", got)
+	}
+}
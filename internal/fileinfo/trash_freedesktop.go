@@ -0,0 +1,99 @@
+//go:build !windows
+
+package fileinfo
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// freedesktopTrash moves native into the home trash described by the
+// freedesktop.org Trash specification. It is used when gio is unavailable.
+// Paths on another filesystem than the home trash are reported as
+// ErrTrashUnsupported rather than being copied, so trash never degrades into
+// a slow cross-device move.
+func freedesktopTrash(native string, now time.Time) error {
+	abs, err := filepath.Abs(native)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Lstat(abs); err != nil {
+		return err
+	}
+	trashDir, err := freedesktopHomeTrashDir()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrTrashUnsupported, err)
+	}
+	filesDir := filepath.Join(trashDir, "files")
+	infoDir := filepath.Join(trashDir, "info")
+	for _, dir := range []string{filesDir, infoDir} {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return err
+		}
+	}
+
+	info, name, err := createTrashInfo(infoDir, filepath.Base(abs), abs, now)
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(abs, filepath.Join(filesDir, name)); err != nil {
+		_ = os.Remove(info)
+		if errors.Is(err, syscall.EXDEV) {
+			return fmt.Errorf("%w: %s is not on the home trash filesystem", ErrTrashUnsupported, abs)
+		}
+		return err
+	}
+	return nil
+}
+
+func freedesktopHomeTrashDir() (string, error) {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(dataHome) {
+		return filepath.Join(dataHome, "Trash"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", "Trash"), nil
+}
+
+// createTrashInfo reserves a unique trash name by creating its .trashinfo file
+// exclusively, as the specification requires, and returns the info path and
+// the chosen name.
+func createTrashInfo(infoDir, base, original string, now time.Time) (string, string, error) {
+	content := "[Trash Info]\nPath=" + (&url.URL{Path: original}).EscapedPath() +
+		"\nDeletionDate=" + now.Format("2006-01-02T15:04:05") + "\n"
+	ext := filepath.Ext(base)
+	stem := base[:len(base)-len(ext)]
+	if stem == "" {
+		stem, ext = base, ""
+	}
+	for i := 1; i < 10000; i++ {
+		name := base
+		if i > 1 {
+			name = stem + "." + strconv.Itoa(i) + ext
+		}
+		path := filepath.Join(infoDir, name+".trashinfo")
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", "", err
+		}
+		_, werr := f.WriteString(content)
+		cerr := f.Close()
+		if werr != nil || cerr != nil {
+			_ = os.Remove(path)
+			return "", "", errors.Join(werr, cerr)
+		}
+		return path, name, nil
+	}
+	return "", "", fmt.Errorf("no free trash name for %s", base)
+}
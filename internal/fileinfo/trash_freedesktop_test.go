@@ -0,0 +1,60 @@
+//go:build !windows
+
+package fileinfo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFreedesktopTrashMovesFileAndWritesInfo(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", filepath.Join(root, "data"))
+	src := filepath.Join(root, "my file.txt")
+	if err := os.WriteFile(src, []byte("x"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2024, 3, 4, 5, 6, 7, 0, time.Local)
+
+	if err := freedesktopTrash(src, now); err != nil {
+		t.Fatalf("freedesktopTrash: %v", err)
+	}
+
+	if _, err := os.Stat(src); !os.IsNotExist(err) {
+		t.Fatalf("source still exists: %v", err)
+	}
+	trash := filepath.Join(root, "data", "Trash")
+	if _, err := os.Stat(filepath.Join(trash, "files", "my file.txt")); err != nil {
+		t.Fatalf("trashed file missing: %v", err)
+	}
+	info, err := os.ReadFile(filepath.Join(trash, "info", "my file.txt.trashinfo"))
+	if err != nil {
+		t.Fatalf("trashinfo missing: %v", err)
+	}
+	for _, want := range []string{"[Trash Info]", "my%20file.txt", "DeletionDate=2024-03-04T05:06:07"} {
+		if !strings.Contains(string(info), want) {
+			t.Fatalf("trashinfo %q missing %q", info, want)
+		}
+	}
+}
+
+func TestFreedesktopTrashPicksUniqueNames(t *testing.T) {
+	root := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", filepath.Join(root, "data"))
+	for i := 0; i < 2; i++ {
+		src := filepath.Join(root, "a.txt")
+		if err := os.WriteFile(src, []byte("x"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := freedesktopTrash(src, time.Now()); err != nil {
+			t.Fatalf("freedesktopTrash #%d: %v", i, err)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "data", "Trash", "files", "a.2.txt")); err != nil {
+		t.Fatalf("second trashed file should be renamed: %v", err)
+	}
+}
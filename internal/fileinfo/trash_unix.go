@@ -7,8 +7,12 @@ import (
 	"errors"
 	"fmt"
 	"os/exec"
+	"runtime"
+	"time"
 )
 
+var errGioTrashDisabled = errors.New("gio trash is disabled by ui.gio.enabled")
+
 func trashPath(ctx context.Context, displayPath string) error {
 	vfs, parsed, err := ResolveRead(displayPath)
 	if err != nil {
@@ -23,8 +27,18 @@ func trashPath(ctx context.Context, displayPath string) error {
 	if native == "" {
 		native = displayPath
 	}
-	if _, err := exec.LookPath("gio"); err != nil {
-		return fmt.Errorf("%w: gio command not found", ErrTrashUnsupported)
+	gioErr := errGioTrashDisabled
+	if GioEnabled() {
+		_, gioErr = exec.LookPath("gio")
+	}
+	if gioErr != nil {
+		if runtime.GOOS == "darwin" {
+			return fmt.Errorf("%w: %v", ErrTrashUnsupported, gioErr)
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return freedesktopTrash(native, time.Now())
 	}
 	cmd := exec.CommandContext(ctx, "gio", "trash", "--", native)
 	if output, err := cmd.CombinedOutput(); err != nil {
@@ -61,6 +61,7 @@ func OpenPortable(p string) (io.ReadCloser, error) {
 	if err != nil {
 		return nil, err
 	}
+	vfs = wrapFaultVFS(vfs)
 	native := parsed.Native
 	if native == "" {
 		native = p
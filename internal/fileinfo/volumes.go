@@ -0,0 +1,119 @@
+package fileinfo
+
+import (
+	"context"
+	"strings"
+)
+
+// VolumeKind classifies a Volume for display.
+type VolumeKind int
+
+const (
+	VolumeLocal VolumeKind = iota
+	VolumeRemovable
+	VolumeNetwork
+	// VolumeDevice is a phone, camera or media player reached through a
+	// device protocol such as MTP rather than a block device.
+	VolumeDevice
+)
+
+func (k VolumeKind) String() string {
+	switch k {
+	case VolumeRemovable:
+		return "removable"
+	case VolumeNetwork:
+		return "network"
+	case VolumeDevice:
+		return "device"
+	default:
+		return "local"
+	}
+}
+
+// Volume is a jump target offered by the volumes menu.
+type Volume struct {
+	Name string
+	Path string
+	Kind VolumeKind
+}
+
+// ListVolumes returns mounted filesystems and attached devices worth jumping
+// to, platform volumes first. Sources that are unavailable are skipped.
+func ListVolumes(ctx context.Context) []Volume {
+	volumes := platformVolumes()
+	devices := gioVolumes(ctx)
+	if len(devices) == 0 {
+		// Without gio, locations the desktop already mounted are still
+		// reachable through gvfsd-fuse.
+		devices = gvfsFuseVolumes()
+	}
+	volumes = append(volumes, devices...)
+	return dedupeVolumes(volumes)
+}
+
+// volumeKindForScheme classifies a GVFS backend for the volumes menu.
+func volumeKindForScheme(scheme string) VolumeKind {
+	switch class := gioPathClass(scheme); {
+	case class.Device:
+		return VolumeDevice
+	case class.Network:
+		return VolumeNetwork
+	default:
+		return VolumeLocal
+	}
+}
+
+func dedupeVolumes(volumes []Volume) []Volume {
+	seen := make(map[string]bool, len(volumes))
+	out := volumes[:0]
+	for _, v := range volumes {
+		if v.Path == "" || seen[v.Path] {
+			continue
+		}
+		seen[v.Path] = true
+		if v.Name == "" {
+			v.Name = v.Path
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+// parseGioMountList extracts GVFS locations from `gio mount -l -i` output:
+// mounts with a gio URI and the activation roots of volumes that are not
+// mounted yet, such as a phone that has just been plugged in. Local file://
+// mounts are left to the platform volume list.
+func parseGioMountList(output string) []Volume {
+	var volumes []Volume
+	add := func(name, uri string) {
+		loc, ok := parseGioURI(strings.TrimSpace(uri))
+		if !ok {
+			return
+		}
+		volumes = append(volumes, Volume{Name: strings.TrimSpace(name), Path: loc.display(), Kind: volumeKindForScheme(loc.scheme)})
+	}
+
+	volumeName := ""
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "Drive("):
+			volumeName = ""
+		case strings.HasPrefix(trimmed, "Volume("):
+			if _, name, ok := strings.Cut(trimmed, ": "); ok {
+				volumeName = name
+			}
+		case strings.HasPrefix(trimmed, "Mount("):
+			_, rest, ok := strings.Cut(trimmed, ": ")
+			if !ok {
+				continue
+			}
+			if i := strings.LastIndex(rest, " -> "); i >= 0 {
+				add(rest[:i], rest[i+len(" -> "):])
+			}
+		case strings.HasPrefix(trimmed, "activation_root="):
+			add(volumeName, strings.TrimPrefix(trimmed, "activation_root="))
+		}
+	}
+	return dedupeVolumes(volumes)
+}
@@ -0,0 +1,50 @@
+package fileinfo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseGioMountList(t *testing.T) {
+	output := `Drive(0): Samsung SSD
+  Type: GProxyDrive (GProxyVolumeMonitorUDisks2)
+  Volume(0): root
+    Type: GProxyVolume (GProxyVolumeMonitorUDisks2)
+    Mount(0): root -> file:///
+Volume(0): Pixel 7
+  Type: GProxyVolume (GProxyVolumeMonitorMTP)
+  activation_root=mtp://Google_Pixel_7_28011FDH2000VX/
+  can_mount=1
+Volume(1): Canon EOS
+  Type: GProxyVolume (GProxyVolumeMonitorGPhoto2)
+  activation_root=gphoto2://Canon_EOS/
+  Mount(0): Canon EOS -> gphoto2://Canon_EOS/
+Mount(0): files on nas.local -> sftp://me@nas.local/
+Mount(1): share on server -> smb://server/share/
+`
+	got := parseGioMountList(output)
+	want := []Volume{
+		{Name: "Pixel 7", Path: "mtp://Google_Pixel_7_28011FDH2000VX/", Kind: VolumeDevice},
+		{Name: "Canon EOS", Path: "gphoto2://Canon_EOS/", Kind: VolumeDevice},
+		{Name: "files on nas.local", Path: "sftp://me@nas.local/", Kind: VolumeNetwork},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseGioMountList = %+v, want %+v", got, want)
+	}
+}
+
+func TestDedupeVolumesKeepsFirstAndFillsName(t *testing.T) {
+	got := dedupeVolumes([]Volume{
+		{Name: "Home", Path: "/home/me"},
+		{Name: "", Path: "/mnt/data"},
+		{Name: "dup", Path: "/home/me"},
+		{Name: "empty"},
+	})
+	want := []Volume{
+		{Name: "Home", Path: "/home/me"},
+		{Name: "/mnt/data", Path: "/mnt/data"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("dedupeVolumes = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,134 @@
+//go:build !windows
+// +build !windows
+
+package fileinfo
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// volumeMountParents are the directories desktop automounters and admins use
+// for removable and extra filesystems.
+var volumeMountParents = []string{"/media/", "/run/media/", "/mnt/"}
+
+func platformVolumes() []Volume {
+	volumes := []Volume{{Name: "/", Path: "/", Kind: VolumeLocal}}
+	if home, err := os.UserHomeDir(); err == nil && home != "" {
+		volumes = append(volumes, Volume{Name: "Home", Path: home, Kind: VolumeLocal})
+	}
+	if entries, err := readProcSelfMountInfo(); err == nil {
+		return append(volumes, volumesFromMountInfo(entries, isRemovableBlockDevice)...)
+	}
+	return append(volumes, volumesFromDirectory("/Volumes")...)
+}
+
+// volumesFromMountInfo keeps mounts below the usual mount parents plus
+// network filesystems mounted anywhere. The gvfsd-fuse root is skipped; its
+// locations are listed by gio instead, or by gvfsFuseVolumes without it.
+func volumesFromMountInfo(entries []mountInfoEntry, removable func(majorMinor string) bool) []Volume {
+	var volumes []Volume
+	for _, entry := range entries {
+		mountPoint := filepath.Clean(entry.mountPoint)
+		if strings.HasPrefix(entry.fsType, "fuse.gvfsd") {
+			continue
+		}
+		network := isNetworkFilesystemType(entry.fsType)
+		if !network && !underVolumeMountParent(mountPoint) {
+			continue
+		}
+		kind := VolumeLocal
+		switch {
+		case network:
+			kind = VolumeNetwork
+		case removable != nil && removable(entry.majorMinor):
+			kind = VolumeRemovable
+		}
+		volumes = append(volumes, Volume{Name: filepath.Base(mountPoint), Path: mountPoint, Kind: kind})
+	}
+	return volumes
+}
+
+// gvfsFuseVolumes lists the locations gvfsd-fuse exposes, such as a phone
+// the desktop mounted over MTP.
+func gvfsFuseVolumes() []Volume {
+	entries, err := readProcSelfMountInfo()
+	if err != nil {
+		return nil
+	}
+	return gvfsFuseVolumesFrom(entries)
+}
+
+func gvfsFuseVolumesFrom(entries []mountInfoEntry) []Volume {
+	var volumes []Volume
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.fsType, "fuse.gvfsd") {
+			continue
+		}
+		dirs, err := os.ReadDir(entry.mountPoint)
+		if err != nil {
+			continue
+		}
+		for _, dir := range dirs {
+			if volume, ok := gvfsFuseVolume(entry.mountPoint, dir.Name()); ok {
+				volumes = append(volumes, volume)
+			}
+		}
+	}
+	return volumes
+}
+
+// gvfsFuseVolume names a gvfsd-fuse directory such as
+// "mtp:host=Google_Pixel_7_1A2B" or "smb-share:server=nas,share=media" after
+// its host or share.
+func gvfsFuseVolume(root, name string) (Volume, bool) {
+	scheme, params, ok := strings.Cut(name, ":")
+	if !ok || scheme == "" {
+		return Volume{}, false
+	}
+	values := map[string]string{}
+	for _, param := range strings.Split(params, ",") {
+		if key, value, ok := strings.Cut(param, "="); ok {
+			if unescaped, err := url.PathUnescape(value); err == nil {
+				value = unescaped
+			}
+			values[key] = value
+		}
+	}
+	label := name
+	switch {
+	case values["share"] != "":
+		label = values["server"] + "/" + values["share"]
+	case values["host"] != "":
+		label = values["host"]
+	}
+	return Volume{Name: label, Path: filepath.Join(root, name), Kind: volumeKindForScheme(scheme)}, true
+}
+
+func underVolumeMountParent(mountPoint string) bool {
+	for _, parent := range volumeMountParents {
+		if strings.HasPrefix(mountPoint, parent) {
+			return true
+		}
+	}
+	return false
+}
+
+// volumesFromDirectory lists the mount points in a /Volumes-style directory,
+// skipping the symlink macOS keeps for the boot volume.
+func volumesFromDirectory(dir string) []Volume {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var volumes []Volume
+	for _, entry := range entries {
+		if entry.Type()&os.ModeSymlink != 0 || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		volumes = append(volumes, Volume{Name: entry.Name(), Path: filepath.Join(dir, entry.Name()), Kind: VolumeLocal})
+	}
+	return volumes
+}
@@ -0,0 +1,45 @@
+//go:build windows
+// +build windows
+
+package fileinfo
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+const driveCDROM = 5
+
+func platformVolumes() []Volume {
+	var volumes []Volume
+	drives, err := windows.GetLogicalDrives()
+	if err == nil {
+		for i := 0; i < 26; i++ {
+			if drives&(1<<uint(i)) == 0 {
+				continue
+			}
+			letter := string(rune('A'+i)) + ":"
+			root := letter + `\`
+			ptr, err := windows.UTF16PtrFromString(root)
+			if err != nil {
+				continue
+			}
+			kind := VolumeLocal
+			switch windows.GetDriveType(ptr) {
+			case driveRemote:
+				kind = VolumeNetwork
+			case driveRemovable, driveCDROM:
+				kind = VolumeRemovable
+			}
+			volumes = append(volumes, Volume{Name: letter, Path: root, Kind: kind})
+		}
+	}
+	if home, err := os.UserHomeDir(); err == nil && home != "" {
+		volumes = append(volumes, Volume{Name: "Home", Path: home, Kind: VolumeLocal})
+	}
+	return volumes
+}
+
+// gvfsFuseVolumes is empty on Windows, which has no GVFS.
+func gvfsFuseVolumes() []Volume { return nil }
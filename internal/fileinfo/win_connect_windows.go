@@ -104,9 +104,7 @@ func ensureWindowsConnection(p Parsed, native string) error {
 	}
 	ret, err := addConnection(host, share, user, creds.Password)
 	if ret == NO_ERROR && err == nil {
-		if store := currentSecretStore(); creds.Persist && store != nil {
-			_ = store.Set(host, share, creds.Domain, creds.Username, creds.Password)
-		}
+		persistCredentials(host, share, creds)
 		return nil
 	}
 	// 1219 conflict: do not attempt any disconnects here; leave to caller/UI.
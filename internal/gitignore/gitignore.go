@@ -0,0 +1,357 @@
+// Package gitignore decides which entries of a local directory git would
+// ignore. It reads the same rule files git does: the global excludes file,
+// the repository's info/exclude, and every .gitignore from the repository
+// root down to the directory being listed, with later and deeper rules
+// taking precedence.
+//
+// Only the entries of the listed directory are judged. A directory that is
+// itself ignored still lists its contents, so a filtered view never hides
+// everything under the directory the user chose to open.
+package gitignore
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// rule is one pattern line of an ignore file.
+type rule struct {
+	base     string   // directory of the ignore file, slash-separated and relative to the repository root
+	segments []string // pattern split at "/"
+	negate   bool     // "!pattern" re-includes what earlier rules ignored
+	dirOnly  bool     // "pattern/" only matches directories
+	anchored bool     // a "/" before the end ties the pattern to base
+}
+
+// Filter answers whether entries are ignored, caching the repository root
+// and rules of each directory it is asked about. A Filter sees ignore files
+// as they were when it first read them; make a new one to pick up edits.
+type Filter struct {
+	mu     sync.Mutex
+	dirs   map[string]*directory
+	global []rule
+	loaded bool
+
+	// homeDir and configDir locate the global git configuration. Tests
+	// replace them.
+	homeDir   func() (string, error)
+	configDir func() string
+}
+
+// directory holds what a Filter learned about one directory. root is empty
+// outside a repository.
+type directory struct {
+	root  string
+	rules []rule
+}
+
+// NewFilter returns a Filter that reads the user's global excludes.
+func NewFilter() *Filter {
+	return &Filter{
+		dirs:      make(map[string]*directory),
+		homeDir:   os.UserHomeDir,
+		configDir: gitConfigDir,
+	}
+}
+
+// Ignored reports whether git ignores the entry at p, a native path.
+// Entries outside a repository and the repository's own .git are never
+// ignored.
+func (f *Filter) Ignored(p string, isDir bool) bool {
+	p = filepath.Clean(p)
+	dir := f.directory(filepath.Dir(p))
+	if dir.root == "" {
+		return false
+	}
+	rel, err := filepath.Rel(dir.root, p)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == ".git" {
+		return false
+	}
+	ignored := false
+	for _, r := range f.rulesFor(dir) {
+		if r.matches(rel, isDir) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+// InRepository reports whether dir lies inside a git repository.
+func (f *Filter) InRepository(dir string) bool {
+	return f.directory(filepath.Clean(dir)).root != ""
+}
+
+func (f *Filter) rulesFor(dir *directory) []rule {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.loaded {
+		f.loaded = true
+		f.global = readRules(f.globalExcludesFile(), "")
+	}
+	rules := make([]rule, 0, len(f.global)+len(dir.rules))
+	rules = append(rules, f.global...)
+	return append(rules, dir.rules...)
+}
+
+// directory returns the cached repository root and rules for dir, reading
+// them on first use.
+func (f *Filter) directory(dir string) *directory {
+	f.mu.Lock()
+	if d, ok := f.dirs[dir]; ok {
+		f.mu.Unlock()
+		return d
+	}
+	f.mu.Unlock()
+
+	d := &directory{}
+	if root, gitDir, ok := findRepository(dir); ok {
+		d.root = root
+		d.rules = readRules(filepath.Join(gitDir, "info", "exclude"), "")
+		rel, _ := filepath.Rel(root, dir)
+		base := ""
+		d.rules = append(d.rules, readRules(filepath.Join(root, ".gitignore"), base)...)
+		if rel != "." {
+			for _, name := range strings.Split(filepath.ToSlash(rel), "/") {
+				base = path.Join(base, name)
+				d.rules = append(d.rules, readRules(filepath.Join(root, filepath.FromSlash(base), ".gitignore"), base)...)
+			}
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.dirs[dir] = d
+	return d
+}
+
+// findRepository walks up from dir to the nearest directory holding .git.
+// A .git file, as in worktrees and submodules, names the git directory.
+func findRepository(dir string) (root, gitDir string, ok bool) {
+	for {
+		candidate := filepath.Join(dir, ".git")
+		if info, err := os.Stat(candidate); err == nil {
+			if info.IsDir() {
+				return dir, candidate, true
+			}
+			if target, ok := readGitDirFile(candidate); ok {
+				if !filepath.IsAbs(target) {
+					target = filepath.Join(dir, target)
+				}
+				return dir, target, true
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", false
+		}
+		dir = parent
+	}
+}
+
+func readGitDirFile(name string) (string, bool) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return "", false
+	}
+	line, _, _ := strings.Cut(string(data), "\n")
+	target, ok := strings.CutPrefix(strings.TrimSpace(line), "gitdir:")
+	if !ok {
+		return "", false
+	}
+	return filepath.FromSlash(strings.TrimSpace(target)), true
+}
+
+// globalExcludesFile returns core.excludesFile from the user's git
+// configuration, or git's default of $XDG_CONFIG_HOME/git/ignore.
+func (f *Filter) globalExcludesFile() string {
+	home, _ := f.homeDir()
+	configDir := f.configDir()
+	var candidates []string
+	if configDir != "" {
+		candidates = append(candidates, filepath.Join(configDir, "git", "config"))
+	}
+	if home != "" {
+		candidates = append(candidates, filepath.Join(home, ".gitconfig"))
+	}
+	// ~/.gitconfig is read after the XDG file, so its setting wins, as in git.
+	excludes := ""
+	for _, name := range candidates {
+		if value, ok := readCoreExcludesFile(name); ok {
+			excludes = value
+		}
+	}
+	if excludes != "" {
+		if rest, ok := strings.CutPrefix(excludes, "~/"); ok && home != "" {
+			excludes = filepath.Join(home, filepath.FromSlash(rest))
+		}
+		return excludes
+	}
+	if configDir == "" {
+		return ""
+	}
+	return filepath.Join(configDir, "git", "ignore")
+}
+
+func gitConfigDir() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return dir
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".config")
+	}
+	return ""
+}
+
+// readCoreExcludesFile reads excludesFile from the [core] section of a git
+// configuration file. Includes and quoting beyond plain double quotes are
+// not followed.
+func readCoreExcludesFile(name string) (string, bool) {
+	file, err := os.Open(name)
+	if err != nil {
+		return "", false
+	}
+	defer file.Close()
+
+	inCore := false
+	value, found := "", false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if line[0] == '[' {
+			section := strings.TrimSpace(strings.Trim(line, "[]"))
+			inCore = strings.EqualFold(section, "core")
+			continue
+		}
+		if !inCore {
+			continue
+		}
+		key, v, ok := strings.Cut(line, "=")
+		if !ok || !strings.EqualFold(strings.TrimSpace(key), "excludesfile") {
+			continue
+		}
+		value, found = strings.Trim(strings.TrimSpace(v), `"`), true
+	}
+	return value, found
+}
+
+// readRules parses an ignore file whose patterns are relative to base. A
+// missing file has no rules.
+func readRules(name, base string) []rule {
+	if name == "" {
+		return nil
+	}
+	file, err := os.Open(name)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var rules []rule
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if r, ok := parseRule(scanner.Text(), base); ok {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// parseRule parses one line of an ignore file. Blank lines and comments
+// yield no rule.
+func parseRule(line, base string) (rule, bool) {
+	line = strings.TrimSuffix(line, "\r")
+	if line == "" || line[0] == '#' {
+		return rule{}, false
+	}
+	line = trimTrailingSpaces(line)
+	r := rule{base: base}
+	if line[0] == '!' {
+		r.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		r.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return rule{}, false
+	}
+	r.anchored = strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+	r.segments = strings.Split(line, "/")
+	for i, segment := range r.segments {
+		// fnmatch negates a bracket expression with "!", path.Match with "^".
+		r.segments[i] = strings.ReplaceAll(segment, "[!", "[^")
+	}
+	return r, true
+}
+
+// trimTrailingSpaces drops trailing spaces unless escaped with a backslash.
+func trimTrailingSpaces(line string) string {
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, `\ `) {
+		line = line[:len(line)-1]
+	}
+	if strings.HasSuffix(line, `\ `) {
+		line = line[:len(line)-2] + " "
+	}
+	return line
+}
+
+// matches reports whether rel, a slash-separated path relative to the
+// repository root, matches the rule.
+func (r rule) matches(rel string, isDir bool) bool {
+	if r.dirOnly && !isDir {
+		return false
+	}
+	if r.base != "" {
+		rest, ok := strings.CutPrefix(rel, r.base+"/")
+		if !ok {
+			return false
+		}
+		rel = rest
+	}
+	names := strings.Split(rel, "/")
+	if !r.anchored {
+		return matchSegments(r.segments, names[len(names)-1:])
+	}
+	return matchSegments(r.segments, names)
+}
+
+// matchSegments matches path segments against pattern segments, where "**"
+// stands for any number of segments.
+func matchSegments(pattern, names []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			if len(pattern) == 1 {
+				return len(names) > 0
+			}
+			for i := 0; i <= len(names); i++ {
+				if matchSegments(pattern[1:], names[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(names) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], names[0]); err != nil || !ok {
+			return false
+		}
+		pattern, names = pattern[1:], names[1:]
+	}
+	return len(names) == 0
+}
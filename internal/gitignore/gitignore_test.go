@@ -0,0 +1,121 @@
+package gitignore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newTestFilter returns a Filter whose global git configuration lives in
+// home, so the user's own excludes do not leak into tests.
+func newTestFilter(home string) *Filter {
+	f := NewFilter()
+	f.homeDir = func() (string, error) { return home, nil }
+	f.configDir = func() string { return filepath.Join(home, ".config") }
+	return f
+}
+
+func TestFilterAppliesNestedGitignores(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(root, ".gitignore"), "# build output\n*.o\n/bin/\nlogs/\n!keep.o\n")
+	writeFile(t, filepath.Join(root, "src", ".gitignore"), "generated_*.go\n!/important.o\n")
+	writeFile(t, filepath.Join(root, ".git", "info", "exclude"), "scratch.txt\n")
+	f := newTestFilter(t.TempDir())
+
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"main.o", false, true},
+		{"keep.o", false, false},
+		{"bin", true, true},
+		{"bin", false, false},
+		{"src/bin", true, false},
+		{"src/logs", true, true},
+		{"src/util.o", false, true},
+		{"src/important.o", false, false},
+		{"src/generated_api.go", false, true},
+		{"generated_api.go", false, false},
+		{"src/deep/generated_api.go", false, true},
+		{"scratch.txt", false, true},
+		{"main.go", false, false},
+		{".git", true, false},
+	}
+	for _, tt := range tests {
+		if got := f.Ignored(filepath.Join(root, filepath.FromSlash(tt.path)), tt.isDir); got != tt.want {
+			t.Errorf("Ignored(%s, dir=%v) = %v, want %v", tt.path, tt.isDir, got, tt.want)
+		}
+	}
+}
+
+func TestFilterDoubleStarAndCharacterClasses(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(root, ".gitignore"), "docs/**/*.pdf\n**/tmp\nout/**\nv[!0-9].txt\ntrailing\\ \n")
+	f := newTestFilter(t.TempDir())
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"docs/a.pdf", true},
+		{"docs/x/y/a.pdf", true},
+		{"a.pdf", false},
+		{"a/b/tmp", true},
+		{"out/file", true},
+		{"out", false},
+		{"vx.txt", true},
+		{"v1.txt", false},
+		{"trailing ", true},
+	}
+	for _, tt := range tests {
+		if got := f.Ignored(filepath.Join(root, filepath.FromSlash(tt.path)), false); got != tt.want {
+			t.Errorf("Ignored(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestFilterReadsGlobalExcludesFile(t *testing.T) {
+	home := t.TempDir()
+	writeFile(t, filepath.Join(home, ".gitconfig"), "[user]\n\tname = someone\n[core]\n\texcludesFile = ~/global-ignore\n")
+	writeFile(t, filepath.Join(home, "global-ignore"), ".DS_Store\n")
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ".git"), "gitdir: ../elsewhere/.git\n")
+	f := newTestFilter(home)
+
+	if !f.Ignored(filepath.Join(root, "sub", ".DS_Store"), false) {
+		t.Fatal("global excludes file was not applied")
+	}
+	if !f.InRepository(filepath.Join(root, "sub")) {
+		t.Fatal("a .git file should mark the repository root")
+	}
+}
+
+func TestFilterOutsideRepositoryIgnoresNothing(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, ".gitignore"), "*\n")
+	f := newTestFilter(t.TempDir())
+
+	if f.InRepository(dir) {
+		t.Skip("temporary directory is inside a git repository")
+	}
+	if f.Ignored(filepath.Join(dir, "file.txt"), false) {
+		t.Fatal("entries outside a repository should never be ignored")
+	}
+}
@@ -0,0 +1,242 @@
+package jobs
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Audit operations recorded besides the job types.
+const (
+	AuditRename          = "rename"
+	AuditCreateDirectory = "createDirectory"
+	AuditCreateFile      = "createFile"
+)
+
+// AuditEntry is one line of the operation audit log. Operation is a job Type
+// or one of the Audit* operations; Outcome is a finished job Status.
+type AuditEntry struct {
+	Time        time.Time    `json:"time"`
+	Operation   string       `json:"operation"`
+	Mode        string       `json:"mode,omitempty"` // delete mode
+	Sources     []string     `json:"sources"`
+	Destination string       `json:"destination,omitempty"`
+	Outcome     Status       `json:"outcome"`
+	DoneFiles   int          `json:"doneFiles,omitempty"`
+	TotalFiles  int          `json:"totalFiles,omitempty"`
+	Error       string       `json:"error,omitempty"`
+	Failures    []JobFailure `json:"failures,omitempty"`
+}
+
+// AuditLog appends entries to a JSON Lines file, one entry per line. The file
+// is only ever appended to; nmf never rewrites or trims it.
+type AuditLog struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewAuditLog returns an audit log writing to path. The file and its
+// directory are created on the first entry.
+func NewAuditLog(path string) *AuditLog {
+	return &AuditLog{path: path}
+}
+
+// Path returns the log file path.
+func (l *AuditLog) Path() string {
+	return l.path
+}
+
+// Append writes entry as one line. A nil log records nothing.
+func (l *AuditLog) Append(entry AuditEntry) error {
+	if l == nil {
+		return nil
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error marshaling audit entry: %w", err)
+	}
+	data = append(data, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return fmt.Errorf("error creating audit log directory: %w", err)
+	}
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("error opening audit log: %w", err)
+	}
+	// One write per line keeps concurrent appenders from interleaving.
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("error writing audit log: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("error closing audit log: %w", err)
+	}
+	return nil
+}
+
+// ReadAuditLog returns the entries of the log at path, oldest first. A
+// missing file is an empty log. Lines that do not parse, such as one cut
+// short by a crash, are skipped and counted in skipped.
+func ReadAuditLog(path string) (entries []AuditEntry, skipped int, err error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("error reading audit log: %w", err)
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if trimmed := strings.TrimSpace(string(line)); trimmed != "" {
+			var entry AuditEntry
+			if json.Unmarshal([]byte(trimmed), &entry) != nil {
+				skipped++
+			} else {
+				entries = append(entries, entry)
+			}
+		}
+		if readErr == io.EOF {
+			return entries, skipped, nil
+		}
+		if readErr != nil {
+			return entries, skipped, fmt.Errorf("error reading audit log: %w", readErr)
+		}
+	}
+}
+
+// FormatAuditEntries renders entries as text for the viewer, newest first,
+// one block per entry.
+func FormatAuditEntries(entries []AuditEntry) string {
+	var b strings.Builder
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		operation := e.Operation
+		if e.Mode != "" {
+			operation += " (" + e.Mode + ")"
+		}
+		fmt.Fprintf(&b, "%s  %s  %s", e.Time.Local().Format("2006-01-02 15:04:05"), operation, e.Outcome)
+		if e.TotalFiles > 0 {
+			fmt.Fprintf(&b, "  %d/%d files", e.DoneFiles, e.TotalFiles)
+		}
+		b.WriteByte('\n')
+		for _, source := range e.Sources {
+			fmt.Fprintf(&b, "  from %s\n", source)
+		}
+		if e.Destination != "" {
+			fmt.Fprintf(&b, "  to   %s\n", e.Destination)
+		}
+		if e.Error != "" {
+			fmt.Fprintf(&b, "  error: %s\n", e.Error)
+		}
+		for _, failure := range e.Failures {
+			verb := "failed"
+			if failure.Warning {
+				verb = "warning"
+			}
+			fmt.Fprintf(&b, "  %s %s: %s\n", verb, failure.Path, failure.Error)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// WriteAuditCSV writes entries as CSV, oldest first, with one row per
+// source so spreadsheets can filter by path.
+func WriteAuditCSV(w io.Writer, entries []AuditEntry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"time", "operation", "mode", "source", "destination", "outcome", "doneFiles", "totalFiles", "error"}); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		sources := e.Sources
+		if len(sources) == 0 {
+			sources = []string{""}
+		}
+		for _, source := range sources {
+			record := []string{
+				e.Time.Format(time.RFC3339),
+				e.Operation,
+				e.Mode,
+				source,
+				e.Destination,
+				string(e.Outcome),
+				strconv.Itoa(e.DoneFiles),
+				strconv.Itoa(e.TotalFiles),
+				e.Error,
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// SetAuditLog records every job the manager finishes running in log; nil
+// stops recording. Jobs canceled before they started changed nothing and
+// are not recorded.
+func (m *Manager) SetAuditLog(log *AuditLog) {
+	m.mu.Lock()
+	m.audit = log
+	m.mu.Unlock()
+}
+
+// changesFiles reports whether j can modify the file system. Checksum jobs
+// only do when they write sidecar files; verify jobs never do.
+func (j *Job) changesFiles() bool {
+	switch j.Type {
+	case TypeChecksum:
+		return j.Options.Checksum.WriteSidecars
+	case TypeVerify:
+		return false
+	default:
+		return true
+	}
+}
+
+func (m *Manager) auditJob(j *Job) {
+	m.mu.Lock()
+	log := m.audit
+	m.mu.Unlock()
+	if log == nil || !j.changesFiles() {
+		return
+	}
+	j.mu.RLock()
+	entry := AuditEntry{
+		Time:        j.CompletedAt,
+		Operation:   string(j.Type),
+		Sources:     append([]string(nil), j.Sources...),
+		Destination: j.DestDir,
+		Outcome:     j.Status,
+		DoneFiles:   j.DoneFiles,
+		TotalFiles:  j.TotalFiles,
+		Error:       j.Error,
+		Failures:    append([]JobFailure(nil), j.Failures...),
+	}
+	if j.Type == TypeDelete {
+		entry.Mode = string(j.DeleteMode)
+	}
+	j.mu.RUnlock()
+	if err := log.Append(entry); err != nil {
+		dbg("audit append failed id=%d err=%v", j.ID, err)
+	}
+}
@@ -0,0 +1,110 @@
+package jobs
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAuditLogRecordsFinishedJobs(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "state", "audit-log.jsonl")
+	src := filepath.Join(dir, "a.txt")
+	missing := filepath.Join(dir, "missing.txt")
+	dest := filepath.Join(dir, "dest")
+	if err := os.WriteFile(src, []byte("a"), 0644); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	if err := os.Mkdir(dest, 0755); err != nil {
+		t.Fatalf("mkdir dest: %v", err)
+	}
+
+	m := NewManager()
+	m.SetAuditLog(NewAuditLog(logPath))
+	moved := m.EnqueueMove([]string{src}, dest)
+	waitForJobStatus(t, moved, StatusCompleted)
+	entries := waitForAuditEntries(t, logPath, 1)
+	failed := m.EnqueueDelete([]string{missing}, DeleteModePermanent)
+	waitForJobStatus(t, failed, StatusFailed)
+	entries = waitForAuditEntries(t, logPath, 2)
+
+	move := entries[0]
+	if move.Operation != string(TypeMove) || move.Outcome != StatusCompleted || move.Destination != dest {
+		t.Fatalf("move entry = %+v, want completed move to %s", move, dest)
+	}
+	if len(move.Sources) != 1 || move.Sources[0] != src || move.Time.IsZero() {
+		t.Fatalf("move entry sources=%v time=%v", move.Sources, move.Time)
+	}
+	del := entries[1]
+	if del.Operation != string(TypeDelete) || del.Mode != string(DeleteModePermanent) || del.Outcome != StatusFailed || len(del.Failures) == 0 {
+		t.Fatalf("delete entry = %+v, want failed permanent delete with failures", del)
+	}
+}
+
+func TestAuditLogAppendsAndSkipsBrokenLines(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "audit-log.jsonl")
+	log := NewAuditLog(logPath)
+	if err := log.Append(AuditEntry{Operation: AuditRename, Sources: []string{"/a/old"}, Destination: "/a/new", Outcome: StatusCompleted}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	f, err := os.OpenFile(logPath, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatalf("open log: %v", err)
+	}
+	if _, err := f.WriteString(`{"operation":"copy","sou` + "\n"); err != nil {
+		t.Fatalf("write broken line: %v", err)
+	}
+	f.Close()
+	if err := log.Append(AuditEntry{Operation: AuditCreateDirectory, Sources: []string{"/a/dir"}, Outcome: StatusFailed, Error: "exists"}); err != nil {
+		t.Fatalf("Append after broken line: %v", err)
+	}
+
+	entries, skipped, err := ReadAuditLog(logPath)
+	if err != nil {
+		t.Fatalf("ReadAuditLog: %v", err)
+	}
+	if len(entries) != 2 || skipped != 1 {
+		t.Fatalf("entries=%d skipped=%d, want 2 and 1", len(entries), skipped)
+	}
+	if entries[0].Operation != AuditRename || entries[1].Operation != AuditCreateDirectory {
+		t.Fatalf("operations = %s, %s; want oldest first", entries[0].Operation, entries[1].Operation)
+	}
+
+	text := FormatAuditEntries(entries)
+	if strings.Index(text, "createDirectory") > strings.Index(text, "rename") {
+		t.Fatalf("viewer text should list the newest entry first:\n%s", text)
+	}
+	var csv bytes.Buffer
+	if err := WriteAuditCSV(&csv, entries); err != nil {
+		t.Fatalf("WriteAuditCSV: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(csv.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "time,operation,") || !strings.Contains(lines[1], ",rename,,/a/old,/a/new,completed,") {
+		t.Fatalf("csv = %q", csv.String())
+	}
+}
+
+func TestReadAuditLogMissingFileIsEmpty(t *testing.T) {
+	entries, skipped, err := ReadAuditLog(filepath.Join(t.TempDir(), "none.jsonl"))
+	if err != nil || len(entries) != 0 || skipped != 0 {
+		t.Fatalf("ReadAuditLog(missing) = %v, %d, %v; want empty", entries, skipped, err)
+	}
+}
+
+func waitForAuditEntries(t *testing.T, path string, n int) []AuditEntry {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		entries, _, err := ReadAuditLog(path)
+		if err == nil && len(entries) >= n {
+			return entries
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("audit entries = %d (err %v), want %d", len(entries), err, n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,615 @@
+package jobs
+
+import (
+	"bufio"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ChecksumAlgorithm selects the digest a checksum or verify job computes.
+type ChecksumAlgorithm string
+
+const (
+	ChecksumMD5    ChecksumAlgorithm = "md5"
+	ChecksumSHA1   ChecksumAlgorithm = "sha1"
+	ChecksumSHA256 ChecksumAlgorithm = "sha256"
+)
+
+// ChecksumAlgorithms lists the supported digests, strongest first.
+var ChecksumAlgorithms = []ChecksumAlgorithm{ChecksumSHA256, ChecksumSHA1, ChecksumMD5}
+
+// Label returns the conventional spelling of a, such as "SHA-256".
+func (a ChecksumAlgorithm) Label() string {
+	switch a {
+	case ChecksumMD5:
+		return "MD5"
+	case ChecksumSHA1:
+		return "SHA-1"
+	case ChecksumSHA256:
+		return "SHA-256"
+	default:
+		return string(a)
+	}
+}
+
+// Extension returns the sidecar file suffix of a, including the leading dot.
+func (a ChecksumAlgorithm) Extension() string {
+	return "." + string(a)
+}
+
+func (a ChecksumAlgorithm) newHash() (hash.Hash, error) {
+	switch a {
+	case ChecksumMD5:
+		return md5.New(), nil
+	case ChecksumSHA1:
+		return sha1.New(), nil
+	case ChecksumSHA256:
+		return sha256.New(), nil
+	default:
+		return nil, fmt.Errorf("unsupported checksum algorithm %q", a)
+	}
+}
+
+// hexLength is the length of a's digest written as hex.
+func (a ChecksumAlgorithm) hexLength() int {
+	switch a {
+	case ChecksumMD5:
+		return 2 * md5.Size
+	case ChecksumSHA1:
+		return 2 * sha1.Size
+	case ChecksumSHA256:
+		return 2 * sha256.Size
+	default:
+		return 0
+	}
+}
+
+// ChecksumAlgorithmForName returns the algorithm whose sidecar extension
+// name ends with, as in "photo.jpg.sha256". ok is false for other names.
+func ChecksumAlgorithmForName(name string) (ChecksumAlgorithm, bool) {
+	lower := strings.ToLower(name)
+	for _, a := range ChecksumAlgorithms {
+		if strings.HasSuffix(lower, a.Extension()) || strings.HasSuffix(lower, string(a)+"sum") || strings.HasSuffix(lower, string(a)+"sums") {
+			return a, true
+		}
+	}
+	return "", false
+}
+
+// ChecksumOptions configures TypeChecksum and TypeVerify jobs.
+type ChecksumOptions struct {
+	// Algorithm is the digest to compute. Verify jobs leave it empty to
+	// detect it from the checksum file's name or digest length.
+	Algorithm ChecksumAlgorithm
+	// WriteSidecars writes "<file>.<algorithm>" next to every hashed file
+	// in the format sha256sum and friends read back with -c.
+	WriteSidecars bool
+}
+
+// ChecksumStatus is the outcome for one file of a checksum or verify job.
+type ChecksumStatus string
+
+const (
+	// ChecksumComputed marks a digest computed by a checksum job.
+	ChecksumComputed ChecksumStatus = "computed"
+	ChecksumOK       ChecksumStatus = "ok"
+	ChecksumMismatch ChecksumStatus = "mismatch"
+	ChecksumMissing  ChecksumStatus = "missing"
+	ChecksumError    ChecksumStatus = "error"
+)
+
+// ChecksumResult is one file's line in a checksum or verify job's report.
+type ChecksumResult struct {
+	Path     string
+	Sum      string
+	Expected string // verify jobs only
+	Status   ChecksumStatus
+	Error    string
+}
+
+// ChecksumResults returns the per-file results recorded so far, in the
+// order the files were processed.
+func (j *Job) ChecksumResults() []ChecksumResult {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return append([]ChecksumResult(nil), j.checksums...)
+}
+
+func (j *Job) addChecksumResult(r ChecksumResult) {
+	j.mu.Lock()
+	j.checksums = append(j.checksums, r)
+	j.mu.Unlock()
+}
+
+// FormatChecksumResults renders results as a report: digests in the
+// "<sum>  <path>" form sha256sum prints, or "<path>: OK" lines for
+// verification, followed by the files that could not be checked. algorithm
+// names the digest of computed results.
+func FormatChecksumResults(algorithm ChecksumAlgorithm, results []ChecksumResult) string {
+	var b strings.Builder
+	var failed []ChecksumResult
+	counts := map[ChecksumStatus]int{}
+	for _, r := range results {
+		counts[r.Status]++
+		switch r.Status {
+		case ChecksumComputed:
+			fmt.Fprintf(&b, "%s  %s\n", r.Sum, r.Path)
+		case ChecksumOK:
+			fmt.Fprintf(&b, "%s: OK\n", r.Path)
+		default:
+			failed = append(failed, r)
+		}
+	}
+	if len(failed) > 0 {
+		if b.Len() > 0 {
+			b.WriteByte('\n')
+		}
+		for _, r := range failed {
+			switch r.Status {
+			case ChecksumMismatch:
+				fmt.Fprintf(&b, "%s: FAILED\n  expected %s\n  actual   %s\n", r.Path, r.Expected, r.Sum)
+			case ChecksumMissing:
+				fmt.Fprintf(&b, "%s: MISSING\n", r.Path)
+			default:
+				fmt.Fprintf(&b, "%s: ERROR %s\n", r.Path, r.Error)
+			}
+		}
+	}
+	var summary string
+	if verified := counts[ChecksumOK] + counts[ChecksumMismatch] + counts[ChecksumMissing]; verified > 0 {
+		summary = fmt.Sprintf("Verified %d file(s): %d OK, %d failed, %d missing", verified, counts[ChecksumOK], counts[ChecksumMismatch], counts[ChecksumMissing])
+	} else {
+		summary = fmt.Sprintf("%s of %d file(s)", algorithm.Label(), counts[ChecksumComputed])
+	}
+	if counts[ChecksumError] > 0 {
+		summary += fmt.Sprintf(", %d unreadable", counts[ChecksumError])
+	}
+	return summary + "\n\n" + b.String()
+}
+
+// EnqueueChecksum enqueues a job hashing every regular file in sources,
+// descending into directories without following links.
+func (m *Manager) EnqueueChecksum(sources []string, options ChecksumOptions) *Job {
+	return m.enqueue(TypeChecksum, sources, "", nil, TransferOptions{Checksum: options})
+}
+
+// EnqueueVerify enqueues a job checking the files listed in each checksum
+// file of sources, resolved relative to the checksum file's directory.
+func (m *Manager) EnqueueVerify(sources []string, options ChecksumOptions) *Job {
+	options.WriteSidecars = false
+	return m.enqueue(TypeVerify, sources, "", nil, TransferOptions{Checksum: options})
+}
+
+// runChecksumJob hashes each source tree. Unreadable files are reported and
+// skipped so one bad file does not hide the digests of the rest; the job
+// still fails at the end so the failures stay visible in the jobs list.
+func (m *Manager) runChecksumJob(j *Job) error {
+	algorithm := j.Options.Checksum.Algorithm
+	if _, err := algorithm.newHash(); err != nil {
+		return err
+	}
+	execCtx := newExecutionContext()
+	execCtx.jobCtx = j.ctx
+	defer func() {
+		if err := execCtx.close(); err != nil {
+			dbg("job %d: execution context close error: %v", j.ID, err)
+		}
+	}()
+	sourceBytes := measureSources(j, execCtx)
+	if canceled(j) {
+		return errCanceled
+	}
+	m.notify()
+
+	hasher := checksummer{j: j, execCtx: execCtx, options: j.Options.Checksum}
+	for i, src := range j.Sources {
+		if canceled(j) {
+			return errCanceled
+		}
+		j.mu.Lock()
+		j.CurrentSource = src
+		j.Message = algorithm.Label()
+		j.clearFileProgressLocked()
+		j.mu.Unlock()
+		m.notify()
+
+		hasher.top = src
+		p, err := resolveExecutionPath(src)
+		if err == nil {
+			err = hasher.add(p)
+		}
+		if errors.Is(err, errCanceled) {
+			return err
+		}
+		if err != nil {
+			hasher.fail(src, failingPath(err), err)
+		}
+		j.mu.Lock()
+		j.DoneFiles = i + 1
+		j.finishSourceBytesLocked(sourceBytes[i])
+		j.clearFileProgressLocked()
+		j.mu.Unlock()
+		m.notify()
+	}
+	if hasher.failed > 0 {
+		return fmt.Errorf("%d file(s) could not be hashed", hasher.failed)
+	}
+	return nil
+}
+
+// checksummer walks source trees for a checksum job.
+type checksummer struct {
+	j       *Job
+	execCtx *executionContext
+	options ChecksumOptions
+	top     string // source being walked, recorded with failures
+	failed  int
+}
+
+// add hashes p, or every regular file under it. Sidecars the job writes
+// itself are skipped inside directories so repeated runs do not hash them.
+func (c *checksummer) add(p executionPath) error {
+	if canceled(c.j) {
+		return errCanceled
+	}
+	fi, err := lstatPath(c.execCtx, p)
+	if err != nil {
+		return wrapPath(p.displayPath(), err)
+	}
+	if fi.IsDir() {
+		if isLinkLikeForTraversal(c.execCtx, p, fi) {
+			return nil
+		}
+		entries, err := readDir(c.execCtx, p)
+		if err != nil {
+			return wrapPath(p.displayPath(), err)
+		}
+		for _, e := range entries {
+			if c.options.WriteSidecars && strings.HasSuffix(strings.ToLower(e.Name()), c.options.Algorithm.Extension()) {
+				continue
+			}
+			err := c.add(joinPath(p, e.Name()))
+			if errors.Is(err, errCanceled) {
+				return err
+			}
+			if err != nil {
+				c.fail(c.top, failingPath(err), err)
+			}
+		}
+		return nil
+	}
+	if !fi.Mode().IsRegular() {
+		dbg("job %d: checksum skips %s", c.j.ID, p.displayPath())
+		return nil
+	}
+	sum, err := hashPath(c.j, c.execCtx, p, fi.Size(), c.options.Algorithm)
+	if err != nil {
+		return err
+	}
+	c.j.addChecksumResult(ChecksumResult{Path: p.displayPath(), Sum: sum, Status: ChecksumComputed})
+	if c.options.WriteSidecars {
+		return writeChecksumSidecar(c.execCtx, p, sum, c.options.Algorithm)
+	}
+	return nil
+}
+
+func (c *checksummer) fail(top, path string, err error) {
+	if path == "" {
+		path = top
+	}
+	c.failed++
+	c.j.addChecksumResult(ChecksumResult{Path: path, Status: ChecksumError, Error: err.Error()})
+	c.j.mu.Lock()
+	c.j.Failures = append(c.j.Failures, JobFailure{TopSource: top, Path: path, Error: err.Error()})
+	c.j.mu.Unlock()
+}
+
+// hashPath returns the hex digest of p's contents, reporting byte progress
+// as it reads.
+func hashPath(j *Job, execCtx *executionContext, p executionPath, size int64, algorithm ChecksumAlgorithm) (string, error) {
+	h, err := algorithm.newHash()
+	if err != nil {
+		return "", err
+	}
+	in, err := openReadPath(execCtx, p)
+	if err != nil {
+		return "", wrapPath(p.displayPath(), err)
+	}
+	defer in.Close()
+	j.beginFileProgress(p.displayPath(), max(size, 0))
+	buf := make([]byte, 1<<20)
+	for {
+		if canceled(j) {
+			return "", errCanceled
+		}
+		n, rerr := in.Read(buf)
+		if n > 0 {
+			h.Write(buf[:n])
+			j.addFileProgress(int64(n), false)
+		}
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			return "", wrapPath(p.displayPath(), rerr)
+		}
+	}
+	j.completeFileProgress()
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeChecksumSidecar replaces "<file>.<algorithm>" next to p with a
+// one-line checksum file naming p relative to it.
+func writeChecksumSidecar(execCtx *executionContext, p executionPath, sum string, algorithm ChecksumAlgorithm) error {
+	name := baseName(p)
+	sidecar := joinPath(dirPath(p), name+algorithm.Extension())
+	out, err := openWritePath(execCtx, sidecar, 0644)
+	if err != nil {
+		return wrapPath(sidecar.displayPath(), err)
+	}
+	_, err = io.WriteString(out, formatChecksumLine(sum, name))
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return wrapPath(sidecar.displayPath(), err)
+	}
+	return nil
+}
+
+// formatChecksumLine writes one line in the GNU coreutils format, escaping
+// names that contain a backslash or newline as sha256sum does.
+func formatChecksumLine(sum, name string) string {
+	if strings.ContainsAny(name, "\\\n") {
+		name = strings.NewReplacer("\\", "\\\\", "\n", "\\n").Replace(name)
+		return "\\" + sum + "  " + name + "\n"
+	}
+	return sum + "  " + name + "\n"
+}
+
+// checksumEntry is one line of a checksum file.
+type checksumEntry struct {
+	algorithm ChecksumAlgorithm
+	sum       string
+	name      string
+}
+
+// parseChecksumFile reads GNU ("<sum>  <name>", "<sum> *<name>") and BSD
+// ("SHA256 (<name>) = <sum>") lines. Blank lines and "#" comments are
+// ignored. Lines without an algorithm tag use algorithm, or are detected
+// from the digest length when it is empty.
+func parseChecksumFile(r io.Reader, algorithm ChecksumAlgorithm) ([]checksumEntry, error) {
+	var entries []checksumEntry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entry, ok := parseChecksumLine(line, algorithm)
+		if !ok {
+			return nil, fmt.Errorf("line %d: not a checksum line", lineNo)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("no checksum lines found")
+	}
+	return entries, nil
+}
+
+func parseChecksumLine(line string, algorithm ChecksumAlgorithm) (checksumEntry, bool) {
+	escaped := strings.HasPrefix(line, "\\")
+	if escaped {
+		line = line[1:]
+	}
+	var entry checksumEntry
+	if open := strings.Index(line, " ("); open > 0 {
+		if closing := strings.LastIndex(line, ") = "); closing > open {
+			tag := ChecksumAlgorithm(strings.ToLower(strings.ReplaceAll(line[:open], "-", "")))
+			if tag.hexLength() > 0 {
+				entry = checksumEntry{algorithm: tag, name: line[open+2 : closing], sum: line[closing+4:]}
+			}
+		}
+	}
+	if entry.sum == "" {
+		sum, name, ok := strings.Cut(line, " ")
+		if !ok || len(name) < 2 || (name[0] != ' ' && name[0] != '*') {
+			return checksumEntry{}, false
+		}
+		entry = checksumEntry{algorithm: algorithm, sum: sum, name: name[1:]}
+		if entry.algorithm == "" {
+			for _, a := range ChecksumAlgorithms {
+				if a.hexLength() == len(sum) {
+					entry.algorithm = a
+				}
+			}
+		}
+	}
+	if escaped {
+		entry.name = unescapeChecksumName(entry.name)
+	}
+	entry.sum = strings.ToLower(entry.sum)
+	if entry.name == "" || entry.algorithm.hexLength() != len(entry.sum) {
+		return checksumEntry{}, false
+	}
+	if _, err := hex.DecodeString(entry.sum); err != nil {
+		return checksumEntry{}, false
+	}
+	return entry, true
+}
+
+func unescapeChecksumName(name string) string {
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		if name[i] == '\\' && i+1 < len(name) {
+			i++
+			if name[i] == 'n' {
+				b.WriteByte('\n')
+				continue
+			}
+		}
+		b.WriteByte(name[i])
+	}
+	return b.String()
+}
+
+// runVerifyJob checks every file listed in the job's checksum files.
+// Mismatched, missing, and unreadable files fail the job after all entries
+// have been checked.
+func (m *Manager) runVerifyJob(j *Job) error {
+	execCtx := newExecutionContext()
+	execCtx.jobCtx = j.ctx
+	defer func() {
+		if err := execCtx.close(); err != nil {
+			dbg("job %d: execution context close error: %v", j.ID, err)
+		}
+	}()
+
+	type listedFile struct {
+		top   string
+		entry checksumEntry
+		path  executionPath
+		size  int64
+		err   error
+	}
+	var files []listedFile
+	for _, src := range j.Sources {
+		if canceled(j) {
+			return errCanceled
+		}
+		checksumFile, err := resolveExecutionPath(src)
+		if err != nil {
+			return wrapPath(src, err)
+		}
+		algorithm := j.Options.Checksum.Algorithm
+		if algorithm == "" {
+			algorithm, _ = ChecksumAlgorithmForName(baseName(checksumFile))
+		}
+		entries, err := readChecksumFile(execCtx, checksumFile, algorithm)
+		if err != nil {
+			return err
+		}
+		dir := dirPath(checksumFile)
+		for _, entry := range entries {
+			f := listedFile{top: src, entry: entry, path: checksumEntryPath(dir, entry.name)}
+			fi, err := statPath(execCtx, f.path)
+			if err != nil {
+				f.err = err
+			} else if !fi.Mode().IsRegular() {
+				f.err = errors.New("not a regular file")
+			} else {
+				f.size = fi.Size()
+			}
+			files = append(files, f)
+		}
+	}
+
+	var totalBytes int64
+	for _, f := range files {
+		totalBytes += f.size
+	}
+	j.mu.Lock()
+	j.TotalFiles = len(files)
+	j.TotalBytes = totalBytes
+	j.mu.Unlock()
+	m.notify()
+
+	failed := 0
+	for i, f := range files {
+		if canceled(j) {
+			return errCanceled
+		}
+		j.mu.Lock()
+		j.CurrentSource = f.top
+		j.Message = "verify " + f.entry.algorithm.Label()
+		j.clearFileProgressLocked()
+		j.mu.Unlock()
+		m.notify()
+
+		result := ChecksumResult{Path: f.path.displayPath(), Expected: f.entry.sum}
+		err := f.err
+		if err == nil {
+			result.Sum, err = hashPath(j, execCtx, f.path, f.size, f.entry.algorithm)
+		}
+		switch {
+		case errors.Is(err, errCanceled):
+			return err
+		case err != nil && errors.Is(err, os.ErrNotExist):
+			result.Status = ChecksumMissing
+			result.Error = "file not found"
+		case err != nil:
+			result.Status = ChecksumError
+			result.Error = err.Error()
+		case result.Sum != f.entry.sum:
+			result.Status = ChecksumMismatch
+			result.Error = "checksum mismatch"
+		default:
+			result.Status = ChecksumOK
+		}
+		j.addChecksumResult(result)
+		j.mu.Lock()
+		if result.Status != ChecksumOK {
+			failed++
+			j.Failures = append(j.Failures, JobFailure{TopSource: f.top, Path: result.Path, Error: result.Error})
+		}
+		j.DoneFiles = i + 1
+		j.clearFileProgressLocked()
+		j.mu.Unlock()
+		m.notify()
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d file(s) failed verification", failed, len(files))
+	}
+	return nil
+}
+
+func readChecksumFile(execCtx *executionContext, p executionPath, algorithm ChecksumAlgorithm) ([]checksumEntry, error) {
+	in, err := openReadPath(execCtx, p)
+	if err != nil {
+		return nil, wrapPath(p.displayPath(), err)
+	}
+	defer in.Close()
+	entries, err := parseChecksumFile(in, algorithm)
+	if err != nil {
+		return nil, wrapPath(p.displayPath(), err)
+	}
+	return entries, nil
+}
+
+// checksumEntryPath resolves a listed name against the checksum file's
+// directory. Names use "/" (or "\" as written on Windows); absolute local
+// names are used as they are.
+func checksumEntryPath(dir executionPath, name string) executionPath {
+	if dir.backend == backendLocal && filepath.IsAbs(name) {
+		out := dir
+		out.path = filepath.Clean(name)
+		out.raw = out.path
+		return out
+	}
+	p := dir
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if part == "." {
+			continue
+		}
+		if part == ".." {
+			p = dirPath(p)
+			continue
+		}
+		p = joinPath(p, part)
+	}
+	return p
+}
@@ -0,0 +1,116 @@
+package jobs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	sha256Hello = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	sha256World = "486ea46224d1bb4fb680f34f7c9ad96a8f24ec88be73ea8e5a6c65260e9cb8a7"
+	md5Hello    = "5d41402abc4b2a76b9719d911017c592"
+)
+
+func TestChecksumJobHashesTreeAndWritesSidecars(t *testing.T) {
+	tmp := t.TempDir()
+	writeChecksumTestFile(t, filepath.Join(tmp, "hello.txt"), "hello")
+	writeChecksumTestFile(t, filepath.Join(tmp, "sub", "world.txt"), "world")
+	writeChecksumTestFile(t, filepath.Join(tmp, "sub", "old.txt.sha256"), "stale")
+
+	m := NewManager()
+	j := m.EnqueueChecksum([]string{tmp}, ChecksumOptions{Algorithm: ChecksumSHA256, WriteSidecars: true})
+	waitForJobStatus(t, j, StatusCompleted)
+
+	sums := map[string]string{}
+	for _, r := range j.ChecksumResults() {
+		if r.Status != ChecksumComputed {
+			t.Fatalf("result %+v, want computed", r)
+		}
+		sums[filepath.Base(r.Path)] = r.Sum
+	}
+	if len(sums) != 2 || sums["hello.txt"] != sha256Hello || sums["world.txt"] != sha256World {
+		t.Fatalf("sums = %v, want hello.txt and world.txt only", sums)
+	}
+	data, err := os.ReadFile(filepath.Join(tmp, "sub", "world.txt.sha256"))
+	if err != nil {
+		t.Fatalf("ReadFile sidecar: %v", err)
+	}
+	if string(data) != sha256World+"  world.txt\n" {
+		t.Fatalf("sidecar = %q", data)
+	}
+	if snap := j.Snapshot(); snap.TotalBytes != int64(len("hello")+len("world")+len("stale")) || snap.DoneBytes != snap.TotalBytes {
+		t.Fatalf("bytes = %d/%d", snap.DoneBytes, snap.TotalBytes)
+	}
+}
+
+func TestVerifyJobReportsMismatchedAndMissingFiles(t *testing.T) {
+	tmp := t.TempDir()
+	writeChecksumTestFile(t, filepath.Join(tmp, "hello.txt"), "hello")
+	writeChecksumTestFile(t, filepath.Join(tmp, "sub", "world.txt"), "changed")
+	list := filepath.Join(tmp, "SHA256SUMS")
+	writeChecksumTestFile(t, list, strings.Join([]string{
+		"# made by hand",
+		sha256Hello + " *hello.txt",
+		sha256World + "  sub/world.txt",
+		"SHA256 (gone.txt) = " + sha256Hello,
+		"",
+	}, "\n"))
+
+	m := NewManager()
+	j := m.EnqueueVerify([]string{list}, ChecksumOptions{})
+	waitForJobStatus(t, j, StatusFailed)
+
+	got := map[string]ChecksumStatus{}
+	for _, r := range j.ChecksumResults() {
+		got[filepath.Base(r.Path)] = r.Status
+	}
+	want := map[string]ChecksumStatus{"hello.txt": ChecksumOK, "world.txt": ChecksumMismatch, "gone.txt": ChecksumMissing}
+	for name, status := range want {
+		if got[name] != status {
+			t.Fatalf("status of %s = %q, want %q (all %v)", name, got[name], status, got)
+		}
+	}
+	snap := j.Snapshot()
+	if snap.TotalFiles != 3 || snap.DoneFiles != 3 || len(snap.Failures) != 2 {
+		t.Fatalf("snapshot files %d/%d failures %v", snap.DoneFiles, snap.TotalFiles, snap.Failures)
+	}
+	report := FormatChecksumResults("", j.ChecksumResults())
+	if !strings.HasPrefix(report, "Verified 3 file(s): 1 OK, 1 failed, 1 missing") {
+		t.Fatalf("report = %q", report)
+	}
+}
+
+func TestParseChecksumLine(t *testing.T) {
+	tests := []struct {
+		line      string
+		algorithm ChecksumAlgorithm
+		want      checksumEntry
+		ok        bool
+	}{
+		{line: md5Hello + "  a b.txt", want: checksumEntry{ChecksumMD5, md5Hello, "a b.txt"}, ok: true},
+		{line: strings.ToUpper(sha256Hello) + " *bin", want: checksumEntry{ChecksumSHA256, sha256Hello, "bin"}, ok: true},
+		{line: "MD5 (x (1).txt) = " + md5Hello, want: checksumEntry{ChecksumMD5, md5Hello, "x (1).txt"}, ok: true},
+		{line: `\` + md5Hello + `  a\\b\nc`, want: checksumEntry{ChecksumMD5, md5Hello, "a\\b\nc"}, ok: true},
+		{line: md5Hello + "  file", algorithm: ChecksumSHA256},
+		{line: md5Hello + " file"},
+		{line: "not a checksum"},
+	}
+	for _, tt := range tests {
+		got, ok := parseChecksumLine(tt.line, tt.algorithm)
+		if ok != tt.ok || got != tt.want {
+			t.Fatalf("parseChecksumLine(%q) = %+v, %t; want %+v, %t", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func writeChecksumTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
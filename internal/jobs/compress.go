@@ -0,0 +1,433 @@
+package jobs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"nmf/internal/fileinfo"
+)
+
+// ArchiveFormat selects the container an archive job writes.
+type ArchiveFormat string
+
+const (
+	ArchiveFormatZip   ArchiveFormat = "zip"
+	ArchiveFormatTarGz ArchiveFormat = "tar.gz"
+)
+
+// ArchiveFormats lists the formats an archive job can create.
+var ArchiveFormats = []ArchiveFormat{ArchiveFormatZip, ArchiveFormatTarGz}
+
+// Extension returns the file name suffix of f, including the leading dot.
+func (f ArchiveFormat) Extension() string {
+	return "." + string(f)
+}
+
+// ArchiveFormatForName returns the format whose extension name ends with.
+// ok is false when name carries no creatable archive extension.
+func ArchiveFormatForName(name string) (ArchiveFormat, bool) {
+	lower := strings.ToLower(name)
+	for _, f := range ArchiveFormats {
+		if strings.HasSuffix(lower, f.Extension()) {
+			return f, true
+		}
+	}
+	if strings.HasSuffix(lower, ".tgz") {
+		return ArchiveFormatTarGz, true
+	}
+	return "", false
+}
+
+// ArchiveLevel trades archive size for speed.
+type ArchiveLevel string
+
+const (
+	// ArchiveLevelNormal uses the format's default compression.
+	ArchiveLevelNormal ArchiveLevel = ""
+	// ArchiveLevelStore stores entries without compressing them.
+	ArchiveLevelStore ArchiveLevel = "store"
+	// ArchiveLevelFast favors speed over size.
+	ArchiveLevelFast ArchiveLevel = "fast"
+	// ArchiveLevelBest favors size over speed.
+	ArchiveLevelBest ArchiveLevel = "best"
+)
+
+func (l ArchiveLevel) flateLevel() int {
+	switch l {
+	case ArchiveLevelStore:
+		return flate.NoCompression
+	case ArchiveLevelFast:
+		return flate.BestSpeed
+	case ArchiveLevelBest:
+		return flate.BestCompression
+	default:
+		return flate.DefaultCompression
+	}
+}
+
+// ArchiveOptions describes the archive a TypeArchive job creates in its
+// DestDir.
+type ArchiveOptions struct {
+	// Name is the archive file name; the format extension is appended when
+	// it is missing.
+	Name   string
+	Format ArchiveFormat
+	Level  ArchiveLevel
+}
+
+// FileName returns the validated archive file name.
+func (o ArchiveOptions) FileName() (string, error) {
+	switch o.Format {
+	case ArchiveFormatZip, ArchiveFormatTarGz:
+	default:
+		return "", fmt.Errorf("unsupported archive format %q", o.Format)
+	}
+	name, err := fileinfo.ValidateRenameName(o.Name)
+	if err != nil {
+		return "", err
+	}
+	if f, ok := ArchiveFormatForName(name); !ok || f != o.Format {
+		name += o.Format.Extension()
+	}
+	return name, nil
+}
+
+// runArchiveJob packs every source into one new archive in DestDir. The
+// archive is written to a ".part" file and renamed into place when complete,
+// so a failed or canceled job never leaves a truncated archive behind.
+func (m *Manager) runArchiveJob(j *Job) error {
+	name, err := j.Options.Archive.FileName()
+	if err != nil {
+		return wrapPath(j.DestDir, err)
+	}
+	destPath, err := resolveExecutionPath(j.DestDir)
+	if err != nil {
+		return wrapPath(j.DestDir, err)
+	}
+	if destPath.backend == backendArchive {
+		return wrapPath(destPath.displayPath(), errors.New("archive destinations are read-only"))
+	}
+	if destPath.backend == backendGio {
+		return wrapPath(destPath.displayPath(), errGioReadOnly)
+	}
+
+	execCtx := newExecutionContext()
+	execCtx.jobCtx = j.ctx
+	defer func() {
+		if err := execCtx.close(); err != nil {
+			dbg("job %d: execution context close error: %v", j.ID, err)
+		}
+	}()
+	if err := validateDestinationDirectory(execCtx, destPath); err != nil {
+		return err
+	}
+
+	if len(j.Sources) == 0 {
+		return errors.New("nothing to archive")
+	}
+	sources := make([]executionPath, len(j.Sources))
+	entryNames := make(map[string]string, len(j.Sources))
+	for i, src := range j.Sources {
+		p, err := resolveExecutionPath(src)
+		if err != nil {
+			return wrapPath(src, err)
+		}
+		base := baseName(p)
+		if other, ok := entryNames[base]; ok {
+			return wrapPath(src, fmt.Errorf("%s is also named %q; archive entries must be unique", other, base))
+		}
+		entryNames[base] = src
+		sources[i] = p
+	}
+
+	dst := joinPath(destPath, name)
+	dstInfo := virtualFileInfo{name: name, mode: 0644, modTime: time.Now()}
+	dst, skipped, overwrite, err := resolveDestinationConflict(j, execCtx, sources[0], dst, dstInfo)
+	if err != nil {
+		return err
+	}
+	if skipped {
+		dbg("job %d: archive %s skipped", j.ID, dst.displayPath())
+		return nil
+	}
+
+	sourceBytes := measureSources(j, execCtx)
+	if canceled(j) {
+		return errCanceled
+	}
+	m.notify()
+
+	tmp := dst
+	tmp.path = dst.path + ".part"
+	tmp.raw = tmp.path
+	out, err := openWritePath(execCtx, tmp, 0644)
+	if err != nil {
+		return wrapPath(tmp.displayPath(), err)
+	}
+	w, err := newArchiveWriter(out, j.Options.Archive)
+	if err != nil {
+		out.Close()
+		_ = removePath(execCtx, tmp)
+		return wrapPath(tmp.displayPath(), err)
+	}
+	packer := archivePacker{j: j, execCtx: execCtx, w: w, skip: []executionPath{tmp, dst}}
+
+	for i, src := range j.Sources {
+		if canceled(j) {
+			err = errCanceled
+			break
+		}
+		j.mu.Lock()
+		j.CurrentSource = src
+		j.Message = "archive"
+		j.clearFileProgressLocked()
+		j.mu.Unlock()
+		m.notify()
+
+		if err = packer.add(sources[i], baseName(sources[i])); err != nil {
+			if !errors.Is(err, errCanceled) {
+				j.mu.Lock()
+				j.Failures = append(j.Failures, JobFailure{TopSource: src, Path: failingPath(err), Error: err.Error()})
+				j.mu.Unlock()
+			}
+			break
+		}
+		j.mu.Lock()
+		j.DoneFiles = i + 1
+		j.finishSourceBytesLocked(sourceBytes[i])
+		j.clearFileProgressLocked()
+		j.mu.Unlock()
+		m.notify()
+	}
+	if err == nil {
+		err = w.Close()
+		if err != nil {
+			err = wrapPath(tmp.displayPath(), err)
+		}
+	}
+	if cerr := out.Close(); err == nil && cerr != nil {
+		err = wrapPath(tmp.displayPath(), cerr)
+	}
+	if err != nil {
+		_ = removePath(execCtx, tmp)
+		return err
+	}
+	if err := replacePath(execCtx, tmp, dst, overwrite); err != nil {
+		_ = removePath(execCtx, tmp)
+		return wrapPath(dst.displayPath(), err)
+	}
+	dbg("job %d: archive created %s", j.ID, dst.displayPath())
+	return nil
+}
+
+// archivePacker walks source trees into an archiveWriter. Links are stored
+// as links and never followed.
+type archivePacker struct {
+	j       *Job
+	execCtx *executionContext
+	w       archiveWriter
+	skip    []executionPath // the archive being written, when it lies under a source
+}
+
+func (p archivePacker) add(src executionPath, name string) error {
+	if canceled(p.j) {
+		return errCanceled
+	}
+	for _, s := range p.skip {
+		if sameExecutionPath(src, s) {
+			return nil
+		}
+	}
+	fi, err := lstatPath(p.execCtx, src)
+	if err != nil {
+		return wrapPath(src.displayPath(), err)
+	}
+	if target, isLink, err := linkTargetForCopy(p.execCtx, src, fi); err != nil {
+		return wrapPath(src.displayPath(), err)
+	} else if isLink {
+		return wrapPath(src.displayPath(), p.w.addLink(name, target, fi))
+	}
+	if fi.IsDir() {
+		if err := p.w.addDir(name, fi); err != nil {
+			return wrapPath(src.displayPath(), err)
+		}
+		entries, err := readDir(p.execCtx, src)
+		if err != nil {
+			return wrapPath(src.displayPath(), err)
+		}
+		for _, e := range entries {
+			if err := p.add(joinPath(src, e.Name()), name+"/"+e.Name()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if !fi.Mode().IsRegular() {
+		dbg("job %d: archive skips special file %s", p.j.ID, src.displayPath())
+		return nil
+	}
+	return p.addFile(src, name, fi)
+}
+
+func (p archivePacker) addFile(src executionPath, name string, fi os.FileInfo) error {
+	in, err := openReadPath(p.execCtx, src)
+	if err != nil {
+		return wrapPath(src.displayPath(), err)
+	}
+	defer in.Close()
+	dst, err := p.w.addFile(name, fi)
+	if err != nil {
+		return wrapPath(src.displayPath(), err)
+	}
+	p.j.beginFileProgress(src.displayPath(), max(fi.Size(), 0))
+	buf := make([]byte, 1<<20)
+	for {
+		if canceled(p.j) {
+			return errCanceled
+		}
+		n, rerr := in.Read(buf)
+		if n > 0 {
+			if _, werr := dst.Write(buf[:n]); werr != nil {
+				return wrapPath(src.displayPath(), werr)
+			}
+			p.j.addFileProgress(int64(n), false)
+		}
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			return wrapPath(src.displayPath(), rerr)
+		}
+	}
+	p.j.completeFileProgress()
+	return nil
+}
+
+// archiveWriter adds entries with slash-separated names to one archive.
+type archiveWriter interface {
+	addDir(name string, fi os.FileInfo) error
+	addLink(name, target string, fi os.FileInfo) error
+	addFile(name string, fi os.FileInfo) (io.Writer, error)
+	Close() error
+}
+
+func newArchiveWriter(out io.Writer, opts ArchiveOptions) (archiveWriter, error) {
+	switch opts.Format {
+	case ArchiveFormatZip:
+		zw := zip.NewWriter(out)
+		level := opts.Level.flateLevel()
+		zw.RegisterCompressor(zip.Deflate, func(w io.Writer) (io.WriteCloser, error) {
+			return flate.NewWriter(w, level)
+		})
+		return &zipArchiveWriter{zw: zw, store: opts.Level == ArchiveLevelStore}, nil
+	case ArchiveFormatTarGz:
+		gz, err := gzip.NewWriterLevel(out, opts.Level.flateLevel())
+		if err != nil {
+			return nil, err
+		}
+		return &tarGzArchiveWriter{gz: gz, tw: tar.NewWriter(gz)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported archive format %q", opts.Format)
+	}
+}
+
+type zipArchiveWriter struct {
+	zw    *zip.Writer
+	store bool
+}
+
+func (w *zipArchiveWriter) header(name string, fi os.FileInfo) (*zip.FileHeader, error) {
+	hdr, err := zip.FileInfoHeader(fi)
+	if err != nil {
+		return nil, err
+	}
+	hdr.Name = name
+	hdr.Method = zip.Deflate
+	if w.store {
+		hdr.Method = zip.Store
+	}
+	return hdr, nil
+}
+
+func (w *zipArchiveWriter) addDir(name string, fi os.FileInfo) error {
+	hdr, err := w.header(name+"/", fi)
+	if err != nil {
+		return err
+	}
+	hdr.Method = zip.Store
+	_, err = w.zw.CreateHeader(hdr)
+	return err
+}
+
+func (w *zipArchiveWriter) addLink(name, target string, fi os.FileInfo) error {
+	hdr, err := w.header(name, fi)
+	if err != nil {
+		return err
+	}
+	hdr.SetMode(os.ModeSymlink | 0777)
+	hdr.Method = zip.Store
+	out, err := w.zw.CreateHeader(hdr)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, target)
+	return err
+}
+
+func (w *zipArchiveWriter) addFile(name string, fi os.FileInfo) (io.Writer, error) {
+	hdr, err := w.header(name, fi)
+	if err != nil {
+		return nil, err
+	}
+	return w.zw.CreateHeader(hdr)
+}
+
+func (w *zipArchiveWriter) Close() error {
+	return w.zw.Close()
+}
+
+type tarGzArchiveWriter struct {
+	gz *gzip.Writer
+	tw *tar.Writer
+}
+
+func (w *tarGzArchiveWriter) writeHeader(name, link string, fi os.FileInfo) error {
+	hdr, err := tar.FileInfoHeader(fi, link)
+	if err != nil {
+		return err
+	}
+	hdr.Name = name
+	hdr.Uname, hdr.Gname = "", ""
+	return w.tw.WriteHeader(hdr)
+}
+
+func (w *tarGzArchiveWriter) addDir(name string, fi os.FileInfo) error {
+	return w.writeHeader(name+"/", "", fi)
+}
+
+func (w *tarGzArchiveWriter) addLink(name, target string, fi os.FileInfo) error {
+	link := virtualFileInfo{name: fi.Name(), mode: os.ModeSymlink | 0777, modTime: fi.ModTime()}
+	return w.writeHeader(name, target, link)
+}
+
+func (w *tarGzArchiveWriter) addFile(name string, fi os.FileInfo) (io.Writer, error) {
+	if err := w.writeHeader(name, "", fi); err != nil {
+		return nil, err
+	}
+	return w.tw, nil
+}
+
+func (w *tarGzArchiveWriter) Close() error {
+	if err := w.tw.Close(); err != nil {
+		return err
+	}
+	return w.gz.Close()
+}
@@ -0,0 +1,184 @@
+package jobs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestArchiveOptionsFileName(t *testing.T) {
+	tests := []struct {
+		opts ArchiveOptions
+		want string
+	}{
+		{ArchiveOptions{Name: "photos", Format: ArchiveFormatZip}, "photos.zip"},
+		{ArchiveOptions{Name: "photos.ZIP", Format: ArchiveFormatZip}, "photos.ZIP"},
+		{ArchiveOptions{Name: "photos.zip", Format: ArchiveFormatTarGz}, "photos.zip.tar.gz"},
+		{ArchiveOptions{Name: "src.tgz", Format: ArchiveFormatTarGz}, "src.tgz"},
+	}
+	for _, tt := range tests {
+		got, err := tt.opts.FileName()
+		if err != nil || got != tt.want {
+			t.Errorf("FileName(%+v) = %q, %v; want %q", tt.opts, got, err, tt.want)
+		}
+	}
+	if _, err := (ArchiveOptions{Name: "a/b", Format: ArchiveFormatZip}).FileName(); err == nil {
+		t.Error("names with separators should be rejected")
+	}
+	if _, err := (ArchiveOptions{Name: "a", Format: "rar"}).FileName(); err == nil {
+		t.Error("unsupported formats should be rejected")
+	}
+}
+
+func TestArchiveJobCreatesZip(t *testing.T) {
+	src := t.TempDir()
+	writeArchiveTestTree(t, src)
+	dest := t.TempDir()
+
+	m := NewManager()
+	j := m.EnqueueArchive(
+		[]string{filepath.Join(src, "docs"), filepath.Join(src, "top.txt")},
+		dest, nil, ArchiveOptions{Name: "bundle", Format: ArchiveFormatZip, Level: ArchiveLevelBest},
+	)
+	waitForJobStatus(t, j, StatusCompleted)
+
+	zr, err := zip.OpenReader(filepath.Join(dest, "bundle.zip"))
+	if err != nil {
+		t.Fatalf("open created zip: %v", err)
+	}
+	defer zr.Close()
+	contents := make(map[string]string)
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		contents[f.Name] = string(data)
+	}
+	sort.Strings(names)
+	if got, want := strings.Join(names, ","), "docs/,docs/a.txt,docs/sub/,docs/sub/b.txt,top.txt"; got != want {
+		t.Fatalf("zip entries = %s, want %s", got, want)
+	}
+	if contents["docs/sub/b.txt"] != "bravo" || contents["top.txt"] != "top" {
+		t.Fatalf("zip contents = %v", contents)
+	}
+	snap := j.Snapshot()
+	if snap.DoneFiles != 2 || snap.TotalBytes != int64(len("alpha")+len("bravo")+len("top")) {
+		t.Fatalf("progress = %d files, %d bytes", snap.DoneFiles, snap.TotalBytes)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "bundle.zip.part")); !os.IsNotExist(err) {
+		t.Fatalf("temporary archive left behind: %v", err)
+	}
+}
+
+func TestArchiveJobCreatesTarGzBesideSources(t *testing.T) {
+	src := t.TempDir()
+	writeArchiveTestTree(t, src)
+
+	m := NewManager()
+	j := m.EnqueueArchive([]string{filepath.Join(src, "docs")}, filepath.Join(src, "docs"), nil,
+		ArchiveOptions{Name: "docs", Format: ArchiveFormatTarGz, Level: ArchiveLevelStore})
+	waitForJobStatus(t, j, StatusCompleted)
+
+	f, err := os.Open(filepath.Join(src, "docs", "docs.tar.gz"))
+	if err != nil {
+		t.Fatalf("open created archive: %v", err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip reader: %v", err)
+	}
+	tr := tar.NewReader(gz)
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("read tar: %v", err)
+		}
+		names = append(names, hdr.Name)
+	}
+	sort.Strings(names)
+	// The archive is written inside the source tree and must not include itself.
+	if got, want := strings.Join(names, ","), "docs/,docs/a.txt,docs/sub/,docs/sub/b.txt"; got != want {
+		t.Fatalf("tar entries = %s, want %s", got, want)
+	}
+}
+
+func TestArchiveJobAutoSuffixesExistingArchive(t *testing.T) {
+	src := t.TempDir()
+	writeArchiveTestTree(t, src)
+	dest := t.TempDir()
+	existing := filepath.Join(dest, "top.zip")
+	if err := os.WriteFile(existing, []byte("keep"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewManager()
+	j := m.EnqueueArchive([]string{filepath.Join(src, "top.txt")}, dest, nil,
+		ArchiveOptions{Name: "top", Format: ArchiveFormatZip})
+	waitForJobStatus(t, j, StatusCompleted)
+
+	if data, _ := os.ReadFile(existing); string(data) != "keep" {
+		t.Fatalf("existing archive was replaced: %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "top (1).zip")); err != nil {
+		t.Fatalf("auto-suffixed archive missing: %v", err)
+	}
+}
+
+func TestArchiveJobRejectsDuplicateEntryNames(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"a", "b"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(root, dir, "same.txt"), []byte(dir), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := NewManager()
+	j := m.EnqueueArchive(
+		[]string{filepath.Join(root, "a", "same.txt"), filepath.Join(root, "b", "same.txt")},
+		root, nil, ArchiveOptions{Name: "out", Format: ArchiveFormatZip},
+	)
+	waitForJobStatus(t, j, StatusFailed)
+	if _, err := os.Stat(filepath.Join(root, "out.zip")); !os.IsNotExist(err) {
+		t.Fatalf("archive created despite duplicate entries: %v", err)
+	}
+}
+
+func writeArchiveTestTree(t *testing.T, root string) {
+	t.Helper()
+	files := map[string]string{
+		"docs/a.txt":     "alpha",
+		"docs/sub/b.txt": "bravo",
+		"top.txt":        "top",
+	}
+	for name, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
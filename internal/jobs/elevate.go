@@ -0,0 +1,174 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Elevator places staged files into local destinations the user cannot write,
+// typically by running one privileged helper for a whole job. The main
+// process never gains privileges itself.
+type Elevator interface {
+	Place(ctx context.Context, placements []Placement) error
+}
+
+// Placement is one privileged step: create directory Dest, or install the
+// user-owned staged file Source as Dest. Mode, UID, and GID apply to the
+// result; the staged file's modification time is kept.
+type Placement struct {
+	Dir    bool
+	Source string
+	Dest   string
+	Mode   os.FileMode
+	UID    int
+	GID    int
+	// TopSource is the job source the placement belongs to, for failures.
+	TopSource string
+}
+
+// SetElevator enables privileged final placement for copy jobs; nil turns it
+// off. A copy that hits a permission error on a local destination then stages
+// the data as the user and asks e to place everything once the job's own
+// work has succeeded.
+func (m *Manager) SetElevator(e Elevator) {
+	m.mu.Lock()
+	m.elevator = e
+	m.mu.Unlock()
+}
+
+func (m *Manager) currentElevator() Elevator {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.elevator
+}
+
+// elevation collects one job's deferred placements and their staging area.
+type elevation struct {
+	elevator   Elevator
+	staging    string
+	staged     int
+	placements []Placement
+	dirs       map[string]bool
+}
+
+// canElevate reports whether err from writing the local path dst should be
+// retried through the job's elevator.
+func (ctx *executionContext) canElevate(dst executionPath, err error) bool {
+	return ctx != nil && ctx.elevation != nil && dst.backend == backendLocal && errors.Is(err, fs.ErrPermission)
+}
+
+// deferredDir reports whether dst is a directory left for the elevator to
+// create, so it does not exist yet.
+func (ctx *executionContext) deferredDir(dst executionPath) bool {
+	return ctx != nil && ctx.elevation != nil && ctx.elevation.dirs[dst.path]
+}
+
+// deferDir records dst as a directory the elevator creates with mode.
+func (ctx *executionContext) deferDir(j *Job, dst executionPath, mode os.FileMode) {
+	e := ctx.elevation
+	if e.dirs == nil {
+		e.dirs = make(map[string]bool)
+	}
+	e.dirs[dst.path] = true
+	uid, gid := placementOwner(dst.path)
+	e.placements = append(e.placements, Placement{Dir: true, Dest: dst.path, Mode: mode.Perm(), UID: uid, GID: gid, TopSource: currentSource(j)})
+	dbg("job %d: defer mkdir %s to elevator", j.ID, dst.displayPath())
+}
+
+// stageElevatedCopy writes in to a private staging file and records its
+// placement at dst for the end of the job.
+func stageElevatedCopy(j *Job, execCtx *executionContext, in io.Reader, srcDisplay string, dst executionPath, fi os.FileInfo) error {
+	e := execCtx.elevation
+	if e.staging == "" {
+		dir, err := os.MkdirTemp("", "nmf-elevate-*")
+		if err != nil {
+			return wrapPath(dst.displayPath(), err)
+		}
+		e.staging = dir
+	}
+	e.staged++
+	staged, err := resolveExecutionPath(filepath.Join(e.staging, fmt.Sprintf("%d", e.staged)))
+	if err != nil {
+		return wrapPath(dst.displayPath(), err)
+	}
+	dbg("job %d: stage %s for elevated placement at %s", j.ID, staged.displayPath(), dst.displayPath())
+	if err := copyToPart(j, execCtx, in, nil, srcDisplay, staged, fi, false, nil, 0); err != nil {
+		return err
+	}
+	uid, gid := placementOwner(dst.path)
+	if j.Options.PreserveOwnership {
+		if srcUID, srcGID, ok := fileOwner(fi); ok {
+			uid, gid = srcUID, srcGID
+		}
+	}
+	e.placements = append(e.placements, Placement{Source: staged.path, Dest: dst.path, Mode: fi.Mode().Perm(), UID: uid, GID: gid, TopSource: currentSource(j)})
+	return nil
+}
+
+// placeElevated runs the job's deferred placements, recording a failure for
+// each affected source when the elevator refuses or fails.
+func (m *Manager) placeElevated(j *Job, execCtx *executionContext) error {
+	if execCtx.elevation == nil || len(execCtx.elevation.placements) == 0 {
+		return nil
+	}
+	placements := execCtx.elevation.placements
+	j.mu.Lock()
+	j.Message = "elevate"
+	j.mu.Unlock()
+	m.notify()
+	dbg("job %d: elevated placement of %d item(s)", j.ID, len(placements))
+	err := execCtx.elevation.elevator.Place(j.ctx, placements)
+	if err == nil {
+		return nil
+	}
+	if canceled(j) {
+		return errCanceled
+	}
+	err = wrapPath(placements[0].Dest, err)
+	seen := make(map[string]bool)
+	j.mu.Lock()
+	for _, p := range placements {
+		if !seen[p.TopSource] {
+			seen[p.TopSource] = true
+			j.Failures = append(j.Failures, JobFailure{TopSource: p.TopSource, Path: p.Dest, Error: err.Error()})
+		}
+	}
+	j.mu.Unlock()
+	return err
+}
+
+func (e *elevation) close() error {
+	if e == nil || e.staging == "" {
+		return nil
+	}
+	return os.RemoveAll(e.staging)
+}
+
+// placementOwner keeps the owner of an existing destination; new entries take
+// the owner of their nearest existing parent directory.
+func placementOwner(dest string) (int, int) {
+	for p := dest; ; {
+		if fi, err := os.Lstat(p); err == nil {
+			if uid, gid, ok := fileOwner(fi); ok {
+				return uid, gid
+			}
+			return 0, 0
+		}
+		parent := filepath.Dir(p)
+		if parent == p {
+			return 0, 0
+		}
+		p = parent
+	}
+}
+
+func currentSource(j *Job) string {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.CurrentSource
+}
@@ -0,0 +1,77 @@
+//go:build linux
+
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// placeScript runs as root under pkexec. Its arguments come in groups of six
+// (kind, mode, uid, gid, source, dest), so no path is ever parsed by the shell.
+const placeScript = `while [ "$#" -ge 6 ]; do
+	if [ "$1" = d ]; then
+		install -d -m "$2" -o "$3" -g "$4" -- "$6" || exit 1
+	else
+		install -D -p -m "$2" -o "$3" -g "$4" -T -- "$5" "$6" || exit 1
+	fi
+	shift 6
+done`
+
+// runElevated runs name with args and returns its combined output. Tests
+// replace it.
+var runElevated = func(ctx context.Context, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	return out.Bytes(), err
+}
+
+// pkexecElevator places files with one polkit-authorized install run per job,
+// so the user authenticates once however many files were staged.
+type pkexecElevator struct {
+	pkexec string
+}
+
+// DefaultElevator returns the pkexec elevator, or nil when pkexec is not
+// installed.
+func DefaultElevator() Elevator {
+	path, err := exec.LookPath("pkexec")
+	if err != nil {
+		return nil
+	}
+	return pkexecElevator{pkexec: path}
+}
+
+func (e pkexecElevator) Place(ctx context.Context, placements []Placement) error {
+	args := []string{"/bin/sh", "-c", placeScript, "sh"}
+	for _, p := range placements {
+		kind := "f"
+		if p.Dir {
+			kind = "d"
+		}
+		args = append(args, kind, fmt.Sprintf("%04o", p.Mode.Perm()), strconv.Itoa(p.UID), strconv.Itoa(p.GID), p.Source, p.Dest)
+	}
+	out, err := runElevated(ctx, e.pkexec, args...)
+	if err == nil {
+		return nil
+	}
+	// pkexec exits 126 when the authentication dialog is dismissed and 127
+	// when authorization is refused.
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && (exitErr.ExitCode() == 126 || exitErr.ExitCode() == 127) {
+		return fmt.Errorf("administrator authorization was not granted: %w", os.ErrPermission)
+	}
+	if msg := strings.TrimSpace(string(out)); msg != "" {
+		return fmt.Errorf("pkexec: %s: %w", msg, err)
+	}
+	return fmt.Errorf("pkexec: %w", err)
+}
@@ -0,0 +1,33 @@
+//go:build linux
+
+package jobs
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestPkexecElevatorPassesPlacementsAsArguments(t *testing.T) {
+	old := runElevated
+	defer func() { runElevated = old }()
+	var got []string
+	runElevated = func(_ context.Context, name string, args ...string) ([]byte, error) {
+		got = append([]string{name}, args...)
+		return nil, nil
+	}
+
+	err := pkexecElevator{pkexec: "/usr/bin/pkexec"}.Place(context.Background(), []Placement{
+		{Dir: true, Dest: "/etc/app", Mode: 0755},
+		{Source: "/tmp/nmf-elevate-1/1", Dest: "/etc/app/my file.conf", Mode: 0640, UID: 0, GID: 4},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/usr/bin/pkexec", "/bin/sh", "-c", placeScript, "sh",
+		"d", "0755", "0", "0", "", "/etc/app",
+		"f", "0640", "0", "4", "/tmp/nmf-elevate-1/1", "/etc/app/my file.conf"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("pkexec args = %q, want %q", got, want)
+	}
+}
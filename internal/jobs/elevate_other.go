@@ -0,0 +1,9 @@
+//go:build !linux
+
+package jobs
+
+// DefaultElevator returns nil: privileged placement needs pkexec, which only
+// Linux desktops provide.
+func DefaultElevator() Elevator {
+	return nil
+}
@@ -0,0 +1,152 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// copyingElevator performs placements as the current user.
+type copyingElevator struct {
+	placements []Placement
+	err        error
+}
+
+func (e *copyingElevator) Place(_ context.Context, placements []Placement) error {
+	e.placements = append(e.placements, placements...)
+	if e.err != nil {
+		return e.err
+	}
+	for _, p := range placements {
+		if p.Dir {
+			if err := os.MkdirAll(p.Dest, p.Mode); err != nil {
+				return err
+			}
+			continue
+		}
+		data, err := os.ReadFile(p.Source)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(p.Dest, data, p.Mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestElevatedCopyStagesUntilPlacement(t *testing.T) {
+	tmp := t.TempDir()
+	srcFile := filepath.Join(tmp, "app.conf")
+	if err := os.WriteFile(srcFile, []byte("setting=1\n"), 0640); err != nil {
+		t.Fatal(err)
+	}
+	fi, err := os.Stat(srcFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	etc := filepath.Join(tmp, "etc")
+	if err := os.Mkdir(etc, 0755); err != nil {
+		t.Fatal(err)
+	}
+	dst, err := resolveExecutionPath(filepath.Join(etc, "app.conf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	elevator := &copyingElevator{}
+	execCtx := newExecutionContext()
+	execCtx.elevation = &elevation{elevator: elevator}
+	j := &Job{Type: TypeCopy, ctx: context.Background(), CurrentSource: srcFile}
+	if err := stageElevatedCopy(j, execCtx, strings.NewReader("setting=1\n"), srcFile, dst, fi); err != nil {
+		t.Fatalf("stageElevatedCopy: %v", err)
+	}
+	if _, err := os.Lstat(dst.path); !os.IsNotExist(err) {
+		t.Fatalf("destination should wait for placement, stat err = %v", err)
+	}
+
+	if err := (&Manager{}).placeElevated(j, execCtx); err != nil {
+		t.Fatalf("placeElevated: %v", err)
+	}
+	if data, err := os.ReadFile(dst.path); err != nil || string(data) != "setting=1\n" {
+		t.Fatalf("placed file = %q, %v", data, err)
+	}
+	p := elevator.placements[0]
+	if p.Dest != dst.path || p.Mode != 0640 || p.TopSource != srcFile {
+		t.Fatalf("placement = %+v", p)
+	}
+	staging := execCtx.elevation.staging
+	if err := execCtx.close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(staging); !os.IsNotExist(err) {
+		t.Fatalf("staging directory should be removed, stat err = %v", err)
+	}
+}
+
+func TestElevatedPlacementFailureRecordsSources(t *testing.T) {
+	execCtx := newExecutionContext()
+	execCtx.elevation = &elevation{
+		elevator:   &copyingElevator{err: errors.New("not authorized")},
+		placements: []Placement{{Dest: "/etc/a", TopSource: "/src/a"}, {Dest: "/etc/b", TopSource: "/src/a"}},
+	}
+	j := &Job{Type: TypeCopy, ctx: context.Background()}
+	if err := (&Manager{}).placeElevated(j, execCtx); err == nil {
+		t.Fatal("placeElevated should fail")
+	}
+	if len(j.Failures) != 1 || j.Failures[0].TopSource != "/src/a" || j.Failures[0].Path != "/etc/a" {
+		t.Fatalf("failures = %+v, want one for /src/a", j.Failures)
+	}
+}
+
+func TestCopyIntoUnwritableDirectoryUsesElevator(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("needs Unix permissions that the current user cannot bypass")
+	}
+	tmp := t.TempDir()
+	src := filepath.Join(tmp, "src")
+	if err := os.MkdirAll(filepath.Join(src, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "sub", "f.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dest := filepath.Join(tmp, "locked")
+	if err := os.Mkdir(dest, 0555); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.Chmod(dest, 0755)
+
+	elevator := &copyingElevator{}
+	m := &Manager{}
+	m.SetElevator(&unlockingElevator{dir: dest, next: elevator})
+	j := &Job{Type: TypeCopy, Sources: []string{src}, DestDir: dest, ctx: context.Background()}
+	if err := m.runJob(j); err != nil {
+		t.Fatalf("runJob: %v", err)
+	}
+	if len(elevator.placements) != 3 || !elevator.placements[0].Dir || !elevator.placements[1].Dir {
+		t.Fatalf("placements = %+v, want two directories then the file", elevator.placements)
+	}
+	if data, err := os.ReadFile(filepath.Join(dest, "src", "sub", "f.txt")); err != nil || string(data) != "x" {
+		t.Fatalf("placed file = %q, %v", data, err)
+	}
+}
+
+// unlockingElevator makes dir writable before delegating, standing in for
+// root so the copying fake can place as the test user.
+type unlockingElevator struct {
+	dir  string
+	next Elevator
+}
+
+func (e *unlockingElevator) Place(ctx context.Context, placements []Placement) error {
+	if err := os.Chmod(e.dir, 0755); err != nil {
+		return err
+	}
+	return e.next.Place(ctx, placements)
+}
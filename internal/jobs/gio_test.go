@@ -0,0 +1,123 @@
+package jobs
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"nmf/internal/fileinfo"
+)
+
+// fakeGioVFS serves a tiny read-only tree keyed by URI.
+type fakeGioVFS struct {
+	dirs  map[string][]string
+	files map[string]string
+}
+
+func (f fakeGioVFS) ReadDir(uri string) ([]os.DirEntry, error) {
+	names, ok := f.dirs[uri]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	entries := make([]os.DirEntry, 0, len(names))
+	for _, name := range names {
+		fi, err := f.Stat(f.Join(uri, name))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, fakeGioDirEntry{fi})
+	}
+	return entries, nil
+}
+
+func (f fakeGioVFS) Stat(uri string) (os.FileInfo, error) {
+	if _, ok := f.dirs[uri]; ok {
+		return virtualFileInfo{name: f.Base(uri), mode: os.ModeDir | 0755, modTime: time.Unix(1, 0)}, nil
+	}
+	if data, ok := f.files[uri]; ok {
+		return virtualFileInfo{name: f.Base(uri), size: int64(len(data)), mode: 0644, modTime: time.Unix(1, 0)}, nil
+	}
+	return nil, os.ErrNotExist
+}
+
+func (fakeGioVFS) Capabilities() fileinfo.Capabilities { return fileinfo.Capabilities{} }
+
+func (fakeGioVFS) Join(elem ...string) string {
+	return strings.TrimRight(elem[0], "/") + "/" + strings.Join(elem[1:], "/")
+}
+
+func (fakeGioVFS) Base(p string) string { return p[strings.LastIndex(p, "/")+1:] }
+
+func (f fakeGioVFS) Open(uri string) (io.ReadCloser, error) {
+	data, ok := f.files[uri]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return io.NopCloser(strings.NewReader(data)), nil
+}
+
+type fakeGioDirEntry struct{ fi os.FileInfo }
+
+func (e fakeGioDirEntry) Name() string               { return e.fi.Name() }
+func (e fakeGioDirEntry) IsDir() bool                { return e.fi.IsDir() }
+func (e fakeGioDirEntry) Type() os.FileMode          { return e.fi.Mode().Type() }
+func (e fakeGioDirEntry) Info() (os.FileInfo, error) { return e.fi, nil }
+
+func newFakeGioPath(uri string) executionPath {
+	return executionPath{
+		raw:     uri,
+		path:    uri,
+		backend: backendGio,
+		gio: fakeGioVFS{
+			dirs: map[string][]string{
+				"mtp://phone/DCIM":        {"Camera"},
+				"mtp://phone/DCIM/Camera": {"IMG_0001.jpg"},
+			},
+			files: map[string]string{
+				"mtp://phone/DCIM/Camera/IMG_0001.jpg": "jpeg bytes",
+			},
+		},
+	}
+}
+
+func TestCopyFromGioLocationToLocalDirectory(t *testing.T) {
+	dstDir := t.TempDir()
+	dest, err := resolveExecutionPath(dstDir)
+	if err != nil {
+		t.Fatalf("resolveExecutionPath returned error: %v", err)
+	}
+	job := &Job{Type: TypeCopy, ctx: t.Context()}
+
+	if err := copyOrMovePathResolved(job, newExecutionContext(), newFakeGioPath("mtp://phone/DCIM"), dest); err != nil {
+		t.Fatalf("copyOrMovePathResolved returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dstDir, "DCIM", "Camera", "IMG_0001.jpg"))
+	if err != nil {
+		t.Fatalf("ReadFile copied file returned error: %v", err)
+	}
+	if string(data) != "jpeg bytes" {
+		t.Fatalf("copied content = %q", string(data))
+	}
+}
+
+func TestGioLocationIsReadOnly(t *testing.T) {
+	src, err := resolveExecutionPath(t.TempDir())
+	if err != nil {
+		t.Fatalf("resolveExecutionPath returned error: %v", err)
+	}
+	gio := newFakeGioPath("mtp://phone/DCIM")
+
+	if err := copyOrMovePathResolved(&Job{Type: TypeCopy, ctx: t.Context()}, newExecutionContext(), src, gio); err == nil {
+		t.Fatal("copy into gio location returned nil error")
+	}
+	if err := copyOrMovePathResolved(&Job{Type: TypeMove, ctx: t.Context()}, newExecutionContext(), gio, src); err == nil {
+		t.Fatal("move out of gio location returned nil error")
+	}
+	if err := validateDeleteTarget(gio); err == nil {
+		t.Fatal("validateDeleteTarget gio location returned nil error")
+	}
+}
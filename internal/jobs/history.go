@@ -0,0 +1,388 @@
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"nmf/internal/filecompare"
+)
+
+const historyFileVersion = 1
+
+// historyFile is the on-disk form of the finished-job history, oldest first.
+type historyFile struct {
+	Version int             `json:"version"`
+	Jobs    []HistoryRecord `json:"jobs"`
+}
+
+// HistoryRecord is the persisted form of a finished job. Job IDs are not
+// stored; restored jobs receive fresh IDs from the loading Manager.
+type HistoryRecord struct {
+	Type                Type               `json:"type"`
+	Status              Status             `json:"status"`
+	Sources             []string           `json:"sources"`
+	DestDir             string             `json:"destDir,omitempty"`
+	DeleteMode          DeleteMode         `json:"deleteMode,omitempty"`
+	PreserveTimestamps  bool               `json:"preserveTimestamps,omitempty"`
+	PreserveOwnership   bool               `json:"preserveOwnership,omitempty"`
+	PreserveXattrs      bool               `json:"preserveXattrs,omitempty"`
+	OrganizeByDate      bool               `json:"organizeByDate,omitempty"`
+	Layout              TransferLayout     `json:"layout,omitempty"`
+	RelativeBase        string             `json:"relativeBase,omitempty"`
+	ArchiveName         string             `json:"archiveName,omitempty"`
+	ArchiveFormat       ArchiveFormat      `json:"archiveFormat,omitempty"`
+	ArchiveLevel        ArchiveLevel       `json:"archiveLevel,omitempty"`
+	ChecksumAlgorithm   ChecksumAlgorithm  `json:"checksumAlgorithm,omitempty"`
+	ChecksumSidecars    bool               `json:"checksumSidecars,omitempty"`
+	SyncMethod          filecompare.Method `json:"syncMethod,omitempty"`
+	TotalFiles          int                `json:"totalFiles"`
+	DoneFiles           int                `json:"doneFiles"`
+	TotalBytes          int64              `json:"totalBytes,omitempty"`
+	DoneBytes           int64              `json:"doneBytes,omitempty"`
+	Error               string             `json:"error,omitempty"`
+	Failures            []JobFailure       `json:"failures,omitempty"`
+	FailureAcknowledged bool               `json:"failureAcknowledged,omitempty"`
+	MoveRenames         int                `json:"moveRenames,omitempty"`
+	MoveCopies          int                `json:"moveCopies,omitempty"`
+	EnqueuedAt          time.Time          `json:"enqueuedAt"`
+	StartedAt           time.Time          `json:"startedAt,omitzero"`
+	CompletedAt         time.Time          `json:"completedAt"`
+}
+
+func historyRecordFromJob(j *Job) HistoryRecord {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return HistoryRecord{
+		Type:                j.Type,
+		Status:              j.Status,
+		Sources:             append([]string(nil), j.Sources...),
+		DestDir:             j.DestDir,
+		DeleteMode:          j.DeleteMode,
+		PreserveTimestamps:  j.Options.PreserveTimestamps,
+		PreserveOwnership:   j.Options.PreserveOwnership,
+		PreserveXattrs:      j.Options.PreserveXattrs,
+		OrganizeByDate:      j.Options.OrganizeByDate,
+		Layout:              j.Options.Layout,
+		RelativeBase:        j.Options.RelativeBase,
+		ArchiveName:         j.Options.Archive.Name,
+		ArchiveFormat:       j.Options.Archive.Format,
+		ArchiveLevel:        j.Options.Archive.Level,
+		ChecksumAlgorithm:   j.Options.Checksum.Algorithm,
+		ChecksumSidecars:    j.Options.Checksum.WriteSidecars,
+		SyncMethod:          j.Options.Sync.Method,
+		TotalFiles:          j.TotalFiles,
+		DoneFiles:           j.DoneFiles,
+		TotalBytes:          j.TotalBytes,
+		DoneBytes:           j.DoneBytes,
+		Error:               j.Error,
+		Failures:            append([]JobFailure(nil), j.Failures...),
+		FailureAcknowledged: j.FailureAcknowledged,
+		MoveRenames:         j.MoveRenames,
+		MoveCopies:          j.MoveCopies,
+		EnqueuedAt:          j.EnqueuedAt,
+		StartedAt:           j.StartedAt,
+		CompletedAt:         j.CompletedAt,
+	}
+}
+
+func (r HistoryRecord) job(id int64) *Job {
+	return &Job{
+		ID:                  id,
+		Type:                r.Type,
+		Sources:             append([]string(nil), r.Sources...),
+		DestDir:             r.DestDir,
+		DeleteMode:          r.DeleteMode,
+		Options:             r.transferOptions(),
+		Status:              r.Status,
+		TotalFiles:          r.TotalFiles,
+		DoneFiles:           r.DoneFiles,
+		TotalBytes:          r.TotalBytes,
+		DoneBytes:           r.DoneBytes,
+		Error:               r.Error,
+		Failures:            append([]JobFailure(nil), r.Failures...),
+		FailureAcknowledged: r.FailureAcknowledged,
+		MoveRenames:         r.MoveRenames,
+		MoveCopies:          r.MoveCopies,
+		EnqueuedAt:          r.EnqueuedAt,
+		StartedAt:           r.StartedAt,
+		CompletedAt:         r.CompletedAt,
+		restored:            true,
+	}
+}
+
+func (r HistoryRecord) transferOptions() TransferOptions {
+	return TransferOptions{
+		PreserveTimestamps: r.PreserveTimestamps,
+		PreserveOwnership:  r.PreserveOwnership,
+		PreserveXattrs:     r.PreserveXattrs,
+		OrganizeByDate:     r.OrganizeByDate,
+		Layout:             r.Layout,
+		RelativeBase:       r.RelativeBase,
+		Archive: ArchiveOptions{
+			Name:   r.ArchiveName,
+			Format: r.ArchiveFormat,
+			Level:  r.ArchiveLevel,
+		},
+		Checksum: ChecksumOptions{
+			Algorithm:     r.ChecksumAlgorithm,
+			WriteSidecars: r.ChecksumSidecars,
+		},
+		Sync: SyncOptions{Method: r.SyncMethod},
+	}
+}
+
+func (r HistoryRecord) finished() bool {
+	switch r.Status {
+	case StatusCompleted, StatusFailed, StatusCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
+// LoadHistory restores finished jobs saved by a previous session from path
+// and keeps path updated as history changes. A missing file starts an empty
+// history; a corrupt one is reported and left untouched until the next save.
+func (m *Manager) LoadHistory(path string) error {
+	var loadErr error
+	var records []HistoryRecord
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		var file historyFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			loadErr = fmt.Errorf("error parsing job history: %w", err)
+		} else {
+			records = file.Jobs
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		loadErr = fmt.Errorf("error reading job history: %w", err)
+	}
+
+	m.mu.Lock()
+	m.historyPath = path
+	restored := make([]*Job, 0, len(records)+len(m.history))
+	for _, r := range records {
+		if !r.finished() {
+			continue
+		}
+		restored = append(restored, r.job(atomic.AddInt64(&m.nextID, 1)))
+	}
+	m.history = append(restored, m.history...)
+	m.trimHistoryLocked()
+	n := len(m.history)
+	m.mu.Unlock()
+	dbg("history loaded path=%s restored=%d total=%d err=%v", path, len(restored), n, loadErr)
+	if len(restored) > 0 {
+		m.notify()
+	}
+	return loadErr
+}
+
+// saveHistory writes the current history when LoadHistory enabled
+// persistence. Each call snapshots under m.mu and stamps a generation, so a
+// slow writer can never replace a newer file with an older snapshot.
+func (m *Manager) saveHistory() {
+	m.mu.Lock()
+	path := m.historyPath
+	if path == "" {
+		m.mu.Unlock()
+		return
+	}
+	m.historyGen++
+	gen := m.historyGen
+	records := make([]HistoryRecord, 0, len(m.history))
+	for _, j := range m.history {
+		records = append(records, historyRecordFromJob(j))
+	}
+	m.mu.Unlock()
+
+	m.historyWriteMu.Lock()
+	defer m.historyWriteMu.Unlock()
+	if gen <= m.historyWritten {
+		return
+	}
+	if err := writeHistoryFile(path, records); err != nil {
+		dbg("history save failed path=%s err=%v", path, err)
+		return
+	}
+	m.historyWritten = gen
+}
+
+func writeHistoryFile(path string, records []HistoryRecord) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("error creating job history directory: %w", err)
+	}
+	data, err := json.MarshalIndent(historyFile{Version: historyFileVersion, Jobs: records}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling job history: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, "job-history-*.json.tmp")
+	if err != nil {
+		return fmt.Errorf("error creating temp job history file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("error writing temp job history file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("error closing temp job history file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("error renaming temp job history file: %w", err)
+	}
+	return nil
+}
+
+// Rerun queues a new job repeating a failed or canceled job from history
+// with the same sources, destination, and options. Permanent deletes are
+// never rerun because they need a fresh confirmation.
+func (m *Manager) Rerun(id int64, resolver ConflictResolver) (*Job, error) {
+	r, err := m.historyRecord(id)
+	if err != nil {
+		return nil, err
+	}
+	if r.Status != StatusFailed && r.Status != StatusCanceled {
+		return nil, fmt.Errorf("only failed or canceled jobs can be rerun (job %d is %s)", id, r.Status)
+	}
+	j, err := m.requeue(id, r, r.Sources, resolver)
+	if err != nil {
+		return nil, err
+	}
+	dbg("rerun id=%d as id=%d", id, j.ID)
+	return j, nil
+}
+
+// RetryFailed queues a new job for the top-level sources recorded in a failed
+// job's Failures plus the sources the job never reached, keeping its type,
+// destination, and options. Sources that completed are not repeated.
+func (m *Manager) RetryFailed(id int64, resolver ConflictResolver) (*Job, error) {
+	r, err := m.historyRecord(id)
+	if err != nil {
+		return nil, err
+	}
+	if r.Status != StatusFailed {
+		return nil, fmt.Errorf("only failed jobs can be retried (job %d is %s)", id, r.Status)
+	}
+	if r.Type == TypeArchive {
+		return nil, errors.New("an archive holds every source; rerun the whole job instead")
+	}
+	sources := retrySources(r)
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("job %d recorded no failed items", id)
+	}
+	j, err := m.requeue(id, r, sources, resolver)
+	if err != nil {
+		return nil, err
+	}
+	dbg("retry failed id=%d as id=%d sources=%d", id, j.ID, len(sources))
+	return j, nil
+}
+
+// retrySources returns the failed sources of r followed by every source from
+// the failing index onward. A transfer stops at its first failure, so the
+// sources after it were never attempted and must be retried too. DoneFiles
+// only indexes Sources when the job counted one file per source.
+func retrySources(r HistoryRecord) []string {
+	sources := failedSources(r.Failures)
+	if r.TotalFiles != len(r.Sources) {
+		return sources
+	}
+	seen := make(map[string]bool, len(sources))
+	for _, src := range sources {
+		seen[src] = true
+	}
+	for _, src := range r.Sources[min(max(r.DoneFiles, 0), len(r.Sources)):] {
+		if !seen[src] {
+			seen[src] = true
+			sources = append(sources, src)
+		}
+	}
+	return sources
+}
+
+// failedSources returns the distinct top-level sources of failures in
+// order. A failure inside a directory retries the whole top-level item so
+// the destination layout stays the same as in the original job. Warnings
+// about metadata do not count: those items were transferred.
+func failedSources(failures []JobFailure) []string {
+	seen := make(map[string]bool, len(failures))
+	var sources []string
+	for _, f := range failures {
+		if f.Warning {
+			continue
+		}
+		src := f.TopSource
+		if src == "" {
+			src = f.Path
+		}
+		if src == "" || seen[src] {
+			continue
+		}
+		seen[src] = true
+		sources = append(sources, src)
+	}
+	return sources
+}
+
+func (m *Manager) historyRecord(id int64) (HistoryRecord, error) {
+	m.mu.Lock()
+	var prev *Job
+	for _, j := range m.history {
+		if j.ID == id {
+			prev = j
+			break
+		}
+	}
+	m.mu.Unlock()
+	if prev == nil {
+		return HistoryRecord{}, fmt.Errorf("job %d is not in history", id)
+	}
+	return historyRecordFromJob(prev), nil
+}
+
+// requeue enqueues sources as a new job of r's type with r's destination and
+// options.
+func (m *Manager) requeue(id int64, r HistoryRecord, sources []string, resolver ConflictResolver) (*Job, error) {
+	options := r.transferOptions()
+	switch r.Type {
+	case TypeCopy:
+		return m.EnqueueCopyWithOptions(sources, r.DestDir, resolver, options), nil
+	case TypeMove:
+		return m.EnqueueMoveWithOptions(sources, r.DestDir, resolver, options), nil
+	case TypeExtract:
+		return m.EnqueueExtractWithOptions(sources, r.DestDir, resolver, options), nil
+	case TypeArchive:
+		return m.EnqueueArchive(sources, r.DestDir, resolver, options.Archive), nil
+	case TypeSymlink:
+		return m.EnqueueSymlink(sources, r.DestDir, resolver), nil
+	case TypeChecksum:
+		return m.EnqueueChecksum(sources, options.Checksum), nil
+	case TypeVerify:
+		return m.EnqueueVerify(sources, options.Checksum), nil
+	case TypeSync:
+		if len(sources) != 1 {
+			return nil, fmt.Errorf("job %d has %d sync sources, want 1", id, len(sources))
+		}
+		return m.EnqueueSync(sources[0], r.DestDir, options.Sync), nil
+	case TypeRename:
+		return nil, errors.New("batch renames cannot be rerun; rename the items again")
+	case TypeDelete:
+		if r.DeleteMode == DeleteModePermanent || r.DeleteMode == DeleteModeSecure {
+			return nil, errors.New("permanent deletes cannot be rerun; delete the items again")
+		}
+		return m.EnqueueDelete(sources, r.DeleteMode), nil
+	default:
+		return nil, fmt.Errorf("job %d has unknown type %q", id, r.Type)
+	}
+}
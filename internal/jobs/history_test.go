@@ -0,0 +1,221 @@
+package jobs
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHistoryPersistsAcrossManagers(t *testing.T) {
+	dir := t.TempDir()
+	historyPath := filepath.Join(dir, "state", "job-history.json")
+	missing := filepath.Join(dir, "missing.txt")
+	dest := filepath.Join(dir, "dest")
+	if err := os.Mkdir(dest, 0755); err != nil {
+		t.Fatalf("mkdir dest: %v", err)
+	}
+
+	first := NewManager()
+	if err := first.LoadHistory(historyPath); err != nil {
+		t.Fatalf("LoadHistory on missing file: %v", err)
+	}
+	job := first.EnqueueCopyWithOptions([]string{missing}, dest, nil, TransferOptions{PreserveTimestamps: true})
+	waitForJobStatus(t, job, StatusFailed)
+	waitForHistoryFile(t, historyPath)
+
+	second := NewManager()
+	if err := second.LoadHistory(historyPath); err != nil {
+		t.Fatalf("LoadHistory: %v", err)
+	}
+	list := second.List()
+	if len(list) != 1 {
+		t.Fatalf("restored jobs = %d, want 1", len(list))
+	}
+	got := list[0]
+	if got.Type != TypeCopy || got.Status != StatusFailed || !got.Restored {
+		t.Fatalf("restored job = %+v, want restored failed copy", got)
+	}
+	if len(got.Sources) != 1 || got.Sources[0] != missing || got.DestDir != dest {
+		t.Fatalf("restored sources=%v dest=%s", got.Sources, got.DestDir)
+	}
+	if len(got.Failures) != 1 || got.Failures[0].TopSource != missing || got.Error == "" {
+		t.Fatalf("restored failures=%+v error=%q", got.Failures, got.Error)
+	}
+	if got.CompletedAt.IsZero() {
+		t.Fatal("restored job lost its completion time")
+	}
+}
+
+func TestHistoryLoadReportsCorruptFile(t *testing.T) {
+	historyPath := filepath.Join(t.TempDir(), "job-history.json")
+	if err := os.WriteFile(historyPath, []byte("{not json"), 0644); err != nil {
+		t.Fatalf("write history: %v", err)
+	}
+
+	m := NewManager()
+	if err := m.LoadHistory(historyPath); err == nil {
+		t.Fatal("LoadHistory should report a corrupt history file")
+	}
+	if n := len(m.List()); n != 0 {
+		t.Fatalf("jobs after corrupt load = %d, want 0", n)
+	}
+}
+
+func TestRerunRepeatsFailedJob(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "later.txt")
+	dest := filepath.Join(dir, "dest")
+	if err := os.Mkdir(dest, 0755); err != nil {
+		t.Fatalf("mkdir dest: %v", err)
+	}
+
+	m := NewManager()
+	failed := m.EnqueueCopy([]string{src}, dest)
+	waitForJobStatus(t, failed, StatusFailed)
+
+	if err := os.WriteFile(src, []byte("now present"), 0644); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	rerun, err := m.Rerun(failed.ID, nil)
+	if err != nil {
+		t.Fatalf("Rerun: %v", err)
+	}
+	if rerun.ID == failed.ID {
+		t.Fatal("rerun should queue a new job")
+	}
+	waitForJobStatus(t, rerun, StatusCompleted)
+	if _, err := os.Stat(filepath.Join(dest, "later.txt")); err != nil {
+		t.Fatalf("rerun copy missing: %v", err)
+	}
+
+	if _, err := m.Rerun(rerun.ID, nil); err == nil {
+		t.Fatal("Rerun should refuse completed jobs")
+	}
+}
+
+func TestRerunRefusesPermanentDelete(t *testing.T) {
+	m := &Manager{history: []*Job{{ID: 7, Type: TypeDelete, DeleteMode: DeleteModePermanent, Status: StatusFailed}}}
+	if _, err := m.Rerun(7, nil); err == nil {
+		t.Fatal("Rerun should refuse permanent deletes")
+	}
+}
+
+func TestRetryFailedRequeuesOnlyFailedSources(t *testing.T) {
+	dir := t.TempDir()
+	ok := filepath.Join(dir, "ok.txt")
+	missing := filepath.Join(dir, "missing.txt")
+	dest := filepath.Join(dir, "dest")
+	if err := os.WriteFile(ok, []byte("data"), 0644); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	if err := os.Mkdir(dest, 0755); err != nil {
+		t.Fatalf("mkdir dest: %v", err)
+	}
+
+	m := NewManager()
+	failed := m.EnqueueMoveWithOptions([]string{ok, missing}, dest, nil, TransferOptions{PreserveTimestamps: true})
+	waitForJobStatus(t, failed, StatusFailed)
+	if err := os.WriteFile(missing, []byte("now present"), 0644); err != nil {
+		t.Fatalf("write missing source: %v", err)
+	}
+
+	retry, err := m.RetryFailed(failed.ID, nil)
+	if err != nil {
+		t.Fatalf("RetryFailed: %v", err)
+	}
+	snap := retry.Snapshot()
+	if snap.Type != TypeMove || snap.DestDir != dest || !retry.Options.PreserveTimestamps {
+		t.Fatalf("retry job = %+v, want move to %s with original options", snap, dest)
+	}
+	if len(snap.Sources) != 1 || snap.Sources[0] != missing {
+		t.Fatalf("retry sources = %v, want only %s", snap.Sources, missing)
+	}
+	waitForJobStatus(t, retry, StatusCompleted)
+	if _, err := os.Stat(filepath.Join(dest, "missing.txt")); err != nil {
+		t.Fatalf("retried item missing: %v", err)
+	}
+
+	if _, err := m.RetryFailed(retry.ID, nil); err == nil {
+		t.Fatal("RetryFailed should refuse completed jobs")
+	}
+}
+
+func TestRetryFailedRequeuesSourcesAfterFirstFailure(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "missing.txt")
+	ok := filepath.Join(dir, "ok.txt")
+	dest := filepath.Join(dir, "dest")
+	if err := os.WriteFile(ok, []byte("data"), 0644); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	if err := os.Mkdir(dest, 0755); err != nil {
+		t.Fatalf("mkdir dest: %v", err)
+	}
+
+	m := NewManager()
+	failed := m.EnqueueCopyWithOptions([]string{missing, ok}, dest, nil, TransferOptions{})
+	waitForJobStatus(t, failed, StatusFailed)
+	if _, err := os.Stat(filepath.Join(dest, "ok.txt")); !os.IsNotExist(err) {
+		t.Fatalf("source after the failure was copied: %v", err)
+	}
+	if err := os.WriteFile(missing, []byte("now present"), 0644); err != nil {
+		t.Fatalf("write missing source: %v", err)
+	}
+
+	retry, err := m.RetryFailed(failed.ID, nil)
+	if err != nil {
+		t.Fatalf("RetryFailed: %v", err)
+	}
+	if snap := retry.Snapshot(); !reflect.DeepEqual(snap.Sources, []string{missing, ok}) {
+		t.Fatalf("retry sources = %v, want %s and the unreached %s", snap.Sources, missing, ok)
+	}
+	waitForJobStatus(t, retry, StatusCompleted)
+	for _, name := range []string{"missing.txt", "ok.txt"} {
+		if _, err := os.Stat(filepath.Join(dest, name)); err != nil {
+			t.Fatalf("retried item %s missing: %v", name, err)
+		}
+	}
+}
+
+func TestRetrySourcesSkipsUnreachedWhenProgressCountsFiles(t *testing.T) {
+	r := HistoryRecord{
+		Sources:    []string{"/a", "/b"},
+		TotalFiles: 5,
+		DoneFiles:  1,
+		Failures:   []JobFailure{{TopSource: "/a", Path: "/a/x"}},
+	}
+	if got := retrySources(r); !reflect.DeepEqual(got, []string{"/a"}) {
+		t.Fatalf("retrySources = %v, want only the failed source", got)
+	}
+	r.TotalFiles = 2
+	if got := retrySources(r); !reflect.DeepEqual(got, []string{"/a", "/b"}) {
+		t.Fatalf("retrySources = %v, want failed and unreached sources", got)
+	}
+}
+
+func TestFailedSourcesDeduplicatesTopSources(t *testing.T) {
+	got := failedSources([]JobFailure{
+		{TopSource: "/a/dir", Path: "/a/dir/x"},
+		{TopSource: "/a/dir", Path: "/a/dir/y"},
+		{Path: "/a/file"},
+		{TopSource: "/a/warned", Path: "/b/warned", Warning: true},
+		{},
+	})
+	if len(got) != 2 || got[0] != "/a/dir" || got[1] != "/a/file" {
+		t.Fatalf("failedSources = %v", got)
+	}
+}
+
+func waitForHistoryFile(t *testing.T, path string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := os.Stat(path); err == nil {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("history file %s was not written", path)
+}
@@ -0,0 +1,124 @@
+package jobs
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+)
+
+// TransferLayout controls how copy/move sources map into the destination.
+type TransferLayout string
+
+const (
+	// LayoutKeep copies or moves each top-level source as-is.
+	LayoutKeep TransferLayout = ""
+	// LayoutFlatten places every file found under the sources directly in the
+	// destination, auto-suffixing name collisions.
+	LayoutFlatten TransferLayout = "flatten"
+	// LayoutRelative recreates each source's parent directories relative to
+	// TransferOptions.RelativeBase below the destination.
+	LayoutRelative TransferLayout = "relative"
+)
+
+// transferTarget returns the directory that receives src, creating dated or
+// relative subdirectories below destPath as the job options require.
+func transferTarget(j *Job, execCtx *executionContext, src string, srcPath, destPath executionPath, base *executionPath) (executionPath, error) {
+	target := destPath
+	if j.Options.OrganizeByDate {
+		dated, err := organizeDestination(j, execCtx, src, srcPath, destPath)
+		if err != nil {
+			return executionPath{}, err
+		}
+		target = dated
+	}
+	if j.Options.Layout != LayoutRelative || base == nil {
+		return target, nil
+	}
+	segments, err := relativeSegments(*base, dirPath(srcPath))
+	if err != nil {
+		return executionPath{}, wrapPath(src, err)
+	}
+	if len(segments) == 0 {
+		return target, nil
+	}
+	for _, segment := range segments {
+		target = joinPath(target, segment)
+	}
+	if err := ensureDir(execCtx, target, 0755); err != nil {
+		return executionPath{}, wrapPath(target.displayPath(), err)
+	}
+	return target, nil
+}
+
+// relativeSegments splits the path of dir below base into its directory
+// names. dir must be base itself or one of its descendants.
+func relativeSegments(base, dir executionPath) ([]string, error) {
+	if sameExecutionPath(base, dir) {
+		return nil, nil
+	}
+	if !isDescendantExecutionPath(dir, base) {
+		return nil, errors.New("source is outside the relative base directory " + base.displayPath())
+	}
+	if dir.backend == backendLocal {
+		rel, err := filepath.Rel(filepath.Clean(base.path), filepath.Clean(dir.path))
+		if err != nil {
+			return nil, err
+		}
+		return strings.Split(rel, string(filepath.Separator)), nil
+	}
+	basePath := normalizeSMBExecutionPath(base.path)
+	dirPathValue := normalizeSMBExecutionPath(dir.path)
+	rel := strings.Trim(dirPathValue[len(basePath):], "/")
+	return strings.Split(rel, "/"), nil
+}
+
+// flattenPathResolved copies or moves the files under src directly into
+// destDir. Directories are traversed rather than recreated; after a move the
+// emptied source directories are removed.
+func flattenPathResolved(j *Job, execCtx *executionContext, src executionPath, destDir executionPath) error {
+	fi, err := lstatPath(execCtx, src)
+	if err != nil {
+		return wrapPath(src.displayPath(), err)
+	}
+	if !fi.IsDir() || isLinkLikeForTraversal(execCtx, src, fi) {
+		return copyOrMovePathResolved(j, execCtx, src, destDir)
+	}
+	if j.Type == TypeMove && isDescendantExecutionPath(destDir, src) {
+		return wrapPath(destDir.displayPath(), errors.New("cannot move a directory into itself"))
+	}
+	entries, err := readDir(execCtx, src)
+	if err != nil {
+		return wrapPath(src.displayPath(), err)
+	}
+	skippedChild := false
+	for _, e := range entries {
+		if canceled(j) {
+			return errCanceled
+		}
+		if err := validateArchiveSourceName(src, e.Name()); err != nil {
+			return wrapPath(src.displayPath(), err)
+		}
+		child := joinPath(src, e.Name())
+		dbg("job %d: flatten %s -> %s", j.ID, child.displayPath(), destDir.displayPath())
+		if err := flattenPathResolved(j, execCtx, child, destDir); err != nil {
+			if errors.Is(err, errSkipped) {
+				skippedChild = true
+				continue
+			}
+			return err
+		}
+	}
+	if skippedChild {
+		return errSkipped
+	}
+	if j.Type == TypeMove {
+		if canceled(j) {
+			return errCanceled
+		}
+		dbg("job %d: rmdir %s", j.ID, src.displayPath())
+		if err := removePath(execCtx, src); err != nil {
+			return wrapPath(src.displayPath(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package jobs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeLayoutTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("mkdir %s: %v", rel, err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatalf("write %s: %v", rel, err)
+		}
+	}
+}
+
+func TestCopyFlattenAutoSuffixesCollisions(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := filepath.Join(tmpDir, "album")
+	dest := filepath.Join(tmpDir, "flat")
+	writeLayoutTree(t, src, map[string]string{"a/cover.jpg": "a", "b/cover.jpg": "b", "b/deep/track.mp3": "t"})
+	if err := os.Mkdir(dest, 0755); err != nil {
+		t.Fatalf("make destination: %v", err)
+	}
+
+	m := &Manager{}
+	job := &Job{Type: TypeCopy, Sources: []string{src}, DestDir: dest, Options: TransferOptions{Layout: LayoutFlatten}, ctx: context.Background()}
+	if err := m.runJob(job); err != nil {
+		t.Fatalf("run flatten copy: %v", err)
+	}
+
+	entries, err := os.ReadDir(dest)
+	if err != nil {
+		t.Fatalf("read destination: %v", err)
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			t.Fatalf("flatten should not create directory %s", e.Name())
+		}
+		names = append(names, e.Name())
+	}
+	if len(names) != 3 {
+		t.Fatalf("destination entries = %v, want 3 files", names)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "track.mp3")); err != nil {
+		t.Fatalf("nested file should be flattened: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(src, "a", "cover.jpg")); err != nil {
+		t.Fatalf("copy should keep sources: %v", err)
+	}
+}
+
+func TestMoveFlattenRemovesEmptiedSourceDirectories(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := filepath.Join(tmpDir, "album")
+	dest := filepath.Join(tmpDir, "flat")
+	writeLayoutTree(t, src, map[string]string{"disc1/01.flac": "1", "disc2/02.flac": "2"})
+	if err := os.Mkdir(dest, 0755); err != nil {
+		t.Fatalf("make destination: %v", err)
+	}
+
+	m := &Manager{}
+	job := &Job{Type: TypeMove, Sources: []string{src}, DestDir: dest, Options: TransferOptions{PreserveTimestamps: true, Layout: LayoutFlatten}, ctx: context.Background()}
+	if err := m.runJob(job); err != nil {
+		t.Fatalf("run flatten move: %v", err)
+	}
+
+	if _, err := os.Stat(src); !os.IsNotExist(err) {
+		t.Fatalf("source tree should be removed, got %v", err)
+	}
+	for _, name := range []string{"01.flac", "02.flac"} {
+		if _, err := os.Stat(filepath.Join(dest, name)); err != nil {
+			t.Fatalf("%s should be moved flat: %v", name, err)
+		}
+	}
+}
+
+func TestCopyRelativeLayoutRecreatesParentsBelowBase(t *testing.T) {
+	tmpDir := t.TempDir()
+	base := filepath.Join(tmpDir, "projects")
+	writeLayoutTree(t, base, map[string]string{"app/src/main.go": "package main"})
+	dest := filepath.Join(tmpDir, "backup")
+	if err := os.Mkdir(dest, 0755); err != nil {
+		t.Fatalf("make destination: %v", err)
+	}
+	src := filepath.Join(base, "app", "src", "main.go")
+
+	m := &Manager{}
+	job := &Job{Type: TypeCopy, Sources: []string{src}, DestDir: dest, Options: TransferOptions{Layout: LayoutRelative, RelativeBase: base}, ctx: context.Background()}
+	if err := m.runJob(job); err != nil {
+		t.Fatalf("run relative copy: %v", err)
+	}
+
+	if got, err := os.ReadFile(filepath.Join(dest, "app", "src", "main.go")); err != nil || string(got) != "package main" {
+		t.Fatalf("relative copy content wrong: got %q err=%v", got, err)
+	}
+}
+
+func TestRelativeLayoutRejectsSourceOutsideBase(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeLayoutTree(t, tmpDir, map[string]string{"other/file.txt": "x"})
+	dest := filepath.Join(tmpDir, "dest")
+	if err := os.Mkdir(dest, 0755); err != nil {
+		t.Fatalf("make destination: %v", err)
+	}
+
+	m := &Manager{}
+	job := &Job{Type: TypeCopy, Sources: []string{filepath.Join(tmpDir, "other", "file.txt")}, DestDir: dest, Options: TransferOptions{Layout: LayoutRelative, RelativeBase: filepath.Join(tmpDir, "base")}, ctx: context.Background()}
+	if err := m.runJob(job); err == nil {
+		t.Fatal("source outside relative base should fail")
+	}
+}
@@ -29,19 +29,42 @@ func dbg(format string, args ...interface{}) {
 	}
 }
 
-// Manager coordinates queueing and background processing (single worker).
+// Manager coordinates queueing and background processing. Up to Workers jobs
+// run at once, and jobs touching the same volume are limited separately so two
+// transfers do not thrash one disk or share.
 type Manager struct {
-	mu          sync.Mutex
-	cond        *sync.Cond
-	queue       []*Job
-	running     bool
-	closed      bool
-	nextID      int64
-	nextSubID   int64
-	subscribers map[int64]func()
-	current     *Job
-	history     []*Job
-	historyMax  int
+	mu             sync.Mutex
+	queue          []*Job
+	closed         bool
+	nextID         int64
+	nextSubID      int64
+	subscribers    map[int64]func()
+	active         []*Job
+	volumeLoad     map[string]int
+	workers        int
+	perVolumeLimit int
+	history        []*Job
+	historyMax     int
+	historyPath    string // persisted history file; empty disables saving
+	historyGen     uint64
+	historyWriteMu sync.Mutex
+	historyWritten uint64
+	resume         *resumeJournal                // remote transfer resume journal; nil disables resuming
+	audit          *AuditLog                     // operation audit log; nil disables recording
+	elevator       Elevator                      // privileged placement for copies; nil disables it
+	duplicateNames fileinfo.DuplicateNamePattern // names auto-suffixed copies
+}
+
+// SchedulerOptions bounds concurrent job execution.
+type SchedulerOptions struct {
+	Workers        int // total running jobs; values below 1 mean 1
+	PerVolumeLimit int // running jobs per source/destination volume; 0 means unlimited
+}
+
+// DefaultSchedulerOptions runs jobs on different volumes in parallel while
+// keeping each volume to one job at a time.
+func DefaultSchedulerOptions() SchedulerOptions {
+	return SchedulerOptions{Workers: 2, PerVolumeLimit: 1}
 }
 
 var (
@@ -60,13 +83,51 @@ func NewManager() *Manager {
 	m := &Manager{
 		historyMax:  100,
 		subscribers: make(map[int64]func()),
+		volumeLoad:  make(map[string]int),
 	}
-	m.cond = sync.NewCond(&m.mu)
-	go m.worker()
-	dbg("manager created; worker started")
+	m.applySchedulerLocked(DefaultSchedulerOptions())
+	dbg("manager created; workers=%d per_volume=%d", m.workers, m.perVolumeLimit)
 	return m
 }
 
+// Configure changes the scheduler limits. Running jobs are not interrupted;
+// the new limits apply to the next dispatch.
+func (m *Manager) Configure(opts SchedulerOptions) {
+	m.mu.Lock()
+	m.applySchedulerLocked(opts)
+	dbg("scheduler configured: workers=%d per_volume=%d", m.workers, m.perVolumeLimit)
+	started := m.dispatchLocked()
+	m.mu.Unlock()
+	if started {
+		m.notify()
+	}
+}
+
+func (m *Manager) applySchedulerLocked(opts SchedulerOptions) {
+	m.workers = opts.Workers
+	if m.workers < 1 {
+		m.workers = 1
+	}
+	m.perVolumeLimit = opts.PerVolumeLimit
+	if m.perVolumeLimit < 0 {
+		m.perVolumeLimit = 0
+	}
+}
+
+// SetDuplicateNamePattern changes how copies are named when they would
+// overwrite an existing entry; jobs already running keep their pattern.
+func (m *Manager) SetDuplicateNamePattern(p fileinfo.DuplicateNamePattern) {
+	m.mu.Lock()
+	m.duplicateNames = p
+	m.mu.Unlock()
+}
+
+func (m *Manager) currentDuplicateNames() fileinfo.DuplicateNamePattern {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.duplicateNames
+}
+
 // Subscribe registers a callback called on state changes.
 func (m *Manager) Subscribe(cb func()) func() {
 	if cb == nil {
@@ -130,6 +191,12 @@ func (m *Manager) EnqueueExtract(sources []string, destDir string) *Job {
 	return m.EnqueueExtractWithResolver(sources, destDir, nil)
 }
 
+// EnqueueArchive enqueues a job packing sources into one new archive in
+// destDir. resolver is asked when the archive name already exists.
+func (m *Manager) EnqueueArchive(sources []string, destDir string, resolver ConflictResolver, options ArchiveOptions) *Job {
+	return m.enqueue(TypeArchive, sources, destDir, resolver, TransferOptions{Archive: options})
+}
+
 // EnqueueDelete enqueues a delete job.
 func (m *Manager) EnqueueDelete(sources []string, mode DeleteMode) *Job {
 	return m.enqueueDelete(sources, mode)
@@ -147,7 +214,14 @@ func (m *Manager) EnqueueCopyWithOptions(sources []string, destDir string, resol
 
 // EnqueueMoveWithResolver enqueues a move job with an optional collision resolver.
 func (m *Manager) EnqueueMoveWithResolver(sources []string, destDir string, resolver ConflictResolver) *Job {
-	return m.enqueue(TypeMove, sources, destDir, resolver, TransferOptions{PreserveTimestamps: true})
+	return m.EnqueueMoveWithOptions(sources, destDir, resolver, TransferOptions{})
+}
+
+// EnqueueMoveWithOptions enqueues a move job with transfer options. Moves
+// always preserve timestamps.
+func (m *Manager) EnqueueMoveWithOptions(sources []string, destDir string, resolver ConflictResolver, options TransferOptions) *Job {
+	options.PreserveTimestamps = true
+	return m.enqueue(TypeMove, sources, destDir, resolver, options)
 }
 
 // EnqueueExtractWithResolver enqueues an archive extraction job with an optional collision resolver.
@@ -164,13 +238,14 @@ func (m *Manager) enqueue(t Type, sources []string, destDir string, resolver Con
 	j := &Job{ID: atomic.AddInt64(&m.nextID, 1), Type: t, Sources: append([]string(nil), sources...), DestDir: destDir, Resolver: resolver, Options: options, Status: StatusPending, EnqueuedAt: time.Now()}
 	j.ctx, j.cancel = contextWithCancel()
 	j.TotalFiles = len(sources)
+	j.volumes = jobVolumes(j)
 
 	m.mu.Lock()
 	m.queue = append(m.queue, j)
+	m.dispatchLocked()
 	m.mu.Unlock()
-	dbg("enqueue id=%d type=%s n=%d preserve_timestamps=%t -> %s", j.ID, string(t), len(sources), options.PreserveTimestamps, destDir)
+	dbg("enqueue id=%d type=%s n=%d preserve_timestamps=%t by_date=%t layout=%q volumes=%v -> %s", j.ID, string(t), len(sources), options.PreserveTimestamps, options.OrganizeByDate, string(options.Layout), j.volumes, destDir)
 	m.notify()
-	m.cond.Signal()
 	return j
 }
 
@@ -188,13 +263,14 @@ func (m *Manager) enqueueDelete(sources []string, mode DeleteMode) *Job {
 	}
 	j.ctx, j.cancel = contextWithCancel()
 	j.TotalFiles = len(sources)
+	j.volumes = jobVolumes(j)
 
 	m.mu.Lock()
 	m.queue = append(m.queue, j)
+	m.dispatchLocked()
 	m.mu.Unlock()
-	dbg("enqueue id=%d type=%s mode=%s n=%d", j.ID, string(TypeDelete), string(mode), len(sources))
+	dbg("enqueue id=%d type=%s mode=%s n=%d volumes=%v", j.ID, string(TypeDelete), string(mode), len(sources), j.volumes)
 	m.notify()
-	m.cond.Signal()
 	return j
 }
 
@@ -212,27 +288,112 @@ func (m *Manager) Cancel(id int64) bool {
 			m.queue = append(m.queue[:i], m.queue[i+1:]...)
 			dbg("cancel pending id=%d", id)
 			m.addHistoryLocked(j)
+			go m.saveHistory()
 			go m.notify()
 			return true
 		}
 	}
 	// currently running
-	if m.current != nil && m.current.ID == id {
-		m.current.Cancel()
-		dbg("cancel running id=%d", id)
-		go m.notify()
-		return true
+	for _, j := range m.active {
+		if j.ID == id {
+			j.Cancel()
+			dbg("cancel running id=%d", id)
+			go m.notify()
+			return true
+		}
 	}
 	return false
 }
 
-// List returns snapshots of pending + possibly running job (head is running when active).
+// Reorder moves a pending job delta places toward the back of the queue, or
+// toward the front when delta is negative. A job stays among the jobs of its
+// own priority. It reports whether the job moved.
+func (m *Manager) Reorder(id int64, delta int) bool {
+	m.mu.Lock()
+	i := m.queueIndexLocked(id)
+	if i < 0 {
+		m.mu.Unlock()
+		return false
+	}
+	j := m.queue[i]
+	lo, hi := 0, m.highPriorityCountLocked()
+	if !j.HighPriority {
+		lo, hi = hi, len(m.queue)
+	}
+	to := min(max(i+delta, lo), hi-1)
+	if to == i {
+		m.mu.Unlock()
+		return false
+	}
+	m.queue = append(m.queue[:i], m.queue[i+1:]...)
+	m.queue = insertJob(m.queue, to, j)
+	dbg("reorder id=%d %d -> %d", id, i, to)
+	m.dispatchLocked()
+	m.mu.Unlock()
+	m.notify()
+	return true
+}
+
+// SetPriority marks a pending job as high priority, moving it behind the
+// other high-priority jobs so it is dispatched next, or returns it to the
+// front of the normal jobs. It reports whether the priority changed.
+func (m *Manager) SetPriority(id int64, high bool) bool {
+	m.mu.Lock()
+	i := m.queueIndexLocked(id)
+	if i < 0 || m.queue[i].HighPriority == high {
+		m.mu.Unlock()
+		return false
+	}
+	j := m.queue[i]
+	m.queue = append(m.queue[:i], m.queue[i+1:]...)
+	to := m.highPriorityCountLocked()
+	j.mu.Lock()
+	j.HighPriority = high
+	j.mu.Unlock()
+	m.queue = insertJob(m.queue, to, j)
+	dbg("priority id=%d high=%t position=%d", id, high, to)
+	m.dispatchLocked()
+	m.mu.Unlock()
+	m.notify()
+	return true
+}
+
+// queueIndexLocked returns the position of a pending job, or -1; caller must
+// hold m.mu.
+func (m *Manager) queueIndexLocked(id int64) int {
+	for i, j := range m.queue {
+		if j.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// highPriorityCountLocked counts the high-priority jobs, which always lead
+// the queue; caller must hold m.mu.
+func (m *Manager) highPriorityCountLocked() int {
+	n := 0
+	for n < len(m.queue) && m.queue[n].HighPriority {
+		n++
+	}
+	return n
+}
+
+func insertJob(queue []*Job, i int, j *Job) []*Job {
+	queue = append(queue, nil)
+	copy(queue[i+1:], queue[i:])
+	queue[i] = j
+	return queue
+}
+
+// List returns snapshots of running jobs, then pending jobs, then history
+// (newest first).
 func (m *Manager) List() []JobSnapshot {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	out := make([]JobSnapshot, 0, len(m.queue)+1+len(m.history))
-	if m.current != nil {
-		out = append(out, m.current.Snapshot())
+	out := make([]JobSnapshot, 0, len(m.active)+len(m.queue)+len(m.history))
+	for _, j := range m.active {
+		out = append(out, j.Snapshot())
 	}
 	for _, j := range m.queue {
 		out = append(out, j.Snapshot())
@@ -266,76 +427,128 @@ func (m *Manager) AcknowledgeFailure(id int64) bool {
 	m.mu.Unlock()
 
 	if changed {
+		m.saveHistory()
 		m.notify()
 	}
 	return changed
 }
 
 func (m *Manager) allJobsLocked() []*Job {
-	out := make([]*Job, 0, len(m.queue)+len(m.history)+1)
-	if m.current != nil {
-		out = append(out, m.current)
-	}
+	out := make([]*Job, 0, len(m.active)+len(m.queue)+len(m.history))
+	out = append(out, m.active...)
 	out = append(out, m.queue...)
 	out = append(out, m.history...)
 	return out
 }
 
-func (m *Manager) worker() {
-	for {
-		m.mu.Lock()
-		for len(m.queue) == 0 && !m.closed {
-			dbg("worker waiting (queue=0, closed=%t)", m.closed)
-			m.cond.Wait()
+// dispatchLocked starts every queued job the limits allow, oldest first, and
+// reports whether any started; caller must hold m.mu. A job blocked by a busy
+// volume reserves its volumes for the rest of the pass so later jobs cannot
+// starve it.
+func (m *Manager) dispatchLocked() bool {
+	if m.closed {
+		return false
+	}
+	if m.volumeLoad == nil {
+		m.volumeLoad = make(map[string]int)
+	}
+	reserved := make(map[string]bool)
+	started := false
+	for i := 0; i < len(m.queue) && len(m.active) < max(m.workers, 1); {
+		j := m.queue[i]
+		if !m.volumesAvailableLocked(j.volumes, reserved) {
+			for _, v := range j.volumes {
+				reserved[v] = true
+			}
+			i++
+			continue
 		}
-		if m.closed {
-			m.mu.Unlock()
-			return
+		m.queue = append(m.queue[:i], m.queue[i+1:]...)
+		m.active = append(m.active, j)
+		for _, v := range j.volumes {
+			m.volumeLoad[v]++
 		}
-		// pop head
-		j := m.queue[0]
-		m.queue = m.queue[1:]
-		m.current = j
-		dbg("worker popped id=%d type=%s (remaining=%d)", j.ID, string(j.Type), len(m.queue))
-		m.mu.Unlock()
+		dbg("dispatch id=%d type=%s (running=%d queued=%d)", j.ID, string(j.Type), len(m.active), len(m.queue))
+		go m.execute(j)
+		started = true
+	}
+	return started
+}
 
-		// run job serially
-		j.mu.Lock()
-		j.Status = StatusRunning
-		j.StartedAt = time.Now()
-		j.progressNotify = m.notify
-		j.mu.Unlock()
-		dbg("start job id=%d", j.ID)
-		m.notify()
-		err := m.runJob(j)
-		j.mu.Lock()
-		j.progressNotify = nil
-		if err != nil {
-			if errors.Is(err, errCanceled) {
-				j.Status = StatusCanceled
-				dbg("job canceled id=%d after %d/%d", j.ID, j.DoneFiles, j.TotalFiles)
-			} else {
-				j.Status = StatusFailed
-				j.Error = err.Error()
-				dbg("job failed id=%d err=%v", j.ID, err)
-			}
+func (m *Manager) volumesAvailableLocked(volumes []string, reserved map[string]bool) bool {
+	for _, v := range volumes {
+		if reserved[v] {
+			return false
+		}
+		if m.perVolumeLimit > 0 && m.volumeLoad[v] >= m.perVolumeLimit {
+			return false
+		}
+	}
+	return true
+}
+
+// execute runs one dispatched job and releases its slot afterwards.
+func (m *Manager) execute(j *Job) {
+	j.mu.Lock()
+	j.Status = StatusRunning
+	j.StartedAt = time.Now()
+	j.progressNotify = m.notify
+	j.mu.Unlock()
+	j.duplicateNames = m.currentDuplicateNames()
+	dbg("start job id=%d", j.ID)
+	m.notify()
+	err := m.runJob(j)
+	j.mu.Lock()
+	j.progressNotify = nil
+	if err != nil {
+		if errors.Is(err, errCanceled) {
+			j.Status = StatusCanceled
+			dbg("job canceled id=%d after %d/%d", j.ID, j.DoneFiles, j.TotalFiles)
 		} else {
-			j.Status = StatusCompleted
-			dbg("job completed id=%d done=%d", j.ID, j.DoneFiles)
+			j.Status = StatusFailed
+			j.Error = err.Error()
+			dbg("job failed id=%d err=%v", j.ID, err)
+		}
+	} else {
+		j.Status = StatusCompleted
+		dbg("job completed id=%d done=%d", j.ID, j.DoneFiles)
+	}
+	j.CompletedAt = time.Now()
+	j.mu.Unlock()
+	m.mu.Lock()
+	m.releaseLocked(j)
+	m.addHistoryLocked(j)
+	m.dispatchLocked()
+	m.mu.Unlock()
+	m.saveHistory()
+	m.auditJob(j)
+	m.notify()
+}
+
+// releaseLocked removes j from the running set; caller must hold m.mu.
+func (m *Manager) releaseLocked(j *Job) {
+	for i, active := range m.active {
+		if active == j {
+			m.active = append(m.active[:i], m.active[i+1:]...)
+			break
+		}
+	}
+	for _, v := range j.volumes {
+		if m.volumeLoad[v] <= 1 {
+			delete(m.volumeLoad, v)
+		} else {
+			m.volumeLoad[v]--
 		}
-		j.CompletedAt = time.Now()
-		j.mu.Unlock()
-		m.notify()
-		m.mu.Lock()
-		m.current = nil
-		m.addHistoryLocked(j)
-		m.mu.Unlock()
 	}
 }
 
 // addHistoryLocked appends a finished job to history and trims oldest; caller must hold m.mu
 func (m *Manager) addHistoryLocked(j *Job) {
 	m.history = append(m.history, j)
+	m.trimHistoryLocked()
+}
+
+func (m *Manager) trimHistoryLocked() {
 	if m.historyMax > 0 && len(m.history) > m.historyMax {
 		drop := len(m.history) - m.historyMax
 		if drop > 0 {
@@ -353,11 +566,34 @@ func (m *Manager) runJob(j *Job) error {
 	if j.Type == TypeExtract {
 		return m.runExtractJob(j)
 	}
+	if j.Type == TypeArchive {
+		return m.runArchiveJob(j)
+	}
+	if j.Type == TypeSymlink {
+		return m.runSymlinkJob(j)
+	}
+	if j.Type == TypeChecksum {
+		return m.runChecksumJob(j)
+	}
+	if j.Type == TypeVerify {
+		return m.runVerifyJob(j)
+	}
+	if j.Type == TypeSync {
+		return m.runSyncJob(j)
+	}
+	if j.Type == TypeRename {
+		return m.runRenameJob(j)
+	}
 	destPath, err := resolveExecutionPath(j.DestDir)
 	if err != nil {
 		return wrapPath(j.DestDir, err)
 	}
 	execCtx := newExecutionContext()
+	execCtx.jobCtx = j.ctx
+	execCtx.resume = m.resumeJournal()
+	if elevator := m.currentElevator(); elevator != nil && j.Type == TypeCopy {
+		execCtx.elevation = &elevation{elevator: elevator}
+	}
 	defer func() {
 		if err := execCtx.close(); err != nil {
 			dbg("job %d: execution context close error: %v", j.ID, err)
@@ -366,6 +602,27 @@ func (m *Manager) runJob(j *Job) error {
 	if err := validateDestinationDirectory(execCtx, destPath); err != nil {
 		return err
 	}
+	var relativeBase *executionPath
+	if j.Options.Layout == LayoutRelative && j.Options.RelativeBase != "" {
+		base, err := resolveExecutionPath(j.Options.RelativeBase)
+		if err != nil {
+			return wrapPath(j.Options.RelativeBase, err)
+		}
+		relativeBase = &base
+	}
+	sourceBytes := measureSources(j, execCtx)
+	if canceled(j) {
+		return errCanceled
+	}
+	if err := checkFreeSpace(j, sourceBytes, destPath); err != nil {
+		return err
+	}
+	m.notify()
+	if j.Options.Layout == LayoutFlatten && j.conflictDefault == "" {
+		// Flattening routinely collides same-named files from different
+		// directories; keep them all instead of prompting per file.
+		j.conflictDefault = ConflictAutoSuffix
+	}
 	for i, src := range j.Sources {
 		if canceled(j) {
 			return errCanceled
@@ -381,13 +638,20 @@ func (m *Manager) runJob(j *Job) error {
 		if err != nil {
 			err = wrapPath(src, err)
 		} else {
-			err = copyOrMovePathResolved(j, execCtx, srcPath, destPath)
+			var target executionPath
+			target, err = transferTarget(j, execCtx, src, srcPath, destPath, relativeBase)
+			if err == nil && j.Options.Layout == LayoutFlatten {
+				err = flattenPathResolved(j, execCtx, srcPath, target)
+			} else if err == nil {
+				err = copyOrMovePathResolved(j, execCtx, srcPath, target)
+			}
 		}
 		if err != nil {
 			if errors.Is(err, errSkipped) {
 				dbg("job %d: skipped %s", j.ID, src)
 				j.mu.Lock()
 				j.DoneFiles = i + 1
+				j.finishSourceBytesLocked(sourceBytes[i])
 				j.clearFileProgressLocked()
 				j.mu.Unlock()
 				m.notify()
@@ -402,22 +666,81 @@ func (m *Manager) runJob(j *Job) error {
 		}
 		j.mu.Lock()
 		j.DoneFiles = i + 1
+		j.finishSourceBytesLocked(sourceBytes[i])
 		j.clearFileProgressLocked()
 		j.mu.Unlock()
 		dbg("job %d: done %d/%d", j.ID, j.DoneFiles, j.TotalFiles)
 		m.notify()
 	}
-	return nil
+	return m.placeElevated(j, execCtx)
+}
+
+// measureSources precomputes the regular-file bytes under each source and
+// records their sum as the job's TotalBytes. Unreadable entries count as zero
+// so a partial measurement never blocks the transfer itself.
+func measureSources(j *Job, execCtx *executionContext) []int64 {
+	sizes := make([]int64, len(j.Sources))
+	var total int64
+	for i, src := range j.Sources {
+		if canceled(j) {
+			break
+		}
+		p, err := resolveExecutionPath(src)
+		if err != nil {
+			continue
+		}
+		sizes[i] = treeBytes(j, execCtx, p)
+		total += sizes[i]
+	}
+	j.mu.Lock()
+	j.TotalBytes = total
+	j.mu.Unlock()
+	dbg("job %d: measured %d bytes in %d source(s)", j.ID, total, len(j.Sources))
+	return sizes
+}
+
+func treeBytes(j *Job, execCtx *executionContext, p executionPath) int64 {
+	fi, err := lstatPath(execCtx, p)
+	if err != nil {
+		return 0
+	}
+	if !fi.IsDir() {
+		if fi.Mode().IsRegular() {
+			return fi.Size()
+		}
+		return 0
+	}
+	if isLinkLikeForTraversal(execCtx, p, fi) {
+		return 0
+	}
+	entries, err := readDir(execCtx, p)
+	if err != nil {
+		return 0
+	}
+	var total int64
+	for _, e := range entries {
+		if canceled(j) {
+			return total
+		}
+		total += treeBytes(j, execCtx, joinPath(p, e.Name()))
+	}
+	return total
 }
 
 func (m *Manager) runDeleteJob(j *Job) error {
 	execCtx := newExecutionContext()
+	execCtx.jobCtx = j.ctx
 	defer func() {
 		if err := execCtx.close(); err != nil {
 			dbg("job %d: SMB session close error: %v", j.ID, err)
 		}
 	}()
 
+	var sourceBytes []int64
+	if j.DeleteMode == DeleteModeSecure {
+		// Overwriting is as slow as copying, so report byte progress too.
+		sourceBytes = measureSources(j, execCtx)
+	}
 	for i, src := range j.Sources {
 		if canceled(j) {
 			return errCanceled
@@ -429,9 +752,10 @@ func (m *Manager) runDeleteJob(j *Job) error {
 		m.notify()
 
 		var err error
-		if j.DeleteMode == DeleteModePermanent {
+		switch j.DeleteMode {
+		case DeleteModePermanent, DeleteModeSecure:
 			err = deletePermanentPath(j, execCtx, src)
-		} else {
+		default:
 			err = trashPath(j.ctx, src)
 		}
 		if err != nil {
@@ -443,6 +767,10 @@ func (m *Manager) runDeleteJob(j *Job) error {
 		}
 		j.mu.Lock()
 		j.DoneFiles = i + 1
+		if sourceBytes != nil {
+			j.finishSourceBytesLocked(sourceBytes[i])
+			j.clearFileProgressLocked()
+		}
 		j.mu.Unlock()
 		m.notify()
 	}
@@ -457,8 +785,12 @@ func (m *Manager) runExtractJob(j *Job) error {
 	if destPath.backend == backendArchive {
 		return wrapPath(destPath.displayPath(), errors.New("archive destinations are read-only"))
 	}
+	if destPath.backend == backendGio {
+		return wrapPath(destPath.displayPath(), errGioReadOnly)
+	}
 
 	execCtx := newExecutionContext()
+	execCtx.jobCtx = j.ctx
 	defer func() {
 		if err := execCtx.close(); err != nil {
 			dbg("job %d: SMB session close error: %v", j.ID, err)
@@ -511,6 +843,8 @@ var errCanceled = errors.New("job canceled")
 var errSkipped = errors.New("job item skipped")
 var errUnsafeDeleteTarget = errors.New("unsafe delete target")
 
+var errGioReadOnly = errors.New("gio locations without a gvfs FUSE mount are read-only")
+
 var trashPath = fileinfo.TrashPath
 
 const progressNotifyInterval = 350 * time.Millisecond
@@ -521,6 +855,7 @@ const (
 	backendLocal executionBackend = iota
 	backendSMB
 	backendArchive
+	backendGio
 )
 
 type executionPath struct {
@@ -531,11 +866,20 @@ type executionPath struct {
 	smbOpener      fileinfo.SMBSessionOpener
 	smbDisplayRoot string
 	archivePath    string
+	gio            fileinfo.VFS
 }
 
 type executionContext struct {
 	smbSessions map[string]fileinfo.SMBSession
 	archiveVFSs map[string]*fileinfo.ArchiveVFS
+	resume      *resumeJournal
+	elevation   *elevation
+	// jobCtx ends with the job; SMB sessions waiting for re-entered
+	// credentials give up then.
+	jobCtx context.Context
+	// metadataOff holds the metadata kinds the job stopped preserving after
+	// the destination or the user's rights ruled them out.
+	metadataOff map[string]bool
 }
 
 type virtualFileInfo struct {
@@ -560,6 +904,9 @@ func deletePermanentPath(j *Job, execCtx *executionContext, src string) error {
 	if err := validateDeleteTarget(srcPath); err != nil {
 		return wrapPath(srcPath.displayPath(), err)
 	}
+	if j.DeleteMode == DeleteModeSecure && srcPath.backend != backendLocal {
+		return wrapPath(srcPath.displayPath(), errSecureDeleteUnsupported)
+	}
 	return deletePermanentResolved(j, execCtx, srcPath)
 }
 
@@ -567,6 +914,8 @@ func validateDeleteTarget(p executionPath) error {
 	switch p.backend {
 	case backendArchive:
 		return fmt.Errorf("%w: archive paths are read-only", errUnsafeDeleteTarget)
+	case backendGio:
+		return fmt.Errorf("%w: gio locations without a gvfs FUSE mount are read-only", errUnsafeDeleteTarget)
 	case backendSMB:
 		clean := normalizeSMBExecutionPath(p.path)
 		if clean == "/" || clean == "." || clean == "" {
@@ -618,6 +967,14 @@ func deletePermanentResolved(j *Job, execCtx *executionContext, src executionPat
 	if canceled(j) {
 		return errCanceled
 	}
+	if j.DeleteMode == DeleteModeSecure && fi.Mode().IsRegular() {
+		dbg("job %d: shred %s", j.ID, src.displayPath())
+		shredded, err := shredFile(j, src.path, fi)
+		if err != nil {
+			return wrapPath(src.displayPath(), err)
+		}
+		src.path = shredded
+	}
 	dbg("job %d: permanent delete %s", j.ID, src.displayPath())
 	if err := removePath(execCtx, src); err != nil {
 		return wrapPath(src.displayPath(), err)
@@ -652,6 +1009,9 @@ func (ctx *executionContext) close() error {
 		}
 	}
 	ctx.archiveVFSs = make(map[string]*fileinfo.ArchiveVFS)
+	if err := ctx.elevation.close(); err != nil {
+		closeErr = errors.Join(closeErr, err)
+	}
 	return closeErr
 }
 
@@ -692,7 +1052,11 @@ func (ctx *executionContext) smbOpsFor(p executionPath) (fileinfo.SMBPathOps, er
 		return session, nil
 	}
 
-	session, err := p.smbOpener.OpenSession()
+	opCtx := ctx.jobCtx
+	if opCtx == nil {
+		opCtx = context.Background()
+	}
+	session, err := p.smbOpener.OpenSession(opCtx)
 	if err != nil {
 		return nil, err
 	}
@@ -704,6 +1068,11 @@ func (p executionPath) displayPath() string {
 	switch p.backend {
 	case backendArchive:
 		return fileinfo.ArchiveDisplayPath(p.archivePath, p.path)
+	case backendGio:
+		if display, _, err := fileinfo.CanonicalDisplayPath(p.path); err == nil {
+			return display
+		}
+		return p.path
 	case backendSMB:
 		root := p.smbDisplayRoot
 		if root == "" {
@@ -766,6 +1135,7 @@ func copyOrMovePath(j *Job, src string, destDir string) error {
 	}
 
 	execCtx := newExecutionContext()
+	execCtx.jobCtx = j.ctx
 	defer func() {
 		if err := execCtx.close(); err != nil {
 			dbg("job %d: SMB session close error: %v", j.ID, err)
@@ -782,6 +1152,9 @@ func validateDestinationDirectory(execCtx *executionContext, dest executionPath)
 	if dest.backend == backendArchive {
 		return wrapPath(dest.displayPath(), errors.New("archive destinations are read-only"))
 	}
+	if dest.backend == backendGio {
+		return wrapPath(dest.displayPath(), errGioReadOnly)
+	}
 	info, err := statPath(execCtx, dest)
 	if err != nil {
 		return wrapPath(dest.displayPath(), err)
@@ -796,9 +1169,15 @@ func copyOrMovePathResolved(j *Job, execCtx *executionContext, src executionPath
 	if destDir.backend == backendArchive {
 		return wrapPath(destDir.displayPath(), errors.New("archive destinations are read-only"))
 	}
+	if destDir.backend == backendGio {
+		return wrapPath(destDir.displayPath(), errGioReadOnly)
+	}
 	if j.Type == TypeMove && src.backend == backendArchive {
 		return wrapPath(src.displayPath(), errors.New("cannot move out of an archive; use copy instead"))
 	}
+	if j.Type == TypeMove && src.backend == backendGio {
+		return wrapPath(src.displayPath(), errors.New("cannot move out of a gio location without a gvfs FUSE mount; use copy instead"))
+	}
 
 	fi, err := lstatPath(execCtx, src)
 	if err != nil {
@@ -838,6 +1217,7 @@ func copyOrMovePathResolved(j *Job, execCtx *executionContext, src executionPath
 			if !overwrite {
 				if err := renamePath(execCtx, src, dst); err == nil {
 					dbg("job %d: rename link %s -> %s", j.ID, src.displayPath(), dst.displayPath())
+					j.countMove(true)
 					return nil
 				} else {
 					dbg("job %d: rename link fallback %s -> %s: %v", j.ID, src.displayPath(), dst.displayPath(), err)
@@ -846,6 +1226,7 @@ func copyOrMovePathResolved(j *Job, execCtx *executionContext, src executionPath
 				return wrapPath(dst.displayPath(), err)
 			} else if err := renamePath(execCtx, src, dst); err == nil {
 				dbg("job %d: rename link %s -> %s", j.ID, src.displayPath(), dst.displayPath())
+				j.countMove(true)
 				return nil
 			} else {
 				dbg("job %d: rename link fallback %s -> %s: %v", j.ID, src.displayPath(), dst.displayPath(), err)
@@ -865,13 +1246,20 @@ func copyOrMovePathResolved(j *Job, execCtx *executionContext, src executionPath
 			if err := removePath(execCtx, src); err != nil {
 				return wrapPath(src.displayPath(), err)
 			}
+			j.countMove(false)
 		}
 		return nil
 	}
 
 	if fi.IsDir() {
+		if existing, err := lstatPath(execCtx, dst); err == nil && existing.IsDir() {
+			j.mergeDepth++
+			defer func() { j.mergeDepth-- }()
+		}
 		dbg("job %d: mkdir %s (mode=%v)", j.ID, dst.displayPath(), fi.Mode())
-		if err := ensureDir(execCtx, dst, fi.Mode()); err != nil {
+		if err := ensureDir(execCtx, dst, fi.Mode()); execCtx.canElevate(dst, err) {
+			execCtx.deferDir(j, dst, fi.Mode())
+		} else if err != nil {
 			return wrapPath(dst.displayPath(), err)
 		}
 		entries, err := readDir(execCtx, src)
@@ -899,10 +1287,8 @@ func copyOrMovePathResolved(j *Job, execCtx *executionContext, src executionPath
 		if skippedChild {
 			return errSkipped
 		}
-		if shouldPreserveTimestamps(j) {
-			if err := chtimesPath(execCtx, dst, fi.ModTime(), fi.ModTime()); err != nil {
-				return wrapPath(dst.displayPath(), err)
-			}
+		if !execCtx.deferredDir(dst) {
+			preserveMetadata(j, execCtx, &src, dst, fi)
 		}
 		if j.Type == TypeMove {
 			if canceled(j) {
@@ -930,12 +1316,13 @@ func copyOrMovePathResolved(j *Job, execCtx *executionContext, src executionPath
 		if err := removePath(execCtx, src); err != nil {
 			return wrapPath(src.displayPath(), err)
 		}
+		j.countMove(false)
 	}
 	return nil
 }
 
 func validateArchiveSourceName(src executionPath, name string) error {
-	if src.backend != backendArchive {
+	if src.backend != backendArchive && src.backend != backendGio {
 		return nil
 	}
 	return fileinfo.ValidateArchiveEntryBaseName(name)
@@ -989,11 +1376,7 @@ func extractArchivePath(j *Job, execCtx *executionContext, src string, destDir e
 			if err := ensureDir(execCtx, dst, entry.Info.Mode()); err != nil {
 				return wrapPath(dst.displayPath(), err)
 			}
-			if shouldPreserveTimestamps(j) {
-				if err := chtimesPath(execCtx, dst, entry.Info.ModTime(), entry.Info.ModTime()); err != nil {
-					return wrapPath(dst.displayPath(), err)
-				}
-			}
+			preserveMetadata(j, execCtx, nil, dst, entry.Info)
 			return nil
 		}
 
@@ -1078,8 +1461,13 @@ func tryFastMovePath(j *Job, execCtx *executionContext, src, dst executionPath,
 			return false, nil
 		}
 	}
+	if crossesVolumes(src, dst) {
+		dbg("job %d: copy across volumes %s -> %s", j.ID, src.displayPath(), dst.displayPath())
+		return false, nil
+	}
 	if err := renamePath(execCtx, src, dst); err == nil {
 		dbg("job %d: rename %s -> %s", j.ID, src.displayPath(), dst.displayPath())
+		j.countMove(true)
 		return true, nil
 	} else {
 		dbg("job %d: rename fallback %s -> %s: %v", j.ID, src.displayPath(), dst.displayPath(), err)
@@ -1087,6 +1475,35 @@ func tryFastMovePath(j *Job, execCtx *executionContext, src, dst executionPath,
 	return false, nil
 }
 
+// crossesVolumes reports whether src and dst are known to be on different
+// filesystems or shares, where a rename cannot work and a move must copy
+// and delete. Unknown volumes count as the same, so the rename is tried.
+func crossesVolumes(src, dst executionPath) bool {
+	if src.backend != dst.backend {
+		return true
+	}
+	switch src.backend {
+	case backendSMB:
+		return normalizeSMBRoot(src.smbDisplayRoot) != normalizeSMBRoot(dst.smbDisplayRoot)
+	case backendLocal:
+		a, b := localVolumeKey(src.path), localVolumeKey(dirPath(dst).path)
+		return a != "" && b != "" && a != b
+	default:
+		return false
+	}
+}
+
+// countMove records how a move put one item in place, for the job details.
+func (j *Job) countMove(renamed bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if renamed {
+		j.MoveRenames++
+	} else {
+		j.MoveCopies++
+	}
+}
+
 func resolveDestinationConflict(j *Job, execCtx *executionContext, src, dst executionPath, srcInfo os.FileInfo) (executionPath, bool, bool, error) {
 	if sameExecutionPath(src, dst) {
 		if j.Type == TypeMove {
@@ -1103,14 +1520,67 @@ func resolveDestinationConflict(j *Job, execCtx *executionContext, src, dst exec
 		return dst, false, false, wrapPath(dst.displayPath(), err)
 	}
 	if srcInfo.IsDir() && dstInfo.IsDir() {
-		return dst, false, false, nil
+		return askDirectoryConflict(j, execCtx, src, dst, srcInfo, dstInfo)
 	}
 	return askDestinationConflict(j, execCtx, src, dst, srcInfo, dstInfo)
 }
 
+// askDirectoryConflict resolves a directory landing on an existing directory.
+// Merge stays the non-interactive and extraction behavior; interactive
+// copy/move jobs may also skip, rename, or auto-suffix the incoming directory.
+func askDirectoryConflict(j *Job, execCtx *executionContext, src, dst executionPath, srcInfo, dstInfo os.FileInfo) (executionPath, bool, bool, error) {
+	if j.Resolver == nil || j.Type == TypeExtract || j.mergeDepth > 0 {
+		return dst, false, false, nil
+	}
+	resolution := ConflictResolution{Action: j.dirConflictDefault}
+	suggested, err := nextAvailablePath(j, execCtx, dst, true)
+	if err != nil {
+		return dst, false, false, err
+	}
+	if resolution.Action == "" {
+		resolution = resolveConflict(j, ConflictRequest{
+			JobID:          j.ID,
+			Type:           j.Type,
+			SourcePath:     src.displayPath(),
+			Destination:    dst.displayPath(),
+			SourceModified: srcInfo.ModTime(),
+			DestModified:   dstInfo.ModTime(),
+			SuggestedName:  baseName(suggested),
+			SuggestedPath:  suggested.displayPath(),
+			IsDir:          true,
+			DefaultAction:  ConflictMerge,
+			CanApplyToRest: true,
+			CanMerge:       true,
+		})
+		if resolution.ApplyToRest && resolution.Action != ConflictRename {
+			j.dirConflictDefault = resolution.Action
+		}
+	}
+
+	switch resolution.Action {
+	case ConflictMerge, ConflictOverwrite, ConflictOverwriteIfNewer:
+		return dst, false, false, nil
+	case ConflictSkip:
+		return dst, true, false, nil
+	case ConflictCancelJob:
+		return dst, false, false, errCanceled
+	case ConflictRename:
+		name, err := fileinfo.ValidateRenameName(resolution.NewName)
+		if err != nil {
+			return dst, false, false, wrapPath(dst.displayPath(), err)
+		}
+		renamed := joinPath(dirPath(dst), name)
+		return resolveDestinationConflict(j, execCtx, src, renamed, srcInfo)
+	case ConflictAutoSuffix, "":
+		return suggested, false, false, nil
+	default:
+		return dst, false, false, fmt.Errorf("unknown conflict action: %s", resolution.Action)
+	}
+}
+
 func askDestinationConflict(j *Job, execCtx *executionContext, src, dst executionPath, srcInfo, dstInfo os.FileInfo) (executionPath, bool, bool, error) {
 	for {
-		suggested, err := nextAvailablePath(execCtx, dst)
+		suggested, err := nextAvailablePath(j, execCtx, dst, srcInfo.IsDir())
 		if err != nil {
 			return dst, false, false, err
 		}
@@ -1233,6 +1703,9 @@ func sameExecutionPath(a, b executionPath) bool {
 		return normalizeSMBRoot(a.smbDisplayRoot) == normalizeSMBRoot(b.smbDisplayRoot) &&
 			normalizeSMBExecutionPath(a.path) == normalizeSMBExecutionPath(b.path)
 	}
+	if a.backend == backendGio {
+		return strings.TrimRight(a.path, "/") == strings.TrimRight(b.path, "/")
+	}
 
 	ap := filepath.Clean(a.path)
 	bp := filepath.Clean(b.path)
@@ -1258,6 +1731,9 @@ func isDescendantExecutionPath(child, parent executionPath) bool {
 			return false
 		}
 		return isDescendantSlashPath(child.path, parent.path)
+	case backendGio:
+		parentPath := strings.TrimRight(parent.path, "/") + "/"
+		return strings.HasPrefix(child.path, parentPath) && len(child.path) > len(parentPath)
 	default:
 		childPath := filepath.Clean(child.path)
 		parentPath := filepath.Clean(parent.path)
@@ -1298,27 +1774,18 @@ func pathExists(execCtx *executionContext, p executionPath) (bool, error) {
 	}
 }
 
-func nextAvailablePath(execCtx *executionContext, dst executionPath) (executionPath, error) {
+// nextAvailablePath names the copy of dst that auto-suffix and the conflict
+// dialog suggest, using the manager's duplicate name pattern.
+func nextAvailablePath(j *Job, execCtx *executionContext, dst executionPath, isDir bool) (executionPath, error) {
 	dir := dirPath(dst)
-	stem, ext := splitCopyName(baseName(dst))
-	for i := 1; ; i++ {
-		candidate := joinPath(dir, fmt.Sprintf("%s (%d)%s", stem, i, ext))
-		exists, err := pathExists(execCtx, candidate)
-		if err != nil {
-			return candidate, wrapPath(candidate.displayPath(), err)
-		}
-		if !exists {
-			return candidate, nil
-		}
-	}
-}
-
-func splitCopyName(name string) (string, string) {
-	dot := strings.LastIndex(name, ".")
-	if dot <= 0 {
-		return name, ""
+	name, err := j.duplicateNames.Next(baseName(dst), isDir, func(name string) (bool, error) {
+		return pathExists(execCtx, joinPath(dir, name))
+	})
+	candidate := joinPath(dir, name)
+	if err != nil {
+		return candidate, wrapPath(candidate.displayPath(), err)
 	}
-	return name[:dot], name[dot:]
+	return candidate, nil
 }
 
 func normalizeSMBRoot(root string) string {
@@ -1370,6 +1837,14 @@ func resolveExecutionPath(p string) (executionPath, error) {
 		}, nil
 	}
 
+	if fileinfo.IsGioProviderPath(parsed) {
+		return executionPath{
+			raw:     p,
+			path:    native,
+			backend: backendGio,
+			gio:     vfs,
+		}, nil
+	}
 	if parsed.Scheme == fileinfo.SchemeSMB && parsed.Provider != "local" {
 		smb, ok := vfs.(fileinfo.SMBPathOps)
 		if !ok {
@@ -1412,6 +1887,9 @@ func baseName(p executionPath) string {
 	if p.backend == backendSMB {
 		return p.smb.Base(p.path)
 	}
+	if p.backend == backendGio {
+		return p.gio.Base(p.path)
+	}
 	return filepath.Base(p.path)
 }
 
@@ -1425,6 +1903,8 @@ func joinPath(base executionPath, name string) executionPath {
 		}
 	} else if base.backend == backendSMB {
 		out.path = base.smb.Join(base.path, name)
+	} else if base.backend == backendGio {
+		out.path = base.gio.Join(base.path, name)
 	} else {
 		out.path = filepath.Join(base.path, name)
 	}
@@ -1443,6 +1923,13 @@ func dirPath(p executionPath) executionPath {
 		out.raw = out.path
 		return out
 	}
+	if p.backend == backendGio {
+		if _, parsed, err := fileinfo.CanonicalDisplayPath(fileinfo.ParentPath(p.path)); err == nil {
+			out.path = parsed.Native
+		}
+		out.raw = out.path
+		return out
+	}
 	if p.backend == backendSMB {
 		clean := strings.ReplaceAll(p.path, "\\", "/")
 		parent := pathpkg.Dir(clean)
@@ -1459,6 +1946,9 @@ func dirPath(p executionPath) executionPath {
 }
 
 func lstatPath(execCtx *executionContext, p executionPath) (os.FileInfo, error) {
+	if err := fileinfo.InjectFault(fileinfo.FaultOpStat, p.path); err != nil {
+		return nil, err
+	}
 	if p.backend == backendArchive {
 		vfs, err := execCtx.archiveVFSFor(p)
 		if err != nil {
@@ -1466,6 +1956,9 @@ func lstatPath(execCtx *executionContext, p executionPath) (os.FileInfo, error)
 		}
 		return vfs.Stat(p.path)
 	}
+	if p.backend == backendGio {
+		return p.gio.Stat(p.path)
+	}
 	if p.backend == backendSMB {
 		ops, err := execCtx.smbOpsFor(p)
 		if err != nil {
@@ -1477,6 +1970,9 @@ func lstatPath(execCtx *executionContext, p executionPath) (os.FileInfo, error)
 }
 
 func statPath(execCtx *executionContext, p executionPath) (os.FileInfo, error) {
+	if err := fileinfo.InjectFault(fileinfo.FaultOpStat, p.path); err != nil {
+		return nil, err
+	}
 	if p.backend == backendArchive {
 		vfs, err := execCtx.archiveVFSFor(p)
 		if err != nil {
@@ -1484,6 +1980,9 @@ func statPath(execCtx *executionContext, p executionPath) (os.FileInfo, error) {
 		}
 		return vfs.Stat(p.path)
 	}
+	if p.backend == backendGio {
+		return p.gio.Stat(p.path)
+	}
 	if p.backend == backendSMB {
 		ops, err := execCtx.smbOpsFor(p)
 		if err != nil {
@@ -1495,6 +1994,9 @@ func statPath(execCtx *executionContext, p executionPath) (os.FileInfo, error) {
 }
 
 func readDir(execCtx *executionContext, p executionPath) ([]os.DirEntry, error) {
+	if err := fileinfo.InjectFault(fileinfo.FaultOpReadDir, p.path); err != nil {
+		return nil, err
+	}
 	if p.backend == backendArchive {
 		vfs, err := execCtx.archiveVFSFor(p)
 		if err != nil {
@@ -1502,6 +2004,9 @@ func readDir(execCtx *executionContext, p executionPath) ([]os.DirEntry, error)
 		}
 		return vfs.ReadDir(p.path)
 	}
+	if p.backend == backendGio {
+		return p.gio.ReadDir(p.path)
+	}
 	if p.backend == backendSMB {
 		ops, err := execCtx.smbOpsFor(p)
 		if err != nil {
@@ -1513,9 +2018,15 @@ func readDir(execCtx *executionContext, p executionPath) ([]os.DirEntry, error)
 }
 
 func ensureDir(execCtx *executionContext, p executionPath, mode os.FileMode) error {
+	if err := fileinfo.InjectFault(fileinfo.FaultOpMkdir, p.path); err != nil {
+		return err
+	}
 	if p.backend == backendArchive {
 		return errors.New("archive paths are read-only")
 	}
+	if p.backend == backendGio {
+		return errGioReadOnly
+	}
 	perm := mode.Perm()
 	if perm == 0 {
 		perm = 0755
@@ -1539,6 +2050,9 @@ func chtimesPath(execCtx *executionContext, p executionPath, atime, mtime time.T
 	if p.backend == backendArchive {
 		return errors.New("archive paths are read-only")
 	}
+	if p.backend == backendGio {
+		return errGioReadOnly
+	}
 	if p.backend == backendSMB {
 		ops, err := execCtx.smbOpsFor(p)
 		if err != nil {
@@ -1550,9 +2064,15 @@ func chtimesPath(execCtx *executionContext, p executionPath, atime, mtime time.T
 }
 
 func removePath(execCtx *executionContext, p executionPath) error {
+	if err := fileinfo.InjectFault(fileinfo.FaultOpRemove, p.path); err != nil {
+		return err
+	}
 	if p.backend == backendArchive {
 		return errors.New("archive paths are read-only")
 	}
+	if p.backend == backendGio {
+		return errGioReadOnly
+	}
 	if p.backend == backendSMB {
 		ops, err := execCtx.smbOpsFor(p)
 		if err != nil {
@@ -1567,6 +2087,9 @@ func readlinkPath(execCtx *executionContext, p executionPath) (string, error) {
 	if p.backend == backendArchive {
 		return "", errors.New("archive symlink targets are not supported")
 	}
+	if p.backend == backendGio {
+		return "", errors.New("gio symlink targets are not supported")
+	}
 	if p.backend == backendSMB {
 		ops, err := execCtx.smbOpsFor(p)
 		if err != nil {
@@ -1581,6 +2104,9 @@ func symlinkPath(execCtx *executionContext, target string, link executionPath) e
 	if link.backend == backendArchive {
 		return errors.New("archive paths are read-only")
 	}
+	if link.backend == backendGio {
+		return errGioReadOnly
+	}
 	if link.backend == backendSMB {
 		ops, err := execCtx.smbOpsFor(link)
 		if err != nil {
@@ -1592,12 +2118,18 @@ func symlinkPath(execCtx *executionContext, target string, link executionPath) e
 }
 
 func renamePath(execCtx *executionContext, src executionPath, dst executionPath) error {
+	if err := fileinfo.InjectFault(fileinfo.FaultOpRename, src.path); err != nil {
+		return err
+	}
 	if src.backend != dst.backend {
 		return errors.New("cannot rename across backends")
 	}
 	if dst.backend == backendArchive {
 		return errors.New("archive paths are read-only")
 	}
+	if dst.backend == backendGio {
+		return errGioReadOnly
+	}
 	if dst.backend == backendSMB {
 		if normalizeSMBRoot(src.smbDisplayRoot) != normalizeSMBRoot(dst.smbDisplayRoot) {
 			return errors.New("cannot rename across SMB shares")
@@ -1612,6 +2144,9 @@ func renamePath(execCtx *executionContext, src executionPath, dst executionPath)
 }
 
 func openReadPath(execCtx *executionContext, p executionPath) (io.ReadCloser, error) {
+	if err := fileinfo.InjectFault(fileinfo.FaultOpOpen, p.path); err != nil {
+		return nil, err
+	}
 	if p.backend == backendArchive {
 		vfs, err := execCtx.archiveVFSFor(p)
 		if err != nil {
@@ -1619,6 +2154,9 @@ func openReadPath(execCtx *executionContext, p executionPath) (io.ReadCloser, er
 		}
 		return vfs.Open(p.path)
 	}
+	if p.backend == backendGio {
+		return p.gio.Open(p.path)
+	}
 	if p.backend == backendSMB {
 		ops, err := execCtx.smbOpsFor(p)
 		if err != nil {
@@ -1630,9 +2168,15 @@ func openReadPath(execCtx *executionContext, p executionPath) (io.ReadCloser, er
 }
 
 func openWritePath(execCtx *executionContext, p executionPath, mode os.FileMode) (io.ReadWriteCloser, error) {
+	if err := fileinfo.InjectFault(fileinfo.FaultOpWrite, p.path); err != nil {
+		return nil, err
+	}
 	if p.backend == backendArchive {
 		return nil, errors.New("archive paths are read-only")
 	}
+	if p.backend == backendGio {
+		return nil, errGioReadOnly
+	}
 	perm := mode.Perm()
 	if perm == 0 {
 		perm = 0666
@@ -1653,6 +2197,9 @@ func replacePath(execCtx *executionContext, tmp executionPath, dst executionPath
 	if dst.backend == backendArchive {
 		return errors.New("archive paths are read-only")
 	}
+	if dst.backend == backendGio {
+		return errGioReadOnly
+	}
 	if dst.backend == backendSMB {
 		ops, err := execCtx.smbOpsFor(dst)
 		if err != nil {
@@ -1678,26 +2225,82 @@ func replacePath(execCtx *executionContext, tmp executionPath, dst executionPath
 }
 
 func copyFileWithCancel(j *Job, execCtx *executionContext, src, dst executionPath, fi os.FileInfo, overwrite bool) error {
-	in, err := openReadPath(execCtx, src)
-	if err != nil {
-		return wrapPath(src.displayPath(), err)
+	var resume *resumeTransfer
+	var in io.ReadCloser
+	var offset int64
+	if execCtx.resume != nil && resumableTransfer(src, dst, fi) {
+		resume = newResumeTransfer(execCtx.resume, src, dst, fi)
+		in, offset = resumeSource(execCtx, resume, src, partPath(dst))
+		if in != nil {
+			ok, err := confirmResume(j, execCtx, src, dst, fi, offset)
+			if err != nil {
+				// Canceling keeps the partial file for a later attempt.
+				in.Close()
+				return err
+			}
+			if !ok {
+				in.Close()
+				in, offset = nil, 0
+				resume.discard()
+			}
+		}
+	}
+	if in == nil {
+		var err error
+		if in, err = openReadPath(execCtx, src); err != nil {
+			return wrapPath(src.displayPath(), err)
+		}
 	}
 	defer in.Close()
-	return copyReaderWithCancel(j, execCtx, in, src.displayPath(), dst, fi, overwrite)
+	return copyToPart(j, execCtx, in, &src, src.displayPath(), dst, fi, overwrite, resume, offset)
 }
 
 func copyReaderWithCancel(j *Job, execCtx *executionContext, in io.Reader, srcDisplay string, dst executionPath, fi os.FileInfo, overwrite bool) error {
+	return copyToPart(j, execCtx, in, nil, srcDisplay, dst, fi, overwrite, nil, 0)
+}
+
+// partPath is the temporary file a copy to dst writes before renaming.
+func partPath(dst executionPath) executionPath {
 	tmp := dst
 	tmp.path = dst.path + ".part"
 	tmp.raw = tmp.path
+	return tmp
+}
+
+// copyToPart writes in to dst's .part file, renames it into place, and
+// preserves the metadata of src, which is nil for data from a reader. With
+// resume set, offset bytes are already in the .part file, progress is
+// journaled, and a read or write failure keeps the .part file so a later
+// attempt can continue; cancel still removes it.
+func copyToPart(j *Job, execCtx *executionContext, in io.Reader, src *executionPath, srcDisplay string, dst executionPath, fi os.FileInfo, overwrite bool, resume *resumeTransfer, offset int64) error {
+	tmp := partPath(dst)
 
 	if err := ensureDir(execCtx, dirPath(tmp), 0755); err != nil {
+		if offset == 0 && execCtx.canElevate(dst, err) {
+			return stageElevatedCopy(j, execCtx, in, srcDisplay, dst, fi)
+		}
 		return wrapPath(dst.displayPath(), err)
 	}
 
-	out, err := openWritePath(execCtx, tmp, fi.Mode())
-	if err != nil {
-		return wrapPath(tmp.displayPath(), err)
+	var out io.WriteCloser
+	var err error
+	if offset > 0 {
+		out, err = openResumeWritePath(execCtx, tmp, offset)
+		if err != nil {
+			// The source is already positioned at offset, so a failed
+			// reopen fails this attempt; the next one starts over.
+			resume.discard()
+			return wrapPath(tmp.displayPath(), err)
+		}
+		dbg("job %d: resume %s at %d", j.ID, tmp.displayPath(), offset)
+	} else {
+		out, err = openWritePath(execCtx, tmp, fi.Mode())
+		if err != nil {
+			if execCtx.canElevate(dst, err) {
+				return stageElevatedCopy(j, execCtx, in, srcDisplay, dst, fi)
+			}
+			return wrapPath(tmp.displayPath(), err)
+		}
 	}
 
 	totalBytes := fi.Size()
@@ -1705,33 +2308,51 @@ func copyReaderWithCancel(j *Job, execCtx *executionContext, in io.Reader, srcDi
 		totalBytes = 0
 	}
 	j.beginFileProgress(srcDisplay, totalBytes)
+	j.addFileProgress(offset, false)
+
+	// fail closes the .part file and either keeps it for a resumable
+	// transfer or removes it.
+	written := offset
+	fail := func(err error) error {
+		out.Close()
+		if resume != nil && !errors.Is(err, errCanceled) {
+			resume.interrupted(written)
+			return err
+		}
+		if resume != nil {
+			resume.discard()
+		}
+		_ = removePath(execCtx, tmp)
+		return err
+	}
 
 	buf := make([]byte, 1<<20) // 1 MiB
 	for {
 		if canceled(j) {
-			out.Close()
-			_ = removePath(execCtx, tmp)
-			return errCanceled
+			return fail(errCanceled)
 		}
 		n, rerr := in.Read(buf)
 		if n > 0 {
 			if _, werr := out.Write(buf[:n]); werr != nil {
-				out.Close()
-				_ = removePath(execCtx, tmp)
-				return wrapPath(tmp.displayPath(), werr)
+				return fail(wrapPath(tmp.displayPath(), werr))
 			}
+			written += int64(n)
 			j.addFileProgress(int64(n), false)
+			if resume != nil {
+				resume.checkpoint(written)
+			}
 		}
 		if rerr == io.EOF {
 			break
 		}
 		if rerr != nil {
-			out.Close()
-			_ = removePath(execCtx, tmp)
-			return wrapPath(srcDisplay, rerr)
+			return fail(wrapPath(srcDisplay, rerr))
 		}
 	}
 	j.completeFileProgress()
+	if resume != nil {
+		resume.discard()
+	}
 	if err := out.Close(); err != nil {
 		_ = removePath(execCtx, tmp)
 		return wrapPath(tmp.displayPath(), err)
@@ -1749,11 +2370,7 @@ func copyReaderWithCancel(j *Job, execCtx *executionContext, in io.Reader, srcDi
 		_ = removePath(execCtx, tmp)
 		return wrapPath(dst.displayPath(), err)
 	}
-	if shouldPreserveTimestamps(j) {
-		if err := chtimesPath(execCtx, dst, fi.ModTime(), fi.ModTime()); err != nil {
-			return wrapPath(dst.displayPath(), err)
-		}
-	}
+	preserveMetadata(j, execCtx, src, dst, fi)
 	return nil
 }
 
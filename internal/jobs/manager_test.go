@@ -10,6 +10,7 @@ import (
 	"runtime"
 	"strings"
 	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
 
@@ -235,7 +236,7 @@ type fakeSMBOpener struct {
 	new       func() fileinfo.SMBSession
 }
 
-func (o *fakeSMBOpener) OpenSession() (fileinfo.SMBSession, error) {
+func (o *fakeSMBOpener) OpenSession(context.Context) (fileinfo.SMBSession, error) {
 	o.openCalls++
 	if o.err != nil {
 		return nil, o.err
@@ -404,6 +405,30 @@ func TestCopyToSameDirectoryUsesAutoSuffix(t *testing.T) {
 	}
 }
 
+func TestCopyToSameDirectoryUsesDuplicateNamePattern(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{"file.txt", "file_copy1.txt"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(name), 0644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	pattern, err := fileinfo.ParseDuplicateNamePattern("{name}_copy{n}{ext}")
+	if err != nil {
+		t.Fatalf("ParseDuplicateNamePattern: %v", err)
+	}
+	m := NewManager()
+	m.SetDuplicateNamePattern(pattern)
+	job := m.EnqueueCopy([]string{filepath.Join(tmpDir, "file.txt"), filepath.Join(tmpDir, "file_copy1.txt")}, tmpDir)
+	waitForJobStatus(t, job, StatusCompleted)
+
+	for name, want := range map[string]string{"file_copy2.txt": "file.txt", "file_copy3.txt": "file_copy1.txt"} {
+		got, err := os.ReadFile(filepath.Join(tmpDir, name))
+		if err != nil || string(got) != want {
+			t.Fatalf("%s = %q, %v; want a copy of %s", name, got, err, want)
+		}
+	}
+}
+
 func TestCopyRejectsMissingOrNonDirectoryDestination(t *testing.T) {
 	srcDir := t.TempDir()
 	src := filepath.Join(srcDir, "source.txt")
@@ -441,6 +466,25 @@ func TestCopyRejectsMissingOrNonDirectoryDestination(t *testing.T) {
 	}
 }
 
+func TestCopyFailsOnInjectedWriteFault(t *testing.T) {
+	srcDir := t.TempDir()
+	src := filepath.Join(srcDir, "source.txt")
+	if err := os.WriteFile(src, []byte("source"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dest := t.TempDir()
+	t.Cleanup(func() { fileinfo.SetFaultInjection(fileinfo.FaultSpec{}) })
+	fileinfo.SetFaultInjection(fileinfo.FaultSpec{EIORate: 1, Ops: map[string]bool{fileinfo.FaultOpWrite: true}})
+
+	job := &Job{Type: TypeCopy, ctx: context.Background()}
+	if err := copyOrMovePath(job, src, dest); !errors.Is(err, syscall.EIO) {
+		t.Fatalf("copy err = %v, want injected EIO", err)
+	}
+	if entries, _ := os.ReadDir(dest); len(entries) != 0 {
+		t.Fatalf("failed copy left %d entries in the destination", len(entries))
+	}
+}
+
 func TestCopyFileRecordsCurrentFileProgress(t *testing.T) {
 	srcDir := t.TempDir()
 	dstDir := t.TempDir()
@@ -467,6 +511,42 @@ func TestCopyFileRecordsCurrentFileProgress(t *testing.T) {
 	}
 }
 
+func TestRunJobTracksTotalAndDoneBytes(t *testing.T) {
+	srcDir := t.TempDir()
+	dstDir := t.TempDir()
+	file := filepath.Join(srcDir, "a.bin")
+	tree := filepath.Join(srcDir, "tree")
+	if err := os.WriteFile(file, bytes.Repeat([]byte("a"), 1000), 0644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(tree, "sub"), 0755); err != nil {
+		t.Fatalf("make tree: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tree, "sub", "b.bin"), bytes.Repeat([]byte("b"), 500), 0644); err != nil {
+		t.Fatalf("write nested file: %v", err)
+	}
+
+	for _, typ := range []Type{TypeCopy, TypeMove} {
+		dest := filepath.Join(dstDir, string(typ))
+		if err := os.Mkdir(dest, 0755); err != nil {
+			t.Fatalf("make destination: %v", err)
+		}
+		sources := []string{file, tree}
+		if typ == TypeMove {
+			sources = []string{filepath.Join(dstDir, "copy", "a.bin"), filepath.Join(dstDir, "copy", "tree")}
+		}
+		m := &Manager{}
+		job := &Job{Type: typ, Sources: sources, DestDir: dest, ctx: context.Background()}
+		if err := m.runJob(job); err != nil {
+			t.Fatalf("%s job: %v", typ, err)
+		}
+		snap := job.Snapshot()
+		if snap.TotalBytes != 1500 || snap.DoneBytes != 1500 {
+			t.Fatalf("%s bytes = %d/%d, want 1500/1500", typ, snap.DoneBytes, snap.TotalBytes)
+		}
+	}
+}
+
 func TestDeleteTrashJobUsesTrashBackend(t *testing.T) {
 	oldTrashPath := trashPath
 	defer func() { trashPath = oldTrashPath }()
@@ -714,6 +794,9 @@ func TestMoveFileUsesRenameFastPath(t *testing.T) {
 	if _, err := os.Stat(part); !os.IsNotExist(err) {
 		t.Fatalf("copy temp file should not be created on rename fast path, got %v", err)
 	}
+	if job.MoveRenames != 1 || job.MoveCopies != 0 {
+		t.Fatalf("move strategy = %d renames, %d copies; want one rename", job.MoveRenames, job.MoveCopies)
+	}
 }
 
 func TestMoveDirectoryIntoItselfIsRejected(t *testing.T) {
@@ -768,6 +851,81 @@ func TestMoveDirectoryMergeSkipsRenameFastPath(t *testing.T) {
 	}
 }
 
+func TestMoveDirectoryConflictOffersMergeOnce(t *testing.T) {
+	tmpDir := t.TempDir()
+	srcDir := filepath.Join(tmpDir, "src", "dir")
+	dstParent := filepath.Join(tmpDir, "dst")
+	dstDir := filepath.Join(dstParent, "dir")
+	for _, d := range []string{filepath.Join(srcDir, "nested"), filepath.Join(dstDir, "nested")} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatalf("make dir: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(srcDir, "nested", "new.txt"), []byte("new"), 0644); err != nil {
+		t.Fatalf("write source child: %v", err)
+	}
+
+	var requests []ConflictRequest
+	job := &Job{Type: TypeMove, ctx: context.Background(), Resolver: func(_ context.Context, req ConflictRequest) ConflictResolution {
+		requests = append(requests, req)
+		return ConflictResolution{Action: ConflictMerge}
+	}}
+	if err := copyOrMovePath(job, srcDir, dstParent); err != nil {
+		t.Fatalf("move directory merge failed: %v", err)
+	}
+
+	if len(requests) != 1 {
+		t.Fatalf("conflict prompts = %d, want 1 for the top-level directory", len(requests))
+	}
+	if req := requests[0]; !req.CanMerge || req.DefaultAction != ConflictMerge || !req.IsDir {
+		t.Fatalf("request = %+v, want mergeable directory conflict", req)
+	}
+	if _, err := os.Stat(filepath.Join(dstDir, "nested", "new.txt")); err != nil {
+		t.Fatalf("merged nested file missing: %v", err)
+	}
+	if _, err := os.Stat(srcDir); !os.IsNotExist(err) {
+		t.Fatalf("source directory should be removed after merge, got %v", err)
+	}
+}
+
+func TestMoveDirectoryConflictSkipAndAutoSuffix(t *testing.T) {
+	for _, action := range []ConflictAction{ConflictSkip, ConflictAutoSuffix} {
+		tmpDir := t.TempDir()
+		srcDir := filepath.Join(tmpDir, "src", "dir")
+		dstParent := filepath.Join(tmpDir, "dst")
+		for _, d := range []string{srcDir, filepath.Join(dstParent, "dir")} {
+			if err := os.MkdirAll(d, 0755); err != nil {
+				t.Fatalf("make dir: %v", err)
+			}
+		}
+		if err := os.WriteFile(filepath.Join(srcDir, "a.txt"), []byte("a"), 0644); err != nil {
+			t.Fatalf("write source child: %v", err)
+		}
+
+		job := &Job{Type: TypeMove, ctx: context.Background(), Resolver: func(context.Context, ConflictRequest) ConflictResolution {
+			return ConflictResolution{Action: action}
+		}}
+		err := copyOrMovePath(job, srcDir, dstParent)
+
+		switch action {
+		case ConflictSkip:
+			if !errors.Is(err, errSkipped) {
+				t.Fatalf("skip err = %v, want errSkipped", err)
+			}
+			if _, err := os.Stat(filepath.Join(srcDir, "a.txt")); err != nil {
+				t.Fatalf("skipped source should remain: %v", err)
+			}
+		case ConflictAutoSuffix:
+			if err != nil {
+				t.Fatalf("auto suffix move failed: %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(dstParent, "dir (1)", "a.txt")); err != nil {
+				t.Fatalf("auto-suffixed directory missing: %v", err)
+			}
+		}
+	}
+}
+
 func TestMoveSMBUsesRenameFastPathWithinShare(t *testing.T) {
 	tmpDir := t.TempDir()
 	src := filepath.Join(tmpDir, "file.txt")
@@ -798,6 +956,49 @@ func TestMoveSMBUsesRenameFastPathWithinShare(t *testing.T) {
 	}
 }
 
+func TestMoveAcrossSMBSharesSkipsRename(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := filepath.Join(tmpDir, "file.txt")
+	if err := os.WriteFile(src, []byte("source"), 0644); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	fi, err := os.Lstat(src)
+	if err != nil {
+		t.Fatalf("stat source: %v", err)
+	}
+
+	ops := &trackingSMBOps{}
+	job := &Job{ID: 43, Type: TypeMove, ctx: context.Background()}
+	srcPath := executionPath{backend: backendSMB, path: "/from/file.txt", smb: ops, smbDisplayRoot: "smb://host/one"}
+	dstPath := executionPath{backend: backendSMB, path: "/to/file.txt", smb: ops, smbDisplayRoot: "smb://host/two"}
+
+	moved, err := tryFastMovePath(job, newExecutionContext(), srcPath, dstPath, fi, false)
+	if err != nil || moved {
+		t.Fatalf("tryFastMovePath = %t, %v; want copy fallback", moved, err)
+	}
+	if ops.renameCalls != 0 {
+		t.Fatalf("SMB Rename calls = %d, want none across shares", ops.renameCalls)
+	}
+}
+
+func TestCrossesVolumes(t *testing.T) {
+	dir := t.TempDir()
+	local := executionPath{backend: backendLocal, path: filepath.Join(dir, "a")}
+	sibling := executionPath{backend: backendLocal, path: filepath.Join(dir, "sub", "b")}
+	smb := executionPath{backend: backendSMB, path: "/a", smbDisplayRoot: "smb://host/share"}
+	sameShare := executionPath{backend: backendSMB, path: "/b", smbDisplayRoot: "SMB://Host/Share/"}
+
+	if crossesVolumes(local, sibling) {
+		t.Fatal("paths in one temp directory cross volumes")
+	}
+	if crossesVolumes(smb, sameShare) {
+		t.Fatal("paths in one SMB share cross volumes")
+	}
+	if !crossesVolumes(local, smb) {
+		t.Fatal("local and SMB paths share a volume")
+	}
+}
+
 func TestCopyCollisionSkipDoesNotOverwrite(t *testing.T) {
 	srcDir := t.TempDir()
 	dstDir := t.TempDir()
@@ -1124,6 +1325,42 @@ func TestCopyPreservesFileTimestampWhenRequested(t *testing.T) {
 	}
 }
 
+func TestCopyPreservesAccessTime(t *testing.T) {
+	srcDir := t.TempDir()
+	dstDir := t.TempDir()
+	src := filepath.Join(srcDir, "file.txt")
+	if err := os.WriteFile(src, []byte("source"), 0644); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	atime := time.Unix(1_600_000_000, 0)
+	mtime := time.Unix(1_700_000_000, 0)
+	if err := os.Chtimes(src, atime, mtime); err != nil {
+		t.Fatalf("set source time: %v", err)
+	}
+	info, err := os.Stat(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fileinfo.AccessTime(info).Equal(atime) {
+		t.Skip("platform does not report access times")
+	}
+
+	job := &Job{Type: TypeCopy, ctx: context.Background(), Options: TransferOptions{PreserveTimestamps: true}}
+	if err := copyOrMovePath(job, src, dstDir); err != nil {
+		t.Fatalf("copy failed: %v", err)
+	}
+	copied, err := os.Stat(filepath.Join(dstDir, "file.txt"))
+	if err != nil {
+		t.Fatalf("stat copied file: %v", err)
+	}
+	if got := fileinfo.AccessTime(copied); !got.Equal(atime) {
+		t.Fatalf("copied file atime = %s, want %s", got, atime)
+	}
+	if len(job.Failures) != 0 {
+		t.Fatalf("failures = %+v, want none", job.Failures)
+	}
+}
+
 func TestCopyPreservesDirectoryTimestampAfterChildren(t *testing.T) {
 	srcRoot := t.TempDir()
 	dstRoot := t.TempDir()
@@ -0,0 +1,60 @@
+package jobs
+
+import (
+	"os"
+
+	"nmf/internal/fileinfo"
+)
+
+// Kinds of metadata a copy carries over besides the data itself.
+const (
+	metadataTimes  = "timestamps"
+	metadataOwner  = "owner"
+	metadataXattrs = "extended attributes"
+)
+
+// preserveMetadata carries fi's metadata over to dst once dst is in place:
+// with the job's options, the owner and extended attributes of the local
+// src, then the access and modification times. src is nil when the data came
+// from a reader, such as an archive entry; only times are kept then.
+//
+// None of this fails the copy. Each piece that could not be set is recorded
+// as a warning in j.Failures; a kind the destination or the user's rights
+// rule out altogether is warned about once and then no longer tried.
+func preserveMetadata(j *Job, execCtx *executionContext, src *executionPath, dst executionPath, fi os.FileInfo) {
+	if src != nil && src.backend == backendLocal && dst.backend == backendLocal {
+		if j.Options.PreserveOwnership {
+			execCtx.applyMetadata(j, dst, metadataOwner, func() error { return chownLike(dst.path, fi) })
+		}
+		if j.Options.PreserveXattrs {
+			execCtx.applyMetadata(j, dst, metadataXattrs, func() error { return copyXattrs(src.path, dst.path) })
+		}
+	}
+	if shouldPreserveTimestamps(j) {
+		execCtx.applyMetadata(j, dst, metadataTimes, func() error {
+			return chtimesPath(execCtx, dst, fileinfo.AccessTime(fi), fi.ModTime())
+		})
+	}
+}
+
+func (ctx *executionContext) applyMetadata(j *Job, dst executionPath, kind string, apply func() error) {
+	if ctx.metadataOff[kind] {
+		return
+	}
+	err := apply()
+	if err == nil {
+		return
+	}
+	msg := "could not preserve " + kind + ": " + err.Error()
+	if metadataUnsupported(err) {
+		if ctx.metadataOff == nil {
+			ctx.metadataOff = make(map[string]bool)
+		}
+		ctx.metadataOff[kind] = true
+		msg += " (not tried again in this job)"
+	}
+	dbg("job %d: %s: %s", j.ID, dst.displayPath(), msg)
+	j.mu.Lock()
+	j.Failures = append(j.Failures, JobFailure{TopSource: j.CurrentSource, Path: dst.displayPath(), Error: msg, Warning: true})
+	j.mu.Unlock()
+}
@@ -0,0 +1,18 @@
+//go:build !linux && !darwin
+// +build !linux,!darwin
+
+package jobs
+
+import "os"
+
+func fileOwner(os.FileInfo) (int, int, bool) {
+	return 0, 0, false
+}
+
+// Ownership and extended attributes are Unix notions; elsewhere only times
+// are preserved.
+func chownLike(string, os.FileInfo) error { return nil }
+
+func copyXattrs(string, string) error { return nil }
+
+func metadataUnsupported(error) bool { return false }
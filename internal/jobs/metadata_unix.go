@@ -0,0 +1,114 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package jobs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+func fileOwner(fi os.FileInfo) (int, int, bool) {
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, 0, false
+	}
+	return int(st.Uid), int(st.Gid), true
+}
+
+// chownLike gives p the owner and group of fi, skipping the call when they
+// already match, as for a user copying their own files.
+func chownLike(p string, fi os.FileInfo) error {
+	uid, gid, ok := fileOwner(fi)
+	if !ok {
+		return nil
+	}
+	if cur, err := os.Lstat(p); err == nil {
+		if curUID, curGID, ok := fileOwner(cur); ok && curUID == uid && curGID == gid {
+			return nil
+		}
+	}
+	return os.Lchown(p, uid, gid)
+}
+
+// copyXattrs copies every extended attribute of src to dst, without
+// following links. Attributes that cannot be set are named in the error; the
+// rest are still copied.
+func copyXattrs(src, dst string) error {
+	names, err := xattrNames(src)
+	if err != nil {
+		return err
+	}
+	var failed []string
+	var firstErr error
+	for _, name := range names {
+		value, err := xattrValue(src, name)
+		if err == nil {
+			err = unix.Lsetxattr(dst, name, value, 0)
+		}
+		if err != nil {
+			if errors.Is(err, unix.ENOTSUP) || errors.Is(err, unix.EOPNOTSUPP) {
+				return err
+			}
+			failed = append(failed, name)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	if firstErr != nil {
+		// Not wrapped: a refused security attribute must not stop the
+		// others from being copied for the rest of the job.
+		return fmt.Errorf("%s: %v", strings.Join(failed, ", "), firstErr)
+	}
+	return nil
+}
+
+func xattrNames(p string) ([]string, error) {
+	n, err := unix.Llistxattr(p, nil)
+	if err != nil || n <= 0 {
+		if errors.Is(err, unix.ENOTSUP) || errors.Is(err, unix.EOPNOTSUPP) {
+			// Nothing to copy from a filesystem without attributes.
+			return nil, nil
+		}
+		return nil, err
+	}
+	buf := make([]byte, n)
+	n, err = unix.Llistxattr(p, buf)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, name := range bytes.Split(buf[:n], []byte{0}) {
+		if len(name) > 0 {
+			names = append(names, string(name))
+		}
+	}
+	return names, nil
+}
+
+func xattrValue(p, name string) ([]byte, error) {
+	n, err := unix.Lgetxattr(p, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, n)
+	n, err = unix.Lgetxattr(p, name, buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// metadataUnsupported reports whether err rules out a kind of metadata for
+// the whole job: the destination has no extended attributes, or the user
+// may not give files away.
+func metadataUnsupported(err error) bool {
+	return errors.Is(err, unix.ENOTSUP) || errors.Is(err, unix.EOPNOTSUPP) || errors.Is(err, unix.EPERM)
+}
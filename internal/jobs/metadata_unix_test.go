@@ -0,0 +1,92 @@
+//go:build linux || darwin
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestCopyPreservesXattrsWhenRequested(t *testing.T) {
+	srcDir := t.TempDir()
+	dstDir := t.TempDir()
+	src := filepath.Join(srcDir, "file.txt")
+	if err := os.WriteFile(src, []byte("source"), 0644); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	if err := unix.Lsetxattr(src, "user.nmf.test", []byte("value"), 0); err != nil {
+		t.Skipf("temp directory has no user xattrs: %v", err)
+	}
+
+	job := &Job{Type: TypeCopy, ctx: context.Background(), Options: TransferOptions{PreserveXattrs: true}}
+	if err := copyOrMovePath(job, src, dstDir); err != nil {
+		t.Fatalf("copy failed: %v", err)
+	}
+	got, err := xattrValue(filepath.Join(dstDir, "file.txt"), "user.nmf.test")
+	if err != nil || string(got) != "value" {
+		t.Fatalf("copied xattr = %q, %v; want value", got, err)
+	}
+
+	plain := &Job{Type: TypeCopy, ctx: context.Background()}
+	other := t.TempDir()
+	if err := copyOrMovePath(plain, src, other); err != nil {
+		t.Fatalf("copy failed: %v", err)
+	}
+	if _, err := xattrValue(filepath.Join(other, "file.txt"), "user.nmf.test"); err == nil {
+		t.Fatal("xattr copied without PreserveXattrs")
+	}
+}
+
+func TestCopyPreservesOwnOwnershipWithoutWarnings(t *testing.T) {
+	srcDir := t.TempDir()
+	dstDir := t.TempDir()
+	src := filepath.Join(srcDir, "file.txt")
+	if err := os.WriteFile(src, []byte("source"), 0644); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+
+	job := &Job{Type: TypeCopy, ctx: context.Background(), Options: TransferOptions{PreserveOwnership: true}}
+	if err := copyOrMovePath(job, src, dstDir); err != nil {
+		t.Fatalf("copy failed: %v", err)
+	}
+	if len(job.Failures) != 0 {
+		t.Fatalf("failures = %+v, want none", job.Failures)
+	}
+}
+
+func TestMetadataFailuresBecomeWarnings(t *testing.T) {
+	job := &Job{ID: 1, CurrentSource: "/src/a"}
+	execCtx := &executionContext{}
+	dst, err := resolveExecutionPath(filepath.Join(t.TempDir(), "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for range 2 {
+		execCtx.applyMetadata(job, dst, metadataTimes, func() error { return errors.New("clock skew") })
+	}
+	calls := 0
+	for range 3 {
+		execCtx.applyMetadata(job, dst, metadataXattrs, func() error {
+			calls++
+			return unix.ENOTSUP
+		})
+	}
+
+	if calls != 1 {
+		t.Fatalf("unsupported xattrs tried %d times, want 1", calls)
+	}
+	if len(job.Failures) != 3 {
+		t.Fatalf("failures = %+v, want two time warnings and one xattr warning", job.Failures)
+	}
+	for _, f := range job.Failures {
+		if !f.Warning || f.TopSource != "/src/a" || f.Path != dst.displayPath() {
+			t.Fatalf("failure = %+v, want warning for /src/a at %s", f, dst.displayPath())
+		}
+	}
+}
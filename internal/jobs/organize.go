@@ -0,0 +1,109 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"nmf/internal/fileinfo"
+)
+
+// OrganizeDateSource reports where an organize-by-date placement got its date.
+type OrganizeDateSource string
+
+const (
+	OrganizeDateTaken    OrganizeDateSource = "taken"
+	OrganizeDateModified OrganizeDateSource = "modified"
+)
+
+// OrganizePlanEntry describes where an organize-by-date transfer places one
+// source. DestDir is the YYYY/MM/DD directory, not the final item path.
+type OrganizePlanEntry struct {
+	Source     string
+	Date       time.Time
+	DateSource OrganizeDateSource
+	DestDir    string
+}
+
+// PlanOrganizeByDate computes organize-by-date placements without creating
+// directories or transferring anything, for dry-run previews. Sources whose
+// date cannot be resolved stop the plan with that error, matching how the job
+// itself would fail.
+func PlanOrganizeByDate(ctx context.Context, sources []string, destDir string) ([]OrganizePlanEntry, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	destPath, err := resolveExecutionPath(destDir)
+	if err != nil {
+		return nil, wrapPath(destDir, err)
+	}
+	execCtx := newExecutionContext()
+	execCtx.jobCtx = ctx
+	defer func() {
+		if err := execCtx.close(); err != nil {
+			dbg("organize plan: execution context close error: %v", err)
+		}
+	}()
+	entries := make([]OrganizePlanEntry, 0, len(sources))
+	for _, src := range sources {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		srcPath, err := resolveExecutionPath(src)
+		if err != nil {
+			return nil, wrapPath(src, err)
+		}
+		date, source, err := organizeDate(ctx, execCtx, src, srcPath)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, OrganizePlanEntry{
+			Source:     src,
+			Date:       date,
+			DateSource: source,
+			DestDir:    organizeDateDir(destPath, date).displayPath(),
+		})
+	}
+	return entries, nil
+}
+
+// organizeDate prefers the capture date embedded in media files and falls
+// back to the source modification time.
+func organizeDate(ctx context.Context, execCtx *executionContext, src string, srcPath executionPath) (time.Time, OrganizeDateSource, error) {
+	if fileinfo.IsMediaFile(src) {
+		meta, err := fileinfo.ReadMediaMetadata(ctx, src)
+		if err == nil && !meta.DateTaken.IsZero() {
+			return meta.DateTaken, OrganizeDateTaken, nil
+		}
+		if err != nil {
+			dbg("organize: metadata unavailable for %s: %v", src, err)
+		}
+	}
+	fi, err := lstatPath(execCtx, srcPath)
+	if err != nil {
+		return time.Time{}, "", wrapPath(srcPath.displayPath(), err)
+	}
+	return fi.ModTime(), OrganizeDateModified, nil
+}
+
+func organizeDateDir(destPath executionPath, date time.Time) executionPath {
+	dir := joinPath(destPath, date.Format("2006"))
+	dir = joinPath(dir, date.Format("01"))
+	return joinPath(dir, date.Format("02"))
+}
+
+// organizeDestination resolves and creates the dated directory for src.
+func organizeDestination(j *Job, execCtx *executionContext, src string, srcPath, destPath executionPath) (executionPath, error) {
+	date, source, err := organizeDate(j.ctx, execCtx, src, srcPath)
+	if err != nil {
+		return executionPath{}, err
+	}
+	dir := organizeDateDir(destPath, date)
+	dbg("job %d: organize %s by %s date -> %s", j.ID, src, source, dir.displayPath())
+	if err := ensureDir(execCtx, dir, 0755); err != nil {
+		return executionPath{}, wrapPath(dir.displayPath(), err)
+	}
+	j.mu.Lock()
+	j.Message = date.Format("2006/01/02")
+	j.mu.Unlock()
+	return dir, nil
+}
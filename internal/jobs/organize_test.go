@@ -0,0 +1,72 @@
+package jobs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPlanOrganizeByDateFallsBackToModifiedTime(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := filepath.Join(tmpDir, "notes.txt")
+	if err := os.WriteFile(src, []byte("notes"), 0644); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	mtime := time.Date(2023, 7, 4, 12, 0, 0, 0, time.Local)
+	if err := os.Chtimes(src, mtime, mtime); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+	dest := filepath.Join(tmpDir, "sorted")
+
+	plan, err := PlanOrganizeByDate(context.Background(), []string{src}, dest)
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	if len(plan) != 1 {
+		t.Fatalf("plan entries = %d, want 1", len(plan))
+	}
+	if plan[0].DateSource != OrganizeDateModified {
+		t.Fatalf("date source = %q, want modified", plan[0].DateSource)
+	}
+	if want := filepath.Join(dest, "2023", "07", "04"); plan[0].DestDir != want {
+		t.Fatalf("dest dir = %q, want %q", plan[0].DestDir, want)
+	}
+	if _, err := os.Stat(dest); !os.IsNotExist(err) {
+		t.Fatalf("dry run should not create destination, got %v", err)
+	}
+}
+
+func TestMoveOrganizeByDateCreatesDatedDirectories(t *testing.T) {
+	tmpDir := t.TempDir()
+	dest := filepath.Join(tmpDir, "sorted")
+	if err := os.Mkdir(dest, 0755); err != nil {
+		t.Fatalf("make destination: %v", err)
+	}
+	src := filepath.Join(tmpDir, "clip.txt")
+	if err := os.WriteFile(src, []byte("clip"), 0644); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+	mtime := time.Date(2021, 12, 31, 23, 0, 0, 0, time.Local)
+	if err := os.Chtimes(src, mtime, mtime); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+
+	m := &Manager{}
+	job := &Job{Type: TypeMove, Sources: []string{src}, DestDir: dest, Options: TransferOptions{PreserveTimestamps: true, OrganizeByDate: true}, ctx: context.Background()}
+	if err := m.runJob(job); err != nil {
+		t.Fatalf("run organize move: %v", err)
+	}
+
+	if _, err := os.Stat(src); !os.IsNotExist(err) {
+		t.Fatalf("source should be moved away, got %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(dest, "2021", "12", "31", "clip.txt"))
+	if err != nil || string(got) != "clip" {
+		t.Fatalf("organized content wrong: got %q err=%v", got, err)
+	}
+	if job.Message != "2021/12/31" {
+		t.Fatalf("job message = %q, want dated folder", job.Message)
+	}
+}
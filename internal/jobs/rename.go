@@ -0,0 +1,314 @@
+package jobs
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"nmf/internal/fileinfo"
+)
+
+// RenameCase recases batch-renamed names.
+type RenameCase string
+
+const (
+	RenameCaseKeep  RenameCase = ""
+	RenameCaseLower RenameCase = "lower"
+	RenameCaseUpper RenameCase = "upper"
+	// RenameCaseTitle capitalizes the first letter of the name and of each
+	// word after a space, underscore, or hyphen, and lowercases the rest.
+	RenameCaseTitle RenameCase = "title"
+)
+
+// RenameCases lists the case transforms in the order the dialog cycles them.
+var RenameCases = []RenameCase{RenameCaseKeep, RenameCaseLower, RenameCaseUpper, RenameCaseTitle}
+
+// Label names the transform for the batch rename dialog.
+func (c RenameCase) Label() string {
+	switch c {
+	case RenameCaseLower:
+		return "lowercase"
+	case RenameCaseUpper:
+		return "UPPERCASE"
+	case RenameCaseTitle:
+		return "Title Case"
+	default:
+		return "keep case"
+	}
+}
+
+// RenameRules describes a batch rename. Each name first has Find replaced,
+// is then shaped by Pattern, and is finally recased.
+type RenameRules struct {
+	// Find is replaced by Replace everywhere in the name. With Regex, Find
+	// is a regular expression and Replace may use its groups as $1 or
+	// ${name}. An empty Find replaces nothing.
+	Find    string
+	Replace string
+	Regex   bool
+	// Pattern builds the new name from {name}, the name without its
+	// extension, {ext}, the extension including its dot, and {n}, the
+	// counter; {n:3} pads the counter to three digits, at most
+	// maxRenameCounterWidth. Without {ext} the
+	// extension is kept after the pattern, as in duplicate name patterns.
+	// Empty keeps the name.
+	Pattern string
+	// CounterStart numbers the first name; each following name adds
+	// CounterStep, or one when CounterStep is zero.
+	CounterStart int
+	CounterStep  int
+	Case         RenameCase
+}
+
+// RenamePlanEntry is one row of a batch rename preview.
+type RenamePlanEntry struct {
+	Source  string
+	OldName string
+	NewName string
+	// Err explains why the entry cannot be renamed: an invalid new name or
+	// a collision. Such entries keep their name.
+	Err error
+}
+
+// Changed reports whether the entry renames its source.
+func (e RenamePlanEntry) Changed() bool {
+	return e.Err == nil && e.NewName != e.OldName
+}
+
+var renameCounterToken = regexp.MustCompile(`\{n(?::(\d+))?\}`)
+
+// maxRenameCounterWidth bounds {n:W}; no file system takes a longer name, and
+// a larger width would make every preview build a huge string.
+const maxRenameCounterWidth = 255
+
+// PlanRenames applies rules to files, which share one parent directory, in
+// order. existing lists every name in that directory. A new name that
+// equals, ignoring case, a name staying in the directory or another new name
+// is a collision, so plans behave the same on case-insensitive volumes.
+// Only an invalid Find expression or counter width fails the whole plan.
+func PlanRenames(files []fileinfo.FileInfo, existing []string, rules RenameRules) ([]RenamePlanEntry, error) {
+	for _, m := range renameCounterToken.FindAllStringSubmatch(rules.Pattern, -1) {
+		if m[1] == "" {
+			continue
+		}
+		if width, err := strconv.Atoi(m[1]); err != nil || width > maxRenameCounterWidth {
+			return nil, fmt.Errorf("counter width must be at most %d", maxRenameCounterWidth)
+		}
+	}
+	var find *regexp.Regexp
+	if rules.Regex && rules.Find != "" {
+		var err error
+		if find, err = regexp.Compile(rules.Find); err != nil {
+			return nil, fmt.Errorf("invalid find expression: %w", err)
+		}
+	}
+	step := rules.CounterStep
+	if step == 0 {
+		step = 1
+	}
+	entries := make([]RenamePlanEntry, len(files))
+	renamed := make(map[string]bool, len(files))
+	for i, fi := range files {
+		renamed[fi.Name] = true
+		name := fi.Name
+		switch {
+		case find != nil:
+			name = find.ReplaceAllString(name, rules.Replace)
+		case rules.Find != "":
+			name = strings.ReplaceAll(name, rules.Find, rules.Replace)
+		}
+		name = applyRenamePattern(name, fi.IsDir, rules.Pattern, rules.CounterStart+i*step)
+		name = applyRenameCase(name, rules.Case)
+		entries[i] = RenamePlanEntry{Source: fi.Path, OldName: fi.Name, NewName: name}
+		if valid, err := fileinfo.ValidateRenameName(name); err != nil {
+			entries[i].Err = err
+		} else {
+			entries[i].NewName = valid
+		}
+	}
+
+	staying := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		if !renamed[name] {
+			staying[strings.ToLower(name)] = true
+		}
+	}
+	// A collision keeps an entry at its old name, which can collide with
+	// another new name in turn, so repeat until nothing changes.
+	for changed := true; changed; {
+		changed = false
+		final := make(map[string]int, len(entries))
+		for _, e := range entries {
+			final[strings.ToLower(renameFinalName(e))]++
+		}
+		for i, e := range entries {
+			if !e.Changed() {
+				continue
+			}
+			key := strings.ToLower(e.NewName)
+			switch {
+			case staying[key]:
+				entries[i].Err = fmt.Errorf("%s already exists", e.NewName)
+			case final[key] > 1:
+				entries[i].Err = fmt.Errorf("another item is also renamed to %s", e.NewName)
+			default:
+				continue
+			}
+			changed = true
+		}
+	}
+	return entries, nil
+}
+
+func renameFinalName(e RenamePlanEntry) string {
+	if e.Err != nil {
+		return e.OldName
+	}
+	return e.NewName
+}
+
+func applyRenamePattern(name string, isDir bool, pattern string, n int) string {
+	if pattern == "" {
+		return name
+	}
+	stem, ext := name, ""
+	if !isDir {
+		stem, ext = fileinfo.SplitDuplicateName(name)
+	}
+	if !strings.Contains(pattern, "{ext}") {
+		pattern += "{ext}"
+	}
+	out := renameCounterToken.ReplaceAllStringFunc(pattern, func(token string) string {
+		width := 0
+		if m := renameCounterToken.FindStringSubmatch(token); m[1] != "" {
+			width, _ = strconv.Atoi(m[1])
+		}
+		return fmt.Sprintf("%0*d", width, n)
+	})
+	return strings.NewReplacer("{name}", stem, "{ext}", ext).Replace(out)
+}
+
+func applyRenameCase(name string, c RenameCase) string {
+	switch c {
+	case RenameCaseLower:
+		return strings.ToLower(name)
+	case RenameCaseUpper:
+		return strings.ToUpper(name)
+	case RenameCaseTitle:
+		runes := []rune(strings.ToLower(name))
+		for i, r := range runes {
+			if i == 0 || runes[i-1] == ' ' || runes[i-1] == '_' || runes[i-1] == '-' {
+				runes[i] = unicode.ToUpper(r)
+			}
+		}
+		return string(runes)
+	default:
+		return name
+	}
+}
+
+// RenameOptions holds the new names of a TypeRename job, one per source.
+type RenameOptions struct {
+	Names []string
+}
+
+// EnqueueRename enqueues a job renaming each source within its directory to
+// the name at the same index. Callers pass only changed entries of a plan.
+func (m *Manager) EnqueueRename(sources, names []string) *Job {
+	destDir := ""
+	if len(sources) > 0 {
+		destDir = fileinfo.ParentPath(sources[0])
+	}
+	return m.enqueue(TypeRename, sources, destDir, nil, TransferOptions{Rename: RenameOptions{Names: append([]string(nil), names...)}})
+}
+
+// renameStep is one completed rename, kept so it can be undone.
+type renameStep struct {
+	from string
+	to   string
+}
+
+// runRenameJob moves every source to a temporary name first and then to its
+// new name, so names swapped or shifted within the batch never meet. Any
+// failure or cancel undoes the completed steps in reverse, leaving the
+// directory as it was.
+func (m *Manager) runRenameJob(j *Job) error {
+	names := j.Options.Rename.Names
+	if len(names) != len(j.Sources) {
+		return fmt.Errorf("rename has %d names for %d sources", len(names), len(j.Sources))
+	}
+	var done []renameStep
+	rename := func(from, name string) error {
+		to, err := fileinfo.RenamePortable(from, name)
+		if err != nil {
+			return wrapPath(from, err)
+		}
+		done = append(done, renameStep{from: from, to: to})
+		return nil
+	}
+	fail := func(src string, err error) error {
+		j.mu.Lock()
+		j.Failures = append(j.Failures, JobFailure{TopSource: src, Path: failingPath(err), Error: err.Error()})
+		j.mu.Unlock()
+		undoRenames(j, done)
+		return err
+	}
+
+	temps := make([]string, len(j.Sources))
+	for i, src := range j.Sources {
+		if canceled(j) {
+			undoRenames(j, done)
+			return errCanceled
+		}
+		j.mu.Lock()
+		j.CurrentSource = src
+		j.Message = "preparing"
+		j.mu.Unlock()
+		m.notify()
+		if err := rename(src, fmt.Sprintf(".nmf-rename-%d-%d", j.ID, i)); err != nil {
+			return fail(src, err)
+		}
+		temps[i] = done[len(done)-1].to
+	}
+	for i, src := range j.Sources {
+		if canceled(j) {
+			undoRenames(j, done)
+			return errCanceled
+		}
+		j.mu.Lock()
+		j.CurrentSource = src
+		j.Message = names[i]
+		j.mu.Unlock()
+		m.notify()
+		if err := rename(temps[i], names[i]); err != nil {
+			return fail(src, err)
+		}
+		j.mu.Lock()
+		j.DoneFiles = i + 1
+		j.mu.Unlock()
+		dbg("job %d: renamed %s -> %s", j.ID, src, names[i])
+		m.notify()
+	}
+	return nil
+}
+
+// undoRenames reverts steps newest first. Steps that cannot be reverted are
+// added to the job's failures so the user can find the leftover names.
+func undoRenames(j *Job, steps []renameStep) {
+	failed := 0
+	for i := len(steps) - 1; i >= 0; i-- {
+		s := steps[i]
+		if _, err := fileinfo.RenamePortable(s.to, fileinfo.BaseName(s.from)); err != nil {
+			failed++
+			j.mu.Lock()
+			j.Failures = append(j.Failures, JobFailure{TopSource: s.from, Path: s.to, Error: "undo: " + err.Error()})
+			j.mu.Unlock()
+		}
+	}
+	j.mu.Lock()
+	j.DoneFiles = 0
+	j.mu.Unlock()
+	dbg("job %d: undid %d rename step(s), %d failed", j.ID, len(steps), failed)
+}
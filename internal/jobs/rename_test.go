@@ -0,0 +1,140 @@
+package jobs
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"nmf/internal/fileinfo"
+)
+
+func TestPlanRenamesAppliesRulesInOrder(t *testing.T) {
+	files := []fileinfo.FileInfo{
+		{Name: "IMG_0042.JPG", Path: "/p/IMG_0042.JPG"},
+		{Name: "IMG_0043.JPG", Path: "/p/IMG_0043.JPG"},
+		{Name: "my photos.d", Path: "/p/my photos.d", IsDir: true},
+	}
+	tests := []struct {
+		name  string
+		rules RenameRules
+		want  []string
+	}{
+		{name: "literal", rules: RenameRules{Find: "IMG_", Replace: "trip-"}, want: []string{"trip-0042.JPG", "trip-0043.JPG", "my photos.d"}},
+		{name: "regex groups", rules: RenameRules{Find: `^IMG_(\d+)\.(\w+)$`, Replace: "${2}_$1", Regex: true}, want: []string{"JPG_0042", "JPG_0043", "my photos.d"}},
+		{name: "counter", rules: RenameRules{Pattern: "shot {n:3}", CounterStart: 9, CounterStep: 2}, want: []string{"shot 009.JPG", "shot 011.JPG", "shot 013"}},
+		{name: "counter and name", rules: RenameRules{Pattern: "{n}-{name}{ext}"}, want: []string{"0-IMG_0042.JPG", "1-IMG_0043.JPG", "2-my photos.d"}},
+		{name: "lower", rules: RenameRules{Case: RenameCaseLower}, want: []string{"img_0042.jpg", "img_0043.jpg", "my photos.d"}},
+		{name: "title", rules: RenameRules{Case: RenameCaseTitle}, want: []string{"Img_0042.jpg", "Img_0043.jpg", "My Photos.d"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := PlanRenames(files, nil, tt.rules)
+			if err != nil {
+				t.Fatalf("PlanRenames: %v", err)
+			}
+			got := make([]string, len(plan))
+			for i, e := range plan {
+				if e.Err != nil {
+					t.Fatalf("%s: %v", e.OldName, e.Err)
+				}
+				got[i] = e.NewName
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("names = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := PlanRenames(files, nil, RenameRules{Find: "(", Regex: true}); err == nil {
+		t.Fatal("PlanRenames with an invalid expression succeeded")
+	}
+	for _, pattern := range []string{"{n:256}", "{n:99999999999999999999}"} {
+		if _, err := PlanRenames(files, nil, RenameRules{Pattern: pattern}); err == nil {
+			t.Fatalf("PlanRenames with pattern %q succeeded", pattern)
+		}
+	}
+}
+
+func TestPlanRenamesFlagsCollisions(t *testing.T) {
+	files := []fileinfo.FileInfo{
+		{Name: "1.txt", Path: "/p/1.txt"},
+		{Name: "2.txt", Path: "/p/2.txt"},
+		{Name: "3.txt", Path: "/p/3.txt"},
+	}
+	existing := []string{"1.txt", "2.txt", "3.txt", "x.txt"}
+	plan, err := PlanRenames(files, existing, RenameRules{Pattern: "{n}", CounterStart: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range plan {
+		if !e.Changed() {
+			t.Fatalf("shifting %s to %s: %v", e.OldName, e.NewName, e.Err)
+		}
+	}
+
+	// 3.txt cannot become 4.txt, which stays under another case, so it keeps
+	// its name, which 2.txt wanted, which in turn leaves 1.txt stuck.
+	plan, err = PlanRenames(files, append(existing, "4.TXT"), RenameRules{Pattern: "{n}", CounterStart: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range plan {
+		if e.Err == nil {
+			t.Errorf("%s -> %s: no collision reported", e.OldName, e.NewName)
+		}
+	}
+
+	plan, err = PlanRenames(files[:2], existing, RenameRules{Find: "2", Replace: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan[0].Err != nil || plan[0].Changed() || plan[1].Err == nil {
+		t.Fatalf("plan = %+v, want 1.txt unchanged and 2.txt colliding with it", plan)
+	}
+}
+
+func TestRenameJobSwapsNames(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.txt")
+	b := filepath.Join(dir, "b.txt")
+	writeChecksumTestFile(t, a, "a")
+	writeChecksumTestFile(t, b, "b")
+
+	m := NewManager()
+	j := m.EnqueueRename([]string{a, b}, []string{"b.txt", "a.txt"})
+	waitForJobStatus(t, j, StatusCompleted)
+
+	for path, want := range map[string]string{a: "b", b: "a"} {
+		if data, err := os.ReadFile(path); err != nil || string(data) != want {
+			t.Fatalf("%s = %q, %v; want %q", path, data, err, want)
+		}
+	}
+}
+
+func TestRenameJobUndoesEverythingOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.txt")
+	b := filepath.Join(dir, "b.txt")
+	writeChecksumTestFile(t, a, "a")
+	writeChecksumTestFile(t, b, "b")
+
+	m := NewManager()
+	j := m.EnqueueRename([]string{a, b}, []string{"c.txt", "bad/name"})
+	waitForJobStatus(t, j, StatusFailed)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	if !reflect.DeepEqual(names, []string{"a.txt", "b.txt"}) {
+		t.Fatalf("directory after failed rename = %q, want the original names", names)
+	}
+	if snap := j.Snapshot(); len(snap.Failures) != 1 || snap.DoneFiles != 0 {
+		t.Fatalf("failures %+v done %d", snap.Failures, snap.DoneFiles)
+	}
+}
@@ -0,0 +1,373 @@
+package jobs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const resumeFileVersion = 1
+
+var (
+	// resumeMinBytes is the smallest remote transfer worth journaling;
+	// smaller files simply start over.
+	resumeMinBytes int64 = 16 << 20
+	// resumeCheckpointBytes is how much data is written between journal
+	// updates.
+	resumeCheckpointBytes int64 = 16 << 20
+	// resumeVerifyBytes is how much of the .part tail is compared with the
+	// source before a transfer continues from its recorded offset.
+	resumeVerifyBytes int64 = 64 << 10
+)
+
+// resumeFile is the on-disk form of the transfer resume journal.
+type resumeFile struct {
+	Version   int            `json:"version"`
+	Transfers []ResumeRecord `json:"transfers"`
+}
+
+// ResumeRecord describes a partially written destination. Bytes before
+// Offset were written to Dest+".part" by an earlier attempt at copying
+// Source, which had Size and ModTime at the time.
+type ResumeRecord struct {
+	Source    string    `json:"source"`
+	Dest      string    `json:"dest"`
+	Size      int64     `json:"size"`
+	ModTime   time.Time `json:"modTime"`
+	Offset    int64     `json:"offset"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// resumeJournal holds the records of interrupted remote transfers keyed by
+// destination display path and rewrites its file on every change.
+type resumeJournal struct {
+	mu      sync.Mutex
+	path    string
+	records map[string]ResumeRecord
+}
+
+// LoadTransferResume restores the resume journal from path and keeps path
+// updated while remote transfers run. A missing file starts an empty
+// journal; a corrupt one is reported and replaced on the next update.
+// Without it, interrupted transfers always start over.
+func (m *Manager) LoadTransferResume(path string) error {
+	journal := &resumeJournal{path: path, records: make(map[string]ResumeRecord)}
+	var loadErr error
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		var file resumeFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			loadErr = fmt.Errorf("error parsing transfer resume journal: %w", err)
+		} else {
+			for _, r := range file.Transfers {
+				if r.Dest != "" && r.Offset > 0 {
+					journal.records[r.Dest] = r
+				}
+			}
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		loadErr = fmt.Errorf("error reading transfer resume journal: %w", err)
+	}
+
+	m.mu.Lock()
+	m.resume = journal
+	m.mu.Unlock()
+	dbg("transfer resume journal loaded path=%s records=%d err=%v", path, len(journal.records), loadErr)
+	return loadErr
+}
+
+func (m *Manager) resumeJournal() *resumeJournal {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.resume
+}
+
+func (r *resumeJournal) lookup(dest string) (ResumeRecord, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	record, ok := r.records[dest]
+	return record, ok
+}
+
+func (r *resumeJournal) put(record ResumeRecord) {
+	record.UpdatedAt = time.Now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records[record.Dest] = record
+	r.saveLocked()
+}
+
+func (r *resumeJournal) remove(dest string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.records[dest]; !ok {
+		return
+	}
+	delete(r.records, dest)
+	r.saveLocked()
+}
+
+// saveLocked writes the journal; records are small and updates are spaced
+// by resumeCheckpointBytes, so the write happens under r.mu.
+func (r *resumeJournal) saveLocked() {
+	records := make([]ResumeRecord, 0, len(r.records))
+	for _, record := range r.records {
+		records = append(records, record)
+	}
+	if err := writeResumeFile(r.path, records); err != nil {
+		dbg("transfer resume save failed path=%s err=%v", r.path, err)
+	}
+}
+
+func writeResumeFile(path string, records []ResumeRecord) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("error creating transfer resume directory: %w", err)
+	}
+	data, err := json.MarshalIndent(resumeFile{Version: resumeFileVersion, Transfers: records}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling transfer resume journal: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, "transfer-resume-*.json.tmp")
+	if err != nil {
+		return fmt.Errorf("error creating temp transfer resume file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("error writing temp transfer resume file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("error closing temp transfer resume file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("error renaming temp transfer resume file: %w", err)
+	}
+	return nil
+}
+
+// resumableTransfer reports whether copying src to dst is journaled: a file
+// large enough to matter that is read from or written to a remote backend.
+func resumableTransfer(src, dst executionPath, fi os.FileInfo) bool {
+	if fi.Size() < resumeMinBytes || !fi.Mode().IsRegular() {
+		return false
+	}
+	if dst.backend != backendLocal && dst.backend != backendSMB {
+		return false
+	}
+	return src.backend == backendSMB || src.backend == backendGio || dst.backend == backendSMB
+}
+
+// resumeTransfer tracks one journaled copy while it runs.
+type resumeTransfer struct {
+	journal *resumeJournal
+	record  ResumeRecord
+	saved   int64
+}
+
+func newResumeTransfer(journal *resumeJournal, src, dst executionPath, fi os.FileInfo) *resumeTransfer {
+	return &resumeTransfer{
+		journal: journal,
+		record: ResumeRecord{
+			Source:  src.displayPath(),
+			Dest:    dst.displayPath(),
+			Size:    fi.Size(),
+			ModTime: fi.ModTime(),
+		},
+	}
+}
+
+// matches reports whether a journaled record belongs to this transfer.
+func (t *resumeTransfer) matches(record ResumeRecord) bool {
+	return record.Source == t.record.Source &&
+		record.Size == t.record.Size &&
+		record.ModTime.Equal(t.record.ModTime) &&
+		record.Offset > 0 && record.Offset < record.Size
+}
+
+// checkpoint journals offset once enough data was written since the last
+// update.
+func (t *resumeTransfer) checkpoint(offset int64) {
+	if offset-t.saved < resumeCheckpointBytes {
+		return
+	}
+	t.interrupted(offset)
+}
+
+// interrupted journals offset immediately so a failed transfer keeps its
+// progress.
+func (t *resumeTransfer) interrupted(offset int64) {
+	if offset <= 0 || offset == t.saved {
+		return
+	}
+	t.record.Offset = offset
+	t.journal.put(t.record)
+	t.saved = offset
+}
+
+func (t *resumeTransfer) discard() {
+	t.journal.remove(t.record.Dest)
+}
+
+// resumeSource checks a journaled .part file against the source. When it
+// still matches, it returns the source opened and positioned at the recorded
+// offset; otherwise it drops the record and returns nil so the copy starts
+// over.
+func resumeSource(execCtx *executionContext, t *resumeTransfer, src, tmp executionPath) (io.ReadCloser, int64) {
+	record, ok := t.journal.lookup(t.record.Dest)
+	if !ok {
+		return nil, 0
+	}
+	if !t.matches(record) {
+		dbg("resume: stale record dest=%s", record.Dest)
+		t.discard()
+		return nil, 0
+	}
+	partInfo, err := statPath(execCtx, tmp)
+	if err != nil || partInfo.Size() < record.Offset {
+		dbg("resume: partial file missing or short dest=%s err=%v", record.Dest, err)
+		t.discard()
+		return nil, 0
+	}
+
+	verify := min(resumeVerifyBytes, record.Offset)
+	start := record.Offset - verify
+	partTail, err := readRange(execCtx, tmp, start, verify)
+	if err != nil {
+		dbg("resume: read partial tail failed dest=%s err=%v", record.Dest, err)
+		t.discard()
+		return nil, 0
+	}
+	in, err := openReadPath(execCtx, src)
+	if err != nil {
+		return nil, 0
+	}
+	srcTail := make([]byte, verify)
+	if err := skipBytes(in, start); err == nil {
+		_, err = io.ReadFull(in, srcTail)
+	}
+	if err != nil || !bytes.Equal(srcTail, partTail) {
+		dbg("resume: tail mismatch dest=%s err=%v", record.Dest, err)
+		in.Close()
+		t.discard()
+		return nil, 0
+	}
+	dbg("resume: continuing dest=%s offset=%d", record.Dest, record.Offset)
+	t.record.Offset = record.Offset
+	t.saved = record.Offset
+	return in, record.Offset
+}
+
+// confirmResume offers to continue the verified partial copy of src at
+// offset and reports whether to. Jobs without a resolver resume without
+// asking; declining starts the file over.
+func confirmResume(j *Job, execCtx *executionContext, src, dst executionPath, fi os.FileInfo, offset int64) (bool, error) {
+	action := j.resumeDefault
+	if action == "" && j.Resolver == nil {
+		action = ConflictResume
+	}
+	if action == "" {
+		tmp := partPath(dst)
+		req := ConflictRequest{
+			JobID:          j.ID,
+			Type:           j.Type,
+			SourcePath:     src.displayPath(),
+			Destination:    tmp.displayPath(),
+			SourceModified: fi.ModTime(),
+			DefaultAction:  ConflictResume,
+			CanApplyToRest: true,
+			ResumeOffset:   offset,
+			ResumeSize:     fi.Size(),
+		}
+		if partInfo, err := statPath(execCtx, tmp); err == nil {
+			req.DestModified = partInfo.ModTime()
+		}
+		resolution := resolveConflict(j, req)
+		action = resolution.Action
+		if resolution.ApplyToRest && action != ConflictCancelJob {
+			j.resumeDefault = action
+		}
+	}
+	switch action {
+	case ConflictResume:
+		return true, nil
+	case ConflictCancelJob:
+		return false, errCanceled
+	default:
+		dbg("resume: starting over dest=%s", dst.displayPath())
+		return false, nil
+	}
+}
+
+// readRange reads n bytes of p starting at offset.
+func readRange(execCtx *executionContext, p executionPath, offset, n int64) ([]byte, error) {
+	in, err := openReadPath(execCtx, p)
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+	if err := skipBytes(in, offset); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(in, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// skipBytes advances a freshly opened reader by n bytes, seeking when the
+// reader supports it and reading through the data otherwise.
+func skipBytes(in io.Reader, n int64) error {
+	if n <= 0 {
+		return nil
+	}
+	if s, ok := in.(io.Seeker); ok {
+		_, err := s.Seek(n, io.SeekStart)
+		return err
+	}
+	_, err := io.CopyN(io.Discard, in, n)
+	return err
+}
+
+// openResumeWritePath reopens a .part file without truncating it and
+// positions it at offset.
+func openResumeWritePath(execCtx *executionContext, p executionPath, offset int64) (io.WriteCloser, error) {
+	var out io.ReadWriteCloser
+	var err error
+	switch p.backend {
+	case backendLocal:
+		out, err = os.OpenFile(p.path, os.O_WRONLY, 0)
+	case backendSMB:
+		ops, opsErr := execCtx.smbOpsFor(p)
+		if opsErr != nil {
+			return nil, opsErr
+		}
+		out, err = ops.OpenFile(p.path, os.O_WRONLY, 0666)
+	default:
+		return nil, errors.New("backend cannot resume writes")
+	}
+	if err != nil {
+		return nil, err
+	}
+	s, ok := out.(io.Seeker)
+	if !ok {
+		out.Close()
+		return nil, errors.New("destination cannot seek")
+	}
+	if _, err := s.Seek(offset, io.SeekStart); err != nil {
+		out.Close()
+		return nil, err
+	}
+	return out, nil
+}
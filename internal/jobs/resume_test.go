@@ -0,0 +1,272 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// resumeGioVFS serves one file whose reads fail after failAfter bytes
+// (negative means never) and records where readers were seeked to.
+type resumeGioVFS struct {
+	fakeGioVFS
+	failAfter int
+	seeks     *[]int64
+}
+
+func (f resumeGioVFS) Open(uri string) (io.ReadCloser, error) {
+	data, ok := f.files[uri]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	if f.failAfter >= 0 {
+		return io.NopCloser(io.MultiReader(strings.NewReader(data[:f.failAfter]), failingReader{})), nil
+	}
+	return &seekRecorder{Reader: strings.NewReader(data), seeks: f.seeks}, nil
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("connection reset") }
+
+type seekRecorder struct {
+	*strings.Reader
+	seeks *[]int64
+}
+
+func (r *seekRecorder) Seek(offset int64, whence int) (int64, error) {
+	*r.seeks = append(*r.seeks, offset)
+	return r.Reader.Seek(offset, whence)
+}
+
+func (*seekRecorder) Close() error { return nil }
+
+const resumeTestURI = "mtp://phone/DCIM/movie.mp4"
+
+func newResumeGioPath(content string, failAfter int, seeks *[]int64) executionPath {
+	return executionPath{
+		raw:     resumeTestURI,
+		path:    resumeTestURI,
+		backend: backendGio,
+		gio: resumeGioVFS{
+			fakeGioVFS: fakeGioVFS{files: map[string]string{resumeTestURI: content}},
+			failAfter:  failAfter,
+			seeks:      seeks,
+		},
+	}
+}
+
+func useSmallResumeThresholds(t *testing.T) {
+	t.Helper()
+	minBytes, checkpoint, verify := resumeMinBytes, resumeCheckpointBytes, resumeVerifyBytes
+	resumeMinBytes, resumeCheckpointBytes, resumeVerifyBytes = 1, 10, 8
+	t.Cleanup(func() {
+		resumeMinBytes, resumeCheckpointBytes, resumeVerifyBytes = minBytes, checkpoint, verify
+	})
+}
+
+func copyWithJournal(t *testing.T, journalPath string, src, dst executionPath, resolver ConflictResolver) error {
+	t.Helper()
+	m := NewManager()
+	if err := m.LoadTransferResume(journalPath); err != nil {
+		t.Fatalf("LoadTransferResume returned error: %v", err)
+	}
+	execCtx := newExecutionContext()
+	execCtx.resume = m.resumeJournal()
+	fi, err := statPath(execCtx, src)
+	if err != nil {
+		t.Fatalf("statPath returned error: %v", err)
+	}
+	return copyFileWithCancel(&Job{Type: TypeCopy, Resolver: resolver, ctx: t.Context()}, execCtx, src, dst, fi, false)
+}
+
+func TestInterruptedRemoteCopyResumesAfterRestart(t *testing.T) {
+	useSmallResumeThresholds(t)
+	content := strings.Repeat("0123456789abcdef", 8)
+	dstDir := t.TempDir()
+	journalPath := filepath.Join(t.TempDir(), "transfer-resume.json")
+	dst, err := resolveExecutionPath(filepath.Join(dstDir, "movie.mp4"))
+	if err != nil {
+		t.Fatalf("resolveExecutionPath returned error: %v", err)
+	}
+
+	if err := copyWithJournal(t, journalPath, newResumeGioPath(content, 45, nil), dst, nil); err == nil {
+		t.Fatal("interrupted copy returned nil error")
+	}
+	part, err := os.ReadFile(dst.path + ".part")
+	if err != nil {
+		t.Fatalf("partial file was not kept: %v", err)
+	}
+	if string(part) != content[:45] {
+		t.Fatalf("partial content = %q", string(part))
+	}
+
+	var seeks []int64
+	if err := copyWithJournal(t, journalPath, newResumeGioPath(content, -1, &seeks), dst, nil); err != nil {
+		t.Fatalf("resumed copy returned error: %v", err)
+	}
+	data, err := os.ReadFile(dst.path)
+	if err != nil {
+		t.Fatalf("ReadFile copied file returned error: %v", err)
+	}
+	if string(data) != content {
+		t.Fatalf("copied content = %q", string(data))
+	}
+	if len(seeks) != 1 || seeks[0] != 45-resumeVerifyBytes {
+		t.Fatalf("source seeks = %v, want [%d]", seeks, 45-resumeVerifyBytes)
+	}
+	if _, err := os.Stat(dst.path + ".part"); !os.IsNotExist(err) {
+		t.Fatalf("partial file still exists: %v", err)
+	}
+	m := NewManager()
+	if err := m.LoadTransferResume(journalPath); err != nil {
+		t.Fatalf("LoadTransferResume returned error: %v", err)
+	}
+	if _, ok := m.resumeJournal().lookup(dst.displayPath()); ok {
+		t.Fatal("finished transfer is still journaled")
+	}
+}
+
+func TestResumeStartsOverWhenSourceChanged(t *testing.T) {
+	useSmallResumeThresholds(t)
+	content := strings.Repeat("0123456789abcdef", 8)
+	dstDir := t.TempDir()
+	journalPath := filepath.Join(t.TempDir(), "transfer-resume.json")
+	dst, err := resolveExecutionPath(filepath.Join(dstDir, "movie.mp4"))
+	if err != nil {
+		t.Fatalf("resolveExecutionPath returned error: %v", err)
+	}
+	if err := os.WriteFile(dst.path+".part", []byte(strings.Repeat("x", 40)), 0644); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+	stale := ResumeRecord{
+		Source:  resumeTestURI,
+		Dest:    dst.displayPath(),
+		Size:    int64(len(content)),
+		ModTime: time.Unix(1, 0),
+		Offset:  40,
+	}
+	if err := writeResumeFile(journalPath, []ResumeRecord{stale}); err != nil {
+		t.Fatalf("writeResumeFile returned error: %v", err)
+	}
+
+	if err := copyWithJournal(t, journalPath, newResumeGioPath(content, -1, new([]int64)), dst, nil); err != nil {
+		t.Fatalf("copy returned error: %v", err)
+	}
+	data, err := os.ReadFile(dst.path)
+	if err != nil {
+		t.Fatalf("ReadFile copied file returned error: %v", err)
+	}
+	if string(data) != content {
+		t.Fatalf("copied content = %q", string(data))
+	}
+}
+
+func TestResumeOffersPartialCopyToResolver(t *testing.T) {
+	useSmallResumeThresholds(t)
+	content := strings.Repeat("0123456789abcdef", 8)
+	// The first byte of the partial file is marked outside the verified
+	// tail, so only a resumed copy keeps it.
+	for _, tc := range []struct {
+		action ConflictAction
+		want   string
+	}{
+		{ConflictResume, "X" + content[1:]},
+		{ConflictOverwrite, content},
+	} {
+		t.Run(string(tc.action), func(t *testing.T) {
+			dst, err := resolveExecutionPath(filepath.Join(t.TempDir(), "movie.mp4"))
+			if err != nil {
+				t.Fatalf("resolveExecutionPath returned error: %v", err)
+			}
+			journalPath := filepath.Join(t.TempDir(), "transfer-resume.json")
+			if err := copyWithJournal(t, journalPath, newResumeGioPath(content, 45, nil), dst, nil); err == nil {
+				t.Fatal("interrupted copy returned nil error")
+			}
+			if err := os.WriteFile(dst.path+".part", []byte("X"+content[1:45]), 0644); err != nil {
+				t.Fatalf("WriteFile returned error: %v", err)
+			}
+
+			var asked []ConflictRequest
+			resolver := func(_ context.Context, req ConflictRequest) ConflictResolution {
+				asked = append(asked, req)
+				return ConflictResolution{Action: tc.action}
+			}
+			if err := copyWithJournal(t, journalPath, newResumeGioPath(content, -1, new([]int64)), dst, resolver); err != nil {
+				t.Fatalf("second copy returned error: %v", err)
+			}
+			if len(asked) != 1 || asked[0].ResumeOffset != 45 || asked[0].ResumeSize != int64(len(content)) || asked[0].Destination != dst.displayPath()+".part" {
+				t.Fatalf("resolver requests = %+v, want one offer at 45 of %d", asked, len(content))
+			}
+			data, err := os.ReadFile(dst.path)
+			if err != nil || string(data) != tc.want {
+				t.Fatalf("copied content = %q, %v", string(data), err)
+			}
+		})
+	}
+}
+
+func TestCanceledResumeOfferKeepsPartialFile(t *testing.T) {
+	useSmallResumeThresholds(t)
+	content := strings.Repeat("0123456789abcdef", 8)
+	dst, err := resolveExecutionPath(filepath.Join(t.TempDir(), "movie.mp4"))
+	if err != nil {
+		t.Fatalf("resolveExecutionPath returned error: %v", err)
+	}
+	journalPath := filepath.Join(t.TempDir(), "transfer-resume.json")
+	if err := copyWithJournal(t, journalPath, newResumeGioPath(content, 45, nil), dst, nil); err == nil {
+		t.Fatal("interrupted copy returned nil error")
+	}
+
+	cancelJob := func(context.Context, ConflictRequest) ConflictResolution {
+		return ConflictResolution{Action: ConflictCancelJob}
+	}
+	err = copyWithJournal(t, journalPath, newResumeGioPath(content, -1, new([]int64)), dst, cancelJob)
+	if !errors.Is(err, errCanceled) {
+		t.Fatalf("copy error = %v, want errCanceled", err)
+	}
+	if part, err := os.ReadFile(dst.path + ".part"); err != nil || string(part) != content[:45] {
+		t.Fatalf("partial file = %q, %v; want it kept", string(part), err)
+	}
+	m := NewManager()
+	if err := m.LoadTransferResume(journalPath); err != nil {
+		t.Fatalf("LoadTransferResume returned error: %v", err)
+	}
+	if _, ok := m.resumeJournal().lookup(dst.displayPath()); !ok {
+		t.Fatal("canceled offer dropped the journal record")
+	}
+}
+
+func TestCanceledRemoteCopyDropsPartialFile(t *testing.T) {
+	useSmallResumeThresholds(t)
+	dstDir := t.TempDir()
+	dst, err := resolveExecutionPath(filepath.Join(dstDir, "movie.mp4"))
+	if err != nil {
+		t.Fatalf("resolveExecutionPath returned error: %v", err)
+	}
+	m := NewManager()
+	if err := m.LoadTransferResume(filepath.Join(t.TempDir(), "transfer-resume.json")); err != nil {
+		t.Fatalf("LoadTransferResume returned error: %v", err)
+	}
+	execCtx := newExecutionContext()
+	execCtx.resume = m.resumeJournal()
+	src := newResumeGioPath(strings.Repeat("z", 64), -1, new([]int64))
+	fi, err := statPath(execCtx, src)
+	if err != nil {
+		t.Fatalf("statPath returned error: %v", err)
+	}
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	err = copyFileWithCancel(&Job{Type: TypeCopy, ctx: ctx}, execCtx, src, dst, fi, false)
+	if !errors.Is(err, errCanceled) {
+		t.Fatalf("copy error = %v, want errCanceled", err)
+	}
+	if _, err := os.Stat(dst.path + ".part"); !os.IsNotExist(err) {
+		t.Fatalf("partial file kept after cancel: %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package jobs
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+var errSecureDeleteUnsupported = errors.New("secure delete only supports local files")
+
+const shredChunkSize = 1 << 20
+
+// shredFile overwrites the local regular file at path with one pass of random
+// data, flushes it to the device, truncates it, and renames it to a random
+// name in the same directory. It returns the new path, which the caller
+// removes. Read-only files are made writable first. A canceled shred leaves
+// the partly overwritten file under its original name.
+func shredFile(j *Job, path string, fi os.FileInfo) (string, error) {
+	if fi.Mode().Perm()&0200 == 0 {
+		if err := os.Chmod(path, fi.Mode().Perm()|0200); err != nil {
+			return "", err
+		}
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return "", err
+	}
+	size := fi.Size()
+	j.beginFileProgress(path, size)
+	buf := make([]byte, shredChunkSize)
+	for written := int64(0); written < size; {
+		if canceled(j) {
+			f.Close()
+			return "", errCanceled
+		}
+		chunk := buf
+		if remaining := size - written; remaining < int64(len(chunk)) {
+			chunk = chunk[:remaining]
+		}
+		rand.Read(chunk)
+		n, err := f.Write(chunk)
+		written += int64(n)
+		j.addFileProgress(int64(n), false)
+		if err != nil {
+			f.Close()
+			return "", err
+		}
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Truncate(0); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	j.completeFileProgress()
+
+	// Renaming drops the original name from the directory before unlinking.
+	name := make([]byte, 8)
+	rand.Read(name)
+	renamed := filepath.Join(filepath.Dir(path), hex.EncodeToString(name))
+	if err := os.Rename(path, renamed); err != nil {
+		return "", err
+	}
+	return renamed, nil
+}
@@ -0,0 +1,50 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSecureDeleteOverwritesFilesBeforeRemoving(t *testing.T) {
+	tmp := t.TempDir()
+	root := filepath.Join(tmp, "secret")
+	if err := os.MkdirAll(root, 0755); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(root, "key.txt")
+	data := bytes.Repeat([]byte("s"), shredChunkSize+10)
+	if err := os.WriteFile(file, data, 0400); err != nil {
+		t.Fatal(err)
+	}
+	// A second link shares the file's data and outlives the delete.
+	link := filepath.Join(tmp, "link")
+	if err := os.Link(file, link); err != nil {
+		t.Skipf("hard links unavailable: %v", err)
+	}
+
+	j := &Job{Type: TypeDelete, Sources: []string{root}, DeleteMode: DeleteModeSecure, ctx: context.Background(), TotalFiles: 1}
+	if err := (&Manager{}).runDeleteJob(j); err != nil {
+		t.Fatalf("runDeleteJob returned error: %v", err)
+	}
+	if _, err := os.Lstat(root); !os.IsNotExist(err) {
+		t.Fatalf("deleted directory still exists or stat failed unexpectedly: %v", err)
+	}
+	if fi, err := os.Stat(link); err != nil || fi.Size() != 0 {
+		t.Fatalf("linked data should be truncated, got %v, %v", fi, err)
+	}
+	want := int64(len(data))
+	if j.TotalBytes != want || j.DoneBytes != want {
+		t.Fatalf("bytes = %d/%d, want %d/%d", j.DoneBytes, j.TotalBytes, want, want)
+	}
+}
+
+func TestSecureDeleteRerunIsRefused(t *testing.T) {
+	m := NewManager()
+	_, err := m.requeue(1, HistoryRecord{Type: TypeDelete, DeleteMode: DeleteModeSecure}, []string{"a"}, nil)
+	if err == nil {
+		t.Fatal("secure delete rerun should be refused")
+	}
+}
@@ -0,0 +1,71 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+
+	"nmf/internal/fileinfo"
+)
+
+// destinationStorage reports the free space at a destination; tests replace it.
+var destinationStorage = fileinfo.StatStoragePortable
+
+// InsufficientSpaceError fails a copy or move whose sources do not fit in the
+// destination's free space, before anything is written.
+type InsufficientSpaceError struct {
+	Dest   string
+	Needed int64
+	Free   int64
+}
+
+func (e *InsufficientSpaceError) Error() string {
+	return fmt.Sprintf("not enough free space on %s: %s needed, %s free",
+		e.Dest, fileinfo.FormatFileSize(e.Needed), fileinfo.FormatFileSize(e.Free))
+}
+
+// SpaceShortfall returns the error that failed j before it started because
+// its destination was too small.
+func (j *Job) SpaceShortfall() (InsufficientSpaceError, bool) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	if j.shortfall == nil {
+		return InsufficientSpaceError{}, false
+	}
+	return *j.shortfall, true
+}
+
+// checkFreeSpace compares the bytes j will write against the free space at
+// destPath. A move that renames within one volume writes nothing, so only
+// sources crossing volumes count. When the destination cannot report its
+// capacity, as inside an archive, the transfer goes ahead unchecked.
+func checkFreeSpace(j *Job, sourceBytes []int64, destPath executionPath) error {
+	var needed int64
+	for i, raw := range j.Sources {
+		src, err := resolveExecutionPath(raw)
+		if err != nil {
+			continue
+		}
+		if j.Type == TypeMove && !crossesVolumes(src, joinPath(destPath, baseName(src))) {
+			continue
+		}
+		needed += sourceBytes[i]
+	}
+	if needed == 0 {
+		return nil
+	}
+	info, err := destinationStorage(j.DestDir)
+	if err != nil {
+		if !errors.Is(err, fileinfo.ErrStorageUnsupported) {
+			dbg("job %d: free space unknown for %s: %v", j.ID, j.DestDir, err)
+		}
+		return nil
+	}
+	if uint64(needed) <= info.Free {
+		return nil
+	}
+	shortfall := &InsufficientSpaceError{Dest: j.DestDir, Needed: needed, Free: int64(info.Free)}
+	j.mu.Lock()
+	j.shortfall = shortfall
+	j.mu.Unlock()
+	return shortfall
+}
@@ -0,0 +1,100 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"nmf/internal/fileinfo"
+)
+
+func stubDestinationStorage(t *testing.T, info fileinfo.StorageInfo, err error) {
+	t.Helper()
+	old := destinationStorage
+	t.Cleanup(func() { destinationStorage = old })
+	destinationStorage = func(string) (fileinfo.StorageInfo, error) { return info, err }
+}
+
+func writeSpaceSource(t *testing.T, size int) string {
+	t.Helper()
+	src := filepath.Join(t.TempDir(), "big.bin")
+	if err := os.WriteFile(src, bytes.Repeat([]byte("x"), size), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return src
+}
+
+func TestCopyFailsBeforeWritingWhenDestinationIsTooSmall(t *testing.T) {
+	stubDestinationStorage(t, fileinfo.StorageInfo{Free: 999}, nil)
+	src := writeSpaceSource(t, 1000)
+	dest := t.TempDir()
+
+	job := &Job{Type: TypeCopy, Sources: []string{src}, DestDir: dest, ctx: context.Background()}
+	err := (&Manager{}).runJob(job)
+	var spaceErr *InsufficientSpaceError
+	if !errors.As(err, &spaceErr) {
+		t.Fatalf("runJob err = %v, want InsufficientSpaceError", err)
+	}
+	if spaceErr.Needed != 1000 || spaceErr.Free != 999 || spaceErr.Dest != dest {
+		t.Fatalf("shortfall = %+v, want 1000 needed, 999 free on %s", *spaceErr, dest)
+	}
+	if got, ok := job.SpaceShortfall(); !ok || got.Needed != 1000 {
+		t.Fatalf("SpaceShortfall = %+v, %v", got, ok)
+	}
+	if entries, _ := os.ReadDir(dest); len(entries) != 0 {
+		t.Fatalf("failed copy wrote %d entries to the destination", len(entries))
+	}
+}
+
+func TestCopyProceedsWhenSpaceFitsOrIsUnknown(t *testing.T) {
+	tests := []struct {
+		name string
+		info fileinfo.StorageInfo
+		err  error
+	}{
+		{name: "fits", info: fileinfo.StorageInfo{Free: 1000}},
+		{name: "unsupported", err: fileinfo.ErrStorageUnsupported},
+		{name: "stat error", err: errors.New("statfs failed")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubDestinationStorage(t, tt.info, tt.err)
+			src := writeSpaceSource(t, 1000)
+			dest := t.TempDir()
+			job := &Job{Type: TypeCopy, Sources: []string{src}, DestDir: dest, ctx: context.Background()}
+			if err := (&Manager{}).runJob(job); err != nil {
+				t.Fatalf("runJob: %v", err)
+			}
+			if _, ok := job.SpaceShortfall(); ok {
+				t.Fatal("SpaceShortfall reported for a copy that ran")
+			}
+			if _, err := os.Stat(filepath.Join(dest, "big.bin")); err != nil {
+				t.Fatalf("copy missing: %v", err)
+			}
+		})
+	}
+}
+
+func TestMoveWithinVolumeIgnoresFreeSpace(t *testing.T) {
+	stubDestinationStorage(t, fileinfo.StorageInfo{Free: 0}, nil)
+	root := t.TempDir()
+	src := filepath.Join(root, "big.bin")
+	if err := os.WriteFile(src, bytes.Repeat([]byte("x"), 1000), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dest := filepath.Join(root, "dest")
+	if err := os.Mkdir(dest, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	job := &Job{Type: TypeMove, Sources: []string{src}, DestDir: dest, ctx: context.Background()}
+	if err := (&Manager{}).runJob(job); err != nil {
+		t.Fatalf("same-volume move should only rename: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dest, "big.bin")); err != nil {
+		t.Fatalf("moved file missing: %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+package jobs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// errSymlinkNotLocal rejects symlink jobs whose source or destination is not
+// on a local filesystem, where a link target could not be resolved the same
+// way by other programs.
+var errSymlinkNotLocal = errors.New("symbolic links can only be created between local paths")
+
+// EnqueueSymlink enqueues a job creating one symbolic link in destDir for
+// each source, named like the source and pointing at its absolute path.
+// resolver is asked when a link name already exists.
+func (m *Manager) EnqueueSymlink(sources []string, destDir string, resolver ConflictResolver) *Job {
+	return m.enqueue(TypeSymlink, sources, destDir, resolver, TransferOptions{})
+}
+
+func (m *Manager) runSymlinkJob(j *Job) error {
+	destPath, err := resolveExecutionPath(j.DestDir)
+	if err != nil {
+		return wrapPath(j.DestDir, err)
+	}
+	if destPath.backend != backendLocal {
+		return wrapPath(destPath.displayPath(), errSymlinkNotLocal)
+	}
+	execCtx := newExecutionContext()
+	execCtx.jobCtx = j.ctx
+	defer func() {
+		if err := execCtx.close(); err != nil {
+			dbg("job %d: execution context close error: %v", j.ID, err)
+		}
+	}()
+	if err := validateDestinationDirectory(execCtx, destPath); err != nil {
+		return err
+	}
+
+	for i, src := range j.Sources {
+		if canceled(j) {
+			return errCanceled
+		}
+		j.mu.Lock()
+		j.CurrentSource = src
+		j.Message = ""
+		j.mu.Unlock()
+		m.notify()
+
+		if err := createSymlink(j, execCtx, src, destPath); err != nil {
+			j.mu.Lock()
+			j.Failures = append(j.Failures, JobFailure{TopSource: src, Path: failingPath(err), Error: err.Error()})
+			j.mu.Unlock()
+			return err
+		}
+		j.mu.Lock()
+		j.DoneFiles = i + 1
+		j.mu.Unlock()
+		dbg("job %d: linked %d/%d", j.ID, j.DoneFiles, j.TotalFiles)
+		m.notify()
+	}
+	return nil
+}
+
+// createSymlink links src from destDir. A conflicting name goes through the
+// usual conflict resolution; overwriting replaces only an existing link.
+func createSymlink(j *Job, execCtx *executionContext, src string, destDir executionPath) error {
+	srcPath, err := resolveExecutionPath(src)
+	if err != nil {
+		return wrapPath(src, err)
+	}
+	if srcPath.backend != backendLocal {
+		return wrapPath(srcPath.displayPath(), errSymlinkNotLocal)
+	}
+	if _, err := lstatPath(execCtx, srcPath); err != nil {
+		return wrapPath(srcPath.displayPath(), err)
+	}
+	name := baseName(srcPath)
+	link := joinPath(destDir, name)
+	linkInfo := virtualFileInfo{name: name, mode: os.ModeSymlink | 0777, modTime: time.Now()}
+	link, skipped, overwrite, err := resolveDestinationConflict(j, execCtx, srcPath, link, linkInfo)
+	if err != nil {
+		return err
+	}
+	if skipped {
+		dbg("job %d: symlink %s skipped", j.ID, link.displayPath())
+		return nil
+	}
+	if overwrite {
+		if err := removePath(execCtx, link); err != nil {
+			return wrapPath(link.displayPath(), err)
+		}
+	}
+	target, err := filepath.Abs(srcPath.path)
+	if err != nil {
+		return wrapPath(srcPath.displayPath(), err)
+	}
+	if err := symlinkPath(execCtx, target, link); err != nil {
+		return wrapPath(link.displayPath(), err)
+	}
+	return nil
+}
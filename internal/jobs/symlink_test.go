@@ -0,0 +1,46 @@
+package jobs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestSymlinkJobLinksSourcesAndSuffixesTakenNames(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("creating symlinks needs extra privileges on Windows")
+	}
+	tmp := t.TempDir()
+	targetDir, _ := makeSymlinkTargetTree(t, tmp)
+	file := filepath.Join(tmp, "notes.txt")
+	if err := os.WriteFile(file, []byte("n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewManager()
+	j := m.EnqueueSymlink([]string{targetDir, file}, targetDir, func(context.Context, ConflictRequest) ConflictResolution {
+		t.Fatal("no conflict expected")
+		return ConflictResolution{}
+	})
+	waitForJobStatus(t, j, StatusCompleted)
+	for name, want := range map[string]string{"target": targetDir, "notes.txt": file} {
+		got, err := os.Readlink(filepath.Join(targetDir, name))
+		if err != nil || got != want {
+			t.Fatalf("link %s -> %q (err %v), want %q", name, got, err, want)
+		}
+	}
+
+	// Linking into the source's own directory collides with the source.
+	again := m.EnqueueSymlink([]string{file}, tmp, func(_ context.Context, req ConflictRequest) ConflictResolution {
+		if req.Type != TypeSymlink {
+			t.Errorf("conflict type = %s, want symlink", req.Type)
+		}
+		return ConflictResolution{Action: ConflictAutoSuffix}
+	})
+	waitForJobStatus(t, again, StatusCompleted)
+	if got, err := os.Readlink(filepath.Join(tmp, "notes (1).txt")); err != nil || got != file {
+		t.Fatalf("suffixed link -> %q (err %v), want %q", got, err, file)
+	}
+}
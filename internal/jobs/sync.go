@@ -0,0 +1,155 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+
+	"nmf/internal/filecompare"
+	"nmf/internal/fileinfo"
+)
+
+// SyncOptions configures a TypeSync job.
+type SyncOptions struct {
+	// Method is filecompare.SyncMirror or filecompare.SyncTwoWay.
+	Method filecompare.Method
+	// Plan holds the actions the user previewed. Nil plans the sync again
+	// when the job starts, as reruns from history do.
+	Plan []filecompare.SyncAction
+}
+
+// EnqueueSync enqueues a job synchronizing destDir with source. Copies keep
+// their timestamps so the next sync sees them as unchanged.
+func (m *Manager) EnqueueSync(source, destDir string, options SyncOptions) *Job {
+	return m.enqueue(TypeSync, []string{source}, destDir, nil, TransferOptions{PreserveTimestamps: true, Sync: options})
+}
+
+// runSyncJob runs each planned action in order. A failed action is recorded
+// and the rest still run; conflicts are left for the user to settle.
+func (m *Manager) runSyncJob(j *Job) error {
+	if len(j.Sources) != 1 {
+		return fmt.Errorf("sync needs one source directory, got %d", len(j.Sources))
+	}
+	source := j.Sources[0]
+	options := j.Options.Sync
+	if !options.Method.IsSync() {
+		return fmt.Errorf("unknown sync method %q", options.Method)
+	}
+	plan := options.Plan
+	if plan == nil {
+		j.mu.Lock()
+		j.Message = "planning"
+		j.mu.Unlock()
+		m.notify()
+		var err error
+		if plan, err = filecompare.PlanSync(j.ctx, source, j.DestDir, options.Method); err != nil {
+			if canceled(j) {
+				return errCanceled
+			}
+			return err
+		}
+	}
+	var total int64
+	for _, a := range plan {
+		if a.Kind == filecompare.SyncCopy {
+			total += a.Size
+		}
+	}
+	j.mu.Lock()
+	j.TotalFiles = len(plan)
+	j.TotalBytes = total
+	j.mu.Unlock()
+	m.notify()
+
+	execCtx := newExecutionContext()
+	execCtx.jobCtx = j.ctx
+	defer func() {
+		if err := execCtx.close(); err != nil {
+			dbg("job %d: execution context close error: %v", j.ID, err)
+		}
+	}()
+	failed := 0
+	for i, a := range plan {
+		if canceled(j) {
+			return errCanceled
+		}
+		j.mu.Lock()
+		j.CurrentSource = a.To
+		j.Message = string(a.Kind)
+		j.clearFileProgressLocked()
+		j.mu.Unlock()
+		m.notify()
+
+		err := runSyncAction(j, execCtx, a)
+		if errors.Is(err, errCanceled) {
+			return err
+		}
+		if err != nil {
+			failed++
+			j.mu.Lock()
+			j.Failures = append(j.Failures, JobFailure{TopSource: source, Path: failingPath(err), Error: err.Error()})
+			j.mu.Unlock()
+		}
+		j.mu.Lock()
+		j.DoneFiles = i + 1
+		if a.Kind == filecompare.SyncCopy {
+			j.finishSourceBytesLocked(a.Size)
+		}
+		j.clearFileProgressLocked()
+		j.mu.Unlock()
+		m.notify()
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d sync action(s) failed", failed)
+	}
+	return nil
+}
+
+func runSyncAction(j *Job, execCtx *executionContext, a filecompare.SyncAction) error {
+	switch a.Kind {
+	case filecompare.SyncConflict:
+		dbg("job %d: sync conflict left alone %s <-> %s", j.ID, a.From, a.To)
+		return nil
+	case filecompare.SyncDelete:
+		target, err := resolveExecutionPath(a.To)
+		if err != nil {
+			return wrapPath(a.To, err)
+		}
+		if err := validateDeleteTarget(target); err != nil {
+			return wrapPath(target.displayPath(), err)
+		}
+		dbg("job %d: sync delete %s", j.ID, target.displayPath())
+		return deletePermanentResolved(j, execCtx, target)
+	case filecompare.SyncCopy:
+		return syncCopy(j, execCtx, a.From, a.To)
+	default:
+		return fmt.Errorf("unknown sync action %q", a.Kind)
+	}
+}
+
+// syncCopy writes from to to. Regular files replace the existing copy
+// through a .part file; directories missing on the other side and symlinks
+// go through the ordinary copy path into to's parent, under from's name.
+func syncCopy(j *Job, execCtx *executionContext, from, to string) error {
+	src, err := resolveExecutionPath(from)
+	if err != nil {
+		return wrapPath(from, err)
+	}
+	dst, err := resolveExecutionPath(to)
+	if err != nil {
+		return wrapPath(to, err)
+	}
+	fi, err := lstatPath(execCtx, src)
+	if err != nil {
+		return wrapPath(src.displayPath(), err)
+	}
+	dbg("job %d: sync copy %s -> %s", j.ID, src.displayPath(), dst.displayPath())
+	if fi.Mode().IsRegular() {
+		return copyFileWithCancel(j, execCtx, src, dst, fi, true)
+	}
+	if !fi.IsDir() {
+		if err := removePath(execCtx, dst); err != nil && !fileinfo.IsNotExist(err) {
+			return wrapPath(dst.displayPath(), err)
+		}
+	}
+	return copyOrMovePathResolved(j, execCtx, src, dirPath(dst))
+}
@@ -0,0 +1,70 @@
+package jobs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"nmf/internal/filecompare"
+)
+
+func TestSyncJobMirrorsSourceIntoDestination(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+	old := time.Unix(1_700_000_000, 0)
+	writeChecksumTestFile(t, filepath.Join(src, "a.txt"), "new a")
+	writeChecksumTestFile(t, filepath.Join(src, "dir", "b.txt"), "b")
+	writeChecksumTestFile(t, filepath.Join(dst, "a.txt"), "old")
+	writeChecksumTestFile(t, filepath.Join(dst, "stale", "c.txt"), "c")
+	if err := os.Chtimes(filepath.Join(dst, "a.txt"), old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewManager()
+	j := m.EnqueueSync(src, dst, SyncOptions{Method: filecompare.SyncMirror})
+	waitForJobStatus(t, j, StatusCompleted)
+
+	for name, want := range map[string]string{"a.txt": "new a", "dir/b.txt": "b"} {
+		data, err := os.ReadFile(filepath.Join(dst, filepath.FromSlash(name)))
+		if err != nil || string(data) != want {
+			t.Fatalf("%s = %q, %v; want %q", name, data, err, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dst, "stale")); !os.IsNotExist(err) {
+		t.Fatalf("stale directory still present: %v", err)
+	}
+	plan, err := filecompare.PlanSync(context.Background(), src, dst, filecompare.SyncMirror)
+	if err != nil || len(plan) != 0 {
+		t.Fatalf("plan after sync = %+v, %v; want nothing left to do", plan, err)
+	}
+	if snap := j.Snapshot(); snap.TotalFiles != 3 || snap.DoneFiles != 3 || snap.SyncMethod != filecompare.SyncMirror {
+		t.Fatalf("snapshot files %d/%d method %q", snap.DoneFiles, snap.TotalFiles, snap.SyncMethod)
+	}
+}
+
+func TestSyncJobRunsPreviewedPlanAndSkipsConflicts(t *testing.T) {
+	src := t.TempDir()
+	dst := t.TempDir()
+	writeChecksumTestFile(t, filepath.Join(src, "keep.txt"), "keep")
+	writeChecksumTestFile(t, filepath.Join(dst, "extra.txt"), "extra")
+	plan := []filecompare.SyncAction{
+		{Kind: filecompare.SyncCopy, From: filepath.Join(dst, "extra.txt"), To: filepath.Join(src, "extra.txt"), Size: 5},
+		{Kind: filecompare.SyncConflict, From: filepath.Join(src, "keep.txt"), To: filepath.Join(dst, "keep.txt")},
+	}
+
+	m := NewManager()
+	j := m.EnqueueSync(src, dst, SyncOptions{Method: filecompare.SyncTwoWay, Plan: plan})
+	waitForJobStatus(t, j, StatusCompleted)
+
+	if _, err := os.Stat(filepath.Join(src, "extra.txt")); err != nil {
+		t.Fatalf("planned copy missing: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "keep.txt")); !os.IsNotExist(err) {
+		t.Fatalf("unplanned copy of keep.txt happened: %v", err)
+	}
+	if snap := j.Snapshot(); snap.TotalBytes != 5 || snap.DoneBytes != 5 {
+		t.Fatalf("bytes = %d/%d, want 5/5", snap.DoneBytes, snap.TotalBytes)
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"sync"
 	"time"
+
+	"nmf/internal/filecompare"
+	"nmf/internal/fileinfo"
 )
 
 // Type represents job type.
@@ -14,6 +17,17 @@ const (
 	TypeMove    Type = "move"
 	TypeDelete  Type = "delete"
 	TypeExtract Type = "extract"
+	TypeArchive Type = "archive"
+	TypeSymlink Type = "symlink"
+	// TypeChecksum hashes files; TypeVerify checks them against checksum
+	// files. Neither has a destination directory.
+	TypeChecksum Type = "checksum"
+	TypeVerify   Type = "verify"
+	// TypeSync synchronizes DestDir with its single source directory.
+	TypeSync Type = "sync"
+	// TypeRename renames each source within its directory; DestDir is that
+	// directory. Its audit entries share AuditRename with single renames.
+	TypeRename Type = AuditRename
 )
 
 // DeleteMode controls whether a delete job uses OS trash or permanent removal.
@@ -22,6 +36,9 @@ type DeleteMode string
 const (
 	DeleteModeTrash     DeleteMode = "trash"
 	DeleteModePermanent DeleteMode = "permanent"
+	// DeleteModeSecure overwrites local regular files with random data before
+	// removing them permanently.
+	DeleteModeSecure DeleteMode = "secure"
 )
 
 // Status represents job status.
@@ -46,17 +63,34 @@ type Job struct {
 	Resolver        ConflictResolver
 	Options         TransferOptions
 	conflictDefault ConflictAction
+	// dirConflictDefault remembers an "apply to rest" choice for
+	// directory-onto-directory collisions separately from file collisions.
+	dirConflictDefault ConflictAction
+	// resumeDefault remembers an "apply to rest" choice for partial copies
+	// left by interrupted transfers.
+	resumeDefault ConflictAction
+	// mergeDepth counts merged directories being traversed; nested directory
+	// collisions inside a merge merge without asking again.
+	mergeDepth int
+	// duplicateNames names auto-suffixed copies; set when the job starts.
+	duplicateNames fileinfo.DuplicateNamePattern
 
 	// state
 	mu                  sync.RWMutex
 	Status              Status
 	TotalFiles          int
 	DoneFiles           int
+	TotalBytes          int64 // precomputed before a copy/move starts; 0 when unknown
+	DoneBytes           int64
+	completedBytes      int64
 	CurrentSource       string
 	Message             string
 	Error               string
 	Failures            []JobFailure
 	FailureAcknowledged bool
+	HighPriority        bool // queued ahead of normal jobs; written with m.mu held too
+	MoveRenames         int  // items a move put in place with one rename
+	MoveCopies          int  // files a move copied and deleted across volumes
 	EnqueuedAt          time.Time
 	StartedAt           time.Time
 	CompletedAt         time.Time
@@ -67,6 +101,10 @@ type Job struct {
 	CurrentUpdatedAt    time.Time
 	lastProgressNotify  time.Time
 	progressNotify      func()
+	volumes             []string // scheduler volume keys, fixed at enqueue
+	restored            bool     // loaded from a previous session's history
+	checksums           []ChecksumResult
+	shortfall           *InsufficientSpaceError // set when the free-space check failed the job
 
 	// cancellation
 	ctx    context.Context
@@ -75,7 +113,30 @@ type Job struct {
 
 // TransferOptions controls copy/move execution details.
 type TransferOptions struct {
+	// PreserveTimestamps keeps access and modification times; moves always
+	// keep them.
 	PreserveTimestamps bool
+	// PreserveOwnership gives local copies the owner and group of their
+	// source on Unix, which takes root for files of other users.
+	PreserveOwnership bool
+	// PreserveXattrs copies the extended attributes of local files on Unix.
+	PreserveXattrs bool
+	// OrganizeByDate places each source under DestDir/YYYY/MM/DD, dated by
+	// its media capture date or, failing that, its modification time.
+	OrganizeByDate bool
+	// Layout selects keep, flatten, or relative placement of source trees.
+	Layout TransferLayout
+	// RelativeBase is the directory that LayoutRelative measures source
+	// parents from. Empty keeps the top-level layout.
+	RelativeBase string
+	// Archive names and configures the archive a TypeArchive job creates.
+	Archive ArchiveOptions
+	// Checksum selects the digest of TypeChecksum and TypeVerify jobs.
+	Checksum ChecksumOptions
+	// Sync selects the method and plan of TypeSync jobs.
+	Sync SyncOptions
+	// Rename holds the new names of TypeRename jobs.
+	Rename RenameOptions
 }
 
 // ConflictAction is the user's choice when a destination path already exists.
@@ -88,6 +149,11 @@ const (
 	ConflictOverwriteIfNewer ConflictAction = "overwrite_if_newer"
 	ConflictOverwrite        ConflictAction = "overwrite"
 	ConflictCancelJob        ConflictAction = "cancel_job"
+	// ConflictMerge combines a source directory into an existing destination
+	// directory, resolving nested file collisions individually.
+	ConflictMerge ConflictAction = "merge"
+	// ConflictResume continues an interrupted copy from its partial file.
+	ConflictResume ConflictAction = "resume"
 )
 
 // ConflictResolver is called by the worker when a destination name collision is
@@ -107,6 +173,15 @@ type ConflictRequest struct {
 	IsDir          bool
 	DefaultAction  ConflictAction
 	CanApplyToRest bool
+	// CanMerge is set when both sides are directories; overwrite actions do
+	// not apply and ConflictMerge is offered instead.
+	CanMerge bool
+	// ResumeOffset is set when Destination is the partial file of an
+	// interrupted copy of the unchanged source, holding ResumeOffset of its
+	// ResumeSize bytes. ConflictResume and ConflictOverwrite, which starts
+	// over, are offered instead.
+	ResumeOffset int64
+	ResumeSize   int64
 }
 
 // ConflictResolution contains the selected collision behavior.
@@ -126,12 +201,20 @@ func (j *Job) Snapshot() JobSnapshot {
 		Status:              j.Status,
 		TotalFiles:          j.TotalFiles,
 		DoneFiles:           j.DoneFiles,
+		TotalBytes:          j.TotalBytes,
+		DoneBytes:           j.DoneBytes,
 		CurrentSource:       j.CurrentSource,
 		Message:             j.Message,
 		Error:               j.Error,
 		DestDir:             j.DestDir,
 		DeleteMode:          j.DeleteMode,
+		Archive:             j.Options.Archive,
+		Checksum:            j.Options.Checksum,
+		SyncMethod:          j.Options.Sync.Method,
 		FailureAcknowledged: j.FailureAcknowledged,
+		HighPriority:        j.HighPriority,
+		MoveRenames:         j.MoveRenames,
+		MoveCopies:          j.MoveCopies,
 		EnqueuedAt:          j.EnqueuedAt,
 		StartedAt:           j.StartedAt,
 		CompletedAt:         j.CompletedAt,
@@ -142,6 +225,7 @@ func (j *Job) Snapshot() JobSnapshot {
 		CurrentUpdatedAt:    j.CurrentUpdatedAt,
 		Sources:             append([]string(nil), j.Sources...),
 		Failures:            append([]JobFailure(nil), j.Failures...),
+		Restored:            j.restored,
 	}
 }
 
@@ -174,6 +258,10 @@ func (j *Job) addFileProgress(bytes int64, force bool) {
 		if j.CurrentTotalBytes > 0 && j.CurrentBytes > j.CurrentTotalBytes {
 			j.CurrentBytes = j.CurrentTotalBytes
 		}
+		j.DoneBytes += bytes
+		if j.TotalBytes > 0 && j.DoneBytes > j.TotalBytes {
+			j.DoneBytes = j.TotalBytes
+		}
 	}
 	j.CurrentUpdatedAt = now
 	if force || j.lastProgressNotify.IsZero() || now.Sub(j.lastProgressNotify) >= progressNotifyInterval {
@@ -200,6 +288,13 @@ func (j *Job) completeFileProgress() {
 	j.addFileProgress(0, true)
 }
 
+// finishSourceBytesLocked credits a finished top-level source with its
+// precomputed size, so renames and skips advance byte progress as well.
+func (j *Job) finishSourceBytesLocked(size int64) {
+	j.completedBytes += size
+	j.DoneBytes = j.completedBytes
+}
+
 func (j *Job) clearFileProgressLocked() {
 	j.CurrentFile = ""
 	j.CurrentBytes = 0
@@ -217,13 +312,21 @@ type JobSnapshot struct {
 	Sources             []string
 	DestDir             string
 	DeleteMode          DeleteMode
+	Archive             ArchiveOptions     // TypeArchive only
+	Checksum            ChecksumOptions    // TypeChecksum and TypeVerify only
+	SyncMethod          filecompare.Method // TypeSync only
 	TotalFiles          int
 	DoneFiles           int
+	TotalBytes          int64
+	DoneBytes           int64
 	CurrentSource       string
 	Message             string
 	Error               string
 	Failures            []JobFailure
 	FailureAcknowledged bool
+	HighPriority        bool
+	MoveRenames         int // TypeMove only
+	MoveCopies          int // TypeMove only
 	EnqueuedAt          time.Time
 	StartedAt           time.Time
 	CompletedAt         time.Time
@@ -232,11 +335,15 @@ type JobSnapshot struct {
 	CurrentTotalBytes   int64
 	CurrentStartedAt    time.Time
 	CurrentUpdatedAt    time.Time
+	Restored            bool // finished in a previous session
 }
 
 // JobFailure records a single failing path and error message.
 type JobFailure struct {
-	TopSource string // top-level source item being processed when failure occurred
-	Path      string // specific path that failed (may be a child inside a directory)
-	Error     string
+	TopSource string `json:"topSource,omitempty"` // top-level source item being processed when failure occurred
+	Path      string `json:"path,omitempty"`      // specific path that failed (may be a child inside a directory)
+	Error     string `json:"error"`
+	// Warning marks metadata a copy could not preserve; the item itself was
+	// transferred.
+	Warning bool `json:"warning,omitempty"`
 }
@@ -0,0 +1,52 @@
+package jobs
+
+import (
+	"path/filepath"
+	"strings"
+
+	"nmf/internal/fileinfo"
+)
+
+// jobVolumes returns the distinct volume keys a job reads from or writes to.
+// The scheduler uses them to limit how many jobs hit one volume at once.
+func jobVolumes(j *Job) []string {
+	paths := append([]string(nil), j.Sources...)
+	if j.Type != TypeDelete && j.DestDir != "" {
+		paths = append(paths, j.DestDir)
+	}
+	seen := make(map[string]bool, len(paths))
+	out := make([]string, 0, len(paths))
+	for _, p := range paths {
+		key := volumeKey(p)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, key)
+	}
+	return out
+}
+
+// volumeKey identifies the storage behind p without touching the network:
+// SMB paths map to their share, archive paths to the volume holding the
+// archive file, and local paths to their device. Unresolvable paths return "".
+func volumeKey(p string) string {
+	_, parsed, err := fileinfo.CanonicalDisplayPath(p)
+	if err != nil {
+		return ""
+	}
+	switch {
+	case parsed.Scheme == fileinfo.SchemeArchive:
+		return volumeKey(parsed.Archive)
+	case parsed.Scheme == fileinfo.SchemeSMB && parsed.Host != "" && parsed.Share != "":
+		return "smb://" + strings.ToLower(parsed.Host) + "/" + strings.ToLower(parsed.Share)
+	}
+	local := parsed.Native
+	if local == "" {
+		local = p
+	}
+	if abs, err := filepath.Abs(local); err == nil {
+		local = abs
+	}
+	return localVolumeKey(local)
+}
@@ -0,0 +1,134 @@
+package jobs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestVolumeKeyGroupsPathsOnTheSameStorage(t *testing.T) {
+	tmpDir := t.TempDir()
+	existing := volumeKey(tmpDir)
+	if existing == "" {
+		t.Fatalf("volume key for %s is empty", tmpDir)
+	}
+	if got := volumeKey(filepath.Join(tmpDir, "not", "created", "yet")); got != existing {
+		t.Fatalf("missing destination key = %q, want %q", got, existing)
+	}
+	if got := volumeKey("smb://Server/Share/dir/file.txt"); got != "smb://server/share" {
+		t.Fatalf("smb key = %q", got)
+	}
+
+	job := &Job{Type: TypeCopy, Sources: []string{filepath.Join(tmpDir, "a"), filepath.Join(tmpDir, "b")}, DestDir: tmpDir}
+	if got := jobVolumes(job); len(got) != 1 || got[0] != existing {
+		t.Fatalf("job volumes = %v, want [%s]", got, existing)
+	}
+}
+
+func TestSchedulerLimitsConcurrentJobsPerVolume(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := filepath.Join(tmpDir, "a.txt")
+	dest := filepath.Join(tmpDir, "dest")
+	if err := os.Mkdir(dest, 0755); err != nil {
+		t.Fatalf("mkdir dest: %v", err)
+	}
+	for _, p := range []string{src, filepath.Join(dest, "a.txt")} {
+		if err := os.WriteFile(p, []byte("a"), 0644); err != nil {
+			t.Fatalf("write %s: %v", p, err)
+		}
+	}
+
+	asked := make(chan struct{}, 1)
+	release := make(chan struct{})
+	blockingResolver := func(ctx context.Context, req ConflictRequest) ConflictResolution {
+		asked <- struct{}{}
+		<-release
+		return ConflictResolution{Action: ConflictSkip}
+	}
+	newJob := func(id int64, volume string, resolver ConflictResolver) *Job {
+		j := &Job{ID: id, Type: TypeCopy, Sources: []string{src}, DestDir: dest, Resolver: resolver, Status: StatusPending, volumes: []string{volume}}
+		j.ctx, j.cancel = contextWithCancel()
+		return j
+	}
+	blocked := newJob(1, "disk-a", blockingResolver)
+	sameDisk := newJob(2, "disk-a", nil)
+	otherDisk := newJob(3, "disk-b", nil)
+
+	m := &Manager{workers: 2, perVolumeLimit: 1}
+	m.mu.Lock()
+	m.queue = []*Job{blocked, sameDisk, otherDisk}
+	m.dispatchLocked()
+	m.mu.Unlock()
+
+	<-asked
+	waitForJobStatus(t, otherDisk, StatusCompleted)
+	if got := sameDisk.Snapshot().Status; got != StatusPending {
+		t.Fatalf("same-volume job status = %s while the volume is busy, want pending", got)
+	}
+
+	close(release)
+	waitForJobStatus(t, blocked, StatusCompleted)
+	waitForJobStatus(t, sameDisk, StatusCompleted)
+}
+
+func waitForJobStatus(t *testing.T, j *Job, want Status) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if j.Snapshot().Status == want {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("job %d status = %s, want %s", j.ID, j.Snapshot().Status, want)
+}
+
+func TestReorderAndPriorityChangeDispatchOrder(t *testing.T) {
+	m := &Manager{workers: 1}
+	m.closed = true // keep every job pending
+	for id := int64(1); id <= 4; id++ {
+		m.queue = append(m.queue, &Job{ID: id, Status: StatusPending})
+	}
+	var notified int32
+	m.Subscribe(func() { atomic.AddInt32(&notified, 1) })
+	order := func() []int64 {
+		var ids []int64
+		for _, s := range m.List() {
+			ids = append(ids, s.ID)
+		}
+		return ids
+	}
+
+	if !m.Reorder(3, -1) || !reflect.DeepEqual(order(), []int64{1, 3, 2, 4}) {
+		t.Fatalf("after moving 3 up: %v", order())
+	}
+	if !m.SetPriority(4, true) || !reflect.DeepEqual(order(), []int64{4, 1, 3, 2}) {
+		t.Fatalf("after prioritizing 4: %v", order())
+	}
+	if !m.SetPriority(2, true) || !reflect.DeepEqual(order(), []int64{4, 2, 1, 3}) {
+		t.Fatalf("after prioritizing 2: %v", order())
+	}
+	// Jobs do not cross the priority boundary.
+	if m.Reorder(1, -5) || m.Reorder(2, 3) {
+		t.Fatalf("moved across the priority boundary: %v", order())
+	}
+	if !m.Reorder(4, 1) || !reflect.DeepEqual(order(), []int64{2, 4, 1, 3}) {
+		t.Fatalf("after moving 4 down: %v", order())
+	}
+	if !m.SetPriority(2, false) || !reflect.DeepEqual(order(), []int64{4, 2, 1, 3}) {
+		t.Fatalf("after clearing 2: %v", order())
+	}
+	if m.SetPriority(2, false) || m.Reorder(99, 1) {
+		t.Fatal("no-op changes reported success")
+	}
+	if snap := m.List()[0]; !snap.HighPriority {
+		t.Fatalf("snapshot %+v is not marked high priority", snap)
+	}
+	if got := atomic.LoadInt32(&notified); got != 5 {
+		t.Fatalf("notifications = %d, want 5", got)
+	}
+}
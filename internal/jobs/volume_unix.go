@@ -0,0 +1,30 @@
+//go:build !windows
+// +build !windows
+
+package jobs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// localVolumeKey returns the device of p or of its nearest existing ancestor,
+// so a destination that does not exist yet still maps to its filesystem.
+func localVolumeKey(p string) string {
+	for {
+		fi, err := os.Stat(p)
+		if err == nil {
+			if st, ok := fi.Sys().(*syscall.Stat_t); ok {
+				return fmt.Sprintf("dev:%d", uint64(st.Dev))
+			}
+			return ""
+		}
+		parent := filepath.Dir(p)
+		if parent == p {
+			return ""
+		}
+		p = parent
+	}
+}
@@ -0,0 +1,14 @@
+//go:build windows
+// +build windows
+
+package jobs
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// localVolumeKey returns the drive letter or UNC share of p.
+func localVolumeKey(p string) string {
+	return strings.ToLower(filepath.VolumeName(p))
+}
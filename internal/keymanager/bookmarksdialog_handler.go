@@ -0,0 +1,70 @@
+package keymanager
+
+import "strconv"
+
+// BookmarksDialogInterface defines the interface needed by BookmarksDialogKeyHandler.
+type BookmarksDialogInterface interface {
+	MoveUp()
+	MoveDown()
+	MoveToTop()
+	MoveToBottom()
+
+	JumpToHotkey(n int)
+	AssignHotkey(n int) // 0 clears the selected bookmark's hotkey
+	AddCurrentDirectory()
+	RenameSelected()
+	RemoveSelected()
+	ToggleWatch()
+
+	AcceptSelection()
+	CancelDialog()
+}
+
+// BookmarksDialogKeyHandler handles keyboard events for the bookmarks dialog.
+// Digits jump straight to the bookmark holding that hotkey; Ctrl+digit moves
+// the hotkey to the selected bookmark.
+type BookmarksDialogKeyHandler struct {
+	*dialogKeyHandler
+}
+
+// NewBookmarksDialogKeyHandler creates a new bookmarks dialog key handler.
+func NewBookmarksDialogKeyHandler(d BookmarksDialogInterface, debugPrint func(format string, args ...interface{})) *BookmarksDialogKeyHandler {
+	bindings := []dialogBinding{
+		{"Up", d.MoveUp},
+		{"S-Up", d.MoveToTop},
+		{"Down", d.MoveDown},
+		{"S-Down", d.MoveToBottom},
+
+		{"Return", d.AcceptSelection},
+		{"Escape", d.CancelDialog},
+		{"Insert", d.AddCurrentDirectory},
+		{"F2", d.RenameSelected},
+		// Plain Delete only: Shift+Delete arrives as a folded Cut shortcut.
+		{"Delete", d.RemoveSelected},
+		{"C-D", d.RemoveSelected},
+		{"C-0", func() { d.AssignHotkey(0) }},
+	}
+	for n := 1; n <= 9; n++ {
+		n := n
+		bindings = append(bindings, dialogBinding{"C-" + strconv.Itoa(n), func() { d.AssignHotkey(n) }})
+	}
+	base := newDialogKeyHandler("BookmarksDialog", debugPrint, bindings).withRune(func(r rune, modifiers ModifierState) bool {
+		if modifiers.AltPressed || modifiers.CtrlPressed {
+			return true
+		}
+		switch {
+		case r >= '1' && r <= '9':
+			d.JumpToHotkey(int(r - '0'))
+		case r == 'a' || r == 'A':
+			d.AddCurrentDirectory()
+		case r == 'r' || r == 'R':
+			d.RenameSelected()
+		case r == 'w' || r == 'W':
+			d.ToggleWatch()
+		default:
+			return false
+		}
+		return true
+	})
+	return &BookmarksDialogKeyHandler{dialogKeyHandler: base}
+}
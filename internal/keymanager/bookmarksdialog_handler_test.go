@@ -0,0 +1,73 @@
+package keymanager
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+)
+
+type fakeBookmarksDialog struct {
+	jumped   []int
+	assigned []int
+	added    int
+	renamed  int
+	removed  int
+	watched  int
+	accepted int
+	canceled int
+}
+
+func (f *fakeBookmarksDialog) MoveUp()              {}
+func (f *fakeBookmarksDialog) MoveDown()            {}
+func (f *fakeBookmarksDialog) MoveToTop()           {}
+func (f *fakeBookmarksDialog) MoveToBottom()        {}
+func (f *fakeBookmarksDialog) JumpToHotkey(n int)   { f.jumped = append(f.jumped, n) }
+func (f *fakeBookmarksDialog) AssignHotkey(n int)   { f.assigned = append(f.assigned, n) }
+func (f *fakeBookmarksDialog) AddCurrentDirectory() { f.added++ }
+func (f *fakeBookmarksDialog) RenameSelected()      { f.renamed++ }
+func (f *fakeBookmarksDialog) RemoveSelected()      { f.removed++ }
+func (f *fakeBookmarksDialog) ToggleWatch()         { f.watched++ }
+func (f *fakeBookmarksDialog) AcceptSelection()     { f.accepted++ }
+func (f *fakeBookmarksDialog) CancelDialog()        { f.canceled++ }
+
+func TestBookmarksDialogHandlerDigitsJumpAndCtrlDigitsAssign(t *testing.T) {
+	dialog := &fakeBookmarksDialog{}
+	handler := NewBookmarksDialogKeyHandler(dialog, func(string, ...interface{}) {})
+
+	if !handler.OnTypedRune('3', ModifierState{}) {
+		t.Fatal("digit rune should be handled")
+	}
+	if !handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.Key5}, ModifierState{CtrlPressed: true}) {
+		t.Fatal("Ctrl+5 should be handled")
+	}
+	if !handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.Key0}, ModifierState{CtrlPressed: true}) {
+		t.Fatal("Ctrl+0 should be handled")
+	}
+	if len(dialog.jumped) != 1 || dialog.jumped[0] != 3 {
+		t.Fatalf("jumped = %v, want [3]", dialog.jumped)
+	}
+	if len(dialog.assigned) != 2 || dialog.assigned[0] != 5 || dialog.assigned[1] != 0 {
+		t.Fatalf("assigned = %v, want [5 0]", dialog.assigned)
+	}
+}
+
+func TestBookmarksDialogHandlerEditingKeys(t *testing.T) {
+	dialog := &fakeBookmarksDialog{}
+	handler := NewBookmarksDialogKeyHandler(dialog, func(string, ...interface{}) {})
+
+	handler.OnTypedRune('a', ModifierState{})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyInsert}, ModifierState{})
+	handler.OnTypedRune('r', ModifierState{})
+	handler.OnTypedRune('w', ModifierState{})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyF2}, ModifierState{})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyDelete}, ModifierState{})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyReturn}, ModifierState{})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyEscape}, ModifierState{})
+
+	if dialog.added != 2 || dialog.renamed != 2 || dialog.removed != 1 || dialog.accepted != 1 || dialog.canceled != 1 || dialog.watched != 1 {
+		t.Fatalf("dialog calls = %+v", dialog)
+	}
+	if handler.OnTypedRune('x', ModifierState{}) {
+		t.Fatal("unbound rune should not be handled")
+	}
+}
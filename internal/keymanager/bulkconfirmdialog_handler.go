@@ -0,0 +1,22 @@
+package keymanager
+
+// BulkConfirmDialogInterface defines keyboard actions for the second
+// confirmation of a large move or delete.
+type BulkConfirmDialogInterface interface {
+	ConfirmBulk()
+	CancelBulk()
+}
+
+// BulkConfirmDialogKeyHandler handles keyboard events for the bulk
+// confirmation dialog.
+type BulkConfirmDialogKeyHandler struct {
+	*dialogKeyHandler
+}
+
+func NewBulkConfirmDialogKeyHandler(d BulkConfirmDialogInterface) *BulkConfirmDialogKeyHandler {
+	base := newDialogKeyHandler("BulkConfirmDialog", nil, []dialogBinding{
+		{"Return", d.ConfirmBulk},
+		{"Escape", d.CancelBulk},
+	})
+	return &BulkConfirmDialogKeyHandler{dialogKeyHandler: base}
+}
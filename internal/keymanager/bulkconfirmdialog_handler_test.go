@@ -0,0 +1,33 @@
+package keymanager
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+)
+
+type fakeBulkConfirmDialog struct {
+	confirmed int
+	cancelled int
+}
+
+func (f *fakeBulkConfirmDialog) ConfirmBulk() { f.confirmed++ }
+func (f *fakeBulkConfirmDialog) CancelBulk()  { f.cancelled++ }
+
+func TestBulkConfirmDialogHandlerConfirmAndCancel(t *testing.T) {
+	dialog := &fakeBulkConfirmDialog{}
+	handler := NewBulkConfirmDialogKeyHandler(dialog)
+
+	if handler.GetName() != "BulkConfirmDialog" {
+		t.Fatalf("GetName() = %q, want %q", handler.GetName(), "BulkConfirmDialog")
+	}
+	if !handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyReturn}, ModifierState{}) {
+		t.Fatal("Return should be handled")
+	}
+	if !handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyEscape}, ModifierState{}) {
+		t.Fatal("Escape should be handled")
+	}
+	if dialog.confirmed != 1 || dialog.cancelled != 1 {
+		t.Fatalf("confirmed=%d cancelled=%d, want 1 each", dialog.confirmed, dialog.cancelled)
+	}
+}
@@ -5,9 +5,15 @@ import (
 )
 
 // BusyKeyHandler swallows all key input while a busy operation is active.
-// Escape can trigger an optional cancel callback.
+// Escape can trigger an optional cancel callback. The first list navigation
+// key is remembered so the caller can replay it once the operation is done.
 type BusyKeyHandler struct {
-	onCancel func()
+	onCancel    func()
+	onSwallowed func()
+	swallowed   bool
+
+	queued          *fyne.KeyEvent
+	queuedModifiers ModifierState
 }
 
 func NewBusyKeyHandler(onCancel ...func()) *BusyKeyHandler {
@@ -18,17 +24,62 @@ func NewBusyKeyHandler(onCancel ...func()) *BusyKeyHandler {
 	return &BusyKeyHandler{onCancel: cancel}
 }
 
+// SetOnSwallowed registers f to run once, when the first key is swallowed.
+func (b *BusyKeyHandler) SetOnSwallowed(f func()) {
+	b.onSwallowed = f
+}
+
+// QueuedKey returns the first navigation key pressed while busy. Escape
+// drops it, since the user asked to abandon the operation.
+func (b *BusyKeyHandler) QueuedKey() (*fyne.KeyEvent, ModifierState, bool) {
+	if b.queued == nil {
+		return nil, ModifierState{}, false
+	}
+	return b.queued, b.queuedModifiers, true
+}
+
 func (b *BusyKeyHandler) GetName() string { return "BusyGuard" }
 
-func (b *BusyKeyHandler) OnKeyActivated(ev *fyne.KeyEvent, _ ModifierState) bool {
-	b.cancelIfEscape(ev)
+func (b *BusyKeyHandler) OnKeyActivated(ev *fyne.KeyEvent, modifiers ModifierState) bool {
+	b.noteSwallowed()
+	if ev == nil {
+		return true
+	}
+	if ev.Name == fyne.KeyEscape {
+		b.queued = nil
+		if b.onCancel != nil {
+			b.onCancel()
+		}
+		return true
+	}
+	if b.queued == nil && isBusyNavigationKey(ev.Name) {
+		b.queued = &fyne.KeyEvent{Name: ev.Name}
+		b.queuedModifiers = modifiers
+	}
+	return true
+}
+
+func (b *BusyKeyHandler) OnTypedRune(_ rune, _ ModifierState) bool {
+	b.noteSwallowed()
 	return true
 }
 
-func (b *BusyKeyHandler) OnTypedRune(_ rune, _ ModifierState) bool { return true }
+func (b *BusyKeyHandler) noteSwallowed() {
+	if b.swallowed {
+		return
+	}
+	b.swallowed = true
+	if b.onSwallowed != nil {
+		b.onSwallowed()
+	}
+}
 
-func (b *BusyKeyHandler) cancelIfEscape(ev *fyne.KeyEvent) {
-	if ev != nil && ev.Name == fyne.KeyEscape && b.onCancel != nil {
-		b.onCancel()
+// isBusyNavigationKey reports the keys worth replaying after a load: those
+// that only move the cursor in the list.
+func isBusyNavigationKey(name fyne.KeyName) bool {
+	switch name {
+	case fyne.KeyUp, fyne.KeyDown, fyne.KeyPageUp, fyne.KeyPageDown, fyne.KeyHome, fyne.KeyEnd:
+		return true
 	}
+	return false
 }
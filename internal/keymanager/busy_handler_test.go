@@ -32,3 +32,32 @@ func TestBusyKeyHandlerSwallowsNonEscapeInput(t *testing.T) {
 		t.Fatalf("cancelled = %d, want 0", cancelled)
 	}
 }
+
+func TestBusyKeyHandlerQueuesFirstNavigationKey(t *testing.T) {
+	handler := NewBusyKeyHandler()
+	swallowed := 0
+	handler.SetOnSwallowed(func() { swallowed++ })
+
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyA}, ModifierState{})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyDown}, ModifierState{ShiftPressed: true})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyUp}, ModifierState{})
+	handler.OnTypedRune('j', ModifierState{})
+
+	if swallowed != 1 {
+		t.Fatalf("onSwallowed calls = %d, want 1", swallowed)
+	}
+	ev, modifiers, ok := handler.QueuedKey()
+	if !ok || ev.Name != fyne.KeyDown || !modifiers.ShiftPressed {
+		t.Fatalf("QueuedKey = %v, %+v, %t; want Shift+Down", ev, modifiers, ok)
+	}
+}
+
+func TestBusyKeyHandlerEscapeDropsQueuedKey(t *testing.T) {
+	handler := NewBusyKeyHandler()
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyDown}, ModifierState{})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyEscape}, ModifierState{})
+
+	if _, _, ok := handler.QueuedKey(); ok {
+		t.Fatal("Escape should drop the queued key")
+	}
+}
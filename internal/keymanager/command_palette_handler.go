@@ -0,0 +1,48 @@
+package keymanager
+
+import "unicode"
+
+// CommandPaletteInterface defines the interface needed by
+// CommandPaletteKeyHandler.
+type CommandPaletteInterface interface {
+	HideCommandPalette()
+	AcceptCommandPalette()
+	AddCommandPaletteCharacter(char rune)
+	RemoveLastCommandPaletteCharacter()
+	MoveCommandPaletteSelection(delta int)
+}
+
+// CommandPaletteKeyHandler handles keyboard events while the command palette
+// overlay is open. Printable runes extend the query; it owns input until the
+// palette closes.
+type CommandPaletteKeyHandler struct {
+	*dialogKeyHandler
+}
+
+// NewCommandPaletteKeyHandler creates a new command palette key handler.
+func NewCommandPaletteKeyHandler(cp CommandPaletteInterface, debugPrint func(format string, args ...interface{})) *CommandPaletteKeyHandler {
+	h := &CommandPaletteKeyHandler{}
+	h.dialogKeyHandler = newDialogKeyHandler("CommandPalette", debugPrint, []dialogBinding{
+		{"Escape", cp.HideCommandPalette},
+		{"C-G", cp.HideCommandPalette},
+		{"Return", cp.AcceptCommandPalette},
+		{"Backspace", cp.RemoveLastCommandPaletteCharacter},
+		{"C-H", cp.RemoveLastCommandPaletteCharacter},
+		{"Up", func() { cp.MoveCommandPaletteSelection(-1) }},
+		{"C-P", func() { cp.MoveCommandPaletteSelection(-1) }},
+		{"Down", func() { cp.MoveCommandPaletteSelection(1) }},
+		{"C-N", func() { cp.MoveCommandPaletteSelection(1) }},
+		{"S-Up", func() { cp.MoveCommandPaletteSelection(-5) }},
+		{"S-Down", func() { cp.MoveCommandPaletteSelection(5) }},
+	}).withRune(func(r rune, modifiers ModifierState) bool {
+		if modifiers.CtrlPressed || modifiers.AltPressed {
+			return false
+		}
+		if unicode.IsPrint(r) && !unicode.IsControl(r) {
+			cp.AddCommandPaletteCharacter(r)
+			return true
+		}
+		return false
+	})
+	return h
+}
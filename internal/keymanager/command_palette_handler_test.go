@@ -0,0 +1,60 @@
+package keymanager
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+)
+
+type fakeCommandPalette struct {
+	hidden   int
+	accepted int
+	query    string
+	removed  int
+	moves    []int
+}
+
+func (f *fakeCommandPalette) HideCommandPalette()                { f.hidden++ }
+func (f *fakeCommandPalette) AcceptCommandPalette()              { f.accepted++ }
+func (f *fakeCommandPalette) AddCommandPaletteCharacter(r rune)  { f.query += string(r) }
+func (f *fakeCommandPalette) RemoveLastCommandPaletteCharacter() { f.removed++ }
+func (f *fakeCommandPalette) MoveCommandPaletteSelection(d int)  { f.moves = append(f.moves, d) }
+
+func TestCommandPaletteHandlerTypingAndNavigation(t *testing.T) {
+	palette := &fakeCommandPalette{}
+	handler := NewCommandPaletteKeyHandler(palette, func(string, ...interface{}) {})
+
+	for _, r := range "tab" {
+		if !handler.OnTypedRune(r, ModifierState{}) {
+			t.Fatalf("rune %q should be handled", r)
+		}
+	}
+	if handler.OnTypedRune('x', ModifierState{CtrlPressed: true}) {
+		t.Fatal("Ctrl rune should not extend the query")
+	}
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyBackspace}, ModifierState{})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyDown}, ModifierState{})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyP}, ModifierState{CtrlPressed: true})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyDown}, ModifierState{ShiftPressed: true})
+
+	if palette.query != "tab" || palette.removed != 1 {
+		t.Fatalf("query = %q removed = %d, want tab, 1", palette.query, palette.removed)
+	}
+	if len(palette.moves) != 3 || palette.moves[0] != 1 || palette.moves[1] != -1 || palette.moves[2] != 5 {
+		t.Fatalf("moves = %v, want [1 -1 5]", palette.moves)
+	}
+}
+
+func TestCommandPaletteHandlerAcceptAndCancel(t *testing.T) {
+	palette := &fakeCommandPalette{}
+	handler := NewCommandPaletteKeyHandler(palette, func(string, ...interface{}) {})
+
+	if handler.GetName() != "CommandPalette" {
+		t.Fatalf("GetName() = %q, want CommandPalette", handler.GetName())
+	}
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyReturn}, ModifierState{})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyEscape}, ModifierState{})
+	if palette.accepted != 1 || palette.hidden != 1 {
+		t.Fatalf("accepted = %d hidden = %d, want 1, 1", palette.accepted, palette.hidden)
+	}
+}
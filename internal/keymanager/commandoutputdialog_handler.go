@@ -0,0 +1,50 @@
+package keymanager
+
+// CommandOutputDialogInterface defines the interface needed by CommandOutputDialogKeyHandler.
+type CommandOutputDialogInterface interface {
+	ScrollUp()
+	ScrollDown()
+	PageUp()
+	PageDown()
+	ScrollToTop()
+	ScrollToBottom()
+
+	StopCommand() // no-op once the command has exited
+	CloseDialog() // also stops a command that is still running
+}
+
+// CommandOutputDialogKeyHandler handles keyboard events for the output of a
+// shell command. Ctrl+C stops the command and keeps its output on screen.
+type CommandOutputDialogKeyHandler struct {
+	*dialogKeyHandler
+}
+
+// NewCommandOutputDialogKeyHandler creates a new command output dialog key handler.
+func NewCommandOutputDialogKeyHandler(d CommandOutputDialogInterface, debugPrint func(format string, args ...interface{})) *CommandOutputDialogKeyHandler {
+	base := newDialogKeyHandler("CommandOutputDialog", debugPrint, []dialogBinding{
+		{"Up", d.ScrollUp},
+		{"Down", d.ScrollDown},
+		{"PageUp", d.PageUp},
+		{"S-Up", d.PageUp},
+		{"PageDown", d.PageDown},
+		{"S-Down", d.PageDown},
+		{"Home", d.ScrollToTop},
+		{"End", d.ScrollToBottom},
+
+		{"C-C", d.StopCommand},
+		{"Return", d.CloseDialog},
+		{"Escape", d.CloseDialog},
+	}).withRune(func(r rune, modifiers ModifierState) bool {
+		if modifiers.AltPressed || modifiers.CtrlPressed {
+			return true
+		}
+		switch r {
+		case 'q', 'Q':
+			d.CloseDialog()
+		default:
+			return false
+		}
+		return true
+	})
+	return &CommandOutputDialogKeyHandler{dialogKeyHandler: base}
+}
@@ -0,0 +1,52 @@
+package keymanager
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+)
+
+type fakeCommandOutputDialog struct {
+	up       int
+	down     int
+	pageUp   int
+	pageDown int
+	top      int
+	bottom   int
+	stopped  int
+	closed   int
+}
+
+func (f *fakeCommandOutputDialog) ScrollUp()       { f.up++ }
+func (f *fakeCommandOutputDialog) ScrollDown()     { f.down++ }
+func (f *fakeCommandOutputDialog) PageUp()         { f.pageUp++ }
+func (f *fakeCommandOutputDialog) PageDown()       { f.pageDown++ }
+func (f *fakeCommandOutputDialog) ScrollToTop()    { f.top++ }
+func (f *fakeCommandOutputDialog) ScrollToBottom() { f.bottom++ }
+func (f *fakeCommandOutputDialog) StopCommand()    { f.stopped++ }
+func (f *fakeCommandOutputDialog) CloseDialog()    { f.closed++ }
+
+func TestCommandOutputDialogHandlerKeys(t *testing.T) {
+	dialog := &fakeCommandOutputDialog{}
+	handler := NewCommandOutputDialogKeyHandler(dialog, func(string, ...interface{}) {})
+
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyUp}, ModifierState{})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyDown}, ModifierState{})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyPageUp}, ModifierState{})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyUp}, ModifierState{ShiftPressed: true})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyPageDown}, ModifierState{})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyHome}, ModifierState{})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyEnd}, ModifierState{})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyC}, ModifierState{CtrlPressed: true})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyReturn}, ModifierState{})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyEscape}, ModifierState{})
+	handler.OnTypedRune('q', ModifierState{})
+
+	want := fakeCommandOutputDialog{up: 1, down: 1, pageUp: 2, pageDown: 1, top: 1, bottom: 1, stopped: 1, closed: 3}
+	if *dialog != want {
+		t.Fatalf("dialog calls = %+v, want %+v", *dialog, want)
+	}
+	if handler.OnTypedRune('x', ModifierState{}) {
+		t.Fatal("unbound rune should not be handled")
+	}
+}
@@ -30,6 +30,8 @@ type CompareDialogInterface interface {
 	SelectSizeEqual()
 	SelectSizeTimeEqual()
 	SelectSizeContentEqual()
+	SelectSyncMirror()
+	SelectSyncTwoWay()
 }
 
 // CompareDialogKeyHandler handles keyboard events for the compare dialog.
@@ -46,6 +48,8 @@ func NewCompareDialogKeyHandler(d CompareDialogInterface, debugPrint func(format
 		{"A-S", d.SelectSizeEqual},
 		{"A-T", d.SelectSizeTimeEqual},
 		{"A-C", d.SelectSizeContentEqual},
+		{"A-R", d.SelectSyncMirror},
+		{"A-W", d.SelectSyncTwoWay},
 
 		{"C-H", d.BackspaceSearch},
 
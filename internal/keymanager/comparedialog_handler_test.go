@@ -13,6 +13,8 @@ type fakeCompareDialog struct {
 	size           int
 	sizeTime       int
 	sizeContent    int
+	syncMirror     int
+	syncTwoWay     int
 }
 
 func (f *fakeCompareDialog) MoveUp()                   {}
@@ -37,6 +39,8 @@ func (f *fakeCompareDialog) SelectNewer()              { f.newer++ }
 func (f *fakeCompareDialog) SelectSizeEqual()          { f.size++ }
 func (f *fakeCompareDialog) SelectSizeTimeEqual()      { f.sizeTime++ }
 func (f *fakeCompareDialog) SelectSizeContentEqual()   { f.sizeContent++ }
+func (f *fakeCompareDialog) SelectSyncMirror()         { f.syncMirror++ }
+func (f *fakeCompareDialog) SelectSyncTwoWay()         { f.syncTwoWay++ }
 
 func TestCompareDialogAltShortcutsSelectMethods(t *testing.T) {
 	dialog := &fakeCompareDialog{}
@@ -54,6 +58,8 @@ func TestCompareDialogAltShortcutsSelectMethods(t *testing.T) {
 		{name: "size", key: fyne.KeyS, want: func() int { return dialog.size }},
 		{name: "size time", key: fyne.KeyT, want: func() int { return dialog.sizeTime }},
 		{name: "size content", key: fyne.KeyC, want: func() int { return dialog.sizeContent }},
+		{name: "sync mirror", key: fyne.KeyR, want: func() int { return dialog.syncMirror }},
+		{name: "sync two-way", key: fyne.KeyW, want: func() int { return dialog.syncTwoWay }},
 	}
 	for _, tt := range tests {
 		before := tt.want()
@@ -9,6 +9,8 @@ type ConflictDialogInterface interface {
 	SelectAutoName()
 	SelectRename()
 	SelectSkip()
+	SelectMerge()
+	SelectResume()
 }
 
 // ConflictDialogKeyHandler handles commit/cancel keys while resolving a copy/move conflict.
@@ -24,6 +26,8 @@ func NewConflictDialogKeyHandler(d ConflictDialogInterface) *ConflictDialogKeyHa
 		{"A-A", d.SelectAutoName},
 		{"A-R", d.SelectRename},
 		{"A-S", d.SelectSkip},
+		{"A-M", d.SelectMerge},
+		{"A-U", d.SelectResume},
 
 		{"Return", d.Continue},
 		{"Escape", d.CancelJob},
@@ -12,6 +12,8 @@ type fakeConflictDialog struct {
 	autoName         int
 	rename           int
 	skip             int
+	merge            int
+	resume           int
 }
 
 func (f *fakeConflictDialog) Continue()               {}
@@ -21,6 +23,8 @@ func (f *fakeConflictDialog) SelectOverwrite()        { f.overwrite++ }
 func (f *fakeConflictDialog) SelectAutoName()         { f.autoName++ }
 func (f *fakeConflictDialog) SelectRename()           { f.rename++ }
 func (f *fakeConflictDialog) SelectSkip()             { f.skip++ }
+func (f *fakeConflictDialog) SelectMerge()            { f.merge++ }
+func (f *fakeConflictDialog) SelectResume()           { f.resume++ }
 
 func TestConflictDialogAltShortcutsSelectChoices(t *testing.T) {
 	dialog := &fakeConflictDialog{}
@@ -37,6 +41,8 @@ func TestConflictDialogAltShortcutsSelectChoices(t *testing.T) {
 		{name: "auto name", key: fyne.KeyA, want: func() int { return dialog.autoName }},
 		{name: "rename", key: fyne.KeyR, want: func() int { return dialog.rename }},
 		{name: "skip", key: fyne.KeyS, want: func() int { return dialog.skip }},
+		{name: "merge", key: fyne.KeyM, want: func() int { return dialog.merge }},
+		{name: "resume", key: fyne.KeyU, want: func() int { return dialog.resume }},
 	}
 	for _, tt := range tests {
 		before := tt.want()
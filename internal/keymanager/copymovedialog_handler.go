@@ -29,6 +29,12 @@ type CopyMoveDialogInterface interface {
 	AcceptDirectPath() // Ctrl+Enter: use search text as destination directly
 	OpenDestination()
 	CancelDialog()
+
+	// Options
+	ToggleOrganizeByDate()
+	CycleOperation()
+	CycleLayout()
+	CycleRelativeBase()
 }
 
 // CopyMoveDialogKeyHandler handles keyboard events for the copy/move dialog
@@ -41,6 +47,10 @@ func NewCopyMoveDialogKeyHandler(d CopyMoveDialogInterface, debugPrint func(form
 	base := newDialogKeyHandler("CopyMoveDialog", debugPrint, []dialogBinding{
 		{"C-H", d.BackspaceSearch},
 		{"C-N", d.OpenDestination},
+		{"C-D", d.ToggleOrganizeByDate},
+		{"C-O", d.CycleOperation},
+		{"C-L", d.CycleLayout},
+		{"C-B", d.CycleRelativeBase},
 
 		{"Up", d.MoveUp},
 		{"S-Up", d.MoveToTop},
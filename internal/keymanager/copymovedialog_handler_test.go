@@ -57,3 +57,29 @@ func TestCopyMoveDialogHandlerMoveKeys(t *testing.T) {
 		}
 	}
 }
+
+func TestCopyMoveDialogHandlerCtrlDTogglesOrganizeByDate(t *testing.T) {
+	dialog := &fakeFilterSearchDialog{}
+	handler := NewCopyMoveDialogKeyHandler(dialog, func(string, ...interface{}) {})
+
+	if !handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyD}, ModifierState{CtrlPressed: true}) {
+		t.Fatal("Ctrl+D should be handled")
+	}
+	if dialog.organize != 1 || dialog.search != "" {
+		t.Fatalf("organize toggles = %d search = %q, want one toggle and no search text", dialog.organize, dialog.search)
+	}
+}
+
+func TestCopyMoveDialogHandlerLayoutShortcuts(t *testing.T) {
+	dialog := &fakeFilterSearchDialog{}
+	handler := NewCopyMoveDialogKeyHandler(dialog, func(string, ...interface{}) {})
+
+	for _, key := range []fyne.KeyName{fyne.KeyL, fyne.KeyB, fyne.KeyO} {
+		if !handler.OnKeyActivated(&fyne.KeyEvent{Name: key}, ModifierState{CtrlPressed: true}) {
+			t.Fatalf("Ctrl+%s should be handled", key)
+		}
+	}
+	if dialog.layout != 1 || dialog.base != 1 || dialog.operation != 1 {
+		t.Fatalf("layout=%d base=%d operation=%d, want one cycle each", dialog.layout, dialog.base, dialog.operation)
+	}
+}
@@ -0,0 +1,58 @@
+package keymanager
+
+// CredentialsDialogInterface defines the interface needed by CredentialsDialogKeyHandler.
+type CredentialsDialogInterface interface {
+	MoveUp()
+	MoveDown()
+	MoveToTop()
+	MoveToBottom()
+
+	EditSelected()
+	RemoveSelected()
+	ForgetAll()        // asks to confirm forgetting every entry
+	ConfirmForgetAll() // no-op unless ForgetAll is asking
+	CancelForgetAll()  // no-op unless ForgetAll is asking
+
+	CancelDialog()
+}
+
+// CredentialsDialogKeyHandler handles keyboard events for the credentials
+// manager. X asks to forget every entry; Y or N answers.
+type CredentialsDialogKeyHandler struct {
+	*dialogKeyHandler
+}
+
+// NewCredentialsDialogKeyHandler creates a new credentials dialog key handler.
+func NewCredentialsDialogKeyHandler(d CredentialsDialogInterface, debugPrint func(format string, args ...interface{})) *CredentialsDialogKeyHandler {
+	base := newDialogKeyHandler("CredentialsDialog", debugPrint, []dialogBinding{
+		{"Up", d.MoveUp},
+		{"S-Up", d.MoveToTop},
+		{"Down", d.MoveDown},
+		{"S-Down", d.MoveToBottom},
+
+		{"Return", d.EditSelected},
+		{"F2", d.EditSelected},
+		{"Escape", d.CancelDialog},
+		// Plain Delete only: Shift+Delete arrives as a folded Cut shortcut.
+		{"Delete", d.RemoveSelected},
+		{"C-D", d.RemoveSelected},
+	}).withRune(func(r rune, modifiers ModifierState) bool {
+		if modifiers.AltPressed || modifiers.CtrlPressed {
+			return true
+		}
+		switch r {
+		case 'e', 'E':
+			d.EditSelected()
+		case 'x', 'X':
+			d.ForgetAll()
+		case 'y', 'Y':
+			d.ConfirmForgetAll()
+		case 'n', 'N':
+			d.CancelForgetAll()
+		default:
+			return false
+		}
+		return true
+	})
+	return &CredentialsDialogKeyHandler{dialogKeyHandler: base}
+}
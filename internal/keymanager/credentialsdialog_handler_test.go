@@ -0,0 +1,52 @@
+package keymanager
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+)
+
+type fakeCredentialsDialog struct {
+	edited    int
+	removed   int
+	forgetAll int
+	confirmed int
+	declined  int
+	canceled  int
+}
+
+func (f *fakeCredentialsDialog) MoveUp()           {}
+func (f *fakeCredentialsDialog) MoveDown()         {}
+func (f *fakeCredentialsDialog) MoveToTop()        {}
+func (f *fakeCredentialsDialog) MoveToBottom()     {}
+func (f *fakeCredentialsDialog) EditSelected()     { f.edited++ }
+func (f *fakeCredentialsDialog) RemoveSelected()   { f.removed++ }
+func (f *fakeCredentialsDialog) ForgetAll()        { f.forgetAll++ }
+func (f *fakeCredentialsDialog) ConfirmForgetAll() { f.confirmed++ }
+func (f *fakeCredentialsDialog) CancelForgetAll()  { f.declined++ }
+func (f *fakeCredentialsDialog) CancelDialog()     { f.canceled++ }
+
+func TestCredentialsDialogHandlerKeys(t *testing.T) {
+	dialog := &fakeCredentialsDialog{}
+	handler := NewCredentialsDialogKeyHandler(dialog, func(string, ...interface{}) {})
+
+	handler.OnTypedRune('e', ModifierState{})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyF2}, ModifierState{})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyReturn}, ModifierState{})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyDelete}, ModifierState{})
+	handler.OnTypedRune('X', ModifierState{})
+	handler.OnTypedRune('y', ModifierState{})
+	handler.OnTypedRune('n', ModifierState{})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyEscape}, ModifierState{})
+
+	want := fakeCredentialsDialog{edited: 3, removed: 1, forgetAll: 1, confirmed: 1, declined: 1, canceled: 1}
+	if *dialog != want {
+		t.Fatalf("dialog calls = %+v, want %+v", *dialog, want)
+	}
+	if handler.OnTypedRune('q', ModifierState{}) {
+		t.Fatal("unbound rune should not be handled")
+	}
+	if !handler.OnTypedRune('x', ModifierState{CtrlPressed: true}) || dialog.forgetAll != 1 {
+		t.Fatal("Ctrl+X should be consumed without forgetting")
+	}
+}
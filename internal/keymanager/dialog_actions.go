@@ -12,6 +12,8 @@ type DialogActions struct {
 	ShowDirectoryTreeDialog     func()
 	ShowNavigationHistoryDialog func()
 	ShowDirectoryJumpDialog     func()
+	ShowBookmarksDialog         func()
+	ShowPlacesDialog            func()
 
 	ShowFilterDialog            func()
 	ShowIncrementalSearchDialog func()
@@ -19,18 +21,37 @@ type DialogActions struct {
 	ShowJobsDialog              func()
 	ShowPathEditDialog          func()
 	ShowCreateDirectoryDialog   func()
+	ShowCreateFileDialog        func()
+	ShowSelectByPatternDialog   func()
+	ShowDirectoryNoteDialog     func()
 	ShowClipboardTextFileDialog func()
 	ShowMessageDialog           func(title string, message string)
 
 	ShowCopyDialog           func()
 	ShowMoveDialog           func()
 	ShowExtractArchiveDialog func()
+	ShowCompressDialog       func()
 	ShowCompareDialog        func()
+	ShowChecksumMenu         func()
 	ShowRenameDialog         func()
+	ShowBatchRenameDialog    func()
 	ShowDeleteDialog         func(permanent bool)
+	ShowSecureDeleteDialog   func()
 	ShowExplorerContextMenu  func()
 	ShowExternalCommandMenu  func()
+	ShowRunCommandDialog     func()
+	ShowSendToMenu           func()
+	ShowOpenWithMenu         func()
+	ShowVolumesMenu          func()
+	ShowConnectionMenu       func()
+	ShowCredentialsDialog    func()
 	ShowFileViewer           func()
+	ShowQuickLook            func()
 	ShowMaintenanceDialog    func()
+	ShowPropertiesDialog     func()
+	ShowCommandPalette       func()
+	ShowAuditLog             func()
+	ShowExportAuditLogDialog func()
+	ShowExportListingDialog  func()
 	ShowCommandMenu          func(title string, items []CommandMenuItem)
 }
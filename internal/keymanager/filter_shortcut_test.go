@@ -17,6 +17,11 @@ type fakeFilterSearchDialog struct {
 	direct    int
 	deleted   int
 	unpinned  int
+	organize  int
+	operation int
+	layout    int
+	base      int
+	saved     int
 }
 
 func (f *fakeFilterSearchDialog) MoveUp()                       {}
@@ -33,10 +38,15 @@ func (f *fakeFilterSearchDialog) SelectCurrentItem()            {}
 func (f *fakeFilterSearchDialog) AcceptSelection()              {}
 func (f *fakeFilterSearchDialog) AcceptDirectInput()            { f.direct++ }
 func (f *fakeFilterSearchDialog) DeleteSelectedEntry()          { f.deleted++ }
+func (f *fakeFilterSearchDialog) SavePreset()                   { f.saved++ }
 func (f *fakeFilterSearchDialog) UnpinSelectedPath()            { f.unpinned++ }
 func (f *fakeFilterSearchDialog) AcceptDirectPathNavigation()   { f.direct++ }
 func (f *fakeFilterSearchDialog) AcceptDirectPath()             {}
 func (f *fakeFilterSearchDialog) OpenDestination()              { f.open++ }
+func (f *fakeFilterSearchDialog) ToggleOrganizeByDate()         { f.organize++ }
+func (f *fakeFilterSearchDialog) CycleOperation()               { f.operation++ }
+func (f *fakeFilterSearchDialog) CycleLayout()                  { f.layout++ }
+func (f *fakeFilterSearchDialog) CycleRelativeBase()            { f.base++ }
 func (f *fakeFilterSearchDialog) CancelDialog()                 {}
 func (f *fakeFilterSearchDialog) CopySelectedPathToSearch()     {}
 func (f *fakeFilterSearchDialog) CopySelectedShortcutToSearch() {}
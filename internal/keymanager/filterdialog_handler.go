@@ -19,6 +19,7 @@ type FilterDialogInterface interface {
 	GetSearchText() string
 	AcceptDirectInput()
 	DeleteSelectedEntry()
+	SavePreset()
 
 	// Focus management (deprecated in focusless design)
 	IsSearchFocused() bool
@@ -45,6 +46,7 @@ func NewFilterDialogKeyHandler(fd FilterDialogInterface, debugPrint func(format
 		{"C-F", func() {}},
 		{"C-H", fd.BackspaceSearch},
 		{"C-D", fd.DeleteSelectedEntry},
+		{"C-S", fd.SavePreset},
 
 		{"Up", fd.MoveUp},
 		{"S-Up", fd.MoveToTop},
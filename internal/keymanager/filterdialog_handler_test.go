@@ -51,3 +51,18 @@ func TestFilterDialogHandlerMoveKeys(t *testing.T) {
 		t.Fatal("Shift+Down should be handled")
 	}
 }
+
+func TestFilterDialogHandlerCtrlSSavesPreset(t *testing.T) {
+	dialog := &fakeFilterSearchDialog{}
+	handler := NewFilterDialogKeyHandler(dialog, func(string, ...interface{}) {})
+
+	if !handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyS}, ModifierState{CtrlPressed: true}) {
+		t.Fatal("Ctrl+S should be handled")
+	}
+	if dialog.saved != 1 {
+		t.Fatalf("saved = %d, want 1", dialog.saved)
+	}
+	if dialog.search != "" {
+		t.Fatalf("search = %q, want Ctrl+S not appended", dialog.search)
+	}
+}
@@ -7,6 +7,11 @@ type JobsDialogInterface interface {
 	MoveToTop()
 	MoveToBottom()
 	CancelSelected()
+	MoveSelectedUp()
+	MoveSelectedDown()
+	TogglePrioritySelected()
+	RerunSelected()
+	RetryFailedSelected()
 	CloseDialog()
 }
 
@@ -21,10 +26,15 @@ func NewJobsDialogKeyHandler(d JobsDialogInterface, debugPrint func(format strin
 		{"S-Up", d.MoveToTop},
 		{"Down", d.MoveDown},
 		{"S-Down", d.MoveToBottom},
+		{"C-Up", d.MoveSelectedUp},
+		{"C-Down", d.MoveSelectedDown},
+		{"P", d.TogglePrioritySelected},
 
 		// Plain Delete only: Shift+Delete arrives as a folded Cut shortcut and
 		// has no binding here, so it falls through unmatched.
 		{"Delete", d.CancelSelected},
+		{"R", d.RerunSelected},
+		{"S-R", d.RetryFailedSelected},
 
 		{"Return", d.CloseDialog},
 		{"Escape", d.CloseDialog},
@@ -12,15 +12,25 @@ type fakeJobsDialog struct {
 	top    int
 	bottom int
 	cancel int
+	raise  int
+	lower  int
+	prio   int
+	rerun  int
+	retry  int
 	close  int
 }
 
-func (f *fakeJobsDialog) MoveUp()         { f.up++ }
-func (f *fakeJobsDialog) MoveDown()       { f.down++ }
-func (f *fakeJobsDialog) MoveToTop()      { f.top++ }
-func (f *fakeJobsDialog) MoveToBottom()   { f.bottom++ }
-func (f *fakeJobsDialog) CancelSelected() { f.cancel++ }
-func (f *fakeJobsDialog) CloseDialog()    { f.close++ }
+func (f *fakeJobsDialog) MoveUp()                 { f.up++ }
+func (f *fakeJobsDialog) MoveDown()               { f.down++ }
+func (f *fakeJobsDialog) MoveToTop()              { f.top++ }
+func (f *fakeJobsDialog) MoveToBottom()           { f.bottom++ }
+func (f *fakeJobsDialog) CancelSelected()         { f.cancel++ }
+func (f *fakeJobsDialog) MoveSelectedUp()         { f.raise++ }
+func (f *fakeJobsDialog) MoveSelectedDown()       { f.lower++ }
+func (f *fakeJobsDialog) TogglePrioritySelected() { f.prio++ }
+func (f *fakeJobsDialog) RerunSelected()          { f.rerun++ }
+func (f *fakeJobsDialog) RetryFailedSelected()    { f.retry++ }
+func (f *fakeJobsDialog) CloseDialog()            { f.close++ }
 
 func TestJobsDialogHandlerReturnClosesDialog(t *testing.T) {
 	tests := []fyne.KeyName{fyne.KeyReturn, fyne.KeyEnter}
@@ -54,3 +64,43 @@ func TestJobsDialogHandlerDeleteCancelsSelected(t *testing.T) {
 		t.Fatalf("close count = %d, want 0", dialog.close)
 	}
 }
+
+func TestJobsDialogHandlerRRerunsSelected(t *testing.T) {
+	dialog := &fakeJobsDialog{}
+	handler := NewJobsDialogKeyHandler(dialog, func(string, ...interface{}) {})
+
+	if !handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyR}, ModifierState{}) {
+		t.Fatal("R should be handled")
+	}
+	if dialog.rerun != 1 {
+		t.Fatalf("rerun count = %d, want 1", dialog.rerun)
+	}
+}
+
+func TestJobsDialogHandlerShiftRRetriesFailedItems(t *testing.T) {
+	dialog := &fakeJobsDialog{}
+	handler := NewJobsDialogKeyHandler(dialog, func(string, ...interface{}) {})
+
+	if !handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyR}, ModifierState{ShiftPressed: true}) {
+		t.Fatal("Shift+R should be handled")
+	}
+	if dialog.retry != 1 || dialog.rerun != 0 {
+		t.Fatalf("retry=%d rerun=%d, want 1 and 0", dialog.retry, dialog.rerun)
+	}
+}
+
+func TestJobsDialogHandlerReordersAndPrioritizes(t *testing.T) {
+	dialog := &fakeJobsDialog{}
+	handler := NewJobsDialogKeyHandler(dialog, func(string, ...interface{}) {})
+
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyUp}, ModifierState{CtrlPressed: true})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyDown}, ModifierState{CtrlPressed: true})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyP}, ModifierState{})
+
+	if dialog.raise != 1 || dialog.lower != 1 || dialog.prio != 1 {
+		t.Fatalf("raise=%d lower=%d priority=%d, want 1 each", dialog.raise, dialog.lower, dialog.prio)
+	}
+	if dialog.up != 0 || dialog.down != 0 {
+		t.Fatalf("selection moved: up=%d down=%d", dialog.up, dialog.down)
+	}
+}
@@ -214,10 +214,13 @@ var keyNameAliases = map[string]fyne.KeyName{
 	"DEL":       fyne.KeyDelete,
 	"DOT":       fyne.KeyPeriod,
 	"ENTER":     fyne.KeyReturn,
+	"EQUAL":     fyne.KeyEqual,
 	"ESC":       fyne.KeyEscape,
+	"MINUS":     fyne.KeyMinus,
 	"PAGEUP":    fyne.KeyPageUp,
 	"PAGEDOWN":  fyne.KeyPageDown,
 	"PERIOD":    fyne.KeyPeriod,
+	"PLUS":      fyne.KeyPlus,
 	"SEMICOLON": fyne.KeySemicolon,
 }
 
@@ -301,6 +304,22 @@ var validKeyNames = map[fyne.KeyName]struct{}{
 	fyne.KeyBackTick:     {},
 }
 
+// String formats the spec in configuration syntax, modifiers first
+// ("C-S-P").
+func (s keySpec) String() string {
+	prefix := ""
+	if s.mod.CtrlPressed {
+		prefix += "C-"
+	}
+	if s.mod.AltPressed {
+		prefix += "A-"
+	}
+	if s.mod.ShiftPressed {
+		prefix += "S-"
+	}
+	return prefix + string(s.key)
+}
+
 func (b keyBinding) matches(ev *fyne.KeyEvent, modifiers ModifierState) bool {
 	return b.spec.matches(ev, modifiers)
 }
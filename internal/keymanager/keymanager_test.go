@@ -2,6 +2,7 @@ package keymanager
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"testing"
 
@@ -391,14 +392,26 @@ type mainScreenFakeFileManager struct {
 	showJobsCount            int
 	showHistoryCount         int
 	pinCurrentHistoryCount   int
+	bookmarkSlotJumps        []int
+	bookmarkSlotSets         []int
 	showSearchCount          int
 	showDirectoryJumpCount   int
+	showPlacesCount          int
+	showBookmarksCount       int
 	reopenClosedCount        int
+	reopenClosedItemCount    int
+	newTabCount              int
+	showTreeCount            int
+	closeTabCount            int
+	switchTabDeltas          []int
 	focusWindowLeftCount     int
 	focusWindowRightCount    int
 	resetWindowSizeCount     int
 	resetAllWindowSizesCount int
 	showCreateDirCount       int
+	showCreateFileCount      int
+	showSelectPatternCount   int
+	showDirNoteCount         int
 	createDirName            string
 	createDirResult          bool
 	showClipboardFileCount   int
@@ -410,12 +423,31 @@ type mainScreenFakeFileManager struct {
 	clipboardText            string
 	clipboardResult          bool
 	showRenameCount          int
+	showBatchRenameCount     int
 	showDeleteCount          int
+	showSecureDeleteCount    int
 	showExplorerMenuCount    int
+	showSendToCount          int
+	showOpenWithCount        int
+	showVolumesCount         int
+	showConnectionCount      int
+	showPaletteCount         int
+	dirSizeCount             int
+	quickLookCount           int
 	showExternalMenuCount    int
+	togglePreviewPaneCount   int
+	toggleTreePaneCount      int
+	toggleAutoRefreshCount   int
+	openTerminalCount        int
+	toggleThumbnailsCount    int
+	toggleHiddenFilesCount   int
+	toggleGitIgnoredCount    int
 	showViewerCount          int
 	showMaintenanceCount     int
+	showPropertiesCount      int
 	showCompareCount         int
+	showCompressCount        int
+	showRunCommandCount      int
 	showSortCount            int
 	openFilePath             string
 	openDefaultAppPath       string
@@ -430,6 +462,7 @@ type mainScreenFakeFileManager struct {
 	selectedFiles            map[string]bool
 	allSelectedFiles         []fileinfo.FileInfo
 	refreshFileListCount     int
+	colorTags                []fileinfo.ColorTag
 }
 
 func (f *mainScreenFakeFileManager) GetCurrentCursorIndex() int    { return f.cursorIndex }
@@ -475,13 +508,36 @@ func (f *mainScreenFakeFileManager) RefreshFileList()                  { f.refre
 func (f *mainScreenFakeFileManager) SaveCursorPosition(dirPath string) { f.saveCursorPath = dirPath }
 func (f *mainScreenFakeFileManager) OpenNewWindow()                    {}
 func (f *mainScreenFakeFileManager) ReopenClosedWindow()               { f.reopenClosedCount++ }
-func (f *mainScreenFakeFileManager) FocusWindowLeft()                  { f.focusWindowLeftCount++ }
-func (f *mainScreenFakeFileManager) FocusWindowRight()                 { f.focusWindowRightCount++ }
-func (f *mainScreenFakeFileManager) ResetWindowSize()                  { f.resetWindowSizeCount++ }
-func (f *mainScreenFakeFileManager) ResetAllWindowSizes()              { f.resetAllWindowSizesCount++ }
-func (f *mainScreenFakeFileManager) PinCurrentHistoryPath()            { f.pinCurrentHistoryCount++ }
-func (f *mainScreenFakeFileManager) ClearFilter()                      {}
-func (f *mainScreenFakeFileManager) ToggleFilter()                     {}
+func (f *mainScreenFakeFileManager) ReopenClosed()                     { f.reopenClosedItemCount++ }
+func (f *mainScreenFakeFileManager) NewTab()                           { f.newTabCount++ }
+func (f *mainScreenFakeFileManager) CloseTab()                         { f.closeTabCount++ }
+func (f *mainScreenFakeFileManager) SwitchTab(delta int) {
+	f.switchTabDeltas = append(f.switchTabDeltas, delta)
+}
+func (f *mainScreenFakeFileManager) FocusWindowLeft()       { f.focusWindowLeftCount++ }
+func (f *mainScreenFakeFileManager) FocusWindowRight()      { f.focusWindowRightCount++ }
+func (f *mainScreenFakeFileManager) ResetWindowSize()       { f.resetWindowSizeCount++ }
+func (f *mainScreenFakeFileManager) ResetAllWindowSizes()   { f.resetAllWindowSizesCount++ }
+func (f *mainScreenFakeFileManager) PinCurrentHistoryPath() { f.pinCurrentHistoryCount++ }
+func (f *mainScreenFakeFileManager) ClearFilter()           {}
+func (f *mainScreenFakeFileManager) ToggleFilter()          {}
+func (f *mainScreenFakeFileManager) TogglePreviewPane()     { f.togglePreviewPaneCount++ }
+func (f *mainScreenFakeFileManager) ToggleTreePane()        { f.toggleTreePaneCount++ }
+func (f *mainScreenFakeFileManager) ToggleAutoRefresh()     { f.toggleAutoRefreshCount++ }
+func (f *mainScreenFakeFileManager) OpenTerminalHere()      { f.openTerminalCount++ }
+func (f *mainScreenFakeFileManager) ToggleThumbnails()      { f.toggleThumbnailsCount++ }
+func (f *mainScreenFakeFileManager) ToggleHiddenFiles()     { f.toggleHiddenFilesCount++ }
+func (f *mainScreenFakeFileManager) ToggleGitIgnored()      { f.toggleGitIgnoredCount++ }
+func (f *mainScreenFakeFileManager) SetColorTag(tag fileinfo.ColorTag) {
+	f.colorTags = append(f.colorTags, tag)
+}
+func (f *mainScreenFakeFileManager) JumpToBookmarkSlot(n int) {
+	f.bookmarkSlotJumps = append(f.bookmarkSlotJumps, n)
+}
+func (f *mainScreenFakeFileManager) SetBookmarkSlot(n int) {
+	f.bookmarkSlotSets = append(f.bookmarkSlotSets, n)
+}
+func (f *mainScreenFakeFileManager) CalculateDirectorySizes() { f.dirSizeCount++ }
 func (f *mainScreenFakeFileManager) CreateDirectory(name string) bool {
 	f.createDirName = name
 	return f.createDirResult
@@ -512,15 +568,20 @@ func (f *mainScreenFakeFileManager) OpenFileDefaultApp(file *fileinfo.FileInfo)
 // assert a Show* outcome call handler.SetActions(fakeDialogActions(fm)).
 func fakeDialogActions(f *mainScreenFakeFileManager) DialogActions {
 	return DialogActions{
-		ShowDirectoryTreeDialog:     func() {},
+		ShowDirectoryTreeDialog:     func() { f.showTreeCount++ },
 		ShowNavigationHistoryDialog: func() { f.showHistoryCount++ },
 		ShowDirectoryJumpDialog:     func() { f.showDirectoryJumpCount++ },
+		ShowPlacesDialog:            func() { f.showPlacesCount++ },
+		ShowBookmarksDialog:         func() { f.showBookmarksCount++ },
 		ShowFilterDialog:            func() {},
 		ShowIncrementalSearchDialog: func() { f.showSearchCount++ },
 		ShowSortDialog:              func() { f.showSortCount++ },
 		ShowJobsDialog:              func() { f.showJobsCount++ },
 		ShowPathEditDialog:          func() { f.focusPathCount++ },
 		ShowCreateDirectoryDialog:   func() { f.showCreateDirCount++ },
+		ShowCreateFileDialog:        func() { f.showCreateFileCount++ },
+		ShowSelectByPatternDialog:   func() { f.showSelectPatternCount++ },
+		ShowDirectoryNoteDialog:     func() { f.showDirNoteCount++ },
 		ShowClipboardTextFileDialog: func() { f.showClipboardFileCount++ },
 		ShowMessageDialog: func(title string, message string) {
 			f.messageTitle = title
@@ -530,16 +591,27 @@ func fakeDialogActions(f *mainScreenFakeFileManager) DialogActions {
 		ShowCopyDialog:           func() {},
 		ShowMoveDialog:           func() {},
 		ShowExtractArchiveDialog: func() {},
+		ShowCompressDialog:       func() { f.showCompressCount++ },
+		ShowRunCommandDialog:     func() { f.showRunCommandCount++ },
 		ShowCompareDialog:        func() { f.showCompareCount++ },
 		ShowRenameDialog:         func() { f.showRenameCount++ },
+		ShowBatchRenameDialog:    func() { f.showBatchRenameCount++ },
 		ShowDeleteDialog: func(permanent bool) {
 			f.showDeleteCount++
 			f.deletePermanent = permanent
 		},
+		ShowSecureDeleteDialog:  func() { f.showSecureDeleteCount++ },
 		ShowExplorerContextMenu: func() { f.showExplorerMenuCount++ },
+		ShowSendToMenu:          func() { f.showSendToCount++ },
+		ShowOpenWithMenu:        func() { f.showOpenWithCount++ },
+		ShowVolumesMenu:         func() { f.showVolumesCount++ },
+		ShowConnectionMenu:      func() { f.showConnectionCount++ },
+		ShowQuickLook:           func() { f.quickLookCount++ },
 		ShowExternalCommandMenu: func() { f.showExternalMenuCount++ },
 		ShowFileViewer:          func() { f.showViewerCount++ },
 		ShowMaintenanceDialog:   func() { f.showMaintenanceCount++ },
+		ShowPropertiesDialog:    func() { f.showPropertiesCount++ },
+		ShowCommandPalette:      func() { f.showPaletteCount++ },
 		ShowCommandMenu:         func(title string, items []CommandMenuItem) {},
 	}
 }
@@ -745,6 +817,146 @@ func TestMainScreenConfiguredBindingCanShowMaintenanceDialog(t *testing.T) {
 	}
 }
 
+func TestMainScreenCtrlBShowsBookmarksDialog(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handled := handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyB}, ModifierState{CtrlPressed: true})
+
+	if !handled {
+		t.Fatal("Ctrl+B should be handled")
+	}
+	if fm.showBookmarksCount != 1 {
+		t.Fatalf("ShowBookmarksDialog count = %d, want 1", fm.showBookmarksCount)
+	}
+}
+
+func TestMainScreenTabCommands(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	for _, tc := range []struct {
+		name string
+		key  fyne.KeyName
+		mods ModifierState
+	}{
+		{name: "Ctrl+T", key: fyne.KeyT, mods: ModifierState{CtrlPressed: true}},
+		{name: "Ctrl+W", key: fyne.KeyW, mods: ModifierState{CtrlPressed: true}},
+		{name: "Ctrl+Tab", key: fyne.KeyTab, mods: ModifierState{CtrlPressed: true}},
+		{name: "Ctrl+Shift+Tab", key: fyne.KeyTab, mods: ModifierState{CtrlPressed: true, ShiftPressed: true}},
+	} {
+		if !handler.OnKeyActivated(&fyne.KeyEvent{Name: tc.key}, tc.mods) {
+			t.Fatalf("%s should be handled", tc.name)
+		}
+	}
+
+	if fm.newTabCount != 1 || fm.closeTabCount != 1 {
+		t.Fatalf("new = %d close = %d, want 1, 1", fm.newTabCount, fm.closeTabCount)
+	}
+	if len(fm.switchTabDeltas) != 2 || fm.switchTabDeltas[0] != 1 || fm.switchTabDeltas[1] != -1 {
+		t.Fatalf("switch deltas = %v, want [1 -1]", fm.switchTabDeltas)
+	}
+	if fm.showExplorerMenuCount != 0 || fm.showSendToCount != 0 {
+		t.Fatal("Ctrl+Tab fell through to the Tab bindings")
+	}
+}
+
+func TestMainScreenTShowsDirectoryTree(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	if !handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyT}, ModifierState{}) {
+		t.Fatal("T should be handled")
+	}
+	if fm.showTreeCount != 1 || fm.newTabCount != 0 {
+		t.Fatalf("tree count = %d, new tab count = %d; want 1, 0", fm.showTreeCount, fm.newTabCount)
+	}
+}
+
+func TestMainScreenShiftVShowsVolumesMenu(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handled := handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyV}, ModifierState{ShiftPressed: true})
+
+	if !handled {
+		t.Fatal("Shift+V should be handled")
+	}
+	if fm.showVolumesCount != 1 || fm.showViewerCount != 0 {
+		t.Fatalf("ShowVolumesMenu count = %d, viewer count = %d; want 1, 0", fm.showVolumesCount, fm.showViewerCount)
+	}
+}
+
+func TestMainScreenCtrlRShowsConnectionMenu(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handled := handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyR}, ModifierState{CtrlPressed: true})
+
+	if !handled {
+		t.Fatal("Ctrl+R should be handled")
+	}
+	if fm.showConnectionCount != 1 || fm.showRenameCount != 0 {
+		t.Fatalf("ShowConnectionMenu count = %d, rename count = %d; want 1, 0", fm.showConnectionCount, fm.showRenameCount)
+	}
+}
+
+func TestMainScreenCtrlShiftPShowsCommandPalette(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handled := handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyP}, ModifierState{CtrlPressed: true, ShiftPressed: true})
+
+	if !handled {
+		t.Fatal("Ctrl+Shift+P should be handled")
+	}
+	if fm.showPaletteCount != 1 {
+		t.Fatalf("ShowCommandPalette count = %d, want 1", fm.showPaletteCount)
+	}
+}
+
+func TestMainScreenCommandsListsEffectiveKeys(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := NewMainScreenKeyHandler(fm, func(string, ...interface{}) {}, []config.KeyBindingEntry{
+		{Key: "R", Command: CommandTabNew},
+	})
+
+	keys := make(map[string][]string)
+	for _, entry := range handler.Commands() {
+		keys[entry.ID] = entry.Keys
+	}
+	if _, ok := keys[CommandPaletteShow]; ok {
+		t.Fatal("Commands should not list the palette itself")
+	}
+	if got := keys[CommandTabNew]; !slices.Equal(got, []string{"R", "C-T"}) {
+		t.Fatalf("tab.new keys = %v, want [R C-T]", got)
+	}
+	if got := keys[CommandRenameShow]; !slices.Equal(got, []string{"F2"}) {
+		t.Fatalf("rename.show keys = %v, want [F2] once R is rebound", got)
+	}
+	if got, ok := keys[CommandWindowReopen]; !ok || len(got) != 0 {
+		t.Fatalf("window.reopen keys = %v listed=%v, want listed without keys", got, ok)
+	}
+}
+
+func TestMainScreenRunCommandExecutesByID(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	if !handler.RunCommand(CommandTabNew) {
+		t.Fatal("RunCommand(tab.new) returned false")
+	}
+	if !handler.RunCommand(CommandRenameShow) {
+		t.Fatal("RunCommand(rename.show) returned false")
+	}
+	if handler.RunCommand("missing.command") {
+		t.Fatal("RunCommand of an unknown command returned true")
+	}
+	if fm.newTabCount != 1 || fm.showRenameCount != 1 {
+		t.Fatalf("new tab = %d rename = %d, want 1, 1", fm.newTabCount, fm.showRenameCount)
+	}
+}
+
 func TestMainScreenJShowsDirectoryJumpDialog(t *testing.T) {
 	fm := &mainScreenFakeFileManager{}
 	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
@@ -776,6 +988,35 @@ func TestMainScreenShiftBPinsCurrentHistoryPath(t *testing.T) {
 	}
 }
 
+func TestMainScreenDigitsJumpToAndSetBookmarkSlots(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.Key3}, ModifierState{CtrlPressed: true})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.Key9}, ModifierState{CtrlPressed: true, AltPressed: true})
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.Key1}, ModifierState{AltPressed: true})
+
+	if !slices.Equal(fm.bookmarkSlotJumps, []int{3}) || !slices.Equal(fm.bookmarkSlotSets, []int{9}) {
+		t.Fatalf("slot jumps = %v sets = %v, want [3] and [9]", fm.bookmarkSlotJumps, fm.bookmarkSlotSets)
+	}
+	if !slices.Equal(fm.colorTags, []fileinfo.ColorTag{fileinfo.ColorTagRed}) {
+		t.Fatalf("A-1 should still set the red color tag, got %v", fm.colorTags)
+	}
+}
+
+func TestMainScreenAltDigitCanBeBoundToBookmarkSlot(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {}, []config.KeyBindingEntry{
+		{Key: "A-1", Command: BookmarkJumpCommand(1)},
+	})
+
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.Key1}, ModifierState{AltPressed: true})
+
+	if !slices.Equal(fm.bookmarkSlotJumps, []int{1}) || len(fm.colorTags) != 0 {
+		t.Fatalf("slot jumps = %v color tags = %v, want a jump to slot 1 only", fm.bookmarkSlotJumps, fm.colorTags)
+	}
+}
+
 func TestMainScreenLeftFocusesLeftWindow(t *testing.T) {
 	fm := &mainScreenFakeFileManager{}
 	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
@@ -841,6 +1082,47 @@ func TestMainScreenKShowsCreateDirectoryDialog(t *testing.T) {
 	}
 }
 
+func TestMainScreenShiftKShowsCreateFileDialog(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handled := handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyK}, ModifierState{ShiftPressed: true})
+
+	if !handled {
+		t.Fatal("Shift+K should be handled")
+	}
+	if fm.showCreateFileCount != 1 || fm.showCreateDirCount != 0 {
+		t.Fatalf("create file/dir counts = %d/%d, want 1/0", fm.showCreateFileCount, fm.showCreateDirCount)
+	}
+}
+
+func TestMainScreenShiftNShowsDirectoryNoteDialog(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	if !handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyN}, ModifierState{ShiftPressed: true}) {
+		t.Fatal("Shift+N should be handled")
+	}
+	if fm.showDirNoteCount != 1 {
+		t.Fatalf("directory note dialog count = %d, want 1", fm.showDirNoteCount)
+	}
+}
+
+func TestMainScreenAltDigitsSetColorTags(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	for _, key := range []fyne.KeyName{fyne.Key1, fyne.Key5, fyne.Key7, fyne.Key0} {
+		if !handler.OnKeyActivated(&fyne.KeyEvent{Name: key}, ModifierState{AltPressed: true}) {
+			t.Fatalf("Alt+%s should be handled", key)
+		}
+	}
+	want := []fileinfo.ColorTag{fileinfo.ColorTagRed, fileinfo.ColorTagBlue, fileinfo.ColorTagGray, fileinfo.ColorTagNone}
+	if fmt.Sprint(fm.colorTags) != fmt.Sprint(want) {
+		t.Fatalf("color tags = %v, want %v", fm.colorTags, want)
+	}
+}
+
 func TestMainScreenPShowsClipboardTextFileDialog(t *testing.T) {
 	fm := &mainScreenFakeFileManager{}
 	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
@@ -895,6 +1177,34 @@ func TestMainScreenModifiedRenameKeysDoNotShowRenameDialog(t *testing.T) {
 	}
 }
 
+func TestMainScreenShiftRShowsBatchRenameDialog(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handled := handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyR}, ModifierState{ShiftPressed: true})
+
+	if !handled {
+		t.Fatal("Shift+R should be handled")
+	}
+	if fm.showBatchRenameCount != 1 || fm.showRenameCount != 0 {
+		t.Fatalf("batch rename count = %d, rename count = %d, want 1 and 0", fm.showBatchRenameCount, fm.showRenameCount)
+	}
+}
+
+func TestMainScreenCtrlPShowsPlacesDialog(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handled := handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyP}, ModifierState{CtrlPressed: true})
+
+	if !handled {
+		t.Fatal("Ctrl+P should be handled")
+	}
+	if fm.showPlacesCount != 1 {
+		t.Fatalf("ShowPlacesDialog count = %d, want 1", fm.showPlacesCount)
+	}
+}
+
 func TestMainScreenTabShowsExplorerContextMenu(t *testing.T) {
 	fm := &mainScreenFakeFileManager{}
 	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
@@ -909,6 +1219,59 @@ func TestMainScreenTabShowsExplorerContextMenu(t *testing.T) {
 	}
 }
 
+func TestMainScreenShiftTabShowsSendToMenu(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handled := handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyTab}, ModifierState{ShiftPressed: true})
+
+	if !handled {
+		t.Fatal("Shift+Tab should be handled")
+	}
+	if fm.showSendToCount != 1 || fm.showExplorerMenuCount != 0 {
+		t.Fatalf("SendTo count = %d, Explorer count = %d; want 1, 0", fm.showSendToCount, fm.showExplorerMenuCount)
+	}
+}
+
+func TestMainScreenCtrlReturnShowsOpenWithMenu(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handled := handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyReturn}, ModifierState{CtrlPressed: true})
+
+	if !handled {
+		t.Fatal("Ctrl+Return should be handled")
+	}
+	if fm.showOpenWithCount != 1 || fm.openDefaultAppPath != "" {
+		t.Fatalf("OpenWith count = %d, default app path = %q; want 1 and no default app", fm.showOpenWithCount, fm.openDefaultAppPath)
+	}
+}
+
+func TestMainScreenShiftDCalculatesDirectorySizes(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyD}, ModifierState{ShiftPressed: true})
+
+	if fm.dirSizeCount != 1 {
+		t.Fatalf("CalculateDirectorySizes count = %d, want 1", fm.dirSizeCount)
+	}
+}
+
+func TestMainScreenShiftSpaceShowsQuickLookWithoutToggling(t *testing.T) {
+	fm := &mainScreenFakeFileManager{files: []fileinfo.FileInfo{{Name: "a.txt", Path: "/dir/a.txt"}}}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeySpace}, ModifierState{ShiftPressed: true})
+
+	if fm.quickLookCount != 1 {
+		t.Fatalf("ShowQuickLook count = %d, want 1", fm.quickLookCount)
+	}
+	if fm.selectedFiles["/dir/a.txt"] {
+		t.Fatal("Shift+Space should not toggle selection")
+	}
+}
+
 func TestMainScreenPeriodRefreshesCurrentDirectory(t *testing.T) {
 	fm := &mainScreenFakeFileManager{currentPath: "/tmp/nmf"}
 	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
@@ -974,6 +1337,126 @@ func TestMainScreenShiftDeleteShowsPermanentDeleteDialog(t *testing.T) {
 	}
 }
 
+func TestMainScreenCtrlShiftDeleteShowsSecureDeleteDialog(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handled := handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyDelete}, ModifierState{CtrlPressed: true, ShiftPressed: true})
+
+	if !handled {
+		t.Fatal("Ctrl+Shift+Delete should be handled")
+	}
+	if fm.showSecureDeleteCount != 1 || fm.showDeleteCount != 0 {
+		t.Fatalf("secure/plain delete dialogs = %d/%d, want 1/0", fm.showSecureDeleteCount, fm.showDeleteCount)
+	}
+}
+
+func TestMainScreenAltPTogglesPreviewPane(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handled := handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyP}, ModifierState{AltPressed: true})
+
+	if !handled {
+		t.Fatal("A-P should be handled")
+	}
+	if fm.togglePreviewPaneCount != 1 {
+		t.Fatalf("TogglePreviewPane count = %d, want 1", fm.togglePreviewPaneCount)
+	}
+}
+
+func TestMainScreenAltDTogglesTreePane(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handled := handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyD}, ModifierState{AltPressed: true})
+
+	if !handled {
+		t.Fatal("A-D should be handled")
+	}
+	if fm.toggleTreePaneCount != 1 {
+		t.Fatalf("ToggleTreePane count = %d, want 1", fm.toggleTreePaneCount)
+	}
+}
+
+func TestMainScreenAltRTogglesAutoRefresh(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handled := handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyR}, ModifierState{AltPressed: true})
+
+	if !handled {
+		t.Fatal("A-R should be handled")
+	}
+	if fm.toggleAutoRefreshCount != 1 {
+		t.Fatalf("ToggleAutoRefresh count = %d, want 1", fm.toggleAutoRefreshCount)
+	}
+}
+
+func TestMainScreenCtrlBackTickOpensTerminal(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	if !handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyBackTick}, ModifierState{CtrlPressed: true}) {
+		t.Fatal("C-` should be handled")
+	}
+	if fm.openTerminalCount != 1 {
+		t.Fatalf("OpenTerminalHere count = %d, want 1", fm.openTerminalCount)
+	}
+}
+
+func TestMainScreenShift1ShowsRunCommandDialog(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	if !handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.Key1}, ModifierState{ShiftPressed: true}) {
+		t.Fatal("Shift+1 should be handled")
+	}
+	if fm.showRunCommandCount != 1 {
+		t.Fatalf("ShowRunCommandDialog count = %d, want 1", fm.showRunCommandCount)
+	}
+}
+
+func TestMainScreenCtrlPeriodTogglesHiddenFiles(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handled := handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyPeriod}, ModifierState{CtrlPressed: true})
+
+	if !handled {
+		t.Fatal("C-Period should be handled")
+	}
+	if fm.toggleHiddenFilesCount != 1 {
+		t.Fatalf("ToggleHiddenFiles count = %d, want 1", fm.toggleHiddenFilesCount)
+	}
+}
+
+func TestMainScreenAltITogglesGitIgnored(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	if !handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyI}, ModifierState{AltPressed: true}) {
+		t.Fatal("A-I should be handled")
+	}
+	if fm.toggleGitIgnoredCount != 1 {
+		t.Fatalf("ToggleGitIgnored count = %d, want 1", fm.toggleGitIgnoredCount)
+	}
+}
+
+func TestMainScreenAltTTogglesThumbnails(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handled := handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyT}, ModifierState{AltPressed: true})
+
+	if !handled {
+		t.Fatal("A-T should be handled")
+	}
+	if fm.toggleThumbnailsCount != 1 {
+		t.Fatalf("ToggleThumbnails count = %d, want 1", fm.toggleThumbnailsCount)
+	}
+}
+
 func TestMainScreenXShowsExternalCommandMenu(t *testing.T) {
 	fm := &mainScreenFakeFileManager{}
 	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
@@ -1002,6 +1485,23 @@ func TestMainScreenVShowsFileViewer(t *testing.T) {
 	}
 }
 
+func TestMainScreenAltReturnShowsPropertiesDialog(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handled := handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyReturn}, ModifierState{AltPressed: true})
+
+	if !handled {
+		t.Fatal("A-Return should be handled")
+	}
+	if fm.showPropertiesCount != 1 {
+		t.Fatalf("ShowPropertiesDialog count = %d, want 1", fm.showPropertiesCount)
+	}
+	if fm.openFilePath != "" {
+		t.Fatalf("A-Return should not open the file, opened %q", fm.openFilePath)
+	}
+}
+
 func TestMainScreenShiftCShowsCompareDialog(t *testing.T) {
 	fm := &mainScreenFakeFileManager{}
 	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
@@ -1016,6 +1516,20 @@ func TestMainScreenShiftCShowsCompareDialog(t *testing.T) {
 	}
 }
 
+func TestMainScreenShiftUShowsCompressDialog(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handled := handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyU}, ModifierState{ShiftPressed: true})
+
+	if !handled {
+		t.Fatal("Shift+U should be handled")
+	}
+	if fm.showCompressCount != 1 {
+		t.Fatalf("ShowCompressDialog count = %d, want 1", fm.showCompressCount)
+	}
+}
+
 func TestMainScreenCtrlAMarksAllSelectableFiles(t *testing.T) {
 	fm := &mainScreenFakeFileManager{
 		files: []fileinfo.FileInfo{
@@ -1117,6 +1631,62 @@ func TestMainScreenShiftIInvertsMarksIncludingDirectories(t *testing.T) {
 	}
 }
 
+func TestMainScreenMinusClearsVisibleMarks(t *testing.T) {
+	fm := &mainScreenFakeFileManager{
+		files: []fileinfo.FileInfo{
+			{Name: "..", Path: "/parent"},
+			{Name: "a.txt", Path: "/dir/a.txt"},
+			{Name: "sub", Path: "/dir/sub", IsDir: true},
+		},
+		selectedFiles: map[string]bool{
+			"/dir/a.txt":      true,
+			"/dir/sub":        true,
+			"/dir/hidden.log": true,
+		},
+	}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handled := handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyMinus}, ModifierState{})
+
+	if !handled {
+		t.Fatal("Minus should be handled")
+	}
+	if fm.selectedFiles["/dir/a.txt"] || fm.selectedFiles["/dir/sub"] {
+		t.Fatalf("selected files = %+v, want visible marks cleared", fm.selectedFiles)
+	}
+	if !fm.selectedFiles["/dir/hidden.log"] {
+		t.Fatalf("selected files = %+v, filtered-out mark should be untouched", fm.selectedFiles)
+	}
+	if fm.refreshFileListCount != 1 {
+		t.Fatalf("RefreshFileList count = %d, want 1", fm.refreshFileListCount)
+	}
+}
+
+func TestMainScreenPlusShowsSelectByPatternDialog(t *testing.T) {
+	tests := []struct {
+		name string
+		key  fyne.KeyName
+		mods ModifierState
+	}{
+		{name: "keypad plus", key: fyne.KeyPlus},
+		{name: "shift equal", key: fyne.KeyEqual, mods: ModifierState{ShiftPressed: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fm := &mainScreenFakeFileManager{}
+			handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+			handler.SetActions(fakeDialogActions(fm))
+
+			if !handler.OnKeyActivated(&fyne.KeyEvent{Name: tt.key}, tt.mods) {
+				t.Fatalf("%s should be handled", tt.name)
+			}
+			if fm.showSelectPatternCount != 1 {
+				t.Fatalf("ShowSelectByPatternDialog count = %d, want 1", fm.showSelectPatternCount)
+			}
+		})
+	}
+}
+
 func TestMainScreenConfiguredBindingOverridesDefault(t *testing.T) {
 	fm := &mainScreenFakeFileManager{}
 	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {}, []config.KeyBindingEntry{
@@ -1184,6 +1754,20 @@ func TestMainScreenConfiguredBindingCanReopenWindow(t *testing.T) {
 	}
 }
 
+func TestMainScreenCtrlShiftNReopensLastClosed(t *testing.T) {
+	fm := &mainScreenFakeFileManager{}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handled := handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyN}, ModifierState{CtrlPressed: true, ShiftPressed: true})
+
+	if !handled {
+		t.Fatal("Ctrl+Shift+N should be handled")
+	}
+	if fm.reopenClosedItemCount != 1 || fm.reopenClosedCount != 0 {
+		t.Fatalf("ReopenClosed count = %d, ReopenClosedWindow count = %d; want 1 and 0", fm.reopenClosedItemCount, fm.reopenClosedCount)
+	}
+}
+
 func TestMainScreenShiftQResetsCurrentWindowSize(t *testing.T) {
 	fm := &mainScreenFakeFileManager{}
 	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
@@ -1420,6 +2004,34 @@ func TestMainScreenProvidesClipboardWriterToExtraCommand(t *testing.T) {
 	}
 }
 
+func TestMainScreenCopyPathCopiesMarkedOrCursorPaths(t *testing.T) {
+	fm := &mainScreenFakeFileManager{
+		clipboardResult: true,
+		cursorIndex:     1,
+		files: []fileinfo.FileInfo{
+			{Name: "..", Path: "/"},
+			{Name: "a.txt", Path: "/dir/a.txt"},
+			{Name: "b.txt", Path: "/dir/b.txt"},
+			{Name: "gone.txt", Path: "/dir/gone.txt", Status: fileinfo.StatusDeleted},
+		},
+	}
+	handler := newMainScreenKeyHandlerForTest(fm, func(string, ...interface{}) {})
+
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyC}, ModifierState{CtrlPressed: true, ShiftPressed: true})
+	if fm.clipboardText != "/dir/a.txt" {
+		t.Fatalf("clipboard text = %q, want the cursor path", fm.clipboardText)
+	}
+
+	fm.selectedFiles = map[string]bool{"/dir/b.txt": true, "/dir/gone.txt": true, "/": true}
+	fm.selectedFiles["/dir/a.txt"] = true
+	if !handler.RunCommand(CommandCopyPath) {
+		t.Fatal("RunCommand(copyPath) = false, want true")
+	}
+	if fm.clipboardText != "/dir/a.txt\n/dir/b.txt" {
+		t.Fatalf("clipboard text = %q, want the marked paths in list order", fm.clipboardText)
+	}
+}
+
 func TestMainScreenDoesNotDeferNonTransitionCommand(t *testing.T) {
 	km := NewKeyManager(func(string, ...interface{}) {})
 	fm := &mainScreenFakeFileManager{
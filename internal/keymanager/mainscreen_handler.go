@@ -2,6 +2,9 @@ package keymanager
 
 import (
 	"os"
+	"sort"
+	"strconv"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/driver/desktop"
@@ -23,13 +26,33 @@ const (
 	CommandSelectAll           = "selection.markAll"
 	CommandSelectInvert        = "selection.invert"
 	CommandSelectInvertWithDir = "selection.invertWithDirectories"
+	CommandSelectByPattern     = "selection.byPattern"
+	CommandSelectClear         = "selection.clear"
 	CommandParentDirectory     = "directory.parent"
 	CommandRefresh             = "directory.refresh"
+	CommandAutoRefreshToggle   = "directory.autoRefresh"
 	CommandHome                = "directory.home"
 	CommandDirectoryCreate     = "directory.create"
+	CommandFileCreate          = "file.create"
+	CommandDirectoryNote       = "directory.note"
+	CommandDirectorySize       = "directory.size"
+	CommandColorTagRed         = "colorTag.red"
+	CommandColorTagOrange      = "colorTag.orange"
+	CommandColorTagYellow      = "colorTag.yellow"
+	CommandColorTagGreen       = "colorTag.green"
+	CommandColorTagBlue        = "colorTag.blue"
+	CommandColorTagPurple      = "colorTag.purple"
+	CommandColorTagGray        = "colorTag.gray"
+	CommandColorTagClear       = "colorTag.clear"
 	CommandClipboardTextFile   = "clipboard.createTextFile"
+	CommandCopyPath            = "clipboard.copyPath"
 	CommandWindowNew           = "window.new"
 	CommandWindowReopen        = "window.reopen"
+	CommandClosedReopen        = "closed.reopen"
+	CommandTabNew              = "tab.new"
+	CommandTabClose            = "tab.close"
+	CommandTabNext             = "tab.next"
+	CommandTabPrevious         = "tab.previous"
 	CommandWindowFocusLeft     = "window.focusLeft"
 	CommandWindowFocusRight    = "window.focusRight"
 	CommandWindowResetSize     = "window.resetSize"
@@ -38,9 +61,18 @@ const (
 	CommandHistoryShow         = "history.show"
 	CommandHistoryPinCurrent   = "history.pinCurrent"
 	CommandDirectoryJumpShow   = "directoryJump.show"
+	CommandBookmarksShow       = "bookmarks.show"
+	CommandPlacesShow          = "places.show"
+	CommandBookmarkJumpPrefix  = "bookmark.jump" // + slot digit, see BookmarkJumpCommand
+	CommandBookmarkSetPrefix   = "bookmark.set"  // + slot digit, see BookmarkSetCommand
 	CommandFilterShow          = "filter.show"
 	CommandFilterClear         = "filter.clear"
 	CommandFilterToggle        = "filter.toggle"
+	CommandPreviewPaneToggle   = "previewPane.toggle"
+	CommandTreePaneToggle      = "treePane.toggle"
+	CommandThumbnailsToggle    = "view.thumbnails"
+	CommandHiddenFilesToggle   = "view.hiddenFiles"
+	CommandGitIgnoredToggle    = "view.gitIgnored"
 	CommandSearchShow          = "search.show"
 	CommandSortShow            = "sort.show"
 	CommandJobsShow            = "jobs.show"
@@ -49,14 +81,31 @@ const (
 	CommandCopyShow            = "copy.show"
 	CommandMoveShow            = "move.show"
 	CommandArchiveExtract      = "archive.extract"
+	CommandArchiveCreate       = "archive.create"
 	CommandCompareShow         = "compare.show"
+	CommandChecksumMenu        = "checksum.menu"
 	CommandRenameShow          = "rename.show"
+	CommandRenameBatch         = "rename.batch"
 	CommandDeleteTrash         = "delete.trash"
 	CommandDeletePermanent     = "delete.permanent"
+	CommandDeleteSecure        = "delete.secure"
 	CommandExplorerContextShow = "explorerContext.show"
 	CommandExternalCommandMenu = "externalCommand.menu"
+	CommandSendToMenu          = "sendTo.menu"
+	CommandOpenWithMenu        = "openWith.menu"
+	CommandVolumesMenu         = "volumes.menu"
+	CommandConnectionMenu      = "connection.menu"
+	CommandCredentialsShow     = "credentials.show"
+	CommandTerminalOpen        = "terminal.open"
+	CommandShellRun            = "shell.run"
+	CommandQuickLook           = "quickLook.show"
 	CommandViewerShow          = "viewer.show"
 	CommandMaintenanceShow     = "maintenance.show"
+	CommandPropertiesShow      = "properties.show"
+	CommandAuditLogShow        = "auditLog.show"
+	CommandAuditLogExport      = "auditLog.export"
+	CommandListingExport       = "listing.export"
+	CommandPaletteShow         = "commandPalette.show"
 	CommandNoop                = "noop"
 )
 
@@ -85,14 +134,30 @@ type FileManagerInterface interface {
 
 	OpenNewWindow()
 	ReopenClosedWindow()
+	ReopenClosed()
+	NewTab()
+	CloseTab()
+	SwitchTab(delta int)
 	FocusWindowLeft()
 	FocusWindowRight()
 	ResetWindowSize()
 	ResetAllWindowSizes()
 	PinCurrentHistoryPath()
+	JumpToBookmarkSlot(n int)
+	SetBookmarkSlot(n int)
 
 	ClearFilter()
 	ToggleFilter()
+	ToggleAutoRefresh()
+	TogglePreviewPane()
+	ToggleTreePane()
+	ToggleThumbnails()
+	ToggleHiddenFiles()
+	ToggleGitIgnored()
+
+	SetColorTag(tag fileinfo.ColorTag)
+	CalculateDirectorySizes()
+	OpenTerminalHere()
 
 	CreateDirectory(name string) bool
 	CreateClipboardTextFile(name string) bool
@@ -237,33 +302,75 @@ func (mh *MainScreenKeyHandler) executeBinding(ev *fyne.KeyEvent, modifiers Modi
 		if ev != nil {
 			key = ev.Name
 		}
-		ctx := CommandContext{
-			Modifiers:       modifiers,
-			Key:             key,
-			Event:           keyEventTyped,
-			FileManager:     mh.fileManager,
-			DeferTransition: mh.deferTransition,
-
-			ShowCommandMenu:             mh.actions.ShowCommandMenu,
-			ShowMessageDialog:           mh.actions.ShowMessageDialog,
-			ShowCreateDirectoryDialog:   mh.actions.ShowCreateDirectoryDialog,
-			ShowClipboardTextFileDialog: mh.actions.ShowClipboardTextFileDialog,
-		}
-		ctx.RunCommand = func(command string) bool {
-			return mh.executeCommand(command, ctx)
-		}
-		if runner, ok := mh.fileManager.(externalCommandRunner); ok {
-			ctx.RunExternalCommand = runner.RunExternalCommand
-		}
-		if writer, ok := mh.fileManager.(clipboardWriter); ok {
-			ctx.SetClipboard = writer.SetClipboardText
-		}
+		ctx := mh.commandContext(key, modifiers)
 		mh.executeCommand(binding.command, ctx)
 		return true
 	}
 	return false
 }
 
+func (mh *MainScreenKeyHandler) commandContext(key fyne.KeyName, modifiers ModifierState) CommandContext {
+	ctx := CommandContext{
+		Modifiers:       modifiers,
+		Key:             key,
+		Event:           keyEventTyped,
+		FileManager:     mh.fileManager,
+		DeferTransition: mh.deferTransition,
+
+		ShowCommandMenu:             mh.actions.ShowCommandMenu,
+		ShowMessageDialog:           mh.actions.ShowMessageDialog,
+		ShowCreateDirectoryDialog:   mh.actions.ShowCreateDirectoryDialog,
+		ShowClipboardTextFileDialog: mh.actions.ShowClipboardTextFileDialog,
+	}
+	ctx.RunCommand = func(command string) bool {
+		return mh.executeCommand(command, ctx)
+	}
+	if runner, ok := mh.fileManager.(externalCommandRunner); ok {
+		ctx.RunExternalCommand = runner.RunExternalCommand
+	}
+	if writer, ok := mh.fileManager.(clipboardWriter); ok {
+		ctx.SetClipboard = writer.SetClipboardText
+	}
+	return ctx
+}
+
+// CommandEntry names a main-screen command and the keys that trigger it.
+type CommandEntry struct {
+	ID   string
+	Keys []string
+}
+
+// Commands lists every built-in and script command sorted by ID, with the
+// keys whose binding actually reaches it (an earlier binding for the same
+// key shadows later ones). noop and the palette command are left out.
+func (mh *MainScreenKeyHandler) Commands() []CommandEntry {
+	keys := make(map[string][]string)
+	seen := make(map[keySpec]bool)
+	for _, binding := range mh.bindings {
+		if seen[binding.spec] {
+			continue
+		}
+		seen[binding.spec] = true
+		keys[binding.command] = append(keys[binding.command], binding.spec.String())
+	}
+	entries := make([]CommandEntry, 0, len(mh.commands))
+	for id := range mh.commands {
+		if id == CommandNoop || id == CommandPaletteShow {
+			continue
+		}
+		entries = append(entries, CommandEntry{ID: id, Keys: keys[id]})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+	return entries
+}
+
+// RunCommand executes a command by ID as if an unmodified key bound to it
+// was pressed; commands that change the input owner still go through the
+// transition gate. It reports false for unknown or skipped commands.
+func (mh *MainScreenKeyHandler) RunCommand(commandID string) bool {
+	return mh.executeCommand(commandID, mh.commandContext("", ModifierState{}))
+}
+
 func (mh *MainScreenKeyHandler) executeCommand(commandID string, ctx CommandContext) bool {
 	command, ok := mh.commands[commandID]
 	if !ok {
@@ -318,41 +425,77 @@ func (mh *MainScreenKeyHandler) buildBindings(configured []config.KeyBindingEntr
 }
 
 func defaultMainScreenBindings() []config.KeyBindingEntry {
-	return []config.KeyBindingEntry{
+	bindings := []config.KeyBindingEntry{
 		{Key: "Up", Command: CommandCursorUp},
 		{Key: "S-Up", Command: CommandCursorPageUp},
 		{Key: "Down", Command: CommandCursorDown},
 		{Key: "S-Down", Command: CommandCursorPageDown},
 		{Key: "Return", Command: CommandOpen},
 		{Key: "S-Return", Command: CommandOpenDefaultApp},
+		{Key: "C-Return", Command: CommandOpenWithMenu},
 		{Key: "Space", Command: CommandSelectToggle},
 		{Key: "C-A", Command: CommandSelectAll},
 		{Key: "I", Command: CommandSelectInvert},
 		{Key: "S-I", Command: CommandSelectInvertWithDir},
+		{Key: "Plus", Command: CommandSelectByPattern},
+		{Key: "S-Equal", Command: CommandSelectByPattern},
+		{Key: "Minus", Command: CommandSelectClear},
 		{Key: "Backspace", Command: CommandParentDirectory},
 		{Key: "S-Comma", Command: CommandCursorFirst},
 		{Key: "Period", Command: CommandRefresh},
+		{Key: "A-R", Command: CommandAutoRefreshToggle},
 		{Key: "S-Period", Command: CommandCursorLast},
 		{Key: "S-Backtick", Command: CommandHome},
 		{Key: "K", Command: CommandDirectoryCreate},
+		{Key: "S-K", Command: CommandFileCreate},
+		{Key: "S-N", Command: CommandDirectoryNote},
+		{Key: "S-D", Command: CommandDirectorySize},
+		{Key: "A-1", Command: CommandColorTagRed},
+		{Key: "A-2", Command: CommandColorTagOrange},
+		{Key: "A-3", Command: CommandColorTagYellow},
+		{Key: "A-4", Command: CommandColorTagGreen},
+		{Key: "A-5", Command: CommandColorTagBlue},
+		{Key: "A-6", Command: CommandColorTagPurple},
+		{Key: "A-7", Command: CommandColorTagGray},
+		{Key: "A-0", Command: CommandColorTagClear},
 		{Key: "P", Command: CommandClipboardTextFile},
+		{Key: "C-S-C", Command: CommandCopyPath},
 		{Key: "F2", Command: CommandRenameShow},
 		{Key: "R", Command: CommandRenameShow},
+		{Key: "S-R", Command: CommandRenameBatch},
 		{Key: "Left", Command: CommandWindowFocusLeft},
 		{Key: "Right", Command: CommandWindowFocusRight},
 		{Key: "S-Q", Command: CommandWindowResetSize},
 		{Key: "C-S-Q", Command: CommandWindowResetAllSizes},
 		{Key: "Tab", Command: CommandExplorerContextShow},
+		{Key: "S-Tab", Command: CommandSendToMenu},
 		{Key: "F3", Command: CommandFilterToggle},
+		{Key: "A-P", Command: CommandPreviewPaneToggle},
+		{Key: "A-D", Command: CommandTreePaneToggle},
+		{Key: "A-T", Command: CommandThumbnailsToggle},
+		{Key: "C-Period", Command: CommandHiddenFilesToggle},
+		{Key: "A-I", Command: CommandGitIgnoredToggle},
 		{Key: "Q", Command: CommandQuit},
 		{Key: "C", Command: CommandCopyShow},
 		{Key: "U", Command: CommandArchiveExtract},
+		{Key: "S-U", Command: CommandArchiveCreate},
 		{Key: "S-C", Command: CommandCompareShow},
+		{Key: "S-H", Command: CommandChecksumMenu},
 		{Key: "M", Command: CommandMoveShow},
 		{Key: "X", Command: CommandExternalCommandMenu},
 		{Key: "V", Command: CommandViewerShow},
+		{Key: "S-V", Command: CommandVolumesMenu},
+		{Key: "C-R", Command: CommandConnectionMenu},
+		{Key: "C-`", Command: CommandTerminalOpen},
+		{Key: "S-1", Command: CommandShellRun},
+		{Key: "S-Space", Command: CommandQuickLook},
 		{Key: "C-N", Command: CommandWindowNew},
-		{Key: "C-T", Command: CommandTreeShow},
+		{Key: "C-S-N", Command: CommandClosedReopen},
+		{Key: "T", Command: CommandTreeShow},
+		{Key: "C-T", Command: CommandTabNew},
+		{Key: "C-W", Command: CommandTabClose},
+		{Key: "C-Tab", Command: CommandTabNext},
+		{Key: "C-S-Tab", Command: CommandTabPrevious},
 		{Key: "C-H", Command: CommandHistoryShow},
 		{Key: "S-B", Command: CommandHistoryPinCurrent},
 		{Key: "C-F", Command: CommandFilterShow},
@@ -361,13 +504,39 @@ func defaultMainScreenBindings() []config.KeyBindingEntry {
 		{Key: "C-L", Command: CommandPathEdit},
 		{Key: "S-J", Command: CommandJobsShow},
 		{Key: "J", Command: CommandDirectoryJumpShow},
+		{Key: "C-B", Command: CommandBookmarksShow},
+		{Key: "C-P", Command: CommandPlacesShow},
 		{Key: "Delete", Command: CommandDeleteTrash},
 		{Key: "S-Delete", Command: CommandDeletePermanent},
+		{Key: "C-S-Delete", Command: CommandDeleteSecure},
+		{Key: "A-Return", Command: CommandPropertiesShow},
+		{Key: "C-S-P", Command: CommandPaletteShow},
 	}
+	// Slot jumps would sit on A-1..A-9, but A-0..A-7 set color tags, so
+	// they use Ctrl; C-A-N sets a slot either way.
+	for n := 1; n <= config.MaxBookmarkHotkey; n++ {
+		digit := strconv.Itoa(n)
+		bindings = append(bindings,
+			config.KeyBindingEntry{Key: "C-" + digit, Command: BookmarkJumpCommand(n)},
+			config.KeyBindingEntry{Key: "C-A-" + digit, Command: BookmarkSetCommand(n)},
+		)
+	}
+	return bindings
+}
+
+// BookmarkJumpCommand returns the command that jumps to bookmark slot n.
+func BookmarkJumpCommand(n int) string {
+	return CommandBookmarkJumpPrefix + strconv.Itoa(n)
+}
+
+// BookmarkSetCommand returns the command that puts the current directory
+// into bookmark slot n.
+func BookmarkSetCommand(n int) string {
+	return CommandBookmarkSetPrefix + strconv.Itoa(n)
 }
 
 func (mh *MainScreenKeyHandler) defaultCommands() map[string]commandSpec {
-	return map[string]commandSpec{
+	commands := map[string]commandSpec{
 		CommandCursorUp:            {fn: mh.cursorUp},
 		CommandCursorDown:          {fn: mh.cursorDown},
 		CommandCursorPageUp:        {fn: mh.cursorPageUp},
@@ -380,11 +549,22 @@ func (mh *MainScreenKeyHandler) defaultCommands() map[string]commandSpec {
 		CommandSelectAll:           {fn: mh.selectAll},
 		CommandSelectInvert:        {fn: func(CommandContext) { mh.invertSelection(false) }},
 		CommandSelectInvertWithDir: {fn: func(CommandContext) { mh.invertSelection(true) }},
+		CommandSelectByPattern: {fn: func(CommandContext) {
+			mh.showDialogAction("ShowSelectByPatternDialog", mh.actions.ShowSelectByPatternDialog)
+		}, transition: true},
+		CommandSelectClear:         {fn: mh.clearSelection},
 		CommandParentDirectory:     {fn: mh.parentDirectory},
 		CommandRefresh:             {fn: mh.refreshDirectory},
+		CommandAutoRefreshToggle:   {fn: func(CommandContext) { mh.fileManager.ToggleAutoRefresh() }},
+		CommandTerminalOpen:        {fn: func(CommandContext) { mh.fileManager.OpenTerminalHere() }},
 		CommandHome:                {fn: mh.homeDirectory},
 		CommandWindowNew:           {fn: func(CommandContext) { mh.fileManager.OpenNewWindow() }, transition: true},
 		CommandWindowReopen:        {fn: func(CommandContext) { mh.fileManager.ReopenClosedWindow() }, transition: true},
+		CommandClosedReopen:        {fn: func(CommandContext) { mh.fileManager.ReopenClosed() }, transition: true},
+		CommandTabNew:              {fn: func(CommandContext) { mh.fileManager.NewTab() }},
+		CommandTabClose:            {fn: func(CommandContext) { mh.fileManager.CloseTab() }},
+		CommandTabNext:             {fn: func(CommandContext) { mh.fileManager.SwitchTab(1) }},
+		CommandTabPrevious:         {fn: func(CommandContext) { mh.fileManager.SwitchTab(-1) }},
 		CommandWindowFocusLeft:     {fn: func(CommandContext) { mh.fileManager.FocusWindowLeft() }, transition: true},
 		CommandWindowFocusRight:    {fn: func(CommandContext) { mh.fileManager.FocusWindowRight() }, transition: true},
 		CommandWindowResetSize:     {fn: func(CommandContext) { mh.fileManager.ResetWindowSize() }},
@@ -399,9 +579,20 @@ func (mh *MainScreenKeyHandler) defaultCommands() map[string]commandSpec {
 		CommandDirectoryJumpShow: {fn: func(CommandContext) {
 			mh.showDialogAction("ShowDirectoryJumpDialog", mh.actions.ShowDirectoryJumpDialog)
 		}, transition: true},
-		CommandFilterShow:   {fn: func(CommandContext) { mh.showDialogAction("ShowFilterDialog", mh.actions.ShowFilterDialog) }, transition: true},
-		CommandFilterClear:  {fn: func(CommandContext) { mh.fileManager.ClearFilter() }},
-		CommandFilterToggle: {fn: func(CommandContext) { mh.fileManager.ToggleFilter() }},
+		CommandBookmarksShow: {fn: func(CommandContext) {
+			mh.showDialogAction("ShowBookmarksDialog", mh.actions.ShowBookmarksDialog)
+		}, transition: true},
+		CommandPlacesShow: {fn: func(CommandContext) {
+			mh.showDialogAction("ShowPlacesDialog", mh.actions.ShowPlacesDialog)
+		}, transition: true},
+		CommandFilterShow:        {fn: func(CommandContext) { mh.showDialogAction("ShowFilterDialog", mh.actions.ShowFilterDialog) }, transition: true},
+		CommandFilterClear:       {fn: func(CommandContext) { mh.fileManager.ClearFilter() }},
+		CommandFilterToggle:      {fn: func(CommandContext) { mh.fileManager.ToggleFilter() }},
+		CommandPreviewPaneToggle: {fn: func(CommandContext) { mh.fileManager.TogglePreviewPane() }},
+		CommandTreePaneToggle:    {fn: func(CommandContext) { mh.fileManager.ToggleTreePane() }},
+		CommandThumbnailsToggle:  {fn: func(CommandContext) { mh.fileManager.ToggleThumbnails() }},
+		CommandHiddenFilesToggle: {fn: func(CommandContext) { mh.fileManager.ToggleHiddenFiles() }},
+		CommandGitIgnoredToggle:  {fn: func(CommandContext) { mh.fileManager.ToggleGitIgnored() }},
 		CommandSearchShow: {fn: func(CommandContext) {
 			mh.showDialogAction("ShowIncrementalSearchDialog", mh.actions.ShowIncrementalSearchDialog)
 		}, transition: true},
@@ -411,9 +602,25 @@ func (mh *MainScreenKeyHandler) defaultCommands() map[string]commandSpec {
 		CommandDirectoryCreate: {fn: func(CommandContext) {
 			mh.showDialogAction("ShowCreateDirectoryDialog", mh.actions.ShowCreateDirectoryDialog)
 		}, transition: true},
+		CommandFileCreate: {fn: func(CommandContext) {
+			mh.showDialogAction("ShowCreateFileDialog", mh.actions.ShowCreateFileDialog)
+		}, transition: true},
+		CommandDirectoryNote: {fn: func(CommandContext) {
+			mh.showDialogAction("ShowDirectoryNoteDialog", mh.actions.ShowDirectoryNoteDialog)
+		}, transition: true},
+		CommandColorTagRed:    {fn: mh.colorTag(fileinfo.ColorTagRed)},
+		CommandColorTagOrange: {fn: mh.colorTag(fileinfo.ColorTagOrange)},
+		CommandColorTagYellow: {fn: mh.colorTag(fileinfo.ColorTagYellow)},
+		CommandColorTagGreen:  {fn: mh.colorTag(fileinfo.ColorTagGreen)},
+		CommandColorTagBlue:   {fn: mh.colorTag(fileinfo.ColorTagBlue)},
+		CommandColorTagPurple: {fn: mh.colorTag(fileinfo.ColorTagPurple)},
+		CommandColorTagGray:   {fn: mh.colorTag(fileinfo.ColorTagGray)},
+		CommandColorTagClear:  {fn: mh.colorTag(fileinfo.ColorTagNone)},
+		CommandDirectorySize:  {fn: func(CommandContext) { mh.fileManager.CalculateDirectorySizes() }},
 		CommandClipboardTextFile: {fn: func(CommandContext) {
 			mh.showDialogAction("ShowClipboardTextFileDialog", mh.actions.ShowClipboardTextFileDialog)
 		}, transition: true},
+		CommandCopyPath: {fn: mh.copyPaths},
 		CommandQuit:     {fn: func(CommandContext) { mh.fileManager.QuitApplication() }, transition: true},
 		CommandCopyShow: {fn: func(CommandContext) { mh.showDialogAction("ShowCopyDialog", mh.actions.ShowCopyDialog) }, transition: true},
 		CommandMoveShow: {fn: func(CommandContext) { mh.showDialogAction("ShowMoveDialog", mh.actions.ShowMoveDialog) }, transition: true},
@@ -422,20 +629,70 @@ func (mh *MainScreenKeyHandler) defaultCommands() map[string]commandSpec {
 		CommandArchiveExtract: {fn: func(CommandContext) {
 			mh.showDialogAction("ShowExtractArchiveDialog", mh.actions.ShowExtractArchiveDialog)
 		}, transition: true},
-		CommandCompareShow:     {fn: func(CommandContext) { mh.showDialogAction("ShowCompareDialog", mh.actions.ShowCompareDialog) }, transition: true},
-		CommandRenameShow:      {fn: mh.rename, transition: true},
+		CommandArchiveCreate: {fn: func(CommandContext) {
+			mh.showDialogAction("ShowCompressDialog", mh.actions.ShowCompressDialog)
+		}, transition: true},
+		CommandCompareShow: {fn: func(CommandContext) { mh.showDialogAction("ShowCompareDialog", mh.actions.ShowCompareDialog) }, transition: true},
+		CommandRenameShow:  {fn: mh.rename, transition: true},
+		CommandRenameBatch: {fn: func(CommandContext) {
+			mh.showDialogAction("ShowBatchRenameDialog", mh.actions.ShowBatchRenameDialog)
+		}, transition: true},
 		CommandDeleteTrash:     {fn: func(CommandContext) { mh.showDeleteDialog(false) }, transition: true},
 		CommandDeletePermanent: {fn: func(CommandContext) { mh.showDeleteDialog(true) }, transition: true},
+		CommandDeleteSecure: {fn: func(CommandContext) {
+			mh.showDialogAction("ShowSecureDeleteDialog", mh.actions.ShowSecureDeleteDialog)
+		}, transition: true},
+		CommandChecksumMenu: {fn: func(CommandContext) {
+			mh.showDialogAction("ShowChecksumMenu", mh.actions.ShowChecksumMenu)
+		}, transition: true},
+		CommandAuditLogShow: {fn: func(CommandContext) { mh.showDialogAction("ShowAuditLog", mh.actions.ShowAuditLog) }, transition: true},
+		CommandAuditLogExport: {fn: func(CommandContext) {
+			mh.showDialogAction("ShowExportAuditLogDialog", mh.actions.ShowExportAuditLogDialog)
+		}, transition: true},
+		CommandListingExport: {fn: func(CommandContext) {
+			mh.showDialogAction("ShowExportListingDialog", mh.actions.ShowExportListingDialog)
+		}, transition: true},
 		CommandExplorerContextShow: {fn: func(CommandContext) {
 			mh.showDialogAction("ShowExplorerContextMenu", mh.actions.ShowExplorerContextMenu)
 		}, transition: true},
 		CommandExternalCommandMenu: {fn: func(CommandContext) {
 			mh.showDialogAction("ShowExternalCommandMenu", mh.actions.ShowExternalCommandMenu)
 		}, transition: true},
+		CommandSendToMenu: {fn: func(CommandContext) {
+			mh.showDialogAction("ShowSendToMenu", mh.actions.ShowSendToMenu)
+		}, transition: true},
+		CommandOpenWithMenu: {fn: func(CommandContext) {
+			mh.showDialogAction("ShowOpenWithMenu", mh.actions.ShowOpenWithMenu)
+		}, transition: true},
+		CommandVolumesMenu: {fn: func(CommandContext) {
+			mh.showDialogAction("ShowVolumesMenu", mh.actions.ShowVolumesMenu)
+		}, transition: true},
+		CommandConnectionMenu: {fn: func(CommandContext) {
+			mh.showDialogAction("ShowConnectionMenu", mh.actions.ShowConnectionMenu)
+		}, transition: true},
+		CommandCredentialsShow: {fn: func(CommandContext) {
+			mh.showDialogAction("ShowCredentialsDialog", mh.actions.ShowCredentialsDialog)
+		}, transition: true},
+		CommandShellRun: {fn: func(CommandContext) {
+			mh.showDialogAction("ShowRunCommandDialog", mh.actions.ShowRunCommandDialog)
+		}, transition: true},
 		CommandViewerShow:      {fn: func(CommandContext) { mh.showDialogAction("ShowFileViewer", mh.actions.ShowFileViewer) }, transition: true},
+		CommandQuickLook:       {fn: func(CommandContext) { mh.showDialogAction("ShowQuickLook", mh.actions.ShowQuickLook) }, transition: true},
 		CommandMaintenanceShow: {fn: func(CommandContext) { mh.showDialogAction("ShowMaintenanceDialog", mh.actions.ShowMaintenanceDialog) }, transition: true},
+		CommandPropertiesShow:  {fn: func(CommandContext) { mh.showDialogAction("ShowPropertiesDialog", mh.actions.ShowPropertiesDialog) }, transition: true},
+		CommandPaletteShow:     {fn: func(CommandContext) { mh.showDialogAction("ShowCommandPalette", mh.actions.ShowCommandPalette) }, transition: true},
 		CommandNoop:            {fn: func(CommandContext) {}},
 	}
+	for n := 1; n <= config.MaxBookmarkHotkey; n++ {
+		n := n
+		commands[BookmarkJumpCommand(n)] = commandSpec{fn: func(CommandContext) { mh.fileManager.JumpToBookmarkSlot(n) }}
+		commands[BookmarkSetCommand(n)] = commandSpec{fn: func(CommandContext) { mh.fileManager.SetBookmarkSlot(n) }, transition: true}
+	}
+	return commands
+}
+
+func (mh *MainScreenKeyHandler) colorTag(tag fileinfo.ColorTag) CommandFunc {
+	return func(CommandContext) { mh.fileManager.SetColorTag(tag) }
 }
 
 // showDialogAction invokes a no-argument UI-launcher closure from
@@ -564,6 +821,47 @@ func (mh *MainScreenKeyHandler) selectAll(CommandContext) {
 	}
 }
 
+// copyPaths puts the paths of the marked entries, or of the cursor entry when
+// none are marked, on the clipboard one per line, the targets copy.show and
+// move.show would act on.
+func (mh *MainScreenKeyHandler) copyPaths(ctx CommandContext) {
+	if ctx.SetClipboard == nil {
+		mh.debugPrint("MainScreen: WARNING clipboard unavailable for %s", CommandCopyPath)
+		return
+	}
+	var paths []string
+	selectedFiles := mh.fileManager.GetSelectedFiles()
+	for _, fileInfo := range mh.fileManager.GetFiles() {
+		if selectedFiles[fileInfo.Path] && fileInfo.Name != ".." && fileInfo.Status != fileinfo.StatusDeleted {
+			paths = append(paths, fileInfo.Path)
+		}
+	}
+	if len(paths) == 0 {
+		fileInfo, ok := mh.fileManager.FileAt(mh.fileManager.GetCurrentCursorIndex())
+		if !ok || fileInfo.Name == ".." || fileInfo.Status == fileinfo.StatusDeleted {
+			return
+		}
+		paths = append(paths, fileInfo.Path)
+	}
+	ctx.SetClipboard(strings.Join(paths, "\n"))
+}
+
+// clearSelection unmarks the visible entries. Like selectAll it follows the
+// active filter; marks on filtered-out entries are not operation targets.
+func (mh *MainScreenKeyHandler) clearSelection(CommandContext) {
+	changed := false
+	selectedFiles := mh.fileManager.GetSelectedFiles()
+	for _, fileInfo := range mh.fileManager.GetFiles() {
+		if selectedFiles[fileInfo.Path] {
+			mh.fileManager.SetFileSelected(fileInfo.Path, false)
+			changed = true
+		}
+	}
+	if changed {
+		mh.fileManager.RefreshFileList()
+	}
+}
+
 func (mh *MainScreenKeyHandler) invertSelection(includeDirectories bool) {
 	changed := false
 	selectedFiles := mh.fileManager.GetSelectedFiles()
@@ -0,0 +1,22 @@
+package keymanager
+
+// OrganizePreviewDialogInterface defines keyboard actions for the
+// organize-by-date dry-run preview.
+type OrganizePreviewDialogInterface interface {
+	ConfirmOrganize()
+	CancelOrganize()
+}
+
+// OrganizePreviewDialogKeyHandler handles keyboard events for the organize
+// preview dialog.
+type OrganizePreviewDialogKeyHandler struct {
+	*dialogKeyHandler
+}
+
+func NewOrganizePreviewDialogKeyHandler(d OrganizePreviewDialogInterface) *OrganizePreviewDialogKeyHandler {
+	base := newDialogKeyHandler("OrganizePreviewDialog", nil, []dialogBinding{
+		{"Return", d.ConfirmOrganize},
+		{"Escape", d.CancelOrganize},
+	})
+	return &OrganizePreviewDialogKeyHandler{dialogKeyHandler: base}
+}
@@ -0,0 +1,33 @@
+package keymanager
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+)
+
+type fakeOrganizePreviewDialog struct {
+	confirmed int
+	cancelled int
+}
+
+func (f *fakeOrganizePreviewDialog) ConfirmOrganize() { f.confirmed++ }
+func (f *fakeOrganizePreviewDialog) CancelOrganize()  { f.cancelled++ }
+
+func TestOrganizePreviewDialogHandlerConfirmAndCancel(t *testing.T) {
+	dialog := &fakeOrganizePreviewDialog{}
+	handler := NewOrganizePreviewDialogKeyHandler(dialog)
+
+	if handler.GetName() != "OrganizePreviewDialog" {
+		t.Fatalf("GetName() = %q, want %q", handler.GetName(), "OrganizePreviewDialog")
+	}
+	if !handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyReturn}, ModifierState{}) {
+		t.Fatal("Return should be handled")
+	}
+	if !handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyEscape}, ModifierState{}) {
+		t.Fatal("Escape should be handled")
+	}
+	if dialog.confirmed != 1 || dialog.cancelled != 1 {
+		t.Fatalf("confirmed=%d cancelled=%d, want 1 each", dialog.confirmed, dialog.cancelled)
+	}
+}
@@ -0,0 +1,56 @@
+package keymanager
+
+import (
+	"unicode"
+)
+
+// PlacesDialogInterface defines the interface needed by PlacesDialogKeyHandler.
+type PlacesDialogInterface interface {
+	MoveUp()
+	MoveDown()
+	MoveToTop()
+	MoveToBottom()
+
+	ClearSearch()
+	AppendToSearch(char string)
+	BackspaceSearch()
+
+	AcceptSelection()
+	CancelDialog()
+}
+
+// PlacesDialogKeyHandler handles keyboard events for the places dialog.
+type PlacesDialogKeyHandler struct {
+	*dialogKeyHandler
+}
+
+// NewPlacesDialogKeyHandler creates a new places dialog key handler.
+func NewPlacesDialogKeyHandler(d PlacesDialogInterface, debugPrint func(format string, args ...interface{})) *PlacesDialogKeyHandler {
+	base := newDialogKeyHandler("PlacesDialog", debugPrint, []dialogBinding{
+		{"C-H", d.BackspaceSearch},
+
+		{"Up", d.MoveUp},
+		{"C-P", d.MoveUp},
+		{"S-Up", d.MoveToTop},
+		{"Down", d.MoveDown},
+		{"C-N", d.MoveDown},
+		{"S-Down", d.MoveToBottom},
+
+		{"Return", d.AcceptSelection},
+		{"Escape", d.CancelDialog},
+		{"Backspace", d.BackspaceSearch},
+		// Plain Delete only: Shift+Delete arrives as a folded Cut shortcut and
+		// has no binding here, so it falls through unmatched.
+		{"Delete", d.ClearSearch},
+	}).withRune(func(r rune, modifiers ModifierState) bool {
+		if modifiers.AltPressed {
+			return true
+		}
+		if unicode.IsPrint(r) && !unicode.IsControl(r) {
+			d.AppendToSearch(string(r))
+			return true
+		}
+		return false
+	})
+	return &PlacesDialogKeyHandler{dialogKeyHandler: base}
+}
@@ -0,0 +1,30 @@
+package keymanager
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+)
+
+func TestPlacesDialogHandlerFiltersAndCtrlPMovesUp(t *testing.T) {
+	dialog := &fakeFilterSearchDialog{}
+	handler := NewPlacesDialogKeyHandler(dialog, func(string, ...interface{}) {})
+
+	if handler.GetName() != "PlacesDialog" {
+		t.Fatalf("GetName() = %q, want %q", handler.GetName(), "PlacesDialog")
+	}
+	if !handler.OnTypedRune('d', ModifierState{}) || dialog.search != "d" {
+		t.Fatalf("search = %q, want d", dialog.search)
+	}
+	// Ctrl+P opens the dialog, so pressing it again steps through the list
+	// instead of typing.
+	if !handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyP}, ModifierState{CtrlPressed: true}) {
+		t.Fatal("Ctrl+P should be handled")
+	}
+	if !handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyBackspace}, ModifierState{}) || dialog.backspace != 1 {
+		t.Fatalf("backspace count = %d, want 1", dialog.backspace)
+	}
+	if !handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyReturn}, ModifierState{}) {
+		t.Fatal("Return should be handled")
+	}
+}
@@ -18,8 +18,14 @@ type SortDialogInterface interface {
 	SetSortBySize()
 	SetSortByModified()
 	SetSortByExtension()
+	SetSortByDateTaken()
+	SetSortByTag()
 	ToggleSortOrder()
 	ToggleDirectoriesFirst()
+	ToggleNatural()
+	ToggleLocale()
+	ToggleGroupByDateTaken()
+	ToggleDirectoryOnly()
 }
 
 // SortDialogKeyHandler handles keyboard events for the sort configuration dialog
@@ -49,6 +55,8 @@ func NewSortDialogHandler(sortDialog SortDialogInterface,
 		{"2", sortDialog.SetSortBySize},
 		{"3", sortDialog.SetSortByModified},
 		{"4", sortDialog.SetSortByExtension},
+		{"5", sortDialog.SetSortByDateTaken},
+		{"6", sortDialog.SetSortByTag},
 	}).withRune(func(r rune, modifiers ModifierState) bool {
 		switch r {
 		case 'o', 'O':
@@ -59,6 +67,22 @@ func NewSortDialogHandler(sortDialog SortDialogInterface,
 			// D: toggle directories first
 			sortDialog.ToggleDirectoriesFirst()
 			return true
+		case 'n', 'N':
+			// N: toggle natural numbers
+			sortDialog.ToggleNatural()
+			return true
+		case 'l', 'L':
+			// L: toggle locale order
+			sortDialog.ToggleLocale()
+			return true
+		case 'g', 'G':
+			// G: toggle grouping by date taken
+			sortDialog.ToggleGroupByDateTaken()
+			return true
+		case 'r', 'R':
+			// R: toggle saving for this directory only
+			sortDialog.ToggleDirectoryOnly()
+			return true
 		}
 		return false
 	})
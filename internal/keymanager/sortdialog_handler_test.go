@@ -16,8 +16,14 @@ type fakeSortDialog struct {
 	bySize      int
 	byModified  int
 	byExt       int
+	byTaken     int
+	byTag       int
 	orderToggle int
 	dirsToggle  int
+	natToggle   int
+	locToggle   int
+	groupToggle int
+	dirOnly     int
 }
 
 func (f *fakeSortDialog) MoveToPreviousField()    { f.prevField++ }
@@ -29,8 +35,14 @@ func (f *fakeSortDialog) SetSortByName()          { f.byName++ }
 func (f *fakeSortDialog) SetSortBySize()          { f.bySize++ }
 func (f *fakeSortDialog) SetSortByModified()      { f.byModified++ }
 func (f *fakeSortDialog) SetSortByExtension()     { f.byExt++ }
+func (f *fakeSortDialog) SetSortByDateTaken()     { f.byTaken++ }
+func (f *fakeSortDialog) SetSortByTag()           { f.byTag++ }
 func (f *fakeSortDialog) ToggleSortOrder()        { f.orderToggle++ }
 func (f *fakeSortDialog) ToggleDirectoriesFirst() { f.dirsToggle++ }
+func (f *fakeSortDialog) ToggleNatural()          { f.natToggle++ }
+func (f *fakeSortDialog) ToggleLocale()           { f.locToggle++ }
+func (f *fakeSortDialog) ToggleGroupByDateTaken() { f.groupToggle++ }
+func (f *fakeSortDialog) ToggleDirectoryOnly()    { f.dirOnly++ }
 
 func TestSortDialogHandlerTabNavigation(t *testing.T) {
 	dialog := &fakeSortDialog{}
@@ -86,6 +98,8 @@ func TestSortDialogHandlerNumberShortcuts(t *testing.T) {
 		{fyne.Key2, func() int { return dialog.bySize }},
 		{fyne.Key3, func() int { return dialog.byModified }},
 		{fyne.Key4, func() int { return dialog.byExt }},
+		{fyne.Key5, func() int { return dialog.byTaken }},
+		{fyne.Key6, func() int { return dialog.byTag }},
 	}
 	for _, tt := range tests {
 		if !handler.OnKeyActivated(&fyne.KeyEvent{Name: tt.key}, ModifierState{}) {
@@ -119,6 +133,24 @@ func TestSortDialogHandlerRuneShortcuts(t *testing.T) {
 		t.Fatalf("dirsToggle = %d, want 2", dialog.dirsToggle)
 	}
 
+	for _, r := range []rune{'n', 'N', 'l', 'L', 'g', 'G'} {
+		if !handler.OnTypedRune(r, ModifierState{}) {
+			t.Fatalf("rune %q should be handled", r)
+		}
+	}
+	if dialog.natToggle != 2 || dialog.locToggle != 2 || dialog.groupToggle != 2 {
+		t.Fatalf("natToggle = %d, locToggle = %d, groupToggle = %d, want 2 each", dialog.natToggle, dialog.locToggle, dialog.groupToggle)
+	}
+
+	for _, r := range []rune{'r', 'R'} {
+		if !handler.OnTypedRune(r, ModifierState{}) {
+			t.Fatalf("rune %q should be handled", r)
+		}
+	}
+	if dialog.dirOnly != 2 {
+		t.Fatalf("dirOnly = %d, want 2", dialog.dirOnly)
+	}
+
 	if handler.OnTypedRune('z', ModifierState{}) {
 		t.Fatal("unrelated rune should not be handled")
 	}
@@ -0,0 +1,22 @@
+package keymanager
+
+// SyncPreviewDialogInterface defines keyboard actions for the directory
+// synchronization preview.
+type SyncPreviewDialogInterface interface {
+	ConfirmSync()
+	CancelSync()
+}
+
+// SyncPreviewDialogKeyHandler handles keyboard events for the sync preview
+// dialog.
+type SyncPreviewDialogKeyHandler struct {
+	*dialogKeyHandler
+}
+
+func NewSyncPreviewDialogKeyHandler(d SyncPreviewDialogInterface) *SyncPreviewDialogKeyHandler {
+	base := newDialogKeyHandler("SyncPreviewDialog", nil, []dialogBinding{
+		{"Return", d.ConfirmSync},
+		{"Escape", d.CancelSync},
+	})
+	return &SyncPreviewDialogKeyHandler{dialogKeyHandler: base}
+}
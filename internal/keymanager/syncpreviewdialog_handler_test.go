@@ -0,0 +1,33 @@
+package keymanager
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+)
+
+type fakeSyncPreviewDialog struct {
+	confirmed int
+	cancelled int
+}
+
+func (f *fakeSyncPreviewDialog) ConfirmSync() { f.confirmed++ }
+func (f *fakeSyncPreviewDialog) CancelSync()  { f.cancelled++ }
+
+func TestSyncPreviewDialogHandlerConfirmAndCancel(t *testing.T) {
+	dialog := &fakeSyncPreviewDialog{}
+	handler := NewSyncPreviewDialogKeyHandler(dialog)
+
+	if handler.GetName() != "SyncPreviewDialog" {
+		t.Fatalf("GetName() = %q, want %q", handler.GetName(), "SyncPreviewDialog")
+	}
+	if !handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyReturn}, ModifierState{}) {
+		t.Fatal("Return should be handled")
+	}
+	if !handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyEscape}, ModifierState{}) {
+		t.Fatal("Escape should be handled")
+	}
+	if dialog.confirmed != 1 || dialog.cancelled != 1 {
+		t.Fatalf("confirmed=%d cancelled=%d, want 1 each", dialog.confirmed, dialog.cancelled)
+	}
+}
@@ -135,6 +135,7 @@ func Apply(state *config.State, result Result) int {
 		if _, exists := state.CursorMemory.Entries[path]; exists {
 			delete(state.CursorMemory.Entries, path)
 			delete(state.CursorMemory.LastUsed, path)
+			delete(state.CursorMemory.ScrollOffsets, path)
 			removed++
 		}
 	}
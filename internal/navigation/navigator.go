@@ -0,0 +1,231 @@
+// Package navigation tracks the directory loads of a file list window.
+package navigation
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// EventKind says what happened to a load.
+type EventKind int
+
+const (
+	// EventStarted is sent when a load begins. A load that supersedes an
+	// unfinished one sends only its own EventStarted.
+	EventStarted EventKind = iota
+	// EventCompleted is sent after the load's listing has been applied.
+	EventCompleted
+	// EventFailed is sent when the path could not be listed. The window
+	// should fall back to Load.Previous.
+	EventFailed
+	// EventCanceled is sent when the active load is canceled before it
+	// applied, such as by Esc on the busy overlay.
+	EventCanceled
+	// EventRestLoaded is sent when a streaming load has merged its last
+	// entries after its first page completed.
+	EventRestLoaded
+)
+
+// Event reports a load transition to observers.
+type Event struct {
+	Kind EventKind
+	Load *Load
+	Err  error // set for EventFailed
+}
+
+// Load is one directory load. Its context is canceled as soon as the load is
+// superseded, canceled, or invalidated, so listing work can stop early.
+type Load struct {
+	ID       uint64
+	Path     string
+	Previous string // directory shown when the load began
+	// Streaming is set once the load's first page has been claimed; the rest
+	// of its entries are still being merged in.
+	Streaming bool
+	ctx       context.Context
+	cancel    context.CancelFunc
+}
+
+// Context returns the load's cancellation context.
+func (l *Load) Context() context.Context {
+	return l.ctx
+}
+
+// Navigator owns which load is current for one window. Only the most recent
+// load may apply its result: Begin cancels the load before it, and Claim
+// refuses loads that are no longer active. This keeps rapid navigation from
+// applying an older listing over a newer one.
+//
+// The zero value is ready to use. Navigator is safe for concurrent use;
+// observers run synchronously on the goroutine that reported the transition,
+// after the lock is released. In nmf that is always the UI goroutine.
+type Navigator struct {
+	mu        sync.Mutex
+	nextID    uint64
+	active    *Load
+	streaming *Load // first page shown, rest still loading
+	observers map[uint64]func(Event)
+	nextObs   uint64
+}
+
+// Observe registers fn for load events and returns a function that removes
+// it again.
+func (n *Navigator) Observe(fn func(Event)) func() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.observers == nil {
+		n.observers = make(map[uint64]func(Event))
+	}
+	n.nextObs++
+	id := n.nextObs
+	n.observers[id] = fn
+	return func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		delete(n.observers, id)
+	}
+}
+
+// Begin starts a load of path from previous, canceling the active load and
+// any streaming load still merging entries.
+func (n *Navigator) Begin(path, previous string) *Load {
+	ctx, cancel := context.WithCancel(context.Background())
+	n.mu.Lock()
+	n.cancelLocked()
+	n.nextID++
+	load := &Load{ID: n.nextID, Path: path, Previous: previous, ctx: ctx, cancel: cancel}
+	n.active = load
+	n.mu.Unlock()
+	n.notify(Event{Kind: EventStarted, Load: load})
+	return load
+}
+
+// IsActive reports whether load is still the current load.
+func (n *Navigator) IsActive(load *Load) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return load != nil && n.active == load
+}
+
+// Pending reports whether a load is in flight or still streaming entries.
+func (n *Navigator) Pending() bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.active != nil || n.streaming != nil
+}
+
+// Stale reports whether load should stop: its context is done, err is a
+// cancellation, or a newer load took over.
+func (n *Navigator) Stale(load *Load, err error) bool {
+	if load.ctx.Err() != nil || errors.Is(err, context.Canceled) {
+		return true
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.active != load && n.streaming != load
+}
+
+// Claim ends load as the active load so its result can be applied. It
+// returns false when load was superseded, canceled, or invalidated, in which
+// case the result must be dropped.
+func (n *Navigator) Claim(load *Load) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if load == nil || n.active != load {
+		return false
+	}
+	n.active = nil
+	load.cancel()
+	return true
+}
+
+// ClaimFirstPage is Claim for a load that shows its first entries before the
+// rest are listed. The load stays cancelable by the next Begin until
+// ClaimRest, and its Streaming flag is set for observers of EventCompleted.
+func (n *Navigator) ClaimFirstPage(load *Load) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if load == nil || n.active != load {
+		return false
+	}
+	n.active = nil
+	n.streaming = load
+	load.Streaming = true
+	return true
+}
+
+// ClaimRest ends a streaming load so its last entries can be applied. It
+// returns false when a newer load took over.
+func (n *Navigator) ClaimRest(load *Load) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if load == nil || n.streaming != load {
+		return false
+	}
+	n.streaming = nil
+	load.cancel()
+	return true
+}
+
+// CompleteRest reports that a streaming load has applied all its entries.
+func (n *Navigator) CompleteRest(load *Load) {
+	n.notify(Event{Kind: EventRestLoaded, Load: load})
+}
+
+// Complete reports that a claimed load's listing has been applied.
+func (n *Navigator) Complete(load *Load) {
+	n.notify(Event{Kind: EventCompleted, Load: load})
+}
+
+// Fail reports that a claimed load could not list its path.
+func (n *Navigator) Fail(load *Load, err error) {
+	n.notify(Event{Kind: EventFailed, Load: load, Err: err})
+}
+
+// Cancel cancels load if it is still active and reports EventCanceled.
+func (n *Navigator) Cancel(load *Load) bool {
+	if !n.Claim(load) {
+		return false
+	}
+	n.notify(Event{Kind: EventCanceled, Load: load})
+	return true
+}
+
+// CancelActive cancels the active load, if any.
+func (n *Navigator) CancelActive() bool {
+	n.mu.Lock()
+	load := n.active
+	n.mu.Unlock()
+	return load != nil && n.Cancel(load)
+}
+
+// Invalidate cancels the active load without reporting an event. A closing
+// window uses it so no observer touches torn-down UI.
+func (n *Navigator) Invalidate() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.cancelLocked()
+}
+
+func (n *Navigator) cancelLocked() {
+	for _, load := range []*Load{n.active, n.streaming} {
+		if load != nil {
+			load.cancel()
+		}
+	}
+	n.active = nil
+	n.streaming = nil
+}
+
+func (n *Navigator) notify(ev Event) {
+	n.mu.Lock()
+	observers := make([]func(Event), 0, len(n.observers))
+	for _, fn := range n.observers {
+		observers = append(observers, fn)
+	}
+	n.mu.Unlock()
+	for _, fn := range observers {
+		fn(ev)
+	}
+}
@@ -0,0 +1,137 @@
+package navigation
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestBeginCancelsPreviousLoad(t *testing.T) {
+	var n Navigator
+
+	first := n.Begin("/a", "")
+	second := n.Begin("/b", "/a")
+
+	if first.ID == second.ID {
+		t.Fatal("load IDs should be unique")
+	}
+	if !errors.Is(first.Context().Err(), context.Canceled) {
+		t.Fatalf("first context error = %v, want context.Canceled", first.Context().Err())
+	}
+	if !n.Stale(first, nil) {
+		t.Fatal("superseded load should be stale")
+	}
+	if n.Stale(second, nil) {
+		t.Fatal("active load should not be stale")
+	}
+	if n.Cancel(first) {
+		t.Fatal("stale cancel should not cancel the active load")
+	}
+	if !n.IsActive(second) {
+		t.Fatal("second load should stay active")
+	}
+}
+
+func TestClaimRejectsStaleAndCanceledLoads(t *testing.T) {
+	var n Navigator
+	var kinds []EventKind
+	n.Observe(func(ev Event) { kinds = append(kinds, ev.Kind) })
+
+	first := n.Begin("/a", "")
+	second := n.Begin("/b", "")
+	if n.Claim(first) {
+		t.Fatal("stale load should not be claimed")
+	}
+	if !n.Claim(second) {
+		t.Fatal("active load should be claimed")
+	}
+	if n.IsActive(second) || n.Claim(second) {
+		t.Fatal("claimed load should no longer be active")
+	}
+
+	third := n.Begin("/c", "/b")
+	if !n.CancelActive() {
+		t.Fatal("CancelActive should cancel the active load")
+	}
+	if n.Claim(third) {
+		t.Fatal("canceled load should not apply a queued UI callback")
+	}
+
+	want := []EventKind{EventStarted, EventStarted, EventStarted, EventCanceled}
+	if len(kinds) != len(want) {
+		t.Fatalf("events = %v, want %v", kinds, want)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Fatalf("events = %v, want %v", kinds, want)
+		}
+	}
+}
+
+func TestInvalidateCancelsSilently(t *testing.T) {
+	var n Navigator
+	load := n.Begin("/a", "")
+	events := 0
+	stop := n.Observe(func(Event) { events++ })
+	defer stop()
+
+	n.Invalidate()
+
+	if !errors.Is(load.Context().Err(), context.Canceled) {
+		t.Fatalf("load context error = %v, want context.Canceled", load.Context().Err())
+	}
+	if n.IsActive(load) || n.Claim(load) {
+		t.Fatal("invalidated load should not be claimable")
+	}
+	if events != 0 {
+		t.Fatalf("events = %d, want none", events)
+	}
+}
+
+func TestStreamingLoadStaysCancelableUntilRest(t *testing.T) {
+	var n Navigator
+	var kinds []EventKind
+	n.Observe(func(ev Event) { kinds = append(kinds, ev.Kind) })
+
+	load := n.Begin("/big", "/")
+	if !n.ClaimFirstPage(load) {
+		t.Fatal("active load should claim its first page")
+	}
+	if !n.Pending() {
+		t.Fatal("streaming load should be pending")
+	}
+	if !load.Streaming || load.Context().Err() != nil || n.Stale(load, nil) {
+		t.Fatal("streaming load should keep running after its first page")
+	}
+	n.Complete(load)
+	if !n.ClaimRest(load) || n.ClaimRest(load) {
+		t.Fatal("streaming load should claim its rest exactly once")
+	}
+	n.CompleteRest(load)
+	if n.Pending() {
+		t.Fatal("no load should be pending after the rest was claimed")
+	}
+
+	want := []EventKind{EventStarted, EventCompleted, EventRestLoaded}
+	if len(kinds) != len(want) {
+		t.Fatalf("events = %v, want %v", kinds, want)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Fatalf("events = %v, want %v", kinds, want)
+		}
+	}
+
+	streaming := n.Begin("/big", "/")
+	n.ClaimFirstPage(streaming)
+	next := n.Begin("/other", "/big")
+	if !errors.Is(streaming.Context().Err(), context.Canceled) || !n.Stale(streaming, nil) {
+		t.Fatal("Begin should cancel a streaming load")
+	}
+	if n.ClaimRest(streaming) {
+		t.Fatal("superseded streaming load should not apply its rest")
+	}
+	if !n.IsActive(next) {
+		t.Fatal("new load should be active")
+	}
+}
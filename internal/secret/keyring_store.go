@@ -2,6 +2,8 @@ package secret
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/99designs/keyring"
 )
@@ -34,19 +36,19 @@ func (s *keyringStore) Get(host, share string) (domain, user, pass string, found
 	}
 	// Store user/domain in item.Description as "domain\user"; password in item.Data
 	desc := item.Description
-	if desc != "" {
-		// parse domain\user or user
-		if i := indexRuneAny(desc, []rune{'\\', ';'}); i >= 0 {
-			domain = desc[:i]
-			user = desc[i+1:]
-		} else {
-			user = desc
-		}
-	}
+	domain, user = splitAccount(desc)
 	pass = string(item.Data)
 	return domain, user, pass, true, nil
 }
 
+// splitAccount parses "domain\user" or "user".
+func splitAccount(desc string) (domain, user string) {
+	if i := indexRuneAny(desc, []rune{'\\', ';'}); i >= 0 {
+		return desc[:i], desc[i+1:]
+	}
+	return "", desc
+}
+
 func (s *keyringStore) Set(host, share, domain, user, pass string) error {
 	desc := user
 	if domain != "" {
@@ -64,6 +66,40 @@ func (s *keyringStore) Delete(host, share string) error {
 	return s.ring.Remove(makeKey(host, share))
 }
 
+// List reads each item for its account, since most backends keep no
+// metadata readable without the secret; the password is dropped. Entries are
+// sorted by host, then share.
+func (s *keyringStore) List() ([]Entry, error) {
+	keys, err := s.ring.Keys()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(keys))
+	for _, key := range keys {
+		host, share, ok := strings.Cut(key, "|")
+		if !ok {
+			continue
+		}
+		item, err := s.ring.Get(key)
+		if err != nil {
+			if err == keyring.ErrKeyNotFound {
+				continue
+			}
+			return nil, err
+		}
+		entry := Entry{Host: host, Share: share}
+		entry.Domain, entry.User = splitAccount(item.Description)
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Host != entries[j].Host {
+			return entries[i].Host < entries[j].Host
+		}
+		return entries[i].Share < entries[j].Share
+	})
+	return entries, nil
+}
+
 // indexRuneAny returns the first index of any rune in targets.
 func indexRuneAny(s string, targets []rune) int {
 	for i, r := range s {
@@ -6,4 +6,14 @@ type Store interface {
 	Get(host, share string) (domain, user, pass string, found bool, err error)
 	Set(host, share, domain, user, pass string) error
 	Delete(host, share string) error
+	// List returns every stored entry without its password.
+	List() ([]Entry, error)
+}
+
+// Entry is one stored host/share and the account saved for it.
+type Entry struct {
+	Host   string
+	Share  string
+	Domain string
+	User   string
 }
@@ -0,0 +1,287 @@
+// Package selection holds the set of marked entries of a file list window.
+package selection
+
+import (
+	"sort"
+	"sync"
+)
+
+// Model is the set of marked paths of one window. It is the single place
+// marks are read and changed: list rows, the status bars, job target
+// collection, tabs, and drag sources all go through it.
+//
+// Model is safe for concurrent use. Observers run synchronously on the
+// goroutine that made a change, after the lock is released, and only when
+// the set actually changed. Read methods accept a nil *Model as empty.
+//
+// The model keeps running Totals of what its marks weigh, so status bars
+// read them without walking the listing. Each mark keeps the weight it was
+// counted with and gives that back when unmarked.
+type Model struct {
+	mu        sync.RWMutex
+	marked    map[string]Totals
+	totals    Totals
+	weigh     Weigher
+	observers map[uint64]func()
+	nextID    uint64
+}
+
+// Totals sums the weights of the marked paths.
+type Totals struct {
+	Entries int
+	Files   int
+	Bytes   int64
+}
+
+func (t Totals) add(o Totals) Totals {
+	return Totals{Entries: t.Entries + o.Entries, Files: t.Files + o.Files, Bytes: t.Bytes + o.Bytes}
+}
+
+func (t Totals) sub(o Totals) Totals {
+	return Totals{Entries: t.Entries - o.Entries, Files: t.Files - o.Files, Bytes: t.Bytes - o.Bytes}
+}
+
+// Weigher returns what marking path adds to the totals. It runs on the
+// goroutine that changes the marks, with the model locked, so it must not
+// call back into the model.
+type Weigher func(path string) Totals
+
+// New returns a model with paths marked.
+func New(paths ...string) *Model {
+	m := &Model{
+		marked:    make(map[string]Totals, len(paths)),
+		observers: make(map[uint64]func()),
+	}
+	for _, p := range paths {
+		m.marked[p] = Totals{}
+	}
+	return m
+}
+
+// SetWeigher makes weigh the source of mark weights and recounts the marks
+// with it. A nil weigher weighs every mark as zero.
+func (m *Model) SetWeigher(weigh Weigher) {
+	m.mu.Lock()
+	m.weigh = weigh
+	m.mu.Unlock()
+	m.Reweigh()
+}
+
+// Reweigh recounts every mark with the weigher, for when what the paths
+// weigh changed, as when the listing behind them was replaced. It costs one
+// weigher call per mark.
+func (m *Model) Reweigh() {
+	m.mu.Lock()
+	var totals Totals
+	for p := range m.marked {
+		w := m.weightLocked(p)
+		m.marked[p] = w
+		totals = totals.add(w)
+	}
+	changed := totals != m.totals
+	m.totals = totals
+	m.mu.Unlock()
+	if changed {
+		m.notify()
+	}
+}
+
+// Totals returns the summed weights of the marks.
+func (m *Model) Totals() Totals {
+	if m == nil {
+		return Totals{}
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.totals
+}
+
+func (m *Model) weightLocked(path string) Totals {
+	if m.weigh == nil {
+		return Totals{}
+	}
+	return m.weigh(path)
+}
+
+func (m *Model) markLocked(path string) {
+	w := m.weightLocked(path)
+	m.marked[path] = w
+	m.totals = m.totals.add(w)
+}
+
+func (m *Model) unmarkLocked(path string) {
+	m.totals = m.totals.sub(m.marked[path])
+	delete(m.marked, path)
+}
+
+// IsMarked reports whether path is marked.
+func (m *Model) IsMarked(path string) bool {
+	if m == nil {
+		return false
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.marked[path]
+	return ok
+}
+
+// Len returns the number of marked paths.
+func (m *Model) Len() int {
+	if m == nil {
+		return 0
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.marked)
+}
+
+// Paths returns the marked paths in sorted order.
+func (m *Model) Paths() []string {
+	if m == nil {
+		return nil
+	}
+	m.mu.RLock()
+	paths := make([]string, 0, len(m.marked))
+	for p := range m.marked {
+		paths = append(paths, p)
+	}
+	m.mu.RUnlock()
+	sort.Strings(paths)
+	return paths
+}
+
+// Snapshot returns a copy of the marks as a path set, the shape the key
+// handlers and tab state use.
+func (m *Model) Snapshot() map[string]bool {
+	if m == nil {
+		return map[string]bool{}
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	snapshot := make(map[string]bool, len(m.marked))
+	for p := range m.marked {
+		snapshot[p] = true
+	}
+	return snapshot
+}
+
+// Set marks or unmarks path and reports whether that changed anything.
+func (m *Model) Set(path string, marked bool) bool {
+	return m.SetMany([]string{path}, marked) > 0
+}
+
+// Toggle flips the mark on path and returns the new state.
+func (m *Model) Toggle(path string) bool {
+	m.mu.Lock()
+	_, was := m.marked[path]
+	if was {
+		m.unmarkLocked(path)
+	} else {
+		m.markLocked(path)
+	}
+	m.mu.Unlock()
+	m.notify()
+	return !was
+}
+
+// SetMany marks or unmarks every path in paths with one notification and
+// returns how many changed.
+func (m *Model) SetMany(paths []string, marked bool) int {
+	m.mu.Lock()
+	changed := 0
+	for _, p := range paths {
+		_, was := m.marked[p]
+		switch {
+		case marked && !was:
+			m.markLocked(p)
+			changed++
+		case !marked && was:
+			m.unmarkLocked(p)
+			changed++
+		}
+	}
+	m.mu.Unlock()
+	if changed > 0 {
+		m.notify()
+	}
+	return changed
+}
+
+// Rename moves a mark from oldPath to newPath, keeping a renamed entry
+// marked with the weight it had. It is a no-op when oldPath is not marked.
+func (m *Model) Rename(oldPath, newPath string) {
+	m.mu.Lock()
+	w, was := m.marked[oldPath]
+	if was {
+		delete(m.marked, oldPath)
+		if old, ok := m.marked[newPath]; ok {
+			m.totals = m.totals.sub(old)
+		}
+		m.marked[newPath] = w
+	}
+	m.mu.Unlock()
+	if was && oldPath != newPath {
+		m.notify()
+	}
+}
+
+// Replace makes paths the whole marked set.
+func (m *Model) Replace(paths []string) {
+	m.mu.Lock()
+	changed := len(m.marked) != len(paths)
+	next := make(map[string]Totals, len(paths))
+	var totals Totals
+	for _, p := range paths {
+		if _, dup := next[p]; dup {
+			continue
+		}
+		w, ok := m.marked[p]
+		if !ok {
+			w = m.weightLocked(p)
+			changed = true
+		}
+		next[p] = w
+		totals = totals.add(w)
+	}
+	m.marked = next
+	m.totals = totals
+	m.mu.Unlock()
+	if changed {
+		m.notify()
+	}
+}
+
+// Clear unmarks everything.
+func (m *Model) Clear() {
+	m.Replace(nil)
+}
+
+// Observe registers fn to run after every change and returns a function
+// that removes it.
+func (m *Model) Observe(fn func()) func() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextID++
+	id := m.nextID
+	m.observers[id] = fn
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			m.mu.Lock()
+			delete(m.observers, id)
+			m.mu.Unlock()
+		})
+	}
+}
+
+func (m *Model) notify() {
+	m.mu.RLock()
+	observers := make([]func(), 0, len(m.observers))
+	for _, fn := range m.observers {
+		observers = append(observers, fn)
+	}
+	m.mu.RUnlock()
+	for _, fn := range observers {
+		fn()
+	}
+}
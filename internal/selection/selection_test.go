@@ -0,0 +1,110 @@
+package selection
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestModelSetManyNotifiesOncePerChange(t *testing.T) {
+	m := New()
+	notified := 0
+	m.Observe(func() { notified++ })
+
+	if got := m.SetMany([]string{"/a", "/b", "/a"}, true); got != 2 {
+		t.Fatalf("SetMany changed = %d, want 2", got)
+	}
+	if notified != 1 {
+		t.Fatalf("notifications = %d, want 1", notified)
+	}
+	if m.SetMany([]string{"/a"}, true) != 0 || notified != 1 {
+		t.Fatalf("re-marking should not notify, notifications = %d", notified)
+	}
+	if !m.Set("/a", false) || notified != 2 {
+		t.Fatalf("unmark should notify, notifications = %d", notified)
+	}
+	if got := m.Paths(); !reflect.DeepEqual(got, []string{"/b"}) {
+		t.Fatalf("Paths() = %v, want [/b]", got)
+	}
+}
+
+func TestModelToggleRenameReplace(t *testing.T) {
+	m := New("/a")
+	if m.Toggle("/a") || m.IsMarked("/a") {
+		t.Fatal("Toggle should unmark /a")
+	}
+	if !m.Toggle("/b") || !m.IsMarked("/b") {
+		t.Fatal("Toggle should mark /b")
+	}
+
+	m.Rename("/b", "/c")
+	if m.IsMarked("/b") || !m.IsMarked("/c") {
+		t.Fatalf("Rename marks = %v, want /c only", m.Paths())
+	}
+	m.Rename("/missing", "/d")
+	if m.IsMarked("/d") {
+		t.Fatal("renaming an unmarked path should not mark the new one")
+	}
+
+	m.Replace([]string{"/x", "/y"})
+	if got := m.Snapshot(); !reflect.DeepEqual(got, map[string]bool{"/x": true, "/y": true}) {
+		t.Fatalf("Snapshot() = %v", got)
+	}
+	m.Clear()
+	if m.Len() != 0 {
+		t.Fatalf("Len() after Clear = %d", m.Len())
+	}
+}
+
+func TestModelObserveCancelAndNilReads(t *testing.T) {
+	m := New()
+	notified := 0
+	cancel := m.Observe(func() { notified++ })
+	m.Set("/a", true)
+	cancel()
+	cancel()
+	m.Set("/b", true)
+	if notified != 1 {
+		t.Fatalf("notifications = %d, want 1 before cancel", notified)
+	}
+
+	var empty *Model
+	if empty.IsMarked("/a") || empty.Len() != 0 || empty.Paths() != nil || len(empty.Snapshot()) != 0 {
+		t.Fatal("nil model should read as empty")
+	}
+}
+
+func TestModelKeepsRunningTotals(t *testing.T) {
+	sizes := map[string]int64{"/a": 10, "/b": 20, "/c": 40}
+	m := New()
+	m.SetWeigher(func(path string) Totals {
+		size, ok := sizes[path]
+		if !ok {
+			return Totals{}
+		}
+		return Totals{Entries: 1, Files: 1, Bytes: size}
+	})
+
+	m.SetMany([]string{"/a", "/b", "/missing"}, true)
+	if got := m.Totals(); got != (Totals{Entries: 2, Files: 2, Bytes: 30}) {
+		t.Fatalf("Totals() after SetMany = %+v", got)
+	}
+	m.Toggle("/a")
+	m.Rename("/b", "/c")
+	if got := m.Totals(); got != (Totals{Entries: 1, Files: 1, Bytes: 20}) {
+		t.Fatalf("Totals() after Toggle and Rename = %+v, want /b's weight kept", got)
+	}
+
+	// Reweighing picks up what the marks weigh now.
+	m.Reweigh()
+	if got := m.Totals(); got != (Totals{Entries: 1, Files: 1, Bytes: 40}) {
+		t.Fatalf("Totals() after Reweigh = %+v", got)
+	}
+	m.Replace([]string{"/a", "/c"})
+	if got := m.Totals(); got != (Totals{Entries: 2, Files: 2, Bytes: 50}) {
+		t.Fatalf("Totals() after Replace = %+v", got)
+	}
+	m.Clear()
+	if got := m.Totals(); got != (Totals{}) {
+		t.Fatalf("Totals() after Clear = %+v", got)
+	}
+}
@@ -0,0 +1,230 @@
+package shellmenu
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// OpenWithHandler is an application that can open a file. Args use the same
+// placeholders as user-defined Open With entries (see ExpandOpenWithArgs).
+type OpenWithHandler struct {
+	Name    string
+	Command string
+	Args    []string
+}
+
+// ExpandOpenWithArgs turns argument templates into one argument list per
+// process to start. %f is one target, so a template using it runs once per
+// target; %F expands to every target as separate arguments in a single run,
+// where %f is the first target; %d is dir and %% a literal percent sign. No
+// templates means "%f".
+func ExpandOpenWithArgs(templates []string, targets []string, dir string) [][]string {
+	if len(templates) == 0 {
+		templates = []string{"%f"}
+	}
+	perTarget := false
+	for _, template := range templates {
+		if strings.Contains(template, "%F") {
+			perTarget = false
+			break
+		}
+		if strings.Contains(template, "%f") {
+			perTarget = true
+		}
+	}
+	if !perTarget {
+		first := ""
+		if len(targets) > 0 {
+			first = targets[0]
+		}
+		return [][]string{expandOpenWithRun(templates, first, targets, dir)}
+	}
+	runs := make([][]string, 0, len(targets))
+	for _, target := range targets {
+		runs = append(runs, expandOpenWithRun(templates, target, targets, dir))
+	}
+	return runs
+}
+
+func expandOpenWithRun(templates []string, target string, targets []string, dir string) []string {
+	var args []string
+	for _, template := range templates {
+		if template == "%F" {
+			args = append(args, targets...)
+			continue
+		}
+		var b strings.Builder
+		for i := 0; i < len(template); i++ {
+			if template[i] != '%' || i+1 == len(template) {
+				b.WriteByte(template[i])
+				continue
+			}
+			i++
+			switch template[i] {
+			case 'f':
+				b.WriteString(target)
+			case 'F':
+				b.WriteString(strings.Join(targets, " "))
+			case 'd':
+				b.WriteString(dir)
+			case '%':
+				b.WriteByte('%')
+			default:
+				b.WriteByte('%')
+				b.WriteByte(template[i])
+			}
+		}
+		args = append(args, b.String())
+	}
+	return args
+}
+
+// desktopEntry is the part of a freedesktop.org .desktop file Open With needs.
+type desktopEntry struct {
+	Name      string
+	Exec      string
+	MimeTypes []string
+}
+
+// parseDesktopEntry reads the [Desktop Entry] group of data. It reports false
+// for entries that are not visible applications.
+func parseDesktopEntry(data []byte) (desktopEntry, bool) {
+	var entry desktopEntry
+	inGroup := false
+	hidden := false
+	isApp := false
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			inGroup = line == "[Desktop Entry]"
+			continue
+		}
+		if !inGroup {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		switch key {
+		case "Type":
+			isApp = value == "Application"
+		case "Name":
+			entry.Name = value
+		case "Exec":
+			entry.Exec = value
+		case "MimeType":
+			for _, mimeType := range strings.Split(value, ";") {
+				if mimeType = strings.TrimSpace(mimeType); mimeType != "" {
+					entry.MimeTypes = append(entry.MimeTypes, mimeType)
+				}
+			}
+		case "NoDisplay", "Hidden":
+			hidden = hidden || value == "true"
+		}
+	}
+	return entry, isApp && !hidden && entry.Name != "" && entry.Exec != ""
+}
+
+// desktopExecArgs splits an Exec value into the command and Open With
+// argument templates. %u/%U are treated as %f/%F because targets are passed
+// as paths; the icon, name, and location codes are dropped.
+func desktopExecArgs(exec string) (string, []string) {
+	fields := splitDesktopExec(exec)
+	if len(fields) == 0 {
+		return "", nil
+	}
+	codes := strings.NewReplacer("%%", "%%", "%u", "%f", "%U", "%F", "%i", "", "%c", "", "%k", "")
+	var args []string
+	for _, field := range fields[1:] {
+		if field == "%i" || field == "%c" || field == "%k" {
+			continue
+		}
+		args = append(args, codes.Replace(field))
+	}
+	return fields[0], args
+}
+
+// splitDesktopExec splits an Exec value on spaces, honouring double quotes
+// and backslash escapes inside them.
+func splitDesktopExec(exec string) []string {
+	var fields []string
+	var b strings.Builder
+	inQuote, hasField := false, false
+	for i := 0; i < len(exec); i++ {
+		c := exec[i]
+		switch {
+		case inQuote && c == '\\' && i+1 < len(exec):
+			i++
+			b.WriteByte(exec[i])
+		case c == '"':
+			inQuote = !inQuote
+			hasField = true
+		case !inQuote && (c == ' ' || c == '\t'):
+			if hasField {
+				fields = append(fields, b.String())
+				b.Reset()
+				hasField = false
+			}
+		default:
+			b.WriteByte(c)
+			hasField = true
+		}
+	}
+	if hasField {
+		fields = append(fields, b.String())
+	}
+	return fields
+}
+
+// desktopHandlers lists the applications below the applications directories
+// dirs that declare mimeType, sorted by name. A desktop file ID found in an
+// earlier directory hides later ones, as XDG_DATA_DIRS precedence requires.
+func desktopHandlers(dirs []string, mimeType string) []OpenWithHandler {
+	seen := make(map[string]bool)
+	var handlers []OpenWithHandler
+	for _, dir := range dirs {
+		filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(d.Name(), ".desktop") {
+				return nil
+			}
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return nil
+			}
+			id := strings.ReplaceAll(filepath.ToSlash(rel), "/", "-")
+			if seen[id] {
+				return nil
+			}
+			seen[id] = true
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil
+			}
+			entry, ok := parseDesktopEntry(data)
+			if !ok || !slices.Contains(entry.MimeTypes, mimeType) {
+				return nil
+			}
+			command, args := desktopExecArgs(entry.Exec)
+			if command == "" {
+				return nil
+			}
+			handlers = append(handlers, OpenWithHandler{Name: entry.Name, Command: command, Args: args})
+			return nil
+		})
+	}
+	sort.SliceStable(handlers, func(i, j int) bool {
+		return strings.ToLower(handlers[i].Name) < strings.ToLower(handlers[j].Name)
+	})
+	return handlers
+}
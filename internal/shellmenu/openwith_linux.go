@@ -0,0 +1,55 @@
+package shellmenu
+
+import (
+	"mime"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// OpenWithHandlers lists the desktop applications registered for the MIME
+// type of path, from the applications directories of XDG_DATA_HOME and
+// XDG_DATA_DIRS.
+func OpenWithHandlers(path string) ([]OpenWithHandler, error) {
+	mimeType := fileMIMEType(path)
+	if mimeType == "" {
+		return nil, nil
+	}
+	return desktopHandlers(applicationDirs(), mimeType), nil
+}
+
+// fileMIMEType asks xdg-mime, which also looks at file contents, and falls
+// back to the extension table.
+func fileMIMEType(path string) string {
+	if out, err := exec.Command("xdg-mime", "query", "filetype", path).Output(); err == nil {
+		if mimeType := strings.TrimSpace(string(out)); mimeType != "" {
+			return mimeType
+		}
+	}
+	mimeType, _, _ := strings.Cut(mime.TypeByExtension(filepath.Ext(path)), ";")
+	return mimeType
+}
+
+func applicationDirs() []string {
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			dataHome = filepath.Join(home, ".local", "share")
+		}
+	}
+	dataDirs := os.Getenv("XDG_DATA_DIRS")
+	if dataDirs == "" {
+		dataDirs = "/usr/local/share:/usr/share"
+	}
+	var dirs []string
+	if dataHome != "" {
+		dirs = append(dirs, filepath.Join(dataHome, "applications"))
+	}
+	for _, dir := range filepath.SplitList(dataDirs) {
+		if dir != "" {
+			dirs = append(dirs, filepath.Join(dir, "applications"))
+		}
+	}
+	return dirs
+}
@@ -0,0 +1,9 @@
+//go:build !linux && !windows
+
+package shellmenu
+
+// OpenWithHandlers lists no system handlers on this platform; only
+// user-defined Open With entries are offered.
+func OpenWithHandlers(path string) ([]OpenWithHandler, error) {
+	return nil, nil
+}
@@ -0,0 +1,68 @@
+package shellmenu
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExpandOpenWithArgs(t *testing.T) {
+	targets := []string{"/w/a.png", "/w/b png.png"}
+	cases := []struct {
+		name      string
+		templates []string
+		want      [][]string
+	}{
+		{"default runs per file", nil, [][]string{{"/w/a.png"}, {"/w/b png.png"}}},
+		{"per file with dir", []string{"--cwd=%d", "%f"}, [][]string{{"--cwd=/w", "/w/a.png"}, {"--cwd=/w", "/w/b png.png"}}},
+		{"all files once", []string{"-n", "%F"}, [][]string{{"-n", "/w/a.png", "/w/b png.png"}}},
+		{"all files wins over file", []string{"%f", "%F"}, [][]string{{"/w/a.png", "/w/a.png", "/w/b png.png"}}},
+		{"no target placeholder", []string{"%d", "100%%"}, [][]string{{"/w", "100%"}}},
+	}
+	for _, tc := range cases {
+		if got := ExpandOpenWithArgs(tc.templates, targets, "/w"); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: ExpandOpenWithArgs(%q) = %q, want %q", tc.name, tc.templates, got, tc.want)
+		}
+	}
+}
+
+func TestDesktopExecArgsConvertsFieldCodes(t *testing.T) {
+	command, args := desktopExecArgs(`"/opt/My App/bin/app" --name %c %i --open=%u "quoted \"arg\"" %U`)
+	if command != "/opt/My App/bin/app" {
+		t.Fatalf("command = %q", command)
+	}
+	want := []string{"--name", "--open=%f", `quoted "arg"`, "%F"}
+	if !reflect.DeepEqual(args, want) {
+		t.Fatalf("args = %q, want %q", args, want)
+	}
+}
+
+func writeDesktopFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDesktopHandlersFiltersByMIMETypeAndPrecedence(t *testing.T) {
+	user, system := t.TempDir(), t.TempDir()
+	writeDesktopFile(t, system, "viewer.desktop", "[Desktop Entry]\nType=Application\nName=System Viewer\nExec=viewer %f\nMimeType=image/png;image/jpeg;\n")
+	writeDesktopFile(t, user, "viewer.desktop", "[Desktop Entry]\nType=Application\nName=My Viewer\nExec=myviewer %U\nMimeType=image/png;\n")
+	writeDesktopFile(t, system, "kde/editor.desktop", "[Desktop Entry]\nType=Application\nName=Editor\nExec=editor %F\nMimeType=image/png;\n[Desktop Action new]\nName=New Window\n")
+	writeDesktopFile(t, system, "hidden.desktop", "[Desktop Entry]\nType=Application\nName=Hidden\nExec=hidden %f\nNoDisplay=true\nMimeType=image/png;\n")
+	writeDesktopFile(t, system, "text.desktop", "[Desktop Entry]\nType=Application\nName=Text\nExec=text %f\nMimeType=text/plain;\n")
+
+	got := desktopHandlers([]string{user, system, filepath.Join(system, "missing")}, "image/png")
+	want := []OpenWithHandler{
+		{Name: "Editor", Command: "editor", Args: []string{"%F"}},
+		{Name: "My Viewer", Command: "myviewer", Args: []string{"%F"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("desktopHandlers = %+v, want %+v", got, want)
+	}
+}
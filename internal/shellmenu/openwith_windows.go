@@ -0,0 +1,11 @@
+package shellmenu
+
+// OpenWithHandlers offers the Windows "Open with" chooser, which lists the
+// registered applications itself.
+func OpenWithHandlers(path string) ([]OpenWithHandler, error) {
+	return []OpenWithHandler{{
+		Name:    "Choose another app...",
+		Command: "rundll32.exe",
+		Args:    []string{"shell32.dll,OpenAs_RunDLL", "%f"},
+	}}, nil
+}
@@ -0,0 +1,26 @@
+package shellmenu
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// SendToTarget is one entry of the Windows SendTo folder: a shortcut, a
+// folder, or a shell drop handler such as "Compressed (zipped) Folder".
+type SendToTarget struct {
+	Name string
+	Path string
+}
+
+// sendToDisplayName hides the extension of SendTo entries, as Explorer does
+// for shortcuts and handler stubs like ".ZFSendToTarget". Folder names are
+// kept whole.
+func sendToDisplayName(name string, isDir bool) string {
+	if isDir {
+		return name
+	}
+	if ext := filepath.Ext(name); ext != "" && len(ext) < len(name) {
+		return strings.TrimSuffix(name, ext)
+	}
+	return name
+}
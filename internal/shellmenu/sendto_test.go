@@ -0,0 +1,21 @@
+package shellmenu
+
+import "testing"
+
+func TestSendToDisplayNameHidesEntryExtensions(t *testing.T) {
+	cases := []struct {
+		name  string
+		isDir bool
+		want  string
+	}{
+		{"Notepad.lnk", false, "Notepad"},
+		{"Compressed (zipped) Folder.ZFSendToTarget", false, "Compressed (zipped) Folder"},
+		{".hidden", false, ".hidden"},
+		{"Backups.old", true, "Backups.old"},
+	}
+	for _, tc := range cases {
+		if got := sendToDisplayName(tc.name, tc.isDir); got != tc.want {
+			t.Fatalf("sendToDisplayName(%q, %t) = %q, want %q", tc.name, tc.isDir, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+//go:build windows
+
+package shellmenu
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var iidIDropTarget = windows.GUID{Data1: 0x00000122, Data2: 0x0000, Data3: 0x0000, Data4: [8]byte{0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}}
+
+type dropTargetVtbl struct {
+	queryInterface uintptr
+	addRef         uintptr
+	release        uintptr
+	dragEnter      uintptr
+	dragOver       uintptr
+	dragLeave      uintptr
+	drop           uintptr
+}
+
+type dropTarget struct {
+	vtbl *dropTargetVtbl
+}
+
+// SendToTargets lists the entries of the user's SendTo folder, sorted by
+// name. Hidden entries and desktop.ini are skipped.
+func SendToTargets() ([]SendToTarget, error) {
+	dir, err := windows.KnownFolderPath(windows.FOLDERID_SendTo, 0)
+	if err != nil {
+		return nil, fmt.Errorf("SendTo folder unavailable: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	targets := make([]SendToTarget, 0, len(entries))
+	for _, entry := range entries {
+		if strings.EqualFold(entry.Name(), "desktop.ini") {
+			continue
+		}
+		if info, err := entry.Info(); err == nil {
+			if data, ok := info.Sys().(*syscall.Win32FileAttributeData); ok && data.FileAttributes&windows.FILE_ATTRIBUTE_HIDDEN != 0 {
+				continue
+			}
+		}
+		targets = append(targets, SendToTarget{
+			Name: sendToDisplayName(entry.Name(), entry.IsDir()),
+			Path: filepath.Join(dir, entry.Name()),
+		})
+	}
+	sort.SliceStable(targets, func(i, j int) bool {
+		return strings.ToLower(targets[i].Name) < strings.ToLower(targets[j].Name)
+	})
+	dbg("SendToTargets dir=%s count=%d", dir, len(targets))
+	return targets, nil
+}
+
+// SendTo drops paths on target the way Explorer's Send To menu does: the
+// target's shell drop handler receives the files as a copy, so shortcuts
+// start their program with the files as arguments and shell handlers such as
+// compressed folders or mail recipients run their own action.
+func SendTo(hwnd uintptr, target SendToTarget, paths []string) error {
+	if hwnd == 0 {
+		return ErrUnsupported
+	}
+	nativePaths := normalizePaths(paths)
+	dbg("SendTo hwnd=%d target=%s sources=%d", hwnd, target.Path, len(nativePaths))
+	if len(nativePaths) == 0 {
+		return nil
+	}
+	if err := ensureSameParent(nativePaths); err != nil {
+		return err
+	}
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	oleInited, err := initializeOLE()
+	if err != nil {
+		return err
+	}
+	if oleInited {
+		defer procOleUninitialize.Call()
+	}
+
+	folder, childPIDLs, absPIDLs, err := shellFolderAndChildren(nativePaths)
+	if err != nil {
+		return err
+	}
+	defer releaseUnknown((*unknown)(unsafe.Pointer(folder)))
+	for _, pidl := range absPIDLs {
+		defer procCoTaskMemFree.Call(pidl)
+	}
+	data, err := shellDataObject(hwnd, folder, childPIDLs)
+	if err != nil {
+		return err
+	}
+	defer releaseUnknown((*unknown)(unsafe.Pointer(data)))
+
+	targetFolder, targetChild, targetPIDLs, err := shellFolderAndChildren([]string{target.Path})
+	if err != nil {
+		return err
+	}
+	defer releaseUnknown((*unknown)(unsafe.Pointer(targetFolder)))
+	defer procCoTaskMemFree.Call(targetPIDLs[0])
+
+	var drop *dropTarget
+	hr, _, _ := syscall.SyscallN(
+		targetFolder.vtbl.getUIObjectOf,
+		uintptr(unsafe.Pointer(targetFolder)),
+		hwnd,
+		1,
+		uintptr(unsafe.Pointer(&targetChild[0])),
+		uintptr(unsafe.Pointer(&iidIDropTarget)),
+		0,
+		uintptr(unsafe.Pointer(&drop)),
+	)
+	if failed(hr) {
+		return fmt.Errorf("%s does not accept files: 0x%x", target.Name, uint32(hr))
+	}
+	defer releaseUnknown((*unknown)(unsafe.Pointer(drop)))
+
+	// POINTL is passed by value: one register on 64-bit, two words on 32-bit.
+	pt := []uintptr{0}
+	if unsafe.Sizeof(uintptr(0)) == 4 {
+		pt = []uintptr{0, 0}
+	}
+	effect := uint32(dropEffectCopy)
+	args := append([]uintptr{uintptr(unsafe.Pointer(drop)), uintptr(unsafe.Pointer(data)), mouseKeyStateLeftButton}, pt...)
+	hr, _, _ = syscall.SyscallN(drop.vtbl.dragEnter, append(args, uintptr(unsafe.Pointer(&effect)))...)
+	dbg("SendTo DragEnter hr=0x%x effect=0x%x", uint32(hr), effect)
+	if failed(hr) || effect == 0 {
+		syscall.SyscallN(drop.vtbl.dragLeave, uintptr(unsafe.Pointer(drop)))
+		return fmt.Errorf("%s does not accept these files", target.Name)
+	}
+	effect = dropEffectCopy
+	hr, _, _ = syscall.SyscallN(drop.vtbl.drop, append(args, uintptr(unsafe.Pointer(&effect)))...)
+	dbg("SendTo Drop hr=0x%x effect=0x%x", uint32(hr), effect)
+	if failed(hr) {
+		return fmt.Errorf("Send To %s failed: 0x%x", target.Name, uint32(hr))
+	}
+	return nil
+}
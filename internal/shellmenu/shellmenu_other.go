@@ -25,3 +25,13 @@ func ShowAtClientPosition(_ uintptr, _ []string, _, _ int) error {
 func StartFileDrag(_ uintptr, _ []string) error {
 	return ErrUnsupported
 }
+
+// SendToTargets lists the user's SendTo folder entries.
+func SendToTargets() ([]SendToTarget, error) {
+	return nil, ErrUnsupported
+}
+
+// SendTo hands paths to a SendTo folder entry.
+func SendTo(_ uintptr, _ SendToTarget, _ []string) error {
+	return ErrUnsupported
+}
@@ -27,3 +27,12 @@ func TestStartFileDragReturnsUnsupportedOffWindows(t *testing.T) {
 		t.Fatalf("StartFileDrag error = %v, want ErrUnsupported", err)
 	}
 }
+
+func TestSendToReturnsUnsupportedOffWindows(t *testing.T) {
+	if _, err := SendToTargets(); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("SendToTargets error = %v, want ErrUnsupported", err)
+	}
+	if err := SendTo(0, SendToTarget{Name: "Mail"}, []string{"/tmp/example"}); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("SendTo error = %v, want ErrUnsupported", err)
+	}
+}
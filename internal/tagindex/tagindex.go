@@ -0,0 +1,273 @@
+// Package tagindex remembers which files carry a color tag so tag:// views
+// can list them without walking the filesystem. The index only learns about
+// directories nmf has listed or files it tagged itself; tags are always
+// re-read from their storage before a view is shown.
+package tagindex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"nmf/internal/fileinfo"
+)
+
+const indexFileVersion = 1
+
+type indexFile struct {
+	Version int                          `json:"version"`
+	Tags    map[string]fileinfo.ColorTag `json:"tags"`
+}
+
+// Index maps display paths to their last known color tag.
+type Index struct {
+	mu      sync.Mutex
+	path    string
+	tags    map[string]fileinfo.ColorTag
+	gen     uint64
+	writeMu sync.Mutex
+	written uint64
+}
+
+// New returns an empty index that is not persisted until Load is called.
+func New() *Index {
+	return &Index{tags: make(map[string]fileinfo.ColorTag)}
+}
+
+// Load reads the index saved at path and keeps path updated afterwards. A
+// missing file starts an empty index; a corrupt one is reported and replaced
+// on the next save.
+func (x *Index) Load(path string) error {
+	var loadErr error
+	var file indexFile
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &file); err != nil {
+			loadErr = fmt.Errorf("error parsing tag index: %w", err)
+			file.Tags = nil
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		loadErr = fmt.Errorf("error reading tag index: %w", err)
+	}
+
+	x.mu.Lock()
+	x.path = path
+	for p, tag := range file.Tags {
+		if tag == fileinfo.ColorTagNone || tag > fileinfo.MaxColorTag {
+			continue
+		}
+		if _, ok := x.tags[p]; !ok {
+			x.tags[p] = tag
+		}
+	}
+	x.mu.Unlock()
+	return loadErr
+}
+
+// Set records tag for p, or forgets p for ColorTagNone.
+func (x *Index) Set(p string, tag fileinfo.ColorTag) {
+	x.mu.Lock()
+	changed := x.setLocked(p, tag)
+	x.mu.Unlock()
+	if changed {
+		x.save()
+	}
+}
+
+// UpdateDirectory replaces everything known about the direct children of dir
+// with the tags in files, which must be a complete listing of dir.
+func (x *Index) UpdateDirectory(dir string, files []fileinfo.FileInfo) {
+	listed := make(map[string]bool, len(files))
+	x.mu.Lock()
+	changed := false
+	for _, fi := range files {
+		if fi.Name == ".." {
+			continue
+		}
+		listed[fi.Path] = true
+		if x.setLocked(fi.Path, fi.ColorTag) {
+			changed = true
+		}
+	}
+	for p := range x.tags {
+		if !listed[p] && fileinfo.ParentPath(p) == dir {
+			delete(x.tags, p)
+			changed = true
+		}
+	}
+	x.mu.Unlock()
+	if changed {
+		x.save()
+	}
+}
+
+func (x *Index) setLocked(p string, tag fileinfo.ColorTag) bool {
+	old, ok := x.tags[p]
+	if tag == fileinfo.ColorTagNone {
+		if ok {
+			delete(x.tags, p)
+		}
+		return ok
+	}
+	x.tags[p] = tag
+	return !ok || old != tag
+}
+
+// Paths returns the indexed paths carrying tag, sorted.
+func (x *Index) Paths(tag fileinfo.ColorTag) []string {
+	x.mu.Lock()
+	paths := make([]string, 0)
+	for p, t := range x.tags {
+		if t == tag {
+			paths = append(paths, p)
+		}
+	}
+	x.mu.Unlock()
+	sort.Strings(paths)
+	return paths
+}
+
+// List returns the entries of a tag:// view. The root lists one directory per
+// tag in use; a tag view lists the tagged files under their real paths, so
+// every file operation works on them unchanged. Entries whose tag changed or
+// that were deleted outside nmf are dropped from the index while listing.
+func (x *Index) List(ctx context.Context, view string) ([]fileinfo.FileInfo, error) {
+	tag, ok := fileinfo.ParseTagViewPath(view)
+	if !ok {
+		return nil, fmt.Errorf("unknown tag view %q", view)
+	}
+	if tag == fileinfo.ColorTagNone {
+		return x.listRoot(), nil
+	}
+
+	byDir := make(map[string][]fileinfo.FileInfo)
+	var dirs []string
+	for _, p := range x.Paths(tag) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		fi, err := fileinfo.FileInfoFromPath(p)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				x.Set(p, fileinfo.ColorTagNone)
+			}
+			continue
+		}
+		dir := fileinfo.ParentPath(p)
+		if _, seen := byDir[dir]; !seen {
+			dirs = append(dirs, dir)
+		}
+		byDir[dir] = append(byDir[dir], fi)
+	}
+
+	files := make([]fileinfo.FileInfo, 0)
+	for _, dir := range dirs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		group := byDir[dir]
+		if err := fileinfo.LoadColorTags(dir, group); err != nil {
+			// Keep the indexed tag when the storage cannot be read right now.
+			for i := range group {
+				group[i].ColorTag = tag
+			}
+		}
+		for _, fi := range group {
+			if fi.ColorTag != tag {
+				x.Set(fi.Path, fi.ColorTag)
+				continue
+			}
+			files = append(files, fi)
+		}
+	}
+	return files, nil
+}
+
+func (x *Index) listRoot() []fileinfo.FileInfo {
+	counts := make(map[fileinfo.ColorTag]int)
+	x.mu.Lock()
+	for _, tag := range x.tags {
+		counts[tag]++
+	}
+	x.mu.Unlock()
+
+	files := make([]fileinfo.FileInfo, 0, len(counts))
+	for tag := fileinfo.ColorTagNone + 1; tag <= fileinfo.MaxColorTag; tag++ {
+		if counts[tag] == 0 {
+			continue
+		}
+		files = append(files, fileinfo.FileInfo{
+			Name:     tag.String(),
+			Path:     fileinfo.TagViewPath(tag),
+			IsDir:    true,
+			FileType: fileinfo.FileTypeDirectory,
+			Status:   fileinfo.StatusNormal,
+			ColorTag: tag,
+		})
+	}
+	return files
+}
+
+// save writes the index when Load enabled persistence. Snapshots are stamped
+// with a generation so a slow writer never replaces a newer file.
+func (x *Index) save() {
+	x.mu.Lock()
+	path := x.path
+	if path == "" {
+		x.mu.Unlock()
+		return
+	}
+	x.gen++
+	gen := x.gen
+	tags := make(map[string]fileinfo.ColorTag, len(x.tags))
+	for p, tag := range x.tags {
+		tags[p] = tag
+	}
+	x.mu.Unlock()
+
+	x.writeMu.Lock()
+	defer x.writeMu.Unlock()
+	if gen <= x.written {
+		return
+	}
+	if err := writeIndexFile(path, tags); err != nil {
+		return
+	}
+	x.written = gen
+}
+
+func writeIndexFile(path string, tags map[string]fileinfo.ColorTag) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("error creating tag index directory: %w", err)
+	}
+	data, err := json.MarshalIndent(indexFile{Version: indexFileVersion, Tags: tags}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling tag index: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, "tag-index-*.json.tmp")
+	if err != nil {
+		return fmt.Errorf("error creating temp tag index file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("error writing temp tag index file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("error closing temp tag index file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("error renaming temp tag index file: %w", err)
+	}
+	return nil
+}
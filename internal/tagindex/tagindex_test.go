@@ -0,0 +1,99 @@
+package tagindex
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"nmf/internal/fileinfo"
+)
+
+func TestUpdateDirectoryAndListView(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	a := filepath.Join(dir, "a.txt")
+	b := filepath.Join(dir, "b.txt")
+	if err := fileinfo.SetColorTag(a, fileinfo.ColorTagRed); err != nil {
+		t.Fatal(err)
+	}
+	if err := fileinfo.SetColorTag(b, fileinfo.ColorTagRed); err != nil {
+		t.Fatal(err)
+	}
+
+	x := New()
+	x.UpdateDirectory(dir, []fileinfo.FileInfo{
+		{Name: "..", Path: filepath.Dir(dir)},
+		{Name: "a.txt", Path: a, ColorTag: fileinfo.ColorTagRed},
+		{Name: "b.txt", Path: b, ColorTag: fileinfo.ColorTagRed},
+		{Name: "c.txt", Path: filepath.Join(dir, "c.txt")},
+	})
+
+	root, err := x.List(context.Background(), "tag://")
+	if err != nil || len(root) != 1 || root[0].Path != "tag://red" || !root[0].IsDir {
+		t.Fatalf("root listing = %+v, %v", root, err)
+	}
+
+	// Tags changed or removed outside nmf are dropped from the view and index.
+	if err := fileinfo.SetColorTag(b, fileinfo.ColorTagNone); err != nil {
+		t.Fatal(err)
+	}
+	files, err := x.List(context.Background(), "tag://red")
+	if err != nil || len(files) != 1 || files[0].Path != a || files[0].Name != "a.txt" {
+		t.Fatalf("red listing = %+v, %v", files, err)
+	}
+	if got := x.Paths(fileinfo.ColorTagRed); len(got) != 1 || got[0] != a {
+		t.Fatalf("Paths after listing = %v", got)
+	}
+
+	if err := os.Remove(a); err != nil {
+		t.Fatal(err)
+	}
+	files, err = x.List(context.Background(), "tag://red")
+	if err != nil || len(files) != 0 {
+		t.Fatalf("listing after delete = %+v, %v", files, err)
+	}
+	if got := x.Paths(fileinfo.ColorTagRed); len(got) != 0 {
+		t.Fatalf("deleted path still indexed: %v", got)
+	}
+}
+
+func TestUpdateDirectoryForgetsMissingChildren(t *testing.T) {
+	x := New()
+	x.Set("/data/old.txt", fileinfo.ColorTagBlue)
+	x.Set("/other/keep.txt", fileinfo.ColorTagBlue)
+	x.UpdateDirectory("/data", []fileinfo.FileInfo{{Name: "new.txt", Path: "/data/new.txt", ColorTag: fileinfo.ColorTagBlue}})
+
+	got := x.Paths(fileinfo.ColorTagBlue)
+	if len(got) != 2 || got[0] != "/data/new.txt" || got[1] != "/other/keep.txt" {
+		t.Fatalf("Paths = %v", got)
+	}
+}
+
+func TestIndexPersistsAcrossLoads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "tag-index.json")
+	x := New()
+	if err := x.Load(path); err != nil {
+		t.Fatalf("Load missing file: %v", err)
+	}
+	x.Set("/data/a.txt", fileinfo.ColorTagGreen)
+
+	y := New()
+	if err := y.Load(path); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got := y.Paths(fileinfo.ColorTagGreen); len(got) != 1 || got[0] != "/data/a.txt" {
+		t.Fatalf("restored Paths = %v", got)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := New().Load(path); err == nil {
+		t.Fatal("corrupt index should be reported")
+	}
+}
@@ -3,7 +3,6 @@ package ui
 import (
 	"context"
 	"errors"
-	"path/filepath"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -13,9 +12,11 @@ import (
 	"nmf/internal/config"
 	"nmf/internal/fileinfo"
 	"nmf/internal/keymanager"
+	"nmf/internal/vault"
 )
 
-// ArchivePasswordProvider prompts the user for encrypted archive passwords.
+// ArchivePasswordProvider prompts the user for encrypted archive and vault
+// passwords.
 type ArchivePasswordProvider struct {
 	parent   fyne.Window
 	km       *keymanager.KeyManager
@@ -31,6 +32,32 @@ func NewArchivePasswordProvider(parent fyne.Window, km *keymanager.KeyManager, b
 }
 
 func (p *ArchivePasswordProvider) GetArchivePassword(ctx context.Context, req fileinfo.ArchivePasswordRequest) (string, error) {
+	title := "Archive Password: " + fileinfo.BaseName(req.ArchivePath)
+	if req.Retry {
+		title = "Archive Password Retry: " + fileinfo.BaseName(req.ArchivePath)
+	}
+	label := "Password"
+	if req.Format != "" {
+		label = req.Format + " Password"
+	}
+	return p.getPassword(ctx, title, label, "archive password cancelled")
+}
+
+// GetVaultPassword prompts for the password of a gocryptfs vault or the
+// identity of an age vault.
+func (p *ArchivePasswordProvider) GetVaultPassword(ctx context.Context, req vault.PasswordRequest) (string, error) {
+	title := "Vault Password: " + fileinfo.BaseName(req.Path)
+	if req.Retry {
+		title = "Vault Password Retry: " + fileinfo.BaseName(req.Path)
+	}
+	label := string(req.Kind) + " Password"
+	if req.Kind == vault.KindAge {
+		label = "age Identity"
+	}
+	return p.getPassword(ctx, title, label, "vault password cancelled")
+}
+
+func (p *ArchivePasswordProvider) getPassword(ctx context.Context, title, label, cancelMessage string) (string, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
@@ -45,9 +72,9 @@ func (p *ArchivePasswordProvider) GetArchivePassword(ctx context.Context, req fi
 		if ctx.Err() != nil {
 			return
 		}
-		d := newArchivePasswordDialog(req, p.parent, p.km, p.bindings, func(ok bool, pass string) {
+		d := newArchivePasswordDialog(title, label, p.parent, p.km, p.bindings, func(ok bool, pass string) {
 			if !ok {
-				done <- result{err: errors.New("archive password cancelled")}
+				done <- result{err: errors.New(cancelMessage)}
 				return
 			}
 			done <- result{password: pass}
@@ -79,7 +106,8 @@ func (p *ArchivePasswordProvider) GetArchivePassword(ctx context.Context, req fi
 // and removed on every close path, and the entry routes key events through
 // the KeyManager so Escape cancels and Enter submits.
 type archivePasswordDialog struct {
-	req        fileinfo.ArchivePasswordRequest
+	title      string
+	label      string
 	parent     fyne.Window
 	km         *keymanager.KeyManager
 	kmToken    keymanager.HandlerToken
@@ -90,9 +118,10 @@ type archivePasswordDialog struct {
 	onFinished func(bool, string)
 }
 
-func newArchivePasswordDialog(req fileinfo.ArchivePasswordRequest, parent fyne.Window, km *keymanager.KeyManager, bindings []config.KeyBindingEntry, onFinished func(bool, string)) *archivePasswordDialog {
+func newArchivePasswordDialog(title, label string, parent fyne.Window, km *keymanager.KeyManager, bindings []config.KeyBindingEntry, onFinished func(bool, string)) *archivePasswordDialog {
 	d := &archivePasswordDialog{
-		req:        req,
+		title:      title,
+		label:      label,
 		parent:     parent,
 		km:         km,
 		bindings:   bindings,
@@ -110,17 +139,8 @@ func newArchivePasswordDialog(req fileinfo.ArchivePasswordRequest, parent fyne.W
 }
 
 func (d *archivePasswordDialog) show() {
-	title := "Archive Password: " + filepath.Base(d.req.ArchivePath)
-	if d.req.Retry {
-		title = "Archive Password Retry: " + filepath.Base(d.req.ArchivePath)
-	}
-	label := "Password"
-	if d.req.Format != "" {
-		label = d.req.Format + " Password"
-	}
-
 	content := container.NewVBox(
-		container.NewBorder(nil, nil, widget.NewLabel(label), nil, lineEditThemeOverride(d.entry)),
+		container.NewBorder(nil, nil, widget.NewLabel(d.label), nil, lineEditThemeOverride(d.entry)),
 		dialogButtonRow("Cancel", d.CancelDialog, "Open", d.AcceptEdit),
 	)
 
@@ -129,7 +149,7 @@ func (d *archivePasswordDialog) show() {
 		d.kmToken = d.km.PushHandler(handler)
 	}
 
-	d.dialog = dialog.NewCustomWithoutButtons(title, content, d.parent)
+	d.dialog = dialog.NewCustomWithoutButtons(d.title, content, d.parent)
 	d.dialog.SetOnClosed(func() {
 		d.CancelDialog()
 	})
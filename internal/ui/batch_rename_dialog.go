@@ -0,0 +1,407 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"nmf/internal/config"
+	"nmf/internal/jobs"
+	"nmf/internal/keymanager"
+)
+
+// BatchRenamePlanner turns rename rules into a preview of the marked items.
+type BatchRenamePlanner func(jobs.RenameRules) ([]jobs.RenamePlanEntry, error)
+
+// BatchRenameDialog edits batch rename rules and previews every old and new
+// name as they change. It accepts only plans that rename something and have
+// no invalid names or collisions. Tab moves between the text fields, Alt+X
+// toggles regular expressions, and Alt+C cycles the case transform.
+type BatchRenameDialog struct {
+	parent     fyne.Window
+	keyManager *keymanager.KeyManager
+	kmToken    keymanager.HandlerToken
+	bindings   []config.KeyBindingEntry
+	dialog     dialog.Dialog
+	find       *LineEditEntry
+	replace    *LineEditEntry
+	pattern    *LineEditEntry
+	start      *LineEditEntry
+	step       *LineEditEntry
+	regexCheck *widget.Check
+	caseSelect *widget.Select
+	summary    *widget.Label
+	list       *widget.List
+	planner    BatchRenamePlanner
+	plan       []jobs.RenamePlanEntry
+	planErr    error
+	active     int
+	closed     bool
+	onAccept   func([]jobs.RenamePlanEntry)
+}
+
+// NewBatchRenameDialog creates the dialog; planner is called on every edit.
+func NewBatchRenameDialog(planner BatchRenamePlanner, km *keymanager.KeyManager, configuredBindings ...[]config.KeyBindingEntry) *BatchRenameDialog {
+	d := &BatchRenameDialog{keyManager: km, planner: planner}
+	if len(configuredBindings) > 0 {
+		d.bindings = configuredBindings[0]
+	}
+	d.find = d.newEntry("text to find")
+	d.replace = d.newEntry("replacement ($1 for groups)")
+	d.pattern = d.newEntry("{name}{ext}, {n} or {n:3} for a counter")
+	d.start = d.newEntry("1")
+	d.step = d.newEntry("1")
+	d.start.SetText("1")
+	d.step.SetText("1")
+	d.regexCheck = widget.NewCheck("Regular expression (Alt+X)", func(bool) { d.Refresh() })
+	labels := make([]string, len(jobs.RenameCases))
+	for i, c := range jobs.RenameCases {
+		labels[i] = c.Label()
+	}
+	d.caseSelect = widget.NewSelect(labels, func(string) { d.Refresh() })
+	d.caseSelect.SetSelectedIndex(0)
+	d.summary = widget.NewLabel("")
+	d.summary.Wrapping = fyne.TextWrapWord
+	d.list = widget.NewList(
+		func() int { return len(d.plan) },
+		func() fyne.CanvasObject {
+			before := widget.NewLabel("")
+			before.TextStyle = fyne.TextStyle{Monospace: true}
+			before.Truncation = fyne.TextTruncateEllipsis
+			after := widget.NewLabel("")
+			after.TextStyle = fyne.TextStyle{Monospace: true}
+			after.Truncation = fyne.TextTruncateEllipsis
+			return container.NewGridWithColumns(2, before, after)
+		},
+		func(i widget.ListItemID, obj fyne.CanvasObject) {
+			if i < 0 || int(i) >= len(d.plan) {
+				return
+			}
+			row := obj.(*fyne.Container)
+			before, after := batchRenameRow(d.plan[i])
+			row.Objects[0].(*widget.Label).SetText(before)
+			row.Objects[1].(*widget.Label).SetText(after)
+		},
+	)
+	return d
+}
+
+func (d *BatchRenameDialog) newEntry(placeholder string) *LineEditEntry {
+	entry := NewLineEditEntry(d.CancelDialog, d.keyManager)
+	entry.SetPlaceHolder(placeholder)
+	entry.OnChanged = func(string) { d.Refresh() }
+	entry.OnSubmitted = func(string) { d.AcceptRename() }
+	return entry
+}
+
+// batchRenameRow renders one preview row; problems replace the new name.
+func batchRenameRow(e jobs.RenamePlanEntry) (string, string) {
+	switch {
+	case e.Err != nil:
+		return e.OldName, "! " + e.Err.Error()
+	case !e.Changed():
+		return e.OldName, "(unchanged)"
+	default:
+		return e.OldName, e.NewName
+	}
+}
+
+// ShowDialog displays the dialog. onAccept receives the accepted plan.
+func (d *BatchRenameDialog) ShowDialog(parent fyne.Window, onAccept func([]jobs.RenamePlanEntry)) {
+	d.parent = parent
+	d.onAccept = onAccept
+	for _, entry := range d.entries() {
+		entry.SetIMEWindow(parent)
+	}
+
+	field := func(label string, obj fyne.CanvasObject) fyne.CanvasObject {
+		return container.NewBorder(nil, nil, widget.NewLabel(label), nil, obj)
+	}
+	header := container.NewGridWithColumns(2, boldLabel("Before"), boldLabel("After"))
+	scroll := container.NewScroll(d.list)
+	scroll.SetMinSize(metricsSize(batchRenameDialogWidth-40, batchRenameListHeight))
+	content := container.NewVBox(
+		field("Find:", lineEditThemeOverride(d.find)),
+		field("Replace:", lineEditThemeOverride(d.replace)),
+		d.regexCheck,
+		field("New name:", lineEditThemeOverride(d.pattern)),
+		container.NewGridWithColumns(3,
+			field("Start:", lineEditThemeOverride(d.start)),
+			field("Step:", lineEditThemeOverride(d.step)),
+			field("Case (Alt+C):", d.caseSelect),
+		),
+		d.summary,
+		header,
+		scroll,
+		dialogButtonRow("Cancel", d.CancelDialog, "Rename", d.AcceptRename),
+	)
+
+	var debugPrint func(format string, args ...interface{})
+	if d.keyManager != nil {
+		debugPrint = d.keyManager.Debugf
+		d.kmToken = d.keyManager.PushHandler(newBatchRenameKeyHandler(d, d.bindings, debugPrint))
+	}
+
+	d.dialog = dialog.NewCustomWithoutButtons("Batch Rename", content, parent)
+	d.dialog.SetOnClosed(func() {
+		d.CancelDialog()
+	})
+	d.Refresh()
+	d.dialog.Show()
+	d.dialog.Resize(fyne.NewSize(responsiveDialogWidth(parent, batchRenameDialogWidth), batchRenameDialogHeight))
+	d.focusEntry(0)
+}
+
+func boldLabel(text string) *widget.Label {
+	label := widget.NewLabel(text)
+	label.TextStyle = fyne.TextStyle{Bold: true}
+	return label
+}
+
+// Rules returns the rules as currently entered.
+func (d *BatchRenameDialog) Rules() (jobs.RenameRules, error) {
+	rules := jobs.RenameRules{
+		Find:    d.find.Text,
+		Replace: d.replace.Text,
+		Regex:   d.regexCheck.Checked,
+		Pattern: strings.TrimSpace(d.pattern.Text),
+	}
+	if i := d.caseSelect.SelectedIndex(); i >= 0 {
+		rules.Case = jobs.RenameCases[i]
+	}
+	var err error
+	if rules.CounterStart, err = batchRenameNumber("start", d.start.Text); err != nil {
+		return rules, err
+	}
+	if rules.CounterStep, err = batchRenameNumber("step", d.step.Text); err != nil {
+		return rules, err
+	}
+	return rules, nil
+}
+
+func batchRenameNumber(name, text string) (int, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, fmt.Errorf("counter %s must be a whole number", name)
+	}
+	return n, nil
+}
+
+// Refresh replans with the current rules and updates the preview.
+func (d *BatchRenameDialog) Refresh() {
+	if d.list == nil {
+		return
+	}
+	rules, err := d.Rules()
+	if err == nil {
+		d.plan, err = d.planner(rules)
+	}
+	d.planErr = err
+	d.summary.SetText(batchRenameSummary(d.plan, err))
+	d.list.Refresh()
+}
+
+// batchRenameSummary counts what the plan renames and what blocks it.
+func batchRenameSummary(plan []jobs.RenamePlanEntry, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	var changed, problems int
+	for _, e := range plan {
+		switch {
+		case e.Err != nil:
+			problems++
+		case e.Changed():
+			changed++
+		}
+	}
+	text := fmt.Sprintf("%d of %d item(s) will be renamed", changed, len(plan))
+	if problems > 0 {
+		text += fmt.Sprintf("; %d cannot be, fix them to continue", problems)
+	}
+	return text
+}
+
+// Ready reports whether the current plan can be run.
+func (d *BatchRenameDialog) Ready() bool {
+	if d.planErr != nil {
+		return false
+	}
+	changed := false
+	for _, e := range d.plan {
+		if e.Err != nil {
+			return false
+		}
+		changed = changed || e.Changed()
+	}
+	return changed
+}
+
+// AcceptRename runs the plan when it is ready and keeps the dialog open
+// otherwise.
+func (d *BatchRenameDialog) AcceptRename() {
+	if d.closed || !d.Ready() {
+		return
+	}
+	plan := append([]jobs.RenamePlanEntry(nil), d.plan...)
+	d.close()
+	if d.onAccept != nil {
+		d.onAccept(plan)
+	}
+}
+
+func (d *BatchRenameDialog) AcceptEdit() {
+	d.AcceptRename()
+}
+
+func (d *BatchRenameDialog) CancelDialog() {
+	if d.closed {
+		return
+	}
+	d.close()
+}
+
+func (d *BatchRenameDialog) close() {
+	d.closed = true
+	deferDialogClose(d.keyManager, "batchRename.close", func() {
+		if d.keyManager != nil {
+			d.keyManager.RemoveHandler(d.kmToken)
+		}
+		if d.dialog != nil {
+			d.dialog.Hide()
+		}
+		unfocusIfDialogOwned(d.parent, d.find, d.replace, d.pattern, d.start, d.step)
+	})
+}
+
+// ToggleRegex switches Find between literal text and a regular expression.
+func (d *BatchRenameDialog) ToggleRegex() {
+	d.regexCheck.SetChecked(!d.regexCheck.Checked)
+}
+
+// CycleCase selects the next case transform.
+func (d *BatchRenameDialog) CycleCase() {
+	d.caseSelect.SetSelectedIndex((d.caseSelect.SelectedIndex() + 1) % len(jobs.RenameCases))
+}
+
+func (d *BatchRenameDialog) MoveToNextField() {
+	d.focusEntry((d.active + 1) % len(d.entries()))
+}
+
+func (d *BatchRenameDialog) MoveToPreviousField() {
+	entries := d.entries()
+	d.focusEntry((d.active + len(entries) - 1) % len(entries))
+}
+
+func (d *BatchRenameDialog) MoveCursorStart() {
+	d.currentEntry().MoveCursorStart()
+}
+func (d *BatchRenameDialog) MoveCursorEnd() {
+	d.currentEntry().MoveCursorEnd()
+}
+func (d *BatchRenameDialog) MoveCursorLeft() {
+	d.currentEntry().MoveCursorLeft()
+}
+func (d *BatchRenameDialog) MoveCursorRight() {
+	d.currentEntry().MoveCursorRight()
+}
+func (d *BatchRenameDialog) DeleteBeforeCursor() {
+	d.currentEntry().DeleteBeforeCursor()
+}
+func (d *BatchRenameDialog) DeleteAtCursor() {
+	d.currentEntry().DeleteAtCursor()
+}
+func (d *BatchRenameDialog) DeleteBeforeCursorToStart() {
+	d.currentEntry().DeleteBeforeCursorToStart()
+}
+func (d *BatchRenameDialog) DeleteAfterCursorToEnd() {
+	d.currentEntry().DeleteAfterCursorToEnd()
+}
+func (d *BatchRenameDialog) PasteFromClipboard() {
+	d.currentEntry().PasteFromClipboard()
+}
+func (d *BatchRenameDialog) InsertRune(r rune) bool {
+	entry := d.currentEntry()
+	if d.parent != nil && d.parent.Canvas().Focused() == entry {
+		return false
+	}
+	entry.InsertText(string(r))
+	return true
+}
+
+func (d *BatchRenameDialog) focusEntry(index int) {
+	entries := d.entries()
+	if index < 0 || index >= len(entries) {
+		index = 0
+	}
+	d.active = index
+	if d.parent != nil {
+		d.parent.Canvas().Focus(entries[index])
+	}
+	entries[index].UpdateIMEAnchor()
+}
+
+func (d *BatchRenameDialog) currentEntry() *LineEditEntry {
+	entries := d.entries()
+	if d.active < 0 || d.active >= len(entries) {
+		d.active = 0
+	}
+	return entries[d.active]
+}
+
+func (d *BatchRenameDialog) entries() []*LineEditEntry {
+	return []*LineEditEntry{d.find, d.replace, d.pattern, d.start, d.step}
+}
+
+// batchRenameKeyHandler adds field switching and the option toggles to the
+// line edit keys of the focused field.
+type batchRenameKeyHandler struct {
+	dialog   *BatchRenameDialog
+	lineEdit *keymanager.LineEditDialogKeyHandler
+}
+
+func newBatchRenameKeyHandler(d *BatchRenameDialog, bindings []config.KeyBindingEntry, debugPrint func(format string, args ...interface{})) *batchRenameKeyHandler {
+	return &batchRenameKeyHandler{
+		dialog:   d,
+		lineEdit: keymanager.NewLineEditDialogKeyHandler(d, debugPrint, bindings),
+	}
+}
+
+func (h *batchRenameKeyHandler) GetName() string { return "BatchRenameDialog" }
+
+func (h *batchRenameKeyHandler) OnKeyActivated(ev *fyne.KeyEvent, modifiers keymanager.ModifierState) bool {
+	if ev == nil {
+		return false
+	}
+	switch {
+	case ev.Name == fyne.KeyTab && modifiers.ShiftPressed:
+		h.dialog.MoveToPreviousField()
+		return true
+	case ev.Name == fyne.KeyTab:
+		h.dialog.MoveToNextField()
+		return true
+	case ev.Name == fyne.KeyX && modifiers.AltPressed && !modifiers.CtrlPressed:
+		h.dialog.ToggleRegex()
+		return true
+	case ev.Name == fyne.KeyC && modifiers.AltPressed && !modifiers.CtrlPressed:
+		h.dialog.CycleCase()
+		return true
+	}
+	return h.lineEdit.OnKeyActivated(ev, modifiers)
+}
+
+func (h *batchRenameKeyHandler) OnTypedRune(r rune, modifiers keymanager.ModifierState) bool {
+	if modifiers.AltPressed {
+		return true
+	}
+	return h.lineEdit.OnTypedRune(r, modifiers)
+}
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+
+	"nmf/internal/fileinfo"
+	"nmf/internal/jobs"
+	"nmf/internal/keymanager"
+)
+
+func TestBatchRenameDialogPreviewsAndBlocksCollisions(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+	w := test.NewWindow(nil)
+	defer w.Close()
+	km := keymanager.NewKeyManager(func(string, ...interface{}) {})
+	files := []fileinfo.FileInfo{
+		{Name: "a.txt", Path: "/p/a.txt"},
+		{Name: "b.txt", Path: "/p/b.txt"},
+	}
+	d := NewBatchRenameDialog(func(rules jobs.RenameRules) ([]jobs.RenamePlanEntry, error) {
+		return jobs.PlanRenames(files, []string{"a.txt", "b.txt"}, rules)
+	}, km)
+	var accepted []jobs.RenamePlanEntry
+	d.ShowDialog(w, func(plan []jobs.RenamePlanEntry) { accepted = plan })
+	defer func() {
+		d.CancelDialog()
+		fyne.DoAndWait(func() {})
+	}()
+
+	d.pattern.SetText("same")
+	if d.Ready() {
+		t.Fatal("plan renaming both items to same.txt is ready")
+	}
+	d.AcceptRename()
+	if accepted != nil || d.closed {
+		t.Fatal("colliding plan was accepted")
+	}
+
+	d.pattern.SetText("file{n:2}")
+	if got := d.summary.Text; got != "2 of 2 item(s) will be renamed" {
+		t.Fatalf("summary = %q", got)
+	}
+	if before, after := batchRenameRow(d.plan[1]); before != "b.txt" || after != "file02.txt" {
+		t.Fatalf("row = %q -> %q, want b.txt -> file02.txt", before, after)
+	}
+
+	handler := newBatchRenameKeyHandler(d, nil, nil)
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyC}, keymanager.ModifierState{AltPressed: true})
+	if got := d.plan[0].NewName; got != "file01.txt" {
+		t.Fatalf("lowercase name = %q", got)
+	}
+	handler.OnKeyActivated(&fyne.KeyEvent{Name: fyne.KeyX}, keymanager.ModifierState{AltPressed: true})
+	d.find.SetText("(")
+	if d.Ready() || d.summary.Text == "" {
+		t.Fatalf("invalid expression: ready %v summary %q", d.Ready(), d.summary.Text)
+	}
+
+	d.find.SetText("")
+	d.AcceptRename()
+	if len(accepted) != 2 || accepted[0].NewName != "file01.txt" {
+		t.Fatalf("accepted = %+v", accepted)
+	}
+}
@@ -0,0 +1,428 @@
+package ui
+
+import (
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"nmf/internal/config"
+	"nmf/internal/fileinfo"
+	"nmf/internal/keymanager"
+)
+
+// BookmarksDialog lists saved bookmarks and edits them in place. Every edit
+// is reported through onChanged so the caller can persist it immediately.
+type BookmarksDialog struct {
+	entries       []config.Bookmark
+	currentPath   string
+	list          *widget.List
+	emptyLabel    *widget.Label
+	selectedIndex int
+	debugPrint    func(format string, args ...interface{})
+	keyManager    *keymanager.KeyManager
+	kmToken       keymanager.HandlerToken
+	bindings      []config.KeyBindingEntry
+	dialog        dialog.Dialog
+	parent        fyne.Window
+	sink          *KeySink
+	closed        bool
+	editing       bool
+
+	onJump    func(string)
+	onChanged func([]config.Bookmark)
+}
+
+// NewBookmarksDialog creates a bookmarks dialog. currentPath is the directory
+// added by AddCurrentDirectory.
+func NewBookmarksDialog(
+	entries []config.Bookmark,
+	currentPath string,
+	keyManager *keymanager.KeyManager,
+	debugPrint func(format string, args ...interface{}),
+	configuredBindings ...[]config.KeyBindingEntry,
+) *BookmarksDialog {
+	d := &BookmarksDialog{
+		entries:       config.NormalizeBookmarks(entries),
+		currentPath:   currentPath,
+		selectedIndex: -1,
+		debugPrint:    debugPrint,
+		keyManager:    keyManager,
+	}
+	if len(configuredBindings) > 0 {
+		d.bindings = configuredBindings[0]
+	}
+	if len(d.entries) > 0 {
+		d.selectedIndex = 0
+	}
+	d.createWidgets()
+	return d
+}
+
+func bookmarkHotkeyLabel(b config.Bookmark) string {
+	if b.Hotkey == 0 {
+		return ""
+	}
+	return strconv.Itoa(b.Hotkey)
+}
+
+func bookmarkWatchLabel(b config.Bookmark) string {
+	if !b.Watch {
+		return ""
+	}
+	return "(watched)"
+}
+
+func (d *BookmarksDialog) createWidgets() {
+	d.list = widget.NewList(
+		func() int {
+			return len(d.entries)
+		},
+		func() fyne.CanvasObject {
+			hotkey := widget.NewLabel("")
+			hotkey.TextStyle = fyne.TextStyle{Monospace: true}
+			hotkeyBox := container.NewGridWrap(directoryJumpShortcutCellSize(), hotkey)
+			name := widget.NewLabel("")
+			name.TextStyle = fyne.TextStyle{Bold: true}
+			path := widget.NewLabel("")
+			path.TextStyle = fyne.TextStyle{Monospace: true}
+			watch := widget.NewLabel("")
+			watch.TextStyle = fyne.TextStyle{Italic: true}
+			return container.NewHBox(hotkeyBox, name, path, watch)
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			if id < 0 || int(id) >= len(d.entries) {
+				return
+			}
+			entry := d.entries[id]
+			row, ok := obj.(*fyne.Container)
+			if !ok || len(row.Objects) < 4 {
+				return
+			}
+			if hotkeyBox, ok := row.Objects[0].(*fyne.Container); ok && len(hotkeyBox.Objects) > 0 {
+				if hotkeyLabel, ok := hotkeyBox.Objects[0].(*widget.Label); ok {
+					hotkeyLabel.SetText(bookmarkHotkeyLabel(entry))
+				}
+			}
+			if nameLabel, ok := row.Objects[1].(*widget.Label); ok {
+				nameLabel.SetText(entry.Name)
+			}
+			if pathLabel, ok := row.Objects[2].(*widget.Label); ok {
+				pathLabel.SetText(entry.Path)
+			}
+			if watchLabel, ok := row.Objects[3].(*widget.Label); ok {
+				watchLabel.SetText(bookmarkWatchLabel(entry))
+			}
+		},
+	)
+	d.list.OnSelected = func(id widget.ListItemID) {
+		if id >= 0 && int(id) < len(d.entries) {
+			d.selectedIndex = int(id)
+			d.debugPrint("BookmarksDialog: selected %s index=%d", d.entries[id].Path, d.selectedIndex)
+			d.focusSink()
+		}
+	}
+	d.list.Resize(searchDialogListSize())
+
+	d.emptyLabel = widget.NewLabel("No bookmarks. Press A to add the current directory.")
+	d.emptyLabel.Alignment = fyne.TextAlignCenter
+}
+
+func bookmarksListWidth(entries []config.Bookmark, minimum float32) float32 {
+	lines := make([]string, len(entries))
+	for i, entry := range entries {
+		lines[i] = entry.Name + "  " + entry.Path
+	}
+	width := dialogTextWidth(lines, minimum) + directoryJumpShortcutCellSize().Width
+	if width < minimum {
+		return minimum
+	}
+	return width
+}
+
+// ShowDialog shows the bookmarks dialog. onJump receives the accepted path;
+// onChanged receives the full normalized list after every edit.
+func (d *BookmarksDialog) ShowDialog(parent fyne.Window, onJump func(string), onChanged func([]config.Bookmark)) {
+	listWidth := responsiveDialogWidth(parent, searchDialogListWidth)
+	contentWidth := responsiveDialogWidth(parent, searchDialogContentWidth)
+	listSize := metricsSize(listWidth, searchDialogListHeight)
+	contentSize := metricsSize(contentWidth, searchDialogContentHeight)
+
+	titleLabel := widget.NewLabel("Bookmarks")
+	titleLabel.TextStyle.Bold = true
+	hintLabel := widget.NewLabel("1-9: jump   A: add current   R/F2: rename   W: watch   Del: remove   Ctrl+1-9: set key")
+
+	listScroll := newScrollableDialogList(d.list, bookmarksListWidth(d.entries, listWidth), listWidth, searchDialogListHeight)
+
+	fixedContainer := container.NewWithoutLayout(listScroll, d.emptyLabel)
+	fixedContainer.Resize(listSize)
+	listScroll.Resize(listSize)
+	listScroll.Move(fyne.NewPos(0, 0))
+	d.emptyLabel.Resize(listSize)
+	d.emptyLabel.Move(fyne.NewPos(0, 0))
+	d.updateEmptyState()
+
+	content := container.NewBorder(
+		container.NewVBox(titleLabel, hintLabel),
+		dialogButtonBar(dialogCancelButton("Close", d.CancelDialog), dialogConfirmButton("Jump", d.AcceptSelection)),
+		nil,
+		nil,
+		fixedContainer,
+	)
+	content.Resize(contentSize)
+
+	d.onJump = onJump
+	d.onChanged = onChanged
+	d.parent = parent
+
+	handler := keymanager.NewBookmarksDialogKeyHandler(d, d.debugPrint)
+	d.kmToken = d.keyManager.PushHandler(handler)
+
+	d.sink = NewKeySink(content, d.keyManager, WithTabCapture(true))
+	d.dialog = dialog.NewCustomWithoutButtons("Bookmarks", d.sink, parent)
+	d.dialog.Show()
+	if d.selectedIndex >= 0 {
+		d.list.Select(widget.ListItemID(d.selectedIndex))
+	}
+	d.focusSink()
+}
+
+func (d *BookmarksDialog) focusSink() {
+	if d.parent != nil && d.sink != nil {
+		d.parent.Canvas().Focus(d.sink)
+	}
+}
+
+func (d *BookmarksDialog) updateEmptyState() {
+	if d.emptyLabel == nil {
+		return
+	}
+	if len(d.entries) == 0 {
+		d.emptyLabel.Show()
+	} else {
+		d.emptyLabel.Hide()
+	}
+}
+
+// changed normalizes the entries, refreshes the list, and reports the new
+// list to the caller.
+func (d *BookmarksDialog) changed() {
+	d.entries = config.NormalizeBookmarks(d.entries)
+	if d.selectedIndex >= len(d.entries) {
+		d.selectedIndex = len(d.entries) - 1
+	}
+	d.list.Refresh()
+	if d.selectedIndex >= 0 {
+		d.list.Select(widget.ListItemID(d.selectedIndex))
+	} else {
+		d.list.UnselectAll()
+	}
+	d.updateEmptyState()
+	if d.onChanged != nil {
+		saved := make([]config.Bookmark, len(d.entries))
+		copy(saved, d.entries)
+		d.onChanged(saved)
+	}
+}
+
+func (d *BookmarksDialog) selectIndex(index int) {
+	if index < 0 || index >= len(d.entries) {
+		return
+	}
+	d.selectedIndex = index
+	d.list.Select(widget.ListItemID(index))
+}
+
+// MoveUp moves the selection up.
+func (d *BookmarksDialog) MoveUp() {
+	if d.selectedIndex > 0 {
+		d.selectIndex(d.selectedIndex - 1)
+	}
+}
+
+// MoveDown moves the selection down.
+func (d *BookmarksDialog) MoveDown() {
+	if d.selectedIndex < len(d.entries)-1 {
+		d.selectIndex(d.selectedIndex + 1)
+	}
+}
+
+// MoveToTop moves selection to the top.
+func (d *BookmarksDialog) MoveToTop() {
+	d.selectIndex(0)
+}
+
+// MoveToBottom moves selection to the bottom.
+func (d *BookmarksDialog) MoveToBottom() {
+	d.selectIndex(len(d.entries) - 1)
+}
+
+// JumpToHotkey accepts the bookmark holding hotkey n, if any.
+func (d *BookmarksDialog) JumpToHotkey(n int) {
+	for _, entry := range d.entries {
+		if entry.Hotkey == n {
+			d.debugPrint("BookmarksDialog: hotkey %d path=%s", n, entry.Path)
+			d.acceptPath(entry.Path)
+			return
+		}
+	}
+	d.debugPrint("BookmarksDialog: hotkey %d unassigned", n)
+}
+
+// AssignHotkey gives hotkey n to the selected bookmark, taking it from any
+// other bookmark that held it. n == 0 clears the selected bookmark's hotkey.
+func (d *BookmarksDialog) AssignHotkey(n int) {
+	if d.selectedIndex < 0 || d.selectedIndex >= len(d.entries) || n < 0 || n > config.MaxBookmarkHotkey {
+		return
+	}
+	for i := range d.entries {
+		if n != 0 && d.entries[i].Hotkey == n {
+			d.entries[i].Hotkey = 0
+		}
+	}
+	d.entries[d.selectedIndex].Hotkey = n
+	d.debugPrint("BookmarksDialog: assign hotkey %d path=%s", n, d.entries[d.selectedIndex].Path)
+	d.changed()
+}
+
+func (d *BookmarksDialog) nextFreeHotkey() int {
+	used := make(map[int]bool, len(d.entries))
+	for _, entry := range d.entries {
+		used[entry.Hotkey] = true
+	}
+	for n := 1; n <= config.MaxBookmarkHotkey; n++ {
+		if !used[n] {
+			return n
+		}
+	}
+	return 0
+}
+
+// AddCurrentDirectory bookmarks the current directory, or selects its
+// existing bookmark. New bookmarks take the lowest free hotkey.
+func (d *BookmarksDialog) AddCurrentDirectory() {
+	if d.currentPath == "" {
+		return
+	}
+	for i, entry := range d.entries {
+		if entry.Path == d.currentPath {
+			d.selectIndex(i)
+			return
+		}
+	}
+	d.entries = append(d.entries, config.Bookmark{
+		Name:   fileinfo.BaseName(d.currentPath),
+		Path:   d.currentPath,
+		Hotkey: d.nextFreeHotkey(),
+	})
+	d.selectedIndex = len(d.entries) - 1
+	d.debugPrint("BookmarksDialog: add path=%s", d.currentPath)
+	d.changed()
+}
+
+// RenameSelected edits the selected bookmark's name in a nested dialog.
+func (d *BookmarksDialog) RenameSelected() {
+	if d.editing || d.selectedIndex < 0 || d.selectedIndex >= len(d.entries) {
+		return
+	}
+	index := d.selectedIndex
+	path := d.entries[index].Path
+	d.editing = true
+	dlg := NewLineEditDialog(LineEditDialogOptions{
+		Title:       "Rename Bookmark",
+		Prompt:      "Name for " + path + ":",
+		InitialText: d.entries[index].Name,
+		ConfirmText: "Rename",
+		OnClosed: func() {
+			d.editing = false
+			d.focusSink()
+		},
+	}, d.keyManager, d.bindings)
+	dlg.ShowDialog(d.parent, func(name string) bool {
+		if d.closed || index >= len(d.entries) || d.entries[index].Path != path {
+			return true
+		}
+		d.entries[index].Name = name
+		d.debugPrint("BookmarksDialog: rename path=%s name=%s", path, name)
+		d.changed()
+		return true
+	})
+}
+
+// ToggleWatch marks the selected bookmark to be watched in the background,
+// or clears the mark. Marking stops at config.MaxWatchedBookmarks.
+func (d *BookmarksDialog) ToggleWatch() {
+	if d.selectedIndex < 0 || d.selectedIndex >= len(d.entries) {
+		return
+	}
+	entry := &d.entries[d.selectedIndex]
+	if !entry.Watch {
+		watched := 0
+		for _, e := range d.entries {
+			if e.Watch {
+				watched++
+			}
+		}
+		if watched >= config.MaxWatchedBookmarks {
+			d.debugPrint("BookmarksDialog: watch limit %d reached path=%s", config.MaxWatchedBookmarks, entry.Path)
+			return
+		}
+	}
+	entry.Watch = !entry.Watch
+	d.debugPrint("BookmarksDialog: watch=%t path=%s", entry.Watch, entry.Path)
+	d.changed()
+}
+
+// RemoveSelected deletes the selected bookmark.
+func (d *BookmarksDialog) RemoveSelected() {
+	if d.selectedIndex < 0 || d.selectedIndex >= len(d.entries) {
+		return
+	}
+	d.debugPrint("BookmarksDialog: remove path=%s", d.entries[d.selectedIndex].Path)
+	d.entries = append(d.entries[:d.selectedIndex], d.entries[d.selectedIndex+1:]...)
+	d.changed()
+}
+
+// AcceptSelection jumps to the selected bookmark.
+func (d *BookmarksDialog) AcceptSelection() {
+	if d.selectedIndex < 0 || d.selectedIndex >= len(d.entries) {
+		return
+	}
+	d.acceptPath(d.entries[d.selectedIndex].Path)
+}
+
+func (d *BookmarksDialog) acceptPath(path string) {
+	if d.closed {
+		return
+	}
+	d.closed = true
+
+	deferDialogClose(d.keyManager, "bookmarks.accept", func() {
+		d.keyManager.RemoveHandler(d.kmToken)
+		if d.dialog != nil {
+			d.dialog.Hide()
+		}
+		unfocusIfDialogOwned(d.parent, d.sink)
+		if d.onJump != nil && path != "" {
+			d.onJump(path)
+		}
+	})
+}
+
+// CancelDialog closes the dialog without jumping.
+func (d *BookmarksDialog) CancelDialog() {
+	if d.closed {
+		return
+	}
+	d.closed = true
+
+	deferDialogClose(d.keyManager, "bookmarks.cancel", func() {
+		d.keyManager.RemoveHandler(d.kmToken)
+		if d.dialog != nil {
+			d.dialog.Hide()
+		}
+		unfocusIfDialogOwned(d.parent, d.sink)
+	})
+}
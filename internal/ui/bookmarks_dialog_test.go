@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/test"
+
+	"nmf/internal/config"
+	"nmf/internal/keymanager"
+)
+
+func newTestBookmarksDialog(entries []config.Bookmark, currentPath string) (*BookmarksDialog, *keymanager.KeyManager, *[][]config.Bookmark) {
+	km := keymanager.NewKeyManager(func(string, ...interface{}) {})
+	d := NewBookmarksDialog(entries, currentPath, km, func(string, ...interface{}) {})
+	var saved [][]config.Bookmark
+	d.onChanged = func(list []config.Bookmark) {
+		saved = append(saved, list)
+	}
+	d.kmToken = km.PushHandler(keymanager.NewBookmarksDialogKeyHandler(d, func(string, ...interface{}) {}))
+	return d, km, &saved
+}
+
+func TestBookmarksDialogAddCurrentDirectoryTakesFreeHotkey(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	d, _, saved := newTestBookmarksDialog([]config.Bookmark{
+		{Name: "home", Path: "/home/u", Hotkey: 1},
+	}, "/work/project")
+
+	d.AddCurrentDirectory()
+	if len(*saved) != 1 {
+		t.Fatalf("onChanged calls = %d, want 1", len(*saved))
+	}
+	got := (*saved)[0]
+	if len(got) != 2 || got[1] != (config.Bookmark{Name: "project", Path: "/work/project", Hotkey: 2}) {
+		t.Fatalf("bookmarks = %+v", got)
+	}
+
+	d.AddCurrentDirectory()
+	if len(*saved) != 1 || d.selectedIndex != 1 {
+		t.Fatalf("re-adding should only select the existing bookmark, calls=%d index=%d", len(*saved), d.selectedIndex)
+	}
+}
+
+func TestBookmarksDialogAssignHotkeyStealsDigit(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	d, km, saved := newTestBookmarksDialog([]config.Bookmark{
+		{Name: "a", Path: "/a", Hotkey: 3},
+		{Name: "b", Path: "/b"},
+	}, "")
+	d.selectIndex(1)
+
+	km.HandleKeyDown(&fyne.KeyEvent{Name: desktop.KeyControlLeft})
+	km.HandleKeyDown(&fyne.KeyEvent{Name: fyne.Key3})
+	km.HandleTypedKey(&fyne.KeyEvent{Name: fyne.Key3})
+
+	if len(*saved) != 1 {
+		t.Fatalf("onChanged calls = %d, want 1", len(*saved))
+	}
+	got := (*saved)[0]
+	if got[0].Hotkey != 0 || got[1].Hotkey != 3 {
+		t.Fatalf("hotkeys = %d,%d, want 0,3", got[0].Hotkey, got[1].Hotkey)
+	}
+}
+
+func TestBookmarksDialogRemoveSelectedKeepsSelectionInRange(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	d, _, saved := newTestBookmarksDialog([]config.Bookmark{
+		{Name: "a", Path: "/a"},
+		{Name: "b", Path: "/b"},
+	}, "")
+	d.selectIndex(1)
+
+	d.RemoveSelected()
+	if d.selectedIndex != 0 || len((*saved)[0]) != 1 || (*saved)[0][0].Path != "/a" {
+		t.Fatalf("after remove index=%d saved=%+v", d.selectedIndex, *saved)
+	}
+	d.RemoveSelected()
+	if d.selectedIndex != -1 || len((*saved)[1]) != 0 {
+		t.Fatalf("after removing last index=%d saved=%+v", d.selectedIndex, *saved)
+	}
+}
+
+func TestBookmarksDialogDigitJumpsViaTransition(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	d, km, _ := newTestBookmarksDialog([]config.Bookmark{
+		{Name: "a", Path: "/a", Hotkey: 4},
+	}, "")
+	var jumped string
+	d.onJump = func(path string) {
+		jumped = path
+	}
+
+	km.HandleKeyDown(&fyne.KeyEvent{Name: fyne.Key4})
+	km.HandleTypedKey(&fyne.KeyEvent{Name: fyne.Key4})
+	km.HandleTypedRune('4')
+
+	if jumped != "/a" {
+		t.Fatalf("jumped = %q, want /a", jumped)
+	}
+	if got := km.GetStackSize(); got != 0 {
+		t.Fatalf("key manager stack size = %d, want 0", got)
+	}
+}
+
+func TestBookmarksDialogToggleWatchStopsAtLimit(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	entries := make([]config.Bookmark, config.MaxWatchedBookmarks+1)
+	for i := range entries {
+		entries[i] = config.Bookmark{Path: "/dir" + string(rune('a'+i)), Watch: i < config.MaxWatchedBookmarks}
+	}
+	entries[0].Watch = false
+	d, _, saved := newTestBookmarksDialog(entries, "")
+
+	d.selectIndex(0)
+	d.ToggleWatch()
+	if len(*saved) != 1 || !(*saved)[0][0].Watch {
+		t.Fatalf("watch on = %+v", *saved)
+	}
+	d.selectIndex(config.MaxWatchedBookmarks)
+	d.ToggleWatch()
+	if len(*saved) != 1 || d.entries[config.MaxWatchedBookmarks].Watch {
+		t.Fatalf("marking past the limit should be refused, saves=%d", len(*saved))
+	}
+	d.selectIndex(0)
+	d.ToggleWatch()
+	if len(*saved) != 2 || (*saved)[1][0].Watch {
+		t.Fatalf("watch off = %+v", (*saved)[1])
+	}
+}
@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"nmf/internal/fileinfo"
+	"nmf/internal/keymanager"
+)
+
+// bulkConfirmPrefixLen is how many leading letters of the destination
+// folder's name a large move asks for.
+const bulkConfirmPrefixLen = 3
+
+// BulkConfirmDialog asks a second time before a move or delete above the
+// configured size thresholds is queued. A move must be confirmed by typing
+// the first letters of the destination folder's name, which catches a
+// mis-picked destination; a delete by ticking "I understand".
+type BulkConfirmDialog struct {
+	title      string
+	message    string
+	action     string
+	name       string // destination folder name to type; empty asks for the check
+	entry      *deleteConfirmEntry
+	check      *widget.Check
+	keyManager *keymanager.KeyManager
+	kmToken    keymanager.HandlerToken
+	parent     fyne.Window
+	dialog     dialog.Dialog
+	closed     bool
+	onAccept   func()
+}
+
+// NewBulkMoveConfirmDialog confirms moving size worth of items into
+// destination.
+func NewBulkMoveConfirmDialog(size fileinfo.DirSize, destination string, km *keymanager.KeyManager) *BulkConfirmDialog {
+	d := &BulkConfirmDialog{
+		title:      "Large Move",
+		message:    fmt.Sprintf("Move %s to\n%s?", bulkSizeSummary(size), middleEllipsizeFileName(destination, renameDisplayedNameMax)),
+		action:     "Move",
+		name:       bulkConfirmName(destination),
+		keyManager: km,
+	}
+	d.entry = newDeleteConfirmEntry(d.CancelBulk)
+	d.entry.OnSubmitted = func(string) {
+		d.ConfirmBulk()
+	}
+	return d
+}
+
+// NewBulkDeleteConfirmDialog confirms deleting size worth of items; action
+// names the delete, such as "Trash" or "Delete".
+func NewBulkDeleteConfirmDialog(size fileinfo.DirSize, action string, km *keymanager.KeyManager) *BulkConfirmDialog {
+	d := &BulkConfirmDialog{
+		title:      "Large Delete",
+		message:    fmt.Sprintf("%s %s?", action, bulkSizeSummary(size)),
+		action:     action,
+		keyManager: km,
+	}
+	d.check = widget.NewCheck("I understand this affects all of these files", nil)
+	return d
+}
+
+func bulkSizeSummary(size fileinfo.DirSize) string {
+	return fmt.Sprintf("%d file(s) in %d folder(s), %s", size.Files, size.Dirs, fileinfo.FormatFileSize(size.Bytes))
+}
+
+// bulkConfirmName returns the name whose first letters confirm a move into
+// destination: the folder's own name, or the whole path for a root.
+func bulkConfirmName(destination string) string {
+	name := fileinfo.BaseName(destination)
+	if name == "" || name == "." || name == "/" || name == `\` {
+		return destination
+	}
+	return name
+}
+
+// prefixLen is how many letters of d.name must be typed.
+func (d *BulkConfirmDialog) prefixLen() int {
+	return min(bulkConfirmPrefixLen, utf8.RuneCountInString(d.name))
+}
+
+func (d *BulkConfirmDialog) ShowDialog(parent fyne.Window, onAccept func()) {
+	d.parent = parent
+	d.onAccept = onAccept
+
+	content := container.NewVBox(widget.NewLabel(d.message))
+	if d.entry != nil {
+		content.Add(widget.NewLabel(fmt.Sprintf("Type the first %d letters of %q to confirm:", d.prefixLen(), d.name)))
+		content.Add(d.entry)
+	} else {
+		content.Add(d.check)
+	}
+	content.Add(dialogButtonRow("Cancel", d.CancelBulk, d.action, d.ConfirmBulk))
+
+	handler := keymanager.NewBulkConfirmDialogKeyHandler(d)
+	d.kmToken = d.keyManager.PushHandler(handler)
+
+	d.dialog = dialog.NewCustomWithoutButtons(d.title, content, parent)
+	d.dialog.SetOnClosed(func() {
+		d.CancelBulk()
+	})
+	d.dialog.Show()
+	if d.parent == nil {
+		return
+	}
+	if d.entry != nil {
+		d.entry.SetIMEWindow(d.parent)
+		d.parent.Canvas().Focus(d.entry)
+	} else {
+		d.parent.Canvas().Focus(d.check)
+	}
+}
+
+// confirmed reports whether the typed letters or the check allow the
+// operation. Typing more of the name than required is fine.
+func (d *BulkConfirmDialog) confirmed() bool {
+	if d.entry == nil {
+		return d.check != nil && d.check.Checked
+	}
+	typed := strings.ToLower(strings.TrimSpace(d.entry.Text))
+	return utf8.RuneCountInString(typed) >= d.prefixLen() && strings.HasPrefix(strings.ToLower(d.name), typed)
+}
+
+func (d *BulkConfirmDialog) ConfirmBulk() {
+	if d.closed {
+		return
+	}
+	if !d.confirmed() {
+		if d.parent != nil && d.entry != nil {
+			d.parent.Canvas().Focus(d.entry)
+		}
+		return
+	}
+	d.closed = true
+	deferDialogClose(d.keyManager, "bulk.confirm", func() {
+		d.keyManager.RemoveHandler(d.kmToken)
+		if d.dialog != nil {
+			d.dialog.Hide()
+		}
+		d.unfocus()
+		if d.onAccept != nil {
+			d.onAccept()
+		}
+	})
+}
+
+func (d *BulkConfirmDialog) CancelBulk() {
+	if d.closed {
+		return
+	}
+	d.closed = true
+	deferDialogClose(d.keyManager, "bulk.cancel", func() {
+		d.keyManager.RemoveHandler(d.kmToken)
+		if d.dialog != nil {
+			d.dialog.Hide()
+		}
+		d.unfocus()
+	})
+}
+
+func (d *BulkConfirmDialog) unfocus() {
+	if d.entry != nil {
+		unfocusIfDialogOwned(d.parent, d.entry)
+	} else {
+		unfocusIfDialogOwned(d.parent, d.check)
+	}
+}
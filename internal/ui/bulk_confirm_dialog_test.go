@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"testing"
+
+	"nmf/internal/fileinfo"
+	"nmf/internal/keymanager"
+)
+
+func TestBulkMoveConfirmRequiresDestinationPrefix(t *testing.T) {
+	km := keymanager.NewKeyManager(func(string, ...interface{}) {})
+	d := NewBulkMoveConfirmDialog(fileinfo.DirSize{Files: 5000}, "/mnt/backup/Photos", km)
+	accepted := false
+	d.onAccept = func() { accepted = true }
+
+	for _, typed := range []string{"", "ph", "pic"} {
+		d.entry.SetText(typed)
+		d.ConfirmBulk()
+		if accepted || d.closed {
+			t.Fatalf("typing %q confirmed the move", typed)
+		}
+	}
+	d.entry.SetText("phot")
+	d.ConfirmBulk()
+	if !accepted || !d.closed {
+		t.Fatal("typing the first letters of the destination should confirm")
+	}
+}
+
+func TestBulkConfirmNameOfRootIsThePath(t *testing.T) {
+	if got := bulkConfirmName("/"); got != "/" {
+		t.Fatalf("bulkConfirmName(/) = %q", got)
+	}
+	d := NewBulkMoveConfirmDialog(fileinfo.DirSize{}, "/", keymanager.NewKeyManager(func(string, ...interface{}) {}))
+	if d.prefixLen() != 1 {
+		t.Fatalf("prefixLen = %d, want the whole one-letter name", d.prefixLen())
+	}
+}
+
+func TestBulkDeleteConfirmRequiresCheck(t *testing.T) {
+	km := keymanager.NewKeyManager(func(string, ...interface{}) {})
+	d := NewBulkDeleteConfirmDialog(fileinfo.DirSize{Files: 5000}, "Trash", km)
+	accepted := false
+	d.onAccept = func() { accepted = true }
+
+	d.ConfirmBulk()
+	if accepted || d.closed {
+		t.Fatal("delete confirmed without the check")
+	}
+	d.check.SetChecked(true)
+	d.ConfirmBulk()
+	if !accepted || !d.closed {
+		t.Fatal("delete should confirm once checked")
+	}
+}
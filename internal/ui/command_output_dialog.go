@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"nmf/internal/keymanager"
+)
+
+// commandOutputMaxLines bounds the output kept on screen; older lines are
+// dropped so a chatty command cannot grow the dialog without limit.
+const commandOutputMaxLines = 5000
+
+// CommandOutputDialog shows the combined stdout and stderr of a running
+// command as it arrives, and its exit status when it finishes. The view
+// follows new output while it is scrolled to the bottom.
+type CommandOutputDialog struct {
+	title       string
+	commandLine string
+	grid        *widget.TextGrid
+	scroll      *container.Scroll
+	statusLabel *widget.Label
+	stopButton  *widget.Button
+
+	lines    []string
+	partial  string
+	dropped  int
+	finished bool
+	status   string
+
+	debugPrint func(format string, args ...interface{})
+	keyManager *keymanager.KeyManager
+	kmToken    keymanager.HandlerToken
+	dialog     dialog.Dialog
+	parent     fyne.Window
+	sink       *KeySink
+	closed     bool
+	onStop     func()
+	onClosed   func()
+}
+
+// NewCommandOutputDialog creates an output dialog for commandLine.
+func NewCommandOutputDialog(title, commandLine string, keyManager *keymanager.KeyManager, debugPrint func(format string, args ...interface{})) *CommandOutputDialog {
+	if debugPrint == nil {
+		debugPrint = func(string, ...interface{}) {}
+	}
+	d := &CommandOutputDialog{
+		title:       title,
+		commandLine: commandLine,
+		debugPrint:  debugPrint,
+		keyManager:  keyManager,
+		status:      "Running...",
+	}
+	d.grid = widget.NewTextGrid()
+	d.grid.Scroll = fyne.ScrollNone
+	d.scroll = container.NewScroll(d.grid)
+	d.statusLabel = widget.NewLabel("")
+	d.updateStatus()
+	return d
+}
+
+// ShowDialog shows the dialog. onStop asks the command to stop; onClosed
+// runs once the dialog is gone.
+func (d *CommandOutputDialog) ShowDialog(parent fyne.Window, onStop func(), onClosed func()) {
+	d.parent = parent
+	d.onStop = onStop
+	d.onClosed = onClosed
+
+	width := responsiveDialogWidthWithRatio(parent, maintenanceDialogWidth, fileViewerWidthRatio, fileViewerFallbackWidth)
+	d.scroll.SetMinSize(fyne.NewSize(width, maintenanceListHeight+140))
+
+	commandLabel := widget.NewLabel("$ " + d.commandLine)
+	commandLabel.TextStyle = fyne.TextStyle{Monospace: true}
+	commandLabel.Truncation = fyne.TextTruncateEllipsis
+	hintLabel := widget.NewLabel("Ctrl+C: stop   Enter/Esc: close")
+	d.stopButton = dialogAuxButton("Stop", theme.MediaStopIcon(), d.StopCommand)
+	if d.finished {
+		d.stopButton.Disable()
+	}
+
+	content := container.NewBorder(
+		container.NewVBox(commandLabel, hintLabel),
+		container.NewVBox(
+			d.statusLabel,
+			dialogButtonBar(
+				d.stopButton,
+				dialogConfirmButton("Close", d.CloseDialog),
+			),
+		),
+		nil,
+		nil,
+		d.scroll,
+	)
+
+	handler := keymanager.NewCommandOutputDialogKeyHandler(d, d.debugPrint)
+	d.kmToken = d.keyManager.PushHandler(handler)
+
+	d.sink = NewKeySink(content, d.keyManager, WithTabCapture(true))
+	d.dialog = dialog.NewCustomWithoutButtons(d.title, d.sink, parent)
+	d.dialog.Show()
+	parent.Canvas().Focus(d.sink)
+}
+
+// AppendOutput adds a chunk of output. Lines may be split across chunks.
+func (d *CommandOutputDialog) AppendOutput(chunk string) {
+	if chunk == "" {
+		return
+	}
+	text := d.partial + strings.ReplaceAll(chunk, "\r\n", "\n")
+	parts := strings.Split(text, "\n")
+	d.partial = parts[len(parts)-1]
+	d.lines = append(d.lines, parts[:len(parts)-1]...)
+	if over := len(d.lines) - commandOutputMaxLines; over > 0 {
+		d.lines = append(d.lines[:0:0], d.lines[over:]...)
+		d.dropped += over
+		d.updateStatus()
+	}
+	d.render()
+}
+
+// Finish records how the command ended and stops offering to stop it.
+func (d *CommandOutputDialog) Finish(status string) {
+	d.finished = true
+	d.status = status
+	if d.stopButton != nil {
+		d.stopButton.Disable()
+	}
+	d.updateStatus()
+	d.render()
+}
+
+// Text returns the output shown so far.
+func (d *CommandOutputDialog) Text() string {
+	text := strings.Join(d.lines, "\n")
+	if d.partial != "" {
+		if text != "" {
+			text += "\n"
+		}
+		text += d.partial
+	}
+	return text
+}
+
+// Status returns the status line.
+func (d *CommandOutputDialog) Status() string {
+	return d.statusLabel.Text
+}
+
+func (d *CommandOutputDialog) render() {
+	follow := d.atBottom()
+	text := d.Text()
+	if text == "" && d.finished {
+		text = "(no output)"
+	}
+	d.grid.SetText(text)
+	if follow {
+		d.scroll.ScrollToBottom()
+	}
+}
+
+func (d *CommandOutputDialog) atBottom() bool {
+	return d.scroll.Offset.Y+d.scroll.Size().Height >= d.scroll.Content.MinSize().Height-1
+}
+
+func (d *CommandOutputDialog) updateStatus() {
+	status := d.status
+	if d.dropped > 0 {
+		status += fmt.Sprintf("  (first %d lines not shown)", d.dropped)
+	}
+	d.statusLabel.SetText(status)
+}
+
+func (d *CommandOutputDialog) lineHeight() float32 {
+	return fyne.MeasureText("M", theme.TextSize(), fyne.TextStyle{Monospace: true}).Height
+}
+
+func (d *CommandOutputDialog) scrollBy(dy float32) {
+	maxY := d.scroll.Content.MinSize().Height - d.scroll.Size().Height
+	y := d.scroll.Offset.Y + dy
+	if y > maxY {
+		y = maxY
+	}
+	if y < 0 {
+		y = 0
+	}
+	d.scroll.ScrollToOffset(fyne.NewPos(d.scroll.Offset.X, y))
+}
+
+// ScrollUp scrolls one line up.
+func (d *CommandOutputDialog) ScrollUp() { d.scrollBy(-d.lineHeight()) }
+
+// ScrollDown scrolls one line down.
+func (d *CommandOutputDialog) ScrollDown() { d.scrollBy(d.lineHeight()) }
+
+// PageUp scrolls one page up.
+func (d *CommandOutputDialog) PageUp() { d.scrollBy(-d.scroll.Size().Height) }
+
+// PageDown scrolls one page down.
+func (d *CommandOutputDialog) PageDown() { d.scrollBy(d.scroll.Size().Height) }
+
+// ScrollToTop scrolls to the first line.
+func (d *CommandOutputDialog) ScrollToTop() { d.scroll.ScrollToTop() }
+
+// ScrollToBottom scrolls to the last line and follows new output again.
+func (d *CommandOutputDialog) ScrollToBottom() { d.scroll.ScrollToBottom() }
+
+// StopCommand asks the running command to stop. The dialog stays open to
+// show the output and exit status.
+func (d *CommandOutputDialog) StopCommand() {
+	if d.finished || d.onStop == nil {
+		return
+	}
+	d.debugPrint("CommandOutputDialog: stop requested")
+	d.status = "Stopping..."
+	d.updateStatus()
+	d.onStop()
+}
+
+// CloseDialog closes the dialog, stopping the command if it still runs.
+func (d *CommandOutputDialog) CloseDialog() {
+	if d.closed {
+		return
+	}
+	d.closed = true
+	if !d.finished && d.onStop != nil {
+		d.onStop()
+	}
+
+	deferDialogClose(d.keyManager, "commandOutput.close", func() {
+		d.keyManager.RemoveHandler(d.kmToken)
+		if d.dialog != nil {
+			d.dialog.Hide()
+		}
+		unfocusIfDialogOwned(d.parent, d.sink)
+		if d.onClosed != nil {
+			d.onClosed()
+		}
+	})
+}
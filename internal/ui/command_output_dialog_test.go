@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/test"
+
+	"nmf/internal/keymanager"
+)
+
+func newTestCommandOutputDialog(t *testing.T) (*CommandOutputDialog, *keymanager.KeyManager, *int, *int) {
+	t.Helper()
+	app := test.NewApp()
+	t.Cleanup(app.Quit)
+	w := test.NewWindow(nil)
+	t.Cleanup(w.Close)
+	km := keymanager.NewKeyManager(func(string, ...interface{}) {})
+	d := NewCommandOutputDialog("Run Command", "make test", km, nil)
+	stops, closes := 0, 0
+	d.ShowDialog(w, func() { stops++ }, func() { closes++ })
+	return d, km, &stops, &closes
+}
+
+func TestCommandOutputDialogJoinsChunksIntoLines(t *testing.T) {
+	d, _, _, _ := newTestCommandOutputDialog(t)
+
+	d.AppendOutput("first li")
+	d.AppendOutput("ne\r\nsecond\nthi")
+	if got, want := d.Text(), "first line\nsecond\nthi"; got != want {
+		t.Fatalf("Text() = %q, want %q", got, want)
+	}
+	d.AppendOutput("rd\n")
+	if got, want := d.Text(), "first line\nsecond\nthird"; got != want {
+		t.Fatalf("Text() = %q, want %q", got, want)
+	}
+	if got := d.Status(); got != "Running..." {
+		t.Fatalf("Status() = %q, want Running...", got)
+	}
+}
+
+func TestCommandOutputDialogDropsOldestLines(t *testing.T) {
+	d, _, _, _ := newTestCommandOutputDialog(t)
+
+	d.AppendOutput(strings.Repeat("x\n", commandOutputMaxLines+3))
+	if got := len(d.lines); got != commandOutputMaxLines {
+		t.Fatalf("kept %d lines, want %d", got, commandOutputMaxLines)
+	}
+	if !strings.Contains(d.Status(), "first 3 lines not shown") {
+		t.Fatalf("Status() = %q, want dropped line count", d.Status())
+	}
+}
+
+func TestCommandOutputDialogCtrlCStopsAndEscapeCloses(t *testing.T) {
+	d, km, stops, closes := newTestCommandOutputDialog(t)
+
+	km.HandleKeyDown(&fyne.KeyEvent{Name: desktop.KeyControlLeft})
+	pressDialogKey(km, fyne.KeyC, 0)
+	km.HandleKeyUp(&fyne.KeyEvent{Name: desktop.KeyControlLeft})
+	if *stops != 1 || d.closed {
+		t.Fatalf("after Ctrl+C stops=%d closed=%v, want 1 and open", *stops, d.closed)
+	}
+
+	d.Finish("Exited with status 1")
+	pressDialogKey(km, fyne.KeyEscape, 0)
+	if *stops != 1 {
+		t.Fatalf("closing a finished command stopped it again: stops=%d", *stops)
+	}
+	if *closes != 1 {
+		t.Fatalf("onClosed ran %d times, want 1", *closes)
+	}
+}
+
+func TestCommandOutputDialogCloseStopsRunningCommand(t *testing.T) {
+	d, _, stops, closes := newTestCommandOutputDialog(t)
+
+	d.CloseDialog()
+	d.CloseDialog()
+	if *stops != 1 || *closes != 1 {
+		t.Fatalf("stops=%d closes=%d, want 1 and 1", *stops, *closes)
+	}
+}
@@ -0,0 +1,265 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+	"strings"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+
+	customtheme "nmf/internal/theme"
+)
+
+// commandPaletteRows is how many matches the palette shows at once.
+const commandPaletteRows = 8
+
+// CommandPaletteItem is one command the palette can run.
+type CommandPaletteItem struct {
+	ID   string   // command ID, also what the query matches against
+	Keys []string // bound keys, shown next to the ID
+}
+
+// CommandPaletteOverlay is a top overlay like the incremental search bar that
+// fuzzy-filters command IDs and shows the best matches below the query.
+type CommandPaletteOverlay struct {
+	query         string
+	items         []CommandPaletteItem
+	matches       []CommandPaletteItem
+	current       int
+	container     *fyne.Container
+	queryLabel    *canvas.Text
+	queryText     *shrinkingTextLabel
+	rowLabels     []*canvas.Text
+	rowTexts      []*shrinkingTextLabel
+	visible       bool
+	parent        fyne.Window
+	debugPrint    func(format string, args ...interface{})
+	themeProvider ThemeColorProvider
+}
+
+// NewCommandPaletteOverlay creates a hidden command palette.
+func NewCommandPaletteOverlay(themeProvider ThemeColorProvider, debugPrint func(format string, args ...interface{})) *CommandPaletteOverlay {
+	p := &CommandPaletteOverlay{
+		current:       -1,
+		debugPrint:    debugPrint,
+		themeProvider: themeProvider,
+	}
+	p.createWidgets()
+	return p
+}
+
+func (p *CommandPaletteOverlay) createWidgets() {
+	textColor := p.overlayColor(customtheme.ColorSearchOverlayForeground)
+	background := canvas.NewRectangle(p.overlayColor(customtheme.ColorSearchOverlayBackground))
+
+	p.queryLabel = canvas.NewText("", textColor)
+	p.queryLabel.TextStyle.Bold = true
+	p.queryText = newShrinkingTextLabel(p.queryLabel)
+
+	rows := container.NewVBox(p.queryText)
+	for i := 0; i < commandPaletteRows; i++ {
+		label := canvas.NewText("", textColor)
+		label.TextStyle.Monospace = true
+		text := newShrinkingTextLabel(label)
+		p.rowLabels = append(p.rowLabels, label)
+		p.rowTexts = append(p.rowTexts, text)
+		rows.Add(text)
+	}
+
+	p.container = container.NewMax(background, container.NewPadded(rows))
+	p.container.Hide()
+}
+
+func (p *CommandPaletteOverlay) overlayColor(name string) color.RGBA {
+	if p.themeProvider == nil {
+		return color.RGBA{}
+	}
+	return p.themeProvider.GetCustomColor(name)
+}
+
+// GetContainer returns the container widget for the overlay.
+func (p *CommandPaletteOverlay) GetContainer() *fyne.Container {
+	return p.container
+}
+
+// Show opens the palette with an empty query over items.
+func (p *CommandPaletteOverlay) Show(parent fyne.Window, items []CommandPaletteItem) {
+	p.parent = parent
+	p.items = items
+	p.query = ""
+	p.visible = true
+	p.updateMatches()
+	p.container.Show()
+	if parent != nil && parent.Canvas() != nil {
+		parent.Canvas().Refresh(p.container)
+	}
+	p.debugPrint("CommandPalette: Showing %d commands", len(items))
+	p.updateIMEAnchor()
+}
+
+// Hide closes the palette.
+func (p *CommandPaletteOverlay) Hide() {
+	if !p.visible {
+		return
+	}
+	p.visible = false
+	p.container.Hide()
+	p.debugPrint("CommandPalette: Hiding")
+}
+
+// IsVisible reports whether the palette is open.
+func (p *CommandPaletteOverlay) IsVisible() bool {
+	return p.visible
+}
+
+// AddCharacter appends r to the query and refilters.
+func (p *CommandPaletteOverlay) AddCharacter(r rune) {
+	if !p.visible {
+		return
+	}
+	p.query += string(r)
+	p.updateMatches()
+}
+
+// RemoveLastCharacter deletes the last query rune and refilters.
+func (p *CommandPaletteOverlay) RemoveLastCharacter() {
+	if !p.visible || p.query == "" {
+		return
+	}
+	p.query = trimLastRune(p.query)
+	p.updateMatches()
+}
+
+// MoveSelection moves the highlighted match by delta, wrapping around.
+func (p *CommandPaletteOverlay) MoveSelection(delta int) {
+	if !p.visible || len(p.matches) == 0 {
+		return
+	}
+	n := len(p.matches)
+	p.current = ((p.current+delta)%n + n) % n
+	p.updateDisplay()
+}
+
+// Selected returns the highlighted command ID.
+func (p *CommandPaletteOverlay) Selected() (string, bool) {
+	if !p.visible || p.current < 0 || p.current >= len(p.matches) {
+		return "", false
+	}
+	return p.matches[p.current].ID, true
+}
+
+func (p *CommandPaletteOverlay) updateMatches() {
+	p.matches = FilterCommandPaletteItems(p.items, p.query)
+	p.current = -1
+	if len(p.matches) > 0 {
+		p.current = 0
+	}
+	p.updateDisplay()
+}
+
+func (p *CommandPaletteOverlay) updateDisplay() {
+	if len(p.matches) == 0 {
+		p.queryText.SetText(fmt.Sprintf("> %s (no matching commands)", p.query))
+	} else {
+		p.queryText.SetText(fmt.Sprintf("> %s [%d/%d]", p.query, p.current+1, len(p.matches)))
+	}
+
+	// Keep the highlighted row inside the visible window of matches.
+	first := 0
+	if p.current >= commandPaletteRows {
+		first = p.current - commandPaletteRows + 1
+	}
+	for i, text := range p.rowTexts {
+		index := first + i
+		if index >= len(p.matches) {
+			text.SetText("")
+			continue
+		}
+		item := p.matches[index]
+		marker := "  "
+		if index == p.current {
+			marker = "> "
+		}
+		line := marker + item.ID
+		if len(item.Keys) > 0 {
+			line += "  (" + strings.Join(item.Keys, ", ") + ")"
+		}
+		text.SetText(line)
+	}
+	p.updateIMEAnchor()
+}
+
+func (p *CommandPaletteOverlay) updateIMEAnchor() {
+	if !p.visible || p.parent == nil {
+		return
+	}
+	setIMEAnchorAtTextEnd(p.parent, p.queryText, p.queryText.fullText, p.queryLabel.TextStyle)
+}
+
+// FilterCommandPaletteItems returns the items whose ID fuzzy-matches query,
+// best match first and shorter IDs first on ties. An empty query keeps every
+// item in its original order.
+func FilterCommandPaletteItems(items []CommandPaletteItem, query string) []CommandPaletteItem {
+	if strings.TrimSpace(query) == "" {
+		return append([]CommandPaletteItem(nil), items...)
+	}
+	type scored struct {
+		item  CommandPaletteItem
+		score int
+	}
+	var hits []scored
+	for _, item := range items {
+		if score, ok := fuzzyCommandScore(query, item.ID); ok {
+			hits = append(hits, scored{item: item, score: score})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		return len(hits[i].item.ID) < len(hits[j].item.ID)
+	})
+	out := make([]CommandPaletteItem, len(hits))
+	for i, hit := range hits {
+		out[i] = hit.item
+	}
+	return out
+}
+
+// fuzzyCommandScore matches query as a case-insensitive subsequence of
+// candidate, ignoring spaces in the query. Runs of adjacent characters and
+// matches at word starts (after '.', or a camelCase hump) score higher.
+func fuzzyCommandScore(query, candidate string) (int, bool) {
+	var q []rune
+	for _, r := range strings.ToLower(query) {
+		if !unicode.IsSpace(r) {
+			q = append(q, r)
+		}
+	}
+	c := []rune(candidate)
+	score := 0
+	qi := 0
+	prevMatch := -2
+	for ci := 0; ci < len(c) && qi < len(q); ci++ {
+		if unicode.ToLower(c[ci]) != q[qi] {
+			continue
+		}
+		score++
+		if prevMatch == ci-1 {
+			score += 4
+		}
+		if ci == 0 || c[ci-1] == '.' || (unicode.IsUpper(c[ci]) && unicode.IsLower(c[ci-1])) {
+			score += 6
+		}
+		prevMatch = ci
+		qi++
+	}
+	if qi < len(q) {
+		return 0, false
+	}
+	return score, true
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func commandPaletteTestItems() []CommandPaletteItem {
+	return []CommandPaletteItem{
+		{ID: "copy.show", Keys: []string{"C"}},
+		{ID: "tab.close", Keys: []string{"C-W"}},
+		{ID: "tab.new", Keys: []string{"C-T"}},
+		{ID: "window.resetAllSizes", Keys: []string{"C-S-Q"}},
+		{ID: "window.resetSize", Keys: []string{"S-Q"}},
+	}
+}
+
+func paletteIDs(items []CommandPaletteItem) []string {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+func TestFilterCommandPaletteItemsFuzzyMatches(t *testing.T) {
+	got := paletteIDs(FilterCommandPaletteItems(commandPaletteTestItems(), "tn"))
+	if len(got) != 1 || got[0] != "tab.new" {
+		t.Fatalf("tn matched %v, want [tab.new]", got)
+	}
+
+	got = paletteIDs(FilterCommandPaletteItems(commandPaletteTestItems(), "RESET size"))
+	if strings.Join(got, ",") != "window.resetSize,window.resetAllSizes" {
+		t.Fatalf("reset size matched %v, want resetSize before resetAllSizes", got)
+	}
+
+	if got := FilterCommandPaletteItems(commandPaletteTestItems(), "zz"); len(got) != 0 {
+		t.Fatalf("zz matched %v, want none", paletteIDs(got))
+	}
+	if got := FilterCommandPaletteItems(commandPaletteTestItems(), ""); len(got) != 5 {
+		t.Fatalf("empty query kept %d items, want 5", len(got))
+	}
+}
+
+func TestCommandPaletteOverlaySelectsAndWraps(t *testing.T) {
+	palette := NewCommandPaletteOverlay(incrementalSearchTheme{}, func(string, ...interface{}) {})
+	palette.Show(nil, commandPaletteTestItems())
+
+	for _, r := range "tab" {
+		palette.AddCharacter(r)
+	}
+	if !strings.Contains(palette.queryLabel.Text, "> tab [1/2]") {
+		t.Fatalf("query display = %q, want query and count", palette.queryLabel.Text)
+	}
+	if id, ok := palette.Selected(); !ok || id != "tab.new" {
+		t.Fatalf("selected = %q, %v; want tab.new", id, ok)
+	}
+	palette.MoveSelection(1)
+	palette.MoveSelection(1)
+	if id, _ := palette.Selected(); id != "tab.new" {
+		t.Fatalf("selection after wrapping = %q, want tab.new", id)
+	}
+	if !strings.Contains(palette.rowLabels[0].Text, "> tab.new  (C-T)") {
+		t.Fatalf("first row = %q, want highlighted tab.new with its key", palette.rowLabels[0].Text)
+	}
+
+	palette.RemoveLastCharacter()
+	palette.Hide()
+	if _, ok := palette.Selected(); ok || palette.IsVisible() {
+		t.Fatal("hidden palette should have no selection")
+	}
+}
@@ -140,7 +140,7 @@ func (d *CompareDialog) ShowDialog(parent fyne.Window, onAccept func(CompareResu
 	fromLine := container.NewGridWrap(fyne.NewSize(dialogWidth, fromLabel.MinSize().Height), fromLabel)
 	headerBox := container.NewVBox(header, fromLine)
 
-	methodLabel := widget.NewLabel("Mark files where:")
+	methodLabel := widget.NewLabel("Mark files where, or synchronize:")
 	methodBox := container.NewVBox(methodLabel, d.methodRadio)
 
 	searchLabel := widget.NewLabel("Destination:")
@@ -376,6 +376,14 @@ func (d *CompareDialog) SelectSizeContentEqual() {
 	d.selectMethod(filecompare.SizeContentEqual)
 }
 
+func (d *CompareDialog) SelectSyncMirror() {
+	d.selectMethod(filecompare.SyncMirror)
+}
+
+func (d *CompareDialog) SelectSyncTwoWay() {
+	d.selectMethod(filecompare.SyncTwoWay)
+}
+
 func (d *CompareDialog) selectMethod(method filecompare.Method) {
 	if d.methodRadio == nil {
 		return
@@ -473,6 +481,10 @@ func (d *CompareDialog) selectedMethod() filecompare.Method {
 		return filecompare.SizeTimeEqual
 	case compareMethodLabel(filecompare.SizeContentEqual):
 		return filecompare.SizeContentEqual
+	case compareMethodLabel(filecompare.SyncMirror):
+		return filecompare.SyncMirror
+	case compareMethodLabel(filecompare.SyncTwoWay):
+		return filecompare.SyncTwoWay
 	default:
 		return filecompare.MissingOrNewer
 	}
@@ -496,6 +508,8 @@ func compareMethodLabels() []string {
 		compareMethodLabel(filecompare.SizeEqual),
 		compareMethodLabel(filecompare.SizeTimeEqual),
 		compareMethodLabel(filecompare.SizeContentEqual),
+		compareMethodLabel(filecompare.SyncMirror),
+		compareMethodLabel(filecompare.SyncTwoWay),
 	}
 }
 
@@ -511,6 +525,10 @@ func compareMethodLabel(method filecompare.Method) string {
 		return "File size and timestamp match (Alt+T)"
 	case filecompare.SizeContentEqual:
 		return "File size and content match (Alt+C)"
+	case filecompare.SyncMirror:
+		return "Sync: mirror into destination, deleting extras (Alt+R)"
+	case filecompare.SyncTwoWay:
+		return "Sync: both ways, newest wins (Alt+W)"
 	default:
 		return "Missing in destination or newer (Alt+U)"
 	}
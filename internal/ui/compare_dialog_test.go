@@ -70,6 +70,8 @@ func TestCompareMethodLabelsShowAltShortcuts(t *testing.T) {
 		"File size matches (Alt+S)",
 		"File size and timestamp match (Alt+T)",
 		"File size and content match (Alt+C)",
+		"Sync: mirror into destination, deleting extras (Alt+R)",
+		"Sync: both ways, newest wins (Alt+W)",
 	}
 
 	got := compareMethodLabels()
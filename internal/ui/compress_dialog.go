@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"nmf/internal/config"
+	"nmf/internal/jobs"
+	"nmf/internal/keymanager"
+)
+
+var compressLevelLabels = []string{"Store", "Fast", "Normal", "Best"}
+
+var compressLevels = []jobs.ArchiveLevel{jobs.ArchiveLevelStore, jobs.ArchiveLevelFast, jobs.ArchiveLevelNormal, jobs.ArchiveLevelBest}
+
+// CompressDialog asks for the name, format, and compression level of a new
+// archive. Typing an archive extension picks the format, and picking a
+// format rewrites the extension, so the dialog works from the keyboard alone.
+type CompressDialog struct {
+	*LineEditDialog
+	formatSelect *widget.Select
+	levelSelect  *widget.Select
+	syncing      bool
+}
+
+// NewCompressDialog creates the dialog with baseName (without extension)
+// as the suggested archive name.
+func NewCompressDialog(count int, baseName string, km *keymanager.KeyManager, configuredBindings ...[]config.KeyBindingEntry) *CompressDialog {
+	d := &CompressDialog{}
+	formats := make([]string, len(jobs.ArchiveFormats))
+	for i, f := range jobs.ArchiveFormats {
+		formats[i] = string(f)
+	}
+	d.formatSelect = widget.NewSelect(formats, d.formatChanged)
+	d.levelSelect = widget.NewSelect(compressLevelLabels, nil)
+	d.levelSelect.SetSelectedIndex(2)
+
+	initial := baseName + jobs.ArchiveFormatZip.Extension()
+	stem := len([]rune(baseName))
+	d.LineEditDialog = NewLineEditDialog(LineEditDialogOptions{
+		Title:            "Compress",
+		Prompt:           compressPrompt(count),
+		InitialText:      initial,
+		InitialSelection: &LineEditSelection{Start: 0, End: stem},
+		ConfirmText:      "Compress",
+		Height:           compressDialogHeight,
+		Extra: container.NewGridWithColumns(2,
+			container.NewBorder(nil, nil, widget.NewLabel("Format:"), nil, d.formatSelect),
+			container.NewBorder(nil, nil, widget.NewLabel("Level:"), nil, d.levelSelect),
+		),
+	}, km, configuredBindings...)
+	d.syncing = true
+	d.formatSelect.SetSelectedIndex(0)
+	d.syncing = false
+	d.entry.OnChanged = d.nameChanged
+	return d
+}
+
+func compressPrompt(count int) string {
+	if count == 1 {
+		return "Archive name for 1 item:"
+	}
+	return fmt.Sprintf("Archive name for %d items:", count)
+}
+
+// ShowDialog displays the dialog; onAccept returns false to keep it open.
+func (d *CompressDialog) ShowDialog(parent fyne.Window, onAccept func(jobs.ArchiveOptions) bool) {
+	d.LineEditDialog.ShowDialog(parent, func(name string) bool {
+		return onAccept(d.Options(name))
+	})
+}
+
+// Options returns the archive choices for name.
+func (d *CompressDialog) Options(name string) jobs.ArchiveOptions {
+	opts := jobs.ArchiveOptions{
+		Name:   strings.TrimSpace(name),
+		Format: jobs.ArchiveFormat(d.formatSelect.Selected),
+		Level:  jobs.ArchiveLevelNormal,
+	}
+	if i := d.levelSelect.SelectedIndex(); i >= 0 {
+		opts.Level = compressLevels[i]
+	}
+	return opts
+}
+
+// nameChanged follows the format to an archive extension typed by the user.
+func (d *CompressDialog) nameChanged(name string) {
+	if d.syncing {
+		return
+	}
+	if f, ok := jobs.ArchiveFormatForName(name); ok && string(f) != d.formatSelect.Selected {
+		d.syncing = true
+		d.formatSelect.SetSelected(string(f))
+		d.syncing = false
+	}
+}
+
+// formatChanged swaps the name's archive extension for the chosen format.
+func (d *CompressDialog) formatChanged(format string) {
+	if d.syncing || d.entry == nil {
+		return
+	}
+	name := d.entry.Text
+	if f, ok := jobs.ArchiveFormatForName(name); ok {
+		lower := strings.ToLower(name)
+		for _, ext := range []string{f.Extension(), ".tgz"} {
+			if strings.HasSuffix(lower, ext) {
+				name = name[:len(name)-len(ext)]
+				break
+			}
+		}
+	}
+	d.syncing = true
+	d.entry.SetText(name + jobs.ArchiveFormat(format).Extension())
+	d.syncing = false
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+
+	"nmf/internal/jobs"
+)
+
+func TestCompressDialogSuggestsZipName(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	d := NewCompressDialog(3, "photos", nil)
+	if d.entry.Text != "photos.zip" {
+		t.Fatalf("initial name = %q, want photos.zip", d.entry.Text)
+	}
+	if got := d.entry.SelectedText(); got != "photos" {
+		t.Fatalf("initial selection = %q, want the name stem", got)
+	}
+	opts := d.Options(d.entry.Text)
+	if opts.Format != jobs.ArchiveFormatZip || opts.Level != jobs.ArchiveLevelNormal {
+		t.Fatalf("default options = %+v", opts)
+	}
+}
+
+func TestCompressDialogKeepsFormatAndExtensionInSync(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	d := NewCompressDialog(1, "src", nil)
+	d.entry.SetText("src.tar.gz")
+	if d.formatSelect.Selected != string(jobs.ArchiveFormatTarGz) {
+		t.Fatalf("format after typing .tar.gz = %q", d.formatSelect.Selected)
+	}
+
+	d.formatSelect.SetSelected(string(jobs.ArchiveFormatZip))
+	if d.entry.Text != "src.zip" {
+		t.Fatalf("name after choosing zip = %q, want src.zip", d.entry.Text)
+	}
+
+	d.levelSelect.SetSelected("Best")
+	if got := d.Options(d.entry.Text); got.Level != jobs.ArchiveLevelBest || got.Name != "src.zip" {
+		t.Fatalf("options = %+v", got)
+	}
+}
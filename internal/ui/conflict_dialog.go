@@ -26,6 +26,8 @@ const (
 	conflictOverwriteLabel        = "Overwrite (Alt+O)"
 	conflictSkipLabel             = "Skip this item (Alt+S)"
 	conflictRenameLabel           = "Rename to (Alt+R):"
+	conflictMergeLabel            = "Merge into existing folder (Alt+M)"
+	conflictStartOverLabel        = "Start over (Alt+O)"
 )
 
 // ConflictDialog resolves one copy/move destination name collision.
@@ -73,13 +75,7 @@ func (d *ConflictDialog) ShowDialog(parent fyne.Window, callback func(jobs.Confl
 		suggested = "auto name"
 	}
 
-	options := []string{
-		conflictOverwriteIfNewerLabel,
-		conflictOverwriteLabel,
-		fmt.Sprintf("Auto name (Alt+A): %s", suggested),
-		conflictSkipLabel,
-		conflictRenameLabel,
-	}
+	options := conflictChoiceLabels(d.req, suggested)
 	d.choice = widget.NewRadioGroup(options, func(string) {
 		d.updateEntryState()
 		if d.choice != nil && strings.HasPrefix(d.choice.Selected, "Rename") {
@@ -89,16 +85,21 @@ func (d *ConflictDialog) ShowDialog(parent fyne.Window, callback func(jobs.Confl
 	d.choice.Required = true
 	switch d.req.DefaultAction {
 	case jobs.ConflictOverwriteIfNewer:
-		d.choice.SetSelected(options[0])
+		d.selectChoiceExact(conflictOverwriteIfNewerLabel)
 	case jobs.ConflictOverwrite:
-		d.choice.SetSelected(options[1])
+		d.selectChoiceExact(conflictOverwriteLabel)
+	case jobs.ConflictMerge:
+		d.selectChoiceExact(conflictMergeLabel)
+	case jobs.ConflictResume:
+		d.selectChoiceByPrefix("Resume")
 	case jobs.ConflictAutoSuffix:
-		d.choice.SetSelected(options[2])
+		d.selectChoiceByPrefix("Auto name")
 	case jobs.ConflictRename:
-		d.choice.SetSelected(options[4])
+		d.selectChoiceByPrefix("Rename")
 	case jobs.ConflictSkip:
-		d.choice.SetSelected(options[3])
-	default:
+		d.selectChoiceByPrefix("Skip")
+	}
+	if d.choice.Selected == "" {
 		d.choice.SetSelected(options[0])
 	}
 
@@ -114,8 +115,18 @@ func (d *ConflictDialog) ShowDialog(parent fyne.Window, callback func(jobs.Confl
 	d.errorLabel.Hide()
 	d.updateEntryState()
 
+	title := "Name conflict"
+	message := "A destination item with the same name already exists."
+	if d.req.CanMerge {
+		message = "A destination folder with the same name already exists."
+	}
+	if d.req.ResumeOffset > 0 {
+		title = "Resume copy"
+		message = "An interrupted copy of this unchanged source left a partial file."
+		d.nameEntry.Hide()
+	}
 	content := container.NewVBox(
-		widget.NewLabel("A destination item with the same name already exists."),
+		widget.NewLabel(message),
 		container.NewBorder(nil, nil, widget.NewLabel("Source:"), nil, source),
 		container.NewBorder(nil, nil, widget.NewLabel("Modified:"), nil, sourceModified),
 		container.NewBorder(nil, nil, widget.NewLabel("Target:"), nil, dest),
@@ -140,7 +151,7 @@ func (d *ConflictDialog) ShowDialog(parent fyne.Window, callback func(jobs.Confl
 		dialogContent = d.sink
 	}
 
-	d.dialog = dialog.NewCustomWithoutButtons("Name conflict", dialogContent, parent)
+	d.dialog = dialog.NewCustomWithoutButtons(title, dialogContent, parent)
 	d.dialog.SetOnClosed(func() {
 		d.CancelJob()
 	})
@@ -150,6 +161,27 @@ func (d *ConflictDialog) ShowDialog(parent fyne.Window, callback func(jobs.Confl
 	d.focusCurrent()
 }
 
+// conflictChoiceLabels lists the choices for req. Folder-onto-folder
+// conflicts offer merging in place of the overwrite choices; a partial copy
+// offers resuming it or starting over.
+func conflictChoiceLabels(req jobs.ConflictRequest, suggested string) []string {
+	if req.ResumeOffset > 0 {
+		resume := fmt.Sprintf("Resume from %s of %s (Alt+U)", fileinfo.FormatFileSize(req.ResumeOffset), fileinfo.FormatFileSize(req.ResumeSize))
+		return []string{resume, conflictStartOverLabel}
+	}
+	autoName := fmt.Sprintf("Auto name (Alt+A): %s", suggested)
+	if req.CanMerge {
+		return []string{conflictMergeLabel, autoName, conflictSkipLabel, conflictRenameLabel}
+	}
+	return []string{
+		conflictOverwriteIfNewerLabel,
+		conflictOverwriteLabel,
+		autoName,
+		conflictSkipLabel,
+		conflictRenameLabel,
+	}
+}
+
 func formatConflictModified(t time.Time) string {
 	if t.IsZero() {
 		return "-"
@@ -172,6 +204,12 @@ func (d *ConflictDialog) Continue() {
 	}
 
 	switch {
+	case selected == conflictMergeLabel:
+		res.Action = jobs.ConflictMerge
+	case strings.HasPrefix(selected, "Resume"):
+		res.Action = jobs.ConflictResume
+	case selected == conflictStartOverLabel:
+		res.Action = jobs.ConflictOverwrite
 	case strings.HasPrefix(selected, "Overwrite if newer"):
 		res.Action = jobs.ConflictOverwriteIfNewer
 	case strings.HasPrefix(selected, "Overwrite"):
@@ -210,9 +248,11 @@ func (d *ConflictDialog) SelectOverwriteIfNewer() {
 	d.selectChoiceExact(conflictOverwriteIfNewerLabel)
 }
 
-// SelectOverwrite selects unconditional overwrite.
+// SelectOverwrite selects unconditional overwrite, or starting a partial
+// copy over.
 func (d *ConflictDialog) SelectOverwrite() {
 	d.selectChoiceExact(conflictOverwriteLabel)
+	d.selectChoiceExact(conflictStartOverLabel)
 }
 
 // SelectAutoName selects automatic suffix naming.
@@ -231,6 +271,16 @@ func (d *ConflictDialog) SelectSkip() {
 	d.selectChoiceByPrefix("Skip")
 }
 
+// SelectMerge selects merging into an existing folder when offered.
+func (d *ConflictDialog) SelectMerge() {
+	d.selectChoiceExact(conflictMergeLabel)
+}
+
+// SelectResume selects continuing a partial copy when offered.
+func (d *ConflictDialog) SelectResume() {
+	d.selectChoiceByPrefix("Resume")
+}
+
 func (d *ConflictDialog) finish(res jobs.ConflictResolution) {
 	if d.closed {
 		return
@@ -299,12 +349,16 @@ func (d *ConflictDialog) registerShortcuts() {
 		{KeyName: fyne.KeyA, Modifier: fyne.KeyModifierAlt},
 		{KeyName: fyne.KeyR, Modifier: fyne.KeyModifierAlt},
 		{KeyName: fyne.KeyS, Modifier: fyne.KeyModifierAlt},
+		{KeyName: fyne.KeyM, Modifier: fyne.KeyModifierAlt},
+		{KeyName: fyne.KeyU, Modifier: fyne.KeyModifierAlt},
 	}
 	c.AddShortcut(d.shortcuts[0], func(fyne.Shortcut) { d.SelectOverwriteIfNewer() })
 	c.AddShortcut(d.shortcuts[1], func(fyne.Shortcut) { d.SelectOverwrite() })
 	c.AddShortcut(d.shortcuts[2], func(fyne.Shortcut) { d.SelectAutoName() })
 	c.AddShortcut(d.shortcuts[3], func(fyne.Shortcut) { d.SelectRename() })
 	c.AddShortcut(d.shortcuts[4], func(fyne.Shortcut) { d.SelectSkip() })
+	c.AddShortcut(d.shortcuts[5], func(fyne.Shortcut) { d.SelectMerge() })
+	c.AddShortcut(d.shortcuts[6], func(fyne.Shortcut) { d.SelectResume() })
 }
 
 func (d *ConflictDialog) unregisterShortcuts() {
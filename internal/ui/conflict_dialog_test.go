@@ -241,3 +241,67 @@ func TestConflictNameEntryResolvesLineEditThemeOverride(t *testing.T) {
 		t.Fatalf("entry theme selection = %#v, want line edit selection color {5 6 7 8}", got)
 	}
 }
+
+func TestConflictDialogFolderConflictDefaultsToMerge(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	parent := test.NewWindow(widget.NewLabel(""))
+	defer parent.Close()
+
+	var got jobs.ConflictResolution
+	dialog := NewConflictDialog(jobs.ConflictRequest{
+		SuggestedName: "photos (1)",
+		IsDir:         true,
+		CanMerge:      true,
+		DefaultAction: jobs.ConflictMerge,
+	}, nil)
+	dialog.ShowDialog(parent, func(res jobs.ConflictResolution) { got = res })
+
+	for _, option := range dialog.choice.Options {
+		if option == conflictOverwriteLabel || option == conflictOverwriteIfNewerLabel {
+			t.Fatalf("folder conflict should not offer %q", option)
+		}
+	}
+	if dialog.choice.Selected != conflictMergeLabel {
+		t.Fatalf("selected = %q, want merge", dialog.choice.Selected)
+	}
+	dialog.SelectSkip()
+	dialog.SelectMerge()
+	dialog.Continue()
+
+	if got.Action != jobs.ConflictMerge {
+		t.Fatalf("resolution = %+v, want merge", got)
+	}
+}
+
+func TestConflictDialogPartialCopyOffersResume(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	parent := test.NewWindow(widget.NewLabel(""))
+	defer parent.Close()
+
+	var got jobs.ConflictResolution
+	dialog := NewConflictDialog(jobs.ConflictRequest{
+		Destination:    "/dst/movie.mp4.part",
+		DefaultAction:  jobs.ConflictResume,
+		CanApplyToRest: true,
+		ResumeOffset:   1 << 30,
+		ResumeSize:     4 << 30,
+	}, nil)
+	dialog.ShowDialog(parent, func(res jobs.ConflictResolution) { got = res })
+
+	if len(dialog.choice.Options) != 2 || dialog.choice.Options[1] != conflictStartOverLabel {
+		t.Fatalf("options = %q, want resume and start over", dialog.choice.Options)
+	}
+	if dialog.choice.Selected != "Resume from 1.0 GB of 4.0 GB (Alt+U)" {
+		t.Fatalf("selected = %q, want resume", dialog.choice.Selected)
+	}
+	dialog.SelectOverwrite()
+	dialog.Continue()
+
+	if got.Action != jobs.ConflictOverwrite {
+		t.Fatalf("resolution = %+v, want start over", got)
+	}
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"image/color"
+	"slices"
 	"strings"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"nmf/internal/fileinfo"
+	"nmf/internal/jobs"
 	"nmf/internal/keymanager"
 	"nmf/internal/search"
 	customtheme "nmf/internal/theme"
@@ -27,6 +29,9 @@ const (
 	OpCopy    Operation = "copy"
 	OpMove    Operation = "move"
 	OpExtract Operation = "extract"
+	// OpSymlink links the targets from the destination; the copy and move
+	// dialogs can switch to it.
+	OpSymlink Operation = "symlink"
 )
 
 // DestinationCandidate describes a copy/move destination and where it came from.
@@ -37,13 +42,27 @@ type DestinationCandidate struct {
 
 // CopyMoveResult describes the accepted copy/move dialog choices.
 type CopyMoveResult struct {
+	Operation          Operation // the operation chosen in the dialog
 	Destination        string
 	PreserveTimestamps bool
+	OrganizeByDate     bool
+	Layout             jobs.TransferLayout
+	RelativeBase       string
 }
 
+var copyMoveLayoutLabels = []string{"Keep structure", "Flatten", "Relative to base"}
+
+var copyMoveLayouts = []jobs.TransferLayout{jobs.LayoutKeep, jobs.LayoutFlatten, jobs.LayoutRelative}
+
+var copyMoveOperationLabels = []string{"Copy", "Move", "Create symlink here"}
+
+var copyMoveOperations = []Operation{OpCopy, OpMove, OpSymlink}
+
 // CopyMoveDialog presents targets and lets user pick destination by filtering history
 type CopyMoveDialog struct {
 	op           Operation
+	opSelect     *widget.Select
+	header       *widget.Label
 	targets      []string
 	searchEntry  *CustomSearchEntry
 	destList     *widget.List
@@ -55,6 +74,9 @@ type CopyMoveDialog struct {
 	selectedPath string
 	selectedIdx  int
 	preserveCB   *widget.Check
+	organizeCB   *widget.Check
+	layoutSelect *widget.Select
+	baseSelect   *widget.Select
 
 	debugPrint  func(format string, args ...interface{})
 	keyManager  *keymanager.KeyManager
@@ -94,19 +116,43 @@ func NewCopyMoveDialog(
 		keyManager: km,
 		debugPrint: debugPrint,
 	}
-	if op == OpCopy || op == OpExtract {
+	if op == OpCopy || op == OpMove || op == OpExtract {
 		d.preserveCB = widget.NewCheck("Preserve timestamps", nil)
 		d.preserveCB.SetChecked(preserveTimestamps)
 	}
+	if op == OpCopy || op == OpMove {
+		d.opSelect = widget.NewSelect(copyMoveOperationLabels, func(label string) {
+			for i, l := range copyMoveOperationLabels {
+				if l == label {
+					d.applyOperation(copyMoveOperations[i])
+				}
+			}
+		})
+		d.organizeCB = widget.NewCheck("Organize into YYYY/MM/DD folders (Ctrl+D)", nil)
+		d.baseSelect = widget.NewSelect(nil, nil)
+		d.baseSelect.Disable()
+		d.layoutSelect = widget.NewSelect(copyMoveLayoutLabels, func(label string) {
+			if label == copyMoveLayoutLabels[2] && len(d.baseSelect.Options) > 0 {
+				d.baseSelect.Enable()
+			} else {
+				d.baseSelect.Disable()
+			}
+		})
+		d.layoutSelect.SetSelectedIndex(0)
+	}
 	if len(matchers) > 0 {
 		d.matchers = matchers[0]
 	}
 	d.createWidgets()
 	d.updateFiltered("")
+	if d.opSelect != nil {
+		d.opSelect.SetSelectedIndex(slices.Index(copyMoveOperations, op))
+	}
 	return d
 }
 
 func (d *CopyMoveDialog) createWidgets() {
+	d.header = widget.NewLabel("")
 	d.searchEntry = NewCustomSearchEntry()
 	d.searchEntry.SetPlaceHolder("Type to filter destination...")
 	d.searchEntry.OnChanged = func(q string) { d.updateFiltered(q) }
@@ -154,8 +200,8 @@ func (d *CopyMoveDialog) ShowDialog(parent fyne.Window, onAccept func(CopyMoveRe
 
 	// Targets summary
 	count := len(d.targets)
-	header := widget.NewLabel(fmt.Sprintf("%s %d item(s)", strings.Title(string(d.op)), count))
-	header.TextStyle.Bold = true
+	d.header.TextStyle.Bold = true
+	d.updateHeader()
 
 	// Show up to 20 items, then elide
 	maxShow := 20
@@ -210,16 +256,30 @@ func (d *CopyMoveDialog) ShowDialog(parent fyne.Window, onAccept func(CopyMoveRe
 	}
 
 	contentObjects := []fyne.CanvasObject{
-		header,
+		d.header,
 		targetsScroll,
 		overflowLabel,
 		widget.NewSeparator(),
 		searchSection,
 		fixed,
 	}
+	if d.opSelect != nil {
+		contentObjects = append(contentObjects, container.NewBorder(nil, nil, widget.NewLabel("Operation (Ctrl+O):"), nil, d.opSelect))
+	}
 	if d.preserveCB != nil {
 		contentObjects = append(contentObjects, d.preserveCB)
 	}
+	if d.organizeCB != nil {
+		contentObjects = append(contentObjects, d.organizeCB)
+	}
+	if d.layoutSelect != nil {
+		layoutLabel := widget.NewLabel("Layout (Ctrl+L):")
+		baseLabel := widget.NewLabel("Base (Ctrl+B):")
+		contentObjects = append(contentObjects,
+			container.NewBorder(nil, nil, layoutLabel, nil, d.layoutSelect),
+			container.NewBorder(nil, nil, baseLabel, nil, d.baseSelect),
+		)
+	}
 	contentObjects = append(contentObjects, dialogButtonBar(dialogCancelButton("Cancel", d.CancelDialog), dialogConfirmButton("OK", d.AcceptSelection)))
 	content := container.NewVBox(contentObjects...)
 
@@ -238,9 +298,126 @@ func (d *CopyMoveDialog) ShowDialog(parent fyne.Window, onAccept func(CopyMoveRe
 	}
 }
 
+// Operation reports the operation the dialog will run.
+func (d *CopyMoveDialog) Operation() Operation {
+	return d.op
+}
+
+// CycleOperation switches the copy and move dialogs to the next of copy,
+// move, and creating symlinks.
+func (d *CopyMoveDialog) CycleOperation() {
+	if d.opSelect == nil {
+		return
+	}
+	d.opSelect.SetSelectedIndex((d.opSelect.SelectedIndex() + 1) % len(copyMoveOperations))
+}
+
+// applyOperation shows the options that apply to op: timestamps only for
+// copies and extraction, and no layout or dated folders for symlinks.
+func (d *CopyMoveDialog) applyOperation(op Operation) {
+	d.op = op
+	d.updateHeader()
+	if d.preserveCB != nil {
+		if op == OpCopy || op == OpExtract {
+			d.preserveCB.Show()
+		} else {
+			d.preserveCB.Hide()
+		}
+	}
+	if d.layoutSelect == nil {
+		return
+	}
+	if op == OpSymlink {
+		d.organizeCB.Disable()
+		d.layoutSelect.Disable()
+		d.baseSelect.Disable()
+		return
+	}
+	d.organizeCB.Enable()
+	d.layoutSelect.Enable()
+	if d.Layout() == jobs.LayoutRelative && len(d.baseSelect.Options) > 0 {
+		d.baseSelect.Enable()
+	}
+}
+
+func (d *CopyMoveDialog) updateHeader() {
+	if d.header == nil {
+		return
+	}
+	verb := strings.Title(string(d.op))
+	if d.op == OpSymlink {
+		verb = "Link"
+	}
+	d.header.SetText(fmt.Sprintf("%s %d item(s)", verb, len(d.targets)))
+}
+
 // PreserveTimestamps reports whether accepted copy should preserve timestamps.
 func (d *CopyMoveDialog) PreserveTimestamps() bool {
-	return d.preserveCB != nil && d.preserveCB.Checked
+	return (d.op == OpCopy || d.op == OpExtract) && d.preserveCB != nil && d.preserveCB.Checked
+}
+
+// OrganizeByDate reports whether accepted copy/move should place items into
+// dated subdirectories of the destination.
+func (d *CopyMoveDialog) OrganizeByDate() bool {
+	return d.op != OpSymlink && d.organizeCB != nil && d.organizeCB.Checked
+}
+
+// SetRelativeBaseCandidates lists the directories that the "Relative to base"
+// layout may measure source paths from. The first candidate is preselected.
+func (d *CopyMoveDialog) SetRelativeBaseCandidates(paths []string) {
+	if d.baseSelect == nil {
+		return
+	}
+	d.baseSelect.Options = append([]string(nil), paths...)
+	if len(paths) > 0 {
+		d.baseSelect.SetSelectedIndex(0)
+	} else {
+		d.baseSelect.ClearSelected()
+	}
+	if d.Layout() == jobs.LayoutRelative && len(paths) > 0 {
+		d.baseSelect.Enable()
+	}
+}
+
+// Layout reports the selected source layout.
+func (d *CopyMoveDialog) Layout() jobs.TransferLayout {
+	if d.layoutSelect == nil || d.op == OpSymlink {
+		return jobs.LayoutKeep
+	}
+	idx := d.layoutSelect.SelectedIndex()
+	if idx < 0 || idx >= len(copyMoveLayouts) {
+		return jobs.LayoutKeep
+	}
+	return copyMoveLayouts[idx]
+}
+
+// CycleLayout advances to the next source layout.
+func (d *CopyMoveDialog) CycleLayout() {
+	if d.layoutSelect == nil {
+		return
+	}
+	next := (d.layoutSelect.SelectedIndex() + 1) % len(copyMoveLayouts)
+	if copyMoveLayouts[next] == jobs.LayoutRelative && len(d.baseSelect.Options) == 0 {
+		next = (next + 1) % len(copyMoveLayouts)
+	}
+	d.layoutSelect.SetSelectedIndex(next)
+}
+
+// CycleRelativeBase advances to the next relative base candidate.
+func (d *CopyMoveDialog) CycleRelativeBase() {
+	if d.baseSelect == nil || len(d.baseSelect.Options) == 0 {
+		return
+	}
+	d.baseSelect.SetSelectedIndex((d.baseSelect.SelectedIndex() + 1) % len(d.baseSelect.Options))
+}
+
+// ToggleOrganizeByDate flips the organize-by-date option when the operation
+// supports it.
+func (d *CopyMoveDialog) ToggleOrganizeByDate() {
+	if d.organizeCB == nil {
+		return
+	}
+	d.organizeCB.SetChecked(!d.organizeCB.Checked)
 }
 
 // updateFiltered updates destination list
@@ -438,11 +615,19 @@ func (d *CopyMoveDialog) AcceptSelection() {
 		}
 		unfocusIfDialogOwned(d.parent, d.sink, d.searchEntry)
 		if d.onAccept != nil && acceptedPath != "" {
-			d.onAccept(CopyMoveResult{Destination: acceptedPath, PreserveTimestamps: d.PreserveTimestamps()})
+			d.onAccept(d.result(acceptedPath))
 		}
 	})
 }
 
+func (d *CopyMoveDialog) result(destination string) CopyMoveResult {
+	result := CopyMoveResult{Operation: d.op, Destination: destination, PreserveTimestamps: d.PreserveTimestamps(), OrganizeByDate: d.OrganizeByDate(), Layout: d.Layout()}
+	if result.Layout == jobs.LayoutRelative {
+		result.RelativeBase = d.baseSelect.Selected
+	}
+	return result
+}
+
 func (d *CopyMoveDialog) AcceptDirectPath() {
 	if d.closed {
 		return
@@ -470,7 +655,7 @@ func (d *CopyMoveDialog) AcceptDirectPath() {
 		}
 		unfocusIfDialogOwned(d.parent, d.sink, d.searchEntry)
 		if d.onAccept != nil && acceptedPath != "" {
-			d.onAccept(CopyMoveResult{Destination: acceptedPath, PreserveTimestamps: d.PreserveTimestamps()})
+			d.onAccept(d.result(acceptedPath))
 		}
 	})
 }
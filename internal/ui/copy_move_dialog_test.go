@@ -6,6 +6,7 @@ import (
 
 	"fyne.io/fyne/v2/widget"
 
+	"nmf/internal/jobs"
 	"nmf/internal/search"
 )
 
@@ -215,3 +216,74 @@ func TestMoveDialogDoesNotExposePreserveTimestamps(t *testing.T) {
 		t.Fatal("move dialog should not expose copy preserve timestamps option")
 	}
 }
+
+func TestCopyMoveDialogToggleOrganizeByDate(t *testing.T) {
+	for _, op := range []Operation{OpCopy, OpMove} {
+		dialog := NewCopyMoveDialog(op, []string{"photo.jpg"}, []DestinationCandidate{{Path: "/tmp/one"}}, map[string]time.Time{}, false, nil, func(string, ...interface{}) {})
+		if dialog.OrganizeByDate() {
+			t.Fatalf("%s: organize by date should default off", op)
+		}
+		dialog.ToggleOrganizeByDate()
+		if got := dialog.result("/tmp/one"); !got.OrganizeByDate {
+			t.Fatalf("%s: result = %+v, want organize by date", op, got)
+		}
+	}
+
+	extract := NewCopyMoveDialog(OpExtract, []string{"a.zip"}, nil, map[string]time.Time{}, false, nil, func(string, ...interface{}) {})
+	extract.ToggleOrganizeByDate()
+	if extract.OrganizeByDate() {
+		t.Fatal("extract dialog should not expose organize by date")
+	}
+}
+
+func TestCopyMoveDialogLayoutCycleAndRelativeBase(t *testing.T) {
+	dialog := NewCopyMoveDialog(OpCopy, []string{"src"}, []DestinationCandidate{{Path: "/tmp/one"}}, map[string]time.Time{}, false, nil, func(string, ...interface{}) {})
+
+	dialog.CycleLayout()
+	dialog.CycleLayout()
+	if got := dialog.Layout(); got != jobs.LayoutKeep {
+		t.Fatalf("without base candidates relative layout should be skipped, got %q", got)
+	}
+
+	dialog.SetRelativeBaseCandidates([]string{"/home/u/projects", "/home/u"})
+	dialog.CycleLayout()
+	dialog.CycleLayout()
+	dialog.CycleRelativeBase()
+	got := dialog.result("/tmp/one")
+	if got.Layout != jobs.LayoutRelative || got.RelativeBase != "/home/u" {
+		t.Fatalf("result = %+v, want relative layout from /home/u", got)
+	}
+
+	dialog.CycleLayout()
+	if got := dialog.result("/tmp/one"); got.Layout != jobs.LayoutKeep || got.RelativeBase != "" {
+		t.Fatalf("result = %+v, want keep layout without base", got)
+	}
+}
+
+func TestCopyMoveDialogCyclesToSymlinkOperation(t *testing.T) {
+	dialog := NewCopyMoveDialog(OpMove, []string{"src"}, []DestinationCandidate{{Path: "/tmp/one"}}, map[string]time.Time{}, true, nil, func(string, ...interface{}) {})
+	dialog.ToggleOrganizeByDate()
+	if got := dialog.result("/tmp/one"); got.Operation != OpMove || !got.OrganizeByDate {
+		t.Fatalf("result = %+v, want move organized by date", got)
+	}
+
+	dialog.CycleOperation()
+	got := dialog.result("/tmp/one")
+	if got.Operation != OpSymlink || got.OrganizeByDate || got.Layout != jobs.LayoutKeep || got.PreserveTimestamps {
+		t.Fatalf("result = %+v, want a plain symlink operation", got)
+	}
+	if dialog.header.Text != "Link 1 item(s)" {
+		t.Fatalf("header = %q", dialog.header.Text)
+	}
+
+	dialog.CycleOperation()
+	if got := dialog.result("/tmp/one"); got.Operation != OpCopy || !got.PreserveTimestamps {
+		t.Fatalf("result = %+v, want copy with the preserve default", got)
+	}
+
+	extract := NewCopyMoveDialog(OpExtract, []string{"a.zip"}, nil, map[string]time.Time{}, false, nil, func(string, ...interface{}) {})
+	extract.CycleOperation()
+	if extract.Operation() != OpExtract {
+		t.Fatalf("extract dialog operation = %s, want extract", extract.Operation())
+	}
+}
@@ -0,0 +1,388 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"nmf/internal/config"
+	"nmf/internal/fileinfo"
+	"nmf/internal/keymanager"
+	"nmf/internal/secret"
+)
+
+// CredentialsDialogActions reads and changes the keyring entries the
+// credentials dialog lists.
+type CredentialsDialogActions struct {
+	Load   func(host, share string) (fileinfo.Credentials, bool)
+	Save   func(host, share string, c fileinfo.Credentials) error
+	Forget func(host, share string) error
+}
+
+// CredentialsDialog lists the SMB credentials saved in the keyring and
+// edits or removes them. Changes are written to the keyring as they happen.
+type CredentialsDialog struct {
+	entries       []secret.Entry
+	actions       CredentialsDialogActions
+	list          *widget.List
+	emptyLabel    *widget.Label
+	statusLabel   *widget.Label
+	selectedIndex int
+	debugPrint    func(format string, args ...interface{})
+	keyManager    *keymanager.KeyManager
+	kmToken       keymanager.HandlerToken
+	bindings      []config.KeyBindingEntry
+	dialog        dialog.Dialog
+	parent        fyne.Window
+	sink          *KeySink
+	closed        bool
+	editing       bool
+	forgetting    bool
+}
+
+// NewCredentialsDialog creates a credentials dialog listing entries.
+func NewCredentialsDialog(
+	entries []secret.Entry,
+	actions CredentialsDialogActions,
+	keyManager *keymanager.KeyManager,
+	debugPrint func(format string, args ...interface{}),
+	configuredBindings ...[]config.KeyBindingEntry,
+) *CredentialsDialog {
+	d := &CredentialsDialog{
+		entries:       entries,
+		actions:       actions,
+		selectedIndex: -1,
+		debugPrint:    debugPrint,
+		keyManager:    keyManager,
+	}
+	if len(configuredBindings) > 0 {
+		d.bindings = configuredBindings[0]
+	}
+	if len(d.entries) > 0 {
+		d.selectedIndex = 0
+	}
+	d.createWidgets()
+	return d
+}
+
+func credentialsLocationLabel(e secret.Entry) string {
+	return e.Host + "/" + e.Share
+}
+
+func credentialsAccountLabel(e secret.Entry) string {
+	if e.Domain == "" {
+		return e.User
+	}
+	return e.Domain + `\` + e.User
+}
+
+func (d *CredentialsDialog) createWidgets() {
+	d.list = widget.NewList(
+		func() int {
+			return len(d.entries)
+		},
+		func() fyne.CanvasObject {
+			location := widget.NewLabel("")
+			location.TextStyle = fyne.TextStyle{Bold: true}
+			account := widget.NewLabel("")
+			account.TextStyle = fyne.TextStyle{Monospace: true}
+			return container.NewHBox(location, account)
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			if id < 0 || int(id) >= len(d.entries) {
+				return
+			}
+			entry := d.entries[id]
+			row, ok := obj.(*fyne.Container)
+			if !ok || len(row.Objects) < 2 {
+				return
+			}
+			if locationLabel, ok := row.Objects[0].(*widget.Label); ok {
+				locationLabel.SetText(credentialsLocationLabel(entry))
+			}
+			if accountLabel, ok := row.Objects[1].(*widget.Label); ok {
+				accountLabel.SetText(credentialsAccountLabel(entry))
+			}
+		},
+	)
+	d.list.OnSelected = func(id widget.ListItemID) {
+		if id >= 0 && int(id) < len(d.entries) {
+			d.selectedIndex = int(id)
+			d.focusSink()
+		}
+	}
+	d.list.Resize(searchDialogListSize())
+
+	d.emptyLabel = widget.NewLabel("No saved credentials. Check \"Save password\" when logging in to a share.")
+	d.emptyLabel.Alignment = fyne.TextAlignCenter
+	d.emptyLabel.Wrapping = fyne.TextWrapWord
+	d.statusLabel = widget.NewLabel("")
+}
+
+func credentialsListWidth(entries []secret.Entry, minimum float32) float32 {
+	lines := make([]string, len(entries))
+	for i, entry := range entries {
+		lines[i] = credentialsLocationLabel(entry) + "  " + credentialsAccountLabel(entry)
+	}
+	return dialogTextWidth(lines, minimum)
+}
+
+// ShowDialog shows the credentials dialog.
+func (d *CredentialsDialog) ShowDialog(parent fyne.Window) {
+	listWidth := responsiveDialogWidth(parent, searchDialogListWidth)
+	contentWidth := responsiveDialogWidth(parent, searchDialogContentWidth)
+	listSize := metricsSize(listWidth, searchDialogListHeight)
+	contentSize := metricsSize(contentWidth, searchDialogContentHeight)
+
+	titleLabel := widget.NewLabel("Saved Credentials")
+	titleLabel.TextStyle.Bold = true
+	hintLabel := widget.NewLabel("E/F2/Enter: edit   Del: remove   X: forget all   Esc: close")
+
+	listScroll := newScrollableDialogList(d.list, credentialsListWidth(d.entries, listWidth), listWidth, searchDialogListHeight)
+
+	fixedContainer := container.NewWithoutLayout(listScroll, d.emptyLabel)
+	fixedContainer.Resize(listSize)
+	listScroll.Resize(listSize)
+	listScroll.Move(fyne.NewPos(0, 0))
+	d.emptyLabel.Resize(listSize)
+	d.emptyLabel.Move(fyne.NewPos(0, 0))
+	d.updateEmptyState()
+
+	content := container.NewBorder(
+		container.NewVBox(titleLabel, hintLabel),
+		container.NewVBox(
+			d.statusLabel,
+			dialogButtonBar(
+				dialogAuxButton("Forget All", theme.WarningIcon(), d.ForgetAll),
+				dialogAuxButton("Remove", theme.DeleteIcon(), d.RemoveSelected),
+				dialogCancelButton("Close", d.CancelDialog),
+				dialogConfirmButton("Edit", d.EditSelected),
+			),
+		),
+		nil,
+		nil,
+		fixedContainer,
+	)
+	content.Resize(contentSize)
+
+	d.parent = parent
+
+	handler := keymanager.NewCredentialsDialogKeyHandler(d, d.debugPrint)
+	d.kmToken = d.keyManager.PushHandler(handler)
+
+	d.sink = NewKeySink(content, d.keyManager, WithTabCapture(true))
+	d.dialog = dialog.NewCustomWithoutButtons("Credentials", d.sink, parent)
+	d.dialog.Show()
+	if d.selectedIndex >= 0 {
+		d.list.Select(widget.ListItemID(d.selectedIndex))
+	}
+	d.focusSink()
+}
+
+func (d *CredentialsDialog) focusSink() {
+	if d.parent != nil && d.sink != nil {
+		d.parent.Canvas().Focus(d.sink)
+	}
+}
+
+func (d *CredentialsDialog) updateEmptyState() {
+	if d.emptyLabel == nil {
+		return
+	}
+	if len(d.entries) == 0 {
+		d.emptyLabel.Show()
+	} else {
+		d.emptyLabel.Hide()
+	}
+}
+
+func (d *CredentialsDialog) setStatus(text string) {
+	d.statusLabel.SetText(text)
+}
+
+// changed refreshes the list after entries were edited or removed.
+func (d *CredentialsDialog) changed() {
+	if d.selectedIndex >= len(d.entries) {
+		d.selectedIndex = len(d.entries) - 1
+	}
+	d.list.Refresh()
+	if d.selectedIndex >= 0 {
+		d.list.Select(widget.ListItemID(d.selectedIndex))
+	} else {
+		d.list.UnselectAll()
+	}
+	d.updateEmptyState()
+}
+
+func (d *CredentialsDialog) selectIndex(index int) {
+	if index < 0 || index >= len(d.entries) {
+		return
+	}
+	d.selectedIndex = index
+	d.list.Select(widget.ListItemID(index))
+}
+
+func (d *CredentialsDialog) selected() (secret.Entry, bool) {
+	if d.closed || d.selectedIndex < 0 || d.selectedIndex >= len(d.entries) {
+		return secret.Entry{}, false
+	}
+	return d.entries[d.selectedIndex], true
+}
+
+// MoveUp moves the selection up.
+func (d *CredentialsDialog) MoveUp() {
+	if d.selectedIndex > 0 {
+		d.selectIndex(d.selectedIndex - 1)
+	}
+}
+
+// MoveDown moves the selection down.
+func (d *CredentialsDialog) MoveDown() {
+	if d.selectedIndex < len(d.entries)-1 {
+		d.selectIndex(d.selectedIndex + 1)
+	}
+}
+
+// MoveToTop moves selection to the top.
+func (d *CredentialsDialog) MoveToTop() {
+	d.selectIndex(0)
+}
+
+// MoveToBottom moves selection to the bottom.
+func (d *CredentialsDialog) MoveToBottom() {
+	d.selectIndex(len(d.entries) - 1)
+}
+
+// EditSelected edits the selected entry's account and password in a nested
+// dialog and saves them back to the keyring.
+func (d *CredentialsDialog) EditSelected() {
+	entry, ok := d.selected()
+	if !ok || d.editing || d.forgetting {
+		return
+	}
+	current, ok := d.actions.Load(entry.Host, entry.Share)
+	if !ok {
+		d.dropEntry(entry)
+		d.setStatus(credentialsLocationLabel(entry) + " is no longer saved.")
+		return
+	}
+	d.editing = true
+	edit := newSMBCredentialsEditDialog(entry.Host, entry.Share, current, d.parent, d.keyManager, d.bindings, func(accepted bool, c fileinfo.Credentials) {
+		d.editing = false
+		defer d.focusSink()
+		if !accepted || d.closed {
+			return
+		}
+		if err := d.actions.Save(entry.Host, entry.Share, c); err != nil {
+			d.debugPrint("CredentialsDialog: save %s failed: %v", credentialsLocationLabel(entry), err)
+			d.setStatus(fmt.Sprintf("Could not save %s: %v", credentialsLocationLabel(entry), err))
+			return
+		}
+		d.debugPrint("CredentialsDialog: saved %s", credentialsLocationLabel(entry))
+		for i := range d.entries {
+			if d.entries[i].Host == entry.Host && d.entries[i].Share == entry.Share {
+				d.entries[i].Domain = c.Domain
+				d.entries[i].User = c.Username
+			}
+		}
+		d.setStatus("Saved " + credentialsLocationLabel(entry) + ".")
+		d.changed()
+	})
+	edit.show()
+}
+
+// RemoveSelected deletes the selected entry from the keyring.
+func (d *CredentialsDialog) RemoveSelected() {
+	entry, ok := d.selected()
+	if !ok || d.editing || d.forgetting {
+		return
+	}
+	if err := d.actions.Forget(entry.Host, entry.Share); err != nil {
+		d.debugPrint("CredentialsDialog: remove %s failed: %v", credentialsLocationLabel(entry), err)
+		d.setStatus(fmt.Sprintf("Could not remove %s: %v", credentialsLocationLabel(entry), err))
+		return
+	}
+	d.debugPrint("CredentialsDialog: removed %s", credentialsLocationLabel(entry))
+	d.dropEntry(entry)
+	d.setStatus("Removed " + credentialsLocationLabel(entry) + ".")
+}
+
+func (d *CredentialsDialog) dropEntry(entry secret.Entry) {
+	for i := range d.entries {
+		if d.entries[i].Host == entry.Host && d.entries[i].Share == entry.Share {
+			d.entries = append(d.entries[:i], d.entries[i+1:]...)
+			break
+		}
+	}
+	d.changed()
+}
+
+// ForgetAll asks to confirm removing every listed entry.
+func (d *CredentialsDialog) ForgetAll() {
+	if d.closed || d.editing || len(d.entries) == 0 {
+		return
+	}
+	d.forgetting = true
+	d.setStatus(fmt.Sprintf("Forget all %d saved credentials? Y: yes   N: no", len(d.entries)))
+}
+
+// ConfirmForgetAll removes every listed entry after ForgetAll asked. Entries
+// the keyring failed to remove stay listed.
+func (d *CredentialsDialog) ConfirmForgetAll() {
+	if !d.forgetting || d.closed {
+		return
+	}
+	d.forgetting = false
+	var kept []secret.Entry
+	var firstErr error
+	for _, entry := range d.entries {
+		if err := d.actions.Forget(entry.Host, entry.Share); err != nil {
+			d.debugPrint("CredentialsDialog: forget %s failed: %v", credentialsLocationLabel(entry), err)
+			kept = append(kept, entry)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	d.debugPrint("CredentialsDialog: forgot %d entries", len(d.entries)-len(kept))
+	d.entries = kept
+	if firstErr != nil {
+		d.setStatus(fmt.Sprintf("Could not remove %d entries: %v", len(kept), firstErr))
+	} else {
+		d.setStatus("Forgot all saved credentials.")
+	}
+	d.changed()
+}
+
+// CancelForgetAll answers no to ForgetAll.
+func (d *CredentialsDialog) CancelForgetAll() {
+	if !d.forgetting {
+		return
+	}
+	d.forgetting = false
+	d.setStatus("")
+}
+
+// CancelDialog closes the dialog, or answers no while ForgetAll is asking.
+func (d *CredentialsDialog) CancelDialog() {
+	if d.forgetting {
+		d.CancelForgetAll()
+		return
+	}
+	if d.closed {
+		return
+	}
+	d.closed = true
+
+	deferDialogClose(d.keyManager, "credentials.cancel", func() {
+		d.keyManager.RemoveHandler(d.kmToken)
+		if d.dialog != nil {
+			d.dialog.Hide()
+		}
+		unfocusIfDialogOwned(d.parent, d.sink)
+	})
+}
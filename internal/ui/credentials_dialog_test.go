@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"errors"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+
+	"nmf/internal/fileinfo"
+	"nmf/internal/keymanager"
+	"nmf/internal/secret"
+)
+
+type credentialsDialogStore struct {
+	saved     map[string]fileinfo.Credentials
+	forgotten []string
+	failOn    string
+}
+
+func (s *credentialsDialogStore) actions() CredentialsDialogActions {
+	return CredentialsDialogActions{
+		Load: func(host, share string) (fileinfo.Credentials, bool) {
+			c, ok := s.saved[host+"/"+share]
+			return c, ok
+		},
+		Save: func(host, share string, c fileinfo.Credentials) error {
+			s.saved[host+"/"+share] = c
+			return nil
+		},
+		Forget: func(host, share string) error {
+			if host+"/"+share == s.failOn {
+				return errors.New("keyring locked")
+			}
+			s.forgotten = append(s.forgotten, host+"/"+share)
+			delete(s.saved, host+"/"+share)
+			return nil
+		},
+	}
+}
+
+func newTestCredentialsDialog(t *testing.T) (*CredentialsDialog, *keymanager.KeyManager, *credentialsDialogStore, fyne.Window) {
+	t.Helper()
+	app := test.NewApp()
+	t.Cleanup(app.Quit)
+	w := test.NewWindow(nil)
+	t.Cleanup(w.Close)
+	store := &credentialsDialogStore{saved: map[string]fileinfo.Credentials{
+		"files/docs":   {Domain: "CORP", Username: "alice", Password: "a"},
+		"files/public": {Username: "guest", Password: "g"},
+		"nas/media":    {Username: "bob", Password: "b"},
+	}}
+	km := keymanager.NewKeyManager(func(string, ...interface{}) {})
+	d := NewCredentialsDialog([]secret.Entry{
+		{Host: "files", Share: "docs", Domain: "CORP", User: "alice"},
+		{Host: "files", Share: "public", User: "guest"},
+		{Host: "nas", Share: "media", User: "bob"},
+	}, store.actions(), km, func(string, ...interface{}) {})
+	d.ShowDialog(w)
+	return d, km, store, w
+}
+
+// pressDialogKey delivers one key press the way the window does, down
+// first so the key manager gate is open.
+func pressDialogKey(km *keymanager.KeyManager, name fyne.KeyName, r rune) {
+	km.HandleKeyDown(&fyne.KeyEvent{Name: name})
+	km.HandleTypedKey(&fyne.KeyEvent{Name: name})
+	if r != 0 {
+		km.HandleTypedRune(r)
+	}
+	km.HandleKeyUp(&fyne.KeyEvent{Name: name})
+}
+
+func TestCredentialsDialogRemoveSelected(t *testing.T) {
+	d, km, store, _ := newTestCredentialsDialog(t)
+
+	d.MoveDown()
+	pressDialogKey(km, fyne.KeyDelete, 0)
+	if len(store.forgotten) != 1 || store.forgotten[0] != "files/public" {
+		t.Fatalf("forgotten = %v, want [files/public]", store.forgotten)
+	}
+	if len(d.entries) != 2 || d.entries[1].Host != "nas" || d.selectedIndex != 1 {
+		t.Fatalf("entries = %+v selected=%d", d.entries, d.selectedIndex)
+	}
+}
+
+func TestCredentialsDialogForgetAllAsksFirst(t *testing.T) {
+	d, km, store, _ := newTestCredentialsDialog(t)
+	store.failOn = "nas/media"
+
+	pressDialogKey(km, fyne.KeyX, 'x')
+	if len(store.forgotten) != 0 {
+		t.Fatal("X forgot entries without confirmation")
+	}
+	pressDialogKey(km, fyne.KeyEscape, 0)
+	if d.closed || d.forgetting {
+		t.Fatal("Escape should answer no, not close the dialog")
+	}
+	pressDialogKey(km, fyne.KeyY, 'y')
+	if len(store.forgotten) != 0 {
+		t.Fatal("Y without a pending question forgot entries")
+	}
+
+	pressDialogKey(km, fyne.KeyX, 'x')
+	pressDialogKey(km, fyne.KeyY, 'y')
+	if len(store.forgotten) != 2 {
+		t.Fatalf("forgotten = %v, want both removable entries", store.forgotten)
+	}
+	if len(d.entries) != 1 || d.entries[0].Host != "nas" {
+		t.Fatalf("entries = %+v, want the entry the keyring kept", d.entries)
+	}
+}
+
+func TestCredentialsDialogEditSavesAccount(t *testing.T) {
+	d, _, store, w := newTestCredentialsDialog(t)
+
+	d.EditSelected()
+	edit, ok := w.Canvas().Focused().(*LineEditEntry)
+	if !ok {
+		t.Fatalf("focus = %T, want the edit dialog's password entry", w.Canvas().Focused())
+	}
+	edit.SetText("new")
+	typeEntryKey(edit, fyne.KeyReturn)
+	fyne.DoAndWait(func() {})
+
+	if got := store.saved["files/docs"]; got != (fileinfo.Credentials{Domain: "CORP", Username: "alice", Password: "new"}) {
+		t.Fatalf("saved = %+v, want the new password without Persist", got)
+	}
+	if d.editing || w.Canvas().Focused() != d.sink {
+		t.Fatal("focus did not return to the credentials list")
+	}
+}
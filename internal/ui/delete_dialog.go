@@ -14,10 +14,17 @@ import (
 
 const deleteConfirmWord = "DELETE"
 
+// secureDeleteCaveat is shown in the secure delete dialog because overwriting
+// in place is only dependable on media that write in place.
+const secureDeleteCaveat = "Overwriting is only reliable on spinning disks and simple USB sticks. " +
+	"SSDs and flash cards remap writes, and copy-on-write or journaling file systems " +
+	"(Btrfs, ZFS, APFS, ReFS), snapshots, and backups can keep old copies of the data."
+
 // DeleteConfirmDialog confirms trash or permanent deletion for one or more items.
 type DeleteConfirmDialog struct {
 	targets    []string
 	permanent  bool
+	secure     bool
 	entry      *deleteConfirmEntry
 	keyManager *keymanager.KeyManager
 	kmToken    keymanager.HandlerToken
@@ -42,13 +49,24 @@ func NewDeleteConfirmDialog(targets []string, permanent bool, km *keymanager.Key
 	return d
 }
 
+// NewSecureDeleteConfirmDialog confirms overwriting and permanently deleting
+// targets. Like permanent delete it requires typing the confirm word.
+func NewSecureDeleteConfirmDialog(targets []string, km *keymanager.KeyManager) *DeleteConfirmDialog {
+	d := NewDeleteConfirmDialog(targets, true, km)
+	d.secure = true
+	return d
+}
+
 func (d *DeleteConfirmDialog) ShowDialog(parent fyne.Window, onAccept func()) {
 	d.parent = parent
 	d.onAccept = onAccept
 
 	title := "Move to Trash"
 	action := "Trash"
-	if d.permanent {
+	if d.secure {
+		title = "Securely Delete"
+		action = "Shred"
+	} else if d.permanent {
 		title = "Permanently Delete"
 		action = "Delete"
 	}
@@ -57,6 +75,11 @@ func (d *DeleteConfirmDialog) ShowDialog(parent fyne.Window, onAccept func()) {
 		widget.NewLabel(d.message()),
 		d.targetList(),
 	)
+	if d.secure {
+		caveat := widget.NewLabel(secureDeleteCaveat)
+		caveat.Wrapping = fyne.TextWrapWord
+		content.Add(caveat)
+	}
 	if d.permanent {
 		content.Add(widget.NewLabel(fmt.Sprintf("Type %s to confirm:", deleteConfirmWord)))
 		content.Add(d.entry)
@@ -79,6 +102,9 @@ func (d *DeleteConfirmDialog) ShowDialog(parent fyne.Window, onAccept func()) {
 
 func (d *DeleteConfirmDialog) message() string {
 	count := len(d.targets)
+	if d.secure {
+		return fmt.Sprintf("Overwrite and permanently delete %d item(s)? This cannot be undone.", count)
+	}
 	if d.permanent {
 		return fmt.Sprintf("Permanently delete %d item(s)? This cannot be undone.", count)
 	}
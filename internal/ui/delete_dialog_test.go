@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"strings"
 	"testing"
 
 	"fyne.io/fyne/v2"
@@ -96,3 +97,23 @@ func TestTrashDeleteDoesNotRequireConfirmWord(t *testing.T) {
 		t.Fatal("trash delete should accept without confirm word")
 	}
 }
+
+func TestSecureDeleteRequiresConfirmWord(t *testing.T) {
+	km := keymanager.NewKeyManager(func(string, ...interface{}) {})
+	d := NewSecureDeleteConfirmDialog([]string{"file.txt"}, km)
+	accepted := false
+	d.onAccept = func() { accepted = true }
+
+	if got := d.message(); !strings.HasPrefix(got, "Overwrite and permanently delete 1 item(s)?") {
+		t.Fatalf("message = %q, want secure delete wording", got)
+	}
+	d.ConfirmDelete()
+	if accepted {
+		t.Fatal("secure delete should not accept without DELETE")
+	}
+	d.entry.SetText("DELETE")
+	d.ConfirmDelete()
+	if !accepted {
+		t.Fatal("secure delete should accept exact DELETE")
+	}
+}
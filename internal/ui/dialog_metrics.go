@@ -44,6 +44,15 @@ const (
 	renameDialogMaxWidth   float32 = 960
 	renameDialogWidthRatio         = 0.70
 
+	compressDialogHeight float32 = 230
+
+	batchRenameDialogWidth  float32 = 720
+	batchRenameDialogHeight float32 = 600
+	batchRenameListHeight   float32 = 240
+
+	propertiesDialogHeight float32 = 560
+	propertiesInfoHeight   float32 = 300
+
 	conflictDialogWidth float32 = 620
 
 	deleteDialogWidth      float32 = 560
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// DirectoryTabBar shows a window's directory tabs as a row of stock
+// widget.Button instances, like the viewer pane switcher. It keeps no
+// directory state: the owner calls SetTabs after every change, and the bar
+// stays hidden while the window has a single tab.
+type DirectoryTabBar struct {
+	buttons  []*widget.Button
+	row      *fyne.Container
+	bar      *fyne.Container
+	active   int
+	onSelect func(index int)
+}
+
+func NewDirectoryTabBar(onSelect func(index int)) *DirectoryTabBar {
+	t := &DirectoryTabBar{onSelect: onSelect}
+	t.row = container.NewHBox()
+	t.bar = container.NewVBox(container.NewHScroll(t.row), widget.NewSeparator())
+	t.bar.Hide()
+	return t
+}
+
+func (t *DirectoryTabBar) Container() fyne.CanvasObject {
+	return t.bar
+}
+
+// SetTabs replaces the tab labels and highlights active.
+func (t *DirectoryTabBar) SetTabs(labels []string, active int) {
+	for len(t.buttons) < len(labels) {
+		index := len(t.buttons)
+		button := widget.NewButton("", func() {
+			if t.onSelect != nil {
+				t.onSelect(index)
+			}
+		})
+		t.buttons = append(t.buttons, button)
+	}
+	objects := make([]fyne.CanvasObject, 0, len(labels))
+	for i, label := range labels {
+		button := t.buttons[i]
+		button.Text = label
+		button.Importance = widget.MediumImportance
+		if i == active {
+			button.Importance = widget.HighImportance
+		}
+		button.Refresh()
+		objects = append(objects, button)
+	}
+	t.active = active
+	t.row.Objects = objects
+	t.row.Refresh()
+	if len(labels) > 1 {
+		t.bar.Show()
+	} else {
+		t.bar.Hide()
+	}
+}
+
+// Labels returns the shown tab labels; used by tests.
+func (t *DirectoryTabBar) Labels() []string {
+	labels := make([]string, 0, len(t.row.Objects))
+	for _, object := range t.row.Objects {
+		labels = append(labels, object.(*widget.Button).Text)
+	}
+	return labels
+}
+
+// Active returns the highlighted tab index; used by tests.
+func (t *DirectoryTabBar) Active() int {
+	return t.active
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestDirectoryTabBarHidesWithSingleTab(t *testing.T) {
+	test.NewTempApp(t)
+	bar := NewDirectoryTabBar(nil)
+
+	bar.SetTabs([]string{"home"}, 0)
+	if bar.Container().Visible() {
+		t.Fatal("tab bar visible with one tab")
+	}
+
+	bar.SetTabs([]string{"home", "src"}, 1)
+	if !bar.Container().Visible() {
+		t.Fatal("tab bar hidden with two tabs")
+	}
+	if got := bar.Labels(); !reflect.DeepEqual(got, []string{"home", "src"}) || bar.Active() != 1 {
+		t.Fatalf("labels = %v active = %d", got, bar.Active())
+	}
+	if bar.buttons[1].Importance != widget.HighImportance || bar.buttons[0].Importance != widget.MediumImportance {
+		t.Fatal("active tab is not highlighted")
+	}
+}
+
+func TestDirectoryTabBarTapSelectsIndex(t *testing.T) {
+	test.NewTempApp(t)
+	selected := -1
+	bar := NewDirectoryTabBar(func(index int) { selected = index })
+	bar.SetTabs([]string{"a", "b", "c"}, 0)
+
+	test.Tap(bar.buttons[2])
+	if selected != 2 {
+		t.Fatalf("selected = %d, want 2", selected)
+	}
+
+	bar.SetTabs([]string{"a", "c"}, 1)
+	if got := bar.Labels(); !reflect.DeepEqual(got, []string{"a", "c"}) {
+		t.Fatalf("labels after close = %v", got)
+	}
+}
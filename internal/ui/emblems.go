@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+
+	"nmf/internal/fileinfo"
+)
+
+// Emblem is a set of small badges drawn over a file icon.
+type Emblem uint8
+
+const (
+	// EmblemLink marks symbolic links and junctions.
+	EmblemLink Emblem = 1 << iota
+	// EmblemReadOnly marks entries without write permission.
+	EmblemReadOnly
+	// EmblemRemote marks entries on a network share.
+	EmblemRemote
+	// EmblemSelected marks selected entries.
+	EmblemSelected
+)
+
+// Each badge owns one corner of the 24x24 icon page so any combination stays
+// legible: remote top-left, read-only top-right, link bottom-left, selected
+// bottom-right. The color tag swatch is drawn above the selected badge.
+// Badges are light glyphs on a dark disc so they read on both themes.
+var emblemBadges = []struct {
+	emblem Emblem
+	x, y   float32
+	glyph  string
+}{
+	{EmblemRemote, 0, 0,
+		`<path d="M2.6 6.4h3.9a1.3 1.3 0 0 0 0-2.6a1.8 1.8 0 0 0-3.4 0.4a1.1 1.1 0 0 0-0.5 2.2z" fill="#fff"/>`},
+	{EmblemReadOnly, 15, 0,
+		`<rect x="2.7" y="4.2" width="3.6" height="2.9" rx="0.4" fill="#fff"/>` +
+			`<path d="M3.3 4.2V3.4a1.2 1.2 0 0 1 2.4 0v0.8" fill="none" stroke="#fff" stroke-width="0.9"/>`},
+	{EmblemLink, 0, 15,
+		`<path d="M2.8 6.2l3.2-3.2 M3.6 3h2.4v2.4" fill="none" stroke="#fff" stroke-width="1.2" stroke-linecap="round" stroke-linejoin="round"/>`},
+	{EmblemSelected, 15, 15,
+		`<path d="M2.3 4.7l1.6 1.6 3-3.4" fill="none" stroke="#fff" stroke-width="1.3" stroke-linecap="round" stroke-linejoin="round"/>`},
+}
+
+// EmblemsFor picks the badges for a list entry. It only looks at fields
+// already in fi, so rows never touch the filesystem; remote means an smb://
+// display path.
+func EmblemsFor(fi fileinfo.FileInfo, selected bool) Emblem {
+	var e Emblem
+	if fi.FileType == fileinfo.FileTypeSymlink {
+		e |= EmblemLink
+	}
+	if fi.ReadOnly {
+		e |= EmblemReadOnly
+	}
+	if fileinfo.IsSMBDisplay(fi.Path) {
+		e |= EmblemRemote
+	}
+	if selected {
+		e |= EmblemSelected
+	}
+	return e
+}
+
+var emblemCache = struct {
+	sync.Mutex
+	items map[Emblem]fyne.Resource
+}{items: make(map[Emblem]fyne.Resource)}
+
+// EmblemResource returns one transparent SVG overlay holding every badge in
+// e, sized to cover the icon. Each combination is rendered once and cached;
+// nil is returned when e is empty.
+func EmblemResource(e Emblem) fyne.Resource {
+	if e == 0 {
+		return nil
+	}
+	emblemCache.Lock()
+	defer emblemCache.Unlock()
+	if res, ok := emblemCache.items[e]; ok {
+		return res
+	}
+	var svg strings.Builder
+	svg.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24">`)
+	for _, badge := range emblemBadges {
+		if e&badge.emblem == 0 {
+			continue
+		}
+		fmt.Fprintf(&svg, `<g transform="translate(%g %g)">`, badge.x, badge.y)
+		svg.WriteString(`<circle cx="4.5" cy="4.5" r="4.3" fill="#202020" fill-opacity="0.85"/>`)
+		svg.WriteString(badge.glyph)
+		svg.WriteString(`</g>`)
+	}
+	svg.WriteString(`</svg>`)
+	res := fyne.NewStaticResource(fmt.Sprintf("emblem-%02x.svg", uint8(e)), []byte(svg.String()))
+	emblemCache.items[e] = res
+	return res
+}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"nmf/internal/fileinfo"
+)
+
+func TestEmblemsFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		fi       fileinfo.FileInfo
+		selected bool
+		want     Emblem
+	}{
+		{"plain", fileinfo.FileInfo{Path: "/tmp/a.txt"}, false, 0},
+		{"link", fileinfo.FileInfo{Path: "/tmp/l", FileType: fileinfo.FileTypeSymlink}, false, EmblemLink},
+		{"read-only", fileinfo.FileInfo{Path: "/tmp/ro", ReadOnly: true}, false, EmblemReadOnly},
+		{"remote", fileinfo.FileInfo{Path: "smb://host/share/a.txt"}, false, EmblemRemote},
+		{"selected remote link", fileinfo.FileInfo{Path: "SMB://host/share/l", FileType: fileinfo.FileTypeSymlink}, true,
+			EmblemLink | EmblemRemote | EmblemSelected},
+	}
+	for _, tt := range tests {
+		if got := EmblemsFor(tt.fi, tt.selected); got != tt.want {
+			t.Errorf("%s: EmblemsFor = %04b, want %04b", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEmblemResourceIsCachedPerCombination(t *testing.T) {
+	if EmblemResource(0) != nil {
+		t.Fatal("no emblems should have no overlay")
+	}
+	res := EmblemResource(EmblemLink | EmblemReadOnly)
+	if !strings.HasSuffix(res.Name(), ".svg") {
+		t.Fatalf("resource name %q should be an SVG", res.Name())
+	}
+	if got := strings.Count(string(res.Content()), "<circle"); got != 2 {
+		t.Fatalf("overlay has %d badges, want 2: %s", got, res.Content())
+	}
+	if EmblemResource(EmblemLink|EmblemReadOnly) != res {
+		t.Fatal("same combination should reuse the cached resource")
+	}
+	if EmblemResource(EmblemLink) == res {
+		t.Fatal("different combinations must not share a resource")
+	}
+}
@@ -0,0 +1,226 @@
+package ui
+
+import (
+	"image/color"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"nmf/internal/config"
+)
+
+// columnChars is the width of each fixed column in monospace characters,
+// enough for its longest usual value ("1023.9 MB", "2006-01-02 15:04:05").
+var columnChars = map[string]int{
+	config.ColumnSize:        9,
+	config.ColumnExtension:   6,
+	config.ColumnModified:    19,
+	config.ColumnPermissions: 10,
+	config.ColumnOwner:       10,
+	config.ColumnLinkTarget:  24,
+}
+
+// FileColumnWidth returns the width of a column; the name column is
+// flexible and returns 0.
+func FileColumnWidth(column string) float32 {
+	chars, ok := columnChars[column]
+	if !ok {
+		return 0
+	}
+	textSize := fyne.CurrentApp().Settings().Theme().Size(theme.SizeNameText)
+	text := fyne.MeasureText(strings.Repeat("0", chars), textSize, fyne.TextStyle{Monospace: true})
+	return text.Width + 2*theme.InnerPadding()
+}
+
+// columnTitle is the header text of a column.
+func columnTitle(column string) string {
+	switch column {
+	case config.ColumnExtension:
+		return "Ext"
+	case config.ColumnPermissions:
+		return "Mode"
+	case config.ColumnLinkTarget:
+		return "Target"
+	}
+	return strings.ToUpper(column[:1]) + column[1:]
+}
+
+// columnLayout places one object per column: fixed columns get their width
+// and the name column takes what is left. Rows and the header share it so
+// their cells line up.
+type columnLayout struct {
+	widths []float32
+}
+
+func newColumnLayout(columns []string) *columnLayout {
+	widths := make([]float32, len(columns))
+	for i, column := range columns {
+		widths[i] = FileColumnWidth(column)
+	}
+	return &columnLayout{widths: widths}
+}
+
+func (l *columnLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	fixed := float32(0)
+	for _, w := range l.widths {
+		fixed += w
+	}
+	flex := max(0, size.Width-fixed)
+	x := float32(0)
+	for i, obj := range objects {
+		if i >= len(l.widths) {
+			break
+		}
+		w := l.widths[i]
+		if w == 0 {
+			w = flex
+		}
+		obj.Move(fyne.NewPos(x, 0))
+		obj.Resize(fyne.NewSize(w, size.Height))
+		x += w
+	}
+}
+
+func (l *columnLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	var size fyne.Size
+	for i, obj := range objects {
+		if i >= len(l.widths) {
+			break
+		}
+		objMin := obj.MinSize()
+		if l.widths[i] == 0 {
+			size.Width += objMin.Width
+		} else {
+			size.Width += l.widths[i]
+		}
+		size.Height = max(size.Height, objMin.Height)
+	}
+	return size
+}
+
+// NewColumnFileListRow creates a file-list row for the detailed view: the
+// icon and name fill the name column and every other column is a label, in
+// the order of columns. InfoLabel is nil in this layout.
+func NewColumnFileListRow(cursorStyle config.CursorStyleConfig, nameColor color.RGBA, columns []string) *FileListRow {
+	row := NewFileListRow(cursorStyle, nameColor)
+	row.InfoLabel = nil
+	row.columns = append([]string(nil), columns...)
+	cells := make([]fyne.CanvasObject, len(columns))
+	row.cells = make([]*widget.Label, len(columns))
+	for i, column := range columns {
+		if column == config.ColumnName {
+			cells[i] = container.NewBorder(nil, nil, row.Icon, nil, row.NameLabel)
+			continue
+		}
+		label := widget.NewLabel("")
+		label.TextStyle = fyne.TextStyle{Monospace: true}
+		label.Truncation = fyne.TextTruncateClip
+		if column == config.ColumnSize {
+			label.Alignment = fyne.TextAlignTrailing
+		}
+		row.cells[i] = label
+		cells[i] = label
+	}
+	row.content = container.New(newColumnLayout(columns), cells...)
+	return row
+}
+
+// SetColumnText sets the text of a column cell; the name column and
+// columns the row does not show are ignored.
+func (r *FileListRow) SetColumnText(column, text string) {
+	for i, c := range r.columns {
+		if c == column && r.cells[i] != nil {
+			r.cells[i].SetText(text)
+			return
+		}
+	}
+}
+
+// ColumnText returns the text of a column cell; used by tests.
+func (r *FileListRow) ColumnText(column string) string {
+	for i, c := range r.columns {
+		if c == column && r.cells[i] != nil {
+			return r.cells[i].Text
+		}
+	}
+	return ""
+}
+
+// ColumnHeader is the clickable header row above the detailed list. Clicking
+// a sortable column calls onSort with its ui.sort.sortBy value.
+type ColumnHeader struct {
+	widget.BaseWidget
+
+	columns []string
+	buttons []*widget.Button
+	content *fyne.Container
+}
+
+// NewColumnHeader creates a header for columns.
+func NewColumnHeader(columns []string, onSort func(sortBy string)) *ColumnHeader {
+	h := &ColumnHeader{columns: append([]string(nil), columns...)}
+	cells := make([]fyne.CanvasObject, len(columns))
+	h.buttons = make([]*widget.Button, len(columns))
+	for i, column := range columns {
+		button := widget.NewButton(columnTitle(column), nil)
+		button.Importance = widget.LowImportance
+		button.Alignment = widget.ButtonAlignLeading
+		button.IconPlacement = widget.ButtonIconTrailingText
+		if sortBy, ok := config.ColumnSortBy(column); ok {
+			button.OnTapped = func() {
+				if onSort != nil {
+					onSort(sortBy)
+				}
+			}
+		} else {
+			button.Disable()
+		}
+		h.buttons[i] = button
+		cells[i] = button
+	}
+	h.content = container.New(newColumnLayout(columns), cells...)
+	h.ExtendBaseWidget(h)
+	return h
+}
+
+// SetSort marks the column sorting the list with an arrow.
+func (h *ColumnHeader) SetSort(sortBy string, ascending bool) {
+	for i, column := range h.columns {
+		var icon fyne.Resource
+		if by, ok := config.ColumnSortBy(column); ok && by == sortBy {
+			icon = theme.MenuDropUpIcon()
+			if !ascending {
+				icon = theme.MenuDropDownIcon()
+			}
+		}
+		if h.buttons[i].Icon != icon {
+			h.buttons[i].SetIcon(icon)
+		}
+	}
+}
+
+// SortIndicator returns the column showing the sort arrow; used by tests.
+func (h *ColumnHeader) SortIndicator() string {
+	for i, button := range h.buttons {
+		if button.Icon != nil {
+			return h.columns[i]
+		}
+	}
+	return ""
+}
+
+// Tap clicks the header of column; used by tests.
+func (h *ColumnHeader) Tap(column string) {
+	for i, c := range h.columns {
+		if c == column && h.buttons[i].OnTapped != nil && !h.buttons[i].Disabled() {
+			h.buttons[i].OnTapped()
+		}
+	}
+}
+
+func (h *ColumnHeader) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(h.content)
+}
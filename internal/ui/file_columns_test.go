@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+
+	"nmf/internal/config"
+)
+
+func TestColumnFileListRowLaysOutColumnsInConfiguredOrder(t *testing.T) {
+	test.NewTempApp(t)
+	columns := []string{config.ColumnSize, config.ColumnName, config.ColumnModified}
+	row := NewColumnFileListRow(config.CursorStyleConfig{}, color.RGBA{A: 255}, columns)
+	row.SetColumnText(config.ColumnSize, "1.0 KB")
+	row.SetColumnText(config.ColumnOwner, "ignored")
+	if row.InfoLabel != nil || row.ColumnText(config.ColumnSize) != "1.0 KB" {
+		t.Fatalf("size cell = %q", row.ColumnText(config.ColumnSize))
+	}
+
+	test.WidgetRenderer(row)
+	row.Resize(fyne.NewSize(800, row.MinSize().Height))
+	sizeCell := row.cells[0]
+	modifiedCell := row.cells[2]
+	if sizeCell.Position().X != 0 {
+		t.Fatalf("size column x = %v, want first", sizeCell.Position().X)
+	}
+	if got, want := sizeCell.Size().Width, FileColumnWidth(config.ColumnSize); got != want {
+		t.Fatalf("size column width = %v, want %v", got, want)
+	}
+	if right := modifiedCell.Position().X + modifiedCell.Size().Width; right != 800 {
+		t.Fatalf("modified column ends at %v, want the row's right edge", right)
+	}
+	if row.NameLabel.Size().Width <= 0 {
+		t.Fatal("the name column should take the remaining width")
+	}
+}
+
+func TestColumnHeaderSortsBySortableColumns(t *testing.T) {
+	test.NewTempApp(t)
+	var sorted []string
+	header := NewColumnHeader(
+		[]string{config.ColumnName, config.ColumnSize, config.ColumnOwner},
+		func(sortBy string) { sorted = append(sorted, sortBy) },
+	)
+	header.Tap(config.ColumnSize)
+	header.Tap(config.ColumnOwner)
+	if len(sorted) != 1 || sorted[0] != "size" {
+		t.Fatalf("sorted = %q, want only size", sorted)
+	}
+
+	header.SetSort("size", false)
+	if got := header.SortIndicator(); got != config.ColumnSize {
+		t.Fatalf("sort indicator on %q, want size", got)
+	}
+	header.SetSort("dateTaken", true)
+	if got := header.SortIndicator(); got != "" {
+		t.Fatalf("sort indicator on %q, want none for a sort without column", got)
+	}
+}
@@ -10,14 +10,23 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"nmf/internal/config"
+	"nmf/internal/fileinfo"
 )
 
 const backgroundCursorAlphaScale = 0.38
 
+// tagSwatchScale sizes the color tag swatch relative to the icon.
+const tagSwatchScale = 0.45
+
+// groupRuleAlphaScale dims the text color for the rule above a group.
+const groupRuleAlphaScale = 0.5
+
 // FileListRow is the reusable visual template for a file list item.
 //
 // Its renderer owns a fixed set of canvas objects. List UpdateItem callbacks
 // update row state and child widgets, but never replace the canvas object tree.
+// Handlers are installed once per row and read the entry recorded by Bind, so
+// an update allocates no closures either.
 type FileListRow struct {
 	widget.BaseWidget
 
@@ -26,6 +35,8 @@ type FileListRow struct {
 	InfoLabel *widget.Label
 
 	content        *fyne.Container
+	columns        []string        // detailed view columns; nil for the compact layout
+	cells          []*widget.Label // per column, nil for the name column
 	cursorStyle    config.CursorStyleConfig
 	hasStatus      bool
 	statusColor    color.RGBA
@@ -33,6 +44,29 @@ type FileListRow struct {
 	selectionColor color.RGBA
 	cursor         bool
 	cursorColor    color.RGBA
+	tagColor       color.RGBA
+	emblems        Emblem
+	groupStart     bool
+
+	bound      bool
+	boundIndex int
+	boundFile  fileinfo.FileInfo
+
+	onTappedSecondary func(*fyne.PointEvent)
+}
+
+// FileListRowState is everything the row's decoration layers show: status,
+// selection, and cursor backgrounds, the color tag swatch, emblems, and the
+// rule above the first entry of a group.
+type FileListRowState struct {
+	StatusColor    *color.RGBA
+	Selected       bool
+	SelectionColor color.RGBA
+	Cursor         bool
+	CursorColor    color.RGBA
+	TagColor       color.RGBA
+	Emblems        Emblem
+	GroupStart     bool
 }
 
 // NewFileListRow creates a reusable file-list row with fixed content and
@@ -53,10 +87,26 @@ func NewFileListRow(cursorStyle config.CursorStyleConfig, nameColor color.RGBA)
 		content:     container.NewBorder(nil, nil, icon, info, name),
 		cursorStyle: cursorStyle,
 	}
+	icon.SetOnTappedSecondary(row.TappedSecondary)
+	name.SetOnTappedSecondary(row.TappedSecondary)
 	row.ExtendBaseWidget(row)
 	return row
 }
 
+// TappedSecondary handles a right click anywhere on the row, including the
+// icon and the name, which pass their right clicks on to the row.
+func (r *FileListRow) TappedSecondary(ev *fyne.PointEvent) {
+	if r.onTappedSecondary != nil {
+		r.onTappedSecondary(ev)
+	}
+}
+
+// SetOnTappedSecondary sets the callback invoked when the row is
+// right-clicked.
+func (r *FileListRow) SetOnTappedSecondary(onTappedSecondary func(*fyne.PointEvent)) {
+	r.onTappedSecondary = onTappedSecondary
+}
+
 // SetDecorations updates the row's status, selection, and cursor state.
 // The renderer keeps its CanvasObject identities stable across calls.
 func (r *FileListRow) SetDecorations(
@@ -66,6 +116,54 @@ func (r *FileListRow) SetDecorations(
 	cursor bool,
 	cursorColor color.RGBA,
 ) {
+	if r.applyDecorations(statusColor, selected, selectionColor, cursor, cursorColor) {
+		r.Refresh()
+	}
+}
+
+// SetState updates every decoration layer with at most one refresh, where
+// SetDecorations, SetTagColor, and SetEmblems would refresh once each.
+func (r *FileListRow) SetState(state FileListRowState) {
+	changed := r.applyDecorations(state.StatusColor, state.Selected, state.SelectionColor, state.Cursor, state.CursorColor)
+	if r.tagColor != state.TagColor {
+		r.tagColor = state.TagColor
+		changed = true
+	}
+	if r.emblems != state.Emblems {
+		r.emblems = state.Emblems
+		changed = true
+	}
+	if r.groupStart != state.GroupStart {
+		r.groupStart = state.GroupStart
+		changed = true
+	}
+	if changed {
+		r.Refresh()
+	}
+}
+
+// Bind records the listing entry the recycled row shows now.
+func (r *FileListRow) Bind(index int, file fileinfo.FileInfo) {
+	r.bound = true
+	r.boundIndex = index
+	r.boundFile = file
+}
+
+// Bound returns the entry recorded by the last Bind; ok is false before the
+// list has bound the row.
+func (r *FileListRow) Bound() (index int, file fileinfo.FileInfo, ok bool) {
+	return r.boundIndex, r.boundFile, r.bound
+}
+
+// applyDecorations stores the status, selection, and cursor state and
+// reports whether it changed.
+func (r *FileListRow) applyDecorations(
+	statusColor *color.RGBA,
+	selected bool,
+	selectionColor color.RGBA,
+	cursor bool,
+	cursorColor color.RGBA,
+) bool {
 	hasStatus := statusColor != nil
 	nextStatusColor := color.RGBA{}
 	if hasStatus {
@@ -86,7 +184,7 @@ func (r *FileListRow) SetDecorations(
 		r.selectionColor == nextSelectionColor &&
 		r.cursor == cursor &&
 		r.cursorColor == nextCursorColor {
-		return
+		return false
 	}
 
 	r.hasStatus = hasStatus
@@ -95,6 +193,26 @@ func (r *FileListRow) SetDecorations(
 	r.selectionColor = nextSelectionColor
 	r.cursor = cursor
 	r.cursorColor = nextCursorColor
+	return true
+}
+
+// SetTagColor shows a color tag swatch over the icon's bottom-right corner.
+// A fully transparent color hides it.
+func (r *FileListRow) SetTagColor(tagColor color.RGBA) {
+	if r.tagColor == tagColor {
+		return
+	}
+	r.tagColor = tagColor
+	r.Refresh()
+}
+
+// SetEmblems shows the badges in e over the icon. The overlay is one cached
+// image per combination, so changing emblems never adds canvas objects.
+func (r *FileListRow) SetEmblems(e Emblem) {
+	if r.emblems == e {
+		return
+	}
+	r.emblems = e
 	r.Refresh()
 }
 
@@ -105,6 +223,10 @@ func (r *FileListRow) CreateRenderer() fyne.WidgetRenderer {
 	renderer := &fileListRowRenderer{
 		row: r,
 	}
+	renderer.emblem = canvas.NewImageFromResource(nil)
+	renderer.emblem.FillMode = canvas.ImageFillContain
+	renderer.emblem.Hide()
+	renderer.tag = canvas.NewCircle(&renderer.tagFill)
 	renderer.status = canvas.NewRectangle(&renderer.statusFill)
 	renderer.selection = canvas.NewRectangle(&renderer.selectionFill)
 	renderer.cursorBackground = canvas.NewRectangle(&renderer.cursorBackgroundFill)
@@ -112,8 +234,11 @@ func (r *FileListRow) CreateRenderer() fyne.WidgetRenderer {
 	renderer.cursorBottom = canvas.NewRectangle(&renderer.cursorBottomFill)
 	renderer.cursorLeft = canvas.NewRectangle(&renderer.cursorLeftFill)
 	renderer.cursorRight = canvas.NewRectangle(&renderer.cursorRightFill)
+	renderer.groupRule = canvas.NewRectangle(&renderer.groupRuleFill)
 	renderer.objects = []fyne.CanvasObject{
 		r.content,
+		renderer.emblem,
+		renderer.tag,
 		renderer.status,
 		renderer.selection,
 		renderer.cursorBackground,
@@ -121,6 +246,7 @@ func (r *FileListRow) CreateRenderer() fyne.WidgetRenderer {
 		renderer.cursorBottom,
 		renderer.cursorLeft,
 		renderer.cursorRight,
+		renderer.groupRule,
 	}
 	renderer.applyColors(false)
 	return renderer
@@ -129,6 +255,9 @@ func (r *FileListRow) CreateRenderer() fyne.WidgetRenderer {
 type fileListRowRenderer struct {
 	objects          []fyne.CanvasObject
 	row              *FileListRow
+	emblem           *canvas.Image
+	emblems          Emblem
+	tag              *canvas.Circle
 	status           *canvas.Rectangle
 	selection        *canvas.Rectangle
 	cursorBackground *canvas.Rectangle
@@ -136,7 +265,9 @@ type fileListRowRenderer struct {
 	cursorBottom     *canvas.Rectangle
 	cursorLeft       *canvas.Rectangle
 	cursorRight      *canvas.Rectangle
+	groupRule        *canvas.Rectangle
 
+	tagFill              color.RGBA
 	statusFill           color.RGBA
 	selectionFill        color.RGBA
 	cursorBackgroundFill color.RGBA
@@ -144,6 +275,7 @@ type fileListRowRenderer struct {
 	cursorBottomFill     color.RGBA
 	cursorLeftFill       color.RGBA
 	cursorRightFill      color.RGBA
+	groupRuleFill        color.RGBA
 }
 
 func (r *fileListRowRenderer) Destroy() {}
@@ -158,6 +290,14 @@ func (r *fileListRowRenderer) Layout(size fyne.Size) {
 	r.selection.Resize(size)
 	r.cursorBackground.Resize(size)
 
+	iconPos := r.row.Icon.Position()
+	iconSize := r.row.Icon.Size()
+	r.emblem.Move(iconPos)
+	r.emblem.Resize(iconSize)
+	swatch := max(4, min(iconSize.Width, iconSize.Height)*tagSwatchScale)
+	r.tag.Move(fyne.NewPos(iconPos.X+iconSize.Width-swatch, iconPos.Y+iconSize.Height-swatch))
+	r.tag.Resize(fyne.NewSize(swatch, swatch))
+
 	thickness := r.cursorThickness()
 	horizontalThickness := min(thickness, size.Height)
 	verticalThickness := min(thickness, size.Width)
@@ -170,6 +310,9 @@ func (r *fileListRowRenderer) Layout(size fyne.Size) {
 	r.cursorLeft.Resize(fyne.NewSize(verticalThickness, size.Height))
 	r.cursorRight.Move(fyne.NewPos(max(0, size.Width-verticalThickness), 0))
 	r.cursorRight.Resize(fyne.NewSize(verticalThickness, size.Height))
+
+	r.groupRule.Move(fyne.NewPos(0, 0))
+	r.groupRule.Resize(fyne.NewSize(size.Width, min(max(1, currentAppThemeSize(theme.SizeNameSeparatorThickness)), size.Height)))
 }
 
 func (r *fileListRowRenderer) MinSize() fyne.Size {
@@ -217,6 +360,24 @@ func (r *fileListRowRenderer) applyColors(refresh bool) {
 		cursorBottomColor = cursorLineColor
 	}
 
+	if r.emblems != r.row.emblems {
+		r.emblems = r.row.emblems
+		r.emblem.Resource = EmblemResource(r.emblems)
+		if r.emblems == 0 {
+			r.emblem.Hide()
+		} else {
+			r.emblem.Show()
+		}
+		if refresh {
+			r.emblem.Refresh()
+		}
+	}
+	if r.tagFill != r.row.tagColor {
+		r.tagFill = r.row.tagColor
+		if refresh {
+			r.tag.Refresh()
+		}
+	}
 	setRectangleColor(&r.statusFill, r.status, statusColor, refresh)
 	setRectangleColor(&r.selectionFill, r.selection, selectionColor, refresh)
 	setRectangleColor(&r.cursorBackgroundFill, r.cursorBackground, cursorBackgroundColor, refresh)
@@ -224,6 +385,13 @@ func (r *fileListRowRenderer) applyColors(refresh bool) {
 	setRectangleColor(&r.cursorBottomFill, r.cursorBottom, cursorBottomColor, refresh)
 	setRectangleColor(&r.cursorLeftFill, r.cursorLeft, cursorLeftColor, refresh)
 	setRectangleColor(&r.cursorRightFill, r.cursorRight, cursorRightColor, refresh)
+
+	groupRuleColor := transparent
+	if r.row.groupStart {
+		groupRuleColor = color.RGBAModel.Convert(currentAppThemeColor(theme.ColorNameForeground)).(color.RGBA)
+		groupRuleColor = scaleAlpha(groupRuleColor, groupRuleAlphaScale)
+	}
+	setRectangleColor(&r.groupRuleFill, r.groupRule, groupRuleColor, refresh)
 }
 
 func (r *fileListRowRenderer) cursorStyle() string {
@@ -8,6 +8,7 @@ import (
 	"fyne.io/fyne/v2/test"
 
 	"nmf/internal/config"
+	"nmf/internal/fileinfo"
 )
 
 func TestFileListRowKeepsFixedRendererObjectsAcrossUpdates(t *testing.T) {
@@ -184,6 +185,8 @@ func TestFileListRowDecorationZOrder(t *testing.T) {
 
 	want := []fyne.CanvasObject{
 		row.content,
+		renderer.emblem,
+		renderer.tag,
 		renderer.status,
 		renderer.selection,
 		renderer.cursorBackground,
@@ -191,6 +194,7 @@ func TestFileListRowDecorationZOrder(t *testing.T) {
 		renderer.cursorBottom,
 		renderer.cursorLeft,
 		renderer.cursorRight,
+		renderer.groupRule,
 	}
 	if len(objects) != len(want) {
 		t.Fatalf("renderer objects = %d, want %d", len(objects), len(want))
@@ -202,6 +206,70 @@ func TestFileListRowDecorationZOrder(t *testing.T) {
 	}
 }
 
+func TestFileListRowTagSwatch(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	row := NewFileListRow(config.CursorStyleConfig{}, color.RGBA{A: 255})
+	renderer := test.WidgetRenderer(row).(*fileListRowRenderer)
+	row.Resize(fyne.NewSize(240, 24))
+
+	if got := rgba(renderer.tag.FillColor); got.A != 0 {
+		t.Fatalf("untagged swatch color = %#v, want transparent", got)
+	}
+
+	red := color.RGBA{R: 0xff, G: 0x45, B: 0x3a, A: 0xff}
+	row.SetTagColor(red)
+	if got := rgba(renderer.tag.FillColor); got != red {
+		t.Fatalf("swatch color = %#v, want %#v", got, red)
+	}
+
+	iconEnd := row.Icon.Position().Add(row.Icon.Size())
+	swatchEnd := renderer.tag.Position().Add(renderer.tag.Size())
+	if swatchEnd != iconEnd {
+		t.Fatalf("swatch bottom-right = %v, want icon bottom-right %v", swatchEnd, iconEnd)
+	}
+
+	row.SetTagColor(color.RGBA{})
+	if got := rgba(renderer.tag.FillColor); got.A != 0 {
+		t.Fatalf("cleared swatch color = %#v, want transparent", got)
+	}
+}
+
+func TestFileListRowEmblems(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	row := NewFileListRow(config.CursorStyleConfig{}, color.RGBA{A: 255})
+	renderer := test.WidgetRenderer(row).(*fileListRowRenderer)
+	row.Resize(fyne.NewSize(240, 24))
+
+	if renderer.emblem.Visible() {
+		t.Fatal("emblem overlay should be hidden without emblems")
+	}
+
+	objects := len(renderer.Objects())
+	row.SetEmblems(EmblemLink | EmblemSelected)
+	if !renderer.emblem.Visible() {
+		t.Fatal("emblem overlay should be shown")
+	}
+	if renderer.emblem.Resource != EmblemResource(EmblemLink|EmblemSelected) {
+		t.Fatal("emblem overlay should use the cached combined resource")
+	}
+	if renderer.emblem.Position() != row.Icon.Position() || renderer.emblem.Size() != row.Icon.Size() {
+		t.Fatalf("emblem bounds = %v %v, want icon bounds %v %v",
+			renderer.emblem.Position(), renderer.emblem.Size(), row.Icon.Position(), row.Icon.Size())
+	}
+	if got := len(renderer.Objects()); got != objects {
+		t.Fatalf("renderer objects = %d after SetEmblems, want %d", got, objects)
+	}
+
+	row.SetEmblems(0)
+	if renderer.emblem.Visible() {
+		t.Fatal("cleared emblem overlay should be hidden")
+	}
+}
+
 func rgba(c color.Color) color.RGBA {
 	return color.RGBAModel.Convert(c).(color.RGBA)
 }
@@ -225,3 +293,106 @@ func BenchmarkFileListRowDecorationUpdate(b *testing.B) {
 		row.SetDecorations(nil, false, color.RGBA{}, i%2 == 0, cursorColor)
 	}
 }
+
+func TestFileListRowSetStateAppliesAllLayers(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	row := NewFileListRow(
+		config.CursorStyleConfig{Type: "underline", Thickness: 2},
+		color.RGBA{A: 255},
+	)
+	renderer := test.WidgetRenderer(row).(*fileListRowRenderer)
+	before := append([]fyne.CanvasObject(nil), renderer.Objects()...)
+
+	status := color.RGBA{R: 1, G: 2, B: 3, A: 80}
+	tag := color.RGBA{R: 200, A: 255}
+	row.SetState(FileListRowState{
+		StatusColor:    &status,
+		Selected:       true,
+		SelectionColor: color.RGBA{R: 4, G: 5, B: 6, A: 100},
+		Cursor:         true,
+		CursorColor:    color.RGBA{R: 7, G: 8, B: 9, A: 255},
+		TagColor:       tag,
+		Emblems:        EmblemSelected,
+	})
+
+	if got := rgba(renderer.status.FillColor); got != status {
+		t.Fatalf("status color = %#v, want %#v", got, status)
+	}
+	if got, want := rgba(renderer.cursorBottom.FillColor), (color.RGBA{R: 7, G: 8, B: 9, A: 255}); got != want {
+		t.Fatalf("underline color = %#v, want %#v", got, want)
+	}
+	if renderer.tagFill != tag {
+		t.Fatalf("tag fill = %#v, want %#v", renderer.tagFill, tag)
+	}
+	if !renderer.emblem.Visible() {
+		t.Fatal("emblem overlay should be visible")
+	}
+	for i, obj := range renderer.Objects() {
+		if obj != before[i] {
+			t.Fatalf("renderer object %d changed identity", i)
+		}
+	}
+}
+
+func TestFileListRowGroupStartDrawsRule(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	row := NewFileListRow(
+		config.CursorStyleConfig{Type: "underline", Thickness: 2},
+		color.RGBA{A: 255},
+	)
+	renderer := test.WidgetRenderer(row).(*fileListRowRenderer)
+	row.Resize(fyne.NewSize(300, 24))
+
+	row.SetState(FileListRowState{GroupStart: true})
+	if renderer.groupRuleFill.A == 0 {
+		t.Fatal("group rule should be visible on the first entry of a group")
+	}
+	if size := renderer.groupRule.Size(); size.Width != 300 || size.Height <= 0 || size.Height >= 24 {
+		t.Fatalf("group rule size = %v, want a thin line across the row", size)
+	}
+	row.SetState(FileListRowState{})
+	if renderer.groupRuleFill.A != 0 {
+		t.Fatal("group rule should be hidden inside a group")
+	}
+}
+
+func TestFileListRowBindRecordsEntry(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	row := NewFileListRow(config.CursorStyleConfig{}, color.RGBA{A: 255})
+	if _, _, ok := row.Bound(); ok {
+		t.Fatal("new row should not be bound")
+	}
+	row.Bind(3, fileinfo.FileInfo{Name: "a.txt", Path: "/tmp/a.txt"})
+	index, file, ok := row.Bound()
+	if !ok || index != 3 || file.Path != "/tmp/a.txt" {
+		t.Fatalf("Bound() = %d, %+v, %t; want 3, /tmp/a.txt, true", index, file, ok)
+	}
+}
+
+func TestFileListRowForwardsRightClicksFromChildren(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	row := NewFileListRow(config.CursorStyleConfig{}, color.RGBA{A: 255})
+	var got []fyne.Position
+	row.SetOnTappedSecondary(func(ev *fyne.PointEvent) {
+		got = append(got, ev.AbsolutePosition)
+	})
+
+	test.TapSecondaryAt(row.Icon, fyne.NewPos(1, 1))
+	test.TapSecondaryAt(row.NameLabel, fyne.NewPos(2, 2))
+	row.TappedSecondary(&fyne.PointEvent{AbsolutePosition: fyne.NewPos(30, 40)})
+
+	if len(got) != 3 {
+		t.Fatalf("right clicks = %v, want icon, name, and row", got)
+	}
+	if got[2] != fyne.NewPos(30, 40) {
+		t.Fatalf("row right click position = %v, want (30, 40)", got[2])
+	}
+}
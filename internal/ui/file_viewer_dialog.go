@@ -3,7 +3,6 @@ package ui
 import (
 	"fmt"
 	"html"
-	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -154,7 +153,7 @@ func (d *FileViewerDialog) ShowDialog(parent fyne.Window) {
 
 	toolbar := d.buildViewerToolbar(parent)
 
-	nameLabel := widget.NewLabel(filepath.Base(d.preview.Path))
+	nameLabel := widget.NewLabel(fileinfo.BaseName(d.preview.Path))
 	nameRow := container.NewBorder(nil, nil, nil, d.closeButton,
 		container.NewVBox(layout.NewSpacer(), nameLabel, layout.NewSpacer()))
 
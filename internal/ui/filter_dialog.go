@@ -37,6 +37,10 @@ type FilterDialog struct {
 	previewLabel    *widget.Label             // Preview of match count
 	currentFiles    []fileinfo.FileInfo       // Current directory files for preview
 	matchers        *search.Provider
+	presets         []config.FilterPreset     // Named presets listed ahead of history
+	savePreset      func(config.FilterPreset) // Callback for saving the search text as a preset
+	bindings        []config.KeyBindingEntry  // Configured bindings for the nested name prompt
+	editing         bool                      // Nested preset name prompt is open
 }
 
 // NewFilterDialog creates a new filter dialog
@@ -65,11 +69,51 @@ func NewFilterDialog(
 	return dialog
 }
 
+// SetPresets lists presets as "preset:<name>" entries ahead of the history
+// and enables saving the search text as a new preset through onSave.
+func (fd *FilterDialog) SetPresets(presets []config.FilterPreset, onSave func(config.FilterPreset), bindings ...[]config.KeyBindingEntry) {
+	fd.presets = presets
+	fd.savePreset = onSave
+	if len(bindings) > 0 {
+		fd.bindings = bindings[0]
+	}
+	entries := make([]config.FilterEntry, 0, len(presets)+len(fd.allEntries))
+	seen := make(map[string]bool, len(presets))
+	for _, preset := range presets {
+		pattern := config.FilterPresetPrefix + preset.Name
+		seen[pattern] = true
+		entries = append(entries, config.FilterEntry{Pattern: pattern})
+	}
+	for _, entry := range fd.allEntries {
+		if !seen[entry.Pattern] {
+			entries = append(entries, entry)
+		}
+	}
+	fd.allEntries = entries
+	query := ""
+	if fd.searchEntry != nil {
+		query = fd.searchEntry.Text
+	}
+	fd.updateFilteredEntries(query)
+}
+
+// displayText returns the list text for an entry; presets show their spec.
+func (fd *FilterDialog) displayText(entry config.FilterEntry) string {
+	name, ok := config.FilterPresetName(entry.Pattern)
+	if !ok {
+		return entry.Pattern
+	}
+	if preset, found := config.FindFilterPreset(fd.presets, name); found {
+		return entry.Pattern + "  [" + preset.Spec() + "]"
+	}
+	return entry.Pattern
+}
+
 // createWidgets creates the UI widgets
 func (fd *FilterDialog) createWidgets() {
 	// Create search entry - custom entry that redirects focus to KeySink
 	fd.searchEntry = NewCustomSearchEntry()
-	fd.searchEntry.SetPlaceHolder("Enter filter pattern (e.g., *.go, *.{js,ts}, test*)...")
+	fd.searchEntry.SetPlaceHolder("Enter filter pattern (e.g., *.go, *.{js,ts}, test*, *.iso size>1G)...")
 
 	// Set up real-time search
 	fd.searchEntry.OnChanged = func(query string) {
@@ -133,7 +177,7 @@ func (fd *FilterDialog) updateFilteredEntries(query string) {
 		fd.filteredEntries = []config.FilterEntry{}
 
 		for _, entry := range fd.allEntries {
-			if matcher.Match(entry.Pattern) {
+			if matcher.Match(fd.displayText(entry)) {
 				fd.filteredEntries = append(fd.filteredEntries, entry)
 			}
 		}
@@ -142,7 +186,7 @@ func (fd *FilterDialog) updateFilteredEntries(query string) {
 	// Format entries for display.
 	displayEntries := make([]string, len(fd.filteredEntries))
 	for i, entry := range fd.filteredEntries {
-		displayEntries[i] = entry.Pattern
+		displayEntries[i] = fd.displayText(entry)
 	}
 
 	// Update data binding with the formatted entries
@@ -171,15 +215,32 @@ func (fd *FilterDialog) updatePreview(pattern string) {
 	// Count matches in current directory
 	matchCount := 0
 	dirCount := 0
-	for _, file := range fd.currentFiles {
-		if file.IsDir {
-			dirCount++ // Count directories separately
-			continue   // Directories are always shown, so don't include in match count
+	if _, ok := config.FilterPresetName(effectivePattern); ok {
+		filtered, err := fileinfo.FilterFilesWithPresets(fd.currentFiles, effectivePattern, fd.presets)
+		if err != nil {
+			fd.previewLabel.SetText(err.Error())
+			return
+		}
+		for _, file := range filtered {
+			switch {
+			case file.Name == "..":
+			case file.IsDir:
+				dirCount++
+			default:
+				matchCount++
+			}
 		}
+	} else {
+		for _, file := range fd.currentFiles {
+			if file.IsDir {
+				dirCount++ // Count directories separately
+				continue   // Directories are always shown, so don't include in match count
+			}
 
-		matched, err := fileinfo.MatchesPattern(file.Name, effectivePattern)
-		if err == nil && matched {
-			matchCount++
+			matched, err := fileinfo.MatchesFile(file, effectivePattern)
+			if err == nil && matched {
+				matchCount++
+			}
 		}
 	}
 
@@ -395,13 +456,15 @@ func (fd *FilterDialog) AcceptDirectInput() {
 	if fd.searchEntry == nil || strings.TrimSpace(fd.searchEntry.Text) == "" {
 		return
 	}
-	fd.closed = true
-
-	selectedEntry := &config.FilterEntry{
+	fd.closeWithEntry("filter.acceptDirect", &config.FilterEntry{
 		Pattern: strings.TrimSpace(fd.searchEntry.Text),
-	}
+	})
+}
 
-	deferDialogClose(fd.keyManager, "filter.acceptDirect", func() {
+// closeWithEntry closes the dialog and hands selectedEntry to the callback.
+func (fd *FilterDialog) closeWithEntry(label string, selectedEntry *config.FilterEntry) {
+	fd.closed = true
+	deferDialogClose(fd.keyManager, label, func() {
 		fd.keyManager.RemoveHandler(fd.kmToken)
 		if fd.callback != nil && selectedEntry != nil {
 			fd.callback(selectedEntry)
@@ -413,6 +476,49 @@ func (fd *FilterDialog) AcceptDirectInput() {
 	})
 }
 
+// SavePreset parses the search text as a preset spec, asks for a name, and
+// applies the saved preset. Specs use glob terms plus !glob, size>N, size<N,
+// since:<age|date>, and type:<file,dir,hidden>.
+func (fd *FilterDialog) SavePreset() {
+	if fd.closed || fd.editing || fd.savePreset == nil || fd.searchEntry == nil {
+		return
+	}
+	spec := strings.TrimSpace(fd.searchEntry.Text)
+	if spec == "" {
+		return
+	}
+	if _, err := config.ParseFilterPresetSpec("preset", spec); err != nil {
+		fd.previewLabel.SetText("Invalid preset: " + err.Error())
+		return
+	}
+	fd.editing = true
+	dlg := NewLineEditDialog(LineEditDialogOptions{
+		Title:       "Save Filter Preset",
+		Prompt:      "Name for " + spec + ":",
+		ConfirmText: "Save",
+		OnClosed: func() {
+			fd.editing = false
+			if fd.parent != nil && fd.sink != nil && !fd.closed {
+				fd.parent.Canvas().Focus(fd.sink)
+			}
+		},
+	}, fd.keyManager, fd.bindings)
+	dlg.ShowDialog(fd.parent, func(name string) bool {
+		if fd.closed {
+			return true
+		}
+		preset, err := config.ParseFilterPresetSpec(strings.TrimSpace(name), spec)
+		if err != nil {
+			fd.debugPrint("FilterDialog: invalid preset %q: %v", name, err)
+			return false
+		}
+		fd.debugPrint("FilterDialog: save preset name=%s spec=%s", preset.Name, spec)
+		fd.savePreset(preset)
+		fd.closeWithEntry("filter.savePreset", &config.FilterEntry{Pattern: config.FilterPresetPrefix + preset.Name})
+		return true
+	})
+}
+
 // DeleteSelectedEntry removes the selected filter history entry.
 func (fd *FilterDialog) DeleteSelectedEntry() {
 	if fd.selectedIndex < 0 || fd.selectedIndex >= len(fd.filteredEntries) {
@@ -134,3 +134,55 @@ func TestFilterDialogDeleteSelectedEntry(t *testing.T) {
 		t.Fatalf("all entries = %#v, want selected entry removed", dialog.allEntries)
 	}
 }
+
+func TestFilterDialogListsPresetsAndPreviewsCriteria(t *testing.T) {
+	dialog := NewFilterDialog(
+		[]config.FilterEntry{{Pattern: "preset:big"}, {Pattern: "*.md"}},
+		[]fileinfo.FileInfo{
+			{Name: "..", IsDir: true},
+			{Name: "disk.iso", Size: 2 << 30},
+			{Name: "small.iso", Size: 1024},
+			{Name: "docs", IsDir: true},
+		},
+		nil,
+		func(string, ...interface{}) {},
+		search.NewPlainProvider(),
+	)
+	dialog.SetPresets([]config.FilterPreset{
+		{Name: "big", Include: []string{"*.iso"}, MinSize: "1G", Types: []string{config.FilterTypeFile}},
+	}, nil)
+
+	items, err := dialog.dataBinding.Get()
+	if err != nil {
+		t.Fatalf("data binding get failed: %v", err)
+	}
+	if len(items) != 2 || !strings.HasPrefix(items[0], "preset:big  [") || items[1] != "*.md" {
+		t.Fatalf("display items = %#v, want preset first without duplicate history entry", items)
+	}
+
+	dialog.updatePreview("preset:big")
+	if got := dialog.previewLabel.Text; got != "Matches: 1 files + 0 directories" {
+		t.Fatalf("preview = %q, want preset criteria applied", got)
+	}
+
+	dialog.updateFilteredEntries("iso")
+	if len(dialog.filteredEntries) != 1 || dialog.filteredEntries[0].Pattern != "preset:big" {
+		t.Fatalf("filtered entries = %#v, want search to match preset spec", dialog.filteredEntries)
+	}
+}
+
+func TestFilterDialogSavePresetRejectsInvalidSpec(t *testing.T) {
+	dialog := NewFilterDialog(nil, nil, nil, func(string, ...interface{}) {}, search.NewPlainProvider())
+	saved := false
+	dialog.SetPresets(nil, func(config.FilterPreset) { saved = true })
+	dialog.searchEntry.SetText("*.iso size>lots")
+
+	dialog.SavePreset()
+
+	if saved || dialog.editing {
+		t.Fatalf("saved=%v editing=%v, want invalid spec rejected before prompting", saved, dialog.editing)
+	}
+	if !strings.HasPrefix(dialog.previewLabel.Text, "Invalid preset:") {
+		t.Fatalf("preview = %q, want invalid preset message", dialog.previewLabel.Text)
+	}
+}
@@ -7,7 +7,6 @@ import (
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 
@@ -18,14 +17,17 @@ import (
 
 const completedJobTargetLimit = 10
 
-// JobsWindow shows the global background job queue and allows cancel.
+// JobsWindow shows the global background job queue and allows cancel and
+// rerun of failed jobs, including those restored from earlier sessions.
 type JobsWindow struct {
 	list        *widget.List
-	bind        binding.StringList
+	lines       []string
 	items       []jobs.JobSnapshot
 	selectedIdx int
 	selectedID  int64
 	details     *widget.Label
+	notice      string
+	resolver    jobs.ConflictResolver
 	window      fyne.Window
 	sink        *KeySink
 	km          *keymanager.KeyManager
@@ -42,13 +44,21 @@ func NewJobsWindow(app fyne.App, debugPrint func(format string, args ...interfac
 		debugPrint:  debugPrint,
 		selectedIdx: -1,
 	}
-	jd.bind = binding.NewStringList()
-	jd.list = widget.NewListWithData(jd.bind,
-		func() fyne.CanvasObject { return widget.NewLabel("") },
-		func(item binding.DataItem, obj fyne.CanvasObject) {
-			s, _ := item.(binding.String).Get()
-			if l, ok := obj.(*widget.Label); ok {
-				l.SetText(s)
+	jd.list = widget.NewList(
+		func() int { return len(jd.lines) },
+		func() fyne.CanvasObject {
+			return container.NewVBox(widget.NewLabel(""), widget.NewProgressBar())
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			row, ok := obj.(*fyne.Container)
+			if !ok || id < 0 || int(id) >= len(jd.lines) || len(row.Objects) < 2 {
+				return
+			}
+			if l, ok := row.Objects[0].(*widget.Label); ok {
+				l.SetText(jd.lines[id])
+			}
+			if bar, ok := row.Objects[1].(*widget.ProgressBar); ok && int(id) < len(jd.items) {
+				bar.SetValue(jobProgressFraction(jd.items[id]))
 			}
 		},
 	)
@@ -56,6 +66,9 @@ func NewJobsWindow(app fyne.App, debugPrint func(format string, args ...interfac
 	jd.details.Wrapping = fyne.TextWrapWord
 	jd.list.OnSelected = func(id widget.ListItemID) {
 		jd.selectedIdx = int(id)
+		if jd.notice != "" && (id < 0 || int(id) >= len(jd.items) || jd.items[id].ID != jd.selectedID) {
+			jd.notice = ""
+		}
 		if id >= 0 && int(id) < len(jd.items) {
 			jd.selectedID = jd.items[id].ID
 		} else {
@@ -71,6 +84,11 @@ func NewJobsWindow(app fyne.App, debugPrint func(format string, args ...interfac
 
 	// Buttons
 	cancelBtn := dialogAuxButton("Cancel Selected", theme.CancelIcon(), func() { jd.cancelSelected() })
+	upBtn := dialogAuxButton("Move Up", theme.MoveUpIcon(), jd.MoveSelectedUp)
+	downBtn := dialogAuxButton("Move Down", theme.MoveDownIcon(), jd.MoveSelectedDown)
+	priorityBtn := dialogAuxButton("Priority", theme.UploadIcon(), jd.TogglePrioritySelected)
+	rerunBtn := dialogAuxButton("Rerun Selected", theme.ViewRefreshIcon(), func() { jd.rerunSelected() })
+	retryBtn := dialogAuxButton("Retry Failed", theme.MediaReplayIcon(), func() { jd.retryFailedSelected() })
 	closeBtn := dialogConfirmButton("Close", func() {
 		jd.Close()
 	})
@@ -82,7 +100,7 @@ func NewJobsWindow(app fyne.App, debugPrint func(format string, args ...interfac
 	detailsScroll.SetMinSize(metricsSize(jobsDetailsWidth, jobsDetailsHeight))
 	split := container.NewVSplit(dialogListThemeOverride(jd.list), detailsScroll)
 	split.Offset = 0.5
-	bottom := dialogButtonBar(cancelBtn, closeBtn)
+	bottom := dialogButtonBar(cancelBtn, upBtn, downBtn, priorityBtn, rerunBtn, retryBtn, closeBtn)
 	content := container.NewBorder(container.NewVBox(header), bottom, nil, nil, split)
 
 	handler := keymanager.NewJobsDialogKeyHandler(jd, jd.debugPrint)
@@ -129,6 +147,11 @@ func (jd *JobsWindow) SetOnClosed(fn func()) {
 	jd.onClosed = fn
 }
 
+// SetConflictResolver sets the collision resolver used by rerun jobs.
+func (jd *JobsWindow) SetConflictResolver(resolver jobs.ConflictResolver) {
+	jd.resolver = resolver
+}
+
 func (jd *JobsWindow) refresh() {
 	m := jobs.GetManager()
 	snapshots := m.List()
@@ -141,11 +164,13 @@ func (jd *JobsWindow) refresh() {
 			when = it.StartedAt
 		}
 		ts := when.Format("15:04:05")
-		target := it.DestDir
-		if it.Type == jobs.TypeDelete {
-			target = string(it.DeleteMode)
+		if it.Restored {
+			ts = when.Format("01-02 15:04")
+		}
+		lines[i] = fmt.Sprintf("[%s] %s %d/%d → %s  (%s)", ts, string(it.Type), it.DoneFiles, it.TotalFiles, jobTarget(it), status)
+		if it.Status == jobs.StatusPending && it.HighPriority {
+			lines[i] = "! " + lines[i]
 		}
-		lines[i] = fmt.Sprintf("[%s] %s %d/%d → %s  (%s)", ts, string(it.Type), it.DoneFiles, it.TotalFiles, target, status)
 		if summary := runningProgressSummary(it); summary != "" {
 			lines[i] += "  " + summary
 		}
@@ -153,7 +178,7 @@ func (jd *JobsWindow) refresh() {
 			lines[i] += "  ERROR"
 		}
 	}
-	jd.bind.Set(lines)
+	jd.lines = lines
 	jd.list.Refresh()
 	// Keep selection stable by job ID.
 	selectIdx := -1
@@ -177,6 +202,26 @@ func (jd *JobsWindow) refresh() {
 	jd.updateDetails()
 }
 
+// jobTarget describes where a job writes: the delete mode for deletes, the
+// archive file for archive jobs, the digest for checksum jobs, and the
+// destination directory otherwise.
+func jobTarget(it jobs.JobSnapshot) string {
+	switch it.Type {
+	case jobs.TypeDelete:
+		return string(it.DeleteMode)
+	case jobs.TypeArchive:
+		if name, err := it.Archive.FileName(); err == nil {
+			return fileinfo.JoinPath(it.DestDir, name)
+		}
+	case jobs.TypeChecksum, jobs.TypeVerify:
+		if it.Checksum.Algorithm == "" {
+			return "detected digest"
+		}
+		return it.Checksum.Algorithm.Label()
+	}
+	return it.DestDir
+}
+
 func (jd *JobsWindow) updateDetails() {
 	if jd.selectedIdx < 0 || jd.selectedIdx >= len(jd.items) {
 		jd.details.SetText("")
@@ -184,36 +229,82 @@ func (jd *JobsWindow) updateDetails() {
 	}
 	it := jd.items[jd.selectedIdx]
 	b := &strings.Builder{}
-	target := it.DestDir
-	if it.Type == jobs.TypeDelete {
-		target = string(it.DeleteMode)
+	if jd.notice != "" {
+		fmt.Fprintln(b, jd.notice)
+	}
+	fmt.Fprintf(b, "Job #%d %s → %s\nStatus: %s, %d/%d completed\n", it.ID, string(it.Type), jobTarget(it), string(it.Status), it.DoneFiles, it.TotalFiles)
+	if it.Status == jobs.StatusPending && it.HighPriority {
+		fmt.Fprintln(b, "High priority: starts before normal jobs")
+	}
+	if it.Restored {
+		fmt.Fprintf(b, "From a previous session, finished %s\n", it.CompletedAt.Format("2006-01-02 15:04:05"))
 	}
-	fmt.Fprintf(b, "Job #%d %s → %s\nStatus: %s, %d/%d completed\n", it.ID, string(it.Type), target, string(it.Status), it.DoneFiles, it.TotalFiles)
+	writeMoveStrategy(b, it)
 	if it.Status == jobs.StatusRunning {
 		writeRunningProgress(b, it)
 	} else if it.Status == jobs.StatusFailed {
-		if len(it.Failures) > 0 {
-			fmt.Fprintln(b, "Failures:")
-			for _, f := range it.Failures {
-				if f.TopSource != "" {
-					fmt.Fprintf(b, "  - item: %s\n", f.TopSource)
-				}
-				if f.Path != "" {
-					fmt.Fprintf(b, "    path: %s\n", f.Path)
-				}
-				if f.Error != "" {
-					fmt.Fprintf(b, "    error: %s\n", f.Error)
-				}
-			}
+		if hasJobErrors(it.Failures) {
+			writeJobFailures(b, "Failures:", it.Failures, false)
 		} else if it.Error != "" {
 			fmt.Fprintf(b, "Error: %s\n", it.Error)
 		}
+		writeJobFailures(b, "Warnings:", it.Failures, true)
 	} else if it.Status == jobs.StatusCompleted {
+		writeJobFailures(b, "Warnings:", it.Failures, true)
 		writeCompletedTargets(b, it.Sources)
 	}
 	jd.details.SetText(b.String())
 }
 
+// writeMoveStrategy tells how a move put its items in place: renamed within
+// one filesystem or share, or copied and deleted across volumes.
+func writeMoveStrategy(b *strings.Builder, it jobs.JobSnapshot) {
+	if it.Type != jobs.TypeMove {
+		return
+	}
+	switch {
+	case it.MoveRenames > 0 && it.MoveCopies == 0:
+		fmt.Fprintf(b, "Strategy: renamed in place (%d item(s))\n", it.MoveRenames)
+	case it.MoveCopies > 0 && it.MoveRenames == 0:
+		fmt.Fprintf(b, "Strategy: copied and deleted across volumes (%d file(s))\n", it.MoveCopies)
+	case it.MoveRenames > 0:
+		fmt.Fprintf(b, "Strategy: %d item(s) renamed in place, %d file(s) copied and deleted\n", it.MoveRenames, it.MoveCopies)
+	}
+}
+
+func hasJobErrors(failures []jobs.JobFailure) bool {
+	for _, f := range failures {
+		if !f.Warning {
+			return true
+		}
+	}
+	return false
+}
+
+// writeJobFailures lists the failures, or with warnings the metadata
+// warnings, under title; it writes nothing when there are none.
+func writeJobFailures(b *strings.Builder, title string, failures []jobs.JobFailure, warnings bool) {
+	wrote := false
+	for _, f := range failures {
+		if f.Warning != warnings {
+			continue
+		}
+		if !wrote {
+			fmt.Fprintln(b, title)
+			wrote = true
+		}
+		if f.TopSource != "" {
+			fmt.Fprintf(b, "  - item: %s\n", f.TopSource)
+		}
+		if f.Path != "" {
+			fmt.Fprintf(b, "    path: %s\n", f.Path)
+		}
+		if f.Error != "" {
+			fmt.Fprintf(b, "    error: %s\n", f.Error)
+		}
+	}
+}
+
 func runningProgressSummary(it jobs.JobSnapshot) string {
 	if it.Status != jobs.StatusRunning || it.CurrentFile == "" {
 		return ""
@@ -231,7 +322,42 @@ func runningProgressSummary(it jobs.JobSnapshot) string {
 	return strings.Join(parts, " ")
 }
 
+// jobProgressFraction reports overall job progress in [0, 1], by bytes when
+// the job measured its sources and by top-level items otherwise.
+func jobProgressFraction(it jobs.JobSnapshot) float64 {
+	if it.Status == jobs.StatusCompleted {
+		return 1
+	}
+	if it.TotalBytes > 0 {
+		return progressPercent(it.DoneBytes, it.TotalBytes) / 100
+	}
+	if it.TotalFiles > 0 {
+		return progressPercent(int64(it.DoneFiles), int64(it.TotalFiles)) / 100
+	}
+	return 0
+}
+
+// writeOverallProgress describes whole-job byte progress with the average
+// throughput since the job started.
+func writeOverallProgress(b *strings.Builder, it jobs.JobSnapshot, now time.Time) {
+	if it.TotalBytes <= 0 {
+		return
+	}
+	progress := fmt.Sprintf("%s / %s (%.1f%%)", formatBytes(it.DoneBytes), formatBytes(it.TotalBytes), progressPercent(it.DoneBytes, it.TotalBytes))
+	if it.DoneBytes > 0 && !it.StartedAt.IsZero() {
+		if elapsed := now.Sub(it.StartedAt).Seconds(); elapsed > 0 {
+			rate := float64(it.DoneBytes) / elapsed
+			progress += fmt.Sprintf(", %s/s", formatBytes(int64(rate)))
+			if remaining := it.TotalBytes - it.DoneBytes; remaining > 0 {
+				progress += ", ETA " + formatDuration(time.Duration(float64(remaining)/rate*float64(time.Second)))
+			}
+		}
+	}
+	fmt.Fprintf(b, "Total: %s\n", progress)
+}
+
 func writeRunningProgress(b *strings.Builder, it jobs.JobSnapshot) {
+	writeOverallProgress(b, it, time.Now())
 	if it.CurrentFile == "" {
 		return
 	}
@@ -394,6 +520,60 @@ func (jd *JobsWindow) cancelSelected() {
 }
 func (jd *JobsWindow) CancelSelected() { jd.cancelSelected() }
 
+// MoveSelectedUp moves the selected pending job one place earlier in the
+// queue.
+func (jd *JobsWindow) MoveSelectedUp() { jd.reorderSelected(-1) }
+
+// MoveSelectedDown moves the selected pending job one place later in the
+// queue.
+func (jd *JobsWindow) MoveSelectedDown() { jd.reorderSelected(1) }
+
+func (jd *JobsWindow) reorderSelected(delta int) {
+	if jd.selectedID != 0 && jobs.GetManager().Reorder(jd.selectedID, delta) {
+		fyne.Do(jd.refresh)
+	}
+}
+
+// TogglePrioritySelected marks the selected pending job as high priority, or
+// clears the mark.
+func (jd *JobsWindow) TogglePrioritySelected() {
+	if jd.selectedIdx < 0 || jd.selectedIdx >= len(jd.items) {
+		return
+	}
+	it := jd.items[jd.selectedIdx]
+	if it.Status == jobs.StatusPending && jobs.GetManager().SetPriority(it.ID, !it.HighPriority) {
+		fyne.Do(jd.refresh)
+	}
+}
+
+func (jd *JobsWindow) rerunSelected() {
+	jd.requeueSelected("Rerun", jobs.GetManager().Rerun)
+}
+func (jd *JobsWindow) RerunSelected() { jd.rerunSelected() }
+
+func (jd *JobsWindow) retryFailedSelected() {
+	jd.requeueSelected("Retry", jobs.GetManager().RetryFailed)
+}
+func (jd *JobsWindow) RetryFailedSelected() { jd.retryFailedSelected() }
+
+// requeueSelected queues a new job from the selected history entry and
+// selects it, or shows why the entry cannot be queued again.
+func (jd *JobsWindow) requeueSelected(action string, requeue func(int64, jobs.ConflictResolver) (*jobs.Job, error)) {
+	if jd.selectedID == 0 {
+		return
+	}
+	job, err := requeue(jd.selectedID, jd.resolver)
+	if err != nil {
+		jd.debugPrint("JobsWindow: %s id=%d failed: %v", strings.ToLower(action), jd.selectedID, err)
+		jd.notice = action + " failed: " + err.Error()
+		jd.updateDetails()
+		return
+	}
+	jd.notice = ""
+	jd.selectedID = job.ID
+	fyne.Do(jd.refresh)
+}
+
 func (jd *JobsWindow) CloseDialog() { jd.Close() }
 
 func (jd *JobsWindow) Close() {
@@ -71,6 +71,27 @@ func TestJobsWindowCompletedDetailsSummarizeTargets(t *testing.T) {
 	}
 }
 
+func TestJobsWindowMoveDetailsShowStrategy(t *testing.T) {
+	for _, tc := range []struct {
+		renames, copies int
+		want            string
+	}{
+		{2, 0, "Strategy: renamed in place (2 item(s))"},
+		{0, 5, "Strategy: copied and deleted across volumes (5 file(s))"},
+		{1, 3, "Strategy: 1 item(s) renamed in place, 3 file(s) copied and deleted"},
+	} {
+		jw := &JobsWindow{
+			details:     widget.NewLabel(""),
+			items:       []jobs.JobSnapshot{{ID: 8, Type: jobs.TypeMove, Status: jobs.StatusCompleted, Sources: []string{"/tmp/a"}, DestDir: "/mnt/b", MoveRenames: tc.renames, MoveCopies: tc.copies}},
+			selectedIdx: 0,
+		}
+		jw.updateDetails()
+		if !strings.Contains(jw.details.Text, tc.want) {
+			t.Fatalf("move details missing %q in:\n%s", tc.want, jw.details.Text)
+		}
+	}
+}
+
 func TestJobsWindowCompletedDetailsDoesNotAddMoreLineAtLimit(t *testing.T) {
 	sources := []string{
 		"/tmp/one.txt",
@@ -152,3 +173,36 @@ func TestRunningProgressSummaryFallsBackToBytesWithoutTotal(t *testing.T) {
 		t.Fatalf("runningProgressSummary = %q, want byte fallback", got)
 	}
 }
+
+func TestJobProgressFractionPrefersBytes(t *testing.T) {
+	tests := []struct {
+		name string
+		it   jobs.JobSnapshot
+		want float64
+	}{
+		{name: "bytes", it: jobs.JobSnapshot{Status: jobs.StatusRunning, TotalBytes: 400, DoneBytes: 100, TotalFiles: 2, DoneFiles: 1}, want: 0.25},
+		{name: "files", it: jobs.JobSnapshot{Status: jobs.StatusRunning, TotalFiles: 4, DoneFiles: 1}, want: 0.25},
+		{name: "completed", it: jobs.JobSnapshot{Status: jobs.StatusCompleted}, want: 1},
+		{name: "unknown", it: jobs.JobSnapshot{Status: jobs.StatusPending}, want: 0},
+	}
+	for _, tt := range tests {
+		if got := jobProgressFraction(tt.it); got != tt.want {
+			t.Fatalf("%s: jobProgressFraction = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWriteOverallProgressIncludesThroughputAndETA(t *testing.T) {
+	now := time.Now()
+	it := jobs.JobSnapshot{Status: jobs.StatusRunning, StartedAt: now.Add(-4 * time.Second), TotalBytes: 8192, DoneBytes: 4096}
+	b := &strings.Builder{}
+
+	writeOverallProgress(b, it, now)
+
+	got := b.String()
+	for _, want := range []string{"Total: 4.0 KiB / 8.0 KiB (50.0%)", "1.0 KiB/s", "ETA 00:04"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("overall progress missing %q in %q", want, got)
+		}
+	}
+}
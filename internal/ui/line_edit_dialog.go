@@ -45,6 +45,14 @@ type LineEditDialogOptions struct {
 	ResponsiveWidth  bool
 	WidthRatio       float32
 	MaxWidth         float32
+	// Header is shown above the prompt, for dialogs that show details about
+	// what is being edited.
+	Header fyne.CanvasObject
+	// Extra is shown between the entry and the buttons, for dialogs that
+	// pair the text with a few options.
+	Extra fyne.CanvasObject
+	// OnClosed runs once when the dialog closes, whether accepted or not.
+	OnClosed func()
 }
 
 // LineEditDialog edits one line of text and commits it through a callback.
@@ -101,6 +109,9 @@ func (d *LineEditDialog) ShowDialog(parent fyne.Window, onAccept func(string) bo
 	d.onAccept = onAccept
 
 	content := container.NewVBox()
+	if d.opts.Header != nil {
+		content.Add(d.opts.Header)
+	}
 	if d.opts.CurrentText != "" {
 		currentLabel := widget.NewLabel("Current:")
 		currentName := widget.NewLabel(middleEllipsizeFileName(d.opts.CurrentText, renameDisplayedNameMax))
@@ -112,6 +123,9 @@ func (d *LineEditDialog) ShowDialog(parent fyne.Window, onAccept func(string) bo
 		content.Add(widget.NewLabel(d.opts.Prompt))
 	}
 	content.Add(lineEditThemeOverride(d.entry))
+	if d.opts.Extra != nil {
+		content.Add(d.opts.Extra)
+	}
 	content.Add(dialogButtonRow("Cancel", d.CancelDialog, d.opts.ConfirmText, d.AcceptEdit))
 
 	var debugPrint func(format string, args ...interface{})
@@ -171,6 +185,9 @@ func (d *LineEditDialog) CancelDialog() {
 
 func (d *LineEditDialog) close() {
 	d.closed = true
+	if d.opts.OnClosed != nil {
+		d.opts.OnClosed()
+	}
 	deferDialogClose(d.keyManager, "lineEdit.close", func() {
 		d.keyManager.RemoveHandler(d.kmToken)
 		if d.dialog != nil {
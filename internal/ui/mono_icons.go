@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+
+	"nmf/internal/fileinfo"
+)
+
+// MonoIconKind selects a glyph of the built-in monochrome icon set.
+type MonoIconKind int
+
+const (
+	MonoIconFile MonoIconKind = iota
+	MonoIconFolder
+	MonoIconFolderLink
+	MonoIconLink
+	MonoIconImage
+	MonoIconAudio
+	MonoIconVideo
+	MonoIconArchive
+)
+
+// The glyphs share a 24x24 outline page or folder so every kind reads at a
+// glance; %[1]s is the stroke color.
+const (
+	monoPage   = `<path d="M6 2.5h8l4.5 4.5v14.5H6z M14 2.5V7h4.5" fill="none" stroke="%[1]s" stroke-width="1.6" stroke-linejoin="round"/>`
+	monoFolder = `<path d="M2.5 5.5h6.5l2 2h10.5v12H2.5z" fill="none" stroke="%[1]s" stroke-width="1.6" stroke-linejoin="round"/>`
+	monoArrow  = `<path d="M9 17l5.5-5.5 M10.5 11.5h4v4" fill="none" stroke="%[1]s" stroke-width="1.6" stroke-linecap="round" stroke-linejoin="round"/>`
+)
+
+var monoIconBodies = map[MonoIconKind]string{
+	MonoIconFile:       monoPage,
+	MonoIconFolder:     monoFolder,
+	MonoIconFolderLink: monoFolder + monoArrow,
+	MonoIconLink:       monoPage + monoArrow,
+	MonoIconImage: monoPage +
+		`<path d="M8.5 18.5l2.5-3.5 2 2 1.5-2 2 3.5z" fill="%[1]s"/><circle cx="10" cy="11.5" r="1.3" fill="%[1]s"/>`,
+	MonoIconAudio: monoPage +
+		`<path d="M11.5 17V10.5l4-1v5.5" fill="none" stroke="%[1]s" stroke-width="1.4" stroke-linejoin="round"/>` +
+		`<circle cx="10.3" cy="17" r="1.4" fill="%[1]s"/><circle cx="14.3" cy="15" r="1.4" fill="%[1]s"/>`,
+	MonoIconVideo: monoPage +
+		`<path d="M10 10.5v7l5.5-3.5z" fill="%[1]s"/>`,
+	MonoIconArchive: monoPage +
+		`<path d="M11 4v2 M12.5 6v2 M11 8v2 M12.5 10v2" stroke="%[1]s" stroke-width="1.4"/><rect x="10.2" y="13" width="3.2" height="4" rx="0.6" fill="none" stroke="%[1]s" stroke-width="1.4"/>`,
+}
+
+// monoArchiveExts is judged from the name only; list rows must not open files.
+var monoArchiveExts = map[string]bool{
+	".zip": true, ".7z": true, ".rar": true, ".tar": true, ".gz": true, ".tgz": true,
+	".bz2": true, ".tbz2": true, ".xz": true, ".txz": true, ".zst": true, ".tzst": true,
+}
+
+var monoIconCache = struct {
+	sync.Mutex
+	items map[string]fyne.Resource
+}{items: make(map[string]fyne.Resource)}
+
+// MonoIconKindFor picks the glyph for a list entry from its link state and
+// extension.
+func MonoIconKindFor(fi fileinfo.FileInfo) MonoIconKind {
+	link := fi.FileType == fileinfo.FileTypeSymlink
+	switch {
+	case fi.IsDir && link:
+		return MonoIconFolderLink
+	case fi.IsDir:
+		return MonoIconFolder
+	case link:
+		return MonoIconLink
+	case monoArchiveExts[strings.ToLower(filepath.Ext(fi.Name))]:
+		return MonoIconArchive
+	}
+	switch fileinfo.MediaKindForName(fi.Name) {
+	case fileinfo.MediaKindImage:
+		return MonoIconImage
+	case fileinfo.MediaKindAudio:
+		return MonoIconAudio
+	case fileinfo.MediaKindVideo:
+		return MonoIconVideo
+	}
+	return MonoIconFile
+}
+
+// MonoIcon returns the SVG glyph for kind drawn in c. SVG keeps the icon
+// sharp at any scale; resources are cached per kind and color.
+func MonoIcon(kind MonoIconKind, c color.Color) fyne.Resource {
+	r, g, b, _ := c.RGBA()
+	hex := fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+	key := fmt.Sprintf("%d%s", kind, hex)
+
+	monoIconCache.Lock()
+	defer monoIconCache.Unlock()
+	if res, ok := monoIconCache.items[key]; ok {
+		return res
+	}
+	body, ok := monoIconBodies[kind]
+	if !ok {
+		body = monoPage
+	}
+	svg := `<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24">` +
+		fmt.Sprintf(body, hex) + `</svg>`
+	res := fyne.NewStaticResource(fmt.Sprintf("mono-%d-%s.svg", kind, hex[1:]), []byte(svg))
+	monoIconCache.items[key] = res
+	return res
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+
+	"nmf/internal/fileinfo"
+)
+
+func TestMonoIconKindFor(t *testing.T) {
+	tests := []struct {
+		fi   fileinfo.FileInfo
+		want MonoIconKind
+	}{
+		{fileinfo.FileInfo{Name: "src", IsDir: true, FileType: fileinfo.FileTypeDirectory}, MonoIconFolder},
+		{fileinfo.FileInfo{Name: "link", IsDir: true, FileType: fileinfo.FileTypeSymlink}, MonoIconFolderLink},
+		{fileinfo.FileInfo{Name: "a.jpg", FileType: fileinfo.FileTypeSymlink}, MonoIconLink},
+		{fileinfo.FileInfo{Name: "Photo.JPG"}, MonoIconImage},
+		{fileinfo.FileInfo{Name: "song.flac"}, MonoIconAudio},
+		{fileinfo.FileInfo{Name: "clip.mov"}, MonoIconVideo},
+		{fileinfo.FileInfo{Name: "backup.tar.gz"}, MonoIconArchive},
+		{fileinfo.FileInfo{Name: "notes.txt"}, MonoIconFile},
+	}
+	for _, tt := range tests {
+		if got := MonoIconKindFor(tt.fi); got != tt.want {
+			t.Errorf("MonoIconKindFor(%q) = %d, want %d", tt.fi.Name, got, tt.want)
+		}
+	}
+}
+
+func TestMonoIconIsColoredCachedSVG(t *testing.T) {
+	red := color.RGBA{R: 0xff, A: 0xff}
+	res := MonoIcon(MonoIconImage, red)
+	if !strings.HasSuffix(res.Name(), ".svg") {
+		t.Fatalf("resource name %q should be an SVG", res.Name())
+	}
+	if !strings.Contains(string(res.Content()), `"#ff0000"`) {
+		t.Fatalf("icon is not drawn in the requested color: %s", res.Content())
+	}
+	if MonoIcon(MonoIconImage, red) != res {
+		t.Fatal("same kind and color should reuse the cached resource")
+	}
+	if MonoIcon(MonoIconImage, color.RGBA{B: 0xff, A: 0xff}) == res {
+		t.Fatal("different colors must not share a resource")
+	}
+}
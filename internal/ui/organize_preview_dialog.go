@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"nmf/internal/keymanager"
+)
+
+// OrganizePreviewDialog shows the dry-run placement of an organize-by-date
+// copy/move and queues it only after confirmation.
+type OrganizePreviewDialog struct {
+	op          Operation
+	destination string
+	lines       []string
+	keyManager  *keymanager.KeyManager
+	kmToken     keymanager.HandlerToken
+	dialog      dialog.Dialog
+	closed      bool
+	onAccept    func()
+}
+
+// NewOrganizePreviewDialog creates a preview for op into destination. Each
+// line describes one planned placement.
+func NewOrganizePreviewDialog(op Operation, destination string, lines []string, km *keymanager.KeyManager) *OrganizePreviewDialog {
+	return &OrganizePreviewDialog{
+		op:          op,
+		destination: destination,
+		lines:       append([]string(nil), lines...),
+		keyManager:  km,
+	}
+}
+
+func (d *OrganizePreviewDialog) ShowDialog(parent fyne.Window, onAccept func()) {
+	d.onAccept = onAccept
+
+	action := strings.Title(string(d.op))
+	header := widget.NewLabel(fmt.Sprintf("%s %d item(s) by date into %s:", action, len(d.lines), d.destination))
+	header.Wrapping = fyne.TextWrapWord
+	label := widget.NewLabel(strings.Join(d.lines, "\n"))
+	label.TextStyle = fyne.TextStyle{Monospace: true}
+	label.Wrapping = fyne.TextWrapOff
+	scroll := container.NewScroll(label)
+	scroll.SetMinSize(metricsSize(deleteDialogWidth-40, deleteTargetListHeight))
+
+	content := container.NewVBox(
+		header,
+		scroll,
+		dialogButtonRow("Cancel", d.CancelOrganize, action, d.ConfirmOrganize),
+	)
+
+	handler := keymanager.NewOrganizePreviewDialogKeyHandler(d)
+	d.kmToken = d.keyManager.PushHandler(handler)
+
+	d.dialog = dialog.NewCustomWithoutButtons("Organize by Date", content, parent)
+	d.dialog.SetOnClosed(func() {
+		d.CancelOrganize()
+	})
+	d.dialog.Show()
+}
+
+func (d *OrganizePreviewDialog) ConfirmOrganize() {
+	if d.closed {
+		return
+	}
+	d.closed = true
+	deferDialogClose(d.keyManager, "organize.confirm", func() {
+		d.keyManager.RemoveHandler(d.kmToken)
+		if d.dialog != nil {
+			d.dialog.Hide()
+		}
+		if d.onAccept != nil {
+			d.onAccept()
+		}
+	})
+}
+
+func (d *OrganizePreviewDialog) CancelOrganize() {
+	if d.closed {
+		return
+	}
+	d.closed = true
+	deferDialogClose(d.keyManager, "organize.cancel", func() {
+		d.keyManager.RemoveHandler(d.kmToken)
+		if d.dialog != nil {
+			d.dialog.Hide()
+		}
+	})
+}
@@ -0,0 +1,36 @@
+package ui
+
+import (
+	"testing"
+
+	"nmf/internal/keymanager"
+)
+
+func TestOrganizePreviewDialogConfirmRunsOnce(t *testing.T) {
+	km := keymanager.NewKeyManager(func(string, ...interface{}) {})
+	d := NewOrganizePreviewDialog(OpMove, "/photos", []string{"2024/01/02/a.jpg"}, km)
+	accepted := 0
+	d.onAccept = func() { accepted++ }
+
+	d.ConfirmOrganize()
+	d.ConfirmOrganize()
+	d.CancelOrganize()
+
+	if accepted != 1 {
+		t.Fatalf("accepted = %d, want 1", accepted)
+	}
+}
+
+func TestOrganizePreviewDialogCancelDoesNotAccept(t *testing.T) {
+	km := keymanager.NewKeyManager(func(string, ...interface{}) {})
+	d := NewOrganizePreviewDialog(OpCopy, "/photos", nil, km)
+	accepted := false
+	d.onAccept = func() { accepted = true }
+
+	d.CancelOrganize()
+	d.ConfirmOrganize()
+
+	if accepted {
+		t.Fatal("cancelled preview should not queue the job")
+	}
+}
@@ -0,0 +1,346 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"nmf/internal/keymanager"
+)
+
+// PlaceKind says where a place in the places dialog came from. Kinds are
+// listed in this order.
+type PlaceKind int
+
+const (
+	PlaceWindow PlaceKind = iota
+	PlaceBookmark
+	PlaceVolume
+	PlaceRecent
+)
+
+// Label returns the short tag shown in front of a place.
+func (k PlaceKind) Label() string {
+	switch k {
+	case PlaceWindow:
+		return "Window"
+	case PlaceBookmark:
+		return "Bookmark"
+	case PlaceVolume:
+		return "Volume"
+	default:
+		return "Recent"
+	}
+}
+
+// Place is one directory offered by the places dialog.
+type Place struct {
+	Kind PlaceKind
+	Name string
+	Path string
+}
+
+// PlacesDialog lists other windows, bookmarks, volumes, and recent
+// directories in one filterable list.
+type PlacesDialog struct {
+	searchEntry    *CustomSearchEntry
+	list           *widget.List
+	emptyLabel     *widget.Label
+	listScroll     *container.Scroll
+	allPlaces      []Place
+	filteredPlaces []Place
+	selectedIndex  int
+	debugPrint     func(format string, args ...interface{})
+	keyManager     *keymanager.KeyManager
+	kmToken        keymanager.HandlerToken
+	dialog         dialog.Dialog
+	callback       func(Place)
+	parent         fyne.Window
+	closed         bool
+	sink           *KeySink
+}
+
+// NewPlacesDialog creates a places dialog. places should already be in kind
+// order without duplicate paths.
+func NewPlacesDialog(
+	places []Place,
+	keyManager *keymanager.KeyManager,
+	debugPrint func(format string, args ...interface{}),
+) *PlacesDialog {
+	d := &PlacesDialog{
+		allPlaces:     places,
+		selectedIndex: -1,
+		debugPrint:    debugPrint,
+		keyManager:    keyManager,
+	}
+	d.createWidgets()
+	d.updateFilteredPlaces("")
+	return d
+}
+
+// filterPlaces keeps the places whose name or path contains query, ignoring
+// case. Places whose name starts with the query come first; otherwise the
+// kind order is kept.
+func filterPlaces(places []Place, query string) []Place {
+	needle := strings.ToLower(strings.TrimSpace(query))
+	if needle == "" {
+		return places
+	}
+	filtered := []Place{}
+	for _, p := range places {
+		if strings.Contains(strings.ToLower(p.Name), needle) || strings.Contains(strings.ToLower(p.Path), needle) {
+			filtered = append(filtered, p)
+		}
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		left := strings.HasPrefix(strings.ToLower(filtered[i].Name), needle)
+		right := strings.HasPrefix(strings.ToLower(filtered[j].Name), needle)
+		return left && !right
+	})
+	return filtered
+}
+
+func (d *PlacesDialog) createWidgets() {
+	d.searchEntry = NewCustomSearchEntry()
+	d.searchEntry.SetPlaceHolder("Type to filter places...")
+	d.searchEntry.OnChanged = func(query string) {
+		d.updateFilteredPlaces(query)
+	}
+
+	d.list = widget.NewList(
+		func() int {
+			return len(d.filteredPlaces)
+		},
+		func() fyne.CanvasObject {
+			kind := widget.NewLabel("")
+			kind.TextStyle = fyne.TextStyle{Italic: true}
+			kindBox := container.NewGridWrap(placeKindCellSize(), kind)
+			name := widget.NewLabel("")
+			name.TextStyle = fyne.TextStyle{Bold: true}
+			path := widget.NewLabel("")
+			path.TextStyle = fyne.TextStyle{Monospace: true}
+			return container.NewHBox(kindBox, name, path)
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			if id < 0 || int(id) >= len(d.filteredPlaces) {
+				return
+			}
+			place := d.filteredPlaces[id]
+			row, ok := obj.(*fyne.Container)
+			if !ok || len(row.Objects) < 3 {
+				return
+			}
+			if kindBox, ok := row.Objects[0].(*fyne.Container); ok && len(kindBox.Objects) > 0 {
+				if kind, ok := kindBox.Objects[0].(*widget.Label); ok {
+					kind.SetText(place.Kind.Label())
+				}
+			}
+			if name, ok := row.Objects[1].(*widget.Label); ok {
+				name.SetText(place.Name)
+			}
+			if path, ok := row.Objects[2].(*widget.Label); ok {
+				path.SetText(place.Path)
+			}
+		},
+	)
+
+	d.list.OnSelected = func(id widget.ListItemID) {
+		if id >= 0 && int(id) < len(d.filteredPlaces) {
+			d.selectedIndex = int(id)
+			d.debugPrint("PlacesDialog: selected %s index=%d", d.filteredPlaces[id].Path, d.selectedIndex)
+			if d.parent != nil && d.sink != nil {
+				d.parent.Canvas().Focus(d.sink)
+			}
+		}
+	}
+	d.list.Resize(searchDialogListSize())
+}
+
+func placeKindCellSize() fyne.Size {
+	appTheme := fyne.CurrentApp().Settings().Theme()
+	textSize := appTheme.Size(theme.SizeNameText)
+	padding := appTheme.Size(theme.SizeNamePadding)
+	innerPadding := appTheme.Size(theme.SizeNameInnerPadding)
+
+	return fyne.NewSize(textSize*6+padding*2, textSize+innerPadding*2)
+}
+
+func placesListWidth(places []Place, minimum float32) float32 {
+	rows := make([]string, len(places))
+	for i, p := range places {
+		rows[i] = p.Name + "  " + p.Path
+	}
+	return dialogTextWidth(rows, minimum) + placeKindCellSize().Width
+}
+
+func (d *PlacesDialog) updateFilteredPlaces(query string) {
+	d.filteredPlaces = filterPlaces(d.allPlaces, query)
+	d.list.Refresh()
+
+	if len(d.filteredPlaces) > 0 {
+		d.selectedIndex = 0
+		d.list.Select(0)
+	} else {
+		d.selectedIndex = -1
+	}
+	if d.listScroll != nil && d.emptyLabel != nil {
+		if len(d.filteredPlaces) == 0 {
+			d.listScroll.Hide()
+			d.emptyLabel.Show()
+		} else {
+			d.emptyLabel.Hide()
+			d.listScroll.Show()
+		}
+	}
+}
+
+// SetPlaces replaces the listed places, for sources such as volumes that
+// arrive after the dialog opened, and reapplies the current filter.
+func (d *PlacesDialog) SetPlaces(places []Place) {
+	if d.closed {
+		return
+	}
+	d.allPlaces = places
+	d.updateFilteredPlaces(d.searchEntry.Text)
+}
+
+// ShowDialog shows the places dialog and calls callback with the chosen place.
+func (d *PlacesDialog) ShowDialog(parent fyne.Window, callback func(Place)) {
+	listWidth := responsiveDialogWidth(parent, searchDialogListWidth)
+	contentWidth := responsiveDialogWidth(parent, searchDialogContentWidth)
+	listSize := metricsSize(listWidth, searchDialogListHeight)
+	contentSize := metricsSize(contentWidth, searchDialogContentHeight)
+
+	titleLabel := widget.NewLabel("Places")
+	titleLabel.TextStyle.Bold = true
+
+	searchLabel := widget.NewLabel("Filter:")
+	searchSection := container.NewBorder(nil, nil, searchLabel, nil, d.searchEntry)
+
+	d.listScroll = newScrollableDialogList(d.list, placesListWidth(d.allPlaces, listWidth), listWidth, searchDialogListHeight)
+
+	d.emptyLabel = widget.NewLabel("No matching places found")
+	d.emptyLabel.Alignment = fyne.TextAlignCenter
+	d.emptyLabel.Hide()
+
+	fixedContainer := container.NewWithoutLayout(d.listScroll, d.emptyLabel)
+	fixedContainer.Resize(listSize)
+	d.listScroll.Resize(listSize)
+	d.listScroll.Move(fyne.NewPos(0, 0))
+	d.emptyLabel.Resize(listSize)
+	d.emptyLabel.Move(fyne.NewPos(0, 0))
+	d.updateFilteredPlaces(d.searchEntry.Text)
+
+	content := container.NewBorder(
+		container.NewVBox(titleLabel, searchSection),
+		dialogButtonBar(dialogCancelButton("Cancel", d.CancelDialog), dialogConfirmButton("OK", d.AcceptSelection)),
+		nil,
+		nil,
+		fixedContainer,
+	)
+	content.Resize(contentSize)
+
+	d.callback = callback
+	d.parent = parent
+
+	handler := keymanager.NewPlacesDialogKeyHandler(d, d.debugPrint)
+	d.kmToken = d.keyManager.PushHandler(handler)
+
+	d.sink = NewKeySink(content, d.keyManager, WithTabCapture(true))
+	d.searchEntry.SetFocusRedirect(parent, d.sink)
+
+	d.dialog = dialog.NewCustomWithoutButtons("Places", d.sink, parent)
+
+	d.dialog.Show()
+	if d.parent != nil && d.sink != nil {
+		d.parent.Canvas().Focus(d.sink)
+		d.searchEntry.RefreshIMEAnchor()
+	}
+}
+
+// MoveUp moves the selection up.
+func (d *PlacesDialog) MoveUp() {
+	if len(d.filteredPlaces) > 0 && d.selectedIndex > 0 {
+		d.list.Select(widget.ListItemID(d.selectedIndex - 1))
+	}
+}
+
+// MoveDown moves the selection down.
+func (d *PlacesDialog) MoveDown() {
+	if d.selectedIndex+1 < len(d.filteredPlaces) {
+		d.list.Select(widget.ListItemID(d.selectedIndex + 1))
+	}
+}
+
+// MoveToTop moves selection to the top.
+func (d *PlacesDialog) MoveToTop() {
+	if len(d.filteredPlaces) > 0 {
+		d.list.Select(0)
+	}
+}
+
+// MoveToBottom moves selection to the bottom.
+func (d *PlacesDialog) MoveToBottom() {
+	if len(d.filteredPlaces) > 0 {
+		d.list.Select(widget.ListItemID(len(d.filteredPlaces) - 1))
+	}
+}
+
+// ClearSearch clears the filter text.
+func (d *PlacesDialog) ClearSearch() {
+	d.searchEntry.SetText("")
+}
+
+// AppendToSearch appends a character to the filter text.
+func (d *PlacesDialog) AppendToSearch(char string) {
+	d.searchEntry.SetText(d.searchEntry.Text + char)
+	d.debugPrint("PlacesDialog: append search=%s", d.searchEntry.Text)
+}
+
+// BackspaceSearch removes the last character from the filter text.
+func (d *PlacesDialog) BackspaceSearch() {
+	if current := d.searchEntry.Text; current != "" {
+		d.searchEntry.SetText(trimLastRune(current))
+	}
+}
+
+// AcceptSelection opens the selected place.
+func (d *PlacesDialog) AcceptSelection() {
+	if d.closed || d.selectedIndex < 0 || d.selectedIndex >= len(d.filteredPlaces) {
+		return
+	}
+	place := d.filteredPlaces[d.selectedIndex]
+	d.closed = true
+
+	deferDialogClose(d.keyManager, "places.accept", func() {
+		d.keyManager.RemoveHandler(d.kmToken)
+		if d.dialog != nil {
+			d.dialog.Hide()
+		}
+		unfocusIfDialogOwned(d.parent, d.sink, d.searchEntry)
+		if d.callback != nil {
+			d.callback(place)
+		}
+	})
+}
+
+// CancelDialog closes the dialog without a selection.
+func (d *PlacesDialog) CancelDialog() {
+	if d.closed {
+		return
+	}
+	d.closed = true
+
+	deferDialogClose(d.keyManager, "places.cancel", func() {
+		d.keyManager.RemoveHandler(d.kmToken)
+		if d.dialog != nil {
+			d.dialog.Hide()
+		}
+		unfocusIfDialogOwned(d.parent, d.sink, d.searchEntry)
+	})
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+
+	"nmf/internal/keymanager"
+)
+
+func TestFilterPlacesRanksNamePrefixFirst(t *testing.T) {
+	places := []Place{
+		{Kind: PlaceWindow, Name: "src", Path: "/home/me/docs/src"},
+		{Kind: PlaceBookmark, Name: "Docs", Path: "/home/me/docs"},
+		{Kind: PlaceRecent, Name: "tmp", Path: "/tmp"},
+	}
+	got := filterPlaces(places, "DOC")
+	if len(got) != 2 || got[0].Name != "Docs" || got[1].Name != "src" {
+		t.Fatalf("filterPlaces = %+v, want Docs then src", got)
+	}
+	if got := filterPlaces(places, " "); len(got) != len(places) {
+		t.Fatalf("blank query kept %d places, want %d", len(got), len(places))
+	}
+}
+
+func TestPlacesDialogKeepsFilterWhenPlacesArrive(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+	w := test.NewWindow(nil)
+	defer w.Close()
+
+	km := keymanager.NewKeyManager(func(string, ...interface{}) {})
+	d := NewPlacesDialog([]Place{{Kind: PlaceRecent, Name: "tmp", Path: "/tmp"}}, km, func(string, ...interface{}) {})
+	var accepted Place
+	d.ShowDialog(w, func(p Place) { accepted = p })
+
+	d.AppendToSearch("us")
+	if len(d.filteredPlaces) != 0 {
+		t.Fatalf("filtered = %+v, want none", d.filteredPlaces)
+	}
+	d.SetPlaces([]Place{
+		{Kind: PlaceVolume, Name: "USB", Path: "/media/usb"},
+		{Kind: PlaceRecent, Name: "tmp", Path: "/tmp"},
+	})
+	d.AcceptSelection()
+	fyne.DoAndWait(func() {})
+	if accepted.Path != "/media/usb" {
+		t.Fatalf("accepted = %+v, want the USB volume", accepted)
+	}
+	if got := km.GetStackSize(); got != 0 {
+		t.Fatalf("key manager stack size = %d, want 0", got)
+	}
+}
@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"nmf/internal/fileinfo"
+)
+
+// PreviewPane shows the cursor file beside the list: a header with its name
+// and details, and below it a thumbnail, a text excerpt, or a short message.
+// It keeps no file state; the owner pushes content on every cursor change.
+type PreviewPane struct {
+	title   *widget.Label
+	info    *widget.Label
+	image   *canvas.Image
+	text    *widget.Label
+	scroll  *container.Scroll
+	message *widget.Label
+	pane    *fyne.Container
+}
+
+// NewPreviewPane creates a hidden pane at least width wide.
+func NewPreviewPane(width float32) *PreviewPane {
+	p := &PreviewPane{
+		title:   widget.NewLabel(""),
+		info:    widget.NewLabel(""),
+		image:   canvas.NewImageFromImage(nil),
+		text:    widget.NewLabel(""),
+		message: widget.NewLabel(""),
+	}
+	p.title.TextStyle = fyne.TextStyle{Bold: true}
+	p.title.Truncation = fyne.TextTruncateEllipsis
+	p.info.Wrapping = fyne.TextWrapWord
+	p.image.FillMode = canvas.ImageFillContain
+	p.image.ScaleMode = canvas.ImageScaleSmooth
+	p.text.TextStyle = fyne.TextStyle{Monospace: true}
+	p.text.Wrapping = fyne.TextWrapBreak
+	p.message.Wrapping = fyne.TextWrapWord
+	p.message.Alignment = fyne.TextAlignCenter
+	p.scroll = container.NewVScroll(p.text)
+
+	spacer := canvas.NewRectangle(nil)
+	spacer.SetMinSize(fyne.NewSize(width, 0))
+	body := container.NewBorder(
+		container.NewVBox(p.title, p.info, widget.NewSeparator()),
+		nil, nil, nil,
+		container.NewStack(p.image, p.scroll, container.NewVBox(p.message)),
+	)
+	p.pane = container.NewBorder(nil, nil, widget.NewSeparator(), nil, container.NewStack(spacer, body))
+	p.pane.Hide()
+	p.SetMessage("")
+	return p
+}
+
+func (p *PreviewPane) Container() fyne.CanvasObject {
+	return p.pane
+}
+
+// SetInfo replaces the header: the file name and one detail per line.
+func (p *PreviewPane) SetInfo(title string, lines []string) {
+	p.title.SetText(title)
+	p.info.SetText(strings.Join(lines, "\n"))
+	if len(lines) == 0 {
+		p.info.Hide()
+	} else {
+		p.info.Show()
+	}
+}
+
+// SetMessage shows text, such as "Loading...", in place of a preview.
+func (p *PreviewPane) SetMessage(text string) {
+	p.image.Image = nil
+	p.image.Hide()
+	p.scroll.Hide()
+	p.message.SetText(text)
+	p.message.Show()
+}
+
+// SetPreview shows a loaded preview.
+func (p *PreviewPane) SetPreview(preview fileinfo.PanePreview) {
+	switch {
+	case preview.Thumbnail != nil:
+		p.message.Hide()
+		p.scroll.Hide()
+		p.image.Image = preview.Thumbnail
+		p.image.Show()
+		p.image.Refresh()
+	case preview.Text != "":
+		p.message.Hide()
+		p.image.Image = nil
+		p.image.Hide()
+		text := preview.Text
+		if preview.Truncated {
+			text += "\n..."
+		}
+		p.text.SetText(text)
+		p.scroll.ScrollToTop()
+		p.scroll.Show()
+	case preview.Err != "":
+		p.SetMessage(preview.Err)
+	case preview.ImageFormat != "":
+		p.SetMessage(fmt.Sprintf("%s image", strings.ToUpper(preview.ImageFormat)))
+	default:
+		p.SetMessage("Empty file")
+	}
+}
+
+// Message returns the shown message; used by tests.
+func (p *PreviewPane) Message() string {
+	if !p.message.Visible() {
+		return ""
+	}
+	return p.message.Text
+}
+
+// Text returns the shown text excerpt; used by tests.
+func (p *PreviewPane) Text() string {
+	if !p.scroll.Visible() {
+		return ""
+	}
+	return p.text.Text
+}
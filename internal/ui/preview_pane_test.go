@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"image"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+
+	"nmf/internal/fileinfo"
+)
+
+func TestPreviewPaneSwitchesBetweenTextImageAndMessage(t *testing.T) {
+	test.NewTempApp(t)
+	pane := NewPreviewPane(200)
+	if pane.Container().Visible() {
+		t.Fatal("preview pane should start hidden")
+	}
+
+	pane.SetPreview(fileinfo.PanePreview{Text: "hello", Truncated: true})
+	if pane.Text() != "hello\n..." || pane.Message() != "" {
+		t.Fatalf("text = %q message = %q", pane.Text(), pane.Message())
+	}
+
+	pane.SetPreview(fileinfo.PanePreview{Thumbnail: image.NewRGBA(image.Rect(0, 0, 4, 4))})
+	if pane.Text() != "" || !pane.image.Visible() {
+		t.Fatal("thumbnail should replace the text excerpt")
+	}
+
+	pane.SetMessage("Loading...")
+	if pane.Message() != "Loading..." || pane.image.Visible() {
+		t.Fatalf("message = %q image visible = %t", pane.Message(), pane.image.Visible())
+	}
+}
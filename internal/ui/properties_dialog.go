@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"nmf/internal/config"
+	"nmf/internal/fileinfo"
+	"nmf/internal/keymanager"
+)
+
+var permissionClasses = []string{"Owner", "Group", "Other"}
+
+var permissionBitLabels = []string{"Read", "Write", "Exec"}
+
+// PropertiesDialog shows file details above an octal mode entry mirrored by
+// a grid of rwx checkboxes. The details can be replaced while the dialog is
+// open, so slow values such as directory sizes fill in as they arrive.
+type PropertiesDialog struct {
+	*LineEditDialog
+	info     *widget.Label
+	bits     [9]*widget.Check
+	editable bool
+	syncing  bool
+}
+
+// NewPropertiesDialog creates the dialog for count items. mode seeds the
+// permission editor; when editable is false it is shown read-only and the
+// confirm button only closes the dialog. onClosed runs when it closes.
+func NewPropertiesDialog(count int, info string, mode os.FileMode, editable bool, onClosed func(), km *keymanager.KeyManager, configuredBindings ...[]config.KeyBindingEntry) *PropertiesDialog {
+	d := &PropertiesDialog{editable: editable}
+	d.info = widget.NewLabel(info)
+	d.info.Wrapping = fyne.TextWrapWord
+	d.info.Selectable = true
+	scroll := container.NewVScroll(d.info)
+	scroll.SetMinSize(fyne.NewSize(0, propertiesInfoHeight))
+
+	grid := container.NewGridWithColumns(4, widget.NewLabel(""))
+	for _, label := range permissionBitLabels {
+		grid.Add(widget.NewLabel(label))
+	}
+	for class, name := range permissionClasses {
+		grid.Add(widget.NewLabel(name))
+		for bit := range permissionBitLabels {
+			check := widget.NewCheck("", func(bool) { d.checksChanged() })
+			d.bits[class*3+bit] = check
+			grid.Add(check)
+		}
+	}
+
+	confirm := "Apply"
+	if !editable {
+		confirm = "Close"
+	}
+	d.LineEditDialog = NewLineEditDialog(LineEditDialogOptions{
+		Title:       "Properties",
+		Prompt:      propertiesPrompt(count, editable),
+		InitialText: fmt.Sprintf("%03o", mode.Perm()),
+		ConfirmText: confirm,
+		Height:      propertiesDialogHeight,
+		Header:      scroll,
+		Extra:       grid,
+		OnClosed:    onClosed,
+	}, km, configuredBindings...)
+	d.setChecks(mode.Perm())
+	d.entry.OnChanged = d.modeChanged
+	if !editable {
+		d.entry.Disable()
+		for _, check := range d.bits {
+			check.Disable()
+		}
+	}
+	return d
+}
+
+func propertiesPrompt(count int, editable bool) string {
+	switch {
+	case !editable:
+		return "Permissions (cannot be changed here):"
+	case count == 1:
+		return "Permissions (octal):"
+	default:
+		return fmt.Sprintf("Permissions (octal) for %d items:", count)
+	}
+}
+
+// ShowDialog displays the dialog. onApply receives the edited permission
+// bits and returns false to keep the dialog open; it is not called when the
+// dialog is read-only.
+func (d *PropertiesDialog) ShowDialog(parent fyne.Window, onApply func(os.FileMode) bool) {
+	d.LineEditDialog.ShowDialog(parent, func(text string) bool {
+		if !d.editable {
+			return true
+		}
+		mode, err := fileinfo.ParsePermissionBits(text)
+		if err != nil {
+			d.SetInfo(err.Error() + "\n\n" + d.info.Text)
+			return false
+		}
+		return onApply(mode)
+	})
+}
+
+// SetInfo replaces the details text. It must run on the Fyne thread.
+func (d *PropertiesDialog) SetInfo(text string) {
+	d.info.SetText(text)
+}
+
+// Mode returns the permission bits currently shown by the checkboxes.
+func (d *PropertiesDialog) Mode() os.FileMode {
+	var mode os.FileMode
+	for i, check := range d.bits {
+		if check.Checked {
+			mode |= 1 << (8 - i)
+		}
+	}
+	return mode
+}
+
+func (d *PropertiesDialog) setChecks(mode os.FileMode) {
+	d.syncing = true
+	for i, check := range d.bits {
+		check.SetChecked(mode&(1<<(8-i)) != 0)
+	}
+	d.syncing = false
+}
+
+// modeChanged mirrors a valid octal entry into the checkboxes.
+func (d *PropertiesDialog) modeChanged(text string) {
+	if d.syncing {
+		return
+	}
+	if mode, err := fileinfo.ParsePermissionBits(text); err == nil {
+		d.setChecks(mode)
+	}
+}
+
+// checksChanged rewrites the octal entry from the checkboxes.
+func (d *PropertiesDialog) checksChanged() {
+	if d.syncing || d.entry == nil {
+		return
+	}
+	d.syncing = true
+	d.entry.SetText(fmt.Sprintf("%03o", d.Mode()))
+	d.syncing = false
+}
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+)
+
+func TestPropertiesDialogKeepsModeAndChecksInSync(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	d := NewPropertiesDialog(1, "Name: a", 0o644, true, nil, nil)
+	if d.entry.Text != "644" || d.Mode() != 0o644 {
+		t.Fatalf("initial mode = %q / %o, want 644", d.entry.Text, d.Mode())
+	}
+
+	d.entry.SetText("750")
+	if d.Mode() != 0o750 {
+		t.Fatalf("checks after typing 750 = %o", d.Mode())
+	}
+
+	d.bits[8].SetChecked(true)
+	if d.entry.Text != "751" {
+		t.Fatalf("entry after checking other-exec = %q, want 751", d.entry.Text)
+	}
+
+	d.entry.SetText("75")
+	if d.Mode() != 0o75 {
+		t.Fatalf("short octal should still apply, got %o", d.Mode())
+	}
+}
+
+func TestPropertiesDialogReadOnlyDisablesEditing(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	d := NewPropertiesDialog(2, "Items: 2", 0o755, false, nil, nil)
+	if !d.entry.Disabled() || !d.bits[0].Disabled() {
+		t.Fatal("read-only dialog should disable the mode editors")
+	}
+	d.SetInfo("Items: 2\nTotal: 1 KB")
+	if d.info.Text != "Items: 2\nTotal: 1 KB" {
+		t.Fatalf("info = %q", d.info.Text)
+	}
+}
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+
+	"nmf/internal/config"
+	"nmf/internal/keymanager"
+)
+
+// RunCommandDialog asks for a shell command line to run in the current
+// directory, and whether to reload the directory once it finishes.
+type RunCommandDialog struct {
+	*LineEditDialog
+	refreshCheck *widget.Check
+}
+
+// NewRunCommandDialog creates the prompt with initial as the suggested
+// command line.
+func NewRunCommandDialog(dir, initial string, refresh bool, km *keymanager.KeyManager, configuredBindings ...[]config.KeyBindingEntry) *RunCommandDialog {
+	d := &RunCommandDialog{}
+	d.refreshCheck = widget.NewCheck("Refresh the directory when the command finishes", nil)
+	d.refreshCheck.SetChecked(refresh)
+	d.LineEditDialog = NewLineEditDialog(LineEditDialogOptions{
+		Title:            "Run Command",
+		Prompt:           "Command to run in " + dir + "  (%f: cursor file, %s: marked files, %%: a literal %)",
+		InitialText:      initial,
+		InitialSelection: &LineEditSelection{Start: 0, End: len([]rune(initial))},
+		ConfirmText:      "Run",
+		Width:            760,
+		Height:           compressDialogHeight,
+		Extra:            d.refreshCheck,
+	}, km, configuredBindings...)
+	return d
+}
+
+// ShowDialog displays the dialog; onAccept returns false to keep it open.
+func (d *RunCommandDialog) ShowDialog(parent fyne.Window, onAccept func(line string, refresh bool) bool) {
+	d.LineEditDialog.ShowDialog(parent, func(line string) bool {
+		return onAccept(line, d.refreshCheck.Checked)
+	})
+}
@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+
+	"nmf/internal/keymanager"
+)
+
+func TestRunCommandDialogSelectsLastCommandAndReportsRefresh(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+	w := test.NewWindow(nil)
+	defer w.Close()
+	km := keymanager.NewKeyManager(func(string, ...interface{}) {})
+
+	d := NewRunCommandDialog("/src", "make test", true, km)
+	if got := d.entry.SelectedText(); got != "make test" {
+		t.Fatalf("initial selection = %q, want the whole command", got)
+	}
+
+	var gotLine string
+	var gotRefresh bool
+	d.ShowDialog(w, func(line string, refresh bool) bool {
+		gotLine, gotRefresh = line, refresh
+		return true
+	})
+	d.refreshCheck.SetChecked(false)
+	d.entry.SetText("ls -l %s")
+	d.AcceptEdit()
+	if gotLine != "ls -l %s" || gotRefresh {
+		t.Fatalf("accepted line=%q refresh=%v, want ls -l %%s and false", gotLine, gotRefresh)
+	}
+}
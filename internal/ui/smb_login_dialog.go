@@ -39,6 +39,10 @@ func (p *SMBCredentialsProvider) Get(ctx context.Context, host, share, _ string)
 	}
 	done := make(chan result, 1)
 	dialogReady := make(chan *smbLoginDialog, 1)
+	// The keyring is read here rather than on the UI thread, since it may
+	// block while the keyring unlocks.
+	stored, saved := fileinfo.StoredCredentialsFor(host, share)
+	keyring := fileinfo.SecretStoreAvailable()
 
 	fyne.Do(func() {
 		if ctx.Err() != nil {
@@ -51,6 +55,7 @@ func (p *SMBCredentialsProvider) Get(ctx context.Context, host, share, _ string)
 			}
 			done <- result{credentials: c}
 		})
+		d.setSaveState(keyring, saved, stored)
 		dialogReady <- d
 		if ctx.Err() != nil {
 			d.CancelDialog()
@@ -72,40 +77,95 @@ func (p *SMBCredentialsProvider) Get(ctx context.Context, host, share, _ string)
 	}
 }
 
+// smbLoginDialog asks for the credentials of one share. As a login prompt
+// it offers saving them to the keyring; the credentials manager reuses it to
+// edit a saved entry, without the save box.
 type smbLoginDialog struct {
-	host       string
-	share      string
-	parent     fyne.Window
-	km         *keymanager.KeyManager
-	kmToken    keymanager.HandlerToken
-	bindings   []config.KeyBindingEntry
-	dialog     dialog.Dialog
-	domain     *LineEditEntry
-	username   *LineEditEntry
-	password   *LineEditEntry
-	saveCheck  *widget.Check
-	active     int
-	closed     bool
-	onFinished func(bool, fileinfo.Credentials)
+	host        string
+	share       string
+	title       string
+	confirmText string
+	parent      fyne.Window
+	km          *keymanager.KeyManager
+	kmToken     keymanager.HandlerToken
+	bindings    []config.KeyBindingEntry
+	dialog      dialog.Dialog
+	domain      *LineEditEntry
+	username    *LineEditEntry
+	password    *LineEditEntry
+	saveCheck   *widget.Check
+	showSave    bool
+	wasSaved    bool
+	active      int
+	closed      bool
+	onFinished  func(bool, fileinfo.Credentials)
 }
 
 func newSMBLoginDialog(host, share string, parent fyne.Window, km *keymanager.KeyManager, bindings []config.KeyBindingEntry, onFinished func(bool, fileinfo.Credentials)) *smbLoginDialog {
 	d := &smbLoginDialog{
-		host:       host,
-		share:      share,
-		parent:     parent,
-		km:         km,
-		bindings:   bindings,
-		active:     1,
-		onFinished: onFinished,
+		host:        host,
+		share:       share,
+		title:       "SMB Login: " + host + "/" + share,
+		confirmText: "Login",
+		parent:      parent,
+		km:          km,
+		bindings:    bindings,
+		showSave:    true,
+		active:      1,
+		onFinished:  onFinished,
 	}
 	d.domain = d.newEntry("domain (optional)", false)
 	d.username = d.newEntry("username", false)
 	d.password = d.newEntry("password", true)
-	d.saveCheck = widget.NewCheck("この端末に保存 (keyring)", nil)
+	d.saveCheck = widget.NewCheck("Save password in keyring (Ctrl+S)", nil)
 	return d
 }
 
+// newSMBCredentialsEditDialog edits the saved credentials c of host/share.
+func newSMBCredentialsEditDialog(host, share string, c fileinfo.Credentials, parent fyne.Window, km *keymanager.KeyManager, bindings []config.KeyBindingEntry, onFinished func(bool, fileinfo.Credentials)) *smbLoginDialog {
+	d := newSMBLoginDialog(host, share, parent, km, bindings, onFinished)
+	d.title = "Edit Credentials: " + host + "/" + share
+	d.confirmText = "Save"
+	d.showSave = false
+	d.prefill(c)
+	d.password.SetText(c.Password)
+	return d
+}
+
+// setSaveState shows whether the share has a keyring entry: the save box
+// starts checked with the saved account filled in, so logging in again keeps
+// the entry, and clearing the box removes it. Without a keyring the box is
+// disabled.
+func (d *smbLoginDialog) setSaveState(keyring, saved bool, stored fileinfo.Credentials) {
+	if !keyring {
+		d.saveCheck.SetText("Save password (no keyring available)")
+		d.saveCheck.Disable()
+		return
+	}
+	d.wasSaved = saved
+	d.saveCheck.SetChecked(saved)
+	if saved {
+		d.prefill(stored)
+	}
+}
+
+// prefill fills in the account of c and focuses the password.
+func (d *smbLoginDialog) prefill(c fileinfo.Credentials) {
+	d.domain.SetText(c.Domain)
+	d.username.SetText(c.Username)
+	if c.Username != "" {
+		d.active = 2
+	}
+}
+
+// ToggleSave flips the save box, when a keyring can hold the password.
+func (d *smbLoginDialog) ToggleSave() {
+	if !d.showSave || d.saveCheck.Disabled() {
+		return
+	}
+	d.saveCheck.SetChecked(!d.saveCheck.Checked)
+}
+
 func (d *smbLoginDialog) newEntry(placeholder string, password bool) *LineEditEntry {
 	entry := NewLineEditEntry(d.CancelDialog, d.km)
 	entry.SetPlaceHolder(placeholder)
@@ -125,33 +185,37 @@ func (d *smbLoginDialog) show() {
 		container.NewBorder(nil, nil, widget.NewLabel("Domain"), nil, lineEditThemeOverride(d.domain)),
 		container.NewBorder(nil, nil, widget.NewLabel("Username"), nil, lineEditThemeOverride(d.username)),
 		container.NewBorder(nil, nil, widget.NewLabel("Password"), nil, lineEditThemeOverride(d.password)),
-		d.saveCheck,
-		dialogButtonRow("Cancel", d.CancelDialog, "Login", d.AcceptLogin),
 	)
+	if d.showSave {
+		content.Add(d.saveCheck)
+	}
+	content.Add(dialogButtonRow("Cancel", d.CancelDialog, d.confirmText, d.AcceptLogin))
 
 	if d.km != nil {
 		handler := newSMBLoginKeyHandler(d, d.bindings, d.km.Debugf)
 		d.kmToken = d.km.PushHandler(handler)
 	}
 
-	d.dialog = dialog.NewCustomWithoutButtons("SMB Login: "+d.host+"/"+d.share, content, d.parent)
+	d.dialog = dialog.NewCustomWithoutButtons(d.title, content, d.parent)
 	d.dialog.SetOnClosed(func() {
 		d.CancelDialog()
 	})
 	d.dialog.Show()
 	d.dialog.Resize(metricsSize(smbLoginDialogWidth, smbLoginDialogHeight))
-	d.focusEntry(1)
+	d.focusEntry(d.active)
 }
 
 func (d *smbLoginDialog) AcceptLogin() {
 	if d.closed {
 		return
 	}
+	save := d.showSave && d.saveCheck.Checked
 	creds := fileinfo.Credentials{
 		Domain:   d.domain.Text,
 		Username: d.username.Text,
 		Password: d.password.Text,
-		Persist:  d.saveCheck.Checked,
+		Persist:  save,
+		Forget:   d.showSave && d.wasSaved && !save,
 	}
 	d.close(true, creds)
 }
@@ -276,6 +340,10 @@ func newSMBLoginKeyHandler(d *smbLoginDialog, bindings []config.KeyBindingEntry,
 func (h *smbLoginKeyHandler) GetName() string { return "SMBLoginDialog" }
 
 func (h *smbLoginKeyHandler) OnKeyActivated(ev *fyne.KeyEvent, modifiers keymanager.ModifierState) bool {
+	if ev != nil && ev.Name == fyne.KeyS && modifiers.CtrlPressed && !modifiers.AltPressed && !modifiers.ShiftPressed {
+		h.dialog.ToggleSave()
+		return true
+	}
 	if ev != nil && ev.Name == fyne.KeyTab {
 		if modifiers.ShiftPressed {
 			h.dialog.MoveToPreviousField()
@@ -184,3 +184,48 @@ func typeShiftEntryKey(entry *LineEditEntry, key fyne.KeyName) {
 	entry.KeyUp(&fyne.KeyEvent{Name: key})
 	entry.KeyUp(&fyne.KeyEvent{Name: desktop.KeyShiftLeft})
 }
+
+func TestSMBLoginDialogSaveBoxFollowsKeyring(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+	w := test.NewWindow(nil)
+	defer w.Close()
+	km := keymanager.NewKeyManager(func(string, ...interface{}) {})
+	var got fileinfo.Credentials
+	d := newSMBLoginDialog("server", "share", w, km, nil, func(_ bool, creds fileinfo.Credentials) {
+		got = creds
+	})
+	d.setSaveState(true, true, fileinfo.Credentials{Domain: "CORP", Username: "alice", Password: "old"})
+	d.show()
+
+	if !d.saveCheck.Checked || d.username.Text != "alice" || d.password.Text != "" {
+		t.Fatalf("save=%t username=%q password=%q, want the saved account checked without its password", d.saveCheck.Checked, d.username.Text, d.password.Text)
+	}
+	if w.Canvas().Focused() != d.password {
+		t.Fatalf("focus = %T, want the password entry", w.Canvas().Focused())
+	}
+	km.HandleKeyDown(&fyne.KeyEvent{Name: desktop.KeyControlLeft})
+	pressDialogKey(km, fyne.KeyS, 0)
+	km.HandleKeyUp(&fyne.KeyEvent{Name: desktop.KeyControlLeft})
+	d.password.SetText("secret")
+	d.AcceptLogin()
+	fyne.DoAndWait(func() {})
+
+	if got.Persist || !got.Forget {
+		t.Fatalf("credentials = %+v, want the saved entry forgotten", got)
+	}
+}
+
+func TestSMBLoginDialogSaveBoxDisabledWithoutKeyring(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+	w := test.NewWindow(nil)
+	defer w.Close()
+	d := newSMBLoginDialog("server", "share", w, nil, nil, nil)
+	d.setSaveState(false, false, fileinfo.Credentials{})
+
+	d.ToggleSave()
+	if !d.saveCheck.Disabled() || d.saveCheck.Checked {
+		t.Fatal("save box should stay unchecked and disabled without a keyring")
+	}
+}
@@ -30,6 +30,11 @@ type SortDialog struct {
 	sortByRadio        *widget.RadioGroup
 	sortOrderRadio     *widget.RadioGroup
 	directoriesFirstCB *widget.Check
+	naturalCB          *widget.Check
+	localeCB           *widget.Check
+	localeTag          string // Locale the locale checkbox turns on
+	groupByDateCB      *widget.Check
+	directoryOnlyCB    *widget.Check
 
 	currentConfig config.SortConfig
 	debugPrint    func(format string, args ...interface{})
@@ -57,6 +62,10 @@ func NewSortDialog(currentConfig config.SortConfig, keyManager *keymanager.KeyMa
 		currentConfig: currentConfig,
 		keyManager:    keyManager,
 		debugPrint:    debugPrint,
+		localeTag:     currentConfig.Locale,
+	}
+	if sd.localeTag == "" {
+		sd.localeTag = config.SortLocaleAuto
 	}
 
 	sd.createWidgets()
@@ -71,6 +80,8 @@ func (sd *SortDialog) createWidgets() {
 		"Size",
 		"Modified",
 		"Extension",
+		"Date taken",
+		"Tag",
 	}, func(selected string) {
 		sd.debugPrint("SortDialog: Sort by selected: %s", selected)
 		// Prevent deselection - ensure at least one option is always selected
@@ -101,6 +112,34 @@ func (sd *SortDialog) createWidgets() {
 		sd.setCurrentField(sortFieldOptions)
 	})
 
+	// Natural numbers checkbox
+	sd.naturalCB = widget.NewCheck("Natural numbers (file2 before file10)", func(checked bool) {
+		sd.debugPrint("SortDialog: Natural numbers: %t", checked)
+		sd.setCurrentField(sortFieldOptions)
+	})
+
+	// Locale order checkbox; an explicit locale from the config is kept
+	localeLabel := "Locale order"
+	if sd.localeTag != config.SortLocaleAuto {
+		localeLabel += " (" + sd.localeTag + ")"
+	}
+	sd.localeCB = widget.NewCheck(localeLabel, func(checked bool) {
+		sd.debugPrint("SortDialog: Locale order: %t", checked)
+		sd.setCurrentField(sortFieldOptions)
+	})
+
+	// Group by date taken checkbox: one group per capture day
+	sd.groupByDateCB = widget.NewCheck("Group by date taken", func(checked bool) {
+		sd.debugPrint("SortDialog: Group by date taken: %t", checked)
+		sd.setCurrentField(sortFieldOptions)
+	})
+
+	// Directory-only checkbox: save the sort for the current directory alone
+	sd.directoryOnlyCB = widget.NewCheck("This directory only", func(checked bool) {
+		sd.debugPrint("SortDialog: This directory only: %t", checked)
+		sd.setCurrentField(sortFieldOptions)
+	})
+
 	// Set current values
 	sd.loadCurrentSettings()
 }
@@ -117,6 +156,10 @@ func (sd *SortDialog) loadCurrentSettings() {
 		sd.sortByRadio.SetSelected("Modified")
 	case "extension":
 		sd.sortByRadio.SetSelected("Extension")
+	case "dateTaken":
+		sd.sortByRadio.SetSelected("Date taken")
+	case "tag":
+		sd.sortByRadio.SetSelected("Tag")
 	default:
 		sd.sortByRadio.SetSelected("Name")
 	}
@@ -130,6 +173,9 @@ func (sd *SortDialog) loadCurrentSettings() {
 
 	// Set directories first
 	sd.directoriesFirstCB.SetChecked(sd.currentConfig.DirectoriesFirst)
+	sd.naturalCB.SetChecked(sd.currentConfig.Natural)
+	sd.localeCB.SetChecked(sd.currentConfig.Locale != "")
+	sd.groupByDateCB.SetChecked(sd.currentConfig.GroupBy == "dateTaken")
 }
 
 // loadCurrentSortBySelection restores the current sort by selection
@@ -143,6 +189,10 @@ func (sd *SortDialog) loadCurrentSortBySelection() {
 		sd.sortByRadio.SetSelected("Modified")
 	case "extension":
 		sd.sortByRadio.SetSelected("Extension")
+	case "dateTaken":
+		sd.sortByRadio.SetSelected("Date taken")
+	case "tag":
+		sd.sortByRadio.SetSelected("Tag")
 	default:
 		sd.sortByRadio.SetSelected("Name")
 	}
@@ -186,7 +236,7 @@ func (sd *SortDialog) Show(parent fyne.Window, keyManager *keymanager.KeyManager
 // createContent creates the dialog content layout
 func (sd *SortDialog) createContent() *fyne.Container {
 	// Sort by section
-	sortByLabel := widget.NewLabel("Sort by: (1-4)")
+	sortByLabel := widget.NewLabel("Sort by: (1-6)")
 	sd.sortByBG = canvas.NewRectangle(color.Transparent)
 	sortByContainer := container.NewStack(sd.sortByBG, container.NewVBox(sortByLabel, sd.sortByRadio))
 
@@ -198,9 +248,9 @@ func (sd *SortDialog) createContent() *fyne.Container {
 
 	// Options section
 	optionsLabel := widget.NewLabel("")
-	optionsLabel2 := widget.NewLabel("Options: (D)")
+	optionsLabel2 := widget.NewLabel("Options: (D/N/L/G/R)")
 	sd.optionsBG = canvas.NewRectangle(color.Transparent)
-	optionsContainer := container.NewStack(sd.optionsBG, container.NewVBox(optionsLabel, optionsLabel2, sd.directoriesFirstCB))
+	optionsContainer := container.NewStack(sd.optionsBG, container.NewVBox(optionsLabel, optionsLabel2, sd.directoriesFirstCB, sd.naturalCB, sd.localeCB, sd.groupByDateCB, sd.directoryOnlyCB))
 
 	// Keyboard shortcuts help
 	shortcutsHelp := widget.NewLabel("Shortcuts: Enter=Apply, Esc=Cancel, Tab=Navigate")
@@ -233,6 +283,13 @@ func (sd *SortDialog) applySettings() {
 	// Build sort config from UI
 	sortConfig := config.SortConfig{
 		DirectoriesFirst: sd.directoriesFirstCB.Checked,
+		Natural:          sd.naturalCB.Checked,
+	}
+	if sd.localeCB.Checked {
+		sortConfig.Locale = sd.localeTag
+	}
+	if sd.groupByDateCB.Checked {
+		sortConfig.GroupBy = "dateTaken"
 	}
 
 	// Convert sort by selection to config value
@@ -245,6 +302,10 @@ func (sd *SortDialog) applySettings() {
 		sortConfig.SortBy = "modified"
 	case "Extension":
 		sortConfig.SortBy = "extension"
+	case "Date taken":
+		sortConfig.SortBy = "dateTaken"
+	case "Tag":
+		sortConfig.SortBy = "tag"
 	default:
 		sortConfig.SortBy = "name"
 	}
@@ -310,6 +371,18 @@ func (sd *SortDialog) SetOnApply(callback func(sortConfig config.SortConfig)) {
 	sd.onApply = callback
 }
 
+// SetDirectoryOnly sets whether the sort is saved for the current directory
+// alone; it starts checked when the directory already has its own sort.
+func (sd *SortDialog) SetDirectoryOnly(directoryOnly bool) {
+	sd.directoryOnlyCB.SetChecked(directoryOnly)
+}
+
+// DirectoryOnly reports whether the sort is to be saved for the current
+// directory alone.
+func (sd *SortDialog) DirectoryOnly() bool {
+	return sd.directoryOnlyCB.Checked
+}
+
 // SetOnCancel sets the callback for when dialog is cancelled
 func (sd *SortDialog) SetOnCancel(callback func()) {
 	sd.onCancel = callback
@@ -324,6 +397,13 @@ func (sd *SortDialog) SetOnCleanup(callback func()) {
 func (sd *SortDialog) GetCurrentSelection() config.SortConfig {
 	sortConfig := config.SortConfig{
 		DirectoriesFirst: sd.directoriesFirstCB.Checked,
+		Natural:          sd.naturalCB.Checked,
+	}
+	if sd.localeCB.Checked {
+		sortConfig.Locale = sd.localeTag
+	}
+	if sd.groupByDateCB.Checked {
+		sortConfig.GroupBy = "dateTaken"
 	}
 
 	// Convert sort by selection to config value
@@ -336,6 +416,10 @@ func (sd *SortDialog) GetCurrentSelection() config.SortConfig {
 		sortConfig.SortBy = "modified"
 	case "Extension":
 		sortConfig.SortBy = "extension"
+	case "Date taken":
+		sortConfig.SortBy = "dateTaken"
+	case "Tag":
+		sortConfig.SortBy = "tag"
 	default:
 		sortConfig.SortBy = "name"
 	}
@@ -484,6 +568,18 @@ func (sd *SortDialog) SetSortByExtension() {
 	sd.sortByRadio.SetSelected("Extension")
 }
 
+// SetSortByDateTaken sets sort by to Date taken (5 key)
+func (sd *SortDialog) SetSortByDateTaken() {
+	sd.debugPrint("SortDialog: Keyboard shortcut: Set sort by Date taken")
+	sd.sortByRadio.SetSelected("Date taken")
+}
+
+// SetSortByTag sets sort by to Tag (6 key)
+func (sd *SortDialog) SetSortByTag() {
+	sd.debugPrint("SortDialog: Keyboard shortcut: Set sort by Tag")
+	sd.sortByRadio.SetSelected("Tag")
+}
+
 // ToggleSortOrder toggles between Ascending and Descending (O key)
 func (sd *SortDialog) ToggleSortOrder() {
 	sd.debugPrint("SortDialog: Keyboard shortcut: Toggle sort order")
@@ -499,3 +595,27 @@ func (sd *SortDialog) ToggleDirectoriesFirst() {
 	sd.debugPrint("SortDialog: Keyboard shortcut: Toggle directories first")
 	sd.directoriesFirstCB.SetChecked(!sd.directoriesFirstCB.Checked)
 }
+
+// ToggleNatural toggles natural number ordering (N key)
+func (sd *SortDialog) ToggleNatural() {
+	sd.debugPrint("SortDialog: Keyboard shortcut: Toggle natural numbers")
+	sd.naturalCB.SetChecked(!sd.naturalCB.Checked)
+}
+
+// ToggleLocale toggles locale-aware ordering (L key)
+func (sd *SortDialog) ToggleLocale() {
+	sd.debugPrint("SortDialog: Keyboard shortcut: Toggle locale order")
+	sd.localeCB.SetChecked(!sd.localeCB.Checked)
+}
+
+// ToggleGroupByDateTaken toggles grouping by capture day (G key)
+func (sd *SortDialog) ToggleGroupByDateTaken() {
+	sd.debugPrint("SortDialog: Keyboard shortcut: Toggle group by date taken")
+	sd.groupByDateCB.SetChecked(!sd.groupByDateCB.Checked)
+}
+
+// ToggleDirectoryOnly toggles saving the sort for this directory only (R key)
+func (sd *SortDialog) ToggleDirectoryOnly() {
+	sd.debugPrint("SortDialog: Keyboard shortcut: Toggle this directory only")
+	sd.directoryOnlyCB.SetChecked(!sd.directoryOnlyCB.Checked)
+}
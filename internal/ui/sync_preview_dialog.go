@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"nmf/internal/keymanager"
+)
+
+// SyncPreviewDialog lists the copies, deletes, and conflicts a directory
+// synchronization plans and queues it only after confirmation.
+type SyncPreviewDialog struct {
+	summary    string
+	lines      []string
+	keyManager *keymanager.KeyManager
+	kmToken    keymanager.HandlerToken
+	dialog     dialog.Dialog
+	closed     bool
+	onAccept   func()
+}
+
+// NewSyncPreviewDialog creates a preview headed by summary. Each line
+// describes one planned action.
+func NewSyncPreviewDialog(summary string, lines []string, km *keymanager.KeyManager) *SyncPreviewDialog {
+	return &SyncPreviewDialog{
+		summary:    summary,
+		lines:      append([]string(nil), lines...),
+		keyManager: km,
+	}
+}
+
+func (d *SyncPreviewDialog) ShowDialog(parent fyne.Window, onAccept func()) {
+	d.onAccept = onAccept
+
+	header := widget.NewLabel(d.summary)
+	header.Wrapping = fyne.TextWrapWord
+	label := widget.NewLabel(strings.Join(d.lines, "\n"))
+	label.TextStyle = fyne.TextStyle{Monospace: true}
+	label.Wrapping = fyne.TextWrapOff
+	scroll := container.NewScroll(label)
+	scroll.SetMinSize(metricsSize(deleteDialogWidth-40, deleteTargetListHeight))
+
+	content := container.NewVBox(
+		header,
+		scroll,
+		dialogButtonRow("Cancel", d.CancelSync, "Synchronize", d.ConfirmSync),
+	)
+
+	handler := keymanager.NewSyncPreviewDialogKeyHandler(d)
+	d.kmToken = d.keyManager.PushHandler(handler)
+
+	d.dialog = dialog.NewCustomWithoutButtons("Synchronize Directories", content, parent)
+	d.dialog.SetOnClosed(func() {
+		d.CancelSync()
+	})
+	d.dialog.Show()
+}
+
+func (d *SyncPreviewDialog) ConfirmSync() {
+	if d.closed {
+		return
+	}
+	d.closed = true
+	deferDialogClose(d.keyManager, "sync.confirm", func() {
+		d.keyManager.RemoveHandler(d.kmToken)
+		if d.dialog != nil {
+			d.dialog.Hide()
+		}
+		if d.onAccept != nil {
+			d.onAccept()
+		}
+	})
+}
+
+func (d *SyncPreviewDialog) CancelSync() {
+	if d.closed {
+		return
+	}
+	d.closed = true
+	deferDialogClose(d.keyManager, "sync.cancel", func() {
+		d.keyManager.RemoveHandler(d.kmToken)
+		if d.dialog != nil {
+			d.dialog.Hide()
+		}
+	})
+}
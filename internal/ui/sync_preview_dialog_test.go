@@ -0,0 +1,22 @@
+package ui
+
+import (
+	"testing"
+
+	"nmf/internal/keymanager"
+)
+
+func TestSyncPreviewDialogConfirmRunsOnce(t *testing.T) {
+	km := keymanager.NewKeyManager(func(string, ...interface{}) {})
+	d := NewSyncPreviewDialog("Mirror /a into /b", []string{"copy x.txt"}, km)
+	accepted := 0
+	d.onAccept = func() { accepted++ }
+
+	d.ConfirmSync()
+	d.ConfirmSync()
+	d.CancelSync()
+
+	if accepted != 1 {
+		t.Fatalf("accepted = %d, want 1", accepted)
+	}
+}
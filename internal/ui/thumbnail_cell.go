@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ThumbnailCell is the reusable visual template for a thumbnail grid item: a
+// square image area above the file name. Files without a thumbnail show
+// their icon in the middle of the square instead.
+//
+// Like FileListRow, GridWrap UpdateItem callbacks update its state but never
+// replace the canvas object tree.
+type ThumbnailCell struct {
+	widget.BaseWidget
+
+	Icon      *TappableIcon
+	NameLabel *FileNameLabel
+
+	image      *canvas.Image
+	background *canvas.Rectangle
+	frame      *canvas.Rectangle
+	tag        *canvas.Circle
+	content    fyne.CanvasObject
+
+	onTappedSecondary func(*fyne.PointEvent)
+}
+
+// NewThumbnailCell creates a cell whose image area is edge pixels square.
+func NewThumbnailCell(edge float32, nameColor color.RGBA) *ThumbnailCell {
+	c := &ThumbnailCell{
+		Icon:       NewTappableIcon(theme.FileIcon(), nil),
+		NameLabel:  NewFileNameLabel("filename", nameColor),
+		image:      canvas.NewImageFromImage(nil),
+		background: canvas.NewRectangle(color.Transparent),
+		frame:      canvas.NewRectangle(color.Transparent),
+		tag:        canvas.NewCircle(color.Transparent),
+	}
+	c.image.FillMode = canvas.ImageFillContain
+	c.image.ScaleMode = canvas.ImageScaleSmooth
+	c.image.SetMinSize(fyne.NewSize(edge, edge))
+	c.frame.StrokeWidth = 2
+	c.frame.StrokeColor = color.Transparent
+
+	iconSize := edge / 2
+	square := container.NewStack(
+		c.image,
+		container.NewCenter(container.NewGridWrap(fyne.NewSize(iconSize, iconSize), c.Icon)),
+		container.NewWithoutLayout(c.tag),
+	)
+	c.tag.Resize(fyne.NewSize(edge/8, edge/8))
+	c.tag.Move(fyne.NewPos(edge-edge/8, 0))
+	c.content = container.NewStack(
+		c.background,
+		container.NewPadded(container.NewBorder(nil, c.NameLabel, nil, nil, square)),
+		c.frame,
+	)
+	c.Icon.SetOnTappedSecondary(c.TappedSecondary)
+	c.NameLabel.SetOnTappedSecondary(c.TappedSecondary)
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+// TappedSecondary handles a right click anywhere on the cell, as
+// FileListRow.TappedSecondary does for a row.
+func (c *ThumbnailCell) TappedSecondary(ev *fyne.PointEvent) {
+	if c.onTappedSecondary != nil {
+		c.onTappedSecondary(ev)
+	}
+}
+
+// SetOnTappedSecondary sets the callback invoked when the cell is
+// right-clicked.
+func (c *ThumbnailCell) SetOnTappedSecondary(onTappedSecondary func(*fyne.PointEvent)) {
+	c.onTappedSecondary = onTappedSecondary
+}
+
+// SetThumbnail shows img, or icon when img is nil.
+func (c *ThumbnailCell) SetThumbnail(img image.Image, icon fyne.Resource) {
+	c.image.Image = img
+	if img != nil {
+		c.Icon.Hide()
+		c.image.Show()
+		c.image.Refresh()
+		return
+	}
+	c.image.Hide()
+	c.Icon.SetResource(icon)
+	c.Icon.Show()
+}
+
+// HasThumbnail reports whether the cell shows an image; used by tests.
+func (c *ThumbnailCell) HasThumbnail() bool {
+	return c.image.Visible() && c.image.Image != nil
+}
+
+// SetDecorations updates the cell's status, selection, and cursor state.
+// The cursor is a frame around the cell whatever the list cursor style.
+func (c *ThumbnailCell) SetDecorations(
+	statusColor *color.RGBA,
+	selected bool,
+	selectionColor color.RGBA,
+	cursor bool,
+	cursorColor color.RGBA,
+) {
+	var fill color.Color = color.Transparent
+	if statusColor != nil {
+		fill = *statusColor
+	}
+	if selected {
+		fill = selectionColor
+	}
+	c.background.FillColor = fill
+	c.background.Refresh()
+	var stroke color.Color = color.Transparent
+	if cursor {
+		stroke = cursorColor
+	}
+	c.frame.StrokeColor = stroke
+	c.frame.Refresh()
+}
+
+// SetTagColor shows the color tag as a dot in the image's corner; a fully
+// transparent color hides it.
+func (c *ThumbnailCell) SetTagColor(tagColor color.RGBA) {
+	c.tag.FillColor = tagColor
+	c.tag.Refresh()
+}
+
+func (c *ThumbnailCell) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(c.content)
+}
@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+)
+
+func TestThumbnailCellSwitchesBetweenImageAndIcon(t *testing.T) {
+	test.NewTempApp(t)
+	cell := NewThumbnailCell(64, color.RGBA{A: 255})
+	if cell.MinSize().Width < 64 || cell.MinSize().Height < 64 {
+		t.Fatalf("min size = %v, want at least the 64px image area", cell.MinSize())
+	}
+
+	cell.SetThumbnail(image.NewRGBA(image.Rect(0, 0, 64, 32)), theme.FileIcon())
+	if !cell.HasThumbnail() || cell.Icon.Visible() {
+		t.Fatal("a thumbnail should replace the icon")
+	}
+
+	cell.SetThumbnail(nil, theme.FolderIcon())
+	if cell.HasThumbnail() || !cell.Icon.Visible() {
+		t.Fatal("a cell without thumbnail should show the icon")
+	}
+}
+
+func TestThumbnailCellDrawsCursorFrameAndSelection(t *testing.T) {
+	test.NewTempApp(t)
+	cell := NewThumbnailCell(64, color.RGBA{A: 255})
+	selection := color.RGBA{R: 1, A: 255}
+	cursor := color.RGBA{G: 2, A: 255}
+
+	cell.SetDecorations(nil, true, selection, true, cursor)
+	if cell.background.FillColor != selection || cell.frame.StrokeColor != cursor {
+		t.Fatalf("fill = %v stroke = %v", cell.background.FillColor, cell.frame.StrokeColor)
+	}
+
+	cell.SetDecorations(nil, false, selection, false, cursor)
+	if cell.background.FillColor != color.Transparent || cell.frame.StrokeColor != color.Transparent {
+		t.Fatalf("fill = %v stroke = %v, want both cleared", cell.background.FillColor, cell.frame.StrokeColor)
+	}
+}
@@ -0,0 +1,235 @@
+package ui
+
+import (
+	"image/color"
+	"math"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/mobile"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	touchLongPressDelay = 500 * time.Millisecond
+	touchDoubleTapDelay = 350 * time.Millisecond
+	// touchSlop is how far a finger may wander before a tap becomes a drag.
+	touchSlop = 12
+	// touchSwipeDistance is the horizontal travel of a two-finger swipe.
+	touchSwipeDistance = 60
+	// touchPinchRatio is the finger distance change that counts as a pinch.
+	touchPinchRatio = 1.25
+)
+
+// TouchGestures receives the gestures a TouchLayer recognizes. Positions are
+// relative to the layer. Any callback may be nil.
+type TouchGestures struct {
+	Tap       func(pos fyne.Position)
+	DoubleTap func(pos fyne.Position)
+	LongPress func(pos fyne.Position)
+	// Drag reports a one-finger vertical move; dy is positive downwards.
+	Drag func(dy float32)
+	// Swipe reports a two-finger horizontal swipe; dx is positive rightwards.
+	Swipe func(dx float32)
+	// Pinch reports a two-finger pinch; scale > 1 when the fingers spread.
+	Pinch func(scale float32)
+}
+
+type touchPoint struct {
+	start fyne.Position
+	pos   fyne.Position
+}
+
+// TouchLayer is a transparent layer stacked over a list that turns touch
+// events into gestures. It implements only mobile.Touchable and
+// mobile.Movable, so mouse clicks, scrolling, and keys on desktop drivers
+// pass through to the list untouched.
+type TouchLayer struct {
+	widget.BaseWidget
+
+	gestures TouchGestures
+	now      func() time.Time
+	after    func(time.Duration, func()) // schedules the long-press check
+
+	touches     map[int]*touchPoint
+	multi       bool // two fingers took part in the current gesture
+	resolved    bool // the two-finger gesture has been reported
+	dragging    bool
+	longPressed bool
+	generation  uint64 // invalidates pending long-press timers
+	startDist   float32
+	startMid    fyne.Position
+
+	lastTap    time.Time
+	lastTapPos fyne.Position
+}
+
+// NewTouchLayer creates a touch layer reporting to gestures.
+func NewTouchLayer(gestures TouchGestures) *TouchLayer {
+	l := &TouchLayer{
+		gestures: gestures,
+		now:      time.Now,
+		touches:  make(map[int]*touchPoint),
+	}
+	l.after = func(d time.Duration, f func()) {
+		time.AfterFunc(d, func() { fyne.Do(f) })
+	}
+	l.ExtendBaseWidget(l)
+	return l
+}
+
+// CreateRenderer draws nothing; the layer only receives touches.
+func (l *TouchLayer) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(canvas.NewRectangle(color.Transparent))
+}
+
+// TouchDown starts tracking a finger.
+func (l *TouchLayer) TouchDown(ev *mobile.TouchEvent) {
+	l.touches[ev.ID] = &touchPoint{start: ev.Position, pos: ev.Position}
+	l.generation++
+	switch len(l.touches) {
+	case 1:
+		if !l.multi {
+			l.dragging = false
+			l.longPressed = false
+			l.scheduleLongPress(l.generation)
+		}
+	case 2:
+		l.multi = true
+		l.resolved = false
+		a, b := l.pair()
+		l.startDist = touchDistance(a.pos, b.pos)
+		l.startMid = touchMidpoint(a.pos, b.pos)
+	}
+}
+
+// TouchMoved follows a finger; one finger past the slop drags the list.
+func (l *TouchLayer) TouchMoved(ev *mobile.TouchEvent) {
+	p := l.touches[ev.ID]
+	if p == nil {
+		return
+	}
+	dy := ev.Position.Y - p.pos.Y
+	p.pos = ev.Position
+	if l.multi {
+		return
+	}
+	if !l.dragging && touchDistance(p.start, p.pos) > touchSlop {
+		l.dragging = true
+		l.generation++
+	}
+	if l.dragging && l.gestures.Drag != nil {
+		l.gestures.Drag(dy)
+	}
+}
+
+// TouchUp ends a finger and reports a tap, double tap, swipe, or pinch.
+func (l *TouchLayer) TouchUp(ev *mobile.TouchEvent) {
+	p := l.touches[ev.ID]
+	if p == nil {
+		return
+	}
+	p.pos = ev.Position
+	if l.multi {
+		if len(l.touches) == 2 && !l.resolved {
+			l.resolveTwoFinger()
+		}
+		l.release(ev.ID)
+		return
+	}
+	l.release(ev.ID)
+	if l.dragging || l.longPressed {
+		return
+	}
+	now := l.now()
+	if !l.lastTap.IsZero() && now.Sub(l.lastTap) <= touchDoubleTapDelay && touchDistance(l.lastTapPos, p.pos) <= touchSlop*2 {
+		l.lastTap = time.Time{}
+		if l.gestures.DoubleTap != nil {
+			l.gestures.DoubleTap(p.pos)
+		}
+		return
+	}
+	l.lastTap, l.lastTapPos = now, p.pos
+	if l.gestures.Tap != nil {
+		l.gestures.Tap(p.pos)
+	}
+}
+
+// TouchCancel drops a finger without reporting a gesture.
+func (l *TouchLayer) TouchCancel(ev *mobile.TouchEvent) {
+	l.resolved = true
+	l.dragging = true
+	l.release(ev.ID)
+}
+
+func (l *TouchLayer) release(id int) {
+	delete(l.touches, id)
+	l.generation++
+	if len(l.touches) == 0 {
+		l.multi = false
+	}
+}
+
+func (l *TouchLayer) scheduleLongPress(generation uint64) {
+	l.after(touchLongPressDelay, func() { l.fireLongPress(generation) })
+}
+
+// fireLongPress reports a long press when the finger that started the
+// generation is still down and has not moved.
+func (l *TouchLayer) fireLongPress(generation uint64) {
+	if generation != l.generation || l.multi || l.dragging || len(l.touches) != 1 {
+		return
+	}
+	l.longPressed = true
+	for _, p := range l.touches {
+		if l.gestures.LongPress != nil {
+			l.gestures.LongPress(p.start)
+		}
+	}
+}
+
+// resolveTwoFinger reports a pinch when the finger distance changed enough,
+// otherwise a swipe when the fingers travelled mostly sideways.
+func (l *TouchLayer) resolveTwoFinger() {
+	l.resolved = true
+	a, b := l.pair()
+	if l.startDist > 0 {
+		scale := touchDistance(a.pos, b.pos) / l.startDist
+		if scale >= touchPinchRatio || scale <= 1/touchPinchRatio {
+			if l.gestures.Pinch != nil {
+				l.gestures.Pinch(scale)
+			}
+			return
+		}
+	}
+	move := touchMidpoint(a.pos, b.pos).Subtract(l.startMid)
+	if math.Abs(float64(move.X)) >= touchSwipeDistance && math.Abs(float64(move.X)) > 2*math.Abs(float64(move.Y)) {
+		if l.gestures.Swipe != nil {
+			l.gestures.Swipe(move.X)
+		}
+	}
+}
+
+// pair returns the two tracked fingers in a stable order.
+func (l *TouchLayer) pair() (*touchPoint, *touchPoint) {
+	var ids []int
+	for id := range l.touches {
+		ids = append(ids, id)
+	}
+	if len(ids) < 2 {
+		return &touchPoint{}, &touchPoint{}
+	}
+	if ids[0] > ids[1] {
+		ids[0], ids[1] = ids[1], ids[0]
+	}
+	return l.touches[ids[0]], l.touches[ids[1]]
+}
+
+func touchDistance(a, b fyne.Position) float32 {
+	return float32(math.Hypot(float64(a.X-b.X), float64(a.Y-b.Y)))
+}
+
+func touchMidpoint(a, b fyne.Position) fyne.Position {
+	return fyne.NewPos((a.X+b.X)/2, (a.Y+b.Y)/2)
+}
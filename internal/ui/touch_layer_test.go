@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/mobile"
+)
+
+func touchAt(id int, x, y float32) *mobile.TouchEvent {
+	ev := &mobile.TouchEvent{ID: id}
+	ev.Position = fyne.NewPos(x, y)
+	return ev
+}
+
+// newTestTouchLayer creates a layer whose long-press timer never fires on
+// its own; tests call fireLongPress directly.
+func newTestTouchLayer(gestures TouchGestures) *TouchLayer {
+	layer := NewTouchLayer(gestures)
+	layer.after = func(time.Duration, func()) {}
+	return layer
+}
+
+func TestTouchLayerTapThenDoubleTap(t *testing.T) {
+	var taps, doubles int
+	layer := newTestTouchLayer(TouchGestures{
+		Tap:       func(fyne.Position) { taps++ },
+		DoubleTap: func(fyne.Position) { doubles++ },
+	})
+	now := time.Unix(100, 0)
+	layer.now = func() time.Time { return now }
+
+	layer.TouchDown(touchAt(0, 10, 40))
+	layer.TouchUp(touchAt(0, 11, 41))
+	now = now.Add(200 * time.Millisecond)
+	layer.TouchDown(touchAt(0, 12, 40))
+	layer.TouchUp(touchAt(0, 12, 40))
+
+	if taps != 1 || doubles != 1 {
+		t.Fatalf("taps=%d doubles=%d, want one tap then one double tap", taps, doubles)
+	}
+}
+
+func TestTouchLayerDragSuppressesTapAndLongPress(t *testing.T) {
+	var taps, longPresses int
+	var dragged float32
+	layer := newTestTouchLayer(TouchGestures{
+		Tap:       func(fyne.Position) { taps++ },
+		LongPress: func(fyne.Position) { longPresses++ },
+		Drag:      func(dy float32) { dragged += dy },
+	})
+
+	layer.TouchDown(touchAt(0, 10, 100))
+	generation := layer.generation
+	layer.TouchMoved(touchAt(0, 10, 70))
+	layer.fireLongPress(generation)
+	layer.TouchUp(touchAt(0, 10, 70))
+
+	if taps != 0 || longPresses != 0 || dragged != -30 {
+		t.Fatalf("taps=%d longPresses=%d dragged=%v, want only a -30 drag", taps, longPresses, dragged)
+	}
+}
+
+func TestTouchLayerLongPressReplacesTap(t *testing.T) {
+	var taps int
+	var pressed fyne.Position
+	layer := newTestTouchLayer(TouchGestures{
+		Tap:       func(fyne.Position) { taps++ },
+		LongPress: func(pos fyne.Position) { pressed = pos },
+	})
+
+	layer.TouchDown(touchAt(0, 20, 30))
+	layer.fireLongPress(layer.generation)
+	layer.TouchUp(touchAt(0, 20, 30))
+
+	if pressed != fyne.NewPos(20, 30) || taps != 0 {
+		t.Fatalf("pressed=%v taps=%d, want long press at 20,30 and no tap", pressed, taps)
+	}
+}
+
+func TestTouchLayerTwoFingerSwipeAndPinch(t *testing.T) {
+	var swiped, scale float32
+	var taps int
+	layer := newTestTouchLayer(TouchGestures{
+		Tap:   func(fyne.Position) { taps++ },
+		Swipe: func(dx float32) { swiped = dx },
+		Pinch: func(s float32) { scale = s },
+	})
+
+	layer.TouchDown(touchAt(0, 100, 100))
+	layer.TouchDown(touchAt(1, 100, 140))
+	layer.TouchMoved(touchAt(0, 180, 102))
+	layer.TouchMoved(touchAt(1, 180, 142))
+	layer.TouchUp(touchAt(0, 180, 102))
+	layer.TouchUp(touchAt(1, 180, 142))
+	if swiped != 80 || scale != 0 || taps != 0 {
+		t.Fatalf("swiped=%v scale=%v taps=%d, want an 80px swipe only", swiped, scale, taps)
+	}
+
+	swiped = 0
+	layer.TouchDown(touchAt(0, 100, 100))
+	layer.TouchDown(touchAt(1, 100, 140))
+	layer.TouchMoved(touchAt(0, 100, 60))
+	layer.TouchMoved(touchAt(1, 100, 180))
+	layer.TouchUp(touchAt(1, 100, 180))
+	layer.TouchUp(touchAt(0, 100, 60))
+	if scale != 3 || swiped != 0 || taps != 0 {
+		t.Fatalf("scale=%v swiped=%v taps=%d, want a 3x pinch only", scale, swiped, taps)
+	}
+}
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"context"
 	"strings"
 	"sync"
 
@@ -18,10 +19,10 @@ import (
 // DirectoryTreeDialog represents a directory tree navigation dialog
 type DirectoryTreeDialog struct {
 	tree           *widget.Tree
-	currentRoot    string                                   // Current root path: "/" or parent directory
+	currentRoot    string                                   // Root path of the chosen root option
 	selectedPath   string                                   // Currently selected directory path
-	parentPath     string                                   // Parent directory of current FileManager path
-	rootMode       bool                                     // true = filesystem root "/", false = parent directory
+	roots          []treeRootOption                         // Root choices: filesystem, share, parent directory
+	rootIndex      int                                      // Index of the chosen root option
 	debugPrint     func(format string, args ...interface{}) // Debug function
 	keyManager     *keymanager.KeyManager                   // Keyboard input manager
 	kmToken        keymanager.HandlerToken                  // Token of the pushed key handler
@@ -33,27 +34,41 @@ type DirectoryTreeDialog struct {
 	radioGroup     *widget.RadioGroup                       // Root mode selection radio group
 	children       map[string][]string
 	branches       map[string]bool
-	loading        map[string]bool
-	loadChildren   func(string) ([]string, error)
+	loading        map[string]*treeLoad
+	loads          sync.WaitGroup // Reading goroutines of loading
+	loadChildren   func(context.Context, string) ([]string, error)
 	classifyBranch func(string) (bool, bool)
 	loadApplyMu    sync.Mutex
 	loadUIApplyMu  sync.Mutex
 }
 
-// NewDirectoryTreeDialog creates a new directory tree dialog
+// treeRootOption is one root the dialog can show the tree from.
+type treeRootOption struct {
+	label string
+	path  string
+}
+
+// NewDirectoryTreeDialog creates a new directory tree dialog. On a remote
+// share the tree starts at the share root, which "/" cannot reach.
 func NewDirectoryTreeDialog(currentPath string, keyManager *keymanager.KeyManager, debugPrint func(format string, args ...interface{})) *DirectoryTreeDialog {
-	parentPath := GetPlatformParent(currentPath)
+	roots := []treeRootOption{{constants.RootModeOptionText, GetSystemRoot()}}
+	rootIndex := 0
+	if shareRoot := treeShareRoot(currentPath); shareRoot != "" {
+		roots = append(roots, treeRootOption{constants.ShareModeOptionText, shareRoot})
+		rootIndex = 1
+	}
+	roots = append(roots, treeRootOption{constants.ParentModeOptionText, GetPlatformParent(currentPath)})
 
 	dialog := &DirectoryTreeDialog{
 		selectedPath:   currentPath,
-		parentPath:     parentPath,
-		rootMode:       true, // Start with filesystem root
-		currentRoot:    GetSystemRoot(),
+		roots:          roots,
+		rootIndex:      rootIndex,
+		currentRoot:    roots[rootIndex].path,
 		debugPrint:     debugPrint,
 		keyManager:     keyManager,
 		children:       make(map[string][]string),
 		branches:       make(map[string]bool),
-		loading:        make(map[string]bool),
+		loading:        make(map[string]*treeLoad),
 		classifyBranch: IsPlatformDirectory,
 	}
 	dialog.loadChildren = dialog.readDirectoryChildren
@@ -65,9 +80,16 @@ func NewDirectoryTreeDialog(currentPath string, keyManager *keymanager.KeyManage
 // createTree creates the tree widget with lazy loading
 func (dtd *DirectoryTreeDialog) createTree() {
 	dtd.tree = widget.NewTree(
-		// childUIDs function - returns child directories
+		// childUIDs function - returns child directories, or a placeholder
+		// row while they are first read
 		func(uid widget.TreeNodeID) []widget.TreeNodeID {
+			if isTreeLoadingID(uid) {
+				return nil
+			}
 			children := dtd.getDirectoryChildren(string(uid))
+			if len(children) == 0 && dtd.isLoading(string(uid)) {
+				return []widget.TreeNodeID{treeLoadingID(string(uid))}
+			}
 			result := make([]widget.TreeNodeID, len(children))
 			for i, child := range children {
 				result[i] = widget.TreeNodeID(child)
@@ -76,28 +98,33 @@ func (dtd *DirectoryTreeDialog) createTree() {
 		},
 		// isBranch function - all paths are branches (directories)
 		func(uid widget.TreeNodeID) bool {
-			return dtd.isDirectory(string(uid))
+			return !isTreeLoadingID(uid) && dtd.isDirectory(string(uid))
 		},
 		// create function - creates node UI
 		func(branch bool) fyne.CanvasObject {
 			icon := widget.NewIcon(theme.FolderIcon())
 			icon.Resize(metricsSize(treeDialogIconSize, treeDialogIconSize))
+			activity := widget.NewActivity()
+			activity.Hide()
 			label := widget.NewLabel("Directory")
-			return container.NewHBox(icon, label)
+			return container.NewHBox(container.NewStack(icon, activity), label)
 		},
 		// update function - updates node UI with directory name
 		func(uid widget.TreeNodeID, branch bool, obj fyne.CanvasObject) {
-			hbox := obj.(*fyne.Container)
-			if len(hbox.Objects) >= 2 {
-				if label, ok := hbox.Objects[1].(*widget.Label); ok {
-					label.SetText(dtd.getDisplayName(string(uid)))
-				}
+			if isTreeLoadingID(uid) {
+				setTreeNodeContent(obj, "Loading...", true)
+				return
 			}
+			setTreeNodeContent(obj, dtd.getDisplayName(string(uid)), false)
 		},
 	)
 
 	// Set selection handler
 	dtd.tree.OnSelected = func(uid widget.TreeNodeID) {
+		if isTreeLoadingID(uid) {
+			dtd.tree.Unselect(uid)
+			return
+		}
 		dtd.selectedPath = string(uid)
 		dtd.debugPrint("TreeDialog: Directory selected: %s", uid)
 		// Keep focus on sink so KeyManager continues to receive keys
@@ -110,6 +137,13 @@ func (dtd *DirectoryTreeDialog) createTree() {
 	dtd.tree.OnBranchOpened = func(uid widget.TreeNodeID) {
 		dtd.debugPrint("TreeDialog: Branch opened: %s", uid)
 	}
+	// Collapsing a branch stops reading it and the branches below it
+	dtd.tree.OnBranchClosed = func(uid widget.TreeNodeID) {
+		dtd.loadApplyMu.Lock()
+		cancelTreeLoads(dtd.loading, string(uid))
+		dtd.loadApplyMu.Unlock()
+		dtd.debugPrint("TreeDialog: Branch closed: %s", uid)
+	}
 
 	// Set initial root node
 	dtd.tree.Root = widget.TreeNodeID(dtd.currentRoot)
@@ -122,19 +156,23 @@ func (dtd *DirectoryTreeDialog) getDirectoryChildren(path string) []string {
 		dtd.loadApplyMu.Unlock()
 		return append([]string(nil), children...)
 	}
-	if dtd.loading[path] || dtd.closed {
+	if dtd.loading[path] != nil || dtd.closed {
 		dtd.loadApplyMu.Unlock()
 		return nil
 	}
-	dtd.loading[path] = true
+	load := newTreeLoad()
+	dtd.loading[path] = load
 	loader := dtd.loadChildren
 	classifier := dtd.classifyBranch
 	dtd.loadApplyMu.Unlock()
-	go func() {
-		children, err := loader(path)
+	dtd.loads.Go(func() {
+		children, err := loader(load.ctx, path)
 		branches := make(map[string]bool, len(children))
 		if err == nil {
 			for _, child := range children {
+				if load.ctx.Err() != nil {
+					break
+				}
 				isBranch := true
 				if classified, handled := classifier(child); handled {
 					isBranch = classified
@@ -146,11 +184,13 @@ func (dtd *DirectoryTreeDialog) getDirectoryChildren(path string) []string {
 			dtd.loadUIApplyMu.Lock()
 			defer dtd.loadUIApplyMu.Unlock()
 			dtd.loadApplyMu.Lock()
-			delete(dtd.loading, path)
-			if dtd.closed {
+			// A collapsed branch or closed dialog cancelled this load.
+			if dtd.loading[path] != load || dtd.closed {
 				dtd.loadApplyMu.Unlock()
 				return
 			}
+			delete(dtd.loading, path)
+			load.cancel()
 			if err != nil {
 				dtd.debugPrint("TreeDialog: Error reading directory %s: %v", path, err)
 				dtd.children[path] = nil
@@ -166,23 +206,62 @@ func (dtd *DirectoryTreeDialog) getDirectoryChildren(path string) []string {
 				tree.Refresh()
 			}
 		})
-	}()
+	})
 	return nil
 }
 
-func (dtd *DirectoryTreeDialog) readDirectoryChildren(path string) ([]string, error) {
+func (dtd *DirectoryTreeDialog) readDirectoryChildren(ctx context.Context, path string) ([]string, error) {
+	return readTreeChildren(ctx, path)
+}
+
+// isLoading reports whether the children of path are being read for the
+// first time.
+func (dtd *DirectoryTreeDialog) isLoading(path string) bool {
+	dtd.loadApplyMu.Lock()
+	defer dtd.loadApplyMu.Unlock()
+	_, cached := dtd.children[path]
+	return !cached && dtd.loading[path] != nil
+}
+
+// treeShareRoot returns the top of path's hierarchy when it is not reachable
+// from the filesystem root, as for smb:// and other remote display paths,
+// or "" for local paths.
+func treeShareRoot(path string) string {
+	if path == "" {
+		return ""
+	}
+	top := path
+	for {
+		parent := GetPlatformParent(top)
+		if parent == "" || parent == top {
+			break
+		}
+		top = parent
+	}
+	if top == GetSystemRoot() || IsVirtualRoot(top) {
+		return ""
+	}
+	return top
+}
+
+// readTreeChildren lists the subdirectories of path for the tree dialog and
+// pane, skipping dot directories. Cancelling ctx abandons the read.
+func readTreeChildren(ctx context.Context, path string) ([]string, error) {
 	// Check for platform-specific children first (e.g., Windows drives)
 	if platformChildren, handled := GetPlatformSpecificChildren(path); handled {
 		return platformChildren, nil
 	}
 
-	entries, err := fileinfo.ReadDirPortable(path)
+	entries, err := fileinfo.ReadDirPortableContext(ctx, path)
 	if err != nil {
 		return nil, err
 	}
 
 	var children []string
 	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		childPath := fileinfo.JoinPath(path, entry.Name())
 		metadata, err := fileinfo.InspectPath(childPath, entry.Name(), entry)
 		if err != nil || !metadata.IsDir {
@@ -215,6 +294,11 @@ func (dtd *DirectoryTreeDialog) isDirectory(path string) bool {
 
 // getDisplayName returns the display name for a directory path
 func (dtd *DirectoryTreeDialog) getDisplayName(path string) string {
+	return treeDisplayName(path)
+}
+
+// treeDisplayName returns the label of a tree node.
+func treeDisplayName(path string) string {
 	// Check for platform-specific display names first
 	if displayName, handled := GetPlatformDisplayName(path); handled {
 		return displayName
@@ -223,6 +307,9 @@ func (dtd *DirectoryTreeDialog) getDisplayName(path string) string {
 	if path == GetSystemRoot() {
 		return GetSystemRoot()
 	}
+	if (fileinfo.IsSMBDisplay(path) || fileinfo.IsGioURI(path)) && fileinfo.ParentPath(path) == path {
+		return path
+	}
 	base := fileinfo.BaseName(path)
 	if base == "." {
 		return fileinfo.ParentPath(path)
@@ -266,54 +353,30 @@ func (dtd *DirectoryTreeDialog) ShowDialog(parent fyne.Window, callback func(str
 	contentWidth := responsiveDialogWidth(parent, treeDialogContentWidth)
 
 	// Create radio group for root selection
-	rootOptions := []string{constants.RootModeOptionText, constants.ParentModeOptionText}
-	var selectedOption string
-	if dtd.rootMode {
-		selectedOption = constants.RootModeOptionText
-	} else {
-		selectedOption = constants.ParentModeOptionText
+	rootOptions := make([]string, len(dtd.roots))
+	for i, root := range dtd.roots {
+		rootOptions[i] = root.label
 	}
 
 	dtd.radioGroup = widget.NewRadioGroup(rootOptions, func(selected string) {
 		// Prevent deselection by re-clicking - ignore empty selection
 		if selected == "" {
 			// Restore the previous selection
-			if dtd.rootMode {
-				dtd.radioGroup.SetSelected(constants.RootModeOptionText)
-			} else {
-				dtd.radioGroup.SetSelected(constants.ParentModeOptionText)
-			}
+			dtd.radioGroup.SetSelected(dtd.roots[dtd.rootIndex].label)
 			return
 		}
 
-		var newRootMode bool
-		var newCurrentRoot string
-
-		switch selected {
-		case constants.RootModeOptionText:
-			newRootMode = true
-			newCurrentRoot = GetSystemRoot()
-		case constants.ParentModeOptionText:
-			newRootMode = false
-			newCurrentRoot = dtd.parentPath
-		}
-
-		// Only update if the mode is actually changing
-		if dtd.rootMode != newRootMode {
-			dtd.rootMode = newRootMode
-			dtd.currentRoot = newCurrentRoot
-
-			// Refresh tree with new root
-			dtd.tree.Refresh()
-
-			// Expand the root level initially
-			dtd.expandInitialLevel()
+		// Only update if the root is actually changing
+		for i, root := range dtd.roots {
+			if root.label == selected && i != dtd.rootIndex {
+				dtd.setRoot(i)
+			}
 		}
 		if dtd.parent != nil && dtd.sink != nil {
 			dtd.parent.Canvas().Focus(dtd.sink)
 		}
 	})
-	dtd.radioGroup.SetSelected(selectedOption)
+	dtd.radioGroup.SetSelected(dtd.roots[dtd.rootIndex].label)
 	dtd.radioGroup.Horizontal = true
 
 	// Create top control panel
@@ -483,6 +546,10 @@ func (dtd *DirectoryTreeDialog) AcceptSelection() {
 		return
 	}
 	dtd.closed = true
+	for _, load := range dtd.loading {
+		load.cancel()
+	}
+	clear(dtd.loading)
 	dtd.loadApplyMu.Unlock()
 
 	selectedPath := dtd.selectedPath
@@ -506,6 +573,10 @@ func (dtd *DirectoryTreeDialog) CancelDialog() {
 		return
 	}
 	dtd.closed = true
+	for _, load := range dtd.loading {
+		load.cancel()
+	}
+	clear(dtd.loading)
 	dtd.loadApplyMu.Unlock()
 
 	deferDialogClose(dtd.keyManager, "tree.cancel", func() {
@@ -517,30 +588,28 @@ func (dtd *DirectoryTreeDialog) CancelDialog() {
 	})
 }
 
-// ToggleRootMode toggles between root filesystem and parent directory mode
+// ToggleRootMode moves to the next root option: filesystem root, share
+// root when browsing a remote share, then parent directory.
 func (dtd *DirectoryTreeDialog) ToggleRootMode() {
-	dtd.rootMode = !dtd.rootMode
-	if dtd.rootMode {
-		dtd.currentRoot = GetSystemRoot()
-	} else {
-		dtd.currentRoot = dtd.parentPath
-	}
+	dtd.setRoot((dtd.rootIndex + 1) % len(dtd.roots))
 
 	// Update RadioGroup selection to match new mode
 	if dtd.radioGroup != nil {
-		if dtd.rootMode {
-			dtd.radioGroup.SetSelected(constants.RootModeOptionText)
-		} else {
-			dtd.radioGroup.SetSelected(constants.ParentModeOptionText)
-		}
+		dtd.radioGroup.SetSelected(dtd.roots[dtd.rootIndex].label)
 	}
-
-	// Refresh tree with new root
-	dtd.tree.Refresh()
-	dtd.expandInitialLevel()
 	if dtd.parent != nil && dtd.sink != nil {
 		dtd.parent.Canvas().Focus(dtd.sink)
 	}
 
-	dtd.debugPrint("TreeDialog: Toggled root mode to %t (root: %s)", dtd.rootMode, dtd.currentRoot)
+	dtd.debugPrint("TreeDialog: Toggled root mode to %s (root: %s)", dtd.roots[dtd.rootIndex].label, dtd.currentRoot)
+}
+
+// setRoot shows the tree from the root option at index i.
+func (dtd *DirectoryTreeDialog) setRoot(i int) {
+	dtd.rootIndex = i
+	dtd.currentRoot = dtd.roots[i].path
+
+	// Refresh tree with new root
+	dtd.tree.Refresh()
+	dtd.expandInitialLevel()
 }
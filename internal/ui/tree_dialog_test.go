@@ -1,33 +1,40 @@
 package ui
 
 import (
+	"context"
 	"testing"
 	"time"
 
-	fynetest "fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2"
+
+	"nmf/internal/constants"
+	"nmf/internal/keymanager"
+	"nmf/internal/uitest"
 )
 
+// waitForTreeTest runs the load results queued for the uitest app on the
+// test goroutine until condition holds.
 func waitForTreeTest(t *testing.T, condition func() bool) {
 	t.Helper()
-	deadline := time.Now().Add(time.Second)
-	for !condition() {
-		if time.Now().After(deadline) {
-			t.Fatal("timed out waiting for tree state")
-		}
-		time.Sleep(time.Millisecond)
+	app, ok := fyne.CurrentApp().(*uitest.App)
+	if !ok {
+		t.Fatal("tree tests need the uitest app")
+	}
+	if !app.WaitFor(condition, time.Second) {
+		t.Fatal("timed out waiting for tree state")
 	}
 }
 
 func TestDirectoryTreeChildrenLoadDoesNotBlockDataSource(t *testing.T) {
-	app := fynetest.NewApp()
-	defer app.Quit()
+	uitest.NewApp(t)
 
 	started := make(chan struct{})
 	release := make(chan struct{})
 	finished := make(chan struct{})
 	dialog := NewDirectoryTreeDialog("/tmp", nil, func(string, ...interface{}) {})
+	joinTreeLoads(t, &dialog.loadApplyMu, dialog.loading, &dialog.loads)
 	dialog.tree = nil
-	dialog.loadChildren = func(path string) ([]string, error) {
+	dialog.loadChildren = func(_ context.Context, path string) ([]string, error) {
 		if path != "/tmp" {
 			return nil, nil
 		}
@@ -76,12 +83,12 @@ func TestDirectoryTreeChildrenCacheReturnsCopy(t *testing.T) {
 }
 
 func TestDirectoryTreeCachesPlatformBranchClassification(t *testing.T) {
-	app := fynetest.NewApp()
-	defer app.Quit()
+	uitest.NewApp(t)
 	dialog := NewDirectoryTreeDialog("/tmp", nil, func(string, ...interface{}) {})
+	joinTreeLoads(t, &dialog.loadApplyMu, dialog.loading, &dialog.loads)
 	dialog.tree = nil
 	classified := make(chan string, 1)
-	dialog.loadChildren = func(string) ([]string, error) {
+	dialog.loadChildren = func(context.Context, string) ([]string, error) {
 		return []string{"X:\\"}, nil
 	}
 	dialog.classifyBranch = func(path string) (bool, bool) {
@@ -102,3 +109,125 @@ func TestDirectoryTreeCachesPlatformBranchClassification(t *testing.T) {
 	}
 	waitForTreeTest(t, func() bool { return !dialog.isDirectory("X:\\") })
 }
+
+func TestDirectoryTreeStartsAtShareRootOnRemotePaths(t *testing.T) {
+	uitest.NewApp(t)
+
+	dialog := NewDirectoryTreeDialog("smb://server/share/docs/reports", nil, func(string, ...interface{}) {})
+	joinTreeLoads(t, &dialog.loadApplyMu, dialog.loading, &dialog.loads)
+	if dialog.currentRoot != "smb://server/share" {
+		t.Fatalf("root = %q, want smb://server/share", dialog.currentRoot)
+	}
+	var labels []string
+	for _, root := range dialog.roots {
+		labels = append(labels, root.label)
+	}
+	if len(labels) != 3 || labels[1] != constants.ShareModeOptionText {
+		t.Fatalf("root options = %v, want filesystem, share, and parent", labels)
+	}
+	if got := dialog.getDisplayName("smb://server/share"); got != "smb://server/share" {
+		t.Fatalf("share root label = %q", got)
+	}
+
+	dialog.ToggleRootMode()
+	if dialog.currentRoot != "smb://server/share/docs" {
+		t.Fatalf("root after toggle = %q, want parent directory", dialog.currentRoot)
+	}
+	dialog.ToggleRootMode()
+	if dialog.currentRoot != GetSystemRoot() {
+		t.Fatalf("root after second toggle = %q, want %q", dialog.currentRoot, GetSystemRoot())
+	}
+}
+
+func TestDirectoryTreeOffersNoShareRootLocally(t *testing.T) {
+	uitest.NewApp(t)
+
+	dialog := NewDirectoryTreeDialog(t.TempDir(), nil, func(string, ...interface{}) {})
+	joinTreeLoads(t, &dialog.loadApplyMu, dialog.loading, &dialog.loads)
+	if len(dialog.roots) != 2 || dialog.currentRoot != GetSystemRoot() {
+		t.Fatalf("roots = %+v current = %q, want filesystem and parent from %q", dialog.roots, dialog.currentRoot, GetSystemRoot())
+	}
+}
+
+func TestDirectoryTreeShowsPlaceholderWhileLoading(t *testing.T) {
+	uitest.NewApp(t)
+
+	release := make(chan struct{})
+	dialog := NewDirectoryTreeDialog("/tmp", nil, func(string, ...interface{}) {})
+	joinTreeLoads(t, &dialog.loadApplyMu, dialog.loading, &dialog.loads)
+	dialog.loadChildren = func(_ context.Context, path string) ([]string, error) {
+		if path != "/data" {
+			return nil, nil
+		}
+		<-release
+		return []string{"/data/child"}, nil
+	}
+
+	children := dialog.tree.ChildUIDs("/data")
+	if len(children) != 1 || !isTreeLoadingID(children[0]) {
+		t.Fatalf("children while loading = %#v, want the placeholder", children)
+	}
+	if dialog.tree.IsBranch(children[0]) {
+		t.Fatal("placeholder should not be a branch")
+	}
+	close(release)
+	waitForTreeTest(t, func() bool {
+		got := dialog.tree.ChildUIDs("/data")
+		return len(got) == 1 && got[0] == "/data/child"
+	})
+}
+
+func TestDirectoryTreeCollapseCancelsLoad(t *testing.T) {
+	uitest.NewApp(t)
+
+	started := make(chan struct{}, 2)
+	cancelled := make(chan struct{})
+	dialog := NewDirectoryTreeDialog("/tmp", nil, func(string, ...interface{}) {})
+	joinTreeLoads(t, &dialog.loadApplyMu, dialog.loading, &dialog.loads)
+	dialog.loadChildren = func(ctx context.Context, path string) ([]string, error) {
+		if path != "/data/sub" {
+			return nil, nil
+		}
+		started <- struct{}{}
+		<-ctx.Done()
+		close(cancelled)
+		return nil, ctx.Err()
+	}
+
+	dialog.getDirectoryChildren("/data/sub")
+	<-started
+	dialog.tree.OnBranchClosed("/data")
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("collapsing the parent branch did not cancel the load")
+	}
+	if dialog.isLoading("/data/sub") {
+		t.Fatal("cancelled load is still registered")
+	}
+}
+
+func TestDirectoryTreeCloseCancelsLoads(t *testing.T) {
+	uitest.NewApp(t)
+
+	cancelled := make(chan struct{})
+	started := make(chan struct{})
+	dialog := NewDirectoryTreeDialog("/tmp", nil, func(string, ...interface{}) {})
+	joinTreeLoads(t, &dialog.loadApplyMu, dialog.loading, &dialog.loads)
+	dialog.keyManager = keymanager.NewKeyManager(func(string, ...interface{}) {})
+	dialog.loadChildren = func(ctx context.Context, path string) ([]string, error) {
+		close(started)
+		<-ctx.Done()
+		close(cancelled)
+		return nil, ctx.Err()
+	}
+
+	dialog.getDirectoryChildren("/data")
+	<-started
+	dialog.CancelDialog()
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("closing the dialog did not cancel the load")
+	}
+}
@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"context"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// treeLoadingSuffix ends the ID of the placeholder row a branch shows while
+// its children are read for the first time. NUL never appears in a path.
+const treeLoadingSuffix = "\x00loading"
+
+func treeLoadingID(path string) widget.TreeNodeID {
+	return widget.TreeNodeID(path + treeLoadingSuffix)
+}
+
+func isTreeLoadingID(uid widget.TreeNodeID) bool {
+	return strings.HasSuffix(string(uid), treeLoadingSuffix)
+}
+
+// treeLoad is one background child read of a tree branch. Cancelling it
+// stops the read and drops its result; a load that is no longer the one
+// registered for its path applies nothing.
+type treeLoad struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newTreeLoad() *treeLoad {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &treeLoad{ctx: ctx, cancel: cancel}
+}
+
+// cancelTreeLoads cancels and forgets the loads of dir and the directories
+// below it, so a collapsed branch stops reading and reads again when
+// reopened. The caller holds the lock guarding loads.
+func cancelTreeLoads(loads map[string]*treeLoad, dir string) {
+	for path, load := range loads {
+		if isTreePathWithin(path, dir) {
+			load.cancel()
+			delete(loads, path)
+		}
+	}
+}
+
+// isTreePathWithin reports whether path is dir or lies below it.
+func isTreePathWithin(path, dir string) bool {
+	for {
+		if path == dir {
+			return true
+		}
+		parent := GetPlatformParent(path)
+		if parent == "" || parent == path {
+			return false
+		}
+		path = parent
+	}
+}
+
+// setTreeNodeContent fills a tree row holding a folder icon, an activity
+// indicator, and a label: placeholder rows spin instead of showing the icon.
+func setTreeNodeContent(obj fyne.CanvasObject, text string, loading bool) {
+	c, ok := obj.(*fyne.Container)
+	if !ok {
+		return
+	}
+	for _, o := range c.Objects {
+		switch o := o.(type) {
+		case *widget.Icon:
+			if loading {
+				o.Hide()
+			} else {
+				o.Show()
+			}
+		case *widget.Activity:
+			if loading {
+				o.Show()
+				o.Start()
+			} else {
+				o.Stop()
+				o.Hide()
+			}
+		case *widget.Label:
+			o.SetText(text)
+		case *fyne.Container:
+			setTreeNodeContent(o, text, loading)
+		}
+	}
+}
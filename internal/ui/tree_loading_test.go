@@ -0,0 +1,20 @@
+package ui
+
+import (
+	"sync"
+	"testing"
+)
+
+// joinTreeLoads cancels the loads still registered in loading when the test
+// ends and waits for every reading goroutine in loads, so none of them
+// queues work for a later test. mu guards loading.
+func joinTreeLoads(t *testing.T, mu *sync.Mutex, loading map[string]*treeLoad, loads *sync.WaitGroup) {
+	t.Cleanup(func() {
+		mu.Lock()
+		for _, load := range loading {
+			load.cancel()
+		}
+		mu.Unlock()
+		loads.Wait()
+	})
+}
@@ -0,0 +1,249 @@
+package ui
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// DirectoryTreePane shows the directory tree beside the list. The owner
+// pushes the listed path with SetCurrentPath; the pane opens the branches
+// down to it and selects it, and clicking another directory asks the owner
+// to navigate there. Children are read in the background the first time a
+// branch is shown and reread when RefreshDirectory reports a change.
+type DirectoryTreePane struct {
+	tree       *widget.Tree
+	pane       *fyne.Container
+	current    string            // Path of the listed directory
+	chainNext  map[string]string // Ancestor of current -> its child on the way to current
+	syncing    bool              // Set while the pane selects current itself
+	onNavigate func(string)
+	debugPrint func(format string, args ...interface{})
+
+	mu           sync.Mutex
+	children     map[string][]string
+	loading      map[string]*treeLoad
+	loads        sync.WaitGroup  // Reading goroutines of loading
+	stale        map[string]bool // Reload requested while a load was running
+	loadChildren func(context.Context, string) ([]string, error)
+}
+
+// NewDirectoryTreePane creates a hidden pane at least width wide. onNavigate
+// receives the directory the user picks.
+func NewDirectoryTreePane(width float32, onNavigate func(string), debugPrint func(format string, args ...interface{})) *DirectoryTreePane {
+	if debugPrint == nil {
+		debugPrint = func(string, ...interface{}) {}
+	}
+	p := &DirectoryTreePane{
+		chainNext:    make(map[string]string),
+		onNavigate:   onNavigate,
+		debugPrint:   debugPrint,
+		children:     make(map[string][]string),
+		loading:      make(map[string]*treeLoad),
+		stale:        make(map[string]bool),
+		loadChildren: readTreeChildren,
+	}
+	p.tree = widget.NewTree(
+		func(uid widget.TreeNodeID) []widget.TreeNodeID {
+			if isTreeLoadingID(uid) {
+				return nil
+			}
+			children := p.directoryChildren(string(uid))
+			result := make([]widget.TreeNodeID, len(children), len(children)+1)
+			for i, child := range children {
+				result[i] = widget.TreeNodeID(child)
+			}
+			if p.isLoading(string(uid)) {
+				result = append(result, treeLoadingID(string(uid)))
+			}
+			return result
+		},
+		func(uid widget.TreeNodeID) bool {
+			return uid != "" && !isTreeLoadingID(uid)
+		},
+		func(branch bool) fyne.CanvasObject {
+			icon := widget.NewIcon(theme.FolderIcon())
+			activity := widget.NewActivity()
+			activity.Hide()
+			label := widget.NewLabel("Directory")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return container.NewBorder(nil, nil, container.NewStack(icon, activity), nil, label)
+		},
+		func(uid widget.TreeNodeID, branch bool, obj fyne.CanvasObject) {
+			if isTreeLoadingID(uid) {
+				setTreeNodeContent(obj, "Loading...", true)
+				return
+			}
+			setTreeNodeContent(obj, treeDisplayName(string(uid)), false)
+		},
+	)
+	p.tree.OnBranchClosed = func(uid widget.TreeNodeID) {
+		p.mu.Lock()
+		cancelTreeLoads(p.loading, string(uid))
+		p.mu.Unlock()
+	}
+	p.tree.OnSelected = func(uid widget.TreeNodeID) {
+		if isTreeLoadingID(uid) {
+			p.tree.Unselect(uid)
+			return
+		}
+		path := string(uid)
+		if p.syncing || path == p.current {
+			return
+		}
+		p.debugPrint("TreePane: Directory selected: %s", path)
+		if p.onNavigate != nil {
+			p.onNavigate(path)
+		}
+	}
+
+	spacer := canvas.NewRectangle(nil)
+	spacer.SetMinSize(fyne.NewSize(width, 0))
+	p.pane = container.NewBorder(nil, nil, nil, widget.NewSeparator(), container.NewStack(spacer, p.tree))
+	p.pane.Hide()
+	return p
+}
+
+func (p *DirectoryTreePane) Container() fyne.CanvasObject {
+	return p.pane
+}
+
+// CurrentPath returns the directory the pane is synced to.
+func (p *DirectoryTreePane) CurrentPath() string {
+	return p.current
+}
+
+// SetCurrentPath opens the branches from the top of path's hierarchy down to
+// path and selects it. Its children are reread so a directory revisited
+// since it was last expanded shows what the list shows.
+func (p *DirectoryTreePane) SetCurrentPath(path string) {
+	if path == "" {
+		return
+	}
+	chain := []string{path}
+	for dir := path; ; {
+		parent := GetPlatformParent(dir)
+		if parent == "" || parent == dir {
+			break
+		}
+		chain = append(chain, parent)
+		dir = parent
+	}
+
+	p.current = path
+	p.chainNext = make(map[string]string, len(chain))
+	for i := len(chain) - 1; i > 0; i-- {
+		p.chainNext[chain[i]] = chain[i-1]
+	}
+	p.tree.Root = widget.TreeNodeID(chain[len(chain)-1])
+	for i := len(chain) - 1; i > 0; i-- {
+		p.tree.OpenBranch(widget.TreeNodeID(chain[i]))
+	}
+	p.RefreshDirectory(path)
+
+	p.syncing = true
+	p.tree.Select(widget.TreeNodeID(path))
+	p.syncing = false
+	p.tree.ScrollTo(widget.TreeNodeID(path))
+	p.tree.Refresh()
+}
+
+// RefreshDirectory rereads the children of dir if the pane has read them
+// before. Directories never shown are read when they are first needed.
+func (p *DirectoryTreePane) RefreshDirectory(dir string) {
+	p.mu.Lock()
+	_, cached := p.children[dir]
+	if !cached {
+		p.mu.Unlock()
+		return
+	}
+	if p.loading[dir] != nil {
+		p.stale[dir] = true
+		p.mu.Unlock()
+		return
+	}
+	p.mu.Unlock()
+	p.load(dir)
+}
+
+// isLoading reports whether the children of path are being read for the
+// first time.
+func (p *DirectoryTreePane) isLoading(path string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, cached := p.children[path]
+	return !cached && p.loading[path] != nil
+}
+
+// directoryChildren returns the cached children of path, starting a
+// background read on first use. The child on the way to the current path is
+// always included, so hidden directories and not yet read branches still
+// lead to it.
+func (p *DirectoryTreePane) directoryChildren(path string) []string {
+	p.mu.Lock()
+	children, cached := p.children[path]
+	children = append([]string(nil), children...)
+	start := !cached && p.loading[path] == nil
+	p.mu.Unlock()
+	if start {
+		p.load(path)
+	}
+
+	next, ok := p.chainNext[path]
+	if !ok {
+		return children
+	}
+	i := sort.SearchStrings(children, next)
+	if i < len(children) && children[i] == next {
+		return children
+	}
+	return append(children[:i], append([]string{next}, children[i:]...)...)
+}
+
+// load reads dir's children off the UI thread and applies them on it.
+// Collapsing dir cancels the read.
+func (p *DirectoryTreePane) load(dir string) {
+	load := newTreeLoad()
+	p.mu.Lock()
+	p.loading[dir] = load
+	loader := p.loadChildren
+	p.mu.Unlock()
+	p.loads.Go(func() {
+		children, err := loader(load.ctx, dir)
+		dirs := children[:0]
+		for _, child := range children {
+			if isDir, handled := IsPlatformDirectory(child); !handled || isDir {
+				dirs = append(dirs, child)
+			}
+		}
+		children = dirs
+		fyne.Do(func() {
+			p.mu.Lock()
+			if p.loading[dir] != load {
+				p.mu.Unlock()
+				return
+			}
+			delete(p.loading, dir)
+			load.cancel()
+			if err != nil {
+				p.debugPrint("TreePane: Error reading directory %s: %v", dir, err)
+				children = nil
+			}
+			sort.Strings(children)
+			p.children[dir] = children
+			again := p.stale[dir]
+			delete(p.stale, dir)
+			p.mu.Unlock()
+			p.tree.Refresh()
+			if again {
+				p.load(dir)
+			}
+		})
+	})
+}
@@ -0,0 +1,122 @@
+//go:build !windows
+
+package ui
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/widget"
+
+	"nmf/internal/uitest"
+)
+
+func TestDirectoryTreePaneSyncOpensAncestorsAndSelects(t *testing.T) {
+	uitest.NewApp(t)
+
+	var navigated []string
+	pane := NewDirectoryTreePane(200, func(path string) { navigated = append(navigated, path) }, nil)
+	joinTreeLoads(t, &pane.mu, pane.loading, &pane.loads)
+	pane.loadChildren = func(context.Context, string) ([]string, error) { return nil, nil }
+
+	pane.SetCurrentPath("/home/user/.config")
+
+	if pane.tree.Root != "/" {
+		t.Fatalf("root = %q, want /", pane.tree.Root)
+	}
+	for _, dir := range []string{"/", "/home", "/home/user"} {
+		if !pane.tree.IsBranchOpen(widget.TreeNodeID(dir)) {
+			t.Fatalf("branch %s is closed, want open", dir)
+		}
+	}
+	// The hidden directory is listed even though the loader skips it.
+	waitForTreeTest(t, func() bool {
+		children := pane.directoryChildren("/home/user")
+		return len(children) == 1 && children[0] == "/home/user/.config"
+	})
+	if len(navigated) != 0 {
+		t.Fatalf("syncing navigated to %v, want nothing", navigated)
+	}
+}
+
+func TestDirectoryTreePaneSelectionNavigates(t *testing.T) {
+	uitest.NewApp(t)
+
+	var navigated []string
+	pane := NewDirectoryTreePane(200, func(path string) { navigated = append(navigated, path) }, nil)
+	joinTreeLoads(t, &pane.mu, pane.loading, &pane.loads)
+	pane.loadChildren = func(context.Context, string) ([]string, error) { return nil, nil }
+	pane.SetCurrentPath("/tmp")
+
+	pane.tree.Select("/tmp")
+	pane.tree.Select("/var")
+
+	if len(navigated) != 1 || navigated[0] != "/var" {
+		t.Fatalf("navigated = %v, want [/var]", navigated)
+	}
+}
+
+func TestDirectoryTreePaneRefreshRereadsCachedDirectory(t *testing.T) {
+	uitest.NewApp(t)
+
+	var mu sync.Mutex
+	listing := []string{"/data/a"}
+	pane := NewDirectoryTreePane(200, nil, nil)
+	joinTreeLoads(t, &pane.mu, pane.loading, &pane.loads)
+	pane.loadChildren = func(_ context.Context, path string) ([]string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if path != "/data" {
+			return nil, nil
+		}
+		return append([]string(nil), listing...), nil
+	}
+
+	pane.RefreshDirectory("/data")
+	pane.directoryChildren("/data")
+	waitForTreeTest(t, func() bool { return len(pane.directoryChildren("/data")) == 1 })
+
+	mu.Lock()
+	listing = []string{"/data/b", "/data/a"}
+	mu.Unlock()
+	pane.RefreshDirectory("/data")
+	waitForTreeTest(t, func() bool {
+		children := pane.directoryChildren("/data")
+		return len(children) == 2 && children[0] == "/data/a" && children[1] == "/data/b"
+	})
+}
+
+func TestDirectoryTreePaneCollapseCancelsLoad(t *testing.T) {
+	uitest.NewApp(t)
+
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	pane := NewDirectoryTreePane(200, nil, nil)
+	joinTreeLoads(t, &pane.mu, pane.loading, &pane.loads)
+	pane.loadChildren = func(ctx context.Context, path string) ([]string, error) {
+		if path != "/slow" {
+			return nil, nil
+		}
+		close(started)
+		<-ctx.Done()
+		close(cancelled)
+		return nil, ctx.Err()
+	}
+
+	children := pane.tree.ChildUIDs("/slow")
+	if len(children) != 1 || !isTreeLoadingID(children[0]) {
+		t.Fatalf("children while loading = %#v, want the placeholder", children)
+	}
+	<-started
+	pane.tree.OnBranchClosed("/slow")
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("collapsing the branch did not cancel the load")
+	}
+	if pane.isLoading("/slow") {
+		t.Fatal("cancelled load is still registered")
+	}
+}
@@ -4,8 +4,6 @@
 package ui
 
 import (
-	"path/filepath"
-
 	"nmf/internal/fileinfo"
 )
 
@@ -37,10 +35,7 @@ func IsPlatformDirectory(path string) (bool, bool) {
 	return false, false
 }
 
-// GetPlatformParent returns the parent path using standard filepath.Dir on Unix
+// GetPlatformParent returns the parent path; Unix needs no special roots.
 func GetPlatformParent(path string) string {
-	if fileinfo.IsSMBDisplay(path) {
-		return fileinfo.ParentPath(path)
-	}
-	return filepath.Dir(path)
+	return fileinfo.ParentPath(path)
 }
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"image/color"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
@@ -13,20 +14,27 @@ import (
 // TappableIcon is a custom icon widget that can handle tap events
 type TappableIcon struct {
 	widget.BaseWidget
-	icon      *widget.Icon
-	onTapped  func()
-	onDragged func()
-	dragging  bool
-	pressed   bool
-	pressPos  fyne.Position
+	icon              *widget.Icon
+	onTapped          func()
+	onTappedSecondary func(*fyne.PointEvent)
+	onDragged         func()
+	dragging          bool
+	pressed           bool
+	pressPos          fyne.Position
+	loading           bool
+	placeholder       *canvas.Rectangle
+	pulse             *fyne.Animation
 }
 
 // NewTappableIcon creates a new tappable icon widget
 func NewTappableIcon(resource fyne.Resource, onTapped func()) *TappableIcon {
 	icon := widget.NewIcon(resource)
+	placeholder := canvas.NewRectangle(color.Transparent)
+	placeholder.CornerRadius = theme.InputRadiusSize()
 	ti := &TappableIcon{
-		icon:     icon,
-		onTapped: onTapped,
+		icon:        icon,
+		onTapped:    onTapped,
+		placeholder: placeholder,
 	}
 	ti.ExtendBaseWidget(ti)
 	return ti
@@ -39,6 +47,13 @@ func (ti *TappableIcon) Tapped(_ *fyne.PointEvent) {
 	}
 }
 
+// TappedSecondary handles right-click events on the icon.
+func (ti *TappableIcon) TappedSecondary(ev *fyne.PointEvent) {
+	if ti.onTappedSecondary != nil {
+		ti.onTappedSecondary(ev)
+	}
+}
+
 // MouseDown records the initial press for native file drag startup.
 func (ti *TappableIcon) MouseDown(ev *desktop.MouseEvent) {
 	if ev.Button != desktop.MouseButtonPrimary {
@@ -99,6 +114,11 @@ func (ti *TappableIcon) SetOnTapped(onTapped func()) {
 	ti.onTapped = onTapped
 }
 
+// SetOnTappedSecondary sets the right-click handler function.
+func (ti *TappableIcon) SetOnTappedSecondary(onTappedSecondary func(*fyne.PointEvent)) {
+	ti.onTappedSecondary = onTappedSecondary
+}
+
 // SetOnDragged sets the callback invoked when a drag starts.
 func (ti *TappableIcon) SetOnDragged(onDragged func()) {
 	ti.onDragged = onDragged
@@ -106,25 +126,93 @@ func (ti *TappableIcon) SetOnDragged(onDragged func()) {
 	ti.pressed = false
 }
 
+// SetLoading replaces the icon with a softly pulsing placeholder while the
+// real icon is still being fetched, and restores it when loading is false.
+func (ti *TappableIcon) SetLoading(loading bool) {
+	if ti.loading == loading {
+		return
+	}
+	ti.loading = loading
+	if loading {
+		base := theme.Color(theme.ColorNameDisabled)
+		r, g, b, _ := base.RGBA()
+		dim := color.NRGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: 0x20}
+		bright := dim
+		bright.A = 0x60
+		ti.placeholder.FillColor = dim
+		ti.pulse = canvas.NewColorRGBAAnimation(dim, bright, 700*time.Millisecond, func(c color.Color) {
+			ti.placeholder.FillColor = c
+			ti.placeholder.Refresh()
+		})
+		ti.pulse.AutoReverse = true
+		ti.pulse.RepeatCount = fyne.AnimationRepeatForever
+		ti.pulse.Start()
+	} else if ti.pulse != nil {
+		ti.pulse.Stop()
+		ti.pulse = nil
+	}
+	ti.Refresh()
+}
+
+// IsLoading reports whether the loading placeholder is shown.
+func (ti *TappableIcon) IsLoading() bool {
+	return ti.loading
+}
+
 // CreateRenderer creates the widget renderer
 func (ti *TappableIcon) CreateRenderer() fyne.WidgetRenderer {
-	return widget.NewSimpleRenderer(ti.icon)
+	r := &tappableIconRenderer{icon: ti}
+	r.Refresh()
+	return r
+}
+
+type tappableIconRenderer struct {
+	icon *TappableIcon
+}
+
+func (r *tappableIconRenderer) Layout(size fyne.Size) {
+	r.icon.icon.Resize(size)
+	inset := size.Height / 8
+	r.icon.placeholder.Move(fyne.NewPos(inset, inset))
+	r.icon.placeholder.Resize(size.SubtractWidthHeight(2*inset, 2*inset))
+}
+
+func (r *tappableIconRenderer) MinSize() fyne.Size {
+	return r.icon.icon.MinSize()
+}
+
+func (r *tappableIconRenderer) Refresh() {
+	r.icon.icon.Hidden = r.icon.loading
+	r.icon.placeholder.Hidden = !r.icon.loading
+	r.icon.icon.Refresh()
+	r.icon.placeholder.Refresh()
+}
+
+func (r *tappableIconRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.icon.icon, r.icon.placeholder}
+}
+
+func (r *tappableIconRenderer) Destroy() {
+	if r.icon.pulse != nil {
+		r.icon.pulse.Stop()
+	}
 }
 
 // FileNameLabel draws a file name that shrinks to its assigned width.
 type FileNameLabel struct {
 	widget.BaseWidget
-	name          string
-	color         color.RGBA
-	deleted       bool
-	text          *canvas.Text
-	onTapped      func(fyne.KeyModifier)
-	onDragged     func()
-	dragging      bool
-	pressed       bool
-	suppressTap   bool
-	pressPos      fyne.Position
-	pressModifier fyne.KeyModifier
+	name              string
+	color             color.RGBA
+	deleted           bool
+	text              *canvas.Text
+	onTapped          func(fyne.KeyModifier)
+	onTappedSecondary func(*fyne.PointEvent)
+	onDragged         func()
+	dragging          bool
+	pressed           bool
+	suppressTap       bool
+	pressPos          fyne.Position
+	pressModifier     fyne.KeyModifier
 }
 
 func NewFileNameLabel(name string, textColor color.RGBA) *FileNameLabel {
@@ -159,6 +247,13 @@ func (l *FileNameLabel) Tapped(_ *fyne.PointEvent) {
 	}
 }
 
+// TappedSecondary handles right-click actions on the file name area.
+func (l *FileNameLabel) TappedSecondary(ev *fyne.PointEvent) {
+	if l.onTappedSecondary != nil {
+		l.onTappedSecondary(ev)
+	}
+}
+
 // MouseDown records the initial press for click modifiers and drag startup.
 func (l *FileNameLabel) MouseDown(ev *desktop.MouseEvent) {
 	if ev.Button != desktop.MouseButtonPrimary {
@@ -217,6 +312,12 @@ func (l *FileNameLabel) SetOnTapped(onTapped func(fyne.KeyModifier)) {
 	l.suppressTap = false
 }
 
+// SetOnTappedSecondary sets the callback invoked when the file name is
+// right-clicked.
+func (l *FileNameLabel) SetOnTappedSecondary(onTappedSecondary func(*fyne.PointEvent)) {
+	l.onTappedSecondary = onTappedSecondary
+}
+
 // SetOnDragged sets the callback invoked when a drag starts.
 func (l *FileNameLabel) SetOnDragged(onDragged func()) {
 	l.onDragged = onDragged
@@ -8,6 +8,7 @@ import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
 )
 
 func TestFileNameLabelMinSizeDoesNotUseFullNameWidth(t *testing.T) {
@@ -146,3 +147,24 @@ func TestTappableIconMouseMovedStartsDragAfterThreshold(t *testing.T) {
 		t.Fatalf("drag calls = %d, want 2 after axis threshold", calls)
 	}
 }
+
+func TestTappableIconLoadingSwapsIconForPlaceholder(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	icon := NewTappableIcon(theme.FileIcon(), nil)
+	renderer := test.WidgetRenderer(icon)
+	icon.SetLoading(true)
+	if !icon.IsLoading() || icon.icon.Visible() || !icon.placeholder.Visible() {
+		t.Fatal("loading icon should show only the placeholder")
+	}
+	if icon.pulse == nil {
+		t.Fatal("loading placeholder should pulse")
+	}
+
+	icon.SetLoading(false)
+	renderer.Refresh()
+	if icon.IsLoading() || !icon.icon.Visible() || icon.placeholder.Visible() || icon.pulse != nil {
+		t.Fatal("finished icon should show the icon and stop the pulse")
+	}
+}
@@ -0,0 +1,170 @@
+// Package uitest provides a Fyne test app whose fyne.Do callbacks run on the
+// test goroutine, for tests whose background work reports back through
+// fyne.Do or fyne.DoAndWait.
+package uitest
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+)
+
+// pollInterval is how often WaitFor rechecks a condition that may change
+// without a callback, such as a job finishing or a file appearing.
+const pollInterval = 5 * time.Millisecond
+
+// App is the Fyne test app with fyne.Do and fyne.DoAndWait callbacks queued
+// until the test goroutine pumps them. The plain test driver runs them on
+// the calling goroutine, which lets background work touch widgets while the
+// test does; queued, they run on the test goroutine, which drives the
+// widgets as the main thread would. The test goroutine must therefore not
+// call fyne.DoAndWait itself: nothing would pump the callback it waits for.
+type App struct {
+	fyne.App
+	driver *driver
+}
+
+// NewApp makes the current Fyne app a test app whose callbacks wait for
+// Pump. When the test ends it is stopped and the plain test app it wraps
+// becomes current again.
+func NewApp(t testing.TB) *App {
+	t.Helper()
+	base := test.NewApp()
+	a := &App{
+		App: base,
+		driver: &driver{
+			Driver:  base.Driver(),
+			wake:    make(chan struct{}, 1),
+			stopped: make(chan struct{}),
+		},
+	}
+	fyne.SetCurrentApp(a)
+	t.Cleanup(func() {
+		a.Stop()
+		base.Quit()
+		// Later tests that make no app of their own get the plain one.
+		fyne.SetCurrentApp(base)
+	})
+	return a
+}
+
+// Driver returns the queueing driver.
+func (a *App) Driver() fyne.Driver { return a.driver }
+
+// Pump runs the queued callbacks on the calling goroutine, including those
+// they queue in turn.
+func (a *App) Pump() {
+	for {
+		queued := a.driver.take()
+		if len(queued) == 0 {
+			return
+		}
+		for _, fn := range queued {
+			fn()
+		}
+	}
+}
+
+// WaitFor pumps until cond, called on the test goroutine after each pump,
+// holds, and reports false if it still does not once timeout passed.
+func (a *App) WaitFor(cond func() bool, timeout time.Duration) bool {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	poll := time.NewTicker(pollInterval)
+	defer poll.Stop()
+	for {
+		a.Pump()
+		if cond() {
+			return true
+		}
+		select {
+		case <-a.driver.wake:
+		case <-poll.C:
+		case <-deadline.C:
+			a.Pump()
+			return cond()
+		}
+	}
+}
+
+// PumpWhile runs wait on another goroutine and pumps until it returns, so
+// goroutines that wait joins can finish a fyne.DoAndWait on the way out.
+func (a *App) PumpWhile(wait func()) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		wait()
+	}()
+	for {
+		a.Pump()
+		select {
+		case <-done:
+			a.Pump()
+			return
+		case <-a.driver.wake:
+		}
+	}
+}
+
+// Stop drops the queued callbacks and every later one, as a quit app drops
+// them, and releases callers blocked in fyne.DoAndWait. It is safe to call
+// more than once.
+func (a *App) Stop() {
+	d := a.driver
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	select {
+	case <-d.stopped:
+	default:
+		close(d.stopped)
+	}
+	d.queued = nil
+}
+
+type driver struct {
+	fyne.Driver
+
+	mu      sync.Mutex
+	queued  []func()
+	wake    chan struct{} // Signaled when a callback is queued
+	stopped chan struct{}
+}
+
+// DoFromGoroutine queues fn for the next pump; with wait set, the caller
+// blocks until a pump ran it or the app stopped.
+func (d *driver) DoFromGoroutine(fn func(), wait bool) {
+	done := make(chan struct{})
+	d.mu.Lock()
+	select {
+	case <-d.stopped:
+		d.mu.Unlock()
+		return
+	default:
+	}
+	d.queued = append(d.queued, func() {
+		defer close(done)
+		fn()
+	})
+	d.mu.Unlock()
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+	if wait {
+		select {
+		case <-done:
+		case <-d.stopped:
+		}
+	}
+}
+
+func (d *driver) take() []func() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	queued := d.queued
+	d.queued = nil
+	return queued
+}
@@ -0,0 +1,72 @@
+package uitest
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+func TestCallbacksRunOnlyWhenPumped(t *testing.T) {
+	app := NewApp(t)
+
+	ran := false
+	queued := make(chan struct{})
+	go func() {
+		fyne.Do(func() { ran = true })
+		close(queued)
+	}()
+	<-queued
+	if ran {
+		t.Fatal("callback ran before the pump")
+	}
+	app.Pump()
+	if !ran {
+		t.Fatal("callback did not run in the pump")
+	}
+}
+
+func TestWaitForPumpsDoAndWait(t *testing.T) {
+	app := NewApp(t)
+
+	count := 0
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fyne.DoAndWait(func() {
+			count++
+			fyne.Do(func() { count++ })
+		})
+	}()
+	if !app.WaitFor(func() bool { return count == 2 }, time.Second) {
+		t.Fatalf("count = %d, want the callback and the one it queued", count)
+	}
+	<-done
+}
+
+func TestPumpWhileServesTheGoroutinesItJoins(t *testing.T) {
+	app := NewApp(t)
+
+	ran := false
+	app.PumpWhile(func() {
+		fyne.DoAndWait(func() { ran = true })
+	})
+	if !ran {
+		t.Fatal("PumpWhile returned before the callback ran")
+	}
+}
+
+func TestStopReleasesWaitersAndDropsCallbacks(t *testing.T) {
+	app := NewApp(t)
+
+	returned := make(chan struct{})
+	go func() {
+		defer close(returned)
+		fyne.DoAndWait(func() { t.Error("callback ran after Stop") })
+	}()
+	<-app.driver.wake
+	app.Stop()
+	<-returned
+	fyne.Do(func() { t.Error("callback queued after Stop ran") })
+	app.Pump()
+}
@@ -0,0 +1,201 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+)
+
+// runCommand runs name with args, feeding stdin, and returns its combined
+// output. Tests replace it.
+var runCommand = func(ctx context.Context, stdin string, name string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	return out.Bytes(), err
+}
+
+// exitCode returns the process exit code carried by err, or -1.
+func exitCode(err error) int {
+	var coder interface{ ExitCode() int }
+	if errors.As(err, &coder) {
+		return coder.ExitCode()
+	}
+	return -1
+}
+
+func toolError(tool string, out []byte, err error) error {
+	if msg := strings.TrimSpace(string(out)); msg != "" {
+		return fmt.Errorf("%s: %s: %w", tool, msg, err)
+	}
+	return fmt.Errorf("%s: %w", tool, err)
+}
+
+// gocryptfsWrongPassword is the exit code gocryptfs uses for a rejected
+// password.
+const gocryptfsWrongPassword = 12
+
+// mountGocryptfs mounts cipher on plain. gocryptfs reads the password from
+// stdin when it is not a terminal and returns once the mount is ready.
+func mountGocryptfs(ctx context.Context, cipher, plain, password string) error {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		return fmt.Errorf("gocryptfs: %w", ErrUnsupported)
+	}
+	out, err := runCommand(ctx, password+"\n", "gocryptfs", "-q", "--", cipher, plain)
+	if err == nil {
+		return nil
+	}
+	if exitCode(err) == gocryptfsWrongPassword {
+		return ErrWrongPassword
+	}
+	return toolError("gocryptfs", out, err)
+}
+
+func unmountGocryptfs(plain string) error {
+	name, args := "fusermount", []string{"-u", plain}
+	if runtime.GOOS == "darwin" {
+		name, args = "umount", []string{plain}
+	}
+	if out, err := runCommand(context.Background(), "", name, args...); err != nil {
+		return toolError(name, out, err)
+	}
+	return nil
+}
+
+// ageRecipientsFile names the recipients an age vault is encrypted to. It
+// marks the directory as a vault and is what locking encrypts back to.
+const ageRecipientsFile = ".age-recipients"
+
+// ageEntry is a plaintext entry as decryptAge left it, so encryptAge can
+// tell what changed while the vault was unlocked.
+type ageEntry struct {
+	dir     bool
+	size    int64
+	modTime time.Time
+}
+
+// decryptAge decrypts every *.age file below cipher into the same relative
+// path below plain, without the suffix, and returns the entries it wrote by
+// plaintext-relative path. The password is the age identity (an
+// AGE-SECRET-KEY line), passed on stdin.
+func decryptAge(ctx context.Context, cipher, plain, identity string) (map[string]ageEntry, error) {
+	entries := make(map[string]ageEntry)
+	err := filepath.WalkDir(cipher, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(cipher, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if rel == "." {
+				return nil
+			}
+			entries[rel] = ageEntry{dir: true}
+			return os.Mkdir(filepath.Join(plain, rel), 0700)
+		}
+		if !d.Type().IsRegular() || !strings.HasSuffix(d.Name(), ".age") {
+			return nil
+		}
+		rel = strings.TrimSuffix(rel, ".age")
+		dst := filepath.Join(plain, rel)
+		out, err := runCommand(ctx, identity+"\n", "age", "-d", "-i", "-", "-o", dst, path)
+		if err != nil {
+			// Both an identity that does not match and one that does not
+			// parse are reported as identity errors.
+			if strings.Contains(strings.ToLower(string(out)), "identit") {
+				return ErrWrongPassword
+			}
+			return toolError("age", out, err)
+		}
+		if err := os.Chmod(dst, 0600); err != nil {
+			return err
+		}
+		fi, err := os.Stat(dst)
+		if err != nil {
+			return err
+		}
+		entries[rel] = ageEntry{size: fi.Size(), modTime: fi.ModTime()}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// encryptAge writes the changes made below plain since decryptAge back to
+// cipher: new and modified files are encrypted to the vault's recipients,
+// and the *.age files and directories of removed entries are deleted. Each
+// file is encrypted next to its target and renamed over it, so a failure
+// leaves the previous ciphertext in place. Symlinks and other special files
+// are not carried over.
+func encryptAge(ctx context.Context, cipher, plain string, decrypted map[string]ageEntry) error {
+	recipients := filepath.Join(cipher, ageRecipientsFile)
+	seen := make(map[string]bool, len(decrypted))
+	err := filepath.WalkDir(plain, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(plain, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		seen[rel] = true
+		if d.IsDir() {
+			return os.MkdirAll(filepath.Join(cipher, rel), 0700)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if old, ok := decrypted[rel]; ok && !old.dir && old.size == fi.Size() && old.modTime.Equal(fi.ModTime()) {
+			return nil
+		}
+		dst := filepath.Join(cipher, rel+".age")
+		tmp := dst + ".tmp"
+		if out, err := runCommand(ctx, "", "age", "-e", "-R", recipients, "-o", tmp, path); err != nil {
+			os.Remove(tmp)
+			return toolError("age", out, err)
+		}
+		return os.Rename(tmp, dst)
+	})
+	if err != nil {
+		return err
+	}
+	// Deepest first, so directories are empty by the time they are removed.
+	removed := make([]string, 0, len(decrypted))
+	for rel := range decrypted {
+		if !seen[rel] {
+			removed = append(removed, rel)
+		}
+	}
+	slices.Sort(removed)
+	slices.Reverse(removed)
+	for _, rel := range removed {
+		target := filepath.Join(cipher, rel)
+		if !decrypted[rel].dir {
+			target += ".age"
+		}
+		if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
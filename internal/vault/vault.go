@@ -0,0 +1,419 @@
+// Package vault unlocks encrypted directories (gocryptfs and age) into a
+// private plaintext directory that the local provider browses, and locks
+// them again on request, when idle, or when the application exits.
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"nmf/internal/secret"
+)
+
+// Kind names an encrypted directory format.
+type Kind string
+
+const (
+	KindNone      Kind = ""
+	KindGocryptfs Kind = "gocryptfs"
+	KindAge       Kind = "age"
+)
+
+// SecretHost is the secret store host under which vault passwords are kept;
+// the share is the cipher directory path.
+const SecretHost = "vault"
+
+var (
+	// ErrWrongPassword reports that the tool rejected the password or
+	// identity.
+	ErrWrongPassword = errors.New("wrong vault password")
+	// ErrUnsupported reports a vault format that cannot be opened on this
+	// platform.
+	ErrUnsupported = errors.New("vault format is not supported on this platform")
+)
+
+// Detect reports the vault format of the local directory dir, or KindNone.
+// A gocryptfs vault carries gocryptfs.conf; an age vault carries the
+// .age-recipients file its files were encrypted to.
+func Detect(dir string) Kind {
+	if fileExists(filepath.Join(dir, "gocryptfs.conf")) {
+		return KindGocryptfs
+	}
+	if fileExists(filepath.Join(dir, ageRecipientsFile)) {
+		return KindAge
+	}
+	return KindNone
+}
+
+func fileExists(path string) bool {
+	fi, err := os.Stat(path)
+	return err == nil && fi.Mode().IsRegular()
+}
+
+// PasswordRequest describes an interactive vault password prompt.
+type PasswordRequest struct {
+	Path  string // cipher directory
+	Kind  Kind
+	Retry bool // the previous answer was rejected
+}
+
+// Options configures a Manager.
+type Options struct {
+	// IdleTimeout locks a vault no window has shown for this long; zero
+	// keeps vaults unlocked until Lock or Close.
+	IdleTimeout time.Duration
+	// SavePasswords stores accepted passwords in Store.
+	SavePasswords bool
+	Store         secret.Store
+	// Prompt asks the user for a password.
+	Prompt func(context.Context, PasswordRequest) (string, error)
+	// InUse returns the paths windows currently show; vaults containing
+	// one of them are never locked for being idle.
+	InUse  func() []string
+	Debugf func(format string, args ...interface{})
+}
+
+type unlocked struct {
+	kind     Kind
+	cipher   string
+	plain    string
+	lastUsed time.Time
+	age      map[string]ageEntry // What an age vault decrypted, for locking
+}
+
+// Manager tracks unlocked vaults. It is safe for concurrent use.
+type Manager struct {
+	unlockMu sync.Mutex // serializes unlocking, prompts, and locking
+	mu       sync.Mutex
+	opts     Options
+	vaults   map[string]*unlocked // keyed by cipher directory
+	stop     chan struct{}
+}
+
+// NewManager returns a Manager with no unlocked vaults and no idle timer.
+func NewManager() *Manager {
+	return &Manager{vaults: make(map[string]*unlocked)}
+}
+
+// Configure replaces the options and restarts the idle timer.
+func (m *Manager) Configure(opts Options) {
+	m.stopIdleTimer()
+	m.mu.Lock()
+	m.opts = opts
+	m.mu.Unlock()
+	if opts.IdleTimeout > 0 {
+		m.startIdleTimer(idleCheckInterval(opts.IdleTimeout))
+	}
+}
+
+func idleCheckInterval(timeout time.Duration) time.Duration {
+	return min(max(timeout/4, time.Second), time.Minute)
+}
+
+func (m *Manager) options() Options {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.opts
+}
+
+func (m *Manager) debugf(format string, args ...interface{}) {
+	if debugf := m.options().Debugf; debugf != nil {
+		debugf(format, args...)
+	}
+}
+
+// Unlock opens the vault at cipher and returns its plaintext directory. An
+// already unlocked vault is reused. The password comes from the secret store
+// when one is saved, otherwise from Prompt, which is asked again while the
+// answer is rejected.
+func (m *Manager) Unlock(ctx context.Context, cipher string) (string, error) {
+	cipher = filepath.Clean(cipher)
+	m.unlockMu.Lock()
+	defer m.unlockMu.Unlock()
+	if plain, ok := m.touch(cipher); ok {
+		return plain, nil
+	}
+	kind := Detect(cipher)
+	if kind == KindNone {
+		return "", fmt.Errorf("not a vault: %s", cipher)
+	}
+	opts := m.options()
+
+	password, fromStore := "", false
+	if opts.Store != nil {
+		if _, _, pass, found, err := opts.Store.Get(SecretHost, cipher); err == nil && found {
+			password, fromStore = pass, true
+		}
+	}
+	retry := false
+	for {
+		if !fromStore {
+			if opts.Prompt == nil {
+				return "", ErrWrongPassword
+			}
+			pass, err := opts.Prompt(ctx, PasswordRequest{Path: cipher, Kind: kind, Retry: retry})
+			if err != nil {
+				return "", err
+			}
+			password = pass
+		}
+		plain, age, err := open(ctx, kind, cipher, password)
+		if errors.Is(err, ErrWrongPassword) {
+			m.debugf("vault: password rejected path=%s stored=%t", cipher, fromStore)
+			fromStore, retry = false, true
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if opts.SavePasswords && opts.Store != nil {
+			if err := opts.Store.Set(SecretHost, cipher, "", "", password); err != nil {
+				m.debugf("vault: saving password failed path=%s err=%v", cipher, err)
+			}
+		}
+		m.mu.Lock()
+		m.vaults[cipher] = &unlocked{kind: kind, cipher: cipher, plain: plain, lastUsed: time.Now(), age: age}
+		m.mu.Unlock()
+		m.debugf("vault: unlocked kind=%s path=%s plain=%s", kind, cipher, plain)
+		return plain, nil
+	}
+}
+
+func (m *Manager) touch(cipher string) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.vaults[cipher]
+	if !ok {
+		return "", false
+	}
+	v.lastUsed = time.Now()
+	return v.plain, true
+}
+
+// PlainPath returns the plaintext directory of the unlocked vault at cipher.
+func (m *Manager) PlainPath(cipher string) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.vaults[filepath.Clean(cipher)]
+	if !ok {
+		return "", false
+	}
+	return v.plain, true
+}
+
+// CipherPath returns the cipher directory of the unlocked vault whose
+// plaintext directory is exactly plain.
+func (m *Manager) CipherPath(plain string) (string, bool) {
+	plain = filepath.Clean(plain)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, v := range m.vaults {
+		if v.plain == plain {
+			return v.cipher, true
+		}
+	}
+	return "", false
+}
+
+// Touch marks the vault containing path as used now.
+func (m *Manager) Touch(path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, v := range m.vaults {
+		if within(path, v.plain) {
+			v.lastUsed = time.Now()
+		}
+	}
+}
+
+// within reports whether path is dir or below it.
+func within(path, dir string) bool {
+	path = filepath.Clean(path)
+	return path == dir || strings.HasPrefix(path, dir+string(filepath.Separator))
+}
+
+// Lock closes the vault at cipher and removes its plaintext directory. An
+// age vault has the changes made to its plaintext encrypted back first. A
+// vault whose mount is still busy, or whose changes could not be encrypted,
+// stays unlocked and the error is returned.
+func (m *Manager) Lock(cipher string) error {
+	cipher = filepath.Clean(cipher)
+	m.unlockMu.Lock()
+	defer m.unlockMu.Unlock()
+	m.mu.Lock()
+	v, ok := m.vaults[cipher]
+	m.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	err := closeVault(v)
+	m.debugf("vault: lock path=%s err=%v", cipher, err)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	delete(m.vaults, cipher)
+	m.mu.Unlock()
+	return nil
+}
+
+// LockAll locks every unlocked vault.
+func (m *Manager) LockAll() error {
+	m.mu.Lock()
+	ciphers := make([]string, 0, len(m.vaults))
+	for cipher := range m.vaults {
+		ciphers = append(ciphers, cipher)
+	}
+	m.mu.Unlock()
+	var errs []error
+	for _, cipher := range ciphers {
+		errs = append(errs, m.Lock(cipher))
+	}
+	return errors.Join(errs...)
+}
+
+// LockIdle locks vaults unused for longer than the idle timeout that none of
+// the inUse paths are inside.
+func (m *Manager) LockIdle(now time.Time, inUse []string) {
+	timeout := m.options().IdleTimeout
+	if timeout <= 0 {
+		return
+	}
+	m.mu.Lock()
+	var idle []string
+	for cipher, v := range m.vaults {
+		busy := false
+		for _, path := range inUse {
+			if within(path, v.plain) {
+				busy = true
+				v.lastUsed = now
+				break
+			}
+		}
+		if !busy && now.Sub(v.lastUsed) >= timeout {
+			idle = append(idle, cipher)
+		}
+	}
+	m.mu.Unlock()
+	for _, cipher := range idle {
+		m.debugf("vault: idle timeout path=%s", cipher)
+		if err := m.Lock(cipher); err != nil {
+			m.debugf("vault: idle lock failed path=%s err=%v", cipher, err)
+		}
+	}
+}
+
+func (m *Manager) startIdleTimer(interval time.Duration) {
+	stop := make(chan struct{})
+	m.mu.Lock()
+	m.stop = stop
+	m.mu.Unlock()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				var inUse []string
+				if f := m.options().InUse; f != nil {
+					inUse = f()
+				}
+				m.LockIdle(now, inUse)
+			}
+		}
+	}()
+}
+
+// stopIdleTimer does not wait for the timer goroutine: InUse may be waiting
+// for the UI thread that is calling Close.
+func (m *Manager) stopIdleTimer() {
+	m.mu.Lock()
+	stop := m.stop
+	m.stop = nil
+	m.mu.Unlock()
+	if stop != nil {
+		close(stop)
+	}
+}
+
+// Close stops the idle timer and locks every vault.
+func (m *Manager) Close() error {
+	m.stopIdleTimer()
+	return m.LockAll()
+}
+
+// open unlocks cipher with password into a new plaintext directory. For an
+// age vault it also returns what was decrypted.
+func open(ctx context.Context, kind Kind, cipher, password string) (string, map[string]ageEntry, error) {
+	plain, err := newPlainDir()
+	if err != nil {
+		return "", nil, err
+	}
+	var age map[string]ageEntry
+	switch kind {
+	case KindGocryptfs:
+		err = mountGocryptfs(ctx, cipher, plain, password)
+	case KindAge:
+		age, err = decryptAge(ctx, cipher, plain, password)
+	default:
+		err = fmt.Errorf("unknown vault kind %q", kind)
+	}
+	if err != nil {
+		removePlainDir(plain)
+		return "", nil, err
+	}
+	return plain, age, nil
+}
+
+func closeVault(v *unlocked) error {
+	switch v.kind {
+	case KindGocryptfs:
+		if err := unmountGocryptfs(v.plain); err != nil {
+			return err
+		}
+	case KindAge:
+		if err := encryptAge(context.Background(), v.cipher, v.plain, v.age); err != nil {
+			return fmt.Errorf("error encrypting vault changes: %w", err)
+		}
+	}
+	return removePlainDir(v.plain)
+}
+
+// newPlainDir creates a private directory for decrypted content, preferring
+// the per-user runtime directory so plaintext stays off persistent disks
+// where the platform offers one.
+func newPlainDir() (string, error) {
+	base := os.Getenv("XDG_RUNTIME_DIR")
+	if base == "" {
+		base = os.TempDir()
+	}
+	dir, err := os.MkdirTemp(base, "nmf-vault-")
+	if err != nil {
+		return "", fmt.Errorf("error creating vault directory: %w", err)
+	}
+	if err := os.Chmod(dir, 0700); err != nil {
+		os.Remove(dir)
+		return "", fmt.Errorf("error securing vault directory: %w", err)
+	}
+	return dir, nil
+}
+
+// removePlainDir deletes a plaintext directory. Read-only decrypted files are
+// made writable first because Windows refuses to delete them otherwise.
+func removePlainDir(dir string) error {
+	filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && d.Type().IsRegular() {
+			os.Chmod(path, 0600)
+		}
+		return nil
+	})
+	return os.RemoveAll(dir)
+}
@@ -0,0 +1,317 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"nmf/internal/secret"
+)
+
+type memoryStore map[string]string
+
+func (s memoryStore) Get(host, share string) (string, string, string, bool, error) {
+	pass, ok := s[host+"|"+share]
+	return "", "", pass, ok, nil
+}
+
+func (s memoryStore) Set(host, share, domain, user, pass string) error {
+	s[host+"|"+share] = pass
+	return nil
+}
+
+func (s memoryStore) Delete(host, share string) error {
+	delete(s, host+"|"+share)
+	return nil
+}
+
+func (s memoryStore) List() ([]secret.Entry, error) {
+	entries := make([]secret.Entry, 0, len(s))
+	for key := range s {
+		host, share, _ := strings.Cut(key, "|")
+		entries = append(entries, secret.Entry{Host: host, Share: share})
+	}
+	return entries, nil
+}
+
+type exitError int
+
+func (e exitError) Error() string { return "exit status" }
+func (e exitError) ExitCode() int { return int(e) }
+
+type commandCall struct {
+	stdin string
+	name  string
+	args  []string
+}
+
+// fakeTools replaces runCommand: age decrypts to "plain:<name>" for the
+// identity key and encrypts to "cipher:<content>", gocryptfs accepts
+// password, and every call is recorded.
+func fakeTools(t *testing.T, key string) *[]commandCall {
+	t.Helper()
+	var calls []commandCall
+	orig := runCommand
+	runCommand = func(ctx context.Context, stdin string, name string, args ...string) ([]byte, error) {
+		calls = append(calls, commandCall{stdin: stdin, name: name, args: args})
+		switch name {
+		case "age":
+			if args[0] == "-e" {
+				src, dst := args[len(args)-1], args[len(args)-2]
+				data, err := os.ReadFile(src)
+				if err != nil {
+					return nil, err
+				}
+				return nil, os.WriteFile(dst, append([]byte("cipher:"), data...), 0600)
+			}
+			if stdin != key+"\n" {
+				return []byte("age: error: no identity matched any of the recipients"), exitError(1)
+			}
+			src, dst := args[len(args)-1], args[len(args)-2]
+			return nil, os.WriteFile(dst, []byte("plain:"+filepath.Base(src)), 0600)
+		case "gocryptfs":
+			if stdin != key+"\n" {
+				return []byte("Password incorrect."), exitError(gocryptfsWrongPassword)
+			}
+		}
+		return nil, nil
+	}
+	t.Cleanup(func() { runCommand = orig })
+	return &calls
+}
+
+func newAgeVault(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".age-recipients"), []byte("age1example\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"notes.txt.age", "docs/plan.md.age"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("cipher"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestDetect(t *testing.T) {
+	gocryptfs := t.TempDir()
+	if err := os.WriteFile(filepath.Join(gocryptfs, "gocryptfs.conf"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		dir  string
+		want Kind
+	}{
+		{gocryptfs, KindGocryptfs},
+		{newAgeVault(t), KindAge},
+		{t.TempDir(), KindNone},
+	} {
+		if got := Detect(tc.dir); got != tc.want {
+			t.Errorf("Detect(%s) = %q, want %q", tc.dir, got, tc.want)
+		}
+	}
+}
+
+func TestUnlockAgeVaultPromptsUntilAcceptedAndLocks(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+	fakeTools(t, "AGE-SECRET-KEY-1")
+	cipher := newAgeVault(t)
+	store := memoryStore{}
+	answers := []string{"wrong", "AGE-SECRET-KEY-1"}
+	var requests []PasswordRequest
+	m := NewManager()
+	m.Configure(Options{
+		SavePasswords: true,
+		Store:         store,
+		Prompt: func(ctx context.Context, req PasswordRequest) (string, error) {
+			requests = append(requests, req)
+			answer := answers[0]
+			answers = answers[1:]
+			return answer, nil
+		},
+	})
+
+	plain, err := m.Unlock(t.Context(), cipher)
+	if err != nil {
+		t.Fatalf("Unlock returned error: %v", err)
+	}
+	if len(requests) != 2 || requests[0].Retry || !requests[1].Retry || requests[1].Kind != KindAge {
+		t.Fatalf("prompt requests = %+v, want first then retry for age", requests)
+	}
+	data, err := os.ReadFile(filepath.Join(plain, "docs", "plan.md"))
+	if err != nil || string(data) != "plain:plan.md.age" {
+		t.Fatalf("decrypted file = %q, %v", data, err)
+	}
+	if fi, err := os.Stat(filepath.Join(plain, "notes.txt")); err != nil || fi.Mode().Perm() != 0600 {
+		t.Fatalf("decrypted file mode = %v, %v; want 0600", fi, err)
+	}
+	if got := store["vault|"+cipher]; got != "AGE-SECRET-KEY-1" {
+		t.Fatalf("saved password = %q", got)
+	}
+	if again, err := m.Unlock(t.Context(), cipher); err != nil || again != plain || len(requests) != 2 {
+		t.Fatalf("second Unlock = %q, %v after %d prompts; want reuse of %q", again, err, len(requests), plain)
+	}
+	if got, ok := m.CipherPath(plain); !ok || got != cipher {
+		t.Fatalf("CipherPath = %q, %t", got, ok)
+	}
+
+	if err := m.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+	if _, err := os.Stat(plain); !os.IsNotExist(err) {
+		t.Fatalf("plaintext directory survived lock: %v", err)
+	}
+	if _, ok := m.PlainPath(cipher); ok {
+		t.Fatal("vault still unlocked after Close")
+	}
+}
+
+func TestLockEncryptsAgeVaultChanges(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+	calls := fakeTools(t, "k")
+	cipher := newAgeVault(t)
+	m := NewManager()
+	m.Configure(Options{Prompt: func(context.Context, PasswordRequest) (string, error) { return "k", nil }})
+	plain, err := m.Unlock(t.Context(), cipher)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypts := len(*calls)
+
+	if err := os.WriteFile(filepath.Join(plain, "notes.txt"), []byte("edited"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(plain, "new"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(plain, "new", "todo.txt"), []byte("added"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(filepath.Join(plain, "docs")); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Lock(cipher); err != nil {
+		t.Fatalf("Lock returned error: %v", err)
+	}
+
+	for name, want := range map[string]string{
+		"notes.txt.age":    "cipher:edited",
+		"new/todo.txt.age": "cipher:added",
+	} {
+		if data, err := os.ReadFile(filepath.Join(cipher, name)); err != nil || string(data) != want {
+			t.Errorf("%s = %q, %v; want %q", name, data, err, want)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(cipher, "docs")); !os.IsNotExist(err) {
+		t.Errorf("removed directory survived lock: %v", err)
+	}
+	for _, call := range (*calls)[decrypts:] {
+		if !slices.Contains(call.args, filepath.Join(cipher, ".age-recipients")) {
+			t.Errorf("age args = %v, want encryption to the vault recipients", call.args)
+		}
+	}
+	if n := len(*calls) - decrypts; n != 2 {
+		t.Errorf("%d files encrypted on lock, want the 2 changed ones", n)
+	}
+	entries, _ := os.ReadDir(cipher)
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".tmp") {
+			t.Errorf("temporary file %s left in the vault", e.Name())
+		}
+	}
+}
+
+func TestUnlockUsesStoredPasswordAndCancelStopsPrompting(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skip("gocryptfs is not supported on this platform")
+	}
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+	calls := fakeTools(t, "secret")
+	cipher := t.TempDir()
+	if err := os.WriteFile(filepath.Join(cipher, "gocryptfs.conf"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m := NewManager()
+	m.Configure(Options{
+		Store: memoryStore{"vault|" + cipher: "secret"},
+		Prompt: func(context.Context, PasswordRequest) (string, error) {
+			t.Fatal("prompted although a password was stored")
+			return "", nil
+		},
+	})
+	plain, err := m.Unlock(t.Context(), cipher)
+	if err != nil {
+		t.Fatalf("Unlock returned error: %v", err)
+	}
+	if err := m.Lock(cipher); err != nil {
+		t.Fatalf("Lock returned error: %v", err)
+	}
+	names := make([]string, len(*calls))
+	for i, call := range *calls {
+		names[i] = call.name
+	}
+	unmount := "fusermount"
+	if runtime.GOOS == "darwin" {
+		unmount = "umount"
+	}
+	if !slices.Equal(names, []string{"gocryptfs", unmount}) {
+		t.Fatalf("commands = %v, want mount then unmount", names)
+	}
+	last := (*calls)[len(*calls)-1]
+	if last.args[len(last.args)-1] != plain {
+		t.Fatalf("unmount args = %v, want %s", last.args, plain)
+	}
+
+	canceled := errors.New("canceled")
+	m.Configure(Options{
+		Store: memoryStore{"vault|" + cipher: "stale"},
+		Prompt: func(context.Context, PasswordRequest) (string, error) {
+			return "", canceled
+		},
+	})
+	if _, err := m.Unlock(t.Context(), cipher); !errors.Is(err, canceled) {
+		t.Fatalf("Unlock error = %v, want prompt cancellation", err)
+	}
+	entries, _ := os.ReadDir(os.Getenv("XDG_RUNTIME_DIR"))
+	if len(entries) != 0 {
+		t.Fatalf("failed unlock left plaintext directories: %v", entries)
+	}
+}
+
+func TestLockIdleKeepsVaultsWindowsShow(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+	fakeTools(t, "k")
+	m := NewManager()
+	m.Configure(Options{Prompt: func(context.Context, PasswordRequest) (string, error) { return "k", nil }})
+	m.opts.IdleTimeout = time.Minute
+	first, second := newAgeVault(t), newAgeVault(t)
+	firstPlain, err := m.Unlock(t.Context(), first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Unlock(t.Context(), second); err != nil {
+		t.Fatal(err)
+	}
+
+	m.LockIdle(time.Now().Add(2*time.Minute), []string{filepath.Join(firstPlain, "docs")})
+	if _, ok := m.PlainPath(first); !ok {
+		t.Fatal("vault shown by a window was locked")
+	}
+	if _, ok := m.PlainPath(second); ok {
+		t.Fatal("idle vault was not locked")
+	}
+	if !strings.HasPrefix(firstPlain, os.Getenv("XDG_RUNTIME_DIR")) {
+		t.Fatalf("plaintext directory %s is outside XDG_RUNTIME_DIR", firstPlain)
+	}
+	m.Close()
+}
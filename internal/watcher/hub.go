@@ -2,6 +2,7 @@ package watcher
 
 import (
 	"errors"
+	"runtime"
 	"sync"
 	"time"
 
@@ -70,6 +71,7 @@ type WatchHub struct {
 	watchPath      watchPathFunc
 	debounce       time.Duration
 	debugPrint     func(format string, args ...interface{})
+	stopping       sync.WaitGroup // Sources whose last subscriber left
 }
 
 type watchSourceInit struct {
@@ -99,11 +101,43 @@ func newWatchHub(debugPrint func(format string, args ...interface{}), newBackend
 	}
 }
 
+// SetDebounce sets how long an event source waits for a burst of events to
+// settle before reading one snapshot. It applies to the next event of every
+// source; d <= 0 restores the default.
+func (h *WatchHub) SetDebounce(d time.Duration) {
+	if d <= 0 {
+		d = defaultDebounceInterval
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.debounce = d
+}
+
+func (h *WatchHub) debounceInterval() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.debounce
+}
+
+// Refresh reads path now, in the background, and sends the snapshot to its
+// subscribers as if a poll or event had fired. Paths without a source are
+// ignored.
+func (h *WatchHub) Refresh(path string) {
+	h.mu.Lock()
+	src := h.sources[path]
+	h.mu.Unlock()
+	if src == nil {
+		return
+	}
+	h.debugPrint("WatchHub: refresh path=%s", path)
+	go src.readAndBroadcast()
+}
+
 // Subscribe attaches to the shared source for path. The interval is used only
-// when the source must fall back to polling.
+// when the source must fall back to polling; zero picks PollInterval(path).
 func (h *WatchHub) Subscribe(path string, interval time.Duration) *Subscription {
 	if interval <= 0 {
-		interval = 2 * time.Second
+		interval = PollInterval(path)
 	}
 
 	for {
@@ -169,13 +203,19 @@ func (h *WatchHub) unsubscribe(path string, id uint64) {
 	h.mu.Unlock()
 
 	if empty {
-		go func() {
+		h.stopping.Go(func() {
 			src.stop()
 			h.debugPrint("WatchHub: source stop path=%s", path)
-		}()
+		})
 	}
 }
 
+// Wait blocks until every source whose last subscription ended has stopped.
+// Like unsubscribing, it must not run on the UI thread.
+func (h *WatchHub) Wait() {
+	h.stopping.Wait()
+}
+
 func readSnapshot(path string) (Snapshot, error) {
 	entries, err := fileinfo.ReadDirPortable(path)
 	if err != nil {
@@ -194,6 +234,14 @@ func readSnapshot(path string) (Snapshot, error) {
 }
 
 func resolveWatchPath(path string) (string, bool) {
+	return resolveWatchPathWith(path, runtime.GOOS, fileinfo.ClassifyPath)
+}
+
+// resolveWatchPathWith returns the path to hand to the OS watcher, or false
+// when the source must poll. Local paths on a network filesystem poll outside
+// Windows: inotify and kqueue only see changes made through this machine's
+// kernel, while ReadDirectoryChangesW is forwarded to the SMB server.
+func resolveWatchPathWith(path string, goos string, classify func(string) (fileinfo.PathClass, error)) (string, bool) {
 	vfs, parsed, err := fileinfo.ResolveRead(path)
 	if err != nil {
 		return path, true
@@ -202,13 +250,19 @@ func resolveWatchPath(path string) (string, bool) {
 	if !vfs.Capabilities().Watch {
 		return "", false
 	}
-	if parsed.Provider == "local" && parsed.Native != "" {
-		return parsed.Native, true
+	native := ""
+	if parsed.Provider == "local" || parsed.Scheme == fileinfo.SchemeFile {
+		native = parsed.Native
 	}
-	if parsed.Scheme == fileinfo.SchemeFile && parsed.Native != "" {
-		return parsed.Native, true
+	if native == "" {
+		return path, true
+	}
+	if goos != "windows" {
+		if class, err := classify(native); err == nil && class.Network {
+			return "", false
+		}
 	}
-	return path, true
+	return native, true
 }
 
 type watchSource struct {
@@ -363,6 +417,21 @@ func (s *watchSource) eventLoop(backend watchBackend) bool {
 			debounce.Stop()
 		}
 	}()
+	scheduleRead := func() {
+		if debounce == nil {
+			debounce = time.NewTimer(s.hub.debounceInterval())
+			debounceC = debounce.C
+			return
+		}
+		if !debounce.Stop() {
+			select {
+			case <-debounce.C:
+			default:
+			}
+		}
+		debounce.Reset(s.hub.debounceInterval())
+		debounceC = debounce.C
+	}
 
 	for {
 		select {
@@ -371,28 +440,21 @@ func (s *watchSource) eventLoop(backend watchBackend) bool {
 				s.hub.debugPrint("WatchHub: fswatcher events closed path=%s", s.path)
 				return true
 			}
-			if debounce == nil {
-				debounce = time.NewTimer(s.hub.debounce)
-				debounceC = debounce.C
-			} else {
-				if !debounce.Stop() {
-					select {
-					case <-debounce.C:
-					default:
-					}
-				}
-				debounce.Reset(s.hub.debounce)
-				debounceC = debounce.C
-			}
+			scheduleRead()
 		case err, ok := <-errorsC:
 			if !ok {
 				errorsC = nil
 				continue
 			}
-			if err != nil && !errors.Is(err, fswatcher.ErrClosed) {
-				s.hub.debugPrint("WatchHub: fswatcher error path=%s err=%v", s.path, err)
+			if errors.Is(err, fswatcher.ErrClosed) {
+				return true
 			}
-			return true
+			// Backend errors such as a full event queue mean events were
+			// dropped, not that the watch is gone: rescan the directory and
+			// keep watching. A backend that cannot go on closes its event
+			// channel, which falls back to polling above.
+			s.hub.debugPrint("WatchHub: fswatcher error path=%s err=%v; rescanning", s.path, err)
+			scheduleRead()
 		case <-debounceC:
 			debounceC = nil
 			s.readAndBroadcast()
@@ -337,6 +337,51 @@ func TestWatchHubDebouncesEventSnapshots(t *testing.T) {
 	}
 }
 
+func TestWatchHubRescansAfterBackendErrorAndKeepsWatching(t *testing.T) {
+	backend := newFakeBackend()
+	var listMu sync.Mutex
+	listCount := 0
+	hub := newWatchHub(dummyDebug, func() (watchBackend, error) {
+		return backend, nil
+	}, func(string) (Snapshot, error) {
+		listMu.Lock()
+		listCount++
+		listMu.Unlock()
+		return Snapshot{}, nil
+	}, func(path string) (string, bool) {
+		return path, true
+	}, 5*time.Millisecond)
+
+	sub := hub.Subscribe("/tmp/overflow", time.Hour)
+	defer sub.Unsubscribe()
+
+	backend.errs <- errors.New("event queue overflow")
+	select {
+	case <-sub.Updates:
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("timed out waiting for the rescan after a backend error")
+	}
+
+	hub.mu.Lock()
+	src := hub.sources["/tmp/overflow"]
+	hub.mu.Unlock()
+	if src == nil || src.pollFallback || backend.isClosed() {
+		t.Fatal("a backend error should not abandon the OS watcher")
+	}
+	backend.events <- fswatcher.Event{Name: "/tmp/overflow/a", Op: fswatcher.Create}
+	select {
+	case <-sub.Updates:
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("events after the error should still be delivered")
+	}
+	listMu.Lock()
+	got := listCount
+	listMu.Unlock()
+	if got != 2 {
+		t.Fatalf("snapshot reads = %d, want 2", got)
+	}
+}
+
 func TestWatchHubUsesNativeWatchPath(t *testing.T) {
 	backend := newFakeBackend()
 	hub := newWatchHub(dummyDebug, func() (watchBackend, error) {
@@ -385,3 +430,41 @@ func TestWatchHubSkipsFSWatcherForUnwatchablePath(t *testing.T) {
 		t.Fatal("backend should not be created for unwatchable path")
 	}
 }
+
+func TestWatchHubRefreshReadsBeforeTheNextPoll(t *testing.T) {
+	hub := newWatchHub(dummyDebug, nil, func(path string) (Snapshot, error) {
+		return Snapshot{path + "/new.txt": {Name: "new.txt", Path: path + "/new.txt"}}, nil
+	}, func(string) (string, bool) {
+		return "", false
+	}, time.Millisecond)
+
+	sub := hub.Subscribe("smb://server/share/dir", time.Hour)
+	defer sub.Unsubscribe()
+	hub.Refresh("smb://server/share/other")
+	hub.Refresh("smb://server/share/dir")
+
+	select {
+	case snapshot := <-sub.Updates:
+		if _, ok := snapshot["smb://server/share/dir/new.txt"]; !ok {
+			t.Fatalf("snapshot = %v, want the refreshed listing", snapshot)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the refreshed snapshot")
+	}
+}
+
+func TestResolveWatchPathPollsNetworkMountsOutsideWindows(t *testing.T) {
+	dir := t.TempDir()
+	network := func(string) (fileinfo.PathClass, error) { return fileinfo.PathClass{Network: true}, nil }
+	local := func(string) (fileinfo.PathClass, error) { return fileinfo.PathClass{}, nil }
+
+	if _, ok := resolveWatchPathWith(dir, "linux", network); ok {
+		t.Fatal("network mount on linux should poll")
+	}
+	if got, ok := resolveWatchPathWith(dir, "linux", local); !ok || got == "" {
+		t.Fatalf("local path = %q, %t; want native watch", got, ok)
+	}
+	if got, ok := resolveWatchPathWith(dir, "windows", network); !ok || got == "" {
+		t.Fatalf("network path on windows = %q, %t; want native watch", got, ok)
+	}
+}
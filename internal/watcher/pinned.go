@@ -0,0 +1,147 @@
+package watcher
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"nmf/internal/fileinfo"
+)
+
+// PinnedWatcher follows a small set of directories whether or not any window
+// shows them and reports entries that appear there. Each directory holds a
+// WatchHub subscription, so a window showing the same path shares its source.
+type PinnedWatcher struct {
+	hub      *WatchHub
+	interval time.Duration
+	onAdded  func(dir string, added []fileinfo.FileInfo)
+
+	mu      sync.Mutex
+	watches map[string]*pinnedWatch
+	closed  bool
+
+	debugPrint func(format string, args ...interface{})
+}
+
+type pinnedWatch struct {
+	subscription *Subscription
+	stop         chan struct{}
+}
+
+// NewPinnedWatcher creates a watcher reporting new entries through onAdded,
+// which runs on a background goroutine. interval is the polling fallback;
+// zero picks PollInterval per watched path.
+func NewPinnedWatcher(hub *WatchHub, interval time.Duration, onAdded func(dir string, added []fileinfo.FileInfo), debugPrint func(format string, args ...interface{})) *PinnedWatcher {
+	if debugPrint == nil {
+		debugPrint = func(string, ...interface{}) {}
+	}
+	if hub == nil {
+		hub = NewWatchHub(debugPrint)
+	}
+	return &PinnedWatcher{
+		hub:        hub,
+		interval:   interval,
+		onAdded:    onAdded,
+		watches:    make(map[string]*pinnedWatch),
+		debugPrint: debugPrint,
+	}
+}
+
+// SetPaths watches exactly paths: new ones start, dropped ones stop, and
+// unchanged ones keep their baseline. It never blocks on a slow path.
+func (p *PinnedWatcher) SetPaths(paths []string) {
+	wanted := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		if path != "" {
+			wanted[path] = true
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return
+	}
+	for path, watch := range p.watches {
+		if !wanted[path] {
+			delete(p.watches, path)
+			close(watch.stop)
+			p.debugPrint("PinnedWatcher: stop path=%s", path)
+		}
+	}
+	for path := range wanted {
+		if p.watches[path] == nil {
+			watch := &pinnedWatch{stop: make(chan struct{})}
+			p.watches[path] = watch
+			go p.run(path, watch)
+			p.debugPrint("PinnedWatcher: start path=%s", path)
+		}
+	}
+}
+
+// Paths returns the watched directories in sorted order.
+func (p *PinnedWatcher) Paths() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	paths := make([]string, 0, len(p.watches))
+	for path := range p.watches {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Close stops every watch. Later SetPaths calls are ignored.
+func (p *PinnedWatcher) Close() {
+	p.SetPaths(nil)
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+}
+
+// run subscribes before reading the baseline, so an entry created in between
+// is either in the baseline or reported by the next snapshot.
+func (p *PinnedWatcher) run(path string, watch *pinnedWatch) {
+	subscription := p.hub.Subscribe(path, p.interval)
+	defer subscription.Unsubscribe()
+
+	baseline, err := p.hub.listSnapshot(path)
+	if err != nil {
+		p.debugPrint("PinnedWatcher: baseline read failed path=%s err=%v", path, err)
+		baseline = Snapshot{}
+	}
+	for {
+		select {
+		case <-watch.stop:
+			return
+		case snapshot, ok := <-subscription.Updates:
+			if !ok {
+				return
+			}
+			if added := addedEntries(baseline, snapshot); len(added) > 0 {
+				select {
+				case <-watch.stop:
+					return
+				default:
+				}
+				p.debugPrint("PinnedWatcher: path=%s added=%d", path, len(added))
+				if p.onAdded != nil {
+					p.onAdded(path, added)
+				}
+			}
+			baseline = snapshot
+		}
+	}
+}
+
+// addedEntries returns the entries of current missing from previous, sorted
+// by name.
+func addedEntries(previous, current Snapshot) []fileinfo.FileInfo {
+	var added []fileinfo.FileInfo
+	for path, file := range current {
+		if _, ok := previous[path]; !ok {
+			added = append(added, file)
+		}
+	}
+	sort.Slice(added, func(i, j int) bool { return added[i].Name < added[j].Name })
+	return added
+}
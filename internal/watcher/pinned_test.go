@@ -0,0 +1,102 @@
+package watcher
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fswatcher/fswatcher"
+	"nmf/internal/fileinfo"
+)
+
+func TestPinnedWatcherReportsOnlyNewEntries(t *testing.T) {
+	backend := newFakeBackend()
+	var listMu sync.Mutex
+	names := []string{"old.txt"}
+	hub := newWatchHub(dummyDebug, func() (watchBackend, error) {
+		return backend, nil
+	}, func(path string) (Snapshot, error) {
+		listMu.Lock()
+		defer listMu.Unlock()
+		snapshot := Snapshot{}
+		for _, name := range names {
+			snapshot[path+"/"+name] = fileinfo.FileInfo{Name: name, Path: path + "/" + name}
+		}
+		return snapshot, nil
+	}, func(path string) (string, bool) {
+		return path, true
+	}, 5*time.Millisecond)
+
+	reports := make(chan []fileinfo.FileInfo, 4)
+	pinned := NewPinnedWatcher(hub, time.Second, func(dir string, added []fileinfo.FileInfo) {
+		if dir != "/tmp/downloads" {
+			t.Errorf("onAdded dir = %q", dir)
+		}
+		reports <- added
+	}, dummyDebug)
+	defer pinned.Close()
+	pinned.SetPaths([]string{"/tmp/downloads"})
+	waitFor(t, time.Second, func() bool {
+		hub.mu.Lock()
+		defer hub.mu.Unlock()
+		return hub.sources["/tmp/downloads"] != nil
+	})
+	time.Sleep(20 * time.Millisecond) // let the baseline read finish
+
+	listMu.Lock()
+	names = append(names, "new.pdf")
+	listMu.Unlock()
+	backend.events <- fswatcher.Event{Name: "/tmp/downloads/new.pdf", Op: fswatcher.Create}
+
+	select {
+	case added := <-reports:
+		if len(added) != 1 || added[0].Name != "new.pdf" {
+			t.Fatalf("added = %+v, want only new.pdf", added)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("pinned watcher did not report the new entry")
+	}
+
+	backend.events <- fswatcher.Event{Name: "/tmp/downloads/new.pdf", Op: fswatcher.Write}
+	select {
+	case added := <-reports:
+		t.Fatalf("a rescan without new entries reported %+v", added)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestPinnedWatcherSetPathsStartsAndStopsWatches(t *testing.T) {
+	hub := newWatchHub(dummyDebug, func() (watchBackend, error) {
+		return newFakeBackend(), nil
+	}, func(string) (Snapshot, error) {
+		return Snapshot{}, nil
+	}, func(path string) (string, bool) {
+		return path, true
+	}, 5*time.Millisecond)
+	pinned := NewPinnedWatcher(hub, time.Second, nil, dummyDebug)
+
+	pinned.SetPaths([]string{"/b", "/a", ""})
+	if got := pinned.Paths(); len(got) != 2 || got[0] != "/a" || got[1] != "/b" {
+		t.Fatalf("Paths = %v, want [/a /b]", got)
+	}
+	pinned.SetPaths([]string{"/b"})
+	if got := pinned.Paths(); len(got) != 1 || got[0] != "/b" {
+		t.Fatalf("Paths = %v, want [/b]", got)
+	}
+	waitFor(t, time.Second, func() bool {
+		hub.mu.Lock()
+		defer hub.mu.Unlock()
+		return hub.sources["/a"] == nil && hub.sources["/b"] != nil
+	})
+
+	pinned.Close()
+	pinned.SetPaths([]string{"/c"})
+	if got := pinned.Paths(); len(got) != 0 {
+		t.Fatalf("Paths after Close = %v, want none", got)
+	}
+	waitFor(t, time.Second, func() bool {
+		hub.mu.Lock()
+		defer hub.mu.Unlock()
+		return len(hub.sources) == 0
+	})
+}
@@ -0,0 +1,31 @@
+package watcher
+
+import (
+	"time"
+
+	"nmf/internal/fileinfo"
+)
+
+const (
+	// LocalPollInterval is how often a polled local directory is listed.
+	LocalPollInterval = 2 * time.Second
+	// RemotePollInterval is the longer interval for network-backed
+	// directories, where every poll is a round trip to the server.
+	RemotePollInterval = 4 * time.Second
+)
+
+// PollInterval returns the polling fallback interval for path. SMB URLs,
+// network gio locations, network filesystem mounts, and Windows UNC or
+// mapped network drives get RemotePollInterval; everything else polls at
+// LocalPollInterval.
+func PollInterval(path string) time.Duration {
+	return pollIntervalFor(path, fileinfo.ClassifyPath)
+}
+
+func pollIntervalFor(path string, classify func(string) (fileinfo.PathClass, error)) time.Duration {
+	class, err := classify(path)
+	if err == nil && class.Network {
+		return RemotePollInterval
+	}
+	return LocalPollInterval
+}
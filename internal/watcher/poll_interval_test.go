@@ -0,0 +1,26 @@
+package watcher
+
+import (
+	"errors"
+	"testing"
+
+	"nmf/internal/fileinfo"
+)
+
+func TestPollIntervalUsesRemoteIntervalForNetworkPaths(t *testing.T) {
+	if got := PollInterval("smb://server/share/dir"); got != RemotePollInterval {
+		t.Fatalf("PollInterval(smb) = %v, want %v", got, RemotePollInterval)
+	}
+	if got := PollInterval(t.TempDir()); got != LocalPollInterval {
+		t.Fatalf("PollInterval(tempdir) = %v, want %v", got, LocalPollInterval)
+	}
+
+	network := func(string) (fileinfo.PathClass, error) { return fileinfo.PathClass{Network: true}, nil }
+	if got := pollIntervalFor("/mnt/cifs", network); got != RemotePollInterval {
+		t.Fatalf("network mount interval = %v, want %v", got, RemotePollInterval)
+	}
+	failing := func(string) (fileinfo.PathClass, error) { return fileinfo.PathClass{}, errors.New("boom") }
+	if got := pollIntervalFor("/x", failing); got != LocalPollInterval {
+		t.Fatalf("unclassified interval = %v, want %v", got, LocalPollInterval)
+	}
+}
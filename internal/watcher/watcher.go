@@ -18,8 +18,18 @@ type FileManager interface {
 	// the current listing. Implementations must run this only on the Fyne
 	// main goroutine (see applyDataChanges, which marshals via fyne.DoAndWait).
 	ApplyChanges(added, deleted, modified []fileinfo.FileInfo)
+	// ReplaceListing swaps in a complete listing after a mass change,
+	// keeping the cursor and marks on paths that still exist. Like
+	// ApplyChanges it runs only on the Fyne main goroutine.
+	ReplaceListing(files []fileinfo.FileInfo)
 }
 
+// MassChangeThreshold is the number of changed entries in one snapshot above
+// which the watcher replaces the listing instead of merging each change.
+// Bursts such as a git checkout or a build would otherwise mark thousands of
+// rows one by one.
+const MassChangeThreshold = 256
+
 // DirectoryWatcher handles incremental directory change detection
 type DirectoryWatcher struct {
 	fm            FileManager
@@ -32,14 +42,19 @@ type DirectoryWatcher struct {
 	changeChan    chan *PendingChanges                     // Channel for thread-safe change communication
 	running       bool                                     // True while current watcher run is active
 	runID         uint64                                   // Monotonically increasing watcher run generation
+	massChange    int                                      // Change count that triggers a full replace; MassChangeThreshold by default
+	keep          func(fileinfo.FileInfo) bool             // Entries the listing shows; nil keeps all
 	debugPrint    func(format string, args ...interface{}) // Debug function
 }
 
-// PendingChanges represents file changes waiting to be applied
+// PendingChanges represents file changes waiting to be applied. Replace,
+// when set, is the whole new listing of a mass change and the other fields
+// are empty.
 type PendingChanges struct {
 	Added    []fileinfo.FileInfo
 	Deleted  []fileinfo.FileInfo
 	Modified []fileinfo.FileInfo
+	Replace  []fileinfo.FileInfo
 }
 
 // NewDirectoryWatcher creates a new directory watcher
@@ -51,7 +66,7 @@ func NewDirectoryWatcher(fm FileManager, hub *WatchHub, debugPrint func(format s
 		fm:            fm,
 		hub:           hub,
 		previousFiles: make(map[string]fileinfo.FileInfo),
-		pollInterval:  2 * time.Second,
+		massChange:    MassChangeThreshold,
 		debugPrint:    debugPrint,
 	}
 }
@@ -66,9 +81,6 @@ func (dw *DirectoryWatcher) Start() {
 	dw.runID++
 	runID := dw.runID
 	interval := dw.pollInterval
-	if interval <= 0 {
-		interval = 2 * time.Second
-	}
 	path := dw.fm.GetCurrentPath()
 	stopChan := make(chan struct{})
 	changeChan := make(chan *PendingChanges, 10) // Buffered channel
@@ -98,12 +110,22 @@ func (dw *DirectoryWatcher) Start() {
 }
 
 // SetPollInterval sets the polling interval used when Start() is called next.
+// Zero, the default, lets the hub pick PollInterval for the path.
 func (dw *DirectoryWatcher) SetPollInterval(d time.Duration) {
 	dw.mu.Lock()
 	defer dw.mu.Unlock()
 	dw.pollInterval = d
 }
 
+// SetEntryFilter limits snapshots to entries for which keep returns true,
+// such as non-hidden files while hidden files are off. Entries it rejects
+// never show up as added, deleted, or modified. nil keeps every entry.
+func (dw *DirectoryWatcher) SetEntryFilter(keep func(fileinfo.FileInfo) bool) {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+	dw.keep = keep
+}
+
 // Stop stops the directory watcher
 func (dw *DirectoryWatcher) Stop() {
 	dw.mu.Lock()
@@ -145,6 +167,18 @@ func (dw *DirectoryWatcher) updateSnapshot() {
 	}
 }
 
+// Refresh re-reads the watched directory at once instead of at the next
+// poll or event, so a listing shown from a cache is reconciled with the
+// directory through the usual change merge. It does nothing while stopped.
+func (dw *DirectoryWatcher) Refresh() {
+	dw.mu.RLock()
+	running := dw.running
+	dw.mu.RUnlock()
+	if running {
+		dw.hub.Refresh(dw.fm.GetCurrentPath())
+	}
+}
+
 // RefreshSnapshot resets the watcher baseline to the file manager's current list.
 func (dw *DirectoryWatcher) RefreshSnapshot() {
 	dw.updateSnapshot()
@@ -168,13 +202,125 @@ func (dw *DirectoryWatcher) applyLoop(runID uint64, stopChan <-chan struct{}, ch
 	for {
 		select {
 		case changes := <-changeChan:
-			dw.applyPendingChanges(runID, changes)
+			dw.applyPendingChanges(runID, drainPendingChanges(changes, changeChan))
 		case <-stopChan:
 			return
 		}
 	}
 }
 
+// drainPendingChanges folds the change sets already queued behind first into
+// it, so snapshots that arrived while the UI was busy applying the previous
+// batch cost one list rebuild instead of one each.
+func drainPendingChanges(first *PendingChanges, changeChan <-chan *PendingChanges) *PendingChanges {
+	merged := first
+	for {
+		select {
+		case next := <-changeChan:
+			merged = mergePendingChanges(merged, next)
+		default:
+			return merged
+		}
+	}
+}
+
+// mergePendingChanges combines two consecutive change sets into the one that
+// takes the listing from before a to after b. A later full replace wins; an
+// incremental set after a replace is folded into the replaced listing.
+func mergePendingChanges(a, b *PendingChanges) *PendingChanges {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if b.Replace != nil {
+		return b
+	}
+	if a.Replace != nil {
+		return &PendingChanges{Replace: foldIntoListing(a.Replace, b)}
+	}
+
+	var order []string
+	byPath := make(map[string]fileinfo.FileInfo)
+	ordered := make(map[string]bool)
+	record := func(file fileinfo.FileInfo) {
+		if !ordered[file.Path] {
+			ordered[file.Path] = true
+			order = append(order, file.Path)
+		}
+		prev, seen := byPath[file.Path]
+		switch {
+		case !seen:
+		case prev.Status == fileinfo.StatusAdded && file.Status == fileinfo.StatusDeleted:
+			// Added and gone again before the UI saw it.
+			delete(byPath, file.Path)
+			return
+		case prev.Status == fileinfo.StatusAdded:
+			file.Status = fileinfo.StatusAdded
+		case prev.Status == fileinfo.StatusDeleted && file.Status == fileinfo.StatusAdded:
+			file.Status = fileinfo.StatusModified
+		}
+		byPath[file.Path] = file
+	}
+	for _, set := range []*PendingChanges{a, b} {
+		for _, list := range [][]fileinfo.FileInfo{set.Deleted, set.Added, set.Modified} {
+			for _, file := range list {
+				record(file)
+			}
+		}
+	}
+
+	merged := &PendingChanges{}
+	for _, path := range order {
+		file, ok := byPath[path]
+		if !ok {
+			continue
+		}
+		switch file.Status {
+		case fileinfo.StatusAdded:
+			merged.Added = append(merged.Added, file)
+		case fileinfo.StatusDeleted:
+			merged.Deleted = append(merged.Deleted, file)
+		default:
+			merged.Modified = append(merged.Modified, file)
+		}
+	}
+	return merged
+}
+
+// foldIntoListing applies an incremental change set to a full listing.
+func foldIntoListing(listing []fileinfo.FileInfo, changes *PendingChanges) []fileinfo.FileInfo {
+	byPath := make(map[string]int, len(listing))
+	files := append([]fileinfo.FileInfo(nil), listing...)
+	for i, file := range files {
+		byPath[file.Path] = i
+	}
+	for _, file := range append(append([]fileinfo.FileInfo(nil), changes.Added...), changes.Modified...) {
+		file.Status = fileinfo.StatusNormal
+		if i, ok := byPath[file.Path]; ok {
+			files[i] = file
+			continue
+		}
+		byPath[file.Path] = len(files)
+		files = append(files, file)
+	}
+	gone := make(map[string]bool, len(changes.Deleted))
+	for _, file := range changes.Deleted {
+		gone[file.Path] = true
+	}
+	if len(gone) == 0 {
+		return files
+	}
+	kept := files[:0]
+	for _, file := range files {
+		if !gone[file.Path] {
+			kept = append(kept, file)
+		}
+	}
+	return kept
+}
+
 // isCurrentRun reports whether runID still matches the currently active watcher run.
 func (dw *DirectoryWatcher) isCurrentRun(runID uint64) bool {
 	dw.mu.RLock()
@@ -188,21 +334,29 @@ func (dw *DirectoryWatcher) queueSnapshotChanges(runID uint64, currentFiles Snap
 		return
 	}
 
+	dw.filterSnapshot(currentFiles)
+
 	// Detect changes
 	added, deleted, modified := dw.detectChanges(currentFiles)
 
 	// Apply changes if any detected
-	if len(added) > 0 || len(deleted) > 0 || len(modified) > 0 {
+	if count := len(added) + len(deleted) + len(modified); count > 0 {
 		if !dw.isCurrentRun(runID) {
 			return
 		}
 
-		select {
-		case changeChan <- &PendingChanges{
+		changes := &PendingChanges{
 			Added:    added,
 			Deleted:  deleted,
 			Modified: modified,
-		}:
+		}
+		if count > dw.massChange {
+			dw.debugPrint("DirectoryWatcher: %d changes, replacing the listing", count)
+			changes = &PendingChanges{Replace: snapshotFiles(currentFiles)}
+		}
+
+		select {
+		case changeChan <- changes:
 			// Advance the expected baseline only after the change set is queued.
 			// This prevents a burst of snapshots from deriving duplicate adds or
 			// deletes while the first UI application is still pending.
@@ -214,6 +368,30 @@ func (dw *DirectoryWatcher) queueSnapshotChanges(runID uint64, currentFiles Snap
 	}
 }
 
+// filterSnapshot drops entries rejected by the entry filter. The snapshot is
+// this subscriber's own copy, so it is edited in place.
+func (dw *DirectoryWatcher) filterSnapshot(snapshot Snapshot) {
+	dw.mu.RLock()
+	keep := dw.keep
+	dw.mu.RUnlock()
+	if keep == nil {
+		return
+	}
+	for path, file := range snapshot {
+		if !keep(file) {
+			delete(snapshot, path)
+		}
+	}
+}
+
+func snapshotFiles(snapshot Snapshot) []fileinfo.FileInfo {
+	files := make([]fileinfo.FileInfo, 0, len(snapshot))
+	for _, file := range snapshot {
+		files = append(files, file)
+	}
+	return files
+}
+
 func (dw *DirectoryWatcher) advanceSnapshot(runID uint64, files Snapshot) {
 	dw.mu.Lock()
 	defer dw.mu.Unlock()
@@ -260,9 +438,9 @@ func (dw *DirectoryWatcher) detectChanges(currentFiles map[string]fileinfo.FileI
 // applyDataChanges applies detected changes to the file manager data. The
 // merge itself (fm.ApplyChanges) runs inside fyne.DoAndWait so changes remain
 // ordered and confined to the Fyne main goroutine, alongside all other
-// fm.files/fm.selectedFiles access; the previous background-goroutine merge
-// (calling GetFiles/RemoveFromSelections here directly) raced with UI-thread
-// code such as SetFileSelected, which mutates fm.selectedFiles without a lock.
+// fm.files access; the previous background-goroutine merge (calling
+// GetFiles/RemoveFromSelections here directly) raced with UI-thread code that
+// rebuilds fm.files without a lock.
 func (dw *DirectoryWatcher) applyDataChanges(runID uint64, added, deleted, modified []fileinfo.FileInfo) {
 	if len(added) == 0 && len(deleted) == 0 && len(modified) == 0 {
 		return
@@ -286,6 +464,15 @@ func (dw *DirectoryWatcher) applyPendingChanges(runID uint64, changes *PendingCh
 	if changes == nil || !dw.isCurrentRun(runID) {
 		return
 	}
+	if changes.Replace != nil {
+		dw.debugPrint("DirectoryWatcher: Replacing listing with %d entries", len(changes.Replace))
+		fyne.DoAndWait(func() {
+			if dw.isCurrentRun(runID) {
+				dw.fm.ReplaceListing(changes.Replace)
+			}
+		})
+		return
+	}
 	// Apply data changes (binding auto-updates UI)
 	dw.applyDataChanges(runID, changes.Added, changes.Deleted, changes.Modified)
 }
@@ -1,6 +1,7 @@
 package watcher
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ type mockFM struct {
 	path          string
 	files         []fileinfo.FileInfo
 	selectedFiles map[string]bool
+	replaced      int
 }
 
 func (m *mockFM) GetCurrentPath() string { return m.path }
@@ -60,6 +62,11 @@ func (m *mockFM) ApplyChanges(added, deleted, modified []fileinfo.FileInfo) {
 	m.UpdateFiles(files)
 }
 
+func (m *mockFM) ReplaceListing(files []fileinfo.FileInfo) {
+	m.replaced++
+	m.UpdateFiles(files)
+}
+
 func dummyDebug(format string, args ...interface{}) {}
 
 func fi(path string, name string, size int64, mod time.Time) fileinfo.FileInfo {
@@ -253,6 +260,34 @@ func TestQueueSnapshotChangesAdvancesExpectedBaseline(t *testing.T) {
 	}
 }
 
+func TestQueueSnapshotChangesSkipsFilteredEntries(t *testing.T) {
+	m := &mockFM{path: "."}
+	dw := NewDirectoryWatcher(m, nil, dummyDebug)
+	dw.running = true
+	dw.runID = 1
+	dw.SetEntryFilter(func(file fileinfo.FileInfo) bool { return !strings.HasPrefix(file.Name, ".") })
+	queued := make(chan *PendingChanges, 2)
+	now := time.Now()
+
+	dw.queueSnapshotChanges(1, Snapshot{
+		"./.cache": fi("./.cache", ".cache", 1, now),
+	}, queued)
+	dw.queueSnapshotChanges(1, Snapshot{
+		"./.cache": fi("./.cache", ".cache", 2, now),
+		"./a.txt":  fi("./a.txt", "a.txt", 1, now),
+	}, queued)
+
+	changes := <-queued
+	if len(changes.Added) != 1 || changes.Added[0].Name != "a.txt" || len(changes.Modified) != 0 {
+		t.Fatalf("changes = %#v, want only a.txt added", changes)
+	}
+	select {
+	case extra := <-queued:
+		t.Fatalf("unexpected extra changes %#v", extra)
+	default:
+	}
+}
+
 // TestApplyDataChanges_MergesAddedDeletedModified exercises applyDataChanges'
 // new path (fyne.DoAndWait -> fm.ApplyChanges). It runs the call
 // from a spawned goroutine (not the test's own goroutine) so the fyne test
@@ -326,3 +361,113 @@ func TestApplyDataChanges_NoopWhenAllEmpty(t *testing.T) {
 		t.Fatalf("files should be untouched, got %#v", m.files)
 	}
 }
+
+func TestQueueSnapshotChangesReplacesListingAfterMassChange(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	now := time.Now()
+	m := &mockFM{path: "/tmp", files: []fileinfo.FileInfo{fi("/tmp/a.txt", "a.txt", 1, now)}}
+	dw := NewDirectoryWatcher(m, nil, dummyDebug)
+	dw.running = true
+	dw.runID = 1
+	dw.massChange = 2
+	dw.updateSnapshot()
+	queued := make(chan *PendingChanges, 2)
+
+	dw.queueSnapshotChanges(1, Snapshot{
+		"/tmp/b.txt": fi("/tmp/b.txt", "b.txt", 1, now),
+		"/tmp/c.txt": fi("/tmp/c.txt", "c.txt", 1, now),
+	}, queued)
+	changes := <-queued
+	if len(changes.Added)+len(changes.Deleted)+len(changes.Modified) != 0 || len(changes.Replace) != 2 {
+		t.Fatalf("changes = %#v, want a two-entry replacement", changes)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		dw.applyPendingChanges(1, changes)
+	}()
+	<-done
+	if m.replaced != 1 || len(m.files) != 2 {
+		t.Fatalf("replaced=%d files=%#v, want one replacement with b and c", m.replaced, m.files)
+	}
+	for _, f := range m.files {
+		if f.Status != fileinfo.StatusNormal {
+			t.Fatalf("replaced entry %s has status %v, want normal", f.Name, f.Status)
+		}
+	}
+
+	dw.queueSnapshotChanges(1, Snapshot{
+		"/tmp/b.txt": fi("/tmp/b.txt", "b.txt", 1, now),
+	}, queued)
+	if changes := <-queued; changes.Replace != nil || len(changes.Deleted) != 1 {
+		t.Fatalf("small change after replacement = %#v, want an incremental delete", changes)
+	}
+}
+
+func TestDrainPendingChangesFoldsQueuedBatches(t *testing.T) {
+	now := time.Now()
+	withStatus := func(f fileinfo.FileInfo, s fileinfo.FileStatus) fileinfo.FileInfo {
+		f.Status = s
+		return f
+	}
+	a := fi("/tmp/a.txt", "a.txt", 1, now)
+	b := fi("/tmp/b.txt", "b.txt", 1, now)
+	c := fi("/tmp/c.txt", "c.txt", 1, now)
+	old := fi("/tmp/old.txt", "old.txt", 1, now)
+
+	queued := make(chan *PendingChanges, 3)
+	queued <- &PendingChanges{
+		Added:   []fileinfo.FileInfo{withStatus(b, fileinfo.StatusAdded)},
+		Deleted: []fileinfo.FileInfo{withStatus(old, fileinfo.StatusDeleted)},
+	}
+	queued <- &PendingChanges{
+		Deleted:  []fileinfo.FileInfo{withStatus(b, fileinfo.StatusDeleted)},
+		Added:    []fileinfo.FileInfo{withStatus(old, fileinfo.StatusAdded), withStatus(c, fileinfo.StatusAdded)},
+		Modified: []fileinfo.FileInfo{withStatus(a, fileinfo.StatusModified)},
+	}
+	first := &PendingChanges{Added: []fileinfo.FileInfo{withStatus(a, fileinfo.StatusAdded)}}
+
+	merged := drainPendingChanges(first, queued)
+
+	if len(queued) != 0 {
+		t.Fatalf("%d change sets left queued, want all drained", len(queued))
+	}
+	if len(merged.Added) != 2 || merged.Added[0].Path != a.Path || merged.Added[1].Path != c.Path {
+		t.Fatalf("added = %#v, want a.txt (added then modified) and c.txt", merged.Added)
+	}
+	if len(merged.Modified) != 1 || merged.Modified[0].Path != old.Path {
+		t.Fatalf("modified = %#v, want old.txt (deleted then re-added)", merged.Modified)
+	}
+	if len(merged.Deleted) != 0 {
+		t.Fatalf("deleted = %#v, want none (b.txt came and went)", merged.Deleted)
+	}
+}
+
+func TestMergePendingChangesFoldsIntoReplacement(t *testing.T) {
+	now := time.Now()
+	a := fi("/tmp/a.txt", "a.txt", 1, now)
+	b := fi("/tmp/b.txt", "b.txt", 1, now)
+	c := fi("/tmp/c.txt", "c.txt", 1, now)
+	added := c
+	added.Status = fileinfo.StatusAdded
+
+	merged := mergePendingChanges(
+		&PendingChanges{Replace: []fileinfo.FileInfo{a, b}},
+		&PendingChanges{Added: []fileinfo.FileInfo{added}, Deleted: []fileinfo.FileInfo{a}},
+	)
+
+	if len(merged.Replace) != 2 || merged.Replace[0].Path != b.Path || merged.Replace[1].Path != c.Path {
+		t.Fatalf("replace = %#v, want b.txt and c.txt", merged.Replace)
+	}
+	if merged.Replace[1].Status != fileinfo.StatusNormal {
+		t.Fatalf("folded entry status = %v, want normal", merged.Replace[1].Status)
+	}
+
+	later := []fileinfo.FileInfo{c}
+	if got := mergePendingChanges(merged, &PendingChanges{Replace: later}); len(got.Replace) != 1 {
+		t.Fatalf("later replacement = %#v, want it to win", got)
+	}
+}
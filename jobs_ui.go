@@ -1,15 +1,16 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
-	"runtime"
 	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/widget"
 
+	"nmf/internal/config"
 	"nmf/internal/fileinfo"
 	"nmf/internal/jobs"
 	"nmf/internal/ui"
@@ -141,6 +142,49 @@ func (fm *FileManager) ShowExtractArchiveDialog() {
 	})
 }
 
+// ShowCompressDialog asks for an archive name, format, and compression level
+// and queues an archive job packing the targets into the current directory.
+func (fm *FileManager) ShowCompressDialog() {
+	targets := fm.collectTargets()
+	srcPaths := fm.collectTargetPaths()
+	if len(srcPaths) == 0 {
+		debugPrint("FileManager: No target for compress")
+		return
+	}
+	destDir := fm.currentPath
+	if fileinfo.IsTagViewPath(destDir) || fileinfo.IsArchivePath(destDir) {
+		fm.ShowMessageDialog("Compress failed", "Archives can only be created in a regular or SMB directory.")
+		return
+	}
+
+	dlg := ui.NewCompressDialog(len(srcPaths), compressBaseName(targets, destDir), fm.keyManager, fm.config.UI.KeyBindings)
+	dlg.ShowDialog(fm.window, func(opts jobs.ArchiveOptions) bool {
+		if _, err := opts.FileName(); err != nil {
+			fm.ShowMessageDialog("Compress failed", err.Error())
+			return false
+		}
+		fm.jobManager().EnqueueArchive(srcPaths, destDir, fm.conflictResolver(), opts)
+		fm.FocusFileList()
+		return true
+	})
+}
+
+// compressBaseName suggests an archive name: the single target without its
+// extension, or the current directory's name for several targets.
+func compressBaseName(targets []string, dir string) string {
+	if len(targets) == 1 {
+		name := targets[0]
+		if ext := filepath.Ext(name); ext != "" && len(ext) < len(name) {
+			name = strings.TrimSuffix(name, ext)
+		}
+		return name
+	}
+	if base := fileinfo.BaseName(dir); base != "" && base != "." && base != string(filepath.Separator) {
+		return base
+	}
+	return "archive"
+}
+
 // showCopyMoveDialog builds targets and destination candidates then shows dialog
 func (fm *FileManager) showCopyMoveDialog(op ui.Operation) {
 	// Determine targets: marked files if any; otherwise cursor item
@@ -152,31 +196,152 @@ func (fm *FileManager) showCopyMoveDialog(op ui.Operation) {
 
 	// We need full source paths for jobs, not only names — compute now
 	srcPaths := fm.collectTargetPaths()
+	targetInfos := fm.targetFileInfos()
 	fm.showTransferDestinationDialog(op, targets, func(result ui.CopyMoveResult) {
 		selectedDest := result.Destination
-		if op == ui.OpMove && sameDirectoryPath(selectedDest, fm.currentPath) {
+		// The dialog may have switched between copy, move, and symlink.
+		op := result.Operation
+		if op == ui.OpSymlink {
+			fm.jobManager().EnqueueSymlink(srcPaths, selectedDest, fm.conflictResolver())
+			fm.FocusFileList()
+			return
+		}
+		options := transferOptionsForResult(result, fm.config.UI.Copy)
+		if !result.OrganizeByDate && op == ui.OpMove && options.Layout == jobs.LayoutKeep && fileinfo.SamePath(selectedDest, fm.currentPath) {
 			debugPrint("FileManager: %s destination is current directory; no-op dest=%s", strings.Title(string(op)), selectedDest)
 			fm.FocusFileList()
 			return
 		}
+		queue := func() {
+			if result.OrganizeByDate {
+				fm.previewOrganizeByDate(op, srcPaths, selectedDest, options)
+				return
+			}
+			fm.enqueueTransfer(op, srcPaths, selectedDest, options)
+			fm.FocusFileList()
+		}
+		if op != ui.OpMove {
+			queue()
+			return
+		}
+		fm.confirmLargeOperation(targetInfos, func(size fileinfo.DirSize) *ui.BulkConfirmDialog {
+			return ui.NewBulkMoveConfirmDialog(size, selectedDest, fm.keyManager)
+		}, queue)
+	})
+}
 
-		mgr := fm.jobManager()
-		resolver := fm.conflictResolver()
-		if op == ui.OpCopy {
-			mgr.EnqueueCopyWithOptions(srcPaths, selectedDest, resolver, jobs.TransferOptions{PreserveTimestamps: result.PreserveTimestamps})
-		} else {
-			mgr.EnqueueMoveWithResolver(srcPaths, selectedDest, resolver)
+// transferOptionsForResult combines the dialog's choices with the
+// configured ownership and extended attribute preservation.
+func transferOptionsForResult(result ui.CopyMoveResult, copyConfig config.CopyConfig) jobs.TransferOptions {
+	return jobs.TransferOptions{
+		PreserveTimestamps: result.PreserveTimestamps,
+		PreserveOwnership:  copyConfig.PreserveOwnership,
+		PreserveXattrs:     copyConfig.PreserveXattrs,
+		OrganizeByDate:     result.OrganizeByDate,
+		Layout:             result.Layout,
+		RelativeBase:       result.RelativeBase,
+	}
+}
+
+func (fm *FileManager) enqueueTransfer(op ui.Operation, srcPaths []string, dest string, options jobs.TransferOptions) {
+	mgr := fm.jobManager()
+	resolver := fm.conflictResolver()
+	var j *jobs.Job
+	if op == ui.OpCopy {
+		j = mgr.EnqueueCopyWithOptions(srcPaths, dest, resolver, options)
+	} else {
+		j = mgr.EnqueueMoveWithOptions(srcPaths, dest, resolver, options)
+	}
+	fm.reportSpaceShortfall(j)
+}
+
+// reportSpaceShortfall tells the user when j fails its free-space check,
+// which it does before writing anything, rather than leaving the reason in
+// the jobs window alone.
+func (fm *FileManager) reportSpaceShortfall(j *jobs.Job) {
+	if j == nil {
+		return
+	}
+	fm.whenJobFinished(j, func(snap jobs.JobSnapshot) {
+		shortfall, ok := j.SpaceShortfall()
+		if snap.Status != jobs.StatusFailed || !ok {
+			return
 		}
-		fm.FocusFileList()
+		fyne.Do(func() {
+			if fm.isWindowClosed() {
+				return
+			}
+			fm.ShowMessageDialog("Not enough space", spaceShortfallMessage(snap.Type, shortfall))
+		})
 	})
 }
 
+func spaceShortfallMessage(typ jobs.Type, e jobs.InsufficientSpaceError) string {
+	verb := "Copying"
+	if typ == jobs.TypeMove {
+		verb = "Moving"
+	}
+	return fmt.Sprintf("%s needs %s, but only %s is free on:\n%s\n\nNothing was written.",
+		verb, fileinfo.FormatFileSize(e.Needed), fileinfo.FormatFileSize(e.Free), e.Dest)
+}
+
+// previewOrganizeByDate resolves the dated placement of srcPaths off the UI
+// goroutine and queues the organize-by-date transfer once the user confirms
+// the dry-run preview.
+func (fm *FileManager) previewOrganizeByDate(op ui.Operation, srcPaths []string, dest string, options jobs.TransferOptions) {
+	go func() {
+		plan, err := jobs.PlanOrganizeByDate(context.Background(), srcPaths, dest)
+		fyne.Do(func() {
+			if fm.isWindowClosed() {
+				return
+			}
+			if err != nil {
+				debugPrint("FileManager: organize preview failed dest=%s err=%v", dest, err)
+				fm.ShowMessageDialog("Organize failed", err.Error())
+				return
+			}
+			dlg := ui.NewOrganizePreviewDialog(op, dest, organizePreviewLines(plan), fm.keyManager)
+			dlg.ShowDialog(fm.window, func() {
+				fm.enqueueTransfer(op, srcPaths, dest, options)
+				fm.FocusFileList()
+			})
+		})
+	}()
+}
+
+// relativeBaseCandidates lists the ancestors of the current directory,
+// nearest first, for the copy/move "Relative to base" layout.
+func (fm *FileManager) relativeBaseCandidates() []string {
+	var bases []string
+	current := fm.currentPath
+	for {
+		parent := fileinfo.ParentPath(current)
+		if parent == "" || parent == current {
+			return bases
+		}
+		bases = append(bases, parent)
+		current = parent
+	}
+}
+
+// organizePreviewLines renders one "YYYY/MM/DD/name (date source)" line per
+// planned placement, relative to the destination.
+func organizePreviewLines(plan []jobs.OrganizePlanEntry) []string {
+	lines := make([]string, 0, len(plan))
+	for _, entry := range plan {
+		name := fileinfo.BaseName(entry.Source)
+		lines = append(lines, fmt.Sprintf("%s/%s (%s)", entry.Date.Format("2006/01/02"), name, entry.DateSource))
+	}
+	return lines
+}
+
 func (fm *FileManager) showTransferDestinationDialog(op ui.Operation, targets []string, onAccept func(ui.CopyMoveResult)) {
 	dest := fm.buildDestinationCandidates()
 	if len(dest) == 0 {
 		debugPrint("FileManager: No destination candidates available")
 	}
 	dlg := ui.NewCopyMoveDialog(op, targets, dest, fm.state.NavigationHistory.LastUsed, fm.config.UI.Copy.PreserveTimestamps, fm.keyManager, debugPrint, fm.searchMatchers)
+	dlg.SetRelativeBaseCandidates(fm.relativeBaseCandidates())
 	openDest := destinationCandidateOpenMap(dest)
 	refreshDestinations := func(preferredPath string) {
 		dest = fm.buildDestinationCandidates()
@@ -220,40 +385,6 @@ func (fm *FileManager) conflictResolver() jobs.ConflictResolver {
 	return fm.runtime.promptBroker.ResolveConflict
 }
 
-func sameDirectoryPath(a, b string) bool {
-	a = strings.TrimSpace(a)
-	b = strings.TrimSpace(b)
-	if a == "" || b == "" {
-		return false
-	}
-
-	resolvedA, _, errA := fileinfo.ResolvePathDisplay(a)
-	if errA == nil {
-		a = resolvedA
-	}
-	resolvedB, _, errB := fileinfo.ResolvePathDisplay(b)
-	if errB == nil {
-		b = resolvedB
-	}
-
-	return normalizeComparablePath(a) == normalizeComparablePath(b)
-}
-
-func normalizeComparablePath(p string) string {
-	p = strings.TrimSpace(p)
-	if fileinfo.IsSMBDisplay(p) {
-		p = strings.ReplaceAll(p, "\\", "/")
-		p = strings.TrimRight(p, "/")
-		return strings.ToLower(p)
-	}
-
-	cleaned := filepath.Clean(p)
-	if runtime.GOOS == "windows" {
-		cleaned = strings.ToLower(cleaned)
-	}
-	return cleaned
-}
-
 // collectTargets returns display names of targets based on selection or cursor
 func (fm *FileManager) collectTargets() []string {
 	selectedFiles := fm.selectedFileInfos()
@@ -366,6 +497,18 @@ func (fm *FileManager) buildDestinationCandidates() []ui.DestinationCandidate {
 		}
 	}
 
+	// Bookmarks come before history so saved destinations stay near the top
+	for _, b := range fm.state.GetBookmarks() {
+		if fileinfo.IsArchivePath(b.Path) {
+			continue
+		}
+		if _, ok := seen[b.Path]; ok {
+			continue
+		}
+		seen[b.Path] = len(candidates)
+		candidates = append(candidates, ui.DestinationCandidate{Path: b.Path})
+	}
+
 	// Append navigation history skipping dups
 	for _, p := range fm.state.GetNavigationHistory() {
 		if fileinfo.IsArchivePath(p) {
@@ -1,11 +1,15 @@
 package main
 
 import (
+	"path/filepath"
 	"testing"
 	"time"
 
 	"fyne.io/fyne/v2/test"
 	"fyne.io/fyne/v2/widget"
+
+	"nmf/internal/config"
+	"nmf/internal/jobs"
 )
 
 func TestJobsButtonText(t *testing.T) {
@@ -29,6 +33,27 @@ func TestJobsButtonText(t *testing.T) {
 	}
 }
 
+func TestCompressBaseName(t *testing.T) {
+	dir := filepath.Join(string(filepath.Separator), "home", "photos")
+	tests := []struct {
+		targets []string
+		dir     string
+		want    string
+	}{
+		{[]string{"report.pdf"}, dir, "report"},
+		{[]string{"src"}, dir, "src"},
+		{[]string{".bashrc"}, dir, ".bashrc"},
+		{[]string{"a.jpg", "b.jpg"}, dir, "photos"},
+		{[]string{"a", "b"}, "smb://host/share", "share"},
+		{[]string{"a", "b"}, string(filepath.Separator), "archive"},
+	}
+	for _, tt := range tests {
+		if got := compressBaseName(tt.targets, tt.dir); got != tt.want {
+			t.Errorf("compressBaseName(%v, %q) = %q, want %q", tt.targets, tt.dir, got, tt.want)
+		}
+	}
+}
+
 func TestJobsBlinkDropsTicksAfterWindowClose(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
@@ -47,3 +72,64 @@ func TestJobsBlinkDropsTicksAfterWindowClose(t *testing.T) {
 		t.Fatalf("closed window jobs importance = %v, want unchanged", fm.jobsButton.Importance)
 	}
 }
+
+func TestOrganizePreviewLinesShowDatedPlacement(t *testing.T) {
+	plan := []jobs.OrganizePlanEntry{
+		{Source: "/pics/a.jpg", Date: time.Date(2024, 3, 9, 10, 0, 0, 0, time.Local), DateSource: jobs.OrganizeDateTaken},
+		{Source: "/pics/notes.txt", Date: time.Date(2023, 12, 1, 8, 0, 0, 0, time.Local), DateSource: jobs.OrganizeDateModified},
+	}
+
+	got := organizePreviewLines(plan)
+
+	want := []string{"2024/03/09/a.jpg (taken)", "2023/12/01/notes.txt (modified)"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("lines = %q, want %q", got, want)
+	}
+}
+
+func TestRelativeBaseCandidatesListAncestorsNearestFirst(t *testing.T) {
+	root := filepath.VolumeName(t.TempDir()) + string(filepath.Separator)
+	current := filepath.Join(root, "home", "u", "photos")
+	fm := &FileManager{currentPath: current}
+
+	got := fm.relativeBaseCandidates()
+
+	want := []string{filepath.Join(root, "home", "u"), filepath.Join(root, "home"), root}
+	if len(got) != len(want) {
+		t.Fatalf("candidates = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("candidates = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestBuildDestinationCandidatesPlacesBookmarksBeforeHistory(t *testing.T) {
+	resetFileManagerWindowTestRegistry(t)
+
+	st := &config.State{}
+	st.SetBookmarks([]config.Bookmark{
+		{Name: "here", Path: "/work"},
+		{Name: "backup", Path: "/backup"},
+		{Name: "zip", Path: "/a.zip!/inner"},
+	})
+	st.AddToNavigationHistory("/history", 10)
+	st.AddToNavigationHistory("/backup", 10)
+	fm := &FileManager{currentPath: "/work", state: st}
+
+	got := fm.buildDestinationCandidates()
+	var paths []string
+	for _, c := range got {
+		paths = append(paths, c.Path)
+	}
+	want := []string{"/work", "/backup", "/history"}
+	if len(paths) != len(want) {
+		t.Fatalf("candidates = %v, want %v", paths, want)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Fatalf("candidates = %v, want %v", paths, want)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"fyne.io/fyne/v2"
 
+	"nmf/internal/jobs"
 	"nmf/internal/ui"
 )
 
@@ -18,6 +19,7 @@ type JobsWindowController struct {
 	app        fyne.App
 	debugPrint func(format string, args ...interface{})
 	window     *ui.JobsWindow
+	resolver   jobs.ConflictResolver
 }
 
 // NewJobsWindowController creates a controller for the shared Jobs window.
@@ -25,11 +27,21 @@ func NewJobsWindowController(app fyne.App, debugPrint func(format string, args .
 	return &JobsWindowController{app: app, debugPrint: debugPrint}
 }
 
+// SetConflictResolver sets the collision resolver for jobs rerun from the
+// Jobs window.
+func (c *JobsWindowController) SetConflictResolver(resolver jobs.ConflictResolver) {
+	c.resolver = resolver
+	if c.window != nil {
+		c.window.SetConflictResolver(resolver)
+	}
+}
+
 // Show lazily creates (or recreates, if the previous one was closed) the
 // shared Jobs window and brings it to the front.
 func (c *JobsWindowController) Show() {
 	if c.window == nil || c.window.Closed() {
 		c.window = ui.NewJobsWindow(c.app, c.debugPrint)
+		c.window.SetConflictResolver(c.resolver)
 		current := c.window
 		c.window.SetOnClosed(func() {
 			if c.window == current {
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"nmf/internal/config"
+	"nmf/internal/fileinfo"
+	"nmf/internal/keymanager"
+)
+
+// openLinkedDirectory opens a symlinked directory as ui.openLinks says:
+// through the link's own path, at the directory it resolves to, or by asking
+// with a menu of both. Links whose physical path cannot be resolved, or is
+// the same, just open.
+func (fm *FileManager) openLinkedDirectory(file *fileinfo.FileInfo) {
+	physical, err := fileinfo.PhysicalPath(file.Path)
+	if err != nil || fileinfo.SamePath(physical, file.Path) {
+		if err != nil {
+			debugPrint("FileManager: Physical path unavailable path=%s err=%v", file.Path, err)
+		}
+		fm.LoadDirectory(file.Path)
+		return
+	}
+	switch fm.config.UI.OpenLinks {
+	case config.OpenLinksFollow:
+		fm.LoadDirectory(file.Path)
+	case config.OpenLinksPhysical:
+		fm.LoadDirectory(physical)
+	default:
+		fm.showCommandMenu(linkedDirectoryMenuItems(file.Path, physical, fm.LoadDirectory))
+	}
+}
+
+// linkedDirectoryMenuItems offers the two ways into a symlinked directory.
+func linkedDirectoryMenuItems(link, physical string, open func(string)) []keymanager.CommandMenuItem {
+	return []keymanager.CommandMenuItem{
+		{Label: "Follow link: " + link, Key: "F", Action: func() { open(link) }},
+		{Label: "Open physical path: " + physical, Key: "P", Action: func() { open(physical) }},
+	}
+}
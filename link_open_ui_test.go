@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	"nmf/internal/fileinfo"
+)
+
+func TestLinkedDirectoryMenuOffersLinkAndPhysicalPaths(t *testing.T) {
+	var opened []string
+	items := linkedDirectoryMenuItems("/home/u/docs", "/data/docs", func(p string) { opened = append(opened, p) })
+	if len(items) != 2 || items[0].Key != "F" || items[1].Key != "P" {
+		t.Fatalf("items = %+v, want follow (F) then physical (P)", items)
+	}
+	items[0].Action()
+	items[1].Action()
+	if len(opened) != 2 || opened[0] != "/home/u/docs" || opened[1] != "/data/docs" {
+		t.Fatalf("opened = %v, want link path then physical path", opened)
+	}
+}
+
+func TestWithLinkTargetPrefixesSymlinkInfo(t *testing.T) {
+	if got := withLinkTarget(fileinfo.FileInfo{LinkTarget: "../shared"}, "1.0 KB 2024-01-02 03:04:05"); got != "→ ../shared  1.0 KB 2024-01-02 03:04:05" {
+		t.Fatalf("link info = %q", got)
+	}
+	if got := withLinkTarget(fileinfo.FileInfo{}, "info"); got != "info" {
+		t.Fatalf("plain info = %q, want unchanged", got)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"fyne.io/fyne/v2/theme"
@@ -68,16 +69,22 @@ func (fm *FileManager) RefreshCursor() {
 	seq, cursorIdx := fm.beginCursorRefresh("cursor")
 	if cursorIdx < 0 {
 		// No cursor: refresh to clear any stale cursor decoration.
-		fm.fileList.Refresh()
+		fm.refreshFileList()
 		fm.endCursorRefresh(seq, "cursor", cursorIdx)
+		fm.updatePreviewPane()
 		return
 	}
 	// Fyne v2.8.0 List.ScrollTo unconditionally ends with a full Refresh(), so
 	// an explicit Refresh here would double the per-keypress render cost.
-	// Re-verify on Fyne upgrades.
-	scrollTarget := fm.cursorScrollTarget(cursorIdx, moveDirection)
-	fm.fileList.ScrollTo(widget.ListItemID(scrollTarget))
+	// Re-verify on Fyne upgrades. GridWrap.ScrollTo refreshes the same way.
+	if fm.thumbnailMode && fm.fileGrid != nil {
+		fm.fileGrid.ScrollTo(widget.GridWrapItemID(cursorIdx))
+	} else {
+		scrollTarget := fm.cursorScrollTarget(cursorIdx, moveDirection)
+		fm.fileList.ScrollTo(widget.ListItemID(scrollTarget))
+	}
 	fm.endCursorRefresh(seq, "cursor", cursorIdx)
+	fm.updatePreviewPane()
 }
 
 // cursorScrollTarget returns the look-ahead row that keeps the cursor away
@@ -130,6 +137,28 @@ func (fm *FileManager) effectiveScrollMargin() (margin int, itemHeight, rowStrid
 	return min(fm.config.UI.ScrollMargin, maxMargin), itemHeight, rowStride
 }
 
+// restoreListScrollOffset scrolls the list back to a remembered offset so a
+// revisited directory shows the same window of rows. It runs after
+// refreshListAndCursor, whose cursor scroll stands when the cursor row would
+// not be fully visible at the remembered offset (the listing changed, or the
+// window is shorter now).
+func (fm *FileManager) restoreListScrollOffset(offset float32) {
+	if fm.thumbnailMode || fm.fileList == nil || fm.fileListItemHeight <= 0 {
+		return
+	}
+	cursorIdx := fm.GetCurrentCursorIndex()
+	if cursorIdx < 0 {
+		return
+	}
+	rowStride := fm.fileListItemHeight + fm.fileList.Theme().Size(theme.SizeNamePadding)
+	cursorTop := float32(cursorIdx) * rowStride
+	if cursorTop < offset || cursorTop+fm.fileListItemHeight > offset+fm.fileList.Size().Height {
+		return
+	}
+	fm.fileList.ScrollToOffset(offset)
+	fm.scheduleIconPrefetch()
+}
+
 // refreshListAndCursor refreshes the list after fm.files was replaced, then
 // scrolls to the cursor. The leading Refresh is load-bearing, not redundant:
 // ScrollTo clamps its offset against the scroller's *current* content size,
@@ -147,11 +176,16 @@ func (fm *FileManager) refreshListAndCursor() {
 	// a margin, and do not leak the pending direction into a later refresh.
 	fm.cursorMoveDirection = 0
 	seq, cursorIdx := fm.beginCursorRefresh("list")
-	fm.fileList.Refresh()
+	fm.refreshFileList()
 	if cursorIdx >= 0 {
-		fm.fileList.ScrollTo(widget.ListItemID(cursorIdx))
+		if fm.thumbnailMode && fm.fileGrid != nil {
+			fm.fileGrid.ScrollTo(widget.GridWrapItemID(cursorIdx))
+		} else {
+			fm.fileList.ScrollTo(widget.ListItemID(cursorIdx))
+		}
 	}
 	fm.endCursorRefresh(seq, "list", cursorIdx)
+	fm.updatePreviewPane()
 }
 
 // beginCursorRefresh starts a diagnostic sequence without changing refresh
@@ -199,20 +233,21 @@ func (fm *FileManager) FileAt(index int) (fileinfo.FileInfo, bool) {
 	return fm.files[index], true
 }
 
-// GetSelectedFiles returns the map of selected files.
+// GetSelectedFiles returns a copy of the marked paths.
 func (fm *FileManager) GetSelectedFiles() map[string]bool {
-	return fm.selectedFiles
+	return fm.selection.Snapshot()
 }
 
-// SetFileSelected sets the selection state of a file.
+// SetFileSelected sets the selection state of a file. The status bars redraw
+// through the selection observer, once per batch of changes (see
+// onSelectionChanged).
 func (fm *FileManager) SetFileSelected(path string, selected bool) {
-	fm.selectedFiles[path] = selected
-	fm.updateStatusBar()
+	fm.selection.Set(path, selected)
 }
 
 // RefreshFileList refreshes the file list display.
 func (fm *FileManager) RefreshFileList() {
-	fm.fileList.Refresh()
+	fm.refreshFileList()
 	fm.updateStatusBar()
 }
 
@@ -245,6 +280,14 @@ func (fm *FileManager) ShowFilterDialog() {
 	}
 
 	filterDialog := ui.NewFilterDialog(entries, currentFiles, fm.keyManager, debugPrint, fm.searchMatchers)
+	filterDialog.SetPresets(fm.filterPresets(), func(preset config.FilterPreset) {
+		fm.state.SaveFilterPreset(preset)
+		if fm.stateManager != nil {
+			if err := fm.stateManager.SaveAsync(fm.state); err != nil {
+				debugPrint("FileManager: Error saving filter preset: %v", err)
+			}
+		}
+	}, fm.config.UI.KeyBindings)
 	filterDialog.ShowDialog(fm.window, func(selectedEntry *config.FilterEntry) {
 		if selectedEntry != nil {
 			debugPrint("FileManager: filter dialog selected pattern=%s focused=%s", selectedEntry.Pattern, focusedObjectLabel(fm.window))
@@ -253,7 +296,11 @@ func (fm *FileManager) ShowFilterDialog() {
 		}
 		fm.focusFileList("filter-dialog-closed")
 	}, func(pattern string) {
-		if fm.state.RemoveFileFilterEntry(pattern) && fm.stateManager != nil {
+		removed := fm.state.RemoveFileFilterEntry(pattern)
+		if name, ok := config.FilterPresetName(pattern); ok && fm.state.RemoveFilterPreset(name) {
+			removed = true
+		}
+		if removed && fm.stateManager != nil {
 			if err := fm.stateManager.SaveAsync(fm.state); err != nil {
 				debugPrint("FileManager: Error saving filter history deletion: %v", err)
 			}
@@ -274,6 +321,12 @@ func (fm *FileManager) ApplyFilter(entry *config.FilterEntry) {
 		debugPrint("FileManager: Invalid filter pattern '%s': %v", effectivePattern, err)
 		return
 	}
+	if name, ok := config.FilterPresetName(effectivePattern); ok {
+		if _, found := config.FindFilterPreset(fm.filterPresets(), name); !found {
+			debugPrint("FileManager: Unknown filter preset '%s'", name)
+			return
+		}
+	}
 
 	fm.currentFilter = entry
 	fm.state.FileFilter.Current = entry
@@ -293,7 +346,7 @@ func (fm *FileManager) ApplyFilter(entry *config.FilterEntry) {
 	}
 
 	// Apply filter
-	filtered, err := fileinfo.FilterFiles(baseFiles, effectivePattern)
+	filtered, err := fm.filterFiles(baseFiles, effectivePattern)
 	if err != nil {
 		debugPrint("FileManager: Filter error: %v", err)
 		return
@@ -331,7 +384,7 @@ func (fm *FileManager) ClearFilter() {
 		fm.sortFilesWithConfig(fm.CurrentSort())
 
 		// Update UI
-		fm.fileList.Refresh()
+		fm.refreshFileList()
 		fm.updateStatusBar()
 	}
 
@@ -363,13 +416,33 @@ func (fm *FileManager) DisableFilter() {
 		fm.sortFilesWithConfig(fm.CurrentSort())
 
 		// Update UI
-		fm.fileList.Refresh()
+		fm.refreshFileList()
 		fm.updateStatusBar()
 	}
 
 	debugPrint("FileManager: Filter disabled, showing all %d files", len(fm.files))
 }
 
+// filterFiles applies a filter pattern to files; "preset:<name>" patterns
+// resolve against filterPresets.
+func (fm *FileManager) filterFiles(files []fileinfo.FileInfo, pattern string) ([]fileinfo.FileInfo, error) {
+	return fileinfo.FilterFilesWithPresets(files, pattern, fm.filterPresets())
+}
+
+// filterPresets returns the presets from config.json followed by those saved
+// from the Filter dialog.
+func (fm *FileManager) filterPresets() []config.FilterPreset {
+	var saved []config.FilterPreset
+	if fm.state != nil {
+		saved = fm.state.FileFilter.Presets
+	}
+	var configured []config.FilterPreset
+	if fm.config != nil {
+		configured = fm.config.UI.FileFilter.Presets
+	}
+	return config.MergeFilterPresets(configured, saved)
+}
+
 // saveFilterToHistory saves a filter entry to the history.
 func (fm *FileManager) saveFilterToHistory(entry *config.FilterEntry) {
 	if entry == nil || entry.Pattern == "" || config.EffectiveFilterPattern(entry.Pattern) == "" {
@@ -412,29 +485,21 @@ func (fm *FileManager) ShowIncrementalSearchDialog() {
 func (fm *FileManager) ShowSortDialog() {
 	debugPrint("FileManager: Showing sort dialog")
 
-	// Get current sort configuration
+	// Get current sort configuration; a sort saved for this directory wins
 	currentConfig := fm.state.EffectiveSort(fm.config.UI.Sort)
+	view, directoryOnly := fm.state.LookupDirectoryView(fm.vaultCipherPath(fm.currentPath))
+	if directoryOnly {
+		currentConfig = view.Sort
+	}
 
 	// Create sort dialog
 	sortDialog := ui.NewSortDialog(currentConfig, fm.keyManager, debugPrint)
+	sortDialog.SetDirectoryOnly(directoryOnly)
 
 	// Set up apply callback
 	sortDialog.SetOnApply(func(sortConfig config.SortConfig) {
-		debugPrint("FileManager: Applying sort configuration: %+v", sortConfig)
-
-		// Persist the applied sort as a state override
-		appliedSort := sortConfig
-		fm.state.Sort = &appliedSort
-
-		// Save state to file
-		if fm.stateManager != nil {
-			if err := fm.stateManager.SaveAsync(fm.state); err != nil {
-				debugPrint("FileManager: Failed to save sort state: %v", err)
-			}
-		}
-
-		fm.applySort(sortConfig)
-
+		debugPrint("FileManager: Applying sort configuration: %+v directoryOnly=%t", sortConfig, sortDialog.DirectoryOnly())
+		fm.saveSortChoice(sortConfig, sortDialog.DirectoryOnly())
 		debugPrint("FileManager: Sort configuration applied successfully")
 	})
 
@@ -484,6 +549,8 @@ func (fm *FileManager) setPathDisplay(path string) {
 	if fm.pathDisplay != nil {
 		fm.pathDisplay.SetText(path)
 	}
+	fm.updateConnectionIndicator(path)
+	fm.updateTreePane(path)
 }
 
 // ShowIncrementalSearchOverlay shows the search overlay.
@@ -575,6 +642,10 @@ func (fm *FileManager) OpenFile(file *fileinfo.FileInfo) {
 	if file == nil {
 		return
 	}
+	if file.IsDir && file.FileType == fileinfo.FileTypeSymlink {
+		fm.openLinkedDirectory(file)
+		return
+	}
 	if file.IsDir {
 		// Use the path provided in listing to handle parent (..) and SMB display paths correctly
 		fm.LoadDirectory(file.Path)
@@ -592,12 +663,7 @@ func (fm *FileManager) OpenFile(file *fileinfo.FileInfo) {
 	}
 
 	// Regular file: try to open with associated application
-	if err := fileinfo.OpenWithDefaultApp(file.Path); err != nil {
-		debugPrint("FileManager: Failed to open file '%s': %v", file.Path, err)
-		fm.resetKeyStateAfterExternalOpen("open-file-error")
-		fm.ShowMessageDialog("ファイルを開けませんでした", err.Error())
-		return
-	}
+	fm.openWithDefaultApp(file.Path, file.Size, "open-file-error")
 }
 
 // OpenFileDefaultApp opens a file with the system default app, or navigates into a directory.
@@ -609,12 +675,40 @@ func (fm *FileManager) OpenFileDefaultApp(file *fileinfo.FileInfo) {
 		fm.LoadDirectory(file.Path)
 		return
 	}
-	if err := fileinfo.OpenWithDefaultApp(file.Path); err != nil {
-		debugPrint("FileManager: Failed to open file with default app '%s': %v", file.Path, err)
-		fm.resetKeyStateAfterExternalOpen("open-default-app-error")
-		fm.ShowMessageDialog("ファイルを開けませんでした", err.Error())
+	fm.openWithDefaultApp(file.Path, file.Size, "open-default-app-error")
+}
+
+// openWithDefaultApp launches path with its associated application. Files
+// larger than ui.largeFileWarnMB ask first, so a stray Enter on a huge video
+// or database on a share does not start a long transfer.
+func (fm *FileManager) openWithDefaultApp(path string, size int64, errorLabel string) {
+	launch := func() {
+		if err := fileinfo.OpenWithDefaultApp(path); err != nil {
+			debugPrint("FileManager: Failed to open file with default app '%s': %v", path, err)
+			fm.resetKeyStateAfterExternalOpen(errorLabel)
+			fm.ShowMessageDialog("ファイルを開けませんでした", err.Error())
+		}
+	}
+	if !exceedsLargeFileWarning(size, fm.config.UI.LargeFileWarnMB) {
+		launch()
 		return
 	}
+	debugPrint("FileManager: Large file open needs confirmation path=%s size=%d", path, size)
+	fm.showCommandMenu(largeFileOpenMenuItems(size, launch))
+}
+
+// exceedsLargeFileWarning reports whether size is above thresholdMB MiB.
+// A zero threshold never warns.
+func exceedsLargeFileWarning(size int64, thresholdMB int) bool {
+	return thresholdMB > 0 && size > int64(thresholdMB)<<20
+}
+
+// largeFileOpenMenuItems asks whether to open a file of size anyway.
+func largeFileOpenMenuItems(size int64, open func()) []keymanager.CommandMenuItem {
+	return []keymanager.CommandMenuItem{
+		{Label: "This file is " + fileinfo.FormatFileSize(size) + " - open anyway", Key: "O", Action: open},
+		{Label: "Don't open", Key: "N", Action: func() {}},
+	}
 }
 
 func (fm *FileManager) resetKeyStateAfterExternalOpen(label string) {
@@ -636,10 +730,64 @@ func (fm *FileManager) SetCursorToFile(file *fileinfo.FileInfo) {
 }
 
 func (fm *FileManager) sortFilesWithConfig(sortConfig config.SortConfig) {
+	fm.sortFilesWithDates(sortConfig, fm.dateTakenResolver(fm.captureDates, true))
+}
+
+func (fm *FileManager) sortFilesWithDates(sortConfig config.SortConfig, dateTaken func(fileinfo.FileInfo) time.Time) {
 	debugPrint("FileManager: Sorting files: sortBy=%s, order=%s, dirFirst=%t",
 		sortConfig.SortBy, sortConfig.SortOrder, sortConfig.DirectoriesFirst)
 
-	fm.files = sortFileInfoSlice(fm.files, sortConfig)
+	fm.files = sortFileInfoSliceWithDates(fm.files, sortConfig, dateTaken)
+}
+
+// usesDateTaken reports whether sortConfig sorts or groups by capture date.
+func usesDateTaken(sortConfig config.SortConfig) bool {
+	return sortConfig.SortBy == "dateTaken" || sortConfig.GroupBy == "dateTaken"
+}
+
+// readsCaptureDates reports whether date-taken sorting and grouping read
+// capture dates from the files in dir. Network shares and devices keep
+// modification times, since reading every photo's header there would cost a
+// round trip per file. It looks at the mount table, so loads call it off the
+// UI thread and keep the answer with the listing.
+func readsCaptureDates(dir string) bool {
+	class, err := fileinfo.ClassifyPath(dir)
+	return err == nil && !class.Network && !class.Device
+}
+
+// dateTakenResolver returns the capture-date lookup used by "dateTaken"
+// sorting and grouping, or nil when no metadata service is available or read
+// is false (see readsCaptureDates). It never reads a file itself: images
+// missing from the metadata cache sort by modification time, and with
+// request set they are queued for the background parser, after which the
+// update callback re-sorts with their capture dates. That re-sort passes
+// request false, so it cannot queue more work. Other files always use their
+// modification time. The lookup is safe to call from background directory
+// loads.
+func (fm *FileManager) dateTakenResolver(read, request bool) func(fileinfo.FileInfo) time.Time {
+	svc := fm.metadataSvc
+	if svc == nil || !read {
+		return nil
+	}
+	lookup := svc.GetCached
+	if request {
+		lookup = svc.GetCachedOrRequest
+	}
+	return func(file fileinfo.FileInfo) time.Time {
+		if fileinfo.MediaKindForName(file.Name) != fileinfo.MediaKindImage {
+			return file.Modified
+		}
+		if meta, ok := lookup(file); ok && !meta.DateTaken.IsZero() {
+			return meta.DateTaken
+		}
+		return file.Modified
+	}
+}
+
+// captureDay numbers the calendar day of t, for "dateTaken" grouping.
+func captureDay(t time.Time) int {
+	year, month, day := t.Date()
+	return year*10000 + int(month)*100 + day
 }
 
 // sortFileInfoSlice returns files reordered per sortConfig. It pins ".." at
@@ -648,6 +796,12 @@ func (fm *FileManager) sortFilesWithConfig(sortConfig config.SortConfig) {
 // parent entry together. It touches no FileManager state, so it is safe to
 // call from a background goroutine (see loadDirectoryAsync).
 func sortFileInfoSlice(files []fileinfo.FileInfo, sortConfig config.SortConfig) []fileinfo.FileInfo {
+	return sortFileInfoSliceWithDates(files, sortConfig, nil)
+}
+
+// sortFileInfoSliceWithDates is sortFileInfoSlice with a capture-date lookup
+// for the "dateTaken" key. A nil dateTaken falls back to modification times.
+func sortFileInfoSliceWithDates(files []fileinfo.FileInfo, sortConfig config.SortConfig, dateTaken func(fileinfo.FileInfo) time.Time) []fileinfo.FileInfo {
 	if len(files) <= 1 {
 		return files // No need to sort 0 or 1 items
 	}
@@ -669,8 +823,8 @@ func sortFileInfoSlice(files []fileinfo.FileInfo, sortConfig config.SortConfig)
 		}
 
 		// Sort directories and files separately
-		sortSlice(dirs, sortConfig)
-		sortSlice(regularFiles, sortConfig)
+		sortSliceWithDates(dirs, sortConfig, dateTaken)
+		sortSliceWithDates(regularFiles, sortConfig, dateTaken)
 
 		// Rebuild the files slice: parent directory first, then sorted directories, then sorted files
 		newFiles := make([]fileinfo.FileInfo, 0, len(files))
@@ -698,7 +852,7 @@ func sortFileInfoSlice(files []fileinfo.FileInfo, sortConfig config.SortConfig)
 		}
 	}
 
-	sortSlice(regularFiles, sortConfig)
+	sortSliceWithDates(regularFiles, sortConfig, dateTaken)
 
 	// Rebuild with parent directory first if it exists
 	newFiles := make([]fileinfo.FileInfo, 0, len(files))
@@ -710,11 +864,47 @@ func sortFileInfoSlice(files []fileinfo.FileInfo, sortConfig config.SortConfig)
 	return newFiles
 }
 
+// applyPersistentSort saves sortConfig and applies it. It stays a
+// directory's own sort when the current directory has one saved, and becomes
+// the window's sort otherwise.
+func (fm *FileManager) applyPersistentSort(sortConfig config.SortConfig) {
+	_, directoryOnly := fm.state.LookupDirectoryView(fm.vaultCipherPath(fm.currentPath))
+	fm.saveSortChoice(sortConfig, directoryOnly)
+}
+
+// saveSortChoice saves a sort picked in the Sort dialog or the column header
+// and applies it. With directoryOnly it is saved for the current directory
+// alone, which later loads of that directory use ahead of view profiles and
+// the window's sort; without, it becomes the window's sort and any sort saved
+// for the directory is dropped.
+func (fm *FileManager) saveSortChoice(sortConfig config.SortConfig, directoryOnly bool) {
+	dir := fm.vaultCipherPath(fm.currentPath)
+	if directoryOnly {
+		fm.state.SetDirectoryView(dir, config.DirectoryView{Sort: sortConfig}, fm.config.UI.DirectoryViews.MaxEntries)
+	} else {
+		fm.state.ClearDirectoryView(dir)
+		appliedSort := sortConfig
+		fm.state.Sort = &appliedSort
+	}
+	if fm.stateManager != nil {
+		if err := fm.stateManager.SaveAsync(fm.state); err != nil {
+			debugPrint("FileManager: Failed to save sort state: %v", err)
+		}
+	}
+	fm.applySort(sortConfig)
+}
+
 func (fm *FileManager) applySort(sortConfig config.SortConfig) {
+	fm.applySortWithDates(sortConfig, fm.dateTakenResolver(fm.captureDates, true))
+}
+
+// applySortWithDates is applySort with the capture-date lookup given, which
+// the metadata update callback uses to re-sort from the cache alone.
+func (fm *FileManager) applySortWithDates(sortConfig config.SortConfig, dateTaken func(fileinfo.FileInfo) time.Time) {
 	currentPath := fm.cursorPath
-	fm.activeSort = sortConfig
+	fm.setActiveSort(sortConfig)
 
-	fm.sortFilesWithConfig(sortConfig)
+	fm.sortFilesWithDates(sortConfig, dateTaken)
 
 	if currentPath != "" {
 		fm.cursorPath = currentPath
@@ -732,10 +922,15 @@ func (fm *FileManager) applySort(sortConfig config.SortConfig) {
 
 // sortKey precomputes the lowercase comparison keys for a file so sortSlice
 // avoids recomputing strings.ToLower/filepath.Ext on every comparison.
+// nameKey is the natural or collation key when the sort asks for one, else
+// lowerName itself.
 type sortKey struct {
 	file      fileinfo.FileInfo
 	lowerName string
+	nameKey   string
 	lowerExt  string
+	taken     time.Time
+	day       int // captureDay of taken when grouping by date taken
 }
 
 // sortSlice sorts a slice of FileInfo according to the sort configuration.
@@ -743,54 +938,134 @@ type sortKey struct {
 // decorated slice once, then writes the reordered files back. It touches no
 // FileManager state, so it is safe to call from a background goroutine.
 func sortSlice(files []fileinfo.FileInfo, sortConfig config.SortConfig) {
+	sortSliceWithDates(files, sortConfig, nil)
+}
+
+func sortSliceWithDates(files []fileinfo.FileInfo, sortConfig config.SortConfig, dateTaken func(fileinfo.FileInfo) time.Time) {
 	if len(files) <= 1 {
 		return
 	}
 
+	nameKey := newNameKeyer(sortConfig)
 	keys := make([]sortKey, len(files))
 	for i, file := range files {
-		k := sortKey{file: file, lowerName: strings.ToLower(file.Name)}
-		if sortConfig.SortBy == "extension" {
-			k.lowerExt = strings.ToLower(filepath.Ext(file.Name))
-		}
-		keys[i] = k
+		keys[i] = newSortKey(file, sortConfig, nameKey, dateTaken)
 	}
 
-	desc := sortConfig.SortOrder == "desc"
-
 	slices.SortFunc(keys, func(a, b sortKey) int {
-		var c int
-		switch sortConfig.SortBy {
-		case "size":
-			c = cmp.Compare(a.file.Size, b.file.Size)
-		case "modified":
-			c = a.file.Modified.Compare(b.file.Modified)
-		case "extension":
-			// Files without extensions come first
-			switch {
-			case a.lowerExt == "" && b.lowerExt != "":
-				c = -1
-			case a.lowerExt != "" && b.lowerExt == "":
-				c = 1
-			default:
-				c = cmp.Compare(a.lowerExt, b.lowerExt)
-				// If extensions are the same, sort by name
-				if c == 0 {
-					c = cmp.Compare(a.lowerName, b.lowerName)
-				}
-			}
+		return compareSortKeys(a, b, sortConfig)
+	})
+
+	for i, k := range keys {
+		files[i] = k.file
+	}
+}
+
+func newSortKey(file fileinfo.FileInfo, sortConfig config.SortConfig, nameKey nameKeyer, dateTaken func(fileinfo.FileInfo) time.Time) sortKey {
+	k := sortKey{file: file, lowerName: strings.ToLower(file.Name)}
+	k.nameKey = k.lowerName
+	if nameKey != nil {
+		k.nameKey = nameKey(file.Name)
+	}
+	if sortConfig.SortBy == "extension" {
+		k.lowerExt = strings.ToLower(filepath.Ext(file.Name))
+	}
+	if usesDateTaken(sortConfig) {
+		k.taken = file.Modified
+		if dateTaken != nil && !file.IsDir {
+			k.taken = dateTaken(file)
+		}
+	}
+	if sortConfig.GroupBy == "dateTaken" {
+		k.day = captureDay(k.taken)
+	}
+	return k
+}
+
+// compareSortKeys orders two entries by sortConfig's key and direction. It
+// does not look at ".." or DirectoriesFirst; sortFileInfoSliceWithDates and
+// mergeSortedFiles handle those groups around it. Grouping by date taken
+// orders the capture days first and applies the key within each day.
+// Entries equal under the key fall back to their names, then to the exact
+// name and path, so the order is total: the unstable full sort and the
+// streamed merge agree.
+func compareSortKeys(a, b sortKey, sortConfig config.SortConfig) int {
+	var c int
+	switch sortConfig.SortBy {
+	case "size":
+		c = cmp.Compare(a.file.Size, b.file.Size)
+	case "modified":
+		c = a.file.Modified.Compare(b.file.Modified)
+	case "dateTaken":
+		c = a.taken.Compare(b.taken)
+	case "tag":
+		// Tagged entries come first in palette order, untagged last
+		c = cmp.Compare(tagSortRank(a.file.ColorTag), tagSortRank(b.file.ColorTag))
+	case "extension":
+		// Files without extensions come first
+		switch {
+		case a.lowerExt == "" && b.lowerExt != "":
+			c = -1
+		case a.lowerExt != "" && b.lowerExt == "":
+			c = 1
 		default:
-			// "name" and unknown SortBy default to name sorting
-			c = cmp.Compare(a.lowerName, b.lowerName)
+			c = cmp.Compare(a.lowerExt, b.lowerExt)
 		}
+	default:
+		// "name" and unknown SortBy default to name sorting
+	}
+	if sortConfig.GroupBy == "dateTaken" {
+		c = cmp.Or(cmp.Compare(a.day, b.day), c)
+	}
+	if c == 0 {
+		c = compareNameKeys(a, b)
+	}
+	if c == 0 {
+		c = cmp.Compare(a.file.Name, b.file.Name)
+	}
+	if c == 0 {
+		c = cmp.Compare(a.file.Path, b.file.Path)
+	}
+
+	if sortConfig.SortOrder == "desc" {
+		c = -c
+	}
+	return c
+}
 
-		if desc {
-			c = -c
+// mergeSortedFiles merges two listings that sortFileInfoSliceWithDates has
+// already ordered with the same config, keeping ".." first and, with
+// DirectoriesFirst, directories before files. Streamed directory loads use it
+// to fold each sorted chunk into the listing in linear time.
+func mergeSortedFiles(a, b []fileinfo.FileInfo, sortConfig config.SortConfig, dateTaken func(fileinfo.FileInfo) time.Time) []fileinfo.FileInfo {
+	nameKey := newNameKeyer(sortConfig)
+	before := func(x, y fileinfo.FileInfo) bool {
+		if (x.Name == "..") != (y.Name == "..") {
+			return x.Name == ".."
 		}
-		return c
-	})
+		if sortConfig.DirectoriesFirst && x.IsDir != y.IsDir {
+			return x.IsDir
+		}
+		return compareSortKeys(newSortKey(x, sortConfig, nameKey, dateTaken), newSortKey(y, sortConfig, nameKey, dateTaken), sortConfig) < 0
+	}
+	merged := make([]fileinfo.FileInfo, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if before(b[j], a[i]) {
+			merged = append(merged, b[j])
+			j++
+		} else {
+			merged = append(merged, a[i])
+			i++
+		}
+	}
+	merged = append(merged, a[i:]...)
+	return append(merged, b[j:]...)
+}
 
-	for i, k := range keys {
-		files[i] = k.file
+func tagSortRank(tag fileinfo.ColorTag) int {
+	if tag == fileinfo.ColorTagNone {
+		return int(fileinfo.MaxColorTag) + 1
 	}
+	return int(tag)
 }
@@ -1,7 +1,10 @@
 package main
 
 import (
+	"encoding/binary"
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -10,9 +13,11 @@ import (
 	"fyne.io/fyne/v2/test"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+	"golang.org/x/text/language"
 
 	"nmf/internal/config"
 	"nmf/internal/fileinfo"
+	"nmf/internal/selection"
 )
 
 func TestUpdateFilesUsesActiveTemporarySort(t *testing.T) {
@@ -30,8 +35,8 @@ func TestUpdateFilesUsesActiveTemporarySort(t *testing.T) {
 				Sort: config.SortConfig{SortBy: "name", SortOrder: "asc", DirectoriesFirst: true},
 			},
 		},
-		activeSort:    config.SortConfig{SortBy: "modified", SortOrder: "desc", DirectoriesFirst: true},
-		selectedFiles: map[string]bool{},
+		activeSort: config.SortConfig{SortBy: "modified", SortOrder: "desc", DirectoriesFirst: true},
+		selection:  selection.New(),
 	}
 	newer := fileinfo.FileInfo{Name: "a.txt", Path: "/tmp/a.txt", Modified: time.Unix(2, 0)}
 	older := fileinfo.FileInfo{Name: "z.txt", Path: "/tmp/z.txt", Modified: time.Unix(1, 0)}
@@ -65,7 +70,7 @@ func TestApplyFilterUsesEffectivePatternBeforeComment(t *testing.T) {
 			{Name: "notes.md", Path: "/tmp/notes.md"},
 			{Name: "docs", Path: "/tmp/docs", IsDir: true},
 		},
-		selectedFiles: map[string]bool{},
+		selection: selection.New(),
 	}
 
 	fm.ApplyFilter(&config.FilterEntry{Pattern: "*.go ;; 日本語"})
@@ -327,6 +332,38 @@ func TestCursorScrollTargetCorrectsDirectionForOffscreenCursor(t *testing.T) {
 	}
 }
 
+func TestRestoreListScrollOffsetKeepsCursorVisible(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	fm := newScrollMarginTestFileManager(40, 0)
+	window := test.NewWindow(fm.fileList)
+	defer window.Close()
+	window.SetPadded(false)
+
+	padding := fm.fileList.Theme().Size(theme.SizeNamePadding)
+	rowStride := fm.fileListItemHeight + padding
+	window.Resize(fyne.NewSize(300, fm.fileListItemHeight+9*rowStride))
+
+	// Cursor row 15 is inside the viewport that starts at row 10.
+	fm.SetCursorByIndex(15)
+	fm.refreshListAndCursor()
+	fm.restoreListScrollOffset(10 * rowStride)
+	if got := fm.fileList.GetScrollOffset(); got != 10*rowStride {
+		t.Fatalf("offset = %v, want remembered %v", got, 10*rowStride)
+	}
+
+	// Cursor row 30 would be off screen at the remembered offset, so the
+	// cursor scroll from refreshListAndCursor stays.
+	fm.SetCursorByIndex(30)
+	fm.refreshListAndCursor()
+	before := fm.fileList.GetScrollOffset()
+	fm.restoreListScrollOffset(10 * rowStride)
+	if got := fm.fileList.GetScrollOffset(); got != before {
+		t.Fatalf("offset = %v, want cursor scroll %v kept", got, before)
+	}
+}
+
 func TestRefreshCursorConsumesMoveDirection(t *testing.T) {
 	app := test.NewApp()
 	defer app.Quit()
@@ -396,6 +433,207 @@ func TestSortSliceEquivalence(t *testing.T) {
 	}
 }
 
+func TestSortSliceByDateTakenUsesCaptureDateWithModifiedFallback(t *testing.T) {
+	files := []fileinfo.FileInfo{
+		{Name: "b.jpg", Modified: time.Unix(100, 0)},
+		{Name: "a.jpg", Modified: time.Unix(300, 0)},
+		{Name: "notes.txt", Modified: time.Unix(200, 0)},
+	}
+	taken := map[string]time.Time{
+		"b.jpg": time.Unix(500, 0),
+		"a.jpg": time.Unix(50, 0),
+	}
+	dateTaken := func(file fileinfo.FileInfo) time.Time {
+		if when, ok := taken[file.Name]; ok {
+			return when
+		}
+		return file.Modified
+	}
+
+	sortSliceWithDates(files, config.SortConfig{SortBy: "dateTaken", SortOrder: "asc"}, dateTaken)
+
+	got := []string{files[0].Name, files[1].Name, files[2].Name}
+	if want := []string{"a.jpg", "notes.txt", "b.jpg"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("dateTaken order = %v, want %v", got, want)
+	}
+
+	sortSlice(files, config.SortConfig{SortBy: "dateTaken", SortOrder: "asc"})
+	got = []string{files[0].Name, files[1].Name, files[2].Name}
+	if want := []string{"b.jpg", "notes.txt", "a.jpg"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("dateTaken without resolver = %v, want modified order %v", got, want)
+	}
+}
+
+func TestSortSliceGroupsByCaptureDay(t *testing.T) {
+	at := func(day, hour int) time.Time { return time.Date(2024, 5, day, hour, 0, 0, 0, time.Local) }
+	files := []fileinfo.FileInfo{
+		{Name: "..", IsDir: true},
+		{Name: "c.jpg", Modified: at(2, 9)},
+		{Name: "a.jpg", Modified: at(2, 18)},
+		{Name: "b.jpg", Modified: at(1, 12)},
+		{Name: "d.jpg", Modified: at(1, 8)},
+	}
+	sortConfig := config.SortConfig{SortBy: "name", SortOrder: "asc", GroupBy: "dateTaken"}
+	names := func(files []fileinfo.FileInfo) []string {
+		got := make([]string, len(files))
+		for i, file := range files {
+			got[i] = file.Name
+		}
+		return got
+	}
+
+	files = sortFileInfoSlice(files, sortConfig)
+	if got, want := names(files), []string{"..", "b.jpg", "d.jpg", "a.jpg", "c.jpg"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("grouped order = %v, want %v", got, want)
+	}
+	fm := &FileManager{files: files, activeSort: sortConfig}
+	var starts []int
+	for i := range files {
+		if fm.startsDateGroup(i) {
+			starts = append(starts, i)
+		}
+	}
+	if !reflect.DeepEqual(starts, []int{3}) {
+		t.Fatalf("group rules above rows %v, want [3]", starts)
+	}
+
+	sortConfig.SortOrder = "desc"
+	if got, want := names(sortFileInfoSlice(files, sortConfig)), []string{"..", "c.jpg", "a.jpg", "d.jpg", "b.jpg"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("descending grouped order = %v, want %v", got, want)
+	}
+}
+
+func TestDateTakenResolverParsesImagesInBackground(t *testing.T) {
+	// A TIFF whose only tag is DateTime, which stands in for a missing
+	// DateTimeOriginal.
+	date := "2024:05:06 07:08:09\x00"
+	data := []byte("II*\x00")
+	data = binary.LittleEndian.AppendUint32(data, 8)
+	data = binary.LittleEndian.AppendUint16(data, 1)
+	data = binary.LittleEndian.AppendUint16(data, 0x0132)
+	data = binary.LittleEndian.AppendUint16(data, 2)
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(date)))
+	data = binary.LittleEndian.AppendUint32(data, 26)
+	data = binary.LittleEndian.AppendUint32(data, 0)
+	data = append(data, date...)
+	path := filepath.Join(t.TempDir(), "shot.tif")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stat, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shot := fileinfo.FileInfo{Name: "shot.tif", Path: path, Modified: stat.ModTime()}
+
+	svc := fileinfo.NewMetadataService(nil)
+	defer svc.Close()
+	updated := make(chan struct{}, 1)
+	svc.OnUpdated(func() {
+		select {
+		case updated <- struct{}{}:
+		default:
+		}
+	})
+	fm := &FileManager{metadataSvc: svc}
+	if fm.dateTakenResolver(false, true) != nil {
+		t.Fatal("resolver returned for a listing whose capture dates are not read")
+	}
+	resolve := fm.dateTakenResolver(true, true)
+	cached := fm.dateTakenResolver(true, false)
+
+	clip := fileinfo.FileInfo{Name: "clip.mp4", Path: filepath.Join(filepath.Dir(path), "clip.mp4"), Modified: time.Unix(100, 0)}
+	if got := resolve(clip); !got.Equal(clip.Modified) {
+		t.Fatalf("video date = %v, want its modification time", got)
+	}
+	if got := cached(shot); !got.Equal(shot.Modified) {
+		t.Fatalf("uncached image date = %v, want its modification time", got)
+	}
+	select {
+	case <-updated:
+		t.Fatal("a cache-only lookup queued a parse")
+	case <-time.After(200 * time.Millisecond):
+	}
+	if got := resolve(shot); !got.Equal(shot.Modified) {
+		t.Fatalf("uncached image date = %v, want its modification time while the parse is queued", got)
+	}
+	select {
+	case <-updated:
+	case <-time.After(5 * time.Second):
+		t.Fatal("queued parse never finished")
+	}
+	if got, want := resolve(shot), time.Date(2024, 5, 6, 7, 8, 9, 0, time.Local); !got.Equal(want) {
+		t.Fatalf("parsed image date = %v, want %v", got, want)
+	}
+	if got, want := cached(shot), time.Date(2024, 5, 6, 7, 8, 9, 0, time.Local); !got.Equal(want) {
+		t.Fatalf("cache-only image date = %v, want %v", got, want)
+	}
+}
+
+func TestSortSliceByTagPutsTaggedFirst(t *testing.T) {
+	files := []fileinfo.FileInfo{
+		{Name: "plain.txt"},
+		{Name: "blue.txt", ColorTag: fileinfo.ColorTagBlue},
+		{Name: "b-red.txt", ColorTag: fileinfo.ColorTagRed},
+		{Name: "a-red.txt", ColorTag: fileinfo.ColorTagRed},
+	}
+
+	sortSlice(files, config.SortConfig{SortBy: "tag", SortOrder: "asc"})
+
+	got := []string{files[0].Name, files[1].Name, files[2].Name, files[3].Name}
+	if want := []string{"a-red.txt", "b-red.txt", "blue.txt", "plain.txt"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("tag order = %v, want %v", got, want)
+	}
+}
+
+func TestSortSliceNaturalOrdersDigitRunsByValue(t *testing.T) {
+	input := []fileinfo.FileInfo{{Name: "file10.txt"}, {Name: "File2.txt"}, {Name: "file1.txt"}, {Name: "file01.txt"}, {Name: "file"}}
+
+	files := append([]fileinfo.FileInfo(nil), input...)
+	sortSlice(files, config.SortConfig{SortBy: "name", SortOrder: "asc"})
+	if want := []string{"file", "file01.txt", "file1.txt", "file10.txt", "File2.txt"}; !reflect.DeepEqual(namesOf(files), want) {
+		t.Fatalf("plain order = %v, want %v", namesOf(files), want)
+	}
+
+	files = append([]fileinfo.FileInfo(nil), input...)
+	sortSlice(files, config.SortConfig{SortBy: "name", SortOrder: "asc", Natural: true})
+	if want := []string{"file", "file01.txt", "file1.txt", "File2.txt", "file10.txt"}; !reflect.DeepEqual(namesOf(files), want) {
+		t.Fatalf("natural order = %v, want %v", namesOf(files), want)
+	}
+
+	files = append([]fileinfo.FileInfo(nil), input...)
+	sortSlice(files, config.SortConfig{SortBy: "name", SortOrder: "asc", Natural: true, Locale: "en"})
+	if want := []string{"file", "file01.txt", "file1.txt", "File2.txt", "file10.txt"}; !reflect.DeepEqual(namesOf(files), want) {
+		t.Fatalf("natural locale order = %v, want %v", namesOf(files), want)
+	}
+}
+
+func TestSortSliceLocaleCollatesAccents(t *testing.T) {
+	files := []fileinfo.FileInfo{{Name: "ezra"}, {Name: "éclair"}, {Name: "Eclair"}, {Name: "apple"}}
+
+	sortSlice(files, config.SortConfig{SortBy: "name", SortOrder: "asc", Locale: "en"})
+
+	got := []string{files[0].Name, files[1].Name, files[2].Name, files[3].Name}
+	if want := []string{"apple", "Eclair", "éclair", "ezra"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("locale order = %v, want %v", got, want)
+	}
+}
+
+func TestSortLocaleTagReadsPOSIXLocale(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_COLLATE", "de_DE.UTF-8@euro")
+	if got := sortLocaleTag(config.SortLocaleAuto).String(); got != "de-DE" {
+		t.Fatalf("sortLocaleTag(auto) = %q, want de-DE", got)
+	}
+	t.Setenv("LC_ALL", "C")
+	if got := sortLocaleTag(config.SortLocaleAuto); got != language.Und {
+		t.Fatalf("sortLocaleTag(auto) under C = %v, want und", got)
+	}
+	if got := sortLocaleTag("ja").String(); got != "ja" {
+		t.Fatalf("sortLocaleTag(ja) = %q", got)
+	}
+}
+
 // TestSortFileInfoSlicePure exercises sortFileInfoSlice as a pure function
 // (no *FileManager involved), verifying it neither mutates the input slice
 // header's backing semantics unexpectedly nor touches any FileManager state,
@@ -468,3 +706,92 @@ func TestSortFileInfoSlicePure(t *testing.T) {
 		}
 	})
 }
+
+func TestIconPixelSizeScalesWithCanvas(t *testing.T) {
+	for _, tt := range []struct {
+		size, scale float32
+		want        int
+	}{{14, 1, 14}, {14, 1.5, 21}, {14, 2, 28}, {13, 1.25, 17}, {14, 0, 14}} {
+		if got := iconPixelSize(tt.size, tt.scale); got != tt.want {
+			t.Errorf("iconPixelSize(%v, %v) = %d, want %d", tt.size, tt.scale, got, tt.want)
+		}
+	}
+}
+
+func TestIconPrefetchOrderPrioritizesViewport(t *testing.T) {
+	got := iconPrefetchOrder(10, 12, 30)
+	want := []int{10, 11, 12}
+	for i := 13; i <= 28; i++ {
+		want = append(want, i)
+	}
+	want = append(want, 9, 8, 7, 6)
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("iconPrefetchOrder = %v, want %v", got, want)
+	}
+	if got := iconPrefetchOrder(0, 5, 3); !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Fatalf("short list order = %v", got)
+	}
+	if got := iconPrefetchOrder(0, -1, 10); got != nil {
+		t.Fatalf("empty viewport order = %v", got)
+	}
+}
+
+func TestMergeSortedFilesMatchesFullSort(t *testing.T) {
+	var all []fileinfo.FileInfo
+	for i := 0; i < 40; i++ {
+		all = append(all, fileinfo.FileInfo{
+			Name:     fmt.Sprintf("entry%02d", (i*17)%40),
+			IsDir:    i%3 == 0,
+			Size:     int64((i * 7) % 11),
+			Modified: time.Unix(int64((i*13)%29), 0),
+		})
+	}
+	parent := fileinfo.FileInfo{Name: "..", IsDir: true}
+
+	for _, cfg := range []config.SortConfig{
+		{SortBy: "name", SortOrder: "asc", DirectoriesFirst: true},
+		{SortBy: "size", SortOrder: "desc", DirectoriesFirst: false},
+		{SortBy: "modified", SortOrder: "asc", DirectoriesFirst: true},
+	} {
+		want := sortFileInfoSlice(append([]fileinfo.FileInfo{parent}, all...), cfg)
+
+		merged := sortFileInfoSlice(append([]fileinfo.FileInfo{parent}, all[:15]...), cfg)
+		for start := 15; start < len(all); start += 10 {
+			chunk := append([]fileinfo.FileInfo(nil), all[start:min(start+10, len(all))]...)
+			merged = mergeSortedFiles(merged, sortFileInfoSlice(chunk, cfg), cfg, nil)
+		}
+
+		gotNames := make([]string, len(merged))
+		wantNames := make([]string, len(want))
+		for i := range merged {
+			gotNames[i] = merged[i].Name
+			wantNames[i] = want[i].Name
+		}
+		if !reflect.DeepEqual(gotNames, wantNames) {
+			t.Fatalf("%+v: merged = %v, want %v", cfg, gotNames, wantNames)
+		}
+	}
+}
+
+func TestLargeFileOpenWarning(t *testing.T) {
+	if exceedsLargeFileWarning(1<<30, 1024) {
+		t.Fatal("a file of exactly the threshold should open without asking")
+	}
+	if !exceedsLargeFileWarning(1<<30+1, 1024) {
+		t.Fatal("a file above the threshold should ask")
+	}
+	if exceedsLargeFileWarning(1<<40, 0) {
+		t.Fatal("a zero threshold should never ask")
+	}
+
+	opened := 0
+	items := largeFileOpenMenuItems(8800000000, func() { opened++ })
+	if len(items) != 2 || items[0].Key != "O" || items[0].Label != "This file is 8.2 GB - open anyway" {
+		t.Fatalf("items = %+v", items)
+	}
+	items[1].Action()
+	items[0].Action()
+	if opened != 1 {
+		t.Fatalf("opened = %d, want 1", opened)
+	}
+}
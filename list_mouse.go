@@ -4,20 +4,38 @@ import (
 	"fyne.io/fyne/v2"
 
 	"nmf/internal/fileinfo"
+	"nmf/internal/keymanager"
 )
 
+// fileContextMenuEntries are the right-click menu entries, in menu order.
+// Each runs a main-screen command, so the menu acts on the same targets as
+// the command's keys: the marked entries, or the cursor entry when none are
+// marked. An entry without a command is a separator.
+var fileContextMenuEntries = []struct {
+	label   string
+	key     string
+	command string
+}{
+	{"Open", "O", keymanager.CommandOpen},
+	{"Open With...", "W", keymanager.CommandOpenWithMenu},
+	{},
+	{"Copy...", "C", keymanager.CommandCopyShow},
+	{"Move...", "M", keymanager.CommandMoveShow},
+	{"Rename...", "R", keymanager.CommandRenameShow},
+	{"Delete...", "D", keymanager.CommandDeleteTrash},
+	{},
+	{"Copy Path", "A", keymanager.CommandCopyPath},
+	{"Properties", "P", keymanager.CommandPropertiesShow},
+}
+
 func (fm *FileManager) handleFileNameClick(index int, clicked fileinfo.FileInfo, modifier fyne.KeyModifier) {
-	if fm.selectedFiles == nil {
-		fm.selectedFiles = make(map[string]bool)
-	}
 	anchor := fm.GetCurrentCursorIndex()
 	fm.SetCursorByIndex(index)
 
 	if modifier&fyne.KeyModifierShift != 0 {
 		fm.markFileRange(anchor, index)
 	} else if isTargetFileInfo(clicked) {
-		fm.selectedFiles[clicked.Path] = !fm.selectedFiles[clicked.Path]
-		fm.updateStatusBar()
+		fm.selection.Toggle(clicked.Path)
 	}
 
 	if fm.fileList != nil {
@@ -29,6 +47,41 @@ func (fm *FileManager) handleFileNameClick(index int, clicked fileinfo.FileInfo,
 	}
 }
 
+// showFileContextMenu moves the cursor to the right-clicked entry, leaving the
+// marks alone, and opens the file context menu at pos.
+func (fm *FileManager) showFileContextMenu(index int, pos fyne.Position) {
+	if index < 0 || index >= len(fm.files) || fm.mainKeyHandler == nil {
+		return
+	}
+	debugPrint("FileManager: Context menu index=%d path=%s", index, fm.files[index].Path)
+	fm.SetCursorByIndex(index)
+	if fm.fileList != nil {
+		fm.fileList.UnselectAll()
+	}
+	fm.FocusFileList()
+	fm.RefreshCursor()
+	fm.showCommandMenuAt(fm.fileContextMenuItems(), pos)
+}
+
+func (fm *FileManager) fileContextMenuItems() []keymanager.CommandMenuItem {
+	items := make([]keymanager.CommandMenuItem, 0, len(fileContextMenuEntries))
+	for _, entry := range fileContextMenuEntries {
+		if entry.command == "" {
+			items = append(items, keymanager.CommandMenuItem{Separator: true})
+			continue
+		}
+		command := entry.command
+		items = append(items, keymanager.CommandMenuItem{
+			Label: entry.label,
+			Key:   entry.key,
+			Action: func() {
+				fm.mainKeyHandler.RunCommand(command)
+			},
+		})
+	}
+	return items
+}
+
 func (fm *FileManager) markFileRange(anchor, target int) {
 	if len(fm.files) == 0 {
 		return
@@ -44,16 +97,11 @@ func (fm *FileManager) markFileRange(anchor, target int) {
 		start, end = end, start
 	}
 
-	changed := false
+	paths := make([]string, 0, end-start+1)
 	for i := start; i <= end; i++ {
-		fi := fm.files[i]
-		if !isTargetFileInfo(fi) || fm.selectedFiles[fi.Path] {
-			continue
+		if fi := fm.files[i]; isTargetFileInfo(fi) {
+			paths = append(paths, fi.Path)
 		}
-		fm.selectedFiles[fi.Path] = true
-		changed = true
-	}
-	if changed {
-		fm.updateStatusBar()
 	}
+	fm.selection.SetMany(paths, true)
 }
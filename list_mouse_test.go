@@ -8,6 +8,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 
 	"nmf/internal/fileinfo"
+	"nmf/internal/selection"
 )
 
 func newMouseListTestFileManager(t *testing.T) *FileManager {
@@ -28,7 +29,7 @@ func newMouseListTestFileManager(t *testing.T) *FileManager {
 			func() fyne.CanvasObject { return widget.NewLabel("") },
 			func(widget.ListItemID, fyne.CanvasObject) {},
 		),
-		selectedFiles: map[string]bool{},
+		selection: selection.New(),
 	}
 }
 
@@ -43,14 +44,14 @@ func TestHandleFileNameClickTogglesMarkAndMovesCursor(t *testing.T) {
 	if got := fm.GetCurrentCursorIndex(); got != 1 {
 		t.Fatalf("cursor index = %d, want 1", got)
 	}
-	if !fm.selectedFiles["/tmp/a.txt"] {
-		t.Fatalf("selectedFiles = %+v, want a.txt marked", fm.selectedFiles)
+	if !fm.selection.IsMarked("/tmp/a.txt") {
+		t.Fatalf("marks = %+v, want a.txt marked", fm.selection.Paths())
 	}
 
 	fm.handleFileNameClick(1, fm.files[1], 0)
 
-	if fm.selectedFiles["/tmp/a.txt"] {
-		t.Fatalf("selectedFiles = %+v, want a.txt unmarked", fm.selectedFiles)
+	if fm.selection.IsMarked("/tmp/a.txt") {
+		t.Fatalf("marks = %+v, want a.txt unmarked", fm.selection.Paths())
 	}
 }
 
@@ -67,12 +68,12 @@ func TestHandleFileNameClickShiftMarksRangeFromPreviousCursor(t *testing.T) {
 		t.Fatalf("cursor index = %d, want 4", got)
 	}
 	for _, path := range []string{"/tmp/a.txt", "/tmp/b.txt", "/tmp/docs"} {
-		if !fm.selectedFiles[path] {
-			t.Fatalf("selectedFiles = %+v, want %s marked", fm.selectedFiles, path)
+		if !fm.selection.IsMarked(path) {
+			t.Fatalf("marks = %+v, want %s marked", fm.selection.Paths(), path)
 		}
 	}
-	if fm.selectedFiles["/tmp/gone.txt"] {
-		t.Fatalf("selectedFiles = %+v, deleted item should not be marked", fm.selectedFiles)
+	if fm.selection.IsMarked("/tmp/gone.txt") {
+		t.Fatalf("marks = %+v, deleted item should not be marked", fm.selection.Paths())
 	}
 }
 
@@ -88,7 +89,7 @@ func TestHandleFileNameClickSkipsParentAndDeletedEntries(t *testing.T) {
 	if got := fm.GetCurrentCursorIndex(); got != 3 {
 		t.Fatalf("cursor index = %d, want 3", got)
 	}
-	if len(fm.selectedFiles) != 0 {
-		t.Fatalf("selectedFiles = %+v, want no marks", fm.selectedFiles)
+	if fm.selection.Len() != 0 {
+		t.Fatalf("marks = %+v, want no marks", fm.selection.Paths())
 	}
 }
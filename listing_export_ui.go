@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+
+	"nmf/internal/fileinfo"
+	"nmf/internal/jobs"
+	"nmf/internal/keymanager"
+	"nmf/internal/ui"
+)
+
+// ShowExportListingDialog exports the current view, filtered and sorted as
+// shown, to a CSV, JSON, or Markdown file picked by the name's extension.
+// With checksums, a SHA-256 job over the listed files runs first and the
+// file is written once it finishes.
+func (fm *FileManager) ShowExportListingDialog() {
+	files := fm.exportableFiles()
+	if len(files) == 0 {
+		fm.ShowMessageDialog("Export listing", "The current view is empty.")
+		return
+	}
+	fm.showCommandMenu(exportListingMenuItems(func(withSums bool) {
+		fm.askExportListingName(files, withSums)
+	}))
+}
+
+func exportListingMenuItems(export func(withSums bool)) []keymanager.CommandMenuItem {
+	return []keymanager.CommandMenuItem{
+		{Label: "Export listing", Key: "L", Action: func() { export(false) }},
+		{Label: "Export listing with SHA-256", Key: "S", Action: func() { export(true) }},
+	}
+}
+
+// exportableFiles returns the visible entries in list order without "..".
+func (fm *FileManager) exportableFiles() []fileinfo.FileInfo {
+	var files []fileinfo.FileInfo
+	for _, fi := range fm.GetFiles() {
+		if isTargetFileInfo(fi) {
+			files = append(files, fi)
+		}
+	}
+	return files
+}
+
+func (fm *FileManager) askExportListingName(files []fileinfo.FileInfo, withSums bool) {
+	base := fileinfo.BaseName(fm.currentPath)
+	if base == "" || base == "." || base == string(filepath.Separator) {
+		base = "listing"
+	}
+	dlg := ui.NewLineEditDialog(ui.LineEditDialogOptions{
+		Title:       "Export Listing",
+		Prompt:      "File name or path (.csv, .json, or .md):",
+		InitialText: base + "-listing.csv",
+		ConfirmText: "Export",
+	}, fm.keyManager, fm.config.UI.KeyBindings)
+	dlg.ShowDialog(fm.window, func(name string) bool {
+		format, ok := fileinfo.ListingFormatForName(name)
+		if !ok {
+			fm.ShowMessageDialog("Export failed", "The file name must end in .csv, .json, or .md.")
+			return false
+		}
+		dir, fileName := exportDestination(fm.currentPath, strings.TrimSpace(name))
+		if !withSums {
+			return fm.writeListingExport(dir, fileName, format, files, nil)
+		}
+		fm.exportListingWithChecksums(dir, fileName, format, files)
+		return true
+	})
+}
+
+// exportDestination splits name into a directory and a file name. A bare
+// name goes into current; a relative path is taken from current.
+func exportDestination(current, name string) (string, string) {
+	if !strings.ContainsAny(name, `/\`) {
+		return current, name
+	}
+	p := name
+	if !filepath.IsAbs(p) && !strings.Contains(p, "://") {
+		p = fileinfo.JoinPath(current, p)
+	}
+	return fileinfo.ParentPath(p), fileinfo.BaseName(p)
+}
+
+// exportListingWithChecksums hashes the listed regular files in a checksum
+// job and writes the listing when it finishes. Entries that could not be
+// hashed get an empty digest; a canceled job writes nothing.
+func (fm *FileManager) exportListingWithChecksums(dir, name string, format fileinfo.ListingFormat, files []fileinfo.FileInfo) {
+	var paths []string
+	for _, fi := range files {
+		if !fi.IsDir && fi.FileType != fileinfo.FileTypeSymlink {
+			paths = append(paths, fi.Path)
+		}
+	}
+	if len(paths) == 0 {
+		fm.writeListingExport(dir, name, format, files, map[string]string{})
+		return
+	}
+	j := fm.jobManager().EnqueueChecksum(paths, jobs.ChecksumOptions{Algorithm: jobs.ChecksumSHA256})
+	debugPrint("FileManager: Listing export checksum job id=%d files=%d", j.ID, len(paths))
+	fm.whenJobFinished(j, func(snap jobs.JobSnapshot) {
+		if snap.Status == jobs.StatusCanceled {
+			return
+		}
+		sums := make(map[string]string)
+		for _, r := range j.ChecksumResults() {
+			if r.Sum != "" {
+				sums[r.Path] = r.Sum
+			}
+		}
+		fyne.Do(func() {
+			if !fm.isWindowClosed() {
+				fm.writeListingExport(dir, name, format, files, sums)
+			}
+		})
+	})
+	fm.FocusFileList()
+}
+
+// writeListingExport writes files as format to a new file name in dir,
+// which may be on an SMB share.
+func (fm *FileManager) writeListingExport(dir, name string, format fileinfo.ListingFormat, files []fileinfo.FileInfo, sums map[string]string) bool {
+	var buf bytes.Buffer
+	if err := fileinfo.WriteListing(&buf, format, files, sums); err != nil {
+		fm.ShowMessageDialog("Export failed", err.Error())
+		return false
+	}
+	newPath, err := fileinfo.CreateTextFilePortable(dir, name, buf.String())
+	if err != nil {
+		debugPrint("FileManager: Listing export failed parent=%s name=%s err=%v", dir, name, err)
+		fm.ShowMessageDialog("Export failed", err.Error())
+		return false
+	}
+	if fileinfo.SamePath(dir, fm.currentPath) {
+		fm.applyCreatedPathToList(newPath, false)
+	} else {
+		fm.ShowMessageDialog("Export listing", fmt.Sprintf("Exported %d entries to %s.", len(files), newPath))
+	}
+	debugPrint("FileManager: Exported %d listing entries as %s to %s", len(files), format, newPath)
+	fm.FocusFileList()
+	return true
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestExportDestination(t *testing.T) {
+	current := filepath.FromSlash("/data/photos")
+	cases := []struct {
+		name, dir, file string
+	}{
+		{"list.csv", current, "list.csv"},
+		{"reports/list.md", filepath.Join(current, "reports"), "list.md"},
+		{filepath.FromSlash("/tmp/list.json"), filepath.FromSlash("/tmp"), "list.json"},
+	}
+	for _, c := range cases {
+		dir, file := exportDestination(current, c.name)
+		if dir != c.dir || file != c.file {
+			t.Errorf("exportDestination(%q) = %q, %q; want %q, %q", c.name, dir, file, c.dir, c.file)
+		}
+	}
+}
+
+func TestExportListingMenuItemsChooseChecksums(t *testing.T) {
+	var got []bool
+	items := exportListingMenuItems(func(withSums bool) { got = append(got, withSums) })
+	for _, item := range items {
+		item.Action()
+	}
+	if len(got) != 2 || got[0] || !got[1] {
+		t.Fatalf("actions chose %v, want plain then with checksums", got)
+	}
+}
@@ -32,7 +32,7 @@ var (
 	windowCount    int32    // atomic counter for window count
 	windowOrderMu  sync.Mutex
 	windowOrder    []*FileManager
-	reopenPaths    []string
+	closedItems    []closedItem // recently closed windows and tabs, oldest first
 )
 
 // debugPrint prints debug messages only when debug mode is enabled
@@ -69,9 +69,11 @@ func main() {
 	// Parse command line flags
 	var startPath string
 	var debugLogPath string
+	var faultSpec string
 	flag.BoolVar(&debugMode, "d", false, "Enable debug mode")
 	flag.StringVar(&debugLogPath, "debug-log", "", "Write debug logs to the specified file")
 	flag.StringVar(&startPath, "path", "", "Starting directory path")
+	flag.StringVar(&faultSpec, "faults", "", "Inject filesystem faults for development, e.g. latency=300ms,eio=0.2")
 	flag.Parse()
 	cliDebugMode := debugMode
 
@@ -176,6 +178,9 @@ func main() {
 		debugPrint("Config: Invalid archive ZIP name encoding %q: %v; using %s", cfg.UI.Archive.ZipNameEncoding, err, fileinfo.DefaultArchiveZipNameEncoding)
 		_ = fileinfo.SetArchiveOptions(fileinfo.ArchiveOptions{ZipNameEncoding: fileinfo.DefaultArchiveZipNameEncoding})
 	}
+	fileinfo.SetGioEnabled(cfg.UI.Gio.Enabled)
+	fileinfo.SetSMBKerberosEnabled(cfg.UI.Gio.SMBKerberos)
+	configureFaultInjection(faultSpec, cfg.Debug.FaultInjection)
 	startPath, err = selectStartupPath(startPath, cliStartPath, cfg)
 	if err != nil {
 		log.Printf("Error selecting startup path: %v", err)
@@ -204,12 +209,58 @@ func main() {
 	shellmenu.Debugf = debugPrint
 
 	runtime := newApplicationRuntime(fyneApp)
+	runtime.jobManager.Configure(jobs.SchedulerOptions{Workers: cfg.UI.Jobs.Workers, PerVolumeLimit: cfg.UI.Jobs.PerVolumeLimit})
+	debugPrint("Config: job workers=%d per-volume limit=%d", cfg.UI.Jobs.Workers, cfg.UI.Jobs.PerVolumeLimit)
+	if duplicateNames, err := fileinfo.ParseDuplicateNamePattern(cfg.UI.Copy.DuplicateName); err != nil {
+		log.Printf("Error in ui.copy.duplicateName: %v", err)
+	} else {
+		runtime.jobManager.SetDuplicateNamePattern(duplicateNames)
+	}
+	if cfg.UI.Copy.Elevate {
+		elevator := jobs.DefaultElevator()
+		runtime.jobManager.SetElevator(elevator)
+		debugPrint("Config: copy elevation available=%t", elevator != nil)
+	}
+	if err := runtime.jobManager.LoadHistory(stateManager.JobHistoryPath()); err != nil {
+		log.Printf("Error loading job history: %v", err)
+	}
+	if err := runtime.jobManager.LoadTransferResume(stateManager.TransferResumePath()); err != nil {
+		log.Printf("Error loading transfer resume journal: %v", err)
+	}
+	if err := runtime.tagIndex.Load(stateManager.TagIndexPath()); err != nil {
+		log.Printf("Error loading tag index: %v", err)
+	}
+	runtime.configureVaults(cfg.UI.Vault)
+	runtime.configureAuditLog(cfg.UI.AuditLog, stateManager.AuditLogPath())
+	runtime.watchHub.SetDebounce(time.Duration(cfg.UI.WatchDebounceMs) * time.Millisecond)
+	runtime.configurePinnedWatch(cfg.UI.WatchRules, state)
 	fm := NewFileManager(runtime, startPath, cfg, configManager, state, stateManager, customTheme, configScript)
+	fm.restoreTabSession(startupTabSession(cliStartPath, cfg, state))
 	fm.window.Show()
 	applyInitialWindowPosition(fm.window, cfg.Window)
 	fyneApp.Run()
 }
 
+// configureFaultInjection installs the -faults flag's spec, or else the
+// config's debug.faultInjection. A malformed spec is logged and ignored so a
+// typo never keeps the app from starting.
+func configureFaultInjection(flagSpec, configSpec string) {
+	spec := flagSpec
+	if spec == "" {
+		spec = configSpec
+	}
+	if spec == "" {
+		return
+	}
+	faults, err := fileinfo.ParseFaultSpec(spec)
+	if err != nil {
+		log.Printf("Ignoring fault injection spec %q: %v", spec, err)
+		return
+	}
+	fileinfo.SetFaultInjection(faults)
+	log.Printf("Fault injection enabled: %s", spec)
+}
+
 // resolveDirectoryPath resolves user input into a path suitable for LoadDirectory.
 // Local paths are validated as existing directories. SMB paths are normalized to canonical smb:// form.
 func resolveDirectoryPath(input string) (string, fileinfo.Parsed, error) {
@@ -230,6 +281,15 @@ func selectStartupPath(cliPath string, cliSpecified bool, cfg *config.Config) (s
 	return pwd, nil
 }
 
+// startupTabSession returns the saved tab session to reopen in the first
+// window: only with startup.restoreTabs on and no command-line path.
+func startupTabSession(cliSpecified bool, cfg *config.Config, state *config.State) config.TabSessionState {
+	if cliSpecified || cfg == nil || !cfg.Startup.RestoreTabs || state == nil {
+		return config.TabSessionState{}
+	}
+	return state.GetTabSession()
+}
+
 func expandHomePath(path string) (string, error) {
 	if !strings.HasPrefix(path, "~") {
 		return path, nil
@@ -300,22 +300,18 @@ func TestResolveDirectoryPath_EmptyRejected(t *testing.T) {
 	}
 }
 
-func TestSameDirectoryPath_LocalCleanedPath(t *testing.T) {
-	tmpDir := t.TempDir()
+func TestStartupTabSessionRequiresRestoreTabsAndNoCLIPath(t *testing.T) {
+	state := &config.State{}
+	state.SetTabSession([]config.TabState{{Path: "/a"}, {Path: "/b"}}, 1)
+	enabled := &config.Config{Startup: config.StartupConfig{RestoreTabs: true}}
 
-	if !sameDirectoryPath(filepath.Join(tmpDir, "."), tmpDir) {
-		t.Fatalf("expected cleaned local paths to match")
+	if got := startupTabSession(false, enabled, state); len(got.Tabs) != 2 || got.Active != 1 {
+		t.Fatalf("session = %+v, want the saved two tabs", got)
 	}
-}
-
-func TestSameDirectoryPath_SMBNormalizedPath(t *testing.T) {
-	if !sameDirectoryPath("smb://Example.Local/share/path/", "smb://example.local/share/path") {
-		t.Fatalf("expected normalized SMB paths to match")
+	if got := startupTabSession(true, enabled, state); len(got.Tabs) != 0 {
+		t.Fatalf("session with CLI path = %+v, want none", got)
 	}
-}
-
-func TestSameDirectoryPath_EmptyDoesNotMatch(t *testing.T) {
-	if sameDirectoryPath("", "") {
-		t.Fatalf("expected empty paths not to match")
+	if got := startupTabSession(false, &config.Config{}, state); len(got.Tabs) != 0 {
+		t.Fatalf("session with restoreTabs off = %+v, want none", got)
 	}
 }
@@ -14,19 +14,23 @@ func (fm *FileManager) OpenNewWindow() {
 	fm.openWindowAtPath(fm.currentPath)
 }
 
+// ReopenClosedWindow reopens the most recently closed window with its tabs,
+// or opens a new window on the current path when none was closed.
 func (fm *FileManager) ReopenClosedWindow() {
-	path, ok := nextReopenPath()
+	item, ok := popClosedItem(true)
 	if !ok {
 		debugPrint("FileManager: No closed window path available; opening current path")
-		path = fm.currentPath
+		fm.openWindowAtPath(fm.currentPath)
+		return
 	}
-	fm.openWindowAtPath(path)
+	fm.reopenClosedItem(item)
 }
 
-func (fm *FileManager) openWindowAtPath(path string) {
+func (fm *FileManager) openWindowAtPath(path string) *FileManager {
 	newFM := NewFileManager(fm.runtime, path, fm.config, fm.configManager, fm.state, fm.stateManager, fm.customTheme, fm.configScript)
 	newFM.window.Show()
 	positionWindowNextTo(fm.window, newFM.window)
+	return newFM
 }
 
 // ShowDirectoryTreeDialog shows the directory tree navigation dialog.
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"strconv"
+
+	"nmf/internal/config"
+	"nmf/internal/fileinfo"
+	"nmf/internal/keymanager"
+	"nmf/internal/shellmenu"
+)
+
+// ShowOpenWithMenu lists the applications that can open the marked files, or
+// the cursor item: ui.openWith entries matching the first target's extension,
+// then the handlers the system registers for it.
+func (fm *FileManager) ShowOpenWithMenu() {
+	targets := fm.collectTargetPaths()
+	if len(targets) == 0 {
+		fm.showCommandPopup("Open With", informationalExternalCommandMenuItem("No file selected."))
+		return
+	}
+
+	handlers, err := shellmenu.OpenWithHandlers(externalCommandArgumentPath(targets[0]))
+	if err != nil {
+		debugPrint("FileManager: Open With handlers unavailable err=%v", err)
+	}
+	items := openWithMenuItems(fm.config.UI.OpenWith, handlers, targets[0], func(command string, args []string) {
+		fm.openWith(command, args, targets)
+	})
+	if len(items) == 0 {
+		fm.showCommandPopup("Open With", informationalExternalCommandMenuItem("No applications for this file."))
+		return
+	}
+	fm.showCommandMenu(items)
+}
+
+// openWithMenuItems lists the matching user entries with their own keys,
+// then the system handlers with digit accelerators for the first nine.
+func openWithMenuItems(entries []config.OpenWithEntry, handlers []shellmenu.OpenWithHandler, target string, run func(command string, args []string)) []keymanager.CommandMenuItem {
+	var items []keymanager.CommandMenuItem
+	for _, entry := range entries {
+		if entry.Name == "" || entry.Command == "" || !externalCommandMatches(target, entry.Extensions) {
+			continue
+		}
+		command, args := entry.Command, entry.Args
+		items = append(items, keymanager.CommandMenuItem{
+			Label:  entry.Name,
+			Key:    entry.Key,
+			Action: func() { run(command, args) },
+		})
+	}
+	if len(items) > 0 && len(handlers) > 0 {
+		items = append(items, keymanager.CommandMenuItem{Separator: true})
+	}
+	for i, handler := range handlers {
+		command, args := handler.Command, handler.Args
+		item := keymanager.CommandMenuItem{
+			Label:  handler.Name,
+			Action: func() { run(command, args) },
+		}
+		if i < 9 {
+			item.Key = strconv.Itoa(i + 1)
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// openWith starts command for targets, once per target when its arguments
+// use %f and once for all of them otherwise.
+func (fm *FileManager) openWith(command string, argTemplates []string, targets []string) {
+	commandTargets := make([]string, len(targets))
+	for i, target := range targets {
+		commandTargets[i] = externalCommandArgumentPath(target)
+	}
+	dir := fileinfo.CommandArgumentPath(fm.currentPath)
+	for _, args := range shellmenu.ExpandOpenWithArgs(argTemplates, commandTargets, dir) {
+		if !fm.runExternalCommand(command, args, "") {
+			return
+		}
+	}
+	fm.FocusFileList()
+}
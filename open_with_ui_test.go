@@ -0,0 +1,39 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"nmf/internal/config"
+	"nmf/internal/shellmenu"
+)
+
+func TestOpenWithMenuItemsListUserEntriesBeforeSystemHandlers(t *testing.T) {
+	entries := []config.OpenWithEntry{
+		{Name: "GIMP", Key: "g", Extensions: []string{"png"}, Command: "gimp", Args: []string{"%F"}},
+		{Name: "Text editor", Extensions: []string{"txt"}, Command: "gedit"},
+		{Name: "Any", Command: "any"},
+	}
+	handlers := []shellmenu.OpenWithHandler{
+		{Name: "Image Viewer", Command: "eog", Args: []string{"%U"}},
+	}
+	var ran []string
+	items := openWithMenuItems(entries, handlers, "/pics/cat.PNG", func(command string, args []string) {
+		ran = append(append(ran, command), args...)
+	})
+
+	labels := make([]string, len(items))
+	for i, item := range items {
+		labels[i] = item.Label
+	}
+	if !slices.Equal(labels, []string{"GIMP", "Any", "", "Image Viewer"}) || !items[2].Separator {
+		t.Fatalf("labels = %q, want user entries, separator, system handler", labels)
+	}
+	if items[0].Key != "g" || items[3].Key != "1" {
+		t.Fatalf("keys = %q %q, want g and 1", items[0].Key, items[3].Key)
+	}
+	items[3].Action()
+	if !slices.Equal(ran, []string{"eog", "%U"}) {
+		t.Fatalf("action ran %q, want the system handler", ran)
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"slices"
+	"time"
+
+	"fyne.io/fyne/v2"
+
+	"nmf/internal/config"
+	"nmf/internal/fileinfo"
+	"nmf/internal/navigation"
+)
+
+// parentListing is the listing of a directory the window left for one of
+// its subdirectories, with the hidden-file settings it was read under.
+type parentListing struct {
+	listing        directoryListing
+	showHidden     bool
+	hideGitIgnored bool
+}
+
+// parentListingCache keeps the listings of the directories above the
+// current one, keyed by path, so walking back up with Backspace shows them
+// at once. It is pruned to the current path's ancestors on every load.
+type parentListingCache map[string]parentListing
+
+// prune drops every listing that is not an ancestor of path.
+func (c parentListingCache) prune(path string) {
+	for p := range c {
+		if !isAncestorPath(p, path) {
+			delete(c, p)
+		}
+	}
+}
+
+// isAncestorPath reports whether ancestor lies strictly above path.
+func isAncestorPath(ancestor, path string) bool {
+	for {
+		parent := fileinfo.ParentPath(path)
+		if parent == path || parent == "" {
+			return false
+		}
+		if parent == ancestor {
+			return true
+		}
+		path = parent
+	}
+}
+
+// rememberParentListing keeps the current listing when the window is about
+// to enter path below it. Tag views, vault contents, and listings still
+// streaming in are not kept.
+func (fm *FileManager) rememberParentListing(path string) {
+	current := fm.currentPath
+	if current == "" || !isAncestorPath(current, path) || fm.navigator.Pending() {
+		return
+	}
+	if fileinfo.IsTagViewPath(current) || fm.vaultCipherPath(current) != current {
+		return
+	}
+	if fm.parentListings == nil {
+		fm.parentListings = make(parentListingCache)
+	}
+	listing := fm.currentListing()
+	fm.parentListings[current] = parentListing{listing: listing, showHidden: fm.showHidden, hideGitIgnored: fm.hideGitIgnored}
+	debugPrint("FileManager: Remembered parent listing path=%s files=%d", current, len(listing.files))
+}
+
+// currentListing copies the listing shown now, without watcher highlights
+// and entries marked deleted, for showing it again later.
+func (fm *FileManager) currentListing() directoryListing {
+	files := make([]fileinfo.FileInfo, 0, len(fm.originalFiles))
+	for _, f := range fm.originalFiles {
+		if f.Status == fileinfo.StatusDeleted {
+			continue
+		}
+		f.Status = fileinfo.StatusNormal
+		files = append(files, f)
+	}
+	var storageErr error
+	if !fm.storageKnown {
+		storageErr = fileinfo.ErrStorageUnsupported
+	}
+	return directoryListing{
+		path:         fm.currentPath,
+		files:        files,
+		storage:      fm.storageInfo,
+		storageErr:   storageErr,
+		note:         fm.dirNote,
+		sortCfg:      fm.activeSort,
+		captureDates: fm.captureDates,
+	}
+}
+
+// cachedParentListing returns the kept listing of path when the window is
+// walking up to it and it was read with the same sort and hidden-file
+// settings.
+func (fm *FileManager) cachedParentListing(path string, sortCfg config.SortConfig) (directoryListing, bool) {
+	cached, ok := fm.parentListings[path]
+	if !ok || !isAncestorPath(path, fm.currentPath) {
+		return directoryListing{}, false
+	}
+	if cached.listing.sortCfg != sortCfg || cached.showHidden != fm.showHidden || cached.hideGitIgnored != fm.hideGitIgnored {
+		return directoryListing{}, false
+	}
+	return cached.listing, true
+}
+
+// showCachedListing applies a kept parent listing for load at once, like
+// the first page of a streamed load, and lists the directory again in the
+// background. The watcher starts only after that refresh, from the listing
+// it confirmed.
+func (fm *FileManager) showCachedListing(load *navigation.Load, listing directoryListing, keep func(fileinfo.FileInfo) bool) {
+	if !fm.navigator.ClaimFirstPage(load) {
+		return
+	}
+	debugPrint("FileManager: LoadDirectory from parent cache path=%s files=%d", load.Path, len(listing.files))
+	fm.applyDirectoryListing(load, listing, true)
+	fm.navigator.Complete(load)
+	fm.loads.Go(func() { fm.revalidateCachedListing(load, listing.sortCfg, keep) })
+}
+
+// revalidateCachedListing reads load's directory again and replaces the
+// cached entries shown for it when anything changed, keeping the cursor on
+// its entry. A directory that can no longer be read keeps the cached view.
+func (fm *FileManager) revalidateCachedListing(load *navigation.Load, sortCfg config.SortConfig, keep func(fileinfo.FileInfo) bool) {
+	path := load.Path
+	entries, err := fileinfo.ReadDirPortableContext(load.Context(), path)
+	if fm.staleDirectoryLoad(load, err) {
+		return
+	}
+	var listing directoryListing
+	if err == nil {
+		var ok bool
+		listing, ok = fm.newDirectoryListing(load, path, sortCfg, false, len(entries)+1)
+		if !ok || !fm.fillDirectoryListing(load, &listing, entries, keep, fm.listingDateTaken(listing)) {
+			return
+		}
+		if fm.staleDirectoryLoad(load, nil) {
+			return
+		}
+	}
+	fyne.Do(func() {
+		if !fm.navigator.ClaimRest(load) {
+			return
+		}
+		switch {
+		case err != nil:
+			debugPrint("FileManager: Parent cache refresh failed path=%s err=%v", path, err)
+		case sameListedFiles(fm.originalFiles, listing.files):
+			debugPrint("FileManager: Parent cache confirmed path=%s", path)
+			fm.loadedAt = time.Now()
+		default:
+			debugPrint("FileManager: Parent cache refreshed path=%s files=%d", path, len(listing.files))
+			fm.storageInfo = listing.storage
+			fm.storageKnown = listing.storageErr == nil
+			fm.dirNote = listing.note
+			fm.loadedAt = time.Now()
+			fm.updateFiles(listing.files, false)
+		}
+		fm.navigator.CompleteRest(load)
+	})
+}
+
+// sameListedFiles reports whether two listings hold the same entries in the
+// same order with the same size, time, type, and color tag.
+func sameListedFiles(a, b []fileinfo.FileInfo) bool {
+	return slices.EqualFunc(a, b, func(x, y fileinfo.FileInfo) bool {
+		return x.Path == y.Path && x.Size == y.Size && x.Modified.Equal(y.Modified) &&
+			x.IsDir == y.IsDir && x.FileType == y.FileType && x.ColorTag == y.ColorTag
+	})
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestParentListingCacheKeepsOnlyAncestors(t *testing.T) {
+	root := t.TempDir()
+	a := filepath.Join(root, "a")
+	ab := filepath.Join(a, "b")
+	other := filepath.Join(root, "other")
+	c := parentListingCache{root: {}, a: {}, ab: {}, other: {}}
+
+	c.prune(filepath.Join(ab, "c"))
+	for _, p := range []string{root, a, ab} {
+		if _, ok := c[p]; !ok {
+			t.Fatalf("ancestor %s pruned", p)
+		}
+	}
+	if _, ok := c[other]; ok {
+		t.Fatal("sibling listing kept")
+	}
+
+	c.prune(a)
+	if _, ok := c[a]; ok {
+		t.Fatal("listing of the directory being entered kept")
+	}
+	if len(c) != 1 {
+		t.Fatalf("cache = %v, want only the root", c)
+	}
+	if isAncestorPath(ab, ab) || isAncestorPath(ab, a) || !isAncestorPath(root, ab) {
+		t.Fatal("isAncestorPath must be strict and only look upward")
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+
+	"nmf/internal/config"
+	"nmf/internal/fileinfo"
+	"nmf/internal/watcher"
+)
+
+// pinnedWatchMaxNames caps the file names listed in one notification.
+const pinnedWatchMaxNames = 3
+
+// configurePinnedWatch starts watching the bookmarks marked watch for new
+// entries matching rules. With no rules nothing is watched.
+func (r *ApplicationRuntime) configurePinnedWatch(rules []config.WatchRule, state *config.State) {
+	r.watchRules = rules
+	r.pinnedWatcher = watcher.NewPinnedWatcher(r.watchHub, 0, r.notifyPinnedEntries, debugPrint)
+	r.updatePinnedWatch(state)
+}
+
+// updatePinnedWatch follows the current watch marks in state.
+func (r *ApplicationRuntime) updatePinnedWatch(state *config.State) {
+	if r == nil || r.pinnedWatcher == nil || state == nil {
+		return
+	}
+	r.pinnedWatcher.SetPaths(pinnedWatchPaths(r.watchRules, state.WatchedBookmarks()))
+}
+
+// pinnedWatchPaths returns the watched bookmark paths some rule applies to.
+// Archive paths are skipped; they are never watched.
+func pinnedWatchPaths(rules []config.WatchRule, bookmarks []config.Bookmark) []string {
+	var paths []string
+	for _, b := range bookmarks {
+		if !fileinfo.IsArchivePath(b.Path) && config.WatchRulesCover(rules, b.Path) {
+			paths = append(paths, b.Path)
+		}
+	}
+	return paths
+}
+
+// notifyPinnedEntries raises one desktop notification per matching rule for
+// entries that appeared in a watched directory. It runs on the watcher's
+// goroutine.
+func (r *ApplicationRuntime) notifyPinnedEntries(dir string, added []fileinfo.FileInfo) {
+	for _, n := range pinnedWatchNotifications(r.watchRules, dir, added) {
+		debugPrint("PinnedWatch: notify title=%q content=%q", n.Title, n.Content)
+		fyne.Do(func() {
+			r.app.SendNotification(n)
+		})
+	}
+}
+
+// pinnedWatchNotifications groups the added entries by the first rule they
+// match, keeping rule order.
+func pinnedWatchNotifications(rules []config.WatchRule, dir string, added []fileinfo.FileInfo) []*fyne.Notification {
+	names := make(map[string][]string)
+	var order []string
+	for _, file := range added {
+		rule, ok := config.MatchWatchRule(rules, dir, file.Name)
+		if !ok {
+			continue
+		}
+		if _, seen := names[rule.Name]; !seen {
+			order = append(order, rule.Name)
+		}
+		names[rule.Name] = append(names[rule.Name], file.Name)
+	}
+	notifications := make([]*fyne.Notification, 0, len(order))
+	for _, rule := range order {
+		list := names[rule]
+		content := strings.Join(list[:min(len(list), pinnedWatchMaxNames)], ", ")
+		if extra := len(list) - pinnedWatchMaxNames; extra > 0 {
+			content += fmt.Sprintf(" and %d more", extra)
+		}
+		title := fmt.Sprintf("%s: new in %s", rule, fileinfo.BaseName(dir))
+		notifications = append(notifications, fyne.NewNotification(title, content))
+	}
+	return notifications
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+
+	"nmf/internal/config"
+	"nmf/internal/fileinfo"
+)
+
+func TestPinnedWatchPathsNeedACoveringRule(t *testing.T) {
+	bookmarks := []config.Bookmark{
+		{Path: "/home/u/Downloads", Watch: true},
+		{Path: "/srv/inbox", Watch: true},
+		{Path: "/home/u/a.zip!/", Watch: true},
+	}
+	if got := pinnedWatchPaths(nil, bookmarks); len(got) != 0 {
+		t.Fatalf("paths without rules = %v, want none", got)
+	}
+	if got := pinnedWatchPaths([]config.WatchRule{{Name: "all"}}, bookmarks); len(got) != 2 || got[1] != "/srv/inbox" {
+		t.Fatalf("paths = %v, want every bookmark but the archive", got)
+	}
+	rules := []config.WatchRule{{Name: "downloads", Directories: []string{"/home/u/Downloads"}}}
+	if got := pinnedWatchPaths(rules, bookmarks); len(got) != 1 || got[0] != "/home/u/Downloads" {
+		t.Fatalf("paths = %v, want only Downloads", got)
+	}
+}
+
+func TestPinnedWatchNotificationsGroupByRule(t *testing.T) {
+	rules := []config.WatchRule{
+		{Name: "PDF", Patterns: []string{"*.pdf"}},
+		{Name: "Images", Patterns: []string{"*.jpg"}},
+	}
+	added := []fileinfo.FileInfo{
+		{Name: "a.pdf"}, {Name: "b.pdf"}, {Name: "c.pdf"}, {Name: "d.pdf"}, {Name: "e.pdf"},
+		{Name: "x.jpg"}, {Name: "notes.txt"},
+	}
+	got := pinnedWatchNotifications(rules, "/home/u/Downloads", added)
+	if len(got) != 2 {
+		t.Fatalf("notifications = %d, want 2", len(got))
+	}
+	if got[0].Title != "PDF: new in Downloads" || got[0].Content != "a.pdf, b.pdf, c.pdf and 2 more" {
+		t.Errorf("first notification = %q / %q", got[0].Title, got[0].Content)
+	}
+	if got[1].Title != "Images: new in Downloads" || got[1].Content != "x.jpg" {
+		t.Errorf("second notification = %q / %q", got[1].Title, got[1].Content)
+	}
+}
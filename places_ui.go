@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+
+	"fyne.io/fyne/v2"
+
+	"nmf/internal/config"
+	"nmf/internal/fileinfo"
+	"nmf/internal/ui"
+)
+
+// ShowPlacesDialog offers other windows, bookmarks, volumes, and recent
+// directories in one filterable list. Volumes are listed in the background
+// and appear once known, so the dialog opens without waiting on gio.
+func (fm *FileManager) ShowPlacesDialog() {
+	var windows []string
+	for _, other := range snapshotFileManagerWindows() {
+		if other != fm {
+			windows = append(windows, other.currentPath)
+		}
+	}
+	bookmarks := fm.state.GetBookmarks()
+	recent := fm.state.GetNavigationHistory()
+
+	dlg := ui.NewPlacesDialog(buildPlaces(fm.currentPath, windows, bookmarks, nil, recent), fm.keyManager, debugPrint)
+	dlg.ShowDialog(fm.window, func(place ui.Place) {
+		debugPrint("FileManager: Places selected kind=%s path=%s", place.Kind.Label(), place.Path)
+		if place.Kind == ui.PlaceWindow {
+			if loc, ok := fm.findOpenDirectory(place.Path); ok {
+				fm.switchToOpenDirectory(loc)
+				return
+			}
+		}
+		fm.jumpToConfiguredDirectory(place.Path)
+		fm.focusFileList("places-selected")
+	})
+
+	go func() {
+		volumes := fileinfo.ListVolumes(context.Background())
+		debugPrint("FileManager: Places volumes listed count=%d", len(volumes))
+		if len(volumes) == 0 {
+			return
+		}
+		fyne.Do(func() {
+			if fm.isWindowClosed() {
+				return
+			}
+			dlg.SetPlaces(buildPlaces(fm.currentPath, windows, bookmarks, volumes, recent))
+		})
+	}()
+}
+
+// buildPlaces lists other windows, bookmarks, volumes, and recent
+// directories in that order. A path shows once, under its first kind, and
+// the current directory is left out.
+func buildPlaces(current string, windows []string, bookmarks []config.Bookmark, volumes []fileinfo.Volume, recent []string) []ui.Place {
+	seen := map[string]bool{current: true, "": true}
+	var places []ui.Place
+	add := func(kind ui.PlaceKind, name, path string) {
+		if seen[path] {
+			return
+		}
+		seen[path] = true
+		if name == "" {
+			name = fileinfo.BaseName(path)
+		}
+		places = append(places, ui.Place{Kind: kind, Name: name, Path: path})
+	}
+	for _, p := range windows {
+		add(ui.PlaceWindow, "", p)
+	}
+	for _, b := range bookmarks {
+		add(ui.PlaceBookmark, b.Name, b.Path)
+	}
+	for _, v := range volumes {
+		name := v.Name
+		if v.Kind != fileinfo.VolumeLocal {
+			name += " [" + v.Kind.String() + "]"
+		}
+		add(ui.PlaceVolume, name, v.Path)
+	}
+	for _, p := range recent {
+		add(ui.PlaceRecent, "", p)
+	}
+	return places
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"nmf/internal/config"
+	"nmf/internal/fileinfo"
+	"nmf/internal/ui"
+)
+
+func TestBuildPlacesOrdersKindsAndDropsDuplicates(t *testing.T) {
+	places := buildPlaces("/home/me",
+		[]string{"/work", "/home/me"},
+		[]config.Bookmark{{Name: "Projects", Path: "/work"}, {Name: "Music", Path: "/music"}},
+		[]fileinfo.Volume{{Name: "USB", Path: "/media/usb", Kind: fileinfo.VolumeLocal}},
+		[]string{"/home/me", "/music", "/tmp"},
+	)
+	want := []ui.Place{
+		{Kind: ui.PlaceWindow, Name: "work", Path: "/work"},
+		{Kind: ui.PlaceBookmark, Name: "Music", Path: "/music"},
+		{Kind: ui.PlaceVolume, Name: "USB", Path: "/media/usb"},
+		{Kind: ui.PlaceRecent, Name: "tmp", Path: "/tmp"},
+	}
+	if !reflect.DeepEqual(places, want) {
+		t.Fatalf("places = %+v, want %+v", places, want)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"nmf/internal/fileinfo"
+)
+
+// TogglePreviewPane shows or hides the preview pane for this window.
+func (fm *FileManager) TogglePreviewPane() {
+	if fm.previewPane == nil {
+		return
+	}
+	pane := fm.previewPane.Container()
+	if pane.Visible() {
+		pane.Hide()
+	} else {
+		pane.Show()
+		fm.updatePreviewPane()
+	}
+	debugPrint("FileManager: Preview pane visible=%t", pane.Visible())
+	fm.FocusFileList()
+}
+
+// updatePreviewPane shows the cursor file in the preview pane. A preview not
+// cached yet is requested from the preview service, whose update callback
+// calls back here, so the list never waits for file contents.
+func (fm *FileManager) updatePreviewPane() {
+	if fm.previewPane == nil || !fm.previewPane.Container().Visible() {
+		return
+	}
+	file, ok := fm.FileAt(fm.GetCurrentCursorIndex())
+	if !ok || file.Name == ".." {
+		fm.previewPane.SetInfo("", nil)
+		fm.previewPane.SetMessage("No file")
+		return
+	}
+	fm.previewPane.SetInfo(file.Name, fm.previewPaneInfo(file))
+	switch {
+	case file.Status == fileinfo.StatusDeleted:
+		fm.previewPane.SetMessage("Deleted")
+	case file.IsDir:
+		fm.previewPane.SetMessage("Directory")
+	default:
+		if preview, ok := fm.previewSvc.GetCachedOrRequest(file); ok {
+			fm.previewPane.SetPreview(preview)
+		} else {
+			fm.previewPane.SetMessage("Loading...")
+		}
+	}
+}
+
+// previewPaneInfo returns the detail lines shown above the preview.
+func (fm *FileManager) previewPaneInfo(file fileinfo.FileInfo) []string {
+	var lines []string
+	if !file.IsDir {
+		lines = append(lines, "Size: "+fileinfo.FormatFileSize(file.Size))
+	}
+	lines = append(lines, "Modified: "+file.Modified.Format("2006-01-02 15:04:05"))
+	if tag := file.ColorTag; tag != fileinfo.ColorTagNone {
+		lines = append(lines, "Tag: "+tag.String())
+	}
+	if meta, ok := fm.metadataSvc.GetCachedOrRequest(file); ok {
+		lines = append(lines, meta.Lines()...)
+	}
+	return lines
+}
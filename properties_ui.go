@@ -0,0 +1,289 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+
+	"nmf/internal/fileinfo"
+	"nmf/internal/ui"
+)
+
+// propertiesDetails is everything the Properties dialog describes. Sizes
+// holds the recursive size of each directory target once it has been
+// counted; directories missing from it are still being counted.
+type propertiesDetails struct {
+	files  []fileinfo.FileInfo
+	props  []fileinfo.PathProperties
+	errs   []error
+	note   string
+	meta   fileinfo.MediaMetadata
+	metaOK bool
+	sizes  map[string]fileinfo.DirSize
+	failed map[string]error
+}
+
+// ShowPropertiesDialog shows size, timestamps, owner, permissions, and
+// extended attributes of the marked files, or of the cursor item when
+// nothing is marked. Directory sizes are counted in the background while the
+// dialog is open, and local permission bits can be changed from it.
+func (fm *FileManager) ShowPropertiesDialog() {
+	files := fm.selectedFileInfos()
+	if len(files) == 0 {
+		currentIdx := fm.GetCurrentCursorIndex()
+		all := fm.GetFiles()
+		if currentIdx < 0 || currentIdx >= len(all) {
+			return
+		}
+		file := all[currentIdx]
+		if file.Name == ".." || file.Status == fileinfo.StatusDeleted {
+			return
+		}
+		files = []fileinfo.FileInfo{file}
+	}
+
+	debugPrint("FileManager: properties start count=%d", len(files))
+	go func() {
+		details := &propertiesDetails{
+			files:  files,
+			props:  make([]fileinfo.PathProperties, len(files)),
+			errs:   make([]error, len(files)),
+			sizes:  make(map[string]fileinfo.DirSize),
+			failed: make(map[string]error),
+		}
+		for i, file := range files {
+			details.props[i], details.errs[i] = fileinfo.ReadPathProperties(file.Path)
+		}
+		if len(files) == 1 {
+			file := files[0]
+			if file.IsDir {
+				note, err := fileinfo.ReadDirNote(file.Path)
+				if err != nil {
+					debugPrint("FileManager: properties note unavailable path=%s err=%v", file.Path, err)
+				}
+				details.note = note
+			} else if fileinfo.IsMediaFile(file.Name) && fm.metadataSvc != nil {
+				details.meta, details.metaOK = fm.metadataSvc.Lookup(context.Background(), file)
+			}
+		}
+		fyne.Do(func() {
+			if fm.isWindowClosed() {
+				return
+			}
+			fm.showPropertiesDetails(details)
+		})
+	}()
+}
+
+func (fm *FileManager) showPropertiesDetails(details *propertiesDetails) {
+	ctx, cancel := context.WithCancel(context.Background())
+	editable := details.editable()
+	dlg := ui.NewPropertiesDialog(len(details.files), details.message(), details.mode(), editable, cancel, fm.keyManager, fm.config.UI.KeyBindings)
+	dlg.ShowDialog(fm.window, func(mode os.FileMode) bool {
+		var failures []string
+		for _, file := range details.files {
+			if err := fileinfo.ChmodPortable(file.Path, mode); err != nil {
+				failures = append(failures, file.Name+": "+err.Error())
+			}
+		}
+		debugPrint("FileManager: properties chmod mode=%03o count=%d failed=%d", mode, len(details.files), len(failures))
+		// Reload so read-only emblems follow the new bits.
+		fm.SaveCursorPosition(fm.currentPath)
+		fm.LoadDirectory(fm.currentPath)
+		fm.FocusFileList()
+		if len(failures) > 0 {
+			fm.ShowMessageDialog("Change permissions failed", strings.Join(failures, "\n"))
+		}
+		return true
+	})
+
+	for _, file := range details.files {
+		if !file.IsDir {
+			continue
+		}
+		file := file
+		go func() {
+			size, err := fileinfo.DirectorySize(ctx, file.Path)
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			fyne.Do(func() {
+				if ctx.Err() != nil {
+					return
+				}
+				if err != nil {
+					details.failed[file.Path] = err
+				} else {
+					details.sizes[file.Path] = size
+				}
+				dlg.SetInfo(details.message())
+			})
+		}()
+	}
+}
+
+// editable reports whether every target is local and was read successfully.
+func (d *propertiesDetails) editable() bool {
+	for i := range d.files {
+		if d.errs[i] != nil || !d.props[i].Local {
+			return false
+		}
+	}
+	return len(d.files) > 0
+}
+
+// mode seeds the permission editor from the first readable target.
+func (d *propertiesDetails) mode() os.FileMode {
+	for i := range d.files {
+		if d.errs[i] == nil {
+			return d.props[i].Mode.Perm()
+		}
+	}
+	return 0
+}
+
+func (d *propertiesDetails) message() string {
+	if len(d.files) == 1 {
+		return d.singleMessage()
+	}
+	var files, dirs int
+	var bytes int64
+	pending := 0
+	lines := []string{}
+	for _, file := range d.files {
+		if !file.IsDir {
+			files++
+			bytes += file.Size
+			continue
+		}
+		dirs++
+		if size, ok := d.sizes[file.Path]; ok {
+			files += size.Files
+			dirs += size.Dirs
+			bytes += size.Bytes
+		} else if _, failed := d.failed[file.Path]; !failed {
+			pending++
+		}
+	}
+	lines = append(lines, fmt.Sprintf("Items: %d", len(d.files)))
+	total := fmt.Sprintf("Total: %s (%d bytes) in %d files, %d folders", fileinfo.FormatFileSize(bytes), bytes, files, dirs)
+	if pending > 0 {
+		total += fmt.Sprintf(" (counting %d folders...)", pending)
+	}
+	lines = append(lines, total)
+	for i, file := range d.files {
+		line := file.Name
+		if d.errs[i] != nil {
+			line += ": " + d.errs[i].Error()
+		} else {
+			line += "  " + permissionString(d.props[i].Mode)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (d *propertiesDetails) singleMessage() string {
+	file := d.files[0]
+	lines := []string{filePropertiesMessage(file, d.meta, d.metaOK)}
+	if file.IsDir {
+		if size, ok := d.sizes[file.Path]; ok {
+			lines = append(lines, directorySizeLine(size))
+		} else if err, failed := d.failed[file.Path]; failed {
+			lines = append(lines, "Size: unavailable ("+err.Error()+")")
+		} else {
+			lines = append(lines, "Size: counting...")
+		}
+	}
+	if d.errs[0] != nil {
+		lines = append(lines, "Details unavailable: "+d.errs[0].Error())
+	} else {
+		lines = append(lines, pathPropertiesLines(d.props[0])...)
+	}
+	msg := strings.Join(lines, "\n")
+	if file.IsDir && d.note != "" {
+		msg += "\nNote: " + d.note
+	}
+	return msg
+}
+
+func directorySizeLine(size fileinfo.DirSize) string {
+	line := fmt.Sprintf("Size: %s (%d bytes) in %d files, %d folders", fileinfo.FormatFileSize(size.Bytes), size.Bytes, size.Files, size.Dirs)
+	if size.Unreadable > 0 {
+		line += fmt.Sprintf(", %d unreadable", size.Unreadable)
+	}
+	return line
+}
+
+func pathPropertiesLines(props fileinfo.PathProperties) []string {
+	lines := []string{"Permissions: " + permissionString(props.Mode)}
+	if props.LinkTarget != "" {
+		lines = append(lines, "Link target: "+props.LinkTarget)
+	}
+	if props.Owner != "" {
+		lines = append(lines, "Owner: "+props.Owner)
+	}
+	if props.Group != "" {
+		lines = append(lines, "Group: "+props.Group)
+	}
+	for _, ts := range []struct {
+		label string
+		t     time.Time
+	}{{"Accessed", props.Accessed}, {"Changed", props.Changed}, {"Created", props.Created}} {
+		if !ts.t.IsZero() {
+			lines = append(lines, ts.label+": "+ts.t.Format("2006-01-02 15:04:05"))
+		}
+	}
+	if len(props.Xattrs) > 0 {
+		lines = append(lines, "Extended attributes: "+strings.Join(props.Xattrs, ", "))
+	}
+	return lines
+}
+
+// permissionString renders a mode as "-rwxr-xr-x (755)".
+func permissionString(mode os.FileMode) string {
+	return fmt.Sprintf("%s (%03o)", mode.String(), mode.Perm())
+}
+
+func filePropertiesMessage(file fileinfo.FileInfo, meta fileinfo.MediaMetadata, metaOK bool) string {
+	lines := []string{
+		"Name: " + file.Name,
+		"Path: " + file.Path,
+	}
+	if file.IsDir {
+		lines = append(lines, "Type: directory")
+	} else {
+		lines = append(lines, fmt.Sprintf("Size: %s (%d bytes)", fileinfo.FormatFileSize(file.Size), file.Size))
+	}
+	lines = append(lines, "Modified: "+file.Modified.Format("2006-01-02 15:04:05"))
+	if metaOK {
+		lines = append(lines, meta.Lines()...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func directoryPropertiesMessage(file fileinfo.FileInfo, note string) string {
+	msg := filePropertiesMessage(file, fileinfo.MediaMetadata{}, false)
+	if note != "" {
+		msg += "\nNote: " + note
+	}
+	return msg
+}
+
+// mediaMetadataSummary returns the list-column metadata suffix for a row, or
+// "" when the column is disabled or metadata is not cached yet.
+func (fm *FileManager) mediaMetadataSummary(file fileinfo.FileInfo) string {
+	if fm.metadataSvc == nil || !fm.showMetadataInList() {
+		return ""
+	}
+	meta, ok := fm.metadataSvc.GetCachedOrRequest(file)
+	if !ok {
+		return ""
+	}
+	return meta.Summary()
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"nmf/internal/config"
+	"nmf/internal/fileinfo"
+)
+
+func TestFilePropertiesMessageIncludesMediaMetadata(t *testing.T) {
+	file := fileinfo.FileInfo{
+		Name:     "photo.jpg",
+		Path:     "/pics/photo.jpg",
+		Size:     2048,
+		Modified: time.Date(2024, 1, 2, 3, 4, 5, 0, time.Local),
+	}
+	meta := fileinfo.MediaMetadata{Kind: fileinfo.MediaKindImage, CameraModel: "X100", Width: 6000, Height: 4000}
+
+	got := filePropertiesMessage(file, meta, true)
+
+	for _, want := range []string{"Name: photo.jpg", "Size: 2.0 KB (2048 bytes)", "Modified: 2024-01-02 03:04:05", "Dimensions: 6000x4000", "Camera: X100"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("message %q missing %q", got, want)
+		}
+	}
+}
+
+func TestDirectoryPropertiesMessageIncludesNote(t *testing.T) {
+	file := fileinfo.FileInfo{Name: "proj", Path: "/proj", IsDir: true}
+
+	if got := directoryPropertiesMessage(file, "client A deliverables"); !strings.HasSuffix(got, "\nNote: client A deliverables") {
+		t.Fatalf("message = %q, want trailing note", got)
+	}
+	if got := directoryPropertiesMessage(file, ""); strings.Contains(got, "Note:") {
+		t.Fatalf("message = %q, want no note line", got)
+	}
+}
+
+func TestFilePropertiesMessageOmitsMetadataWhenUnavailable(t *testing.T) {
+	file := fileinfo.FileInfo{Name: "dir", Path: "/dir", IsDir: true}
+	meta := fileinfo.MediaMetadata{Width: 1, Height: 1}
+
+	got := filePropertiesMessage(file, meta, false)
+
+	if !strings.Contains(got, "Type: directory") || strings.Contains(got, "Dimensions") {
+		t.Fatalf("message = %q", got)
+	}
+}
+
+func TestMediaMetadataSummaryRequiresListColumnConfig(t *testing.T) {
+	svc := fileinfo.NewMetadataService(nil)
+	defer svc.Close()
+	cfg := &config.Config{}
+	fm := &FileManager{config: cfg, metadataSvc: svc}
+
+	if got := fm.mediaMetadataSummary(fileinfo.FileInfo{Name: "a.jpg", Path: "/a.jpg"}); got != "" {
+		t.Fatalf("summary = %q, want empty while showInList is disabled", got)
+	}
+}
+
+func TestPropertiesDetailsMessageShowsDirectorySizeProgress(t *testing.T) {
+	dir := fileinfo.FileInfo{Name: "proj", Path: "/proj", IsDir: true}
+	details := &propertiesDetails{
+		files:  []fileinfo.FileInfo{dir},
+		props:  []fileinfo.PathProperties{{Mode: os.ModeDir | 0o750, Owner: "alice (1000)", Xattrs: []string{"user.a"}}},
+		errs:   []error{nil},
+		note:   "client A",
+		sizes:  map[string]fileinfo.DirSize{},
+		failed: map[string]error{},
+	}
+
+	got := details.message()
+	for _, want := range []string{"Size: counting...", "Permissions: drwxr-x--- (750)", "Owner: alice (1000)", "Extended attributes: user.a"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("message %q missing %q", got, want)
+		}
+	}
+	if !strings.HasSuffix(got, "\nNote: client A") {
+		t.Fatalf("message %q, want trailing note", got)
+	}
+
+	details.sizes["/proj"] = fileinfo.DirSize{Files: 2, Dirs: 1, Bytes: 2048}
+	if got := details.message(); !strings.Contains(got, "Size: 2.0 KB (2048 bytes) in 2 files, 1 folders") {
+		t.Fatalf("message %q missing counted size", got)
+	}
+}
+
+func TestPropertiesDetailsEditableOnlyForReadableLocalTargets(t *testing.T) {
+	details := &propertiesDetails{
+		files: []fileinfo.FileInfo{{Name: "a"}, {Name: "b"}},
+		props: []fileinfo.PathProperties{{Local: true, Mode: 0o644}, {Local: false}},
+		errs:  []error{nil, nil},
+	}
+	if details.editable() {
+		t.Fatal("a remote target should make the dialog read-only")
+	}
+	details.props[1].Local = true
+	if !details.editable() || details.mode() != 0o644 {
+		t.Fatalf("editable=%t mode=%o, want editable 644", details.editable(), details.mode())
+	}
+}
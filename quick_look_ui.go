@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fyne.io/fyne/v2"
+
+	"nmf/internal/fileinfo"
+)
+
+// ShowQuickLook toggles the macOS Quick Look panel for the cursor file, as
+// Space does in Finder. Other platforms open the built-in file viewer.
+func (fm *FileManager) ShowQuickLook() {
+	if !fileinfo.QuickLookAvailable() {
+		fm.ShowFileViewer()
+		return
+	}
+	currentIdx := fm.GetCurrentCursorIndex()
+	files := fm.GetFiles()
+	if currentIdx < 0 || currentIdx >= len(files) {
+		return
+	}
+	file := files[currentIdx]
+	if file.Name == ".." || file.Status == fileinfo.StatusDeleted {
+		return
+	}
+
+	// Archive entries are extracted before previewing, so stay off the UI
+	// thread.
+	go func() {
+		err := fileinfo.QuickLook(file.Path)
+		debugPrint("FileManager: Quick Look path=%s err=%v", file.Path, err)
+		if err == nil {
+			return
+		}
+		fyne.Do(func() {
+			if fm.isWindowClosed() {
+				return
+			}
+			fm.ShowMessageDialog("Quick Look failed", err.Error())
+		})
+	}()
+}
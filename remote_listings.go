@@ -0,0 +1,115 @@
+package main
+
+import (
+	"time"
+
+	"nmf/internal/config"
+	"nmf/internal/fileinfo"
+	"nmf/internal/navigation"
+)
+
+// maxRemoteListings bounds how many left network directories a window keeps.
+const maxRemoteListings = 32
+
+// remoteListing is the listing of a network directory the window left, with
+// the hidden-file setting it was read under and when it was left.
+type remoteListing struct {
+	listing    directoryListing
+	showHidden bool
+	left       time.Time
+}
+
+// remoteListingCache keeps the listings of network directories the window
+// left in the last ui.remoteListingTTLSeconds, keyed by path, so going back
+// and forth between SMB directories does not wait for the server.
+type remoteListingCache map[string]remoteListing
+
+// put keeps l for path, dropping the listing left longest ago when the cache
+// is full.
+func (c remoteListingCache) put(path string, l remoteListing) {
+	if _, ok := c[path]; !ok && len(c) >= maxRemoteListings {
+		oldest := ""
+		for p, cached := range c {
+			if oldest == "" || cached.left.Before(c[oldest].left) {
+				oldest = p
+			}
+		}
+		delete(c, oldest)
+	}
+	c[path] = l
+}
+
+// prune drops the listings left more than ttl before now.
+func (c remoteListingCache) prune(now time.Time, ttl time.Duration) {
+	for p, cached := range c {
+		if now.Sub(cached.left) > ttl {
+			delete(c, p)
+		}
+	}
+}
+
+func (fm *FileManager) remoteListingTTL() time.Duration {
+	if fm.config == nil {
+		return 0
+	}
+	return time.Duration(fm.config.UI.RemoteListingTTL) * time.Second
+}
+
+// rememberRemoteListing keeps the current listing when the window is about
+// to leave a network directory for path. Tag views, vault contents, and
+// listings still streaming in are not kept.
+func (fm *FileManager) rememberRemoteListing(path string) {
+	current := fm.currentPath
+	ttl := fm.remoteListingTTL()
+	if ttl <= 0 || current == "" || current == path || fm.navigator.Pending() {
+		return
+	}
+	if fileinfo.IsTagViewPath(current) || fm.vaultCipherPath(current) != current {
+		return
+	}
+	if class, err := fileinfo.ClassifyPath(current); err != nil || !class.Network {
+		return
+	}
+	if fm.remoteListings == nil {
+		fm.remoteListings = make(remoteListingCache)
+	}
+	now := time.Now()
+	fm.remoteListings.prune(now, ttl)
+	listing := fm.currentListing()
+	fm.remoteListings.put(current, remoteListing{listing: listing, showHidden: fm.showHidden, left: now})
+	debugPrint("FileManager: Remembered remote listing path=%s files=%d", current, len(listing.files))
+}
+
+// cachedRemoteListing returns the kept listing of path when it was left
+// within the TTL with the same sort and hidden-file settings, and the
+// watcher will run for it to reconcile the listing with the server.
+func (fm *FileManager) cachedRemoteListing(path string, sortCfg config.SortConfig) (directoryListing, bool) {
+	cached, ok := fm.remoteListings[path]
+	if !ok {
+		return directoryListing{}, false
+	}
+	delete(fm.remoteListings, path)
+	if time.Since(cached.left) > fm.remoteListingTTL() {
+		return directoryListing{}, false
+	}
+	if cached.listing.sortCfg != sortCfg || cached.showHidden != fm.showHidden || !fm.shouldWatchPath(path) {
+		return directoryListing{}, false
+	}
+	return cached.listing, true
+}
+
+// showRemoteListing applies a kept network listing for load at once and
+// completes the load, which starts the watcher with the kept listing as its
+// baseline. The watcher then reads the directory without waiting for its
+// next poll, and what changed meanwhile arrives as ordinary watcher changes.
+func (fm *FileManager) showRemoteListing(load *navigation.Load, listing directoryListing) {
+	if !fm.navigator.Claim(load) {
+		return
+	}
+	debugPrint("FileManager: LoadDirectory from remote cache path=%s files=%d", load.Path, len(listing.files))
+	fm.applyDirectoryListing(load, listing, true)
+	fm.navigator.Complete(load)
+	if fm.dirWatcher != nil {
+		fm.dirWatcher.Refresh()
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRemoteListingCacheExpiresAndEvictsOldest(t *testing.T) {
+	now := time.Now()
+	c := remoteListingCache{}
+	for i := 0; i < maxRemoteListings; i++ {
+		c.put(fmt.Sprintf("smb://server/share/%d", i), remoteListing{left: now.Add(time.Duration(i) * time.Second)})
+	}
+	c.put("smb://server/share/new", remoteListing{left: now.Add(time.Hour)})
+	if len(c) != maxRemoteListings {
+		t.Fatalf("cache holds %d listings, want %d", len(c), maxRemoteListings)
+	}
+	if _, ok := c["smb://server/share/0"]; ok {
+		t.Fatal("the listing left longest ago was kept")
+	}
+	// Replacing a kept listing evicts nothing.
+	c.put("smb://server/share/1", remoteListing{left: now.Add(time.Hour)})
+	if _, ok := c["smb://server/share/2"]; !ok || len(c) != maxRemoteListings {
+		t.Fatal("replacing a kept listing evicted another")
+	}
+
+	c.prune(now.Add(time.Hour+30*time.Second), 30*time.Second)
+	if len(c) != 2 {
+		t.Fatalf("cache = %d listings after the TTL, want the 2 left an hour later", len(c))
+	}
+}
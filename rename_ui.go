@@ -5,6 +5,7 @@ import (
 	"unicode/utf8"
 
 	"nmf/internal/fileinfo"
+	"nmf/internal/jobs"
 	"nmf/internal/ui"
 )
 
@@ -61,6 +62,7 @@ func (fm *FileManager) renameCurrentFile(target fileinfo.FileInfo, newName strin
 	}
 
 	newPath, err := fileinfo.RenamePortable(target.Path, trimmed)
+	fm.recordAudit(jobs.AuditRename, []string{target.Path}, fileinfo.JoinPath(fileinfo.ParentPath(target.Path), trimmed), err)
 	if err != nil {
 		debugPrint("FileManager: Rename failed %s -> %s: %v", target.Path, trimmed, err)
 		fm.ShowMessageDialog("Rename failed", err.Error())
@@ -95,10 +97,8 @@ func (fm *FileManager) applyRenameToList(oldPath, newName, newPath string) {
 		}
 	}
 
-	if fm.selectedFiles[oldPath] {
-		delete(fm.selectedFiles, oldPath)
-		fm.selectedFiles[newPath] = true
-	}
+	fm.selection.Rename(oldPath, newPath)
+	fm.reweighMarks()
 
 	if !updated {
 		fm.mu.Unlock()
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"nmf/internal/fileinfo"
+	"nmf/internal/ui"
+)
+
+// ShowSelectByPatternDialog prompts for a glob or "tag:<color>" pattern and
+// marks the visible entries that match it.
+func (fm *FileManager) ShowSelectByPatternDialog() {
+	dlg := ui.NewLineEditDialog(ui.LineEditDialogOptions{
+		Title:       "Select by Pattern",
+		Prompt:      "Mark entries matching (e.g. *.go, tag:red):",
+		ConfirmText: "Select",
+	}, fm.keyManager, fm.config.UI.KeyBindings)
+	dlg.ShowDialog(fm.window, func(pattern string) bool {
+		if err := fileinfo.ValidatePattern(pattern); err != nil {
+			fm.ShowMessageDialog("Invalid pattern", err.Error())
+			return false
+		}
+		marked := fm.SelectByPattern(pattern)
+		debugPrint("FileManager: Select by pattern %q marked %d entries", pattern, marked)
+		fm.FocusFileList()
+		return true
+	})
+}
+
+// SelectByPattern adds marks to the visible entries whose name (or color tag)
+// matches pattern and returns how many were newly marked. Only fm.files is
+// scanned, so entries hidden by the active filter are never marked.
+func (fm *FileManager) SelectByPattern(pattern string) int {
+	fm.mu.RLock()
+	var paths []string
+	for _, fi := range fm.files {
+		if !isTargetFileInfo(fi) {
+			continue
+		}
+		if matched, err := fileinfo.MatchesFile(fi, pattern); err == nil && matched {
+			paths = append(paths, fi.Path)
+		}
+	}
+	fm.mu.RUnlock()
+
+	marked := fm.selection.SetMany(paths, true)
+
+	if marked > 0 {
+		fm.RefreshFileList()
+	}
+	return marked
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+)
+
+func TestSelectByPatternMarksVisibleMatches(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+
+	fm := newMouseListTestFileManager(t)
+	fm.selection.Set("/tmp/b.txt", true)
+
+	marked := fm.SelectByPattern("*.txt")
+
+	if marked != 1 {
+		t.Fatalf("marked = %d, want 1 (b.txt was already marked)", marked)
+	}
+	if !fm.selection.IsMarked("/tmp/a.txt") || !fm.selection.IsMarked("/tmp/b.txt") {
+		t.Fatalf("selected files = %+v, want a.txt and b.txt marked", fm.selection.Paths())
+	}
+	if fm.selection.IsMarked("/tmp/gone.txt") || fm.selection.IsMarked("/tmp/docs") {
+		t.Fatalf("selected files = %+v, deleted entries and non-matching dirs should stay unmarked", fm.selection.Paths())
+	}
+}
@@ -2,13 +2,26 @@ package main
 
 import "nmf/internal/fileinfo"
 
+// targetFileInfos returns the marked items, or the cursor item when none are
+// marked.
+func (fm *FileManager) targetFileInfos() []fileinfo.FileInfo {
+	if targets := fm.selectedFileInfos(); len(targets) > 0 {
+		return targets
+	}
+	idx := fm.GetCurrentCursorIndex()
+	if idx >= 0 && idx < len(fm.files) && isTargetFileInfo(fm.files[idx]) {
+		return []fileinfo.FileInfo{fm.files[idx]}
+	}
+	return nil
+}
+
 func (fm *FileManager) selectedFileInfos() []fileinfo.FileInfo {
 	fm.mu.RLock()
 	defer fm.mu.RUnlock()
 
-	targets := make([]fileinfo.FileInfo, 0, len(fm.selectedFiles))
+	targets := make([]fileinfo.FileInfo, 0, fm.selection.Len())
 	for _, fi := range fm.files {
-		if !fm.selectedFiles[fi.Path] || !isTargetFileInfo(fi) {
+		if !fm.selection.IsMarked(fi.Path) || !isTargetFileInfo(fi) {
 			continue
 		}
 		targets = append(targets, fi)
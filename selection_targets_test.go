@@ -7,6 +7,7 @@ import (
 	"fyne.io/fyne/v2/test"
 
 	"nmf/internal/fileinfo"
+	"nmf/internal/selection"
 )
 
 func TestGetAllSelectedFilesUsesAllOpenWindowsInOrder(t *testing.T) {
@@ -15,9 +16,9 @@ func TestGetAllSelectedFilesUsesAllOpenWindowsInOrder(t *testing.T) {
 	resetFileManagerWindowTestRegistry(t)
 
 	left := &FileManager{
-		window:        app.NewWindow("left"),
-		files:         []fileinfo.FileInfo{{Name: "a.txt", Path: "/left/a.txt"}, {Name: "skip.txt", Path: "/left/skip.txt"}},
-		selectedFiles: map[string]bool{"/left/a.txt": true, "/left/skip.txt": false},
+		window:    app.NewWindow("left"),
+		files:     []fileinfo.FileInfo{{Name: "a.txt", Path: "/left/a.txt"}, {Name: "skip.txt", Path: "/left/skip.txt"}},
+		selection: selection.New("/left/a.txt"),
 	}
 	right := &FileManager{
 		window: app.NewWindow("right"),
@@ -25,7 +26,7 @@ func TestGetAllSelectedFilesUsesAllOpenWindowsInOrder(t *testing.T) {
 			{Name: "deleted.txt", Path: "/right/deleted.txt", Status: fileinfo.StatusDeleted},
 			{Name: "b.txt", Path: "/right/b.txt"},
 		},
-		selectedFiles: map[string]bool{"/right/deleted.txt": true, "/right/b.txt": true},
+		selection: selection.New("/right/deleted.txt", "/right/b.txt"),
 	}
 
 	registerFileManagerWindow(left)
@@ -48,7 +49,7 @@ func resetFileManagerWindowTestRegistry(t *testing.T) {
 
 	windowOrderMu.Lock()
 	windowOrder = nil
-	reopenPaths = nil
+	closedItems = nil
 	windowOrderMu.Unlock()
 	windowRegistry.Range(func(key, _ any) bool {
 		windowRegistry.Delete(key)
@@ -57,7 +58,7 @@ func resetFileManagerWindowTestRegistry(t *testing.T) {
 	t.Cleanup(func() {
 		windowOrderMu.Lock()
 		windowOrder = nil
-		reopenPaths = nil
+		closedItems = nil
 		windowOrderMu.Unlock()
 		windowRegistry.Range(func(key, _ any) bool {
 			windowRegistry.Delete(key)
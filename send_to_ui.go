@@ -0,0 +1,92 @@
+package main
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+
+	"fyne.io/fyne/v2/driver"
+
+	"nmf/internal/fileinfo"
+	"nmf/internal/keymanager"
+	"nmf/internal/shellmenu"
+)
+
+// ShowSendToMenu lists the user's Windows SendTo folder entries and hands
+// the selected files, or the cursor item, to the chosen entry.
+func (fm *FileManager) ShowSendToMenu() {
+	if runtime.GOOS != "windows" {
+		fm.showCommandPopup("Send To", informationalExternalCommandMenuItem("Send To is only available on Windows."))
+		return
+	}
+	paths := fm.collectTargetPaths()
+	if len(paths) == 0 {
+		fm.showCommandPopup("Send To", informationalExternalCommandMenuItem("No file selected."))
+		return
+	}
+	for _, p := range paths {
+		if fileinfo.IsArchivePath(p) || fileinfo.IsTagViewPath(p) {
+			fm.showCommandPopup("Send To", informationalExternalCommandMenuItem("Send To needs files in a regular directory."))
+			return
+		}
+	}
+
+	targets, err := shellmenu.SendToTargets()
+	if err != nil {
+		debugPrint("FileManager: Send To targets unavailable err=%v", err)
+		fm.ShowMessageDialog("Send To failed", err.Error())
+		return
+	}
+	if len(targets) == 0 {
+		fm.showCommandPopup("Send To", informationalExternalCommandMenuItem("The SendTo folder is empty."))
+		return
+	}
+	fm.showCommandMenu(sendToMenuItems(targets, func(target shellmenu.SendToTarget) {
+		fm.sendTo(target, paths)
+	}))
+}
+
+// sendToMenuItems keeps SendTo folder order and gives the first nine entries
+// digit accelerators.
+func sendToMenuItems(targets []shellmenu.SendToTarget, run func(shellmenu.SendToTarget)) []keymanager.CommandMenuItem {
+	items := make([]keymanager.CommandMenuItem, 0, len(targets))
+	for i, target := range targets {
+		entry := target
+		item := keymanager.CommandMenuItem{
+			Label:  entry.Name,
+			Action: func() { run(entry) },
+		}
+		if i < 9 {
+			item.Key = strconv.Itoa(i + 1)
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func (fm *FileManager) sendTo(target shellmenu.SendToTarget, paths []string) {
+	nativeWindow, ok := fm.window.(driver.NativeWindow)
+	if !ok {
+		debugPrint("FileManager: Send To error native window unavailable window=%T", fm.window)
+		return
+	}
+	var err error
+	nativeWindow.RunNative(func(context any) {
+		winCtx, ok := context.(driver.WindowsWindowContext)
+		if !ok || winCtx.HWND == 0 {
+			err = shellmenu.ErrUnsupported
+			return
+		}
+		err = shellmenu.SendTo(winCtx.HWND, target, paths)
+	})
+	fm.FocusFileList()
+	if err != nil {
+		debugPrint("FileManager: Send To failed target=%s err=%v", target.Path, err)
+		if !errors.Is(err, shellmenu.ErrUnsupported) {
+			fm.ShowMessageDialog("Send To failed", err.Error())
+		}
+		return
+	}
+	debugPrint("FileManager: Send To target=%s sources=%d", target.Path, len(paths))
+	fm.refreshDirectoryAfterShellMenu()
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+
+	"nmf/internal/shellmenu"
+)
+
+func TestSendToMenuItemsAssignDigitKeysInOrder(t *testing.T) {
+	targets := make([]shellmenu.SendToTarget, 10)
+	for i := range targets {
+		targets[i] = shellmenu.SendToTarget{Name: string(rune('A' + i)), Path: `C:\SendTo\` + string(rune('A'+i)) + ".lnk"}
+	}
+	var ran string
+	items := sendToMenuItems(targets, func(target shellmenu.SendToTarget) { ran = target.Path })
+
+	if len(items) != 10 || items[0].Key != "1" || items[8].Key != "9" || items[9].Key != "" {
+		t.Fatalf("keys = %q %q %q (len %d)", items[0].Key, items[8].Key, items[9].Key, len(items))
+	}
+	items[2].Action()
+	if ran != `C:\SendTo\C.lnk` {
+		t.Fatalf("action ran %q, want the third target", ran)
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+
+	"nmf/internal/fileinfo"
+	"nmf/internal/ui"
+)
+
+// shellOutputFlushInterval batches output before it is handed to the UI
+// thread, so a command printing many short lines does not redraw per line.
+const shellOutputFlushInterval = 100 * time.Millisecond
+
+// shellCommandWaitDelay bounds how long a stopped command's pipes are kept
+// open by children that outlive the shell.
+const shellCommandWaitDelay = 2 * time.Second
+
+// ShowRunCommandDialog asks for a shell command line, runs it in the current
+// directory, and streams its output into a dialog. Remote and archive
+// directories have no local working directory, so they are refused, as for
+// external commands.
+func (fm *FileManager) ShowRunCommandDialog() {
+	dir, ignored := externalCommandWorkingDirectory(fm.currentPath)
+	if ignored || dir == "" {
+		debugPrint("FileManager: Run command refused path=%s", fm.currentPath)
+		fm.ShowMessageDialog("Run Command", "Commands can only run in a local directory:\n"+fm.currentPath)
+		return
+	}
+	cursor := fm.cursorTargetPath()
+	marked := fm.collectAllSelectedTargetPaths()
+
+	dlg := ui.NewRunCommandDialog(dir, fm.shellCommandLine, !fm.shellCommandNoRefresh, fm.keyManager, fm.config.UI.KeyBindings)
+	dlg.ShowDialog(fm.window, func(line string, refresh bool) bool {
+		if strings.TrimSpace(line) == "" {
+			return false
+		}
+		fm.shellCommandLine = line
+		fm.shellCommandNoRefresh = !refresh
+		expanded := expandShellCommandLine(line, cursor, marked)
+		fm.runShellCommand(expanded, dir, refresh)
+		return true
+	})
+}
+
+// cursorTargetPath returns the path of the file under the cursor, or "" on
+// the parent entry.
+func (fm *FileManager) cursorTargetPath() string {
+	idx := fm.GetCurrentCursorIndex()
+	if idx < 0 || idx >= len(fm.files) {
+		return ""
+	}
+	fi := fm.files[idx]
+	if fi.Name == ".." || fi.Status == fileinfo.StatusDeleted {
+		return ""
+	}
+	return fi.Path
+}
+
+// expandShellCommandLine replaces %f with the cursor file and %s with the
+// marked files, or the cursor file when nothing is marked. Paths are quoted
+// for the shell; %% is a literal %.
+func expandShellCommandLine(line, cursor string, marked []string) string {
+	quotedCursor := ""
+	if cursor != "" {
+		quotedCursor = quoteShellWord(fileinfo.CommandArgumentPath(cursor))
+	}
+	selected := quotedCursor
+	if len(marked) > 0 {
+		quoted := make([]string, len(marked))
+		for i, path := range marked {
+			quoted[i] = quoteShellWord(fileinfo.CommandArgumentPath(path))
+		}
+		selected = strings.Join(quoted, " ")
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(line); i++ {
+		if line[i] != '%' || i+1 == len(line) {
+			b.WriteByte(line[i])
+			continue
+		}
+		switch line[i+1] {
+		case 'f':
+			b.WriteString(quotedCursor)
+		case 's':
+			b.WriteString(selected)
+		case '%':
+			b.WriteByte('%')
+		default:
+			b.WriteByte('%')
+			continue
+		}
+		i++
+	}
+	return b.String()
+}
+
+// runShellCommand runs line through the system shell in dir and shows its
+// combined stdout and stderr as it arrives. Closing the dialog stops a
+// command that is still running.
+func (fm *FileManager) runShellCommand(line, dir string, refresh bool) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd := shellCommand(ctx, line)
+	cmd.Dir = dir
+	cmd.WaitDelay = shellCommandWaitDelay
+	out := &shellOutputBuffer{}
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	output := ui.NewCommandOutputDialog("Run Command", line, fm.keyManager, debugPrint)
+	if err := cmd.Start(); err != nil {
+		cancel()
+		debugPrint("FileManager: Run command failed line=%s err=%v", line, err)
+		fm.ShowMessageDialog("Command failed", err.Error())
+		return
+	}
+	debugPrint("FileManager: Run command started line=%s pid=%d dir=%s", line, cmd.Process.Pid, dir)
+	path := fm.currentPath
+	output.ShowDialog(fm.window, cancel, func() {
+		if !fm.isWindowClosed() {
+			fm.FocusFileList()
+		}
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	go func() {
+		defer cancel()
+		ticker := time.NewTicker(shellOutputFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if chunk := out.take(); chunk != "" {
+					fyne.Do(func() { output.AppendOutput(chunk) })
+				}
+			case err := <-done:
+				chunk := out.take()
+				status := shellCommandStatus(err, ctx.Err() != nil)
+				debugPrint("FileManager: Run command finished line=%s status=%s", line, status)
+				fyne.Do(func() {
+					output.AppendOutput(chunk)
+					output.Finish(status)
+					if refresh && !fm.isWindowClosed() && fm.currentPath == path {
+						fm.SaveCursorPosition(path)
+						fm.LoadDirectory(path)
+					}
+				})
+				return
+			}
+		}
+	}()
+}
+
+// shellCommandStatus describes how a command ended for the status line.
+func shellCommandStatus(err error, stopped bool) string {
+	if stopped {
+		return "Stopped"
+	}
+	if err == nil {
+		return "Finished"
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() >= 0 {
+		return fmt.Sprintf("Exited with status %d", exitErr.ExitCode())
+	}
+	return "Failed: " + err.Error()
+}
+
+// shellOutputBuffer collects output written by the command's stdout and
+// stderr copiers until the flush loop takes it.
+type shellOutputBuffer struct {
+	mu  sync.Mutex
+	buf strings.Builder
+}
+
+func (b *shellOutputBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *shellOutputBuffer) take() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s := b.buf.String()
+	b.buf.Reset()
+	return s
+}
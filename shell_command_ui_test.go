@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+)
+
+func TestExpandShellCommandLinePlaceholders(t *testing.T) {
+	cursor := "/src/a b.txt"
+	marked := []string{"/src/one.go", "/src/it's.go"}
+	qCursor := quoteShellWord(cursor)
+
+	tests := []struct {
+		line   string
+		marked []string
+		want   string
+	}{
+		{"wc -l %f", marked, "wc -l " + qCursor},
+		{"wc -l %s", marked, "wc -l " + quoteShellWord(marked[0]) + " " + quoteShellWord(marked[1])},
+		{"wc -l %s", nil, "wc -l " + qCursor},
+		{"date +%%Y %d 100%", nil, "date +%Y %d 100%"},
+	}
+	for _, tt := range tests {
+		if got := expandShellCommandLine(tt.line, cursor, tt.marked); got != tt.want {
+			t.Errorf("expand(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+	if got := expandShellCommandLine("ls %f", "", nil); got != "ls " {
+		t.Errorf("expand with no cursor file = %q, want %q", got, "ls ")
+	}
+}
+
+func TestQuoteShellWordQuotesSpecialCharacters(t *testing.T) {
+	if got := quoteShellWord("plain.txt"); got != "plain.txt" {
+		t.Fatalf("quoteShellWord(plain.txt) = %q", got)
+	}
+	for _, word := range []string{"", "a b", "a&b", "a;b"} {
+		if got := quoteShellWord(word); got == word {
+			t.Errorf("quoteShellWord(%q) left the word unquoted", word)
+		}
+	}
+}
+
+func TestShellCommandStatus(t *testing.T) {
+	if got := shellCommandStatus(nil, false); got != "Finished" {
+		t.Fatalf("status(nil) = %q", got)
+	}
+	if got := shellCommandStatus(errors.New("signal: killed"), true); got != "Stopped" {
+		t.Fatalf("status(stopped) = %q", got)
+	}
+	err := exec.Command("/bin/sh", "-c", "exit 3").Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if got := shellCommandStatus(err, false); got != "Exited with status 3" {
+			t.Fatalf("status(exit 3) = %q", got)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+//go:build windows
+
+package main
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// shellCommand runs line through cmd.exe. The command line is passed
+// verbatim: Go's argument escaping would add backslashes cmd.exe does not
+// understand.
+func shellCommand(ctx context.Context, line string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "cmd.exe")
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		CmdLine:    `cmd.exe /d /s /c "` + line + `"`,
+		HideWindow: true,
+	}
+	return cmd
+}
+
+// quoteShellWord double-quotes word when it contains spaces or characters
+// cmd.exe would interpret. File names cannot contain double quotes.
+func quoteShellWord(word string) string {
+	if word != "" && !strings.ContainsAny(word, " \t&|<>()^,;=%!") {
+		return word
+	}
+	return `"` + word + `"`
+}
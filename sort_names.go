@@ -0,0 +1,118 @@
+package main
+
+import (
+	"cmp"
+	"os"
+	"strings"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+
+	"nmf/internal/config"
+)
+
+// nameKeyer maps a file name to a key whose byte order is the name order a
+// sort config asks for. A keyer may keep state between calls, so each sort
+// builds its own with newNameKeyer and uses it from one goroutine.
+type nameKeyer func(name string) string
+
+// newNameKeyer returns the name keyer for cfg, or nil when names compare as
+// plain lowercase strings. With a locale, names are collated for it, ignoring
+// case; Natural then also collates digit runs by value. Without one, Natural
+// compares digit runs by value and everything else by lowercase code point.
+func newNameKeyer(cfg config.SortConfig) nameKeyer {
+	if cfg.Locale != "" {
+		options := []collate.Option{collate.IgnoreCase}
+		if cfg.Natural {
+			options = append(options, collate.Numeric)
+		}
+		collator := collate.New(sortLocaleTag(cfg.Locale), options...)
+		var buf collate.Buffer
+		return func(name string) string {
+			key := string(collator.KeyFromString(&buf, name))
+			buf.Reset()
+			return key
+		}
+	}
+	if cfg.Natural {
+		return func(name string) string {
+			return naturalNameKey(strings.ToLower(name))
+		}
+	}
+	return nil
+}
+
+// compareNameKeys orders two entries by name key. Names with equal keys, such
+// as "file01" and "file1" or names differing only in case under a locale,
+// fall back to lowercase order so the result does not depend on input order.
+func compareNameKeys(a, b sortKey) int {
+	if c := cmp.Compare(a.nameKey, b.nameKey); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.lowerName, b.lowerName)
+}
+
+// naturalNameKey rewrites each run of ASCII digits in name as '0', the run
+// length without leading zeros, and the digits without leading zeros, so that
+// byte order compares the runs by value: "file2" sorts before "file10".
+// Runs longer than 255 significant digits compare by their first 255.
+func naturalNameKey(name string) string {
+	var b strings.Builder
+	b.Grow(len(name) + 8)
+	for i := 0; i < len(name); {
+		if !isASCIIDigit(name[i]) {
+			b.WriteByte(name[i])
+			i++
+			continue
+		}
+		start := i
+		for i < len(name) && isASCIIDigit(name[i]) {
+			i++
+		}
+		digits := strings.TrimLeft(name[start:i], "0")
+		if digits == "" {
+			digits = "0"
+		}
+		digits = digits[:min(len(digits), 255)]
+		b.WriteByte('0')
+		b.WriteByte(byte(len(digits)))
+		b.WriteString(digits)
+	}
+	return b.String()
+}
+
+func isASCIIDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// sortLocaleTag resolves a ui.sort.locale value to a language tag. "auto"
+// takes the collation locale from LC_ALL, LC_COLLATE, or LANG, in that
+// order; the C and POSIX locales and anything unparsable give the root
+// collation, which orders most scripts sensibly without language rules.
+func sortLocaleTag(locale string) language.Tag {
+	if locale != config.SortLocaleAuto {
+		tag, err := language.Parse(locale)
+		if err != nil {
+			return language.Und
+		}
+		return tag
+	}
+	for _, name := range []string{"LC_ALL", "LC_COLLATE", "LANG"} {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		if value == "C" || value == "POSIX" || strings.HasPrefix(value, "C.") {
+			return language.Und
+		}
+		// POSIX locales look like ja_JP.UTF-8 or de_DE@euro.
+		value, _, _ = strings.Cut(value, ".")
+		value, _, _ = strings.Cut(value, "@")
+		tag, err := language.Parse(strings.ReplaceAll(value, "_", "-"))
+		if err != nil {
+			return language.Und
+		}
+		return tag
+	}
+	return language.Und
+}
@@ -3,18 +3,98 @@ package main
 import (
 	"fmt"
 
+	"fyne.io/fyne/v2"
+
 	"nmf/internal/fileinfo"
+	"nmf/internal/selection"
 )
 
+// updateStatusBar redraws the top status line and the bottom selection and
+// disk usage bars. It runs on every directory load and, through
+// onSelectionChanged, after mark changes.
 func (fm *FileManager) updateStatusBar() {
+	if fm.selectionBar != nil {
+		fm.selectionBar.SetText(fm.selectionBarText())
+	}
+	fm.updateDiskUsageBar()
 	if fm.statusLabel == nil {
 		return
 	}
 	fm.statusLabel.SetText(fm.statusBarText())
 }
 
+// onSelectionChanged queues one status bar redraw for a burst of mark
+// changes. fyne.Do called from the UI goroutine runs after the current event,
+// so a command marking thousands of entries redraws once.
+func (fm *FileManager) onSelectionChanged() {
+	if !fm.selectionPending.CompareAndSwap(false, true) {
+		return
+	}
+	fyne.Do(func() {
+		fm.selectionPending.Store(false)
+		if !fm.isWindowClosed() {
+			fm.updateStatusBar()
+		}
+	})
+}
+
+// markWeight is the selection model's weigher: what marking path adds to
+// the selection bar. Entries are looked up in originalFiles, so marks hidden
+// by the filter still count; ".." and deleted rows are never operation
+// targets and weigh nothing, and directories add no bytes.
+func (fm *FileManager) markWeight(path string) selection.Totals {
+	if fm.markIndex == nil {
+		listing := fm.originalFiles
+		if len(listing) == 0 {
+			listing = fm.files
+		}
+		fm.markIndex = make(map[string]int, len(listing))
+		for i, fi := range listing {
+			fm.markIndex[fi.Path] = i
+		}
+		fm.markListing = listing
+	}
+	i, ok := fm.markIndex[path]
+	if !ok || !isTargetFileInfo(fm.markListing[i]) {
+		return selection.Totals{}
+	}
+	fi := fm.markListing[i]
+	if fi.IsDir {
+		return selection.Totals{Entries: 1}
+	}
+	return selection.Totals{Entries: 1, Files: 1, Bytes: fi.Size}
+}
+
+// reweighMarks recounts the mark totals after the listing changed. It
+// rebuilds the path index once per listing change, so mark changes
+// themselves never walk the listing.
+func (fm *FileManager) reweighMarks() {
+	fm.markIndex = nil
+	fm.markListing = nil
+	if fm.selection != nil {
+		fm.selection.Reweigh()
+	}
+}
+
+// selectionBarText formats the bottom bar: the item count of the whole
+// listing and the marked entries with their combined file size, read from
+// the selection model's running totals.
+func (fm *FileManager) selectionBarText() string {
+	listing := fm.originalFiles
+	if len(listing) == 0 {
+		listing = fm.files
+	}
+	items := countEntriesExcludingParent(listing)
+	stats := fm.selection.Totals()
+	if stats.Entries == 0 {
+		return fmt.Sprintf("Items: %d | Marked: 0", items)
+	}
+	return fmt.Sprintf("Items: %d | Marked: %d (%d files, %s)",
+		items, stats.Entries, stats.Files, fileinfo.FormatFileSize(stats.Bytes))
+}
+
 func (fm *FileManager) statusBarText() string {
-	markCount := countMarkedFiles(fm.selectedFiles)
+	markCount := fm.selection.Len()
 	visibleEntries := countEntriesExcludingParent(fm.files)
 	totalEntries := countEntriesExcludingParent(fm.originalFiles)
 	if totalEntries == 0 && len(fm.originalFiles) == 0 {
@@ -30,18 +110,29 @@ func (fm *FileManager) statusBarText() string {
 		total = fileinfo.FormatFileSize(int64(fm.storageInfo.Total))
 	}
 
-	return fmt.Sprintf("Mark: %d | Entry: %d/%d | Free: %s | Used: %s | Total: %s",
+	text := fmt.Sprintf("Mark: %d | Entry: %d/%d | Free: %s | Used: %s | Total: %s",
 		markCount, visibleEntries, totalEntries, free, used, total)
-}
-
-func countMarkedFiles(selected map[string]bool) int {
-	count := 0
-	for _, marked := range selected {
-		if marked {
-			count++
-		}
+	if fm.manualRefresh {
+		text += " | Auto-refresh: off (loaded " + fm.loadedAt.Format("15:04:05") + ")"
+	} else if fm.unwatched {
+		text += " | Auto-refresh: unavailable here (loaded " + fm.loadedAt.Format("15:04:05") + ")"
+	}
+	if fm.viewProfile != "" {
+		text += " | Profile: " + fm.viewProfile
+	}
+	if fm.gitIgnoreActive {
+		text += " | Git-ignored: hidden"
+	}
+	if fm.dirNote != "" {
+		text += " | Note: " + fm.dirNote
+	}
+	fm.busyMu.Lock()
+	busy, busyText := fm.busyActive, fm.busyText
+	fm.busyMu.Unlock()
+	if busy {
+		text = "Busy: " + busyText + " (Esc cancels) | " + text
 	}
-	return count
+	return text
 }
 
 // countEntriesExcludingParent relies on the sort invariant that
@@ -3,8 +3,10 @@ package main
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"nmf/internal/fileinfo"
+	"nmf/internal/selection"
 )
 
 func TestCountEntriesExcludingParent(t *testing.T) {
@@ -36,18 +38,6 @@ func TestCountEntriesExcludingParentEmpty(t *testing.T) {
 	}
 }
 
-func TestCountMarkedFilesCountsOnlyTrueValues(t *testing.T) {
-	selected := map[string]bool{
-		"/tmp/a": true,
-		"/tmp/b": false,
-		"/tmp/c": true,
-	}
-
-	if got := countMarkedFiles(selected); got != 2 {
-		t.Fatalf("countMarkedFiles got %d, want 2", got)
-	}
-}
-
 func TestStatusBarTextShowsVisibleAndTotalEntries(t *testing.T) {
 	fm := &FileManager{
 		files: []fileinfo.FileInfo{
@@ -59,9 +49,7 @@ func TestStatusBarTextShowsVisibleAndTotalEntries(t *testing.T) {
 			{Name: "visible.txt"},
 			{Name: "filtered.log"},
 		},
-		selectedFiles: map[string]bool{
-			"/tmp/visible.txt": true,
-		},
+		selection: selection.New("/tmp/visible.txt"),
 		storageInfo: fileinfo.StorageInfo{
 			Free:  1024,
 			Used:  2048,
@@ -84,11 +72,45 @@ func TestStatusBarTextShowsVisibleAndTotalEntries(t *testing.T) {
 	}
 }
 
+func TestDiskUsageTextWarnsBelowThreshold(t *testing.T) {
+	const gb = 1 << 30
+	tests := []struct {
+		name     string
+		info     fileinfo.StorageInfo
+		known    bool
+		warn     int
+		wantText string
+		wantLow  bool
+	}{
+		{name: "plenty", info: fileinfo.StorageInfo{Free: 50 * gb, Total: 100 * gb}, known: true, warn: 10, wantText: "Disk: 50.0 GB free of 100.0 GB (50%)"},
+		{name: "low", info: fileinfo.StorageInfo{Free: 5 * gb, Total: 100 * gb}, known: true, warn: 10, wantText: "Disk: 5.0 GB free of 100.0 GB (5%)", wantLow: true},
+		{name: "warning off", info: fileinfo.StorageInfo{Free: 0, Total: 100 * gb}, known: true, warn: 0, wantText: "Disk: 0 B free of 100.0 GB (0%)"},
+		{name: "unknown", info: fileinfo.StorageInfo{Free: 5 * gb, Total: 100 * gb}, wantText: "Disk: -"},
+		{name: "no total", known: true, warn: 10, wantText: "Disk: -"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, low := diskUsageText(tt.info, tt.known, tt.warn)
+			if text != tt.wantText || low != tt.wantLow {
+				t.Fatalf("diskUsageText = %q, %v; want %q, %v", text, low, tt.wantText, tt.wantLow)
+			}
+		})
+	}
+}
+
+func TestStatusBarTextAppendsDirectoryNote(t *testing.T) {
+	fm := &FileManager{dirNote: "shared with QA"}
+
+	if text := fm.statusBarText(); !strings.HasSuffix(text, " | Note: shared with QA") {
+		t.Fatalf("statusBarText %q should end with the directory note", text)
+	}
+}
+
 func TestStatusBarTextUsesDashForUnknownStorage(t *testing.T) {
 	fm := &FileManager{
 		files:         []fileinfo.FileInfo{{Name: "a.txt"}},
 		originalFiles: []fileinfo.FileInfo{{Name: "a.txt"}},
-		selectedFiles: map[string]bool{},
+		selection:     selection.New(),
 	}
 
 	text := fm.statusBarText()
@@ -96,3 +118,66 @@ func TestStatusBarTextUsesDashForUnknownStorage(t *testing.T) {
 		t.Fatalf("statusBarText %q should use dashes for unknown storage", text)
 	}
 }
+
+func TestStatusBarTextShowsManualRefreshLoadTime(t *testing.T) {
+	fm := &FileManager{manualRefresh: true, loadedAt: time.Date(2026, 1, 2, 9, 5, 7, 0, time.Local)}
+
+	if text := fm.statusBarText(); !strings.Contains(text, " | Auto-refresh: off (loaded 09:05:07)") {
+		t.Fatalf("statusBarText %q should flag the manual-refresh listing", text)
+	}
+}
+
+func TestStatusBarTextFlagsUnwatchedDirectory(t *testing.T) {
+	fm := &FileManager{unwatched: true, loadedAt: time.Date(2026, 1, 2, 9, 5, 7, 0, time.Local)}
+
+	if text := fm.statusBarText(); !strings.Contains(text, " | Auto-refresh: unavailable here (loaded 09:05:07)") {
+		t.Fatalf("statusBarText %q should flag the unwatched directory", text)
+	}
+}
+
+func TestStatusBarTextLeadsWithBusyState(t *testing.T) {
+	fm := &FileManager{busyActive: true, busyText: "Loading /srv/share..."}
+
+	if text := fm.statusBarText(); !strings.HasPrefix(text, "Busy: Loading /srv/share... (Esc cancels) | Mark: 0") {
+		t.Fatalf("statusBarText %q should lead with the busy state", text)
+	}
+}
+
+func TestSelectionBarTextSumsMarkedFileSizes(t *testing.T) {
+	listing := []fileinfo.FileInfo{
+		{Name: "..", Path: "/tmp", IsDir: true},
+		{Name: "a.txt", Path: "/tmp/a.txt", Size: 1024},
+		{Name: "b.log", Path: "/tmp/b.log", Size: 2048},
+		{Name: "docs", Path: "/tmp/docs", IsDir: true, Size: 4096},
+		{Name: "gone.txt", Path: "/tmp/gone.txt", Size: 512, Status: fileinfo.StatusDeleted},
+	}
+	fm := &FileManager{
+		files:         listing[:2],
+		originalFiles: listing,
+		selection:     selection.New("/tmp/a.txt", "/tmp/b.log", "/tmp/docs", "/tmp/gone.txt"),
+	}
+	fm.selection.SetWeigher(fm.markWeight)
+
+	want := "Items: 4 | Marked: 3 (2 files, 3.0 KB)"
+	if got := fm.selectionBarText(); got != want {
+		t.Fatalf("selectionBarText = %q, want %q", got, want)
+	}
+
+	// Totals follow single mark changes without another pass.
+	fm.selection.Set("/tmp/b.log", false)
+	if got := fm.selectionBarText(); got != "Items: 4 | Marked: 2 (1 files, 1.0 KB)" {
+		t.Fatalf("selectionBarText after unmarking = %q", got)
+	}
+
+	// A changed listing reweighs the marks it still holds.
+	fm.originalFiles[1].Size = 4096
+	fm.reweighMarks()
+	if got := fm.selectionBarText(); got != "Items: 4 | Marked: 2 (1 files, 4.0 KB)" {
+		t.Fatalf("selectionBarText after the listing changed = %q", got)
+	}
+
+	fm.selection.Clear()
+	if got := fm.selectionBarText(); got != "Items: 4 | Marked: 0" {
+		t.Fatalf("selectionBarText without marks = %q", got)
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+
+	"nmf/internal/filecompare"
+	"nmf/internal/fileinfo"
+	"nmf/internal/jobs"
+	"nmf/internal/ui"
+)
+
+// previewSync plans synchronizing dest with source off the UI goroutine and
+// shows the plan; the sync job is queued with exactly that plan once the user
+// confirms it. Esc while planning abandons it.
+func (fm *FileManager) previewSync(source, dest string, method filecompare.Method) {
+	ctx, cancel := context.WithCancel(context.Background())
+	fm.beginBusy(fmt.Sprintf("Planning sync with %s...", dest), func() {
+		cancel()
+		fm.endBusy()
+		fm.FocusFileList()
+	})
+	go func() {
+		plan, err := filecompare.PlanSync(ctx, source, dest, method)
+		fyne.Do(func() {
+			if fm.isWindowClosed() || ctx.Err() != nil {
+				return
+			}
+			cancel()
+			fm.endBusy()
+			if err != nil {
+				debugPrint("FileManager: Sync plan failed source=%s dest=%s method=%s err=%v", source, dest, method, err)
+				fm.ShowMessageDialog("Synchronize Directories", err.Error())
+				fm.FocusFileList()
+				return
+			}
+			debugPrint("FileManager: Sync planned source=%s dest=%s method=%s actions=%d", source, dest, method, len(plan))
+			if len(plan) == 0 {
+				fm.ShowMessageDialog("Synchronize Directories", "The directories are already in sync.")
+				fm.FocusFileList()
+				return
+			}
+			dlg := ui.NewSyncPreviewDialog(syncPreviewSummary(method, source, dest, plan), syncPreviewLines(plan, source, dest), fm.keyManager)
+			dlg.ShowDialog(fm.window, func() {
+				j := fm.jobManager().EnqueueSync(source, dest, jobs.SyncOptions{Method: method, Plan: plan})
+				debugPrint("FileManager: Sync job id=%d method=%s actions=%d", j.ID, method, len(plan))
+				fm.FocusFileList()
+			})
+		})
+	}()
+}
+
+// syncPreviewSummary names the sync and counts its planned copies, with the
+// bytes they write, deletes, and conflicts.
+func syncPreviewSummary(method filecompare.Method, source, dest string, plan []filecompare.SyncAction) string {
+	var copies, deletes, conflicts int
+	var bytes int64
+	for _, a := range plan {
+		switch a.Kind {
+		case filecompare.SyncCopy:
+			copies++
+			bytes += a.Size
+		case filecompare.SyncDelete:
+			deletes++
+		case filecompare.SyncConflict:
+			conflicts++
+		}
+	}
+	head := fmt.Sprintf("Mirror %s into %s", source, dest)
+	if method == filecompare.SyncTwoWay {
+		head = fmt.Sprintf("Synchronize %s and %s both ways", source, dest)
+	}
+	counts := fmt.Sprintf("%d to copy (%s), %d to delete", copies, fileinfo.FormatFileSize(bytes), deletes)
+	if conflicts > 0 {
+		counts += fmt.Sprintf(", %d conflict(s) left alone", conflicts)
+	}
+	return head + ": " + counts
+}
+
+// syncPreviewLines renders one line per planned action, relative to the
+// synchronized directories: "->" copies toward dest, "<-" toward source, and
+// directories end with a slash.
+func syncPreviewLines(plan []filecompare.SyncAction, source, dest string) []string {
+	lines := make([]string, 0, len(plan))
+	for _, a := range plan {
+		name, toSource := cutSyncRoot(a.To, source)
+		if !toSource {
+			name, _ = cutSyncRoot(a.To, dest)
+		}
+		if a.Dir {
+			name += "/"
+		}
+		switch {
+		case a.Kind == filecompare.SyncCopy && toSource:
+			lines = append(lines, "copy     <- "+name)
+		case a.Kind == filecompare.SyncCopy:
+			lines = append(lines, "copy     -> "+name)
+		case a.Kind == filecompare.SyncDelete:
+			lines = append(lines, "delete      "+name)
+		default:
+			lines = append(lines, "conflict    "+name)
+		}
+	}
+	return lines
+}
+
+// cutSyncRoot returns p relative to root and whether p lies under root.
+// Paths outside root come back unchanged.
+func cutSyncRoot(p, root string) (string, bool) {
+	rest, ok := strings.CutPrefix(p, root)
+	if !ok || (rest != "" && rest[0] != '/' && rest[0] != '\\') {
+		return p, false
+	}
+	return strings.TrimLeft(rest, `/\`), true
+}
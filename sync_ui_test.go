@@ -0,0 +1,39 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"nmf/internal/filecompare"
+)
+
+func TestSyncPreviewLinesShowDirectionRelativeToRoots(t *testing.T) {
+	plan := []filecompare.SyncAction{
+		{Kind: filecompare.SyncCopy, From: "/src/a.txt", To: "smb://nas/backup/a.txt", Size: 2048},
+		{Kind: filecompare.SyncCopy, From: "smb://nas/backup/new", To: "/src/new", Dir: true},
+		{Kind: filecompare.SyncDelete, To: "smb://nas/backup/old.txt"},
+		{Kind: filecompare.SyncConflict, From: "/src/kind", To: "smb://nas/backup/kind", Dir: true},
+	}
+	want := []string{
+		"copy     -> a.txt",
+		"copy     <- new/",
+		"delete      old.txt",
+		"conflict    kind/",
+	}
+	if got := syncPreviewLines(plan, "/src", "smb://nas/backup"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("lines = %q, want %q", got, want)
+	}
+	summary := syncPreviewSummary(filecompare.SyncTwoWay, "/src", "smb://nas/backup", plan)
+	if summary != "Synchronize /src and smb://nas/backup both ways: 2 to copy (2.0 KB), 1 to delete, 1 conflict(s) left alone" {
+		t.Fatalf("summary = %q", summary)
+	}
+}
+
+func TestCutSyncRootKeepsSiblingPrefixesOutside(t *testing.T) {
+	if rel, ok := cutSyncRoot("/srcdir/a", "/src"); ok || rel != "/srcdir/a" {
+		t.Fatalf("cutSyncRoot(/srcdir/a, /src) = %q, %t", rel, ok)
+	}
+	if rel, ok := cutSyncRoot(`C:\src\sub\a`, `C:\src`); !ok || rel != `sub\a` {
+		t.Fatalf("cutSyncRoot(windows) = %q, %t", rel, ok)
+	}
+}
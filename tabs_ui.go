@@ -0,0 +1,212 @@
+package main
+
+import (
+	"slices"
+
+	"nmf/internal/config"
+	"nmf/internal/fileinfo"
+)
+
+// tabState is one directory tab's view state. While a tab is active the
+// FileManager fields are authoritative; its entry here is refreshed when the
+// window leaves it (switch, new tab, session save).
+type tabState struct {
+	path     string
+	cursor   string // cursor file path
+	filter   *config.FilterEntry
+	sort     config.SortConfig
+	selected map[string]bool
+}
+
+// captureActiveTab copies the visible listing's view state into the active
+// tab entry.
+func (fm *FileManager) captureActiveTab() {
+	if len(fm.tabs) == 0 {
+		fm.tabs = []tabState{{}}
+		fm.activeTab = 0
+	}
+	fm.tabs[fm.activeTab] = tabState{
+		path:     fm.currentPath,
+		cursor:   fm.cursorPath,
+		filter:   fm.currentFilter,
+		sort:     fm.CurrentSort(),
+		selected: fm.selection.Snapshot(),
+	}
+}
+
+// NewTab opens a tab on the current directory right after the active one. It
+// keeps the cursor and sort but starts without a filter or selection.
+func (fm *FileManager) NewTab() {
+	fm.captureActiveTab()
+	tab := tabState{path: fm.currentPath, cursor: fm.cursorPath, sort: fm.CurrentSort()}
+	index := fm.activeTab + 1
+	fm.tabs = slices.Insert(fm.tabs, index, tab)
+	debugPrint("FileManager: New tab index=%d path=%s tabs=%d", index, tab.path, len(fm.tabs))
+	fm.showTab(index)
+}
+
+// CloseTab closes the active tab and shows its right neighbour, or the left
+// one when it was last. The only tab is never closed; closing the window is
+// app.quit's job.
+func (fm *FileManager) CloseTab() {
+	if len(fm.tabs) <= 1 {
+		debugPrint("FileManager: Close tab ignored; only one tab")
+		return
+	}
+	fm.captureActiveTab()
+	recordClosedItem(closedItem{tabs: []tabState{fm.tabs[fm.activeTab]}})
+	fm.tabs = slices.Delete(fm.tabs, fm.activeTab, fm.activeTab+1)
+	debugPrint("FileManager: Closed tab index=%d tabs=%d", fm.activeTab, len(fm.tabs))
+	fm.showTab(min(fm.activeTab, len(fm.tabs)-1))
+}
+
+// SwitchTab activates the tab delta positions away, wrapping around.
+func (fm *FileManager) SwitchTab(delta int) {
+	if len(fm.tabs) <= 1 {
+		return
+	}
+	fm.captureActiveTab()
+	n := len(fm.tabs)
+	fm.showTab(((fm.activeTab+delta)%n + n) % n)
+}
+
+// selectTab handles a click on the tab bar.
+func (fm *FileManager) selectTab(index int) {
+	if index < 0 || index >= len(fm.tabs) {
+		return
+	}
+	if index == fm.activeTab {
+		fm.focusFileList("tab-bar-active")
+		return
+	}
+	fm.captureActiveTab()
+	fm.showTab(index)
+}
+
+// showTab makes index active and loads its directory; the tab's sort,
+// filter, selection, and cursor are reapplied when the load finishes.
+func (fm *FileManager) showTab(index int) {
+	fm.activeTab = index
+	tab := fm.tabs[index]
+	fm.pendingTabRestore = &tab
+	fm.updateTabBar()
+	fm.saveTabSession()
+	fm.LoadDirectory(tab.path)
+}
+
+// applyPendingTabRestore reapplies a shown tab's view state once its
+// directory has loaded. A load of any other path drops the pending state.
+func (fm *FileManager) applyPendingTabRestore(path string) {
+	tab := fm.pendingTabRestore
+	fm.pendingTabRestore = nil
+	if tab == nil || canonicalNavigationHistoryPath(tab.path) != path {
+		return
+	}
+
+	if tab.sort.SortBy != "" && tab.sort != fm.activeSort {
+		fm.setActiveSort(tab.sort)
+		fm.sortFilesWithConfig(tab.sort)
+	}
+	fm.currentFilter = tab.filter
+	if tab.filter != nil {
+		if pattern := config.EffectiveFilterPattern(tab.filter.Pattern); pattern != "" {
+			if filtered, err := fm.filterFiles(fm.originalFiles, pattern); err != nil {
+				debugPrint("FileManager: Tab filter error: %v", err)
+			} else {
+				fm.files = filtered
+				fm.sortFilesWithConfig(fm.CurrentSort())
+			}
+		}
+	}
+
+	if len(tab.selected) > 0 {
+		var marked []string
+		for _, f := range fm.originalFiles {
+			if tab.selected[f.Path] {
+				marked = append(marked, f.Path)
+			}
+		}
+		fm.selection.SetMany(marked, true)
+	}
+
+	cursorSet := false
+	for i, f := range fm.files {
+		if tab.cursor != "" && f.Path == tab.cursor {
+			fm.SetCursorByIndex(i)
+			cursorSet = true
+			break
+		}
+	}
+	if !cursorSet && fm.GetCurrentCursorIndex() < 0 && len(fm.files) > 0 {
+		fm.SetCursorByIndex(0)
+	}
+}
+
+func (fm *FileManager) updateTabBar() {
+	if fm.tabBar == nil {
+		return
+	}
+	fm.tabBar.SetTabs(tabLabels(fm.tabs), fm.activeTab)
+}
+
+// tabLabels names each tab after its directory.
+func tabLabels(tabs []tabState) []string {
+	labels := make([]string, len(tabs))
+	for i, tab := range tabs {
+		label := fileinfo.BaseName(tab.path)
+		if label == "" || label == "." {
+			label = tab.path
+		}
+		labels[i] = label
+	}
+	return labels
+}
+
+// saveTabSession records this window's tabs in state.json, so the window
+// that changed its tabs last is what startup.restoreTabs reopens.
+func (fm *FileManager) saveTabSession() {
+	if fm.state == nil {
+		return
+	}
+	tabs := make([]config.TabState, 0, len(fm.tabs))
+	for _, tab := range fm.tabs {
+		saved := config.TabState{Path: tab.path, Filter: tab.filter}
+		if tab.cursor != "" {
+			saved.Cursor = fileinfo.BaseName(tab.cursor)
+		}
+		if tab.sort.SortBy != "" {
+			sortCopy := tab.sort
+			saved.Sort = &sortCopy
+		}
+		tabs = append(tabs, saved)
+	}
+	fm.state.SetTabSession(tabs, fm.activeTab)
+	if fm.stateManager != nil {
+		if err := fm.stateManager.SaveAsync(fm.state); err != nil {
+			debugPrint("FileManager: Error saving tab session: %v", err)
+		}
+	}
+}
+
+// restoreTabSession replaces the window's tabs with a saved session and
+// shows its active tab.
+func (fm *FileManager) restoreTabSession(session config.TabSessionState) {
+	session = config.NormalizeTabSession(session)
+	if len(session.Tabs) == 0 {
+		return
+	}
+	tabs := make([]tabState, 0, len(session.Tabs))
+	for _, saved := range session.Tabs {
+		tab := tabState{path: canonicalNavigationHistoryPath(saved.Path), filter: saved.Filter}
+		if saved.Cursor != "" {
+			tab.cursor = fileinfo.JoinPath(tab.path, saved.Cursor)
+		}
+		if saved.Sort != nil {
+			tab.sort = *saved.Sort
+		}
+		tabs = append(tabs, tab)
+	}
+	debugPrint("FileManager: Restoring tab session tabs=%d active=%d", len(tabs), session.Active)
+	fm.tabs = tabs
+	fm.showTab(session.Active)
+}
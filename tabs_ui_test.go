@@ -0,0 +1,93 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"nmf/internal/config"
+	"nmf/internal/fileinfo"
+	"nmf/internal/selection"
+)
+
+func TestApplyPendingTabRestoreReappliesFilterSelectionAndCursor(t *testing.T) {
+	files := []fileinfo.FileInfo{
+		{Name: "a.go", Path: "/src/a.go"},
+		{Name: "b.txt", Path: "/src/b.txt"},
+		{Name: "c.go", Path: "/src/c.go"},
+	}
+	fm := &FileManager{
+		files:         files,
+		originalFiles: files,
+		selection:     selection.New(),
+		cursorIndex:   -1,
+		activeSort:    config.SortConfig{SortBy: "name", SortOrder: "asc"},
+		pendingTabRestore: &tabState{
+			path:     "/src",
+			cursor:   "/src/c.go",
+			filter:   &config.FilterEntry{Pattern: "*.go"},
+			sort:     config.SortConfig{SortBy: "name", SortOrder: "asc"},
+			selected: map[string]bool{"/src/a.go": true, "/gone": true},
+		},
+	}
+
+	fm.applyPendingTabRestore(canonicalNavigationHistoryPath("/src"))
+
+	if fm.pendingTabRestore != nil {
+		t.Fatal("pending restore was not consumed")
+	}
+	if len(fm.files) != 2 || fm.currentFilter == nil {
+		t.Fatalf("files = %+v filter = %v, want the two .go files", fm.files, fm.currentFilter)
+	}
+	if !reflect.DeepEqual(fm.selection.Paths(), []string{"/src/a.go"}) {
+		t.Fatalf("selected = %v", fm.selection.Paths())
+	}
+	if fm.cursorPath != "/src/c.go" {
+		t.Fatalf("cursor = %q, want /src/c.go", fm.cursorPath)
+	}
+}
+
+func TestApplyPendingTabRestoreIgnoresOtherPath(t *testing.T) {
+	fm := &FileManager{
+		selection:         selection.New(),
+		cursorIndex:       -1,
+		pendingTabRestore: &tabState{path: "/a", filter: &config.FilterEntry{Pattern: "*.go"}},
+	}
+
+	fm.applyPendingTabRestore(canonicalNavigationHistoryPath("/b"))
+
+	if fm.pendingTabRestore != nil || fm.currentFilter != nil {
+		t.Fatalf("pending = %v filter = %v, want both cleared/untouched", fm.pendingTabRestore, fm.currentFilter)
+	}
+}
+
+func TestTabLabelsUseDirectoryNames(t *testing.T) {
+	got := tabLabels([]tabState{{path: "/home/me/src"}, {path: "/"}})
+	if got[0] != "src" || got[1] == "" {
+		t.Fatalf("labels = %q", got)
+	}
+}
+
+func TestSaveTabSessionStoresCursorNameAndSort(t *testing.T) {
+	state := &config.State{}
+	fm := &FileManager{
+		state:     state,
+		activeTab: 1,
+		tabs: []tabState{
+			{path: "/a", cursor: "/a/x.txt"},
+			{path: "/b", sort: config.SortConfig{SortBy: "size", SortOrder: "desc"}},
+		},
+	}
+
+	fm.saveTabSession()
+
+	session := state.GetTabSession()
+	if session.Active != 1 || len(session.Tabs) != 2 {
+		t.Fatalf("session = %+v", session)
+	}
+	if session.Tabs[0].Cursor != "x.txt" || session.Tabs[0].Sort != nil {
+		t.Fatalf("first tab = %+v", session.Tabs[0])
+	}
+	if session.Tabs[1].Sort == nil || session.Tabs[1].Sort.SortBy != "size" {
+		t.Fatalf("second tab sort = %+v", session.Tabs[1].Sort)
+	}
+}
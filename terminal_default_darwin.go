@@ -0,0 +1,10 @@
+//go:build darwin
+
+package main
+
+// defaultTerminalCandidates opens Terminal.app on the directory.
+func defaultTerminalCandidates() []terminalCandidate {
+	return []terminalCandidate{
+		{command: "open", args: []string{"-a", "Terminal", "{dir}"}},
+	}
+}
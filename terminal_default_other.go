@@ -0,0 +1,26 @@
+//go:build !windows && !darwin
+
+package main
+
+import "os"
+
+// defaultTerminalCandidates tries $TERMINAL, the Debian alternative, then
+// common emulators. Those without a directory option start in the working
+// directory.
+func defaultTerminalCandidates() []terminalCandidate {
+	var candidates []terminalCandidate
+	if term := os.Getenv("TERMINAL"); term != "" {
+		candidates = append(candidates, terminalCandidate{command: term})
+	}
+	return append(candidates,
+		terminalCandidate{command: "x-terminal-emulator"},
+		terminalCandidate{command: "gnome-terminal", args: []string{"--working-directory={dir}"}},
+		terminalCandidate{command: "konsole", args: []string{"--workdir", "{dir}"}},
+		terminalCandidate{command: "xfce4-terminal", args: []string{"--working-directory={dir}"}},
+		terminalCandidate{command: "kitty", args: []string{"--directory", "{dir}"}},
+		terminalCandidate{command: "alacritty", args: []string{"--working-directory", "{dir}"}},
+		terminalCandidate{command: "wezterm", args: []string{"start", "--cwd", "{dir}"}},
+		terminalCandidate{command: "foot", args: []string{"--working-directory={dir}"}},
+		terminalCandidate{command: "xterm"},
+	)
+}
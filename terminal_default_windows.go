@@ -0,0 +1,13 @@
+//go:build windows
+
+package main
+
+// defaultTerminalCandidates prefers Windows Terminal and falls back to the
+// console host, which starts in the working directory.
+func defaultTerminalCandidates() []terminalCandidate {
+	return []terminalCandidate{
+		{command: "wt.exe", args: []string{"-d", "{dir}"}},
+		{command: "powershell.exe", args: []string{"-NoExit"}},
+		{command: "cmd.exe"},
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"os/exec"
+
+	"nmf/internal/config"
+)
+
+// terminalLookPath finds terminal emulators on PATH. Tests replace it.
+var terminalLookPath = exec.LookPath
+
+// terminalCandidate is a terminal emulator tried when
+// integrations.terminal.command is empty, with its arguments for starting
+// in {dir}.
+type terminalCandidate struct {
+	command string
+	args    []string
+}
+
+// OpenTerminalHere starts the configured terminal emulator in the current
+// directory. Remote and archive directories have no local working directory
+// to start in, so they are refused, as for external commands.
+func (fm *FileManager) OpenTerminalHere() {
+	dir, ignored := externalCommandWorkingDirectory(fm.currentPath)
+	if ignored || dir == "" {
+		debugPrint("FileManager: Open terminal refused path=%s", fm.currentPath)
+		fm.ShowMessageDialog("Open Terminal", "A terminal can only open in a local directory:\n"+fm.currentPath)
+		return
+	}
+	command, args, ok := terminalCommand(fm.config.Integrations.Terminal, dir)
+	if !ok {
+		fm.ShowMessageDialog("Open Terminal", "No terminal emulator was found. Set integrations.terminal.command in the configuration.")
+		return
+	}
+	debugPrint("FileManager: Open terminal command=%s dir=%s", command, dir)
+	fm.runExternalCommand(command, args, dir)
+}
+
+// terminalCommand returns the configured terminal with {dir} expanded, or
+// the first per-OS default found on the system.
+func terminalCommand(cfg config.TerminalConfig, dir string) (string, []string, bool) {
+	if cfg.Command != "" {
+		return cfg.Command, expandExternalCommandArgs(cfg.Args, nil, nil, dir), true
+	}
+	for _, candidate := range defaultTerminalCandidates() {
+		if _, err := terminalLookPath(candidate.command); err != nil {
+			continue
+		}
+		return candidate.command, expandExternalCommandArgs(candidate.args, nil, nil, dir), true
+	}
+	return "", nil, false
+}
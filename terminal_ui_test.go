@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"os/exec"
+	"testing"
+
+	"nmf/internal/config"
+)
+
+func TestTerminalCommandExpandsConfiguredArgs(t *testing.T) {
+	command, args, ok := terminalCommand(config.TerminalConfig{Command: "wezterm", Args: []string{"start", "--cwd", "{dir}"}}, "/home/u/src")
+	if !ok || command != "wezterm" || len(args) != 3 || args[2] != "/home/u/src" {
+		t.Fatalf("terminal = %q %q %t", command, args, ok)
+	}
+}
+
+func TestTerminalCommandPicksFirstInstalledDefault(t *testing.T) {
+	candidates := defaultTerminalCandidates()
+	if len(candidates) == 0 {
+		t.Fatal("no default terminals for this OS")
+	}
+	last := candidates[len(candidates)-1]
+	old := terminalLookPath
+	t.Cleanup(func() { terminalLookPath = old })
+	terminalLookPath = func(name string) (string, error) {
+		if name == last.command {
+			return "/usr/bin/" + name, nil
+		}
+		return "", exec.ErrNotFound
+	}
+	if command, _, ok := terminalCommand(config.TerminalConfig{}, "/tmp"); !ok || command != last.command {
+		t.Fatalf("terminal = %q %t, want %q", command, ok, last.command)
+	}
+
+	terminalLookPath = func(string) (string, error) { return "", errors.New("not found") }
+	if _, _, ok := terminalCommand(config.TerminalConfig{}, "/tmp"); ok {
+		t.Fatal("a terminal was picked with none installed")
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"image/color"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"nmf/internal/fileinfo"
+	customtheme "nmf/internal/theme"
+	"nmf/internal/ui"
+)
+
+// newFileGrid creates the thumbnail view over the same fm.files as the list.
+// Cursor, selection, and clicks behave as in the list; only the layout and
+// the image area differ.
+func (fm *FileManager) newFileGrid() *widget.GridWrap {
+	grid := widget.NewGridWrap(
+		func() int { return len(fm.files) },
+		fm.newThumbnailCell,
+		fm.updateThumbnailCell,
+	)
+	grid.OnSelected = func(id widget.GridWrapItemID) {
+		debugPrint("FileManager: Grid selected id=%d active=%t focused=%s path=%q",
+			id, fm.windowActive, focusedObjectLabel(fm.window), fm.currentPath)
+		fm.SetCursorByIndex(id)
+		grid.UnselectAll()
+		fm.FocusFileList()
+		fm.RefreshCursor()
+	}
+	return grid
+}
+
+func (fm *FileManager) newThumbnailCell() fyne.CanvasObject {
+	return ui.NewThumbnailCell(
+		float32(fm.config.UI.Thumbnails.Size),
+		fm.customTheme.GetCustomColor(customtheme.ColorFileRegular),
+	)
+}
+
+// updateThumbnailCell mirrors updateFileListRow for a grid cell. Image files
+// show their thumbnail once the thumbnail service has it; everything else,
+// and images still being generated, show the list icon.
+func (fm *FileManager) updateThumbnailCell(id widget.GridWrapItemID, obj fyne.CanvasObject) {
+	if id < 0 || id >= len(fm.files) {
+		return
+	}
+	fileInfo := fm.files[id]
+	index := id
+
+	cell, ok := obj.(*ui.ThumbnailCell)
+	if !ok {
+		return
+	}
+
+	textColor := fileinfo.GetTextColor(fileInfo.FileType, fm.customTheme)
+	img, _ := fm.thumbnailSvc.GetCachedOrRequest(fileInfo)
+	cell.SetThumbnail(img, fm.thumbnailFallbackIcon(fileInfo, textColor))
+	cell.Icon.SetLoading(img == nil && fm.thumbnailSvc.Loading(fileInfo))
+
+	cell.Icon.SetOnTapped(func() {
+		debugPrint("FileManager: Icon tapped path=%s dir=%t", fileInfo.Path, fileInfo.IsDir)
+		if fileInfo.IsDir {
+			fm.LoadDirectory(fileInfo.Path)
+		}
+	})
+	cell.Icon.SetOnDragged(func() {
+		debugPrint("FileManager: Icon dragged path=%s", fileInfo.Path)
+		fm.StartFileDrag(fileInfo)
+	})
+
+	cell.NameLabel.SetFile(fileInfo.Name, textColor, fileInfo.Status == fileinfo.StatusDeleted)
+	cell.NameLabel.SetOnTapped(func(modifier fyne.KeyModifier) {
+		debugPrint("FileManager: File name tapped file=%q modifier=%d active=%t focused=%s path=%q",
+			fileInfo.Path, modifier, fm.windowActive, focusedObjectLabel(fm.window), fm.currentPath)
+		fm.handleFileNameClick(index, fileInfo, modifier)
+	})
+	cell.NameLabel.SetOnDragged(func() {
+		debugPrint("FileManager: File name dragged path=%s", fileInfo.Path)
+		fm.StartFileDrag(fileInfo)
+	})
+	cell.SetOnTappedSecondary(func(ev *fyne.PointEvent) {
+		fm.showFileContextMenu(index, ev.AbsolutePosition)
+	})
+
+	isCursor := index == fm.GetCurrentCursorIndex()
+	isSelected := fm.selection.IsMarked(fileInfo.Path)
+	if isCursor {
+		fm.cursorAnchor = cursorRowAnchor{path: fileInfo.Path, object: cell}
+	} else if fm.cursorAnchor.object == cell {
+		fm.cursorAnchor = cursorRowAnchor{}
+	}
+
+	statusColor := fileinfo.GetStatusBackgroundColor(fileInfo.Status, fm.customTheme)
+	selectionColor := fm.customTheme.GetCustomColor(customtheme.ColorSelectionBackground)
+	cursorColor := fm.cursorThemeProvider().GetCustomColor(customtheme.ColorCursor)
+	cell.SetDecorations(statusColor, isSelected, selectionColor, isCursor, cursorColor)
+	cell.SetTagColor(fileInfo.ColorTag.RGBA())
+	if isCursor {
+		fm.noteCursorItemUpdated(index)
+	}
+}
+
+// thumbnailFallbackIcon returns the icon a cell shows without a thumbnail,
+// chosen the same way as the list row icon.
+func (fm *FileManager) thumbnailFallbackIcon(file fileinfo.FileInfo, textColor color.RGBA) fyne.Resource {
+	if fm.usesMonoIcons() {
+		return ui.MonoIcon(ui.MonoIconKindFor(file), textColor)
+	}
+	if file.IsDir {
+		return theme.FolderIcon()
+	}
+	if fm.iconSvc != nil {
+		ext := strings.ToLower(filepath.Ext(file.Name))
+		if res, ok := fm.iconSvc.GetCached(file.Path, file.IsDir, ext); ok && res != nil {
+			return res
+		}
+		fm.scheduleIconPrefetch()
+	}
+	return theme.FileIcon()
+}
+
+// ToggleThumbnails switches this window between the list and the thumbnail
+// grid. The cursor and selection carry over unchanged.
+func (fm *FileManager) ToggleThumbnails() {
+	fm.setThumbnailMode(!fm.thumbnailMode)
+}
+
+// setThumbnailMode shows the thumbnail grid or the list.
+func (fm *FileManager) setThumbnailMode(on bool) {
+	if fm.fileGrid == nil || fm.thumbnailMode == on {
+		return
+	}
+	fm.thumbnailMode = on
+	fm.showFileView()
+	fm.refreshListAndCursor()
+	debugPrint("FileManager: Thumbnail mode=%t", fm.thumbnailMode)
+	fm.FocusFileList()
+}
+
+// showFileView shows the grid in thumbnail mode and the list, with its
+// column header, otherwise.
+func (fm *FileManager) showFileView() {
+	if fm.thumbnailMode {
+		fm.fileList.Hide()
+		fm.fileGrid.Show()
+	} else {
+		fm.fileGrid.Hide()
+		fm.fileList.Show()
+	}
+	if fm.columnHeader == nil {
+		return
+	}
+	if fm.thumbnailMode {
+		fm.columnHeader.Hide()
+	} else {
+		fm.columnHeader.Show()
+	}
+}
+
+// refreshFileList redraws whichever file view is showing.
+func (fm *FileManager) refreshFileList() {
+	if fm.thumbnailMode && fm.fileGrid != nil {
+		fm.fileGrid.Refresh()
+		return
+	}
+	if fm.fileList != nil {
+		fm.fileList.Refresh()
+	}
+}
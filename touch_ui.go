@@ -0,0 +1,111 @@
+package main
+
+import (
+	"runtime"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+
+	"nmf/internal/fileinfo"
+	"nmf/internal/ui"
+)
+
+// newTouchLayer creates the gesture layer stacked over the list and grid.
+// Touch gestures map onto the same FileManager calls as the keyboard
+// commands; desktop mouse and key input never reach the layer.
+func (fm *FileManager) newTouchLayer() *ui.TouchLayer {
+	return ui.NewTouchLayer(ui.TouchGestures{
+		Tap: func(pos fyne.Position) {
+			fm.touchMoveCursor(pos)
+		},
+		DoubleTap: func(pos fyne.Position) {
+			if index, ok := fm.touchMoveCursor(pos); ok {
+				file := fm.files[index]
+				fm.OpenFile(&file)
+			}
+		},
+		LongPress: func(pos fyne.Position) {
+			if _, ok := fm.touchMoveCursor(pos); ok {
+				fm.showTouchContextMenu()
+			}
+		},
+		Drag:  fm.touchScroll,
+		Swipe: fm.touchSwipe,
+		Pinch: func(scale float32) {
+			fm.setThumbnailMode(scale > 1)
+		},
+	})
+}
+
+// touchMoveCursor moves the cursor to the entry under pos without touching
+// the marks, unlike a mouse click on the name.
+func (fm *FileManager) touchMoveCursor(pos fyne.Position) (int, bool) {
+	index := fm.touchIndexAt(pos)
+	if index < 0 {
+		return -1, false
+	}
+	debugPrint("FileManager: Touch cursor index=%d", index)
+	fm.SetCursorByIndex(index)
+	fm.FocusFileList()
+	fm.RefreshCursor()
+	return index, true
+}
+
+// touchIndexAt maps a position on the touch layer to an entry index, or -1
+// when it falls below the last entry or between grid cells.
+func (fm *FileManager) touchIndexAt(pos fyne.Position) int {
+	if pos.X < 0 || pos.Y < 0 {
+		return -1
+	}
+	index := -1
+	if fm.thumbnailMode && fm.fileGrid != nil {
+		cell := fm.newThumbnailCell().MinSize()
+		padding := fm.fileGrid.Theme().Size(theme.SizeNamePadding)
+		col := int(pos.X / (cell.Width + padding))
+		row := int((pos.Y + fm.fileGrid.GetScrollOffset()) / (cell.Height + padding))
+		if cols := fm.fileGrid.ColumnCount(); col < cols {
+			index = row*cols + col
+		}
+	} else if fm.fileList != nil && fm.fileListItemHeight > 0 {
+		rowStride := fm.fileListItemHeight + fm.fileList.Theme().Size(theme.SizeNamePadding)
+		index = int((pos.Y + fm.fileList.GetScrollOffset()) / rowStride)
+	}
+	if index >= len(fm.files) {
+		return -1
+	}
+	return index
+}
+
+// touchScroll scrolls the shown view with a one-finger drag.
+func (fm *FileManager) touchScroll(dy float32) {
+	if fm.thumbnailMode && fm.fileGrid != nil {
+		fm.fileGrid.ScrollToOffset(max(0, fm.fileGrid.GetScrollOffset()-dy))
+		return
+	}
+	if fm.fileList != nil {
+		fm.fileList.ScrollToOffset(max(0, fm.fileList.GetScrollOffset()-dy))
+		fm.scheduleIconPrefetch()
+	}
+}
+
+// touchSwipe goes up to the parent directory on a two-finger swipe to the
+// right, the direction of a "back" gesture.
+func (fm *FileManager) touchSwipe(dx float32) {
+	if dx <= 0 {
+		return
+	}
+	parent := fileinfo.ParentPath(fm.vaultCipherPath(fm.currentPath))
+	if parent != fm.currentPath {
+		fm.LoadDirectory(parent)
+	}
+}
+
+// showTouchContextMenu opens the Explorer context menu on Windows and the
+// external command menu elsewhere, for the cursor entry or the marks.
+func (fm *FileManager) showTouchContextMenu() {
+	if runtime.GOOS == "windows" {
+		fm.ShowExplorerContextMenu()
+		return
+	}
+	fm.ShowExternalCommandMenu()
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+)
+
+func TestTouchIndexAtFollowsListScroll(t *testing.T) {
+	fm := newScrollMarginTestFileManager(40, 0)
+	window := test.NewWindow(fm.fileList)
+	defer window.Close()
+	window.SetPadded(false)
+
+	rowStride := fm.fileListItemHeight + fm.fileList.Theme().Size(theme.SizeNamePadding)
+	window.Resize(fyne.NewSize(300, 5*rowStride))
+
+	if got := fm.touchIndexAt(fyne.NewPos(10, rowStride*2+1)); got != 2 {
+		t.Fatalf("index = %d, want 2", got)
+	}
+	fm.fileList.ScrollToOffset(10 * rowStride)
+	if got := fm.touchIndexAt(fyne.NewPos(10, 1)); got != 10 {
+		t.Fatalf("index after scroll = %d, want 10", got)
+	}
+	fm.fileList.ScrollToOffset(0)
+	if got := fm.touchIndexAt(fyne.NewPos(10, 45*rowStride)); got != -1 {
+		t.Fatalf("index below the last entry = %d, want -1", got)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"nmf/internal/fileinfo"
+)
+
+// ToggleTreePane shows or hides the directory tree pane for this window.
+func (fm *FileManager) ToggleTreePane() {
+	if fm.treePane == nil {
+		return
+	}
+	pane := fm.treePane.Container()
+	if pane.Visible() {
+		pane.Hide()
+	} else {
+		pane.Show()
+		fm.updateTreePane(fm.currentPath)
+	}
+	debugPrint("FileManager: Tree pane visible=%t", pane.Visible())
+	fm.FocusFileList()
+}
+
+// updateTreePane syncs the tree pane with the listed path. Archives and tag
+// views have no place in the directory tree; the pane keeps showing the
+// last directory until the list returns to one.
+func (fm *FileManager) updateTreePane(path string) {
+	if fm.treePane == nil || !fm.treePane.Container().Visible() {
+		return
+	}
+	if path == "" || fileinfo.IsArchivePath(path) || fileinfo.IsTagViewPath(path) {
+		return
+	}
+	if path != fm.treePane.CurrentPath() {
+		fm.treePane.SetCurrentPath(path)
+	}
+}
+
+// navigateFromTreePane opens the directory picked in the tree pane and hands
+// focus back to the list.
+func (fm *FileManager) navigateFromTreePane(path string) {
+	debugPrint("FileManager: Tree pane navigation path=%s", path)
+	fm.LoadDirectory(path)
+	fm.focusFileList("tree-pane-selected")
+}
+
+// refreshTreePane rereads the current directory's branch after the watcher
+// saw subdirectories come, go, or change type.
+func (fm *FileManager) refreshTreePane() {
+	if fm.treePane == nil || !fm.treePane.Container().Visible() {
+		return
+	}
+	fm.treePane.RefreshDirectory(fm.currentPath)
+}
@@ -20,8 +20,19 @@ func (fm *FileManager) setupUI() {
 	fm.pathDisplay = widget.NewLabel(fm.currentPath)
 	fm.pathDisplay.TextStyle = fyne.TextStyle{Monospace: true}
 	fm.pathDisplay.Truncation = fyne.TextTruncateClip
+	fm.connectionButton = widget.NewButton("", fm.ShowConnectionMenu)
+	fm.connectionButton.Hide()
+	fm.connectionUnsub = fileinfo.SubscribeConnectionState(func(fileinfo.RemoteShare) {
+		fyne.Do(func() { fm.updateConnectionIndicator(fm.currentPath) })
+	})
 	fm.statusLabel = widget.NewLabel("")
 	fm.statusLabel.TextStyle = fyne.TextStyle{Monospace: true}
+	fm.selectionBar = widget.NewLabel("")
+	fm.selectionBar.TextStyle = fyne.TextStyle{Monospace: true}
+	fm.diskUsageBar = widget.NewLabel("")
+	fm.diskUsageBar.TextStyle = fyne.TextStyle{Monospace: true}
+	fm.startDiskUsageRefresh()
+	fm.tabBar = ui.NewDirectoryTabBar(fm.selectTab)
 
 	// Create file list
 	fm.fileListItemHeight = fm.newFileListRow().MinSize().Height
@@ -36,9 +47,17 @@ func (fm *FileManager) setupUI() {
 		fm.fileList.HideSeparators = true
 	}
 
+	// The thumbnail grid shares the list's slot; only one of them is shown.
+	// The column header sits above the list in the detailed view.
+	fm.fileGrid = fm.newFileGrid()
+	fm.columnHeader = fm.newColumnHeader()
+	fm.showFileView()
+
 	// Wrap list with a generic focusable KeySink to suppress Tab traversal
+	// The touch layer sits on top but only takes touch events, so mouse
+	// input still reaches the rows.
 	fm.fileListView = ui.NewKeySink(
-		fm.fileList,
+		container.NewStack(fm.fileList, fm.fileGrid, fm.newTouchLayer()),
 		fm.keyManager,
 		ui.WithTabCapture(true),
 		ui.WithFocusChanged(fm.setWindowActive),
@@ -59,7 +78,7 @@ func (fm *FileManager) setupUI() {
 	// Create toolbar (left side)
 	toolbarItems := []widget.ToolbarItem{
 		widget.NewToolbarAction(theme.NavigateBackIcon(), func() {
-			parent := fileinfo.ParentPath(fm.currentPath)
+			parent := fileinfo.ParentPath(fm.vaultCipherPath(fm.currentPath))
 			if parent != fm.currentPath {
 				fm.LoadDirectory(parent)
 			}
@@ -107,10 +126,22 @@ func (fm *FileManager) setupUI() {
 	toolbarRow := container.NewBorder(nil, nil, nil, fm.jobsButton, toolbar)
 	// Subscribe to job updates to update indicator
 	fm.jobsUnsub = fm.jobManager().Subscribe(func() { fyne.Do(fm.onJobsUpdated) })
+	fm.previewPane = ui.NewPreviewPane(float32(fm.config.UI.PreviewPane.Width))
+	if fm.config.UI.PreviewPane.Visible {
+		fm.previewPane.Container().Show()
+	}
+	fm.treePane = ui.NewDirectoryTreePane(float32(fm.config.UI.TreePane.Width), fm.navigateFromTreePane, debugPrint)
+	if fm.config.UI.TreePane.Visible {
+		fm.treePane.Container().Show()
+	}
+	var fileView fyne.CanvasObject = fm.fileListView
+	if fm.columnHeader != nil {
+		fileView = container.NewBorder(fm.columnHeader, nil, nil, nil, fm.fileListView)
+	}
 	mainContent := container.NewBorder(
-		container.NewVBox(toolbarRow, fm.pathDisplay, fm.statusLabel),
-		nil, nil, nil,
-		fm.fileListView,
+		container.NewVBox(toolbarRow, fm.tabBar.Container(), container.NewBorder(nil, nil, nil, fm.connectionButton, fm.pathDisplay), fm.statusLabel),
+		container.NewBorder(nil, nil, nil, fm.diskUsageBar, fm.selectionBar), fm.treePane.Container(), fm.previewPane.Container(),
+		fileView,
 	)
 	fm.windowHighlight = canvas.NewRectangle(color.Transparent)
 	fm.windowHighlight.StrokeColor = color.Transparent
@@ -121,7 +152,7 @@ func (fm *FileManager) setupUI() {
 		mainContent,
 		fm.windowHighlight,
 		container.NewBorder(
-			fm.searchOverlay.GetContainer(), // Top overlay
+			container.NewVBox(fm.searchOverlay.GetContainer(), fm.commandPalette.GetContainer()), // Top overlays
 			nil, nil, nil,
 			nil, // Center is empty, overlay is at top
 		),
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+
+	"fyne.io/fyne/v2"
+
+	"nmf/internal/fileinfo"
+	"nmf/internal/vault"
+)
+
+// vaults returns the shared vault manager, or nil when vault support is off
+// or the window was built without a runtime (tests).
+func (fm *FileManager) vaults() *vault.Manager {
+	if fm != nil && fm.runtime != nil {
+		return fm.runtime.vaults
+	}
+	return nil
+}
+
+// unlockVaultPath returns the directory a load of path should list: the
+// plaintext directory when path is a local vault, unlocking it first, and
+// path itself otherwise. It runs on the directory load goroutine.
+func (fm *FileManager) unlockVaultPath(ctx context.Context, path string) (string, error) {
+	vaults := fm.vaults()
+	if vaults == nil {
+		return path, nil
+	}
+	_, parsed, err := fileinfo.CanonicalDisplayPath(path)
+	if err != nil || parsed.Scheme != fileinfo.SchemeFile {
+		return path, nil
+	}
+	vaults.Touch(path)
+	if vault.Detect(parsed.Native) == vault.KindNone {
+		return path, nil
+	}
+	plain, err := vaults.Unlock(ctx, parsed.Native)
+	if err != nil {
+		return "", err
+	}
+	debugPrint("FileManager: Vault %s opened at %s", path, plain)
+	return plain, nil
+}
+
+// vaultCipherPath returns the vault directory for a vault's plaintext root
+// and path itself otherwise, so ".." and the cursor on leaving a vault refer
+// to the vault rather than its private mount point.
+func (fm *FileManager) vaultCipherPath(path string) string {
+	if vaults := fm.vaults(); vaults != nil {
+		if cipher, ok := vaults.CipherPath(path); ok {
+			return cipher
+		}
+	}
+	return path
+}
+
+// openWindowPaths returns the directories every window and tab shows, read
+// on the UI thread. Vaults holding one of them are never locked as idle.
+func openWindowPaths() []string {
+	var paths []string
+	fyne.DoAndWait(func() {
+		for _, fm := range snapshotFileManagerWindows() {
+			paths = append(paths, fm.currentPath)
+			for _, tab := range fm.tabs {
+				paths = append(paths, tab.path)
+			}
+		}
+	})
+	return paths
+}
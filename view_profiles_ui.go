@@ -0,0 +1,47 @@
+package main
+
+import (
+	"nmf/internal/config"
+)
+
+// viewProfileFor returns the configured view profile matching dir, if any.
+func (fm *FileManager) viewProfileFor(dir string) (config.ViewProfile, bool) {
+	if fm.config == nil {
+		return config.ViewProfile{}, false
+	}
+	return config.MatchViewProfile(fm.config.UI.ViewProfiles, dir)
+}
+
+// applyViewProfile applies the filter and metadata column of the profile
+// matched for a freshly loaded directory, and drops the previous profile's
+// filter when the new directory has none. A filter the user applied by hand
+// is left alone. Runs on the UI thread after fm.files is replaced.
+func (fm *FileManager) applyViewProfile(profile config.ViewProfile, ok bool) {
+	fm.viewProfile = profile.Name
+	fm.profileShowMetadata = profile.ShowMetadata
+	if ok && profile.Filter != "" {
+		entry := &config.FilterEntry{Pattern: profile.Filter}
+		filtered, err := fm.filterFiles(fm.originalFiles, config.EffectiveFilterPattern(entry.Pattern))
+		if err != nil {
+			debugPrint("FileManager: View profile %q filter error: %v", profile.Name, err)
+			return
+		}
+		fm.files = filtered
+		fm.currentFilter = entry
+		fm.profileFilter = entry
+		return
+	}
+	if fm.profileFilter != nil && fm.currentFilter == fm.profileFilter {
+		fm.currentFilter = nil
+	}
+	fm.profileFilter = nil
+}
+
+// showMetadataInList reports whether rows carry the media metadata column,
+// honoring the active view profile's override.
+func (fm *FileManager) showMetadataInList() bool {
+	if fm.profileShowMetadata != nil {
+		return *fm.profileShowMetadata
+	}
+	return fm.config != nil && fm.config.UI.Metadata.ShowInList
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"nmf/internal/config"
+	"nmf/internal/fileinfo"
+)
+
+func TestApplyViewProfileFiltersAndClearsOnLeaving(t *testing.T) {
+	show := true
+	files := []fileinfo.FileInfo{
+		{Name: "..", IsDir: true},
+		{Name: "cat.jpg"},
+		{Name: "notes.txt"},
+	}
+	fm := &FileManager{config: config.Default(), files: files, originalFiles: files}
+	profile := config.ViewProfile{Name: "photos", Filter: "*.jpg", ShowMetadata: &show}
+
+	fm.applyViewProfile(profile, true)
+	if len(fm.files) != 2 || fm.files[1].Name != "cat.jpg" {
+		t.Fatalf("files = %v, want the parent and cat.jpg", fm.files)
+	}
+	if fm.currentFilter == nil || !fm.showMetadataInList() {
+		t.Fatalf("profile filter and metadata override were not applied")
+	}
+	if text := fm.statusBarText(); !strings.Contains(text, "Profile: photos") {
+		t.Fatalf("status bar %q does not name the profile", text)
+	}
+
+	fm.files = files
+	fm.applyViewProfile(config.ViewProfile{}, false)
+	if fm.currentFilter != nil || fm.viewProfile != "" || fm.showMetadataInList() {
+		t.Fatalf("leaving the profile kept filter=%v name=%q", fm.currentFilter, fm.viewProfile)
+	}
+}
+
+func TestApplyViewProfileKeepsManualFilter(t *testing.T) {
+	manual := &config.FilterEntry{Pattern: "*.txt"}
+	fm := &FileManager{config: config.Default(), currentFilter: manual}
+	fm.applyViewProfile(config.ViewProfile{}, false)
+	if fm.currentFilter != manual {
+		t.Fatalf("manual filter was dropped")
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+
+	"nmf/internal/fileinfo"
+	"nmf/internal/keymanager"
+)
+
+// ShowVolumesMenu lists mounted filesystems and attached devices, such as an
+// MTP phone, and jumps to the chosen one.
+func (fm *FileManager) ShowVolumesMenu() {
+	// gio can take a moment to ask the volume monitor, so stay off the UI
+	// thread.
+	go func() {
+		volumes := fileinfo.ListVolumes(context.Background())
+		debugPrint("FileManager: Volumes listed count=%d", len(volumes))
+		fyne.Do(func() {
+			if fm.isWindowClosed() {
+				return
+			}
+			if len(volumes) == 0 {
+				fm.showCommandPopup("Volumes", informationalExternalCommandMenuItem("No volumes found."))
+				return
+			}
+			fm.showCommandMenu(volumeMenuItems(volumes, func(volume fileinfo.Volume) {
+				fm.jumpToConfiguredDirectory(volume.Path)
+				fm.focusFileList("volumes-menu-selected")
+			}))
+		})
+	}()
+}
+
+// volumeMenuItems keeps the listing order, marks non-local volumes with
+// their kind, and gives the first nine entries digit accelerators.
+func volumeMenuItems(volumes []fileinfo.Volume, open func(fileinfo.Volume)) []keymanager.CommandMenuItem {
+	items := make([]keymanager.CommandMenuItem, 0, len(volumes))
+	for i, volume := range volumes {
+		entry := volume
+		label := entry.Name
+		if entry.Kind != fileinfo.VolumeLocal {
+			label += " [" + entry.Kind.String() + "]"
+		}
+		if entry.Path != entry.Name {
+			label += "  " + entry.Path
+		}
+		item := keymanager.CommandMenuItem{
+			Label:  label,
+			Action: func() { open(entry) },
+		}
+		if i < 9 {
+			item.Key = strconv.Itoa(i + 1)
+		}
+		items = append(items, item)
+	}
+	return items
+}
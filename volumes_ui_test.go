@@ -0,0 +1,27 @@
+package main
+
+import (
+	"testing"
+
+	"nmf/internal/fileinfo"
+)
+
+func TestVolumeMenuItemsLabelDevicesAndOpenPath(t *testing.T) {
+	volumes := []fileinfo.Volume{
+		{Name: "/", Path: "/", Kind: fileinfo.VolumeLocal},
+		{Name: "Pixel 7", Path: "mtp://Pixel_7/", Kind: fileinfo.VolumeDevice},
+	}
+	var opened string
+	items := volumeMenuItems(volumes, func(volume fileinfo.Volume) { opened = volume.Path })
+
+	if items[0].Label != "/" || items[0].Key != "1" {
+		t.Fatalf("local item = %q key %q", items[0].Label, items[0].Key)
+	}
+	if items[1].Label != "Pixel 7 [device]  mtp://Pixel_7/" || items[1].Key != "2" {
+		t.Fatalf("device item = %q key %q", items[1].Label, items[1].Key)
+	}
+	items[1].Action()
+	if opened != "mtp://Pixel_7/" {
+		t.Fatalf("action opened %q, want the device path", opened)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"slices"
 	"sync/atomic"
 
 	"fyne.io/fyne/v2"
@@ -16,11 +17,15 @@ func (fm *FileManager) closeWindow() {
 	}
 
 	// Invalidate background work before releasing window-owned UI resources.
-	fm.invalidateActiveDirectoryLoad()
+	fm.navigator.Invalidate()
 	fm.invalidateViewerLoad(0)
+	fm.clearDirectorySizes()
+	fm.cancelBulkMeasures()
 	fm.endBusy()
 
-	recordReopenPath(fm.currentPath)
+	fm.captureActiveTab()
+	recordClosedItem(closedItem{window: true, tabs: slices.Clone(fm.tabs), active: fm.activeTab})
+	fm.saveTabSession()
 	clearFileManagerWindowHighlights()
 
 	// Remove from registry
@@ -36,15 +41,29 @@ func (fm *FileManager) closeWindow() {
 	if fm.iconSvc != nil {
 		fm.iconSvc.Close()
 	}
+	if fm.metadataSvc != nil {
+		fm.metadataSvc.Close()
+	}
+	if fm.previewSvc != nil {
+		fm.previewSvc.Close()
+	}
+	if fm.thumbnailSvc != nil {
+		fm.thumbnailSvc.Close()
+	}
 
 	// Stop blinking indicator if active
 	fm.stopJobsBlink()
+	fm.stopDiskUsageRefresh()
 
 	// Unsubscribe from jobs updates for this window.
 	if fm.jobsUnsub != nil {
 		fm.jobsUnsub()
 		fm.jobsUnsub = nil
 	}
+	if fm.connectionUnsub != nil {
+		fm.connectionUnsub()
+		fm.connectionUnsub = nil
+	}
 	if fm.promptUnregister != nil {
 		fm.promptUnregister()
 		fm.promptUnregister = nil
@@ -91,7 +91,7 @@ func TestCloseWindowIsIdempotentAndInvalidatesLoad(t *testing.T) {
 	}); !installed {
 		t.Fatal("transfer subscription should install before close")
 	}
-	ctx, loadID := fm.beginDirectoryLoad()
+	load := fm.navigator.Begin("/tmp", "")
 
 	fm.closeWindow()
 	fm.closeWindow()
@@ -105,10 +105,10 @@ func TestCloseWindowIsIdempotentAndInvalidatesLoad(t *testing.T) {
 	if transferUnsubscribed != 1 {
 		t.Fatalf("transfer unsubscribe calls = %d, want 1", transferUnsubscribed)
 	}
-	if !errors.Is(ctx.Err(), context.Canceled) {
-		t.Fatalf("load context error = %v, want context.Canceled", ctx.Err())
+	if !errors.Is(load.Context().Err(), context.Canceled) {
+		t.Fatalf("load context error = %v, want context.Canceled", load.Context().Err())
 	}
-	if fm.directoryLoadActive(loadID) {
+	if fm.navigator.IsActive(load) {
 		t.Fatal("closing the window should invalidate its directory load")
 	}
 }
@@ -54,29 +54,6 @@ func unregisterFileManagerWindow(fm *FileManager) {
 	}
 }
 
-func recordReopenPath(path string) {
-	if path == "" {
-		return
-	}
-
-	windowOrderMu.Lock()
-	defer windowOrderMu.Unlock()
-	reopenPaths = append(reopenPaths, path)
-}
-
-func nextReopenPath() (string, bool) {
-	windowOrderMu.Lock()
-	defer windowOrderMu.Unlock()
-	if len(reopenPaths) == 0 {
-		return "", false
-	}
-
-	last := len(reopenPaths) - 1
-	path := reopenPaths[last]
-	reopenPaths = reopenPaths[:last]
-	return path, true
-}
-
 func snapshotFileManagerWindows() []*FileManager {
 	windowOrderMu.Lock()
 	defer windowOrderMu.Unlock()
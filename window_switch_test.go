@@ -1,6 +1,9 @@
 package main
 
-import "testing"
+import (
+	"strconv"
+	"testing"
+)
 
 func TestSelectWindowSwitchCandidateUsesNearestHorizontalRect(t *testing.T) {
 	candidates := []windowSwitchCandidate{
@@ -98,22 +101,47 @@ func TestSelectWindowByOrderDoesNotWrap(t *testing.T) {
 func TestReopenPathStackUsesMostRecentlyClosedPath(t *testing.T) {
 	resetFileManagerWindowTestRegistry(t)
 
-	recordReopenPath("/first")
-	recordReopenPath("")
-	recordReopenPath("/second")
+	recordClosedItem(closedWindowAt("/first"))
+	recordClosedItem(closedWindowAt(""))
+	recordClosedItem(closedWindowAt("/second"))
 
-	path, ok := nextReopenPath()
-	if !ok || path != "/second" {
-		t.Fatalf("first reopen path = %q, %t, want /second, true", path, ok)
+	item, ok := popClosedItem(true)
+	if !ok || item.path() != "/second" {
+		t.Fatalf("first reopen path = %q, %t, want /second, true", item.path(), ok)
 	}
 
-	path, ok = nextReopenPath()
-	if !ok || path != "/first" {
-		t.Fatalf("second reopen path = %q, %t, want /first, true", path, ok)
+	item, ok = popClosedItem(true)
+	if !ok || item.path() != "/first" {
+		t.Fatalf("second reopen path = %q, %t, want /first, true", item.path(), ok)
 	}
 
-	path, ok = nextReopenPath()
-	if ok || path != "" {
-		t.Fatalf("empty reopen path = %q, %t, want empty, false", path, ok)
+	item, ok = popClosedItem(true)
+	if ok || item.path() != "" {
+		t.Fatalf("empty reopen path = %q, %t, want empty, false", item.path(), ok)
 	}
 }
+
+func TestClosedItemStackMixesTabsAndWindows(t *testing.T) {
+	resetFileManagerWindowTestRegistry(t)
+
+	recordClosedItem(closedWindowAt("/window"))
+	recordClosedItem(closedItem{tabs: []tabState{{path: "/tab"}}})
+
+	if item, ok := popClosedItem(true); !ok || !item.window || item.path() != "/window" {
+		t.Fatalf("window reopen = %+v, %t; want the closed window past the newer tab", item, ok)
+	}
+	if item, ok := popClosedItem(false); !ok || item.window || item.path() != "/tab" {
+		t.Fatalf("reopen = %+v, %t; want the closed tab", item, ok)
+	}
+
+	for i := 0; i < maxClosedItems+5; i++ {
+		recordClosedItem(closedWindowAt("/w" + strconv.Itoa(i)))
+	}
+	if len(closedItems) != maxClosedItems || closedItems[0].path() != "/w5" {
+		t.Fatalf("closed items = %d starting %q, want the newest %d", len(closedItems), closedItems[0].path(), maxClosedItems)
+	}
+}
+
+func closedWindowAt(path string) closedItem {
+	return closedItem{window: true, tabs: []tabState{{path: path}}}
+}